@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// backupSizeStats wraps the backup stats to count the bytes that the backup
+// engine writes to the backup storage, so vtbackup can publish the size of
+// the backup it took.
+type backupSizeStats struct {
+	backupstats.Stats
+
+	bytes     *atomic.Int64
+	component string
+	operation string
+}
+
+func newBackupSizeStats(stats backupstats.Stats) *backupSizeStats {
+	return &backupSizeStats{
+		Stats: stats,
+		bytes: &atomic.Int64{},
+	}
+}
+
+// Scope is part of the backupstats.Stats interface.
+func (s *backupSizeStats) Scope(scopes ...backupstats.Scope) backupstats.Stats {
+	scoped := &backupSizeStats{
+		Stats:     s.Stats.Scope(scopes...),
+		bytes:     s.bytes,
+		component: s.component,
+		operation: s.operation,
+	}
+	for _, scope := range scopes {
+		switch scope.Type {
+		case backupstats.ScopeComponent:
+			if scoped.component == "" {
+				scoped.component = scope.Value
+			}
+		case backupstats.ScopeOperation:
+			if scoped.operation == "" {
+				scoped.operation = scope.Value
+			}
+		}
+	}
+	return scoped
+}
+
+// TimedIncrementBytes is part of the backupstats.Stats interface.
+func (s *backupSizeStats) TimedIncrementBytes(b int, d time.Duration) {
+	if s.component == backupstats.BackupEngine.String() && s.operation == "Destination:Write" {
+		s.bytes.Add(int64(b))
+	}
+	s.Stats.TimedIncrementBytes(b, d)
+}
+
+// size returns the number of bytes written to the backup storage so far.
+func (s *backupSizeStats) size() uint64 {
+	return uint64(s.bytes.Load())
+}
+
+// publishBackupStatus records a successful backup in the shard record, so
+// its freshness can be checked without listing the backup storage. Failing
+// to publish doesn't fail the backup.
+func publishBackupStatus(ctx context.Context, topoServer *topo.Server, backupParams *mysqlctl.BackupParams, sizeStats *backupSizeStats) {
+	status := &topodatapb.Shard_BackupStatus{
+		Name:      mysqlctl.BackupName(backupParams.BackupTime, backupParams.TabletAlias),
+		Time:      protoutil.TimeToProto(backupParams.BackupTime),
+		SizeBytes: sizeStats.size(),
+	}
+
+	_, err := topoServer.UpdateShardFields(ctx, backupParams.Keyspace, backupParams.Shard, func(si *topo.ShardInfo) error {
+		if si.LastBackup != nil && protoutil.TimeFromProto(si.LastBackup.Time).After(backupParams.BackupTime) {
+			// A newer backup was published in the meantime.
+			return topo.NewError(topo.NoUpdateNeeded, si.ShardName())
+		}
+		si.LastBackup = status
+		return nil
+	})
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		log.Info(fmt.Sprintf("Shard %v/%v doesn't exist; not publishing backup status.", backupParams.Keyspace, backupParams.Shard))
+	case err != nil:
+		log.Warn(fmt.Sprintf("Failed to publish backup status for %v/%v: %v", backupParams.Keyspace, backupParams.Shard, err))
+	default:
+		log.Info(fmt.Sprintf("Published backup status for %v/%v: %v (%d bytes)", backupParams.Keyspace, backupParams.Shard, status.Name, status.SizeBytes))
+	}
+}
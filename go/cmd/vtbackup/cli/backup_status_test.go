@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestBackupSizeStats(t *testing.T) {
+	sizeStats := newBackupSizeStats(backupstats.NoStats())
+
+	engineStats := sizeStats.Scope(backupstats.Component(backupstats.BackupEngine), backupstats.Implementation("Builtin"))
+	engineStats.Scope(backupstats.Operation("Destination:Write")).TimedIncrementBytes(100, time.Millisecond)
+	engineStats.Scope(backupstats.Operation("Destination:Write")).TimedIncrementBytes(20, time.Millisecond)
+	// Reads of the source files and compression are not part of the backup size.
+	engineStats.Scope(backupstats.Operation("Source:Read")).TimedIncrementBytes(1000, time.Millisecond)
+	engineStats.Scope(backupstats.Operation("Compressor:Write")).TimedIncrementBytes(1000, time.Millisecond)
+
+	// The storage writes are the same bytes as the engine's destination writes.
+	storageStats := sizeStats.Scope(backupstats.Component(backupstats.BackupStorage), backupstats.Implementation("File"))
+	storageStats.Scope(backupstats.Operation("Destination:Write")).TimedIncrementBytes(120, time.Millisecond)
+
+	assert.EqualValues(t, 120, sizeStats.size())
+}
+
+func TestPublishBackupStatus(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	t.Cleanup(ts.Close)
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+
+	backupTime := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	backupParams := &mysqlctl.BackupParams{
+		Keyspace:    "ks",
+		Shard:       "0",
+		TabletAlias: "vtbackup-0000000123",
+		BackupTime:  backupTime,
+	}
+	sizeStats := newBackupSizeStats(backupstats.NoStats())
+	sizeStats.bytes.Store(4096)
+
+	publishBackupStatus(ctx, ts, backupParams, sizeStats)
+
+	si, err := ts.GetShard(ctx, "ks", "0")
+	require.NoError(t, err)
+	require.NotNil(t, si.LastBackup)
+	assert.Equal(t, "2026-10-01.120000.vtbackup-0000000123", si.LastBackup.Name)
+	assert.Equal(t, backupTime, protoutil.TimeFromProto(si.LastBackup.Time).UTC())
+	assert.EqualValues(t, 4096, si.LastBackup.SizeBytes)
+
+	// An older backup doesn't replace the published one.
+	olderParams := *backupParams
+	olderParams.BackupTime = backupTime.Add(-time.Hour)
+	publishBackupStatus(ctx, ts, &olderParams, newBackupSizeStats(backupstats.NoStats()))
+
+	si, err = ts.GetShard(ctx, "ks", "0")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-01.120000.vtbackup-0000000123", si.LastBackup.Name)
+
+	// Publishing for a shard that doesn't exist is not an error.
+	missingParams := *backupParams
+	missingParams.Shard = "-80"
+	publishBackupStatus(ctx, ts, &missingParams, sizeStats)
+}
@@ -409,6 +409,7 @@ func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage back
 		dbName = "vt_" + initKeyspace
 	}
 
+	sizeStats := newBackupSizeStats(backupstats.BackupStats())
 	backupParams := mysqlctl.BackupParams{
 		Cnf:                  mycnf,
 		Mysqld:               mysqld,
@@ -421,7 +422,7 @@ func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage back
 		Shard:                initShard,
 		TabletAlias:          topoproto.TabletAliasString(tabletAlias),
 		TabletType:           topodatapb.TabletType_BACKUP,
-		Stats:                backupstats.BackupStats(),
+		Stats:                sizeStats,
 		UpgradeSafe:          upgradeSafe,
 		MysqlShutdownTimeout: mysqlShutdownTimeout,
 		InitSQL: &tabletmanagerdatapb.BackupRequest_InitSQL{
@@ -469,6 +470,7 @@ func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage back
 		deprecatedDurationByPhase.Set("InitialBackup", int64(time.Since(backupParams.BackupTime).Seconds()))
 		log.Info("Initial backup successful.")
 		phase.Set(phaseNameInitialBackup, int64(0))
+		publishBackupStatus(ctx, topoServer, &backupParams, sizeStats)
 		return nil
 	}
 
@@ -491,6 +493,7 @@ func takeBackup(ctx context.Context, topoServer *topo.Server, backupStorage back
 	}
 
 	log.Info("Backup successful.")
+	publishBackupStatus(ctx, topoServer, &backupParams, sizeStats)
 	return nil
 }
 
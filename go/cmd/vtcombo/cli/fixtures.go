@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtgate"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+const (
+	// SeedHandler executes the SQL statements in the request body against
+	// the keyspace named by the "keyspace" query parameter.
+	SeedHandler = "/debug/vtcombo/seed"
+	// VSchemaPatchHandler merges the JSON vschema in the request body into
+	// the vschema of the keyspace named by the "keyspace" query parameter.
+	VSchemaPatchHandler = "/debug/vtcombo/vschema"
+)
+
+// addFixtureHandlers registers the HTTP endpoints used by tests to seed data
+// and patch the vschema of a running vtcombo.
+func addFixtureHandlers(ctx context.Context, ts *topo.Server, vtg *vtgate.VTGate, cells []string) {
+	servenv.HTTPHandleFunc(SeedHandler, func(w http.ResponseWriter, r *http.Request) {
+		keyspace, body, ok := readFixtureRequest(w, r)
+		if !ok {
+			return
+		}
+		n, err := seedKeyspace(r.Context(), vtg, env.Parser(), keyspace, body)
+		writeFixtureResponse(w, n, err)
+	})
+	servenv.HTTPHandleFunc(VSchemaPatchHandler, func(w http.ResponseWriter, r *http.Request) {
+		keyspace, body, ok := readFixtureRequest(w, r)
+		if !ok {
+			return
+		}
+		patch := &vschemapb.Keyspace{}
+		if err := json2.Unmarshal(body, patch); err != nil {
+			http.Error(w, fmt.Sprintf("can't unmarshal vschema: %v", err), http.StatusBadRequest)
+			return
+		}
+		err := patchVSchema(ctx, ts, env.Parser(), cells, keyspace, patch)
+		writeFixtureResponse(w, 0, err)
+	})
+}
+
+func readFixtureRequest(w http.ResponseWriter, r *http.Request) (string, []byte, bool) {
+	if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+		acl.SendError(w, err)
+		return "", nil, false
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return "", nil, false
+	}
+	keyspace := r.URL.Query().Get("keyspace")
+	if keyspace == "" {
+		http.Error(w, "keyspace is required", http.StatusBadRequest)
+		return "", nil, false
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("can't read request: %v", err), http.StatusBadRequest)
+		return "", nil, false
+	}
+	return keyspace, body, true
+}
+
+func writeFixtureResponse(w http.ResponseWriter, statements int, err error) {
+	resp := struct {
+		Statements int    `json:"statements,omitempty"`
+		Error      string `json:"error,omitempty"`
+	}{Statements: statements}
+	status := http.StatusOK
+	if err != nil {
+		resp.Error = err.Error()
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// seedExecutor is the part of vtgate.VTGate used to seed a keyspace.
+type seedExecutor interface {
+	Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, session *vtgatepb.Session, sql string, bindVariables map[string]*querypb.BindVariable, prepared bool) (*vtgatepb.Session, *sqltypes.Result, error)
+}
+
+// seedKeyspace splits sql into statements and executes them through vtgate,
+// targeting keyspace, so that rows are routed to the right shards. The
+// statements run in a single transaction, so a seed that fails leaves no rows
+// behind. Statements that end the transaction, explicitly or implicitly like
+// DDL, are rejected before anything is executed. It returns the number of
+// statements that were committed.
+func seedKeyspace(ctx context.Context, vtg seedExecutor, parser *sqlparser.Parser, keyspace string, sql []byte) (int, error) {
+	pieces, err := parser.SplitStatementToPieces(string(sql))
+	if err != nil {
+		return 0, err
+	}
+	stmts := make([]string, 0, len(pieces))
+	for _, stmt := range pieces {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		switch sqlparser.Preview(stmt) {
+		case sqlparser.StmtBegin, sqlparser.StmtCommit, sqlparser.StmtRollback, sqlparser.StmtDDL, sqlparser.StmtLockTables, sqlparser.StmtUnlockTables:
+			return 0, fmt.Errorf("seeding keyspace %s: %q: statement is not allowed in a seed transaction", keyspace, stmt)
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	session := &vtgatepb.Session{TargetString: keyspace, Autocommit: true}
+	if session, _, err = vtg.Execute(ctx, nil, session, "begin", nil, false); err != nil {
+		return 0, fmt.Errorf("seeding keyspace %s: %w", keyspace, err)
+	}
+	for _, stmt := range stmts {
+		session, _, err = vtg.Execute(ctx, nil, session, stmt, nil, false)
+		if err != nil {
+			if _, _, rbErr := vtg.Execute(ctx, nil, session, "rollback", nil, false); rbErr != nil {
+				log.Warn("Failed to roll back the seed transaction", slog.String("keyspace", keyspace), slog.Any("error", rbErr))
+			}
+			return 0, fmt.Errorf("seeding keyspace %s: %q: %w", keyspace, stmt, err)
+		}
+	}
+	if _, _, err = vtg.Execute(ctx, nil, session, "commit", nil, false); err != nil {
+		return 0, fmt.Errorf("seeding keyspace %s: %w", keyspace, err)
+	}
+	log.Info("Seeded keyspace", slog.String("keyspace", keyspace), slog.Int("statements", len(stmts)))
+	return len(stmts), nil
+}
+
+// patchVSchema merges patch into the current vschema of keyspace. Tables and
+// vindexes in patch are added, replacing any existing entry with the same
+// name. The merged vschema is validated before it is saved, and the SrvVSchema
+// is rebuilt so vtgate picks up the change.
+func patchVSchema(ctx context.Context, ts *topo.Server, parser *sqlparser.Parser, cells []string, keyspace string, patch *vschemapb.Keyspace) error {
+	ksvs, err := ts.GetVSchema(ctx, keyspace)
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		ksvs = &topo.KeyspaceVSchemaInfo{Name: keyspace, Keyspace: &vschemapb.Keyspace{}}
+	case err != nil:
+		return err
+	}
+	proto.Merge(ksvs.Keyspace, patch)
+
+	if _, err := vindexes.BuildKeyspace(ksvs.Keyspace, parser); err != nil {
+		return fmt.Errorf("invalid vschema for keyspace %s: %w", keyspace, err)
+	}
+	if err := ts.SaveVSchema(ctx, ksvs); err != nil {
+		return err
+	}
+	if err := ts.RebuildSrvVSchema(ctx, cells); err != nil {
+		return err
+	}
+	log.Info("Patched vschema", slog.String("keyspace", keyspace))
+	return nil
+}
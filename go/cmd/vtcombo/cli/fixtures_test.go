@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestPatchVSchema(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	parser := sqlparser.NewTestParser()
+
+	require.NoError(t, ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: "ks",
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"hash": {Type: "hash"},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+			},
+		},
+	}))
+
+	err := patchVSchema(ctx, ts, parser, []string{"zone1"}, "ks", &vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{
+			"t2": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	srv, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	ks := srv.Keyspaces["ks"]
+	require.NotNil(t, ks)
+	assert.True(t, ks.Sharded)
+	assert.Contains(t, ks.Tables, "t1")
+	assert.Contains(t, ks.Tables, "t2")
+
+	// A patch that does not validate is rejected and the vschema is left
+	// unchanged.
+	err = patchVSchema(ctx, ts, parser, []string{"zone1"}, "ks", &vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{
+			"t3": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "missing"}}},
+		},
+	})
+	require.ErrorContains(t, err, "invalid vschema for keyspace ks")
+	ksvs, err := ts.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	assert.NotContains(t, ksvs.Tables, "t3")
+
+	// Patching a keyspace without a vschema starts from an empty one.
+	err = patchVSchema(ctx, ts, parser, []string{"zone1"}, "unsharded", &vschemapb.Keyspace{
+		Tables: map[string]*vschemapb.Table{"t4": {}},
+	})
+	require.NoError(t, err)
+	srv, err = ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Contains(t, srv.Keyspaces["unsharded"].Tables, "t4")
+}
+
+type fakeSeedExecutor struct {
+	failOn string
+	log    []string
+}
+
+func (f *fakeSeedExecutor) Execute(_ context.Context, _ vtgateservice.MySQLConnection, session *vtgatepb.Session, sql string, _ map[string]*querypb.BindVariable, _ bool) (*vtgatepb.Session, *sqltypes.Result, error) {
+	f.log = append(f.log, sql)
+	if sql == f.failOn {
+		return session, nil, errors.New("duplicate entry")
+	}
+	return session, &sqltypes.Result{}, nil
+}
+
+func TestSeedKeyspace(t *testing.T) {
+	ctx := t.Context()
+	parser := sqlparser.NewTestParser()
+	seed := []byte("insert into t1 values (1);\ninsert into t1 values (2);\n")
+
+	vtg := &fakeSeedExecutor{}
+	n, err := seedKeyspace(ctx, vtg, parser, "ks", seed)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"begin", "insert into t1 values (1)", "insert into t1 values (2)", "commit"}, vtg.log)
+
+	// A failing statement rolls back the whole seed.
+	vtg = &fakeSeedExecutor{failOn: "insert into t1 values (2)"}
+	n, err = seedKeyspace(ctx, vtg, parser, "ks", seed)
+	require.ErrorContains(t, err, "duplicate entry")
+	assert.Zero(t, n)
+	assert.Equal(t, []string{"begin", "insert into t1 values (1)", "insert into t1 values (2)", "rollback"}, vtg.log)
+
+	// Statements that would end the transaction are rejected up front.
+	vtg = &fakeSeedExecutor{}
+	_, err = seedKeyspace(ctx, vtg, parser, "ks", []byte("insert into t1 values (1); create table t2 (id int)"))
+	require.ErrorContains(t, err, "statement is not allowed in a seed transaction")
+	assert.Empty(t, vtg.log)
+}
@@ -350,6 +350,7 @@ func run(cmd *cobra.Command, args []string) (err error) {
 
 	servenv.OnRun(func() {
 		addStatusParts(vtg)
+		addFixtureHandlers(ctx, ts, vtg, tpb.Cells)
 	})
 
 	servenv.RunDefault()
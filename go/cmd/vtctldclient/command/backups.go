@@ -55,6 +55,30 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandBackupShard,
 	}
+	// CloneFromTablet makes a CloneFromTablet gRPC call to a vtctld.
+	CloneFromTablet = &cobra.Command{
+		Use:   "CloneFromTablet [--donor <tablet_alias>] <tablet_alias>",
+		Short: "Replaces the data of the specified tablet with a copy of the data of another tablet of its shard, made with the MySQL CLONE plugin.",
+		Long: `Replaces the data of the specified tablet with a copy of the data of another tablet of its shard, made with the MySQL CLONE plugin.
+
+The data is cloned from the primary of the shard, unless a donor tablet is given with --donor. The progress of the clone is reported while the data is copied.
+The clone user must be configured on the tablet with --db-clone-user, and --mysql-clone-enabled must be set on both tablets.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandCloneFromTablet,
+	}
+	// GetBackupFreshness makes a GetBackupFreshness gRPC call to a vtctld.
+	GetBackupFreshness = &cobra.Command{
+		Use:   "GetBackupFreshness [--max-age <duration>] [<keyspace> ...]",
+		Short: "Lists the shards whose last successful backup is older than the given max age.",
+		Long: `Lists the shards whose last successful backup is older than the given max age.
+
+The last successful backup of each shard is the one published to the shard record by vtbackup.
+Shards without a published backup are always listed. If no keyspaces are given, all keyspaces are checked.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ArbitraryArgs,
+		RunE:                  commandGetBackupFreshness,
+	}
 	// GetBackups makes a GetBackups gRPC call to a vtctld.
 	GetBackups = &cobra.Command{
 		Use:                   "GetBackups [--limit <limit>] [--json] <keyspace/shard>",
@@ -205,6 +229,32 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 	}
 }
 
+var getBackupFreshnessOptions = struct {
+	MaxAge time.Duration
+}{
+	MaxAge: 24 * time.Hour,
+}
+
+func commandGetBackupFreshness(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetBackupFreshness(commandCtx, &vtctldatapb.GetBackupFreshnessRequest{
+		Keyspaces: cmd.Flags().Args(),
+		MaxAge:    protoutil.DurationToProto(getBackupFreshnessOptions.MaxAge),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var getBackupsOptions = struct {
 	Limit      uint32
 	OutputJSON bool
@@ -265,6 +315,46 @@ func commandRemoveBackup(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+var cloneFromTabletOptions = struct {
+	Donor string
+}{}
+
+func commandCloneFromTablet(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &vtctldatapb.CloneFromTabletRequest{
+		TabletAlias: alias,
+	}
+	if cloneFromTabletOptions.Donor != "" {
+		req.DonorAlias, err = topoproto.ParseTabletAlias(cloneFromTabletOptions.Donor)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	stream, err := client.CloneFromTablet(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch err {
+		case nil:
+			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
 var restoreFromBackupOptions = struct {
 	BackupTimestamp      string
 	AllowedBackupEngines []string
@@ -347,6 +437,12 @@ func init() {
 	addInitSQLFlags(BackupShard)
 	Root.AddCommand(BackupShard)
 
+	CloneFromTablet.Flags().StringVar(&cloneFromTabletOptions.Donor, "donor", "", "Alias of the tablet to clone the data from. Defaults to the primary of the shard.")
+	Root.AddCommand(CloneFromTablet)
+
+	GetBackupFreshness.Flags().DurationVar(&getBackupFreshnessOptions.MaxAge, "max-age", getBackupFreshnessOptions.MaxAge, "The backup freshness objective. Shards whose last successful backup is older than this are listed.")
+	Root.AddCommand(GetBackupFreshness)
+
 	GetBackups.Flags().Uint32VarP(&getBackupsOptions.Limit, "limit", "l", 0, "Retrieve only the most recent N backups.")
 	GetBackups.Flags().BoolVarP(&getBackupsOptions.OutputJSON, "json", "j", false, "Output backup info in JSON format rather than a list of backups.")
 	Root.AddCommand(GetBackups)
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/vtctl/clusterconfig"
+)
+
+var (
+	// ApplyClusterConfig makes the gRPC calls to a vtctld that make the
+	// cluster match a configuration exported by ExportClusterConfig.
+	ApplyClusterConfig = &cobra.Command{
+		Use:   "ApplyClusterConfig --config-file CONFIG_FILE [--dry-run]",
+		Short: "Applies a declarative configuration of the keyspaces, shards, vschemas and routing rules of the cluster.",
+		Long: `Applies a declarative configuration of the keyspaces, shards, vschemas and routing rules of the cluster.
+
+The configuration is compared with the cluster, and only the differences are applied, with a single rebuild of the
+VSchema graph at the end. If a change fails, the changes applied before it are reverted.
+
+Keyspaces and shards which are not in the configuration are reported, but never deleted. A keyspace without a vschema,
+or a configuration without routing rules, leaves them as they are.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyClusterConfig,
+	}
+	// ExportClusterConfig makes the gRPC calls to a vtctld that read the
+	// configuration of the cluster.
+	ExportClusterConfig = &cobra.Command{
+		Use:                   "ExportClusterConfig [--format yaml|terraform]",
+		Short:                 "Exports the keyspaces, shards, vschemas and routing rules of the cluster as a declarative configuration.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandExportClusterConfig,
+	}
+)
+
+var applyClusterConfigOptions = struct {
+	ConfigFilePath string
+	DryRun         bool
+}{}
+
+func commandApplyClusterConfig(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	data, err := os.ReadFile(applyClusterConfigOptions.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := clusterconfig.Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", applyClusterConfigOptions.ConfigFilePath, err)
+	}
+
+	plan, err := clusterconfig.NewPlan(commandCtx, client, cfg, env.Parser())
+	if err != nil {
+		return err
+	}
+
+	if applyClusterConfigOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have applied the following changes:\n%s", plan)
+		return nil
+	}
+
+	fmt.Printf("Applying the following changes:\n%s", plan)
+
+	return clusterconfig.Apply(commandCtx, client, plan)
+}
+
+var exportClusterConfigOptions = struct {
+	Format string
+}{}
+
+func commandExportClusterConfig(cmd *cobra.Command, args []string) error {
+	var marshal func(*clusterconfig.Config) ([]byte, error)
+	switch exportClusterConfigOptions.Format {
+	case "yaml":
+		marshal = clusterconfig.MarshalYAML
+	case "terraform":
+		marshal = clusterconfig.MarshalTerraform
+	default:
+		return fmt.Errorf("invalid --format %s, expected yaml or terraform", exportClusterConfigOptions.Format)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	cfg, err := clusterconfig.Export(commandCtx, client)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyClusterConfig.Flags().StringVarP(&applyClusterConfigOptions.ConfigFilePath, "config-file", "f", "", "Path to a file containing the configuration, as exported by ExportClusterConfig in YAML.")
+	ApplyClusterConfig.MarkFlagRequired("config-file")
+	ApplyClusterConfig.Flags().BoolVarP(&applyClusterConfigOptions.DryRun, "dry-run", "d", false, "Display the changes that would be applied, without applying them.")
+	Root.AddCommand(ApplyClusterConfig)
+
+	ExportClusterConfig.Flags().StringVar(&exportClusterConfigOptions.Format, "format", "yaml", "The format of the configuration, either yaml, or terraform for the JSON syntax of Terraform.")
+	Root.AddCommand(ExportClusterConfig)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// BreakLock makes a BreakLock gRPC call to a vtctld.
+	BreakLock = &cobra.Command{
+		Use:   "BreakLock --id <id> [--min-age <duration>] {<keyspace> | <keyspace/shard> | --name <name>}",
+		Short: "Releases a keyspace, shard or named lock that was left behind by a process that went away.",
+		Long: `Releases a keyspace, shard or named lock that was left behind by a process that went away.
+
+The lock to break is identified by its ID, as shown by GetLockHolders. It is only broken if it
+was taken longer ago than --min-age, so that a lock that is still in use is not broken by mistake.
+The former holder of the lock is not interrupted, it finds out it lost the lock the next time it
+checks on it.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.RangeArgs(0, 1),
+		RunE:                  commandBreakLock,
+	}
+	// GetLockHolders makes a GetLockHolders gRPC call to a vtctld.
+	GetLockHolders = &cobra.Command{
+		Use:   "GetLockHolders {<keyspace> | <keyspace/shard> | --name <name>}",
+		Short: "Displays the holder of a keyspace, shard or named lock, and the callers waiting for it.",
+		Long: `Displays the holder of a keyspace, shard or named lock, and the callers waiting for it.
+
+The holder of the lock comes first, followed by the callers waiting for it, in the order they
+will get it, as far as the topo server implementation keeps track of them.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.RangeArgs(0, 1),
+		RunE:                  commandGetLockHolders,
+	}
+)
+
+var breakLockOptions = struct {
+	Name   string
+	ID     string
+	MinAge time.Duration
+}{
+	MinAge: time.Hour,
+}
+
+func commandBreakLock(cmd *cobra.Command, args []string) error {
+	keyspace, shard := parseLockResource(cmd.Flags().Arg(0))
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.BreakLock(commandCtx, &vtctldatapb.BreakLockRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Name:     breakLockOptions.Name,
+		Id:       breakLockOptions.ID,
+		MinAge:   protoutil.DurationToProto(breakLockOptions.MinAge),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp.Lock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+var getLockHoldersOptions = struct {
+	Name string
+}{}
+
+func commandGetLockHolders(cmd *cobra.Command, args []string) error {
+	keyspace, shard := parseLockResource(cmd.Flags().Arg(0))
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetLockHolders(commandCtx, &vtctldatapb.GetLockHoldersRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Name:     getLockHoldersOptions.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+// parseLockResource splits a <keyspace> or <keyspace/shard> argument. The
+// vtctld validates the combination with the name of a named lock.
+func parseLockResource(arg string) (keyspace, shard string) {
+	keyspace, shard, _ = strings.Cut(arg, "/")
+	return keyspace, shard
+}
+
+func init() {
+	BreakLock.Flags().StringVar(&breakLockOptions.Name, "name", "", "The name of the named lock to break, instead of a keyspace or shard lock.")
+	BreakLock.Flags().StringVar(&breakLockOptions.ID, "id", "", "The ID of the lock to break, as shown by GetLockHolders.")
+	BreakLock.Flags().DurationVar(&breakLockOptions.MinAge, "min-age", breakLockOptions.MinAge, "Only break the lock if it was taken longer ago than this.")
+	BreakLock.MarkFlagRequired("id")
+	Root.AddCommand(BreakLock)
+
+	GetLockHolders.Flags().StringVar(&getLockHoldersOptions.Name, "name", "", "The name of the named lock to display, instead of a keyspace or shard lock.")
+	Root.AddCommand(GetLockHolders)
+}
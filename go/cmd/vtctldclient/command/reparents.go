@@ -42,6 +42,20 @@ var (
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandEmergencyReparentShard,
 	}
+	// GetReparentReports makes a GetReparentReports gRPC call to a vtctld.
+	GetReparentReports = &cobra.Command{
+		Use:   "GetReparentReports [--limit <count>] <keyspace/shard>",
+		Short: "Outputs the reports of the last emergency reparents of the shard, most recent first.",
+		Long: `Outputs the reports of the last emergency reparents of the shard, most recent first.
+
+A report is saved after each EmergencyReparentShard, whether it was run by VTOrc or requested from vtctld, and whether
+it succeeded or not. It contains the problems that led to the reparent, the steps of the reparent and their durations,
+the replication state of the tablets when replication was stopped, and the candidates that were evaluated for the
+promotion.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetReparentReports,
+	}
 	// InitShardPrimary makes an InitShardPrimary gRPC call to a vtctld.
 	InitShardPrimary = &cobra.Command{
 		Use:   "InitShardPrimary <keyspace/shard> <primary alias>",
@@ -156,6 +170,37 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var getReparentReportsOptions = struct {
+	Limit uint32
+}{}
+
+func commandGetReparentReports(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetReparentReports(commandCtx, &vtctldatapb.GetReparentReportsRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Limit:    getReparentReportsOptions.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Reports)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 var initShardPrimaryOptions = struct {
 	WaitReplicasTimeout time.Duration
 	Force               bool
@@ -312,6 +357,9 @@ func init() {
 	EmergencyReparentShard.Flags().StringSliceVarP(&emergencyReparentShardOptions.IgnoreReplicaAliasStrList, "ignore-replicas", "i", nil, "Comma-separated, repeated list of replica tablet aliases to ignore during the emergency reparent.")
 	Root.AddCommand(EmergencyReparentShard)
 
+	GetReparentReports.Flags().Uint32Var(&getReparentReportsOptions.Limit, "limit", 0, "The maximum number of reports to output. All the saved reports are output if it is 0.")
+	Root.AddCommand(GetReparentReports)
+
 	InitShardPrimary.Flags().DurationVar(&initShardPrimaryOptions.WaitReplicasTimeout, "wait-replicas-timeout", 30*time.Second, "Time to wait for replicas to catch up in reparenting.")
 	InitShardPrimary.Flags().BoolVar(&initShardPrimaryOptions.Force, "force", false, "Force the reparent even if the provided tablet is not writable or the shard primary.")
 	Root.AddCommand(InitShardPrimary)
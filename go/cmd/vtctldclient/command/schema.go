@@ -69,10 +69,15 @@ For --sql, semi-colons and repeated values may be mixed, for example:
 	}
 	// GetSchema makes a GetSchema gRPC call to a vtctld.
 	GetSchema = &cobra.Command{
-		Use:                   "GetSchema [--tables TABLES ...] [--exclude-tables EXCLUDE_TABLES ...] [{--table-names-only | --table-sizes-only}] [--include-views] alias",
-		Short:                 "Displays the full schema for a tablet, optionally restricted to the specified tables/views.",
+		Use:   "GetSchema [--tables TABLES ...] [--exclude-tables EXCLUDE_TABLES ...] [{--table-names-only | --table-sizes-only}] [--include-views] {alias | --keyspace KEYSPACE}",
+		Short: "Displays the full schema for a tablet, or for the primary of every shard in a keyspace, optionally restricted to the specified tables/views.",
+		Long: `Displays the full schema for a tablet, optionally restricted to the specified tables/views.
+
+With --keyspace, the schema is read from the primary of every shard in the keyspace concurrently, and displayed per shard.
+Shards whose schema could not be read are displayed with their error. Each table also gets a digest of its definition,
+which is the same on every shard where the table has the same definition.`,
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		RunE:                  commandGetSchema,
 	}
 	// ReloadSchema makes a ReloadSchema gRPC call to a vtctld.
@@ -248,6 +253,7 @@ func commandCopySchemaShard(cmd *cobra.Command, args []string) error {
 }
 
 var getSchemaOptions = struct {
+	Keyspace        string
 	Tables          []string
 	ExcludeTables   []string
 	IncludeViews    bool
@@ -261,15 +267,27 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 		return errors.New("can only pass one of --table-names-only and --table-sizes-only")
 	}
 
-	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
-	if err != nil {
-		return err
+	var alias *topodatapb.TabletAlias
+	switch {
+	case getSchemaOptions.Keyspace != "" && cmd.Flags().NArg() != 0:
+		return errors.New("can only pass one of a tablet alias and --keyspace")
+	case getSchemaOptions.Keyspace == "":
+		if cmd.Flags().NArg() != 1 {
+			return errors.New("must pass a tablet alias or --keyspace")
+		}
+
+		var err error
+		alias, err = topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+		if err != nil {
+			return err
+		}
 	}
 
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.GetSchema(commandCtx, &vtctldatapb.GetSchemaRequest{
 		TabletAlias:     alias,
+		Keyspace:        getSchemaOptions.Keyspace,
 		Tables:          getSchemaOptions.Tables,
 		ExcludeTables:   getSchemaOptions.ExcludeTables,
 		IncludeViews:    getSchemaOptions.IncludeViews,
@@ -281,6 +299,17 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if getSchemaOptions.Keyspace != "" {
+		data, err := cli.MarshalJSON(resp.ShardSchemas)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", data)
+
+		return nil
+	}
+
 	if getSchemaOptions.TableNamesOnly {
 		names := make([]string, len(resp.Schema.TableDefinitions))
 
@@ -456,6 +485,7 @@ func init() {
 	CopySchemaShard.Flags().DurationVar(&copySchemaShardOptions.waitReplicasTimeout, "wait-replicas-timeout", grpcvtctldserver.DefaultWaitReplicasTimeout, "The amount of time to wait for replicas to receive the schema change via replication.")
 	Root.AddCommand(CopySchemaShard)
 
+	GetSchema.Flags().StringVar(&getSchemaOptions.Keyspace, "keyspace", "", "Displays the schema of every shard in the keyspace, read from the shard primaries, instead of the schema of a single tablet.")
 	GetSchema.Flags().StringSliceVar(&getSchemaOptions.Tables, "tables", nil, "List of tables to display the schema for. Each is either an exact match, or a regular expression of the form `/regexp/`.")
 	GetSchema.Flags().StringSliceVar(&getSchemaOptions.ExcludeTables, "exclude-tables", nil, "List of tables to exclude from the result. Each is either an exact match, or a regular expression of the form `/regexp/`.")
 	GetSchema.Flags().BoolVar(&getSchemaOptions.IncludeViews, "include-views", false, "Includes views in the output in addition to base tables.")
@@ -143,6 +143,23 @@ Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueSt
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandPingTablet,
 	}
+	// PurgeBinaryLogs makes a PurgeBinaryLogs gRPC call to a vtctld.
+	PurgeBinaryLogs = &cobra.Command{
+		Use:   "PurgeBinaryLogs [--flush] [--min-binary-logs <count>] [--dry-run] <tablet_alias>",
+		Short: "Purges the binary logs of a tablet that are no longer needed.",
+		Long: `Purges the binary logs of a tablet that are no longer needed.
+
+A binary log is only purged once every other tablet of the shard, and every VReplication stream, in any keyspace,
+that reads from the tablet's shard, has read past it. Frozen workflows are ignored. The binary logs that follow the
+latest backup of the shard, and those MySQL keeps for binlog_expire_logs_seconds, are kept too. The active binary log,
+and the number of most recent binary logs given by --min-binary-logs, are always kept.
+
+The tablet's type must be listed in its --binlog-purge-tablet-types flag. The binary logs of a primary are never
+purged.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandPurgeBinaryLogs,
+	}
 	// RefreshState makes a RefreshState gRPC call to a vtctld.
 	RefreshState = &cobra.Command{
 		Use:                   "RefreshState <alias>",
@@ -505,6 +522,39 @@ func commandPingTablet(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+var purgeBinaryLogsOptions = struct {
+	Flush         bool
+	MinBinaryLogs uint32
+	DryRun        bool
+}{}
+
+func commandPurgeBinaryLogs(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.PurgeBinaryLogs(commandCtx, &vtctldatapb.PurgeBinaryLogsRequest{
+		TabletAlias:   alias,
+		Flush:         purgeBinaryLogsOptions.Flush,
+		MinBinaryLogs: purgeBinaryLogsOptions.MinBinaryLogs,
+		DryRun:        purgeBinaryLogsOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandRefreshState(cmd *cobra.Command, args []string) error {
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -666,6 +716,12 @@ func init() {
 
 	Root.AddCommand(GetTabletVersion)
 	Root.AddCommand(PingTablet)
+
+	PurgeBinaryLogs.Flags().BoolVar(&purgeBinaryLogsOptions.Flush, "flush", false, "Rotate the binary logs before purging, so that the entries of the active binary log can be purged too.")
+	PurgeBinaryLogs.Flags().Uint32Var(&purgeBinaryLogsOptions.MinBinaryLogs, "min-binary-logs", 1, "Number of most recent binary logs that are always kept.")
+	PurgeBinaryLogs.Flags().BoolVar(&purgeBinaryLogsOptions.DryRun, "dry-run", false, "Report the binary logs that would be purged, without purging them.")
+	Root.AddCommand(PurgeBinaryLogs)
+
 	Root.AddCommand(RefreshState)
 
 	RefreshStateByShard.Flags().StringSliceVarP(&refreshStateByShardOptions.Cells, "cells", "c", nil, "If specified, only call RefreshState on tablets in the specified cells. If empty, all cells are considered.")
@@ -40,8 +40,17 @@ var (
 	}
 	// ValidateKeyspace makes a ValidateKeyspace gRPC call to a vtctld.
 	ValidateKeyspace = &cobra.Command{
-		Use:                   "ValidateKeyspace [--ping-tablets] <keyspace>",
-		Short:                 "Validates that all nodes reachable from the specified keyspace are consistent.",
+		Use:   "ValidateKeyspace [--ping-tablets] [--deep [--sample-size <rows>] [--checksum-tables <tables>]] <keyspace>",
+		Short: "Validates that all nodes reachable from the specified keyspace are consistent.",
+		Long: `Validates that all nodes reachable from the specified keyspace are consistent.
+
+With --deep, the data of the keyspace is validated too: the owned lookup vindexes are checked against a
+sample of the rows of their owner and lookup tables, and each missing or orphaned lookup row is reported
+with a statement that repairs it when run through vtgate. The checksums of the --checksum-tables of the
+replicas of each shard are compared to the ones of its primary, at the same position: the replicas stop
+replicating while the primary checksums the tables, then replicate up to its position and checksum them.
+The checksums are computed with CHECKSUM TABLE, which reads whole tables, and cannot be compared if the
+primary is written to while it checksums them.`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandValidateKeyspace,
@@ -81,7 +90,10 @@ func commandValidate(cmd *cobra.Command, args []string) error {
 }
 
 var validateKeyspaceOptions = struct {
-	PingTablets bool
+	PingTablets    bool
+	Deep           bool
+	SampleSize     uint32
+	ChecksumTables []string
 }{}
 
 func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
@@ -89,8 +101,11 @@ func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
 
 	keyspace := cmd.Flags().Arg(0)
 	resp, err := client.ValidateKeyspace(commandCtx, &vtctldatapb.ValidateKeyspaceRequest{
-		Keyspace:    keyspace,
-		PingTablets: validateKeyspaceOptions.PingTablets,
+		Keyspace:       keyspace,
+		PingTablets:    validateKeyspaceOptions.PingTablets,
+		Deep:           validateKeyspaceOptions.Deep,
+		SampleSize:     validateKeyspaceOptions.SampleSize,
+		ChecksumTables: validateKeyspaceOptions.ChecksumTables,
 	})
 	if err != nil {
 		return err
@@ -170,6 +185,16 @@ func consumeKeyspaceValidationResults(keyspace string, resp *vtctldatapb.Validat
 		_ = consumeShardValidationResults(keyspace, shard, shardResults, buf)
 	}
 
+	for _, issue := range resp.LookupVindexIssues {
+		switch issue.Type {
+		case vtctldatapb.LookupVindexIssue_MISSING_LOOKUP_ROW:
+			fmt.Fprintf(buf, "- lookup vindex %s: no lookup row for owner row (%s) of %s/%s\n", issue.Vindex, issue.Row, issue.Keyspace, issue.Shard)
+		case vtctldatapb.LookupVindexIssue_ORPHANED_LOOKUP_ROW:
+			fmt.Fprintf(buf, "- lookup vindex %s: no owner row for lookup row (%s) of %s/%s\n", issue.Vindex, issue.Row, issue.Keyspace, issue.Shard)
+		}
+		fmt.Fprintf(buf, "  repair: %s\n", issue.Repair)
+	}
+
 	if buf.Len() > 0 {
 		return fmt.Errorf("keyspace %s had validation issues; see above for details", keyspace)
 	}
@@ -197,6 +222,9 @@ func init() {
 
 	Validate.Flags().BoolVarP(&validateOptions.PingTablets, pingTabletsName, pingTabletsShort, pingTabletsDefault, pingTabletsUsage)
 	ValidateKeyspace.Flags().BoolVarP(&validateKeyspaceOptions.PingTablets, pingTabletsName, pingTabletsShort, pingTabletsDefault, pingTabletsUsage)
+	ValidateKeyspace.Flags().BoolVar(&validateKeyspaceOptions.Deep, "deep", false, "Also validate the data of the keyspace: the consistency of the owned lookup vindexes, and the checksums of the --checksum-tables of the replicas.")
+	ValidateKeyspace.Flags().Uint32Var(&validateKeyspaceOptions.SampleSize, "sample-size", 0, "The number of rows sampled from each shard of the owner and lookup tables of the lookup vindexes with --deep. Defaults to 100.")
+	ValidateKeyspace.Flags().StringSliceVar(&validateKeyspaceOptions.ChecksumTables, "checksum-tables", nil, "The tables whose checksums are compared between the replicas of each shard and its primary with --deep. The replicas stop replicating while the tables are checksummed.")
 	ValidateShard.Flags().BoolVarP(&validateShardOptions.PingTablets, pingTabletsName, pingTabletsShort, pingTabletsDefault, pingTabletsUsage)
 
 	Root.AddCommand(Validate)
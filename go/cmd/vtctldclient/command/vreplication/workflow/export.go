@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// export makes a WorkflowExport gRPC call to a vtctld.
+var export = &cobra.Command{
+	Use:   "export",
+	Short: "Export the state of a stopped VReplication workflow, as JSON, so that it can be imported into another cluster.",
+	Long: `Export the state of a stopped VReplication workflow, as JSON, so that it can be imported into another cluster.
+The exported state includes the binlog sources, positions and copy state of all of the streams of the workflow.
+It can then be imported with the import command into another cluster that has the same target shards and can replicate
+from the same source, e.g. to move a running migration to new target infrastructure.`,
+	Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer export --workflow commerce2customer > commerce2customer.json`,
+	DisableFlagsInUseLine: true,
+	Aliases:               []string{"Export"},
+	Args:                  cobra.NoArgs,
+	RunE:                  commandExport,
+}
+
+func commandExport(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowExportRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+		Shards:   baseOptions.Shards,
+	}
+	resp, err := common.GetClient().WorkflowExport(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp.Workflow)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
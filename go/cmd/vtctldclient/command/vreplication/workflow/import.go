@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/json2"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	importOptions = struct {
+		File  string
+		Cells []string
+	}{}
+
+	// importWorkflow makes a WorkflowImport gRPC call to a vtctld.
+	importWorkflow = &cobra.Command{
+		Use:   "import",
+		Short: "Import a VReplication workflow exported from another cluster.",
+		Long: `Import a VReplication workflow exported from another cluster with the export command.
+The streams of the workflow are created on the same target shards of the given keyspace, with the positions and copy state
+they had when the workflow was exported. They are created in a stopped state: start the workflow once the original one
+is stopped for good, so that the two never write to the target tables at the same time.`,
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer import --file commerce2customer.json`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Import"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandImport,
+	}
+)
+
+func commandImport(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	data, err := os.ReadFile(importOptions.File)
+	if err != nil {
+		return err
+	}
+	exported := &vtctldatapb.ExportedWorkflow{}
+	if err := json2.UnmarshalPB(data, exported); err != nil {
+		return fmt.Errorf("invalid exported workflow in %s: %w", importOptions.File, err)
+	}
+
+	req := &vtctldatapb.WorkflowImportRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: exported,
+		Cells:    importOptions.Cells,
+	}
+	resp, err := common.GetClient().WorkflowImport(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err = cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
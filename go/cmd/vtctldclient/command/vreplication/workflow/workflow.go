@@ -64,6 +64,16 @@ func registerCommands(root *cobra.Command) {
 	common.AddShardSubsetFlag(delete, &baseOptions.Shards)
 	base.AddCommand(delete)
 
+	export.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to export.")
+	export.MarkFlagRequired("workflow")
+	common.AddShardSubsetFlag(export, &baseOptions.Shards)
+	base.AddCommand(export)
+
+	importWorkflow.Flags().StringVar(&importOptions.File, "file", "", "The file with the exported workflow, as written by the export command.")
+	importWorkflow.MarkFlagRequired("file")
+	importWorkflow.Flags().StringSliceVarP(&importOptions.Cells, "cells", "c", nil, "Cell(s) or CellAlias(es) (comma-separated) to replicate from, instead of the ones of the exported workflow.")
+	base.AddCommand(importWorkflow)
+
 	common.AddShardSubsetFlag(workflowList, &baseOptions.Shards)
 	base.AddCommand(workflowList)
 
@@ -47,6 +47,10 @@ var (
 	normalize          bool
 	dbName             string
 	plannerVersionStr  string
+	plannerDecisions   bool
+
+	columnStatisticsFlag     string
+	columnStatisticsFileFlag string
 
 	numShards       = 2
 	replicationMode = "ROW"
@@ -107,6 +111,9 @@ func init() {
 	Main.Flags().IntVar(&numShards, "shards", numShards, "Number of shards per keyspace. Passing --ks-shard-map/--ks-shard-map-file causes this flag to be ignored.")
 	Main.Flags().StringVar(&executionMode, "execution-mode", executionMode, "The execution mode to simulate -- must be set to multi, legacy-autocommit, or twopc")
 	Main.Flags().StringVar(&outputMode, "output-mode", outputMode, "Output in human-friendly text or json")
+	Main.Flags().BoolVar(&plannerDecisions, "planner-decisions", plannerDecisions, "Whether to output the join orders chosen and rejected by the planner, with their cost estimates")
+	Main.Flags().StringVar(&columnStatisticsFlag, "column-statistics", columnStatisticsFlag, "JSON map of keyspace name -> table name -> table statistics, as tracked by vtgate with --track-column-statistics, for the planner to order joins by")
+	Main.Flags().StringVar(&columnStatisticsFileFlag, "column-statistics-file", columnStatisticsFileFlag, "File containing json blob of keyspace name -> table name -> table statistics")
 
 	acl.RegisterFlags(Main.Flags())
 }
@@ -168,6 +175,11 @@ func parseAndRun(ctx context.Context) error {
 		return err
 	}
 
+	columnStatistics, err := getFileParam(columnStatisticsFlag, columnStatisticsFileFlag, "column-statistics", false)
+	if err != nil {
+		return err
+	}
+
 	opts := &vtexplain.Options{
 		ExecutionMode:   executionMode,
 		PlannerVersion:  plannerVersion,
@@ -175,6 +187,9 @@ func parseAndRun(ctx context.Context) error {
 		NumShards:       numShards,
 		Normalize:       normalize,
 		Target:          dbName,
+
+		PlannerDecisions: plannerDecisions,
+		ColumnStatistics: columnStatistics,
 	}
 
 	env, err := vtenv.New(vtenv.Options{
@@ -83,6 +83,18 @@ func (c fallbackClient) BinlogDumpGTID(ctx context.Context, req *vtgatepb.Binlog
 	return c.fallback.BinlogDumpGTID(ctx, req, send)
 }
 
+func (c fallbackClient) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return c.fallback.MessageAckBatch(ctx, keyspace, name, ids)
+}
+
+func (c fallbackClient) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return c.fallback.MessagePostpone(ctx, keyspace, name, ids)
+}
+
+func (c fallbackClient) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	return c.fallback.StreamExport(ctx, req, send)
+}
+
 func (c fallbackClient) HandlePanic(err *error) {
 	c.fallback.HandlePanic(err)
 }
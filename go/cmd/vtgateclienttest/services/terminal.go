@@ -97,6 +97,18 @@ func (c *terminalClient) BinlogDumpGTID(ctx context.Context, req *vtgatepb.Binlo
 	return errTerminal
 }
 
+func (c *terminalClient) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, errTerminal
+}
+
+func (c *terminalClient) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, errTerminal
+}
+
+func (c *terminalClient) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	return errTerminal
+}
+
 func (c *terminalClient) HandlePanic(err *error) {
 	if x := recover(); x != nil {
 		log.Error(fmt.Sprintf("Uncaught panic:\n%v\n%s", x, tb.Stack(4)))
@@ -129,6 +129,11 @@ func New() (cmd *cobra.Command) {
 			" If the directory contains a vschema.json file, it"+
 			" will be used as the vschema for the V3 API.")
 
+	cmd.Flags().StringVar(&config.SeedDir, "seed-dir", "",
+		"Directory for SQL seed files. Within this dir, there should be a"+
+			" subdir for each keyspace. Within each keyspace dir, each .sql file"+
+			" is executed through vtgate once the cluster is up.")
+
 	utils.SetFlagStringVar(cmd.Flags(), &config.DefaultSchemaDir, "default-schema-dir", "",
 		"Default directory for initial schema files. If no schema is found"+
 			" in schema-dir, default to this location.")
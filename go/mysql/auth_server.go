@@ -22,7 +22,9 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/subtle"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"net"
 	"os"
@@ -228,16 +230,11 @@ func NewMysqlDialogAuthMethod(layer PlainTextStorage, validator UserValidator, m
 // be called if the return of the first layer indicates the full auth dance is
 // needed.
 //
-// Right now we only support caching_sha2_password over TLS or a Unix socket.
-//
-// If TLS is not enabled, the client needs to encrypt it with the public
-// key of the server. In that case, Vitess is already configured with
-// a certificate anyway, so we recommend to use TLS if you want to use
-// caching_sha2_password in that case instead of allowing the plain
-// text fallback path here.
-//
-// This might change in the future if there's a good argument and implementation
-// for allowing the plain text path here as well.
+// The full auth dance sends the password in plain text over TLS or a Unix
+// socket. Over other connections, the client encrypts it with the RSA public
+// key of the server, which requires the listener to be configured with
+// Listener.CachingSha2PasswordPrivateKey. The auth method is not offered over
+// such connections otherwise.
 func NewSha2CachingAuthMethod(layer1 CachingStorage, layer2 PlainTextStorage, validator UserValidator) AuthMethod {
 	authMethod := mysqlCachingSha2AuthMethod{
 		cache:     layer1,
@@ -351,6 +348,22 @@ func VerifyHashedCachingSha2Password(reply, salt, hashedCachingSha2Password []by
 	return subtle.ConstantTimeCompare(candidateHash2, hashedCachingSha2Password) == 1
 }
 
+// hashMysqlNativePassword returns SHA1(SHA1(password)), the hash of the
+// password stored for mysql_native_password.
+func hashMysqlNativePassword(password []byte) []byte {
+	stage1 := sha1.Sum(password)
+	hash := sha1.Sum(stage1[:])
+	return hash[:]
+}
+
+// hashCachingSha2Password returns SHA256(SHA256(password)), the hash of the
+// password cached for caching_sha2_password.
+func hashCachingSha2Password(password []byte) []byte {
+	stage1 := sha256.Sum256(password)
+	hash := sha256.Sum256(stage1[:])
+	return hash[:]
+}
+
 // ScrambleCachingSha2Password computes the hash of the password using SHA256 as required by
 // caching_sha2_password plugin for "fast" authentication
 func ScrambleCachingSha2Password(salt []byte, password []byte) []byte {
@@ -381,6 +394,49 @@ func ScrambleCachingSha2Password(salt []byte, password []byte) []byte {
 	return stage1
 }
 
+// DecryptPasswordWithPrivateKey decrypts a password encrypted with
+// EncryptPasswordWithPublicKey, as required by the caching_sha2_password
+// plugin for "full" authentication over an insecure connection.
+func DecryptPasswordWithPrivateKey(salt []byte, enc []byte, priv *rsa.PrivateKey) ([]byte, error) {
+	buffer, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, priv, enc, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range buffer {
+		buffer[i] ^= salt[i%len(salt)]
+	}
+	if len(buffer) == 0 || buffer[len(buffer)-1] != 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "decrypted password is not zero terminated")
+	}
+	return buffer[:len(buffer)-1], nil
+}
+
+// LoadCachingSha2PasswordPrivateKey reads the PEM encoded RSA private key
+// used to decrypt the passwords of the caching_sha2_password full
+// authentication over insecure connections.
+func LoadCachingSha2PasswordPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "no PEM data found in %s", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot parse private key in %s: %v", path, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "private key in %s is not an RSA key", path)
+	}
+	return rsaKey, nil
+}
+
 // EncryptPasswordWithPublicKey obfuscates the password and encrypts it with server's public key as required by
 // caching_sha2_password plugin for "full" authentication
 func EncryptPasswordWithPublicKey(salt []byte, password []byte, pub *rsa.PublicKey) ([]byte, error) {
@@ -502,7 +558,7 @@ func (n *mysqlCachingSha2AuthMethod) Name() AuthMethodDescription {
 }
 
 func (n *mysqlCachingSha2AuthMethod) HandleUser(conn *Conn, user string) bool {
-	if !conn.TLSEnabled() && !conn.IsUnixSocket() {
+	if !conn.TLSEnabled() && !conn.IsUnixSocket() && conn.cachingSha2PrivateKey() == nil {
 		return false
 	}
 	return n.validator.HandleUser(user)
@@ -547,16 +603,25 @@ func (n *mysqlCachingSha2AuthMethod) HandleAuthPluginData(c *Conn, user string,
 		}
 		return result, nil
 	case AuthNeedMoreData:
-		if !c.TLSEnabled() && !c.IsUnixSocket() {
+		privateKey := c.cachingSha2PrivateKey()
+		secure := c.TLSEnabled() || c.IsUnixSocket()
+		if !secure && privateKey == nil {
 			return nil, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 		}
 
 		data, pos := c.startEphemeralPacketWithHeader(2)
 		pos = writeByte(data, pos, AuthMoreDataPacket)
 		writeByte(data, pos, CachingSha2FullAuth)
-		c.writeEphemeralPacket()
+		if err := c.writeEphemeralPacket(); err != nil {
+			return nil, err
+		}
 
-		password, err := readPacketPasswordString(c)
+		var password string
+		if secure {
+			password, err = readPacketPasswordString(c)
+		} else {
+			password, err = readPacketEncryptedPassword(c, salt, privateKey)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -623,6 +688,38 @@ func negotiateAuthMethod(conn *Conn, as AuthServer, user string, requestedAuth A
 	return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unknown auth method requested: %s", string(requestedAuth))
 }
 
+// readPacketEncryptedPassword reads the password of the caching_sha2_password
+// full authentication over an insecure connection, which the client encrypts
+// with the public key of the server. The client first requests the public key
+// unless it already knows it.
+func readPacketEncryptedPassword(c *Conn, salt []byte, privateKey *rsa.PrivateKey) (string, error) {
+	data, err := c.ReadPacket()
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 1 && data[0] == CachingSha2RequestPublicKey {
+		publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		if err != nil {
+			return "", err
+		}
+		publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})
+		packet, pos := c.startEphemeralPacketWithHeader(1 + len(publicKeyPEM))
+		pos = writeByte(packet, pos, AuthMoreDataPacket)
+		copy(packet[pos:], publicKeyPEM)
+		if err := c.writeEphemeralPacket(); err != nil {
+			return "", err
+		}
+		if data, err = c.ReadPacket(); err != nil {
+			return "", err
+		}
+	}
+	password, err := DecryptPasswordWithPrivateKey(salt, data, privateKey)
+	if err != nil {
+		return "", sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "cannot decrypt the password: %v", err)
+	}
+	return string(password), nil
+}
+
 func readPacketPasswordString(c *Conn) (string, error) {
 	// Read a packet, the password is the payload, as a
 	// zero terminated string.
@@ -50,6 +50,11 @@ type AuthServerStatic struct {
 	mu sync.Mutex
 	// entries contains the users, passwords and user data.
 	entries map[string][]*AuthServerStaticEntry
+	// sha2Cache holds the SHA256(SHA256(password)) of the entries that
+	// only have a MysqlNativePassword, once their users passed the full
+	// authentication of caching_sha2_password, so that they take the fast
+	// path afterwards. It is keyed by sha2CacheKey, and cleared on reload.
+	sha2Cache map[string][]byte
 
 	// Signal handling related fields.
 	sigChan chan os.Signal
@@ -118,7 +123,7 @@ func NewAuthServerStatic(file, jsonConfig string, reloadInterval time.Duration)
 		entries:        make(map[string][]*AuthServerStaticEntry),
 	}
 
-	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a)}
+	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a), NewSha2CachingAuthMethod(a, a, a)}
 
 	a.reload()
 	a.installSignalHandlers()
@@ -172,9 +177,31 @@ func (a *AuthServerStatic) UserEntryWithPassword(conn *Conn, user string, passwo
 	}
 
 	for _, entry := range entries {
+		if !MatchSourceHost(remoteAddr, entry.SourceHost) {
+			continue
+		}
 		// Validate the password.
-		if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
-			return &StaticUserData{entry.UserData, entry.Groups}, nil
+		switch {
+		case entry.CachingSha2Password != "":
+			hash, err := DecodePasswordHex(entry.CachingSha2Password)
+			if err == nil && subtle.ConstantTimeCompare(hashCachingSha2Password([]byte(password)), hash) == 1 {
+				return &StaticUserData{entry.UserData, entry.Groups}, nil
+			}
+		case entry.MysqlNativePassword != "":
+			hash, err := DecodePasswordHex(entry.MysqlNativePassword)
+			if err == nil && subtle.ConstantTimeCompare(hashMysqlNativePassword([]byte(password)), hash) == 1 {
+				a.mu.Lock()
+				if a.sha2Cache == nil {
+					a.sha2Cache = make(map[string][]byte)
+				}
+				a.sha2Cache[sha2CacheKey(user, entry)] = hashCachingSha2Password([]byte(password))
+				a.mu.Unlock()
+				return &StaticUserData{entry.UserData, entry.Groups}, nil
+			}
+		default:
+			if subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
+				return &StaticUserData{entry.UserData, entry.Groups}, nil
+			}
 		}
 	}
 	return &StaticUserData{}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
@@ -224,6 +251,7 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 		return &StaticUserData{}, AuthRejected, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 	}
 
+	needMoreData := false
 	for _, entry := range entries {
 		if entry.CachingSha2Password != "" {
 			hash, err := DecodePasswordHex(entry.CachingSha2Password)
@@ -235,6 +263,21 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 			if MatchSourceHost(remoteAddr, entry.SourceHost) && isPass {
 				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
 			}
+		} else if entry.MysqlNativePassword != "" {
+			// The caching_sha2_password hash of the password can't be
+			// derived from its mysql_native_password hash: the client
+			// sends its password in the full authentication, which fills
+			// the cache.
+			if !MatchSourceHost(remoteAddr, entry.SourceHost) {
+				continue
+			}
+			a.mu.Lock()
+			hash, ok := a.sha2Cache[sha2CacheKey(user, entry)]
+			a.mu.Unlock()
+			if ok && VerifyHashedCachingSha2Password(authResponse, salt, hash) {
+				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
+			}
+			needMoreData = true
 		} else {
 			computedAuthResponse := ScrambleCachingSha2Password(salt, []byte(entry.Password))
 
@@ -244,9 +287,18 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 			}
 		}
 	}
+	if needMoreData {
+		return &StaticUserData{}, AuthNeedMoreData, nil
+	}
 	return &StaticUserData{}, AuthRejected, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 }
 
+// sha2CacheKey returns the key of entry in the sha2Cache. It contains the
+// mysql_native_password hash, so that a changed password is not cached.
+func sha2CacheKey(user string, entry *AuthServerStaticEntry) string {
+	return user + "\x00" + entry.SourceHost + "\x00" + entry.MysqlNativePassword
+}
+
 // AuthMethods returns the AuthMethod instances this auth server can handle.
 func (a *AuthServerStatic) AuthMethods() []AuthMethod {
 	return a.methods
@@ -277,6 +329,7 @@ func (a *AuthServerStatic) reload() {
 
 	a.mu.Lock()
 	a.entries = entries
+	a.sha2Cache = make(map[string][]byte)
 	a.mu.Unlock()
 }
 
@@ -331,3 +331,45 @@ func TestStaticCachingSha2Passwords(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticCachingSha2FullAuth(t *testing.T) {
+	_ = utils.LeakCheckContext(t)
+	jsonConfig := `
+{
+	"user01": [{ "MysqlNativePassword": "*14E65567ABDB5135D0CFD9A70B3032C179A49EE7" }],
+	"user02": [{
+		"CachingSha2Password": "*d2a47945c740b8ddc53f575733003b68961290d5224a4aedfdb57c8726bb3979"
+	}]
+}`
+
+	auth := NewAuthServerStatic("", jsonConfig, 0)
+	defer auth.close()
+	addr := &net.IPAddr{IP: net.ParseIP("127.0.0.1")}
+	fastAuth := func(user, password string) CacheState {
+		salt, err := newSalt()
+		require.NoError(t, err)
+		_, status, _ := auth.UserEntryWithCacheHash(nil, salt, user, ScrambleCachingSha2Password(salt, []byte(password)), addr)
+		return status
+	}
+
+	// The fast path of a user with a mysql_native_password hash needs the
+	// full authentication, until it succeeded once.
+	assert.Equal(t, AuthNeedMoreData, fastAuth("user01", "secret"))
+	_, err := auth.UserEntryWithPassword(nil, "user01", "wrong", addr)
+	assert.Error(t, err)
+	assert.Equal(t, AuthNeedMoreData, fastAuth("user01", "secret"))
+	_, err = auth.UserEntryWithPassword(nil, "user01", "secret", addr)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthAccepted, fastAuth("user01", "secret"))
+	assert.Equal(t, AuthNeedMoreData, fastAuth("user01", "wrong"))
+
+	// The full authentication also checks caching_sha2_password hashes.
+	_, err = auth.UserEntryWithPassword(nil, "user02", "user02", addr)
+	assert.NoError(t, err)
+	_, err = auth.UserEntryWithPassword(nil, "user02", "wrong", addr)
+	assert.Error(t, err)
+
+	// The cache is cleared on reload.
+	auth.reload()
+	assert.Equal(t, AuthNeedMoreData, fastAuth("user01", "secret"))
+}
@@ -17,9 +17,16 @@ limitations under the license.
 package mysql
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVerifyHashedCachingSha2Password(t *testing.T) {
@@ -57,3 +64,43 @@ func TestVerifyHashedMysqlNativePassword(t *testing.T) {
 	passwordHash[0] = 0x00
 	assert.False(t, VerifyHashedMysqlNativePassword(reply, salt, passwordHash), "password hash match")
 }
+
+func TestDecryptPasswordWithPrivateKey(t *testing.T) {
+	salt := []byte{10, 47, 74, 111, 75, 73, 34, 48, 88, 76, 114, 74, 37, 13, 3, 80, 82, 2, 23, 21}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	enc, err := EncryptPasswordWithPublicKey(salt, []byte("a password longer than the salt"), &key.PublicKey)
+	require.NoError(t, err)
+	password, err := DecryptPasswordWithPrivateKey(salt, enc, key)
+	require.NoError(t, err)
+	assert.Equal(t, "a password longer than the salt", string(password))
+
+	enc[0] ^= 0xff
+	_, err = DecryptPasswordWithPrivateKey(salt, enc, key)
+	assert.Error(t, err)
+}
+
+func TestLoadCachingSha2PasswordPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	for name, block := range map[string]*pem.Block{
+		"pkcs1.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"pkcs8.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		file := path.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(block), 0o600))
+		loaded, err := LoadCachingSha2PasswordPrivateKey(file)
+		require.NoError(t, err, name)
+		assert.True(t, key.Equal(loaded), name)
+	}
+
+	file := path.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	_, err = LoadCachingSha2PasswordPrivateKey(file)
+	assert.ErrorContains(t, err, "no PEM data found")
+}
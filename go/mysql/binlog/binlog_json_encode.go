@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// EncodeBinaryJSON encodes a JSON value in the mysql binary json
+// representation, which ParseBinaryJSON parses back.
+//
+// Integers are encoded in the smallest integer type that holds them, and
+// the other numbers as doubles, like mysql does when it parses JSON text.
+// Opaque values whose mysql type is not known can't be encoded.
+func EncodeBinaryJSON(v *json.Value) ([]byte, error) {
+	typ, data, err := binencodeNode(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(typ)}, data...), nil
+}
+
+// binencodeNode returns the type and the encoded data of a value, without
+// the type identifier.
+func binencodeNode(v *json.Value) (jsonDataType, []byte, error) {
+	switch v.Type() {
+	case json.TypeNull:
+		return jsonLiteral, []byte{jsonNullLiteral}, nil
+	case json.TypeBoolean:
+		if v == json.ValueTrue {
+			return jsonLiteral, []byte{jsonTrueLiteral}, nil
+		}
+		return jsonLiteral, []byte{jsonFalseLiteral}, nil
+	case json.TypeNumber:
+		return binencodeNumber(v)
+	case json.TypeString:
+		s, _ := v.StringBytes()
+		return jsonString, binencodeString(nil, s), nil
+	case json.TypeArray:
+		values, _ := v.Array()
+		return binencodeContainer(jsonSmallArray, nil, values)
+	case json.TypeObject:
+		obj, _ := v.Object()
+		// mysql sorts the keys of objects by length first, and then by
+		// their bytes.
+		type kv struct {
+			k string
+			v *json.Value
+		}
+		var kvs []kv
+		obj.Visit(func(key string, v *json.Value) {
+			kvs = append(kvs, kv{k: key, v: v})
+		})
+		slices.SortStableFunc(kvs, func(a, b kv) int {
+			if len(a.k) != len(b.k) {
+				return len(a.k) - len(b.k)
+			}
+			return strings.Compare(a.k, b.k)
+		})
+		keys := make([]string, 0, len(kvs))
+		values := make([]*json.Value, 0, len(kvs))
+		for _, kv := range kvs {
+			keys = append(keys, kv.k)
+			values = append(values, kv.v)
+		}
+		return binencodeContainer(jsonSmallObject, keys, values)
+	case json.TypeDate, json.TypeDateTime, json.TypeTime:
+		return binencodeTemporal(v)
+	case json.TypeBlob:
+		return jsonOpaque, binencodeOpaque(TypeBlob, v.Raw()), nil
+	case json.TypeBit:
+		return jsonOpaque, binencodeOpaque(TypeBit, v.Raw()), nil
+	default:
+		return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON %s value in the binary JSON format", v.Type())
+	}
+}
+
+func binencodeNumber(v *json.Value) (jsonDataType, []byte, error) {
+	switch v.NumberType() {
+	case json.NumberTypeSigned:
+		i, _ := v.Int64()
+		switch {
+		case i >= math.MinInt16 && i <= math.MaxInt16:
+			return jsonInt16, binary.LittleEndian.AppendUint16(nil, uint16(i)), nil
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			return jsonInt32, binary.LittleEndian.AppendUint32(nil, uint32(i)), nil
+		default:
+			return jsonInt64, binary.LittleEndian.AppendUint64(nil, uint64(i)), nil
+		}
+	case json.NumberTypeUnsigned:
+		u, _ := v.Uint64()
+		return jsonUint64, binary.LittleEndian.AppendUint64(nil, u), nil
+	}
+
+	f, ok := v.Float64()
+	if !ok {
+		return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON number %s in the binary JSON format", v.Raw())
+	}
+	return jsonDouble, binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)), nil
+}
+
+// binencodeTemporal encodes dates, datetimes and times as opaque values, in
+// the packed format mysql uses for them.
+func binencodeTemporal(v *json.Value) (jsonDataType, []byte, error) {
+	var mysqlType byte
+	var packed int64
+	switch v.Type() {
+	case json.TypeDate:
+		d, ok := v.Date()
+		if !ok {
+			return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON date %s in the binary JSON format", v.Raw())
+		}
+		mysqlType = TypeDate
+		packed = (int64(d.Year())*13+int64(d.Month()))<<22 | int64(d.Day())<<17
+		packed <<= 24
+	case json.TypeDateTime:
+		dt, ok := v.DateTime()
+		if !ok {
+			return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON datetime %s in the binary JSON format", v.Raw())
+		}
+		mysqlType = TypeDateTime
+		packed = (int64(dt.Date.Year())*13+int64(dt.Date.Month()))<<22 | int64(dt.Date.Day())<<17 |
+			int64(dt.Time.Hour())<<12 | int64(dt.Time.Minute())<<6 | int64(dt.Time.Second())
+		packed = packed<<24 | int64(dt.Time.Nanosecond()/1000)
+	case json.TypeTime:
+		t, ok := v.Time()
+		if !ok {
+			return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON time %s in the binary JSON format", v.Raw())
+		}
+		mysqlType = TypeTime
+		packed = int64(t.Hour())<<12 | int64(t.Minute())<<6 | int64(t.Second())
+		packed = packed<<24 | int64(t.Nanosecond()/1000)
+		if t.Neg() {
+			packed = -packed
+		}
+	}
+	return jsonOpaque, binencodeOpaque(mysqlType, string(binary.LittleEndian.AppendUint64(nil, uint64(packed)))), nil
+}
+
+// binencodeOpaque encodes an opaque value: its mysql type, and its length
+// followed by its data.
+func binencodeOpaque(mysqlType byte, data string) []byte {
+	return binencodeString([]byte{mysqlType}, []byte(data))
+}
+
+// binencodeString appends the variable length of a string and the string.
+func binencodeString(dst []byte, s []byte) []byte {
+	dst = appendVariableLength(dst, len(s))
+	return append(dst, s...)
+}
+
+// appendVariableLength is the inverse of readVariableLength.
+func appendVariableLength(dst []byte, length int) []byte {
+	for length >= 0x80 {
+		dst = append(dst, byte(length&0x7f)|0x80)
+		length >>= 7
+	}
+	return append(dst, byte(length))
+}
+
+// binencodeContainer encodes an array, or an object if keys is not nil, in
+// the small format if it fits, and in the large format otherwise.
+func binencodeContainer(small jsonDataType, keys []string, values []*json.Value) (jsonDataType, []byte, error) {
+	for _, key := range keys {
+		if len(key) > math.MaxUint16 {
+			return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot encode JSON object key of length %d in the binary JSON format", len(key))
+		}
+	}
+
+	data, ok, err := binencodeContainerData(keys, values, false)
+	if err != nil || ok {
+		return small, data, err
+	}
+	// The large types follow the small types.
+	data, _, err = binencodeContainerData(keys, values, true)
+	return small + 1, data, err
+}
+
+// binencodeContainerData encodes the data of an array or an object, which
+// is laid out as:
+// | elem count | size | key entries | value entries | keys | values |
+// The offsets of the keys and the values are relative to the start of the
+// data. It returns false if the data does not fit in the small format.
+func binencodeContainerData(keys []string, values []*json.Value, large bool) ([]byte, bool, error) {
+	intSize := 2
+	maxInt := math.MaxUint16
+	if large {
+		intSize = 4
+		maxInt = math.MaxUint32
+	}
+	putInt := func(b []byte, n int) {
+		if large {
+			binary.LittleEndian.PutUint32(b, uint32(n))
+		} else {
+			binary.LittleEndian.PutUint16(b, uint16(n))
+		}
+	}
+
+	keyEntriesPos := 2 * intSize
+	valueEntriesPos := keyEntriesPos + len(keys)*(intSize+2)
+	data := make([]byte, valueEntriesPos+len(values)*(1+intSize))
+	putInt(data, len(values))
+
+	for i, key := range keys {
+		if len(data) > maxInt {
+			return nil, false, nil
+		}
+		entry := data[keyEntriesPos+i*(intSize+2):]
+		putInt(entry, len(data))
+		binary.LittleEndian.PutUint16(entry[intSize:], uint16(len(key)))
+		data = append(data, key...)
+	}
+
+	for i, value := range values {
+		typ, valueData, err := binencodeNode(value)
+		if err != nil {
+			return nil, false, err
+		}
+		entry := data[valueEntriesPos+i*(1+intSize):]
+		entry[0] = byte(typ)
+		if isInline(typ, large) {
+			copy(entry[1:1+intSize], valueData)
+			continue
+		}
+		if len(data) > maxInt {
+			return nil, false, nil
+		}
+		putInt(entry[1:], len(data))
+		data = append(data, valueData...)
+	}
+
+	if len(data) > maxInt {
+		return nil, false, nil
+	}
+	putInt(data[intSize:], len(data))
+	return data, true, nil
+}
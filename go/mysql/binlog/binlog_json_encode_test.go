@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/json"
+)
+
+func TestEncodeBinaryJSON(t *testing.T) {
+	// The documents are encoded like mysql encodes them.
+	testcases := []struct {
+		json string
+		data []byte
+	}{
+		{
+			json: `{"a": "b"}`,
+			data: []byte{0, 1, 0, 14, 0, 11, 0, 1, 0, 12, 12, 0, 97, 1, 98},
+		},
+		{
+			json: `{"a": 2}`,
+			data: []byte{0, 1, 0, 12, 0, 11, 0, 1, 0, 5, 2, 0, 97},
+		},
+		{
+			json: `{"asdf":{"foo":123}}`,
+			data: []byte{0, 1, 0, 29, 0, 11, 0, 4, 0, 0, 15, 0, 97, 115, 100, 102, 1, 0, 14, 0, 11, 0, 3, 0, 5, 123, 0, 102, 111, 111},
+		},
+		{
+			json: `[1,2]`,
+			data: []byte{2, 2, 0, 10, 0, 5, 1, 0, 5, 2, 0},
+		},
+		{
+			json: `{"a":"b","c":"d","ab":"abc","bc":["x","y"]}`,
+			data: []byte{0, 4, 0, 60, 0, 32, 0, 1, 0, 33, 0, 1, 0, 34, 0, 2, 0, 36, 0, 2, 0, 12, 38, 0, 12, 40, 0, 12, 42, 0, 2, 46, 0, 97, 99, 97, 98, 98, 99, 1, 98, 1, 100, 3, 97, 98, 99, 2, 0, 14, 0, 12, 10, 0, 12, 12, 0, 1, 120, 1, 121},
+		},
+		{
+			json: `"scalar string"`,
+			data: []byte{12, 13, 115, 99, 97, 108, 97, 114, 32, 115, 116, 114, 105, 110, 103},
+		},
+		{
+			json: `true`,
+			data: []byte{4, 1},
+		},
+		{
+			json: `-1`,
+			data: []byte{5, 255, 255},
+		},
+		{
+			json: `1.5`,
+			data: []byte{11, 0, 0, 0, 0, 0, 0, 248, 63},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.json, func(t *testing.T) {
+			var p json.Parser
+			v, err := p.Parse(tc.json)
+			require.NoError(t, err)
+
+			data, err := EncodeBinaryJSON(v)
+			require.NoError(t, err)
+			require.Equal(t, tc.data, data)
+		})
+	}
+}
+
+func TestEncodeBinaryJSONRoundTrip(t *testing.T) {
+	var large strings.Builder
+	large.WriteString(`{"values": [`)
+	for i := range 5000 {
+		if i > 0 {
+			large.WriteString(", ")
+		}
+		fmt.Fprintf(&large, `{"id": %d, "name": "value %d"}`, i*1000, i)
+	}
+	large.WriteString(`], "other": [70000, -70000]}`)
+
+	docs := []string{
+		`null`,
+		`false`,
+		`{}`,
+		`[]`,
+		`[null, true, false]`,
+		`[32767, -32768, 32768, 2147483647, -2147483649, 9223372036854775807, 18446744073709551615]`,
+		`[1.25, -0.5, 1e300]`,
+		`{"b": 1, "a": {"cc": [1, "x"], "c": {}}, "aaa": "ünicode"}`,
+		`"` + strings.Repeat("long string ", 100) + `"`,
+		large.String(),
+	}
+	for _, doc := range docs {
+		var p json.Parser
+		v, err := p.Parse(doc)
+		require.NoError(t, err)
+
+		data, err := EncodeBinaryJSON(v)
+		require.NoError(t, err)
+
+		decoded, err := ParseBinaryJSON(data)
+		require.NoError(t, err)
+		require.Equal(t, v.String(), decoded.String())
+	}
+
+	// The large document does not fit in the small format.
+	var p json.Parser
+	v, err := p.Parse(large.String())
+	require.NoError(t, err)
+	data, err := EncodeBinaryJSON(v)
+	require.NoError(t, err)
+	require.EqualValues(t, jsonLargeObject, data[0])
+
+	values := []*json.Value{
+		json.NewDate("2015-01-15"),
+		json.NewDateTime("2015-01-15 23:24:25.120000"),
+		json.NewTime("23:24:25.120000"),
+		json.NewBlob(string([]byte{202, 254})),
+		json.NewBit(string([]byte{202, 254})),
+	}
+	for _, v := range values {
+		data, err := EncodeBinaryJSON(v)
+		require.NoError(t, err)
+
+		decoded, err := ParseBinaryJSON(data)
+		require.NoError(t, err)
+		require.Equal(t, v.Type(), decoded.Type())
+		require.Equal(t, v.String(), decoded.String())
+	}
+}
@@ -51,6 +51,7 @@ const (
 	BinaryLogStatus                                                       // Supported in 8.2.0 and above, uses SHOW BINARY LOG STATUS
 	RestrictFKOnNonStandardKey                                            // Supported in 8.4.0 and above, restricts usage of non-standard indexes for foreign keys.
 	MySQLClonePluginFlavorCapability                                      // Supported in 8.0.17 and above, MySQL CLONE plugin for physical snapshot.
+	ResetConnectionCapability                                             // Supported in 5.7.3 and above, COM_RESET_CONNECTION resets the session state.
 )
 
 type CapableOf func(capability FlavorCapability) (bool, error)
@@ -90,6 +91,8 @@ func MySQLVersionHasCapability(serverVersion string, capability FlavorCapability
 	}
 	// Capabilities sorted by version.
 	switch capability {
+	case ResetConnectionCapability:
+		return atLeast(5, 7, 3)
 	case InstantDDLFlavorCapability,
 		InstantExpandEnumCapability,
 		InstantAddLastColumnFlavorCapability,
@@ -265,6 +265,16 @@ func TestMySQLVersionCapableOf(t *testing.T) {
 			capability: InstantDDLXtrabackupCapability,
 			isCapable:  true,
 		},
+		{
+			version:    "5.7.2",
+			capability: ResetConnectionCapability,
+			isCapable:  false,
+		},
+		{
+			version:    "5.7.3",
+			capability: ResetConnectionCapability,
+			isCapable:  true,
+		},
 		{
 			// What happens if server version is unspecified
 			version:    "",
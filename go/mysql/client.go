@@ -22,12 +22,15 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"net"
 	"strconv"
+	"sync"
 	"time"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/vt/log"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttls"
@@ -116,6 +119,7 @@ func ConnectWithAttributes(ctx context.Context, params *ConnParams, attributes C
 
 		// Send the connection back, so the other side can close it.
 		c := newConn(conn, params.FlushDelay, params.TruncateErrLen)
+		c.connParams = params
 		status <- connectResult{
 			c: c,
 		}
@@ -182,10 +186,77 @@ func ConnectWithAttributes(ctx context.Context, params *ConnParams, attributes C
 
 // Ping implements mysql ping command.
 func (c *Conn) Ping() error {
+	return c.simpleCommand(ComPing)
+}
+
+// ResetConnection resets the session state of the connection with
+// COM_RESET_CONNECTION, like mysql_reset_connection(): the session variables
+// take their global values, the transaction is rolled back, and the user
+// variables, temporary tables and prepared statements are dropped. The
+// connection keeps its database and authentication, which makes it much
+// cheaper than reconnecting. Only the servers that support
+// capabilities.ResetConnectionCapability implement it.
+func (c *Conn) ResetConnection() error {
+	return c.simpleCommand(ComResetConnection)
+}
+
+// Kill kills the statement the connection is executing with KILL QUERY. The
+// connection itself is busy with the statement, so the KILL QUERY is sent on
+// a short-lived companion connection, opened with the same parameters. The
+// statement then fails with ERQueryInterrupted, and the connection stays
+// open. ctx bounds the time to connect and send the KILL QUERY.
+func (c *Conn) Kill(ctx context.Context) error {
+	if c.connParams == nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRUnknownError, sqlerror.SSUnknownSQLState, "cannot kill the statement of a server connection")
+	}
+	killConn, err := Connect(ctx, c.connParams)
+	if err != nil {
+		return err
+	}
+	defer killConn.Close()
+
+	stop := context.AfterFunc(ctx, killConn.Close)
+	defer stop()
+	if _, err := killConn.ExecuteFetch(fmt.Sprintf("kill query %d", c.ConnectionID), 0, false); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// KillOnCancel kills the statement the connection is executing with Kill if
+// ctx is done before the returned stop function is called, rather than
+// leaving MySQL to run a statement nobody waits for anymore. Each kill is
+// given timeout to complete. stop returns false if the kill was started,
+// after waiting for it to complete.
+func (c *Conn) KillOnCancel(ctx context.Context, timeout time.Duration) (stop func() bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	stopKill := context.AfterFunc(ctx, func() {
+		defer wg.Done()
+		killCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := c.Kill(killCtx); err != nil {
+			log.Warn(fmt.Sprintf("Could not kill the statement of connection ID %d: %v", c.ConnectionID, err))
+		}
+	})
+	return func() bool {
+		if stopKill() {
+			return true
+		}
+		wg.Wait()
+		return false
+	}
+}
+
+// simpleCommand sends a command without arguments, and reads its OK packet.
+func (c *Conn) simpleCommand(command byte) error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 	data, pos := c.startEphemeralPacketWithHeader(1)
-	data[pos] = ComPing
+	data[pos] = command
 
 	if err := c.writeEphemeralPacket(); err != nil {
 		return sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
@@ -204,6 +275,53 @@ func (c *Conn) Ping() error {
 	return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected packet type: %d", data[0])
 }
 
+// Statistics implements the mysql statistics command, and returns the
+// server status string.
+func (c *Conn) Statistics() (string, error) {
+	// This is a new command, need to reset the sequence.
+	c.resetSequence()
+	data, pos := c.startEphemeralPacketWithHeader(1)
+	data[pos] = ComStatistics
+
+	if err := c.writeEphemeralPacket(); err != nil {
+		return "", sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	data, err := c.readEphemeralPacket()
+	if err != nil {
+		return "", sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	defer c.recycleReadPacket()
+	if len(data) > 0 && data[0] == ErrPacket {
+		return "", ParseErrorPacket(data)
+	}
+	return string(data), nil
+}
+
+// DumpDebugInfo implements the mysql debug command, which asks the
+// server to dump debug information to its logs.
+func (c *Conn) DumpDebugInfo() error {
+	// This is a new command, need to reset the sequence.
+	c.resetSequence()
+	data, pos := c.startEphemeralPacketWithHeader(1)
+	data[pos] = ComDebug
+
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	data, err := c.readEphemeralPacket()
+	if err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	defer c.recycleReadPacket()
+	switch data[0] {
+	case OKPacket, EOFPacket:
+		return nil
+	case ErrPacket:
+		return ParseErrorPacket(data)
+	}
+	return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected packet type: %d", data[0])
+}
+
 // clientHandshake handles the client side of the handshake.
 // Note the connection can be closed while this is running.
 // Returns a SQLError.
@@ -237,6 +355,8 @@ func (c *Conn) clientHandshake(params *ConnParams, attributes ConnectionAttribut
 	if !params.DisableClientDeprecateEOF {
 		c.Capabilities = capabilities & (CapabilityClientDeprecateEOF)
 	}
+	// If the server supports query attributes, we support them too.
+	c.Capabilities |= capabilities & CapabilityClientQueryAttributes
 
 	// Handle switch to SSL if necessary.
 	if params.SslEnabled() {
@@ -320,6 +440,14 @@ func (c *Conn) clientHandshake(params *ConnParams, attributes ConnectionAttribut
 		return err
 	}
 
+	// Everything after the authentication is compressed, if the
+	// server supports the compression we asked for.
+	if params.Compression != "" && capabilities&params.Compression.capability() != 0 {
+		if err := c.enableCompression(params.Compression, zstdCompressionLevel(params)); err != nil {
+			return sqlerror.NewSQLErrorf(sqlerror.CRUnknownError, sqlerror.SSUnknownSQLState, "cannot enable %s protocol compression: %v", params.Compression, err)
+		}
+	}
+
 	// If the server didn't support DbName in its handshake, set
 	// it now. This is what the 'mysql' client does.
 	if capabilities&CapabilityClientConnectWithDB == 0 && params.DbName != "" {
@@ -489,6 +617,9 @@ func (c *Conn) writeSSLRequest(capabilities uint32, characterSet uint8, params *
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
 		c.Capabilities&CapabilityClientSessionTrack |
+		// If the server supported
+		// CapabilityClientQueryAttributes, we also support it.
+		c.Capabilities&CapabilityClientQueryAttributes |
 		// Pass-through ClientFoundRows flag.
 		CapabilityClientFoundRows&uint32(params.Flags)
 
@@ -547,7 +678,14 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		CapabilityClientFoundRows&uint32(params.Flags) |
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
-		c.Capabilities&CapabilityClientSessionTrack
+		c.Capabilities&CapabilityClientSessionTrack |
+		// If the server supported
+		// CapabilityClientQueryAttributes, we also support it.
+		c.Capabilities&CapabilityClientQueryAttributes
+
+	// Ask for protocol compression if the server supports it.
+	compression := params.Compression.capability() & capabilities
+	capabilityFlags |= compression
 
 	// FIXME(alainjobart) add multi statement.
 
@@ -584,6 +722,11 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		length += lenEncIntSize(uint64(attrLength)) + attrLength
 	}
 
+	// The zstd compression level comes last.
+	if compression&CapabilityClientZstdCompressionAlgorithm != 0 {
+		length++
+	}
+
 	data, pos := c.startEphemeralPacketWithHeader(length)
 
 	// Client capability flags.
@@ -630,6 +773,10 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		}
 	}
 
+	if compression&CapabilityClientZstdCompressionAlgorithm != 0 {
+		pos = writeByte(data, pos, byte(zstdCompressionLevel(params)))
+	}
+
 	// Sanity-check the length.
 	if pos != len(data) {
 		return sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "writeHandshakeResponse41: only packed %v bytes, out of %v allocated", pos, len(data))
@@ -764,7 +911,7 @@ func parseAuthSwitchRequest(data []byte) (AuthMethodDescription, []byte, error)
 func (c *Conn) requestPublicKey() (rsaKey *rsa.PublicKey, err error) {
 	// get public key from server
 	data, pos := c.startEphemeralPacketWithHeader(1)
-	data[pos] = 0x02
+	data[pos] = CachingSha2RequestPublicKey
 	if err := c.writeEphemeralPacket(); err != nil {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error sending public key request packet: %v", err)
 	}
@@ -32,6 +32,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
 
 	"vitess.io/vitess/go/vt/tlstest"
 	"vitess.io/vitess/go/vt/vttls"
@@ -498,3 +500,90 @@ func TestTLSClientVerifyIdentity(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Certificate revoked: CommonName=server.example.com")
 }
+
+// killHandler runs "sleep" statements until they are killed with KILL QUERY.
+type killHandler struct {
+	testHandler
+	killMu sync.Mutex
+	kills  map[uint32]chan struct{}
+}
+
+func (th *killHandler) killed(id uint32) chan struct{} {
+	th.killMu.Lock()
+	defer th.killMu.Unlock()
+	if th.kills == nil {
+		th.kills = make(map[uint32]chan struct{})
+	}
+	if th.kills[id] == nil {
+		th.kills[id] = make(chan struct{})
+	}
+	return th.kills[id]
+}
+
+func (th *killHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	var id uint32
+	if _, err := fmt.Sscanf(query, "kill query %d", &id); err == nil {
+		close(th.killed(id))
+		return callback(&sqltypes.Result{})
+	}
+	if query == "sleep" {
+		<-th.killed(c.ConnectionID)
+		return sqlerror.NewSQLError(sqlerror.ERQueryInterrupted, sqlerror.SSQueryInterrupted, "Query execution was interrupted")
+	}
+	return th.testHandler.ComQuery(c, query, callback)
+}
+
+func (th *killHandler) ComQueryMulti(c *Conn, sql string, callback func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error) error {
+	return th.ComQuery(c, sql, func(qr *sqltypes.Result) error {
+		return callback(sqltypes.QueryResponse{QueryResult: qr}, false, true)
+	})
+}
+
+func TestKillOnCancel(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &killHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer c.Close()
+
+	queryCtx, cancel := context.WithCancel(ctx)
+	stop := c.KillOnCancel(queryCtx, 5*time.Second)
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err = c.ExecuteFetch("sleep", 0, false)
+	assertSQLError(t, err, sqlerror.ERQueryInterrupted, sqlerror.SSQueryInterrupted, "interrupted", "sleep", "")
+	assert.False(t, stop())
+
+	// Only the statement was killed, the connection is still usable.
+	_, err = c.ExecuteFetch("select rows", 10, false)
+	require.NoError(t, err)
+
+	// The statements that complete in time are not killed.
+	stop = c.KillOnCancel(ctx, 5*time.Second)
+	_, err = c.ExecuteFetch("select rows", 10, false)
+	require.NoError(t, err)
+	assert.True(t, stop())
+}
+
+func TestKillServerConn(t *testing.T) {
+	c := &Conn{}
+	require.ErrorContains(t, c.Kill(context.Background()), "server connection")
+}
@@ -816,6 +816,11 @@ func TestTinyWeightStrings(t *testing.T) {
 		testcollation(t, "utf8mb4_0900_as_cs"),
 		testcollation(t, "utf8mb4_0900_as_ci"),
 		testcollation(t, "utf8mb4_0900_ai_ci"),
+		testcollation(t, "utf8mb4_ja_0900_as_cs"),
+		testcollation(t, "utf8mb4_ja_0900_as_cs_ks"),
+		testcollation(t, "utf8mb4_de_pb_0900_as_cs"),
+		testcollation(t, "utf8mb4_es_0900_ai_ci"),
+		testcollation(t, "utf8mb4_zh_0900_as_cs"),
 	}
 
 	Strings := []string{
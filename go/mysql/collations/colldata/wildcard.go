@@ -41,6 +41,7 @@ limitations under the License.
 package colldata
 
 import (
+	"bytes"
 	"unicode/utf8"
 
 	"vitess.io/vitess/go/mysql/collations/charset"
@@ -413,10 +414,12 @@ func newEightbitWildcardMatcher(
 					isPrefix: false,
 				}
 			}
-			if chManyCount == 1 && pat[len(pat)-1] == chMany {
+			// consecutive chMany characters were collapsed into a single patternMatchMany,
+			// so the prefix ends at the first chMany of the pattern, not at its last byte
+			if chManyCount == 1 && parsedPattern[len(parsedPattern)-1] == patternMatchMany {
 				return &fastMatcher{
 					collate:  collate,
-					pattern:  pat[:len(pat)-1],
+					pattern:  pat[:bytes.IndexByte(pat, chMany)],
 					isPrefix: true,
 				}
 			}
@@ -115,6 +115,47 @@ func TestLikeMatches(t *testing.T) {
 		{"Ǎḅdbçd", "ǎ%Çd", true},
 		{"Ǎḅeçd", "a%bd", false},
 	})
+
+	testWildcardMatches(t, "latin1_swedish_ci", 0, 0, 0, []wildcardtest{
+		{"abc", "a%", true},
+		{"abc", "a%%", true},
+		{"a%c", "a%%", true},
+		{"abc", "A%%%", true},
+		{"b%c", "a%%", false},
+		{"\xe9t\xe9", "\xc9%", true},
+		{"\xe9t\xe9", "_t_", true},
+		{"\xe9t\xe9", "\\_t_", false},
+		{"_t\xe9", "\\_t_", true},
+	})
+
+	// 8-bit charsets take the escape character in their own encoding: 0xA7 is '§' in latin1.
+	testWildcardMatches(t, "latin1_bin", 0, 0, 0xA7, []wildcardtest{
+		{"a_c", "a\xa7_c", true},
+		{"abc", "a\xa7_c", false},
+		{"a%", "a\xa7%", true},
+		{"ab", "a\xa7%", false},
+		{"a\\c", "a\\_", true},
+		{"a\xa7", "a\xa7", true},
+	})
+
+	// multi-byte charsets take the escape character as a Unicode code point. In sjis, 'ソ' is
+	// encoded as 0x83 0x5C, whose trailing byte is a backslash, and '表' as 0x95 0x5C.
+	testWildcardMatches(t, "sjis_japanese_ci", 0, 0, 0, []wildcardtest{
+		{"\x83\x5c", "\x83\x5c", true},
+		{"\x83\x5ca", "_a", true},
+		{"\x83\x5c\x95\x5c", "%\x95\x5c", true},
+		{"\x83\x5c\x95\x5c", "\x83\x5c_", true},
+		{"\x83\x5c\x95\x5c", "_", false},
+		{"x\x95\x5cy", "x_y", true},
+		{"a%", "a\\%", true},
+		{"ab", "a\\%", false},
+	})
+	testWildcardMatches(t, "sjis_japanese_ci", 0, 0, '表', []wildcardtest{
+		{"a_c", "a\x95\x5c_c", true},
+		{"abc", "a\x95\x5c_c", false},
+		{"a\\c", "a\\_", true},
+		{"a\x95\x5c", "a\x95\x5c\x95\x5c", true},
+	})
 }
 
 // from http://developforperformance.com/MatchingWildcards_AnImprovedAlgorithmForBigData.html
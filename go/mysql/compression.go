@@ -0,0 +1,432 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file implements the compressed client/server protocol, negotiated
+// with CLIENT_COMPRESS (zlib) or CLIENT_ZSTD_COMPRESSION_ALGORITHM (zstd).
+// Once the handshake is over, the regular packet stream is cut into
+// compressed packets, each one with its own 7 bytes header:
+// - 3 bytes: length of the (compressed) payload.
+// - 1 byte: compressed packet sequence number.
+// - 3 bytes: length of the payload before compression, or 0 if the
+// payload was sent uncompressed.
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression.html
+
+// CompressionAlgorithm is a protocol compression algorithm.
+type CompressionAlgorithm string
+
+const (
+	// CompressionZlib is the zlib compressed protocol, CLIENT_COMPRESS.
+	CompressionZlib CompressionAlgorithm = "zlib"
+
+	// CompressionZstd is the zstd compressed protocol,
+	// CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	CompressionZstd CompressionAlgorithm = "zstd"
+
+	// DefaultZstdCompressionLevel is the zstd level used when none
+	// is configured. It is the MySQL default.
+	DefaultZstdCompressionLevel = 3
+
+	// minZstdCompressionLevel and maxZstdCompressionLevel bound the
+	// zstd levels, like MySQL does.
+	minZstdCompressionLevel = 1
+	maxZstdCompressionLevel = 22
+
+	// compressedPacketHeaderSize is the size of a compressed packet header.
+	compressedPacketHeaderSize = 7
+
+	// minCompressLength is the payload size under which we do not
+	// bother compressing. It is the MySQL threshold.
+	minCompressLength = 50
+
+	// maxRetainedCompressionBuffer is the largest buffer a connection
+	// keeps around between compressed packets.
+	maxRetainedCompressionBuffer = 1 << 20
+)
+
+// ParseCompressionAlgorithm parses a protocol compression algorithm name.
+// An empty name means no compression, and returns an empty algorithm.
+func ParseCompressionAlgorithm(name string) (CompressionAlgorithm, error) {
+	switch algorithm := CompressionAlgorithm(strings.ToLower(strings.TrimSpace(name))); algorithm {
+	case "", CompressionZlib, CompressionZstd:
+		return algorithm, nil
+	default:
+		return "", fmt.Errorf("unknown protocol compression algorithm %q, expected one of: zlib, zstd", name)
+	}
+}
+
+// ParseCompressionAlgorithms parses a list of protocol compression
+// algorithm names, ignoring empty ones.
+func ParseCompressionAlgorithms(names []string) ([]CompressionAlgorithm, error) {
+	var algorithms []CompressionAlgorithm
+	for _, name := range names {
+		algorithm, err := ParseCompressionAlgorithm(name)
+		if err != nil {
+			return nil, err
+		}
+		if algorithm != "" {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	return algorithms, nil
+}
+
+// capability returns the capability flag negotiating the algorithm.
+func (ca CompressionAlgorithm) capability() uint32 {
+	switch ca {
+	case CompressionZlib:
+		return CapabilityClientCompress
+	case CompressionZstd:
+		return CapabilityClientZstdCompressionAlgorithm
+	}
+	return 0
+}
+
+// compressionCapabilities returns the capability flags negotiating
+// the given algorithms.
+func compressionCapabilities(algorithms []CompressionAlgorithm) uint32 {
+	var capabilities uint32
+	for _, algorithm := range algorithms {
+		capabilities |= algorithm.capability()
+	}
+	return capabilities
+}
+
+// negotiatedCompression returns the algorithm picked from the capability
+// flags set by both sides, or an empty algorithm. Like MySQL, zstd wins
+// when both are set.
+func negotiatedCompression(capabilities uint32) CompressionAlgorithm {
+	switch {
+	case capabilities&CapabilityClientZstdCompressionAlgorithm != 0:
+		return CompressionZstd
+	case capabilities&CapabilityClientCompress != 0:
+		return CompressionZlib
+	}
+	return ""
+}
+
+var (
+	// protocolZstdDecoder is shared by all the connections: DecodeAll
+	// can be called concurrently.
+	protocolZstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxPacketSize))
+	})
+
+	// protocolZstdEncoders pools the encoders of each encoder level.
+	// An encoder with a concurrency of 1 serializes its EncodeAll
+	// calls, so the connections do not share them. The zstd levels
+	// map to a handful of encoder levels, which bounds the number of
+	// pools whatever the level a client asks for.
+	protocolZstdEncoders [zstd.SpeedBestCompression + 1]sync.Pool
+)
+
+// zstdEncoderLevel returns the encoder level of a zstd level, which is
+// clamped to the levels MySQL accepts.
+func zstdEncoderLevel(level int) zstd.EncoderLevel {
+	return zstd.EncoderLevelFromZstd(min(max(level, minZstdCompressionLevel), maxZstdCompressionLevel))
+}
+
+func getProtocolZstdEncoder(level zstd.EncoderLevel) (*zstd.Encoder, error) {
+	if encoder, ok := protocolZstdEncoders[level].Get().(*zstd.Encoder); ok {
+		return encoder, nil
+	}
+	return zstd.NewWriter(nil, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(1))
+}
+
+func putProtocolZstdEncoder(level zstd.EncoderLevel, encoder *zstd.Encoder) {
+	protocolZstdEncoders[level].Put(encoder)
+}
+
+// zstdCompressionLevel returns the zstd level to ask for.
+func zstdCompressionLevel(params *ConnParams) int {
+	if params.ZstdCompressionLevel == 0 {
+		return DefaultZstdCompressionLevel
+	}
+	return min(max(params.ZstdCompressionLevel, minZstdCompressionLevel), maxZstdCompressionLevel)
+}
+
+// enableCompression switches the connection to the compressed protocol.
+// It has to be called once the handshake is over, by both sides.
+func (c *Conn) enableCompression(algorithm CompressionAlgorithm, level int) error {
+	var zstdLevel zstd.EncoderLevel
+	switch algorithm {
+	case CompressionZlib:
+	case CompressionZstd:
+		if level == 0 {
+			level = DefaultZstdCompressionLevel
+		}
+		zstdLevel = zstdEncoderLevel(level)
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown protocol compression algorithm %q", algorithm)
+	}
+
+	c.compressedReader = &compressedReader{c: c, r: c.getReader(), algorithm: algorithm}
+	c.compressedWriter = &compressedWriter{c: c, w: c.conn, algorithm: algorithm, zstdLevel: zstdLevel}
+	c.compressedSequence = 0
+	return nil
+}
+
+// Compression returns the protocol compression algorithm in use on the
+// connection, or an empty algorithm.
+func (c *Conn) Compression() CompressionAlgorithm {
+	if c.compressedReader == nil {
+		return ""
+	}
+	return c.compressedReader.algorithm
+}
+
+// resetSequence resets the packet sequence numbers, at the start of a
+// new command.
+func (c *Conn) resetSequence() {
+	c.sequence = 0
+	c.compressedSequence = 0
+}
+
+// netWriter returns the writer for the packets: the compressed
+// writer once compression is enabled, the connection otherwise.
+func (c *Conn) netWriter() io.Writer {
+	if c.compressedWriter != nil {
+		return c.compressedWriter
+	}
+	return c.conn
+}
+
+// compressedReader reads compressed packets from the network, and
+// serves their decompressed payloads as a regular packet stream.
+type compressedReader struct {
+	c         *Conn
+	r         io.Reader
+	algorithm CompressionAlgorithm
+
+	header [compressedPacketHeaderSize]byte
+	// raw is the payload as read from the network, out the
+	// decompressed payload, and buf the one of the two being served.
+	raw  []byte
+	out  []byte
+	buf  []byte
+	pos  int
+	zlib io.ReadCloser
+}
+
+// Read implements io.Reader.
+func (cr *compressedReader) Read(p []byte) (int, error) {
+	for cr.pos == len(cr.buf) {
+		if err := cr.readPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cr.buf[cr.pos:])
+	cr.pos += n
+	if cr.pos == len(cr.buf) {
+		// Do not hold on to the buffers of large packets.
+		if cap(cr.raw) > maxRetainedCompressionBuffer {
+			cr.raw = nil
+		}
+		if cap(cr.out) > maxRetainedCompressionBuffer {
+			cr.out = nil
+		}
+		cr.buf, cr.pos = nil, 0
+	}
+	return n, nil
+}
+
+// buffered returns the number of decompressed bytes not read yet.
+func (cr *compressedReader) buffered() int {
+	return len(cr.buf) - cr.pos
+}
+
+func (cr *compressedReader) readPacket() error {
+	if _, err := io.ReadFull(cr.r, cr.header[:]); err != nil {
+		// Let io.EOF through, the callers rely on it to detect
+		// a client disconnecting.
+		return err
+	}
+
+	compressedLength := int(uint32(cr.header[0]) | uint32(cr.header[1])<<8 | uint32(cr.header[2])<<16)
+	sequence := cr.header[3]
+	uncompressedLength := int(uint32(cr.header[4]) | uint32(cr.header[5])<<8 | uint32(cr.header[6])<<16)
+
+	if sequence != cr.c.compressedSequence {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid compressed packet sequence, expected %v got %v", cr.c.compressedSequence, sequence)
+	}
+	cr.c.compressedSequence++
+
+	if cap(cr.raw) < compressedLength {
+		cr.raw = make([]byte, compressedLength)
+	}
+	cr.raw = cr.raw[:compressedLength]
+	if _, err := io.ReadFull(cr.r, cr.raw); err != nil {
+		return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", compressedLength)
+	}
+	cr.pos = 0
+
+	if uncompressedLength == 0 {
+		// The payload was sent as is.
+		cr.buf = cr.raw
+		return nil
+	}
+
+	var err error
+	switch cr.algorithm {
+	case CompressionZlib:
+		cr.buf, err = cr.inflate(uncompressedLength)
+	case CompressionZstd:
+		var decoder *zstd.Decoder
+		if decoder, err = protocolZstdDecoder(); err == nil {
+			cr.out, err = decoder.DecodeAll(cr.raw, cr.out[:0])
+			cr.buf = cr.out
+		}
+	}
+	if err != nil {
+		cr.buf = nil
+		return vterrors.Wrapf(err, "cannot decompress %v packet", cr.algorithm)
+	}
+	if len(cr.buf) != uncompressedLength {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "decompressed packet has %v bytes, expected %v", len(cr.buf), uncompressedLength)
+	}
+	return nil
+}
+
+func (cr *compressedReader) inflate(uncompressedLength int) ([]byte, error) {
+	src := bytes.NewReader(cr.raw)
+	if cr.zlib == nil {
+		zr, err := zlib.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		cr.zlib = zr
+	} else if err := cr.zlib.(zlib.Resetter).Reset(src, nil); err != nil {
+		return nil, err
+	}
+
+	if cap(cr.out) < uncompressedLength {
+		cr.out = make([]byte, uncompressedLength)
+	}
+	cr.out = cr.out[:uncompressedLength]
+	if _, err := io.ReadFull(cr.zlib, cr.out); err != nil {
+		return nil, err
+	}
+	return cr.out, nil
+}
+
+// compressedWriter cuts what is written to it into compressed packets.
+// It is not buffered: every Write sends at least one compressed packet.
+type compressedWriter struct {
+	c         *Conn
+	w         io.Writer
+	algorithm CompressionAlgorithm
+	zstdLevel zstd.EncoderLevel
+
+	out  bytes.Buffer
+	zlib *zlib.Writer
+}
+
+// Write implements io.Writer.
+func (cw *compressedWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), MaxPacketSize)]
+		if err := cw.writePacket(chunk); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+func (cw *compressedWriter) writePacket(payload []byte) error {
+	cw.out.Reset()
+	cw.out.Write(make([]byte, compressedPacketHeaderSize))
+
+	uncompressedLength := 0
+	if len(payload) >= minCompressLength {
+		if err := cw.compress(payload); err != nil {
+			return vterrors.Wrapf(err, "cannot compress %v packet", cw.algorithm)
+		}
+		if cw.out.Len()-compressedPacketHeaderSize < len(payload) {
+			uncompressedLength = len(payload)
+		}
+	}
+	if uncompressedLength == 0 {
+		// Too small, or not compressible: send the payload as is.
+		cw.out.Truncate(compressedPacketHeaderSize)
+		cw.out.Write(payload)
+	}
+
+	data := cw.out.Bytes()
+	compressedLength := len(data) - compressedPacketHeaderSize
+	data[0] = byte(compressedLength)
+	data[1] = byte(compressedLength >> 8)
+	data[2] = byte(compressedLength >> 16)
+	data[3] = cw.c.compressedSequence
+	data[4] = byte(uncompressedLength)
+	data[5] = byte(uncompressedLength >> 8)
+	data[6] = byte(uncompressedLength >> 16)
+
+	if n, err := cw.w.Write(data); err != nil {
+		return vterrors.Wrapf(err, "Write(compressed packet) failed")
+	} else if n != len(data) {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(compressed packet) returned a short write: %v < %v", n, len(data))
+	}
+	cw.c.compressedSequence++
+
+	// Do not hold on to the buffer of large packets.
+	if cw.out.Cap() > maxRetainedCompressionBuffer {
+		cw.out = bytes.Buffer{}
+	}
+	return nil
+}
+
+func (cw *compressedWriter) compress(payload []byte) error {
+	switch cw.algorithm {
+	case CompressionZlib:
+		if cw.zlib == nil {
+			cw.zlib = zlib.NewWriter(&cw.out)
+		} else {
+			cw.zlib.Reset(&cw.out)
+		}
+		if _, err := cw.zlib.Write(payload); err != nil {
+			return err
+		}
+		return cw.zlib.Close()
+	case CompressionZstd:
+		encoder, err := getProtocolZstdEncoder(cw.zstdLevel)
+		if err != nil {
+			return err
+		}
+		cw.out.Write(encoder.EncodeAll(payload, cw.out.AvailableBuffer()))
+		putProtocolZstdEncoder(cw.zstdLevel, encoder)
+		return nil
+	}
+	return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown protocol compression algorithm %q", cw.algorithm)
+}
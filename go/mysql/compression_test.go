@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+)
+
+func TestParseCompressionAlgorithm(t *testing.T) {
+	for name, want := range map[string]CompressionAlgorithm{
+		"":      "",
+		"zlib":  CompressionZlib,
+		" ZSTD": CompressionZstd,
+	} {
+		got, err := ParseCompressionAlgorithm(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := ParseCompressionAlgorithm("lz4")
+	assert.ErrorContains(t, err, "unknown protocol compression algorithm")
+
+	algorithms, err := ParseCompressionAlgorithms([]string{"zstd", "", "zlib"})
+	require.NoError(t, err)
+	assert.Equal(t, []CompressionAlgorithm{CompressionZstd, CompressionZlib}, algorithms)
+}
+
+func TestZstdEncoderLevel(t *testing.T) {
+	assert.Equal(t, zstd.SpeedFastest, zstdEncoderLevel(-1))
+	assert.Equal(t, zstd.SpeedFastest, zstdEncoderLevel(0))
+	assert.Equal(t, zstd.SpeedDefault, zstdEncoderLevel(DefaultZstdCompressionLevel))
+	assert.Equal(t, zstd.SpeedBestCompression, zstdEncoderLevel(22))
+	assert.Equal(t, zstd.SpeedBestCompression, zstdEncoderLevel(255))
+
+	assert.Equal(t, DefaultZstdCompressionLevel, zstdCompressionLevel(&ConnParams{}))
+	assert.Equal(t, 22, zstdCompressionLevel(&ConnParams{ZstdCompressionLevel: 1000}))
+}
+
+func TestZstdCompressionConcurrent(t *testing.T) {
+	payload := []byte(compressionTestPayload(100_000))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			var buf bytes.Buffer
+			writer := &Conn{}
+			cw := &compressedWriter{c: writer, w: &buf, algorithm: CompressionZstd, zstdLevel: zstdEncoderLevel(i * 3)}
+			for range 10 {
+				_, err := cw.Write(payload)
+				assert.NoError(t, err)
+			}
+			assert.Less(t, buf.Len(), 10*len(payload))
+
+			reader := &Conn{}
+			cr := &compressedReader{c: reader, r: &buf, algorithm: CompressionZstd}
+			for range 10 {
+				got := make([]byte, len(payload))
+				_, err := io.ReadFull(cr, got)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, got)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestCompressedProtocol(t *testing.T) {
+	testcases := []struct {
+		name   string
+		server []CompressionAlgorithm
+		client CompressionAlgorithm
+		want   CompressionAlgorithm
+	}{{
+		name:   "zlib",
+		server: []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+		client: CompressionZlib,
+		want:   CompressionZlib,
+	}, {
+		name:   "zstd",
+		server: []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+		client: CompressionZstd,
+		want:   CompressionZstd,
+	}, {
+		name:   "algorithm not supported by the server",
+		server: []CompressionAlgorithm{CompressionZlib},
+		client: CompressionZstd,
+	}, {
+		name:   "compression disabled on the server",
+		client: CompressionZlib,
+	}, {
+		name:   "compression disabled on the client",
+		server: []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := utils.LeakCheckContext(t)
+			th := &testHandler{}
+
+			authServer := NewAuthServerStatic("", "", 0)
+			authServer.entries["user1"] = []*AuthServerStaticEntry{{
+				Password: "password1",
+				UserData: "userData1",
+			}}
+			defer authServer.close()
+
+			l, err := NewListenerWithConfig(ListenerConfig{
+				Protocol:              "tcp",
+				Address:               "127.0.0.1:",
+				AuthServer:            authServer,
+				Handler:               th,
+				ConnReadBufferSize:    connBufferSize,
+				CompressionAlgorithms: tc.server,
+			})
+			require.NoError(t, err)
+			host, port := getHostPort(t, l.Addr())
+			params := &ConnParams{
+				Host:        host,
+				Port:        port,
+				Uname:       "user1",
+				Pass:        "password1",
+				DbName:      "db1",
+				Compression: tc.client,
+			}
+			go l.Accept()
+			defer cleanupListener(ctx, l, params)
+
+			c, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer c.Close()
+
+			assert.Equal(t, tc.want, c.Compression())
+			assert.Equal(t, tc.want, th.LastConn().Compression())
+
+			// Small packets, sent as is.
+			result, err := c.ExecuteFetch("select rows", 10, true)
+			require.NoError(t, err)
+			utils.MustMatch(t, selectRowsResult, result)
+
+			// The schema was set during the handshake, and read back compressed.
+			result, err = c.ExecuteFetch("schema echo", 10, false)
+			require.NoError(t, err)
+			assert.Equal(t, "db1", result.Rows[0][0].ToString())
+
+			// Packets spanning several compressed packets both ways:
+			// the handler echoes the query back.
+			for _, size := range []int{1000, 100_000, MaxPacketSize + 1000} {
+				query := benchmarkQueryPrefix + compressionTestPayload(size)
+				result, err = c.ExecuteFetch(query, 10, false)
+				require.NoError(t, err)
+				require.Len(t, result.Rows, 1)
+				assert.Equal(t, query, result.Rows[0][0].ToString())
+			}
+
+			require.NoError(t, c.Ping())
+		})
+	}
+}
+
+// compressionTestPayload returns a mix of compressible and random bytes.
+func compressionTestPayload(size int) string {
+	var sb strings.Builder
+	for sb.Len() < size {
+		if rand.IntN(2) == 0 {
+			sb.WriteString(strings.Repeat("compressible ", 100))
+			continue
+		}
+		for range 1000 {
+			sb.WriteByte(byte('a' + rand.IntN(26)))
+		}
+	}
+	return sb.String()[:size]
+}
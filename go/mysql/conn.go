@@ -19,6 +19,7 @@ package mysql
 import (
 	"bufio"
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -96,6 +97,11 @@ type Conn struct {
 	// nil when the query returned a resultset, exposed via StreamOKResult.
 	streamOK *sqltypes.Result
 
+	// streamMoreResults is set when the last result a streaming query
+	// returned reported that more results follow, like the other result sets
+	// of the call of a stored procedure. They are read with StreamNextResult.
+	streamMoreResults bool
+
 	// salt is sent by the server during initial handshake to be used for authentication
 	salt []byte
 
@@ -173,6 +179,10 @@ type Conn struct {
 
 	pendingLongDataIngressBytes map[uint32]uint64
 
+	// queryAttributes are the query attributes sent along with the
+	// statement of the command being handled.
+	queryAttributes []QueryAttribute
+
 	// protects the bufferedWriter and bufferedReader
 	bufMu sync.Mutex
 
@@ -194,6 +204,11 @@ type Conn struct {
 	// - at accept time for the server.
 	ConnectionID uint32
 
+	// connParams are the parameters a client connection was opened with,
+	// for Kill to open its companion connection. They are nil for server
+	// connections.
+	connParams *ConnParams
+
 	// StatementID is the prepared statement ID.
 	StatementID uint32
 
@@ -219,6 +234,18 @@ type Conn struct {
 	// Packet encoding variables.
 	sequence uint8
 
+	// compressedReader and compressedWriter are set once the handshake
+	// negotiated protocol compression. compressedSequence is the sequence
+	// number of the compressed packets, reset with sequence at the start
+	// of every command.
+	compressedReader   *compressedReader
+	compressedWriter   *compressedWriter
+	compressedSequence uint8
+
+	// zstdCompressionLevel is the zstd level a client asked for in its
+	// handshake. It is only used on the server side.
+	zstdCompressionLevel int
+
 	// ExpectSemiSyncIndicator is applicable when the connection is used for replication (ComBinlogDump).
 	// When 'true', events are assumed to be padded with 2-byte semi-sync information
 	// See https://dev.mysql.com/doc/internals/en/semi-sync-binlog-event.html
@@ -234,6 +261,10 @@ type Conn struct {
 
 	multiQuery bool
 
+	// lastWarnings is the number of warnings the server reported for the
+	// last result read by the client.
+	lastWarnings uint16
+
 	// mu protects the fields below
 	mu sync.Mutex
 	// cancel keep the cancel function for the current executing query.
@@ -243,6 +274,11 @@ type Conn struct {
 	// the connection gets closed.
 	closing bool
 
+	// commandMu is held by the server while it handles a command of the
+	// connection, and until the handshake is over, so CloseIfIdle never
+	// writes to the client at the same time.
+	commandMu sync.Mutex
+
 	truncateErrLen int
 }
 
@@ -257,6 +293,20 @@ type PrepareData struct {
 	// SpanContext caches the extracted VT_SPAN_CONTEXT value from PrepareStmt.
 	// nil means not yet extracted; non-nil stores the cached value (empty string = no context found).
 	SpanContext *string
+
+	// cursor holds the rows of the last execution that opened a read-only
+	// cursor and that the client has not fetched yet. nil when the statement
+	// has no open cursor.
+	cursor *stmtCursor
+}
+
+// stmtCursor is a materialized read-only cursor opened by COM_STMT_EXECUTE.
+// The rows are handed out to the client in chunks by COM_STMT_FETCH.
+type stmtCursor struct {
+	fields []*querypb.Field
+	rows   [][]sqltypes.Value
+	// size is the size of the values of the rows, in bytes.
+	size int64
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -374,7 +424,7 @@ func (c *Conn) startWriterBuffering() {
 	defer c.bufMu.Unlock()
 
 	c.bufferedWriter = writersPool.Get().(*bufio.Writer)
-	c.bufferedWriter.Reset(c.conn)
+	c.bufferedWriter.Reset(c.netWriter())
 }
 
 // endWriterBuffering must be called to terminate startWriterBuffering.
@@ -431,7 +481,7 @@ func (c *Conn) WritePacketHeader(payloadLength int) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.netWriter()
 	}
 
 	var header [4]byte
@@ -467,7 +517,7 @@ func (c *Conn) WritePacketRaw(data []byte) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.netWriter()
 	}
 
 	if n, err := w.Write(data); err != nil {
@@ -506,6 +556,9 @@ func (c *Conn) startFlushTimer() {
 // Buffered returns the number of bytes that can be read from the buffered reader
 // without blocking on the underlying connection.
 func (c *Conn) Buffered() int {
+	if c.compressedReader != nil {
+		return c.compressedReader.buffered()
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader.Buffered()
 	}
@@ -513,8 +566,12 @@ func (c *Conn) Buffered() int {
 }
 
 // getReader returns reader for connection. It can be *bufio.Reader or net.Conn
-// depending on which buffer size was passed to newServerConn.
+// depending on which buffer size was passed to newServerConn, or the
+// decompressing reader once protocol compression is enabled.
 func (c *Conn) getReader() io.Reader {
+	if c.compressedReader != nil {
+		return c.compressedReader
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader
 	}
@@ -542,11 +599,13 @@ func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	}
 
 	sequence := c.header[3]
-	if sequence != c.sequence {
+	// With protocol compression, the compressed packets carry the sequence
+	// numbers that are checked, like MySQL does.
+	if sequence != c.sequence && c.compressedReader == nil {
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
 	}
 
-	c.sequence++
+	c.sequence = sequence + 1
 
 	return int(uint32(c.header[0]) | uint32(c.header[1])<<8 | uint32(c.header[2])<<16), nil
 }
@@ -781,11 +840,11 @@ func (c *Conn) ReadHeaderInto(buf []byte) (int, error) {
 	}
 
 	sequence := buf[3]
-	if sequence != c.sequence {
+	if sequence != c.sequence && c.compressedReader == nil {
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
 	}
 
-	c.sequence++
+	c.sequence = sequence + 1
 
 	return int(uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16), nil
 }
@@ -821,7 +880,7 @@ func (c *Conn) writePacket(data []byte) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.netWriter()
 	}
 
 	var header [PacketHeaderSize]byte
@@ -856,12 +915,10 @@ func (c *Conn) writePacket(data []byte) error {
 			if toBeSent == MaxPacketSize {
 				// The packet we just sent had exactly
 				// MaxPacketSize size, we need to
-				// sent a zero-size packet too.
-				header[0] = 0
-				header[1] = 0
-				header[2] = 0
-				header[3] = c.sequence
-				if n, err := w.Write(header[:]); err != nil {
+				// sent a zero-size packet too. It doesn't
+				// reuse header, so that header doesn't
+				// escape for every packet.
+				if n, err := w.Write([]byte{0, 0, 0, c.sequence}); err != nil {
 					return vterrors.Wrapf(err, "Write(empty header) failed")
 				} else if n != PacketHeaderSize {
 					return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(empty header) returned a short write: %v < 4", n)
@@ -922,7 +979,7 @@ func (c *Conn) recycleWritePacket() {
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) writeComQuit() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComQuit
@@ -1053,8 +1110,18 @@ func (c *Conn) writeErrorPacketFromErrorAndLog(err error) bool {
 // This method returns a generic error, not a SQLError.
 func (c *Conn) writeErrorPacket(errorCode sqlerror.ErrorCode, sqlState string, format string, args ...any) error {
 	errorMessage := fmt.Sprintf(format, args...)
-	length := 1 + 2 + 1 + 5 + len(errorMessage)
-	data, pos := c.startEphemeralPacketWithHeader(length)
+	data, pos := c.startEphemeralPacketWithHeader(errorPacketLength(errorMessage))
+	encodeErrorPacket(data, pos, errorCode, sqlState, errorMessage)
+	return c.writeEphemeralPacket()
+}
+
+// errorPacketLength returns the length of the payload of an error packet.
+func errorPacketLength(errorMessage string) int {
+	return 1 + 2 + 1 + 5 + len(errorMessage)
+}
+
+// encodeErrorPacket writes the payload of an error packet to data, at pos.
+func encodeErrorPacket(data []byte, pos int, errorCode sqlerror.ErrorCode, sqlState string, errorMessage string) {
 	pos = writeByte(data, pos, ErrPacket)
 	pos = writeUint16(data, pos, uint16(errorCode))
 	pos = writeByte(data, pos, '#')
@@ -1066,8 +1133,6 @@ func (c *Conn) writeErrorPacket(errorCode sqlerror.ErrorCode, sqlState string, f
 	}
 	pos = writeEOFString(data, pos, sqlState)
 	_ = writeEOFString(data, pos, errorMessage)
-
-	return c.writeEphemeralPacket()
 }
 
 // writeErrorPacketFromError writes an error packet, from a regular error.
@@ -1101,7 +1166,7 @@ func (c *Conn) writeEOFPacket(flags uint16, warnings uint16) error {
 // handleNextCommand is called in the server loop to process
 // incoming packets.
 func (c *Conn) handleNextCommand(handler Handler) bool {
-	c.sequence = 0
+	c.resetSequence()
 	c.ResetBytesRead()
 	data, err := c.readEphemeralPacket()
 	if err != nil {
@@ -1115,6 +1180,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		c.GetAndResetBytesRead()
 		return false
 	}
+	c.commandMu.Lock()
+	defer c.commandMu.Unlock()
 	// before continue to process the packet, check if the connection should be closed or not.
 	if c.IsMarkedForClose() {
 		c.GetAndResetBytesRead()
@@ -1122,6 +1189,7 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 	}
 
 	c.currentCommandIngressBytes = c.GetAndResetBytesRead()
+	c.queryAttributes = nil
 
 	switch data[0] {
 	case ComQuit:
@@ -1139,6 +1207,10 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		return c.handleComQuery(handler, data)
 	case ComPing:
 		return c.handleComPing()
+	case ComStatistics:
+		return c.handleComStatistics(handler)
+	case ComDebug:
+		return c.handleComDebug(handler)
 	case ComSetOption:
 		return c.handleComSetOption(data)
 	case ComPrepare:
@@ -1147,6 +1219,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		return c.handleComStmtExecute(handler, data)
 	case ComStmtSendLongData:
 		return c.handleComStmtSendLongData(data)
+	case ComStmtFetch:
+		return c.handleComStmtFetch(handler, data)
 	case ComStmtClose:
 		stmtID, ok := c.parseComStmtClose(data)
 		c.recycleReadPacket()
@@ -1281,6 +1355,7 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 		}
 	}
 
+	prepare.cursor = nil
 	if prepare.BindVars != nil {
 		for k := range prepare.BindVars {
 			prepare.BindVars[k] = nil
@@ -1345,7 +1420,7 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		c.StatusFlags &^= ServerQueryWasSlow
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, cursorType, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
 	if c.pendingLongDataIngressBytes != nil {
 		c.currentCommandIngressBytes += c.pendingLongDataIngressBytes[stmtID]
@@ -1364,13 +1439,21 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
+	prepare := c.PrepareData[stmtID]
+	// Executing the statement again closes the cursor it may still have open.
+	prepare.cursor = nil
+	if cursorType&CursorTypeReadOnly != 0 {
+		kontinue = c.execWithCursor(handler, prepare)
+		timings.Record(queryTimingKey, queryStart)
+		return kontinue
+	}
+
 	receivedResult := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
 	// okSentWithMoreResults is set if that OK carried SERVER_MORE_RESULTS_EXISTS,
 	// meaning an ERR is still a protocol-legal next result after it.
 	okSentWithMoreResults := false
-	prepare := c.PrepareData[stmtID]
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1438,6 +1521,136 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 	return true
 }
 
+// execWithCursor executes a prepared statement for which the client asked
+// for a read-only cursor. Like MySQL, the result set is materialized: only
+// its metadata is sent back, terminated by a packet carrying
+// SERVER_STATUS_CURSOR_EXISTS, and the rows are kept on the statement until
+// the client retrieves them with COM_STMT_FETCH. The execution fails if the
+// rows are larger than the MaxCursorSize of the listener. Statements that
+// do not return a result set get a regular OK packet and open no cursor.
+func (c *Conn) execWithCursor(handler Handler, prepare *PrepareData) bool {
+	var cursor *stmtCursor
+	var ok *PacketOK
+	maxSize := c.maxCursorSize()
+	err := handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
+		if ok != nil {
+			// Failsafe: Unreachable if server is well-behaved.
+			return io.EOF
+		}
+		if cursor == nil {
+			if len(qr.Fields) == 0 {
+				ok = &PacketOK{
+					affectedRows:     qr.RowsAffected,
+					lastInsertID:     qr.InsertID,
+					statusFlags:      c.StatusFlags,
+					sessionStateData: qr.SessionStateChanges,
+				}
+				return nil
+			}
+			cursor = &stmtCursor{fields: qr.Fields}
+		}
+		for _, row := range qr.Rows {
+			for _, value := range row {
+				cursor.size += int64(value.Len())
+			}
+		}
+		if maxSize > 0 && cursor.size > maxSize {
+			return sqlerror.NewSQLErrorf(sqlerror.ERRecordFileFull, sqlerror.SSUnknownSQLState, "the rows of the cursor exceed the maximum cursor size of %d bytes", maxSize)
+		}
+		cursor.rows = append(cursor.rows, qr.Rows...)
+		return nil
+	})
+
+	// Nothing was sent to the client yet, so any error can be reported as is.
+	if err == nil && cursor == nil && ok == nil {
+		// This is just a failsafe. Should never happen.
+		err = sqlerror.NewSQLErrorFromError(errors.New("unexpected: query ended without no results and no error"))
+	}
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	if ok != nil {
+		if err := c.writeOKPacket(ok); err != nil {
+			log.Error(fmt.Sprintf("Error writing result to %s: %v", c, err))
+			return false
+		}
+		return true
+	}
+
+	prepare.cursor = cursor
+	if err := c.writeColumnDefinitions(cursor.fields); err != nil {
+		log.Error(fmt.Sprintf("Error writing result to %s: %v", c, err))
+		return false
+	}
+	if err := c.writeEndResultWithFlags(c.StatusFlags|ServerStatusCursorExists, false, 0, 0, handler.WarningCount(c)); err != nil {
+		log.Error(fmt.Sprintf("Error writing result to %s: %v", c, err))
+		return false
+	}
+	return true
+}
+
+// maxCursorSize returns the maximum size of the rows of a read-only cursor,
+// or zero if there is no limit.
+func (c *Conn) maxCursorSize() int64 {
+	if c.listener == nil {
+		return 0
+	}
+	return c.listener.MaxCursorSize
+}
+
+// handleComStmtFetch sends the next rows of the cursor opened by the last
+// execution of a prepared statement. The rows are terminated by a packet
+// carrying SERVER_STATUS_CURSOR_EXISTS if more rows remain, or
+// SERVER_STATUS_LAST_ROW_SENT once the cursor is exhausted, which also
+// closes it.
+func (c *Conn) handleComStmtFetch(handler Handler, data []byte) (kontinue bool) {
+	c.startWriterBuffering()
+	defer func() {
+		if err := c.endWriterBuffering(); err != nil {
+			log.Error(fmt.Sprintf("conn %v: flush() failed: %v", c.ID(), err))
+			kontinue = false
+		}
+	}()
+
+	stmtID, numRows, ok := c.parseComStmtFetch(data)
+	c.recycleReadPacket()
+	if !ok {
+		log.Error(fmt.Sprintf("Got unhandled packet from client %v, returning error: %v", c.ConnectionID, data))
+		return c.writeErrorAndLog(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "error parsing statement fetch packet")
+	}
+	prepare, ok := c.PrepareData[stmtID]
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%d) given to mysqld_stmt_fetch", stmtID)
+	}
+	cursor := prepare.cursor
+	if cursor == nil {
+		return c.writeErrorAndLog(sqlerror.ERStmtHasNoOpenCursor, sqlerror.SSUnknownSQLState, "The statement (%d) has no open cursor.", stmtID)
+	}
+
+	n := min(int(numRows), len(cursor.rows))
+	for _, row := range cursor.rows[:n] {
+		if err := c.writeBinaryRow(cursor.fields, row); err != nil {
+			log.Error(fmt.Sprintf("Error writing result to %s: %v", c, err))
+			return false
+		}
+	}
+	// Drop the references to the sent rows so they can be garbage collected.
+	clear(cursor.rows[:n])
+	cursor.rows = cursor.rows[n:]
+
+	flags := c.StatusFlags | ServerStatusCursorExists
+	if len(cursor.rows) == 0 {
+		flags = c.StatusFlags | ServerStatusLastRowSent
+		prepare.cursor = nil
+	}
+	if err := c.writeEndResultWithFlags(flags, false, 0, 0, handler.WarningCount(c)); err != nil {
+		log.Error(fmt.Sprintf("Error writing result to %s: %v", c, err))
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 	c.startWriterBuffering()
 	defer func() {
@@ -1530,6 +1743,32 @@ func (c *Conn) handleComPing() bool {
 	return true
 }
 
+// handleComStatistics replies to a COM_STATISTICS request with a string
+// packet, which has no header.
+func (c *Conn) handleComStatistics(handler Handler) bool {
+	c.recycleReadPacket()
+	stats := handler.ComStatistics(c).String()
+	data, pos := c.startEphemeralPacketWithHeader(len(stats))
+	copy(data[pos:], stats)
+	if err := c.writeEphemeralPacket(); err != nil {
+		log.Error(fmt.Sprintf("Error writing ComStatistics result to %s: %v", c, err))
+		return false
+	}
+	return true
+}
+
+func (c *Conn) handleComDebug(handler Handler) bool {
+	c.recycleReadPacket()
+	if err := handler.ComDebug(c); err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Error(fmt.Sprintf("Error writing ComDebug result to %s: %v", c, err))
+		return false
+	}
+	return true
+}
+
 // handleComQueryMulti is a newer version of handleComQuery that uses
 // the StreamExecuteMulti and ExecuteMulti RPC calls to push the splitting of statements
 // down to Vtgate.
@@ -1543,8 +1782,11 @@ func (c *Conn) handleComQueryMulti(handler Handler, data []byte) (kontinue bool)
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
 
 	res := c.execQueryMulti(query, handler)
 	if res != execSuccess {
@@ -1710,11 +1952,13 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = handler.Env().Parser().SplitStatementToPieces(query)
 		if err != nil {
@@ -1808,6 +2052,15 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 			if err := c.writeFields(qr); err != nil {
 				return err
 			}
+		} else if qr.NewResult {
+			// Another result set of the query starts, like one of those of
+			// a stored procedure call, so the current one is ended first.
+			if err := c.writeEndResult(true, 0, 0, handler.WarningCount(c)); err != nil {
+				return err
+			}
+			if err := c.writeFields(qr); err != nil {
+				return err
+			}
 		}
 
 		return c.writeRows(qr)
@@ -2036,6 +2289,13 @@ func (c *Conn) GetTLSClientCerts() []*x509.Certificate {
 	return nil
 }
 
+// LastWarningCount returns the number of warnings the server reported for
+// the last result read from the connection. They can be read with
+// SHOW WARNINGS.
+func (c *Conn) LastWarningCount() uint16 {
+	return c.lastWarnings
+}
+
 // TLSEnabled returns true if this connection is using TLS.
 func (c *Conn) TLSEnabled() bool {
 	return c.Capabilities&CapabilityClientSSL > 0
@@ -2047,6 +2307,15 @@ func (c *Conn) IsUnixSocket() bool {
 	return ok
 }
 
+// cachingSha2PrivateKey returns the RSA key of the caching_sha2_password
+// full authentication of the listener, or nil if it has none.
+func (c *Conn) cachingSha2PrivateKey() *rsa.PrivateKey {
+	if c.listener == nil {
+		return nil
+	}
+	return c.listener.CachingSha2PasswordPrivateKey
+}
+
 // IsClientUnixSocket returns true if the client connection is over a Unix socket with the server.
 func (c *Conn) IsClientUnixSocket() bool {
 	_, ok := c.conn.(*net.UnixConn)
@@ -2109,3 +2378,65 @@ func (c *Conn) IsMarkedForClose() bool {
 func (c *Conn) IsShuttingDown() bool {
 	return c.listener.shutdown.Load()
 }
+
+// CloseIfIdle closes the connection if it is waiting for its next command,
+// after writing the error returned by reason to the client. The client
+// reads the error as the response to the next command it sends, like the
+// error MySQL sends to the idle connections it closes. It returns false,
+// and leaves the connection open, if a command is running or the handshake
+// is not over. reason is only called if the connection is idle, so it can
+// access the state of the connection.
+func (c *Conn) CloseIfIdle(reason func() error) bool {
+	if !c.commandMu.TryLock() {
+		return false
+	}
+	defer c.commandMu.Unlock()
+	if c.IsClosed() || c.IsMarkedForClose() {
+		return false
+	}
+	c.MarkForClose()
+	if err := c.writeUnsolicitedErrorPacket(reason()); err != nil {
+		log.Warn(fmt.Sprintf("Cannot write error packet to idle connection %s: %v", c, err))
+	}
+	c.Close()
+	return true
+}
+
+// writeUnsolicitedErrorPacket writes err to the client of an idle
+// connection, with the sequence numbers of the response to its next
+// command. The connection may be reading that command concurrently, so
+// the packet is written directly to the network, without touching the
+// sequence numbers and buffers shared with the reads.
+func (c *Conn) writeUnsolicitedErrorPacket(err error) error {
+	errorCode, sqlState, errorMessage := sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "unknown error: "+err.Error()
+	if se, ok := err.(*sqlerror.SQLError); ok {
+		errorCode, sqlState, errorMessage = se.Num, se.State, se.Message
+	}
+	length := errorPacketLength(errorMessage)
+	headerSize := PacketHeaderSize
+	if c.compressedWriter != nil {
+		headerSize += compressedPacketHeaderSize
+	}
+	data := make([]byte, headerSize+length)
+	pos := 0
+	if c.compressedWriter != nil {
+		// A compressed packet carrying the packet uncompressed, which
+		// leaves the uncompressed length at 0.
+		compressedLength := PacketHeaderSize + length
+		data[0] = byte(compressedLength)
+		data[1] = byte(compressedLength >> 8)
+		data[2] = byte(compressedLength >> 16)
+		data[3] = 1
+		pos = compressedPacketHeaderSize
+	}
+	data[pos] = byte(length)
+	data[pos+1] = byte(length >> 8)
+	data[pos+2] = byte(length >> 16)
+	data[pos+3] = 1
+	encodeErrorPacket(data, pos+PacketHeaderSize, errorCode, sqlState, errorMessage)
+
+	if _, err := c.conn.Write(data); err != nil {
+		return vterrors.Wrapf(err, "Write(packet) failed")
+	}
+	return nil
+}
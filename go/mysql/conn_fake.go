@@ -92,3 +92,9 @@ func GetTestConn() *Conn {
 func GetTestServerConn(listener *Listener) *Conn {
 	return newServerConn(testConn{}, listener)
 }
+
+// SetTestQueryAttributes sets the query attributes of the command c
+// executes. It is only meant to be used for testing.
+func SetTestQueryAttributes(c *Conn, attributes []QueryAttribute) {
+	c.queryAttributes = attributes
+}
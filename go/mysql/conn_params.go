@@ -67,6 +67,14 @@ type ConnParams struct {
 	TruncateErrLen int
 
 	MultiQuery bool
+
+	// Compression is the protocol compression to use, if the server
+	// supports it: zlib, zstd, or empty for none.
+	Compression CompressionAlgorithm
+
+	// ZstdCompressionLevel is the level to use with zstd compression,
+	// from 1 to 22. Zero means DefaultZstdCompressionLevel.
+	ZstdCompressionLevel int
 }
 
 // EnableSSL will set the right flag on the parameters.
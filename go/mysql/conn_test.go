@@ -1000,6 +1000,69 @@ func TestMultiStatement(t *testing.T) {
 	}
 }
 
+func TestExecuteFetchResults(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	sConn.Capabilities |= CapabilityClientMultiStatements
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &testRun{err: sqlerror.NewSQLError(sqlerror.ERUnknownError, sqlerror.SSUnknownSQLState, "cannot run query")}
+	serve := func() chan bool {
+		done := make(chan bool, 1)
+		go func() {
+			done <- sConn.handleNextCommand(handler)
+		}()
+		return done
+	}
+
+	// All the results are returned, with the status flags of each.
+	done := serve()
+	var results []*sqltypes.Result
+	for result, err := range cConn.ExecuteFetchResults("select 1;select 2", 100, true) {
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+	require.True(t, <-done)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].IsMoreResultsExists())
+	assert.True(t, results[0].Equal(selectRowsResult))
+	assert.False(t, results[1].IsMoreResultsExists())
+	assert.True(t, results[1].Equal(selectRowsResult))
+
+	// The iteration ends with the first error.
+	done = serve()
+	var errs []error
+	results = nil
+	for result, err := range cConn.ExecuteFetchResults("select 1;error;select 3", 100, true) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	require.True(t, <-done)
+	assert.Len(t, results, 1)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "cannot run query")
+
+	// The results that are not iterated over are drained.
+	done = serve()
+	for result, err := range cConn.ExecuteFetchResults("select 1;select 2", 100, true) {
+		require.NoError(t, err)
+		assert.True(t, result.IsMoreResultsExists())
+		break
+	}
+	require.True(t, <-done)
+	done = serve()
+	result, err := cConn.ExecuteFetch("select 3", 100, true)
+	require.NoError(t, err)
+	assert.True(t, result.Equal(selectRowsResult))
+	require.True(t, <-done)
+}
+
 type slowQueryMultiHandler struct {
 	testRun
 }
@@ -1364,6 +1427,30 @@ func TestExecQueryStreamSurfacesMidStreamError(t *testing.T) {
 	require.True(t, result.Equal(selectRowsResult))
 }
 
+// TestExecQueryNewResult verifies that execQuery returns each result set that
+// the handler starts with NewResult as a separate result, like those of a
+// stored procedure call.
+func TestExecQueryNewResult(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	require.NoError(t, cConn.WriteComQuery("result sets"))
+	require.True(t, sConn.handleNextCommand(&testRun{}))
+
+	result, more, _, err := cConn.ReadQueryResult(100, true)
+	require.NoError(t, err)
+	require.True(t, more)
+	assert.True(t, result.Equal(selectRowsResult))
+	result, more, _, err = cConn.ReadQueryResult(100, true)
+	require.NoError(t, err)
+	require.False(t, more)
+	assert.True(t, result.Equal(selectRowsResult))
+}
+
 // TestExecQueryMultiStreamSurfacesMidStreamError exercises go/mysql/conn.go execQueryMulti
 // (text protocol, multi-statement). Same contract: mid-stream error reaches the client
 // as the real SQL error and the connection survives.
@@ -1762,6 +1849,130 @@ func TestHandleComStmtExecuteErrorAfterMoreResultsOKSurfacesError(t *testing.T)
 	require.Equal(t, 2, eofCount, "follow-up binary result must terminate cleanly")
 }
 
+// TestHandleComStmtExecuteWithCursor opens a read-only cursor with
+// COM_STMT_EXECUTE and reads its rows back in chunks with COM_STMT_FETCH.
+func TestHandleComStmtExecuteWithCursor(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	const stmtID uint32 = 1
+	sConn.PrepareData[stmtID] = &PrepareData{
+		StatementID: stmtID,
+		PrepareStmt: "select rows",
+		ParamsCount: 0,
+		BindVars:    map[string]*querypb.BindVariable{},
+	}
+	handler := &testRun{}
+	require.NoError(t, cConn.conn.SetReadDeadline(time.Now().Add(30*time.Second)))
+
+	writeComStmtFetch := func(numRows uint32) {
+		cConn.sequence = 0
+		buf, pos := cConn.startEphemeralPacketWithHeader(9)
+		pos = writeByte(buf, pos, ComStmtFetch)
+		pos = writeUint32(buf, pos, stmtID)
+		_ = writeUint32(buf, pos, numRows)
+		require.NoError(t, cConn.writeEphemeralPacket())
+		require.True(t, sConn.handleNextCommand(handler))
+	}
+	readStatusFlags := func() uint16 {
+		data, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.True(t, cConn.isEOFPacket(data), "expected an EOF packet, got %v", data)
+		_, flags, err := parseEOFPacket(data)
+		require.NoError(t, err)
+		return flags
+	}
+	readRow := func() {
+		data, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.EqualValues(t, OKPacket, data[0], "expected a binary row, got %v", data)
+	}
+
+	cConn.sequence = 0
+	buf, pos := cConn.startEphemeralPacketWithHeader(10)
+	pos = writeByte(buf, pos, ComStmtExecute)
+	pos = writeUint32(buf, pos, stmtID)
+	pos = writeByte(buf, pos, CursorTypeReadOnly)
+	_ = writeUint32(buf, pos, 1) // iteration count
+	require.NoError(t, cConn.writeEphemeralPacket())
+	require.True(t, sConn.handleNextCommand(handler))
+
+	// Only the metadata is sent, terminated by a single EOF packet
+	// telling the client to fetch the rows.
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	columnCount, _, ok := readLenEncInt(data, 0)
+	require.True(t, ok)
+	require.EqualValues(t, len(selectRowsResult.Fields), columnCount)
+	for range columnCount {
+		_, err := cConn.ReadPacket()
+		require.NoError(t, err)
+	}
+	flags := readStatusFlags()
+	assert.NotZero(t, flags&ServerStatusCursorExists)
+	assert.NotNil(t, sConn.PrepareData[stmtID].cursor)
+
+	writeComStmtFetch(1)
+	readRow()
+	flags = readStatusFlags()
+	assert.NotZero(t, flags&ServerStatusCursorExists)
+	assert.Zero(t, flags&ServerStatusLastRowSent)
+
+	writeComStmtFetch(10)
+	readRow()
+	flags = readStatusFlags()
+	assert.Zero(t, flags&ServerStatusCursorExists)
+	assert.NotZero(t, flags&ServerStatusLastRowSent)
+	assert.Nil(t, sConn.PrepareData[stmtID].cursor)
+
+	// The exhausted cursor was closed.
+	writeComStmtFetch(1)
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, ErrPacket, data[0])
+	assert.ErrorContains(t, ParseErrorPacket(data), "has no open cursor")
+}
+
+func TestHandleComStmtExecuteWithCursorMaxSize(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	const stmtID uint32 = 1
+	sConn.PrepareData[stmtID] = &PrepareData{
+		StatementID: stmtID,
+		PrepareStmt: "select rows",
+		ParamsCount: 0,
+		BindVars:    map[string]*querypb.BindVariable{},
+	}
+	sConn.listener = &Listener{MaxCursorSize: 10}
+	require.NoError(t, cConn.conn.SetReadDeadline(time.Now().Add(30*time.Second)))
+
+	cConn.sequence = 0
+	buf, pos := cConn.startEphemeralPacketWithHeader(10)
+	pos = writeByte(buf, pos, ComStmtExecute)
+	pos = writeUint32(buf, pos, stmtID)
+	pos = writeByte(buf, pos, CursorTypeReadOnly)
+	_ = writeUint32(buf, pos, 1) // iteration count
+	require.NoError(t, cConn.writeEphemeralPacket())
+	require.True(t, sConn.handleNextCommand(&testRun{}))
+
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, ErrPacket, data[0])
+	err = ParseErrorPacket(data)
+	assert.ErrorContains(t, err, "maximum cursor size of 10 bytes")
+	assert.Equal(t, sqlerror.ERRecordFileFull, err.(*sqlerror.SQLError).Number())
+	assert.Nil(t, sConn.PrepareData[stmtID].cursor)
+}
+
 func TestInitDbAgainstWrongDbDoesNotDropConnection(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	sConn.Capabilities |= CapabilityClientMultiStatements
@@ -2198,6 +2409,13 @@ func (t testRun) ComQuery(c *Conn, query string, callback func(*sqltypes.Result)
 	if strings.Contains(query, "twice") {
 		callback(selectRowsResult)
 	}
+	if strings.Contains(query, "result sets") {
+		callback(selectRowsResult)
+		next := selectRowsResult.Copy()
+		next.NewResult = true
+		callback(next)
+		return nil
+	}
 	callback(selectRowsResult)
 	return nil
 }
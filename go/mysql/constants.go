@@ -41,6 +41,16 @@ type AuthMethodDescription string
 // the connection phase.
 type ConnectionAttributes map[string]string
 
+// Connection attributes that the MySQL clients usually send.
+const (
+	// ConnAttrProgramName is the name of the program of the client.
+	ConnAttrProgramName = "program_name"
+	// ConnAttrOS is the operating system of the client.
+	ConnAttrOS = "_os"
+	// ConnAttrClientName is the name of the client library.
+	ConnAttrClientName = "_client_name"
+)
+
 // Supported auth forms.
 const (
 	// MysqlNativePassword uses a salt and transmits a hash on the wire.
@@ -80,15 +90,17 @@ const (
 	// CLIENT_NO_SCHEMA 1 << 4
 	// Do not permit database.table.column. We do permit it.
 
-	// CLIENT_COMPRESS 1 << 5
-	// We do not support compression. CPU is usually our bottleneck.
+	// CapabilityClientCompress is CLIENT_COMPRESS.
+	// Use the zlib compressed protocol after the handshake.
+	CapabilityClientCompress = 1 << 5
 
 	// CLIENT_ODBC 1 << 6
 	// No special behavior since 3.22.
 
-	// CLIENT_LOCAL_FILES 1 << 7
+	// CapabilityClientLocalFiles is CLIENT_LOCAL_FILES.
 	// Client can use LOCAL INFILE request of LOAD DATA|XML.
-	// We do not set it.
+	// We only set it when the listener allows LOAD DATA LOCAL INFILE.
+	CapabilityClientLocalFiles = 1 << 7
 
 	// CLIENT_IGNORE_SPACE 1 << 8
 	// Parser can ignore spaces before '('.
@@ -152,6 +164,15 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CapabilityClientZstdCompressionAlgorithm is CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	// Use the zstd compressed protocol after the handshake. The client
+	// sends its compression level at the end of Protocol::HandshakeResponse41.
+	CapabilityClientZstdCompressionAlgorithm = 1 << 26
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES.
+	// COM_QUERY and COM_STMT_EXECUTE can carry query attributes.
+	CapabilityClientQueryAttributes = 1 << 27
 )
 
 // Status flags. They are returned by the server in a few cases.
@@ -183,6 +204,20 @@ const (
 	ServerSessionStateChanged uint16 = 0x4000
 )
 
+// Cursor type flags of COM_STMT_EXECUTE.
+// Originally found in include/mysql/mysql_com.h
+const (
+	// CursorTypeNoCursor executes the statement without a cursor.
+	CursorTypeNoCursor byte = 0x00
+	// CursorTypeReadOnly opens a read-only cursor whose rows are then
+	// retrieved with COM_STMT_FETCH.
+	CursorTypeReadOnly byte = 0x01
+	// ParameterCountAvailable tells that COM_STMT_EXECUTE carries the
+	// parameter count even if the statement has no parameters, because it
+	// carries query attributes.
+	ParameterCountAvailable byte = 0x08
+)
+
 // State Change Information
 const (
 	// one or more system variables changed.
@@ -210,6 +245,12 @@ const (
 	// ComFieldList is COM_Field_List.
 	ComFieldList = 0x04
 
+	// ComStatistics is COM_STATISTICS.
+	ComStatistics = 0x09
+
+	// ComDebug is COM_DEBUG.
+	ComDebug = 0x0d
+
 	// ComPing is COM_PING.
 	ComPing = 0x0e
 
@@ -268,6 +309,11 @@ const (
 	// AuthMoreDataPacket is sent when server requires more data to authenticate
 	AuthMoreDataPacket = 0x01
 
+	// CachingSha2RequestPublicKey is sent by the client to request the RSA
+	// public key of the server, to encrypt the password it sends for the
+	// full authentication of caching_sha2_password over an insecure connection.
+	CachingSha2RequestPublicKey = 0x02
+
 	// CachingSha2FastAuth is sent before OKPacket when server authenticates using cache
 	CachingSha2FastAuth = 0x03
 
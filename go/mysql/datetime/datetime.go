@@ -47,6 +47,8 @@ type DateTime struct {
 const (
 	DefaultPrecision = 6
 	MaxHours         = 838
+
+	maxTimeDuration = MaxHours*time.Hour + 59*time.Minute + 59*time.Second
 )
 
 func (t Time) AppendFormat(b []byte, prec uint8) []byte {
@@ -447,10 +449,33 @@ func (t Time) ToStdTime(now time.Time) (out time.Time) {
 	return t.toStdTime(year, month, day, now.Location())
 }
 
-func (t Time) AddInterval(itv *Interval, stradd bool) (Time, uint8, bool) {
-	dt := DateTime{Time: t}
-	ok := dt.addInterval(itv)
-	return dt.Time, itv.precision(stradd), ok
+// AddInterval adds an interval without date parts to the time. Like MySQL,
+// the result can be negative, and it fails if the result is not within the
+// range of TIME, i.e. -838:59:59 to 838:59:59.
+func (t Time) AddInterval(itv *Interval, prec uint8, stradd bool) (Time, uint8, bool) {
+	if !itv.inRange() {
+		return Time{}, 0, false
+	}
+
+	dur := t.ToDuration() + itv.toDuration()
+	neg := dur < 0
+	if neg {
+		dur = -dur
+	}
+	if dur > maxTimeDuration {
+		return Time{}, 0, false
+	}
+
+	r := Time{
+		hour:       uint16(dur / time.Hour),
+		minute:     uint8((dur % time.Hour) / time.Minute),
+		second:     uint8((dur % time.Minute) / time.Second),
+		nanosecond: uint32(dur % time.Second),
+	}
+	if neg {
+		r.hour |= negMask
+	}
+	return r, max(prec, itv.precision(stradd)), true
 }
 
 func (t Time) toDuration() time.Duration {
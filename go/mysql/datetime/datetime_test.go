@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/decimal"
 	"vitess.io/vitess/go/vt/vthash"
@@ -808,6 +809,41 @@ func TestAddInterval(t *testing.T) {
 	}
 }
 
+func TestTimeAddInterval(t *testing.T) {
+	testCases := []struct {
+		time     string
+		interval *Interval
+		want     string
+		prec     uint8
+		ok       bool
+	}{
+		{time: "01:00:00", interval: ParseIntervalInt64(2, IntervalHour, false), want: "03:00:00", ok: true},
+		{time: "01:00:00", interval: ParseIntervalInt64(2, IntervalHour, true), want: "-01:00:00", ok: true},
+		{time: "-01:00:00", interval: ParseIntervalInt64(30, IntervalMinute, false), want: "-00:30:00", ok: true},
+		{time: "-00:00:01", interval: ParseIntervalInt64(1, IntervalSecond, false), want: "00:00:00", ok: true},
+		{time: "00:00:00.5", interval: ParseIntervalInt64(1, IntervalSecond, true), want: "-00:00:00.5", prec: 1, ok: true},
+		{time: "837:59:59", interval: ParseInterval("1:00:00", IntervalHourSecond, false), want: "838:59:59", ok: true},
+		{time: "-838:59:59", interval: ParseIntervalInt64(1, IntervalSecond, false), want: "-838:59:58", ok: true},
+		{time: "838:59:59", interval: ParseIntervalInt64(1, IntervalSecond, false), ok: false},
+		{time: "-838:59:59", interval: ParseIntervalInt64(1, IntervalSecond, true), ok: false},
+		{time: "00:00:00", interval: ParseIntervalInt64(839, IntervalHour, true), ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.time, func(t *testing.T) {
+			in, l, state := ParseTime(tc.time, -1)
+			require.Equal(t, TimeOK, state)
+
+			got, prec, ok := in.AddInterval(tc.interval, uint8(l), false)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				assert.Equal(t, tc.want, string(got.Format(prec)))
+				assert.Equal(t, tc.prec, prec)
+			}
+		})
+	}
+}
+
 func TestWeightString(t *testing.T) {
 	testCases := []struct {
 		dt   DateTime
@@ -48,6 +48,9 @@ var DefaultMySQLStrftime = map[byte]Spec{
 	'x': fmtYearForWeek3{},
 	'Y': fmtYearLong{},
 	'y': fmtYearShort{},
+	'.': fmtSkip('.'),
+	'@': fmtSkip('@'),
+	'#': fmtSkip('#'),
 }
 
 var Date_YYYY_MM_DD = &Strftime{
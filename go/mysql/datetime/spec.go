@@ -18,6 +18,7 @@ limitations under the License.
 package datetime
 
 import (
+	"strings"
 	"time"
 )
 
@@ -69,7 +70,11 @@ func (fmtWeekdayNameShort) format(dst []byte, t DateTime, prec uint8) []byte {
 	return append(dst, t.Date.Weekday().String()[:3]...)
 }
 
-func (fmtWeekdayNameShort) parse(_ *timeparts, b string) (out string, ok bool) {
+func (fmtWeekdayNameShort) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.weekday, out, ok = lookupWord(shortDayNamesMonday, b)
+		return
+	}
 	_, out, ok = lookup(shortDayNames, b)
 	return
 }
@@ -81,6 +86,10 @@ func (fmtMonthNameShort) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (fmtMonthNameShort) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.month, out, ok = lookupWord(shortMonthNames, b)
+		return
+	}
 	tp.month, out, ok = lookup(shortMonthNames, b)
 	return
 }
@@ -97,6 +106,10 @@ func (s fmtMonth) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (s fmtMonth) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.month, out, ok = getnumUpTo(b, 2)
+		return
+	}
 	tp.month, out, ok = getnum(b, s.zero)
 	if ok && (tp.month < 0 || tp.month > 12) {
 		ok = false
@@ -128,9 +141,12 @@ func (fmtMonthDaySuffix) format(dst []byte, t DateTime, prec uint8) []byte {
 	}
 }
 
-func (d fmtMonthDaySuffix) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtMonthDaySuffix) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.day, out, ok = getnumUpTo(b, 2); ok {
+		// skip the suffix of the day: st, nd, rd or th
+		out = out[min(len(out), 2):]
+	}
+	return
 }
 
 type fmtDay struct {
@@ -145,6 +161,10 @@ func (s fmtDay) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (s fmtDay) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.day, out, ok = getnumUpTo(b, 2)
+		return
+	}
 	tp.day, out, ok = getnum(b, s.zero)
 	return
 }
@@ -169,9 +189,15 @@ func (fmtMicroseconds) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendNsec(dst, t.Time.Nanosecond(), 6)
 }
 
-func (f fmtMicroseconds) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtMicroseconds) parse(tp *timeparts, b string) (out string, ok bool) {
+	var usec int
+	if usec, out, ok = getnumUpTo(b, 6); ok {
+		for range 6 - (len(b) - len(out)) {
+			usec *= 10
+		}
+		tp.nsec = usec * 1000
+	}
+	return
 }
 
 type fmtHour24 struct {
@@ -186,6 +212,10 @@ func (s fmtHour24) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (s fmtHour24) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.hour, out, ok = getnumUpTo(b, 2)
+		return
+	}
 	tp.hour, out, ok = getnum(b, s.zero)
 	if tp.hour < 0 || 24 <= tp.hour {
 		ok = false
@@ -210,6 +240,11 @@ func (f fmtHour12) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (f fmtHour12) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.hour, out, ok = getnumUpTo(b, 2)
+		tp.usaTime = true
+		return
+	}
 	tp.hour, out, ok = getnum(b, f.zero)
 	if tp.hour < 0 || 12 < tp.hour {
 		ok = false
@@ -237,6 +272,10 @@ func (s fmtMin) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (s fmtMin) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.min, out, ok = getnumUpTo(b, 2)
+		return
+	}
 	tp.min, out, ok = getnum(b, s.zero)
 	if tp.min < 0 || 60 <= tp.min {
 		ok = false
@@ -254,9 +293,9 @@ func (fmtZeroYearDay) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, t.Date.Yearday(), 3)
 }
 
-func (j fmtZeroYearDay) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtZeroYearDay) parse(tp *timeparts, b string) (out string, ok bool) {
+	tp.yday, out, ok = getnumUpTo(b, 3)
+	return
 }
 
 type fmtMonthName struct{}
@@ -265,9 +304,9 @@ func (fmtMonthName) format(dst []byte, t DateTime, prec uint8) []byte {
 	return append(dst, time.Month(t.Date.Month()).String()...)
 }
 
-func (m fmtMonthName) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtMonthName) parse(tp *timeparts, b string) (out string, ok bool) {
+	tp.month, out, ok = lookupWord(longMonthNames, b)
+	return
 }
 
 type fmtAMorPM struct{}
@@ -279,9 +318,20 @@ func (fmtAMorPM) format(dst []byte, t DateTime, prec uint8) []byte {
 	return append(dst, "PM"...)
 }
 
-func (p fmtAMorPM) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtAMorPM) parse(tp *timeparts, b string) (string, bool) {
+	// STR_TO_DATE only reads AM or PM after a 12-hour specifier
+	if len(b) < 2 || (tp.strToDate && !tp.usaTime) {
+		return "", false
+	}
+	switch {
+	case match(b[:2], "PM"):
+		tp.pmset, tp.amset = true, false
+	case match(b[:2], "AM"):
+		tp.pmset, tp.amset = false, true
+	default:
+		return "", false
+	}
+	return b[2:], true
 }
 
 type fmtFullTime12 struct{}
@@ -297,9 +347,10 @@ func (fmtFullTime12) format(dst []byte, t DateTime, prec uint8) []byte {
 	return dst
 }
 
-func (r fmtFullTime12) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+var fullTime12Specs = []Spec{fmtHour12{true}, &fmtVerbatim{s: ":"}, fmtMin{true}, &fmtVerbatim{s: ":"}, fmtSecond{true, false}, &fmtVerbatim{s: " "}, fmtAMorPM{}}
+
+func (fmtFullTime12) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parse(fullTime12Specs, b)
 }
 
 type fmtSecond struct {
@@ -321,6 +372,10 @@ func (s fmtSecond) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (s fmtSecond) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		tp.sec, out, ok = getnumUpTo(b, 2)
+		return
+	}
 	tp.sec, out, ok = getnum(b, s.zero)
 	if tp.sec < 0 || 60 <= tp.sec {
 		return "", false
@@ -352,9 +407,10 @@ func (fmtFullTime24) format(dst []byte, t DateTime, prec uint8) []byte {
 	return dst
 }
 
-func (t2 fmtFullTime24) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+var fullTime24Specs = []Spec{fmtHour24{true}, &fmtVerbatim{s: ":"}, fmtMin{true}, &fmtVerbatim{s: ":"}, fmtSecond{true, false}}
+
+func (fmtFullTime24) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parse(fullTime24Specs, b)
 }
 
 type fmtWeek0 struct{}
@@ -364,9 +420,8 @@ func (fmtWeek0) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, week, 2)
 }
 
-func (u fmtWeek0) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeek0) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parseWeek(b, true, false)
 }
 
 type fmtWeek1 struct{}
@@ -376,9 +431,8 @@ func (fmtWeek1) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, week, 2)
 }
 
-func (u fmtWeek1) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeek1) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parseWeek(b, false, false)
 }
 
 type fmtWeek2 struct{}
@@ -388,9 +442,8 @@ func (fmtWeek2) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, week, 2)
 }
 
-func (v fmtWeek2) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeek2) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parseWeek(b, true, true)
 }
 
 type fmtWeek3 struct{}
@@ -400,9 +453,8 @@ func (fmtWeek3) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, week, 2)
 }
 
-func (v fmtWeek3) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeek3) parse(tp *timeparts, b string) (string, bool) {
+	return tp.parseWeek(b, false, true)
 }
 
 type fmtWeekdayName struct{}
@@ -411,9 +463,9 @@ func (fmtWeekdayName) format(dst []byte, t DateTime, prec uint8) []byte {
 	return append(dst, t.Date.Weekday().String()...)
 }
 
-func (w fmtWeekdayName) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeekdayName) parse(tp *timeparts, b string) (out string, ok bool) {
+	tp.weekday, out, ok = lookupWord(longDayNamesMonday, b)
+	return
 }
 
 type fmtWeekday struct{}
@@ -422,9 +474,16 @@ func (fmtWeekday) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, int(t.Date.Weekday()), 0)
 }
 
-func (w fmtWeekday) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtWeekday) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.weekday, out, ok = getnumUpTo(b, 1); ok {
+		if tp.weekday >= 7 {
+			return "", false
+		}
+		if tp.weekday == 0 {
+			tp.weekday = 7
+		}
+	}
+	return
 }
 
 type fmtYearForWeek2 struct{}
@@ -434,9 +493,10 @@ func (fmtYearForWeek2) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, year, 4)
 }
 
-func (x fmtYearForWeek2) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtYearForWeek2) parse(tp *timeparts, b string) (out string, ok bool) {
+	tp.weekYear, out, ok = getnumUpTo(b, 4)
+	tp.weekYearSundayFirst = true
+	return
 }
 
 type fmtYearForWeek3 struct{}
@@ -446,9 +506,10 @@ func (fmtYearForWeek3) format(dst []byte, t DateTime, prec uint8) []byte {
 	return appendInt(dst, year, 4)
 }
 
-func (x fmtYearForWeek3) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (fmtYearForWeek3) parse(tp *timeparts, b string) (out string, ok bool) {
+	tp.weekYear, out, ok = getnumUpTo(b, 4)
+	tp.weekYearSundayFirst = false
+	return
 }
 
 type fmtYearLong struct{}
@@ -458,6 +519,12 @@ func (fmtYearLong) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (y fmtYearLong) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		if tp.year, out, ok = getnumUpTo(b, 4); ok && len(b)-len(out) <= 2 {
+			tp.year = year2000(tp.year)
+		}
+		return
+	}
 	if len(b) >= 4 {
 		b, out = b[0:4], b[4:]
 		tp.year, ok = atoi(b)
@@ -477,6 +544,12 @@ func (f fmtYearShort) format(dst []byte, t DateTime, prec uint8) []byte {
 }
 
 func (fmtYearShort) parse(tp *timeparts, b string) (out string, ok bool) {
+	if tp.strToDate {
+		if tp.year, out, ok = getnumUpTo(b, 2); ok {
+			tp.year = year2000(tp.year)
+		}
+		return
+	}
 	if len(b) >= 2 {
 		b, out = b[0:2], b[2:]
 		if tp.year, ok = atoi(b); ok {
@@ -502,9 +575,64 @@ type fmtVerbatim struct {
 	s string
 }
 
-func (v *fmtVerbatim) parse(t *timeparts, bytes string) (string, bool) {
-	// TODO implement me
-	panic("implement me")
+func (v *fmtVerbatim) parse(tp *timeparts, b string) (string, bool) {
+	if !tp.strToDate {
+		if len(b) < len(v.s) || b[:len(v.s)] != v.s {
+			return "", false
+		}
+		return b[len(v.s):], true
+	}
+	// STR_TO_DATE skips whitespace before every character, and a space
+	// of the format matches any amount of whitespace.
+	for i := 0; i < len(v.s); i++ {
+		b = strings.TrimLeft(b, " \t\n\v\f\r")
+		if len(b) == 0 {
+			return b, true
+		}
+		if isSpace(v.s[i]) {
+			continue
+		}
+		if b[0] != v.s[i] {
+			return "", false
+		}
+		b = b[1:]
+	}
+	return b, true
+}
+
+// fmtUnknown is a specifier without a meaning of its own: it formats as its
+// character, but can't be parsed.
+type fmtUnknown byte
+
+func (u fmtUnknown) format(dst []byte, t DateTime, prec uint8) []byte {
+	return append(dst, byte(u))
+}
+
+func (fmtUnknown) parse(_ *timeparts, _ string) (string, bool) {
+	return "", false
+}
+
+// fmtSkip is one of the %., %@ and %# specifiers, which format as their
+// character and skip the punctuation, letters and digits respectively when
+// parsing.
+type fmtSkip byte
+
+func (s fmtSkip) format(dst []byte, t DateTime, prec uint8) []byte {
+	return append(dst, byte(s))
+}
+
+func (s fmtSkip) parse(_ *timeparts, b string) (string, bool) {
+	skip := isSeparator
+	switch s {
+	case '@':
+		skip = isAlpha
+	case '#':
+		skip = func(b byte) bool { return '0' <= b && b <= '9' }
+	}
+	for len(b) > 0 && skip(b[0]) {
+		b = b[1:]
+	}
+	return b, true
 }
 
 func (v *fmtVerbatim) format(dst []byte, t DateTime, prec uint8) []byte {
@@ -48,7 +48,7 @@ func compile(ds map[byte]Spec, p string, exec func(Spec)) error {
 			}
 			exec(spec)
 		} else {
-			exec(&fmtVerbatim{s: p[1:2]})
+			exec(fmtUnknown(p[1]))
 		}
 		p = p[2:]
 	}
@@ -128,12 +128,9 @@ func (f *Strftime) parse(s string, prec int) (DateTime, string, int, bool) {
 	tp.day = -1
 	tp.yday = -1
 
-	var ok bool
-	for _, w := range f.compiled {
-		s, ok = w.parse(&tp, s)
-		if !ok {
-			return DateTime{}, "", 0, false
-		}
+	s, ok := tp.parse(f.compiled, s)
+	if !ok {
+		return DateTime{}, "", 0, false
 	}
 	t, l, ok := tp.toDateTime(prec)
 	return t, s, l, ok
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"time"
+)
+
+// maxDayNumber is the day number of 9999-12-31.
+const maxDayNumber = 3652424
+
+var longMonthNames = []string{
+	"January",
+	"February",
+	"March",
+	"April",
+	"May",
+	"June",
+	"July",
+	"August",
+	"September",
+	"October",
+	"November",
+	"December",
+}
+
+// The day names used when parsing start on Monday, like the weekdays
+// of STR_TO_DATE.
+var (
+	longDayNamesMonday  = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	shortDayNamesMonday = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+)
+
+// StrToDate parses s with the MySQL format string format, like STR_TO_DATE.
+// The parts of the date and time that format does not specify are zero, so
+// the returned date can be zero or have zero parts. Characters of s left
+// over once format is exhausted are ignored. It returns false if s does not
+// match format, or if the date or time it describes is not valid.
+func StrToDate(format, s string) (DateTime, bool) {
+	f, err := New(format)
+	if err != nil {
+		return DateTime{}, false
+	}
+	return f.StrToDate(s)
+}
+
+// StrToDate parses s with the pattern like STR_TO_DATE. See StrToDate.
+func (f *Strftime) StrToDate(s string) (DateTime, bool) {
+	tp := timeparts{strToDate: true, week: -1, weekYear: -1}
+	if _, ok := tp.parse(f.compiled, s); !ok {
+		return DateTime{}, false
+	}
+	return tp.strToDateTime()
+}
+
+// FormatParts reports whether the MySQL format string format specifies
+// date parts, time parts and fractional seconds. A format that specifies
+// fractional seconds always specifies time parts too.
+func FormatParts(format string) (hasDate, hasTime, hasFrac bool) {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'a', 'b', 'c', 'D', 'd', 'e', 'j', 'M', 'm', 'U', 'u', 'V', 'v', 'W', 'w', 'X', 'x', 'Y', 'y':
+			hasDate = true
+		case 'H', 'h', 'I', 'i', 'k', 'l', 'p', 'r', 'S', 's', 'T':
+			hasTime = true
+		case 'f':
+			hasTime, hasFrac = true, true
+		}
+	}
+	return
+}
+
+// parseWeek reads the week of the year of the %U, %u, %V and %v specifiers.
+func (tp *timeparts) parseWeek(b string, sundayFirst, strict bool) (out string, ok bool) {
+	tp.sundayFirst, tp.strictWeek = sundayFirst, strict
+	if tp.week, out, ok = getnumUpTo(b, 2); ok && ((strict && tp.week == 0) || tp.week > 53) {
+		return "", false
+	}
+	return
+}
+
+// strToDateTime returns the date and time of the parts read by StrToDate.
+func (tp *timeparts) strToDateTime() (DateTime, bool) {
+	if tp.usaTime {
+		if tp.hour < 1 || tp.hour > 12 {
+			return DateTime{}, false
+		}
+		tp.hour %= 12
+		if tp.pmset {
+			tp.hour += 12
+		}
+	}
+
+	if tp.yday > 0 {
+		daynr := MysqlDayNumber(tp.year, 1, 1) + tp.yday - 1
+		if daynr <= 0 || daynr > maxDayNumber {
+			return DateTime{}, false
+		}
+		tp.setDayNumber(daynr)
+	}
+
+	if tp.week >= 0 && tp.weekday > 0 {
+		// %V and %v need the year of %X and %x respectively, and %U and %u
+		// the one of %Y
+		if tp.strictWeek && (tp.weekYear < 0 || tp.weekYearSundayFirst != tp.sundayFirst) {
+			return DateTime{}, false
+		}
+		if !tp.strictWeek && tp.weekYear >= 0 {
+			return DateTime{}, false
+		}
+
+		year := tp.year
+		if tp.strictWeek {
+			year = tp.weekYear
+		}
+		daynr := MysqlDayNumber(year, 1, 1)
+		first := mysqlWeekday(daynr, tp.sundayFirst)
+		if tp.sundayFirst {
+			if first != 0 {
+				daynr += 7
+			}
+			daynr += -first + (tp.week-1)*7 + tp.weekday%7
+		} else {
+			if first > 3 {
+				daynr += 7
+			}
+			daynr += -first + (tp.week-1)*7 + tp.weekday - 1
+		}
+		if daynr <= 0 || daynr > maxDayNumber {
+			return DateTime{}, false
+		}
+		tp.setDayNumber(daynr)
+	}
+
+	if tp.month > 12 || tp.day > 31 || tp.hour > 23 || tp.min > 59 || tp.sec > 59 {
+		return DateTime{}, false
+	}
+	if tp.month > 0 && tp.day > daysIn(time.Month(tp.month), tp.year) {
+		return DateTime{}, false
+	}
+
+	return DateTime{
+		Date: Date{year: uint16(tp.year), month: uint8(tp.month), day: uint8(tp.day)},
+		Time: Time{hour: uint16(tp.hour), minute: uint8(tp.min), second: uint8(tp.sec), nanosecond: uint32(tp.nsec)},
+	}, true
+}
+
+func (tp *timeparts) setDayNumber(daynr int) {
+	year, month, day := mysqlDateFromDayNumber(daynr)
+	tp.year, tp.month, tp.day = int(year), int(month), int(day)
+}
+
+// mysqlWeekday returns the day of the week of the day number daynr, from 0
+// (Monday, or Sunday if sundayFirst is set) to 6.
+func mysqlWeekday(daynr int, sundayFirst bool) int {
+	if sundayFirst {
+		daynr++
+	}
+	return (daynr + 5) % 7
+}
+
+// year2000 returns the year of a year with two digits.
+func year2000(year int) int {
+	if year < 70 {
+		return year + 2000
+	}
+	return year + 1900
+}
+
+// getnumUpTo reads a number of at least one and up to n digits.
+func getnumUpTo(s string, n int) (int, string, bool) {
+	var res, i int
+	for ; i < n && isDigit(s, i); i++ {
+		res = res*10 + int(s[i]-'0')
+	}
+	return res, s[i:], i > 0
+}
+
+// lookupWord reads a word and returns its 1-based position in tab. The word
+// can be a prefix of an entry of tab, as long as it is the prefix of only
+// one entry.
+func lookupWord(tab []string, s string) (int, string, bool) {
+	n := 0
+	for n < len(s) && isAlpha(s[n]) {
+		n++
+	}
+	if n == 0 {
+		return 0, s, false
+	}
+
+	word, found := s[:n], 0
+	for i, v := range tab {
+		if len(v) < n || !match(v[:n], word) {
+			continue
+		}
+		if len(v) == n {
+			return i + 1, s[n:], true
+		}
+		if found > 0 {
+			return 0, s, false
+		}
+		found = i + 1
+	}
+	return found, s[n:], found > 0
+}
+
+func isAlpha(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrToDate(t *testing.T) {
+	testCases := []struct {
+		format string
+		in     string
+		want   string
+		ok     bool
+	}{
+		{"%Y-%m-%d", "2024-03-15", "2024-03-15 00:00:00.000000", true},
+		{"%Y-%m-%d", "2024-3-5", "2024-03-05 00:00:00.000000", true},
+		{"%Y-%m-%d", " 2024 - 03 - 15 trailing", "2024-03-15 00:00:00.000000", true},
+		{"%m /%d /%Y", "04/30 /2004", "2004-04-30 00:00:00.000000", true},
+		{"%Y.%m.%d %T", "2004.12.12 22.30.61", "", false},
+		{"%Y.%m.%d %T", "2004.12.12 22:30:59", "2004-12-12 22:30:59.000000", true},
+		{"%Y", "24", "2024-00-00 00:00:00.000000", true},
+		{"%Y", "99", "1999-00-00 00:00:00.000000", true},
+		{"%y", "69", "2069-00-00 00:00:00.000000", true},
+		{"%y", "70", "1970-00-00 00:00:00.000000", true},
+		{"%m", "9", "0000-09-00 00:00:00.000000", true},
+		{"%Y-%m-%d", "2024", "2024-00-00 00:00:00.000000", true},
+		{"%Y-%m-%d", "2023-02-29", "", false},
+		{"%Y-%m-%d", "2024-13-01", "", false},
+		{"%Y-%m-%d", "2024/03/15", "", false},
+		{"%d %M %Y", "15 march 2024", "2024-03-15 00:00:00.000000", true},
+		{"%d %M %Y", "15 Mar 2024", "2024-03-15 00:00:00.000000", true},
+		{"%d %M %Y", "15 Ju 2024", "", false},
+		{"%d %b %Y", "15 MAR 2024", "2024-03-15 00:00:00.000000", true},
+		{"%d %b %Y", "15 March 2024", "", false},
+		{"%M %D, %Y", "March 1st, 2024", "2024-03-01 00:00:00.000000", true},
+		{"%M %D, %Y", "March 22nd, 2024", "2024-03-22 00:00:00.000000", true},
+		{"%H:%i:%s.%f", "10:11:12.5", "0000-00-00 10:11:12.500000", true},
+		{"%H:%i:%s.%f", "10:11:12.0001234", "0000-00-00 10:11:12.000123", true},
+		{"%h:%i %p", "12:30 am", "0000-00-00 00:30:00.000000", true},
+		{"%h:%i %p", "12:30 PM", "0000-00-00 12:30:00.000000", true},
+		{"%h:%i %p", "01:30 PM", "0000-00-00 13:30:00.000000", true},
+		{"%h:%i %p", "13:30 PM", "", false},
+		{"%H:%i %p", "01:30 PM", "", false},
+		{"%r", "01:30:15 PM", "0000-00-00 13:30:15.000000", true},
+		{"%Y %j", "2024 060", "2024-02-29 00:00:00.000000", true},
+		{"%Y %j", "2023 365", "2023-12-31 00:00:00.000000", true},
+		{"%Y %U %w", "2024 09 5", "2024-03-08 00:00:00.000000", true},
+		{"%Y %u %W", "2024 10 Friday", "2024-03-08 00:00:00.000000", true},
+		{"%X %V %a", "2024 09 Fri", "2024-03-08 00:00:00.000000", true},
+		{"%x %v %a", "2024 10 Fri", "2024-03-08 00:00:00.000000", true},
+		{"%x %v %a", "2021 01 Mon", "2021-01-04 00:00:00.000000", true},
+		{"%X %v %a", "2024 10 Fri", "", false},
+		{"%Y %v %a", "2024 10 Fri", "", false},
+		{"%X %U %a", "2024 10 Fri", "", false},
+		{"%Y %U", "2024 10", "2024-00-00 00:00:00.000000", true},
+		{"%Y %w", "2024 7", "", false},
+		{"%Y%m%d%.%H", "20240315--10", "2024-03-15 10:00:00.000000", true},
+		{"%Y%m%d%@%H", "20240315abc10", "2024-03-15 10:00:00.000000", true},
+		{"%Y%m%d%#-%H", "2024031512-10", "2024-03-15 10:00:00.000000", true},
+		{"%Y%m%d%%%H", "20240315%10", "", false},
+		{"abc", "abc", "0000-00-00 00:00:00.000000", true},
+		{"%Y-%m-%d", "", "0000-00-00 00:00:00.000000", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format+"/"+tc.in, func(t *testing.T) {
+			dt, ok := StrToDate(tc.format, tc.in)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.want, string(dt.Format(6)))
+			}
+		})
+	}
+}
+
+func TestStrToDateRoundTrip(t *testing.T) {
+	formats := []string{
+		"%Y-%m-%d %H:%i:%s.%f",
+		"%y%m%d %H%i%S",
+		"%c/%e/%Y %l:%i:%s %p",
+		"%M %D %Y %r",
+		"%b %d %Y %T",
+		"%a %d %b %Y %h:%i %p",
+		"%Y %j %H",
+		"%Y %U %w",
+		"%Y %u %W",
+		"%X %V %W",
+		"%x %v %a",
+	}
+	dates := []DateTime{
+		{Date: Date{2024, 2, 29}, Time: Time{hour: 13, minute: 4, second: 5, nanosecond: 123456000}},
+		{Date: Date{2021, 1, 1}, Time: Time{hour: 0, minute: 59, second: 59}},
+		{Date: Date{2021, 1, 3}, Time: Time{hour: 12, minute: 0, second: 0}},
+		{Date: Date{2020, 12, 31}, Time: Time{hour: 23, minute: 1, second: 2}},
+		{Date: Date{1999, 12, 27}, Time: Time{hour: 11, minute: 30, second: 0}},
+	}
+
+	for _, format := range formats {
+		f, err := New(format)
+		assert.NoError(t, err)
+		hasDate, hasTime, hasFrac := FormatParts(format)
+		assert.True(t, hasDate)
+
+		for _, dt := range dates {
+			s := f.FormatString(dt, 6)
+			parsed, ok := StrToDate(format, s)
+			if assert.True(t, ok, "%s with %s", s, format) {
+				assert.Equal(t, dt.Date, parsed.Date, "%s with %s", s, format)
+				if hasTime {
+					// the formats that have time parts have all of them, but
+					// some don't have seconds
+					assert.Equal(t, dt.Time.Hour(), parsed.Time.Hour(), "%s with %s", s, format)
+				}
+				if hasFrac {
+					assert.Equal(t, dt.Time, parsed.Time, "%s with %s", s, format)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatParts(t *testing.T) {
+	testCases := []struct {
+		format                    string
+		hasDate, hasTime, hasFrac bool
+	}{
+		{"%Y-%m-%d", true, false, false},
+		{"%H:%i:%s", false, true, false},
+		{"%T.%f", false, true, true},
+		{"%Y-%m-%d %r", true, true, false},
+		{"%x %v %a %f", true, true, true},
+		{"%%Y %", false, false, false},
+		{"", false, false, false},
+	}
+
+	for _, tc := range testCases {
+		hasDate, hasTime, hasFrac := FormatParts(tc.format)
+		assert.Equal(t, tc.hasDate, hasDate, tc.format)
+		assert.Equal(t, tc.hasTime, hasTime, tc.format)
+		assert.Equal(t, tc.hasFrac, hasFrac, tc.format)
+	}
+}
+
+func TestFormatSkipAndUnknownSpecifiers(t *testing.T) {
+	// the specifiers that only have a meaning for STR_TO_DATE, and the
+	// unknown ones, format as their character
+	dt := DateTime{Date: Date{2024, 3, 15}}
+	got, err := Format("%Y%.%@%#%%%q", dt, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "2024.@#%q", string(got))
+}
@@ -18,6 +18,7 @@ package datetime
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -28,11 +29,17 @@ func unknownTimeZone(tz string) error {
 	return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.UnknownTimeZone, "Unknown or incorrect time zone: '%s'", tz)
 }
 
+// ParseTimeZone parses a MySQL time zone value: a named zone, an offset
+// from UTC in the form +HH:MM or -HH:MM, or SYSTEM for the local time zone
+// of the process.
 func ParseTimeZone(tz string) (*time.Location, error) {
 	// Needs to be checked first since time.LoadLocation("") returns UTC.
 	if tz == "" {
 		return nil, unknownTimeZone(tz)
 	}
+	if strings.EqualFold(tz, "SYSTEM") {
+		return time.Local, nil
+	}
 	loc, err := time.LoadLocation(tz)
 	if err == nil {
 		return loc, nil
@@ -99,6 +99,14 @@ func TestParseTimeZone(t *testing.T) {
 			tz:   "-15:00",
 			want: "Unknown or incorrect time zone: '-15:00'",
 		},
+		{
+			tz:   "SYSTEM",
+			want: "Local",
+		},
+		{
+			tz:   "system",
+			want: "Local",
+		},
 		{
 			tz:   "foo",
 			want: "Unknown or incorrect time zone: 'foo'",
@@ -16,7 +16,10 @@ limitations under the License.
 
 package datetime
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type timeparts struct {
 	year  int
@@ -32,6 +35,42 @@ type timeparts struct {
 	amset bool
 
 	prec uint8
+
+	// strToDate is set when parsing like STR_TO_DATE: whitespace is skipped
+	// before every specifier and character of the format, and the parsing
+	// stops once the input is exhausted. The fields below are only used
+	// by STR_TO_DATE.
+	strToDate bool
+	// usaTime is set once the hour was read with a 12-hour specifier.
+	usaTime bool
+	// weekday is the day of the week, from 1 (Monday) to 7 (Sunday), or 0 if unset.
+	weekday int
+	// week is the week of the year, or -1 if unset.
+	week        int
+	sundayFirst bool
+	strictWeek  bool
+	// weekYear is the year of week for %V and %v, or -1 if unset.
+	weekYear            int
+	weekYearSundayFirst bool
+}
+
+// parse reads the parts of s described by specs, and returns what is left
+// of s.
+func (tp *timeparts) parse(specs []Spec, s string) (string, bool) {
+	var ok bool
+	for _, spec := range specs {
+		if tp.strToDate {
+			s = strings.TrimLeft(s, " \t\n\v\f\r")
+			if len(s) == 0 {
+				break
+			}
+		}
+		s, ok = spec.parse(tp, s)
+		if !ok {
+			return "", false
+		}
+	}
+	return s, true
 }
 
 func (tp *timeparts) toDateTime(prec int) (DateTime, int, bool) {
@@ -376,8 +376,17 @@ func (d Decimal) QuoRem(d2 Decimal, precision int32) (Decimal, Decimal) {
 	if e > math.MaxInt32 || e < math.MinInt32 {
 		panic("overflow in decimal QuoRem")
 	}
-	var aa, bb, expo big.Int
 	var scalerest int32
+	if e < 0 {
+		scalerest = d.exp
+	} else {
+		scalerest = scale + d2.exp
+	}
+	if q, r, ok := quoRemFixed(d.value, d2.value, e); ok {
+		return Decimal{value: q, exp: scale}, Decimal{value: r, exp: scalerest}
+	}
+
+	var aa, bb, expo big.Int
 	// d = a 10^ea
 	// d2 = b 10^eb
 	if e < 0 {
@@ -385,7 +394,6 @@ func (d Decimal) QuoRem(d2 Decimal, precision int32) (Decimal, Decimal) {
 		expo.SetInt64(-e)
 		bb.Exp(tenInt, &expo, nil)
 		bb.Mul(d2.value, &bb)
-		scalerest = d.exp
 		// now aa = a
 		//     bb = b 10^(scale + eb - ea)
 	} else {
@@ -393,7 +401,6 @@ func (d Decimal) QuoRem(d2 Decimal, precision int32) (Decimal, Decimal) {
 		aa.Exp(tenInt, &expo, nil)
 		aa.Mul(d.value, &aa)
 		bb = *d2.value
-		scalerest = scale + d2.exp
 		// now aa = a ^ (ea - eb - scale)
 		//     bb = b
 	}
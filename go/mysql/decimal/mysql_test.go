@@ -464,3 +464,38 @@ func RequireFromString(value string) Decimal {
 	}
 	return dec
 }
+
+// largeDivisions are pairs of DECIMAL(65,30) operands, the largest decimals
+// that MySQL supports.
+var largeDivisions = [][2]string{
+	{"12345678901234567890123456789012345.123456789012345678901234567890", "98765432109876543210987654321098765.432109876543210987654321098765"},
+	{"99999999999999999999999999999999999.999999999999999999999999999999", "3.000000000000000000000000000001"},
+	{"-31415926535897932384626433832795028.841971693993751058209749445923", "271828182845904523536.028747135266249775724709369995"},
+	{"0.000000000000000000000000000001", "77777777777777777777777777777777777.777777777777777777777777777777"},
+	{"123456789.123456789", "-0.000000000000000000000000012345"},
+}
+
+func BenchmarkLargeDivision(b *testing.B) {
+	operands := make([][2]Decimal, 0, len(largeDivisions))
+	for _, div := range largeDivisions {
+		operands = append(operands, [2]Decimal{RequireFromString(div[0]), RequireFromString(div[1])})
+	}
+
+	b.Run("Div", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, op := range operands {
+				_ = op[0].Div(op[1], 4)
+			}
+		}
+	})
+
+	b.Run("Mod", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			for _, op := range operands {
+				_, _ = op[0].QuoRem(op[1], 0)
+			}
+		}
+	})
+}
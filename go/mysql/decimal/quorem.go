@@ -0,0 +1,265 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decimal
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// The division of decimals scales one of the operands by a power of ten
+// before dividing their integer values. With the big.Int API, this allocates
+// the power of ten, the scaled operand and the scratch space of the division
+// itself, which makes the division of large decimals up to 10x slower than
+// MySQL's.
+//
+// Instead, quoRemFixed divides in a fixed-size representation of the operands
+// as limbs of big.Words, which is large enough for every division of decimals
+// that MySQL supports: the dividend of a DECIMAL(65,30) division scaled for
+// its result has less than 200 digits. The limbs are kept in a pool, so the
+// only allocations are the ones of the results. Divisions of larger decimals
+// fall back to big.Int.
+
+// fixedBits is the number of bits of the fixed-size representation.
+const fixedBits = 768
+
+// fixedWords is the number of limbs of the fixed-size representation.
+const fixedWords = fixedBits / bits.UintSize
+
+type fixedScratch struct {
+	// u is the dividend, with an extra limb for the normalization of the division
+	u [fixedWords + 1]big.Word
+	// v is the divisor
+	v [fixedWords]big.Word
+	// q is the quotient
+	q [fixedWords + 1]big.Word
+}
+
+var fixedPool = sync.Pool{
+	New: func() any { return new(fixedScratch) },
+}
+
+// pow10Words are the powers of ten that fit in a big.Word
+var pow10Words = func() (pow [n]big.Word) {
+	pow[0] = 1
+	for i := 1; i < n; i++ {
+		pow[i] = pow[i-1] * 10
+	}
+	return
+}()
+
+// quoRemFixed returns the quotient and remainder of the truncated division of
+// x*10^e by y if e >= 0, or of x by y*10^(-e) if e < 0, with the same signs
+// as big.Int.QuoRem. ok is false if the operands are too large for the
+// fixed-size representation.
+func quoRemFixed(x, y *big.Int, e int64) (q, r *big.Int, ok bool) {
+	xw, yw := x.Bits(), y.Bits()
+	if len(xw) > fixedWords || len(yw) > fixedWords || len(yw) == 0 {
+		return nil, nil, false
+	}
+
+	scratch := fixedPool.Get().(*fixedScratch)
+	defer fixedPool.Put(scratch)
+
+	un := copy(scratch.u[:fixedWords], xw)
+	vn := copy(scratch.v[:], yw)
+	if e >= 0 {
+		un, ok = mulPow10(scratch.u[:fixedWords], un, e)
+	} else {
+		vn, ok = mulPow10(scratch.v[:], vn, -e)
+	}
+	if !ok {
+		return nil, nil, false
+	}
+
+	// allocate both results at once, with the limbs of the quotient followed by
+	// the ones of the remainder
+	res := new(struct{ q, r big.Int })
+	if un < vn {
+		words := make([]big.Word, un)
+		copy(words, scratch.u[:un])
+		res.r.SetBits(words)
+	} else {
+		qn := un - vn + 1
+		words := make([]big.Word, qn+vn)
+		if vn == 1 {
+			rem := divWord(scratch.q[:qn], scratch.u[:un], scratch.v[0])
+			copy(words, scratch.q[:qn])
+			words[qn] = rem
+		} else {
+			divLimbs(scratch.q[:qn], scratch.u[:un+1], scratch.v[:vn])
+			copy(words, scratch.q[:qn])
+			copy(words[qn:], scratch.u[:vn])
+		}
+		res.q.SetBits(words[:qn:qn])
+		res.r.SetBits(words[qn:])
+	}
+
+	if x.Sign() < 0 {
+		res.r.Neg(&res.r)
+	}
+	if x.Sign() != y.Sign() {
+		res.q.Neg(&res.q)
+	}
+	return &res.q, &res.r, true
+}
+
+// mulPow10 multiplies the first zn limbs of z by 10^e in place, and returns
+// the new number of limbs, or false if the product doesn't fit in z.
+func mulPow10(z []big.Word, zn int, e int64) (int, bool) {
+	for e > 0 && zn > 0 {
+		mul := bn
+		if e < n {
+			mul = pow10Words[e]
+			e = 0
+		} else {
+			e -= n
+		}
+		var carry big.Word
+		for i := range z[:zn] {
+			hi, lo := bits.Mul(uint(z[i]), uint(mul))
+			lo, c := bits.Add(lo, uint(carry), 0)
+			z[i] = big.Word(lo)
+			carry = big.Word(hi + c)
+		}
+		if carry != 0 {
+			if zn == len(z) {
+				return 0, false
+			}
+			z[zn] = carry
+			zn++
+		}
+	}
+	return zn, true
+}
+
+// divWord stores u / v in q and returns the remainder. q has as many limbs as u.
+func divWord(q, u []big.Word, v big.Word) big.Word {
+	var rem uint
+	for i := len(u) - 1; i >= 0; i-- {
+		var quo uint
+		quo, rem = bits.Div(rem, uint(u[i]), uint(v))
+		q[i] = big.Word(quo)
+	}
+	return big.Word(rem)
+}
+
+// divLimbs stores u / v in q and the remainder in the first len(v) limbs of u,
+// with Knuth's algorithm D (TAOCP vol. 2, 4.3.1). u has an extra limb for the
+// normalization, and q has len(u)-len(v) limbs. v has at least two limbs, and
+// its top limb is not zero. v is clobbered.
+func divLimbs(q, u, v []big.Word) {
+	vn := len(v)
+	un := len(u) - 1
+
+	// D1: normalize, so that the top limb of v has its top bit set
+	shift := uint(bits.LeadingZeros(uint(v[vn-1])))
+	shlLimbs(v, shift)
+	u[un] = shlLimbs(u[:un], shift)
+
+	vn1 := uint(v[vn-1])
+	vn2 := uint(v[vn-2])
+	for j := un - vn; j >= 0; j-- {
+		// D3: estimate the limb of the quotient from the top limbs
+		qhat := ^uint(0)
+		ujn := uint(u[j+vn])
+		if ujn != vn1 {
+			var rhat uint
+			qhat, rhat = bits.Div(ujn, uint(u[j+vn-1]), vn1)
+			ujn2 := uint(u[j+vn-2])
+			for {
+				hi, lo := bits.Mul(qhat, vn2)
+				if hi < rhat || hi == rhat && lo <= ujn2 {
+					break
+				}
+				qhat--
+				prev := rhat
+				rhat += vn1
+				if rhat < prev {
+					break
+				}
+			}
+		}
+
+		// D4: multiply and subtract
+		if mulSubLimbs(u[j:j+vn+1], v, qhat) != 0 {
+			// D6: the estimate was one too large, add v back
+			qhat--
+			u[j+vn] += addLimbs(u[j:j+vn], v)
+		}
+		q[j] = big.Word(qhat)
+	}
+
+	// D8: unnormalize the remainder
+	shrLimbs(u[:vn], shift)
+}
+
+// shlLimbs shifts z left by s bits in place and returns the bits shifted out.
+func shlLimbs(z []big.Word, s uint) big.Word {
+	if s == 0 {
+		return 0
+	}
+	var carry big.Word
+	for i := range z {
+		w := z[i]
+		z[i] = w<<s | carry
+		carry = w >> (bits.UintSize - s)
+	}
+	return carry
+}
+
+// shrLimbs shifts z right by s bits in place.
+func shrLimbs(z []big.Word, s uint) {
+	if s == 0 {
+		return
+	}
+	var carry big.Word
+	for i := len(z) - 1; i >= 0; i-- {
+		w := z[i]
+		z[i] = w>>s | carry
+		carry = w << (bits.UintSize - s)
+	}
+}
+
+// mulSubLimbs subtracts v*m from z in place, where z has one more limb than v,
+// and returns the borrow.
+func mulSubLimbs(z, v []big.Word, m uint) uint {
+	var mulCarry, borrow uint
+	for i := range v {
+		hi, lo := bits.Mul(uint(v[i]), m)
+		lo, c := bits.Add(lo, mulCarry, 0)
+		mulCarry = hi + c
+		var w uint
+		w, borrow = bits.Sub(uint(z[i]), lo, borrow)
+		z[i] = big.Word(w)
+	}
+	w, borrow := bits.Sub(uint(z[len(v)]), mulCarry, borrow)
+	z[len(v)] = big.Word(w)
+	return borrow
+}
+
+// addLimbs adds v to z in place and returns the carry.
+func addLimbs(z, v []big.Word) big.Word {
+	var carry uint
+	for i := range v {
+		var w uint
+		w, carry = bits.Add(uint(z[i]), uint(v[i]), carry)
+		z[i] = big.Word(w)
+	}
+	return big.Word(carry)
+}
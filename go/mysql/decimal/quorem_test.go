@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decimal
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// quoRemBig is the big.Int implementation of quoRemFixed
+func quoRemBig(x, y *big.Int, e int64) (*big.Int, *big.Int) {
+	aa, bb := new(big.Int).Set(x), new(big.Int).Set(y)
+	if e >= 0 {
+		aa.Mul(aa, new(big.Int).Exp(tenInt, big.NewInt(e), nil))
+	} else {
+		bb.Mul(bb, new(big.Int).Exp(tenInt, big.NewInt(-e), nil))
+	}
+	return new(big.Int).QuoRem(aa, bb, new(big.Int))
+}
+
+func TestQuoRemFixed(t *testing.T) {
+	randomInt := func(digits int) *big.Int {
+		if digits == 0 {
+			return new(big.Int)
+		}
+		buf := make([]byte, digits)
+		for i := range buf {
+			buf[i] = byte('0' + rand.IntN(10))
+		}
+		v, _ := new(big.Int).SetString(string(buf), 10)
+		if rand.IntN(2) == 0 {
+			v.Neg(v)
+		}
+		return v
+	}
+	// allNines returns 10^digits-1, which maximizes the corrections of the
+	// estimated limbs of the quotient
+	allNines := func(digits int) *big.Int {
+		return new(big.Int).Sub(bigPow10(uint64(digits)), oneInt)
+	}
+
+	check := func(t *testing.T, x, y *big.Int, e int64) {
+		q, r, ok := quoRemFixed(x, y, e)
+		if !ok {
+			return
+		}
+		wantQ, wantR := quoRemBig(x, y, e)
+		require.Equalf(t, wantQ.String(), q.String(), "quotient of %s * 10^%d / %s", x, e, y)
+		require.Equalf(t, wantR.String(), r.String(), "remainder of %s * 10^%d / %s", x, e, y)
+	}
+
+	t.Run("random", func(t *testing.T) {
+		for range 20000 {
+			x := randomInt(rand.IntN(100))
+			y := randomInt(1 + rand.IntN(80))
+			if y.Sign() == 0 {
+				continue
+			}
+			check(t, x, y, int64(rand.IntN(200)-100))
+		}
+	})
+
+	t.Run("edges", func(t *testing.T) {
+		for xd := 0; xd <= 70; xd += 5 {
+			for yd := 1; yd <= 70; yd += 3 {
+				for _, e := range []int64{-90, -19, -1, 0, 1, 18, 19, 20, 45, 90} {
+					x, y := allNines(xd), allNines(yd)
+					check(t, x, y, e)
+					check(t, x, new(big.Int).Add(y, bigPow10(uint64(yd/2))), e)
+					check(t, new(big.Int).Neg(x), y, e)
+					check(t, x, new(big.Int).Neg(y), e)
+				}
+			}
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, _, ok := quoRemFixed(allNines(200), big.NewInt(7), 100)
+		require.False(t, ok)
+		_, _, ok = quoRemFixed(big.NewInt(7), allNines(200), -100)
+		require.False(t, ok)
+	})
+}
+
+func TestQuoRemFixedAllocations(t *testing.T) {
+	x := RequireFromString("12345678901234567890123456789012345.123456789012345678901234567890")
+	y := RequireFromString("98765432.109876543210987654321098765")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = x.QuoRem(y, 36)
+	})
+	// the limbs of the results and their big.Ints
+	require.LessOrEqual(t, allocs, float64(2))
+}
@@ -131,6 +131,13 @@ type DB struct {
 	lastErrorMu sync.Mutex
 	lastError   error
 
+	// queryWarnings maps tolower(query) to the number of warnings reported
+	// for it, and lastWarnings maps the ID of each connection to the number
+	// of warnings of the last query it executed.
+	warningsMu    sync.Mutex
+	queryWarnings map[string]uint16
+	lastWarnings  map[uint32]uint16
+
 	env *vtenv.Environment
 }
 
@@ -151,6 +158,7 @@ type exprResult struct {
 	expr         *regexp.Regexp
 	result       *sqltypes.Result
 	err          string
+	rejectErr    error
 }
 
 // ExpectedExecuteFetch defines for an expected query the to be faked output.
@@ -190,6 +198,8 @@ func NewWithEnv(t testing.TB, env *vtenv.Environment) *DB {
 		queryPatternUserCallback: make(map[*regexp.Regexp]func(string)),
 		patternData:              make(map[string]exprResult),
 		lastErrorMu:              sync.Mutex{},
+		queryWarnings:            make(map[string]uint16),
+		lastWarnings:             make(map[uint32]uint16),
 		env:                      env,
 	}
 
@@ -377,7 +387,16 @@ func (db *DB) ComQueryMulti(c *mysql.Conn, sql string, callback func(qr sqltypes
 
 // WarningCount is part of the mysql.Handler interface.
 func (db *DB) WarningCount(c *mysql.Conn) uint16 {
-	return 0
+	db.warningsMu.Lock()
+	defer db.warningsMu.Unlock()
+	return db.lastWarnings[c.ConnectionID]
+}
+
+// AddQueryWarnings sets the number of warnings reported for a query.
+func (db *DB) AddQueryWarnings(query string, count uint16) {
+	db.warningsMu.Lock()
+	defer db.warningsMu.Unlock()
+	db.queryWarnings[strings.ToLower(query)] = count
 }
 
 // HandleQuery is the default implementation of the QueryHandler interface
@@ -401,6 +420,9 @@ func (db *DB) HandleQuery(c *mysql.Conn, query string, callback func(*sqltypes.R
 		return callback(result)
 	}
 	key := strings.ToLower(query)
+	db.warningsMu.Lock()
+	db.lastWarnings[c.ConnectionID] = db.queryWarnings[key]
+	db.warningsMu.Unlock()
 	db.mu.Lock()
 	db.queryCalled[key]++
 	db.querylog = append(db.querylog, key)
@@ -453,6 +475,9 @@ func (db *DB) HandleQuery(c *mysql.Conn, query string, callback func(*sqltypes.R
 			if ok {
 				userCallback(query)
 			}
+			if pat.rejectErr != nil {
+				return pat.rejectErr
+			}
 			if pat.err != "" {
 				return errors.New(pat.err)
 			}
@@ -542,13 +567,30 @@ func (db *DB) comQueryOrdered(query string) (*sqltypes.Result, error) {
 }
 
 // ComPrepare is part of the mysql.Handler interface.
-func (db *DB) ComPrepare(*mysql.Conn, string) ([]*querypb.Field, uint16, error) {
-	return nil, 0, nil
+// Every '?' in the query is taken for a parameter.
+func (db *DB) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Field, uint16, error) {
+	return nil, uint16(strings.Count(query, "?")), nil
 }
 
 // ComStmtExecute is part of the mysql.Handler interface.
+// The statement is handled like the query with its parameters substituted
+// by the bound values.
 func (db *DB) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
-	return nil
+	var query strings.Builder
+	param := 0
+	for _, r := range prepare.PrepareStmt {
+		if r != '?' {
+			query.WriteRune(r)
+			continue
+		}
+		param++
+		bv, ok := prepare.BindVars[fmt.Sprintf("v%d", param)]
+		if !ok {
+			return fmt.Errorf("missing bind var v%d", param)
+		}
+		sqlparser.EncodeValue(&query, bv)
+	}
+	return db.Handler.HandleQuery(c, query.String(), callback)
 }
 
 // ComRegisterReplica is part of the mysql.Handler interface.
@@ -629,6 +671,15 @@ func (db *DB) RejectQueryPattern(queryPattern, error string) {
 	db.patternData[queryPattern] = exprResult{queryPattern: queryPattern, expr: expr, err: error}
 }
 
+// RejectQueryPatternWithError allows a query pattern to be rejected with the
+// given error, e.g. a *sqlerror.SQLError to return a specific MySQL error code.
+func (db *DB) RejectQueryPatternWithError(queryPattern string, err error) {
+	expr := regexp.MustCompile("(?is)^" + queryPattern + "$")
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.patternData[queryPattern] = exprResult{queryPattern: queryPattern, expr: expr, rejectErr: err}
+}
+
 // ClearQueryPattern removes all query patterns set up
 func (db *DB) ClearQueryPattern() {
 	db.mu.Lock()
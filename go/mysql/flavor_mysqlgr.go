@@ -23,6 +23,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/proto/replicationdata"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 
 	"vitess.io/vitess/go/vt/vterrors"
@@ -239,6 +240,55 @@ func fetchStatusForGroupReplication(c *Conn, query string, onResult func([]sqlty
 	return onResult(qr.Rows[0])
 }
 
+// GroupReplicationStatus returns the state of this server as a group
+// replication member, along with the group's current ONLINE primary. It
+// returns ErrNoGroupStatus if the server is not a member of a group. It does
+// not depend on the connection's flavor, so it can be used to follow a group
+// that is managed outside of Vitess.
+func (c *Conn) GroupReplicationStatus() (*replicationdata.GroupReplicationStatus, error) {
+	res := &replicationdata.GroupReplicationStatus{}
+	query := `SELECT
+		MEMBER_STATE,
+		MEMBER_ROLE
+	FROM
+		performance_schema.replication_group_members
+	WHERE
+		MEMBER_ID=@@global.server_uuid`
+	err := fetchStatusForGroupReplication(c, query, func(values []sqltypes.Value) error {
+		parseGroupMemberState(res, values)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query = `SELECT
+		MEMBER_HOST,
+		MEMBER_PORT
+	FROM
+		performance_schema.replication_group_members
+	WHERE
+		MEMBER_ROLE='PRIMARY' AND MEMBER_STATE='ONLINE'`
+	err = fetchStatusForGroupReplication(c, query, func(values []sqltypes.Value) error {
+		res.PrimaryHost = values[0].ToString()   /* MEMBER_HOST */
+		res.PrimaryPort, _ = values[1].ToInt32() /* MEMBER_PORT */
+		return nil
+	})
+	// A group without an ONLINE primary, e.g. while it is electing one,
+	// is reported without a primary.
+	if err != nil && err != ErrNoGroupStatus {
+		return nil, err
+	}
+	return res, nil
+}
+
+// parseGroupMemberState parses the state and role of a group member. Only an
+// ONLINE member can act as the group's primary.
+func parseGroupMemberState(res *replicationdata.GroupReplicationStatus, row []sqltypes.Value) {
+	res.MemberState = row[0].ToString()                                           /* MEMBER_STATE */
+	res.IsPrimary = res.MemberState == "ONLINE" && row[1].ToString() == "PRIMARY" /* MEMBER_ROLE */
+}
+
 // primaryStatus returns the result of 'SHOW BINARY LOG STATUS',
 // with parsed executed position.
 func (mysqlGRFlavor) primaryStatus(c *Conn) (replication.PrimaryStatus, error) {
@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
 )
 
 func TestMysqlGRParsePrimaryGroupMember(t *testing.T) {
@@ -173,3 +174,27 @@ func TestMysqlGRSupportCapability(t *testing.T) {
 		})
 	}
 }
+
+func TestMysqlGRParseGroupMemberState(t *testing.T) {
+	testcases := []struct {
+		state     string
+		role      string
+		isPrimary bool
+	}{
+		{state: "ONLINE", role: "PRIMARY", isPrimary: true},
+		{state: "ONLINE", role: "SECONDARY", isPrimary: false},
+		{state: "RECOVERING", role: "SECONDARY", isPrimary: false},
+		{state: "ERROR", role: "PRIMARY", isPrimary: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.state+"/"+tc.role, func(t *testing.T) {
+			res := &replicationdatapb.GroupReplicationStatus{}
+			parseGroupMemberState(res, []sqltypes.Value{
+				sqltypes.NewVarChar(tc.state),
+				sqltypes.NewVarChar(tc.role),
+			})
+			assert.Equal(t, tc.state, res.MemberState)
+			assert.Equal(t, tc.isPrimary, res.IsPrimary)
+		})
+	}
+}
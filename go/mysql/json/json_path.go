@@ -320,6 +320,13 @@ func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value
 	}
 
 	for i, p := range paths {
+		if p.IsRootPath() {
+			// the root path identifies the whole document, which always exists
+			if t == Set || t == Replace {
+				*doc = *values[i]
+			}
+			continue
+		}
 		transform := func(pp *Path, vv *Value) {
 			switch pp.kind {
 			case jpArrayLocation:
@@ -333,6 +340,18 @@ func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value
 					} else {
 						vv.SetArrayItem(from, values[i], t)
 					}
+				} else if t != Remove {
+					// a value that is not an array is treated as a single-element
+					// array: its first position is the value itself, and adding
+					// past it wraps the value into an array
+					from, _ := pp.arrayOffsets([]*Value{vv})
+					switch {
+					case from == 0 && (t == Set || t == Replace):
+						*vv = *values[i]
+					case from > 0 && (t == Set || t == Insert):
+						wrapped := *vv
+						*vv = Value{t: TypeArray, a: []*Value{&wrapped, values[i]}}
+					}
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
@@ -155,6 +155,48 @@ func TestTransformations(t *testing.T) {
 			Values:   []string{"1", "2"},
 			Expected: `["a", {"b": [1, false]}, [10, 20]]`,
 		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$[2][5]`, `$[0][1]`, `$[1].b[0][0]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 2], {"b": [3, false]}, [10, 20, 1]]`,
+		},
+		{
+			T:        Insert,
+			Document: `{"a": 1}`,
+			Paths:    []string{`$.a[0]`, `$.a[last]`, `$.b[0]`, `$.a[1]`},
+			Values:   []string{"2", "3", "4", "5"},
+			Expected: `{"a": [1, 5]}`,
+		},
+		{
+			T:        Replace,
+			Document: `{"a": 1}`,
+			Paths:    []string{`$.a[0]`, `$.a[1]`, `$.b`, `$[0].c`},
+			Values:   []string{"2", "3", "4", "5"},
+			Expected: `{"a": 2}`,
+		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$`, `$.c`},
+			Values:   []string{`{"a": 1}`, "2"},
+			Expected: `{"a": 1, "c": 2}`,
+		},
+		{
+			T:        Insert,
+			Document: `[1]`,
+			Paths:    []string{`$`},
+			Values:   []string{"2"},
+			Expected: `[1]`,
+		},
+		{
+			T:        Replace,
+			Document: `1`,
+			Paths:    []string{`$[0]`},
+			Values:   []string{"2"},
+			Expected: `2`,
+		},
 		{
 			T:        Remove,
 			Document: Document1,
@@ -71,18 +71,14 @@ func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	if v == nil || v.t != TypeArray || idx < 0 {
 		return
 	}
-	switch t {
-	case Insert:
-		if idx < len(v.a) {
-			return
-		}
-		fallthrough
-	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
+	if idx >= len(v.a) {
+		// positions past the end of the array append the value to it
+		if t == Set || t == Insert {
+			v.a = append(v.a, value)
 		}
+		return
 	}
-	if idx < len(v.a) {
+	if t == Set || t == Replace {
 		v.a[idx] = value
 	}
 }
@@ -96,3 +92,80 @@ func (v *Value) DelArrayItem(n int) {
 	}
 	v.a = append(v.a[:n], v.a[n+1:]...)
 }
+
+// Clone returns a deep copy of v, which can be modified without changing v.
+func (v *Value) Clone() *Value {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	switch v.t {
+	case TypeArray:
+		c.a = make([]*Value, len(v.a))
+		for i, item := range v.a {
+			c.a[i] = item.Clone()
+		}
+	case TypeObject:
+		c.o.kvs = make([]kv, len(v.o.kvs))
+		for i, item := range v.o.kvs {
+			c.o.kvs[i] = kv{k: item.k, v: item.v.Clone()}
+		}
+	}
+	return &c
+}
+
+// MergePreserve merges two documents as JSON_MERGE_PRESERVE does: objects are
+// merged key by key, merging the values of the keys that appear in both, and
+// any other values are concatenated as arrays, wrapping the values that are not
+// arrays into one. Neither a nor b are modified.
+func MergePreserve(a, b *Value) *Value {
+	if ao, ok := a.Object(); ok {
+		if bo, ok := b.Object(); ok {
+			var obj Object
+			ao.Visit(obj.Add)
+			bo.Visit(func(key string, value *Value) {
+				if prev := obj.Get(key); prev != nil {
+					value = MergePreserve(prev, value)
+				}
+				obj.Set(key, value, Set)
+			})
+			return NewObject(obj)
+		}
+	}
+	return NewArray(append(autowrap(a), autowrap(b)...))
+}
+
+// MergePatch merges the patch into the target as described by RFC 7396, like
+// JSON_MERGE_PATCH does: if the patch is an object, its members are merged into
+// the target, removing the members whose value is null in the patch; otherwise
+// the patch replaces the target. A nil target is treated as an empty object.
+// Neither target nor patch are modified.
+func MergePatch(target, patch *Value) *Value {
+	po, ok := patch.Object()
+	if !ok {
+		return patch
+	}
+	var obj Object
+	if target != nil {
+		if to, ok := target.Object(); ok {
+			to.Visit(obj.Add)
+		}
+	}
+	po.Visit(func(key string, value *Value) {
+		if value.Type() == TypeNull {
+			obj.Del(key)
+			return
+		}
+		obj.Set(key, MergePatch(obj.Get(key), value), Set)
+	})
+	return NewObject(obj)
+}
+
+// autowrap returns the items of the array v, or v as a single item if it is
+// not an array.
+func autowrap(v *Value) []*Value {
+	if ary, ok := v.Array(); ok {
+		return slices.Clone(ary)
+	}
+	return []*Value{v}
+}
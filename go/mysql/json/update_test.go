@@ -90,3 +90,50 @@ func TestValueDelSet(t *testing.T) {
 	a, _ := va.Array()
 	require.Lenf(t, a, 2, "unexpected number of items left in the array; got %d; want %d", len(a), 2)
 }
+
+func TestClone(t *testing.T) {
+	v := MustParse(`{"a": [1, {"b": 2}], "c": "d"}`)
+	c := v.Clone()
+	require.NoError(t, ApplyTransform(Set, c, []*Path{path(t, `$.a[1].b`), path(t, `$.c`)}, []*Value{ValueTrue, ValueNull}))
+	require.Equal(t, `{"a": [1, {"b": 2}], "c": "d"}`, v.String())
+	require.Equal(t, `{"a": [1, {"b": true}], "c": null}`, c.String())
+}
+
+func TestMergePreserve(t *testing.T) {
+	cases := []struct {
+		a, b, want string
+	}{
+		{`[1, 2]`, `[true, false]`, `[1, 2, true, false]`},
+		{`{"name": "x"}`, `{"id": 47}`, `{"id": 47, "name": "x"}`},
+		{`1`, `true`, `[1, true]`},
+		{`[1, 2]`, `{"id": 47}`, `[1, 2, {"id": 47}]`},
+		{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": [1, 3], "b": 2, "c": 4}`},
+		{`{"a": {"b": 1}}`, `{"a": {"b": [2], "c": 3}}`, `{"a": {"b": [1, 2], "c": 3}}`},
+	}
+	for _, tc := range cases {
+		a, b := MustParse(tc.a), MustParse(tc.b)
+		require.Equal(t, tc.want, MergePreserve(a, b).String(), "%s %s", tc.a, tc.b)
+		require.Equal(t, MustParse(tc.a).String(), a.String())
+		require.Equal(t, MustParse(tc.b).String(), b.String())
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	cases := []struct {
+		target, patch, want string
+	}{
+		{`[1, 2]`, `[true, false]`, `[true, false]`},
+		{`{"name": "x"}`, `{"id": 47}`, `{"id": 47, "name": "x"}`},
+		{`1`, `true`, `true`},
+		{`[1, 2]`, `{"id": 47}`, `{"id": 47}`},
+		{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": 3, "b": 2, "c": 4}`},
+		{`{"a": 1, "b": 2}`, `{"b": null}`, `{"a": 1}`},
+		{`{"a": {"b": 1, "c": 2}}`, `{"a": {"b": null, "d": {"e": null}}}`, `{"a": {"c": 2, "d": {}}}`},
+	}
+	for _, tc := range cases {
+		target, patch := MustParse(tc.target), MustParse(tc.patch)
+		require.Equal(t, tc.want, MergePatch(target, patch).String(), "%s %s", tc.target, tc.patch)
+		require.Equal(t, MustParse(tc.target).String(), target.String())
+	}
+	require.Equal(t, `{"a": 1}`, MergePatch(nil, MustParse(`{"a": 1, "b": null}`)).String())
+}
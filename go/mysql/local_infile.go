@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"io"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+)
+
+// This file contains the server side of the LOCAL INFILE request of LOAD
+// DATA LOCAL INFILE: while it executes the statement, the server sends a
+// packet starting with 0xfb and holding the file name, and the client
+// answers with the content of the file cut in packets, followed by an
+// empty packet. The server then sends the result of the statement.
+
+// LocalInfilePacket is the first byte of the LOCAL INFILE request packet.
+const LocalInfilePacket = 0xfb
+
+// ContentProvider provides the content of the files loaded by LOAD DATA
+// LOCAL INFILE statements. *Conn implements it by asking the client, so
+// that handlers can pass the connection down to where the statements are
+// executed.
+type ContentProvider interface {
+	// LocalInfile returns the content of filename. The caller must close
+	// it, even if it does not read it to the end.
+	LocalInfile(filename string) (io.ReadCloser, error)
+}
+
+var _ ContentProvider = (*Conn)(nil)
+
+// LocalInfile asks the client for the content of filename, and returns it
+// as it is streamed by the client. It can only be called by a Handler
+// while it executes a query, and requires CLIENT_LOCAL_FILES, which is
+// negotiated when the listener allows it. The returned reader must be
+// closed before the result of the query is written: Close reads what is
+// left of the file, so that the connection stays in sync with the client.
+// A client that cannot read the file sends it empty.
+func (c *Conn) LocalInfile(filename string) (io.ReadCloser, error) {
+	if c.Capabilities&CapabilityClientLocalFiles == 0 {
+		return nil, sqlerror.NewSQLError(sqlerror.ERNotAllowedCommand, sqlerror.SSClientError, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + len(filename))
+	pos = writeByte(data, pos, LocalInfilePacket)
+	_ = writeEOFString(data, pos, filename)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	// The responses to queries are buffered, but the client only sends
+	// the file once it got the request.
+	if err := c.FlushWriteBuffer(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	return &localInfileReader{c: c}, nil
+}
+
+// localInfileReader reads the content of a file streamed by the client,
+// until the empty packet ending it.
+type localInfileReader struct {
+	c *Conn
+	// data is what is left to read of the last packet.
+	data []byte
+	done bool
+	err  error
+}
+
+// Read implements io.Reader.
+func (r *localInfileReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for len(r.data) == 0 {
+		if r.done {
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		r.readPacket()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// Close implements io.Closer. It reads the rest of the file.
+func (r *localInfileReader) Close() error {
+	for !r.done {
+		r.readPacket()
+	}
+	r.data = nil
+	return r.err
+}
+
+func (r *localInfileReader) readPacket() {
+	data, err := r.c.readPacket()
+	switch {
+	case err != nil:
+		r.err = sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "reading LOCAL INFILE content failed: %v", err)
+		r.done = true
+	case len(data) == 0:
+		r.done = true
+	default:
+		r.data = data
+	}
+}
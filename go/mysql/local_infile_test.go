@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+// localInfileTestHandler asks the client for the file named by the query,
+// and records up to limit bytes of its content.
+type localInfileTestHandler struct {
+	testHandler
+	limit   int
+	content string
+}
+
+func (th *localInfileTestHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	r, err := c.LocalInfile(query)
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(io.LimitReader(r, int64(th.limit)))
+	if cerr := r.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	th.content = string(content)
+	return callback(&sqltypes.Result{RowsAffected: uint64(len(content))})
+}
+
+func TestLocalInfile(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	handler := &localInfileTestHandler{limit: 1 << 20}
+
+	// The client answers the LOCAL INFILE request with the given chunks.
+	var wg sync.WaitGroup
+	var result *sqltypes.Result
+	var err error
+	execute := func(filename string, chunks ...string) {
+		wg.Go(func() {
+			if err = cConn.WriteComQuery(filename); err != nil {
+				return
+			}
+			var request []byte
+			if request, err = cConn.readPacket(); err != nil {
+				return
+			}
+			if !assert.Equal(t, append([]byte{LocalInfilePacket}, filename...), request) {
+				return
+			}
+			for _, chunk := range append(chunks, "") {
+				if err = cConn.writePacket(append(make([]byte, PacketHeaderSize), chunk...)); err != nil {
+					return
+				}
+			}
+			result, _, _, err = cConn.ReadQueryResult(10, false)
+		})
+		require.True(t, sConn.handleNextCommand(handler))
+		wg.Wait()
+	}
+
+	// LOAD DATA LOCAL INFILE is not allowed until it is negotiated.
+	wg.Go(func() {
+		_, err = cConn.ExecuteFetch("data.csv", 10, false)
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	assert.Equal(t, sqlerror.ERNotAllowedCommand, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Num)
+
+	sConn.Capabilities |= CapabilityClientLocalFiles
+	execute("data.csv", "1,one\n", "2,two\n", strings.Repeat("3,three\n", 10000))
+	require.NoError(t, err)
+	assert.Equal(t, "1,one\n2,two\n"+strings.Repeat("3,three\n", 10000), handler.content)
+	assert.EqualValues(t, len(handler.content), result.RowsAffected)
+
+	// A client that cannot read the file sends it empty.
+	execute("missing.csv")
+	require.NoError(t, err)
+	assert.Empty(t, handler.content)
+
+	// The handler does not have to read the whole file, the rest of it is
+	// skipped when it is closed.
+	handler.limit = 3
+	execute("data.csv", "1,one\n", "2,two\n")
+	require.NoError(t, err)
+	assert.Equal(t, "1,o", handler.content)
+
+	// The connection is still in sync.
+	handler.limit = 1 << 20
+	execute("data.csv", "4,four\n")
+	require.NoError(t, err)
+	assert.Equal(t, "4,four\n", handler.content)
+}
@@ -0,0 +1,602 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/mysql/binlog"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the methods needed to execute queries as server-side
+// prepared statements.
+
+// ErrPreparedStatementUnsupported is returned when a query cannot be executed
+// as a prepared statement with the same result as its text form: an argument
+// has no parameter type that means the same as its SQL literal, or a result
+// column has no binary encoding that converts back to the text the server
+// sends for it. The query should be executed as text instead.
+var ErrPreparedStatementUnsupported = vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "query cannot be executed as a prepared statement")
+
+// paramUnsigned is the flag of an unsigned parameter in COM_STMT_EXECUTE.
+const paramUnsigned = 0x80
+
+// PreparedStatement is a statement prepared on the server by Prepare. It can
+// only be executed on the connection that prepared it.
+type PreparedStatement struct {
+	ID         uint32
+	Query      string
+	ParamCount int
+}
+
+// Prepare prepares query on the server with a COM_STMT_PREPARE packet.
+// Returns a SQLError, or ErrPreparedStatementUnsupported if the result
+// columns of the statement cannot be decoded by ExecutePrepared.
+func (c *Conn) Prepare(query string) (stmt *PreparedStatement, err error) {
+	defer func() {
+		if err != nil {
+			if sqlerr, ok := err.(*sqlerror.SQLError); ok {
+				sqlerr.Query = sqlparser.TruncateQuery(query, c.truncateErrLen)
+			}
+		}
+	}()
+
+	// This is a new command, need to reset the sequence.
+	c.resetSequence()
+
+	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
+	data[pos] = ComPrepare
+	pos++
+	copy(data[pos:], query)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return nil, sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+
+	data, err = c.readEphemeralPacket()
+	if err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	if isErrorPacket(data) {
+		defer c.recycleReadPacket()
+		return nil, ParseErrorPacket(data)
+	}
+	stmt, colNumber, err := parsePrepareOK(data, query)
+	c.recycleReadPacket()
+	if err != nil {
+		return nil, err
+	}
+
+	// The parameter definitions carry nothing we need: the parameter types
+	// are sent with every execution.
+	if stmt.ParamCount > 0 {
+		if err := c.skipDefinitions(stmt.ParamCount); err != nil {
+			return nil, err
+		}
+	}
+
+	supported := true
+	if colNumber > 0 {
+		fields := make([]*querypb.Field, colNumber)
+		for i := range fields {
+			fields[i] = &querypb.Field{}
+			if err := c.readColumnDefinition(fields[i], i); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.readDefinitionsEOF(); err != nil {
+			return nil, err
+		}
+		supported = binaryFieldsSupported(fields)
+	}
+	if !supported {
+		if err := c.ClosePrepared(stmt); err != nil {
+			return nil, err
+		}
+		return nil, ErrPreparedStatementUnsupported
+	}
+	return stmt, nil
+}
+
+// ExecutePrepared executes a prepared statement with the given arguments
+// with a COM_STMT_EXECUTE packet, and returns the result like ExecuteFetch
+// does. The rows are converted to the text the server sends for them in a
+// text result set. Returns a SQLError, or ErrPreparedStatementUnsupported
+// if an argument or a result column cannot be represented faithfully. The
+// error is returned before the statement is executed for the arguments.
+func (c *Conn) ExecutePrepared(stmt *PreparedStatement, args []sqltypes.Value, maxrows int, wantfields bool) (result *sqltypes.Result, err error) {
+	defer func() {
+		if err != nil {
+			if sqlerr, ok := err.(*sqlerror.SQLError); ok {
+				sqlerr.Query = sqlparser.TruncateQuery(stmt.Query, c.truncateErrLen)
+			}
+		}
+	}()
+
+	if len(args) != stmt.ParamCount {
+		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "prepared statement expects %d arguments, got %d", stmt.ParamCount, len(args))
+	}
+	params := make([]preparedParam, len(args))
+	// With query attributes, the parameters have a name, and their count
+	// is sent.
+	queryAttributes := c.Capabilities&CapabilityClientQueryAttributes != 0
+	length := 1 + 4 + 1 + 4
+	if len(args) > 0 {
+		length += (len(args)+7)/8 + 1 + 2*len(args)
+		if queryAttributes {
+			length += lenEncIntSize(uint64(len(args))) + len(args)
+		}
+		for i, arg := range args {
+			if !params[i].bind(arg) {
+				return nil, ErrPreparedStatementUnsupported
+			}
+			length += params[i].len()
+		}
+	}
+
+	// This is a new command, need to reset the sequence.
+	c.resetSequence()
+
+	data, pos := c.startEphemeralPacketWithHeader(length)
+	pos = writeByte(data, pos, ComStmtExecute)
+	pos = writeUint32(data, pos, stmt.ID)
+	// No cursor, and one iteration.
+	pos = writeByte(data, pos, 0x00)
+	pos = writeUint32(data, pos, 1)
+	if len(params) > 0 {
+		if queryAttributes {
+			pos = writeLenEncInt(data, pos, uint64(len(params)))
+		}
+		bitmap := pos
+		for range (len(params) + 7) / 8 {
+			pos = writeByte(data, pos, 0x00)
+		}
+		// The parameter types are always sent.
+		pos = writeByte(data, pos, 0x01)
+		for i, param := range params {
+			if param.typ == binlog.TypeNull {
+				data[bitmap+i/8] |= 1 << uint(i%8)
+			}
+			pos = writeByte(data, pos, param.typ)
+			pos = writeByte(data, pos, param.flags)
+			if queryAttributes {
+				// The parameters of the statement have no name.
+				pos = writeLenEncString(data, pos, "")
+			}
+		}
+		for _, param := range params {
+			pos = param.write(data, pos)
+		}
+	}
+	if err := c.writeEphemeralPacket(); err != nil {
+		return nil, sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+
+	result, more, _, err := c.readQueryResult(maxrows, wantfields, true)
+	if more {
+		// Multiple results are unexpected. Prioritize this "unexpected" error over whatever error we got from the first result.
+		err = errors.Join(ErrExecuteFetchMultipleResults, err)
+	}
+	// draining to make the connection clean.
+	err = c.drainMoreResults(more, err)
+	return result, err
+}
+
+// ClosePrepared deallocates a prepared statement on the server with a
+// COM_STMT_CLOSE packet. The server does not reply to it.
+// Returns SQLError(CRServerGone) if it can't.
+func (c *Conn) ClosePrepared(stmt *PreparedStatement) error {
+	// This is a new command, need to reset the sequence.
+	c.resetSequence()
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + 4)
+	pos = writeByte(data, pos, ComStmtClose)
+	writeUint32(data, pos, stmt.ID)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+	return nil
+}
+
+// parsePrepareOK parses the COM_STMT_PREPARE_OK packet, and returns the
+// statement and its number of result columns.
+func parsePrepareOK(data []byte, query string) (*PreparedStatement, int, error) {
+	if len(data) < 9 || data[0] != OKPacket {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "invalid COM_STMT_PREPARE response packet")
+	}
+	id, pos, _ := readUint32(data, 1)
+	colNumber, pos, _ := readUint16(data, pos)
+	paramCount, _, _ := readUint16(data, pos)
+	return &PreparedStatement{ID: id, Query: query, ParamCount: int(paramCount)}, int(colNumber), nil
+}
+
+// skipDefinitions reads and ignores count column definitions, and the EOF
+// packet that follows them.
+func (c *Conn) skipDefinitions(count int) error {
+	for range count {
+		if _, err := c.readEphemeralPacket(); err != nil {
+			return sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+		}
+		c.recycleReadPacket()
+	}
+	return c.readDefinitionsEOF()
+}
+
+// readDefinitionsEOF reads the EOF packet that ends a list of column
+// definitions, unless it is deprecated.
+func (c *Conn) readDefinitionsEOF() error {
+	if c.Capabilities&CapabilityClientDeprecateEOF != 0 {
+		return nil
+	}
+	data, err := c.readEphemeralPacket()
+	if err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	defer c.recycleReadPacket()
+	if !c.isEOFPacket(data) {
+		return vterrors.Errorf(vtrpc.Code_INTERNAL, "unexpected packet after column definitions: %v", data)
+	}
+	return nil
+}
+
+// preparedParam is an argument of a prepared statement, with the parameter
+// type that gives it the meaning of its SQL literal in the query text.
+type preparedParam struct {
+	typ   byte
+	flags byte
+	num   uint64
+	raw   []byte
+}
+
+// bind sets the parameter to v, and returns false if v cannot be bound.
+func (p *preparedParam) bind(v sqltypes.Value) bool {
+	switch {
+	case v.IsNull():
+		p.typ = binlog.TypeNull
+	case v.IsSigned():
+		val, err := parseBinaryInt(v.Raw(), 64)
+		if err != nil {
+			return false
+		}
+		p.typ, p.num = binlog.TypeLongLong, uint64(val)
+	case v.IsUnsigned():
+		val, err := parseBinaryUint(v.Raw(), 64)
+		if err != nil {
+			return false
+		}
+		p.typ, p.flags, p.num = binlog.TypeLongLong, paramUnsigned, val
+	case v.IsFloat():
+		// A number with an exponent is a double literal, any other number
+		// is a decimal literal.
+		if bytes.IndexAny(v.Raw(), "eE") < 0 {
+			p.typ, p.raw = binlog.TypeNewDecimal, v.Raw()
+			return true
+		}
+		val, err := strconv.ParseFloat(hack.String(v.Raw()), 64)
+		if err != nil {
+			return false
+		}
+		p.typ, p.num = binlog.TypeDouble, math.Float64bits(val)
+	case v.IsDecimal():
+		p.typ, p.raw = binlog.TypeNewDecimal, v.Raw()
+	case v.IsBinary():
+		// Binary values are _binary string literals.
+		p.typ, p.raw = binlog.TypeBlob, v.Raw()
+	case v.IsQuoted():
+		p.typ, p.raw = binlog.TypeVarString, v.Raw()
+	default:
+		return false
+	}
+	return true
+}
+
+// len returns the length of the binary encoding of the parameter value.
+func (p *preparedParam) len() int {
+	switch p.typ {
+	case binlog.TypeNull:
+		return 0
+	case binlog.TypeLongLong, binlog.TypeDouble:
+		return 8
+	default:
+		return lenEncIntSize(uint64(len(p.raw))) + len(p.raw)
+	}
+}
+
+// write writes the binary encoding of the parameter value into data at pos,
+// and returns the position after it.
+func (p *preparedParam) write(data []byte, pos int) int {
+	switch p.typ {
+	case binlog.TypeNull:
+		return pos
+	case binlog.TypeLongLong, binlog.TypeDouble:
+		return writeUint64(data, pos, p.num)
+	default:
+		pos = writeLenEncInt(data, pos, uint64(len(p.raw)))
+		return pos + copy(data[pos:], p.raw)
+	}
+}
+
+// binaryFieldsSupported returns true if the values of all the fields can be
+// converted from the binary protocol to the text the server sends for them
+// in a text result set. Floating point values are formatted differently.
+func binaryFieldsSupported(fields []*querypb.Field) bool {
+	for _, field := range fields {
+		if sqltypes.IsFloat(field.Type) {
+			return false
+		}
+		if sqltypes.IsDateOrTime(field.Type) && field.Decimals > 6 {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBinaryRow parses a row in the binary protocol format, and converts
+// its values to their text form.
+func parseBinaryRow(data []byte, fields []*querypb.Field) ([]sqltypes.Value, error) {
+	// The row starts with a 0x00 header and a NULL bitmap, offset by 2 bits.
+	bitmap := 1
+	pos := bitmap + (len(fields)+7+2)/8
+	if len(data) < pos {
+		return nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding binary row failed")
+	}
+	result := make([]sqltypes.Value, 0, len(fields))
+	for i, field := range fields {
+		if data[bitmap+(i+2)/8]&(1<<uint((i+2)%8)) != 0 {
+			result = append(result, sqltypes.Value{})
+			continue
+		}
+		var val []byte
+		var ok bool
+		val, pos, ok = readBinaryValueAsText(data, pos, field)
+		if !ok {
+			return nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding binary value of column %v failed", i)
+		}
+		result = append(result, sqltypes.MakeTrusted(field.Type, val))
+	}
+	return result, nil
+}
+
+// readBinaryValueAsText reads a value in the binary protocol format, and
+// returns it formatted as in a text result set.
+func readBinaryValueAsText(data []byte, pos int, field *querypb.Field) ([]byte, int, bool) {
+	var val []byte
+	switch field.Type {
+	case sqltypes.Int8:
+		v, p, ok := readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendInt(nil, int64(int8(v)), 10), p
+	case sqltypes.Uint8:
+		v, p, ok := readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendUint(nil, uint64(v), 10), p
+	case sqltypes.Int16:
+		v, p, ok := readUint16(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendInt(nil, int64(int16(v)), 10), p
+	case sqltypes.Uint16:
+		v, p, ok := readUint16(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendUint(nil, uint64(v), 10), p
+	case sqltypes.Year:
+		v, p, ok := readUint16(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		return appendZeroPadded(nil, uint64(v), 4), p, true
+	case sqltypes.Int24, sqltypes.Int32:
+		v, p, ok := readUint32(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendInt(nil, int64(int32(v)), 10), p
+	case sqltypes.Uint24, sqltypes.Uint32:
+		v, p, ok := readUint32(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendUint(nil, uint64(v), 10), p
+	case sqltypes.Int64:
+		v, p, ok := readUint64(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendInt(nil, int64(v), 10), p
+	case sqltypes.Uint64:
+		v, p, ok := readUint64(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		val, pos = strconv.AppendUint(nil, v, 10), p
+	case sqltypes.Timestamp, sqltypes.Date, sqltypes.Datetime:
+		return readBinaryDateTimeAsText(data, pos, field)
+	case sqltypes.Time:
+		return readBinaryTimeAsText(data, pos, field)
+	default:
+		return readLenEncStringAsBytesCopy(data, pos)
+	}
+	// ZEROFILL integers are padded to the display width of the column.
+	if field.Flags&uint32(querypb.MySqlFlag_ZEROFILL_FLAG) != 0 && len(val) < int(field.ColumnLength) {
+		padded := make([]byte, int(field.ColumnLength)-len(val), int(field.ColumnLength))
+		for i := range padded {
+			padded[i] = '0'
+		}
+		val = append(padded, val...)
+	}
+	return val, pos, true
+}
+
+// readBinaryDateTimeAsText reads a DATE, DATETIME or TIMESTAMP value, and
+// returns it formatted as "YYYY-MM-DD[ hh:mm:ss[.fraction]]", with as many
+// fractional digits as the column has.
+func readBinaryDateTimeAsText(data []byte, pos int, field *querypb.Field) ([]byte, int, bool) {
+	size, pos, ok := readByte(data, pos)
+	if !ok {
+		return nil, 0, false
+	}
+	var year uint16
+	var month, day, hour, minute, second byte
+	var microSecond uint32
+	switch size {
+	case 0x00:
+	case 0x04, 0x07, 0x0b:
+		year, pos, ok = readUint16(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		month, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		day, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		if size == 0x04 {
+			break
+		}
+		hour, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		minute, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		second, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		if size == 0x07 {
+			break
+		}
+		microSecond, pos, ok = readUint32(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+	default:
+		return nil, 0, false
+	}
+
+	val := make([]byte, 0, 26)
+	val = appendZeroPadded(val, uint64(year), 4)
+	val = append(val, '-')
+	val = appendZeroPadded(val, uint64(month), 2)
+	val = append(val, '-')
+	val = appendZeroPadded(val, uint64(day), 2)
+	if field.Type != sqltypes.Date {
+		val = append(val, ' ')
+		val = appendClock(val, uint64(hour), minute, second, microSecond, field.Decimals)
+	}
+	return val, pos, true
+}
+
+// readBinaryTimeAsText reads a TIME value, and returns it formatted as
+// "[-]hh:mm:ss[.fraction]", with as many fractional digits as the column has.
+func readBinaryTimeAsText(data []byte, pos int, field *querypb.Field) ([]byte, int, bool) {
+	size, pos, ok := readByte(data, pos)
+	if !ok {
+		return nil, 0, false
+	}
+	var negative, hour, minute, second byte
+	var days, microSecond uint32
+	switch size {
+	case 0x00:
+	case 0x08, 0x0c:
+		negative, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		days, pos, ok = readUint32(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		hour, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		minute, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		second, pos, ok = readByte(data, pos)
+		if !ok {
+			return nil, 0, false
+		}
+		if size == 0x0c {
+			microSecond, pos, ok = readUint32(data, pos)
+			if !ok {
+				return nil, 0, false
+			}
+		}
+	default:
+		return nil, 0, false
+	}
+
+	val := make([]byte, 0, 17)
+	if negative == 0x01 {
+		val = append(val, '-')
+	}
+	val = appendClock(val, uint64(days)*24+uint64(hour), minute, second, microSecond, field.Decimals)
+	return val, pos, true
+}
+
+// appendClock appends "hh:mm:ss", followed by the first decimals digits of
+// the microseconds.
+func appendClock(val []byte, hour uint64, minute, second byte, microSecond uint32, decimals uint32) []byte {
+	val = appendZeroPadded(val, hour, 2)
+	val = append(val, ':')
+	val = appendZeroPadded(val, uint64(minute), 2)
+	val = append(val, ':')
+	val = appendZeroPadded(val, uint64(second), 2)
+	if decimals > 0 {
+		val = append(val, '.')
+		frac := appendZeroPadded(nil, uint64(microSecond), 6)
+		val = append(val, frac[:decimals]...)
+	}
+	return val
+}
+
+// appendZeroPadded appends v padded with leading zeroes to width digits.
+func appendZeroPadded(val []byte, v uint64, width int) []byte {
+	digits := 1
+	for n := v; n >= 10; n /= 10 {
+		digits++
+	}
+	for ; digits < width; digits++ {
+		val = append(val, '0')
+	}
+	return strconv.AppendUint(val, v, 10)
+}
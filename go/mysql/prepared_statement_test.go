@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// preparedTestHandler prepares every query with the configured fields and
+// parameter count, and returns result for every execution.
+type preparedTestHandler struct {
+	testHandler
+	fields     []*querypb.Field
+	paramCount uint16
+	bindVars   map[string]*querypb.BindVariable
+}
+
+func (th *preparedTestHandler) ComPrepare(*Conn, string) ([]*querypb.Field, uint16, error) {
+	return th.fields, th.paramCount, nil
+}
+
+func (th *preparedTestHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	th.bindVars = prepare.BindVars
+	return callback(th.result)
+}
+
+func TestPreparedStatement(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	fields := []*querypb.Field{
+		{Name: "id", Type: querypb.Type_INT64, ColumnLength: 20},
+		{Name: "zf", Type: querypb.Type_UINT32, ColumnLength: 4, Flags: uint32(querypb.MySqlFlag_UNSIGNED_FLAG | querypb.MySqlFlag_ZEROFILL_FLAG)},
+		{Name: "name", Type: querypb.Type_VARCHAR, ColumnLength: 64, Charset: 255},
+		{Name: "price", Type: querypb.Type_DECIMAL, ColumnLength: 10, Decimals: 2},
+		{Name: "y", Type: querypb.Type_YEAR, ColumnLength: 4},
+		{Name: "d", Type: querypb.Type_DATE, ColumnLength: 10},
+		{Name: "dt", Type: querypb.Type_DATETIME, ColumnLength: 23, Decimals: 3},
+		{Name: "zdt", Type: querypb.Type_DATETIME, ColumnLength: 19},
+		{Name: "t", Type: querypb.Type_TIME, ColumnLength: 12, Decimals: 1},
+		{Name: "n", Type: querypb.Type_VARCHAR, ColumnLength: 64, Charset: 255},
+	}
+	handler := &preparedTestHandler{fields: fields, paramCount: 4}
+	handler.result = &sqltypes.Result{
+		Fields: fields,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt64(-42),
+			sqltypes.NewUint32(7),
+			sqltypes.NewVarChar("abc"),
+			sqltypes.NewDecimal("12.50"),
+			sqltypes.MakeTrusted(querypb.Type_YEAR, []byte("0")),
+			sqltypes.NewDate("2020-01-02"),
+			sqltypes.NewDatetime("2020-01-02 03:04:05.120"),
+			sqltypes.NewDatetime("0000-00-00 00:00:00"),
+			sqltypes.NewTime("-25:01:02.5"),
+			sqltypes.NULL,
+		}},
+	}
+
+	var wg sync.WaitGroup
+	var stmt *PreparedStatement
+	var err error
+	wg.Go(func() {
+		stmt, err = cConn.Prepare("select * from t where id = ? and name = ? and price = ? and b = ?")
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, 4, stmt.ParamCount)
+
+	var result *sqltypes.Result
+	wg.Go(func() {
+		result, err = cConn.ExecutePrepared(stmt, []sqltypes.Value{
+			sqltypes.NewInt64(5),
+			sqltypes.NewVarChar("x"),
+			sqltypes.NewFloat64(1.5),
+			sqltypes.NULL,
+		}, FETCH_ALL_ROWS, true)
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*querypb.BindVariable{
+		"v1": sqltypes.Int64BindVariable(5),
+		"v2": sqltypes.StringBindVariable("x"),
+		"v3": sqltypes.DecimalBindVariable("1.5"),
+		"v4": sqltypes.NullBindVariable,
+	}, handler.bindVars)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, `[INT64(-42) UINT32(0007) VARCHAR("abc") DECIMAL(12.50) YEAR(0000) DATE("2020-01-02") DATETIME("2020-01-02 03:04:05.120") DATETIME("0000-00-00 00:00:00") TIME("-25:01:02.5") NULL]`, fmt.Sprint(result.Rows[0]))
+	assert.Len(t, result.Fields, len(fields))
+
+	// Arguments without a parameter type of the same meaning are not sent.
+	_, err = cConn.ExecutePrepared(stmt, []sqltypes.Value{
+		sqltypes.NewHexNum([]byte("0x01")),
+		sqltypes.NewVarChar("x"),
+		sqltypes.NewInt64(1),
+		sqltypes.NULL,
+	}, FETCH_ALL_ROWS, true)
+	assert.ErrorIs(t, err, ErrPreparedStatementUnsupported)
+
+	require.NoError(t, cConn.ClosePrepared(stmt))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Empty(t, sConn.PrepareData)
+}
+
+func TestPreparedStatementUnsupportedFields(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Floating point values are formatted differently in the binary protocol,
+	// so the statement is closed again right away.
+	handler := &preparedTestHandler{fields: []*querypb.Field{
+		{Name: "f", Type: querypb.Type_FLOAT64, ColumnLength: 22},
+	}}
+
+	var wg sync.WaitGroup
+	var err error
+	wg.Go(func() {
+		_, err = cConn.Prepare("select f from t")
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	assert.ErrorIs(t, err, ErrPreparedStatementUnsupported)
+	require.Len(t, sConn.PrepareData, 1)
+
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Empty(t, sConn.PrepareData)
+}
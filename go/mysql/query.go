@@ -17,12 +17,15 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"iter"
 	"math"
 	"strconv"
 	"strings"
 
+	"vitess.io/vitess/go/hack"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
@@ -55,12 +58,42 @@ const (
 // Client -> Server.
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) WriteComQuery(query string) error {
+	return c.WriteComQueryWithAttributes(query, nil)
+}
+
+// WriteComQueryWithAttributes writes a query for the server to execute,
+// along with query attributes.
+// Client -> Server.
+// Returns SQLError(CRServerGone) if it can't, or
+// ErrQueryAttributesUnsupported if attributes are given but the server
+// does not support them, or one of them cannot be encoded.
+func (c *Conn) WriteComQueryWithAttributes(query string, attributes []QueryAttribute) error {
+	length := 1 + len(query)
+	var params []preparedParam
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+		var err error
+		if params, err = bindQueryAttributes(attributes); err != nil {
+			return err
+		}
+		// The parameter count and the parameter set count.
+		length += lenEncIntSize(uint64(len(attributes))) + 1
+		length += queryAttributesLen(attributes, params)
+	} else if len(attributes) > 0 {
+		return ErrQueryAttributesUnsupported
+	}
+
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
-	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
+	data, pos := c.startEphemeralPacketWithHeader(length)
 	data[pos] = ComQuery
 	pos++
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+		pos = writeLenEncInt(data, pos, uint64(len(attributes)))
+		// There is always one parameter set.
+		pos = writeByte(data, pos, 1)
+		pos = writeQueryAttributes(data, pos, attributes, params)
+	}
 	copy(data[pos:], query)
 	if err := c.writeEphemeralPacket(); err != nil {
 		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
@@ -316,7 +349,14 @@ func (c *Conn) parseRow(data []byte, fields []*querypb.Field, reader func([]byte
 //	 2. if the server closes the connection when a command is in flight,
 //	    readComQueryResponse will fail, and we'll return CRServerLost(2013).
 func (c *Conn) ExecuteFetch(query string, maxrows int, wantfields bool) (result *sqltypes.Result, err error) {
-	result, more, err := c.ExecuteFetchMulti(query, maxrows, wantfields)
+	return c.ExecuteFetchWithAttributes(query, nil, maxrows, wantfields)
+}
+
+// ExecuteFetchWithAttributes is ExecuteFetch, with query attributes sent
+// along with the query. The server must support query attributes if any
+// are given.
+func (c *Conn) ExecuteFetchWithAttributes(query string, attributes []QueryAttribute, maxrows int, wantfields bool) (result *sqltypes.Result, err error) {
+	result, more, err := c.executeFetchMulti(query, attributes, maxrows, wantfields)
 	if more {
 		// Multiple results are unexpected. Prioritize this "unexpected" error over whatever error we got from the first result.
 		err = errors.Join(ErrExecuteFetchMultipleResults, err)
@@ -351,6 +391,41 @@ func (c *Conn) drainMoreResults(more bool, err error) error {
 // It returns an additional 'more' flag. If it is set, you must fetch the additional
 // results using ReadQueryResult.
 func (c *Conn) ExecuteFetchMulti(query string, maxrows int, wantfields bool) (result *sqltypes.Result, more bool, err error) {
+	return c.executeFetchMulti(query, nil, maxrows, wantfields)
+}
+
+// ExecuteFetchResults executes a query that may return more than one result,
+// like multiple statements or the call of a stored procedure, and returns an
+// iterator over its results. Each result has the status flags of the packet
+// that concluded it: the flags of the last one tell the state of the session
+// after the query, like whether a transaction is still open. maxrows applies
+// to each result. The iteration ends after the first error. If the caller
+// stops iterating early, the remaining results are drained to keep the
+// connection usable.
+func (c *Conn) ExecuteFetchResults(query string, maxrows int, wantfields bool) iter.Seq2[*sqltypes.Result, error] {
+	return func(yield func(*sqltypes.Result, error) bool) {
+		result, more, err := c.executeFetchMulti(query, nil, maxrows, wantfields)
+		for {
+			if err != nil {
+				if sqlerr, ok := err.(*sqlerror.SQLError); ok {
+					sqlerr.Query = sqlparser.TruncateQuery(query, c.truncateErrLen)
+				}
+				yield(nil, err)
+				return
+			}
+			if !yield(result, nil) {
+				_ = c.drainMoreResults(more, nil)
+				return
+			}
+			if !more {
+				return
+			}
+			result, more, _, err = c.ReadQueryResult(maxrows, wantfields)
+		}
+	}
+}
+
+func (c *Conn) executeFetchMulti(query string, attributes []QueryAttribute, maxrows int, wantfields bool) (result *sqltypes.Result, more bool, err error) {
 	defer func() {
 		if err != nil {
 			if sqlerr, ok := err.(*sqlerror.SQLError); ok {
@@ -360,7 +435,7 @@ func (c *Conn) ExecuteFetchMulti(query string, maxrows int, wantfields bool) (re
 	}()
 
 	// Send the query as a COM_QUERY packet.
-	if err = c.WriteComQuery(query); err != nil {
+	if err = c.WriteComQueryWithAttributes(query, attributes); err != nil {
 		return nil, false, err
 	}
 
@@ -394,6 +469,14 @@ func (c *Conn) ExecuteFetchWithWarningCount(query string, maxrows int, wantfield
 
 // ReadQueryResult gets the result from the last written query.
 func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result, bool, uint16, error) {
+	return c.readQueryResult(maxrows, wantfields, false)
+}
+
+// readQueryResult gets the result from the last written query, or the last
+// executed prepared statement if binary is set: prepared statements return
+// their rows in the binary protocol format.
+func (c *Conn) readQueryResult(maxrows int, wantfields bool, binary bool) (*sqltypes.Result, bool, uint16, error) {
+	c.lastWarnings = 0
 	var packetOk PacketOK
 	// Get the result.
 	colNumber, err := c.readComQueryResponse(&packetOk)
@@ -403,6 +486,7 @@ func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result,
 	more := packetOk.statusFlags&ServerMoreResultsExists != 0
 	warnings := packetOk.warnings
 	if colNumber == 0 {
+		c.lastWarnings = warnings
 		// OK packet, means no results. Just use the numbers.
 		return &sqltypes.Result{
 			RowsAffected:        packetOk.affectedRows,
@@ -424,7 +508,8 @@ func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result,
 	for i := range colNumber {
 		result.Fields[i] = &fields[i]
 
-		if wantfields {
+		// Decoding binary rows needs the full column definitions.
+		if wantfields || binary {
 			if err := c.readColumnDefinition(result.Fields[i], i); err != nil {
 				return nil, false, 0, err
 			}
@@ -455,6 +540,13 @@ func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result,
 		}
 	}
 
+	if binary && !binaryFieldsSupported(result.Fields) {
+		if err := c.drainResults(); err != nil {
+			return nil, false, 0, err
+		}
+		return nil, false, 0, ErrPreparedStatementUnsupported
+	}
+
 	// read each row until EOF or OK packet.
 	for {
 		data, err := c.readEphemeralPacket()
@@ -491,6 +583,7 @@ func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result,
 				result.StatusFlags = packetEof.statusFlags
 				result.Info = packetEof.info
 			}
+			c.lastWarnings = warnings
 			return result, more, warnings, nil
 		} else if isErrorPacket(data) {
 			defer c.recycleReadPacket()
@@ -513,7 +606,12 @@ func (c *Conn) ReadQueryResult(maxrows int, wantfields bool) (*sqltypes.Result,
 		}
 
 		// Regular row.
-		row, err := c.parseRow(data, result.Fields, readLenEncStringAsBytesCopy, nil)
+		var row []sqltypes.Value
+		if binary {
+			row, err = parseBinaryRow(data, result.Fields)
+		} else {
+			row, err = c.parseRow(data, result.Fields, readLenEncStringAsBytesCopy, nil)
+		}
 		if err != nil {
 			c.recycleReadPacket()
 			return nil, false, 0, err
@@ -575,8 +673,18 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+// parseComQuery parses a COM_QUERY packet, and returns its query. The query
+// attributes it carries are stored on the connection.
+func (c *Conn) parseComQuery(data []byte) (string, error) {
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(data[1:]), nil
+	}
+	attributes, pos, err := c.parseComQueryAttributes(data, 1)
+	if err != nil {
+		return "", err
+	}
+	c.queryAttributes = attributes
+	return string(data[pos:]), nil
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -618,8 +726,25 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
 	}
 
-	if prepare.ParamsCount > 0 {
-		bitMap, pos, ok = readBytes(payload, pos, (int(prepare.ParamsCount)+7)/8)
+	// With query attributes, the parameter count is sent, and the query
+	// attributes follow the parameters of the statement.
+	paramCount := int(prepare.ParamsCount)
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 && (paramCount > 0 || cursorType&ParameterCountAvailable != 0) {
+		var count uint64
+		count, pos, ok = readLenEncInt(payload, pos)
+		if !ok {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+		}
+		if count < uint64(paramCount) || count > uint64(len(payload)) {
+			return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "invalid parameter count %v", count)
+		}
+		paramCount = int(count)
+	}
+	attributes := make([]QueryAttribute, paramCount-int(prepare.ParamsCount))
+	attributeTypes := make([]querypb.Type, len(attributes))
+
+	if paramCount > 0 {
+		bitMap, pos, ok = readBytes(payload, pos, (paramCount+7)/8)
 		if !ok {
 			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
 		}
@@ -627,26 +752,30 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 
 	newParamsBoundFlag, pos, ok := readByte(payload, pos)
 	if ok && newParamsBoundFlag == 0x01 {
-		var mysqlType, flags byte
-		for i := range prepare.ParamsCount {
-			mysqlType, pos, ok = readByte(payload, pos)
+		for i := range paramCount {
+			valType, p, ok := c.parseParamType(payload, pos)
 			if !ok {
 				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
 			}
+			pos = p
 
-			flags, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
-			}
-
-			// convert MySQL type to internal type.
-			valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
-			if err != nil {
-				return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+			if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+				var name string
+				name, pos, ok = readLenEncString(payload, pos)
+				if !ok {
+					return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter name failed")
+				}
+				if i >= int(prepare.ParamsCount) {
+					attributes[i-int(prepare.ParamsCount)].Name = name
+					attributeTypes[i-int(prepare.ParamsCount)] = valType
+					continue
+				}
 			}
 
 			prepare.ParamsType[i] = int32(valType)
 		}
+	} else if len(attributes) > 0 {
+		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes have no type")
 	}
 
 	for i := range prepare.ParamsCount {
@@ -670,6 +799,13 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		prepare.BindVars[parameterID] = sqltypes.ValueBindVariable(val)
 	}
 
+	if len(attributes) > 0 {
+		if _, ok = c.parseQueryAttributeValues(payload, pos, bitMap, int(prepare.ParamsCount), attributes, attributeTypes); !ok {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed")
+		}
+		c.queryAttributes = attributes
+	}
+
 	return stmtID, cursorType, nil
 }
 
@@ -952,6 +1088,15 @@ func (c *Conn) parseComStmtReset(data []byte) (uint32, bool) {
 	return val, ok
 }
 
+func (c *Conn) parseComStmtFetch(data []byte) (stmtID uint32, numRows uint32, ok bool) {
+	stmtID, pos, ok := readUint32(data, 1)
+	if !ok {
+		return 0, 0, false
+	}
+	numRows, _, ok = readUint32(data, pos)
+	return stmtID, numRows, ok
+}
+
 func (c *Conn) parseComInitDB(data []byte) string {
 	return string(data[1:])
 }
@@ -1037,18 +1182,10 @@ func (c *Conn) writeRow(row []sqltypes.Value) error {
 // writeFields writes the fields of a Result. It should be called only
 // if there are valid columns in the result.
 func (c *Conn) writeFields(result *sqltypes.Result) error {
-	// Send the number of fields first.
-	if err := c.sendColumnCount(uint64(len(result.Fields))); err != nil {
+	if err := c.writeColumnDefinitions(result.Fields); err != nil {
 		return err
 	}
 
-	// Now send each Field.
-	for _, field := range result.Fields {
-		if err := c.writeColumnDefinition(field); err != nil {
-			return err
-		}
-	}
-
 	// Now send an EOF packet.
 	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
 		// With CapabilityClientDeprecateEOF, we do not send this EOF.
@@ -1059,6 +1196,23 @@ func (c *Conn) writeFields(result *sqltypes.Result) error {
 	return nil
 }
 
+// writeColumnDefinitions sends the number of fields, followed by the
+// definition of each of them.
+func (c *Conn) writeColumnDefinitions(fields []*querypb.Field) error {
+	// Send the number of fields first.
+	if err := c.sendColumnCount(uint64(len(fields))); err != nil {
+		return err
+	}
+
+	// Now send each Field.
+	for _, field := range fields {
+		if err := c.writeColumnDefinition(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // writeRows sends the rows of a Result.
 func (c *Conn) writeRows(result *sqltypes.Result) error {
 	for _, row := range result.Rows {
@@ -1211,12 +1365,12 @@ func (c *Conn) writeBinaryRow(fields []*querypb.Field, row []sqltypes.Value) err
 			bitPos := (i + 2) % 8
 			data[bytePos] |= 1 << uint(bitPos)
 		} else {
-			v, err := val2MySQL(val)
+			var err error
+			pos, err = writeBinaryValue(data, pos, val)
 			if err != nil {
 				c.recycleWritePacket()
 				return fmt.Errorf("internal value %v to MySQL value error: %v", val, err)
 			}
-			pos += copy(data[pos:], v)
 		}
 	}
 
@@ -1269,317 +1423,256 @@ func isZeroDateTime(raw []byte) bool {
 	}
 }
 
+// val2MySQL returns the binary protocol encoding of v.
 func val2MySQL(v sqltypes.Value) ([]byte, error) {
-	var out []byte
-	pos := 0
+	length, err := val2MySQLLen(v)
+	if err != nil {
+		return []byte{}, err
+	}
+	out := make([]byte, length)
+	if _, err := writeBinaryValue(out, 0, v); err != nil {
+		return []byte{}, err
+	}
+	return out, nil
+}
+
+// writeBinaryValue writes the binary protocol encoding of v into data at pos,
+// and returns the position after it. data must have room for val2MySQLLen(v)
+// bytes. Numeric and temporal values are parsed straight from their raw bytes,
+// so nothing is allocated for them.
+func writeBinaryValue(data []byte, pos int, v sqltypes.Value) (int, error) {
+	raw := v.Raw()
 	switch v.Type() {
 	case sqltypes.Null:
 		// no-op
 	case sqltypes.Int8:
-		val, err := strconv.ParseInt(v.ToString(), 10, 8)
+		val, err := parseBinaryInt(raw, 8)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 1)
-		writeByte(out, pos, uint8(val))
+		pos = writeByte(data, pos, uint8(val))
 	case sqltypes.Uint8:
-		val, err := strconv.ParseUint(v.ToString(), 10, 8)
+		val, err := parseBinaryUint(raw, 8)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 1)
-		writeByte(out, pos, uint8(val))
+		pos = writeByte(data, pos, uint8(val))
 	case sqltypes.Uint16:
-		val, err := strconv.ParseUint(v.ToString(), 10, 16)
+		val, err := parseBinaryUint(raw, 16)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 2)
-		writeUint16(out, pos, uint16(val))
+		pos = writeUint16(data, pos, uint16(val))
 	case sqltypes.Int16, sqltypes.Year:
-		val, err := strconv.ParseInt(v.ToString(), 10, 16)
+		val, err := parseBinaryInt(raw, 16)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 2)
-		writeUint16(out, pos, uint16(val))
+		pos = writeUint16(data, pos, uint16(val))
 	case sqltypes.Uint24, sqltypes.Uint32:
-		val, err := strconv.ParseUint(v.ToString(), 10, 32)
+		val, err := parseBinaryUint(raw, 32)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 4)
-		writeUint32(out, pos, uint32(val))
+		pos = writeUint32(data, pos, uint32(val))
 	case sqltypes.Int24, sqltypes.Int32:
-		val, err := strconv.ParseInt(v.ToString(), 10, 32)
+		val, err := parseBinaryInt(raw, 32)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 4)
-		writeUint32(out, pos, uint32(val))
+		pos = writeUint32(data, pos, uint32(val))
 	case sqltypes.Float32:
-		val, err := strconv.ParseFloat(v.ToString(), 32)
+		val, err := strconv.ParseFloat(hack.String(raw), 32)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		bits := math.Float32bits(float32(val))
-		out = make([]byte, 4)
-		writeUint32(out, pos, bits)
+		pos = writeUint32(data, pos, math.Float32bits(float32(val)))
 	case sqltypes.Uint64:
-		val, err := strconv.ParseUint(v.ToString(), 10, 64)
+		val, err := parseBinaryUint(raw, 64)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 8)
-		writeUint64(out, pos, val)
+		pos = writeUint64(data, pos, val)
 	case sqltypes.Int64:
-		val, err := strconv.ParseInt(v.ToString(), 10, 64)
+		val, err := parseBinaryInt(raw, 64)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		out = make([]byte, 8)
-		writeUint64(out, pos, uint64(val))
+		pos = writeUint64(data, pos, uint64(val))
 	case sqltypes.Float64:
-		val, err := strconv.ParseFloat(v.ToString(), 64)
+		val, err := strconv.ParseFloat(hack.String(raw), 64)
 		if err != nil {
-			return []byte{}, err
+			return pos, err
 		}
-		bits := math.Float64bits(val)
-		out = make([]byte, 8)
-		writeUint64(out, pos, bits)
+		pos = writeUint64(data, pos, math.Float64bits(val))
 	case sqltypes.Timestamp, sqltypes.Date, sqltypes.Datetime:
-		if isZeroDateTime(v.Raw()) {
-			out = make([]byte, 1)
-			out[pos] = 0x00
-		} else if len(v.Raw()) > 19 {
-			out = make([]byte, 1+11)
-			out[pos] = 0x0b
-			pos++
-			year, err := strconv.ParseUint(string(v.Raw()[0:4]), 10, 16)
-			if err != nil {
-				return []byte{}, err
-			}
-			month, err := strconv.ParseUint(string(v.Raw()[5:7]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			day, err := strconv.ParseUint(string(v.Raw()[8:10]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			hour, err := strconv.ParseUint(string(v.Raw()[11:13]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			minute, err := strconv.ParseUint(string(v.Raw()[14:16]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			second, err := strconv.ParseUint(string(v.Raw()[17:19]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			val := make([]byte, 6)
-			count := copy(val, v.Raw()[20:])
-			for i := range 6 - count {
-				val[count+i] = 0x30
-			}
-			microSecond, err := strconv.ParseUint(string(val), 10, 32)
-			if err != nil {
-				return []byte{}, err
-			}
-			pos = writeUint16(out, pos, uint16(year))
-			pos = writeByte(out, pos, byte(month))
-			pos = writeByte(out, pos, byte(day))
-			pos = writeByte(out, pos, byte(hour))
-			pos = writeByte(out, pos, byte(minute))
-			pos = writeByte(out, pos, byte(second))
-			writeUint32(out, pos, uint32(microSecond))
-		} else if len(v.Raw()) > 10 {
-			out = make([]byte, 1+7)
-			out[pos] = 0x07
-			pos++
-			year, err := strconv.ParseUint(string(v.Raw()[0:4]), 10, 16)
-			if err != nil {
-				return []byte{}, err
-			}
-			month, err := strconv.ParseUint(string(v.Raw()[5:7]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			day, err := strconv.ParseUint(string(v.Raw()[8:10]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			hour, err := strconv.ParseUint(string(v.Raw()[11:13]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			minute, err := strconv.ParseUint(string(v.Raw()[14:16]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			second, err := strconv.ParseUint(string(v.Raw()[17:]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			pos = writeUint16(out, pos, uint16(year))
-			pos = writeByte(out, pos, byte(month))
-			pos = writeByte(out, pos, byte(day))
-			pos = writeByte(out, pos, byte(hour))
-			pos = writeByte(out, pos, byte(minute))
-			writeByte(out, pos, byte(second))
-		} else if len(v.Raw()) > 0 {
-			out = make([]byte, 1+4)
-			out[pos] = 0x04
-			pos++
-			year, err := strconv.ParseUint(string(v.Raw()[0:4]), 10, 16)
-			if err != nil {
-				return []byte{}, err
-			}
-			month, err := strconv.ParseUint(string(v.Raw()[5:7]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			day, err := strconv.ParseUint(string(v.Raw()[8:]), 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			pos = writeUint16(out, pos, uint16(year))
-			pos = writeByte(out, pos, byte(month))
-			writeByte(out, pos, byte(day))
-		} else {
-			out = make([]byte, 1)
-			out[pos] = 0x00
-		}
+		return writeBinaryDateTime(data, pos, raw)
 	case sqltypes.Time:
-		if string(v.Raw()) == "00:00:00" {
-			out = make([]byte, 1)
-			out[pos] = 0x00
-		} else if strings.Contains(string(v.Raw()), ".") {
-			out = make([]byte, 1+12)
-			out[pos] = 0x0c
-			pos++
-
-			sub1 := strings.Split(string(v.Raw()), ":")
-			if len(sub1) != 3 {
-				err := errors.New("incorrect time value, ':' is not found")
-				return []byte{}, err
-			}
-			sub2 := strings.Split(sub1[2], ".")
-			if len(sub2) != 2 {
-				err := errors.New("incorrect time value, '.' is not found")
-				return []byte{}, err
-			}
-
-			var total []byte
-			if strings.HasPrefix(sub1[0], "-") {
-				out[pos] = 0x01
-				total = []byte(sub1[0])
-				total = total[1:]
-			} else {
-				out[pos] = 0x00
-				total = []byte(sub1[0])
-			}
-			pos++
+		return writeBinaryTime(data, pos, raw)
+	case sqltypes.Decimal, sqltypes.Text, sqltypes.Blob, sqltypes.VarChar,
+		sqltypes.VarBinary, sqltypes.Char, sqltypes.Bit, sqltypes.Enum,
+		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON:
+		pos = writeLenEncInt(data, pos, uint64(len(raw)))
+		pos += copy(data[pos:], raw)
+	default:
+		pos += copy(data[pos:], raw)
+	}
+	return pos, nil
+}
 
-			h, err := strconv.ParseUint(string(total), 10, 32)
-			if err != nil {
-				return []byte{}, err
-			}
+// writeBinaryDateTime writes a DATE, DATETIME or TIMESTAMP value, formatted
+// as "YYYY-MM-DD[ hh:mm:ss[.ffffff]]", in the binary protocol format.
+func writeBinaryDateTime(data []byte, pos int, raw []byte) (int, error) {
+	if len(raw) == 0 || isZeroDateTime(raw) {
+		return writeByte(data, pos, 0x00), nil
+	}
 
-			days := uint32(h) / 24
-			hours := uint32(h) % 24
-			minute := sub1[1]
-			second := sub2[0]
-			microSecond := sub2[1]
+	dayEnd := len(raw)
+	if len(raw) > 10 {
+		dayEnd = 10
+	}
+	year, err := parseBinaryUint(raw[0:4], 16)
+	if err != nil {
+		return pos, err
+	}
+	month, err := parseBinaryUint(raw[5:7], 8)
+	if err != nil {
+		return pos, err
+	}
+	day, err := parseBinaryUint(raw[8:dayEnd], 8)
+	if err != nil {
+		return pos, err
+	}
+	if len(raw) <= 10 {
+		pos = writeByte(data, pos, 0x04)
+		pos = writeUint16(data, pos, uint16(year))
+		pos = writeByte(data, pos, byte(month))
+		return writeByte(data, pos, byte(day)), nil
+	}
 
-			minutes, err := strconv.ParseUint(minute, 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
+	secondEnd := len(raw)
+	if len(raw) > 19 {
+		secondEnd = 19
+	}
+	hour, err := parseBinaryUint(raw[11:13], 8)
+	if err != nil {
+		return pos, err
+	}
+	minute, err := parseBinaryUint(raw[14:16], 8)
+	if err != nil {
+		return pos, err
+	}
+	second, err := parseBinaryUint(raw[17:secondEnd], 8)
+	if err != nil {
+		return pos, err
+	}
+	if len(raw) <= 19 {
+		pos = writeByte(data, pos, 0x07)
+	} else {
+		pos = writeByte(data, pos, 0x0b)
+	}
+	pos = writeUint16(data, pos, uint16(year))
+	pos = writeByte(data, pos, byte(month))
+	pos = writeByte(data, pos, byte(day))
+	pos = writeByte(data, pos, byte(hour))
+	pos = writeByte(data, pos, byte(minute))
+	pos = writeByte(data, pos, byte(second))
+	if len(raw) <= 19 {
+		return pos, nil
+	}
 
-			seconds, err := strconv.ParseUint(second, 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			pos = writeUint32(out, pos, days)
-			pos = writeByte(out, pos, byte(hours))
-			pos = writeByte(out, pos, byte(minutes))
-			pos = writeByte(out, pos, byte(seconds))
-
-			val := make([]byte, 6)
-			count := copy(val, microSecond)
-			for i := range 6 - count {
-				val[count+i] = 0x30
-			}
-			microSeconds, err := strconv.ParseUint(string(val), 10, 32)
-			if err != nil {
-				return []byte{}, err
-			}
-			writeUint32(out, pos, uint32(microSeconds))
-		} else if len(v.Raw()) > 0 {
-			out = make([]byte, 1+8)
-			out[pos] = 0x08
-			pos++
+	microSecond, err := parseBinaryMicroseconds(raw[20:])
+	if err != nil {
+		return pos, err
+	}
+	return writeUint32(data, pos, microSecond), nil
+}
 
-			sub1 := strings.Split(string(v.Raw()), ":")
-			if len(sub1) != 3 {
-				err := errors.New("incorrect time value, ':' is not found")
-				return []byte{}, err
-			}
+// writeBinaryTime writes a TIME value, formatted as "[-]h:mm:ss[.ffffff]",
+// in the binary protocol format.
+func writeBinaryTime(data []byte, pos int, raw []byte) (int, error) {
+	if string(raw) == "00:00:00" {
+		return writeByte(data, pos, 0x00), nil
+	}
+	if len(raw) == 0 {
+		return pos, errors.New("incorrect time value")
+	}
 
-			var total []byte
-			if strings.HasPrefix(sub1[0], "-") {
-				out[pos] = 0x01
-				total = []byte(sub1[0])
-				total = total[1:]
-			} else {
-				out[pos] = 0x00
-				total = []byte(sub1[0])
-			}
-			pos++
+	total, rest, found := bytes.Cut(raw, []byte{':'})
+	minute, second, foundSecond := bytes.Cut(rest, []byte{':'})
+	if !found || !foundSecond || bytes.IndexByte(second, ':') >= 0 {
+		return pos, errors.New("incorrect time value, ':' is not found")
+	}
+	second, microSecond, hasFraction := bytes.Cut(second, []byte{'.'})
+	if bytes.IndexByte(raw, '.') >= 0 && (!hasFraction || bytes.IndexByte(microSecond, '.') >= 0) {
+		return pos, errors.New("incorrect time value, '.' is not found")
+	}
 
-			h, err := strconv.ParseUint(string(total), 10, 32)
-			if err != nil {
-				return []byte{}, err
-			}
+	var negative byte
+	if len(total) > 0 && total[0] == '-' {
+		negative = 0x01
+		total = total[1:]
+	}
+	h, err := parseBinaryUint(total, 32)
+	if err != nil {
+		return pos, err
+	}
+	minutes, err := parseBinaryUint(minute, 8)
+	if err != nil {
+		return pos, err
+	}
+	seconds, err := parseBinaryUint(second, 8)
+	if err != nil {
+		return pos, err
+	}
 
-			days := uint32(h) / 24
-			hours := uint32(h) % 24
-			minute := sub1[1]
-			second := sub1[2]
+	if hasFraction {
+		pos = writeByte(data, pos, 0x0c)
+	} else {
+		pos = writeByte(data, pos, 0x08)
+	}
+	pos = writeByte(data, pos, negative)
+	pos = writeUint32(data, pos, uint32(h)/24)
+	pos = writeByte(data, pos, byte(uint32(h)%24))
+	pos = writeByte(data, pos, byte(minutes))
+	pos = writeByte(data, pos, byte(seconds))
+	if !hasFraction {
+		return pos, nil
+	}
 
-			minutes, err := strconv.ParseUint(minute, 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
+	microSeconds, err := parseBinaryMicroseconds(microSecond)
+	if err != nil {
+		return pos, err
+	}
+	return writeUint32(data, pos, microSeconds), nil
+}
 
-			seconds, err := strconv.ParseUint(second, 10, 8)
-			if err != nil {
-				return []byte{}, err
-			}
-			pos = writeUint32(out, pos, days)
-			pos = writeByte(out, pos, byte(hours))
-			pos = writeByte(out, pos, byte(minutes))
-			writeByte(out, pos, byte(seconds))
-		} else {
-			err := errors.New("incorrect time value")
-			return []byte{}, err
+// parseBinaryMicroseconds parses the fractional seconds of a temporal value.
+// Only the first six digits are significant; shorter fractions are padded
+// with zeroes.
+func parseBinaryMicroseconds(frac []byte) (uint32, error) {
+	if len(frac) > 6 {
+		frac = frac[:6]
+	}
+	var val uint64
+	if len(frac) > 0 {
+		var err error
+		if val, err = parseBinaryUint(frac, 32); err != nil {
+			return 0, err
 		}
-	case sqltypes.Decimal, sqltypes.Text, sqltypes.Blob, sqltypes.VarChar,
-		sqltypes.VarBinary, sqltypes.Char, sqltypes.Bit, sqltypes.Enum,
-		sqltypes.Set, sqltypes.Geometry, sqltypes.Binary, sqltypes.TypeJSON:
-		l := len(v.Raw())
-		length := lenEncIntSize(uint64(l)) + l
-		out = make([]byte, length)
-		pos = writeLenEncInt(out, pos, uint64(l))
-		copy(out[pos:], v.Raw())
-	default:
-		out = make([]byte, len(v.Raw()))
-		copy(out, v.Raw())
 	}
-	return out, nil
+	for range 6 - len(frac) {
+		val *= 10
+	}
+	return uint32(val), nil
+}
+
+func parseBinaryInt(raw []byte, bitSize int) (int64, error) {
+	return strconv.ParseInt(hack.String(raw), 10, bitSize)
+}
+
+func parseBinaryUint(raw []byte, bitSize int) (uint64, error) {
+	return strconv.ParseUint(hack.String(raw), 10, bitSize)
 }
 
 func val2MySQLLen(v sqltypes.Value) (int, error) {
@@ -1612,7 +1705,7 @@ func val2MySQLLen(v sqltypes.Value) (int, error) {
 	case sqltypes.Time:
 		if string(v.Raw()) == "00:00:00" {
 			length = 1
-		} else if strings.Contains(string(v.Raw()), ".") {
+		} else if bytes.IndexByte(v.Raw(), '.') >= 0 {
 			length = 13
 		} else if len(v.Raw()) > 0 {
 			length = 9
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"vitess.io/vitess/go/mysql/binlog"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the encoding of query attributes: name and value pairs
+// that MySQL 8.0.23+ clients send along with COM_QUERY and COM_STMT_EXECUTE
+// when CLIENT_QUERY_ATTRIBUTES is negotiated. They are encoded like the
+// parameters of a prepared statement, with a name after each type.
+
+// ErrQueryAttributesUnsupported is returned when query attributes cannot be
+// sent: the server does not support them, or a value has no parameter type.
+var ErrQueryAttributesUnsupported = vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "query attributes cannot be sent")
+
+// QueryAttribute is a query attribute sent along with a query.
+type QueryAttribute struct {
+	Name  string
+	Value sqltypes.Value
+}
+
+// QueryAttributes returns the query attributes the client sent along with
+// the command being executed, if any.
+func (c *Conn) QueryAttributes() []QueryAttribute {
+	return c.queryAttributes
+}
+
+// bindQueryAttributes returns the parameters encoding the attribute values.
+func bindQueryAttributes(attributes []QueryAttribute) ([]preparedParam, error) {
+	params := make([]preparedParam, len(attributes))
+	for i, attr := range attributes {
+		if !params[i].bind(attr.Value) {
+			return nil, ErrQueryAttributesUnsupported
+		}
+	}
+	return params, nil
+}
+
+// queryAttributesLen returns the length of the encoding of attributes after
+// their count: the NULL bitmap, the types and names, and the values.
+func queryAttributesLen(attributes []QueryAttribute, params []preparedParam) int {
+	if len(attributes) == 0 {
+		return 0
+	}
+	length := (len(attributes)+7)/8 + 1
+	for i, attr := range attributes {
+		length += 2 + lenEncStringSize(attr.Name) + params[i].len()
+	}
+	return length
+}
+
+// writeQueryAttributes writes attributes into data at pos, after their
+// count, and returns the position after them.
+func writeQueryAttributes(data []byte, pos int, attributes []QueryAttribute, params []preparedParam) int {
+	if len(attributes) == 0 {
+		return pos
+	}
+	bitmap := pos
+	pos += (len(attributes) + 7) / 8
+	clear(data[bitmap:pos])
+	for i, param := range params {
+		if param.typ == binlog.TypeNull {
+			data[bitmap+i/8] |= 1 << uint(i%8)
+		}
+	}
+	// The types are always sent.
+	pos = writeByte(data, pos, 0x01)
+	for i, param := range params {
+		pos = writeByte(data, pos, param.typ)
+		pos = writeByte(data, pos, param.flags)
+		pos = writeLenEncString(data, pos, attributes[i].Name)
+	}
+	for _, param := range params {
+		pos = param.write(data, pos)
+	}
+	return pos
+}
+
+// parseComQueryAttributes parses the query attributes of a COM_QUERY packet
+// starting at pos, and returns the position of the query after them.
+func (c *Conn) parseComQueryAttributes(data []byte, pos int) ([]QueryAttribute, int, error) {
+	count, pos, ok := readLenEncInt(data, pos)
+	if !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute count failed")
+	}
+	// The parameter set count is always 1.
+	if _, pos, ok = readLenEncInt(data, pos); !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute set count failed")
+	}
+	if count == 0 {
+		return nil, pos, nil
+	}
+	if count > uint64(len(data)) {
+		return nil, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "invalid query attribute count %v", count)
+	}
+
+	bitmap, pos, ok := readBytes(data, pos, (int(count)+7)/8)
+	if !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+	}
+	bound, pos, ok := readByte(data, pos)
+	if !ok || bound != 0x01 {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes have no type")
+	}
+	attributes := make([]QueryAttribute, count)
+	types := make([]querypb.Type, count)
+	for i := range attributes {
+		if types[i], pos, ok = c.parseParamType(data, pos); !ok {
+			return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute type failed")
+		}
+		if attributes[i].Name, pos, ok = readLenEncString(data, pos); !ok {
+			return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute name failed")
+		}
+	}
+	if pos, ok = c.parseQueryAttributeValues(data, pos, bitmap, 0, attributes, types); !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed")
+	}
+	return attributes, pos, nil
+}
+
+// parseParamType reads the type and flags of a parameter or query attribute,
+// and converts them to the internal type.
+func (c *Conn) parseParamType(data []byte, pos int) (querypb.Type, int, bool) {
+	mysqlType, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, 0, false
+	}
+	flags, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, 0, false
+	}
+	typ, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+	if err != nil {
+		return 0, 0, false
+	}
+	return typ, pos, true
+}
+
+// parseQueryAttributeValues reads the values of attributes, whose NULL bits
+// start at index first of bitmap.
+func (c *Conn) parseQueryAttributeValues(data []byte, pos int, bitmap []byte, first int, attributes []QueryAttribute, types []querypb.Type) (int, bool) {
+	for i := range attributes {
+		typ := types[i]
+		if n := first + i; bitmap[n/8]&(1<<uint(n%8)) != 0 {
+			typ = sqltypes.Null
+		}
+		var ok bool
+		if attributes[i].Value, pos, ok = c.parseStmtArgs(data, typ, pos); !ok {
+			return 0, false
+		}
+	}
+	return pos, true
+}
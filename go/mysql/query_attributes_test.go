@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/binlog"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// queryAttributesTestHandler records the query attributes of the last query
+// or prepared statement execution.
+type queryAttributesTestHandler struct {
+	preparedTestHandler
+	attributes []QueryAttribute
+}
+
+func (th *queryAttributesTestHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	th.attributes = c.QueryAttributes()
+	return callback(&sqltypes.Result{})
+}
+
+func (th *queryAttributesTestHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	th.attributes = c.QueryAttributes()
+	return th.preparedTestHandler.ComStmtExecute(c, prepare, callback)
+}
+
+func TestQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Query attributes cannot be sent until they are negotiated.
+	_, err := cConn.ExecuteFetchWithAttributes("select 1", []QueryAttribute{{Name: "a", Value: sqltypes.NewInt64(1)}}, 10, false)
+	assert.ErrorIs(t, err, ErrQueryAttributesUnsupported)
+
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+	cConn.Capabilities |= CapabilityClientQueryAttributes
+	handler := &queryAttributesTestHandler{}
+	handler.paramCount = 1
+	handler.result = &sqltypes.Result{}
+
+	var wg sync.WaitGroup
+	execute := func(query string, attributes []QueryAttribute) {
+		wg.Go(func() {
+			_, err = cConn.ExecuteFetchWithAttributes(query, attributes, 10, false)
+		})
+		require.True(t, sConn.handleNextCommand(handler))
+		wg.Wait()
+		require.NoError(t, err)
+	}
+
+	attributes := []QueryAttribute{
+		{Name: "traceparent", Value: sqltypes.NewVarChar("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")},
+		{Name: "priority", Value: sqltypes.NewInt64(-3)},
+		{Name: "weight", Value: sqltypes.NewDecimal("0.75")},
+		{Name: "empty", Value: sqltypes.NULL},
+	}
+	execute("select 1", attributes)
+	assert.Equal(t, attributes, handler.attributes)
+
+	// The attributes only apply to the query they are sent with.
+	execute("select 1", nil)
+	assert.Empty(t, handler.attributes)
+
+	// The statement parameters are named, and can be followed by query
+	// attributes.
+	var stmt *PreparedStatement
+	wg.Go(func() {
+		stmt, err = cConn.Prepare("select * from t where id = ?")
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	require.NoError(t, err)
+
+	wg.Go(func() {
+		_, err = cConn.ExecutePrepared(stmt, []sqltypes.Value{sqltypes.NewInt64(5)}, FETCH_ALL_ROWS, true)
+	})
+	require.True(t, sConn.handleNextCommand(handler))
+	wg.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*querypb.BindVariable{"v1": sqltypes.Int64BindVariable(5)}, handler.bindVars)
+	assert.Empty(t, handler.attributes)
+
+	// A client like libmysqlclient sends the attributes after the
+	// parameters.
+	prepare := sConn.PrepareData[stmt.ID]
+	prepare.BindVars = map[string]*querypb.BindVariable{}
+	data := []byte{ComStmtExecute}
+	data = append(data, byte(stmt.ID), byte(stmt.ID>>8), byte(stmt.ID>>16), byte(stmt.ID>>24))
+	data = append(data, ParameterCountAvailable, 1, 0, 0, 0)
+	// Two parameters, the second of which is NULL, and their types.
+	data = append(data, 2, 0x02, 0x01)
+	data = append(data, binlog.TypeLongLong, 0, 0)
+	data = append(data, binlog.TypeVarString, 0, 4, 'n', 'o', 'n', 'e')
+	data = append(data, 6, 0, 0, 0, 0, 0, 0, 0)
+	_, cursorType, err := sConn.parseComStmtExecute(sConn.PrepareData, data)
+	require.NoError(t, err)
+	assert.Equal(t, ParameterCountAvailable, cursorType)
+	assert.Equal(t, sqltypes.Int64BindVariable(6), prepare.BindVars["v1"])
+	assert.Equal(t, []QueryAttribute{{Name: "none", Value: sqltypes.NULL}}, sConn.QueryAttributes())
+}
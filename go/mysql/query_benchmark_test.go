@@ -22,6 +22,10 @@ import (
 	"net"
 	"strings"
 	"testing"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 // Override the default here to test with different values.
@@ -146,3 +150,66 @@ func BenchmarkParallelMediumQueriesWithReadBufferPooling(b *testing.B) {
 func BenchmarkParallelRandomQueriesWithReadBufferPooling(b *testing.B) {
 	benchmarkQuery(b, 10, "", mkReadBufferPoolingCfg)
 }
+
+// discardConn is a net.Conn that drops everything written to it.
+type discardConn struct {
+	net.Conn
+}
+
+func (discardConn) Write(b []byte) (int, error) { return len(b), nil }
+
+// benchmarkRowsResult returns a large result with numeric, temporal and
+// string columns.
+func benchmarkRowsResult() *sqltypes.Result {
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: querypb.Type_INT64},
+			{Name: "count", Type: querypb.Type_UINT32},
+			{Name: "price", Type: querypb.Type_FLOAT64},
+			{Name: "created", Type: querypb.Type_DATETIME},
+			{Name: "day", Type: querypb.Type_DATE},
+			{Name: "elapsed", Type: querypb.Type_TIME},
+			{Name: "name", Type: querypb.Type_VARCHAR},
+		},
+	}
+	for i := range 1000 {
+		result.Rows = append(result.Rows, []sqltypes.Value{
+			sqltypes.NewInt64(int64(i)),
+			sqltypes.NewUint32(uint32(i * 7)),
+			sqltypes.NewFloat64(float64(i) * 1.25),
+			sqltypes.MakeTrusted(querypb.Type_DATETIME, []byte("2020-01-02 03:04:05.123456")),
+			sqltypes.MakeTrusted(querypb.Type_DATE, []byte("2020-01-02")),
+			sqltypes.MakeTrusted(querypb.Type_TIME, []byte("-27:04:05")),
+			sqltypes.NewVarChar("benchmark row"),
+		})
+	}
+	return result
+}
+
+func BenchmarkWriteRows(b *testing.B) {
+	result := benchmarkRowsResult()
+	conn := newConn(discardConn{}, 0, 0)
+	conn.startWriterBuffering()
+	defer conn.endWriterBuffering()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := conn.writeRows(result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteBinaryRows(b *testing.B) {
+	result := benchmarkRowsResult()
+	conn := newConn(discardConn{}, 0, 0)
+	conn.startWriterBuffering()
+	defer conn.endWriterBuffering()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := conn.writeBinaryRows(result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -815,6 +815,60 @@ func TestVal2MySQLNonZeroDateTime(t *testing.T) {
 	require.Equal(t, byte(0x04), out[0])
 }
 
+// TestWriteBinaryValue verifies the binary protocol encoding of numeric and
+// temporal values, which are parsed straight from their raw bytes.
+func TestWriteBinaryValue(t *testing.T) {
+	testcases := []struct {
+		typ  querypb.Type
+		raw  string
+		want []byte
+	}{
+		{sqltypes.Int8, "-2", []byte{0xfe}},
+		{sqltypes.Uint8, "200", []byte{0xc8}},
+		{sqltypes.Int16, "-2", []byte{0xfe, 0xff}},
+		{sqltypes.Year, "2020", []byte{0xe4, 0x07}},
+		{sqltypes.Uint24, "65536", []byte{0x00, 0x00, 0x01, 0x00}},
+		{sqltypes.Int32, "-1", []byte{0xff, 0xff, 0xff, 0xff}},
+		{sqltypes.Uint64, "18446744073709551615", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{sqltypes.Int64, "258", []byte{0x02, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{sqltypes.Float32, "1.5", []byte{0x00, 0x00, 0xc0, 0x3f}},
+		{sqltypes.Float64, "1.5", []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f}},
+		{sqltypes.Decimal, "1.50", []byte{0x04, '1', '.', '5', '0'}},
+		{sqltypes.VarChar, "abc", []byte{0x03, 'a', 'b', 'c'}},
+		{sqltypes.Date, "2020-01-02", []byte{0x04, 0xe4, 0x07, 0x01, 0x02}},
+		{sqltypes.Datetime, "2020-01-02 03:04:05", []byte{0x07, 0xe4, 0x07, 0x01, 0x02, 0x03, 0x04, 0x05}},
+		{sqltypes.Datetime, "2020-01-02 03:04:05.5", []byte{0x0b, 0xe4, 0x07, 0x01, 0x02, 0x03, 0x04, 0x05, 0x20, 0xa1, 0x07, 0x00}},
+		{sqltypes.Timestamp, "2020-01-02 03:04:05.000001", []byte{0x0b, 0xe4, 0x07, 0x01, 0x02, 0x03, 0x04, 0x05, 0x01, 0x00, 0x00, 0x00}},
+		{sqltypes.Time, "00:00:00", []byte{0x00}},
+		{sqltypes.Time, "27:04:05", []byte{0x08, 0x00, 0x01, 0x00, 0x00, 0x00, 0x03, 0x04, 0x05}},
+		{sqltypes.Time, "-27:04:05.25", []byte{0x0c, 0x01, 0x01, 0x00, 0x00, 0x00, 0x03, 0x04, 0x05, 0x90, 0xd0, 0x03, 0x00}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.typ.String()+"/"+tc.raw, func(t *testing.T) {
+			v := sqltypes.MakeTrusted(tc.typ, []byte(tc.raw))
+			out, err := val2MySQL(v)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, out)
+
+			l, err := val2MySQLLen(v)
+			require.NoError(t, err)
+			require.Equal(t, len(out), l)
+		})
+	}
+
+	for _, v := range []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int8, []byte("300")),
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("abc")),
+		sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2020-01-02 03:04:05.5x")),
+		sqltypes.MakeTrusted(sqltypes.Time, []byte("27:04")),
+		sqltypes.MakeTrusted(sqltypes.Time, []byte("27.5:04:05")),
+		sqltypes.MakeTrusted(sqltypes.Time, []byte("")),
+	} {
+		_, err := val2MySQL(v)
+		require.Error(t, err, "%v", v)
+	}
+}
+
 // TestIsZeroDateTime pins the exact set of textual forms treated as the MySQL
 // zero temporal value. Only the canonical zero DATE/DATETIME/TIMESTAMP forms
 // (including all-zero fractional seconds) are zero; malformed values fall
@@ -18,6 +18,7 @@ package mysql
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"fmt"
 	"io"
@@ -137,9 +138,41 @@ type Handler interface {
 
 	ComResetConnection(c *Conn)
 
+	// ComStatistics is called when a connection receives a
+	// COM_STATISTICS request. The returned statistics are sent
+	// back to the client as a human readable string.
+	ComStatistics(c *Conn) ServerStatistics
+
+	// ComDebug is called when a connection receives a COM_DEBUG
+	// request. The handler should dump its internal state to the logs,
+	// or return an error if the user is not allowed to.
+	ComDebug(c *Conn) error
+
 	Env() *vtenv.Environment
 }
 
+// ServerStatistics is the snapshot of the server metrics returned
+// to a COM_STATISTICS request.
+type ServerStatistics struct {
+	Uptime      time.Duration
+	Threads     int64
+	Questions   int64
+	SlowQueries int64
+}
+
+// String formats the statistics like MySQL does, so that tools
+// parsing the response of mysqladmin status keep working. The
+// metrics that have no equivalent are reported as 0.
+func (s ServerStatistics) String() string {
+	uptime := int64(s.Uptime / time.Second)
+	var qps float64
+	if uptime > 0 {
+		qps = float64(s.Questions) / float64(uptime)
+	}
+	return fmt.Sprintf("Uptime: %d  Threads: %d  Questions: %d  Slow queries: %d  Opens: 0  Flush tables: 0  Open tables: 0  Queries per second avg: %.3f",
+		uptime, s.Threads, s.Questions, s.SlowQueries, qps)
+}
+
 // UnimplementedHandler implemnts all of the optional callbacks so as to satisy
 // the Handler interface. Intended to be embedded into your custom Handler
 // implementation without needing to define every callback and to help be forwards
@@ -150,6 +183,12 @@ func (UnimplementedHandler) NewConnection(*Conn)      {}
 func (UnimplementedHandler) ConnectionReady(*Conn)    {}
 func (UnimplementedHandler) ConnectionClosed(*Conn)   {}
 func (UnimplementedHandler) ComResetConnection(*Conn) {}
+func (UnimplementedHandler) ComDebug(*Conn) error     { return nil }
+
+// ComStatistics only reports the number of connections to the server.
+func (UnimplementedHandler) ComStatistics(*Conn) ServerStatistics {
+	return ServerStatistics{Threads: connCount.Get()}
+}
 
 // Listener is the MySQL server protocol listener.
 type Listener struct {
@@ -213,6 +252,27 @@ type Listener struct {
 	// RequireSecureTransport configures the server to reject connections from insecure clients
 	RequireSecureTransport bool
 
+	// CachingSha2PasswordPrivateKey is the RSA key of the full
+	// authentication of caching_sha2_password over insecure connections:
+	// the clients encrypt their password with its public key. If nil,
+	// caching_sha2_password is only offered over TLS and Unix sockets.
+	CachingSha2PasswordPrivateKey *rsa.PrivateKey
+
+	// CompressionAlgorithms are the protocol compression algorithms we
+	// advertise. Clients asking for one of them get a compressed
+	// connection once they are authenticated.
+	CompressionAlgorithms []CompressionAlgorithm
+
+	// AllowLocalInfile advertises CLIENT_LOCAL_FILES, so that the handler
+	// can ask the clients for the content of the files of LOAD DATA LOCAL
+	// INFILE statements.
+	AllowLocalInfile bool
+
+	// MaxCursorSize is the maximum size, in bytes, of the rows a read-only
+	// cursor keeps until the client fetches them. Executions opening a
+	// larger cursor fail. Zero means no limit.
+	MaxCursorSize int64
+
 	// PreHandleFunc is called for each incoming connection, immediately after
 	// accepting a new connection. By default it's no-op. Useful for custom
 	// connection inspection or TLS termination. The returned connection is
@@ -298,6 +358,12 @@ type ListenerConfig struct {
 	ConnKeepAlivePeriod time.Duration
 	FlushDelay          time.Duration
 	MultiQuery          bool
+	// CompressionAlgorithms are the protocol compression algorithms
+	// advertised to the clients. Compression is disabled if empty.
+	CompressionAlgorithms []CompressionAlgorithm
+	// AllowLocalInfile lets the handler ask the clients for the content
+	// of the files of LOAD DATA LOCAL INFILE statements.
+	AllowLocalInfile bool
 }
 
 // NewListenerWithConfig creates new listener using provided config. There are
@@ -315,20 +381,22 @@ func NewListenerWithConfig(cfg ListenerConfig) (*Listener, error) {
 	}
 
 	return &Listener{
-		authServer:          cfg.AuthServer,
-		handler:             cfg.Handler,
-		listener:            l,
-		ServerVersion:       cfg.Handler.Env().MySQLVersion(),
-		connectionID:        1,
-		connReadTimeout:     cfg.ConnReadTimeout,
-		connWriteTimeout:    cfg.ConnWriteTimeout,
-		connReadBufferSize:  cfg.ConnReadBufferSize,
-		connBufferPooling:   cfg.ConnBufferPooling,
-		connKeepAlivePeriod: cfg.ConnKeepAlivePeriod,
-		flushDelay:          cfg.FlushDelay,
-		multiQuery:          cfg.MultiQuery,
-		truncateErrLen:      cfg.Handler.Env().TruncateErrLen(),
-		charset:             cfg.Handler.Env().CollationEnv().DefaultConnectionCharset(),
+		authServer:            cfg.AuthServer,
+		handler:               cfg.Handler,
+		listener:              l,
+		ServerVersion:         cfg.Handler.Env().MySQLVersion(),
+		connectionID:          1,
+		connReadTimeout:       cfg.ConnReadTimeout,
+		connWriteTimeout:      cfg.ConnWriteTimeout,
+		connReadBufferSize:    cfg.ConnReadBufferSize,
+		connBufferPooling:     cfg.ConnBufferPooling,
+		connKeepAlivePeriod:   cfg.ConnKeepAlivePeriod,
+		flushDelay:            cfg.FlushDelay,
+		multiQuery:            cfg.MultiQuery,
+		CompressionAlgorithms: cfg.CompressionAlgorithms,
+		AllowLocalInfile:      cfg.AllowLocalInfile,
+		truncateErrLen:        cfg.Handler.Env().TruncateErrLen(),
+		charset:               cfg.Handler.Env().CollationEnv().DefaultConnectionCharset(),
 	}, nil
 }
 
@@ -337,6 +405,33 @@ func (l *Listener) Addr() net.Addr {
 	return l.listener.Addr()
 }
 
+// TrustProxyProtocolFrom restricts the PROXY protocol headers to the
+// connections from the given IP addresses and CIDR ranges, like the ones of
+// the HAProxy or load balancer instances in front of the listener. The
+// connections from other addresses are handled as plain MySQL connections,
+// so that clients can't spoof their address, and that the server does not
+// wait for a PROXY protocol header before it greets them. It must be called
+// before Accept, on a listener created with the PROXY protocol enabled.
+func (l *Listener) TrustProxyProtocolFrom(allowed []string) error {
+	pl, ok := l.listener.(*proxyproto.Listener)
+	if !ok {
+		return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "the PROXY protocol is not enabled on the listener")
+	}
+	trusted, err := proxyproto.ConnLaxWhiteListPolicy(allowed)
+	if err != nil {
+		return vterrors.Wrapf(err, "invalid PROXY protocol trusted address")
+	}
+	policy := func(opts proxyproto.ConnPolicyOptions) (proxyproto.Policy, error) {
+		policy, err := trusted(opts)
+		if policy == proxyproto.IGNORE {
+			return proxyproto.SKIP, nil
+		}
+		return policy, err
+	}
+	pl.ConnPolicy = policy
+	return nil
+}
+
 // Accept runs an accept loop until the listener is closed.
 func (l *Listener) Accept() {
 	ctx := context.Background()
@@ -379,6 +474,9 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	}
 	c := newServerConn(conn, l)
 	c.ConnectionID = connectionID
+	// CloseIfIdle leaves the connection alone until it is ready for
+	// commands.
+	c.commandMu.Lock()
 
 	// Catch panics, and close the connection in any case.
 	defer func() {
@@ -404,7 +502,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil, l.optionalCapabilities())
 	if err != nil {
 		if err != io.EOF {
 			log.Error(fmt.Sprintf("Cannot send HandshakeV10 packet to %s: %v", c, err))
@@ -547,6 +645,14 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		return
 	}
 
+	// Everything after the OK packet is compressed, if the client asked for it.
+	if compression := negotiatedCompression(c.Capabilities); compression != "" {
+		if err := c.enableCompression(compression, c.zstdCompressionLevel); err != nil {
+			log.Error(fmt.Sprintf("Cannot enable %s protocol compression for %s: %v", compression, c, err))
+			return
+		}
+	}
+
 	// Record how long we took to establish the connection
 	timings.Record(connectTimingKey, acceptTime)
 
@@ -560,6 +666,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	// Tell our handler that we're finished handshake and are ready to
 	// process commands.
 	l.handler.ConnectionReady(c)
+	c.commandMu.Unlock()
 
 	for {
 		kontinue := c.handleNextCommand(l.handler)
@@ -583,9 +690,21 @@ func (l *Listener) Shutdown() {
 	}
 }
 
+// optionalCapabilities returns the capability flags the listener advertises
+// depending on its configuration: the protocol compression algorithms, and
+// LOAD DATA LOCAL INFILE.
+func (l *Listener) optionalCapabilities() uint32 {
+	capabilities := compressionCapabilities(l.CompressionAlgorithms)
+	if l.AllowLocalInfile {
+		capabilities |= CapabilityClientLocalFiles
+	}
+	return capabilities
+}
+
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
-// It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool) ([]byte, error) {
+// optional holds the capability flags advertised depending on the
+// listener configuration, see optionalCapabilities. It returns the salt data.
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool, optional uint32) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -598,10 +717,12 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		CapabilityClientQueryAttributes
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
+	capabilities |= int(optional)
 
 	// Grab the default auth method. This can only be either
 	// mysql_native_password or caching_sha2_password. Both
@@ -726,6 +847,21 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		c.Capabilities |= CapabilityClientMultiStatements
 	}
 
+	// set connection capability for receiving query attributes
+	if clientFlags&CapabilityClientQueryAttributes > 0 {
+		c.Capabilities |= CapabilityClientQueryAttributes
+	}
+
+	// set connection capability for LOAD DATA LOCAL INFILE, if we allow it
+	if l.AllowLocalInfile && clientFlags&CapabilityClientLocalFiles > 0 {
+		c.Capabilities |= CapabilityClientLocalFiles
+	}
+
+	// Remember the protocol compression the client asked for, among
+	// the algorithms we advertised.
+	compression := clientFlags & compressionCapabilities(l.CompressionAlgorithms)
+	c.Capabilities = c.Capabilities&^(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) | compression
+
 	// Max packet size. Don't do anything with this now.
 	// See doc.go for more information.
 	_, pos, ok = readUint32(data, pos)
@@ -816,13 +952,24 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 	}
 
 	// Decode connection attributes send by the client
+	attrsOK := true
 	if clientFlags&CapabilityClientConnAttr != 0 {
-		clientAttributes, _, err := parseConnAttrs(data, pos)
+		clientAttributes, attrsEnd, err := parseConnAttrs(data, pos)
 		if err != nil {
 			log.Warn(fmt.Sprintf("Decode connection attributes send by the client: %v", err))
+			attrsOK = false
 		}
 
 		c.Attributes = clientAttributes
+		pos = attrsEnd
+	}
+
+	// The zstd compression level comes last.
+	if compression&CapabilityClientZstdCompressionAlgorithm != 0 {
+		c.zstdCompressionLevel = DefaultZstdCompressionLevel
+		if level, _, ok := readByte(data, pos); ok && attrsOK {
+			c.zstdCompressionLevel = int(level)
+		}
 	}
 
 	return username, AuthMethodDescription(authMethod), authResponse, nil
@@ -18,6 +18,8 @@ package mysql
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"fmt"
 	"net"
@@ -81,6 +83,35 @@ type testHandler struct {
 	result   *sqltypes.Result
 	err      error
 	warnings uint16
+	stats    *ServerStatistics
+	debugged bool
+	debugErr error
+	resets   int
+}
+
+func (th *testHandler) ComStatistics(c *Conn) ServerStatistics {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	if th.stats == nil {
+		return th.UnimplementedHandler.ComStatistics(c)
+	}
+	return *th.stats
+}
+
+func (th *testHandler) ComDebug(c *Conn) error {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	if th.debugErr != nil {
+		return th.debugErr
+	}
+	th.debugged = true
+	return nil
+}
+
+func (th *testHandler) ComResetConnection(c *Conn) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.resets++
 }
 
 func (th *testHandler) LastConn() *Conn {
@@ -351,6 +382,108 @@ func TestConnectionWithoutSourceHost(t *testing.T) {
 	c.Close()
 }
 
+func TestServerStatisticsAndDebug(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The default statistics only count the connections.
+	stats, err := c.Statistics()
+	require.NoError(t, err)
+	assert.Regexp(t, `^Uptime: 0  Threads: [1-9][0-9]*  Questions: 0  Slow queries: 0  Opens: 0  Flush tables: 0  Open tables: 0  Queries per second avg: 0\.000$`, stats)
+
+	th.mu.Lock()
+	th.stats = &ServerStatistics{
+		Uptime:      100 * time.Second,
+		Threads:     3,
+		Questions:   250,
+		SlowQueries: 2,
+	}
+	th.mu.Unlock()
+	stats, err = c.Statistics()
+	require.NoError(t, err)
+	assert.Equal(t, "Uptime: 100  Threads: 3  Questions: 250  Slow queries: 2  Opens: 0  Flush tables: 0  Open tables: 0  Queries per second avg: 2.500", stats)
+
+	require.NoError(t, c.DumpDebugInfo())
+	th.mu.Lock()
+	assert.True(t, th.debugged)
+	th.mu.Unlock()
+
+	// The connection is still usable.
+	require.NoError(t, c.Ping())
+
+	// A denied request returns the handler's error.
+	th.mu.Lock()
+	th.debugged = false
+	th.debugErr = sqlerror.NewSQLError(sqlerror.ERSpecifiedAccessDenied, sqlerror.SSAccessDeniedError, "Access denied")
+	th.mu.Unlock()
+	err = c.DumpDebugInfo()
+	require.ErrorContains(t, err, "Access denied")
+	th.mu.Lock()
+	assert.False(t, th.debugged)
+	th.mu.Unlock()
+	require.NoError(t, c.Ping())
+}
+
+func TestServerResetConnection(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.ResetConnection())
+	require.NoError(t, c.ResetConnection())
+	th.mu.Lock()
+	assert.Equal(t, 2, th.resets)
+	th.mu.Unlock()
+
+	// The connection is still usable.
+	require.NoError(t, c.Ping())
+}
+
 func TestConnectionWithSourceHost(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 	th := &testHandler{}
@@ -1297,6 +1430,61 @@ func TestCachingSha2PasswordAuthWithoutTLS(t *testing.T) {
 	require.ErrorContains(t, err, "No authentication methods available for authentication")
 }
 
+func TestCachingSha2PasswordAuthWithRSAKey(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	// The mysql_native_password hash of "secret" can't be used for the
+	// fast path of caching_sha2_password, so the first connection goes
+	// through the full authentication.
+	authServer := NewAuthServerStaticWithAuthMethodDescription("", "", 0, CachingSha2Password)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{
+		{MysqlNativePassword: "*14E65567ABDB5135D0CFD9A70B3032C179A49EE7"},
+	}
+	defer authServer.close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err, "NewListener failed: %v", err)
+	l.CachingSha2PasswordPrivateKey = key
+	host := l.Addr().(*net.TCPAddr).IP.String()
+	port := l.Addr().(*net.TCPAddr).Port
+	params := &ConnParams{
+		Host:    host,
+		Port:    port,
+		Uname:   "user1",
+		Pass:    "secret",
+		SslMode: vttls.Disabled,
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	connect := func() {
+		t.Helper()
+		conn, err := Connect(ctx, params)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		result, err := conn.ExecuteFetch("select rows", 10000, true)
+		require.NoError(t, err)
+		utils.MustMatch(t, result, selectRowsResult)
+		conn.writeComQuit()
+	}
+	connect()
+	authServer.mu.Lock()
+	assert.Len(t, authServer.sha2Cache, 1)
+	authServer.mu.Unlock()
+
+	// The second connection takes the fast path.
+	connect()
+
+	// A wrong password is rejected by the full authentication.
+	params.Pass = "wrong"
+	_, err = Connect(ctx, params)
+	require.ErrorContains(t, err, "Access denied for user 'user1'")
+}
+
 func checkCountForTLSVer(t *testing.T, version string, expected int64) {
 	connCounts := connCountByTLSVer.Counts()
 	count, ok := connCounts[version]
@@ -1534,6 +1722,71 @@ func TestListenerShutdown(t *testing.T) {
 	require.Equal(t, "Server shutdown in progress", sqlErr.Message)
 }
 
+func TestCloseIfIdle(t *testing.T) {
+	for _, compression := range []CompressionAlgorithm{"", CompressionZlib} {
+		t.Run(string(compression), func(t *testing.T) {
+			ctx := utils.LeakCheckContext(t)
+			th := &testHandler{}
+			authServer := NewAuthServerStatic("", "", 0)
+			authServer.entries["user1"] = []*AuthServerStaticEntry{{
+				Password: "password1",
+			}}
+			defer authServer.close()
+
+			l, err := NewListenerWithConfig(ListenerConfig{
+				Protocol:              "tcp",
+				Address:               "127.0.0.1:",
+				AuthServer:            authServer,
+				Handler:               th,
+				ConnReadBufferSize:    connBufferSize,
+				CompressionAlgorithms: []CompressionAlgorithm{CompressionZlib},
+			})
+			require.NoError(t, err)
+			host, port := getHostPort(t, l.Addr())
+			params := &ConnParams{
+				Host:        host,
+				Port:        port,
+				Uname:       "user1",
+				Pass:        "password1",
+				Compression: compression,
+			}
+			go l.Accept()
+			defer cleanupListener(ctx, l, params)
+
+			c, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer c.Close()
+			require.NoError(t, c.Ping())
+
+			reason := func() error {
+				return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "closing idle connection")
+			}
+			sc := th.LastConn()
+
+			// A connection running a command is left alone.
+			sc.commandMu.Lock()
+			assert.False(t, sc.CloseIfIdle(func() error {
+				t.Error("reason called for a busy connection")
+				return nil
+			}))
+			sc.commandMu.Unlock()
+			assert.False(t, sc.IsClosed())
+
+			require.True(t, sc.CloseIfIdle(reason))
+			assert.True(t, sc.IsClosed())
+			assert.False(t, sc.CloseIfIdle(reason))
+
+			// The client reads the error as the response to its next command.
+			err = c.Ping()
+			sqlErr, ok := err.(*sqlerror.SQLError)
+			require.True(t, ok, "Wrong error type: %T", err)
+			assert.Equal(t, sqlerror.ERServerShutdown, sqlErr.Number())
+			assert.Equal(t, sqlerror.SSNetError, sqlErr.SQLState())
+			assert.Equal(t, "closing idle connection", sqlErr.Message)
+		})
+	}
+}
+
 func TestParseConnAttrs(t *testing.T) {
 	expected := map[string]string{
 		"_client_version": "8.0.11",
@@ -1639,3 +1892,62 @@ func TestTcpKeepAlive(t *testing.T) {
 	err = setTcpConnProperties(th.lastConn.conn.(*net.TCPConn), 0)
 	require.ErrorContains(t, err, "unable to enable keepalive on tcp connection")
 }
+
+// TestProxyProtocolTrustedAddresses checks that the listener uses the
+// client address of the PROXY protocol header only when it comes from a
+// trusted address.
+func TestProxyProtocolTrustedAddresses(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	l, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.ErrorContains(t, l.TrustProxyProtocolFrom([]string{"127.0.0.1"}), "the PROXY protocol is not enabled on the listener")
+
+	// connect connects to the listener, after sending header if not empty.
+	connect := func(l *Listener, header string) (*Conn, error) {
+		host, port := getHostPort(t, l.Addr())
+		conn, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		require.NoError(t, err)
+		if header != "" {
+			_, err = conn.Write([]byte(header))
+			require.NoError(t, err)
+		}
+		c := newConn(conn, DefaultFlushDelay, 0)
+		if err := c.clientHandshake(&ConnParams{Host: host, Port: port}, ConnectionAttributes{}); err != nil {
+			c.Close()
+			return nil, err
+		}
+		return c, nil
+	}
+	header := "PROXY TCP4 192.0.2.10 127.0.0.1 51000 3306\r\n"
+
+	trusted, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, true, false, 0, 0, false)
+	require.NoError(t, err)
+	require.NoError(t, trusted.TrustProxyProtocolFrom([]string{"10.0.0.1", "127.0.0.0/8"}))
+	go trusted.Accept()
+	defer cleanupListener(ctx, trusted, &ConnParams{})
+
+	c, err := connect(trusted, header)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.10:51000", th.LastConn().RemoteAddr().String())
+	c.Close()
+
+	untrusted, err := NewListener("tcp", "127.0.0.1:", NewAuthServerNone(), th, 0, 0, true, false, 0, 0, false)
+	require.NoError(t, err)
+	assert.ErrorContains(t, untrusted.TrustProxyProtocolFrom([]string{"10.0.0.0/33"}), "invalid PROXY protocol trusted address")
+	require.NoError(t, untrusted.TrustProxyProtocolFrom([]string{"10.0.0.0/8"}))
+	go untrusted.Accept()
+	defer cleanupListener(ctx, untrusted, &ConnParams{})
+
+	// A header from an untrusted address is not parsed, so the handshake
+	// fails, while the clients that connect directly are greeted at once.
+	_, err = connect(untrusted, header)
+	require.Error(t, err)
+
+	c, err = connect(untrusted, "")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", th.LastConn().RemoteAddr().(*net.TCPAddr).IP.String())
+	c.Close()
+}
@@ -49,19 +49,20 @@ const (
 	ERUnsupportedPS   = ErrorCode(1295)
 
 	// resource exhausted
-	ERDiskFull               = ErrorCode(1021)
-	EROutOfMemory            = ErrorCode(1037)
-	EROutOfSortMemory        = ErrorCode(1038)
-	ERConCount               = ErrorCode(1040)
-	EROutOfResources         = ErrorCode(1041)
-	ERRecordFileFull         = ErrorCode(1114)
-	ERHostIsBlocked          = ErrorCode(1129)
-	ERCantCreateThread       = ErrorCode(1135)
-	ERTooManyDelayedThreads  = ErrorCode(1151)
-	ERNetPacketTooLarge      = ErrorCode(1153)
-	ERTooManyUserConnections = ErrorCode(1203)
-	ERLockTableFull          = ErrorCode(1206)
-	ERUserLimitReached       = ErrorCode(1226)
+	ERDiskFull                    = ErrorCode(1021)
+	EROutOfMemory                 = ErrorCode(1037)
+	EROutOfSortMemory             = ErrorCode(1038)
+	ERConCount                    = ErrorCode(1040)
+	EROutOfResources              = ErrorCode(1041)
+	ERRecordFileFull              = ErrorCode(1114)
+	ERHostIsBlocked               = ErrorCode(1129)
+	ERCantCreateThread            = ErrorCode(1135)
+	ERTooManyDelayedThreads       = ErrorCode(1151)
+	ERNetPacketTooLarge           = ErrorCode(1153)
+	ERTooManyUserConnections      = ErrorCode(1203)
+	ERLockTableFull               = ErrorCode(1206)
+	ERUserLimitReached            = ErrorCode(1226)
+	ERMaxPreparedStmtCountReached = ErrorCode(1461)
 
 	// deadline exceeded
 	ERLockWaitTimeout = ErrorCode(1205)
@@ -124,12 +125,18 @@ const (
 	ERSPDoesNotExist                = ErrorCode(1305)
 	ERNoDefaultForField             = ErrorCode(1364)
 	ErSPNotVarArg                   = ErrorCode(1414)
+	ERStmtHasNoOpenCursor           = ErrorCode(1421)
 	ERRowIsReferenced2              = ErrorCode(1451)
 	ErNoReferencedRow2              = ErrorCode(1452)
 	ERSourceHasPurgedRequiredGtids  = ErrorCode(1789)
 	ERInnodbIndexCorrupt            = ErrorCode(1817)
 	ERDupIndex                      = ErrorCode(1831)
 
+	// ALTER TABLE ... ALGORITHM= rejections
+	ERAlterOperationNotSupported       = ErrorCode(1845)
+	ERAlterOperationNotSupportedReason = ErrorCode(1846)
+	ERInnodbMaxRowVersion              = ErrorCode(4092)
+
 	// MySQL used 1871/1872 for master-info and relay-log-info initialization
 	// errors through 8.0.32, and reassigned those numbers in 8.0.33 to
 	// connection-metadata and applier-metadata initialization errors. These
@@ -320,6 +327,9 @@ const (
 
 	// server not available
 	ERServerIsntAvailable = ErrorCode(3168)
+
+	// ERClientInteractionTimeout is ER_CLIENT_INTERACTION_TIMEOUT.
+	ERClientInteractionTimeout = ErrorCode(4031)
 )
 
 // HandlerErrorCode is for errors thrown by the handler, and which are then embedded in other errors.
@@ -99,7 +99,8 @@ func (se *SQLError) VtRpcErrorCode() vtrpcpb.Code {
 	case ERNotSupportedYet:
 		return vtrpcpb.Code_UNIMPLEMENTED
 	case ERDiskFull, EROutOfMemory, EROutOfSortMemory, ERConCount, EROutOfResources, ERRecordFileFull, ERHostIsBlocked,
-		ERCantCreateThread, ERTooManyDelayedThreads, ERNetPacketTooLarge, ERTooManyUserConnections, ERLockTableFull, ERUserLimitReached:
+		ERCantCreateThread, ERTooManyDelayedThreads, ERNetPacketTooLarge, ERTooManyUserConnections, ERLockTableFull, ERUserLimitReached,
+		ERMaxPreparedStmtCountReached:
 		return vtrpcpb.Code_RESOURCE_EXHAUSTED
 	case ERLockWaitTimeout:
 		return vtrpcpb.Code_DEADLINE_EXCEEDED
@@ -303,6 +304,7 @@ var stateToMysqlCode = map[vterrors.State]mysqlCode{
 	vterrors.WrongValue:                          {num: ERWrongValue, state: SSUnknownSQLState},
 	vterrors.WrongFieldWithGroup:                 {num: ERWrongFieldWithGroup, state: SSClientError},
 	vterrors.ServerNotAvailable:                  {num: ERServerIsntAvailable, state: SSNetError},
+	vterrors.ServerShutdown:                      {num: ERServerShutdown, state: SSNetError},
 	vterrors.CantDoThisInTransaction:             {num: ERCantDoThisDuringAnTransaction, state: SSCantDoThisDuringAnTransaction},
 	vterrors.RequiresPrimaryKey:                  {num: ERRequiresPrimaryKey, state: SSClientError},
 	vterrors.RowIsReferenced2:                    {num: ERRowIsReferenced2, state: SSConstraintViolation},
@@ -45,13 +45,40 @@ func (c *Conn) ExecuteStreamFetch(query string) (err error) {
 
 	// Reset the OK packet captured from the previous streaming query.
 	c.streamOK = nil
+	c.streamMoreResults = false
 
 	// Send the query as a COM_QUERY packet.
 	if err := c.WriteComQuery(query); err != nil {
 		return err
 	}
 
-	// Get the result.
+	return c.readStreamResult()
+}
+
+// MoreStreamResults returns true if the result the streaming query is done
+// with reported that more results follow, like the other result sets of the
+// call of a stored procedure. They must be read with StreamNextResult before
+// the connection can execute another query.
+func (c *Conn) MoreStreamResults() bool {
+	return c.fields == nil && c.streamMoreResults
+}
+
+// StreamNextResult starts streaming the next result of the streaming query,
+// once MoreStreamResults returns true. Fields(), FetchNext(), CloseResult()
+// and StreamOKResult() can then be called, as after ExecuteStreamFetch.
+// Returns a SQLError.
+func (c *Conn) StreamNextResult() error {
+	if !c.MoreStreamResults() {
+		return sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "no more results in the streaming query")
+	}
+	c.streamOK = nil
+	c.streamMoreResults = false
+	return c.readStreamResult()
+}
+
+// readStreamResult reads the beginning of a result of a streaming query: its
+// fields, or the OK packet of a result without rows.
+func (c *Conn) readStreamResult() error {
 	var packetOk PacketOK
 	colNumber, err := c.readComQueryResponse(&packetOk)
 	if err != nil {
@@ -70,6 +97,7 @@ func (c *Conn) ExecuteStreamFetch(query string) (err error) {
 			StatusFlags:         packetOk.statusFlags,
 			Info:                packetOk.info,
 		}
+		c.streamMoreResults = packetOk.statusFlags&ServerMoreResultsExists != 0
 		c.fields = make([]*querypb.Field, 0)
 		return nil
 	}
@@ -141,8 +169,10 @@ func (c *Conn) FetchNext(in []sqltypes.Value) ([]sqltypes.Value, error) {
 	}
 
 	if c.isEOFPacket(data) {
-		// Warnings and status flags are ignored.
+		// Warnings are ignored. Of the status flags, only whether more
+		// results follow is kept.
 		c.fields = nil
+		c.streamMoreResults = c.eofStatusFlags(data)&ServerMoreResultsExists != 0
 		return nil, nil
 	} else if isErrorPacket(data) {
 		// An error packet terminates the result set: the server sends nothing
@@ -156,6 +186,19 @@ func (c *Conn) FetchNext(in []sqltypes.Value) ([]sqltypes.Value, error) {
 	return c.parseRow(data, c.fields, readLenEncStringAsBytes, in)
 }
 
+// eofStatusFlags returns the status flags of the packet that ends a result,
+// an EOF packet, or an OK packet with the EOF type code if EOF packets are
+// deprecated.
+func (c *Conn) eofStatusFlags(data []byte) uint16 {
+	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
+		_, statusFlags, _ := parseEOFPacket(data)
+		return statusFlags
+	}
+	var packetEOF PacketOK
+	_ = c.parseOKPacket(&packetEOF, data)
+	return packetEOF.statusFlags
+}
+
 // StreamOKResult returns the OK-packet result of the streaming query started by
 // the most recent ExecuteStreamFetch when it returned no resultset (e.g. a CALL
 // of a procedure that performs DML), or nil when it returned a resultset. It is
@@ -163,3 +163,58 @@ func TestStreamFetchErrorMidResultSet(t *testing.T) {
 	}
 	require.ErrorContains(t, fetchErr, "Recursive query aborted")
 }
+
+// TestStreamNextResult verifies that each result of a streaming query that
+// returns several is streamed in turn, and that the connection can execute
+// another query once they are all read.
+func TestStreamNextResult(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	sConn.Capabilities |= CapabilityClientMultiStatements
+	t.Cleanup(func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	})
+
+	handler := &testRun{}
+	serve := func() chan bool {
+		done := make(chan bool, 1)
+		go func() {
+			done <- sConn.handleNextCommand(handler)
+		}()
+		return done
+	}
+	readRows := func() int {
+		rows := 0
+		for {
+			row, err := cConn.FetchNext(nil)
+			require.NoError(t, err)
+			if row == nil {
+				return rows
+			}
+			rows++
+		}
+	}
+
+	done := serve()
+	require.NoError(t, cConn.ExecuteStreamFetch("select 1;select 2"))
+	assert.False(t, cConn.MoreStreamResults(), "more results cannot be read while the first one is streamed")
+	assert.Equal(t, len(selectRowsResult.Rows), readRows())
+	require.True(t, cConn.MoreStreamResults())
+	require.NoError(t, cConn.StreamNextResult())
+	fields, err := cConn.Fields()
+	require.NoError(t, err)
+	assert.Len(t, fields, len(selectRowsResult.Fields))
+	assert.Equal(t, len(selectRowsResult.Rows), readRows())
+	assert.False(t, cConn.MoreStreamResults())
+	require.True(t, <-done)
+
+	err = cConn.StreamNextResult()
+	require.ErrorContains(t, err, "no more results")
+
+	done = serve()
+	result, err := cConn.ExecuteFetch("select 3", 100, true)
+	require.NoError(t, err)
+	assert.True(t, result.Equal(selectRowsResult))
+	require.True(t, <-done)
+}
@@ -115,6 +115,8 @@ func ResultToProto3(qr *Result) *querypb.QueryResult {
 		Rows:                rows,
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Warnings:            qr.Warnings,
+		NewResult:           qr.NewResult,
 	}
 }
 
@@ -132,6 +134,8 @@ func Proto3ToResult(qr *querypb.QueryResult) *Result {
 		Rows:                proto3ToRows(qr.Fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Warnings:            qr.Warnings,
+		NewResult:           qr.NewResult,
 	}
 }
 
@@ -150,6 +154,8 @@ func CustomProto3ToResult(fields []*querypb.Field, qr *querypb.QueryResult) *Res
 		Rows:                proto3ToRows(fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Warnings:            qr.Warnings,
+		NewResult:           qr.NewResult,
 	}
 }
 
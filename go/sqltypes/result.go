@@ -38,6 +38,15 @@ type Result struct {
 	StatusFlags         uint16           `json:"status_flags"`
 	Info                string           `json:"info"`
 
+	// Warnings are the warnings MySQL raised for the query, read with
+	// SHOW WARNINGS when MySQL reports any.
+	Warnings []*querypb.QueryWarning `json:"warnings,omitempty"`
+
+	// NewResult is set on the first packet of each result set of a
+	// streaming query that follows another, like the result sets of the
+	// call of a stored procedure after the first one.
+	NewResult bool `json:"new_result,omitempty"`
+
 	// proto3Rows caches the proto3-encoded representation of Rows, avoiding
 	// redundant encoding when multiple consumers share the same Result (i.e.
 	// query consolidation). Not populated for the non-consolidation flow;
@@ -116,6 +125,13 @@ func (result *Result) Copy() *Result {
 		SessionStateChanges: result.SessionStateChanges,
 		StatusFlags:         result.StatusFlags,
 		Info:                result.Info,
+		NewResult:           result.NewResult,
+	}
+	if result.Warnings != nil {
+		out.Warnings = make([]*querypb.QueryWarning, len(result.Warnings))
+		for i, w := range result.Warnings {
+			out.Warnings[i] = w.CloneVT()
+		}
 	}
 	if result.Fields != nil {
 		out.Fields = make([]*querypb.Field, len(result.Fields))
@@ -143,6 +159,8 @@ func (result *Result) ShallowCopy() *Result {
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
 		Rows:                result.Rows,
+		Warnings:            result.Warnings,
+		NewResult:           result.NewResult,
 		// proto3Rows is intentionally not propagated: callers may modify Rows
 	}
 }
@@ -165,6 +183,7 @@ func (result *Result) Metadata() *Result {
 		RowsAffected:        result.RowsAffected,
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
+		NewResult:           result.NewResult,
 	}
 }
 
@@ -190,6 +209,7 @@ func (result *Result) Truncate(l int) *Result {
 		RowsAffected:        result.RowsAffected,
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
+		NewResult:           result.NewResult,
 	}
 	if result.Fields != nil {
 		out.Fields = result.Fields[:l]
@@ -348,6 +368,8 @@ func (result *Result) StripMetadata(incl querypb.ExecuteOptions_IncludedFields)
 	for i, f := range result.Fields {
 		r.Fields[i] = &newFieldsArray[i]
 		newFieldsArray[i].Type = f.Type
+		// The encoding of JSON values is needed to read them.
+		newFieldsArray[i].JsonEncoding = f.JsonEncoding
 		if incl == querypb.ExecuteOptions_TYPE_AND_NAME {
 			newFieldsArray[i].Name = f.Name
 		}
@@ -369,6 +391,7 @@ func (result *Result) AppendResult(src *Result) {
 		result.Fields = src.Fields
 	}
 	result.Rows = append(result.Rows, src.Rows...)
+	result.Warnings = append(result.Warnings, src.Warnings...)
 }
 
 // Named returns a NamedResult based on this struct
@@ -196,6 +196,22 @@ func TestStripMetaData(t *testing.T) {
 				Type: VarChar,
 			}},
 		},
+	}, {
+		name:           "json encoding kept",
+		includedFields: querypb.ExecuteOptions_TYPE_ONLY,
+		in: &Result{
+			Fields: []*querypb.Field{{
+				Name:         "field1",
+				Type:         TypeJSON,
+				JsonEncoding: querypb.ExecuteOptions_JSON_BINARY,
+			}},
+		},
+		expected: &Result{
+			Fields: []*querypb.Field{{
+				Type:         TypeJSON,
+				JsonEncoding: querypb.ExecuteOptions_JSON_BINARY,
+			}},
+		},
 	}, {
 		name:           "all fields - not stripped",
 		includedFields: querypb.ExecuteOptions_ALL,
@@ -0,0 +1,664 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sqlarrow converts query results to Apache Arrow record batches and
+// back, so that analytics integrations and exporters can share a columnar
+// in-memory representation of the rows of MySQL.
+//
+// Every MySQL type maps to an Arrow type:
+//
+//   - integers map to the Arrow integers of the same size and signedness, with
+//     MEDIUMINT mapping to 32-bit integers and YEAR to uint16
+//   - FLOAT and DOUBLE map to float32 and float64
+//   - DECIMAL maps to decimal128, or to decimal256 if its precision is larger
+//     than 38 digits
+//   - DATE maps to date32, DATETIME and TIMESTAMP map to timestamps in
+//     microseconds without a time zone, and TIME maps to a duration in
+//     microseconds
+//   - strings, ENUM, SET and JSON map to utf8 strings
+//   - binary strings, BIT, GEOMETRY and VECTOR map to binary
+//
+// The Arrow fields keep the MySQL type and the other attributes of the
+// query fields in their metadata, so a result converted to a record batch is
+// converted back to the same result. Record batches without this metadata are
+// converted with the MySQL type that matches the type of each Arrow field.
+package sqlarrow
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/decimal256"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/mysql/decimal"
+	"vitess.io/vitess/go/mysql/format"
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The keys of the metadata of the Arrow fields with the attributes of the
+// query fields.
+const (
+	MetadataType         = "vitess.type"
+	MetadataCharset      = "vitess.charset"
+	MetadataColumnLength = "vitess.column_length"
+	MetadataDecimals     = "vitess.decimals"
+	MetadataFlags        = "vitess.flags"
+	MetadataColumnType   = "vitess.column_type"
+)
+
+// maxDecimalPrecision is the precision of the DECIMAL columns of unknown
+// precision: the largest precision MySQL supports.
+const maxDecimalPrecision = 65
+
+// Schema returns the Arrow schema of the rows with the given fields.
+func Schema(fields []*querypb.Field) (*arrow.Schema, error) {
+	arrowFields := make([]arrow.Field, 0, len(fields))
+	for _, field := range fields {
+		dt, err := dataType(field)
+		if err != nil {
+			return nil, err
+		}
+		arrowFields = append(arrowFields, arrow.Field{
+			Name:     field.Name,
+			Type:     dt,
+			Nullable: field.Flags&uint32(querypb.MySqlFlag_NOT_NULL_FLAG) == 0,
+			Metadata: fieldMetadata(field),
+		})
+	}
+	return arrow.NewSchema(arrowFields, nil), nil
+}
+
+func dataType(field *querypb.Field) (arrow.DataType, error) {
+	switch field.Type {
+	case sqltypes.Null:
+		return arrow.Null, nil
+	case sqltypes.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case sqltypes.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case sqltypes.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case sqltypes.Uint16, sqltypes.Year:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case sqltypes.Int24, sqltypes.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case sqltypes.Uint24, sqltypes.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case sqltypes.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case sqltypes.Uint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case sqltypes.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case sqltypes.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case sqltypes.Decimal:
+		precision, scale := decimalPrecision(field)
+		if precision <= 38 {
+			return &arrow.Decimal128Type{Precision: precision, Scale: scale}, nil
+		}
+		return &arrow.Decimal256Type{Precision: precision, Scale: scale}, nil
+	case sqltypes.Date:
+		return arrow.FixedWidthTypes.Date32, nil
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, nil
+	case sqltypes.Time:
+		return arrow.FixedWidthTypes.Duration_us, nil
+	case sqltypes.Char, sqltypes.VarChar, sqltypes.Text, sqltypes.Enum, sqltypes.Set, sqltypes.TypeJSON:
+		return arrow.BinaryTypes.String, nil
+	case sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry, sqltypes.Vector:
+		return arrow.BinaryTypes.Binary, nil
+	default:
+		return nil, fmt.Errorf("cannot convert a column of type %s to Arrow", field.Type)
+	}
+}
+
+// decimalPrecision returns the precision and scale of a DECIMAL field. The
+// length of a DECIMAL column includes its sign and its decimal point.
+func decimalPrecision(field *querypb.Field) (int32, int32) {
+	scale := int32(field.Decimals)
+	precision := int32(field.ColumnLength)
+	if scale > 0 {
+		precision--
+	}
+	if field.Flags&uint32(querypb.MySqlFlag_UNSIGNED_FLAG) == 0 {
+		precision--
+	}
+	if field.ColumnLength == 0 || precision < 1 || precision > maxDecimalPrecision {
+		precision = maxDecimalPrecision
+	}
+	return max(precision, scale), scale
+}
+
+func fieldMetadata(field *querypb.Field) arrow.Metadata {
+	keys := []string{MetadataType, MetadataCharset, MetadataColumnLength, MetadataDecimals, MetadataFlags}
+	values := []string{
+		field.Type.String(),
+		strconv.FormatUint(uint64(field.Charset), 10),
+		strconv.FormatUint(uint64(field.ColumnLength), 10),
+		strconv.FormatUint(uint64(field.Decimals), 10),
+		strconv.FormatUint(uint64(field.Flags), 10),
+	}
+	if field.ColumnType != "" {
+		keys = append(keys, MetadataColumnType)
+		values = append(values, field.ColumnType)
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+// Fields returns the query fields of the rows of an Arrow schema.
+func Fields(schema *arrow.Schema) ([]*querypb.Field, error) {
+	fields := make([]*querypb.Field, 0, schema.NumFields())
+	for _, f := range schema.Fields() {
+		field, err := queryField(f)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func queryField(f arrow.Field) (*querypb.Field, error) {
+	if f.Metadata.FindKey(MetadataType) >= 0 {
+		return queryFieldFromMetadata(f)
+	}
+
+	field := &querypb.Field{Name: f.Name, Charset: collations.CollationBinaryID}
+	switch dt := f.Type.(type) {
+	case *arrow.NullType:
+		field.Type = sqltypes.Null
+	case *arrow.BooleanType, *arrow.Int8Type:
+		field.Type = sqltypes.Int8
+	case *arrow.Uint8Type:
+		field.Type = sqltypes.Uint8
+	case *arrow.Int16Type:
+		field.Type = sqltypes.Int16
+	case *arrow.Uint16Type:
+		field.Type = sqltypes.Uint16
+	case *arrow.Int32Type:
+		field.Type = sqltypes.Int32
+	case *arrow.Uint32Type:
+		field.Type = sqltypes.Uint32
+	case *arrow.Int64Type:
+		field.Type = sqltypes.Int64
+	case *arrow.Uint64Type:
+		field.Type = sqltypes.Uint64
+	case *arrow.Float32Type:
+		field.Type = sqltypes.Float32
+	case *arrow.Float64Type:
+		field.Type = sqltypes.Float64
+	case *arrow.Decimal128Type:
+		field.Type = sqltypes.Decimal
+		field.ColumnLength, field.Decimals = decimalLength(dt.Precision, dt.Scale)
+	case *arrow.Decimal256Type:
+		field.Type = sqltypes.Decimal
+		field.ColumnLength, field.Decimals = decimalLength(dt.Precision, dt.Scale)
+	case *arrow.Date32Type:
+		field.Type = sqltypes.Date
+	case *arrow.TimestampType:
+		field.Type = sqltypes.Datetime
+		field.Decimals = timeUnitDecimals(dt.Unit)
+	case *arrow.DurationType:
+		field.Type = sqltypes.Time
+		field.Decimals = timeUnitDecimals(dt.Unit)
+	case *arrow.StringType:
+		field.Type = sqltypes.VarChar
+		field.Charset = collations.CollationUtf8mb4ID
+	case *arrow.BinaryType:
+		field.Type = sqltypes.VarBinary
+	default:
+		return nil, fmt.Errorf("cannot convert the Arrow field %q of type %s to a query field", f.Name, f.Type)
+	}
+	if !f.Nullable {
+		field.Flags |= uint32(querypb.MySqlFlag_NOT_NULL_FLAG)
+	}
+	if sqltypes.IsNumber(field.Type) || sqltypes.IsDateOrTime(field.Type) {
+		field.Flags |= uint32(querypb.MySqlFlag_NUM_FLAG)
+	}
+	if sqltypes.IsUnsigned(field.Type) {
+		field.Flags |= uint32(querypb.MySqlFlag_UNSIGNED_FLAG)
+	}
+	return field, nil
+}
+
+func queryFieldFromMetadata(f arrow.Field) (*querypb.Field, error) {
+	value := func(key string) string {
+		if i := f.Metadata.FindKey(key); i >= 0 {
+			return f.Metadata.Values()[i]
+		}
+		return ""
+	}
+	number := func(key string) (uint32, error) {
+		v := value(key)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s metadata of the Arrow field %q: %w", key, f.Name, err)
+		}
+		return uint32(n), nil
+	}
+
+	typ, ok := querypb.Type_value[value(MetadataType)]
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata of the Arrow field %q: %q", MetadataType, f.Name, value(MetadataType))
+	}
+	field := &querypb.Field{
+		Name:       f.Name,
+		Type:       querypb.Type(typ),
+		ColumnType: value(MetadataColumnType),
+	}
+	var err error
+	if field.Charset, err = number(MetadataCharset); err != nil {
+		return nil, err
+	}
+	if field.ColumnLength, err = number(MetadataColumnLength); err != nil {
+		return nil, err
+	}
+	if field.Decimals, err = number(MetadataDecimals); err != nil {
+		return nil, err
+	}
+	if field.Flags, err = number(MetadataFlags); err != nil {
+		return nil, err
+	}
+
+	dt, err := dataType(field)
+	if err != nil {
+		return nil, err
+	}
+	if !arrow.TypeEqual(dt, f.Type) {
+		return nil, fmt.Errorf("the Arrow field %q of type %s cannot hold values of type %s", f.Name, f.Type, field.Type)
+	}
+	return field, nil
+}
+
+// decimalLength returns the column length and decimals of a signed DECIMAL
+// column with the given precision and scale.
+func decimalLength(precision, scale int32) (uint32, uint32) {
+	length := precision + 1
+	if scale > 0 {
+		length++
+	}
+	return uint32(length), uint32(scale)
+}
+
+func timeUnitDecimals(unit arrow.TimeUnit) uint32 {
+	switch unit {
+	case arrow.Millisecond:
+		return 3
+	case arrow.Microsecond, arrow.Nanosecond:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// ToRecordBatch converts the rows of a result to a record batch allocated with
+// mem. The caller must release the record batch.
+func ToRecordBatch(mem memory.Allocator, result *sqltypes.Result) (arrow.RecordBatch, error) {
+	b, err := NewBuilder(mem, result.Fields)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Release()
+
+	if err := b.Append(result.Rows); err != nil {
+		return nil, err
+	}
+	return b.NewRecordBatch(), nil
+}
+
+// Builder builds record batches out of rows.
+type Builder struct {
+	fields  []*querypb.Field
+	schema  *arrow.Schema
+	builder *array.RecordBuilder
+	rows    int
+}
+
+// NewBuilder returns a Builder of record batches with the rows of the given
+// fields. The caller must release the Builder.
+func NewBuilder(mem memory.Allocator, fields []*querypb.Field) (*Builder, error) {
+	schema, err := Schema(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{
+		fields:  fields,
+		schema:  schema,
+		builder: array.NewRecordBuilder(mem, schema),
+	}, nil
+}
+
+// Schema returns the Arrow schema of the record batches of the Builder.
+func (b *Builder) Schema() *arrow.Schema {
+	return b.schema
+}
+
+// Len returns the number of rows appended since the last record batch.
+func (b *Builder) Len() int {
+	return b.rows
+}
+
+// Append appends rows to the next record batch. If a row cannot be converted,
+// the rows appended since the last record batch are discarded.
+func (b *Builder) Append(rows []sqltypes.Row) error {
+	for _, row := range rows {
+		if len(row) != len(b.fields) {
+			b.discard()
+			return fmt.Errorf("row has %d values, but the result has %d fields", len(row), len(b.fields))
+		}
+		for i, v := range row {
+			if err := appendValue(b.builder.Field(i), b.fields[i], v); err != nil {
+				b.discard()
+				return fmt.Errorf("column %q: %w", b.fields[i].Name, err)
+			}
+		}
+		b.rows++
+	}
+	return nil
+}
+
+// NewRecordBatch returns a record batch with the rows appended since the last
+// record batch. The caller must release the record batch.
+func (b *Builder) NewRecordBatch() arrow.RecordBatch {
+	b.rows = 0
+	return b.builder.NewRecordBatch()
+}
+
+// Release releases the memory of the rows appended since the last record batch.
+func (b *Builder) Release() {
+	b.builder.Release()
+}
+
+func (b *Builder) discard() {
+	b.NewRecordBatch().Release()
+}
+
+func appendValue(b array.Builder, field *querypb.Field, v sqltypes.Value) error {
+	if v.IsNull() {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		i, err := strconv.ParseInt(v.RawStr(), 10, 8)
+		b.Append(int8(i))
+		return err
+	case *array.Uint8Builder:
+		u, err := strconv.ParseUint(v.RawStr(), 10, 8)
+		b.Append(uint8(u))
+		return err
+	case *array.Int16Builder:
+		i, err := strconv.ParseInt(v.RawStr(), 10, 16)
+		b.Append(int16(i))
+		return err
+	case *array.Uint16Builder:
+		u, err := strconv.ParseUint(v.RawStr(), 10, 16)
+		b.Append(uint16(u))
+		return err
+	case *array.Int32Builder:
+		i, err := strconv.ParseInt(v.RawStr(), 10, 32)
+		b.Append(int32(i))
+		return err
+	case *array.Uint32Builder:
+		u, err := strconv.ParseUint(v.RawStr(), 10, 32)
+		b.Append(uint32(u))
+		return err
+	case *array.Int64Builder:
+		i, err := strconv.ParseInt(v.RawStr(), 10, 64)
+		b.Append(i)
+		return err
+	case *array.Uint64Builder:
+		u, err := strconv.ParseUint(v.RawStr(), 10, 64)
+		b.Append(u)
+		return err
+	case *array.Float32Builder:
+		f, err := strconv.ParseFloat(v.RawStr(), 32)
+		b.Append(float32(f))
+		return err
+	case *array.Float64Builder:
+		f, err := strconv.ParseFloat(v.RawStr(), 64)
+		b.Append(f)
+		return err
+	case *array.Decimal128Builder:
+		n, err := decimalCoefficient(v.RawStr(), b.Type().(*arrow.Decimal128Type).Scale)
+		if err != nil {
+			b.AppendNull()
+			return err
+		}
+		b.Append(decimal128.FromBigInt(n))
+		return nil
+	case *array.Decimal256Builder:
+		n, err := decimalCoefficient(v.RawStr(), b.Type().(*arrow.Decimal256Type).Scale)
+		if err != nil {
+			b.AppendNull()
+			return err
+		}
+		b.Append(decimal256.FromBigInt(n))
+		return nil
+	case *array.Date32Builder:
+		// dates with zero parts have no Arrow representation, and the parser
+		// reads some of them as other dates: only the valid dates that the
+		// parser reads exactly are converted
+		d, ok := datetime.ParseDate(v.RawStr())
+		if !ok || d.Month() == 0 || d.Day() == 0 || string(d.Format()) != v.RawStr() {
+			b.AppendNull()
+			return fmt.Errorf("cannot convert the date %q to Arrow", v.RawStr())
+		}
+		b.Append(arrow.Date32FromTime(d.ToStdTime(time.UTC)))
+		return nil
+	case *array.TimestampBuilder:
+		dt, prec, ok := datetime.ParseDateTime(v.RawStr(), -1)
+		if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 || string(dt.Format(uint8(prec))) != v.RawStr() {
+			b.AppendNull()
+			return fmt.Errorf("cannot convert the datetime %q to Arrow", v.RawStr())
+		}
+		b.Append(arrow.Timestamp(dt.ToStdTime(time.Time{}).UnixMicro()))
+		return nil
+	case *array.DurationBuilder:
+		t, _, state := datetime.ParseTime(v.RawStr(), -1)
+		if state != datetime.TimeOK {
+			b.AppendNull()
+			return fmt.Errorf("cannot convert the time %q to Arrow", v.RawStr())
+		}
+		b.Append(arrow.Duration(t.ToDuration().Microseconds()))
+		return nil
+	case *array.StringBuilder:
+		b.Append(v.RawStr())
+		return nil
+	case *array.BinaryBuilder:
+		b.Append(v.Raw())
+		return nil
+	default:
+		b.AppendNull()
+		return fmt.Errorf("cannot convert a value of type %s to Arrow", field.Type)
+	}
+}
+
+// decimalCoefficient returns the coefficient of a decimal with the given
+// scale, i.e. the decimal multiplied by 10^scale.
+func decimalCoefficient(s string, scale int32) (*big.Int, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > int(scale) {
+		return nil, fmt.Errorf("the decimal %q has more than %d decimals", s, scale)
+	}
+	digits := intPart + fracPart + strings.Repeat("0", int(scale)-len(fracPart))
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return n, nil
+}
+
+// FromRecordBatch converts a record batch to a result with its fields and rows.
+func FromRecordBatch(rec arrow.RecordBatch) (*sqltypes.Result, error) {
+	fields, err := Fields(rec.Schema())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := Rows(rec, fields)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: fields, Rows: rows}, nil
+}
+
+// Rows returns the rows of a record batch with the given fields, as returned
+// by Fields for the schema of the record batch.
+func Rows(rec arrow.RecordBatch, fields []*querypb.Field) ([]sqltypes.Row, error) {
+	if int(rec.NumCols()) != len(fields) {
+		return nil, fmt.Errorf("record batch has %d columns, but the result has %d fields", rec.NumCols(), len(fields))
+	}
+
+	rows := make([]sqltypes.Row, rec.NumRows())
+	values := make([]sqltypes.Value, len(rows)*len(fields))
+	for r := range rows {
+		rows[r] = values[r*len(fields) : (r+1)*len(fields) : (r+1)*len(fields)]
+	}
+	for c, col := range rec.Columns() {
+		for r := range rows {
+			v, err := value(col, r, fields[c])
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", fields[c].Name, err)
+			}
+			rows[r][c] = v
+		}
+	}
+	return rows, nil
+}
+
+func value(col arrow.Array, i int, field *querypb.Field) (sqltypes.Value, error) {
+	if col.DataType().ID() == arrow.NULL || col.IsNull(i) {
+		return sqltypes.NULL, nil
+	}
+
+	var raw []byte
+	switch col := col.(type) {
+	case *array.Boolean:
+		raw = []byte{'0'}
+		if col.Value(i) {
+			raw[0] = '1'
+		}
+	case *array.Int8:
+		raw = strconv.AppendInt(nil, int64(col.Value(i)), 10)
+	case *array.Uint8:
+		raw = strconv.AppendUint(nil, uint64(col.Value(i)), 10)
+	case *array.Int16:
+		raw = strconv.AppendInt(nil, int64(col.Value(i)), 10)
+	case *array.Uint16:
+		raw = strconv.AppendUint(nil, uint64(col.Value(i)), 10)
+	case *array.Int32:
+		raw = strconv.AppendInt(nil, int64(col.Value(i)), 10)
+	case *array.Uint32:
+		raw = strconv.AppendUint(nil, uint64(col.Value(i)), 10)
+	case *array.Int64:
+		raw = strconv.AppendInt(nil, col.Value(i), 10)
+	case *array.Uint64:
+		raw = strconv.AppendUint(nil, col.Value(i), 10)
+	case *array.Float32:
+		raw = formatFloat32(col.Value(i))
+	case *array.Float64:
+		raw = format.FormatFloat(col.Value(i))
+	case *array.Decimal128:
+		raw = formatDecimal(col.Value(i).BigInt(), int32(field.Decimals))
+	case *array.Decimal256:
+		raw = formatDecimal(col.Value(i).BigInt(), int32(field.Decimals))
+	case *array.Date32:
+		raw = datetime.NewDateFromStd(col.Value(i).ToTime()).Format()
+	case *array.Timestamp:
+		t := timestampTime(col.Value(i), col.DataType().(*arrow.TimestampType).Unit)
+		raw = datetime.NewDateTimeFromStd(t).Format(uint8(field.Decimals))
+	case *array.Duration:
+		unit := col.DataType().(*arrow.DurationType).Unit
+		ns := int64(col.Value(i)) * int64(unit.Multiplier())
+		raw = datetime.NewTimeFromSeconds(decimal.New(ns, -9)).Format(uint8(field.Decimals))
+	case *array.String:
+		raw = []byte(col.Value(i))
+	case *array.Binary:
+		raw = bytes.Clone(col.Value(i))
+	default:
+		return sqltypes.Value{}, fmt.Errorf("cannot convert an Arrow value of type %s", col.DataType())
+	}
+	return sqltypes.MakeTrusted(field.Type, raw), nil
+}
+
+// timestampTime returns the time of a timestamp without a time zone. Unlike
+// arrow.Timestamp.ToTime, it doesn't overflow for the years before 1678 or after
+// 2262 for units larger than nanoseconds.
+func timestampTime(ts arrow.Timestamp, unit arrow.TimeUnit) time.Time {
+	switch unit {
+	case arrow.Second:
+		return time.Unix(int64(ts), 0).UTC()
+	case arrow.Millisecond:
+		return time.UnixMilli(int64(ts)).UTC()
+	case arrow.Microsecond:
+		return time.UnixMicro(int64(ts)).UTC()
+	default:
+		return time.Unix(0, int64(ts)).UTC()
+	}
+}
+
+// formatFloat32 formats a float32 like format.FormatFloat, with the shortest
+// representation of the float32 instead of the one of its float64 value.
+func formatFloat32(f float32) []byte {
+	verb := byte('f')
+	if abs := math.Abs(float64(f)); abs >= 1e15 || (abs < 1e-15 && abs != 0) {
+		verb = 'g'
+	}
+	raw := strconv.AppendFloat(nil, float64(f), verb, -1, 32)
+	if i := bytes.Index(raw, []byte("e+")); i >= 0 {
+		raw = append(raw[:i+1], raw[i+2:]...)
+	}
+	return raw
+}
+
+// formatDecimal formats the decimal with the given coefficient and scale like
+// MySQL, with exactly scale decimals.
+func formatDecimal(coefficient *big.Int, scale int32) []byte {
+	neg := coefficient.Sign() < 0
+	digits := coefficient.Abs(coefficient).String()
+	if pad := int(scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	var raw []byte
+	if neg {
+		raw = append(raw, '-')
+	}
+	raw = append(raw, digits[:len(digits)-int(scale)]...)
+	if scale > 0 {
+		raw = append(raw, '.')
+		raw = append(raw, digits[len(digits)-int(scale):]...)
+	}
+	return raw
+}
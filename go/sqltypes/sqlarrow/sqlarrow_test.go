@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlarrow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	notNullFlag  = uint32(querypb.MySqlFlag_NOT_NULL_FLAG)
+	unsignedFlag = uint32(querypb.MySqlFlag_UNSIGNED_FLAG)
+	binaryFlag   = uint32(querypb.MySqlFlag_BINARY_FLAG)
+)
+
+// typeCase is a column of every MySQL type, with the Arrow type it converts to
+// and values that cover the range of the type.
+type typeCase struct {
+	field  *querypb.Field
+	arrow  arrow.DataType
+	values []string
+}
+
+func field(name string, typ querypb.Type, length, decimals, flags uint32) *querypb.Field {
+	charset := uint32(collations.CollationBinaryID)
+	if sqltypes.IsText(typ) || typ == sqltypes.Enum || typ == sqltypes.Set {
+		charset = uint32(collations.CollationUtf8mb4ID)
+	}
+	return &querypb.Field{
+		Name:         name,
+		Type:         typ,
+		Charset:      charset,
+		ColumnLength: length,
+		Decimals:     decimals,
+		Flags:        flags,
+	}
+}
+
+var typeCases = []typeCase{
+	{field("int8", sqltypes.Int8, 4, 0, 0), arrow.PrimitiveTypes.Int8, []string{"-128", "0", "127"}},
+	{field("uint8", sqltypes.Uint8, 3, 0, unsignedFlag), arrow.PrimitiveTypes.Uint8, []string{"0", "255"}},
+	{field("int16", sqltypes.Int16, 6, 0, 0), arrow.PrimitiveTypes.Int16, []string{"-32768", "32767"}},
+	{field("uint16", sqltypes.Uint16, 5, 0, unsignedFlag), arrow.PrimitiveTypes.Uint16, []string{"0", "65535"}},
+	{field("int24", sqltypes.Int24, 9, 0, 0), arrow.PrimitiveTypes.Int32, []string{"-8388608", "8388607"}},
+	{field("uint24", sqltypes.Uint24, 8, 0, unsignedFlag), arrow.PrimitiveTypes.Uint32, []string{"0", "16777215"}},
+	{field("int32", sqltypes.Int32, 11, 0, 0), arrow.PrimitiveTypes.Int32, []string{"-2147483648", "2147483647"}},
+	{field("uint32", sqltypes.Uint32, 10, 0, unsignedFlag), arrow.PrimitiveTypes.Uint32, []string{"0", "4294967295"}},
+	{field("int64", sqltypes.Int64, 20, 0, notNullFlag), arrow.PrimitiveTypes.Int64, []string{"-9223372036854775808", "9223372036854775807"}},
+	{field("uint64", sqltypes.Uint64, 20, 0, unsignedFlag), arrow.PrimitiveTypes.Uint64, []string{"0", "18446744073709551615"}},
+	{field("float32", sqltypes.Float32, 12, 31, 0), arrow.PrimitiveTypes.Float32, []string{"1.1", "-3.4028235e38", "1e-20", "0"}},
+	{field("float64", sqltypes.Float64, 22, 31, 0), arrow.PrimitiveTypes.Float64, []string{"1.1", "-1.7976931348623157e308", "2.5e-300", "123456.789"}},
+	{field("decimal", sqltypes.Decimal, 12, 2, 0), &arrow.Decimal128Type{Precision: 10, Scale: 2}, []string{"-12345678.90", "0.05", "0.00", "99999999.99"}},
+	{field("udecimal", sqltypes.Decimal, 10, 0, unsignedFlag), &arrow.Decimal128Type{Precision: 10, Scale: 0}, []string{"0", "9999999999"}},
+	{field("decimal65", sqltypes.Decimal, 67, 30, 0), &arrow.Decimal256Type{Precision: 65, Scale: 30}, []string{
+		"-99999999999999999999999999999999999.999999999999999999999999999999",
+		"0.000000000000000000000000000001",
+	}},
+	{field("year", sqltypes.Year, 4, 0, unsignedFlag), arrow.PrimitiveTypes.Uint16, []string{"0", "1901", "2155"}},
+	{field("date", sqltypes.Date, 10, 0, 0), arrow.FixedWidthTypes.Date32, []string{"1000-01-01", "1969-12-31", "1970-01-01", "9999-12-31"}},
+	{field("datetime", sqltypes.Datetime, 19, 0, 0), &arrow.TimestampType{Unit: arrow.Microsecond}, []string{"1000-01-01 00:00:00", "1969-12-31 23:59:59", "9999-12-31 23:59:59"}},
+	{field("datetime6", sqltypes.Datetime, 26, 6, 0), &arrow.TimestampType{Unit: arrow.Microsecond}, []string{"2024-02-29 12:34:56.123456", "1960-01-01 00:00:00.000001"}},
+	{field("timestamp", sqltypes.Timestamp, 23, 3, 0), &arrow.TimestampType{Unit: arrow.Microsecond}, []string{"1970-01-01 00:00:01.000", "2038-01-19 03:14:07.999"}},
+	{field("time", sqltypes.Time, 10, 0, 0), arrow.FixedWidthTypes.Duration_us, []string{"-838:59:59", "00:00:00", "838:59:59", "-00:00:01"}},
+	{field("time6", sqltypes.Time, 17, 6, 0), arrow.FixedWidthTypes.Duration_us, []string{"12:34:56.000001", "-00:00:00.500000"}},
+	{field("char", sqltypes.Char, 40, 0, 0), arrow.BinaryTypes.String, []string{"", "abc", "ñandú 🐢"}},
+	{field("varchar", sqltypes.VarChar, 1020, 0, 0), arrow.BinaryTypes.String, []string{"hello, world"}},
+	{field("text", sqltypes.Text, 262140, 0, 0), arrow.BinaryTypes.String, []string{"some text"}},
+	{field("binary", sqltypes.Binary, 4, 0, binaryFlag), arrow.BinaryTypes.Binary, []string{"\x00\x01\x02\x03"}},
+	{field("varbinary", sqltypes.VarBinary, 255, 0, binaryFlag), arrow.BinaryTypes.Binary, []string{"", "\xff\xfe"}},
+	{field("blob", sqltypes.Blob, 65535, 0, binaryFlag), arrow.BinaryTypes.Binary, []string{"\x00blob"}},
+	{field("bit", sqltypes.Bit, 12, 0, unsignedFlag), arrow.BinaryTypes.Binary, []string{"\x0f\xff", "\x00\x00"}},
+	{field("enum", sqltypes.Enum, 4, 0, 0), arrow.BinaryTypes.String, []string{"a", "b"}},
+	{field("set", sqltypes.Set, 7, 0, 0), arrow.BinaryTypes.String, []string{"a,b", ""}},
+	{field("json", sqltypes.TypeJSON, 4294967295, 0, binaryFlag), arrow.BinaryTypes.String, []string{`{"a": [1, 2.5, null]}`, `"str"`}},
+	{field("geometry", sqltypes.Geometry, 4294967295, 0, binaryFlag), arrow.BinaryTypes.Binary, []string{"\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0?\x00\x00\x00\x00\x00\x00\x00@"}},
+	{field("vector", sqltypes.Vector, 8, 0, binaryFlag), arrow.BinaryTypes.Binary, []string{"\x00\x00\x80?\x00\x00\x00@"}},
+	{field("null", sqltypes.Null, 0, 0, 0), arrow.Null, nil},
+}
+
+// typeResult returns a result with a column of every type, with a row for
+// every value of the types, and a row with only NULL values.
+func typeResult() *sqltypes.Result {
+	result := &sqltypes.Result{}
+	rows := 0
+	for _, tc := range typeCases {
+		result.Fields = append(result.Fields, tc.field)
+		rows = max(rows, len(tc.values))
+	}
+	for r := range rows + 1 {
+		row := make(sqltypes.Row, len(typeCases))
+		for c, tc := range typeCases {
+			switch {
+			case r < len(tc.values):
+				row[c] = sqltypes.MakeTrusted(tc.field.Type, []byte(tc.values[r]))
+			case tc.field.Flags&notNullFlag != 0:
+				// columns that are NOT NULL repeat their first value
+				row[c] = sqltypes.MakeTrusted(tc.field.Type, []byte(tc.values[0]))
+			default:
+				row[c] = sqltypes.NULL
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+func TestRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	result := typeResult()
+	rec, err := ToRecordBatch(mem, result)
+	require.NoError(t, err)
+	defer rec.Release()
+
+	require.EqualValues(t, len(result.Rows), rec.NumRows())
+	for i, tc := range typeCases {
+		f := rec.Schema().Field(i)
+		assert.Equalf(t, tc.field.Name, f.Name, "name of column %d", i)
+		assert.Truef(t, arrow.TypeEqual(tc.arrow, f.Type), "column %s: got %s, want %s", tc.field.Name, f.Type, tc.arrow)
+		assert.Equalf(t, tc.field.Flags&notNullFlag == 0, f.Nullable, "nullable column %s", tc.field.Name)
+	}
+
+	got, err := FromRecordBatch(rec)
+	require.NoError(t, err)
+	require.Len(t, got.Fields, len(result.Fields))
+	for i := range result.Fields {
+		assert.Truef(t, proto.Equal(result.Fields[i], got.Fields[i]), "field %d: got %v, want %v", i, got.Fields[i], result.Fields[i])
+	}
+	require.Len(t, got.Rows, len(result.Rows))
+	for r := range result.Rows {
+		for c := range result.Fields {
+			assert.Equalf(t, result.Rows[r][c].String(), got.Rows[r][c].String(), "row %d column %s", r, result.Fields[c].Name)
+		}
+	}
+}
+
+func TestArrowValues(t *testing.T) {
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			field("d", sqltypes.Decimal, 7, 2, 0),
+			field("date", sqltypes.Date, 10, 0, 0),
+			field("dt", sqltypes.Datetime, 26, 6, 0),
+			field("t", sqltypes.Time, 10, 0, 0),
+		},
+		Rows: []sqltypes.Row{{
+			sqltypes.MakeTrusted(sqltypes.Decimal, []byte("-1234.5")),
+			sqltypes.MakeTrusted(sqltypes.Date, []byte("1970-01-02")),
+			sqltypes.MakeTrusted(sqltypes.Datetime, []byte("1970-01-01 00:00:01.5")),
+			sqltypes.MakeTrusted(sqltypes.Time, []byte("-25:00:00")),
+		}},
+	}
+	rec, err := ToRecordBatch(memory.DefaultAllocator, result)
+	require.NoError(t, err)
+	defer rec.Release()
+
+	assert.Equal(t, "-123450", rec.Column(0).(*array.Decimal128).Value(0).BigInt().String())
+	assert.Equal(t, arrow.Date32(1), rec.Column(1).(*array.Date32).Value(0))
+	assert.Equal(t, arrow.Timestamp(1500000), rec.Column(2).(*array.Timestamp).Value(0))
+	assert.Equal(t, arrow.Duration(-25*3600*1000000), rec.Column(3).(*array.Duration).Value(0))
+
+	// the decimal is formatted with the decimals of its field
+	got, err := FromRecordBatch(rec)
+	require.NoError(t, err)
+	assert.Equal(t, `[[DECIMAL(-1234.50) DATE("1970-01-02") DATETIME("1970-01-01 00:00:01.500000") TIME("-25:00:00")]]`, fmt.Sprint(got.Rows))
+}
+
+func TestConversionErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	_, err := Schema([]*querypb.Field{{Name: "e", Type: sqltypes.Expression}})
+	assert.EqualError(t, err, "cannot convert a column of type EXPRESSION to Arrow")
+
+	testCases := []struct {
+		field *querypb.Field
+		value string
+		err   string
+	}{
+		{field("date", sqltypes.Date, 10, 0, 0), "0000-00-00", `column "date": cannot convert the date "0000-00-00" to Arrow`},
+		{field("dt", sqltypes.Datetime, 19, 0, 0), "2024-00-01 00:00:00", `column "dt": cannot convert the datetime "2024-00-01 00:00:00" to Arrow`},
+		{field("d", sqltypes.Decimal, 5, 1, 0), "1.25", `column "d": the decimal "1.25" has more than 1 decimals`},
+		{field("i", sqltypes.Int8, 4, 0, 0), "128", `column "i": strconv.ParseInt: parsing "128": value out of range`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.field.Name, func(t *testing.T) {
+			b, err := NewBuilder(mem, []*querypb.Field{tc.field})
+			require.NoError(t, err)
+			defer b.Release()
+
+			require.NoError(t, b.Append([]sqltypes.Row{{sqltypes.NULL}}))
+			err = b.Append([]sqltypes.Row{{sqltypes.MakeTrusted(tc.field.Type, []byte(tc.value))}})
+			assert.EqualError(t, err, tc.err)
+
+			// the rows of a failed append are discarded with the previous ones
+			assert.Zero(t, b.Len())
+			rec := b.NewRecordBatch()
+			defer rec.Release()
+			assert.Zero(t, rec.NumRows())
+		})
+	}
+}
+
+func TestFromForeignRecordBatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "b", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Second}},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 5, Scale: 2}},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"foo", ""}, []bool{true, false})
+	b.Field(3).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{0, 86400}, nil)
+	b.Field(4).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(-5), decimal128.FromI64(12345)}, nil)
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+
+	result, err := FromRecordBatch(rec)
+	require.NoError(t, err)
+
+	var types []querypb.Type
+	for _, f := range result.Fields {
+		types = append(types, f.Type)
+	}
+	assert.Equal(t, []querypb.Type{sqltypes.Int8, sqltypes.Int64, sqltypes.VarChar, sqltypes.Datetime, sqltypes.Decimal}, types)
+	assert.EqualValues(t, collations.CollationUtf8mb4ID, result.Fields[2].Charset)
+	assert.EqualValues(t, 7, result.Fields[4].ColumnLength)
+	assert.EqualValues(t, notNullFlag, result.Fields[1].Flags&notNullFlag)
+	assert.Zero(t, result.Fields[2].Flags&notNullFlag)
+	assert.Equal(t, `[[INT8(1) INT64(1) VARCHAR("foo") DATETIME("1970-01-01 00:00:00") DECIMAL(-0.05)] [INT8(0) INT64(2) NULL DATETIME("1970-01-02 00:00:00") DECIMAL(123.45)]]`, fmt.Sprint(result.Rows))
+
+	// the converted fields are converted back to the same Arrow types
+	rec2, err := ToRecordBatch(mem, result)
+	require.NoError(t, err)
+	defer rec2.Release()
+	assert.Equal(t, "int8", rec2.Schema().Field(0).Type.String())
+	assert.Equal(t, "decimal(5, 2)", rec2.Schema().Field(4).Type.String())
+}
+
+func TestMetadataMismatch(t *testing.T) {
+	schema, err := Schema([]*querypb.Field{field("i", sqltypes.Int32, 11, 0, 0)})
+	require.NoError(t, err)
+
+	f := schema.Field(0)
+	f.Type = arrow.BinaryTypes.String
+	_, err = Fields(arrow.NewSchema([]arrow.Field{f}, nil))
+	assert.EqualError(t, err, `the Arrow field "i" of type utf8 cannot hold values of type INT32`)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlarrow
+
+import (
+	"errors"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+// StreamConverter converts the results of a streaming query to record batches
+// of a fixed number of rows.
+type StreamConverter struct {
+	mem       memory.Allocator
+	batchSize int
+	send      func(arrow.RecordBatch) error
+	builder   *Builder
+}
+
+// NewStreamConverter returns a StreamConverter that sends record batches of
+// batchSize rows to send, and a last record batch with the remaining rows when
+// it is closed. The record batches are released when send returns, so send
+// must retain the ones it keeps.
+func NewStreamConverter(mem memory.Allocator, batchSize int, send func(arrow.RecordBatch) error) *StreamConverter {
+	return &StreamConverter{
+		mem:       mem,
+		batchSize: max(batchSize, 1),
+		send:      send,
+	}
+}
+
+// Callback converts a result of the streaming query, and is meant to be the
+// callback of the query. The first result must have the fields of the query.
+func (s *StreamConverter) Callback(result *sqltypes.Result) error {
+	if s.builder == nil {
+		if result.Fields == nil {
+			return errors.New("the first result of the stream has no fields")
+		}
+		var err error
+		if s.builder, err = NewBuilder(s.mem, result.Fields); err != nil {
+			return err
+		}
+	}
+
+	rows := result.Rows
+	for len(rows) > 0 {
+		n := min(len(rows), s.batchSize-s.builder.Len())
+		if err := s.builder.Append(rows[:n]); err != nil {
+			return err
+		}
+		rows = rows[n:]
+		if s.builder.Len() == s.batchSize {
+			if err := s.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close sends the remaining rows, if any, and releases the StreamConverter.
+func (s *StreamConverter) Close() error {
+	if s.builder == nil {
+		return nil
+	}
+	defer s.builder.Release()
+	if s.builder.Len() == 0 {
+		return nil
+	}
+	return s.flush()
+}
+
+func (s *StreamConverter) flush() error {
+	rec := s.builder.NewRecordBatch()
+	defer rec.Release()
+	return s.send(rec)
+}
+
+// StreamRecordBatches converts the record batches of a reader to results, and
+// sends them to callback like a streaming query: the first result has the
+// fields, and the following ones the rows of a record batch each.
+func StreamRecordBatches(reader array.RecordReader, callback func(*sqltypes.Result) error) error {
+	fields, err := Fields(reader.Schema())
+	if err != nil {
+		return err
+	}
+	if err := callback(&sqltypes.Result{Fields: fields}); err != nil {
+		return err
+	}
+	for reader.Next() {
+		rows, err := Rows(reader.RecordBatch(), fields)
+		if err != nil {
+			return err
+		}
+		if err := callback(&sqltypes.Result{Rows: rows}); err != nil {
+			return err
+		}
+	}
+	return reader.Err()
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlarrow
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestStreamConverter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	var batches []arrow.RecordBatch
+	defer func() {
+		for _, rec := range batches {
+			rec.Release()
+		}
+	}()
+	s := NewStreamConverter(mem, 3, func(rec arrow.RecordBatch) error {
+		rec.Retain()
+		batches = append(batches, rec)
+		return nil
+	})
+
+	// stream the results like a streaming query: the fields first, and then
+	// chunks of rows of any size
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name", "int64|varchar"),
+		"1|a", "2|b", "3|c", "4|d", "5|e", "6|f", "7|g", "8|h")
+	require.NoError(t, s.Callback(&sqltypes.Result{Fields: result.Fields}))
+	require.NoError(t, s.Callback(&sqltypes.Result{Rows: result.Rows[:1]}))
+	require.NoError(t, s.Callback(&sqltypes.Result{Rows: result.Rows[1:7]}))
+	require.NoError(t, s.Callback(&sqltypes.Result{Rows: result.Rows[7:]}))
+	require.NoError(t, s.Close())
+
+	var sizes []int64
+	for _, rec := range batches {
+		sizes = append(sizes, rec.NumRows())
+	}
+	assert.Equal(t, []int64{3, 3, 2}, sizes)
+
+	// the record batches stream back to the same result
+	reader, err := array.NewRecordReader(batches[0].Schema(), batches)
+	require.NoError(t, err)
+	defer reader.Release()
+
+	got := &sqltypes.Result{}
+	var results int
+	err = StreamRecordBatches(reader, func(r *sqltypes.Result) error {
+		results++
+		got.AppendResult(r)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, results)
+	assert.True(t, result.Equal(got), "got %v, want %v", got, result)
+}
+
+func TestStreamConverterErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	s := NewStreamConverter(mem, 10, func(arrow.RecordBatch) error { return nil })
+	assert.EqualError(t, s.Callback(&sqltypes.Result{}), "the first result of the stream has no fields")
+	require.NoError(t, s.Close())
+
+	s = NewStreamConverter(mem, 10, func(arrow.RecordBatch) error { return nil })
+	fields := sqltypes.MakeTestFields("d", "date")
+	require.NoError(t, s.Callback(&sqltypes.Result{Fields: fields}))
+	err := s.Callback(sqltypes.MakeTestResult(fields, "0000-00-00"))
+	assert.EqualError(t, err, `column "d": cannot convert the date "0000-00-00" to Arrow`)
+	require.NoError(t, s.Close())
+}
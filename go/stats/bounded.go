@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+)
+
+// StatsOverflowStr is the label value used for every dimension of the
+// overflow bucket of a bounded metric. Once a bounded metric has reached its
+// cardinality cap, values for new label combinations are recorded in this
+// bucket instead.
+const StatsOverflowStr = "other"
+
+// cardinalityGuard caps the number of distinct label combinations tracked
+// by a multi-label metric. Combinations seen before the cap was reached keep
+// being tracked; any new combination is folded into the overflow key.
+type cardinalityGuard struct {
+	name        string
+	max         int
+	overflowKey string
+
+	mu   sync.RWMutex
+	seen map[string]struct{}
+
+	overflows atomic.Int64
+	warned    atomic.Bool
+}
+
+func newCardinalityGuard(name string, max int, labels []string, combinedLabels []bool) *cardinalityGuard {
+	overflow := make([]string, len(labels))
+	for i := range overflow {
+		overflow[i] = StatsOverflowStr
+	}
+	return &cardinalityGuard{
+		name:        name,
+		max:         max,
+		overflowKey: safeJoinLabels(overflow, combinedLabels),
+		seen:        make(map[string]struct{}),
+	}
+}
+
+// key returns the key under which a value for the given joined label key
+// should be recorded. A max of zero or less disables the cap.
+func (g *cardinalityGuard) key(key string) string {
+	if g.max <= 0 || key == g.overflowKey {
+		return key
+	}
+
+	g.mu.RLock()
+	_, ok := g.seen[key]
+	g.mu.RUnlock()
+	if ok {
+		return key
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.seen[key]; ok {
+		return key
+	}
+	if len(g.seen) < g.max {
+		g.seen[key] = struct{}{}
+		return key
+	}
+	g.overflows.Add(1)
+	if g.warned.CompareAndSwap(false, true) {
+		log.Warn("stats: metric reached its cardinality cap, new label values are recorded in the overflow bucket",
+			slog.String("metric", g.name),
+			slog.Int("max", g.max),
+			slog.String("overflow", g.overflowKey))
+	}
+	return g.overflowKey
+}
+
+func (g *cardinalityGuard) reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	clear(g.seen)
+	g.warned.Store(false)
+}
+
+// BoundedCountersWithMultiLabels is a CountersWithMultiLabels that tracks at
+// most a fixed number of distinct label combinations. Once the cap is
+// reached, values for new combinations are added to the overflow bucket,
+// whose label values are all StatsOverflowStr. This makes it safe to use
+// labels with unbounded domains, such as table names.
+//
+// The published variable is the wrapped CountersWithMultiLabels, so every
+// stats backend exports it like any other multi-label counter. Only the
+// methods that go through the cap are exposed.
+type BoundedCountersWithMultiLabels struct {
+	counters *CountersWithMultiLabels
+	guard    *cardinalityGuard
+}
+
+// NewBoundedCountersWithMultiLabels creates a new
+// BoundedCountersWithMultiLabels that tracks at most maxCardinality label
+// combinations, plus the overflow bucket. A maxCardinality of zero or less
+// disables the cap. The counters are published if name is set.
+func NewBoundedCountersWithMultiLabels(name, help string, labels []string, maxCardinality int) *BoundedCountersWithMultiLabels {
+	counters := NewCountersWithMultiLabels(name, help, labels)
+	return &BoundedCountersWithMultiLabels{
+		counters: counters,
+		guard:    newCardinalityGuard(name, maxCardinality, labels, counters.combinedLabels),
+	}
+}
+
+// Add adds a value to a named counter, or to the overflow bucket if the
+// label combination is new and the cap has been reached.
+// len(names) must be equal to len(Labels).
+func (c *BoundedCountersWithMultiLabels) Add(names []string, value int64) {
+	if len(names) != len(c.counters.labels) {
+		panic("BoundedCountersWithMultiLabels: wrong number of values in Add")
+	}
+	c.counters.add(c.guard.key(safeJoinLabels(names, c.counters.combinedLabels)), value)
+}
+
+// ResetAll clears the counters and the set of tracked label combinations.
+func (c *BoundedCountersWithMultiLabels) ResetAll() {
+	c.counters.ResetAll()
+	c.guard.reset()
+}
+
+// Counts returns a copy of the counters' map, whose keys are the label
+// values joined by ".".
+func (c *BoundedCountersWithMultiLabels) Counts() map[string]int64 {
+	return c.counters.Counts()
+}
+
+// Labels returns the list of labels.
+func (c *BoundedCountersWithMultiLabels) Labels() []string {
+	return c.counters.Labels()
+}
+
+// Help returns the help string.
+func (c *BoundedCountersWithMultiLabels) Help() string {
+	return c.counters.Help()
+}
+
+// String implements the expvar.Var interface.
+func (c *BoundedCountersWithMultiLabels) String() string {
+	return c.counters.String()
+}
+
+// Overflows returns the number of values that were added to the overflow
+// bucket because the cap had been reached.
+func (c *BoundedCountersWithMultiLabels) Overflows() int64 {
+	return c.guard.overflows.Load()
+}
+
+// BoundedMultiTimings is a MultiTimings that tracks at most a fixed number
+// of distinct label combinations. See BoundedCountersWithMultiLabels.
+type BoundedMultiTimings struct {
+	timings *MultiTimings
+	guard   *cardinalityGuard
+}
+
+// NewBoundedMultiTimings creates a new BoundedMultiTimings that tracks at
+// most maxCardinality label combinations, plus the overflow bucket. A
+// maxCardinality of zero or less disables the cap. The timings are published
+// if name is set.
+func NewBoundedMultiTimings(name, help string, labels []string, maxCardinality int) *BoundedMultiTimings {
+	timings := NewMultiTimings(name, help, labels)
+	return &BoundedMultiTimings{
+		timings: timings,
+		guard:   newCardinalityGuard(name, maxCardinality, labels, timings.combinedLabels),
+	}
+}
+
+// Add will add a new value to the named histogram, or to the overflow
+// bucket if the label combination is new and the cap has been reached.
+func (t *BoundedMultiTimings) Add(names []string, elapsed time.Duration) {
+	if len(names) != len(t.timings.labels) {
+		panic("BoundedMultiTimings: wrong number of values in Add")
+	}
+	t.timings.Timings.Add(t.guard.key(safeJoinLabels(names, t.timings.combinedLabels)), elapsed)
+}
+
+// Record is a convenience function that records completion
+// timing data based on the provided start time of an event.
+func (t *BoundedMultiTimings) Record(names []string, startTime time.Time) {
+	t.Add(names, time.Since(startTime))
+}
+
+// Reset clears the timings and the set of tracked label combinations.
+func (t *BoundedMultiTimings) Reset() {
+	t.timings.Reset()
+	t.guard.reset()
+}
+
+// Counts returns the total count and the count of each histogram.
+func (t *BoundedMultiTimings) Counts() map[string]int64 {
+	return t.timings.Counts()
+}
+
+// Histograms returns a copy of the histograms.
+func (t *BoundedMultiTimings) Histograms() map[string]*Histogram {
+	return t.timings.Histograms()
+}
+
+// Labels returns the list of labels.
+func (t *BoundedMultiTimings) Labels() []string {
+	return t.timings.Labels()
+}
+
+// Help returns the help string.
+func (t *BoundedMultiTimings) Help() string {
+	return t.timings.Help()
+}
+
+// String implements the expvar.Var interface.
+func (t *BoundedMultiTimings) String() string {
+	return t.timings.String()
+}
+
+// Overflows returns the number of values that were added to the overflow
+// bucket because the cap had been reached.
+func (t *BoundedMultiTimings) Overflows() int64 {
+	return t.guard.overflows.Load()
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedCounters(t *testing.T) {
+	clearStats()
+	c := NewBoundedCountersWithMultiLabels("boundedCounter1", "help", []string{"Table", "Plan"}, 2)
+	c.Add([]string{"t1", "Select"}, 1)
+	c.Add([]string{"t2", "Select"}, 1)
+	c.Add([]string{"t3", "Select"}, 1)
+	c.Add([]string{"t4", "Insert"}, 2)
+	c.Add([]string{"t1", "Select"}, 1)
+
+	assert.Equal(t, map[string]int64{
+		"t1.Select":   2,
+		"t2.Select":   1,
+		"other.other": 3,
+	}, c.Counts())
+	assert.EqualValues(t, 2, c.Overflows())
+
+	// The wrapped counters are published, so backends see a regular
+	// CountersWithMultiLabels.
+	v := expvar.Get("boundedCounter1")
+	assert.Same(t, c.counters, v)
+
+	c.ResetAll()
+	c.Add([]string{"t3", "Select"}, 1)
+	assert.Equal(t, map[string]int64{"t3.Select": 1}, c.Counts())
+}
+
+func TestBoundedCountersUnbounded(t *testing.T) {
+	clearStats()
+	c := NewBoundedCountersWithMultiLabels("", "help", []string{"Table"}, 0)
+	for _, table := range []string{"t1", "t2", "t3"} {
+		c.Add([]string{table}, 1)
+	}
+	assert.Len(t, c.Counts(), 3)
+	assert.Zero(t, c.Overflows())
+}
+
+func TestBoundedCountersCombinedDimension(t *testing.T) {
+	clearStats()
+	combineDimensions = "Plan"
+	c := NewBoundedCountersWithMultiLabels("", "help", []string{"Table", "Plan"}, 1)
+	c.Add([]string{"t1", "Select"}, 1)
+	c.Add([]string{"t1", "Insert"}, 1)
+	c.Add([]string{"t2", "Insert"}, 1)
+
+	assert.Equal(t, map[string]int64{
+		"t1.all":    2,
+		"other.all": 1,
+	}, c.Counts())
+}
+
+func TestBoundedMultiTimings(t *testing.T) {
+	clearStats()
+	mt := NewBoundedMultiTimings("boundedTimings1", "help", []string{"Table", "Plan"}, 1)
+	mt.Add([]string{"t1", "Select"}, time.Millisecond)
+	mt.Add([]string{"t2", "Select"}, time.Millisecond)
+	mt.Record([]string{"t3", "Select"}, time.Now())
+
+	assert.Equal(t, map[string]int64{
+		"All":         3,
+		"t1.Select":   1,
+		"other.other": 2,
+	}, mt.Counts())
+	assert.EqualValues(t, 2, mt.Overflows())
+	assert.IsType(t, &MultiTimings{}, expvar.Get("boundedTimings1"))
+
+	mt.Reset()
+	mt.Add([]string{"t2", "Select"}, time.Millisecond)
+	assert.Equal(t, map[string]int64{"All": 1, "t2.Select": 1}, mt.Counts())
+}
@@ -505,7 +505,7 @@ var specializedPlanQueries = []specializedPlanQuery{{
 	query: `select 1 from t1 tbl1, t1 tbl2, t1 tbl3, t1 tbl4 where tbl1.id = ? and tbl2.id = ? and tbl3.id = ? and tbl4.id = ?`,
 	args:  []any{1, 1, 1, 1},
 }, {
-	query: `SELECT e.id, e.name, s.age, ROW_NUMBER() OVER (PARTITION BY e.age ORDER BY s.name DESC) AS age_rank FROM t1 e, t1 s where e.id = ? and s.id = ?`,
+	query: `SELECT e.id, e.name, s.age, NTILE(2) OVER (PARTITION BY e.age ORDER BY s.name DESC) AS age_bucket FROM t1 e, t1 s where e.id = ? and s.id = ?`,
 	args:  []any{1, 1},
 }}
 
@@ -526,7 +526,7 @@ var streamingSpecializedPlanQueries = []specializedPlanQuery{{
 	query: `select 1 from t1 stbl1, t1 stbl2, t1 stbl3, t1 stbl4 where stbl1.id = ? and stbl2.id = ? and stbl3.id = ? and stbl4.id = ?`,
 	args:  []any{1, 1, 1, 1},
 }, {
-	query: `SELECT se.id, se.name, ss.age, ROW_NUMBER() OVER (PARTITION BY se.age ORDER BY ss.name DESC) AS age_rank FROM t1 se, t1 ss where se.id = ? and ss.id = ?`,
+	query: `SELECT se.id, se.name, ss.age, NTILE(2) OVER (PARTITION BY se.age ORDER BY ss.name DESC) AS age_bucket FROM t1 se, t1 ss where se.id = ? and ss.id = ?`,
 	args:  []any{1, 1},
 }}
 
@@ -630,7 +630,7 @@ func validateBaselineErrSpecializedPlan(t *testing.T, p map[string]any) {
 	require.EqualValues(t, "PlanSwitcher", pm["OperatorType"])
 	baselineErr := pm["BaselineErr"].(string)
 
-	require.Equal(t, "VT12001: unsupported: window functions are only supported for single-shard queries", baselineErr)
+	require.Equal(t, "VT12001: unsupported: window function 'ntile' in a cross-shard query", baselineErr)
 
 	pd, err := engine.PrimitiveDescriptionFromMap(plan.(map[string]any))
 	require.NoError(t, err)
@@ -56,9 +56,9 @@ var (
 
 func init() {
 	sidecarDBTables = []string{
-		"copy_state", "dt_participant", "dt_state", "heartbeat", "post_copy_action",
+		"column_statistics", "copy_state", "dml_journal", "dt_participant", "dt_state", "heartbeat", "jobs", "post_copy_action",
 		"redo_state", "redo_statement", "reparent_journal", "resharding_journal", "schema_migrations", "schema_version", "semisync_heartbeat",
-		"tables", "udfs", "vdiff", "vdiff_log", "vdiff_table", "views", "vreplication", "vreplication_log",
+		"table_analyze", "tables", "udfs", "vdiff", "vdiff_log", "vdiff_table", "views", "vreplication", "vreplication_conflicts", "vreplication_log",
 	}
 	numSidecarDBTables = len(sidecarDBTables)
 	ddls1 = []string{
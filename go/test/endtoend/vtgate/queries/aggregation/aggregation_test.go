@@ -657,6 +657,26 @@ func TestGroupConcatAggregation(t *testing.T) {
 	compareRow(t, mQr, vtQr, nil, []int{0})
 }
 
+// TestGroupConcatEvaluatedAtVTGate tests the group_concat function with DISTINCT, ORDER BY and LIMIT,
+// which vitess evaluates from all the rows instead of concatenating the results of every shard.
+func TestGroupConcatEvaluatedAtVTGate(t *testing.T) {
+	mcmp, closer := start(t)
+	defer closer()
+	mcmp.Exec("insert into t1(t1_id, `name`, `value`, shardkey) values(1,'a1',null,100), (2,'b1','foo',20), (3,'c1','foo',10), (4,'a1','foo',100), (5,'d1','toto',200), (6,'c1',null,893), (10,'a1','titi',2380), (20,'b1','tete',12833), (9,'e1','yoyo',783493)")
+	mcmp.Exec("insert into t2(id, shardKey) values (1, 10), (2, 20)")
+
+	mcmp.Exec(`SELECT group_concat(distinct name order by name) FROM t1`)
+	mcmp.Exec(`SELECT group_concat(distinct value order by value desc separator '|') FROM t1`)
+	mcmp.Exec(`SELECT group_concat(name order by t1_id separator '' limit 2, 3) FROM t1`)
+	mcmp.Exec(`SELECT name, group_concat(t1_id, value order by t1_id desc limit 2) FROM t1 group by name order by name`)
+	mcmp.Exec(`SELECT count(*), group_concat(distinct name, value order by 2, 1) FROM t1`)
+	mcmp.Exec(`SELECT group_concat(value order by t1.t1_id) FROM t1 join t2 on t1.shardKey = t2.shardKey`)
+
+	mcmp.Exec(`SET group_concat_max_len = 10`)
+	mcmp.Exec(`SELECT group_concat(name order by t1_id) FROM t1`)
+	mcmp.Exec(`SELECT name, group_concat(distinct value order by value) FROM t1 group by name order by name`)
+}
+
 func compareRow(t *testing.T, mRes *sqltypes.Result, vtRes *sqltypes.Result, grpCols []int, fCols []int) {
 	require.Len(t, vtRes.Rows, len(mRes.Rows), "mysql and vitess result count does not match")
 	for _, row := range vtRes.Rows {
@@ -51,6 +51,9 @@ func TestFoundRows(t *testing.T) {
 		mcmp.AssertFoundRowsValue("select SQL_CALC_FOUND_ROWS * from t2 where id3 = 4 order by id3 limit 2", workload, 1)
 		mcmp.AssertFoundRowsValue("select SQL_CALC_FOUND_ROWS * from t2 where id4 = 3 order by id3 limit 2", workload, 3)
 		mcmp.AssertFoundRowsValue("select SQL_CALC_FOUND_ROWS id4, count(id3) from t2 where id3 = 3 group by id4 limit 1", workload, 1)
+		mcmp.AssertFoundRowsValue("select distinct id4 from t2 order by id4", workload, 2)
+		mcmp.AssertFoundRowsValue("select SQL_CALC_FOUND_ROWS distinct id4 from t2 order by id4 limit 1", workload, 2)
+		mcmp.AssertFoundRowsValue("select SQL_CALC_FOUND_ROWS distinct id3 from t2 order by id3 limit 1", workload, 5)
 	}
 
 	runTests("oltp")
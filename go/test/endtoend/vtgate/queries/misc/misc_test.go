@@ -161,6 +161,7 @@ func TestSetAndGetLastInsertID(t *testing.T) {
 		"select last_insert_id(%d)",
 		"select last_insert_id(%d), id1, id2 from t1 limit 1",
 		"select last_insert_id(%d), id1, id2 from t1 where 1 = 2",
+		"select last_insert_id(%d), id1, id2 from t1 order by id1",
 		"select 12 from t1 where last_insert_id(%d)",
 		"update t1 set id2 = last_insert_id(%d) where id1 = 1",
 		"update t1 set id2 = last_insert_id(%d) where id1 = 2",
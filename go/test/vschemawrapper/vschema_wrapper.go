@@ -52,11 +52,16 @@ type VSchemaWrapper struct {
 	Dest                  key.ShardDestination
 	SysVarEnabled         bool
 	DeniedSysVars         map[string]struct{}
+	AllowedGlobalSysVars  map[string]struct{}
 	ForeignKeyChecksState *bool
 	Version               plancontext.PlannerVersion
 	EnableViews           bool
 	TestBuilder           func(query string, vschema plancontext.VSchema, keyspace string) (*engine.Plan, error)
 	Env                   *vtenv.Environment
+
+	// RecordDecisions makes the wrapper record the planner decisions in Decisions.
+	RecordDecisions bool
+	Decisions       []engine.PlannerDecision
 }
 
 func NewVschemaWrapper(
@@ -150,6 +155,14 @@ func (vw *VSchemaWrapper) Environment() *vtenv.Environment {
 func (vw *VSchemaWrapper) PlannerWarning(_ string) {
 }
 
+func (vw *VSchemaWrapper) PlannerDecisionsEnabled() bool {
+	return vw.RecordDecisions
+}
+
+func (vw *VSchemaWrapper) PlannerDecision(decision engine.PlannerDecision) {
+	vw.Decisions = append(vw.Decisions, decision)
+}
+
 func (vw *VSchemaWrapper) ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error) {
 	defaultFkMode := vschemapb.Keyspace_unmanaged
 	if vw.V.Keyspaces[keyspace] != nil && vw.V.Keyspaces[keyspace].ForeignKeyMode != vschemapb.Keyspace_unspecified {
@@ -173,6 +186,10 @@ func (vw *VSchemaWrapper) GetAggregateUDFs() (udfs []string) {
 	return vw.V.GetAggregateUDFs()
 }
 
+func (vw *VSchemaWrapper) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	return vw.V.GetUDFReturnTypes(keyspace)
+}
+
 func (vw *VSchemaWrapper) GetForeignKeyChecksState() *bool {
 	return vw.ForeignKeyChecksState
 }
@@ -231,6 +248,14 @@ func (vw *VSchemaWrapper) HasDeniedSystemVariables() bool {
 	return len(vw.DeniedSysVars) > 0
 }
 
+func (vw *VSchemaWrapper) IsGlobalSystemVariableAllowed(name string) bool {
+	if len(vw.AllowedGlobalSysVars) == 0 {
+		return false
+	}
+	_, allowed := vw.AllowedGlobalSysVars[strings.ToLower(name)]
+	return allowed
+}
+
 func (vw *VSchemaWrapper) TargetDestination(qualifier string) (key.ShardDestination, *vindexes.Keyspace, topodatapb.TabletType, error) {
 	return vw.Vcursor.TargetDestination(qualifier)
 }
@@ -34,12 +34,44 @@ func MysqlCallInfo(ctx context.Context, c *mysql.Conn) context.Context {
 	return NewContext(ctx, &mysqlCallInfoImpl{
 		remoteAddr: c.RemoteAddr().String(),
 		user:       c.User,
+		program:    MysqlClientProgram(c.Attributes),
+		os:         c.Attributes[mysql.ConnAttrOS],
 	})
 }
 
+// MysqlClientProgram returns the program of a MySQL client from its
+// connection attributes: its program_name, or the name of its client
+// library if it sent none.
+func MysqlClientProgram(attributes mysql.ConnectionAttributes) string {
+	if program := attributes[mysql.ConnAttrProgramName]; program != "" {
+		return program
+	}
+	return attributes[mysql.ConnAttrClientName]
+}
+
+// MysqlClientFromContext returns the program and operating system of the
+// MySQL client of the call in ctx, as sent in its connection attributes.
+// They are empty if the call is not from a MySQL client, or if the client
+// did not send them.
+func MysqlClientFromContext(ctx context.Context) (program, os string) {
+	ci, ok := FromContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	mci, ok := ci.(*mysqlCallInfoImpl)
+	if !ok {
+		return "", ""
+	}
+	return mci.program, mci.os
+}
+
 type mysqlCallInfoImpl struct {
 	remoteAddr string
 	user       string
+	// program and os are the program and operating system of the client,
+	// from its connection attributes.
+	program string
+	os      string
 }
 
 func (mci *mysqlCallInfoImpl) RemoteAddr() string {
@@ -54,15 +86,19 @@ func (mci *mysqlCallInfoImpl) Text() string {
 	return fmt.Sprintf("%s@%s(Mysql)", mci.user, mci.remoteAddr)
 }
 
-var mysqlTmpl = template.Must(template.New("tcs").Parse("<b>MySQL User:</b> {{.MySQLUser}} <b>Remote Addr:</b> {{.RemoteAddr}}"))
+var mysqlTmpl = template.Must(template.New("tcs").Parse("<b>MySQL User:</b> {{.MySQLUser}} <b>Remote Addr:</b> {{.RemoteAddr}}{{if .Program}} <b>Program:</b> {{.Program}}{{end}}{{if .OS}} <b>OS:</b> {{.OS}}{{end}}"))
 
 func (mci *mysqlCallInfoImpl) HTML() safehtml.HTML {
 	html, err := mysqlTmpl.ExecuteToHTML(struct {
 		MySQLUser  string
 		RemoteAddr string
+		Program    string
+		OS         string
 	}{
 		MySQLUser:  mci.user,
 		RemoteAddr: mci.remoteAddr,
+		Program:    mci.program,
+		OS:         mci.os,
 	})
 	if err != nil {
 		panic(err)
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
 )
 
 func TestMysqlCallInfo(t *testing.T) {
@@ -33,3 +35,25 @@ func TestMysqlCallInfo(t *testing.T) {
 	require.Equal(t, "test@localhost(Mysql)", mysqlCi.Text())
 	require.Equal(t, "<b>MySQL User:</b> test <b>Remote Addr:</b> localhost", mysqlCi.HTML().String())
 }
+
+func TestMysqlCallInfoClient(t *testing.T) {
+	mysqlCi := &mysqlCallInfoImpl{
+		remoteAddr: "localhost",
+		user:       "test",
+		program:    "mysql",
+		os:         "Linux",
+	}
+	require.Equal(t, "<b>MySQL User:</b> test <b>Remote Addr:</b> localhost <b>Program:</b> mysql <b>OS:</b> Linux", mysqlCi.HTML().String())
+
+	program, os := MysqlClientFromContext(NewContext(t.Context(), mysqlCi))
+	require.Equal(t, "mysql", program)
+	require.Equal(t, "Linux", os)
+
+	program, os = MysqlClientFromContext(t.Context())
+	require.Empty(t, program)
+	require.Empty(t, os)
+
+	require.Equal(t, "mysql", MysqlClientProgram(mysql.ConnectionAttributes{"program_name": "mysql", "_client_name": "libmysql"}))
+	require.Equal(t, "libmysql", MysqlClientProgram(mysql.ConnectionAttributes{"_client_name": "libmysql"}))
+	require.Empty(t, MysqlClientProgram(nil))
+}
@@ -93,6 +93,7 @@ type DBConfigs struct {
 	ConnectTimeoutMilliseconds int           `json:"connectTimeoutMilliseconds,omitempty"`
 	DBName                     string        `json:"dbName,omitempty"`
 	EnableQueryInfo            bool          `json:"enableQueryInfo,omitempty"`
+	ZstdCompressionLevel       int           `json:"zstdCompressionLevel,omitempty"`
 
 	App          UserConfig `json:"app"`
 	Dba          UserConfig `json:"dba"`
@@ -119,6 +120,9 @@ type UserConfig struct {
 	Password string `json:"password,omitempty"`
 	UseSSL   bool   `json:"useSsl,omitempty"`
 	UseTCP   bool   `json:"useTcp,omitempty"`
+	// Compression is the protocol compression to ask mysqld for:
+	// zlib, zstd, or empty for none.
+	Compression string `json:"compression,omitempty"`
 }
 
 // RegisterFlags registers the base DBFlags, credentials flags, and the user
@@ -151,6 +155,7 @@ func registerBaseFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &GlobalDBConfigs.ServerName, "db-server-name", "", "server name of the DB we are connecting to.")
 	utils.SetFlagIntVar(fs, &GlobalDBConfigs.ConnectTimeoutMilliseconds, "db-connect-timeout-ms", 0, "connection timeout to mysqld in milliseconds (0 for no timeout)")
 	utils.SetFlagBoolVar(fs, &GlobalDBConfigs.EnableQueryInfo, "db-conn-query-info", false, "enable parsing and processing of QUERY_OK info fields")
+	utils.SetFlagIntVar(fs, &GlobalDBConfigs.ZstdCompressionLevel, "db-zstd-compression-level", mysql.DefaultZstdCompressionLevel, "zstd compression level, from 1 to 22, of the connections using zstd protocol compression")
 }
 
 // The flags will change the global singleton
@@ -165,6 +170,8 @@ func registerPerUserFlags(fs *pflag.FlagSet, userKey string, uc *UserConfig, cp
 
 	utils.SetFlagBoolVar(fs, &uc.UseSSL, "db-"+userKey+"-use-ssl", true, "Set this flag to false to make the "+userKey+" connection to not use ssl")
 	// fs.BoolVar(&uc.UseSSL, "db_"+userKey+"_use_ssl", true, "Set this flag to false to make the "+userKey+" connection to not use ssl")
+
+	utils.SetFlagStringVar(fs, &uc.Compression, "db-"+userKey+"-compression", "", "Protocol compression of the "+userKey+" connection, if mysqld supports it: zlib or zstd. Empty disables compression.")
 }
 
 // Connector contains Connection Parameters for mysql connection
@@ -373,6 +380,14 @@ func (dbcfgs *DBConfigs) InitWithSocket(defaultSocketFile string, collationEnv *
 
 		cp.Uname = uc.User
 		cp.Pass = uc.Password
+		compression, err := mysql.ParseCompressionAlgorithm(uc.Compression)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Disabling protocol compression of the %s connection: %v", userKey, err))
+		}
+		cp.Compression = compression
+		if compression == mysql.CompressionZstd {
+			cp.ZstdCompressionLevel = dbcfgs.ZstdCompressionLevel
+		}
 		if uc.UseSSL {
 			cp.SslMode = dbcfgs.SslMode
 			cp.SslCa = dbcfgs.SslCa
@@ -54,6 +54,7 @@ func TestInit(t *testing.T) {
 		SslCert:                    "f",
 		SslKey:                     "g",
 		ConnectTimeoutMilliseconds: 250,
+		ZstdCompressionLevel:       5,
 		App: UserConfig{
 			User:     "app",
 			Password: "apppass",
@@ -62,9 +63,10 @@ func TestInit(t *testing.T) {
 			UseSSL: true,
 		},
 		Dba: UserConfig{
-			User:     "dba",
-			Password: "dbapass",
-			UseSSL:   true,
+			User:        "dba",
+			Password:    "dbapass",
+			UseSSL:      true,
+			Compression: "zstd",
 		},
 		appParams: mysql.ConnParams{
 			UnixSocket: "socket",
@@ -116,6 +118,9 @@ func TestInit(t *testing.T) {
 		SslCert:          "f",
 		SslKey:           "g",
 		ConnectTimeoutMs: 250,
+
+		Compression:          mysql.CompressionZstd,
+		ZstdCompressionLevel: 5,
 	}
 	assert.Equal(t, want, dbConfigs.dbaParams)
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -84,6 +85,40 @@ func (dbc *DBConnection) ExecuteFetch(query string, maxrows int, wantfields bool
 	return mqr, nil
 }
 
+// ExecuteFetchResults overwrites mysql.Conn.ExecuteFetchResults.
+func (dbc *DBConnection) ExecuteFetchResults(query string, maxrows int, wantfields bool) iter.Seq2[*sqltypes.Result, error] {
+	return func(yield func(*sqltypes.Result, error) bool) {
+		for mqr, err := range dbc.Conn.ExecuteFetchResults(query, maxrows, wantfields) {
+			if err != nil {
+				dbc.handleError(err)
+			}
+			if !yield(mqr, err) {
+				return
+			}
+		}
+	}
+}
+
+// Prepare overwrites mysql.Conn.Prepare.
+func (dbc *DBConnection) Prepare(query string) (*mysql.PreparedStatement, error) {
+	stmt, err := dbc.Conn.Prepare(query)
+	if err != nil {
+		dbc.handleError(err)
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// ExecutePrepared overwrites mysql.Conn.ExecutePrepared.
+func (dbc *DBConnection) ExecutePrepared(stmt *mysql.PreparedStatement, args []sqltypes.Value, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	mqr, err := dbc.Conn.ExecutePrepared(stmt, args, maxrows, wantfields)
+	if err != nil {
+		dbc.handleError(err)
+		return nil, err
+	}
+	return mqr, nil
+}
+
 // ExecuteStreamFetch overwrites mysql.Conn.ExecuteStreamFetch.
 func (dbc *DBConnection) ExecuteStreamFetch(query string, callback func(*sqltypes.Result) error, alloc func() *sqltypes.Result, streamBufferSize int) error {
 	err := dbc.Conn.ExecuteStreamFetch(query)
@@ -91,6 +126,24 @@ func (dbc *DBConnection) ExecuteStreamFetch(query string, callback func(*sqltype
 		dbc.handleError(err)
 		return err
 	}
+	return dbc.streamResult(callback, alloc, streamBufferSize)
+}
+
+// StreamNextResult streams the next result of the streaming query started by
+// ExecuteStreamFetch, once MoreStreamResults returns true, the same way
+// ExecuteStreamFetch streams the first one.
+func (dbc *DBConnection) StreamNextResult(callback func(*sqltypes.Result) error, alloc func() *sqltypes.Result, streamBufferSize int) error {
+	err := dbc.Conn.StreamNextResult()
+	if err != nil {
+		dbc.handleError(err)
+		return err
+	}
+	return dbc.streamResult(callback, alloc, streamBufferSize)
+}
+
+// streamResult sends the fields of the result being streamed to callback,
+// then its rows in batches of about streamBufferSize bytes.
+func (dbc *DBConnection) streamResult(callback func(*sqltypes.Result) error, alloc func() *sqltypes.Result, streamBufferSize int) error {
 	defer dbc.CloseResult()
 
 	// first call the callback with the fields
@@ -187,6 +187,18 @@ func (fhc *FakeHealthCheck) SetPrimaryTimestamp(tablet *topodatapb.Tablet, times
 	item.ts.PrimaryTermStartTime = timestamp
 }
 
+// SetCapabilities sets the capabilities that the given tablet advertises
+func (fhc *FakeHealthCheck) SetCapabilities(tablet *topodatapb.Tablet, capabilities *querypb.TabletCapabilities) {
+	fhc.mu.Lock()
+	defer fhc.mu.Unlock()
+	key := TabletToMapKey(tablet)
+	item, isPresent := fhc.items[key]
+	if !isPresent {
+		return
+	}
+	item.ts.Capabilities = capabilities
+}
+
 // Unsubscribe is not implemented.
 func (fhc *FakeHealthCheck) Unsubscribe(c chan *TabletHealth) {
 }
@@ -409,6 +421,7 @@ func simpleCopy(th *TabletHealth) *TabletHealth {
 		Tablet:               th.Tablet.CloneVT(),
 		Target:               th.Target.CloneVT(),
 		Stats:                th.Stats.CloneVT(),
+		Capabilities:         th.Capabilities.CloneVT(),
 		LastError:            th.LastError,
 		PrimaryTermStartTime: th.PrimaryTermStartTime,
 		Serving:              th.Serving,
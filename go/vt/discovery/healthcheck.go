@@ -42,6 +42,7 @@ import (
 	"os"
 	"runtime/pprof"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -994,6 +995,12 @@ func (hc *HealthCheckImpl) RegisterStats() {
 		"HealthcheckChecksum",
 		"crc32 checksum of the current healthcheck state",
 		hc.stateChecksum)
+
+	stats.NewGaugesFuncWithMultiLabels(
+		"HealthcheckCapabilitiesVersion",
+		"the number of serving tablets per version of their capabilities",
+		[]string{"Version"},
+		hc.capabilitiesVersionStats)
 }
 
 // ServeHTTP is part of the http.Handler interface. It renders the current state of the discovery gateway tablet cache into json.
@@ -1034,6 +1041,23 @@ func (hc *HealthCheckImpl) servingConnStats() map[string]int64 {
 	return res
 }
 
+// capabilitiesVersionStats returns the number of serving tablets per version
+// of their capabilities. Tablets that predate capabilities are counted as
+// version 0.
+func (hc *HealthCheckImpl) capabilitiesVersionStats() map[string]int64 {
+	res := make(map[string]int64)
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	for _, ths := range hc.healthData {
+		for _, th := range ths {
+			if th.Serving && th.LastError == nil {
+				res[strconv.Itoa(int(th.Capabilities.GetVersion()))]++
+			}
+		}
+	}
+	return res
+}
+
 // stateChecksum returns a crc32 checksum of the healthcheck state
 func (hc *HealthCheckImpl) stateChecksum() int64 {
 	// CacheStatus is sorted so this should be stable across vtgates
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -75,6 +76,10 @@ type KeyspaceEventWatcher struct {
 
 	subsMu sync.Mutex
 	subs   map[chan *KeyspaceEvent]struct{}
+
+	// cutOverShards is the number of shards whose primary reports an imminent
+	// Online DDL cut-over, so that TablesInCutOver is cheap when there is none.
+	cutOverShards atomic.Int64
 }
 
 // KeyspaceEvent is yielded to all watchers when an availability event for a keyspace has been resolved
@@ -208,6 +213,9 @@ type shardState struct {
 	waitForReparent      bool
 	externallyReparented int64
 	currentPrimary       *topodatapb.TabletAlias
+	// cutOverTables are the tables that the primary is about to swap in an
+	// Online DDL cut-over.
+	cutOverTables []string
 }
 
 // Subscribe returns a channel that will receive any KeyspaceEvents for all keyspaces in the
@@ -355,6 +363,9 @@ func (kss *keyspaceState) ensureConsistentLocked() {
 			sstate.serving))
 
 		if !sstate.serving {
+			if len(sstate.cutOverTables) > 0 {
+				kss.kew.cutOverShards.Add(-1)
+			}
 			delete(kss.shards, shard)
 		}
 	}
@@ -432,6 +443,18 @@ func (kss *keyspaceState) onHealthCheck(th *TabletHealth) {
 		kss.consistent = false
 	}
 
+	var cutOverTables []string
+	if th.Stats != nil {
+		cutOverTables = th.Stats.CutoverTables
+	}
+	switch {
+	case len(sstate.cutOverTables) == 0 && len(cutOverTables) > 0:
+		kss.kew.cutOverShards.Add(1)
+	case len(sstate.cutOverTables) > 0 && len(cutOverTables) == 0:
+		kss.kew.cutOverShards.Add(-1)
+	}
+	sstate.cutOverTables = cutOverTables
+
 	kss.ensureConsistentLocked()
 }
 
@@ -558,9 +581,9 @@ func (kss *keyspaceState) getMoveTablesStatus(vs *vschemapb.SrvVSchema) (*MoveTa
 	mtState.State = MoveTablesSwitching
 	rr := topotools.GetRoutingRulesMap(vs.GetRoutingRules())
 	if rr != nil {
-		r, ok := rr[oneDeniedTable]
-		// If a rule exists for the table and points to the target keyspace, writes have been switched.
-		if ok && len(r) > 0 && r[0] != fmt.Sprintf("%s.%s", kss.keyspace, oneDeniedTable) {
+		r := rr.ToTables(oneDeniedTable, time.Now())
+		// If a rule in effect exists for the table and points to the target keyspace, writes have been switched.
+		if len(r) > 0 && r[0] != fmt.Sprintf("%s.%s", kss.keyspace, oneDeniedTable) {
 			mtState.State = MoveTablesSwitched
 			log.Info(fmt.Sprintf("onSrvKeyspace::  keyspace %s writes have been switched for table %s, rule %v", kss.keyspace, oneDeniedTable, r[0]))
 		}
@@ -810,6 +833,28 @@ func (kew *KeyspaceEventWatcher) ShouldStartBufferingForTarget(ctx context.Conte
 	return nil, false
 }
 
+// TablesInCutOver returns whether the primary of any shard of the keyspace reports that
+// an Online DDL cut-over of one of the given tables is imminent.
+func (kew *KeyspaceEventWatcher) TablesInCutOver(ctx context.Context, keyspace string, tables []string) bool {
+	if kew.cutOverShards.Load() == 0 {
+		return false
+	}
+	ks := kew.getKeyspaceStatus(ctx, keyspace)
+	if ks == nil {
+		return false
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	for _, state := range ks.shards {
+		for _, table := range state.cutOverTables {
+			if slices.Contains(tables, table) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetServingKeyspaces gets the serving keyspaces from the keyspace event watcher.
 func (kew *KeyspaceEventWatcher) GetServingKeyspaces() []string {
 	kew.mu.Lock()
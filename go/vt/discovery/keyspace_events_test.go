@@ -640,6 +640,59 @@ func TestOnHealthCheck(t *testing.T) {
 	}
 }
 
+func TestTablesInCutOver(t *testing.T) {
+	kew := &KeyspaceEventWatcher{
+		keyspaces:        make(map[string]*keyspaceState),
+		missingKeyspaces: make(map[string]time.Time),
+		ts:               &fakeTopoServer{},
+	}
+	kss := &keyspaceState{
+		kew:      kew,
+		keyspace: "ks",
+		shards:   make(map[string]*shardState),
+		// Adding this so that we don't run any topo calls from ensureConsistentLocked.
+		moveTablesState: &MoveTablesState{
+			Typ:   MoveTablesRegular,
+			State: MoveTablesSwitching,
+		},
+	}
+	kew.keyspaces["ks"] = kss
+
+	primaryHealth := func(shard string, cutOverTables ...string) *TabletHealth {
+		return &TabletHealth{
+			Target: &querypb.Target{
+				Keyspace:   "ks",
+				Shard:      shard,
+				TabletType: topodatapb.TabletType_PRIMARY,
+			},
+			Serving:              true,
+			PrimaryTermStartTime: 1,
+			Tablet: &topodatapb.Tablet{
+				Alias: &topodatapb.TabletAlias{Cell: testCell, Uid: 1},
+			},
+			Stats: &querypb.RealtimeStats{CutoverTables: cutOverTables},
+		}
+	}
+
+	kss.onHealthCheck(primaryHealth("-80"))
+	kss.onHealthCheck(primaryHealth("80-"))
+	assert.False(t, kew.TablesInCutOver(t.Context(), "ks", []string{"t1"}))
+
+	kss.onHealthCheck(primaryHealth("80-", "t1"))
+	assert.True(t, kew.TablesInCutOver(t.Context(), "ks", []string{"t1", "t2"}))
+	assert.False(t, kew.TablesInCutOver(t.Context(), "ks", []string{"t2"}))
+	assert.False(t, kew.TablesInCutOver(t.Context(), "ks2", []string{"t1"}))
+	assert.EqualValues(t, 1, kew.cutOverShards.Load())
+
+	// Health checks without a change don't count the shard again.
+	kss.onHealthCheck(primaryHealth("80-", "t1"))
+	assert.EqualValues(t, 1, kew.cutOverShards.Load())
+
+	kss.onHealthCheck(primaryHealth("80-"))
+	assert.False(t, kew.TablesInCutOver(t.Context(), "ks", []string{"t1"}))
+	assert.EqualValues(t, 0, kew.cutOverShards.Load())
+}
+
 type fakeTopoServer struct{}
 
 // GetTopoServer returns the full topo.Server instance.
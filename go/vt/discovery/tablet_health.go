@@ -37,6 +37,7 @@ type TabletHealth struct {
 	Tablet               *topodata.Tablet
 	Target               *query.Target
 	Stats                *query.RealtimeStats
+	Capabilities         *query.TabletCapabilities
 	PrimaryTermStartTime int64
 	LastError            error
 	Serving              bool
@@ -49,6 +50,7 @@ func (th *TabletHealth) MarshalJSON() ([]byte, error) {
 		Serving              bool
 		PrimaryTermStartTime int64
 		Stats                *query.RealtimeStats
+		Capabilities         *query.TabletCapabilities
 		LastError            error
 	}{
 		Tablet:               th.Tablet,
@@ -56,6 +58,7 @@ func (th *TabletHealth) MarshalJSON() ([]byte, error) {
 		Serving:              th.Serving,
 		PrimaryTermStartTime: th.PrimaryTermStartTime,
 		Stats:                th.Stats,
+		Capabilities:         th.Capabilities,
 		LastError:            th.LastError,
 	})
 }
@@ -68,10 +71,17 @@ func (th *TabletHealth) DeepEqual(other *TabletHealth) bool {
 		th.Serving == other.Serving &&
 		th.PrimaryTermStartTime == other.PrimaryTermStartTime &&
 		proto.Equal(th.Stats, other.Stats) &&
+		proto.Equal(th.Capabilities, other.Capabilities) &&
 		((th.LastError == nil && other.LastError == nil) ||
 			(th.LastError != nil && other.LastError != nil && th.LastError.Error() == other.LastError.Error()))
 }
 
+// HasCapability returns whether the tablet supports an optional feature. See
+// queryservice.HasCapability.
+func (th *TabletHealth) HasCapability(feature string) bool {
+	return queryservice.HasCapability(th.Capabilities, feature)
+}
+
 // GetTabletHostPort formats a tablet host port address.
 func (th *TabletHealth) GetTabletHostPort() string {
 	hostname := th.Tablet.Hostname
@@ -65,6 +65,10 @@ type tabletHealthCheck struct {
 	// Stats is the current health status, as received by the
 	// StreamHealth RPC (replication lag, ...).
 	Stats *query.RealtimeStats
+	// Capabilities are the optional features the tablet supports, as
+	// received by the StreamHealth RPC. They are nil for tablets that
+	// predate capabilities.
+	Capabilities *query.TabletCapabilities
 	// LastError is the error we last saw when trying to get the
 	// tablet's healthcheck.
 	LastError error
@@ -92,6 +96,7 @@ func (thc *tabletHealthCheck) SimpleCopy() *TabletHealth {
 		Tablet:               thc.Tablet,
 		Target:               thc.Target,
 		Stats:                thc.Stats,
+		Capabilities:         thc.Capabilities,
 		LastError:            thc.LastError,
 		PrimaryTermStartTime: thc.PrimaryTermStartTime,
 		Serving:              thc.Serving,
@@ -193,6 +198,7 @@ func (thc *tabletHealthCheck) processResponse(hc *HealthCheckImpl, shr *query.St
 	thc.Target = shr.Target
 	thc.PrimaryTermStartTime = shr.PrimaryTermStartTimestamp
 	thc.Stats = shr.RealtimeStats
+	thc.Capabilities = shr.Capabilities
 	thc.LastError = healthErr
 	reason := "healthCheck update"
 	if healthErr != nil {
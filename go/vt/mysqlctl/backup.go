@@ -120,6 +120,11 @@ func registerBackupFlags(fs *pflag.FlagSet) {
 	utils.SetFlagIntVar(fs, &backupCompressBlocks, "backup-storage-number-blocks", backupCompressBlocks, "if backup-storage-compress is true, backup-storage-number-blocks sets the number of blocks that can be processed, in parallel, before the writer blocks, during compression (default is 2). It should be equal to the number of CPUs available for compression.")
 }
 
+// BackupName returns the name of the backup taken at backupTime by the given tablet.
+func BackupName(backupTime time.Time, tabletAlias string) string {
+	return fmt.Sprintf("%v.%v", backupTime.UTC().Format(BackupTimestampFormat), tabletAlias)
+}
+
 // Backup is the main entry point for a backup:
 // - uses the BackupStorage service to store a new backup
 // - shuts down Mysqld during the backup
@@ -131,7 +136,7 @@ func Backup(ctx context.Context, params BackupParams) error {
 
 	startTs := time.Now()
 	backupDir := GetBackupDir(params.Keyspace, params.Shard)
-	name := fmt.Sprintf("%v.%v", params.BackupTime.UTC().Format(BackupTimestampFormat), params.TabletAlias)
+	name := BackupName(params.BackupTime, params.TabletAlias)
 	// Start the backup with the BackupStorage.
 	bs, err := backupstorage.GetBackupStorage()
 	if err != nil {
@@ -96,6 +96,9 @@ type BackupParams struct {
 	BackupEngine string
 	// Any SQL that you would like to run before initializing the backup.
 	InitSQL *tabletmanagerdatapb.BackupRequest_InitSQL
+	// ExternalReplication is set when the replication of the tablet's MySQL
+	// is managed outside of Vitess, so the backup must not restart it.
+	ExternalReplication bool
 }
 
 func (b *BackupParams) Copy() BackupParams {
@@ -116,6 +119,7 @@ func (b *BackupParams) Copy() BackupParams {
 		UpgradeSafe:          b.UpgradeSafe,
 		MysqlShutdownTimeout: b.MysqlShutdownTimeout,
 		InitSQL:              b.InitSQL,
+		ExternalReplication:  b.ExternalReplication,
 	}
 }
 
@@ -144,6 +144,52 @@ func ChooseBinlogsForIncrementalBackup(
 	return nil, "", "", vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "cannot find binary logs that cover requested GTID range. backupFromGTIDSet=%v, prevGTIDsUnion=%v", backupFromGTIDSet.String(), prevGTIDsUnion.String())
 }
 
+// ChooseBinlogsToPurge chooses which binary logs can be purged, given a list of known binary logs,
+// a function that returns the "Previous GTIDs" per binary log, and the GTID sets up to which
+// consumers of the binary logs (e.g. VReplication streams) have read. A binary log can only be
+// purged once all of its entries are contained in each of the required GTID sets. The last
+// minBinaryLogs binary logs, and at least the active binary log, are always kept, and at most
+// maxBinaryLogs binary logs are purged.
+// The function returns the binary log to purge up to, which is itself kept, as expected by
+// PURGE BINARY LOGS TO, and the binary logs that are purged. Both are empty if no binary log
+// can be purged.
+func ChooseBinlogsToPurge(
+	ctx context.Context,
+	requiredGTIDSets []replication.GTIDSet,
+	minBinaryLogs int,
+	maxBinaryLogs int,
+	binaryLogs []string,
+	pgtids func(ctx context.Context, binlog string) (gtids string, err error),
+) (
+	purgeTo string,
+	binaryLogsToPurge []string,
+	err error,
+) {
+	keep := max(minBinaryLogs, 1)
+	for i := 1; i <= min(len(binaryLogs)-keep, maxBinaryLogs); i++ {
+		previousGtids, err := pgtids(ctx, binaryLogs[i])
+		if err != nil {
+			return "", nil, vterrors.Wrapf(err, "cannot get previous gtids for binlog %v", binaryLogs[i])
+		}
+		previousGTIDsPos, err := replication.ParsePosition(replication.Mysql56FlavorID, previousGtids)
+		if err != nil {
+			return "", nil, vterrors.Wrapf(err, "cannot decode binlog %s previous gtids %v", binaryLogs[i], previousGtids)
+		}
+		// The Previous-GTIDs of a binary log are the entries of all the binary logs before it.
+		// Those binary logs are only safe to purge if every consumer has read all of them.
+		// The binary logs are read in-order and Previous-GTIDs only expand, so once a consumer
+		// needs a binary log, it also needs all the binary logs after it.
+		for _, required := range requiredGTIDSets {
+			if !required.Contains(previousGTIDsPos.GTIDSet) {
+				return purgeTo, binaryLogsToPurge, nil
+			}
+		}
+		purgeTo = binaryLogs[i]
+		binaryLogsToPurge = binaryLogs[:i]
+	}
+	return purgeTo, binaryLogsToPurge, nil
+}
+
 // IsValidIncrementalBakcup determines whether the given manifest can be used to extend a backup
 // based on baseGTIDSet. The manifest must be able to pick up from baseGTIDSet, and must extend it by at least
 // one entry.
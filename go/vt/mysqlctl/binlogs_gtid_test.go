@@ -307,6 +307,105 @@ func TestChooseBinlogsForIncrementalBackup(t *testing.T) {
 	}
 }
 
+func TestChooseBinlogsToPurge(t *testing.T) {
+	binlogs := []string{
+		"vt-bin.000001",
+		"vt-bin.000002",
+		"vt-bin.000003",
+		"vt-bin.000004",
+		"vt-bin.000005",
+	}
+	previousGTIDs := map[string]string{
+		"vt-bin.000001": "",
+		"vt-bin.000002": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-60",
+		"vt-bin.000003": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-60",
+		"vt-bin.000004": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-78",
+		"vt-bin.000005": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-243",
+	}
+	tt := []struct {
+		name          string
+		required      []string
+		minBinaryLogs int
+		unexpired     int // number of most recent binary logs that have not expired yet
+		expectPurgeTo string
+		expectBinlogs []string
+	}{
+		{
+			name:          "no consumers",
+			expectPurgeTo: "vt-bin.000005",
+			expectBinlogs: []string{"vt-bin.000001", "vt-bin.000002", "vt-bin.000003", "vt-bin.000004"},
+		},
+		{
+			name:          "no consumers, keep binary logs",
+			minBinaryLogs: 3,
+			expectPurgeTo: "vt-bin.000003",
+			expectBinlogs: []string{"vt-bin.000001", "vt-bin.000002"},
+		},
+		{
+			name:          "no consumers, expired binary logs",
+			unexpired:     3,
+			expectPurgeTo: "vt-bin.000003",
+			expectBinlogs: []string{"vt-bin.000001", "vt-bin.000002"},
+		},
+		{
+			name:      "no consumers, no expired binary logs",
+			unexpired: 5,
+		},
+		{
+			name:          "consumer caught up",
+			required:      []string{"16b1039f-22b6-11ed-b765-0a43f95f28a3:1-250"},
+			expectPurgeTo: "vt-bin.000005",
+			expectBinlogs: []string{"vt-bin.000001", "vt-bin.000002", "vt-bin.000003", "vt-bin.000004"},
+		},
+		{
+			name:          "consumer in the middle of a binary log",
+			required:      []string{"16b1039f-22b6-11ed-b765-0a43f95f28a3:1-70"},
+			expectPurgeTo: "vt-bin.000003",
+			expectBinlogs: []string{"vt-bin.000001", "vt-bin.000002"},
+		},
+		{
+			name:     "slowest consumer wins",
+			required: []string{"16b1039f-22b6-11ed-b765-0a43f95f28a3:1-250", "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-59"},
+		},
+		{
+			name:     "consumer of another server",
+			required: []string{"8bc65c84-3fe4-11ed-a912-257f0fcdd6c9:1-100"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var required []replication.GTIDSet
+			for _, r := range tc.required {
+				pos, err := replication.ParsePosition(replication.Mysql56FlavorID, r)
+				require.NoError(t, err)
+				required = append(required, pos.GTIDSet)
+			}
+			purgeTo, binlogsToPurge, err := ChooseBinlogsToPurge(
+				t.Context(),
+				required,
+				tc.minBinaryLogs,
+				len(binlogs)-tc.unexpired,
+				binlogs,
+				func(ctx context.Context, binlog string) (gtids string, err error) {
+					gtids, ok := previousGTIDs[binlog]
+					if !ok {
+						return "", fmt.Errorf("previous gtids not found for binary log %v", binlog)
+					}
+					return gtids, nil
+				},
+			)
+			require.NoError(t, err)
+			if len(tc.expectBinlogs) == 0 {
+				assert.Empty(t, purgeTo)
+				assert.Empty(t, binlogsToPurge)
+				return
+			}
+			assert.Equal(t, tc.expectPurgeTo, purgeTo)
+			assert.Equal(t, tc.expectBinlogs, binlogsToPurge)
+		})
+	}
+}
+
 func TestIsValidIncrementalBakcup(t *testing.T) {
 	incrementalManifest := func(backupPos string, backupFromPos string) *BackupManifest {
 		return &BackupManifest{
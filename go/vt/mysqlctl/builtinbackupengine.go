@@ -518,7 +518,7 @@ func (be *BuiltinBackupEngine) executeFullBackup(ctx context.Context, params Bac
 	replicaStatus, err := params.Mysqld.ReplicationStatus(ctx)
 	switch err {
 	case nil:
-		replicaStartRequired = replicaStatus.Healthy() && !DisableActiveReparents
+		replicaStartRequired = replicaStatus.Healthy() && !DisableActiveReparents && !params.ExternalReplication
 	case mysql.ErrNotReplica:
 		// keep going if we're the primary, might be a degenerate case
 		sourceIsPrimary = true
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/pflag"
 
 	"vitess.io/vitess/go/mysql"
@@ -33,6 +34,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/servenv"
@@ -46,6 +48,7 @@ import (
 const (
 	clonePluginStatusQuery = "SELECT PLUGIN_STATUS FROM information_schema.PLUGINS WHERE PLUGIN_NAME = 'clone'"
 	cloneStatusQuery       = "SELECT STATE, ERROR_NO, ERROR_MESSAGE FROM performance_schema.clone_status ORDER BY ID DESC LIMIT 1"
+	cloneProgressQuery     = "SELECT STAGE, STATE, ESTIMATE, DATA FROM performance_schema.clone_progress ORDER BY ID"
 
 	// clonePrimaryPositionTimeout is how long we wait to read the GTID position
 	// after the clone is done.
@@ -56,6 +59,9 @@ var (
 	cloneFromPrimary        = false
 	cloneFromTablet         = ""
 	cloneRestartWaitTimeout = 5 * time.Minute
+
+	// cloneProgressInterval is how often the progress of a clone is reported.
+	cloneProgressInterval = 10 * time.Second
 )
 
 func init() {
@@ -101,6 +107,11 @@ func CloneFromDonor(ctx context.Context, topoServer *topo.Server, mysqld MysqlDa
 		return replication.Position{}, errors.New("no donor specified")
 	}
 
+	return CloneFromTablet(ctx, topoServer, mysqld, mycnf, donorAlias, nil)
+}
+
+// CloneFromTablet clones data from the given donor tablet using MySQL CLONE REMOTE and returns the GTID position of the cloned data. If logger is non-nil, the progress of the clone is reported to it while the data is copied. If mycnf is non-nil, CloneFromTablet may use it to restart mysqld locally when CLONE completes but MySQL cannot restart itself.
+func CloneFromTablet(ctx context.Context, topoServer *topo.Server, mysqld MysqlDaemon, mycnf *Mycnf, donorAlias *topodatapb.TabletAlias, logger logutil.Logger) (replication.Position, error) {
 	// Get donor tablet info from topology.
 	donorTablet, err := topoServer.GetTablet(ctx, donorAlias)
 	if err != nil {
@@ -120,6 +131,7 @@ func CloneFromDonor(ctx context.Context, topoServer *topo.Server, mysqld MysqlDa
 		DonorUser:     cloneConfig.User,
 		DonorPassword: cloneConfig.Password,
 		UseSSL:        cloneConfig.UseSSL,
+		Logger:        logger,
 	}
 
 	log.Info(fmt.Sprintf("Clone executor configured for donor %s:%d", executor.DonorHost, executor.DonorPort))
@@ -157,6 +169,8 @@ type CloneExecutor struct {
 	DonorPassword string
 	// UseSSL indicates whether to use SSL for the clone connection.
 	UseSSL bool
+	// Logger, if set, receives the progress of the clone while it runs.
+	Logger logutil.Logger
 }
 
 // validateRecipient checks that the recipient MySQL instance meets all prerequisites for cloning.
@@ -316,7 +330,9 @@ func (c *CloneExecutor) ExecuteClone(ctx context.Context, mysqld MysqlDaemon, my
 	// Execute the clone command. When clone completes, MySQL restarts automatically
 	// which will cause the connection to drop. We ignore this error and verify
 	// success by checking clone_status after MySQL comes back up.
+	stopProgress := c.reportProgress(ctx, mysqld)
 	cloneErr := mysqld.ExecuteSuperQuery(ctx, cloneCmd)
+	stopProgress()
 	if cloneErr != nil && !isCloneConnError(cloneErr) {
 		return vterrors.Wrapf(cloneErr, "clone command failed")
 	}
@@ -371,6 +387,78 @@ func (c *CloneExecutor) prepareCloneVerification(ctx context.Context, mysqld Mys
 	return ctx, cancel, nil
 }
 
+// reportProgress reports the progress of the clone to c.Logger every
+// cloneProgressInterval, until the returned function is called.
+func (c *CloneExecutor) reportProgress(ctx context.Context, mysqld MysqlDaemon) (stop func()) {
+	if c.Logger == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(cloneProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			// The progress can't be read while mysqld restarts at the end of the clone.
+			result, err := mysqld.FetchSuperQuery(ctx, cloneProgressQuery)
+			if err != nil {
+				continue
+			}
+			if progress, ok := cloneProgress(result); ok {
+				c.Logger.Infof("%s", progress)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// cloneProgress describes the progress of a clone from the rows of
+// performance_schema.clone_progress. It returns false if no stage of the
+// clone is in progress.
+func cloneProgress(result *sqltypes.Result) (string, bool) {
+	var (
+		stage           string
+		estimate, bytes int64
+	)
+	for _, row := range result.Rows {
+		if len(row) < 4 {
+			continue
+		}
+		if stage == "" && strings.EqualFold(row[1].ToString(), "In Progress") {
+			stage = row[0].ToString()
+		}
+		// Only the stages that copy data have an estimate.
+		if v, err := row[2].ToInt64(); err == nil {
+			estimate += v
+		}
+		if v, err := row[3].ToInt64(); err == nil {
+			bytes += v
+		}
+	}
+
+	if stage == "" {
+		return "", false
+	}
+	if estimate == 0 {
+		return fmt.Sprintf("Clone in progress: stage %s", stage), true
+	}
+	return fmt.Sprintf("Clone in progress: stage %s, copied %s of %s (%d%%)",
+		stage, humanize.IBytes(uint64(bytes)), humanize.IBytes(uint64(estimate)), bytes*100/estimate), true
+}
+
 // buildCloneCommand constructs the CLONE INSTANCE SQL command.
 func (c *CloneExecutor) buildCloneCommand() string {
 	var sb strings.Builder
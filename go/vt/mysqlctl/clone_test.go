@@ -335,6 +335,88 @@ func Test_waitForCloneComplete_ContextCanceled(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+func Test_cloneProgress(t *testing.T) {
+	fields := sqltypes.MakeTestFields("STAGE|STATE|ESTIMATE|DATA", "varchar|varchar|int64|int64")
+	tests := []struct {
+		name     string
+		rows     []string
+		expected string
+	}{
+		{
+			name: "copying data",
+			rows: []string{
+				"DROP DATA|Completed|0|0",
+				"FILE COPY|In Progress|1073741824|268435456",
+				"PAGE COPY|Not Started|0|0",
+			},
+			expected: "Clone in progress: stage FILE COPY, copied 256 MiB of 1.0 GiB (25%)",
+		},
+		{
+			name: "no estimate",
+			rows: []string{
+				"DROP DATA|In Progress|0|0",
+				"FILE COPY|Not Started|0|0",
+			},
+			expected: "Clone in progress: stage DROP DATA",
+		},
+		{
+			name: "not started",
+			rows: []string{
+				"DROP DATA|Not Started|0|0",
+			},
+		},
+		{
+			name: "completed",
+			rows: []string{
+				"FILE COPY|Completed|1024|1024",
+				"RECOVERY|Completed|0|0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, ok := cloneProgress(sqltypes.MakeTestResult(fields, tt.rows...))
+			assert.Equal(t, tt.expected != "", ok)
+			assert.Equal(t, tt.expected, progress)
+		})
+	}
+}
+
+func Test_reportProgress(t *testing.T) {
+	defer func(interval time.Duration) {
+		cloneProgressInterval = interval
+	}(cloneProgressInterval)
+	cloneProgressInterval = time.Millisecond
+
+	fmd := NewFakeMysqlDaemon(nil)
+	defer fmd.Close()
+	fmd.FetchSuperQueryCallback = func(query string) (*sqltypes.Result, error) {
+		assert.Equal(t, cloneProgressQuery, query)
+		return sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("STAGE|STATE|ESTIMATE|DATA", "varchar|varchar|int64|int64"),
+			"FILE COPY|In Progress|2048|1024",
+		), nil
+	}
+
+	logger := logutil.NewMemoryLogger()
+	executor := &CloneExecutor{Logger: logger}
+	stop := executor.reportProgress(t.Context(), fmd)
+	require.Eventually(t, func() bool {
+		return strings.Contains(logger.String(), "Clone in progress: stage FILE COPY, copied 1.0 KiB of 2.0 KiB (50%)")
+	}, 5*time.Second, time.Millisecond)
+	stop()
+
+	// Without a logger, the progress is not queried.
+	fmd.FetchSuperQueryCallback = func(query string) (*sqltypes.Result, error) {
+		assert.Fail(t, "unexpected query", query)
+		return nil, assert.AnError
+	}
+	stop = (&CloneExecutor{}).reportProgress(t.Context(), fmd)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+}
+
 func TestValidateRecipient(t *testing.T) {
 	tests := []struct {
 		name         string
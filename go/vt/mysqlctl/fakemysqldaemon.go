@@ -117,6 +117,16 @@ type FakeMysqlDaemon struct {
 	// PrimaryStatusError is used by PrimaryStatus.
 	PrimaryStatusError error
 
+	// BinaryLogs is returned by GetBinaryLogs.
+	BinaryLogs []string
+
+	// PreviousGTIDs is used by GetPreviousGTIDs, keyed by binary log.
+	PreviousGTIDs map[string]string
+
+	// GroupReplication is returned by GroupReplicationStatus. If it is nil,
+	// GroupReplicationStatus returns mysql.ErrNoGroupStatus.
+	GroupReplication *replicationdata.GroupReplicationStatus
+
 	// GlobalStatusVars is used by GetGlobalStatusVars.
 	GlobalStatusVars map[string]string
 
@@ -412,6 +422,16 @@ func (fmd *FakeMysqlDaemon) ReplicationConfiguration(ctx context.Context) (*repl
 	return nil, nil
 }
 
+// GroupReplicationStatus is part of the MysqlDaemon interface.
+func (fmd *FakeMysqlDaemon) GroupReplicationStatus(ctx context.Context) (*replicationdata.GroupReplicationStatus, error) {
+	fmd.mu.Lock()
+	defer fmd.mu.Unlock()
+	if fmd.GroupReplication == nil {
+		return nil, mysql.ErrNoGroupStatus
+	}
+	return fmd.GroupReplication.CloneVT(), nil
+}
+
 // GetGTIDPurged is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) GetGTIDPurged(ctx context.Context) (replication.Position, error) {
 	return replication.Position{}, nil
@@ -454,18 +474,25 @@ func (fmd *FakeMysqlDaemon) FlushBinaryLogs(ctx context.Context) (err error) {
 
 // GetBinaryLogs is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) GetBinaryLogs(ctx context.Context) (binaryLogs []string, err error) {
-	return []string{}, fmd.ExecuteSuperQueryList(ctx, []string{
+	return append([]string{}, fmd.BinaryLogs...), fmd.ExecuteSuperQueryList(ctx, []string{
 		"FAKE SHOW BINARY LOGS",
 	})
 }
 
 // GetPreviousGTIDs is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) GetPreviousGTIDs(ctx context.Context, binlog string) (previousGtids string, err error) {
-	return "", fmd.ExecuteSuperQueryList(ctx, []string{
+	return fmd.PreviousGTIDs[binlog], fmd.ExecuteSuperQueryList(ctx, []string{
 		fmt.Sprintf("FAKE SHOW BINLOG EVENTS IN '%s' LIMIT 2", binlog),
 	})
 }
 
+// PurgeBinaryLogs is part of the MysqlDaemon interface.
+func (fmd *FakeMysqlDaemon) PurgeBinaryLogs(ctx context.Context, to string) error {
+	return fmd.ExecuteSuperQueryList(ctx, []string{
+		fmt.Sprintf("FAKE PURGE BINARY LOGS TO '%s'", to),
+	})
+}
+
 // PrimaryPosition is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) PrimaryPosition(ctx context.Context) (replication.Position, error) {
 	if fmd.PrimaryPositionError != nil {
@@ -67,6 +67,7 @@ type MysqlDaemon interface {
 	ReplicationStatus(ctx context.Context) (replication.ReplicationStatus, error)
 	PrimaryStatus(ctx context.Context) (replication.PrimaryStatus, error)
 	ReplicationConfiguration(ctx context.Context) (*replicationdata.Configuration, error)
+	GroupReplicationStatus(ctx context.Context) (*replicationdata.GroupReplicationStatus, error)
 	GetGTIDPurged(ctx context.Context) (replication.Position, error)
 	SetSemiSyncEnabled(ctx context.Context, source, replica bool) error
 	SemiSyncEnabled(ctx context.Context) (source, replica bool)
@@ -82,6 +83,7 @@ type MysqlDaemon interface {
 	FlushBinaryLogs(ctx context.Context) (err error)
 	GetBinaryLogs(ctx context.Context) (binaryLogs []string, err error)
 	GetPreviousGTIDs(ctx context.Context, binlog string) (previousGtids string, err error)
+	PurgeBinaryLogs(ctx context.Context, to string) error
 
 	// reparenting related methods
 	ResetReplication(ctx context.Context) error
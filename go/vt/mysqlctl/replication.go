@@ -524,6 +524,19 @@ func (mysqld *Mysqld) ReplicationConfiguration(ctx context.Context) (*replicatio
 	return conn.Conn.ReplicationConfiguration()
 }
 
+// GroupReplicationStatus returns the state of the server as a group
+// replication member. It returns mysql.ErrNoGroupStatus if the server is not
+// a member of a group.
+func (mysqld *Mysqld) GroupReplicationStatus(ctx context.Context) (*replicationdata.GroupReplicationStatus, error) {
+	conn, err := getPoolReconnect(ctx, mysqld.dbaPool)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+
+	return conn.Conn.GroupReplicationStatus()
+}
+
 // GetGTIDPurged returns the gtid purged statuses
 func (mysqld *Mysqld) GetGTIDPurged(ctx context.Context) (replication.Position, error) {
 	conn, err := getPoolReconnect(ctx, mysqld.dbaPool)
@@ -704,6 +717,16 @@ func (mysqld *Mysqld) GetBinaryLogs(ctx context.Context) (binaryLogs []string, e
 	return binaryLogs, err
 }
 
+// PurgeBinaryLogs is part of the MysqlDaemon interface.
+func (mysqld *Mysqld) PurgeBinaryLogs(ctx context.Context, to string) error {
+	query, err := sqlparser.ParseAndBind("PURGE BINARY LOGS TO %a", sqltypes.StringBindVariable(to))
+	if err != nil {
+		return err
+	}
+	_, err = mysqld.FetchSuperQuery(ctx, query)
+	return err
+}
+
 // GetPreviousGTIDs is part of the MysqlDaemon interface.
 func (mysqld *Mysqld) GetPreviousGTIDs(ctx context.Context, binlog string) (previousGtids string, err error) {
 	query := fmt.Sprintf("SHOW BINLOG EVENTS IN '%s' LIMIT 2", binlog)
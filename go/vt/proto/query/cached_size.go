@@ -78,10 +78,12 @@ func (cached *QueryWarning) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(64)
+		size += int64(80)
 	}
 	// field Message string
 	size += hack.RuntimeAllocSize(int64(len(cached.Message)))
+	// field Level string
+	size += hack.RuntimeAllocSize(int64(len(cached.Level)))
 	// field unknownFields google.golang.org/protobuf/runtime/protoimpl.UnknownFields
 	{
 		size += hack.RuntimeAllocSize(int64(cap(cached.unknownFields)))
@@ -19,6 +19,7 @@ package schemadiff
 import (
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -79,6 +80,10 @@ func (si *declarativeSchemaInformation) GetAggregateUDFs() []string {
 	return nil
 }
 
+func (si *declarativeSchemaInformation) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	return nil
+}
+
 func (si *declarativeSchemaInformation) GetForeignKeyChecksState() *bool {
 	return nil
 }
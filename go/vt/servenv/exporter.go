@@ -417,6 +417,22 @@ func (e *Exporter) NewCountersWithMultiLabels(name, help string, labels []string
 	return lvar
 }
 
+// NewBoundedCountersWithMultiLabels creates a name-spaced equivalent for stats.NewBoundedCountersWithMultiLabels.
+// The cap applies to the label combinations of each exporter.
+func (e *Exporter) NewBoundedCountersWithMultiLabels(name, help string, labels []string, maxCardinality int) *stats.BoundedCountersWithMultiLabels {
+	if e.name == "" || name == "" {
+		v := stats.NewBoundedCountersWithMultiLabels(name, help, labels, maxCardinality)
+		addUnnamedExport(name, v)
+		return v
+	}
+
+	lvar := stats.NewBoundedCountersWithMultiLabels("", help, labels, maxCardinality)
+	if exists := e.createCountsTracker(name, help, labels, lvar, reuseOnDup, typeCounter); exists != nil {
+		return exists.(*stats.BoundedCountersWithMultiLabels)
+	}
+	return lvar
+}
+
 // NewGaugesWithMultiLabels creates a name-spaced equivalent for stats.NewGaugesWithMultiLabels.
 func (e *Exporter) NewGaugesWithMultiLabels(name, help string, labels []string) *stats.GaugesWithMultiLabels {
 	if e.name == "" || name == "" {
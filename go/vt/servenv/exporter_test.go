@@ -437,6 +437,43 @@ func TestCountersWithMultiLabels(t *testing.T) {
 	assert.Contains(t, expvar.Get("lcwml").String(), `"i2.a": 6`)
 }
 
+func TestBoundedCountersWithMultiLabels(t *testing.T) {
+	ebd := NewExporter("", "")
+	g := ebd.NewBoundedCountersWithMultiLabels("gbcwml", "", []string{"l"}, 1)
+	g.Add([]string{"a"}, 1)
+	g.Add([]string{"b"}, 1)
+	assert.Equal(t, map[string]int64{"a": 1, "other": 1}, g.Counts())
+
+	ebd = NewExporter("i1", "label")
+
+	// Ensure anonymous vars don't cause panics.
+	ebd.NewBoundedCountersWithMultiLabels("", "", []string{"l"}, 1)
+	ebd.NewBoundedCountersWithMultiLabels("", "", []string{"l"}, 1)
+
+	// Ensure global var gets reused.
+	g = ebd.NewBoundedCountersWithMultiLabels("gbcwml", "", []string{"l"}, 1)
+	g.Add([]string{"a"}, 1)
+	assert.Equal(t, map[string]int64{"a": 2, "other": 1}, g.Counts())
+
+	g = ebd.NewBoundedCountersWithMultiLabels("lbcwml", "", []string{"l"}, 1)
+	g.Add([]string{"a"}, 4)
+	g.Add([]string{"b"}, 1)
+	assert.Contains(t, expvar.Get("lbcwml").String(), `"i1.a": 4`)
+	assert.Contains(t, expvar.Get("lbcwml").String(), `"i1.other": 1`)
+
+	// Ensure var gets reused, with its set of tracked label combinations.
+	g = ebd.NewBoundedCountersWithMultiLabels("lbcwml", "", []string{"l"}, 1)
+	g.Add([]string{"c"}, 5)
+	assert.Contains(t, expvar.Get("lbcwml").String(), `"i1.other": 6`)
+
+	// Every exporter has its own cap.
+	ebd = NewExporter("i2", "label")
+	g = ebd.NewBoundedCountersWithMultiLabels("lbcwml", "", []string{"l"}, 1)
+	g.Add([]string{"b"}, 6)
+	assert.Contains(t, expvar.Get("lbcwml").String(), `"i1.a": 4`)
+	assert.Contains(t, expvar.Get("lbcwml").String(), `"i2.b": 6`)
+}
+
 func TestGaugesWithMultiLabels(t *testing.T) {
 	ebd := NewExporter("", "")
 	g := ebd.NewGaugesWithMultiLabels("ggwml", "", []string{"l"})
@@ -134,6 +134,11 @@ func GetInitStartTime() time.Time {
 	return initStartTime
 }
 
+// Uptime returns how long the process has been running.
+func Uptime() time.Duration {
+	return time.Since(serverStart)
+}
+
 func populateListeningURL(port int32) {
 	host, err := netutil.FullyQualifiedHostname()
 	if err != nil {
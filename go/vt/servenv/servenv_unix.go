@@ -39,7 +39,7 @@ func Init() {
 
 	// Uptime metric
 	_ = stats.NewGaugeFunc("Uptime", "Uptime in nanoseconds", func() int64 {
-		return int64(time.Since(serverStart).Nanoseconds())
+		return int64(Uptime().Nanoseconds())
 	})
 
 	// Ignore SIGPIPE if specified
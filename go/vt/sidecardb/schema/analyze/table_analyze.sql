@@ -0,0 +1,25 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS table_analyze
+(
+    table_schema  VARCHAR(64)     NOT NULL,
+    table_name    VARCHAR(64)     NOT NULL,
+    table_rows    BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    last_analyzed TIMESTAMP(6)    NULL     DEFAULT NULL,
+    analyze_count BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    PRIMARY KEY (`table_schema`, `table_name`)
+) ENGINE = InnoDB CHARSET = utf8mb4
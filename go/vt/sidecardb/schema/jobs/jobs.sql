@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS jobs
+(
+    job_name      VARCHAR(128)     NOT NULL,
+    job_type      VARCHAR(64)      NOT NULL,
+    job_state     VARCHAR(16)      NOT NULL,
+    job_args      MEDIUMBLOB       NULL     DEFAULT NULL,
+    progress      DOUBLE           NOT NULL DEFAULT 0,
+    checkpoint    MEDIUMBLOB       NULL     DEFAULT NULL,
+    message       TEXT             NULL     DEFAULT NULL,
+    lease_owner   VARCHAR(128)     NOT NULL DEFAULT '',
+    lease_expires TIMESTAMP(6)     NULL     DEFAULT NULL,
+    attempts      INT UNSIGNED     NOT NULL DEFAULT 0,
+    created_at    TIMESTAMP(6)     NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    started_at    TIMESTAMP(6)     NULL     DEFAULT NULL,
+    updated_at    TIMESTAMP(6)     NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    completed_at  TIMESTAMP(6)     NULL     DEFAULT NULL,
+    PRIMARY KEY (`job_name`),
+    KEY `job_state_idx` (`job_state`, `created_at`)
+) ENGINE = InnoDB CHARSET = utf8mb4
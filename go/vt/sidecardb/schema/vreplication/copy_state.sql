@@ -20,6 +20,7 @@ CREATE TABLE IF NOT EXISTS copy_state
     `vrepl_id`   int            NOT NULL,
     `table_name` varbinary(128) NOT NULL,
     `lastpk`     mediumblob     DEFAULT NULL,
+    `copy_ranges` mediumblob    DEFAULT NULL,
     PRIMARY KEY (`id`),
     KEY `vrepl_id` (`vrepl_id`,`table_name`)
 ) ENGINE = InnoDB CHARSET = utf8mb4
@@ -0,0 +1,30 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS vreplication_conflicts
+(
+    `id`         bigint         NOT NULL AUTO_INCREMENT,
+    `vrepl_id`   int            NOT NULL,
+    `table_name` varbinary(256) NOT NULL,
+    `pk`         text           NOT NULL,
+    `event`      varbinary(32)  NOT NULL,
+    `policy`     varbinary(64)  NOT NULL,
+    `resolution` varbinary(32)  NOT NULL,
+    `created_at` timestamp      NULL     DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `vrepl_id_idx` (`vrepl_id`),
+    KEY `created_at_idx` (`created_at`)
+) ENGINE = InnoDB CHARSET = utf8mb4
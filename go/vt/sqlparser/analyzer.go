@@ -150,6 +150,11 @@ func CachePlan(stmt Statement) bool {
 	return !checkDirective(stmt, DirectiveSkipQueryPlanCache)
 }
 
+// CanPrepare takes Statement and returns true if the query may be executed as a prepared statement
+func CanPrepare(stmt Statement) bool {
+	return !checkDirective(stmt, DirectiveSkipPreparedStatement)
+}
+
 // MustRewriteAST takes Statement and returns true if RewriteAST must run on it for correct execution irrespective of user flags.
 func MustRewriteAST(stmt Statement, hasSelectLimit bool) bool {
 	switch node := stmt.(type) {
@@ -247,6 +252,8 @@ func Preview(sql string) StatementType {
 		return StmtSRollback
 	case "kill":
 		return StmtKill
+	case "call":
+		return StmtCallProc
 	}
 	return StmtUnknown
 }
@@ -73,6 +73,7 @@ func TestPreview(t *testing.T) {
 		{"revoke", StmtPriv},
 		{"truncate", StmtDDL},
 		{"flush", StmtFlush},
+		{"call proc()", StmtCallProc},
 		{"unknown", StmtUnknown},
 
 		{"/* leading comment */ select ...", StmtSelect},
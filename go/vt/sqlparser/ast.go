@@ -378,6 +378,10 @@ type (
 		Rows       InsertRows
 		RowAlias   *RowAlias
 		OnDup      OnDup
+		// Returning is a Vitess extension that returns the listed expressions
+		// of the inserted rows. MySQL does not support it, so it is emulated
+		// by vttablet.
+		Returning *SelectExprs
 	}
 
 	// Ignore represents whether ignore was specified or not
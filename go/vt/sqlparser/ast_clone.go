@@ -1854,6 +1854,7 @@ func CloneRefOfInsert(n *Insert) *Insert {
 	out.Rows = CloneInsertRows(n.Rows)
 	out.RowAlias = CloneRefOfRowAlias(n.RowAlias)
 	out.OnDup = CloneOnDup(n.OnDup)
+	out.Returning = CloneRefOfSelectExprs(n.Returning)
 	return &out
 }
 
@@ -3407,7 +3407,8 @@ func (c *cow) copyOnRewriteRefOfInsert(n *Insert, parent SQLNode) (out SQLNode,
 		_Rows, changedRows := c.copyOnRewriteInsertRows(n.Rows, n)
 		_RowAlias, changedRowAlias := c.copyOnRewriteRefOfRowAlias(n.RowAlias, n)
 		_OnDup, changedOnDup := c.copyOnRewriteOnDup(n.OnDup, n)
-		if changedComments || changedTable || changedPartitions || changedColumns || changedRows || changedRowAlias || changedOnDup {
+		_Returning, changedReturning := c.copyOnRewriteRefOfSelectExprs(n.Returning, n)
+		if changedComments || changedTable || changedPartitions || changedColumns || changedRows || changedRowAlias || changedOnDup || changedReturning {
 			res := *n
 			res.Comments, _ = _Comments.(*ParsedComments)
 			res.Table, _ = _Table.(*AliasedTableExpr)
@@ -3416,6 +3417,7 @@ func (c *cow) copyOnRewriteRefOfInsert(n *Insert, parent SQLNode) (out SQLNode,
 			res.Rows, _ = _Rows.(InsertRows)
 			res.RowAlias, _ = _RowAlias.(*RowAlias)
 			res.OnDup, _ = _OnDup.(OnDup)
+			res.Returning, _ = _Returning.(*SelectExprs)
 			out = &res
 			if c.cloned != nil {
 				c.cloned(n, out)
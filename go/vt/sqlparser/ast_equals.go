@@ -3270,7 +3270,8 @@ func (cmp *Comparator) RefOfInsert(a, b *Insert) bool {
 		cmp.Columns(a.Columns, b.Columns) &&
 		cmp.InsertRows(a.Rows, b.Rows) &&
 		cmp.RefOfRowAlias(a.RowAlias, b.RowAlias) &&
-		cmp.OnDup(a.OnDup, b.OnDup)
+		cmp.OnDup(a.OnDup, b.OnDup) &&
+		cmp.RefOfSelectExprs(a.Returning, b.Returning)
 }
 
 // RefOfInsertExpr does deep equals between the two objects.
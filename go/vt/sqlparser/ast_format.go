@@ -110,7 +110,7 @@ func (node *Union) Format(buf *TrackedBuffer) {
 	}
 	buf.WriteByte(' ')
 
-	if requiresParen(node.Right) {
+	if requiresParenOnRight(node.Right) {
 		buf.astPrintf(node, "(%v)", node.Right)
 	} else {
 		buf.astPrintf(node, "%v", node.Right)
@@ -170,6 +170,9 @@ func (node *Insert) Format(buf *TrackedBuffer) {
 			node.Comments, node.Ignore.ToString(),
 			node.Table.Expr, node.Partitions, node.Columns, node.Rows, node.RowAlias, node.OnDup)
 	}
+	if node.Returning != nil {
+		buf.astPrintf(node, " returning %v", node.Returning)
+	}
 }
 
 // Format formats the node.
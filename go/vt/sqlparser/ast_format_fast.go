@@ -121,7 +121,7 @@ func (node *Union) FormatFast(buf *TrackedBuffer) {
 	}
 	buf.WriteByte(' ')
 
-	if requiresParen(node.Right) {
+	if requiresParenOnRight(node.Right) {
 		buf.WriteByte('(')
 		node.Right.FormatFast(buf)
 		buf.WriteByte(')')
@@ -242,6 +242,10 @@ func (node *Insert) FormatFast(buf *TrackedBuffer) {
 		node.OnDup.FormatFast(buf)
 
 	}
+	if node.Returning != nil {
+		buf.WriteString(" returning ")
+		node.Returning.FormatFast(buf)
+	}
 }
 
 // FormatFast formats the node.
@@ -1434,6 +1434,15 @@ func requiresParen(stmt TableStatement) bool {
 	return false
 }
 
+// requiresParenOnRight is requiresParen for the right-hand side of a UNION. UNIONs are
+// left-associative, so a UNION on the right-hand side always needs parentheses.
+func requiresParenOnRight(stmt TableStatement) bool {
+	if _, isUnion := stmt.(*Union); isUnion {
+		return true
+	}
+	return requiresParen(stmt)
+}
+
 // ToString returns the string associated with the DDLAction Enum
 func (action DDLAction) ToString() string {
 	switch action {
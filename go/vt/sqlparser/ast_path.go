@@ -229,6 +229,7 @@ const (
 	RefOfInsertRows
 	RefOfInsertRowAlias
 	RefOfInsertOnDup
+	RefOfInsertReturning
 	RefOfInsertExprStr
 	RefOfInsertExprPos
 	RefOfInsertExprLen
@@ -1025,6 +1026,8 @@ func (s ASTStep) DebugString() string {
 		return "(*Insert).RowAlias"
 	case RefOfInsertOnDup:
 		return "(*Insert).OnDup"
+	case RefOfInsertReturning:
+		return "(*Insert).Returning"
 	case RefOfInsertExprStr:
 		return "(*InsertExpr).Str"
 	case RefOfInsertExprPos:
@@ -2242,6 +2245,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*Insert).RowAlias
 		case RefOfInsertOnDup:
 			node = node.(*Insert).OnDup
+		case RefOfInsertReturning:
+			node = node.(*Insert).Returning
 		case RefOfInsertExprStr:
 			node = node.(*InsertExpr).Str
 		case RefOfInsertExprPos:
@@ -6177,6 +6177,15 @@ func (a *application) rewriteRefOfInsert(parent SQLNode, node *Insert, replacer
 	}) {
 		return false
 	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfInsertReturning))
+	}
+	if !a.rewriteRefOfSelectExprs(node, node.Returning, func(newNode, parent SQLNode) {
+		parent.(*Insert).Returning = newNode.(*SelectExprs)
+	}) {
+		return false
+	}
 	if a.collectPaths {
 		a.cur.current.Pop()
 	}
@@ -2416,6 +2416,9 @@ func VisitRefOfInsert(in *Insert, f Visit) error {
 	if err := VisitOnDup(in.OnDup, f); err != nil {
 		return err
 	}
+	if err := VisitRefOfSelectExprs(in.Returning, f); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -2225,7 +2225,7 @@ func (cached *Insert) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(128)
+		size += int64(144)
 	}
 	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
 	size += cached.Comments.CachedSize(true)
@@ -2258,6 +2258,8 @@ func (cached *Insert) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field Returning *vitess.io/vitess/go/vt/sqlparser.SelectExprs
+	size += cached.Returning.CachedSize(true)
 	return size
 }
 
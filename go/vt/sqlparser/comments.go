@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -35,6 +36,9 @@ const (
 	DirectiveMultiShardAutocommit = "MULTI_SHARD_AUTOCOMMIT"
 	// DirectiveSkipQueryPlanCache skips query plan cache when set.
 	DirectiveSkipQueryPlanCache = "SKIP_QUERY_PLAN_CACHE"
+	// DirectiveSkipPreparedStatement makes vttablet execute the query as text, even if
+	// queries are executed as prepared statements on its query pool connections.
+	DirectiveSkipPreparedStatement = "SKIP_PREPARED_STATEMENT"
 	// DirectiveQueryTimeout sets a query timeout in vtgate. Only supported for SELECTS.
 	DirectiveQueryTimeout = "QUERY_TIMEOUT_MS"
 	// DirectiveScatterErrorsAsWarnings enables partial success scatter select queries
@@ -62,6 +66,19 @@ const (
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
 	// where 0 is the highest priority, and MaxPriorityValue is the lowest one.
 	DirectivePriority = "PRIORITY"
+	// DirectiveResultFormat specifies the format in which the result of a SELECT is returned. The only
+	// supported value is ResultFormatJSON.
+	DirectiveResultFormat = "RESULT_FORMAT"
+	// DirectiveCacheTTL makes vtgate cache the result of a SELECT for the given duration, e.g. 5s,
+	// and return it to the following executions of the same query with the same bind variables.
+	DirectiveCacheTTL = "CACHE_TTL"
+	// DirectiveTarget sends an allowlisted SET GLOBAL to the given target instead of the primaries of the
+	// selected keyspace. It uses the syntax of USE, e.g. ks:-80@replica or ks:-80@replica|zone1-0000000100.
+	DirectiveTarget = "TARGET"
+
+	// ResultFormatJSON is the value of the result format directive that returns each row as a single
+	// JSON object column, keyed by the names of the columns.
+	ResultFormatJSON = "json"
 
 	// MaxPriorityValue specifies the maximum value allowed for the priority query directive. Valid priority values are
 	// between zero and MaxPriorityValue.
@@ -73,6 +90,10 @@ const (
 
 var ErrInvalidPriority = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid priority value specified in query")
 
+var ErrInvalidResultFormat = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid result format specified in query")
+
+var ErrInvalidCacheTTL = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid cache TTL specified in query")
+
 func isNonSpace(r rune) bool {
 	return !unicode.IsSpace(r)
 }
@@ -581,6 +602,26 @@ func AllowCrossKeyspaceReadsDirective(stmt Statement) bool {
 	return checkDirective(stmt, DirectiveAllowCrossKeyspaceReads)
 }
 
+// JSONResultFormatDirective returns true if the result format directive asks for the rows of a SELECT to
+// be returned as JSON objects. It is ignored for other statements, and returns an error for an unknown format.
+func JSONResultFormatDirective(stmt Statement) (bool, error) {
+	if _, isSelect := stmt.(SelectStatement); !isSelect {
+		return false, nil
+	}
+	cmt, ok := stmt.(Commented)
+	if !ok {
+		return false, nil
+	}
+	format, ok := cmt.GetParsedComments().Directives().GetString(DirectiveResultFormat, "")
+	if !ok || format == "" {
+		return false, nil
+	}
+	if !strings.EqualFold(format, ResultFormatJSON) {
+		return false, ErrInvalidResultFormat
+	}
+	return true, nil
+}
+
 func checkDirective(stmt Statement, key string) bool {
 	cmt, ok := stmt.(Commented)
 	if ok {
@@ -596,6 +637,7 @@ type QueryHints struct {
 	ForeignKeyChecks    *bool
 	Priority            string
 	Timeout             *int
+	CacheTTL            time.Duration
 }
 
 func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
@@ -617,6 +659,10 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 	qh.Workload = getWorkload(directives)
 	qh.ForeignKeyChecks = getForeignKeyChecksState(comment)
 	qh.Timeout = getQueryTimeout(directives)
+	qh.CacheTTL, err = getCacheTTL(stmt, directives)
+	if err != nil {
+		return qh, err
+	}
 
 	return qh, nil
 }
@@ -689,3 +735,21 @@ func getQueryTimeout(directives *CommentDirectives) *int {
 	}
 	return &timeout
 }
+
+// getCacheTTL gets the duration for which the result of a SELECT is cached, using DirectiveCacheTTL.
+// It is ignored for other statements.
+func getCacheTTL(stmt Statement, directives *CommentDirectives) (time.Duration, error) {
+	if _, isSelect := stmt.(SelectStatement); !isSelect {
+		return 0, nil
+	}
+	ttlString, ok := directives.GetString(DirectiveCacheTTL, "")
+	if !ok || ttlString == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(ttlString)
+	if err != nil || ttl < 0 {
+		return 0, ErrInvalidCacheTTL
+	}
+	return ttl, nil
+}
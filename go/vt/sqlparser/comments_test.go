@@ -18,6 +18,7 @@ package sqlparser
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -516,6 +517,49 @@ func TestGetPriorityFromStatement(t *testing.T) {
 	}
 }
 
+func TestJSONResultFormatDirective(t *testing.T) {
+	testCases := []struct {
+		query         string
+		expected      bool
+		expectedError error
+	}{
+		{
+			query: "select * from a_table",
+		},
+		{
+			query:    "select /*vt+ RESULT_FORMAT=json */ * from a_table",
+			expected: true,
+		},
+		{
+			query:    "select /*vt+ RESULT_FORMAT=JSON */ * from a_table union select * from b_table",
+			expected: true,
+		},
+		{
+			query:         "select /*vt+ RESULT_FORMAT=xml */ * from a_table",
+			expectedError: ErrInvalidResultFormat,
+		},
+		{
+			// The directive only applies to SELECT statements.
+			query: "update /*vt+ RESULT_FORMAT=json */ a_table set a = 1",
+		},
+	}
+
+	parser := NewTestParser()
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			stmt, err := parser.Parse(testCase.query)
+			require.NoError(t, err)
+			jsonRows, err := JSONResultFormatDirective(stmt)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, jsonRows)
+		})
+	}
+}
+
 // TestGetMySQLSetVarValue tests the functionality of GetMySQLSetVarValue
 func TestGetMySQLSetVarValue(t *testing.T) {
 	tests := []struct {
@@ -708,3 +752,44 @@ func TestQueryTimeout(t *testing.T) {
 		})
 	}
 }
+
+// TestCacheTTL tests the extraction of CACHE_TTL from the comments.
+func TestCacheTTL(t *testing.T) {
+	testCases := []struct {
+		query  string
+		expTTL time.Duration
+		expErr error
+	}{{
+		query: "select * from a_table",
+	}, {
+		query:  "select /*vt+ CACHE_TTL=5s */ * from a_table",
+		expTTL: 5 * time.Second,
+	}, {
+		query:  "select /*vt+ CACHE_TTL=1m30s */ * from a_table union select * from b_table",
+		expTTL: 90 * time.Second,
+	}, {
+		query:  "select /*vt+ CACHE_TTL=5 */ * from a_table",
+		expErr: ErrInvalidCacheTTL,
+	}, {
+		query:  "select /*vt+ CACHE_TTL=-1s */ * from a_table",
+		expErr: ErrInvalidCacheTTL,
+	}, {
+		// The directive only applies to SELECT statements.
+		query: "update /*vt+ CACHE_TTL=5s */ a_table set a = 1",
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			qh, err := BuildQueryHints(stmt)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expTTL, qh.CacheTTL)
+		})
+	}
+}
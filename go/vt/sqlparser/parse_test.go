@@ -646,6 +646,11 @@ var validSQL = []struct {
 }, {
 	input:  "select /* union parenthesized select 2 */ 1 from t union (select 1 from t)",
 	output: "select /* union parenthesized select 2 */ 1 from t union select 1 from t",
+}, {
+	input: "select /* union nested on the right */ 1 from t union all (select 1 from t union select 1 from t)",
+}, {
+	input:  "(select /* union nested on the left */ 1 from t union all select 1 from t) union select 1 from t",
+	output: "select /* union nested on the left */ 1 from t union all select 1 from t union select 1 from t",
 }, {
 	input:  "select /* union order by */ 1 from t union select 1 from t order by a",
 	output: "select /* union order by */ 1 from t union select 1 from t order by a asc",
@@ -1340,6 +1345,15 @@ var validSQL = []struct {
 	output: "insert into `user`(`format`, `tree`, `vitess`) values ('Chuck', 42, 'Barry')",
 }, {
 	input: "insert into customer() values ()",
+}, {
+	input: "insert /* returning */ into a(b, c) values (1, 2), (3, 4) returning id, b, c + 1 as d",
+}, {
+	input:  "insert into a set b = 1 returning *",
+	output: "insert into a(b) values (1) returning *",
+}, {
+	input: "insert into a values (1, 2) as a_values on duplicate key update b = a_values.b returning a.id",
+}, {
+	input: "insert into `returning`(`returning`) values (1) returning `returning`",
 }, {
 	input: "update /* simple */ a set b = 3",
 }, {
@@ -6588,6 +6602,10 @@ var invalidSQL = []struct {
 	// This construct is considered invalid due to a grammar conflict.
 	input:  "insert into a select * from b join c on duplicate key update d=e",
 	output: "syntax error at position 54 near 'key'",
+}, {
+	// RETURNING is only accepted for inserts of values.
+	input:  "insert into a select * from b returning id",
+	output: "syntax error at position 43 near 'id'",
 }, {
 	input:  "select * from a left join b",
 	output: "syntax error at position 28",
@@ -80,6 +80,40 @@ func (pq *ParsedQuery) Append(buf *strings.Builder, bindVariables map[string]*qu
 	return nil
 }
 
+// GeneratePreparedQuery generates a query with a '?' placeholder at each
+// bind location, and the values to bind to the placeholders in order, so
+// that the query can be executed as a prepared statement. It returns false
+// if a bind variable is missing or is not a single value.
+func (pq *ParsedQuery) GeneratePreparedQuery(bindVariables map[string]*querypb.BindVariable) (string, []sqltypes.Value, bool) {
+	if len(pq.bindLocations) == 0 {
+		return pq.Query, nil, true
+	}
+	var buf strings.Builder
+	buf.Grow(len(pq.Query))
+	args := make([]sqltypes.Value, 0, len(pq.bindLocations))
+	current := 0
+	for _, loc := range pq.bindLocations {
+		supplied, isList, err := FetchBindVar(pq.Query[loc.Offset:loc.Offset+loc.Length], bindVariables)
+		if err != nil || isList {
+			return "", nil, false
+		}
+		switch supplied.Type {
+		case querypb.Type_TUPLE, querypb.Type_ROW_TUPLE, querypb.Type_RAW:
+			return "", nil, false
+		}
+		v, err := sqltypes.BindVariableToValue(supplied)
+		if err != nil {
+			return "", nil, false
+		}
+		buf.WriteString(pq.Query[current:loc.Offset])
+		buf.WriteByte('?')
+		args = append(args, v)
+		current = loc.Offset + loc.Length
+	}
+	buf.WriteString(pq.Query[current:])
+	return buf.String(), args, true
+}
+
 func (pq *ParsedQuery) BindLocations() []BindLocation {
 	return pq.bindLocations
 }
@@ -164,6 +164,68 @@ func TestGenerateQuery(t *testing.T) {
 	}
 }
 
+func TestGeneratePreparedQuery(t *testing.T) {
+	tcases := []struct {
+		desc     string
+		query    string
+		bindVars map[string]*querypb.BindVariable
+		output   string
+		args     []sqltypes.Value
+		ok       bool
+	}{
+		{
+			desc:   "no substitutions",
+			query:  "select * from a where id = 2",
+			output: "select * from a where id = 2",
+			ok:     true,
+		}, {
+			desc:  "scalar bind vars",
+			query: "select * from a where id1 = :id1 and id2 = :id2 and id3 = :id1 limit :maxLimit",
+			bindVars: map[string]*querypb.BindVariable{
+				"id1":      sqltypes.Int64BindVariable(1),
+				"id2":      sqltypes.StringBindVariable("a"),
+				"maxLimit": sqltypes.Int64BindVariable(10001),
+			},
+			output: "select * from a where id1 = ? and id2 = ? and id3 = ? limit ?",
+			args:   []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NewInt64(1), sqltypes.NewInt64(10001)},
+			ok:     true,
+		}, {
+			desc:  "missing bind var",
+			query: "select * from a where id1 = :id1 and id2 = :id2",
+			bindVars: map[string]*querypb.BindVariable{
+				"id1": sqltypes.Int64BindVariable(1),
+			},
+		}, {
+			desc:  "list bind var",
+			query: "select * from a where id in ::vals",
+			bindVars: map[string]*querypb.BindVariable{
+				"vals": sqltypes.TestBindVariable([]any{1, "aa"}),
+			},
+		}, {
+			desc:  "raw bind var",
+			query: "select * from a where id = :v",
+			bindVars: map[string]*querypb.BindVariable{
+				"v": {Type: querypb.Type_RAW, Value: []byte("1 or 1 = 1")},
+			},
+		},
+	}
+
+	parser := NewTestParser()
+	for _, tcase := range tcases {
+		t.Run(tcase.desc, func(t *testing.T) {
+			tree, err := parser.Parse(tcase.query)
+			require.NoError(t, err)
+			pq := NewParsedQuery(tree)
+			query, args, ok := pq.GeneratePreparedQuery(tcase.bindVars)
+			require.Equal(t, tcase.ok, ok)
+			if ok {
+				assert.Equal(t, tcase.output, query)
+				assert.Equal(t, tcase.args, args)
+			}
+		})
+	}
+}
+
 func TestParseAndBind(t *testing.T) {
 	testcases := []struct {
 		in    string
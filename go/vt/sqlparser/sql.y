@@ -541,7 +541,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <expr> binlog_from_opt
 %type <matchExprOption> match_option
 %type <boolean> distinct_opt union_op replace local_opt
-%type <selectExprs> select_expression_list
+%type <selectExprs> select_expression_list returning_opt
 %type <selectExpr> select_expression
 %type <strs> select_options select_options_opt flush_option_list
 %type <str> select_option algorithm_view_opt algorithm_view security_view security_view_opt
@@ -565,7 +565,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <expr> where_expression_opt
 %type <boolVal> boolean_value
 %type <comparisonExprOperator> compare any_all_compare
-%type <ins> insert_data
+%type <ins> insert_data insert_select
 %type <expr> num_val
 %type <expr> function_call_keyword function_call_nonkeyword function_call_generic function_call_conflict
 %type <isExprOperator> is_suffix
@@ -1290,7 +1290,7 @@ query_primary:
   }
 
 insert_statement:
-  insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause insert_data on_dup_opt
+  insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause insert_data on_dup_opt returning_opt
   {
     // insert_data returns a *Insert pre-filled with Columns & Values
     ins := $6
@@ -1300,9 +1300,22 @@ insert_statement:
     ins.Table = getAliasedTableExprFromTableName($4)
     ins.Partitions = $5
     ins.OnDup = OnDup($7)
+    ins.Returning = $8
     $$ = ins
   }
-| insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause SET update_list on_dup_opt
+| insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause insert_select on_dup_opt
+  {
+    // insert_select returns a *Insert pre-filled with Columns & Rows
+    ins := $6
+    ins.Action = $1
+    ins.Comments = Comments($2).Parsed()
+    ins.Ignore = $3
+    ins.Table = getAliasedTableExprFromTableName($4)
+    ins.Partitions = $5
+    ins.OnDup = OnDup($7)
+    $$ = ins
+  }
+| insert_or_replace comment_opt ignore_opt into_table_name opt_partition_clause SET update_list on_dup_opt returning_opt
   {
     cols := make(Columns, 0, len($7))
     vals := make(ValTuple, 0, len($8))
@@ -1310,7 +1323,7 @@ insert_statement:
       cols = append(cols, updateList.Name.Name)
       vals = append(vals, updateList.Expr)
     }
-    $$ = &Insert{Action: $1, Comments: Comments($2).Parsed(), Ignore: $3, Table: getAliasedTableExprFromTableName($4), Partitions: $5, Columns: cols, Rows: Values{vals}, OnDup: OnDup($8)}
+    $$ = &Insert{Action: $1, Comments: Comments($2).Parsed(), Ignore: $3, Table: getAliasedTableExprFromTableName($4), Partitions: $5, Columns: cols, Rows: Values{vals}, OnDup: OnDup($8), Returning: $9}
   }
 
 insert_or_replace:
@@ -8627,22 +8640,20 @@ optionally_opt:
     $$ = " optionally"
   }
 
-// insert_data expands all combinations into a single rule.
-// This avoids a shift/reduce conflict while encountering the
-// following two possible constructs:
+// insert_data and insert_select expand all combinations into
+// rules of the same statement. This avoids a shift/reduce conflict
+// while encountering the following two possible constructs:
 // insert into t1(a, b) (select * from t2)
 // insert into t1(select * from t2)
 // Because the rules are together, the parser can keep shifting
 // the tokens until it disambiguates a as sql_id and select as keyword.
+// They are split so that RETURNING, a non-reserved keyword, does not
+// conflict with the optional aliases of a select.
 insert_data:
   value_or_values val_tuple_list row_alias_opt
   {
     $$ = &Insert{Rows: $2, RowAlias: $3}
   }
-| select_statement
-  {
-    $$ = &Insert{Rows: $1}
-  }
 | openb ins_column_list closeb value_or_values val_tuple_list row_alias_opt
   {
     $$ = &Insert{Columns: $2, Rows: $5, RowAlias: $6}
@@ -8651,6 +8662,12 @@ insert_data:
   {
     $$ = &Insert{Columns: []IdentifierCI{}, Rows: $4, RowAlias: $5}
   }
+
+insert_select:
+  select_statement
+  {
+    $$ = &Insert{Rows: $1}
+  }
 | openb ins_column_list closeb select_statement
   {
     $$ = &Insert{Columns: $2, Rows: $4}
@@ -8700,6 +8717,15 @@ on_dup_opt:
     $$ = $5
   }
 
+returning_opt:
+  {
+    $$ = nil
+  }
+| RETURNING select_expression_list
+  {
+    $$ = $2
+  }
+
 row_tuple_list:
   row_tuple_or_empty
   {
@@ -27,7 +27,7 @@ func (cached *ResolvedShard) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(24)
+		size += int64(32)
 	}
 	// field Target *vitess.io/vitess/go/vt/proto/query.Target
 	size += cached.Target.CachedSize(true)
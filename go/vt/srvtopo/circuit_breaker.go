@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvtopo
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops topo fetches after a run of consecutive failures.
+// Once open, it lets a single trial fetch through every cooldown; the
+// breaker closes again when a trial succeeds.
+//
+// The resolver keeps one breaker per cell and keyspace, so that a keyspace
+// whose SrvKeyspace can't be read doesn't stop the fetches of the others.
+// A breaker with a threshold of 0 never opens.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	// labels are the cell and keyspace of the breaker, for
+	// resolverCircuitOpen.
+	labels []string
+
+	mu            sync.Mutex
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, cell, keyspace string) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		labels:    []string{cell, keyspace},
+	}
+}
+
+// allow returns whether a topo fetch may be attempted. When it returns true,
+// the caller must report the outcome with recordSuccess, recordFailure or
+// recordAbort.
+func (cb *circuitBreaker) allow() bool {
+	if cb.threshold <= 0 {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.openedAt.IsZero() {
+		return true
+	}
+	if cb.trialInFlight || time.Since(cb.openedAt) < cb.cooldown {
+		return false
+	}
+	cb.trialInFlight = true
+	return true
+}
+
+// recordSuccess closes the breaker.
+func (cb *circuitBreaker) recordSuccess() {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.openedAt.IsZero() {
+		resolverCircuitOpen.Set(cb.labels, 0)
+	}
+	cb.failures = 0
+	cb.openedAt = time.Time{}
+	cb.trialInFlight = false
+}
+
+// recordFailure counts a failed fetch, opening the breaker when the
+// threshold is reached, or re-opening it when a trial fetch failed.
+func (cb *circuitBreaker) recordFailure() {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	if cb.trialInFlight || cb.failures >= cb.threshold {
+		if cb.openedAt.IsZero() {
+			resolverCircuitOpen.Set(cb.labels, 1)
+		}
+		cb.openedAt = time.Now()
+		cb.trialInFlight = false
+	}
+}
+
+// recordAbort reports a fetch that neither succeeded nor failed because of
+// the topo server, such as one whose context was canceled.
+func (cb *circuitBreaker) recordAbort() {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.trialInFlight = false
+}
+
+// isOpen returns whether the breaker is currently open.
+func (cb *circuitBreaker) isOpen() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return !cb.openedAt.IsZero()
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvtopo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/srvtopo/srvtopotest"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// countingSrvTopoServer counts the SrvKeyspace fetches, and can fail them
+// for some keyspaces only.
+type countingSrvTopoServer struct {
+	*srvtopotest.PassthroughSrvTopoServer
+	fetches        int
+	keyspaceErrors map[string]error
+}
+
+func (srv *countingSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	srv.fetches++
+	if err := srv.keyspaceErrors[keyspace]; err != nil {
+		return nil, err
+	}
+	return srv.PassthroughSrvTopoServer.GetSrvKeyspace(ctx, cell, keyspace)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	cb := newCircuitBreaker(2, time.Hour, "cell1", "ks")
+	require.True(t, cb.allow())
+	cb.recordFailure()
+	require.False(t, cb.isOpen())
+
+	// A success resets the consecutive failures.
+	require.True(t, cb.allow())
+	cb.recordSuccess()
+	cb.recordFailure()
+	require.False(t, cb.isOpen())
+	cb.recordFailure()
+	require.True(t, cb.isOpen())
+	require.False(t, cb.allow())
+
+	// After the cooldown, a single trial fetch is allowed.
+	cb.openedAt = time.Now().Add(-2 * time.Hour)
+	require.True(t, cb.allow())
+	require.False(t, cb.allow())
+
+	// An aborted trial lets another one through.
+	cb.recordAbort()
+	require.True(t, cb.allow())
+
+	// A failed trial re-opens the breaker for another cooldown.
+	cb.recordFailure()
+	require.True(t, cb.isOpen())
+	require.False(t, cb.allow())
+
+	// A successful trial closes it.
+	cb.openedAt = time.Now().Add(-2 * time.Hour)
+	require.True(t, cb.allow())
+	cb.recordSuccess()
+	require.False(t, cb.isOpen())
+	require.True(t, cb.allow())
+
+	// A disabled breaker never opens.
+	disabled := newCircuitBreaker(0, time.Hour, "cell1", "ks")
+	for range 10 {
+		disabled.recordFailure()
+	}
+	require.False(t, disabled.isOpen())
+	require.True(t, disabled.allow())
+}
+
+func TestResolverServesCachedSrvKeyspace(t *testing.T) {
+	ctx := t.Context()
+	srv := &countingSrvTopoServer{PassthroughSrvTopoServer: srvtopotest.NewPassthroughSrvTopoServer()}
+	srv.SrvKeyspace = &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_PRIMARY,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+
+	r := NewResolver(srv, nil, "cell1")
+	r.failureThreshold = 2
+	r.cooldown = time.Hour
+	r.maxStaleness = time.Hour
+
+	rss, err := r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.NoError(t, err)
+	require.Len(t, rss, 1)
+	assert.Zero(t, rss[0].Staleness)
+
+	// The topo server fails: the last known SrvKeyspace is used, and the
+	// resolved shards say how old it is.
+	srv.SrvKeyspace = nil
+	srv.SrvKeyspaceError = errors.New("topo is down")
+	staleServes := resolverStaleServes.Counts()["ks"]
+	for range 2 {
+		rss, err = r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+		require.NoError(t, err)
+		require.Len(t, rss, 1)
+		assert.Equal(t, "0", rss[0].Target.Shard)
+		assert.Positive(t, rss[0].Staleness)
+	}
+	assert.Equal(t, 3, srv.fetches)
+	assert.Equal(t, staleServes+2, resolverStaleServes.Counts()["ks"])
+
+	// The breaker is open: the topo server isn't asked anymore.
+	require.True(t, r.getBreaker("cell1", "ks").isOpen())
+	rss, err = r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.NoError(t, err)
+	assert.Positive(t, rss[0].Staleness)
+	assert.Equal(t, 3, srv.fetches)
+
+	assert.EqualValues(t, 1, resolverCircuitOpen.Counts()["cell1.ks"])
+
+	// Other keyspaces have their own breaker: they are still fetched.
+	_, err = r.ResolveDestination(ctx, "other", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "topo is down")
+	assert.Equal(t, 4, srv.fetches)
+	require.False(t, r.getBreaker("cell1", "other").isOpen())
+
+	// Once the cached SrvKeyspace is too old, it isn't used anymore.
+	r.lastKnown["ks"].fetchedAt = time.Now().Add(-2 * time.Hour)
+	_, err = r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "circuit breaker is open")
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+}
+
+func TestResolverCircuitBreakerPerKeyspace(t *testing.T) {
+	ctx := t.Context()
+	srv := &countingSrvTopoServer{
+		PassthroughSrvTopoServer: srvtopotest.NewPassthroughSrvTopoServer(),
+		keyspaceErrors:           map[string]error{"broken": errors.New("corrupt SrvKeyspace")},
+	}
+	srv.SrvKeyspace = &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_PRIMARY,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+
+	r := NewResolver(srv, nil, "cell1")
+	r.failureThreshold = 1
+	r.cooldown = time.Hour
+
+	// A keyspace whose SrvKeyspace can't be read opens its own breaker.
+	_, err := r.ResolveDestination(ctx, "broken", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "corrupt SrvKeyspace")
+	_, err = r.ResolveDestination(ctx, "broken", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "topo server is unavailable for keyspace broken in cell cell1: circuit breaker is open")
+	assert.Equal(t, 1, srv.fetches)
+
+	// The other keyspaces keep being resolved.
+	for range 3 {
+		rss, err := r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+		require.NoError(t, err)
+		require.Len(t, rss, 1)
+	}
+	assert.Equal(t, 4, srv.fetches)
+	assert.False(t, r.getBreaker("cell1", "ks").isOpen())
+}
+
+func TestResolverDoesNotCacheMissingKeyspace(t *testing.T) {
+	ctx := t.Context()
+	srv := &countingSrvTopoServer{PassthroughSrvTopoServer: srvtopotest.NewPassthroughSrvTopoServer()}
+	srv.SrvKeyspace = &topodatapb.SrvKeyspace{
+		Partitions: []*topodatapb.SrvKeyspace_KeyspacePartition{{
+			ServedType:      topodatapb.TabletType_PRIMARY,
+			ShardReferences: []*topodatapb.ShardReference{{Name: "0"}},
+		}},
+	}
+
+	r := NewResolver(srv, nil, "cell1")
+	r.failureThreshold = 1
+	r.cooldown = time.Hour
+	r.maxStaleness = time.Hour
+
+	_, err := r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.NoError(t, err)
+
+	// A keyspace that was deleted is neither a topo failure nor served
+	// from the cache afterwards.
+	srv.SrvKeyspace = nil
+	srv.SrvKeyspaceError = topo.NewError(topo.NoNode, "ks")
+	_, err = r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "node doesn't exist")
+	require.False(t, r.getBreaker("cell1", "ks").isOpen())
+
+	srv.SrvKeyspaceError = errors.New("topo is down")
+	_, err = r.ResolveDestination(ctx, "ks", topodatapb.TabletType_PRIMARY, key.DestinationAllShards{})
+	require.ErrorContains(t, err, "topo is down")
+	require.True(t, r.getBreaker("cell1", "ks").isOpen())
+}
@@ -19,13 +19,19 @@ package srvtopo
 import (
 	"context"
 	"sort"
+	"sync"
+	"time"
 
-	"vitess.io/vitess/go/sqltypes"
-
+	"github.com/spf13/pflag"
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/utils"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 
@@ -34,6 +40,33 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
+var (
+	// resolverFailureThreshold is the number of consecutive topo fetch
+	// failures for a keyspace after which the resolver stops fetching its
+	// SrvKeyspace for resolverCooldown. 0 disables the circuit breakers.
+	resolverFailureThreshold = 0
+	resolverCooldown         = 5 * time.Second
+
+	// resolverMaxStaleness is how old the last known SrvKeyspace can be for
+	// the resolver to keep routing queries with it while the topo server is
+	// failing. 0 disables serving from the cache.
+	resolverMaxStaleness time.Duration
+
+	resolverStaleServes = stats.NewCountersWithSingleLabel("SrvTopoResolverStaleServes", "Number of times the resolver routed using a cached SrvKeyspace because the topo server was unavailable", "Keyspace")
+	resolverCircuitOpen = stats.NewGaugesWithMultiLabels("SrvTopoResolverCircuitOpen", "Whether the resolver circuit breaker for the SrvKeyspace fetches of a keyspace is open", []string{"Cell", "Keyspace"})
+)
+
+func registerResolverFlags(fs *pflag.FlagSet) {
+	utils.SetFlagIntVar(fs, &resolverFailureThreshold, "srv-topo-resolver-failure-threshold", resolverFailureThreshold, "number of consecutive topo failures after which the resolver stops fetching the SrvKeyspace for srv-topo-resolver-cooldown (0 disables the circuit breaker)")
+	utils.SetFlagDurationVar(fs, &resolverCooldown, "srv-topo-resolver-cooldown", resolverCooldown, "how long the resolver waits before retrying the topo server once its circuit breaker opened")
+	utils.SetFlagDurationVar(fs, &resolverMaxStaleness, "srv-topo-resolver-max-staleness", resolverMaxStaleness, "how long the resolver keeps routing with the last known SrvKeyspace when the topo server is unavailable (0 disables serving from the cache)")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerResolverFlags)
+	servenv.OnParseFor("vtcombo", registerResolverFlags)
+}
+
 // A Gateway is the query processing module for each shard,
 // which is used by ScatterConn.
 type Gateway interface {
@@ -63,14 +96,47 @@ type Resolver struct {
 	// FIXME(alainjobart) also need a list of remote cells.
 	// FIXME(alainjobart) and a policy on how to use them.
 	// But for now we only use the local cell.
+
+	// failureThreshold and cooldown configure the circuit breakers that
+	// guard the SrvKeyspace fetches from the topo server.
+	failureThreshold int
+	cooldown         time.Duration
+
+	// maxStaleness is how long lastKnown entries can be used when the topo
+	// server is unavailable.
+	maxStaleness time.Duration
+
+	mu sync.Mutex
+	// breakers has one circuit breaker per cell and keyspace, created on
+	// the first fetch.
+	breakers  map[breakerKey]*circuitBreaker
+	lastKnown map[string]*cachedSrvKeyspace
+}
+
+// breakerKey identifies the SrvKeyspace a circuit breaker guards.
+type breakerKey struct {
+	cell     string
+	keyspace string
+}
+
+// cachedSrvKeyspace is the last SrvKeyspace the resolver fetched for a
+// keyspace.
+type cachedSrvKeyspace struct {
+	srvKeyspace *topodatapb.SrvKeyspace
+	fetchedAt   time.Time
 }
 
 // NewResolver creates a new Resolver.
 func NewResolver(topoServ Server, gateway Gateway, localCell string) *Resolver {
 	return &Resolver{
-		topoServ:  topoServ,
-		gateway:   gateway,
-		localCell: localCell,
+		topoServ:         topoServ,
+		gateway:          gateway,
+		localCell:        localCell,
+		failureThreshold: resolverFailureThreshold,
+		cooldown:         resolverCooldown,
+		maxStaleness:     resolverMaxStaleness,
+		breakers:         make(map[breakerKey]*circuitBreaker),
+		lastKnown:        make(map[string]*cachedSrvKeyspace),
 	}
 }
 
@@ -81,6 +147,11 @@ type ResolvedShard struct {
 
 	// Gateway is the way to execute a query on this shard
 	Gateway Gateway
+
+	// Staleness is the age of the topology information used to resolve
+	// the shard, when the topo server was unavailable and the resolver
+	// fell back to its last known SrvKeyspace. It is 0 otherwise.
+	Staleness time.Duration
 }
 
 // WithKeyspace returns a ResolvedShard with a new keyspace keeping other parameters the same
@@ -92,23 +163,126 @@ func (rs *ResolvedShard) WithKeyspace(newKeyspace string) *ResolvedShard {
 			TabletType: rs.Target.TabletType,
 			Cell:       rs.Target.Cell,
 		},
-		Gateway: rs.Gateway,
+		Gateway:   rs.Gateway,
+		Staleness: rs.Staleness,
 	}
 }
 
 // GetKeyspaceShards return all the shards in a keyspace. It is only valid for the local cell.
 // Do not use it to further resolve shards, instead use the Resolve* methods.
 func (r *Resolver) GetKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, error) {
-	srvKeyspace, err := r.topoServ.GetSrvKeyspace(ctx, r.localCell, keyspace)
+	keyspace, srvKeyspace, shards, _, err := r.getKeyspaceShards(ctx, keyspace, tabletType)
+	return keyspace, srvKeyspace, shards, err
+}
+
+// getKeyspaceShards is GetKeyspaceShards, also returning the staleness of
+// the SrvKeyspace that was used.
+func (r *Resolver) getKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, time.Duration, error) {
+	srvKeyspace, staleness, err := r.getSrvKeyspace(ctx, keyspace)
 	if err != nil {
-		return "", nil, nil, vterrors.Wrapf(err, "keyspace %v fetch error", keyspace)
+		return "", nil, nil, 0, vterrors.Wrapf(err, "keyspace %v fetch error", keyspace)
 	}
 
 	partition := topoproto.SrvKeyspaceGetPartition(srvKeyspace, tabletType)
 	if partition == nil {
-		return "", nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "No partition found for tabletType %v in keyspace %v", topoproto.TabletTypeLString(tabletType), keyspace)
+		return "", nil, nil, 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "No partition found for tabletType %v in keyspace %v", topoproto.TabletTypeLString(tabletType), keyspace)
+	}
+	return keyspace, srvKeyspace, partition.ShardReferences, staleness, nil
+}
+
+// getSrvKeyspace fetches the SrvKeyspace through the circuit breaker of the
+// keyspace. When the topo server is unavailable, it returns the last known
+// SrvKeyspace if it is not older than maxStaleness, along with its age.
+func (r *Resolver) getSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, time.Duration, error) {
+	breaker := r.getBreaker(r.localCell, keyspace)
+	if !breaker.allow() {
+		if srvKeyspace, staleness, ok := r.getLastKnown(keyspace); ok {
+			return srvKeyspace, staleness, nil
+		}
+		return nil, 0, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "topo server is unavailable for keyspace %s in cell %s: circuit breaker is open", keyspace, r.localCell)
+	}
+
+	srvKeyspace, err := r.topoServ.GetSrvKeyspace(ctx, r.localCell, keyspace)
+	switch {
+	case err == nil:
+		breaker.recordSuccess()
+		r.setLastKnown(keyspace, srvKeyspace)
+		return srvKeyspace, 0, nil
+	case topo.IsErrType(err, topo.NoNode):
+		// The topo server answered: the keyspace doesn't exist.
+		breaker.recordSuccess()
+		r.clearLastKnown(keyspace)
+		return nil, 0, err
+	case ctx.Err() != nil:
+		// The query was canceled or timed out, that says nothing about
+		// the topo server.
+		breaker.recordAbort()
+		return nil, 0, err
 	}
-	return keyspace, srvKeyspace, partition.ShardReferences, nil
+
+	breaker.recordFailure()
+	if srvKeyspace, staleness, ok := r.getLastKnown(keyspace); ok {
+		return srvKeyspace, staleness, nil
+	}
+	return nil, 0, err
+}
+
+// getBreaker returns the circuit breaker for the SrvKeyspace of keyspace in
+// cell, creating it if needed.
+func (r *Resolver) getBreaker(cell, keyspace string) *circuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := breakerKey{cell: cell, keyspace: keyspace}
+	cb, ok := r.breakers[k]
+	if !ok {
+		cb = newCircuitBreaker(r.failureThreshold, r.cooldown, cell, keyspace)
+		r.breakers[k] = cb
+	}
+	return cb
+}
+
+func (r *Resolver) getLastKnown(keyspace string) (*topodatapb.SrvKeyspace, time.Duration, bool) {
+	if r.maxStaleness <= 0 {
+		return nil, 0, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.lastKnown[keyspace]
+	if !ok {
+		return nil, 0, false
+	}
+	staleness := time.Since(entry.fetchedAt)
+	if staleness > r.maxStaleness {
+		return nil, 0, false
+	}
+	resolverStaleServes.Add(keyspace, 1)
+	return entry.srvKeyspace, staleness, true
+}
+
+func (r *Resolver) setLastKnown(keyspace string, srvKeyspace *topodatapb.SrvKeyspace) {
+	if r.maxStaleness <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastKnown[keyspace] = &cachedSrvKeyspace{
+		srvKeyspace: srvKeyspace,
+		fetchedAt:   time.Now(),
+	}
+}
+
+func (r *Resolver) clearLastKnown(keyspace string) {
+	if r.maxStaleness <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.lastKnown, keyspace)
 }
 
 // GetAllShards returns the list of ResolvedShards associated with all
@@ -116,7 +290,7 @@ func (r *Resolver) GetKeyspaceShards(ctx context.Context, keyspace string, table
 // FIXME(alainjobart) callers should convert to ResolveDestination(),
 // and GetSrvKeyspace.
 func (r *Resolver) GetAllShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) ([]*ResolvedShard, *topodatapb.SrvKeyspace, error) {
-	keyspace, srvKeyspace, allShards, err := r.GetKeyspaceShards(ctx, keyspace, tabletType)
+	keyspace, srvKeyspace, allShards, staleness, err := r.getKeyspaceShards(ctx, keyspace, tabletType)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,8 +308,9 @@ func (r *Resolver) GetAllShards(ctx context.Context, keyspace string, tabletType
 		// We would then need to read the SrvKeyspace there too.
 		target.Cell = ""
 		res[i] = &ResolvedShard{
-			Target:  target,
-			Gateway: r.gateway,
+			Target:    target,
+			Gateway:   r.gateway,
+			Staleness: staleness,
 		}
 	}
 	return res, srvKeyspace, nil
@@ -169,7 +344,7 @@ func (r *Resolver) GetAllKeyspaces(ctx context.Context) ([]string, error) {
 // - []*ResolvedShard:   shard1, 			shard2
 // - [][][]sqltypes.Value: [[id1a,id1b]],  [[id2a,id2b], [id3a,id3b]]
 func (r *Resolver) ResolveDestinationsMultiCol(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ids [][]sqltypes.Value, destinations []key.ShardDestination) ([]*ResolvedShard, [][][]sqltypes.Value, error) {
-	keyspace, _, allShards, err := r.GetKeyspaceShards(ctx, keyspace, tabletType)
+	keyspace, _, allShards, staleness, err := r.getKeyspaceShards(ctx, keyspace, tabletType)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -179,6 +354,7 @@ func (r *Resolver) ResolveDestinationsMultiCol(ctx context.Context, keyspace str
 		ids:        ids,
 		keyspace:   keyspace,
 		tabletType: tabletType,
+		staleness:  staleness,
 	}
 
 	for i, destination := range destinations {
@@ -197,6 +373,7 @@ type resultAcc struct {
 	ids        [][]sqltypes.Value
 	keyspace   string
 	tabletType topodatapb.TabletType
+	staleness  time.Duration
 }
 
 // resolveShard is called once per shard that is resolved. It will keep track of which shards that are
@@ -216,8 +393,9 @@ func (acc *resultAcc) resolveShard(idx int) func(shard string) error {
 			target.Cell = ""
 			offsetInValues = len(acc.shards)
 			acc.shards = append(acc.shards, &ResolvedShard{
-				Target:  target,
-				Gateway: acc.resolver.gateway,
+				Target:    target,
+				Gateway:   acc.resolver.gateway,
+				Staleness: acc.staleness,
 			})
 			if acc.ids != nil {
 				acc.values = append(acc.values, nil)
@@ -246,7 +424,7 @@ func (acc *resultAcc) resolveShard(idx int) func(shard string) error {
 // - []*ResolvedShard:   shard1, shard2
 // - [][]*querypb.Value: [id1],  [id2, id3]
 func (r *Resolver) ResolveDestinations(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ids []*querypb.Value, destinations []key.ShardDestination) ([]*ResolvedShard, [][]*querypb.Value, error) {
-	keyspace, _, allShards, err := r.GetKeyspaceShards(ctx, keyspace, tabletType)
+	keyspace, _, allShards, staleness, err := r.getKeyspaceShards(ctx, keyspace, tabletType)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -270,8 +448,9 @@ func (r *Resolver) ResolveDestinations(ctx context.Context, keyspace string, tab
 				target.Cell = ""
 				s = len(result)
 				result = append(result, &ResolvedShard{
-					Target:  target,
-					Gateway: r.gateway,
+					Target:    target,
+					Gateway:   r.gateway,
+					Staleness: staleness,
 				})
 				if ids != nil {
 					values = append(values, nil)
@@ -189,6 +189,7 @@ var (
 		{Name: "transaction_write_set_extraction"},
 	}
 	UseReservedConn = []SystemVariable{
+		{Name: "block_encryption_mode"},
 		{Name: "default_week_format"},
 		{Name: "end_markers_in_json", IsBoolean: true, SupportSetVar: true},
 		{Name: "eq_range_index_dive_limit", SupportSetVar: true},
@@ -240,7 +241,6 @@ var (
 		// Until then, SET statements against these settings are allowed
 		// as long as they have the same value as the underlying database
 		{Name: "binlog_format"},
-		{Name: "block_encryption_mode"},
 		{Name: "character_set_client"},
 		{Name: "character_set_connection"},
 		{Name: "character_set_database"},
@@ -148,6 +148,20 @@ type Conn interface {
 	// and acquiring is not under the same mutex in current implementation of `TryLock`.
 	TryLock(ctx context.Context, dirPath, contents string) (LockDescriptor, error)
 
+	// LockHolders returns the locks on the given directory, as taken
+	// by Lock, LockWithTTL, LockName and TryLock. The holder of the
+	// lock comes first, followed by the callers waiting for it, in the
+	// order they will get it.
+	// Returns an empty list if the directory is not locked.
+	LockHolders(ctx context.Context, dirPath string) ([]LockHolder, error)
+
+	// BreakLock releases the lock with the given ID on the given
+	// directory on behalf of its holder, for instance when the holder
+	// went away without releasing it. The holder finds out that it
+	// lost the lock the next time it checks on it.
+	// Returns ErrNoNode if there is no such lock.
+	BreakLock(ctx context.Context, dirPath, id string) error
+
 	//
 	// Watches
 	//
@@ -318,6 +332,17 @@ type LockDescriptor interface {
 	Unlock(ctx context.Context) error
 }
 
+// LockHolder describes a lock as stored by a topo server implementation.
+// It is returned by LockHolders().
+type LockHolder struct {
+	// ID identifies the lock within its directory. It can be passed
+	// to BreakLock().
+	ID string
+
+	// Contents are the contents the lock was taken with.
+	Contents []byte
+}
+
 // CancelFunc is returned by the Watch method.
 type CancelFunc func()
 
@@ -223,3 +223,38 @@ func (s *Server) unlock(ctx context.Context, lockPath string) error {
 
 	return unlockErr
 }
+
+// LockHolders is part of the topo.Conn interface.
+// The callers waiting for a lock are not stored in Consul, so only its
+// holder is returned, identified by its session.
+func (s *Server) LockHolders(ctx context.Context, dirPath string) ([]topo.LockHolder, error) {
+	lockPath := path.Join(s.root, dirPath, locksFilename)
+	pair, _, err := s.kv.Get(lockPath, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, convertError(err, lockPath)
+	}
+	if pair == nil || pair.Session == "" {
+		return nil, nil
+	}
+	return []topo.LockHolder{{
+		ID:       pair.Session,
+		Contents: pair.Value,
+	}}, nil
+}
+
+// BreakLock is part of the topo.Conn interface.
+// We destroy the session of the lock, which releases it.
+func (s *Server) BreakLock(ctx context.Context, dirPath, id string) error {
+	lockPath := path.Join(s.root, dirPath, locksFilename)
+	pair, _, err := s.kv.Get(lockPath, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return convertError(err, lockPath)
+	}
+	if pair == nil || pair.Session != id {
+		return topo.NewError(topo.NoNode, path.Join(lockPath, id))
+	}
+	if _, err := s.client.Session().Destroy(id, (&api.WriteOptions{}).WithContext(ctx)); err != nil {
+		return convertError(err, lockPath)
+	}
+	return nil
+}
@@ -260,3 +260,37 @@ func (ld *etcdLockDescriptor) Unlock(ctx context.Context) error {
 	}
 	return nil
 }
+
+// LockHolders is part of the topo.Conn interface.
+func (s *Server) LockHolders(ctx context.Context, dirPath string) ([]topo.LockHolder, error) {
+	nodePath := path.Join(s.root, dirPath, locksPath)
+	resp, err := s.cli.Get(ctx, nodePath+"/", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByCreateRevision, clientv3.SortAscend))
+	if err != nil {
+		return nil, convertError(err, nodePath)
+	}
+	holders := make([]topo.LockHolder, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		holders = append(holders, topo.LockHolder{
+			ID:       path.Base(string(kv.Key)),
+			Contents: kv.Value,
+		})
+	}
+	return holders, nil
+}
+
+// BreakLock is part of the topo.Conn interface.
+// We revoke the lease of the lock, so its holder cannot keep it alive.
+func (s *Server) BreakLock(ctx context.Context, dirPath, id string) error {
+	key := path.Join(s.root, dirPath, locksPath, id)
+	resp, err := s.cli.Get(ctx, key)
+	if err != nil {
+		return convertError(err, key)
+	}
+	if len(resp.Kvs) == 0 {
+		return topo.NewError(topo.NoNode, key)
+	}
+	if _, err := s.cli.Revoke(ctx, clientv3.LeaseID(resp.Kvs[0].Lease)); err != nil {
+		return convertError(err, key)
+	}
+	return nil
+}
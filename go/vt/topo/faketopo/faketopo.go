@@ -354,6 +354,16 @@ func (f *FakeConn) TryLock(ctx context.Context, dirPath, contents string) (topo.
 	return f.Lock(ctx, dirPath, contents)
 }
 
+// LockHolders implements the Conn interface.
+func (f *FakeConn) LockHolders(ctx context.Context, dirPath string) ([]topo.LockHolder, error) {
+	return nil, nil
+}
+
+// BreakLock implements the Conn interface.
+func (f *FakeConn) BreakLock(ctx context.Context, dirPath, id string) error {
+	return topo.NewError(topo.NoNode, dirPath)
+}
+
 // Watch implements the Conn interface
 func (f *FakeConn) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, error) {
 	f.mu.Lock()
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// LockResourceHolder describes a lock on a keyspace, a shard, or a named
+// resource, as found in the topo server.
+type LockResourceHolder struct {
+	// ID identifies the lock, see LockHolder.
+	ID string
+
+	// Lock is the description of the lock its holder stored, or nil if
+	// its contents could not be decoded.
+	Lock *Lock
+
+	// Time is when the lock was requested. It is zero if it is unknown.
+	Time time.Time
+}
+
+// KeyspaceLockPath returns the path the lock on the given keyspace is
+// taken on.
+func KeyspaceLockPath(keyspace string) string {
+	return (&keyspaceLock{keyspace: keyspace}).Path()
+}
+
+// ShardLockPath returns the path the lock on the given shard is taken on.
+func ShardLockPath(keyspace, shard string) string {
+	return (&shardLock{keyspace: keyspace, shard: shard}).Path()
+}
+
+// NamedLockPath returns the path the named lock with the given name is
+// taken on.
+func NamedLockPath(name string) string {
+	return (&namedLock{name: name}).Path()
+}
+
+// GetLockHolders returns the locks on the given lock path in the global
+// cell, the holder of the lock first, followed by the callers waiting for
+// it. It is meant to diagnose lock contention, and to find locks that were
+// left behind.
+func (ts *Server) GetLockHolders(ctx context.Context, lockPath string) ([]*LockResourceHolder, error) {
+	if ts.globalCell == nil {
+		return nil, errors.New("no global cell connection on the topo server")
+	}
+	holders, err := ts.globalCell.LockHolders(ctx, lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LockResourceHolder, 0, len(holders))
+	for _, holder := range holders {
+		result = append(result, newLockResourceHolder(holder))
+	}
+	return result, nil
+}
+
+// BreakLock releases the lock with the given ID on the given lock path in
+// the global cell on behalf of its holder, and returns the lock it broke.
+// Only locks that were requested more than minAge ago can be broken, so a
+// lock that is still in use is not broken by mistake. Breaking a lock does
+// not interrupt its holder, which finds out the next time it checks on the
+// lock, so it should only be used for locks whose holder went away.
+func (ts *Server) BreakLock(ctx context.Context, lockPath, id string, minAge time.Duration) (*LockResourceHolder, error) {
+	if minAge <= 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "a positive minimum age is required to break a lock")
+	}
+	holders, err := ts.GetLockHolders(ctx, lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, holder := range holders {
+		if holder.ID != id {
+			continue
+		}
+		if holder.Time.IsZero() {
+			return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "cannot break lock %v on %v: its age is unknown", id, lockPath)
+		}
+		age := time.Since(holder.Time).Round(time.Second)
+		if age < minAge {
+			return nil, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "cannot break lock %v on %v: it is %v old, less than %v", id, lockPath, age, minAge)
+		}
+
+		log.Warn(fmt.Sprintf("Breaking lock %v on %v for action %v, taken by %v@%v %v ago", id, lockPath, holder.Lock.Action, holder.Lock.UserName, holder.Lock.HostName, age))
+		if err := ts.globalCell.BreakLock(ctx, lockPath, id); err != nil {
+			return nil, err
+		}
+		return holder, nil
+	}
+	return nil, NewError(NoNode, fmt.Sprintf("%v/%v", lockPath, id))
+}
+
+// newLockResourceHolder decodes the contents of holder, which are the JSON
+// representation of a Lock.
+func newLockResourceHolder(holder LockHolder) *LockResourceHolder {
+	result := &LockResourceHolder{ID: holder.ID}
+	l := &Lock{}
+	if err := json.Unmarshal(holder.Contents, l); err != nil {
+		return result
+	}
+	result.Lock = l
+	if t, err := time.Parse(time.RFC3339, l.Time); err == nil {
+		result.Time = t
+	}
+	return result
+}
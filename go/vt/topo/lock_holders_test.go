@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// TestGetLockHolders tests that the holder of a lock can be inspected.
+func TestGetLockHolders(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+
+	lockPath := topo.KeyspaceLockPath("ks")
+	holders, err := ts.GetLockHolders(ctx, lockPath)
+	require.NoError(t, err)
+	assert.Empty(t, holders)
+
+	lockCtx, unlock, err := ts.LockKeyspace(ctx, "ks", "testing")
+	require.NoError(t, err)
+
+	holders, err = ts.GetLockHolders(ctx, lockPath)
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	require.NotNil(t, holders[0].Lock)
+	assert.Equal(t, "testing", holders[0].Lock.Action)
+	assert.Equal(t, "Running", holders[0].Lock.Status)
+	assert.WithinDuration(t, time.Now(), holders[0].Time, time.Minute)
+
+	// The lock was just taken, so it cannot be broken.
+	_, err = ts.BreakLock(ctx, lockPath, holders[0].ID, time.Hour)
+	require.ErrorContains(t, err, "less than 1h0m0s")
+	_, err = ts.BreakLock(ctx, lockPath, holders[0].ID, 0)
+	require.ErrorContains(t, err, "a positive minimum age is required")
+	require.NoError(t, topo.CheckKeyspaceLocked(lockCtx, "ks"))
+
+	var unlockErr error
+	unlock(&unlockErr)
+	require.NoError(t, unlockErr)
+	holders, err = ts.GetLockHolders(ctx, lockPath)
+	require.NoError(t, err)
+	assert.Empty(t, holders)
+}
+
+// TestBreakLock tests that a lock that was left behind can be broken.
+func TestBreakLock(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	err := ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{})
+	require.NoError(t, err)
+	_, err = ts.GetOrCreateShard(ctx, "ks", "0")
+	require.NoError(t, err)
+
+	// Take the lock on behalf of a process that went away two hours ago.
+	lockPath := topo.ShardLockPath("ks", "0")
+	l := &topo.Lock{
+		Action:   "PlannedReparentShard",
+		HostName: "gone",
+		UserName: "vitess",
+		Time:     time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+		Status:   "Running",
+	}
+	contents, err := l.ToJSON()
+	require.NoError(t, err)
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	_, err = conn.Lock(ctx, lockPath, contents)
+	require.NoError(t, err)
+
+	holders, err := ts.GetLockHolders(ctx, lockPath)
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+
+	_, err = ts.BreakLock(ctx, lockPath, holders[0].ID+"0", time.Hour)
+	require.True(t, topo.IsErrType(err, topo.NoNode), err)
+	_, err = ts.BreakLock(ctx, lockPath, holders[0].ID, 3*time.Hour)
+	require.ErrorContains(t, err, "less than 3h0m0s")
+
+	broken, err := ts.BreakLock(ctx, lockPath, holders[0].ID, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "PlannedReparentShard", broken.Lock.Action)
+	assert.Equal(t, "gone", broken.Lock.HostName)
+
+	// The shard can be locked again.
+	_, unlock, err := ts.LockShard(ctx, "ks", "0", "testing")
+	require.NoError(t, err)
+	var unlockErr error
+	unlock(&unlockErr)
+	require.NoError(t, unlockErr)
+}
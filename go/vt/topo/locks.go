@@ -28,6 +28,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
@@ -49,6 +50,20 @@ var (
 	RemoteOperationTimeout = 15 * time.Second
 )
 
+var (
+	lockWaitTimings = stats.NewMultiTimings(
+		"TopoLockWait",
+		"Time spent waiting to acquire topo locks, by lock type and outcome",
+		[]string{"Type", "Outcome"},
+	)
+
+	lockHoldTimings = stats.NewMultiTimings(
+		"TopoLockHold",
+		"Time topo locks were held for, by lock type",
+		[]string{"Type"},
+	)
+)
+
 // How long named locks are kept in the topo server.
 // This ensures that orphaned named locks are not kept around forever.
 // This should never happen, but it provides a final safety net.
@@ -66,6 +81,10 @@ type Lock struct {
 
 	// Status is the current status of the Lock.
 	Status string
+
+	// acquired is when the lock was acquired, so we can tell how long
+	// it was held for.
+	acquired time.Time
 }
 
 func init() {
@@ -184,17 +203,28 @@ func (l *Lock) lock(ctx context.Context, ts *Server, lt iTopoLock, opts ...LockO
 	if ts.globalCell == nil {
 		return nil, errors.New("no global cell connection on the topo server")
 	}
+
+	startTime := time.Now()
+	var lockDescriptor LockDescriptor
 	switch l.Options.lockType {
 	case NonBlocking:
-		return ts.globalCell.TryLock(ctx, lt.Path(), j)
+		lockDescriptor, err = ts.globalCell.TryLock(ctx, lt.Path(), j)
 	case Named:
-		return ts.globalCell.LockName(ctx, lt.Path(), j)
+		lockDescriptor, err = ts.globalCell.LockName(ctx, lt.Path(), j)
 	default:
 		if l.Options.ttl != 0 {
-			return ts.globalCell.LockWithTTL(ctx, lt.Path(), j, l.Options.ttl)
+			lockDescriptor, err = ts.globalCell.LockWithTTL(ctx, lt.Path(), j, l.Options.ttl)
+		} else {
+			lockDescriptor, err = ts.globalCell.Lock(ctx, lt.Path(), j)
 		}
-		return ts.globalCell.Lock(ctx, lt.Path(), j)
 	}
+	if err != nil {
+		lockWaitTimings.Record([]string{lt.Type(), "Failed"}, startTime)
+		return nil, err
+	}
+	lockWaitTimings.Record([]string{lt.Type(), "Acquired"}, startTime)
+	l.acquired = time.Now()
+	return lockDescriptor, nil
 }
 
 // unlock unlocks a previously locked key.
@@ -219,6 +249,7 @@ func (l *Lock) unlock(ctx context.Context, lt iTopoLock, lockDescriptor LockDesc
 		log.Info(fmt.Sprintf("Unlocking %v %v for successful action %v", lt.Type(), lt.ResourceName(), l.Action))
 		l.Status = "Done"
 	}
+	lockHoldTimings.Record([]string{lt.Type()}, l.acquired)
 	return lockDescriptor.Unlock(ctx)
 }
 
@@ -19,6 +19,8 @@ package memorytopo
 import (
 	"context"
 	"fmt"
+	"path"
+	"strconv"
 	"time"
 
 	"vitess.io/vitess/go/vt/topo"
@@ -39,6 +41,7 @@ func convertError(err error, nodePath string) error {
 type memoryTopoLockDescriptor struct {
 	c       *Conn
 	dirPath string
+	lockID  uint64
 }
 
 // TryLock is part of the topo.Conn interface. Its implementation is same as Lock
@@ -131,6 +134,7 @@ func (c *Conn) lock(ctx context.Context, dirPath, contents string, named bool) (
 		// No one has the lock, grab it.
 		n.lock = make(chan struct{})
 		n.lockContents = contents
+		n.lockID = c.factory.getNextVersion()
 		for _, w := range n.watches {
 			if w.lock == nil {
 				continue
@@ -141,22 +145,31 @@ func (c *Conn) lock(ctx context.Context, dirPath, contents string, named bool) (
 		return &memoryTopoLockDescriptor{
 			c:       c,
 			dirPath: dirPath,
+			lockID:  n.lockID,
 		}, nil
 	}
 }
 
 // Check is part of the topo.LockDescriptor interface.
-// We can never lose a lock in this implementation.
+// We can only lose a lock in this implementation if it is broken.
 func (ld *memoryTopoLockDescriptor) Check(ctx context.Context) error {
+	ld.c.factory.mu.Lock()
+	defer ld.c.factory.mu.Unlock()
+
+	n := ld.c.factory.nodeByPath(ld.c.cell, ld.dirPath)
+	if n == nil || n.lock == nil || n.lockID != ld.lockID {
+		return fmt.Errorf("lock on node %v was broken", ld.dirPath)
+	}
 	return nil
 }
 
 // Unlock is part of the topo.LockDescriptor interface.
 func (ld *memoryTopoLockDescriptor) Unlock(ctx context.Context) error {
-	return ld.c.unlock(ctx, ld.dirPath)
+	return ld.c.unlock(ctx, ld.dirPath, ld.lockID)
 }
 
-func (c *Conn) unlock(ctx context.Context, dirPath string) error {
+// unlock releases the lock with the given ID on dirPath.
+func (c *Conn) unlock(ctx context.Context, dirPath string, lockID uint64) error {
 	if c.closed.Load() {
 		return ErrConnectionClosed
 	}
@@ -168,11 +181,56 @@ func (c *Conn) unlock(ctx context.Context, dirPath string) error {
 	if n == nil {
 		return topo.NewError(topo.NoNode, dirPath)
 	}
-	if n.lock == nil {
+	if n.lock == nil || n.lockID != lockID {
 		return fmt.Errorf("node %v is not locked", dirPath)
 	}
 	close(n.lock)
 	n.lock = nil
 	n.lockContents = ""
+	n.lockID = 0
 	return nil
 }
+
+// LockHolders is part of the topo.Conn interface.
+// The callers waiting for a lock are not tracked, so only its holder
+// is returned.
+func (c *Conn) LockHolders(ctx context.Context, dirPath string) ([]topo.LockHolder, error) {
+	c.factory.callstats.Add([]string{"LockHolders"}, 1)
+	if err := c.dial(ctx); err != nil {
+		return nil, err
+	}
+
+	c.factory.mu.Lock()
+	defer c.factory.mu.Unlock()
+
+	if c.factory.err != nil {
+		return nil, c.factory.err
+	}
+	n := c.factory.nodeByPath(c.cell, dirPath)
+	if n == nil || n.lock == nil {
+		return nil, nil
+	}
+	return []topo.LockHolder{{
+		ID:       strconv.FormatUint(n.lockID, 10),
+		Contents: []byte(n.lockContents),
+	}}, nil
+}
+
+// BreakLock is part of the topo.Conn interface.
+func (c *Conn) BreakLock(ctx context.Context, dirPath, id string) error {
+	c.factory.callstats.Add([]string{"BreakLock"}, 1)
+	if err := c.dial(ctx); err != nil {
+		return err
+	}
+
+	c.factory.mu.Lock()
+	n := c.factory.nodeByPath(c.cell, dirPath)
+	if n == nil || n.lock == nil || strconv.FormatUint(n.lockID, 10) != id {
+		c.factory.mu.Unlock()
+		return topo.NewError(topo.NoNode, path.Join(dirPath, id))
+	}
+	lockID := n.lockID
+	c.factory.mu.Unlock()
+
+	return c.unlock(ctx, dirPath, lockID)
+}
@@ -224,6 +224,10 @@ type node struct {
 	// For regular locks, it has the contents that was passed in.
 	// For primary election, it has the id of the election leader.
 	lockContents string
+
+	// lockID identifies the current lock, so a lock that was broken
+	// cannot be released by its former holder.
+	lockID uint64
 }
 
 func (n *node) isDirectory() bool {
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ReparentReportsPath is the directory of the reparent reports of a shard,
+// relative to the directory of the shard.
+const ReparentReportsPath = "reparent_reports"
+
+// MaxReparentReports is the number of reparent reports kept for each shard.
+// Saving a report deletes the oldest reports beyond it.
+const MaxReparentReports = 20
+
+func reparentReportsPath(keyspace, shard string) string {
+	return path.Join(KeyspacesPath, keyspace, ShardsPath, shard, ReparentReportsPath)
+}
+
+// SaveReparentReport saves the report of a reparent of a shard in the global
+// topo, and deletes the oldest reports of the shard if it has more than
+// MaxReparentReports.
+func (ts *Server) SaveReparentReport(ctx context.Context, report *topodatapb.ReparentReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := report.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	// The reports are named after their start time, so that the names sort
+	// in the order the reparents ran.
+	dir := reparentReportsPath(report.Keyspace, report.Shard)
+	name := fmt.Sprintf("%020d", protoutil.TimeFromProto(report.StartTime).UnixNano())
+	if _, err := ts.globalCell.Create(ctx, path.Join(dir, name), data); err != nil {
+		return err
+	}
+
+	names, err := ts.listReparentReports(ctx, report.Keyspace, report.Shard)
+	if err != nil {
+		return err
+	}
+	for len(names) > MaxReparentReports {
+		if err := ts.globalCell.Delete(ctx, path.Join(dir, names[0]), nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// GetReparentReports returns the reparent reports of a shard, most recent
+// first. If limit is positive, at most limit reports are returned.
+func (ts *Server) GetReparentReports(ctx context.Context, keyspace, shard string, limit int) ([]*topodatapb.ReparentReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	names, err := ts.listReparentReports(ctx, keyspace, shard)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(names)
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+
+	dir := reparentReportsPath(keyspace, shard)
+	reports := make([]*topodatapb.ReparentReport, 0, len(names))
+	for _, name := range names {
+		data, _, err := ts.globalCell.Get(ctx, path.Join(dir, name))
+		if err != nil {
+			if IsErrType(err, NoNode) {
+				// The report was deleted by a concurrent save.
+				continue
+			}
+			return nil, err
+		}
+
+		report := &topodatapb.ReparentReport{}
+		if err := report.UnmarshalVT(data); err != nil {
+			return nil, vterrors.Wrapf(err, "GetReparentReports(%v,%v): bad reparent report %v", keyspace, shard, name)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// deleteReparentReports deletes all the reparent reports of a shard.
+func (ts *Server) deleteReparentReports(ctx context.Context, keyspace, shard string) error {
+	names, err := ts.listReparentReports(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+
+	dir := reparentReportsPath(keyspace, shard)
+	for _, name := range names {
+		if err := ts.globalCell.Delete(ctx, path.Join(dir, name), nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listReparentReports returns the names of the reparent reports of a shard,
+// oldest first.
+func (ts *Server) listReparentReports(ctx context.Context, keyspace, shard string) ([]string, error) {
+	entries, err := ts.globalCell.ListDir(ctx, reparentReportsPath(keyspace, shard), false /* full */)
+	if IsErrType(err, NoNode) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := DirEntriesToStringArray(entries)
+	slices.Sort(names)
+	return names, nil
+}
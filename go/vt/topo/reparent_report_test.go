@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestReparentReports(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+
+	reports, err := ts.GetReparentReports(ctx, "ks", "0", 0)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := range topo.MaxReparentReports + 2 {
+		err := ts.SaveReparentReport(ctx, &topodatapb.ReparentReport{
+			Keyspace:  "ks",
+			Shard:     "0",
+			StartTime: protoutil.TimeToProto(start.Add(time.Duration(i) * time.Minute)),
+			NewPrimary: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  uint32(i),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	// The oldest reports are deleted, and the others are returned most recent
+	// first.
+	reports, err = ts.GetReparentReports(ctx, "ks", "0", 0)
+	require.NoError(t, err)
+	require.Len(t, reports, topo.MaxReparentReports)
+	require.EqualValues(t, topo.MaxReparentReports+1, reports[0].NewPrimary.Uid)
+	require.EqualValues(t, 2, reports[len(reports)-1].NewPrimary.Uid)
+
+	reports, err = ts.GetReparentReports(ctx, "ks", "0", 3)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	require.EqualValues(t, topo.MaxReparentReports-1, reports[2].NewPrimary.Uid)
+
+	// The reports don't keep a deleted shard listed.
+	require.NoError(t, ts.DeleteShard(ctx, "ks", "0"))
+	shards, err := ts.GetShardNames(ctx, "ks")
+	require.NoError(t, err)
+	require.Empty(t, shards)
+}
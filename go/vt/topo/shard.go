@@ -399,6 +399,9 @@ func (ts *Server) DeleteShard(ctx context.Context, keyspace, shard string) error
 	if err := ts.globalCell.Delete(ctx, shardPath, nil); err != nil {
 		return err
 	}
+	if err := ts.deleteReparentReports(ctx, keyspace, shard); err != nil {
+		return err
+	}
 	event.Dispatch(&events.ShardChange{
 		KeyspaceName: keyspace,
 		ShardName:    shard,
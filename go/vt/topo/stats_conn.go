@@ -245,6 +245,41 @@ func (st *StatsConn) internalLock(ctx context.Context, dirPath, contents string,
 	return res, err
 }
 
+// LockHolders is part of the Conn interface
+func (st *StatsConn) LockHolders(ctx context.Context, dirPath string) ([]LockHolder, error) {
+	startTime := time.Now()
+	statsKey := []string{"LockHolders", st.cell}
+	if err := st.readSem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	defer st.readSem.Release(1)
+	topoStatsConnReadWaitTimings.Record(statsKey, startTime)
+	startTime = time.Now() // reset
+	defer topoStatsConnTimings.Record(statsKey, startTime)
+	res, err := st.conn.LockHolders(ctx, dirPath)
+	if err != nil {
+		topoStatsConnErrors.Add(statsKey, int64(1))
+		return res, err
+	}
+	return res, err
+}
+
+// BreakLock is part of the Conn interface
+func (st *StatsConn) BreakLock(ctx context.Context, dirPath, id string) error {
+	statsKey := []string{"BreakLock", st.cell}
+	if st.readOnly {
+		return vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], dirPath)
+	}
+	startTime := time.Now()
+	defer topoStatsConnTimings.Record(statsKey, startTime)
+	err := st.conn.BreakLock(ctx, dirPath, id)
+	if err != nil {
+		topoStatsConnErrors.Add(statsKey, int64(1))
+		return err
+	}
+	return err
+}
+
 // Watch is part of the Conn interface
 func (st *StatsConn) Watch(ctx context.Context, filePath string) (current *WatchData, changes <-chan *WatchData, err error) {
 	startTime := time.Now()
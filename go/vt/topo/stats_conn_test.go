@@ -157,6 +157,25 @@ func (st *fakeConn) TryLock(ctx context.Context, dirPath, contents string) (lock
 	return lock, err
 }
 
+// LockHolders is part of the Conn interface.
+func (st *fakeConn) LockHolders(ctx context.Context, dirPath string) (holders []LockHolder, err error) {
+	if dirPath == "error" {
+		return holders, errors.New("dummy error")
+	}
+	return holders, err
+}
+
+// BreakLock is part of the Conn interface.
+func (st *fakeConn) BreakLock(ctx context.Context, dirPath, id string) (err error) {
+	if st.readOnly {
+		return vterrors.Errorf(vtrpc.Code_READ_ONLY, "topo server connection is read-only")
+	}
+	if dirPath == "error" {
+		return errors.New("dummy error")
+	}
+	return err
+}
+
 // Watch is part of the Conn interface
 func (st *fakeConn) Watch(ctx context.Context, filePath string) (current *WatchData, changes <-chan *WatchData, err error) {
 	return current, changes, err
@@ -353,6 +372,34 @@ func TestStatsConnTopoLock(t *testing.T) {
 	require.Equal(t, int64(1), topoStatsConnErrors.Counts()["Lock.global"])
 }
 
+// TestStatsConnTopoLockHolders emits stats on LockHolders and BreakLock
+func TestStatsConnTopoLockHolders(t *testing.T) {
+	testStatsConnStatsReset()
+	defer testStatsConnStatsReset()
+
+	conn := &fakeConn{}
+	statsConn := NewStatsConn("global", conn, testStatsConnReadSem)
+	ctx := t.Context()
+
+	statsConn.LockHolders(ctx, "")
+	require.Equal(t, int64(1), topoStatsConnTimings.Counts()["LockHolders.global"])
+
+	statsConn.BreakLock(ctx, "", "1")
+	require.Equal(t, int64(1), topoStatsConnTimings.Counts()["BreakLock.global"])
+
+	statsConn.LockHolders(ctx, "error")
+	statsConn.BreakLock(ctx, "error", "1")
+
+	// Error stats gets emitted.
+	require.Equal(t, int64(1), topoStatsConnErrors.Counts()["LockHolders.global"])
+	require.Equal(t, int64(1), topoStatsConnErrors.Counts()["BreakLock.global"])
+
+	// BreakLock is not allowed on a read-only connection.
+	statsConn.SetReadOnly(true)
+	err := statsConn.BreakLock(ctx, "", "1")
+	require.ErrorContains(t, err, "cannot perform BreakLock on  as the topology server connection is read-only")
+}
+
 // TestStatsConnTopoWatch emits stats on Watch
 func TestStatsConnTopoWatch(t *testing.T) {
 	testStatsConnStatsReset()
@@ -49,6 +49,9 @@ func checkLock(t *testing.T, ctx context.Context, ts *topo.Server) {
 
 	t.Log("===      checkLockUnblocks")
 	checkLockUnblocks(ctx, t, conn)
+
+	t.Log("===      checkLockHolders")
+	checkLockHolders(ctx, t, conn)
 }
 
 func checkLockTimeout(ctx context.Context, t *testing.T, conn topo.Conn) {
@@ -161,3 +164,46 @@ func checkLockUnblocks(ctx context.Context, t *testing.T, conn topo.Conn) {
 		t.Fatalf("Unlock(test_keyspace) timed out")
 	}
 }
+
+// checkLockHolders checks we can list the holder of a lock, and break it.
+func checkLockHolders(ctx context.Context, t *testing.T, conn topo.Conn) {
+	keyspacePath := path.Join(topo.KeyspacesPath, "test_keyspace")
+	holders, err := conn.LockHolders(ctx, keyspacePath)
+	if err != nil {
+		t.Fatalf("LockHolders: %v", err)
+	}
+	if len(holders) != 0 {
+		t.Fatalf("LockHolders returned %v for an unlocked keyspace", holders)
+	}
+
+	lockDescriptor, err := conn.Lock(ctx, keyspacePath, "holder")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	holders, err = conn.LockHolders(ctx, keyspacePath)
+	if err != nil {
+		t.Fatalf("LockHolders: %v", err)
+	}
+	if len(holders) != 1 || string(holders[0].Contents) != "holder" {
+		t.Fatalf("LockHolders returned %v, expected the holder", holders)
+	}
+
+	if err := conn.BreakLock(ctx, keyspacePath, holders[0].ID+"0"); !topo.IsErrType(err, topo.NoNode) {
+		t.Fatalf("BreakLock(unknown id): %v", err)
+	}
+	if err := conn.BreakLock(ctx, keyspacePath, holders[0].ID); err != nil {
+		t.Fatalf("BreakLock: %v", err)
+	}
+	holders, err = conn.LockHolders(ctx, keyspacePath)
+	if err != nil {
+		t.Fatalf("LockHolders: %v", err)
+	}
+	if len(holders) != 0 {
+		t.Fatalf("LockHolders returned %v for a broken lock", holders)
+	}
+
+	// Depending on the implementation, the former holder may or may not
+	// get an error releasing the lock that is gone, but it still has to
+	// clean up after itself.
+	_ = lockDescriptor.Unlock(ctx)
+}
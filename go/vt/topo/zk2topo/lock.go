@@ -18,8 +18,10 @@ package zk2topo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"sort"
 	"time"
 
 	"github.com/z-division/go-zookeeper/zk"
@@ -160,3 +162,44 @@ func (ld *zkLockDescriptor) Check(ctx context.Context) error {
 func (ld *zkLockDescriptor) Unlock(ctx context.Context) error {
 	return ld.zs.Delete(ctx, ld.nodePath, nil)
 }
+
+// LockHolders is part of the topo.Conn interface.
+func (zs *Server) LockHolders(ctx context.Context, dirPath string) ([]topo.LockHolder, error) {
+	locksDir := path.Join(zs.root, dirPath, locksPath)
+	children, _, err := zs.conn.Children(ctx, locksDir)
+	if err != nil {
+		if errors.Is(err, zk.ErrNoNode) {
+			return nil, nil
+		}
+		return nil, convertError(err, locksDir)
+	}
+
+	// The lock nodes are sequential, so their names sort in the order
+	// they will get the lock.
+	sort.Strings(children)
+	holders := make([]topo.LockHolder, 0, len(children))
+	for _, child := range children {
+		data, _, err := zs.conn.Get(ctx, path.Join(locksDir, child))
+		if err != nil {
+			if errors.Is(err, zk.ErrNoNode) {
+				// The lock was released since we listed it.
+				continue
+			}
+			return nil, convertError(err, path.Join(locksDir, child))
+		}
+		holders = append(holders, topo.LockHolder{
+			ID:       child,
+			Contents: data,
+		})
+	}
+	return holders, nil
+}
+
+// BreakLock is part of the topo.Conn interface.
+func (zs *Server) BreakLock(ctx context.Context, dirPath, id string) error {
+	nodePath := path.Join(zs.root, dirPath, locksPath, id)
+	if err := zs.conn.Delete(ctx, nodePath, -1); err != nil {
+		return convertError(err, nodePath)
+	}
+	return nil
+}
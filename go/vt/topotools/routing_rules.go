@@ -20,29 +20,78 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
 
 // region routing rules
 
-func GetRoutingRulesMap(rules *vschemapb.RoutingRules) map[string][]string {
+// RoutingRules maps a from_table to its routing rules. A table has more than
+// one rule when its rules are scheduled with windows that don't overlap.
+type RoutingRules map[string][]*vschemapb.RoutingRule
+
+// NewRoutingRules returns the unscheduled rules routing each from_table of
+// rules to its to_tables.
+func NewRoutingRules(rules map[string][]string) RoutingRules {
+	rrs := make(RoutingRules, len(rules))
+	for from, to := range rules {
+		rrs.Set(from, to...)
+	}
+	return rrs
+}
+
+// Set replaces the rules for fromTable, scheduled or not, with a single
+// unscheduled rule routing it to toTables.
+func (rrs RoutingRules) Set(fromTable string, toTables ...string) {
+	rrs[fromTable] = []*vschemapb.RoutingRule{{
+		FromTable: fromTable,
+		ToTables:  toTables,
+	}}
+}
+
+// ToTables returns the to_tables of the rule for fromTable that is in effect
+// at now, or nil if there is none.
+func (rrs RoutingRules) ToTables(fromTable string, now time.Time) []string {
+	for _, rr := range rrs[fromTable] {
+		if vindexes.RoutingRuleActive(rr, now) {
+			return rr.ToTables
+		}
+	}
+	return nil
+}
+
+// ToTablesMap returns a mapping of fromTable=>[]toTables for the rules that
+// are in effect at now.
+func (rrs RoutingRules) ToTablesMap(now time.Time) map[string][]string {
+	rulesMap := make(map[string][]string, len(rrs))
+	for from := range rrs {
+		if to := rrs.ToTables(from, now); to != nil {
+			rulesMap[from] = to
+		}
+	}
+	return rulesMap
+}
+
+// GetRoutingRulesMap groups the rules by from_table.
+func GetRoutingRulesMap(rules *vschemapb.RoutingRules) RoutingRules {
 	if rules == nil {
 		return nil
 	}
-	rulesMap := make(map[string][]string, len(rules.Rules))
+	rulesMap := make(RoutingRules, len(rules.Rules))
 	for _, rr := range rules.Rules {
-		rulesMap[rr.FromTable] = rr.ToTables
+		rulesMap[rr.FromTable] = append(rulesMap[rr.FromTable], rr)
 	}
 	return rulesMap
 }
 
-// GetRoutingRules fetches routing rules from the topology server and returns a
-// mapping of fromTable=>[]toTables.
-func GetRoutingRules(ctx context.Context, ts *topo.Server) (map[string][]string, error) {
+// GetRoutingRules fetches routing rules from the topology server and returns
+// them grouped by from_table.
+func GetRoutingRules(ctx context.Context, ts *topo.Server) (RoutingRules, error) {
 	rrs, err := ts.GetRoutingRules(ctx)
 	if err != nil {
 		return nil, err
@@ -53,18 +102,14 @@ func GetRoutingRules(ctx context.Context, ts *topo.Server) (map[string][]string,
 	return rules, nil
 }
 
-// SaveRoutingRules converts a mapping of fromTable=>[]toTables into a
-// vschemapb.RoutingRules protobuf message and saves it in the topology.
-func SaveRoutingRules(ctx context.Context, ts *topo.Server, rules map[string][]string) error {
-	log.Info(fmt.Sprintf("Saving routing rules %v\n", rules))
-
+// SaveRoutingRules saves rules in the topology as a vschemapb.RoutingRules
+// protobuf message. The schedule of the rules is kept.
+func SaveRoutingRules(ctx context.Context, ts *topo.Server, rules RoutingRules) error {
 	rrs := &vschemapb.RoutingRules{Rules: make([]*vschemapb.RoutingRule, 0, len(rules))}
-	for from, to := range rules {
-		rrs.Rules = append(rrs.Rules, &vschemapb.RoutingRule{
-			FromTable: from,
-			ToTables:  to,
-		})
+	for _, rr := range rules {
+		rrs.Rules = append(rrs.Rules, rr...)
 	}
+	log.Info(fmt.Sprintf("Saving routing rules %v\n", rrs))
 
 	return ts.SaveRoutingRules(ctx, rrs)
 }
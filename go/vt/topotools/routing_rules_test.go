@@ -21,12 +21,17 @@ import (
 	"errors"
 	"maps"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
 
 func TestRoutingRulesRoundTrip(t *testing.T) {
@@ -39,13 +44,48 @@ func TestRoutingRulesRoundTrip(t *testing.T) {
 		"t4": {"t5"},
 	}
 
-	err := SaveRoutingRules(ctx, ts, rules)
+	err := SaveRoutingRules(ctx, ts, NewRoutingRules(rules))
 	require.NoError(t, err, "could not save routing rules to topo %v", rules)
 
 	roundtripRules, err := GetRoutingRules(ctx, ts)
 	require.NoError(t, err, "could not fetch routing rules from topo")
 
-	assert.Equal(t, rules, roundtripRules)
+	assert.Equal(t, rules, roundtripRules.ToTablesMap(time.Now()))
+}
+
+func TestScheduledRoutingRulesRoundTrip(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	now := time.Now().Truncate(time.Second)
+	switchAt := protoutil.TimeToProto(now.Add(time.Hour))
+	rules := NewRoutingRules(map[string][]string{"t1": {"ks1.t1"}})
+	rules["t2"] = []*vschemapb.RoutingRule{
+		{FromTable: "t2", ToTables: []string{"ks1.t2"}, ActiveUntil: switchAt},
+		{FromTable: "t2", ToTables: []string{"ks2.t2"}, ActiveFrom: switchAt},
+	}
+	require.NoError(t, SaveRoutingRules(ctx, ts, rules))
+
+	roundtripRules, err := GetRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	require.Len(t, roundtripRules["t2"], 2)
+	for i, rr := range roundtripRules["t2"] {
+		assert.True(t, proto.Equal(rules["t2"][i], rr), "got %v, want %v", rr, rules["t2"][i])
+	}
+
+	assert.Equal(t, []string{"ks1.t2"}, roundtripRules.ToTables("t2", now))
+	assert.Equal(t, []string{"ks2.t2"}, roundtripRules.ToTables("t2", now.Add(time.Hour)))
+	assert.Nil(t, roundtripRules.ToTables("t3", now))
+	assert.Equal(t, map[string][]string{
+		"t1": {"ks1.t1"},
+		"t2": {"ks1.t2"},
+	}, roundtripRules.ToTablesMap(now))
+
+	// Set replaces the scheduled rules of a table.
+	roundtripRules.Set("t2", "ks3.t2")
+	assert.Equal(t, []string{"ks3.t2"}, roundtripRules.ToTables("t2", now.Add(time.Hour)))
+	assert.Len(t, roundtripRules["t2"], 1)
 }
 
 func TestRoutingRulesErrors(t *testing.T) {
@@ -65,7 +105,7 @@ func TestRoutingRulesErrors(t *testing.T) {
 			"t4": {"t5"},
 		}
 
-		err := SaveRoutingRules(ctx, ts, rules)
+		err := SaveRoutingRules(ctx, ts, NewRoutingRules(rules))
 		assert.Error(t, err, "expected error from GetRoutingRules, got rules=%v", rules)
 	})
 }
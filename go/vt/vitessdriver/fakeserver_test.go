@@ -182,6 +182,18 @@ func (f *fakeVTGateService) BinlogDumpGTID(ctx context.Context, req *vtgatepb.Bi
 	return nil
 }
 
+func (f *fakeVTGateService) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeVTGateService) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeVTGateService) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	return nil
+}
+
 // ExecuteMulti is part of the VTGateService interface
 func (f *fakeVTGateService) ExecuteMulti(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, session *vtgatepb.Session, sqlString string) (newSession *vtgatepb.Session, qrs []*sqltypes.Result, err error) {
 	queries, err := sqlparser.NewTestParser().SplitStatementToPieces(sqlString)
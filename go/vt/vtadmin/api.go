@@ -371,6 +371,7 @@ func (api *API) Handler() http.Handler {
 
 	httpAPI := vtadminhttp.NewAPI(api, api.options.HTTPOpts)
 
+	router.HandleFunc("/backup_freshness", httpAPI.Adapt(vtadminhttp.GetBackupFreshness)).Name("API.GetBackupFreshness")
 	router.HandleFunc("/backups", httpAPI.Adapt(vtadminhttp.GetBackups)).Name("API.GetBackups")
 	router.HandleFunc("/cells", httpAPI.Adapt(vtadminhttp.GetCellInfos)).Name("API.GetCellInfos")
 	router.HandleFunc("/cells_aliases", httpAPI.Adapt(vtadminhttp.GetCellsAliases)).Name("API.GetCellsAliases")
@@ -789,6 +790,58 @@ func (api *API) FindSchema(ctx context.Context, req *vtadminpb.FindSchemaRequest
 	}
 }
 
+// GetBackupFreshness is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetBackupFreshness(ctx context.Context, req *vtadminpb.GetBackupFreshnessRequest) (*vtadminpb.GetBackupFreshnessResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetBackupFreshness")
+	defer span.Finish()
+
+	clusters, _ := api.getClustersForRequest(req.ClusterIds)
+
+	var (
+		m          sync.Mutex
+		wg         sync.WaitGroup
+		rec        concurrency.AllErrorRecorder
+		violations []*vtadminpb.ClusterShardBackupFreshness
+	)
+
+	if req.RequestOptions == nil {
+		req.RequestOptions = &vtctldatapb.GetBackupFreshnessRequest{}
+	}
+
+	for _, c := range clusters {
+		if !api.authz.IsAuthorized(ctx, c.ID, rbac.BackupResource, rbac.GetAction) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(c *cluster.Cluster) {
+			defer wg.Done()
+
+			cvs, err := c.GetBackupFreshness(ctx, req.RequestOptions)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			violations = append(violations, cvs...)
+		}(c)
+	}
+
+	wg.Wait()
+
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return &vtadminpb.GetBackupFreshnessResponse{
+		Violations: violations,
+	}, nil
+}
+
 // GetBackups is part of the vtadminpb.VTAdminServer interface.
 func (api *API) GetBackups(ctx context.Context, req *vtadminpb.GetBackupsRequest) (*vtadminpb.GetBackupsResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.GetBackups")
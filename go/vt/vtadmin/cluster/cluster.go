@@ -844,6 +844,37 @@ func (c *Cluster) findWorkflows(ctx context.Context, keyspaces []string, opts Fi
 	}, nil
 }
 
+// GetBackupFreshness returns the shards in the cluster whose last successful
+// backup is older than the requested freshness objective.
+func (c *Cluster) GetBackupFreshness(ctx context.Context, req *vtctldatapb.GetBackupFreshnessRequest) ([]*vtadminpb.ClusterShardBackupFreshness, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetBackupFreshness")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+	span.Annotate("keyspaces", strings.Join(req.Keyspaces, ","))
+
+	if err := c.topoReadPool.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("GetBackupFreshness() failed to acquire topoReadPool: %w", err)
+	}
+	defer c.topoReadPool.Release()
+
+	resp, err := c.Vtctld.GetBackupFreshness(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("GetBackupFreshness(cluster = %s): %w", c.ID, err)
+	}
+
+	clusterProto := c.ToProto()
+	violations := make([]*vtadminpb.ClusterShardBackupFreshness, len(resp.Violations))
+	for i, freshness := range resp.Violations {
+		violations[i] = &vtadminpb.ClusterShardBackupFreshness{
+			Cluster:   clusterProto,
+			Freshness: freshness,
+		}
+	}
+
+	return violations, nil
+}
+
 // GetBackups returns a ClusterBackups object for all backups in the cluster.
 func (c *Cluster) GetBackups(ctx context.Context, req *vtadminpb.GetBackupsRequest) ([]*vtadminpb.ClusterBackup, error) {
 	span, ctx := trace.NewSpan(ctx, "Cluster.GetBackups")
@@ -1493,6 +1493,101 @@ func TestGetCellInfos(t *testing.T) {
 	}
 }
 
+func TestGetBackupFreshness(t *testing.T) {
+	t.Parallel()
+
+	cpb := &vtadminpb.Cluster{
+		Id:   "test",
+		Name: "test",
+	}
+
+	tests := []struct {
+		name      string
+		vtctld    *fakevtctldclient.VtctldClient
+		expected  []*vtadminpb.ClusterShardBackupFreshness
+		shouldErr bool
+	}{
+		{
+			name: "ok",
+			vtctld: &fakevtctldclient.VtctldClient{
+				GetBackupFreshnessResults: &struct {
+					Response *vtctldatapb.GetBackupFreshnessResponse
+					Error    error
+				}{
+					Response: &vtctldatapb.GetBackupFreshnessResponse{
+						Violations: []*vtctldatapb.ShardBackupFreshness{
+							{
+								Keyspace: "ks",
+								Shard:    "-80",
+								LastBackup: &topodatapb.Shard_BackupStatus{
+									Name:      "backup1",
+									SizeBytes: 100,
+								},
+							},
+							{
+								Keyspace: "ks",
+								Shard:    "80-",
+							},
+						},
+					},
+				},
+			},
+			expected: []*vtadminpb.ClusterShardBackupFreshness{
+				{
+					Cluster: cpb,
+					Freshness: &vtctldatapb.ShardBackupFreshness{
+						Keyspace: "ks",
+						Shard:    "-80",
+						LastBackup: &topodatapb.Shard_BackupStatus{
+							Name:      "backup1",
+							SizeBytes: 100,
+						},
+					},
+				},
+				{
+					Cluster: cpb,
+					Freshness: &vtctldatapb.ShardBackupFreshness{
+						Keyspace: "ks",
+						Shard:    "80-",
+					},
+				},
+			},
+		},
+		{
+			name: "error",
+			vtctld: &fakevtctldclient.VtctldClient{
+				GetBackupFreshnessResults: &struct {
+					Response *vtctldatapb.GetBackupFreshnessResponse
+					Error    error
+				}{
+					Error: errors.New("this should fail"),
+				},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.BuildCluster(t, testutil.TestClusterConfig{
+				Cluster:      cpb,
+				VtctldClient: tt.vtctld,
+			})
+			defer c.Close()
+			violations, err := c.GetBackupFreshness(t.Context(), &vtctldatapb.GetBackupFreshnessRequest{})
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, violations)
+		})
+	}
+}
+
 func TestGetCellsAliases(t *testing.T) {
 	t.Parallel()
 
@@ -18,13 +18,39 @@ package http
 
 import (
 	"context"
+	"time"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/concurrency"
 
 	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
+// GetBackupFreshness implements the http wrapper for /backup_freshness.
+// Query params:
+//   - cluster_id: repeated, cluster ID
+//   - keyspace: repeated, keyspace names
+//   - max_age: the backup freshness objective, as a duration (default 24h)
+func GetBackupFreshness(ctx context.Context, r Request, api *API) *JSONResponse {
+	query := r.URL.Query()
+
+	maxAge, err := r.ParseQueryParamAsDuration("max_age", 24*time.Hour)
+	if err != nil {
+		return NewJSONResponse(nil, err)
+	}
+
+	violations, err := api.server.GetBackupFreshness(ctx, &vtadminpb.GetBackupFreshnessRequest{
+		ClusterIds: query["cluster_id"],
+		RequestOptions: &vtctldatapb.GetBackupFreshnessRequest{
+			Keyspaces: query["keyspace"],
+			MaxAge:    protoutil.DurationToProto(maxAge),
+		},
+	})
+
+	return NewJSONResponse(violations, err)
+}
+
 // GetBackups implements the http wrapper for /backups[?cluster_id=[&cluster_id=]].
 func GetBackups(ctx context.Context, r Request, api *API) *JSONResponse {
 	query := r.URL.Query()
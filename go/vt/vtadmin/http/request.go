@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -115,6 +116,25 @@ func (r Request) ParseQueryParamAsInt64(name string, defaultVal int64) (int64, e
 	return defaultVal, nil
 }
 
+// ParseQueryParamAsDuration attempts to parse the query parameter of the given
+// name into a time.Duration value. If the parameter is not set, the provided
+// default value is returned.
+func (r Request) ParseQueryParamAsDuration(name string, defaultVal time.Duration) (time.Duration, error) {
+	if param := r.URL.Query().Get(name); param != "" {
+		val, err := time.ParseDuration(param)
+		if err != nil {
+			return defaultVal, &errors.BadRequest{
+				Err:        err,
+				ErrDetails: fmt.Sprintf("could not parse query parameter %s (= %v) into duration value", name, param),
+			}
+		}
+
+		return val, nil
+	}
+
+	return defaultVal, nil
+}
+
 // Vars is a mapping of the route variable values in a given request.
 //
 // See (gorilla/mux).Vars for details. We define a type here to add some
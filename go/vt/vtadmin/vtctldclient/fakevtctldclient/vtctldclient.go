@@ -69,6 +69,10 @@ type VtctldClient struct {
 		Response *vtctldatapb.FindAllShardsInKeyspaceResponse
 		Error    error
 	}
+	GetBackupFreshnessResults *struct {
+		Response *vtctldatapb.GetBackupFreshnessResponse
+		Error    error
+	}
 	GetBackupsResults map[string]struct {
 		Response *vtctldatapb.GetBackupsResponse
 		Error    error
@@ -360,6 +364,15 @@ func (fake *VtctldClient) FindAllShardsInKeyspace(ctx context.Context, req *vtct
 	return nil, fmt.Errorf("%w: no result set for keyspace %s", assert.AnError, req.Keyspace)
 }
 
+// GetBackupFreshness is part of the vtctldclient.VtctldClient interface.
+func (fake *VtctldClient) GetBackupFreshness(ctx context.Context, req *vtctldatapb.GetBackupFreshnessRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupFreshnessResponse, error) {
+	if fake.GetBackupFreshnessResults == nil {
+		return nil, fmt.Errorf("%w: GetBackupFreshnessResults not set on fake vtctldclient", assert.AnError)
+	}
+
+	return fake.GetBackupFreshnessResults.Response, fake.GetBackupFreshnessResults.Error
+}
+
 // GetBackups is part of the vtctldclient.VtctldClient interface.
 func (fake *VtctldClient) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	if fake.GetBackupsResults == nil {
@@ -620,6 +620,18 @@ func (itc *internalTabletConn) MessageAck(ctx context.Context, target *querypb.T
 	return count, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
 }
 
+// MessageAckBatch is part of queryservice.QueryService
+func (itc *internalTabletConn) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
+	count, err := itc.tablet.qsc.QueryService().MessageAckBatch(ctx, target, name, ids)
+	return count, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
+}
+
+// MessagePostpone is part of queryservice.QueryService
+func (itc *internalTabletConn) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
+	count, err := itc.tablet.qsc.QueryService().MessagePostpone(ctx, target, name, ids)
+	return count, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
+}
+
 // HandlePanic is part of the QueryService interface.
 func (itc *internalTabletConn) HandlePanic(err *error) {
 }
@@ -809,6 +821,14 @@ func (itmc *internalTabletManagerClient) GetSchema(
 	return t.tm.GetSchema(ctx, request)
 }
 
+func (itmc *internalTabletManagerClient) GetSchemaSnapshot(ctx context.Context, tablet *topodatapb.Tablet, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	t, ok := tabletMap[tablet.Alias.Uid]
+	if !ok {
+		return nil, fmt.Errorf("tmclient: cannot find tablet %v", tablet.Alias.Uid)
+	}
+	return t.tm.GetSchemaSnapshot(ctx, &tabletmanagerdatapb.GetSchemaSnapshotRequest{SinceVersion: sinceVersion})
+}
+
 func (itmc *internalTabletManagerClient) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
 	t, ok := tabletMap[tablet.Alias.Uid]
 	if !ok {
@@ -1064,6 +1084,10 @@ func (itmc *internalTabletManagerClient) RestoreFromBackup(context.Context, *top
 	return nil, errors.New("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) CloneFromTablet(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.CloneFromTabletRequest) (logutil.EventStream, error) {
+	return nil, errors.New("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) CheckThrottler(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	return nil, errors.New("not implemented in vtcombo")
 }
@@ -1119,6 +1143,10 @@ func (itmc *internalTabletManagerClient) ResetReplicationParameters(context.Cont
 	return errors.New("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) PurgeBinaryLogs(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	return nil, errors.New("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) ReplicaWasRestarted(context.Context, *topodatapb.Tablet, *topodatapb.TabletAlias) error {
 	return errors.New("not implemented in vtcombo")
 }
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// Change is a change that Apply makes to the cluster.
+type Change struct {
+	// Description describes the change to the operator.
+	Description string
+
+	apply func(ctx context.Context, client vtctldclient.VtctldClient) error
+	// undo reverts the change when a later change fails. It is nil if the
+	// change does not need to be reverted.
+	undo func(ctx context.Context, client vtctldclient.VtctldClient) error
+}
+
+// Plan is the list of changes that make a cluster match a configuration.
+type Plan struct {
+	Changes []*Change
+	// Unmanaged lists the keyspaces and shards of the cluster that are not
+	// in the configuration. Apply leaves them as they are.
+	Unmanaged []string
+}
+
+// NewPlan returns the changes that make the cluster match cfg. It returns an
+// error if cfg cannot be applied, e.g. because a vschema or routing rule of
+// the resulting configuration is invalid.
+func NewPlan(ctx context.Context, client vtctldclient.VtctldClient, cfg *Config, parser *sqlparser.Parser) (*Plan, error) {
+	current, err := Export(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(current, cfg, parser); err != nil {
+		return nil, err
+	}
+
+	currentKeyspaces := make(map[string]*Keyspace, len(current.Keyspaces))
+	for _, ks := range current.Keyspaces {
+		currentKeyspaces[ks.Name] = ks
+	}
+
+	// Keyspaces are created first, as creating a keyspace rebuilds the
+	// SrvVSchema. All the other changes skip the rebuild, so that they are
+	// only served once they are all applied.
+	var creates, updates, shards, vschemas, rules []*Change
+	plan := &Plan{}
+	for _, ks := range cfg.Keyspaces {
+		cur, ok := currentKeyspaces[ks.Name]
+		delete(currentKeyspaces, ks.Name)
+		if !ok {
+			creates = append(creates, createKeyspace(ks))
+			cur = &Keyspace{Name: ks.Name, DurabilityPolicy: ks.DurabilityPolicy, SidecarDBName: ks.SidecarDBName}
+		}
+
+		if ks.SidecarDBName != "" && ks.SidecarDBName != cur.SidecarDBName {
+			return nil, fmt.Errorf("cannot change the sidecar database name of keyspace %s from %s to %s", ks.Name, cur.SidecarDBName, ks.SidecarDBName)
+		}
+		if ks.DurabilityPolicy != "" && ks.DurabilityPolicy != cur.DurabilityPolicy {
+			updates = append(updates, setDurabilityPolicy(ks.Name, cur.DurabilityPolicy, ks.DurabilityPolicy))
+		}
+
+		for _, shard := range ks.Shards {
+			if !slices.Contains(cur.Shards, shard) {
+				shards = append(shards, createShard(ks.Name, shard))
+			}
+		}
+		for _, shard := range cur.Shards {
+			if !slices.Contains(ks.Shards, shard) {
+				plan.Unmanaged = append(plan.Unmanaged, fmt.Sprintf("shard %s/%s", ks.Name, shard))
+			}
+		}
+
+		if ks.VSchema != nil && !proto.Equal(ks.VSchema, cur.VSchema) {
+			vschemas = append(vschemas, applyVSchema(ks.Name, cur.VSchema, ks.VSchema))
+		}
+	}
+	for _, ks := range current.Keyspaces {
+		if _, ok := currentKeyspaces[ks.Name]; ok {
+			plan.Unmanaged = append(plan.Unmanaged, "keyspace "+ks.Name)
+		}
+	}
+
+	if cfg.RoutingRules != nil && !proto.Equal(cfg.RoutingRules, current.RoutingRules) {
+		rules = append(rules, &Change{
+			Description: "apply routing rules",
+			apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyRoutingRules(ctx, &vtctldatapb.ApplyRoutingRulesRequest{RoutingRules: cfg.RoutingRules, SkipRebuild: true})
+				return err
+			},
+			undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyRoutingRules(ctx, &vtctldatapb.ApplyRoutingRulesRequest{RoutingRules: current.RoutingRules, SkipRebuild: true})
+				return err
+			},
+		})
+	}
+	if cfg.ShardRoutingRules != nil && !proto.Equal(cfg.ShardRoutingRules, current.ShardRoutingRules) {
+		rules = append(rules, &Change{
+			Description: "apply shard routing rules",
+			apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyShardRoutingRules(ctx, &vtctldatapb.ApplyShardRoutingRulesRequest{ShardRoutingRules: cfg.ShardRoutingRules, SkipRebuild: true})
+				return err
+			},
+			undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyShardRoutingRules(ctx, &vtctldatapb.ApplyShardRoutingRulesRequest{ShardRoutingRules: current.ShardRoutingRules, SkipRebuild: true})
+				return err
+			},
+		})
+	}
+	if cfg.KeyspaceRoutingRules != nil && !proto.Equal(cfg.KeyspaceRoutingRules, current.KeyspaceRoutingRules) {
+		rules = append(rules, &Change{
+			Description: "apply keyspace routing rules",
+			apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyKeyspaceRoutingRules(ctx, &vtctldatapb.ApplyKeyspaceRoutingRulesRequest{KeyspaceRoutingRules: cfg.KeyspaceRoutingRules, SkipRebuild: true})
+				return err
+			},
+			undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.ApplyKeyspaceRoutingRules(ctx, &vtctldatapb.ApplyKeyspaceRoutingRulesRequest{KeyspaceRoutingRules: current.KeyspaceRoutingRules, SkipRebuild: true})
+				return err
+			},
+		})
+	}
+
+	plan.Changes = slices.Concat(creates, updates, shards, vschemas, rules)
+	if len(plan.Changes) > 0 {
+		plan.Changes = append(plan.Changes, &Change{
+			Description: "rebuild the VSchema graph",
+			apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+				_, err := client.RebuildVSchemaGraph(ctx, &vtctldatapb.RebuildVSchemaGraphRequest{})
+				return err
+			},
+		})
+	}
+	return plan, nil
+}
+
+// Apply applies the changes of plan in order. If a change fails, the changes
+// applied before it are reverted in reverse order, so that the cluster is
+// left as it was.
+func Apply(ctx context.Context, client vtctldclient.VtctldClient, plan *Plan) error {
+	for i, change := range plan.Changes {
+		err := change.apply(ctx, client)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("failed to %s: %w", change.Description, err)
+		var undoErrs []error
+		for _, applied := range slices.Backward(plan.Changes[:i]) {
+			if applied.undo == nil {
+				continue
+			}
+			if undoErr := applied.undo(ctx, client); undoErr != nil {
+				undoErrs = append(undoErrs, fmt.Errorf("failed to revert %q: %w", applied.Description, undoErr))
+			}
+		}
+		if len(undoErrs) > 0 {
+			return errors.Join(append([]error{err}, undoErrs...)...)
+		}
+		return fmt.Errorf("%w; the previous changes were reverted", err)
+	}
+	return nil
+}
+
+func createKeyspace(ks *Keyspace) *Change {
+	return &Change{
+		Description: "create keyspace " + ks.Name,
+		apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.CreateKeyspace(ctx, &vtctldatapb.CreateKeyspaceRequest{
+				Name:             ks.Name,
+				DurabilityPolicy: ks.DurabilityPolicy,
+				SidecarDbName:    ks.SidecarDBName,
+			})
+			return err
+		},
+		undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{Keyspace: ks.Name, Recursive: true})
+			return err
+		},
+	}
+}
+
+func setDurabilityPolicy(keyspace, from, to string) *Change {
+	return &Change{
+		Description: fmt.Sprintf("set the durability policy of keyspace %s to %s", keyspace, to),
+		apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.SetKeyspaceDurabilityPolicy(ctx, &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{Keyspace: keyspace, DurabilityPolicy: to})
+			return err
+		},
+		undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.SetKeyspaceDurabilityPolicy(ctx, &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{Keyspace: keyspace, DurabilityPolicy: from})
+			return err
+		},
+	}
+}
+
+func createShard(keyspace, shard string) *Change {
+	return &Change{
+		Description: fmt.Sprintf("create shard %s/%s", keyspace, shard),
+		apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.CreateShard(ctx, &vtctldatapb.CreateShardRequest{Keyspace: keyspace, ShardName: shard})
+			return err
+		},
+		undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.DeleteShards(ctx, &vtctldatapb.DeleteShardsRequest{
+				Shards:    []*vtctldatapb.Shard{{Keyspace: keyspace, Name: shard}},
+				Recursive: true,
+			})
+			return err
+		},
+	}
+}
+
+func applyVSchema(keyspace string, from, to *vschemapb.Keyspace) *Change {
+	if from == nil {
+		from = &vschemapb.Keyspace{}
+	}
+	return &Change{
+		Description: "apply the vschema of keyspace " + keyspace,
+		apply: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{Keyspace: keyspace, VSchema: to, SkipRebuild: true})
+			return err
+		},
+		undo: func(ctx context.Context, client vtctldclient.VtctldClient) error {
+			_, err := client.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{Keyspace: keyspace, VSchema: from, SkipRebuild: true})
+			return err
+		},
+	}
+}
+
+// validate checks that the vschemas and routing rules of the cluster are
+// valid once cfg is applied to it.
+func validate(current *Config, cfg *Config, parser *sqlparser.Parser) error {
+	srvVSchema := &vschemapb.SrvVSchema{
+		Keyspaces:            make(map[string]*vschemapb.Keyspace),
+		RoutingRules:         current.RoutingRules,
+		ShardRoutingRules:    current.ShardRoutingRules,
+		KeyspaceRoutingRules: current.KeyspaceRoutingRules,
+	}
+	for _, ks := range current.Keyspaces {
+		srvVSchema.Keyspaces[ks.Name] = ks.VSchema
+		if ks.VSchema == nil {
+			srvVSchema.Keyspaces[ks.Name] = &vschemapb.Keyspace{}
+		}
+	}
+	for _, ks := range cfg.Keyspaces {
+		switch {
+		case ks.VSchema != nil:
+			srvVSchema.Keyspaces[ks.Name] = ks.VSchema
+		case srvVSchema.Keyspaces[ks.Name] == nil:
+			srvVSchema.Keyspaces[ks.Name] = &vschemapb.Keyspace{}
+		}
+	}
+	if cfg.RoutingRules != nil {
+		srvVSchema.RoutingRules = cfg.RoutingRules
+	}
+	if cfg.ShardRoutingRules != nil {
+		srvVSchema.ShardRoutingRules = cfg.ShardRoutingRules
+	}
+	if cfg.KeyspaceRoutingRules != nil {
+		srvVSchema.KeyspaceRoutingRules = cfg.KeyspaceRoutingRules
+	}
+
+	vschema := vindexes.BuildVSchema(srvVSchema, parser)
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(vschema.Keyspaces)) {
+		if err := vschema.Keyspaces[name].Error; err != nil {
+			errs = append(errs, fmt.Errorf("invalid vschema for keyspace %s: %w", name, err))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(vschema.RoutingRules)) {
+		if err := vschema.RoutingRules[name].Error; err != nil {
+			errs = append(errs, fmt.Errorf("invalid routing rule for %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String describes the plan to the operator.
+func (plan *Plan) String() string {
+	var buf strings.Builder
+	if len(plan.Changes) == 0 {
+		buf.WriteString("No changes.\n")
+	}
+	for _, change := range plan.Changes {
+		fmt.Fprintf(&buf, "- %s\n", change.Description)
+	}
+	for _, unmanaged := range plan.Unmanaged {
+		fmt.Fprintf(&buf, "Not in the configuration, left as is: %s\n", unmanaged)
+	}
+	return buf.String()
+}
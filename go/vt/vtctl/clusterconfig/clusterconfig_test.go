@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+	"vitess.io/vitess/go/vt/vtctl/localvtctldclient"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func newTestClient(t *testing.T) (*topo.Server, vtctldclient.VtctldClient) {
+	ts := memorytopo.NewServer(t.Context(), "zone1")
+	t.Cleanup(ts.Close)
+	return ts, localvtctldclient.New(grpcvtctldserver.NewTestVtctldServer(ts, &testutil.TabletManagerClient{}))
+}
+
+// failingClient fails to apply routing rules.
+type failingClient struct {
+	vtctldclient.VtctldClient
+}
+
+func (c *failingClient) ApplyRoutingRules(context.Context, *vtctldatapb.ApplyRoutingRulesRequest, ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
+	return nil, errors.New("routing rules are locked")
+}
+
+func testConfig() *Config {
+	return &Config{
+		Keyspaces: []*Keyspace{{
+			Name:             "commerce",
+			DurabilityPolicy: "semi_sync",
+			Shards:           []string{"0"},
+			VSchema: &vschemapb.Keyspace{
+				Tables: map[string]*vschemapb.Table{"product": {}},
+			},
+		}, {
+			Name:          "customer",
+			SidecarDBName: "_vt_customer",
+			Shards:        []string{"-80", "80-"},
+			VSchema: &vschemapb.Keyspace{
+				Sharded:  true,
+				Vindexes: map[string]*vschemapb.Vindex{"hash": {Type: "hash"}},
+				Tables: map[string]*vschemapb.Table{
+					"customer": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "customer_id", Name: "hash"}}},
+				},
+			},
+		}},
+		RoutingRules: &vschemapb.RoutingRules{Rules: []*vschemapb.RoutingRule{{
+			FromTable: "item",
+			ToTables:  []string{"commerce.product"},
+		}}},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
+		KeyspaceRoutingRules: &vschemapb.KeyspaceRoutingRules{},
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	cfg := testConfig()
+	data, err := MarshalYAML(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "durability_policy: semi_sync")
+	assert.Contains(t, string(data), "from_table: item")
+
+	got, err := Unmarshal(data)
+	require.NoError(t, err)
+	utils.MustMatch(t, cfg, got)
+
+	got, err = Unmarshal([]byte(`{"keyspaces": [{"name": "commerce", "shards": ["0"]}]}`))
+	require.NoError(t, err)
+	utils.MustMatch(t, &Config{Keyspaces: []*Keyspace{{Name: "commerce", Shards: []string{"0"}}}}, got)
+
+	_, err = Unmarshal([]byte("keyspaces:\n- name: commerce\n- name: commerce\n"))
+	assert.ErrorContains(t, err, "keyspace commerce is specified more than once")
+	_, err = Unmarshal([]byte("keyspaces:\n- shards: [\"0\"]\n"))
+	assert.ErrorContains(t, err, "keyspace without a name")
+}
+
+func TestMarshalTerraform(t *testing.T) {
+	data, err := MarshalTerraform(testConfig())
+	require.NoError(t, err)
+
+	var doc struct {
+		Resource map[string]map[string]map[string]string `json:"resource"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, map[string]string{
+		"name":            "customer",
+		"sidecar_db_name": "_vt_customer",
+	}, doc.Resource["vitess_keyspace"]["customer"])
+	assert.Equal(t, map[string]string{
+		"keyspace": "${vitess_keyspace.customer.name}",
+		"name":     "-80",
+	}, doc.Resource["vitess_shard"]["customer_min_80"])
+	assert.Contains(t, doc.Resource["vitess_shard"], "customer_80_max")
+	assert.JSONEq(t, `{"tables": {"product": {}}}`, doc.Resource["vitess_vschema"]["commerce"]["vschema"])
+	assert.JSONEq(t, `{"rules": [{"from_table": "item", "to_tables": ["commerce.product"]}]}`, doc.Resource["vitess_routing_rules"]["cluster"]["rules"])
+}
+
+func TestPlanAndApply(t *testing.T) {
+	ctx := t.Context()
+	ts, client := newTestClient(t)
+	parser := sqlparser.NewTestParser()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "unmanaged", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "snapshot", &topodatapb.Keyspace{KeyspaceType: topodatapb.KeyspaceType_SNAPSHOT}))
+
+	cfg := testConfig()
+	plan, err := NewPlan(ctx, client, cfg, parser)
+	require.NoError(t, err)
+	assert.Equal(t, `- create keyspace commerce
+- create keyspace customer
+- create shard commerce/0
+- create shard customer/-80
+- create shard customer/80-
+- apply the vschema of keyspace commerce
+- apply the vschema of keyspace customer
+- apply routing rules
+- rebuild the VSchema graph
+Not in the configuration, left as is: keyspace unmanaged
+`, plan.String())
+	require.NoError(t, Apply(ctx, client, plan))
+
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, cfg.RoutingRules, srvVSchema.RoutingRules)
+	utils.MustMatch(t, cfg.Keyspaces[1].VSchema, srvVSchema.Keyspaces["customer"])
+
+	// The exported configuration matches the applied one, except for the
+	// keyspaces that are not managed.
+	exported, err := Export(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, exported.Keyspaces, 3)
+	assert.Equal(t, "unmanaged", exported.Keyspaces[2].Name)
+	exported.Keyspaces = exported.Keyspaces[:2]
+	utils.MustMatch(t, cfg, exported)
+
+	plan, err = NewPlan(ctx, client, cfg, parser)
+	require.NoError(t, err)
+	assert.Equal(t, "No changes.\nNot in the configuration, left as is: keyspace unmanaged\n", plan.String())
+
+	cfg.Keyspaces[0].DurabilityPolicy = "none"
+	cfg.Keyspaces[0].VSchema = nil
+	cfg.Keyspaces[1].Shards = []string{"-80"}
+	cfg.RoutingRules = nil
+	plan, err = NewPlan(ctx, client, cfg, parser)
+	require.NoError(t, err)
+	assert.Equal(t, `- set the durability policy of keyspace commerce to none
+- rebuild the VSchema graph
+Not in the configuration, left as is: shard customer/80-
+Not in the configuration, left as is: keyspace unmanaged
+`, plan.String())
+	require.NoError(t, Apply(ctx, client, plan))
+	ks, err := ts.GetKeyspace(ctx, "commerce")
+	require.NoError(t, err)
+	assert.Equal(t, "none", ks.DurabilityPolicy)
+
+	cfg.Keyspaces[1].SidecarDBName = "_vt"
+	_, err = NewPlan(ctx, client, cfg, parser)
+	assert.ErrorContains(t, err, "cannot change the sidecar database name of keyspace customer from _vt_customer to _vt")
+}
+
+func TestPlanInvalid(t *testing.T) {
+	_, client := newTestClient(t)
+	cfg := testConfig()
+	cfg.Keyspaces[1].VSchema.Tables["customer"].ColumnVindexes[0].Name = "xxhash"
+	cfg.RoutingRules.Rules[0].ToTables = []string{"missing.product"}
+
+	_, err := NewPlan(t.Context(), client, cfg, sqlparser.NewTestParser())
+	assert.ErrorContains(t, err, "invalid vschema for keyspace customer")
+	assert.ErrorContains(t, err, "vindex xxhash not found for table customer")
+	assert.ErrorContains(t, err, "invalid routing rule for item: VT05003: unknown database 'missing' in vschema")
+}
+
+func TestApplyRollback(t *testing.T) {
+	ctx := t.Context()
+	ts, client := newTestClient(t)
+	parser := sqlparser.NewTestParser()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "commerce", &topodatapb.Keyspace{DurabilityPolicy: "none"}))
+	require.NoError(t, ts.CreateShard(ctx, "commerce", "0"))
+	require.NoError(t, ts.RebuildSrvVSchema(ctx, nil))
+
+	cfg := testConfig()
+	plan, err := NewPlan(ctx, client, cfg, parser)
+	require.NoError(t, err)
+	err = Apply(ctx, &failingClient{client}, plan)
+	assert.EqualError(t, err, "failed to apply routing rules: routing rules are locked; the previous changes were reverted")
+
+	exported, err := Export(ctx, client)
+	require.NoError(t, err)
+	utils.MustMatch(t, &Config{
+		Keyspaces: []*Keyspace{{
+			Name:             "commerce",
+			DurabilityPolicy: "none",
+			Shards:           []string{"0"},
+			VSchema:          &vschemapb.Keyspace{},
+		}},
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
+		KeyspaceRoutingRules: &vschemapb.KeyspaceRoutingRules{},
+	}, exported)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterconfig exports the configuration of a cluster that is kept
+// in the topo, i.e. its keyspaces, shards, vschemas and routing rules, as a
+// declarative document, and applies the changes made to such a document back
+// to the cluster. This allows the configuration to be managed in version
+// control.
+package clusterconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/yaml2"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// Config is the declarative configuration of a cluster.
+type Config struct {
+	Keyspaces []*Keyspace
+	// The routing rules are left as they are by Apply if they are nil, and
+	// replaced otherwise.
+	RoutingRules         *vschemapb.RoutingRules
+	ShardRoutingRules    *vschemapb.ShardRoutingRules
+	KeyspaceRoutingRules *vschemapb.KeyspaceRoutingRules
+}
+
+// Keyspace is the configuration of a keyspace.
+type Keyspace struct {
+	Name string
+	// DurabilityPolicy is left as it is by Apply if it is empty.
+	DurabilityPolicy string
+	// SidecarDBName can only be set when the keyspace is created.
+	SidecarDBName string
+	Shards        []string
+	// VSchema is left as it is by Apply if it is nil.
+	VSchema *vschemapb.Keyspace
+}
+
+// configJSON is the document form of Config, in which the protos are
+// marshaled with protojson.
+type configJSON struct {
+	Keyspaces            []*keyspaceJSON `json:"keyspaces"`
+	RoutingRules         json.RawMessage `json:"routing_rules,omitempty"`
+	ShardRoutingRules    json.RawMessage `json:"shard_routing_rules,omitempty"`
+	KeyspaceRoutingRules json.RawMessage `json:"keyspace_routing_rules,omitempty"`
+}
+
+type keyspaceJSON struct {
+	Name             string          `json:"name"`
+	DurabilityPolicy string          `json:"durability_policy,omitempty"`
+	SidecarDBName    string          `json:"sidecar_db_name,omitempty"`
+	Shards           []string        `json:"shards,omitempty"`
+	VSchema          json.RawMessage `json:"vschema,omitempty"`
+}
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// marshalProto marshals msg with protojson, or returns nil if msg is nil.
+func marshalProto(msg proto.Message) (json.RawMessage, error) {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	return marshalOptions.Marshal(msg)
+}
+
+// unmarshalProto unmarshals data into a new message created by newMsg, or
+// returns nil if data is empty.
+func unmarshalProto[T proto.Message](data json.RawMessage, newMsg func() T) (T, error) {
+	var msg T
+	if len(data) == 0 {
+		return msg, nil
+	}
+	msg = newMsg()
+	if err := json2.UnmarshalPB(data, msg); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+func (cfg *Config) toJSON() (*configJSON, error) {
+	var err error
+	doc := &configJSON{Keyspaces: make([]*keyspaceJSON, 0, len(cfg.Keyspaces))}
+	for _, ks := range cfg.Keyspaces {
+		ksDoc := &keyspaceJSON{
+			Name:             ks.Name,
+			DurabilityPolicy: ks.DurabilityPolicy,
+			SidecarDBName:    ks.SidecarDBName,
+			Shards:           ks.Shards,
+		}
+		if ks.VSchema != nil {
+			if ksDoc.VSchema, err = marshalProto(ks.VSchema); err != nil {
+				return nil, fmt.Errorf("failed to marshal vschema of keyspace %s: %w", ks.Name, err)
+			}
+		}
+		doc.Keyspaces = append(doc.Keyspaces, ksDoc)
+	}
+	if cfg.RoutingRules != nil {
+		if doc.RoutingRules, err = marshalProto(cfg.RoutingRules); err != nil {
+			return nil, fmt.Errorf("failed to marshal routing rules: %w", err)
+		}
+	}
+	if cfg.ShardRoutingRules != nil {
+		if doc.ShardRoutingRules, err = marshalProto(cfg.ShardRoutingRules); err != nil {
+			return nil, fmt.Errorf("failed to marshal shard routing rules: %w", err)
+		}
+	}
+	if cfg.KeyspaceRoutingRules != nil {
+		if doc.KeyspaceRoutingRules, err = marshalProto(cfg.KeyspaceRoutingRules); err != nil {
+			return nil, fmt.Errorf("failed to marshal keyspace routing rules: %w", err)
+		}
+	}
+	return doc, nil
+}
+
+func (doc *configJSON) toConfig() (*Config, error) {
+	var err error
+	cfg := &Config{Keyspaces: make([]*Keyspace, 0, len(doc.Keyspaces))}
+	names := make(map[string]bool, len(doc.Keyspaces))
+	for _, ksDoc := range doc.Keyspaces {
+		if ksDoc.Name == "" {
+			return nil, fmt.Errorf("keyspace without a name")
+		}
+		if names[ksDoc.Name] {
+			return nil, fmt.Errorf("keyspace %s is specified more than once", ksDoc.Name)
+		}
+		names[ksDoc.Name] = true
+		ks := &Keyspace{
+			Name:             ksDoc.Name,
+			DurabilityPolicy: ksDoc.DurabilityPolicy,
+			SidecarDBName:    ksDoc.SidecarDBName,
+			Shards:           ksDoc.Shards,
+		}
+		if ks.VSchema, err = unmarshalProto(ksDoc.VSchema, func() *vschemapb.Keyspace { return &vschemapb.Keyspace{} }); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal vschema of keyspace %s: %w", ks.Name, err)
+		}
+		cfg.Keyspaces = append(cfg.Keyspaces, ks)
+	}
+	if cfg.RoutingRules, err = unmarshalProto(doc.RoutingRules, func() *vschemapb.RoutingRules { return &vschemapb.RoutingRules{} }); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal routing rules: %w", err)
+	}
+	if cfg.ShardRoutingRules, err = unmarshalProto(doc.ShardRoutingRules, func() *vschemapb.ShardRoutingRules { return &vschemapb.ShardRoutingRules{} }); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shard routing rules: %w", err)
+	}
+	if cfg.KeyspaceRoutingRules, err = unmarshalProto(doc.KeyspaceRoutingRules, func() *vschemapb.KeyspaceRoutingRules { return &vschemapb.KeyspaceRoutingRules{} }); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal keyspace routing rules: %w", err)
+	}
+	return cfg, nil
+}
+
+// MarshalYAML marshals cfg to a YAML document.
+func MarshalYAML(cfg *Config) ([]byte, error) {
+	doc, err := cfg.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml2.Marshal(doc)
+}
+
+// Unmarshal unmarshals a document produced by MarshalYAML. Since YAML is a
+// superset of JSON, the document can also be written in JSON.
+func Unmarshal(data []byte) (*Config, error) {
+	doc := &configJSON{}
+	if err := yaml2.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc.toConfig()
+}
+
+// MarshalTerraform marshals cfg to the JSON syntax of Terraform, as the
+// resources of a Vitess provider:
+//
+//   - vitess_keyspace, with the name, durability_policy and sidecar_db_name
+//     of a keyspace.
+//   - vitess_shard, with the keyspace and name of a shard.
+//   - vitess_vschema, with the keyspace and the JSON-encoded vschema of a
+//     keyspace.
+//   - vitess_routing_rules, vitess_shard_routing_rules and
+//     vitess_keyspace_routing_rules, with the JSON-encoded rules.
+//
+// Resources refer to the keyspace resource they depend on.
+func MarshalTerraform(cfg *Config) ([]byte, error) {
+	resources := map[string]map[string]map[string]string{}
+	addResource := func(typ string, name string, attributes map[string]string) {
+		if resources[typ] == nil {
+			resources[typ] = map[string]map[string]string{}
+		}
+		resources[typ][name] = attributes
+	}
+	for _, ks := range cfg.Keyspaces {
+		ksName := terraformName(ks.Name)
+		attributes := map[string]string{"name": ks.Name}
+		if ks.DurabilityPolicy != "" {
+			attributes["durability_policy"] = ks.DurabilityPolicy
+		}
+		if ks.SidecarDBName != "" {
+			attributes["sidecar_db_name"] = ks.SidecarDBName
+		}
+		addResource("vitess_keyspace", ksName, attributes)
+
+		ksRef := fmt.Sprintf("${vitess_keyspace.%s.name}", ksName)
+		for _, shard := range ks.Shards {
+			addResource("vitess_shard", terraformName(ks.Name+"_"+terraformShardName(shard)), map[string]string{
+				"keyspace": ksRef,
+				"name":     shard,
+			})
+		}
+		if ks.VSchema != nil {
+			vschema, err := marshalProto(ks.VSchema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal vschema of keyspace %s: %w", ks.Name, err)
+			}
+			addResource("vitess_vschema", ksName, map[string]string{
+				"keyspace": ksRef,
+				"vschema":  string(vschema),
+			})
+		}
+	}
+	for typ, rules := range map[string]proto.Message{
+		"vitess_routing_rules":          cfg.RoutingRules,
+		"vitess_shard_routing_rules":    cfg.ShardRoutingRules,
+		"vitess_keyspace_routing_rules": cfg.KeyspaceRoutingRules,
+	} {
+		data, err := marshalProto(rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", typ, err)
+		}
+		if data != nil {
+			addResource(typ, "cluster", map[string]string{"rules": string(data)})
+		}
+	}
+	return json.MarshalIndent(map[string]any{"resource": resources}, "", "  ")
+}
+
+// terraformShardName returns the name of a shard with the unbounded ends of
+// its key range spelled out, e.g. min_80 for -80.
+func terraformShardName(shard string) string {
+	start, end, ok := strings.Cut(shard, "-")
+	if !ok {
+		return shard
+	}
+	if start == "" {
+		start = "min"
+	}
+	if end == "" {
+		end = "max"
+	}
+	return start + "_" + end
+}
+
+// terraformName returns name as a valid Terraform resource name, by replacing
+// the characters that are not allowed with underscores.
+func terraformName(name string) string {
+	runes := []rune(name)
+	for i, r := range runes {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			runes[i] = '_'
+		}
+	}
+	if len(runes) == 0 || !(runes[0] >= 'a' && runes[0] <= 'z' || runes[0] >= 'A' && runes[0] <= 'Z' || runes[0] == '_') {
+		runes = slices.Insert(runes, 0, '_')
+	}
+	return string(runes)
+}
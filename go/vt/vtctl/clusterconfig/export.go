@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// Export returns the configuration of the cluster. SNAPSHOT keyspaces are
+// left out, as they are created from backups rather than declared.
+func Export(ctx context.Context, client vtctldclient.VtctldClient) (*Config, error) {
+	keyspaces, err := client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyspaces: %w", err)
+	}
+
+	cfg := &Config{}
+	for _, keyspace := range keyspaces.Keyspaces {
+		if keyspace.Keyspace.GetKeyspaceType() == topodatapb.KeyspaceType_SNAPSHOT {
+			continue
+		}
+		ks := &Keyspace{
+			Name:             keyspace.Name,
+			DurabilityPolicy: keyspace.Keyspace.GetDurabilityPolicy(),
+			SidecarDBName:    keyspace.Keyspace.GetSidecarDbName(),
+		}
+
+		shards, err := client.FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{Keyspace: keyspace.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shards of keyspace %s: %w", keyspace.Name, err)
+		}
+		for name := range shards.Shards {
+			ks.Shards = append(ks.Shards, name)
+		}
+		slices.Sort(ks.Shards)
+
+		vschema, err := client.GetVSchema(ctx, &vtctldatapb.GetVSchemaRequest{Keyspace: keyspace.Name})
+		switch {
+		case err == nil:
+			ks.VSchema = vschema.VSchema
+		case strings.Contains(err.Error(), "node doesn't exist"):
+			// The keyspace has no vschema yet. Since this is on the client
+			// side of an RPC, topo.IsErrType(topo.NoNode) cannot be used.
+		default:
+			return nil, fmt.Errorf("failed to get vschema of keyspace %s: %w", keyspace.Name, err)
+		}
+
+		cfg.Keyspaces = append(cfg.Keyspaces, ks)
+	}
+	slices.SortFunc(cfg.Keyspaces, func(a, b *Keyspace) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	routingRules, err := client.GetRoutingRules(ctx, &vtctldatapb.GetRoutingRulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing rules: %w", err)
+	}
+	// The routing rules are always exported, so that applying the
+	// configuration removes the rules that were added since.
+	cfg.RoutingRules = routingRules.RoutingRules
+	if cfg.RoutingRules == nil {
+		cfg.RoutingRules = &vschemapb.RoutingRules{}
+	}
+
+	shardRoutingRules, err := client.GetShardRoutingRules(ctx, &vtctldatapb.GetShardRoutingRulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shard routing rules: %w", err)
+	}
+	cfg.ShardRoutingRules = shardRoutingRules.ShardRoutingRules
+	if cfg.ShardRoutingRules == nil {
+		cfg.ShardRoutingRules = &vschemapb.ShardRoutingRules{}
+	}
+
+	keyspaceRoutingRules, err := client.GetKeyspaceRoutingRules(ctx, &vtctldatapb.GetKeyspaceRoutingRulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyspace routing rules: %w", err)
+	}
+	cfg.KeyspaceRoutingRules = keyspaceRoutingRules.KeyspaceRoutingRules
+	if cfg.KeyspaceRoutingRules == nil {
+		cfg.KeyspaceRoutingRules = &vschemapb.KeyspaceRoutingRules{}
+	}
+
+	return cfg, nil
+}
@@ -109,6 +109,15 @@ func (client *gRPCVtctldClient) BackupShard(ctx context.Context, in *vtctldatapb
 	return client.c.BackupShard(ctx, in, opts...)
 }
 
+// BreakLock is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) BreakLock(ctx context.Context, in *vtctldatapb.BreakLockRequest, opts ...grpc.CallOption) (*vtctldatapb.BreakLockResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.BreakLock(ctx, in, opts...)
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CancelSchemaMigration(ctx context.Context, in *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -154,6 +163,15 @@ func (client *gRPCVtctldClient) CleanupSchemaMigration(ctx context.Context, in *
 	return client.c.CleanupSchemaMigration(ctx, in, opts...)
 }
 
+// CloneFromTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) CloneFromTablet(ctx context.Context, in *vtctldatapb.CloneFromTabletRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[vtctldatapb.CloneFromTabletResponse], error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.CloneFromTablet(ctx, in, opts...)
+}
+
 // CompleteSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CompleteSchemaMigration(ctx context.Context, in *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -316,6 +334,15 @@ func (client *gRPCVtctldClient) ForceCutOverSchemaMigration(ctx context.Context,
 	return client.c.ForceCutOverSchemaMigration(ctx, in, opts...)
 }
 
+// GetBackupFreshness is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetBackupFreshness(ctx context.Context, in *vtctldatapb.GetBackupFreshnessRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupFreshnessResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetBackupFreshness(ctx, in, opts...)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	if client.c == nil {
@@ -388,6 +415,15 @@ func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatap
 	return client.c.GetKeyspaces(ctx, in, opts...)
 }
 
+// GetLockHolders is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetLockHolders(ctx context.Context, in *vtctldatapb.GetLockHoldersRequest, opts ...grpc.CallOption) (*vtctldatapb.GetLockHoldersResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetLockHolders(ctx, in, opts...)
+}
+
 // GetMirrorRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldatapb.GetMirrorRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMirrorRulesResponse, error) {
 	if client.c == nil {
@@ -406,6 +442,15 @@ func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldat
 	return client.c.GetPermissions(ctx, in, opts...)
 }
 
+// GetReparentReports is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetReparentReports(ctx context.Context, in *vtctldatapb.GetReparentReportsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetReparentReportsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetReparentReports(ctx, in, opts...)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -721,6 +766,15 @@ func (client *gRPCVtctldClient) PlannedReparentShard(ctx context.Context, in *vt
 	return client.c.PlannedReparentShard(ctx, in, opts...)
 }
 
+// PurgeBinaryLogs is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PurgeBinaryLogs(ctx context.Context, in *vtctldatapb.PurgeBinaryLogsRequest, opts ...grpc.CallOption) (*vtctldatapb.PurgeBinaryLogsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PurgeBinaryLogs(ctx, in, opts...)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	if client.c == nil {
@@ -1153,6 +1207,24 @@ func (client *gRPCVtctldClient) WorkflowDelete(ctx context.Context, in *vtctldat
 	return client.c.WorkflowDelete(ctx, in, opts...)
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowExport(ctx context.Context, in *vtctldatapb.WorkflowExportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowExportResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowExport(ctx, in, opts...)
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowImport(ctx context.Context, in *vtctldatapb.WorkflowImportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowImportResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowImport(ctx, in, opts...)
+}
+
 // WorkflowMirrorTraffic is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) WorkflowMirrorTraffic(ctx context.Context, in *vtctldatapb.WorkflowMirrorTrafficRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowMirrorTrafficResponse, error) {
 	if client.c == nil {
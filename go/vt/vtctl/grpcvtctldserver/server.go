@@ -575,6 +575,44 @@ func (s *VtctldServer) backupTablet(ctx context.Context, tablet *topodatapb.Tabl
 	}
 }
 
+// BreakLock is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) BreakLock(ctx context.Context, req *vtctldatapb.BreakLockRequest) (resp *vtctldatapb.BreakLockResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.BreakLock")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("name", req.Name)
+	span.Annotate("id", req.Id)
+
+	lockPath, err := topoLockPath(req.Keyspace, req.Shard, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Id == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the ID of the lock to break is required")
+	}
+	minAge, ok, err := protoutil.DurationFromProto(req.MinAge)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || minAge <= 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a positive minimum age is required to break a lock")
+	}
+
+	span.Annotate("min_age", minAge.String())
+
+	holder, err := s.ts.BreakLock(ctx, lockPath, req.Id, minAge)
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.BreakLockResponse{
+		Lock: topoLockToProto(holder),
+	}, nil
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CancelSchemaMigration(ctx context.Context, req *vtctldatapb.CancelSchemaMigrationRequest) (resp *vtctldatapb.CancelSchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CancelSchemaMigration")
@@ -859,6 +897,98 @@ func (s *VtctldServer) ForceCutOverSchemaMigration(ctx context.Context, req *vtc
 	return resp, nil
 }
 
+// CloneFromTablet is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) CloneFromTablet(req *vtctldatapb.CloneFromTabletRequest, stream vtctlservicepb.Vtctld_CloneFromTabletServer) (err error) {
+	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.CloneFromTablet")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	if req.TabletAlias == nil {
+		return vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "TabletAlias must not be empty")
+	}
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return err
+	}
+
+	span.Annotate("keyspace", ti.Keyspace)
+	span.Annotate("shard", ti.Shard)
+
+	donorAlias := req.DonorAlias
+	if donorAlias == nil {
+		si, err := s.ts.GetShard(ctx, ti.Keyspace, ti.Shard)
+		if err != nil {
+			return err
+		}
+		if topoproto.TabletAliasIsZero(si.PrimaryAlias) {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary to clone from", ti.Keyspace, ti.Shard)
+		}
+		donorAlias = si.PrimaryAlias
+	}
+
+	span.Annotate("donor_alias", topoproto.TabletAliasString(donorAlias))
+
+	if topoproto.TabletAliasEqual(donorAlias, req.TabletAlias) {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %s cannot clone its own data", topoproto.TabletAliasString(req.TabletAlias))
+	}
+	donor, err := s.ts.GetTablet(ctx, donorAlias)
+	if err != nil {
+		return err
+	}
+	if donor.Keyspace != ti.Keyspace || donor.Shard != ti.Shard {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "donor tablet %s is in shard %s/%s, not in shard %s/%s",
+			topoproto.TabletAliasString(donorAlias), donor.Keyspace, donor.Shard, ti.Keyspace, ti.Shard)
+	}
+
+	logStream, err := s.tmc.CloneFromTablet(ctx, ti.Tablet, &tabletmanagerdatapb.CloneFromTabletRequest{
+		DonorAlias: donorAlias,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := logutil.NewConsoleLogger()
+
+	for {
+		var event *logutilpb.Event
+		event, err = logStream.Recv()
+		switch err {
+		case nil:
+			logutil.LogEvent(logger, event)
+			resp := &vtctldatapb.CloneFromTabletResponse{
+				TabletAlias: req.TabletAlias,
+				Keyspace:    ti.Keyspace,
+				Shard:       ti.Shard,
+				Event:       event,
+			}
+			if err = stream.Send(resp); err != nil {
+				logger.Errorf("failed to send stream response %+v: %v", resp, err)
+			}
+		case io.EOF:
+			// Do not do anything when active reparenting is disabled.
+			if mysqlctl.DisableActiveReparents {
+				return nil
+			}
+
+			// Set the replication source on the freshly-cloned tablet, since
+			// the shard primary may have changed while it was cloning.
+			ti, err = s.ts.GetTablet(ctx, req.TabletAlias)
+			if err != nil {
+				return err
+			}
+
+			err = reparentutil.SetReplicationSource(ctx, s.ts, s.tmc, ti.Tablet)
+			return err
+		default:
+			return err
+		}
+	}
+}
+
 // CompleteSchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) CompleteSchemaMigration(ctx context.Context, req *vtctldatapb.CompleteSchemaMigrationRequest) (resp *vtctldatapb.CompleteSchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.CompleteSchemaMigration")
@@ -1345,6 +1475,7 @@ func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldat
 			WaitAllTablets:            req.WaitForAllTablets,
 			PreventCrossCellPromotion: req.PreventCrossCellPromotion,
 			ExpectedPrimaryAlias:      req.ExpectedPrimary,
+			Initiator:                 "vtctld EmergencyReparentShard",
 		},
 	)
 
@@ -1517,6 +1648,76 @@ func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctlda
 	}, nil
 }
 
+// GetBackupFreshness is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetBackupFreshness(ctx context.Context, req *vtctldatapb.GetBackupFreshnessRequest) (resp *vtctldatapb.GetBackupFreshnessResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackupFreshness")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspaces", strings.Join(req.Keyspaces, ","))
+
+	maxAge, ok, err := protoutil.DurationFromProto(req.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || maxAge <= 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a positive max age is required to check backup freshness")
+		return nil, err
+	}
+
+	span.Annotate("max_age", maxAge.String())
+
+	keyspaces := req.Keyspaces
+	if len(keyspaces) == 0 {
+		keyspaces, err = s.ts.GetKeyspaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	violations := []*vtctldatapb.ShardBackupFreshness{}
+
+	for _, keyspace := range keyspaces {
+		shards, err2 := s.ts.FindAllShardsInKeyspace(ctx, keyspace, nil)
+		if err2 != nil {
+			err = err2
+			return nil, err
+		}
+
+		names := make([]string, 0, len(shards))
+		for name := range shards {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			si := shards[name]
+			freshness := &vtctldatapb.ShardBackupFreshness{
+				Keyspace:   keyspace,
+				Shard:      si.ShardName(),
+				LastBackup: si.LastBackup,
+			}
+
+			if si.LastBackup != nil {
+				age := now.Sub(protoutil.TimeFromProto(si.LastBackup.Time))
+				if age <= maxAge {
+					continue
+				}
+
+				freshness.Age = protoutil.DurationToProto(age)
+			}
+
+			violations = append(violations, freshness)
+		}
+	}
+
+	return &vtctldatapb.GetBackupFreshnessResponse{
+		Violations: violations,
+	}, nil
+}
+
 // GetBackups is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest) (resp *vtctldatapb.GetBackupsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackups")
@@ -1721,6 +1922,35 @@ func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKey
 	return &vtctldatapb.GetKeyspacesResponse{Keyspaces: keyspaces}, nil
 }
 
+// GetLockHolders is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetLockHolders(ctx context.Context, req *vtctldatapb.GetLockHoldersRequest) (resp *vtctldatapb.GetLockHoldersResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetLockHolders")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("name", req.Name)
+
+	lockPath, err := topoLockPath(req.Keyspace, req.Shard, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	holders, err := s.ts.GetLockHolders(ctx, lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.GetLockHoldersResponse{
+		Locks: make([]*vtctldatapb.TopoLock, 0, len(holders)),
+	}
+	for _, holder := range holders {
+		resp.Locks = append(resp.Locks, topoLockToProto(holder))
+	}
+	return resp, nil
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetPermissionsRequest) (resp *vtctldatapb.GetPermissionsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetPermissions")
@@ -1745,6 +1975,32 @@ func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetP
 	}, nil
 }
 
+// GetReparentReports is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetReparentReports(ctx context.Context, req *vtctldatapb.GetReparentReportsRequest) (resp *vtctldatapb.GetReparentReportsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetReparentReports")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("limit", req.Limit)
+
+	// Fail for a shard that doesn't exist, instead of returning no reports.
+	if _, err = s.ts.GetShard(ctx, req.Keyspace, req.Shard); err != nil {
+		return nil, err
+	}
+
+	reports, err := s.ts.GetReparentReports(ctx, req.Keyspace, req.Shard, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetReparentReportsResponse{
+		Reports: reports,
+	}, nil
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetRoutingRules(ctx context.Context, req *vtctldatapb.GetRoutingRulesRequest) (resp *vtctldatapb.GetRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRoutingRules")
@@ -1785,6 +2041,7 @@ func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchema
 	defer panicHandler(&err)
 
 	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("tables", strings.Join(req.Tables, ","))
 	span.Annotate("exclude_tables", strings.Join(req.ExcludeTables, ","))
 	span.Annotate("include_views", req.IncludeViews)
@@ -1793,11 +2050,79 @@ func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchema
 	span.Annotate("table_schema_only", req.TableSchemaOnly)
 
 	r := &tabletmanagerdatapb.GetSchemaRequest{Tables: req.Tables, ExcludeTables: req.ExcludeTables, IncludeViews: req.IncludeViews, TableSchemaOnly: req.TableSchemaOnly}
+
+	if req.Keyspace != "" {
+		if req.TabletAlias != nil {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot specify both a tablet alias and a keyspace")
+			return nil, err
+		}
+
+		return s.getKeyspaceSchema(ctx, req, r)
+	}
+
 	sd, err := schematools.GetSchema(ctx, s.ts, s.tmc, req.TabletAlias, r)
 	if err != nil {
 		return nil, err
 	}
 
+	return &vtctldatapb.GetSchemaResponse{
+		Schema: trimSchemaDefinition(sd, req),
+	}, nil
+}
+
+// getKeyspaceSchema gets the schema from the primary of every shard in the
+// keyspace concurrently. A shard whose schema cannot be read gets an error in
+// its ShardSchema rather than failing the request, so the caller always gets
+// the schemas of the shards that could be read.
+func (s *VtctldServer) getKeyspaceSchema(ctx context.Context, req *vtctldatapb.GetSchemaRequest, r *tabletmanagerdatapb.GetSchemaRequest) (*vtctldatapb.GetSchemaResponse, error) {
+	shards, err := s.ts.FindAllShardsInKeyspace(ctx, req.Keyspace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		m  sync.Mutex
+		wg sync.WaitGroup
+
+		resp = &vtctldatapb.GetSchemaResponse{
+			ShardSchemas: make(map[string]*vtctldatapb.ShardSchema, len(shards)),
+		}
+	)
+
+	for name, si := range shards {
+		wg.Go(func() {
+			shardSchema := &vtctldatapb.ShardSchema{
+				TabletAlias: si.PrimaryAlias,
+			}
+
+			if si.PrimaryAlias == nil {
+				shardSchema.Error = fmt.Sprintf("shard %s/%s has no primary", req.Keyspace, name)
+			} else if sd, err := schematools.GetSchema(ctx, s.ts, s.tmc, si.PrimaryAlias, r); err != nil {
+				shardSchema.Error = err.Error()
+			} else {
+				if !req.TableNamesOnly && !req.TableSizesOnly {
+					shardSchema.TableDigests = make(map[string]string, len(sd.TableDefinitions))
+					for _, td := range sd.TableDefinitions {
+						shardSchema.TableDigests[td.Name] = schematools.TableDigest(td)
+					}
+				}
+				shardSchema.Schema = trimSchemaDefinition(sd, req)
+			}
+
+			m.Lock()
+			defer m.Unlock()
+			resp.ShardSchemas[name] = shardSchema
+		})
+	}
+
+	wg.Wait()
+
+	return resp, nil
+}
+
+// trimSchemaDefinition limits the table definitions of the schema to their
+// names or sizes, as requested.
+func trimSchemaDefinition(sd *tabletmanagerdatapb.SchemaDefinition, req *vtctldatapb.GetSchemaRequest) *tabletmanagerdatapb.SchemaDefinition {
 	if req.TableNamesOnly {
 		nameTds := make([]*tabletmanagerdatapb.TableDefinition, len(sd.TableDefinitions))
 
@@ -1823,9 +2148,7 @@ func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchema
 		sd.TableDefinitions = sizeTds
 	}
 
-	return &vtctldatapb.GetSchemaResponse{
-		Schema: sd,
-	}, nil
+	return sd
 }
 
 func (s *VtctldServer) GetSchemaMigrations(ctx context.Context, req *vtctldatapb.GetSchemaMigrationsRequest) (resp *vtctldatapb.GetSchemaMigrationsResponse, err error) {
@@ -3356,6 +3679,38 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 	return resp, err
 }
 
+// PurgeBinaryLogs is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PurgeBinaryLogs(ctx context.Context, req *vtctldatapb.PurgeBinaryLogsRequest) (resp *vtctldatapb.PurgeBinaryLogsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PurgeBinaryLogs")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("flush", req.Flush)
+	span.Annotate("min_binary_logs", req.MinBinaryLogs)
+	span.Annotate("dry_run", req.DryRun)
+
+	tablet, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.tmc.PurgeBinaryLogs(ctx, tablet.Tablet, &tabletmanagerdatapb.PurgeBinaryLogsRequest{
+		Flush:         req.Flush,
+		MinBinaryLogs: req.MinBinaryLogs,
+		DryRun:        req.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.PurgeBinaryLogsResponse{
+		PurgedBinaryLogs:   r.PurgedBinaryLogs,
+		RetainedBinaryLogs: r.RetainedBinaryLogs,
+	}, nil
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RebuildKeyspaceGraph(ctx context.Context, req *vtctldatapb.RebuildKeyspaceGraphRequest) (resp *vtctldatapb.RebuildKeyspaceGraphResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildKeyspaceGraph")
@@ -4821,6 +5176,7 @@ func (s *VtctldServer) ValidateKeyspace(ctx context.Context, req *vtctldatapb.Va
 
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("ping_tablets", req.PingTablets)
+	span.Annotate("deep", req.Deep)
 
 	resp = &vtctldatapb.ValidateKeyspaceResponse{}
 	getShardNamesCtx, getShardNamesCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
@@ -4869,6 +5225,10 @@ func (s *VtctldServer) ValidateKeyspace(ctx context.Context, req *vtctldatapb.Va
 
 	wg.Wait()
 
+	if req.Deep {
+		s.validateKeyspaceData(ctx, req, resp)
+	}
+
 	return resp, err
 }
 
@@ -5636,6 +5996,36 @@ func (s *VtctldServer) WorkflowDelete(ctx context.Context, req *vtctldatapb.Work
 	return resp, err
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowExport(ctx context.Context, req *vtctldatapb.WorkflowExportRequest) (resp *vtctldatapb.WorkflowExportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowExport")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("shards", req.Shards)
+
+	resp, err = s.ws.WorkflowExport(ctx, req)
+	return resp, err
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowImport(ctx context.Context, req *vtctldatapb.WorkflowImportRequest) (resp *vtctldatapb.WorkflowImportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowImport")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow.GetWorkflow())
+	span.Annotate("cells", req.Cells)
+
+	resp, err = s.ws.WorkflowImport(ctx, req)
+	return resp, err
+}
+
 // WorkflowStatus is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowStatusRequest) (resp *vtctldatapb.WorkflowStatusResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowStatus")
@@ -2424,6 +2424,111 @@ func TestForceCutOverSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestCloneFromTablet(t *testing.T) {
+	ctx := t.Context()
+
+	alias := func(uid uint32) *topodatapb.TabletAlias {
+		return &topodatapb.TabletAlias{Cell: "zone1", Uid: uid}
+	}
+	tablets := []*topodatapb.Tablet{
+		{Alias: alias(100), Keyspace: "ks", Shard: "-", Type: topodatapb.TabletType_REPLICA},
+		{Alias: alias(200), Keyspace: "ks", Shard: "-", Type: topodatapb.TabletType_PRIMARY},
+		{Alias: alias(300), Keyspace: "ks", Shard: "-", Type: topodatapb.TabletType_RDONLY},
+		{Alias: alias(400), Keyspace: "ks2", Shard: "-", Type: topodatapb.TabletType_REPLICA},
+	}
+
+	type cloneResult = struct {
+		DonorAlias *topodatapb.TabletAlias
+		Events     []*logutilpb.Event
+		Error      error
+	}
+	tests := []struct {
+		name      string
+		results   map[string]cloneResult
+		req       *vtctldatapb.CloneFromTabletRequest
+		events    int
+		wantError string
+	}{
+		{
+			name: "clone from the primary",
+			results: map[string]cloneResult{
+				"zone1-0000000100": {DonorAlias: alias(200), Events: []*logutilpb.Event{{}, {}, {}}},
+			},
+			req:    &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(100)},
+			events: 3,
+		},
+		{
+			name: "clone from a replica",
+			results: map[string]cloneResult{
+				"zone1-0000000100": {DonorAlias: alias(300), Events: []*logutilpb.Event{{}}},
+			},
+			req:    &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(100), DonorAlias: alias(300)},
+			events: 1,
+		},
+		{
+			name: "clone failed",
+			results: map[string]cloneResult{
+				"zone1-0000000100": {DonorAlias: alias(200), Events: []*logutilpb.Event{{}}, Error: assert.AnError},
+			},
+			req:       &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(100)},
+			events:    1,
+			wantError: assert.AnError.Error(),
+		},
+		{
+			name:      "donor in another shard",
+			req:       &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(100), DonorAlias: alias(400)},
+			wantError: "donor tablet zone1-0000000400 is in shard ks2/-, not in shard ks/-",
+		},
+		{
+			name:      "clone from itself",
+			req:       &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(200)},
+			wantError: "tablet zone1-0000000200 cannot clone its own data",
+		},
+		{
+			name:      "no such tablet",
+			req:       &vtctldatapb.CloneFromTabletRequest{TabletAlias: alias(404)},
+			wantError: "node doesn't exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, tablets...)
+			tmc := &testutil.TabletManagerClient{
+				CloneFromTabletResults: tt.results,
+				SetReplicationSourceResults: map[string]error{
+					"zone1-0000000100": nil,
+				},
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			client := localvtctldclient.New(vtctld)
+			stream, err := client.CloneFromTablet(ctx, tt.req)
+			require.NoError(t, err)
+
+			var responses []*vtctldatapb.CloneFromTabletResponse
+			for {
+				var resp *vtctldatapb.CloneFromTabletResponse
+				resp, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				assert.Equal(t, "ks", resp.Keyspace)
+				responses = append(responses, resp)
+			}
+
+			if tt.wantError != "" {
+				assert.ErrorContains(t, err, tt.wantError)
+			} else {
+				assert.ErrorIs(t, err, io.EOF)
+			}
+			assert.Len(t, responses, tt.events)
+		})
+	}
+}
+
 func TestCompleteSchemaMigration(t *testing.T) {
 	t.Parallel()
 
@@ -5888,6 +5993,64 @@ func TestFindAllShardsInKeyspace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetBackupFreshness(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	freshBackup := &topodatapb.Shard_BackupStatus{
+		Name:      "fresh",
+		Time:      protoutil.TimeToProto(time.Now().Add(-time.Hour)),
+		SizeBytes: 100,
+	}
+	staleBackup := &topodatapb.Shard_BackupStatus{
+		Name:      "stale",
+		Time:      protoutil.TimeToProto(time.Now().Add(-48 * time.Hour)),
+		SizeBytes: 200,
+	}
+	testutil.AddShards(ctx, t, ts,
+		&vtctldatapb.Shard{Keyspace: "ks1", Name: "-80", Shard: &topodatapb.Shard{LastBackup: freshBackup}},
+		&vtctldatapb.Shard{Keyspace: "ks1", Name: "80-", Shard: &topodatapb.Shard{LastBackup: staleBackup}},
+		&vtctldatapb.Shard{Keyspace: "ks2", Name: "0"},
+	)
+
+	_, err := vtctld.GetBackupFreshness(ctx, &vtctldatapb.GetBackupFreshnessRequest{})
+	assert.Error(t, err, "max age is required")
+
+	resp, err := vtctld.GetBackupFreshness(ctx, &vtctldatapb.GetBackupFreshnessRequest{
+		MaxAge: protoutil.DurationToProto(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Violations, 2)
+	sort.Slice(resp.Violations, func(i, j int) bool {
+		return resp.Violations[i].Keyspace < resp.Violations[j].Keyspace
+	})
+
+	assert.Equal(t, "ks1", resp.Violations[0].Keyspace)
+	assert.Equal(t, "80-", resp.Violations[0].Shard)
+	utils.MustMatch(t, staleBackup, resp.Violations[0].LastBackup)
+	age, ok, err := protoutil.DurationFromProto(resp.Violations[0].Age)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, age, 48*time.Hour)
+
+	assert.Equal(t, "ks2", resp.Violations[1].Keyspace)
+	assert.Equal(t, "0", resp.Violations[1].Shard)
+	assert.Nil(t, resp.Violations[1].LastBackup, "a shard without a published backup violates the objective")
+	assert.Nil(t, resp.Violations[1].Age)
+
+	resp, err = vtctld.GetBackupFreshness(ctx, &vtctldatapb.GetBackupFreshnessRequest{
+		Keyspaces: []string{"ks1"},
+		MaxAge:    protoutil.DurationToProto(72 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Violations)
+}
+
 func TestGetBackups(t *testing.T) {
 	ctx := t.Context()
 	ts := memorytopo.NewServer(ctx)
@@ -6018,6 +6181,87 @@ func TestGetKeyspace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetLockHolders(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	resp, err := vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Locks)
+
+	_, unlock, err := ts.LockKeyspace(ctx, "testkeyspace", "testing")
+	require.NoError(t, err)
+	var unlockErr error
+	defer unlock(&unlockErr)
+
+	resp, err = vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	require.Len(t, resp.Locks, 1)
+	assert.Equal(t, "testing", resp.Locks[0].Action)
+	assert.Equal(t, "Running", resp.Locks[0].Status)
+	assert.NotNil(t, resp.Locks[0].Time)
+	assert.NotNil(t, resp.Locks[0].Age)
+
+	_, err = vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{})
+	assert.ErrorContains(t, err, "a keyspace or lock name is required")
+	_, err = vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{Keyspace: "testkeyspace", Name: "testname"})
+	assert.ErrorContains(t, err, "a named lock cannot also have a keyspace or shard")
+}
+
+func TestBreakLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	// Leave a named lock behind, as a process that went away would.
+	contents, err := (&topo.Lock{
+		Action:   "testing",
+		HostName: "gone",
+		UserName: "vitess",
+		Time:     time.Now().Add(-time.Hour).Format(time.RFC3339),
+		Status:   "Running",
+	}).ToJSON()
+	require.NoError(t, err)
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	_, err = conn.LockName(ctx, topo.NamedLockPath("testname"), contents)
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{Name: "testname"})
+	require.NoError(t, err)
+	require.Len(t, resp.Locks, 1)
+	id := resp.Locks[0].Id
+
+	_, err = vtctld.BreakLock(ctx, &vtctldatapb.BreakLockRequest{Name: "testname", Id: id})
+	assert.ErrorContains(t, err, "a positive minimum age is required")
+	_, err = vtctld.BreakLock(ctx, &vtctldatapb.BreakLockRequest{Name: "testname", MinAge: protoutil.DurationToProto(time.Minute)})
+	assert.ErrorContains(t, err, "the ID of the lock to break is required")
+	_, err = vtctld.BreakLock(ctx, &vtctldatapb.BreakLockRequest{Name: "testname", Id: id, MinAge: protoutil.DurationToProto(2 * time.Hour)})
+	assert.ErrorContains(t, err, "less than 2h0m0s")
+
+	breakResp, err := vtctld.BreakLock(ctx, &vtctldatapb.BreakLockRequest{Name: "testname", Id: id, MinAge: protoutil.DurationToProto(time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, id, breakResp.Lock.Id)
+	assert.Equal(t, "gone", breakResp.Lock.HostName)
+
+	resp, err = vtctld.GetLockHolders(ctx, &vtctldatapb.GetLockHoldersRequest{Name: "testname"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Locks)
+}
+
 func TestGetCellInfoNames(t *testing.T) {
 	t.Parallel()
 
@@ -6395,6 +6639,45 @@ func TestGetPermissions(t *testing.T) {
 	}
 }
 
+func TestGetReparentReports(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{Keyspace: "ks", Name: "-"})
+
+	start := time.Now().Add(-time.Hour)
+	for i := range 3 {
+		err := ts.SaveReparentReport(ctx, &topodatapb.ReparentReport{
+			Keyspace:   "ks",
+			Shard:      "-",
+			StartTime:  protoutil.TimeToProto(start.Add(time.Duration(i) * time.Minute)),
+			NewPrimary: &topodatapb.TabletAlias{Cell: "cell1", Uid: uint32(100 + i)},
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := vtctld.GetReparentReports(ctx, &vtctldatapb.GetReparentReportsRequest{
+		Keyspace: "ks",
+		Shard:    "-",
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Reports, 2)
+	assert.EqualValues(t, 102, resp.Reports[0].NewPrimary.Uid)
+	assert.EqualValues(t, 101, resp.Reports[1].NewPrimary.Uid)
+
+	_, err = vtctld.GetReparentReports(ctx, &vtctldatapb.GetReparentReportsRequest{
+		Keyspace: "ks",
+		Shard:    "80-",
+	})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "unexpected error %v", err)
+}
+
 func TestGetRoutingRules(t *testing.T) {
 	t.Parallel()
 
@@ -6660,6 +6943,152 @@ func TestGetSchema(t *testing.T) {
 	}
 }
 
+func TestGetSchemaKeyspace(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	tmc := testutil.TabletManagerClient{
+		GetSchemaResults: map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "80-",
+	})
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardPrimary: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-40",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		Keyspace: "testkeyspace",
+		Shard:    "40-80",
+		Type:     topodatapb.TabletType_PRIMARY,
+	})
+
+	t1 := &tabletmanagerdatapb.TableDefinition{
+		Name:       "t1",
+		Schema:     "CREATE TABLE t1 (id int(11) not null, PRIMARY KEY (id))",
+		Type:       "BASE",
+		Columns:    []string{"id"},
+		DataLength: 100,
+		RowCount:   50,
+	}
+	// we need to run this on each test case or they will pollute each other
+	setupSchema := func() {
+		tmc.GetSchemaResults["zone1-0000000100"] = struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{
+			Schema: &tabletmanagerdatapb.SchemaDefinition{
+				DatabaseSchema:   "CREATE DATABASE vt_testkeyspace",
+				TableDefinitions: []*tabletmanagerdatapb.TableDefinition{t1.CloneVT()},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		req      *vtctldatapb.GetSchemaRequest
+		expected *vtctldatapb.GetSchemaResponse
+	}{
+		{
+			name: "partial results",
+			req: &vtctldatapb.GetSchemaRequest{
+				Keyspace: "testkeyspace",
+			},
+			expected: &vtctldatapb.GetSchemaResponse{
+				ShardSchemas: map[string]*vtctldatapb.ShardSchema{
+					"-40": {
+						TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+						Schema: &tabletmanagerdatapb.SchemaDefinition{
+							DatabaseSchema:   "CREATE DATABASE vt_testkeyspace",
+							TableDefinitions: []*tabletmanagerdatapb.TableDefinition{t1},
+						},
+						TableDigests: map[string]string{
+							"t1": schematools.TableDigest(t1),
+						},
+					},
+					"40-80": {
+						TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+						Error:       "no schemas for zone1-0000000200",
+					},
+					"80-": {
+						Error: "shard testkeyspace/80- has no primary",
+					},
+				},
+			},
+		},
+		{
+			name: "table names only",
+			req: &vtctldatapb.GetSchemaRequest{
+				Keyspace:       "testkeyspace",
+				TableNamesOnly: true,
+			},
+			expected: &vtctldatapb.GetSchemaResponse{
+				ShardSchemas: map[string]*vtctldatapb.ShardSchema{
+					"-40": {
+						TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+						Schema: &tabletmanagerdatapb.SchemaDefinition{
+							DatabaseSchema:   "CREATE DATABASE vt_testkeyspace",
+							TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "t1"}},
+						},
+					},
+					"40-80": {
+						TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+						Error:       "no schemas for zone1-0000000200",
+					},
+					"80-": {
+						Error: "shard testkeyspace/80- has no primary",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSchema()
+
+			resp, err := vtctld.GetSchema(ctx, tt.req)
+			require.NoError(t, err)
+
+			// The error of a shard wraps the tablet manager error with the
+			// whole tablet record, so only the wrapped error is compared.
+			for shard, shardSchema := range resp.ShardSchemas {
+				if want := tt.expected.ShardSchemas[shard]; want != nil && want.Error != "" {
+					assert.Contains(t, shardSchema.Error, want.Error, "shard %s", shard)
+					shardSchema.Error = want.Error
+				}
+			}
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+
+	t.Run("tablet alias and keyspace", func(t *testing.T) {
+		_, err := vtctld.GetSchema(ctx, &vtctldatapb.GetSchemaRequest{
+			TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace:    "testkeyspace",
+		})
+		assert.ErrorContains(t, err, "cannot specify both a tablet alias and a keyspace")
+	})
+
+	t.Run("no keyspace", func(t *testing.T) {
+		_, err := vtctld.GetSchema(ctx, &vtctldatapb.GetSchemaRequest{
+			Keyspace: "notfound",
+		})
+		assert.Error(t, err)
+	})
+}
+
 func TestGetSchemaMigrations(t *testing.T) {
 	t.Parallel()
 
@@ -9234,6 +9663,115 @@ func TestPlannedReparentShard(t *testing.T) {
 	}
 }
 
+func TestPurgeBinaryLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: "zone1",
+			Uid:  100,
+		},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+	}, nil)
+
+	tests := []struct {
+		name      string
+		tmc       testutil.TabletManagerClient
+		req       *vtctldatapb.PurgeBinaryLogsRequest
+		expected  *vtctldatapb.PurgeBinaryLogsResponse
+		shouldErr bool
+	}{
+		{
+			name: "ok",
+			tmc: testutil.TabletManagerClient{
+				PurgeBinaryLogsResults: map[string]struct {
+					Response *tabletmanagerdatapb.PurgeBinaryLogsResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Response: &tabletmanagerdatapb.PurgeBinaryLogsResponse{
+							PurgedBinaryLogs:   []string{"vt-bin.000001"},
+							RetainedBinaryLogs: []string{"vt-bin.000002"},
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.PurgeBinaryLogsRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+				Flush: true,
+			},
+			expected: &vtctldatapb.PurgeBinaryLogsResponse{
+				PurgedBinaryLogs:   []string{"vt-bin.000001"},
+				RetainedBinaryLogs: []string{"vt-bin.000002"},
+			},
+		},
+		{
+			name: "tablet not found",
+			tmc: testutil.TabletManagerClient{
+				PurgeBinaryLogsResults: map[string]struct {
+					Response *tabletmanagerdatapb.PurgeBinaryLogsResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Response: &tabletmanagerdatapb.PurgeBinaryLogsResponse{},
+					},
+				},
+			},
+			req: &vtctldatapb.PurgeBinaryLogsRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone2",
+					Uid:  404,
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "purge rpc error",
+			tmc: testutil.TabletManagerClient{
+				PurgeBinaryLogsResults: map[string]struct {
+					Response *tabletmanagerdatapb.PurgeBinaryLogsResponse
+					Error    error
+				}{
+					"zone1-0000000100": {
+						Error: assert.AnError,
+					},
+				},
+			},
+			req: &vtctldatapb.PurgeBinaryLogsRequest{
+				TabletAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &tt.tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+
+			resp, err := vtctld.PurgeBinaryLogs(ctx, tt.req)
+			if tt.shouldErr {
+				require.Error(t, err)
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestRebuildKeyspaceGraph(t *testing.T) {
 	t.Parallel()
 
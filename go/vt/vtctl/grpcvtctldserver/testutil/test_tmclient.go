@@ -200,6 +200,13 @@ type TabletManagerClient struct {
 	ChangeTabletTypeResult map[string]error
 	ChangeTabletTypeDelays map[string]time.Duration
 	// keyed by tablet alias.
+	CloneFromTabletResults map[string]struct {
+		// DonorAlias is the donor the tablet is expected to clone from.
+		DonorAlias *topodatapb.TabletAlias
+		Events     []*logutilpb.Event
+		Error      error
+	}
+	// keyed by tablet alias.
 	DemotePrimaryDelays map[string]time.Duration
 	// keyed by tablet alias.
 	DemotePrimaryResults map[string]struct {
@@ -271,6 +278,8 @@ type TabletManagerClient struct {
 	ReadTransactionResult            map[string]*querypb.TransactionMetadata
 	GetTransactionInfoResult         map[string]*tabletmanagerdatapb.GetTransactionInfoResponse
 	// keyed by tablet alias.
+	GetSchemaSnapshotResults map[string]*tabletmanagerdatapb.GetSchemaSnapshotResponse
+	// keyed by tablet alias.
 	InitPrimaryDelays map[string]time.Duration
 	// keyed by tablet alias. injects a sleep to the end of the function
 	// regardless of parent context timeout or error result.
@@ -308,6 +317,11 @@ type TabletManagerClient struct {
 		Error  error
 	}
 	// keyed by tablet alias.
+	PurgeBinaryLogsResults map[string]struct {
+		Response *tabletmanagerdatapb.PurgeBinaryLogsResponse
+		Error    error
+	}
+	// keyed by tablet alias.
 	RefreshStateResults map[string]error
 	// keyed by `<tablet_alias>/<wait_pos>`.
 	ReloadSchemaDelays map[string]time.Duration
@@ -356,6 +370,8 @@ type TabletManagerClient struct {
 	// keyed by tablet alias
 	StartReplicationResults map[string]error
 	// keyed by tablet alias
+	StartReplicationUntilAfterResults map[string]error
+	// keyed by tablet alias
 	RestartReplicationDelays map[string]time.Duration
 	// keyed by tablet alias
 	RestartReplicationResults map[string]error
@@ -801,6 +817,15 @@ func (fake *TabletManagerClient) GetReplicas(ctx context.Context, tablet *topoda
 	return nil, fmt.Errorf("no result set for %v", key)
 }
 
+// GetSchemaSnapshot is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) GetSchemaSnapshot(ctx context.Context, tablet *topodatapb.Tablet, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.GetSchemaSnapshotResults[key]; ok {
+		return result, nil
+	}
+	return nil, fmt.Errorf("%w: no GetSchemaSnapshot result for %v on fake TabletManagerClient", assert.AnError, key)
+}
+
 // GetSchema is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) GetSchema(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetSchemaRequest) (*tabletmanagerdatapb.SchemaDefinition, error) {
 	if fake.GetSchemaResults == nil {
@@ -991,6 +1016,20 @@ func (fake *TabletManagerClient) ReadReparentJournalInfo(ctx context.Context, ta
 	return 0, assert.AnError
 }
 
+// PurgeBinaryLogs is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) PurgeBinaryLogs(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	if fake.PurgeBinaryLogsResults == nil {
+		return nil, assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.PurgeBinaryLogsResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no result for key %s", assert.AnError, key)
+}
+
 // PromoteReplica is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) PromoteReplica(ctx context.Context, tablet *topodatapb.Tablet, semiSync bool) (string, error) {
 	if fake.PromoteReplicaResults == nil {
@@ -1151,6 +1190,33 @@ func (stream *backupRestoreStreamAdapter) Send(msg *logutilpb.Event) error {
 	}
 }
 
+// CloneFromTablet is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) CloneFromTablet(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CloneFromTabletRequest) (logutil.EventStream, error) {
+	key := topoproto.TabletAliasString(tablet.Alias)
+	testdata, ok := fake.CloneFromTabletResults[key]
+	if !ok {
+		return nil, fmt.Errorf("no CloneFromTablet fake result set for %s", key)
+	}
+	if !topoproto.TabletAliasEqual(req.DonorAlias, testdata.DonorAlias) {
+		return nil, fmt.Errorf("%s cloned from %s, expected %s", key, topoproto.TabletAliasString(req.DonorAlias), topoproto.TabletAliasString(testdata.DonorAlias))
+	}
+
+	stream := &backupRestoreStreamAdapter{
+		BidiStream: grpcshim.NewBidiStream(ctx),
+		ch:         make(chan *logutilpb.Event),
+	}
+	go func() {
+		for _, event := range testdata.Events {
+			if err := stream.Send(event); err != nil {
+				return
+			}
+		}
+		stream.CloseWithError(testdata.Error)
+	}()
+
+	return stream, nil
+}
+
 // RestoreFromBackup is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) RestoreFromBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromBackupRequest) (logutil.EventStream, error) {
 	key := topoproto.TabletAliasString(tablet.Alias)
@@ -1401,6 +1467,21 @@ func (fake *TabletManagerClient) StartReplication(ctx context.Context, tablet *t
 	return fmt.Errorf("%w: no result for key %s", assert.AnError, key)
 }
 
+// StartReplicationUntilAfter is part of the tmclient.TabletManagerClient
+// interface.
+func (fake *TabletManagerClient) StartReplicationUntilAfter(ctx context.Context, tablet *topodatapb.Tablet, position string, waitTime time.Duration) error {
+	if fake.StartReplicationUntilAfterResults == nil {
+		return assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if err, ok := fake.StartReplicationUntilAfterResults[key]; ok {
+		return err
+	}
+
+	return fmt.Errorf("%w: no result for key %s", assert.AnError, key)
+}
+
 // RestartReplication is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) RestartReplication(ctx context.Context, tablet *topodatapb.Tablet, semiSync bool) error {
 	if fake.RestartReplicationResults == nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo"
@@ -29,6 +30,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -353,3 +355,37 @@ func removeShardCell(ctx context.Context, ts *topo.Server, cell string, keyspace
 
 	return err
 }
+
+// topoLockPath returns the path of the lock on the given keyspace, shard, or
+// named resource.
+func topoLockPath(keyspace, shard, name string) (string, error) {
+	switch {
+	case name != "" && (keyspace != "" || shard != ""):
+		return "", vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "a named lock cannot also have a keyspace or shard")
+	case name != "":
+		return topo.NamedLockPath(name), nil
+	case keyspace == "":
+		return "", vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "a keyspace or lock name is required")
+	case shard != "":
+		return topo.ShardLockPath(keyspace, shard), nil
+	default:
+		return topo.KeyspaceLockPath(keyspace), nil
+	}
+}
+
+func topoLockToProto(holder *topo.LockResourceHolder) *vtctldatapb.TopoLock {
+	l := &vtctldatapb.TopoLock{
+		Id: holder.ID,
+	}
+	if holder.Lock != nil {
+		l.Action = holder.Lock.Action
+		l.HostName = holder.Lock.HostName
+		l.UserName = holder.Lock.UserName
+		l.Status = holder.Lock.Status
+	}
+	if !holder.Time.IsZero() {
+		l.Time = protoutil.TimeToProto(holder.Time)
+		l.Age = protoutil.DurationToProto(time.Since(holder.Time).Round(time.Second))
+	}
+	return l
+}
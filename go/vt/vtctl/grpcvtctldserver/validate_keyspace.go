@@ -0,0 +1,639 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+const (
+	// defaultValidateSampleSize is the number of rows sampled from each
+	// shard of the owner and lookup tables of the lookup vindexes when the
+	// request does not set it.
+	defaultValidateSampleSize = 100
+	// validateMaxRows is the maximum number of rows of the queries run to
+	// validate the data of a keyspace, other than the samples.
+	validateMaxRows = 10000
+	// validateCatchUpTimeout is how long a replica has to replicate up to
+	// the position at which the primary checksummed the tables.
+	validateCatchUpTimeout = time.Minute
+)
+
+// validateKeyspaceData runs the deep checks of ValidateKeyspace: it compares
+// the checksums of the requested tables of the replicas of each shard to the
+// ones of its primary, and checks the owned lookup vindexes of the keyspace
+// against a sample of their rows.
+func (s *VtctldServer) validateKeyspaceData(ctx context.Context, req *vtctldatapb.ValidateKeyspaceRequest, resp *vtctldatapb.ValidateKeyspaceResponse) {
+	shards, err := s.validateShardPrimaries(ctx, req.Keyspace)
+	if err != nil {
+		resp.Results = append(resp.Results, err.Error())
+		return
+	}
+	if len(req.ChecksumTables) > 0 {
+		for _, shard := range shards {
+			resp.Results = append(resp.Results, s.validateTableChecksums(ctx, shard, req.ChecksumTables)...)
+		}
+	}
+
+	sampleSize := int(req.SampleSize)
+	if sampleSize == 0 {
+		sampleSize = defaultValidateSampleSize
+	}
+	vschema, err := s.ts.GetVSchema(ctx, req.Keyspace)
+	if err != nil {
+		if !topo.IsErrType(err, topo.NoNode) {
+			resp.Results = append(resp.Results, fmt.Sprintf("GetVSchema(%v) failed: %v", req.Keyspace, err))
+		}
+		return
+	}
+	if !vschema.Sharded {
+		return
+	}
+	ksSchema, err := vindexes.BuildKeyspaceSchema(vschema.Keyspace, req.Keyspace, s.ws.SQLParser())
+	if err != nil {
+		resp.Results = append(resp.Results, fmt.Sprintf("BuildKeyspaceSchema(%v) failed: %v", req.Keyspace, err))
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(ksSchema.Tables)) {
+		table := ksSchema.Tables[name]
+		for _, cv := range table.ColumnVindexes {
+			if !cv.Owned {
+				continue
+			}
+			v, err := s.newLookupVindexValidator(ctx, req.Keyspace, shards, table, cv, vschema.Vindexes[cv.Name], sampleSize)
+			if err != nil {
+				resp.Results = append(resp.Results, fmt.Sprintf("cannot validate lookup vindex %v of table %v: %v", cv.Name, table.Name, err))
+				continue
+			}
+			if v == nil {
+				continue
+			}
+			v.validate(ctx)
+			resp.Results = append(resp.Results, v.results...)
+			resp.LookupVindexIssues = append(resp.LookupVindexIssues, v.issues...)
+		}
+	}
+}
+
+// shardPrimary is a shard, and its primary tablet.
+type shardPrimary struct {
+	*topo.ShardInfo
+	primary *topodatapb.Tablet
+}
+
+// validateShardPrimaries returns the shards of keyspace that have a
+// primary, sorted by name. The shards without primary are reported by
+// ValidateShard.
+func (s *VtctldServer) validateShardPrimaries(ctx context.Context, keyspace string) ([]*shardPrimary, error) {
+	shards, err := s.ts.FindAllShardsInKeyspace(ctx, keyspace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("FindAllShardsInKeyspace(%v) failed: %v", keyspace, err)
+	}
+	var primaries []*shardPrimary
+	for _, name := range slices.Sorted(maps.Keys(shards)) {
+		si := shards[name]
+		if si.PrimaryAlias == nil {
+			continue
+		}
+		tablet, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return nil, fmt.Errorf("GetTablet(%v) failed: %v", topoproto.TabletAliasString(si.PrimaryAlias), err)
+		}
+		primaries = append(primaries, &shardPrimary{ShardInfo: si, primary: tablet.Tablet})
+	}
+	return primaries, nil
+}
+
+// validateTableChecksums compares the checksums of tables of the replicas of
+// shard to the ones of its primary, at the same position: the replicas stop
+// replicating while the primary checksums the tables, then each replica
+// replicates up to the position of the primary before checksumming them. The
+// tables cannot be compared if the primary is written to while it checksums
+// them.
+func (s *VtctldServer) validateTableChecksums(ctx context.Context, shard *shardPrimary, tables []string) (results []string) {
+	schema, err := schematools.GetSchema(ctx, s.ts, s.tmc, shard.primary.Alias, &tabletmanagerdatapb.GetSchemaRequest{Tables: tables, TableSchemaOnly: true})
+	if err != nil {
+		return []string{fmt.Sprintf("GetSchema(%v) failed: %v", topoproto.TabletAliasString(shard.primary.Alias), err)}
+	}
+	var escaped []string
+	for _, td := range schema.TableDefinitions {
+		if td.Type == tmutils.TableBaseTable {
+			escaped = append(escaped, sqlescape.EscapeID(td.Name))
+		}
+	}
+	if len(escaped) == 0 {
+		return []string{fmt.Sprintf("none of the tables %v to checksum are found on primary %v", strings.Join(tables, ", "), topoproto.TabletAliasString(shard.primary.Alias))}
+	}
+	query := "checksum table " + strings.Join(escaped, ", ")
+
+	tablets, err := s.ts.GetTabletMapForShard(ctx, shard.Keyspace(), shard.ShardName())
+	if err != nil {
+		return []string{fmt.Sprintf("GetTabletMapForShard(%v/%v) failed: %v", shard.Keyspace(), shard.ShardName(), err)}
+	}
+	durabilityName, err := s.ts.GetKeyspaceDurability(ctx, shard.Keyspace())
+	if err != nil {
+		return []string{fmt.Sprintf("GetKeyspaceDurability(%v) failed: %v", shard.Keyspace(), err)}
+	}
+	durability, err := policy.GetDurabilityPolicy(durabilityName)
+	if err != nil {
+		return []string{fmt.Sprintf("GetDurabilityPolicy(%v) failed: %v", durabilityName, err)}
+	}
+
+	var replicas []*topodatapb.Tablet
+	defer func() {
+		for _, replica := range replicas {
+			if err := s.tmc.StartReplication(ctx, replica, policy.IsReplicaSemiSync(durability, shard.primary, replica)); err != nil {
+				results = append(results, fmt.Sprintf("StartReplication(%v) failed: %v", topoproto.TabletAliasString(replica.Alias), err))
+			}
+		}
+	}()
+	for _, alias := range slices.Sorted(maps.Keys(tablets)) {
+		tablet := tablets[alias]
+		if !topo.IsReplicaType(tablet.Type) {
+			continue
+		}
+		if err := s.tmc.StopReplication(ctx, tablet.Tablet); err != nil {
+			results = append(results, fmt.Sprintf("StopReplication(%v) failed: %v", alias, err))
+			continue
+		}
+		replicas = append(replicas, tablet.Tablet)
+	}
+	if len(replicas) == 0 {
+		return results
+	}
+
+	primaryAlias := topoproto.TabletAliasString(shard.primary.Alias)
+	position, err := s.tmc.PrimaryPosition(ctx, shard.primary)
+	if err != nil {
+		return append(results, fmt.Sprintf("PrimaryPosition(%v) failed: %v", primaryAlias, err))
+	}
+	want, err := s.tableChecksums(ctx, shard.primary, query)
+	if err != nil {
+		return append(results, err.Error())
+	}
+	if after, err := s.tmc.PrimaryPosition(ctx, shard.primary); err != nil {
+		return append(results, fmt.Sprintf("PrimaryPosition(%v) failed: %v", primaryAlias, err))
+	} else if after != position {
+		return append(results, fmt.Sprintf("cannot compare the table checksums of shard %v/%v: primary %v was written to while it checksummed the tables", shard.Keyspace(), shard.ShardName(), primaryAlias))
+	}
+
+	for _, replica := range replicas {
+		alias := topoproto.TabletAliasString(replica.Alias)
+		if err := s.catchUpReplica(ctx, replica, position); err != nil {
+			results = append(results, fmt.Sprintf("replica %v cannot replicate up to position %v of primary %v: %v", alias, position, primaryAlias, err))
+			continue
+		}
+		got, err := s.tableChecksums(ctx, replica, query)
+		if err != nil {
+			results = append(results, err.Error())
+			continue
+		}
+		for _, table := range slices.Sorted(maps.Keys(want)) {
+			if got[table] != want[table] {
+				results = append(results, fmt.Sprintf("checksum of table %v on %v is %q, but %q on primary %v at the same position %v",
+					table, alias, got[table], want[table], primaryAlias, position))
+			}
+		}
+	}
+	return results
+}
+
+// catchUpReplica makes the stopped replica replicate up to position, and no
+// further.
+func (s *VtctldServer) catchUpReplica(ctx context.Context, replica *topodatapb.Tablet, position string) error {
+	ctx, cancel := context.WithTimeout(ctx, validateCatchUpTimeout)
+	defer cancel()
+	if err := s.tmc.StartReplicationUntilAfter(ctx, replica, position, validateCatchUpTimeout); err != nil {
+		return err
+	}
+	return s.tmc.WaitForPosition(ctx, replica, position)
+}
+
+// tableChecksums runs a CHECKSUM TABLE query on tablet, and returns the
+// checksums by table name.
+func (s *VtctldServer) tableChecksums(ctx context.Context, tablet *topodatapb.Tablet, query string) (map[string]string, error) {
+	qr, err := s.executeFetch(ctx, tablet, query, validateMaxRows)
+	if err != nil {
+		return nil, err
+	}
+	checksums := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		// The tables are qualified with the name of the database.
+		_, table, _ := strings.Cut(row[0].ToString(), ".")
+		checksums[table] = row[1].ToString()
+	}
+	return checksums, nil
+}
+
+// executeFetch runs a read-only query on tablet.
+func (s *VtctldServer) executeFetch(ctx context.Context, tablet *topodatapb.Tablet, query string, maxRows int) (*sqltypes.Result, error) {
+	qr, err := s.tmc.ExecuteFetchAsApp(ctx, tablet, true, &tabletmanagerdatapb.ExecuteFetchAsAppRequest{
+		Query:   []byte(query),
+		MaxRows: uint64(maxRows),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ExecuteFetchAsApp(%v, %v) failed: %v", topoproto.TabletAliasString(tablet.Alias), query, err)
+	}
+	return sqltypes.Proto3ToResult(qr), nil
+}
+
+// lookupVindexValidator checks an owned lookup vindex against a sample of
+// the rows of its owner and lookup tables: the first rows of each shard of
+// the owner table must be mapped to their keyspace id by the vindex, and
+// the first rows of each shard of the lookup table must map to a keyspace
+// id that has an owner row.
+type lookupVindexValidator struct {
+	s *VtctldServer
+
+	keyspace string
+	// ownerShards and lookupShards are the shards of the keyspaces of the
+	// owner and lookup tables.
+	ownerShards, lookupShards []*shardPrimary
+	table                     *vindexes.BaseTable
+	vindex                    *vindexes.ColumnVindex
+	lookup                    vindexes.LookupPlanable
+	lookupKeyspace            string
+	lookupTable               string
+	// fromColumns and toColumn are the columns of the lookup table.
+	fromColumns []string
+	toColumn    string
+	sampleSize  int
+	cursor      *lookupVindexCursor
+
+	results []string
+	issues  []*vtctldatapb.LookupVindexIssue
+}
+
+// newLookupVindexValidator returns the validator of the owned vindex cv of
+// table, or nil if the vindex is being backfilled, in which case it is not
+// used yet.
+func (s *VtctldServer) newLookupVindexValidator(ctx context.Context, keyspace string, shards []*shardPrimary, table *vindexes.BaseTable, cv *vindexes.ColumnVindex, params *vschemapb.Vindex, sampleSize int) (*lookupVindexValidator, error) {
+	if backfill, ok := cv.Vindex.(vindexes.LookupBackfill); ok && backfill.IsBackfilling() {
+		return nil, nil
+	}
+	lookup, ok := cv.Vindex.(vindexes.LookupPlanable)
+	if _, isLookup := cv.Vindex.(vindexes.Lookup); !ok || !isLookup {
+		return nil, fmt.Errorf("%v is not a lookup vindex", cv.Type)
+	}
+	if primary := table.ColumnVindexes[0]; primary.Vindex.NeedsVCursor() {
+		return nil, fmt.Errorf("its primary vindex %v is a lookup vindex", primary.Name)
+	}
+
+	lookupKeyspace, lookupTable, err := s.ws.SQLParser().ParseTable(params.Params["table"])
+	if err != nil {
+		return nil, err
+	}
+	if lookupKeyspace == "" {
+		lookupKeyspace = keyspace
+	}
+	lookupShards := shards
+	if lookupKeyspace != keyspace {
+		if lookupShards, err = s.validateShardPrimaries(ctx, lookupKeyspace); err != nil {
+			return nil, err
+		}
+	}
+	var fromColumns []string
+	for _, column := range strings.Split(params.Params["from"], ",") {
+		fromColumns = append(fromColumns, strings.TrimSpace(column))
+	}
+
+	return &lookupVindexValidator{
+		s:              s,
+		keyspace:       keyspace,
+		ownerShards:    shards,
+		lookupShards:   lookupShards,
+		table:          table,
+		vindex:         cv,
+		lookup:         lookup,
+		lookupKeyspace: lookupKeyspace,
+		lookupTable:    lookupTable,
+		fromColumns:    fromColumns,
+		toColumn:       params.Params["to"],
+		sampleSize:     sampleSize,
+		cursor: &lookupVindexCursor{
+			s:      s,
+			shards: lookupShards,
+		},
+	}, nil
+}
+
+func (v *lookupVindexValidator) validate(ctx context.Context) {
+	for _, shard := range v.ownerShards {
+		if err := v.validateOwnerRows(ctx, shard); err != nil {
+			v.results = append(v.results, fmt.Sprintf("cannot validate the rows of table %v of shard %v/%v against lookup vindex %v: %v",
+				v.table.Name, v.keyspace, shard.ShardName(), v.vindex.Name, err))
+		}
+	}
+	for _, shard := range v.lookupShards {
+		if err := v.validateLookupRows(ctx, shard); err != nil {
+			v.results = append(v.results, fmt.Sprintf("cannot validate the rows of lookup table %v of shard %v/%v: %v",
+				v.lookupTable, v.lookupKeyspace, shard.ShardName(), err))
+		}
+	}
+}
+
+// validateOwnerRows checks that the vindex maps the sampled owner rows of
+// shard to their keyspace id. The rows with NULL vindex columns are not
+// sampled, as they cannot be looked up.
+func (v *lookupVindexValidator) validateOwnerRows(ctx context.Context, shard *shardPrimary) error {
+	primary := v.table.ColumnVindexes[0]
+	columns := append(slices.Clone(primary.Columns), v.vindex.Columns...)
+	var buf strings.Builder
+	buf.WriteString("select ")
+	writeColumns(&buf, columns)
+	fmt.Fprintf(&buf, " from %s where ", sqlescape.EscapeID(v.table.Name.String()))
+	for i, column := range v.vindex.Columns {
+		if i > 0 {
+			buf.WriteString(" and ")
+		}
+		fmt.Fprintf(&buf, "%s is not null", sqlescape.EscapeID(column.String()))
+	}
+	fmt.Fprintf(&buf, " limit %d", v.sampleSize)
+	qr, err := v.s.executeFetch(ctx, shard.primary, buf.String(), v.sampleSize)
+	if err != nil || len(qr.Rows) == 0 {
+		return err
+	}
+
+	primaryValues := make([][]sqltypes.Value, 0, len(qr.Rows))
+	vindexValues := make([][]sqltypes.Value, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		primaryValues = append(primaryValues, row[:len(primary.Columns)])
+		vindexValues = append(vindexValues, row[len(primary.Columns):])
+	}
+	ksids, err := vindexes.Map(ctx, primary.Vindex, nil, primaryValues)
+	if err != nil {
+		return err
+	}
+	lookedUp, err := vindexes.Map(ctx, v.vindex.Vindex, v.cursor, vindexValues)
+	if err != nil {
+		return err
+	}
+
+	for i, dest := range ksids {
+		ksid, ok := dest.(key.DestinationKeyspaceID)
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(destinationKeyspaceIDs(lookedUp[i]), func(id []byte) bool { return bytes.Equal(id, ksid) }) {
+			continue
+		}
+		// The repair is the statement that vtgate runs to create the
+		// lookup row of an inserted owner row.
+		v.cursor.writes = nil
+		if err := v.vindex.Vindex.(vindexes.Lookup).Create(ctx, v.cursor, vindexValues[i:i+1], [][]byte{ksid}, false); err != nil {
+			return err
+		}
+		v.issues = append(v.issues, &vtctldatapb.LookupVindexIssue{
+			Vindex:   v.vindex.Name,
+			Type:     vtctldatapb.LookupVindexIssue_MISSING_LOOKUP_ROW,
+			Keyspace: v.keyspace,
+			Shard:    shard.ShardName(),
+			Row:      describeRow(columns, qr.Rows[i]),
+			Repair:   strings.Join(v.cursor.writes, "; "),
+		})
+	}
+	return nil
+}
+
+// validateLookupRows checks that the sampled lookup rows of shard map to a
+// keyspace id that has an owner row with the same vindex columns.
+func (v *lookupVindexValidator) validateLookupRows(ctx context.Context, shard *shardPrimary) error {
+	columns := make([]sqlparser.IdentifierCI, 0, len(v.fromColumns)+1)
+	for _, column := range v.fromColumns {
+		columns = append(columns, sqlparser.NewIdentifierCI(column))
+	}
+	columns = append(columns, sqlparser.NewIdentifierCI(v.toColumn))
+	var buf strings.Builder
+	buf.WriteString("select ")
+	writeColumns(&buf, columns)
+	fmt.Fprintf(&buf, " from %s limit %d", sqlescape.EscapeID(v.lookupTable), v.sampleSize)
+	qr, err := v.s.executeFetch(ctx, shard.primary, buf.String(), v.sampleSize)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range qr.Rows {
+		from, to := row[:len(v.fromColumns)], row[len(v.fromColumns)]
+		dests, err := v.lookup.MapResult(from[:1], []*sqltypes.Result{{Rows: [][]sqltypes.Value{{to}}}})
+		if err != nil {
+			return err
+		}
+		found := false
+		if ksids := destinationKeyspaceIDs(dests[0]); len(ksids) > 0 {
+			if found, err = v.ownerRowExists(ctx, from, ksids[0]); err != nil {
+				return err
+			}
+		}
+		if found {
+			continue
+		}
+		v.issues = append(v.issues, &vtctldatapb.LookupVindexIssue{
+			Vindex:   v.vindex.Name,
+			Type:     vtctldatapb.LookupVindexIssue_ORPHANED_LOOKUP_ROW,
+			Keyspace: v.lookupKeyspace,
+			Shard:    shard.ShardName(),
+			Row:      describeRow(columns, row),
+			Repair:   v.deleteLookupRow(columns, row),
+		})
+	}
+	return nil
+}
+
+// ownerRowExists returns whether the owner table has a row with the vindex
+// column values from and the keyspace id ksid.
+func (v *lookupVindexValidator) ownerRowExists(ctx context.Context, from []sqltypes.Value, ksid []byte) (bool, error) {
+	i := slices.IndexFunc(v.ownerShards, func(shard *shardPrimary) bool { return key.KeyRangeContains(shard.KeyRange, ksid) })
+	if i < 0 {
+		return false, nil
+	}
+	primary := v.table.ColumnVindexes[0]
+	var buf strings.Builder
+	buf.WriteString("select ")
+	writeColumns(&buf, primary.Columns)
+	fmt.Fprintf(&buf, " from %s where ", sqlescape.EscapeID(v.table.Name.String()))
+	for j, column := range v.vindex.Columns {
+		if j > 0 {
+			buf.WriteString(" and ")
+		}
+		fmt.Fprintf(&buf, "%s <=> ", sqlescape.EscapeID(column.String()))
+		from[j].EncodeSQLStringBuilder(&buf)
+	}
+	qr, err := v.s.executeFetch(ctx, v.ownerShards[i].primary, buf.String(), validateMaxRows)
+	if err != nil || len(qr.Rows) == 0 {
+		return false, err
+	}
+	ksids, err := vindexes.Map(ctx, primary.Vindex, nil, qr.Rows)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(ksids, func(dest key.ShardDestination) bool {
+		id, ok := dest.(key.DestinationKeyspaceID)
+		return ok && bytes.Equal(id, ksid)
+	}), nil
+}
+
+// deleteLookupRow returns the statement that deletes the lookup row.
+func (v *lookupVindexValidator) deleteLookupRow(columns []sqlparser.IdentifierCI, row []sqltypes.Value) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "delete from %s.%s where ", sqlescape.EscapeID(v.lookupKeyspace), sqlescape.EscapeID(v.lookupTable))
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteString(" and ")
+		}
+		buf.WriteString(sqlescape.EscapeID(column.String()))
+		if row[i].IsNull() {
+			buf.WriteString(" is null")
+			continue
+		}
+		buf.WriteString(" = ")
+		row[i].EncodeSQLStringBuilder(&buf)
+	}
+	buf.WriteString(" limit 1")
+	return buf.String()
+}
+
+// lookupVindexCursor is the vindexes.VCursor of a validated lookup vindex.
+// It runs the queries of the vindex on the primaries of all the shards of
+// the lookup keyspace, and records its other statements instead of running
+// them.
+type lookupVindexCursor struct {
+	s      *VtctldServer
+	shards []*shardPrimary
+	// writes are the recorded statements.
+	writes []string
+}
+
+var _ vindexes.VCursor = (*lookupVindexCursor)(nil)
+
+// Execute is part of the vindexes.VCursor interface.
+func (c *lookupVindexCursor) Execute(ctx context.Context, method string, query string, bindvars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error) {
+	stmt, err := c.s.ws.SQLParser().Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := stmt.(sqlparser.SelectStatement); !ok {
+		query, err := sqlparser.NewParsedQuery(stmt).GenerateQuery(bindvars, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.writes = append(c.writes, query)
+		return &sqltypes.Result{}, nil
+	}
+
+	// The tablets do not know the keyspace that qualifies the lookup table.
+	sqlparser.RemoveKeyspace(stmt)
+	if query, err = sqlparser.NewParsedQuery(stmt).GenerateQuery(bindvars, nil); err != nil {
+		return nil, err
+	}
+	result := &sqltypes.Result{}
+	for _, shard := range c.shards {
+		qr, err := c.s.executeFetch(ctx, shard.primary, query, validateMaxRows)
+		if err != nil {
+			return nil, err
+		}
+		result.Fields = qr.Fields
+		result.Rows = append(result.Rows, qr.Rows...)
+	}
+	return result, nil
+}
+
+// ExecuteKeyspaceID is part of the vindexes.VCursor interface. It is only
+// used by consistent lookup vindexes to check the owner rows of duplicate
+// lookup rows, and is a no-op.
+func (c *lookupVindexCursor) ExecuteKeyspaceID(ctx context.Context, keyspace string, ksid []byte, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError, autocommit bool) (*sqltypes.Result, error) {
+	return &sqltypes.Result{}, nil
+}
+
+// InTransactionAndIsDML is part of the vindexes.VCursor interface.
+func (c *lookupVindexCursor) InTransactionAndIsDML() bool {
+	return false
+}
+
+// LookupRowLockShardSession is part of the vindexes.VCursor interface.
+func (c *lookupVindexCursor) LookupRowLockShardSession() vtgatepb.CommitOrder {
+	return vtgatepb.CommitOrder_NORMAL
+}
+
+// ConnCollation is part of the vindexes.VCursor interface.
+func (c *lookupVindexCursor) ConnCollation() collations.ID {
+	return c.Environment().CollationEnv().DefaultConnectionCharset()
+}
+
+// Environment is part of the vindexes.VCursor interface.
+func (c *lookupVindexCursor) Environment() *vtenv.Environment {
+	return c.s.ws.Environment()
+}
+
+// destinationKeyspaceIDs returns the keyspace ids of a destination returned
+// by a vindex.
+func destinationKeyspaceIDs(dest key.ShardDestination) [][]byte {
+	switch dest := dest.(type) {
+	case key.DestinationKeyspaceID:
+		return [][]byte{dest}
+	case key.DestinationKeyspaceIDs:
+		return dest
+	}
+	return nil
+}
+
+func writeColumns(buf *strings.Builder, columns []sqlparser.IdentifierCI) {
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(sqlescape.EscapeID(column.String()))
+	}
+}
+
+// describeRow returns the values of row, e.g. "email='a@example.com', user_id=1".
+func describeRow(columns []sqlparser.IdentifierCI, row []sqltypes.Value) string {
+	var buf strings.Builder
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(column.String() + "=")
+		row[i].EncodeSQLStringBuilder(&buf)
+	}
+	return buf.String()
+}
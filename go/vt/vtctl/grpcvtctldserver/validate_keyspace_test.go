@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver/testutil"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+// queryTabletManagerClient answers ExecuteFetchAsApp with the result of
+// the query on the tablet.
+type queryTabletManagerClient struct {
+	*testutil.TabletManagerClient
+	// results are keyed by tablet alias, then by query.
+	results map[string]map[string]*sqltypes.Result
+	// positions are the successive primary positions.
+	positions []string
+	// calls are the replication calls, e.g. "StopReplication(zone1-0000000101)".
+	calls []string
+}
+
+func (tmc *queryTabletManagerClient) PrimaryPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	position := tmc.positions[0]
+	tmc.positions = tmc.positions[1:]
+	return position, nil
+}
+
+func (tmc *queryTabletManagerClient) StopReplication(ctx context.Context, tablet *topodatapb.Tablet) error {
+	tmc.calls = append(tmc.calls, fmt.Sprintf("StopReplication(%s)", topoproto.TabletAliasString(tablet.Alias)))
+	return nil
+}
+
+func (tmc *queryTabletManagerClient) StartReplication(ctx context.Context, tablet *topodatapb.Tablet, semiSync bool) error {
+	tmc.calls = append(tmc.calls, fmt.Sprintf("StartReplication(%s)", topoproto.TabletAliasString(tablet.Alias)))
+	return nil
+}
+
+func (tmc *queryTabletManagerClient) StartReplicationUntilAfter(ctx context.Context, tablet *topodatapb.Tablet, position string, waitTime time.Duration) error {
+	tmc.calls = append(tmc.calls, fmt.Sprintf("StartReplicationUntilAfter(%s, %s)", topoproto.TabletAliasString(tablet.Alias), position))
+	return nil
+}
+
+func (tmc *queryTabletManagerClient) WaitForPosition(ctx context.Context, tablet *topodatapb.Tablet, position string) error {
+	tmc.calls = append(tmc.calls, fmt.Sprintf("WaitForPosition(%s, %s)", topoproto.TabletAliasString(tablet.Alias), position))
+	return nil
+}
+
+func (tmc *queryTabletManagerClient) ExecuteFetchAsApp(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, req *tabletmanagerdatapb.ExecuteFetchAsAppRequest) (*querypb.QueryResult, error) {
+	alias := topoproto.TabletAliasString(tablet.Alias)
+	qr, ok := tmc.results[alias][string(req.Query)]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query on %s: %s", alias, req.Query)
+	}
+	return sqltypes.ResultToProto3(qr), nil
+}
+
+func TestValidateKeyspaceDeep(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+
+	users := sqltypes.MakeTestFields("id|email", "int64|varchar")
+	lookups := sqltypes.MakeTestFields("email|user_id", "varchar|int64")
+	checksums := sqltypes.MakeTestFields("Table|Checksum", "varchar|int64")
+	emptyLookup := sqltypes.MakeTestResult(lookups)
+	tmc := &queryTabletManagerClient{
+		TabletManagerClient: &testutil.TabletManagerClient{
+			GetSchemaResults: map[string]struct {
+				Schema *tabletmanagerdatapb.SchemaDefinition
+				Error  error
+			}{
+				"zone1-0000000100": {Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "user", Type: "BASE TABLE"}, {Name: "v", Type: "VIEW"}},
+				}},
+				"zone1-0000000200": {Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{Name: "user", Type: "BASE TABLE"}},
+				}},
+			},
+		},
+		results: map[string]map[string]*sqltypes.Result{
+			// The checksum of the replica differs, and user 5 is not in
+			// the shard it is mapped to.
+			"zone1-0000000100": {
+				"checksum table `user`": sqltypes.MakeTestResult(checksums, "vt_ks.user|1234"),
+				"select `id`, `email` from `user` where `email` is not null limit 10": sqltypes.MakeTestResult(users, "1|a@example.com", "2|b@example.com"),
+				"select `id` from `user` where `email` <=> 'a@example.com'":           sqltypes.MakeTestResult(users[:1], "1"),
+				"select `id` from `user` where `email` <=> 'b@example.com'":           sqltypes.MakeTestResult(users[:1], "2"),
+				"select `id` from `user` where `email` <=> 'z@example.com'":           sqltypes.MakeTestResult(users[:1]),
+			},
+			"zone1-0000000101": {
+				"checksum table `user`": sqltypes.MakeTestResult(checksums, "vt_ks.user|5678"),
+			},
+			// User 4 has no lookup row.
+			"zone1-0000000200": {
+				"select `id`, `email` from `user` where `email` is not null limit 10": sqltypes.MakeTestResult(users, "4|d@example.com"),
+			},
+			"zone1-0000000300": {
+				"select `email`, `user_id` from `email_idx` limit 10":                   sqltypes.MakeTestResult(lookups, "a@example.com|1", "b@example.com|2", "z@example.com|5"),
+				"select email, user_id from email_idx where email in ('a@example.com')": sqltypes.MakeTestResult(lookups, "a@example.com|1"),
+				"select email, user_id from email_idx where email in ('b@example.com')": sqltypes.MakeTestResult(lookups, "b@example.com|2"),
+				"select email, user_id from email_idx where email in ('d@example.com')": emptyLookup,
+			},
+		},
+		positions: []string{"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10", "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10"},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_PRIMARY},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101}, Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_REPLICA},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 200}, Keyspace: "ks", Shard: "80-", Type: topodatapb.TabletType_PRIMARY},
+		&topodatapb.Tablet{Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 300}, Keyspace: "lookup", Shard: "0", Type: topodatapb.TabletType_PRIMARY},
+	)
+	err := ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: "ks",
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"hash": {Type: "hash"},
+				"email_idx": {
+					Type:   "lookup_hash_unique",
+					Params: map[string]string{"table": "lookup.email_idx", "from": "email", "to": "user_id"},
+					Owner:  "user",
+				},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"user": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{
+						{Name: "hash", Column: "id"},
+						{Name: "email_idx", Column: "email"},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := vtctld.ValidateKeyspace(ctx, &vtctldatapb.ValidateKeyspaceRequest{
+		Keyspace:       "ks",
+		Deep:           true,
+		SampleSize:     10,
+		ChecksumTables: []string{"user"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`checksum of table user on zone1-0000000101 is "5678", but "1234" on primary zone1-0000000100 at the same position MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10`,
+	}, resp.Results)
+	// The replica is compared at the position of the primary.
+	assert.Equal(t, []string{
+		"StopReplication(zone1-0000000101)",
+		"StartReplicationUntilAfter(zone1-0000000101, MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10)",
+		"WaitForPosition(zone1-0000000101, MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10)",
+		"StartReplication(zone1-0000000101)",
+	}, tmc.calls)
+	utils.MustMatch(t, []*vtctldatapb.LookupVindexIssue{{
+		Vindex:   "email_idx",
+		Type:     vtctldatapb.LookupVindexIssue_MISSING_LOOKUP_ROW,
+		Keyspace: "ks",
+		Shard:    "80-",
+		Row:      "id=4, email='d@example.com'",
+		Repair:   "insert into lookup.email_idx(email, user_id) values ('d@example.com', 4)",
+	}, {
+		Vindex:   "email_idx",
+		Type:     vtctldatapb.LookupVindexIssue_ORPHANED_LOOKUP_ROW,
+		Keyspace: "lookup",
+		Shard:    "0",
+		Row:      "email='z@example.com', user_id=5",
+		Repair:   "delete from `lookup`.`email_idx` where `email` = 'z@example.com' and `user_id` = 5 limit 1",
+	}}, resp.LookupVindexIssues)
+
+	// The checksums cannot be compared if the primary is written to while it
+	// checksums the tables.
+	tmc.calls = nil
+	tmc.positions = []string{"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-10", "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-11"}
+	resp, err = vtctld.ValidateKeyspace(ctx, &vtctldatapb.ValidateKeyspaceRequest{
+		Keyspace:       "ks",
+		Deep:           true,
+		SampleSize:     10,
+		ChecksumTables: []string{"user"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"cannot compare the table checksums of shard ks/-80: primary zone1-0000000100 was written to while it checksummed the tables",
+	}, resp.Results)
+	assert.Equal(t, []string{"StopReplication(zone1-0000000101)", "StartReplication(zone1-0000000101)"}, tmc.calls)
+
+	// Without checksum tables, no table is checksummed.
+	tmc.calls = nil
+	resp, err = vtctld.ValidateKeyspace(ctx, &vtctldatapb.ValidateKeyspaceRequest{Keyspace: "ks", Deep: true, SampleSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Results)
+	assert.Empty(t, tmc.calls)
+	assert.Len(t, resp.LookupVindexIssues, 2)
+
+	// Without deep mode, the data is not validated.
+	resp, err = vtctld.ValidateKeyspace(ctx, &vtctldatapb.ValidateKeyspaceRequest{Keyspace: "ks"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Results)
+	assert.Empty(t, resp.LookupVindexIssues)
+}
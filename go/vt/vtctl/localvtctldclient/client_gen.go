@@ -165,6 +165,11 @@ func (client *localVtctldClient) BackupShard(ctx context.Context, in *vtctldatap
 	return stream, nil
 }
 
+// BreakLock is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) BreakLock(ctx context.Context, in *vtctldatapb.BreakLockRequest, opts ...grpc.CallOption) (*vtctldatapb.BreakLockResponse, error) {
+	return client.s.BreakLock(ctx, in)
+}
+
 // CancelSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CancelSchemaMigration(ctx context.Context, in *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
 	return client.s.CancelSchemaMigration(ctx, in)
@@ -190,6 +195,57 @@ func (client *localVtctldClient) CleanupSchemaMigration(ctx context.Context, in
 	return client.s.CleanupSchemaMigration(ctx, in)
 }
 
+type cloneFromTabletStreamAdapter struct {
+	*grpcshim.BidiStream
+	ch chan *vtctldatapb.CloneFromTabletResponse
+}
+
+func (stream *cloneFromTabletStreamAdapter) Recv() (*vtctldatapb.CloneFromTabletResponse, error) {
+	select {
+	case <-stream.Context().Done():
+		return nil, stream.Context().Err()
+	case <-stream.Closed():
+		// Stream has been closed for future sends. If there are messages that
+		// have already been sent, receive them until there are no more. After
+		// all sent messages have been received, Recv will return the CloseErr.
+		select {
+		case msg := <-stream.ch:
+			return msg, nil
+		default:
+			return nil, stream.CloseErr()
+		}
+	case err := <-stream.ErrCh:
+		return nil, err
+	case msg := <-stream.ch:
+		return msg, nil
+	}
+}
+
+func (stream *cloneFromTabletStreamAdapter) Send(msg *vtctldatapb.CloneFromTabletResponse) error {
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-stream.Closed():
+		return grpcshim.ErrStreamClosed
+	case stream.ch <- msg:
+		return nil
+	}
+}
+
+// CloneFromTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) CloneFromTablet(ctx context.Context, in *vtctldatapb.CloneFromTabletRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[vtctldatapb.CloneFromTabletResponse], error) {
+	stream := &cloneFromTabletStreamAdapter{
+		BidiStream: grpcshim.NewBidiStream(ctx),
+		ch:         make(chan *vtctldatapb.CloneFromTabletResponse, 1),
+	}
+	go func() {
+		err := client.s.CloneFromTablet(in, stream)
+		stream.CloseWithError(err)
+	}()
+
+	return stream, nil
+}
+
 // CompleteSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CompleteSchemaMigration(ctx context.Context, in *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
 	return client.s.CompleteSchemaMigration(ctx, in)
@@ -280,6 +336,11 @@ func (client *localVtctldClient) ForceCutOverSchemaMigration(ctx context.Context
 	return client.s.ForceCutOverSchemaMigration(ctx, in)
 }
 
+// GetBackupFreshness is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetBackupFreshness(ctx context.Context, in *vtctldatapb.GetBackupFreshnessRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupFreshnessResponse, error) {
+	return client.s.GetBackupFreshness(ctx, in)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	return client.s.GetBackups(ctx, in)
@@ -320,6 +381,11 @@ func (client *localVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldata
 	return client.s.GetKeyspaces(ctx, in)
 }
 
+// GetLockHolders is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetLockHolders(ctx context.Context, in *vtctldatapb.GetLockHoldersRequest, opts ...grpc.CallOption) (*vtctldatapb.GetLockHoldersResponse, error) {
+	return client.s.GetLockHolders(ctx, in)
+}
+
 // GetMirrorRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldatapb.GetMirrorRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMirrorRulesResponse, error) {
 	return client.s.GetMirrorRules(ctx, in)
@@ -330,6 +396,11 @@ func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctlda
 	return client.s.GetPermissions(ctx, in)
 }
 
+// GetReparentReports is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetReparentReports(ctx context.Context, in *vtctldatapb.GetReparentReportsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetReparentReportsResponse, error) {
+	return client.s.GetReparentReports(ctx, in)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	return client.s.GetRoutingRules(ctx, in)
@@ -505,6 +576,11 @@ func (client *localVtctldClient) PlannedReparentShard(ctx context.Context, in *v
 	return client.s.PlannedReparentShard(ctx, in)
 }
 
+// PurgeBinaryLogs is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PurgeBinaryLogs(ctx context.Context, in *vtctldatapb.PurgeBinaryLogsRequest, opts ...grpc.CallOption) (*vtctldatapb.PurgeBinaryLogsResponse, error) {
+	return client.s.PurgeBinaryLogs(ctx, in)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	return client.s.RebuildKeyspaceGraph(ctx, in)
@@ -791,6 +867,16 @@ func (client *localVtctldClient) WorkflowDelete(ctx context.Context, in *vtctlda
 	return client.s.WorkflowDelete(ctx, in)
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowExport(ctx context.Context, in *vtctldatapb.WorkflowExportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowExportResponse, error) {
+	return client.s.WorkflowExport(ctx, in)
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowImport(ctx context.Context, in *vtctldatapb.WorkflowImportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowImportResponse, error) {
+	return client.s.WorkflowImport(ctx, in)
+}
+
 // WorkflowMirrorTraffic is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) WorkflowMirrorTraffic(ctx context.Context, in *vtctldatapb.WorkflowMirrorTrafficRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowMirrorTrafficResponse, error) {
 	return client.s.WorkflowMirrorTraffic(ctx, in)
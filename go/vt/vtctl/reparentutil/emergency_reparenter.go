@@ -63,10 +63,18 @@ type EmergencyReparentOptions struct {
 	PreventCrossCellPromotion bool
 	ExpectedPrimaryAlias      *topodatapb.TabletAlias
 
+	// Initiator describes what started the reparent, e.g. a VTOrc recovery.
+	// It is recorded in the reparent report.
+	Initiator string
+	// Detections are the problems that led to the reparent. They are recorded
+	// in the reparent report.
+	Detections []*topodatapb.ReparentReport_Detection
+
 	// Private options managed internally. We use value passing to avoid leaking
 	// these details back out.
 	lockAction string
 	durability policy.Durabler
+	report     *reparentReport
 }
 
 // counters for Emergency Reparent Shard
@@ -117,6 +125,7 @@ func (erp *EmergencyReparenter) ReparentShard(ctx context.Context, keyspace stri
 	// dispatch success or failure of ERS
 	startTime := time.Now()
 	ev := &events.Reparent{}
+	opts.report = newReparentReport(keyspace, shard, opts, startTime)
 	defer func() {
 		reparentShardOpTimings.Add("EmergencyReparentShard", time.Since(startTime))
 		switch err {
@@ -127,6 +136,7 @@ func (erp *EmergencyReparenter) ReparentShard(ctx context.Context, keyspace stri
 			ersCounter.Add(append(statsLabels, failureResult), 1)
 			event.DispatchUpdate(ev, "failed EmergencyReparentShard: "+err.Error())
 		}
+		erp.saveReparentReport(ctx, opts.report, ev.NewPrimary, err)
 	}()
 
 	err = erp.reparentShardLocked(ctx, ev, keyspace, shard, opts)
@@ -134,6 +144,25 @@ func (erp *EmergencyReparenter) ReparentShard(ctx context.Context, keyspace stri
 	return ev, err
 }
 
+// saveReparentReport saves the report of a reparent in the topo, for incident
+// reviews. Failing to save it does not fail the reparent.
+func (erp *EmergencyReparenter) saveReparentReport(ctx context.Context, report *reparentReport, newPrimary *topodatapb.Tablet, reparentErr error) {
+	// The report of a reparent that failed before the shard record was read
+	// is not saved, as the shard may not exist.
+	if !report.shardRead {
+		return
+	}
+
+	// The reparent may have failed because its context expired, so the report
+	// is saved with a fresh timeout.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), topo.RemoteOperationTimeout)
+	defer cancel()
+
+	if err := erp.ts.SaveReparentReport(ctx, report.finish(newPrimary, reparentErr)); err != nil {
+		erp.logger.Warningf("failed to save the reparent report of %s/%s: %v", report.report.Keyspace, report.report.Shard, err)
+	}
+}
+
 func (erp *EmergencyReparenter) getLockAction(newPrimaryAlias *topodatapb.TabletAlias) string {
 	action := "EmergencyReparentShard"
 
@@ -195,11 +224,13 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		err = vterrors.Wrapf(err, "restart replication cleanup failed: %v", cleanupErr)
 	}()
 
+	opts.report.startStep("reading the shard record and the tablets")
 	shardInfo, err = erp.ts.GetShard(ctx, keyspace, shard)
 	if err != nil {
 		return err
 	}
 	ev.ShardInfo = *shardInfo
+	opts.report.setShard(shardInfo)
 
 	if opts.ExpectedPrimaryAlias != nil && !topoproto.TabletAliasEqual(opts.ExpectedPrimaryAlias, shardInfo.PrimaryAlias) {
 		return vterrors.Errorf(
@@ -239,7 +270,9 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	}
 
 	// Stop replication on all the tablets and build their status map
+	opts.report.startStep("stopping replication")
 	stoppedReplicationSnapshot, err = stopReplicationAndBuildStatusMaps(ctx, erp.tmc, ev, tabletMap, shardInfo.PrimaryAlias, topo.RemoteOperationTimeout, opts.IgnoreReplicas, opts.NewPrimaryAlias, opts.durability, opts.WaitAllTablets, erp.logger)
+	opts.report.recordReplicationStates(tabletMap, stoppedReplicationSnapshot)
 
 	// If stoppedReplicationSnapshot is not nil, it means we have stopped replication on at
 	// least one replica. We'll keep track of the replicas that had their IO threads stopped
@@ -260,29 +293,36 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	}
 
 	// find the positions of all the valid candidates.
+	opts.report.startStep("finding the candidates")
 	validCandidates, isGTIDBased, err = FindPositionsOfAllCandidates(stoppedReplicationSnapshot.statusMap, stoppedReplicationSnapshot.primaryStatusMap)
 	if err != nil {
 		return err
 	}
+	opts.report.recordCandidates(validCandidates, tabletMap)
 	// Restrict the valid candidates list. We remove any tablet which is of the type DRAINED, RESTORE or BACKUP.
 	validCandidates, err = restrictValidCandidates(validCandidates, tabletMap)
 	if err != nil {
 		return err
-	} else if len(validCandidates) == 0 {
+	}
+	opts.report.rejectCandidates(validCandidates, "tablets of type BACKUP, RESTORE or DRAINED can't be promoted")
+	if len(validCandidates) == 0 {
 		return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no valid candidates for emergency reparent")
 	}
 
 	// Wait for all candidates to apply relay logs
+	opts.report.startStep("waiting for the relay logs to apply")
 	if err = erp.waitForAllRelayLogsToApply(ctx, validCandidates, tabletMap, stoppedReplicationSnapshot.statusMap, opts.WaitReplicasTimeout); err != nil {
 		return err
 	}
 
 	// For GTID based replication, we will run errant GTID detection.
 	if isGTIDBased {
+		opts.report.startStep("detecting errant GTIDs")
 		validCandidates, err = erp.findErrantGTIDs(ctx, validCandidates, stoppedReplicationSnapshot.statusMap, tabletMap, opts.WaitReplicasTimeout)
 		if err != nil {
 			return err
 		}
+		opts.report.rejectCandidates(validCandidates, "the tablet has errant GTIDs")
 		if len(validCandidates) == 0 {
 			return vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "no valid candidates for emergency reparent: all candidates have errant GTIDs")
 		}
@@ -294,10 +334,12 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	// Here we also check for split brain scenarios and check that the selected replica must be more advanced than all the other valid candidates.
 	// We fail in case there is a split brain detected.
 	// The validCandidateTablets list is sorted by the replication positions with ties broken by promotion rules.
+	opts.report.startStep("selecting the new primary")
 	intermediateSource, validCandidateTablets, err = erp.findMostAdvanced(validCandidates, tabletMap, opts)
 	if err != nil {
 		return err
 	}
+	opts.report.setIntermediateSource(intermediateSource)
 	erp.logger.Infof("intermediate source selected - %v", intermediateSource.Alias)
 
 	// After finding the intermediate source, we want to filter the valid candidate list by the following criteria -
@@ -334,6 +376,7 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		// we do not promote the tablet or change the shard record. We only change the replication for all the other tablets
 		// it also returns the list of the tablets that started replication successfully including itself part of the validCandidateTablets list.
 		// These are the candidates that we can use to find a replacement.
+		opts.report.startStep("reparenting the tablets to the intermediate source")
 		validReplacementCandidates, err = erp.promoteIntermediateSource(ctx, ev, intermediateSource, tabletMap, stoppedReplicationSnapshot.statusMap, validCandidateTablets, opts)
 		if err != nil {
 			return err
@@ -349,6 +392,7 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 
 		// if our better candidate is different from our intermediate source, then we wait for it to catch up to the intermediate source
 		if !topoproto.TabletAliasEqual(betterCandidate.Alias, intermediateSource.Alias) {
+			opts.report.startStep("waiting for the new primary to catch up")
 			err = waitForCatchUp(ctx, erp.tmc, erp.logger, betterCandidate, intermediateSource, opts.WaitReplicasTimeout)
 			if err != nil {
 				return err
@@ -370,6 +414,7 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 	// Since the new primary tablet belongs to the validCandidateTablets list, we no longer need any additional constraint checks
 
 	// Final step is to promote our primary candidate
+	opts.report.startStep("promoting the new primary")
 	_, err = erp.reparentReplicas(ctx, ev, newPrimary, tabletMap, stoppedReplicationSnapshot.statusMap, opts, false /* intermediateReparent */)
 	if err != nil {
 		return err
@@ -854,6 +899,7 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 		// Remove tablets which have MustNot promote rule since they must never be promoted
 		if policy.PromotionRule(opts.durability, tablet) == promotionrule.MustNot {
 			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it has the Must Not promote rule", tabletAliasStr)
+			opts.report.rejectCandidate(tabletAliasStr, "the tablet has the MustNot promotion rule")
 			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
 				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s has a must not promotion rule", topoproto.TabletAliasString(opts.NewPrimaryAlias))
 			}
@@ -862,6 +908,7 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 		// If ERS is configured to prevent cross cell promotions, remove any tablet not from the same cell as the previous primary
 		if opts.PreventCrossCellPromotion && prevPrimary != nil && tablet.Alias.Cell != prevPrimary.Alias.Cell {
 			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it isn't in the same cell as the previous primary", tabletAliasStr)
+			opts.report.rejectCandidate(tabletAliasStr, "the tablet is not in the cell of the previous primary")
 			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
 				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s is is a different cell as the previous primary", topoproto.TabletAliasString(opts.NewPrimaryAlias))
 			}
@@ -870,6 +917,7 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 		// Remove any tablet which cannot make forward progress using the list of tablets we have reached
 		if !canEstablishForTablet(opts.durability, tablet, tabletsReachable) {
 			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it will not be able to make forward progress on promotion with the tablets currently reachable", tabletAliasStr)
+			opts.report.rejectCandidate(tabletAliasStr, "the tablet can't make forward progress with the tablets that are reachable")
 			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
 				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s will not be able to make forward progress on being promoted", topoproto.TabletAliasString(opts.NewPrimaryAlias))
 			}
@@ -885,6 +933,9 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 		}
 	}
 	if len(restrictedValidTablets) > 0 {
+		for _, tablet := range notPreferredValidTablets {
+			opts.report.rejectCandidate(topoproto.TabletAliasString(tablet.Alias), "the tablet is taking a backup")
+		}
 		return restrictedValidTablets, nil
 	}
 
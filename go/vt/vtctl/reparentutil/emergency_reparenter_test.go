@@ -37,6 +37,7 @@ import (
 
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/sets"
+	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
@@ -3122,6 +3123,150 @@ func TestEmergencyReparenterStats(t *testing.T) {
 	require.Equal(t, map[string]int64{"All": 2, "EmergencyReparentShard": 2}, reparentShardOpTimings.Counts())
 }
 
+func TestEmergencyReparenterReport(t *testing.T) {
+	tmc := &testutil.TabletManagerClient{
+		PopulateReparentJournalResults: map[string]error{
+			"zone1-0000000102": nil,
+		},
+		PromoteReplicaResults: map[string]struct {
+			Result string
+			Error  error
+		}{
+			"zone1-0000000102": {
+				Result: "ok",
+			},
+		},
+		SetReplicationSourceResults: map[string]error{
+			"zone1-0000000100": nil,
+			"zone1-0000000101": nil,
+		},
+		StopReplicationAndGetStatusResults: map[string]struct {
+			StopStatus *replicationdatapb.StopReplicationStatus
+			Error      error
+		}{
+			"zone1-0000000100": {
+				Error: errors.New("primary is down"),
+			},
+			"zone1-0000000101": {
+				StopStatus: &replicationdatapb.StopReplicationStatus{
+					Before: &replicationdatapb.Status{IoState: int32(replication.ReplicationStateRunning), SqlState: int32(replication.ReplicationStateRunning)},
+					After: &replicationdatapb.Status{
+						SourceUuid:       "3E11FA47-71CA-11E1-9E33-C80AA9429562",
+						RelayLogPosition: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-21",
+						BackupRunning:    true,
+					},
+				},
+			},
+			"zone1-0000000102": {
+				StopStatus: &replicationdatapb.StopReplicationStatus{
+					Before: &replicationdatapb.Status{IoState: int32(replication.ReplicationStateRunning), SqlState: int32(replication.ReplicationStateRunning)},
+					After: &replicationdatapb.Status{
+						SourceUuid:       "3E11FA47-71CA-11E1-9E33-C80AA9429562",
+						RelayLogPosition: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-26",
+					},
+				},
+			},
+		},
+		WaitForPositionResults: map[string]map[string]error{
+			"zone1-0000000101": {
+				"MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-21": nil,
+			},
+			"zone1-0000000102": {
+				"MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-26": nil,
+			},
+		},
+	}
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Type:     topodatapb.TabletType_PRIMARY,
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Type:     topodatapb.TabletType_REPLICA,
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+		{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+			Type:     topodatapb.TabletType_REPLICA,
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+		},
+	}
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{Keyspace: "testkeyspace", Name: "-"})
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardPrimary: true,
+	}, tablets...)
+
+	detection := &topodatapb.ReparentReport_Detection{
+		Tablet:   tablets[0].Alias,
+		Analysis: "DeadPrimary",
+	}
+	erp := NewEmergencyReparenter(ts, tmc, logutil.NewMemoryLogger())
+	_, err := erp.ReparentShard(ctx, "testkeyspace", "-", EmergencyReparentOptions{
+		Initiator:  "test",
+		Detections: []*topodatapb.ReparentReport_Detection{detection},
+	})
+	require.NoError(t, err)
+
+	reports, err := ts.GetReparentReports(ctx, "testkeyspace", "-", 0)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	report := reports[0]
+
+	assert.Equal(t, "test", report.Initiator)
+	utils.MustMatch(t, []*topodatapb.ReparentReport_Detection{detection}, report.Detections)
+	utils.MustMatch(t, tablets[0].Alias, report.PreviousPrimary)
+	utils.MustMatch(t, tablets[2].Alias, report.NewPrimary)
+	utils.MustMatch(t, tablets[2].Alias, report.IntermediateSource)
+	assert.Empty(t, report.Error)
+	assert.NotNil(t, report.Duration)
+
+	var steps []string
+	for _, step := range report.Timeline {
+		steps = append(steps, step.Name)
+		assert.NotNil(t, step.Duration, step.Name)
+	}
+	assert.Equal(t, []string{
+		"reading the shard record and the tablets",
+		"stopping replication",
+		"finding the candidates",
+		"waiting for the relay logs to apply",
+		"detecting errant GTIDs",
+		"selecting the new primary",
+		"promoting the new primary",
+	}, steps)
+
+	utils.MustMatch(t, []*topodatapb.ReparentReport_TabletState{
+		{Alias: tablets[0].Alias, Error: "replication could not be stopped on the tablet"},
+		{Alias: tablets[1].Alias, Reachable: true, IoThreadRunning: true, Position: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-21"},
+		{Alias: tablets[2].Alias, Reachable: true, IoThreadRunning: true, Position: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-26"},
+	}, report.ReplicationStates)
+	utils.MustMatch(t, []*topodatapb.ReparentReport_Candidate{
+		{Alias: tablets[1].Alias, Position: "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-21", RejectedReason: "the tablet is taking a backup"},
+		{Alias: tablets[2].Alias, Position: "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-26"},
+	}, report.Candidates)
+
+	// The report of a failed reparent records its error.
+	_, err = erp.ReparentShard(ctx, "testkeyspace", "-", EmergencyReparentOptions{
+		ExpectedPrimaryAlias: tablets[1].Alias,
+	})
+	require.ErrorContains(t, err, "is not equal to expected alias")
+
+	reports, err = ts.GetReparentReports(ctx, "testkeyspace", "-", 0)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Contains(t, reports[0].Error, "is not equal to expected alias")
+	assert.Nil(t, reports[0].NewPrimary)
+	utils.MustMatch(t, tablets[0].Alias, reports[0].PreviousPrimary)
+}
+
 func TestEmergencyReparenter_findMostAdvanced(t *testing.T) {
 	sid1 := replication.SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
 	mysqlGTID1 := replication.Mysql56GTID{
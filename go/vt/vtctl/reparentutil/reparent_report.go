@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// reparentReport assembles the report of an emergency reparent as it runs.
+// The methods that record the steps of the reparent can be called on a nil
+// report, which records nothing, so that the steps can be tested alone.
+type reparentReport struct {
+	report *topodatapb.ReparentReport
+
+	// step is the step of the timeline that is running, if any.
+	step      *topodatapb.ReparentReport_Step
+	stepStart time.Time
+
+	start time.Time
+	// shardRead is true once the shard record was read.
+	shardRead bool
+	// candidates are the candidates of the report by alias.
+	candidates map[string]*topodatapb.ReparentReport_Candidate
+}
+
+func newReparentReport(keyspace, shard string, opts EmergencyReparentOptions, start time.Time) *reparentReport {
+	return &reparentReport{
+		report: &topodatapb.ReparentReport{
+			Keyspace:   keyspace,
+			Shard:      shard,
+			Initiator:  opts.Initiator,
+			StartTime:  protoutil.TimeToProto(start),
+			Detections: opts.Detections,
+		},
+		start:      start,
+		candidates: make(map[string]*topodatapb.ReparentReport_Candidate),
+	}
+}
+
+// startStep ends the running step of the timeline and starts a new one.
+func (r *reparentReport) startStep(name string) {
+	if r == nil {
+		return
+	}
+
+	now := time.Now()
+	r.endStep(now)
+	r.step = &topodatapb.ReparentReport_Step{
+		Name:      name,
+		StartTime: protoutil.TimeToProto(now),
+	}
+	r.stepStart = now
+	r.report.Timeline = append(r.report.Timeline, r.step)
+}
+
+func (r *reparentReport) endStep(now time.Time) {
+	if r.step != nil {
+		r.step.Duration = protoutil.DurationToProto(now.Sub(r.stepStart))
+		r.step = nil
+	}
+}
+
+// setShard records that the shard record was read, and its primary.
+func (r *reparentReport) setShard(shardInfo *topo.ShardInfo) {
+	if r == nil {
+		return
+	}
+	r.shardRead = true
+	r.report.PreviousPrimary = shardInfo.PrimaryAlias.CloneVT()
+}
+
+// setIntermediateSource records the most advanced candidate.
+func (r *reparentReport) setIntermediateSource(tablet *topodatapb.Tablet) {
+	if r == nil {
+		return
+	}
+	r.report.IntermediateSource = tablet.Alias.CloneVT()
+}
+
+// recordReplicationStates records the replication state of all the tablets
+// of the shard when replication was stopped.
+func (r *reparentReport) recordReplicationStates(tabletMap map[string]*topo.TabletInfo, snapshot *replicationSnapshot) {
+	if r == nil || snapshot == nil {
+		return
+	}
+
+	for alias, tabletInfo := range tabletMap {
+		state := &topodatapb.ReparentReport_TabletState{
+			Alias: tabletInfo.Alias.CloneVT(),
+		}
+		if status, ok := snapshot.statusMap[alias]; ok {
+			state.Reachable = true
+			if ioThreadWasRunning, err := replicaIOThreadWasRunning(status); err == nil {
+				state.IoThreadRunning = ioThreadWasRunning
+			}
+			if status.After != nil {
+				state.Position = status.After.RelayLogPosition
+			}
+		} else if status, ok := snapshot.primaryStatusMap[alias]; ok {
+			state.Reachable = true
+			state.WasPrimary = true
+			state.Position = status.Position
+		} else {
+			state.Error = "replication could not be stopped on the tablet"
+		}
+		r.report.ReplicationStates = append(r.report.ReplicationStates, state)
+	}
+	slices.SortFunc(r.report.ReplicationStates, func(a, b *topodatapb.ReparentReport_TabletState) int {
+		return strings.Compare(topoproto.TabletAliasString(a.Alias), topoproto.TabletAliasString(b.Alias))
+	})
+}
+
+// recordCandidates records the candidates for the promotion and their
+// positions.
+func (r *reparentReport) recordCandidates(validCandidates map[string]*RelayLogPositions, tabletMap map[string]*topo.TabletInfo) {
+	if r == nil {
+		return
+	}
+
+	for alias, positions := range validCandidates {
+		tabletInfo, ok := tabletMap[alias]
+		if !ok {
+			continue
+		}
+		candidate := &topodatapb.ReparentReport_Candidate{
+			Alias:    tabletInfo.Alias.CloneVT(),
+			Position: replication.EncodePosition(positions.Combined),
+		}
+		r.candidates[alias] = candidate
+		r.report.Candidates = append(r.report.Candidates, candidate)
+	}
+	slices.SortFunc(r.report.Candidates, func(a, b *topodatapb.ReparentReport_Candidate) int {
+		return strings.Compare(topoproto.TabletAliasString(a.Alias), topoproto.TabletAliasString(b.Alias))
+	})
+}
+
+// rejectCandidates records the reason the candidates that are not in
+// remaining anymore were rejected.
+func (r *reparentReport) rejectCandidates(remaining map[string]*RelayLogPositions, reason string) {
+	if r == nil {
+		return
+	}
+
+	for alias := range r.candidates {
+		if _, ok := remaining[alias]; !ok {
+			r.rejectCandidate(alias, reason)
+		}
+	}
+}
+
+// rejectCandidate records the reason a candidate was rejected, unless it was
+// already rejected.
+func (r *reparentReport) rejectCandidate(alias string, reason string) {
+	if r == nil {
+		return
+	}
+
+	if candidate, ok := r.candidates[alias]; ok && candidate.RejectedReason == "" {
+		candidate.RejectedReason = reason
+	}
+}
+
+// finish ends the report of a reparent, which promoted newPrimary if it is
+// not nil, and failed with err if it is not nil.
+func (r *reparentReport) finish(newPrimary *topodatapb.Tablet, err error) *topodatapb.ReparentReport {
+	now := time.Now()
+	r.endStep(now)
+	r.report.Duration = protoutil.DurationToProto(now.Sub(r.start))
+	if newPrimary != nil {
+		r.report.NewPrimary = newPrimary.Alias.CloneVT()
+	}
+	if err != nil {
+		r.report.Error = err.Error()
+	}
+	return r.report
+}
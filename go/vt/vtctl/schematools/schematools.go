@@ -18,6 +18,8 @@ package schematools
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -47,6 +49,14 @@ func GetSchema(ctx context.Context, ts *topo.Server, tmc tmclient.TabletManagerC
 	return sd, nil
 }
 
+// TableDigest returns a digest of the definition of a table. Table schemas are
+// normalized by the tablet, so two tables with the same definition on
+// different shards have the same digest.
+func TableDigest(td *tabletmanagerdatapb.TableDefinition) string {
+	digest := sha256.Sum256([]byte(td.Schema))
+	return hex.EncodeToString(digest[:])
+}
+
 // ParseSchemaMigrationStrategy parses the given strategy into the underlying enum type.
 func ParseSchemaMigrationStrategy(name string) (vtctldatapb.SchemaMigration_Strategy, error) {
 	if name == "" {
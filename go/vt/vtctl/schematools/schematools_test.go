@@ -19,6 +19,7 @@ package schematools
 import (
 	"testing"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,27 @@ func TestSchemaMigrationStrategyName(t *testing.T) {
 		})
 	}
 }
+
+func TestTableDigest(t *testing.T) {
+	t.Parallel()
+
+	t1 := &tabletmanagerdatapb.TableDefinition{
+		Name:     "t1",
+		Schema:   "CREATE TABLE t1 (id int, PRIMARY KEY (id))",
+		RowCount: 10,
+	}
+	// Only the definition of the table is part of its digest.
+	t1OtherShard := &tabletmanagerdatapb.TableDefinition{
+		Name:     "t1",
+		Schema:   "CREATE TABLE t1 (id int, PRIMARY KEY (id))",
+		RowCount: 20,
+	}
+	t1Altered := &tabletmanagerdatapb.TableDefinition{
+		Name:   "t1",
+		Schema: "CREATE TABLE t1 (id int, name varchar(10), PRIMARY KEY (id))",
+	}
+
+	assert.Len(t, TableDigest(t1), 64)
+	assert.Equal(t, TableDigest(t1), TableDigest(t1OtherShard))
+	assert.NotEqual(t, TableDigest(t1), TableDigest(t1Altered))
+}
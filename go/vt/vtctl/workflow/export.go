@@ -0,0 +1,420 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// Read the latest copy state of each table of the given streams.
+	sqlExportCopyStates = "select vrepl_id, table_name, lastpk, copy_ranges from _vt.copy_state where vrepl_id in %a and id in (select max(id) from _vt.copy_state where vrepl_id in %a group by vrepl_id, table_name) order by vrepl_id, table_name"
+	// Read the post copy actions of the given streams.
+	sqlExportPostCopyActions = "select vrepl_id, table_name, action from _vt.post_copy_action where vrepl_id in %a order by vrepl_id, table_name"
+	// Create an imported stream, in a stopped state.
+	sqlImportStream = "insert into _vt.vreplication (workflow, source, pos, stop_pos, max_tps, max_replication_lag, cell, tablet_types, time_updated, transaction_timestamp, state, message, db_name, rows_copied, tags, workflow_type, workflow_sub_type, defer_secondary_keys, options) values (%a, %a, %a, %a, %a, %a, %a, %a, %a, 0, %a, '', %a, %a, %a, %a, %a, %a, %a)"
+	// Restore the copy state of a table of an imported stream.
+	sqlImportCopyState = "insert into _vt.copy_state (vrepl_id, table_name, lastpk, copy_ranges) values (%a, %a, %a, %a)"
+	// Restore a post copy action of an imported stream.
+	sqlImportPostCopyAction = "insert into _vt.post_copy_action (vrepl_id, table_name, action) values (%a, %a, %a)"
+)
+
+// WorkflowExport is part of the vtctlservicepb.VtctldServer interface.
+// It reads the full state of a stopped workflow from the target primary
+// tablets: the streams with their binlog sources and positions, and the
+// copy state of the tables that are still being copied.
+func (s *Server) WorkflowExport(ctx context.Context, req *vtctldatapb.WorkflowExportRequest) (*vtctldatapb.WorkflowExportResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowExport")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("shards", req.Shards)
+
+	if req.Keyspace == "" || req.Workflow == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "keyspace and workflow are required")
+	}
+
+	shards := req.Shards
+	if len(shards) == 0 {
+		var err error
+		if shards, err = s.ts.GetShardNames(ctx, req.Keyspace); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(shards)
+
+	exported := &vtctldatapb.ExportedWorkflow{
+		Workflow: req.Workflow,
+		Keyspace: req.Keyspace,
+	}
+	for _, shard := range shards {
+		primary, err := s.getShardPrimary(ctx, req.Keyspace, shard)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.tmc.ReadVReplicationWorkflow(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: req.Workflow,
+		})
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read workflow %s on shard %s/%s", req.Workflow, req.Keyspace, shard)
+		}
+		if res == nil || len(res.Streams) == 0 {
+			continue
+		}
+		streams, err := s.exportStreams(ctx, primary, res)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to export workflow %s on shard %s/%s", req.Workflow, req.Keyspace, shard)
+		}
+		if len(exported.Streams) == 0 {
+			// These are the same for all of the streams of the workflow.
+			exported.Cells = res.Cells
+			exported.TabletTypes = res.TabletTypes
+			exported.TabletSelectionPreference = res.TabletSelectionPreference
+			exported.Tags = res.Tags
+			exported.WorkflowType = res.WorkflowType
+			exported.WorkflowSubType = res.WorkflowSubType
+			exported.DeferSecondaryKeys = res.DeferSecondaryKeys
+			exported.Options = res.Options
+		}
+		exported.Streams = append(exported.Streams, streams...)
+	}
+	if len(exported.Streams) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "workflow %s not found in keyspace %s", req.Workflow, req.Keyspace)
+	}
+
+	return &vtctldatapb.WorkflowExportResponse{Workflow: exported}, nil
+}
+
+// exportStreams returns the exported streams of the workflow read from the
+// primary tablet of a target shard.
+func (s *Server) exportStreams(ctx context.Context, primary *topo.TabletInfo, res *tabletmanagerdatapb.ReadVReplicationWorkflowResponse) ([]*vtctldatapb.ExportedWorkflow_Stream, error) {
+	streams := make([]*vtctldatapb.ExportedWorkflow_Stream, 0, len(res.Streams))
+	streamsByID := make(map[int32]*vtctldatapb.ExportedWorkflow_Stream, len(res.Streams))
+	ids := make([]int32, 0, len(res.Streams))
+	for _, rstream := range res.Streams {
+		// The positions only describe the state of the workflow if none of
+		// its streams can move on while it is being exported.
+		if rstream.State != binlogdatapb.VReplicationWorkflowState_Stopped {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "stream %d is %s, the workflow must be stopped to be exported", rstream.Id, rstream.State)
+		}
+		if rstream.Message == Frozen {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "stream %d is frozen, its writes have already been switched", rstream.Id)
+		}
+		stream := &vtctldatapb.ExportedWorkflow_Stream{
+			Shard:             primary.Shard,
+			Id:                rstream.Id,
+			Bls:               rstream.Bls,
+			Pos:               rstream.Pos,
+			StopPos:           rstream.StopPos,
+			MaxTps:            rstream.MaxTps,
+			MaxReplicationLag: rstream.MaxReplicationLag,
+			RowsCopied:        rstream.RowsCopied,
+		}
+		streams = append(streams, stream)
+		streamsByID[rstream.Id] = stream
+		ids = append(ids, rstream.Id)
+	}
+
+	idsBV, err := sqltypes.BuildBindVariable(ids)
+	if err != nil {
+		return nil, err
+	}
+	query, err := sqlparser.ParseAndBind(sqlExportCopyStates, idsBV, idsBV)
+	if err != nil {
+		return nil, err
+	}
+	p3qr, err := s.tmc.VReplicationExec(ctx, primary.Tablet, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range sqltypes.Proto3ToResult(p3qr).Named().Rows {
+		stream, err := exportedStream(streamsByID, row)
+		if err != nil {
+			return nil, err
+		}
+		stream.CopyStates = append(stream.CopyStates, &vtctldatapb.ExportedWorkflow_Stream_CopyState{
+			Table:      row["table_name"].ToString(),
+			LastPk:     row["lastpk"].Raw(),
+			CopyRanges: row["copy_ranges"].Raw(),
+		})
+	}
+
+	query, err = sqlparser.ParseAndBind(sqlExportPostCopyActions, idsBV)
+	if err != nil {
+		return nil, err
+	}
+	p3qr, err = s.tmc.ExecuteFetchAsDba(ctx, primary.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		MaxRows: math.MaxUint64, // We can't pass -1 for no limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range sqltypes.Proto3ToResult(p3qr).Named().Rows {
+		stream, err := exportedStream(streamsByID, row)
+		if err != nil {
+			return nil, err
+		}
+		stream.PostCopyActions = append(stream.PostCopyActions, &vtctldatapb.ExportedWorkflow_Stream_PostCopyAction{
+			Table:  row["table_name"].ToString(),
+			Action: row["action"].ToString(),
+		})
+	}
+
+	return streams, nil
+}
+
+func exportedStream(streamsByID map[int32]*vtctldatapb.ExportedWorkflow_Stream, row sqltypes.RowNamedValues) (*vtctldatapb.ExportedWorkflow_Stream, error) {
+	id, err := row["vrepl_id"].ToInt32()
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "failed to cast vrepl_id to int32: %v", err)
+	}
+	stream, ok := streamsByID[id]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected stream %d", id)
+	}
+	return stream, nil
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldServer interface.
+// It recreates a workflow exported by WorkflowExport on the primary tablets
+// of the same target shards in the given keyspace. The streams are created
+// in a stopped state, so that the workflow is only started once the original
+// one can no longer move on.
+func (s *Server) WorkflowImport(ctx context.Context, req *vtctldatapb.WorkflowImportRequest) (resp *vtctldatapb.WorkflowImportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowImport")
+	defer span.Finish()
+
+	exported := req.Workflow
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", exported.GetWorkflow())
+	span.Annotate("cells", req.Cells)
+
+	if req.Keyspace == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "keyspace is required")
+	}
+	if exported.GetWorkflow() == "" || len(exported.GetStreams()) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the exported workflow has no name or no streams")
+	}
+
+	streamsByShard := make(map[string][]*vtctldatapb.ExportedWorkflow_Stream)
+	for _, stream := range exported.Streams {
+		if stream.Bls == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "stream %d on shard %s of the exported workflow has no binlog source", stream.Id, stream.Shard)
+		}
+		streamsByShard[stream.Shard] = append(streamsByShard[stream.Shard], stream)
+	}
+	shards := make([]string, 0, len(streamsByShard))
+	for shard := range streamsByShard {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+
+	// The workflow is locked before checking that it does not exist yet, so
+	// that concurrent imports can't both create its streams.
+	lockName := fmt.Sprintf("%s/%s", req.Keyspace, exported.Workflow)
+	ctx, workflowUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowImport")
+	if lockErr != nil {
+		return nil, vterrors.Wrapf(lockErr, "failed to lock the %s workflow", lockName)
+	}
+	defer workflowUnlock(&err)
+
+	// All of the target shards must be able to take the workflow before any
+	// of its streams is created.
+	primaries := make(map[string]*topo.TabletInfo, len(shards))
+	for _, shard := range shards {
+		primary, err := s.getShardPrimary(ctx, req.Keyspace, shard)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.tmc.ReadVReplicationWorkflow(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: exported.Workflow,
+		})
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read workflow %s on shard %s/%s", exported.Workflow, req.Keyspace, shard)
+		}
+		if res != nil && len(res.Streams) > 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "workflow %s already exists on shard %s/%s", exported.Workflow, req.Keyspace, shard)
+		}
+		primaries[shard] = primary
+	}
+
+	cells := exported.Cells
+	if len(req.Cells) > 0 {
+		cells = strings.Join(req.Cells, ",")
+	}
+	tabletTypes := topoproto.MakeStringTypeCSV(exported.TabletTypes)
+	if exported.TabletSelectionPreference == tabletmanagerdatapb.TabletSelectionPreference_INORDER {
+		tabletTypes = discovery.InOrderHint + tabletTypes
+	}
+	options := exported.Options
+	if options == "" {
+		options = "{}"
+	}
+
+	resp = &vtctldatapb.WorkflowImportResponse{
+		Details: make([]*vtctldatapb.WorkflowImportResponse_TabletInfo, 0, len(shards)),
+	}
+	for _, shard := range shards {
+		primary := primaries[shard]
+		details := &vtctldatapb.WorkflowImportResponse_TabletInfo{Tablet: primary.Alias}
+		for _, stream := range streamsByShard[shard] {
+			id, err := s.importStream(ctx, primary, exported, stream, cells, tabletTypes, options)
+			if err != nil {
+				err = vterrors.Wrapf(err, "failed to import stream %d of workflow %s on shard %s/%s", stream.Id, exported.Workflow, req.Keyspace, shard)
+				// None of the shards had the workflow, so whatever it has on
+				// the shards handled so far was created by this import.
+				for _, imported := range shards {
+					if _, derr := s.tmc.DeleteVReplicationWorkflow(ctx, primaries[imported].Tablet, &tabletmanagerdatapb.DeleteVReplicationWorkflowRequest{
+						Workflow: exported.Workflow,
+					}); derr != nil {
+						return nil, vterrors.Wrapf(err, "the partially imported workflow could not be deleted from shard %s/%s (%v), delete it before trying again",
+							req.Keyspace, imported, derr)
+					}
+					if imported == shard {
+						break
+					}
+				}
+				return nil, err
+			}
+			details.StreamIds = append(details.StreamIds, id)
+		}
+		resp.Details = append(resp.Details, details)
+	}
+	resp.Summary = fmt.Sprintf("Successfully imported the %s workflow into the %s keyspace, it can be started once the original workflow is stopped for good", exported.Workflow, req.Keyspace)
+	return resp, nil
+}
+
+// importStream creates an exported stream, along with its copy state, on the
+// primary tablet of its target shard. It returns the id of the new stream.
+func (s *Server) importStream(ctx context.Context, primary *topo.TabletInfo, exported *vtctldatapb.ExportedWorkflow, stream *vtctldatapb.ExportedWorkflow_Stream,
+	cells, tabletTypes, options string,
+) (int32, error) {
+	bls := stream.Bls.CloneVT()
+	protoutil.SortBinlogSourceTables(bls)
+	source, err := prototext.Marshal(bls)
+	if err != nil {
+		return 0, err
+	}
+	query, err := sqlparser.ParseAndBind(sqlImportStream,
+		sqltypes.StringBindVariable(exported.Workflow),
+		sqltypes.StringBindVariable(string(source)),
+		sqltypes.StringBindVariable(stream.Pos),
+		sqltypes.StringBindVariable(stream.StopPos),
+		sqltypes.Int64BindVariable(stream.MaxTps),
+		sqltypes.Int64BindVariable(stream.MaxReplicationLag),
+		sqltypes.StringBindVariable(cells),
+		sqltypes.StringBindVariable(tabletTypes),
+		sqltypes.Int64BindVariable(time.Now().Unix()),
+		sqltypes.StringBindVariable(binlogdatapb.VReplicationWorkflowState_Stopped.String()),
+		sqltypes.StringBindVariable(primary.DbName()),
+		sqltypes.Int64BindVariable(stream.RowsCopied),
+		sqltypes.StringBindVariable(exported.Tags),
+		sqltypes.Int64BindVariable(int64(exported.WorkflowType)),
+		sqltypes.Int64BindVariable(int64(exported.WorkflowSubType)),
+		sqltypes.BoolBindVariable(exported.DeferSecondaryKeys),
+		sqltypes.StringBindVariable(options),
+	)
+	if err != nil {
+		return 0, err
+	}
+	qr, err := s.tmc.VReplicationExec(ctx, primary.Tablet, query)
+	if err != nil {
+		return 0, err
+	}
+	if qr.InsertId == 0 || qr.InsertId > math.MaxInt32 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected id %d for the new stream", qr.InsertId)
+	}
+	id := int32(qr.InsertId)
+
+	queries := make([]string, 0, len(stream.CopyStates)+len(stream.PostCopyActions))
+	for _, cs := range stream.CopyStates {
+		query, err := sqlparser.ParseAndBind(sqlImportCopyState,
+			sqltypes.Int64BindVariable(int64(id)),
+			sqltypes.StringBindVariable(cs.Table),
+			nullableBytesBindVariable(cs.LastPk),
+			nullableBytesBindVariable(cs.CopyRanges),
+		)
+		if err != nil {
+			return 0, err
+		}
+		queries = append(queries, query)
+	}
+	for _, pca := range stream.PostCopyActions {
+		query, err := sqlparser.ParseAndBind(sqlImportPostCopyAction,
+			sqltypes.Int64BindVariable(int64(id)),
+			sqltypes.StringBindVariable(pca.Table),
+			sqltypes.StringBindVariable(pca.Action),
+		)
+		if err != nil {
+			return 0, err
+		}
+		queries = append(queries, query)
+	}
+	for _, query := range queries {
+		if _, err := s.tmc.ExecuteFetchAsDba(ctx, primary.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+			Query: []byte(query),
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// getShardPrimary returns the primary tablet of a shard.
+func (s *Server) getShardPrimary(ctx context.Context, keyspace, shard string) (*topo.TabletInfo, error) {
+	si, err := s.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get shard %s/%s", keyspace, shard)
+	}
+	if !si.HasPrimary() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary tablet", keyspace, shard)
+	}
+	return s.ts.GetTablet(ctx, si.PrimaryAlias)
+}
+
+// nullableBytesBindVariable returns a bind variable for b, which is NULL if
+// b is empty.
+func nullableBytesBindVariable(b []byte) *querypb.BindVariable {
+	if len(b) == 0 {
+		return sqltypes.NullBindVariable
+	}
+	return sqltypes.BytesBindVariable(b)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestWorkflowExportImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workflow := "wf1"
+	sourceKeyspace := &testKeyspace{KeyspaceName: "source", ShardNames: []string{"0"}}
+	targetKeyspace := &testKeyspace{KeyspaceName: "target", ShardNames: []string{"-80", "80-"}}
+	bls := &binlogdatapb.BinlogSource{
+		Keyspace: "source",
+		Shard:    "0",
+		Filter: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: "select * from t1"}, {Match: "t2", Filter: "select * from t2"}},
+		},
+	}
+	readResponse := func(state binlogdatapb.VReplicationWorkflowState, id int32, pos string) *tabletmanagerdatapb.ReadVReplicationWorkflowResponse {
+		return &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+			Workflow:                  workflow,
+			Cells:                     "zone1",
+			TabletTypes:               []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_PRIMARY},
+			TabletSelectionPreference: tabletmanagerdatapb.TabletSelectionPreference_INORDER,
+			WorkflowType:              binlogdatapb.VReplicationWorkflowType_MoveTables,
+			Options:                   "{}",
+			Streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{{
+				Id:         id,
+				Bls:        bls,
+				Pos:        pos,
+				State:      state,
+				RowsCopied: 10,
+			}},
+		}
+	}
+	readRequest := &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{Workflow: workflow}
+
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	// The workflow can only be exported once it is stopped.
+	env.tmc.expectReadVReplicationWorkflowRequest(200, &readVReplicationWorkflowRequestResponse{
+		req: readRequest,
+		res: readResponse(binlogdatapb.VReplicationWorkflowState_Running, 1, "MySQL56/uuid:1-10"),
+	})
+	_, err := env.ws.WorkflowExport(ctx, &vtctldatapb.WorkflowExportRequest{Keyspace: "target", Workflow: workflow})
+	require.ErrorContains(t, err, "stream 1 is Running, the workflow must be stopped to be exported")
+
+	env.tmc.expectReadVReplicationWorkflowRequest(200, &readVReplicationWorkflowRequestResponse{
+		req: readRequest,
+		res: readResponse(binlogdatapb.VReplicationWorkflowState_Stopped, 1, "MySQL56/uuid:1-10"),
+	})
+	env.tmc.expectReadVReplicationWorkflowRequest(210, &readVReplicationWorkflowRequestResponse{
+		req: readRequest,
+		res: readResponse(binlogdatapb.VReplicationWorkflowState_Stopped, 3, "MySQL56/uuid:1-12"),
+	})
+	// On -80 the copy of t2 is still in progress, and t1 has a post copy action.
+	env.tmc.expectVRQuery(200, "select vrepl_id, table_name, lastpk, copy_ranges from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name) order by vrepl_id, table_name",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("vrepl_id|table_name|lastpk|copy_ranges", "int32|varbinary|varbinary|varbinary"),
+			"1|t2|lastpk-t2|null"))
+	env.tmc.expectVRQuery(200, "select vrepl_id, table_name, action from _vt.post_copy_action where vrepl_id in (1) order by vrepl_id, table_name",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("vrepl_id|table_name|action", "int32|varbinary|json"),
+			`1|t1|{"task": "add_secondary_keys"}`))
+	env.tmc.expectVRQuery(210, "select vrepl_id, table_name, lastpk, copy_ranges from _vt.copy_state where vrepl_id in (3) and id in (select max(id) from _vt.copy_state where vrepl_id in (3) group by vrepl_id, table_name) order by vrepl_id, table_name",
+		&sqltypes.Result{})
+	env.tmc.expectVRQuery(210, "select vrepl_id, table_name, action from _vt.post_copy_action where vrepl_id in (3) order by vrepl_id, table_name",
+		&sqltypes.Result{})
+
+	resp, err := env.ws.WorkflowExport(ctx, &vtctldatapb.WorkflowExportRequest{Keyspace: "target", Workflow: workflow})
+	require.NoError(t, err)
+	wantExported := &vtctldatapb.ExportedWorkflow{
+		Workflow:                  workflow,
+		Keyspace:                  "target",
+		Cells:                     "zone1",
+		TabletTypes:               []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_PRIMARY},
+		TabletSelectionPreference: tabletmanagerdatapb.TabletSelectionPreference_INORDER,
+		WorkflowType:              binlogdatapb.VReplicationWorkflowType_MoveTables,
+		Options:                   "{}",
+		Streams: []*vtctldatapb.ExportedWorkflow_Stream{
+			{
+				Shard:      "-80",
+				Id:         1,
+				Bls:        bls,
+				Pos:        "MySQL56/uuid:1-10",
+				RowsCopied: 10,
+				CopyStates: []*vtctldatapb.ExportedWorkflow_Stream_CopyState{{Table: "t2", LastPk: []byte("lastpk-t2")}},
+				PostCopyActions: []*vtctldatapb.ExportedWorkflow_Stream_PostCopyAction{
+					{Table: "t1", Action: `{"task": "add_secondary_keys"}`},
+				},
+			},
+			{
+				Shard:      "80-",
+				Id:         3,
+				Bls:        bls,
+				Pos:        "MySQL56/uuid:1-12",
+				RowsCopied: 10,
+			},
+		},
+	}
+	utils.MustMatch(t, wantExported, resp.Workflow)
+	env.tmc.verifyQueries(t)
+
+	// Import the workflow into another cluster, with the same target shards.
+	importEnv := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer importEnv.close()
+
+	importEnv.tmc.expectReadVReplicationWorkflowRequest(200, &readVReplicationWorkflowRequestResponse{req: readRequest, notFound: true})
+	importEnv.tmc.expectReadVReplicationWorkflowRequest(210, &readVReplicationWorkflowRequestResponse{req: readRequest, notFound: true})
+	source := `keyspace:"source" shard:"0" filter:{rules:{match:"t1" filter:"select \* from t1"} rules:{match:"t2" filter:"select \* from t2"}}`
+	importEnv.tmc.expectVRQuery(200, fmt.Sprintf(`/^insert into _vt.vreplication \(workflow, source, pos, stop_pos, max_tps, max_replication_lag, cell, tablet_types, time_updated, transaction_timestamp, state, message, db_name, rows_copied, tags, workflow_type, workflow_sub_type, defer_secondary_keys, options\) values \('wf1', '%s', 'MySQL56/uuid:1-10', '', 0, 0, 'zone2', 'in_order:replica,primary', \d+, 0, 'Stopped', '', 'vt_target', 10, '', 1, 0, 0, '{}'\)$`, source),
+		&sqltypes.Result{InsertID: 7})
+	importEnv.tmc.expectVRQuery(200, "insert into _vt.copy_state (vrepl_id, table_name, lastpk, copy_ranges) values (7, 't2', _binary'lastpk-t2', null)", &sqltypes.Result{})
+	importEnv.tmc.expectVRQuery(200, `insert into _vt.post_copy_action (vrepl_id, table_name, action) values (7, 't1', '{"task": "add_secondary_keys"}')`, &sqltypes.Result{})
+	importEnv.tmc.expectVRQuery(210, fmt.Sprintf(`/^insert into _vt.vreplication \(.*\) values \('wf1', '%s', 'MySQL56/uuid:1-12', .*\)$`, source),
+		&sqltypes.Result{InsertID: 2})
+
+	importResp, err := importEnv.ws.WorkflowImport(ctx, &vtctldatapb.WorkflowImportRequest{
+		Keyspace: "target",
+		Workflow: resp.Workflow,
+		Cells:    []string{"zone2"},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.WorkflowImportResponse_TabletInfo{
+		{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 200}, StreamIds: []int32{7}},
+		{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 210}, StreamIds: []int32{2}},
+	}, importResp.Details)
+	importEnv.tmc.verifyQueries(t)
+
+	// The workflow can't be imported again.
+	importEnv.tmc.expectReadVReplicationWorkflowRequest(200, &readVReplicationWorkflowRequestResponse{
+		req: readRequest,
+		res: readResponse(binlogdatapb.VReplicationWorkflowState_Stopped, 7, "MySQL56/uuid:1-10"),
+	})
+	_, err = importEnv.ws.WorkflowImport(ctx, &vtctldatapb.WorkflowImportRequest{
+		Keyspace: "target",
+		Workflow: resp.Workflow,
+	})
+	assert.ErrorContains(t, err, "workflow wf1 already exists on shard target/-80")
+
+	// A failed import deletes the streams it created.
+	failEnv := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer failEnv.close()
+
+	failEnv.tmc.expectReadVReplicationWorkflowRequest(200, &readVReplicationWorkflowRequestResponse{req: readRequest, notFound: true})
+	failEnv.tmc.expectReadVReplicationWorkflowRequest(210, &readVReplicationWorkflowRequestResponse{req: readRequest, notFound: true})
+	failEnv.tmc.expectVRQuery(200, "/^insert into _vt.vreplication ", &sqltypes.Result{InsertID: 7})
+	failEnv.tmc.expectVRQuery(200, "/^insert into _vt.copy_state ", &sqltypes.Result{})
+	failEnv.tmc.expectVRQuery(200, "/^insert into _vt.post_copy_action ", &sqltypes.Result{})
+	failEnv.tmc.expectVRQuery(210, "/^insert into _vt.vreplication ", &sqltypes.Result{})
+	_, err = failEnv.ws.WorkflowImport(ctx, &vtctldatapb.WorkflowImportRequest{
+		Keyspace: "target",
+		Workflow: resp.Workflow,
+	})
+	assert.ErrorContains(t, err, "failed to import stream 3 of workflow wf1 on shard target/80-")
+	failEnv.tmc.verifyQueries(t)
+	failEnv.tmc.mu.Lock()
+	assert.Equal(t, map[uint32][]string{200: {workflow}, 210: {workflow}}, failEnv.tmc.deletedWorkflows)
+	failEnv.tmc.mu.Unlock()
+}
@@ -30,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/proto"
@@ -211,7 +212,7 @@ func (env *testEnv) addTablet(t *testing.T, ctx context.Context, id int, keyspac
 }
 
 func (env *testEnv) saveRoutingRules(t *testing.T, rules map[string][]string) {
-	err := topotools.SaveRoutingRules(t.Context(), env.ts, rules)
+	err := topotools.SaveRoutingRules(t.Context(), env.ts, topotools.NewRoutingRules(rules))
 	require.NoError(t, err)
 	err = env.ts.RebuildSrvVSchema(t.Context(), nil)
 	require.NoError(t, err)
@@ -225,7 +226,7 @@ func (env *testEnv) updateTableRoutingRules(t *testing.T, ctx context.Context,
 	}
 	rr, err := env.ts.GetRoutingRules(ctx)
 	require.NoError(t, err)
-	rules := topotools.GetRoutingRulesMap(rr)
+	rules := topotools.GetRoutingRulesMap(rr).ToTablesMap(time.Now())
 	for _, tabletType := range tabletTypes {
 		for _, tableName := range tables {
 			toTarget := []string{toKeyspace + "." + tableName}
@@ -282,6 +283,7 @@ type testTMClient struct {
 	primaryPositions                   map[uint32]string
 	vdiffRequests                      map[uint32]*vdiffRequestResponse
 	refreshStateErrors                 map[uint32]error
+	deletedWorkflows                   map[uint32][]string
 
 	// Stack of ReadVReplicationWorkflowsResponse to return, in order, for each shard
 	readVReplicationWorkflowsResponses       map[string][]*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse
@@ -311,6 +313,7 @@ func newTestTMClient(env *testEnv) *testTMClient {
 		primaryPositions:                   make(map[uint32]string),
 		vdiffRequests:                      make(map[uint32]*vdiffRequestResponse),
 		refreshStateErrors:                 make(map[uint32]error),
+		deletedWorkflows:                   make(map[uint32][]string),
 		env:                                env,
 	}
 }
@@ -344,7 +347,7 @@ func (tmc *testTMClient) ReadVReplicationWorkflow(ctx context.Context, tablet *t
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected ReadVReplicationWorkflow request on tablet %s: got %+v, want %+v",
 				topoproto.TabletAliasString(tablet.Alias), req, expect)
 		}
-		if expect.res != nil {
+		if expect.res != nil || expect.notFound {
 			return expect.res, expect.err
 		}
 	}
@@ -394,6 +397,9 @@ func (tmc *testTMClient) DeleteTableData(ctx context.Context, tablet *topodatapb
 }
 
 func (tmc *testTMClient) DeleteVReplicationWorkflow(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.DeleteVReplicationWorkflowRequest) (response *tabletmanagerdatapb.DeleteVReplicationWorkflowResponse, err error) {
+	tmc.mu.Lock()
+	tmc.deletedWorkflows[tablet.Alias.Uid] = append(tmc.deletedWorkflows[tablet.Alias.Uid], req.Workflow)
+	tmc.mu.Unlock()
 	return &tabletmanagerdatapb.DeleteVReplicationWorkflowResponse{
 		Result: &querypb.QueryResult{
 			RowsAffected: 1,
@@ -527,6 +533,22 @@ func (tmc *testTMClient) VReplicationExec(ctx context.Context, tablet *topodatap
 	return qrs[0].result, qrs[0].err
 }
 
+func (tmc *testTMClient) verifyQueries(t *testing.T) {
+	t.Helper()
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+
+	for tabletID, qrs := range tmc.vrQueries {
+		if len(qrs) != 0 {
+			var list []string
+			for _, qr := range qrs {
+				list = append(list, qr.query)
+			}
+			assert.Failf(t, "queries not executed", "tablet %v: found queries that were expected but never got executed by the test: %v", tabletID, list)
+		}
+	}
+}
+
 func (tmc *testTMClient) ExecuteFetchAsDba(ctx context.Context, tablet *topodatapb.Tablet, usePool bool, req *tabletmanagerdatapb.ExecuteFetchAsDbaRequest) (*querypb.QueryResult, error) {
 	// Reuse VReplicationExec.
 	return tmc.VReplicationExec(ctx, tablet, string(req.Query))
@@ -625,6 +647,8 @@ type readVReplicationWorkflowRequestResponse struct {
 	req *tabletmanagerdatapb.ReadVReplicationWorkflowRequest
 	res *tabletmanagerdatapb.ReadVReplicationWorkflowResponse
 	err error
+	// notFound makes the request return no workflow.
+	notFound bool
 }
 
 type applySchemaRequestResponse struct {
@@ -936,8 +960,9 @@ func (tmc *testTMClient) GetMaxValueForSequences(ctx context.Context, tablet *to
 func checkRouting(t *testing.T, ws *Server, want map[string][]string) {
 	t.Helper()
 	ctx := t.Context()
-	got, err := topotools.GetRoutingRules(ctx, ws.ts)
+	rules, err := topotools.GetRoutingRules(ctx, ws.ts)
 	require.NoError(t, err)
+	got := rules.ToTablesMap(time.Now())
 	require.Equal(t, want, got, "routing rules don't match: got: %v, want: %v", got, want)
 	cells, err := ws.ts.GetCellInfoNames(ctx)
 	require.NoError(t, err)
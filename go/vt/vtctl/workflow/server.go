@@ -160,6 +160,10 @@ func (s *Server) SQLParser() *sqlparser.Parser {
 	return s.env.Parser()
 }
 
+func (s *Server) Environment() *vtenv.Environment {
+	return s.env
+}
+
 // CheckReshardingJournalExistsOnTablet returns the journal (or an empty
 // journal) and a boolean to indicate if the resharding_journal table exists on
 // the given tablet.
@@ -494,10 +498,10 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 				return nil, nil, err
 			}
 			for _, table := range ts.Tables() {
-				// If a rule for the primary tablet type exists for any table and points to the target keyspace,
-				// then writes have been switched.
+				// If a rule in effect for the primary tablet type exists for any table and points to the
+				// target keyspace, then writes have been switched.
 				ruleKey := fmt.Sprintf("%s.%s", sourceKeyspace, table)
-				rr := globalRules[ruleKey]
+				rr := globalRules.ToTables(ruleKey, time.Now())
 				if len(rr) > 0 && rr[0] != ruleKey {
 					state.WritesSwitched = true
 					break
@@ -1440,7 +1444,7 @@ func (s *Server) setupInitialRoutingRules(ctx context.Context, req *vtctldatapb.
 			key = fmt.Sprintf("%s.%s", keyspace, table)
 		}
 		for _, typ := range tabletTypeSuffixes {
-			rules[key+typ] = []string{route}
+			rules.Set(key+typ, route)
 		}
 	}
 	for _, table := range tables {
@@ -2014,8 +2014,8 @@ func TestMoveTablesSwitchWritesCompletesAfterCancelOnFreeze(t *testing.T) {
 
 	rules, err := topotools.GetRoutingRules(t.Context(), env.ts)
 	require.NoError(t, err)
-	require.Equal(t, []string{targetKeyspaceName + "." + tableName}, rules[tableName])
-	require.Equal(t, []string{targetKeyspaceName + "." + tableName}, rules[sourceKeyspaceName+"."+tableName])
+	require.Equal(t, []string{targetKeyspaceName + "." + tableName}, rules.ToTables(tableName, time.Now()))
+	require.Equal(t, []string{targetKeyspaceName + "." + tableName}, rules.ToTables(sourceKeyspaceName+"."+tableName, time.Now()))
 
 	sourceShard, err := env.ts.GetShard(t.Context(), sourceKeyspaceName, "0")
 	require.NoError(t, err)
@@ -2819,7 +2819,7 @@ func TestMirrorTraffic(t *testing.T) {
 			}, tt.sourceShards, tt.targetShards)
 
 			require.NoError(t, topotools.SaveMirrorRules(ctx, te.topoServ, tt.mirrorRules))
-			require.NoError(t, topotools.SaveRoutingRules(ctx, te.topoServ, tt.routingRules))
+			require.NoError(t, topotools.SaveRoutingRules(ctx, te.topoServ, topotools.NewRoutingRules(tt.routingRules)))
 			require.NoError(t, te.topoServ.RebuildSrvVSchema(ctx, []string{te.cell}))
 
 			if tt.setup != nil {
@@ -671,15 +671,15 @@ func (ts *trafficSwitcher) switchTableReads(ctx context.Context, cells []string,
 		tt := strings.ToLower(servedType.String())
 		for _, table := range ts.Tables() {
 			if direction == DirectionForward {
-				toTarget := []string{ts.TargetKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toTarget
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toTarget
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toTarget
+				toTarget := ts.TargetKeyspaceName() + "." + table
+				rules.Set(table+"@"+tt, toTarget)
+				rules.Set(ts.TargetKeyspaceName()+"."+table+"@"+tt, toTarget)
+				rules.Set(ts.SourceKeyspaceName()+"."+table+"@"+tt, toTarget)
 			} else {
-				toSource := []string{ts.SourceKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toSource
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toSource
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toSource
+				toSource := ts.SourceKeyspaceName() + "." + table
+				rules.Set(table+"@"+tt, toSource)
+				rules.Set(ts.TargetKeyspaceName()+"."+table+"@"+tt, toSource)
+				rules.Set(ts.SourceKeyspaceName()+"."+table+"@"+tt, toSource)
 			}
 		}
 	}
@@ -803,8 +803,8 @@ func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
 			sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
 			delete(rules, targetKsTable)
 			ts.Logger().Infof("Deleted routing: %s", targetKsTable)
-			rules[table] = []string{targetKsTable}
-			rules[sourceKsTable] = []string{targetKsTable}
+			rules.Set(table, targetKsTable)
+			rules.Set(sourceKsTable, targetKsTable)
 			ts.Logger().Infof("Added routing: %v %v", table, sourceKsTable)
 		}
 		if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
@@ -27,7 +27,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/proto/binlogdata"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
@@ -497,7 +499,7 @@ func TestDeleteRoutingRulesPreservesUnrelated(t *testing.T) {
 		targetKeyspaceName + "." + tableName: {fmt.Sprintf("%s.%s", sourceKeyspaceName, tableName)},
 		"unrelated":                          {"otherks.unrelated"},
 	}
-	require.NoError(t, topotools.SaveRoutingRules(ctx, env.ts, rules))
+	require.NoError(t, topotools.SaveRoutingRules(ctx, env.ts, topotools.NewRoutingRules(rules)))
 
 	err = ts.deleteRoutingRules(ctx)
 	require.NoError(t, err)
@@ -506,7 +508,71 @@ func TestDeleteRoutingRulesPreservesUnrelated(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, map[string][]string{
 		"unrelated": {"otherks.unrelated"},
-	}, got)
+	}, got.ToTablesMap(time.Now()))
+}
+
+func TestSwitchTableReadsKeepsScheduledRoutingRules(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	tableName := "t1"
+	sourceKeyspaceName := "sourceks"
+	targetKeyspaceName := "targetks"
+
+	sourceKeyspace := &testKeyspace{
+		KeyspaceName: sourceKeyspaceName,
+		ShardNames:   []string{"0"},
+	}
+	targetKeyspace := &testKeyspace{
+		KeyspaceName: targetKeyspaceName,
+		ShardNames:   []string{"0"},
+	}
+
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		tableName: {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   tableName,
+					Schema: fmt.Sprintf("CREATE TABLE %s (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))", tableName),
+				},
+			},
+		},
+	}
+
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+	env.tmc.schema = schema
+
+	ts, _, err := env.ws.getWorkflowState(ctx, targetKeyspaceName, workflowName)
+	require.NoError(t, err)
+
+	// An unrelated table has a traffic switch scheduled in an hour.
+	switchAt := protoutil.TimeToProto(time.Now().Add(time.Hour).Truncate(time.Second))
+	scheduled := []*vschema.RoutingRule{
+		{FromTable: "unrelated", ToTables: []string{"oldks.unrelated"}, ActiveUntil: switchAt},
+		{FromTable: "unrelated", ToTables: []string{"newks.unrelated"}, ActiveFrom: switchAt},
+	}
+	rules := topotools.NewRoutingRules(map[string][]string{
+		tableName:                            {fmt.Sprintf("%s.%s", sourceKeyspaceName, tableName)},
+		sourceKeyspaceName + "." + tableName: {fmt.Sprintf("%s.%s", sourceKeyspaceName, tableName)},
+		targetKeyspaceName + "." + tableName: {fmt.Sprintf("%s.%s", sourceKeyspaceName, tableName)},
+	})
+	rules["unrelated"] = scheduled
+	require.NoError(t, topotools.SaveRoutingRules(ctx, env.ts, rules))
+
+	err = ts.switchTableReads(ctx, nil, []topodatapb.TabletType{topodatapb.TabletType_REPLICA}, false, DirectionForward)
+	require.NoError(t, err)
+
+	got, err := topotools.GetRoutingRules(ctx, env.ts)
+	require.NoError(t, err)
+	require.Len(t, got["unrelated"], 2)
+	for i, rr := range got["unrelated"] {
+		assert.True(t, proto.Equal(scheduled[i], rr), "got %v, want %v", rr, scheduled[i])
+	}
+	assert.Equal(t, []string{"oldks.unrelated"}, got.ToTables("unrelated", time.Now()))
+	assert.Equal(t, []string{"newks.unrelated"}, got.ToTables("unrelated", time.Now().Add(2*time.Hour)))
+	assert.Equal(t, []string{targetKeyspaceName + "." + tableName}, got.ToTables(tableName+"@replica", time.Now()))
 }
 
 func TestDeleteShardRoutingRulesPreservesUnrelated(t *testing.T) {
@@ -589,11 +589,13 @@ func doValidateWorkflowHasCompleted(ctx context.Context, ts *trafficSwitcher) er
 			if err != nil {
 				rec.RecordError(errors.New("could not get RoutingRules"))
 			}
-			for fromTable, toTables := range rules {
-				for _, toTable := range toTables {
-					for _, table := range ts.Tables() {
-						if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table) {
-							rec.RecordError(fmt.Errorf("routing still exists from keyspace %s table %s to %s", ts.SourceKeyspaceName(), table, fromTable))
+			for fromTable, rrs := range rules {
+				for _, rr := range rrs {
+					for _, toTable := range rr.ToTables {
+						for _, table := range ts.Tables() {
+							if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table) {
+								rec.RecordError(fmt.Errorf("routing still exists from keyspace %s table %s to %s", ts.SourceKeyspaceName(), table, fromTable))
+							}
 						}
 					}
 				}
@@ -398,6 +398,8 @@ func (wf *workflowFetcher) scanWorkflow(
 			ThrottlerStatus: &vtctldatapb.Workflow_Stream_ThrottlerStatus{
 				ComponentThrottled: rstream.ComponentThrottled,
 				TimeThrottled:      rstream.TimeThrottled,
+				ReasonThrottled:    rstream.ReasonThrottled,
+				ThrottlerAppName:   res.ConfigOverrides["vreplication-throttler-app-name"],
 			},
 		}
 
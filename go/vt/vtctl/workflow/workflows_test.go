@@ -256,3 +256,70 @@ func TestFetchCopyStatesByShardStream(t *testing.T) {
 
 	assert.Nil(t, copyStatesByStreamId["80-/2"])
 }
+
+func TestBuildWorkflowsThrottlerStatus(t *testing.T) {
+	ctx := t.Context()
+
+	te := newTestMaterializerEnv(t, ctx, &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "source_keyspace",
+		TargetKeyspace: "target_keyspace",
+		Workflow:       "test_workflow",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{
+			{
+				TargetTable:      "table1",
+				SourceExpression: "select * from table1",
+			},
+		},
+	}, []string{"-"}, []string{"-"})
+
+	wf := workflowFetcher{
+		ts:  te.ws.ts,
+		tmc: te.tmc,
+	}
+
+	ti := &topo.TabletInfo{
+		Tablet: &topodatapb.Tablet{
+			Keyspace: "target_keyspace",
+			Shard:    "-",
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+		},
+	}
+	timeThrottled := &vttime.Time{Seconds: 1700000000}
+	results := map[*topo.TabletInfo]*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+		ti: {
+			Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+				{
+					Workflow:        "test_workflow",
+					ConfigOverrides: map[string]string{"vreplication-throttler-app-name": "nightly-reshard"},
+					Streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+						{
+							Id:                 1,
+							Bls:                &binlogdata.BinlogSource{Keyspace: "source_keyspace", Shard: "-"},
+							State:              binlogdata.VReplicationWorkflowState_Running,
+							TimeUpdated:        &vttime.Time{Seconds: 1700000001},
+							TimeThrottled:      timeThrottled,
+							ComponentThrottled: "vplayer",
+							ReasonThrottled:    "metric is above threshold",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	workflows, err := wf.buildWorkflows(ctx, results, nil, &vtctldatapb.GetWorkflowsRequest{Keyspace: "target_keyspace"})
+	require.NoError(t, err)
+	require.Len(t, workflows, 1)
+	shardStream := workflows[0].ShardStreams["-/zone1-0000000200"]
+	require.NotNil(t, shardStream)
+	require.Len(t, shardStream.Streams, 1)
+	assert.Equal(t, &vtctldatapb.Workflow_Stream_ThrottlerStatus{
+		ComponentThrottled: "vplayer",
+		TimeThrottled:      timeThrottled,
+		ReasonThrottled:    "metric is above threshold",
+		ThrottlerAppName:   "nightly-reshard",
+	}, shardStream.Streams[0].ThrottlerStatus)
+}
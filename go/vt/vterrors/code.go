@@ -73,6 +73,8 @@ var (
 
 	VT07001 = errorWithState("VT07001", vtrpcpb.Code_PERMISSION_DENIED, KillDeniedError, "%s", "Kill statement is not allowed. More in docs about how to enable it and its limitations.")
 
+	VT08001 = errorWithoutState("VT08001", vtrpcpb.Code_RESOURCE_EXHAUSTED, "query exceeded the vtgate memory limit of %d bytes", "The rows held in the memory of VTGate by the sorts, aggregations and joins of the query exceeded the --max-memory-bytes limit. The query must be rewritten to process fewer rows in VTGate, e.g. by pushing the work down to MySQL.")
+
 	VT09001 = errorWithState("VT09001", vtrpcpb.Code_FAILED_PRECONDITION, RequiresPrimaryKey, PrimaryVindexNotSet, "the table does not have a primary vindex, the operation is impossible.")
 	VT09002 = errorWithState("VT09002", vtrpcpb.Code_FAILED_PRECONDITION, InnodbReadOnly, "%s statement with a replica target", "This type of DML statement is not allowed on a replica target.")
 	VT09003 = errorWithoutState("VT09003", vtrpcpb.Code_FAILED_PRECONDITION, "INSERT query does not have primary vindex column '%v' in the column list", "A vindex column is mandatory for the insert, please provide one.")
@@ -105,6 +107,7 @@ var (
 	VT09030 = errorWithState("VT09030", vtrpcpb.Code_FAILED_PRECONDITION, CTEMaxRecursionDepth, "Recursive query aborted after 1000 iterations.", "")
 	VT09031 = errorWithoutState("VT09031", vtrpcpb.Code_FAILED_PRECONDITION, "Primary demotion is stalled", "")
 	VT09032 = errorWithoutState("VT09032", vtrpcpb.Code_FAILED_PRECONDITION, "previous transaction failed. Issue a ROLLBACK to resolve the failure.", "This error occurs after a VT15001 error was sent to the client. Later queries in the same session will continue to fail until the client sends a ROLLBACK.")
+	VT09033 = errorWithState("VT09033", vtrpcpb.Code_FAILED_PRECONDITION, InnodbReadOnly, "locking read with a replica target", "SELECT ... FOR UPDATE and SELECT ... FOR SHARE do not lock the rows they read on a replica target. Run them on the primary, or configure VTGate to redirect them to the primary with --replica-locking-reads=redirect.")
 
 	VT10001 = errorWithoutState("VT10001", vtrpcpb.Code_ABORTED, "foreign key constraints are not allowed", "Foreign key constraints are not allowed, see https://vitess.io/blog/2021-06-15-online-ddl-why-no-fk/.")
 	VT10002 = errorWithoutState("VT10002", vtrpcpb.Code_ABORTED, "atomic distributed transaction not allowed: %s", "The distributed transaction cannot be committed. A rollback decision is taken.")
@@ -121,6 +124,7 @@ var (
 	VT14003 = errorWithoutState("VT14003", vtrpcpb.Code_UNAVAILABLE, "no connection for tablet %v", "No connection for the given tablet.")
 	VT14004 = errorWithoutState("VT14004", vtrpcpb.Code_UNAVAILABLE, "cannot find keyspace for: %s", "The specified keyspace could not be found.")
 	VT14005 = errorWithoutState("VT14005", vtrpcpb.Code_UNAVAILABLE, "cannot lookup sidecar database for keyspace: %s", "Failed to read sidecar database identifier.")
+	VT14006 = errorWithState("VT14006", vtrpcpb.Code_UNAVAILABLE, ServerShutdown, "server shutdown in progress, resume the session with the vitess_session_checkpoint connection attribute set to %s", "VTGate is shutting down and saved the state of the session. Reconnect to another VTGate with the vitess_session_checkpoint connection attribute set to the token that ends the message to resume the session.")
 
 	VT15001 = errorWithNoCode("VT15001", "transaction error, issue ROLLBACK and retry the transaction: %s", "Transaction must be rolled back by the application and re-tried.")
 
@@ -169,6 +173,7 @@ var (
 		VT05007,
 		VT06001,
 		VT07001,
+		VT08001,
 		VT09001,
 		VT09002,
 		VT09003,
@@ -200,6 +205,7 @@ var (
 		VT09030,
 		VT09031,
 		VT09032,
+		VT09033,
 		VT10001,
 		VT10002,
 		VT12001,
@@ -211,6 +217,7 @@ var (
 		VT14003,
 		VT14004,
 		VT14005,
+		VT14006,
 	}
 
 	ErrorsWithNoCode = []func(code vtrpcpb.Code, args ...any) *VitessError{
@@ -97,6 +97,9 @@ const (
 	// server not available
 	ServerNotAvailable
 
+	// server shutdown in progress
+	ServerShutdown
+
 	// unknown timezone
 	UnknownTimeZone
 
@@ -0,0 +1,2 @@
+select u.id, m.id from user u join music m on u.name = m.user_id /* join between sharded tables */;
+select u.id from user u join music m on u.id = m.user_id join name_info n on n.name = u.name /* partial merge */;
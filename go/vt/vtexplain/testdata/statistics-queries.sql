@@ -0,0 +1,2 @@
+select u.id, m.id from user u join music m on u.name = m.user_id /* music is the smaller table */;
+select u.id, m.id from user u join music m on u.name = m.user_id where u.pet = 'dog' /* few users have a dog */;
@@ -93,6 +93,15 @@ type (
 		// Target is used to override the "database" target in the
 		// vtgate session to simulate `USE <target>`
 		Target string
+
+		// PlannerDecisions makes the explain output include the join
+		// orders chosen and rejected by the planner, with their costs.
+		PlannerDecisions bool
+
+		// ColumnStatistics is a JSON object of keyspace name -> table name
+		// -> vindexes.TableStatistics, to plan as if the tablets had
+		// sampled these column statistics.
+		ColumnStatistics string
 	}
 
 	// TabletQuery defines a query that was sent to a given tablet and how it was
@@ -378,11 +387,35 @@ func (vte *VTExplain) ExplainsAsText(explains []*Explain) (string, error) {
 			fmt.Fprintf(&b, "%d %s: %s\n", q.Time, q.tablet, q.sql)
 		}
 		fmt.Fprintf(&b, "\n")
+
+		for _, plan := range explain.Plans {
+			writePlannerDecisions(&b, plan.Decisions)
+		}
 	}
 	fmt.Fprintf(&b, "----------------------------------------------------------------------\n")
 	return b.String(), nil
 }
 
+// writePlannerDecisions writes the join orders the planner chose and
+// rejected, with their costs.
+func writePlannerDecisions(b *strings.Builder, decisions []engine.PlannerDecision) {
+	for i, decision := range decisions {
+		fmt.Fprintf(b, "Planner decision %d:\n", i+1)
+		fmt.Fprintf(b, "chosen (%s):\n%s", candidateEstimates(decision.Chosen), decision.Chosen.Plan)
+		for _, rejected := range decision.Rejected {
+			fmt.Fprintf(b, "rejected (%s):\n%s", candidateEstimates(rejected), rejected.Plan)
+		}
+		fmt.Fprintf(b, "\n")
+	}
+}
+
+func candidateEstimates(candidate engine.PlanCandidate) string {
+	if candidate.DrivingRows == 0 {
+		return fmt.Sprintf("cost %d", candidate.Cost)
+	}
+	return fmt.Sprintf("cost %d, %d driving rows", candidate.Cost, candidate.DrivingRows)
+}
+
 func (vte *VTExplain) specialHandlingOfSavepoints(q *MysqlQuery) error {
 	if !strings.HasPrefix(q.SQL, "savepoint") {
 		return nil
@@ -175,6 +175,22 @@ func TestExplain(t *testing.T) {
 			Normalize:       true,
 			PlannerVersion:  querypb.ExecuteOptions_Gen4,
 		}},
+		{"decisions", &Options{
+			ReplicationMode:  "ROW",
+			NumShards:        4,
+			Normalize:        true,
+			PlannerDecisions: true,
+		}},
+		{"statistics", &Options{
+			ReplicationMode:  "ROW",
+			NumShards:        4,
+			Normalize:        true,
+			PlannerDecisions: true,
+			ColumnStatistics: `{"ks_sharded": {
+				"user": {"rows": 100000, "columns": {"pet": {"distinct_values": 10000, "null_fraction": 0.5}}},
+				"music": {"rows": 2000}
+			}}`,
+		}},
 	}
 
 	for _, tst := range tests {
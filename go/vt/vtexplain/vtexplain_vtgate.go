@@ -78,10 +78,45 @@ func (vte *VTExplain) initVtgateExecutor(ctx context.Context, ts *topo.Server, v
 		Normalize:    opts.Normalize,
 		StreamSize:   streamSize,
 		AllowScatter: true,
+
+		RecordPlannerDecisions: opts.PlannerDecisions,
 	}
 	vte.vtgateExecutor = vtgate.NewExecutor(ctx, vte.env, vte.explainTopo, Cell, resolver, eConfig, false, plans, schemaTracker, opts.PlannerVersion, vtgate.NewDynamicViperConfig())
 	vte.vtgateExecutor.SetQueryLogger(streamlog.New[*logstats.LogStats]("VTGate", queryLogBufferSize))
 
+	return vte.setColumnStatistics(opts.ColumnStatistics)
+}
+
+// setColumnStatistics sets the statistics of the tables of the vschema the
+// executor plans with, as the schema tracker would.
+func (vte *VTExplain) setColumnStatistics(statsStr string) error {
+	if statsStr == "" {
+		return nil
+	}
+	var keyspaces map[string]map[string]*vindexes.TableStatistics
+	if err := json2.Unmarshal([]byte(statsStr), &keyspaces); err != nil {
+		return fmt.Errorf("invalid column statistics: %v", err)
+	}
+
+	vschema := vte.vtgateExecutor.VSchema()
+	for ksName, tables := range keyspaces {
+		ks, ok := vschema.Keyspaces[ksName]
+		if !ok {
+			return fmt.Errorf("column statistics of unknown keyspace %s", ksName)
+		}
+		for tableName, tableStats := range tables {
+			table, ok := ks.Tables[tableName]
+			if !ok {
+				return fmt.Errorf("column statistics of unknown table %s.%s", ksName, tableName)
+			}
+			columns := make(map[string]vindexes.ColumnStatistics, len(tableStats.Columns))
+			for name, cs := range tableStats.Columns {
+				columns[strings.ToLower(name)] = cs
+			}
+			tableStats.Columns = columns
+			table.Statistics = tableStats
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adminacl decides which MySQL protocol users of vtgate hold the
+// administrative role, which is vtgate's equivalent of MySQL's SUPER and
+// PROCESS privileges.
+package adminacl
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"vitess.io/vitess/go/viperutil"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+type authorizedAdminUsers struct {
+	allowAll bool
+	acl      map[string]struct{}
+	source   string
+}
+
+// NewAuthorizedAdminUsers creates a new authorizedAdminUsers from a config string.
+// The string can be:
+//   - "" (empty): no users are authorized
+//   - "%": all users are authorized
+//   - "user1,group1,...": comma-separated list of authorized usernames and groups
+func NewAuthorizedAdminUsers(users string) *authorizedAdminUsers {
+	acl := make(map[string]struct{})
+	allowAll := false
+
+	switch users {
+	case "":
+		// no users authorized
+	case "%":
+		allowAll = true
+	default:
+		for user := range strings.SplitSeq(users, ",") {
+			user = strings.TrimSpace(user)
+			if user == "" {
+				continue
+			}
+			acl[user] = struct{}{}
+		}
+	}
+
+	return &authorizedAdminUsers{
+		allowAll: allowAll,
+		acl:      acl,
+		source:   users,
+	}
+}
+
+func (a *authorizedAdminUsers) String() string {
+	return a.source
+}
+
+// AuthorizedAdminUsers specifies the users and groups that hold the
+// administrative role.
+var AuthorizedAdminUsers = viperutil.Configure(
+	"admin_authorized_users",
+	viperutil.Options[*authorizedAdminUsers]{
+		FlagName: "admin-authorized-users",
+		Default:  &authorizedAdminUsers{},
+		Dynamic:  true,
+		GetFunc: func(v *viper.Viper) func(key string) *authorizedAdminUsers {
+			return func(key string) *authorizedAdminUsers {
+				newVal := v.GetString(key)
+				curVal, ok := v.Get(key).(*authorizedAdminUsers)
+				if ok && newVal == curVal.source {
+					return curVal
+				}
+				return NewAuthorizedAdminUsers(newVal)
+			}
+		},
+	},
+)
+
+// RegisterAdminACLFlags registers the admin ACL flags on the given FlagSet.
+//
+// `go/cmd/*` entrypoints should either use servenv.ParseFlags(WithArgs)? which
+// calls this function, or call this function directly before parsing
+// command-line arguments.
+func RegisterAdminACLFlags(fs *pflag.FlagSet) {
+	fs.String("admin-authorized-users", "", "Comma-separated list of users and groups authorized to run administrative MySQL protocol commands, such as COM_DEBUG, or '%' to allow all users.")
+	viperutil.BindFlags(fs, AuthorizedAdminUsers)
+}
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtgate"} {
+		servenv.OnParseFor(cmd, RegisterAdminACLFlags)
+	}
+}
+
+// Authorized returns true if the given caller, or one of its groups, holds
+// the administrative role.
+func Authorized(caller *querypb.VTGateCallerID) bool {
+	users := AuthorizedAdminUsers.Get()
+	if users.allowAll {
+		return true
+	}
+
+	if _, ok := users.acl[caller.GetUsername()]; ok {
+		return true
+	}
+	for _, group := range caller.GetGroups() {
+		if _, ok := users.acl[group]; ok {
+			return true
+		}
+	}
+	return false
+}
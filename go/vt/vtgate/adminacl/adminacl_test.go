@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adminacl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestAdminAcl(t *testing.T) {
+	dba := querypb.VTGateCallerID{Username: "dba"}
+	operator := querypb.VTGateCallerID{Username: "alice", Groups: []string{"dev", "ops"}}
+	regularUser := querypb.VTGateCallerID{Username: "regularUser", Groups: []string{"dev"}}
+
+	// By default no users are allowed in.
+	assert.False(t, Authorized(&dba))
+	assert.False(t, Authorized(&operator))
+	assert.False(t, Authorized(&regularUser))
+
+	AuthorizedAdminUsers.Set(NewAuthorizedAdminUsers("%"))
+	assert.True(t, Authorized(&dba))
+	assert.True(t, Authorized(&regularUser))
+
+	// Users are authorized by name or by group.
+	AuthorizedAdminUsers.Set(NewAuthorizedAdminUsers(" dba , ops "))
+	assert.True(t, Authorized(&dba))
+	assert.True(t, Authorized(&operator))
+	assert.False(t, Authorized(&regularUser))
+
+	AuthorizedAdminUsers.Set(NewAuthorizedAdminUsers(""))
+	assert.False(t, Authorized(&dba))
+	assert.False(t, Authorized(&operator))
+}
@@ -72,6 +72,7 @@ const (
 	ClusterEventReshardingInProgress = "current keyspace is being resharded"
 	ClusterEventReparentInProgress   = "primary is not serving, there may be a reparent operation in progress"
 	ClusterEventMoveTables           = "disallowed due to rule"
+	ClusterEventBufferingRequested   = "primary requested buffering before it stops serving"
 )
 
 var ClusterEvents []string
@@ -81,6 +82,7 @@ func init() {
 		ClusterEventReshardingInProgress,
 		ClusterEventReparentInProgress,
 		ClusterEventMoveTables,
+		ClusterEventBufferingRequested,
 	}
 }
 
@@ -161,6 +163,9 @@ type Buffer struct {
 
 	// stopped is true after Shutdown() was run.
 	stopped atomic.Bool
+
+	// shardRecords is set by WatchShardRecords.
+	shardRecords *shardRecordWatch
 }
 
 // New creates a new Buffer object.
@@ -243,6 +248,9 @@ func (b *Buffer) getOrCreateBuffer(keyspace, shard string) *shardBuffer {
 		return nil
 	}
 
+	if b.shardRecords != nil && !sb.disabled() {
+		sb.watchShardRecord(b.shardRecords)
+	}
 	return sb
 }
 
@@ -42,6 +42,8 @@ var (
 
 	bufferDrainConcurrency = 1
 	bufferKeyspaceShards   string
+
+	bufferWatchShardRecords bool
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -55,6 +57,7 @@ func registerFlags(fs *pflag.FlagSet) {
 
 	utils.SetFlagIntVar(fs, &bufferDrainConcurrency, "buffer-drain-concurrency", 1, "Maximum number of requests retried simultaneously. More concurrency will increase the load on the PRIMARY vttablet when draining the buffer.")
 	utils.SetFlagStringVar(fs, &bufferKeyspaceShards, "buffer-keyspace-shards", "", "If not empty, limit buffering to these entries (comma separated). Entry format: keyspace or keyspace/shard. Requires --enable_buffer=true.")
+	utils.SetFlagBoolVar(fs, &bufferWatchShardRecords, "buffer-watch-shard-records", false, "Watch the shard records of the buffered shards in the global topo, and start buffering as soon as a primary tablet requests it before it stops serving. See the vttablet --change-type-buffering-wait flag.")
 }
 
 func init() {
@@ -144,6 +147,10 @@ type Config struct {
 
 	DrainConcurrency int
 
+	// WatchShardRecords makes the buffer watch the shard records, to start
+	// buffering when a primary tablet requests it.
+	WatchShardRecords bool
+
 	// keyspaces has the same purpose as "shards" but applies to a whole keyspace.
 	Keyspaces map[string]bool
 	// shards is a set of keyspace/shard entries to which buffering is limited.
@@ -219,6 +226,8 @@ func NewConfigFromFlags() *Config {
 
 		DrainConcurrency: bufferDrainConcurrency,
 
+		WatchShardRecords: bufferWatchShardRecords,
+
 		Keyspaces: keyspaces,
 		Shards:    shards,
 
@@ -126,6 +126,11 @@ type shardBuffer struct {
 	// wg tracks all pending Go routines. waitForShutdown() will use this field to
 	// block on them.
 	wg sync.WaitGroup
+	// cancelShardRecordWatch stops the shard record watch, if any.
+	cancelShardRecordWatch context.CancelFunc
+	// lastBufferingRequest is the last buffering request of a primary tablet
+	// seen in the shard record.
+	lastBufferingRequest time.Time
 }
 
 func newShardBufferHealthCheck(buf *Buffer, mode bufferMode, keyspace, shard string) *shardBuffer {
@@ -661,6 +666,9 @@ func (sb *shardBuffer) drain(q []*entry, err error) {
 
 func (sb *shardBuffer) shutdown() {
 	sb.mu.Lock()
+	if sb.cancelShardRecordWatch != nil {
+		sb.cancelShardRecordWatch()
+	}
 	sb.stopBufferingLocked(stopShutdown, "shutdown")
 	sb.mu.Unlock()
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// shardRecordWatchRetryDelay is how long a shard record watch waits before it
+// restarts after an error.
+var shardRecordWatchRetryDelay = 5 * time.Second
+
+var bufferingRequestedError = vterrors.New(vtrpcpb.Code_CLUSTER_EVENT, ClusterEventBufferingRequested)
+
+// shardRecordWatch is what the shardBuffer objects need to watch their shard
+// record.
+type shardRecordWatch struct {
+	ts  *topo.Server
+	kev *discovery.KeyspaceEventWatcher
+}
+
+// WatchShardRecords makes the buffer watch the shard record of every shard
+// it buffers. When the primary tablet of the shard requests buffering in the
+// shard record before it stops serving, buffering starts right away instead
+// of when the first request fails. Buffering then stops as usual, when a
+// primary is serving again.
+//
+// It must be called before the buffer is used.
+func (b *Buffer) WatchShardRecords(ts *topo.Server, kev *discovery.KeyspaceEventWatcher) {
+	b.shardRecords = &shardRecordWatch{ts: ts, kev: kev}
+}
+
+// watchShardRecord starts the watch of the shard record. It is stopped by
+// shutdown().
+func (sb *shardBuffer) watchShardRecord(w *shardRecordWatch) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sb.mu.Lock()
+	sb.cancelShardRecordWatch = cancel
+	sb.mu.Unlock()
+
+	sb.wg.Add(1)
+	go func() {
+		defer sb.wg.Done()
+		for {
+			current, changes, err := w.ts.WatchShard(ctx, sb.keyspace, sb.shard)
+			if err == nil {
+				sb.onShardRecord(ctx, w.kev, current.Value)
+				for change := range changes {
+					if change.Err != nil {
+						// The channel is closed after an error.
+						err = change.Err
+						continue
+					}
+					sb.onShardRecord(ctx, w.kev, change.Value)
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warn(fmt.Sprintf("Watch of the shard record of %s failed, retrying in %v: %v",
+				topoproto.KeyspaceShardString(sb.keyspace, sb.shard), shardRecordWatchRetryDelay, err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(shardRecordWatchRetryDelay):
+			}
+		}
+	}()
+}
+
+// onShardRecord starts buffering if the shard record has a new buffering
+// request of the primary tablet.
+func (sb *shardBuffer) onShardRecord(ctx context.Context, kev *discovery.KeyspaceEventWatcher, shard *topodatapb.Shard) {
+	if shard.GetBufferingRequestedTime() == nil {
+		return
+	}
+	requested := protoutil.TimeFromProto(shard.BufferingRequestedTime)
+	if sb.timeNow().Sub(requested) > sb.buf.config.MaxFailoverDuration {
+		// The request is stale, e.g. the tablet died before it cleared it.
+		return
+	}
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if requested.Equal(sb.lastBufferingRequest) {
+		// We already acted on this request, the record changed for another reason.
+		return
+	}
+	sb.lastBufferingRequest = requested
+	if bufferState(sb.state.Load()) != stateIdle {
+		return
+	}
+	sb.startBufferingLocked(ctx, kev, bufferingRequestedError)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// TestShardRecordBufferingRequest tests that a buffering request of the
+// primary tablet in the shard record starts buffering before any request
+// fails, and that the same request is not acted upon twice.
+func TestShardRecordBufferingRequest(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, keyspace, shard))
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.WatchShardRecords = true
+	cfg.Keyspaces = map[string]bool{keyspace: true}
+	b := New(cfg)
+	b.WatchShardRecords(ts, nil)
+	defer b.Shutdown()
+
+	// Creates the shard buffer, and with it the watch.
+	require.NoError(t, waitForState(b, stateIdle))
+
+	requested := time.Now()
+	_, err := ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.BufferingRequestedTime = protoutil.TimeToProto(requested)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, waitForState(b, stateBuffering))
+
+	stopped := issueRequest(ctx, t, b, nil)
+	require.NoError(t, waitForRequestsInFlight(b, 1))
+
+	// The new primary is serving: buffering stops.
+	b.HandleKeyspaceEvent(&discovery.KeyspaceEvent{
+		Keyspace: keyspace,
+		Shards: []discovery.ShardEvent{{
+			Tablet:  newPrimary.Alias,
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_PRIMARY},
+			Serving: true,
+		}},
+	})
+	require.NoError(t, waitForState(b, stateIdle))
+	require.NoError(t, <-stopped)
+
+	// Another change of the shard record with the same request must not
+	// start buffering again.
+	_, err = ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = newPrimary.Alias
+		return nil
+	})
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, stateIdle, b.getOrCreateBuffer(keyspace, shard).testGetState())
+	require.EqualValues(t, 1, starts.Counts()[statsKeyJoined])
+	require.NoError(t, waitForPoolSlots(b, cfg.Size))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/discovery"
+)
+
+// tableCutOverCheckInterval is how often WaitForTableCutOvers checks whether
+// the cut-overs are done. Cut-overs usually take less than a second.
+var tableCutOverCheckInterval = 10 * time.Millisecond
+
+// WaitForTableCutOvers blocks while the primary tablets report that an Online
+// DDL cut-over of one of the tables is imminent, so that the request isn't
+// held or failed by the tablet while the tables are swapped. tables are
+// keyspace-qualified, as in the TablesUsed of a plan.
+//
+// Requests are held for the buffer window at most, and then sent to the
+// tablets anyway. Only keyspaces with buffering enabled are waited for.
+func (b *Buffer) WaitForTableCutOvers(ctx context.Context, kev *discovery.KeyspaceEventWatcher, tables []string) error {
+	if kev == nil || b.stopped.Load() {
+		return nil
+	}
+
+	var waitCtx context.Context
+	for keyspace, names := range tablesByKeyspace(tables) {
+		if b.config.bufferingMode(keyspace, "") != bufferModeEnabled || !kev.TablesInCutOver(ctx, keyspace, names) {
+			continue
+		}
+		if waitCtx == nil {
+			var cancel context.CancelFunc
+			waitCtx, cancel = context.WithTimeout(ctx, b.config.Window)
+			defer cancel()
+		}
+
+		result := waitForTableCutOver(ctx, waitCtx, kev, keyspace, names)
+		tableCutOverRequests.Add([]string{keyspace, result}, 1)
+		if result == tableCutOverCanceled {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// waitForTableCutOver waits until the cut-overs of the tables of the keyspace
+// are done, or waitCtx is done. It returns the tableCutOverRequests result.
+func waitForTableCutOver(ctx, waitCtx context.Context, kev *discovery.KeyspaceEventWatcher, keyspace string, tables []string) string {
+	for kev.TablesInCutOver(ctx, keyspace, tables) {
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return tableCutOverCanceled
+			}
+			return tableCutOverWindowExceeded
+		case <-time.After(tableCutOverCheckInterval):
+		}
+	}
+	return tableCutOverDone
+}
+
+// tablesByKeyspace groups keyspace-qualified table names by keyspace.
+func tablesByKeyspace(tables []string) map[string][]string {
+	var byKeyspace map[string][]string
+	for _, table := range tables {
+		keyspace, name, found := strings.Cut(table, ".")
+		if !found {
+			continue
+		}
+		if byKeyspace == nil {
+			byKeyspace = make(map[string][]string)
+		}
+		byKeyspace[keyspace] = append(byKeyspace[keyspace], name)
+	}
+	return byKeyspace
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestWaitForTableCutOvers(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	require.NoError(t, ts.UpdateSrvKeyspace(ctx, "cell1", keyspace, &topodatapb.SrvKeyspace{}))
+
+	hc := discovery.NewFakeHealthCheck(make(chan *discovery.TabletHealth, 10))
+	counts := stats.NewCountersWithSingleLabel("", "", "type")
+	kev := discovery.NewKeyspaceEventWatcher(ctx, srvtopo.NewResilientServer(ctx, ts, counts), hc, "cell1")
+
+	primary := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 100},
+		Keyspace: keyspace,
+		Shard:    shard,
+		Type:     topodatapb.TabletType_PRIMARY,
+	}
+	hc.AddTablet(primary)
+	setCutOverTables := func(tables ...string) {
+		hc.UpdateHealth(&discovery.TabletHealth{
+			Tablet: primary,
+			Target: &querypb.Target{
+				Keyspace:   keyspace,
+				Shard:      shard,
+				TabletType: topodatapb.TabletType_PRIMARY,
+			},
+			Serving:              true,
+			PrimaryTermStartTime: 1,
+			Stats:                &querypb.RealtimeStats{CutoverTables: tables},
+		})
+		hc.Broadcast(primary)
+		require.Eventually(t, func() bool {
+			return kev.TablesInCutOver(ctx, keyspace, []string{"t1"}) == (len(tables) > 0)
+		}, 10*time.Second, time.Millisecond)
+	}
+	setCutOverTables()
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.Window = 100 * time.Millisecond
+	b := New(cfg)
+	defer b.Shutdown()
+	tables := []string{keyspace + ".t1", "other.t1"}
+
+	// Without a cut-over, requests are not held.
+	require.NoError(t, b.WaitForTableCutOvers(ctx, kev, tables))
+
+	setCutOverTables("t1")
+	tableCutOverRequests.ResetAll()
+
+	// The request is held for the buffer window at most.
+	start := time.Now()
+	require.NoError(t, b.WaitForTableCutOvers(ctx, kev, tables))
+	require.GreaterOrEqual(t, time.Since(start), cfg.Window)
+	require.EqualValues(t, 1, tableCutOverRequests.Counts()[keyspace+"."+tableCutOverWindowExceeded])
+
+	// The request is held until the cut-over is done.
+	cfg.Window = time.Minute
+	done := make(chan error)
+	go func() {
+		done <- b.WaitForTableCutOvers(ctx, kev, tables)
+	}()
+	select {
+	case err := <-done:
+		require.Fail(t, "request was not held", "err: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	setCutOverTables()
+	require.NoError(t, <-done)
+	require.EqualValues(t, 1, tableCutOverRequests.Counts()[keyspace+"."+tableCutOverDone])
+
+	// A canceled request returns right away.
+	setCutOverTables("t1")
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, b.WaitForTableCutOvers(cancelCtx, kev, tables), context.Canceled)
+	require.EqualValues(t, 1, tableCutOverRequests.Counts()[keyspace+"."+tableCutOverCanceled])
+
+	// Requests on keyspaces without buffering are not held.
+	cfg.Keyspaces = map[string]bool{"other": true}
+	cfg.Enabled = false
+	require.NoError(t, b.WaitForTableCutOvers(ctx, kev, tables))
+}
+
+func TestTablesByKeyspace(t *testing.T) {
+	require.Nil(t, tablesByKeyspace(nil))
+	require.Equal(t, map[string][]string{
+		"ks1": {"t1", "t2"},
+		"ks2": {"t1"},
+	}, tablesByKeyspace([]string{"ks1.t1", "ks2.t1", "dual", "ks1.t2"}))
+}
@@ -100,6 +100,19 @@ var (
 		"BufferRequestsSkipped",
 		"Skipped buffering requests (incl. dry-run)",
 		[]string{"Keyspace", "ShardName", "Reason"})
+	// tableCutOverRequests counts the requests which were held because an
+	// Online DDL cut-over of one of their tables was imminent.
+	// See the constants below for all possible values of "Result".
+	tableCutOverRequests = stats.NewCountersWithMultiLabels(
+		"BufferTableCutOverRequests",
+		"Requests buffered for an Online DDL cut-over",
+		[]string{"Keyspace", "Result"})
+)
+
+const (
+	tableCutOverDone           = "CutOverDone"
+	tableCutOverWindowExceeded = "WindowExceeded"
+	tableCutOverCanceled       = "ContextDone"
 )
 
 // stopReason is used in "stopsByReason" as "Reason" label.
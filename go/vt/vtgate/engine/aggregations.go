@@ -18,9 +18,14 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
+	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -55,9 +60,58 @@ type AggregateParams struct {
 	// not what we use to aggregate at the engine primitive level.
 	OrigOpcode opcode.AggregateOpcode
 
+	// GroupConcat is set for a GROUP_CONCAT that is evaluated from the
+	// values of its arguments, rather than from the results of GROUP_CONCAT
+	// on every shard.
+	GroupConcat *GroupConcatParams
+
 	CollationEnv *collations.Environment
 }
 
+// GroupConcatParams specify how a GROUP_CONCAT is evaluated from the values
+// of its arguments.
+type GroupConcatParams struct {
+	// ArgCols are the columns of the arguments, which are concatenated for
+	// every row. Rows in which any of them is NULL are skipped.
+	ArgCols []int
+	// DistinctCols are set for GROUP_CONCAT(DISTINCT ...), to skip the rows
+	// with the same argument values as a previous row.
+	DistinctCols []CheckCol
+	OrderBy      evalengine.Comparison
+	// Limit and Offset are the LIMIT clause of the GROUP_CONCAT, if any.
+	Limit, Offset evalengine.Expr
+}
+
+func (gc *GroupConcatParams) String() string {
+	var buf strings.Builder
+	if len(gc.DistinctCols) > 0 {
+		buf.WriteString("distinct ")
+	}
+	for i, col := range gc.ArgCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Itoa(col))
+	}
+	for i, obp := range gc.OrderBy {
+		if i == 0 {
+			buf.WriteString(" order by ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(obp.String())
+	}
+	if gc.Limit != nil {
+		buf.WriteString(" limit ")
+		if gc.Offset != nil {
+			buf.WriteString(sqlparser.String(gc.Offset))
+			buf.WriteString(", ")
+		}
+		buf.WriteString(sqlparser.String(gc.Limit))
+	}
+	return buf.String()
+}
+
 // NewAggregateParam creates a new aggregate param
 func NewAggregateParam(
 	oc opcode.AggregateOpcode,
@@ -92,6 +146,9 @@ func (ap *AggregateParams) String() string {
 	if ap.EExpr != nil {
 		keyCol = sqlparser.String(ap.EExpr)
 	}
+	if ap.GroupConcat != nil {
+		keyCol = ap.GroupConcat.String()
+	}
 	if ap.WAssigned() {
 		keyCol = fmt.Sprintf("%s|%d", keyCol, ap.WCol)
 	}
@@ -128,6 +185,12 @@ type aggregator interface {
 	reset()
 }
 
+// memoryHolder is implemented by the aggregators that hold memory for the
+// rows of the group they aggregate, until they are reset.
+type memoryHolder interface {
+	heldMemory() int64
+}
+
 type aggregatorDistinct struct {
 	column       int
 	last         sqltypes.Value
@@ -304,36 +367,130 @@ func eval(env *evalengine.ExpressionEnv, eexpr evalengine.Expr, coll collations.
 func (*aggregatorConstant) reset() {}
 
 type aggregatorGroupConcat struct {
-	from      int
+	args      []int
 	type_     sqltypes.Type
 	separator []byte
+	maxLen    int
+	charset   charset.Charset
+
+	// These are only set when the GROUP_CONCAT is evaluated from the values
+	// of its arguments.
+	distinct *probeTable
+	orderBy  evalengine.Comparison
+	offset   int
+	limit    int
+	// rows are the rows that are sorted and limited before they are
+	// concatenated.
+	rows []sqltypes.Row
 
 	concat []byte
 	n      int
+
+	// held is the number of bytes of memory held by the rows and the
+	// distinct probe table of the group.
+	held int64
 }
 
 func (a *aggregatorGroupConcat) add(row []sqltypes.Value) error {
-	if row[a.from].IsNull() {
+	for _, arg := range a.args {
+		if row[arg].IsNull() {
+			return nil
+		}
+	}
+	if a.distinct != nil {
+		unique, err := a.distinct.exists(row)
+		if err != nil {
+			return err
+		}
+		if unique == nil {
+			return nil
+		}
+		a.held += probeEntryMemory
+	}
+	if a.orderBy != nil || a.limit >= 0 {
+		a.rows = append(a.rows, row)
+		a.held += rowMemory(row)
 		return nil
 	}
+	a.append(row)
+	return nil
+}
+
+// append concatenates the argument values of row to the result, unless the
+// result is already longer than group_concat_max_len.
+func (a *aggregatorGroupConcat) append(row []sqltypes.Value) {
+	if len(a.concat) > a.maxLen {
+		a.n++
+		return
+	}
 	if a.n > 0 {
 		a.concat = append(a.concat, a.separator...)
 	}
-	a.concat = append(a.concat, row[a.from].Raw()...)
+	for _, arg := range a.args {
+		a.concat = append(a.concat, row[arg].Raw()...)
+	}
 	a.n++
-	return nil
 }
 
 func (a *aggregatorGroupConcat) finish(*evalengine.ExpressionEnv, collations.ID) (sqltypes.Value, error) {
+	if a.rows != nil {
+		if a.orderBy != nil {
+			if err := a.sort(); err != nil {
+				return sqltypes.Value{}, err
+			}
+		}
+		rows := a.rows[min(a.offset, len(a.rows)):]
+		if a.limit >= 0 && a.limit < len(rows) {
+			rows = rows[:a.limit]
+		}
+		for _, row := range rows {
+			a.append(row)
+		}
+	}
 	if a.n == 0 {
 		return sqltypes.NULL, nil
 	}
-	return sqltypes.MakeTrusted(a.type_, a.concat), nil
+	return sqltypes.MakeTrusted(a.type_, a.truncate()), nil
+}
+
+func (a *aggregatorGroupConcat) sort() (err error) {
+	defer evalengine.PanicHandler(&err)
+	slices.SortStableFunc(a.rows, a.orderBy.Compare)
+	return nil
+}
+
+// truncate returns the result truncated to group_concat_max_len bytes, without
+// splitting the last character, like MySQL does.
+func (a *aggregatorGroupConcat) truncate() []byte {
+	if len(a.concat) <= a.maxLen {
+		return a.concat
+	}
+	if a.charset == nil {
+		return a.concat[:a.maxLen]
+	}
+	n := 0
+	for n < a.maxLen {
+		_, size := a.charset.DecodeRune(a.concat[n:])
+		if n+max(size, 1) > a.maxLen {
+			break
+		}
+		n += max(size, 1)
+	}
+	return a.concat[:n]
 }
 
 func (a *aggregatorGroupConcat) reset() {
 	a.n = 0
 	a.concat = nil // not safe to reuse this byte slice as it's returned as MakeTrusted
+	a.rows = nil
+	a.held = 0
+	if a.distinct != nil {
+		clear(a.distinct.seenRows)
+	}
+}
+
+func (a *aggregatorGroupConcat) heldMemory() int64 {
+	return a.held
 }
 
 type aggregatorGtid struct {
@@ -386,6 +543,18 @@ func (a *aggregationState) finish() ([]sqltypes.Value, error) {
 	return row, nil
 }
 
+// heldMemory returns the number of bytes of memory held by the aggregators
+// for the rows of the group they aggregate.
+func (a *aggregationState) heldMemory() int64 {
+	var held int64
+	for _, st := range a.aggregators {
+		if mh, ok := st.(memoryHolder); ok {
+			held += mh.heldMemory()
+		}
+	}
+	return held
+}
+
 func (a *aggregationState) reset() {
 	for _, st := range a.aggregators {
 		st.reset()
@@ -411,7 +580,30 @@ func isComparable(typ sqltypes.Type) bool {
 	return false
 }
 
-func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env *evalengine.ExpressionEnv, collation collations.ID) (*aggregationState, []*querypb.Field, error) {
+// defaultGroupConcatMaxLen is the default value of group_concat_max_len in MySQL.
+const defaultGroupConcatMaxLen = 1024
+
+// groupConcatMaxLen returns the group_concat_max_len of the session, which
+// limits the length of the results of GROUP_CONCAT aggregations.
+func groupConcatMaxLen(vcursor VCursor, aggregates []*AggregateParams) int {
+	if !slices.ContainsFunc(aggregates, func(aggr *AggregateParams) bool {
+		return aggr.Opcode == opcode.AggregateGroupConcat
+	}) {
+		return defaultGroupConcatMaxLen
+	}
+	maxLen := defaultGroupConcatMaxLen
+	vcursor.Session().GetSystemVariables(func(k, v string) {
+		if k != "group_concat_max_len" {
+			return
+		}
+		if n, err := strconv.ParseUint(strings.Trim(v, "'"), 10, 64); err == nil {
+			maxLen = int(min(n, math.MaxInt))
+		}
+	})
+	return maxLen
+}
+
+func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env *evalengine.ExpressionEnv, collation collations.ID, groupConcatMaxLen int) (*aggregationState, []*querypb.Field, error) {
 	fields = slice.Map(fields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
 
 	aggregators := make([]aggregator, len(fields))
@@ -497,12 +689,44 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams, env
 
 		case opcode.AggregateGroupConcat:
 			gcFunc := aggr.Func.(*sqlparser.GroupConcatExpr)
-			separator := []byte(gcFunc.Separator)
-			ag = &aggregatorGroupConcat{
-				from:      aggr.Col,
+			separator := sqlparser.GroupConcatDefaultSeparator
+			if gcFunc.Separator != "" {
+				// The separator is kept as an SQL string literal in the AST.
+				var err error
+				separator, err = sqltypes.DecodeStringSQL(gcFunc.Separator)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			gc := &aggregatorGroupConcat{
+				args:      []int{aggr.Col},
 				type_:     targetType,
-				separator: separator,
+				separator: []byte(separator),
+				maxLen:    groupConcatMaxLen,
+				limit:     -1,
+			}
+			if aggr.Col < len(fields) {
+				if cs := colldata.Lookup(collations.ID(fields[aggr.Col].Charset)); cs != nil {
+					gc.charset = cs.Charset()
+				}
+			}
+			if params := aggr.GroupConcat; params != nil {
+				gc.args = params.ArgCols
+				if len(params.DistinctCols) > 0 {
+					gc.distinct = newProbeTable(params.DistinctCols, aggr.CollationEnv)
+				}
+				gc.orderBy = slices.Clone(params.OrderBy)
+				if params.Limit != nil {
+					var err error
+					if gc.limit, err = getIntFrom(env, collation, params.Limit); err != nil {
+						return nil, nil, err
+					}
+					if gc.offset, err = getIntFrom(env, collation, params.Offset); err != nil {
+						return nil, nil, err
+					}
+				}
 			}
+			ag = gc
 
 		case opcode.AggregateConstant:
 			ag = &aggregatorConstant{expr: aggr.EExpr}
@@ -29,7 +29,7 @@ func (cached *AggregateParams) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(128)
+		size += int64(144)
 	}
 	// field EExpr vitess.io/vitess/go/vt/vtgate/evalengine.Expr
 	if cc, ok := cached.EExpr.(cachedObject); ok {
@@ -45,6 +45,8 @@ func (cached *AggregateParams) CachedSize(alloc bool) int64 {
 	}
 	// field Original *vitess.io/vitess/go/vt/sqlparser.AliasedExpr
 	size += cached.Original.CachedSize(true)
+	// field GroupConcat *vitess.io/vitess/go/vt/vtgate/engine.GroupConcatParams
+	size += cached.GroupConcat.CachedSize(true)
 	// field CollationEnv *vitess.io/vitess/go/mysql/collations.Environment
 	size += cached.CollationEnv.CachedSize(true)
 	return size
@@ -84,6 +86,21 @@ func (cached *CheckCol) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *ChunkableList) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	// field Type vitess.io/vitess/go/vt/vtgate/evalengine.Type
+	size += cached.Type.CachedSize(false)
+	return size
+}
+
 //go:nocheckptr
 func (cached *Concatenate) CachedSize(alloc bool) int64 {
 	if cached == nil {
@@ -91,7 +108,7 @@ func (cached *Concatenate) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(32)
+		size += int64(64)
 	}
 	// field Sources []vitess.io/vitess/go/vt/vtgate/engine.Primitive
 	{
@@ -106,6 +123,13 @@ func (cached *Concatenate) CachedSize(alloc bool) int64 {
 	if cached.NoNeedToTypeCheck != nil {
 		size += hack.RuntimeMapSize(cached.NoNeedToTypeCheck)
 	}
+	// field OrderBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.OrderBy)) * int64(56))
+		for _, elem := range cached.OrderBy {
+			size += elem.CachedSize(false)
+		}
+	}
 	return size
 }
 
@@ -452,6 +476,43 @@ func (cached *GroupByParams) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *GroupConcatParams) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field ArgCols []int
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.ArgCols)) * int64(8))
+	}
+	// field DistinctCols []vitess.io/vitess/go/vt/vtgate/engine.CheckCol
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.DistinctCols)) * int64(48))
+		for _, elem := range cached.DistinctCols {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field OrderBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.OrderBy)) * int64(56))
+		for _, elem := range cached.OrderBy {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field Limit vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Limit.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Offset vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Offset.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
 func (cached *HashJoin) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -598,6 +659,21 @@ func (cached *InsertSelect) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *JSONRows) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
 //go:nocheckptr
 func (cached *Join) CachedSize(alloc bool) int64 {
 	if cached == nil {
@@ -652,6 +728,40 @@ func (cached *Limit) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *LoadDataFormat) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field FieldsTerminatedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.FieldsTerminatedBy)))
+	// field LinesStartingBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.LinesStartingBy)))
+	// field LinesTerminatedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.LinesTerminatedBy)))
+	return size
+}
+
+func (cached *LoadDataLocal) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field Filename string
+	size += hack.RuntimeAllocSize(int64(len(cached.Filename)))
+	// field Insert string
+	size += hack.RuntimeAllocSize(int64(len(cached.Insert)))
+	// field Format vitess.io/vitess/go/vt/vtgate/engine.LoadDataFormat
+	size += cached.Format.CachedSize(false)
+	return size
+}
+
 func (cached *Lock) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -714,6 +824,41 @@ func (cached *MStream) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *Mask) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Columns []vitess.io/vitess/go/vt/vtgate/engine.MaskedColumn
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Columns)) * int64(16))
+		for _, elem := range cached.Columns {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
+func (cached *MaskedColumn) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field Mask *vitess.io/vitess/go/vt/proto/vschema.ColumnMask
+	size += cached.Mask.CachedSize(true)
+	return size
+}
+
 func (cached *MemorySort) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -840,7 +985,7 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(224)
+		size += int64(256)
 	}
 	// field Original string
 	size += hack.RuntimeAllocSize(int64(len(cached.Original)))
@@ -866,6 +1011,26 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	}
 	// field QueryHints vitess.io/vitess/go/vt/sqlparser.QueryHints
 	size += cached.QueryHints.CachedSize(false)
+	// field Decisions []vitess.io/vitess/go/vt/vtgate/engine.PlannerDecision
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Decisions)) * int64(48))
+		for _, elem := range cached.Decisions {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
+
+func (cached *PlanCandidate) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(24)
+	}
+	// field Plan string
+	size += hack.RuntimeAllocSize(int64(len(cached.Plan)))
 	return size
 }
 
@@ -895,6 +1060,26 @@ func (cached *PlanSwitcher) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *PlannerDecision) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Chosen vitess.io/vitess/go/vt/vtgate/engine.PlanCandidate
+	size += cached.Chosen.CachedSize(false)
+	// field Rejected []vitess.io/vitess/go/vt/vtgate/engine.PlanCandidate
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Rejected)) * int64(24))
+		for _, elem := range cached.Rejected {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
+
 func (cached *PrepareStmt) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1038,7 +1223,7 @@ func (cached *Route) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(112)
+		size += int64(144)
 	}
 	// field Query string
 	size += hack.RuntimeAllocSize(int64(len(cached.Query)))
@@ -1057,6 +1242,13 @@ func (cached *Route) CachedSize(alloc bool) int64 {
 	}
 	// field RoutingParameters *vitess.io/vitess/go/vt/vtgate/engine.RoutingParameters
 	size += cached.RoutingParameters.CachedSize(true)
+	// field ChunkableLists []vitess.io/vitess/go/vt/vtgate/engine.ChunkableList
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.ChunkableLists)) * int64(40))
+		for _, elem := range cached.ChunkableLists {
+			size += elem.CachedSize(false)
+		}
+	}
 	return size
 }
 
@@ -1290,7 +1482,7 @@ func (cached *ShowExec) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(16)
+		size += int64(24)
 	}
 	// field ShowFilter *vitess.io/vitess/go/vt/sqlparser.ShowFilter
 	size += cached.ShowFilter.CachedSize(true)
@@ -1397,6 +1589,27 @@ func (cached *SysVarSetAware) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *SysVarSetGlobal) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	// field Keyspace *vitess.io/vitess/go/vt/vtgate/vindexes.Keyspace
+	size += cached.Keyspace.CachedSize(true)
+	// field TargetDestination vitess.io/vitess/go/vt/key.ShardDestination
+	if cc, ok := cached.TargetDestination.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Expr string
+	size += hack.RuntimeAllocSize(int64(len(cached.Expr)))
+	return size
+}
+
 func (cached *ThrottleApp) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1715,6 +1928,61 @@ func (cached *VitessMetadata) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *Window) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Funcs []*vitess.io/vitess/go/vt/vtgate/engine.WindowParams
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Funcs)) * int64(8))
+		for _, elem := range cached.Funcs {
+			size += elem.CachedSize(true)
+		}
+	}
+	return size
+}
+
+func (cached *WindowParams) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(128)
+	}
+	// field PartitionBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.PartitionBy)) * int64(56))
+		for _, elem := range cached.PartitionBy {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field OrderBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.OrderBy)) * int64(56))
+		for _, elem := range cached.OrderBy {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field Frame *vitess.io/vitess/go/vt/vtgate/evalengine.WindowFrame
+	if cached.Frame != nil {
+		size += hack.RuntimeAllocSize(int64(40))
+	}
+	// field Alias string
+	size += hack.RuntimeAllocSize(int64(len(cached.Alias)))
+	// field Type vitess.io/vitess/go/vt/vtgate/evalengine.Type
+	size += cached.Type.CachedSize(false)
+	return size
+}
+
 func (cached *percentBasedMirror) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
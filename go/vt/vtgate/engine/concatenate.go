@@ -40,6 +40,14 @@ type Concatenate struct {
 	// These column offsets do not need to be typed checked - they usually contain weight_string()
 	// columns that are not going to be returned to the user
 	NoNeedToTypeCheck map[int]any
+
+	// OrderBy is set when every source returns its rows sorted by it. The rows of the
+	// sources are then merged instead of appended, so the result is sorted the same way.
+	OrderBy evalengine.Comparison
+
+	// TruncateColumnCount specifies the number of columns to return
+	// after merging. 0 means no truncation.
+	TruncateColumnCount int
 }
 
 // NewConcatenate creates a Concatenate primitive. The ignoreCols slice contains the offsets that
@@ -71,6 +79,14 @@ func (c *Concatenate) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 		return nil, err
 	}
 
+	if len(c.OrderBy) > 0 {
+		sourceResults := make([][]*sqltypes.Result, len(res))
+		for i, r := range res {
+			sourceResults[i] = []*sqltypes.Result{r}
+		}
+		return c.mergeSources(sourceResults, fields, fieldTypes, evalengine.ParseSQLMode(vcursor.SQLMode()))
+	}
+
 	var rows [][]sqltypes.Value
 	callback := func(result *sqltypes.Result) error {
 		rows = append(rows, result.Rows...)
@@ -88,6 +104,43 @@ func (c *Concatenate) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 	}, nil
 }
 
+// mergeSources coerces the rows of every source to the field types, and merges
+// the sorted rows of the sources by OrderBy.
+func (c *Concatenate) mergeSources(sourceResults [][]*sqltypes.Result, fields []*querypb.Field, fieldTypes []evalengine.Type, sqlmode evalengine.SQLMode) (result *sqltypes.Result, err error) {
+	defer evalengine.PanicHandler(&err)
+
+	sourceRows := make([][]sqltypes.Row, len(sourceResults))
+	merge := &evalengine.Merger{
+		Compare: c.OrderBy,
+	}
+	for i, res := range sourceResults {
+		if err := c.coerceAndVisitResultsForOneSource(res, fields, fieldTypes, func(result *sqltypes.Result) error {
+			sourceRows[i] = append(sourceRows[i], result.Rows...)
+			return nil
+		}, sqlmode); err != nil {
+			return nil, err
+		}
+		if len(sourceRows[i]) > 0 {
+			merge.Push(sourceRows[i][0], i)
+			sourceRows[i] = sourceRows[i][1:]
+		}
+	}
+	merge.Init()
+
+	result = &sqltypes.Result{Fields: fields}
+	for merge.Len() != 0 {
+		row, source := merge.Peek()
+		result.Rows = append(result.Rows, row)
+		if len(sourceRows[source]) == 0 {
+			merge.Pop()
+			continue
+		}
+		merge.ReplaceMin(sourceRows[source][0], source)
+		sourceRows[source] = sourceRows[source][1:]
+	}
+	return result.Truncate(c.TruncateColumnCount), nil
+}
+
 func (c *Concatenate) coerceValuesTo(row sqltypes.Row, fieldTypes []evalengine.Type, sqlmode evalengine.SQLMode) error {
 	if len(row) != len(fieldTypes) {
 		return errWrongNumberOfColumnsInSelect
@@ -199,6 +252,9 @@ func (c *Concatenate) sequentialExec(ctx context.Context, vcursor VCursor, bindV
 // TryStreamExecute performs a streaming exec.
 func (c *Concatenate) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
 	sqlmode := evalengine.ParseSQLMode(vcursor.SQLMode())
+	if len(c.OrderBy) > 0 {
+		return c.mergeStreamExec(ctx, vcursor, bindVars, callback, sqlmode)
+	}
 	if vcursor.Session().InTransaction() {
 		// as we are in a transaction, we need to execute all queries inside a single connection,
 		// which holds the single transaction we have
@@ -363,6 +419,65 @@ func (c *Concatenate) sequentialStreamExec(ctx context.Context, vcursor VCursor,
 	return nil
 }
 
+// mergeStreamExec buffers the results of all the sources, as no row can be sent before
+// the first row of every source is known, and sends them merged by OrderBy.
+func (c *Concatenate) mergeStreamExec(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, callback func(*sqltypes.Result) error, sqlmode evalengine.SQLMode) error {
+	results := make([][]*sqltypes.Result, len(c.Sources))
+
+	var mu sync.Mutex
+	streamSource := func(ctx context.Context, idx int) error {
+		vars := copyBindVars(bindVars)
+		return vcursor.StreamExecutePrimitive(ctx, c.Sources[idx], vars, true, func(resultChunk *sqltypes.Result) error {
+			// check if context has expired.
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[idx] = append(results[idx], resultChunk)
+			return nil
+		})
+	}
+
+	if vcursor.Session().InTransaction() {
+		// as we are in a transaction, we need to execute all queries inside a single connection,
+		// which holds the single transaction we have
+		for idx := range c.Sources {
+			if err := streamSource(ctx, idx); err != nil {
+				return err
+			}
+		}
+	} else {
+		g, gctx := errgroup.WithContext(ctx)
+		for idx := range c.Sources {
+			g.Go(func() error {
+				return streamSource(gctx, idx)
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+	}
+
+	var firsts []*sqltypes.Result
+	for _, result := range results {
+		if len(result) > 0 {
+			firsts = append(firsts, result[0])
+		}
+	}
+
+	fields, fieldTypes, err := c.getFieldTypes(vcursor, firsts)
+	if err != nil {
+		return err
+	}
+	result, err := c.mergeSources(results, fields, fieldTypes, sqlmode)
+	if err != nil {
+		return err
+	}
+	return callback(result)
+}
+
 func (c *Concatenate) coerceAndVisitResultsForOneSource(
 	res []*sqltypes.Result,
 	fields []*querypb.Field,
@@ -449,5 +564,17 @@ func (c *Concatenate) Inputs() ([]Primitive, []map[string]any) {
 }
 
 func (c *Concatenate) description() PrimitiveDescription {
-	return PrimitiveDescription{OperatorType: "Concatenate"}
+	var other map[string]any
+	if len(c.OrderBy) > 0 {
+		other = map[string]any{
+			"OrderBy": GenericJoin(c.OrderBy, orderByToString),
+		}
+		if c.TruncateColumnCount > 0 {
+			other["ResultColumns"] = c.TruncateColumnCount
+		}
+	}
+	return PrimitiveDescription{
+		OperatorType: "Concatenate",
+		Other:        other,
+	}
 }
@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/test/utils"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 
 	"github.com/stretchr/testify/require"
 
@@ -211,6 +212,72 @@ func TestConcatenateStreamBindVarIsolation(t *testing.T) {
 	})
 }
 
+func TestConcatenateOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []*sqltypes.Result
+		desc     bool
+		expected *sqltypes.Result
+	}{{
+		name: "ascending",
+		inputs: []*sqltypes.Result{
+			r("id|col|extra", "int64|varchar|int64", "1|a|0", "4|d|0", "5|e|0"),
+			r("id|col|extra", "int64|varchar|int64", "2|b|0", "3|c|0", "6|f|0"),
+			r("id|col|extra", "int64|varchar|int64", "3|g|0"),
+		},
+		expected: r("id|col", "int64|varchar", "1|a", "2|b", "3|c", "3|g", "4|d", "5|e", "6|f"),
+	}, {
+		name: "descending",
+		inputs: []*sqltypes.Result{
+			r("id|col|extra", "int64|varchar|int64", "5|e|0", "4|d|0", "1|a|0"),
+			r("id|col|extra", "int64|varchar|int64", "6|f|0", "2|b|0"),
+		},
+		desc:     true,
+		expected: r("id|col", "int64|varchar", "6|f", "5|e", "4|d", "2|b", "1|a"),
+	}, {
+		name: "empty sources",
+		inputs: []*sqltypes.Result{
+			r("id|col|extra", "int64|varchar|int64"),
+			r("id|col|extra", "int64|varchar|int64", "1|a|0", "2|b|0"),
+			r("id|col|extra", "int64|varchar|int64"),
+		},
+		expected: r("id|col", "int64|varchar", "1|a", "2|b"),
+	}}
+
+	for _, tc := range tests {
+		for _, tx := range []bool{false, true} {
+			var sources []Primitive
+			for _, input := range tc.inputs {
+				// input is added twice, since the first one is used by execute and the next by stream execute
+				sources = append(sources, &fakePrimitive{results: []*sqltypes.Result{input, input}})
+			}
+
+			concatenate := NewConcatenate(sources, nil)
+			concatenate.OrderBy = evalengine.Comparison{{
+				Col:             0,
+				WeightStringCol: -1,
+				Desc:            tc.desc,
+			}}
+			concatenate.TruncateColumnCount = 2
+			vcursor := &noopVCursor{inTx: tx}
+
+			t.Run(fmt.Sprintf("%s-InTx=%t-Exec", tc.name, tx), func(t *testing.T) {
+				qr, err := concatenate.TryExecute(t.Context(), vcursor, nil, true)
+				require.NoError(t, err)
+				utils.MustMatch(t, tc.expected.Rows, qr.Rows)
+				require.Len(t, qr.Fields, 2)
+			})
+
+			t.Run(fmt.Sprintf("%s-InTx=%t-StreamExec", tc.name, tx), func(t *testing.T) {
+				qr, err := wrapStreamExecute(concatenate, vcursor, nil, true)
+				require.NoError(t, err)
+				utils.MustMatch(t, tc.expected.Rows, qr.Rows)
+				require.Len(t, qr.Fields, 2)
+			})
+		}
+	}
+}
+
 func TestConcatenateTypes(t *testing.T) {
 	tests := []struct {
 		t1, t2   string
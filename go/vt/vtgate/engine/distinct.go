@@ -49,7 +49,7 @@ type (
 	probeTable struct {
 		seenRows     map[vthash.Hash]struct{}
 		checkCols    []CheckCol
-		sqlmode      evalengine.SQLMode
+		hasher       *evalengine.TupleHasher
 		collationEnv *collations.Environment
 	}
 )
@@ -69,26 +69,27 @@ func (pt *probeTable) exists(inputRow sqltypes.Row) (sqltypes.Row, error) {
 }
 
 func (pt *probeTable) hashCodeForRow(inputRow sqltypes.Row) (vthash.Hash, error) {
-	hasher := vthash.New()
 	for i, checkCol := range pt.checkCols {
 		if i >= len(inputRow) {
+			pt.hasher.Reset()
 			return vthash.Hash{}, vterrors.VT13001("index out of range in row when creating the DISTINCT hash code")
 		}
-		col := inputRow[checkCol.Col]
-		err := evalengine.NullsafeHashcode128(&hasher, col, checkCol.Type.Collation(), checkCol.Type.Type(), pt.sqlmode, checkCol.Type.Values())
+		err := pt.hasher.Add(inputRow[checkCol.Col], checkCol.Type)
 		if err != nil {
 			if err != evalengine.UnsupportedCollationHashError || checkCol.WsCol == nil {
+				pt.hasher.Reset()
 				return vthash.Hash{}, err
 			}
 			checkCol = checkCol.SwitchToWeightString()
 			pt.checkCols[i] = checkCol
-			err = evalengine.NullsafeHashcode128(&hasher, inputRow[checkCol.Col], checkCol.Type.Collation(), checkCol.Type.Type(), pt.sqlmode, checkCol.Type.Values())
+			err = pt.hasher.Add(inputRow[checkCol.Col], checkCol.Type)
 			if err != nil {
+				pt.hasher.Reset()
 				return vthash.Hash{}, err
 			}
 		}
 	}
-	return hasher.Sum128(), nil
+	return pt.hasher.Sum128(), nil
 }
 
 func newProbeTable(checkCols []CheckCol, collationEnv *collations.Environment) *probeTable {
@@ -97,6 +98,7 @@ func newProbeTable(checkCols []CheckCol, collationEnv *collations.Environment) *
 	return &probeTable{
 		seenRows:     make(map[vthash.Hash]struct{}),
 		checkCols:    cols,
+		hasher:       evalengine.NewTupleHasher(0),
 		collationEnv: collationEnv,
 	}
 }
@@ -114,6 +116,8 @@ func (d *Distinct) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 	}
 
 	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 
 	for _, row := range input.Rows {
 		appendRow, err := pt.exists(row)
@@ -121,6 +125,9 @@ func (d *Distinct) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 			return nil, err
 		}
 		if appendRow != nil {
+			if err := memory.hold(rowMemory(appendRow) + probeEntryMemory); err != nil {
+				return nil, err
+			}
 			result.Rows = append(result.Rows, appendRow)
 		}
 	}
@@ -135,6 +142,10 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 	var mu sync.Mutex
 
 	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
+	// the rows are sent as soon as they are seen, but the probe table holds
+	// the hash of every distinct row until the end of the stream
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 	err := vcursor.StreamExecutePrimitive(ctx, d.Source, bindVars, wantfields, func(input *sqltypes.Result) error {
 		result := &sqltypes.Result{
 			Fields:   input.Fields,
@@ -148,6 +159,9 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 				return err
 			}
 			if appendRow != nil {
+				if err := memory.hold(probeEntryMemory); err != nil {
+					return err
+				}
 				result.Rows = append(result.Rows, appendRow)
 			}
 		}
@@ -26,6 +26,7 @@ import (
 
 	"vitess.io/vitess/go/test/utils"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -74,6 +75,10 @@ func TestDistinct(t *testing.T) {
 		collations:     []collations.ID{collations.CollationUtf8mb4ID, collations.Unknown},
 		inputs:         r("myid|id", "varchar|int64", "monkey|1", "horse|1", "Horse|1", "Monkey|1", "horses|1", "MONKEY|2"),
 		expectedResult: r("myid|id", "varchar|int64", "monkey|1", "horse|1", "horses|1", "MONKEY|2"),
+	}, {
+		testName:       "varbinary columns with the same bytes split differently",
+		inputs:         r("a|b", "varbinary|varbinary", "a|bc", "ab|c", "a|bc"),
+		expectedResult: r("a|b", "varbinary|varbinary", "a|bc", "ab|c"),
 	}}
 
 	for _, tc := range testCases {
@@ -216,3 +221,42 @@ func TestWeightStringFallBack(t *testing.T) {
 		Type:  evalengine.NewType(sqltypes.VarBinary, collations.CollationBinaryID),
 	}}, distinct.CheckCols, "checkCols should not be updated")
 }
+
+func TestDistinctMaxMemoryBytes(t *testing.T) {
+	input := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name", "int64|varbinary"),
+		"1|a",
+		"1|a",
+		"2|b",
+		"3|c",
+		"2|b",
+	)
+	fp := &fakePrimitive{results: []*sqltypes.Result{input}}
+	distinct := &Distinct{
+		Source: fp,
+		CheckCols: []CheckCol{
+			{Col: 0, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)},
+			{Col: 1, Type: evalengine.NewType(sqltypes.VarBinary, collations.CollationBinaryID)},
+		},
+	}
+
+	// the distinct rows are held until the distinct returns
+	distinctRows := rowsMemory(input.Rows[1:4]) + 3*probeEntryMemory
+	vc := &memoryVCursor{limit: distinctRows}
+	_, err := distinct.TryExecute(t.Context(), vc, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, distinctRows, vc.peak.Load())
+	assert.Zero(t, vc.bytes.Load())
+
+	// while streaming, only the hashes of the distinct rows are held
+	fp.rewind()
+	vc = &memoryVCursor{limit: 3*probeEntryMemory - 1}
+	_, err = wrapStreamExecute(distinct, vc, nil, false)
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+
+	fp.rewind()
+	vc = &memoryVCursor{limit: 3 * probeEntryMemory}
+	qr, err := wrapStreamExecute(distinct, vc, nil, false)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 3)
+	assert.Zero(t, vc.bytes.Load())
+}
@@ -20,10 +20,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -39,6 +41,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -141,6 +144,22 @@ func (t *noopVCursor) GetWarmingReadsSemaphore() *semaphore.Weighted {
 	panic("implement me")
 }
 
+func (t *noopVCursor) InListChunkSize() int {
+	return 0
+}
+
+func (t *noopVCursor) GetInListChunkSemaphore() *semaphore.Weighted {
+	return nil
+}
+
+func (t *noopVCursor) MaxExecutionTimeHint() bool {
+	return false
+}
+
+func (t *noopVCursor) GetInsertBatcher(ctx context.Context) (*InsertBatcher, *InsertBatchSession) {
+	panic("implement me")
+}
+
 func (t *noopVCursor) GetQueryPriority() (int, error) {
 	panic("implement me")
 }
@@ -181,6 +200,14 @@ func (t *noopVCursor) ShowExec(ctx context.Context, command sqlparser.ShowComman
 	panic("implement me")
 }
 
+func (t *noopVCursor) ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) LocalInfile(filename string) (io.ReadCloser, error) {
+	panic("implement me")
+}
+
 // SetContextWithValue implements VCursor interface.
 func (t *noopVCursor) SetContextWithValue(key, value any) func() {
 	return func() {}
@@ -221,7 +248,6 @@ func (t *noopVCursor) HasSystemVariables() bool {
 }
 
 func (t *noopVCursor) GetSystemVariables(func(k string, v string)) {
-	panic("implement me")
 }
 
 func (t *noopVCursor) GetWarnings() []*querypb.QueryWarning {
@@ -396,6 +422,29 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) TrackMemory(int64) error {
+	return nil
+}
+
+// memoryVCursor is a VCursor that enforces a limit on the memory held by the
+// query, and records the most memory it held.
+type memoryVCursor struct {
+	noopVCursor
+	limit int64
+	bytes atomic.Int64
+	peak  atomic.Int64
+}
+
+func (m *memoryVCursor) TrackMemory(bytes int64) error {
+	used := m.bytes.Add(bytes)
+	for peak := m.peak.Load(); used > peak && !m.peak.CompareAndSwap(peak, used); peak = m.peak.Load() {
+	}
+	if m.limit > 0 && bytes > 0 && used > m.limit {
+		return vterrors.VT08001(m.limit)
+	}
+	return nil
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return "test_ks"
 }
@@ -485,12 +534,16 @@ type loggingVCursor struct {
 
 	resolvedTargetTabletType topodatapb.TabletType
 
-	tableRoutes     tableRoutes
-	dbDDLPlugin     string
-	ksAvailable     bool
-	inReservedConn  bool
-	systemVariables map[string]string
-	disableSetVar   bool
+	tableRoutes          tableRoutes
+	dbDDLPlugin          string
+	ksAvailable          bool
+	inReservedConn       bool
+	systemVariables      map[string]string
+	disableSetVar        bool
+	inListChunkSize      int
+	maxExecutionTimeHint bool
+
+	inListChunkSemaphore *semaphore.Weighted
 
 	// map different shards to keyspaces in the test.
 	ksShardMap map[string][]string
@@ -499,6 +552,9 @@ type loggingVCursor struct {
 
 	parser *sqlparser.Parser
 
+	// localFiles is the content of the files of LOAD DATA LOCAL INFILE.
+	localFiles map[string]string
+
 	onMirrorClonesFn        func(context.Context) VCursor
 	onExecuteMultiShardFn   func(context.Context, Primitive, []*srvtopo.ResolvedShard, []*querypb.BoundQuery, bool, bool)
 	onStreamExecuteMultiFn  func(context.Context, Primitive, string, []*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, bool, bool, func(*sqltypes.Result) error)
@@ -554,8 +610,10 @@ func (f *loggingVCursor) HasSystemVariables() bool {
 	return len(f.systemVariables) > 0
 }
 
-func (f *loggingVCursor) GetSystemVariables(func(k string, v string)) {
-	panic("implement me")
+func (f *loggingVCursor) GetSystemVariables(visit func(k string, v string)) {
+	for k, v := range f.systemVariables {
+		visit(k, v)
+	}
 }
 
 func (f *loggingVCursor) SetFoundRows(u uint64) {
@@ -625,6 +683,22 @@ func (f *loggingVCursor) GetWarmingReadsSemaphore() *semaphore.Weighted {
 	return semaphore.NewWeighted(0)
 }
 
+func (f *loggingVCursor) InListChunkSize() int {
+	return f.inListChunkSize
+}
+
+func (f *loggingVCursor) GetInListChunkSemaphore() *semaphore.Weighted {
+	return f.inListChunkSemaphore
+}
+
+func (f *loggingVCursor) MaxExecutionTimeHint() bool {
+	return f.maxExecutionTimeHint
+}
+
+func (f *loggingVCursor) GetInsertBatcher(ctx context.Context) (*InsertBatcher, *InsertBatchSession) {
+	return nil, nil
+}
+
 func (f *loggingVCursor) GetQueryPriority() (int, error) {
 	return 0, nil
 }
@@ -644,6 +718,15 @@ func (f *loggingVCursor) CloneForMirroring(ctx context.Context) VCursor {
 	panic("no mirror clones available")
 }
 
+func (f *loggingVCursor) LocalInfile(filename string) (io.ReadCloser, error) {
+	f.log = append(f.log, "LocalInfile "+filename)
+	content, ok := f.localFiles[filename]
+	if !ok {
+		return nil, fmt.Errorf("no file %s", filename)
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
 func (f *loggingVCursor) Execute(ctx context.Context, method string, query string, bindvars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error) {
 	name := "Unknown"
 	switch co {
@@ -46,19 +46,10 @@ func (f *Filter) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[s
 		return nil, err
 	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	var rows [][]sqltypes.Value
-	for _, row := range result.Rows {
-		env.Row = row
-		evalResult, err := env.Evaluate(f.Predicate)
-		if err != nil {
-			return nil, err
-		}
-
-		if evalResult.ToBoolean() {
-			rows = append(rows, row)
-		}
+	result.Rows, err = env.FilterBatch(f.Predicate, result.Rows)
+	if err != nil {
+		return nil, err
 	}
-	result.Rows = rows
 	return result.Truncate(f.Truncate), nil
 }
 
@@ -68,19 +59,11 @@ func (f *Filter) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars
 
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
 	filter := func(results *sqltypes.Result) error {
-		var rows [][]sqltypes.Value
-
 		mu.Lock()
 		defer mu.Unlock()
-		for _, row := range results.Rows {
-			env.Row = row
-			evalResult, err := env.Evaluate(f.Predicate)
-			if err != nil {
-				return err
-			}
-			if evalResult.ToBoolean() {
-				rows = append(rows, row)
-			}
+		rows, err := env.FilterBatch(f.Predicate, results.Rows)
+		if err != nil {
+			return err
 		}
 		results.Rows = rows
 		return callback(results.Truncate(f.Truncate))
@@ -75,13 +75,10 @@ type (
 	hashJoinProbeTable struct {
 		innerMap map[vthash.Hash]*probeTableEntry
 
-		coll           collations.ID
-		typ            querypb.Type
+		typ            evalengine.Type
 		lhsKey, rhsKey int
 		cols           []int
-		hasher         vthash.Hasher
-		sqlmode        evalengine.SQLMode
-		values         *evalengine.EnumSetValues
+		hasher         *evalengine.TupleHasher
 	}
 
 	probeTableEntry struct {
@@ -99,8 +96,13 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 	}
 
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 	// build the probe table from the LHS result
 	for _, row := range lresult.Rows {
+		if err := memory.holdRow(row); err != nil {
+			return nil, err
+		}
 		err := pt.addLeftRow(row)
 		if err != nil {
 			return nil, err
@@ -121,11 +123,18 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 		if err != nil {
 			return nil, err
 		}
+		if err := memory.holdRows(matches); err != nil {
+			return nil, err
+		}
 		result.Rows = append(result.Rows, matches...)
 	}
 
 	if hj.Opcode == LeftJoin {
-		result.Rows = append(result.Rows, pt.notFetched()...)
+		notFetched := pt.notFetched()
+		if err := memory.holdRows(notFetched); err != nil {
+			return nil, err
+		}
+		result.Rows = append(result.Rows, notFetched...)
 	}
 
 	return result, nil
@@ -135,6 +144,8 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	// build the probe table from the LHS result
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 	var lfields []*querypb.Field
 	var mu sync.Mutex
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
@@ -144,6 +155,9 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			lfields = result.Fields
 		}
 		for _, current := range result.Rows {
+			if err := memory.holdRow(current); err != nil {
+				return err
+			}
 			err := pt.addLeftRow(current)
 			if err != nil {
 				return err
@@ -248,13 +262,11 @@ func (hj *HashJoin) description() PrimitiveDescription {
 func newHashJoinProbeTable(coll collations.ID, typ querypb.Type, lhsKey, rhsKey int, cols []int, values *evalengine.EnumSetValues) *hashJoinProbeTable {
 	return &hashJoinProbeTable{
 		innerMap: map[vthash.Hash]*probeTableEntry{},
-		coll:     coll,
-		typ:      typ,
+		typ:      evalengine.NewTypeEx(typ, coll, true, 0, 0, values),
 		lhsKey:   lhsKey,
 		rhsKey:   rhsKey,
 		cols:     cols,
-		hasher:   vthash.New(),
-		values:   values,
+		hasher:   evalengine.NewTupleHasher(0),
 	}
 }
 
@@ -272,14 +284,10 @@ func (pt *hashJoinProbeTable) addLeftRow(r sqltypes.Row) error {
 }
 
 func (pt *hashJoinProbeTable) hash(val sqltypes.Value) (vthash.Hash, error) {
-	err := evalengine.NullsafeHashcode128(&pt.hasher, val, pt.coll, pt.typ, pt.sqlmode, pt.values)
-	if err != nil {
+	if err := pt.hasher.Add(val, pt.typ); err != nil {
 		return vthash.Hash{}, err
 	}
-
-	res := pt.hasher.Sum128()
-	pt.hasher.Reset()
-	return res, nil
+	return pt.hasher.Sum128(), nil
 }
 
 func (pt *hashJoinProbeTable) get(rrow sqltypes.Row) (result []sqltypes.Row, err error) {
@@ -163,9 +163,18 @@ func (ins *Insert) executeInsertQueries(
 	if err != nil {
 		return nil, err
 	}
-	result, errs := vcursor.ExecuteMultiShard(ctx, ins, rss, queries, true /*rollbackOnError*/, autocommit, ins.FetchLastInsertID)
-	if errs != nil {
-		return nil, vterrors.Aggregate(errs)
+	var result *sqltypes.Result
+	if batcher, session := ins.insertBatcher(ctx, vcursor, autocommit, rss, queries); batcher != nil {
+		result, err = batcher.Insert(ctx, ins, rss[0], queries[0], session)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var errs []error
+		result, errs = vcursor.ExecuteMultiShard(ctx, ins, rss, queries, true /*rollbackOnError*/, autocommit, ins.FetchLastInsertID)
+		if errs != nil {
+			return nil, vterrors.Aggregate(errs)
+		}
 	}
 
 	if insertID != 0 {
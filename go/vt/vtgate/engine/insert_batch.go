@@ -0,0 +1,374 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	insertBatches = stats.NewCountersWithMultiLabels(
+		"InsertBatches",
+		"Number of insert batches sent, by keyspace and outcome",
+		[]string{"Keyspace", "Outcome"},
+	)
+
+	insertBatchRows = stats.NewCountersWithSingleLabel(
+		"InsertBatchRows",
+		"Number of rows sent in insert batches, by keyspace",
+		"Keyspace",
+	)
+)
+
+type (
+	// InsertBatchSession describes the session of an insert that is batched.
+	// Inserts are only batched with the inserts of sessions with the same
+	// Key, and a batch is sent with the Options and Comments of the session
+	// of its first insert.
+	InsertBatchSession struct {
+		Key      string
+		Options  *querypb.ExecuteOptions
+		Comments sqlparser.MarginComments
+	}
+
+	// InsertBatchExecFunc sends an insert batch to its shard in autocommit mode.
+	InsertBatchExecFunc func(ctx context.Context, primitive Primitive, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *InsertBatchSession) (*sqltypes.Result, error)
+
+	// InsertBatcher groups the single-row autocommit inserts that are sent to
+	// the same shard within a short window into multi-row inserts.
+	//
+	// The first insert for a shard, table and session key opens a batch, which
+	// is sent once the window has passed or once it has maxRows rows, so no
+	// insert waits longer than the window. Every connection has at most one
+	// row pending, rows are sent in arrival order, and the row of a caller
+	// whose context ends before its batch is sent is left out.
+	//
+	// A multi-row insert only reports the insert id of its first row, so the
+	// inserts into a table are only batched once a row sent on its own has
+	// shown that MySQL does not assign insert ids for the table, i.e. that it
+	// has no AUTO_INCREMENT column. Inserts into other tables are sent as is.
+	// The tables are forgotten when the schema may have changed, i.e. on
+	// ClearTables or once a batch gets an insert id.
+	//
+	// MySQL rolls back a multi-row insert as a whole if one of its rows fails,
+	// for example on a duplicate key. When a batch fails with such an error,
+	// its rows are sent again one by one, so that every caller gets the
+	// outcome of its own row. Any other error leaves it unknown whether the
+	// batch was applied, and is returned to all of its callers.
+	InsertBatcher struct {
+		window  time.Duration
+		maxRows int
+		exec    InsertBatchExecFunc
+
+		mu      sync.Mutex
+		batches map[string]*insertBatch
+		// batchable holds the tables, by keyspace and name, known to have
+		// no AUTO_INCREMENT column.
+		batchable map[string]bool
+	}
+
+	insertBatch struct {
+		ins     *Insert
+		rs      *srvtopo.ResolvedShard
+		session *InsertBatchSession
+		rows    []*insertBatchRow
+	}
+
+	insertBatchRow struct {
+		ctx   context.Context
+		mid   sqlparser.ValTuple
+		query *querypb.BoundQuery
+
+		// withdrawn and sent are protected by InsertBatcher.mu.
+		withdrawn bool
+		sent      bool
+
+		done   chan struct{}
+		result *sqltypes.Result
+		err    error
+	}
+)
+
+// NewInsertBatcher creates an InsertBatcher that batches inserts within
+// window into batches of at most maxRows rows, and sends them with exec.
+func NewInsertBatcher(window time.Duration, maxRows int, exec InsertBatchExecFunc) *InsertBatcher {
+	return &InsertBatcher{
+		window:    window,
+		maxRows:   maxRows,
+		exec:      exec,
+		batches:   make(map[string]*insertBatch),
+		batchable: make(map[string]bool),
+	}
+}
+
+// insertBatcher returns the batcher the sharded insert of queries to rss is
+// to be sent with, or nil if it is to be sent on its own. The outcome of a
+// batched row must not depend on the other rows of its batch, so inserts
+// that ignore or update existing rows, or that use LAST_INSERT_ID(), are
+// never batched.
+func (ins *Insert) insertBatcher(ctx context.Context, vcursor VCursor, autocommit bool, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery) (*InsertBatcher, *InsertBatchSession) {
+	if !autocommit || len(rss) != 1 || len(queries) != 1 || len(ins.Mid) != 1 ||
+		ins.Ignore || len(ins.Suffix) > 0 || ins.FetchLastInsertID || ins.PreventAutoCommit {
+		return nil, nil
+	}
+	return vcursor.GetInsertBatcher(ctx)
+}
+
+// Insert sends the single-row insert query of ins to rs as part of a batch,
+// and returns the outcome of the row once the batch has been sent.
+func (ib *InsertBatcher) Insert(ctx context.Context, ins *Insert, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *InsertBatchSession) (*sqltypes.Result, error) {
+	table := batchTable(rs, ins)
+	ib.mu.Lock()
+	batchable := ib.batchable[table]
+	ib.mu.Unlock()
+	if !batchable {
+		qr, err := ib.exec(ctx, ins, rs, query, session)
+		if err == nil && qr.RowsAffected == 1 && qr.InsertID == 0 {
+			ib.mu.Lock()
+			ib.batchable[table] = true
+			ib.mu.Unlock()
+		}
+		return qr, err
+	}
+
+	key := strings.Join([]string{
+		rs.Target.Keyspace,
+		rs.Target.Shard,
+		rs.Target.TabletType.String(),
+		ins.Prefix,
+		ins.Alias,
+		session.Key,
+	}, "\x00")
+	row := &insertBatchRow{
+		ctx:   ctx,
+		mid:   ins.Mid[0],
+		query: query,
+		done:  make(chan struct{}),
+	}
+
+	ib.mu.Lock()
+	batch := ib.batches[key]
+	if batch == nil {
+		batch = &insertBatch{ins: ins, rs: rs, session: session}
+		ib.batches[key] = batch
+		time.AfterFunc(ib.window, func() {
+			if ib.take(key, batch) {
+				ib.send(batch)
+			}
+		})
+	}
+	batch.rows = append(batch.rows, row)
+	full := len(batch.rows) >= ib.maxRows
+	if full {
+		delete(ib.batches, key)
+	}
+	ib.mu.Unlock()
+
+	if full {
+		ib.send(batch)
+	}
+
+	select {
+	case <-row.done:
+		return row.result, row.err
+	case <-ctx.Done():
+		ib.mu.Lock()
+		row.withdrawn = !row.sent
+		ib.mu.Unlock()
+		return nil, vterrors.Wrapf(ctx.Err(), "waiting for insert batch")
+	}
+}
+
+// ClearTables forgets the tables known to have no AUTO_INCREMENT column, so
+// that the next insert into each table is sent on its own again. It is
+// called on vschema changes, which include the schema changes seen by the
+// schema tracker.
+func (ib *InsertBatcher) ClearTables() {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+	clear(ib.batchable)
+}
+
+// forgetTable forgets that the table of batch has no AUTO_INCREMENT column
+// if its insert got an insert id, i.e. if the table has gained one.
+func (ib *InsertBatcher) forgetTable(batch *insertBatch, qr *sqltypes.Result) {
+	if qr == nil || qr.InsertID == 0 {
+		return
+	}
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+	delete(ib.batchable, batchTable(batch.rs, batch.ins))
+}
+
+// batchTable returns the key of the table of ins in batchable.
+func batchTable(rs *srvtopo.ResolvedShard, ins *Insert) string {
+	return rs.Target.Keyspace + "." + ins.TableName
+}
+
+// take removes batch from the open batches, and returns false if it had
+// already been removed to be sent.
+func (ib *InsertBatcher) take(key string, batch *insertBatch) bool {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+	if ib.batches[key] != batch {
+		return false
+	}
+	delete(ib.batches, key)
+	return true
+}
+
+// send sends the rows of batch whose callers are still waiting.
+func (ib *InsertBatcher) send(batch *insertBatch) {
+	ib.mu.Lock()
+	rows := make([]*insertBatchRow, 0, len(batch.rows))
+	for _, row := range batch.rows {
+		if !row.withdrawn {
+			row.sent = true
+			rows = append(rows, row)
+		}
+	}
+	ib.mu.Unlock()
+	if len(rows) == 0 {
+		return
+	}
+
+	// The batch is sent on behalf of all its callers, so a caller that gives
+	// up waiting does not cancel it for the others.
+	ctx, cancel := batchContext(rows)
+	defer cancel()
+
+	keyspace := batch.rs.Target.Keyspace
+	insertBatchRows.Add(keyspace, int64(len(rows)))
+	if len(rows) == 1 {
+		rows[0].finish(ib.exec(ctx, batch.ins, batch.rs, rows[0].query, batch.session))
+		ib.forgetTable(batch, rows[0].result)
+		insertBatches.Add([]string{keyspace, batchOutcome(rows[0].err)}, 1)
+		return
+	}
+
+	qr, err := ib.exec(ctx, batch.ins, batch.rs, batch.query(rows), batch.session)
+	ib.forgetTable(batch, qr)
+	switch {
+	case err == nil:
+		// Without IGNORE or ON DUPLICATE KEY UPDATE, a multi-row insert
+		// either affects all of its rows or fails, so each row affected one.
+		// The table had no AUTO_INCREMENT column, so the insert id is the
+		// one of the batch, i.e. 0 unless the table has gained one.
+		for _, row := range rows {
+			row.finish(&sqltypes.Result{RowsAffected: 1, InsertID: qr.InsertID}, nil)
+		}
+		insertBatches.Add([]string{keyspace, "Success"}, 1)
+	case isStatementError(err):
+		insertBatches.Add([]string{keyspace, "Unbatched"}, 1)
+		var wg sync.WaitGroup
+		for _, row := range rows {
+			wg.Go(func() {
+				row.finish(ib.exec(ctx, batch.ins, batch.rs, row.query, batch.session))
+			})
+		}
+		wg.Wait()
+	default:
+		for _, row := range rows {
+			row.finish(nil, err)
+		}
+		insertBatches.Add([]string{keyspace, "Error"}, 1)
+	}
+}
+
+// query returns the multi-row insert of rows. Every row has its own bind
+// variables, so their names are made unique by the position of the row.
+func (batch *insertBatch) query(rows []*insertBatchRow) *querypb.BoundQuery {
+	bindVars := make(map[string]*querypb.BindVariable)
+	mids := make([]string, 0, len(rows))
+	for i, row := range rows {
+		prefix := "b" + strconv.Itoa(i) + "_"
+		mid := sqlparser.CopyOnRewrite(row.mid, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+			switch arg := cursor.Node().(type) {
+			case *sqlparser.Argument:
+				renamed := *arg
+				renamed.Name = prefix + arg.Name
+				bindVars[renamed.Name] = row.query.BindVariables[arg.Name]
+				cursor.Replace(&renamed)
+			case sqlparser.ListArg:
+				renamed := sqlparser.ListArg(prefix + string(arg))
+				bindVars[string(renamed)] = row.query.BindVariables[string(arg)]
+				cursor.Replace(renamed)
+			}
+		}, nil)
+		mids = append(mids, sqlparser.String(mid))
+	}
+	return &querypb.BoundQuery{
+		Sql:           batch.ins.Prefix + strings.Join(mids, ",") + batch.ins.Alias,
+		BindVariables: bindVars,
+	}
+}
+
+func (row *insertBatchRow) finish(result *sqltypes.Result, err error) {
+	row.result, row.err = result, err
+	close(row.done)
+}
+
+// batchContext returns the context to send rows with. It carries the values
+// of the context of the first row, and the latest deadline of all of them.
+func batchContext(rows []*insertBatchRow) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(rows[0].ctx)
+	var deadline time.Time
+	for _, row := range rows {
+		d, ok := row.ctx.Deadline()
+		if !ok {
+			return context.WithCancel(ctx)
+		}
+		if d.After(deadline) {
+			deadline = d
+		}
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// isStatementError returns true if err is an error MySQL returns for a
+// statement it has not applied because of one of its rows, such as a
+// duplicate key or a value that does not fit its column. Errors about the
+// state of the tablet or its resources, like FAILED_PRECONDITION or
+// RESOURCE_EXHAUSTED, are about the batch as a whole: sending its rows
+// again one by one would only add load.
+func isStatementError(err error) bool {
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_ALREADY_EXISTS, vtrpcpb.Code_INVALID_ARGUMENT:
+		return true
+	}
+	return false
+}
+
+func batchOutcome(err error) string {
+	if err != nil {
+		return "Error"
+	}
+	return "Success"
+}
@@ -0,0 +1,441 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// insertBatchExec records the queries sent by an InsertBatcher, and answers
+// them with the error returned by fail, or with insertID.
+type insertBatchExec struct {
+	mu       sync.Mutex
+	queries  []*querypb.BoundQuery
+	fail     func(query *querypb.BoundQuery) error
+	insertID uint64
+}
+
+func (e *insertBatchExec) exec(ctx context.Context, primitive Primitive, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *InsertBatchSession) (*sqltypes.Result, error) {
+	e.mu.Lock()
+	e.queries = append(e.queries, query)
+	e.mu.Unlock()
+	if e.fail != nil {
+		if err := e.fail(query); err != nil {
+			return nil, err
+		}
+	}
+	rows := max(len(query.BindVariables)/2, 1)
+	return &sqltypes.Result{RowsAffected: uint64(rows), InsertID: e.insertID}, nil
+}
+
+func (e *insertBatchExec) sent() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var sqls []string
+	for _, query := range e.queries {
+		sqls = append(sqls, query.Sql)
+	}
+	return sqls
+}
+
+type insertBatchOutcome struct {
+	result *sqltypes.Result
+	err    error
+}
+
+var insertBatchTarget = &srvtopo.ResolvedShard{
+	Target: &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY},
+}
+
+func newBatchedInsert() *Insert {
+	ins := newInsert(InsertSharded, false, &vindexes.Keyspace{Name: "ks", Sharded: true}, nil, nil,
+		"insert into t(id, v) values ",
+		sqlparser.Values{{
+			&sqlparser.Argument{Name: "_id_0", Type: sqltypes.Int64},
+			sqlparser.NewArgument("v"),
+		}},
+		nil,
+	)
+	ins.TableName = "t"
+	return ins
+}
+
+// newTestInsertBatcher returns an InsertBatcher that already knows that the
+// table of newBatchedInsert has no AUTO_INCREMENT column.
+func newTestInsertBatcher(window time.Duration, maxRows int, exec InsertBatchExecFunc) *InsertBatcher {
+	ib := NewInsertBatcher(window, maxRows, exec)
+	ib.batchable["ks.t"] = true
+	return ib
+}
+
+func batchedRow(id int64) *querypb.BoundQuery {
+	return &querypb.BoundQuery{
+		Sql: "insert into t(id, v) values (:_id_0 /* INT64 */, :v)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_id_0": sqltypes.Int64BindVariable(id),
+			"v":     sqltypes.StringBindVariable("x"),
+		},
+	}
+}
+
+func pendingBatchRows(ib *InsertBatcher) int {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+	rows := 0
+	for _, batch := range ib.batches {
+		for _, row := range batch.rows {
+			if !row.withdrawn {
+				rows++
+			}
+		}
+	}
+	return rows
+}
+
+// insertBatchRowsInOrder inserts the rows with ids, in order, as concurrent callers
+// of ib, and returns the outcome of each row once the callers have returned.
+func insertBatchRowsInOrder(t *testing.T, ctx context.Context, ib *InsertBatcher, session *InsertBatchSession, ids ...int64) []insertBatchOutcome {
+	outcomes := make([]insertBatchOutcome, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Go(func() {
+			result, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(id), session)
+			outcomes[i] = insertBatchOutcome{result: result, err: err}
+		})
+		if i < len(ids)-1 {
+			require.Eventually(t, func() bool {
+				return pendingBatchRows(ib) == i+1
+			}, 30*time.Second, time.Millisecond)
+		}
+	}
+	wg.Wait()
+	return outcomes
+}
+
+func TestInsertBatcherBatchesRows(t *testing.T) {
+	exec := &insertBatchExec{}
+	ib := newTestInsertBatcher(time.Hour, 3, exec.exec)
+
+	outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{}, 1, 2, 3)
+	for _, outcome := range outcomes {
+		require.NoError(t, outcome.err)
+		assert.Equal(t, &sqltypes.Result{RowsAffected: 1}, outcome.result)
+	}
+
+	require.Len(t, exec.queries, 1)
+	assert.Equal(t, "insert into t(id, v) values (:b0__id_0 /* INT64 */, :b0_v),(:b1__id_0 /* INT64 */, :b1_v),(:b2__id_0 /* INT64 */, :b2_v)", exec.queries[0].Sql)
+	assert.Equal(t, map[string]*querypb.BindVariable{
+		"b0__id_0": sqltypes.Int64BindVariable(1),
+		"b0_v":     sqltypes.StringBindVariable("x"),
+		"b1__id_0": sqltypes.Int64BindVariable(2),
+		"b1_v":     sqltypes.StringBindVariable("x"),
+		"b2__id_0": sqltypes.Int64BindVariable(3),
+		"b2_v":     sqltypes.StringBindVariable("x"),
+	}, exec.queries[0].BindVariables)
+	assert.Zero(t, pendingBatchRows(ib))
+}
+
+func TestInsertBatcherWindow(t *testing.T) {
+	exec := &insertBatchExec{}
+	ib := newTestInsertBatcher(10*time.Millisecond, 100, exec.exec)
+
+	// A row that is alone in its window is sent as is, with its own result.
+	result, err := ib.Insert(t.Context(), newBatchedInsert(), insertBatchTarget, batchedRow(1), &InsertBatchSession{})
+	require.NoError(t, err)
+	assert.Equal(t, &sqltypes.Result{RowsAffected: 1}, result)
+	assert.Equal(t, []string{"insert into t(id, v) values (:_id_0 /* INT64 */, :v)"}, exec.sent())
+}
+
+func TestInsertBatcherSeparatesSessions(t *testing.T) {
+	exec := &insertBatchExec{}
+	ib := newTestInsertBatcher(time.Hour, 2, exec.exec)
+
+	// Rows of different session keys never share a batch.
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, err := ib.Insert(t.Context(), newBatchedInsert(), insertBatchTarget, batchedRow(1), &InsertBatchSession{Key: "a"})
+		assert.NoError(t, err)
+	})
+	require.Eventually(t, func() bool {
+		return pendingBatchRows(ib) == 1
+	}, 30*time.Second, time.Millisecond)
+	wg.Go(func() {
+		_, err := ib.Insert(t.Context(), newBatchedInsert(), insertBatchTarget, batchedRow(2), &InsertBatchSession{Key: "b"})
+		assert.NoError(t, err)
+	})
+	require.Eventually(t, func() bool {
+		return pendingBatchRows(ib) == 2
+	}, 30*time.Second, time.Millisecond)
+	assert.Len(t, ib.batches, 2)
+
+	outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{Key: "a"}, 3)
+	require.NoError(t, outcomes[0].err)
+	assert.Equal(t, []string{"insert into t(id, v) values (:b0__id_0 /* INT64 */, :b0_v),(:b1__id_0 /* INT64 */, :b1_v)"}, exec.sent())
+
+	outcomes = insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{Key: "b"}, 4)
+	require.NoError(t, outcomes[0].err)
+	wg.Wait()
+	assert.Len(t, exec.sent(), 2)
+}
+
+func TestInsertBatcherUnbatchesStatementErrors(t *testing.T) {
+	dupEntry := vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "Duplicate entry '2' for key 't.PRIMARY' (errno 1062) (sqlstate 23000)")
+	exec := &insertBatchExec{
+		fail: func(query *querypb.BoundQuery) error {
+			if _, batched := query.BindVariables["b0_v"]; batched {
+				return dupEntry
+			}
+			if id, _ := sqltypes.BindVariableToValue(query.BindVariables["_id_0"]); id.ToString() == "2" {
+				return dupEntry
+			}
+			return nil
+		},
+	}
+	ib := newTestInsertBatcher(time.Hour, 3, exec.exec)
+
+	// The batch is rolled back as a whole, so every row is sent again on its
+	// own and only the duplicate row fails.
+	outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{}, 1, 2, 3)
+	require.NoError(t, outcomes[0].err)
+	assert.Equal(t, &sqltypes.Result{RowsAffected: 1}, outcomes[0].result)
+	assert.ErrorIs(t, outcomes[1].err, dupEntry)
+	require.NoError(t, outcomes[2].err)
+	assert.Len(t, exec.sent(), 4)
+}
+
+func TestInsertBatcherReturnsOtherErrors(t *testing.T) {
+	unavailable := vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "connection lost")
+	exec := &insertBatchExec{
+		fail: func(*querypb.BoundQuery) error {
+			return unavailable
+		},
+	}
+	ib := newTestInsertBatcher(time.Hour, 2, exec.exec)
+
+	// It is unknown whether the batch was applied, so it is not sent again.
+	outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{}, 1, 2)
+	for _, outcome := range outcomes {
+		assert.ErrorIs(t, outcome.err, unavailable)
+	}
+	assert.Len(t, exec.sent(), 1)
+}
+
+func TestInsertBatcherReturnsTabletErrors(t *testing.T) {
+	for _, code := range []vtrpcpb.Code{vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_RESOURCE_EXHAUSTED} {
+		t.Run(code.String(), func(t *testing.T) {
+			tabletErr := vterrors.Errorf(code, "tablet error")
+			exec := &insertBatchExec{
+				fail: func(*querypb.BoundQuery) error {
+					return tabletErr
+				},
+			}
+			ib := newTestInsertBatcher(time.Hour, 2, exec.exec)
+
+			// The error is about the batch as a whole, so its rows are not
+			// sent again one by one.
+			outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{}, 1, 2)
+			for _, outcome := range outcomes {
+				assert.ErrorIs(t, outcome.err, tabletErr)
+			}
+			assert.Len(t, exec.sent(), 1)
+		})
+	}
+}
+
+func TestInsertBatcherSkipsAutoIncrementTables(t *testing.T) {
+	ctx := t.Context()
+	session := &InsertBatchSession{}
+
+	// MySQL assigns insert ids for the table, which a batch can't report
+	// for every row, so every row is sent on its own with its insert id.
+	exec := &insertBatchExec{insertID: 7}
+	ib := NewInsertBatcher(time.Hour, 2, exec.exec)
+	for id := range int64(3) {
+		result, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(id), session)
+		require.NoError(t, err)
+		assert.Equal(t, &sqltypes.Result{RowsAffected: 1, InsertID: 7}, result)
+	}
+	assert.Len(t, exec.sent(), 3)
+	assert.Zero(t, pendingBatchRows(ib))
+
+	// Once a row has shown that the table has no AUTO_INCREMENT column, the
+	// following rows are batched.
+	exec = &insertBatchExec{}
+	ib = NewInsertBatcher(time.Hour, 2, exec.exec)
+	result, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(1), session)
+	require.NoError(t, err)
+	assert.Equal(t, &sqltypes.Result{RowsAffected: 1}, result)
+	outcomes := insertBatchRowsInOrder(t, ctx, ib, session, 2, 3)
+	for _, outcome := range outcomes {
+		require.NoError(t, outcome.err)
+		assert.Equal(t, &sqltypes.Result{RowsAffected: 1}, outcome.result)
+	}
+	assert.Equal(t, []string{
+		"insert into t(id, v) values (:_id_0 /* INT64 */, :v)",
+		"insert into t(id, v) values (:b0__id_0 /* INT64 */, :b0_v),(:b1__id_0 /* INT64 */, :b1_v)",
+	}, exec.sent())
+}
+
+func TestInsertBatcherForgetsTables(t *testing.T) {
+	ctx := t.Context()
+	session := &InsertBatchSession{}
+	single := "insert into t(id, v) values (:_id_0 /* INT64 */, :v)"
+
+	// After a vschema or schema change, the next row is sent on its own
+	// again, to find out whether the table has gained an AUTO_INCREMENT
+	// column.
+	exec := &insertBatchExec{}
+	ib := newTestInsertBatcher(time.Hour, 2, exec.exec)
+	ib.ClearTables()
+	_, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(1), session)
+	require.NoError(t, err)
+	assert.Equal(t, []string{single}, exec.sent())
+
+	// A batch that gets an insert id shows that the table has gained an
+	// AUTO_INCREMENT column, so the following rows are sent on their own.
+	exec = &insertBatchExec{insertID: 7}
+	ib = newTestInsertBatcher(time.Hour, 2, exec.exec)
+	outcomes := insertBatchRowsInOrder(t, ctx, ib, session, 1, 2)
+	for _, outcome := range outcomes {
+		require.NoError(t, outcome.err)
+	}
+	result, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(3), session)
+	require.NoError(t, err)
+	assert.Equal(t, &sqltypes.Result{RowsAffected: 1, InsertID: 7}, result)
+	assert.Equal(t, []string{
+		"insert into t(id, v) values (:b0__id_0 /* INT64 */, :b0_v),(:b1__id_0 /* INT64 */, :b1_v)",
+		single,
+	}, exec.sent())
+}
+
+func TestInsertBatcherLeavesOutWithdrawnRows(t *testing.T) {
+	exec := &insertBatchExec{}
+	ib := newTestInsertBatcher(time.Hour, 3, exec.exec)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, err := ib.Insert(ctx, newBatchedInsert(), insertBatchTarget, batchedRow(1), &InsertBatchSession{})
+		assert.ErrorContains(t, err, "waiting for insert batch")
+	})
+	require.Eventually(t, func() bool {
+		return pendingBatchRows(ib) == 1
+	}, 30*time.Second, time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	outcomes := insertBatchRowsInOrder(t, t.Context(), ib, &InsertBatchSession{}, 2, 3)
+	for _, outcome := range outcomes {
+		require.NoError(t, outcome.err)
+	}
+	require.Len(t, exec.queries, 1)
+	assert.Equal(t, "insert into t(id, v) values (:b0__id_0 /* INT64 */, :b0_v),(:b1__id_0 /* INT64 */, :b1_v)", exec.queries[0].Sql)
+	assert.Equal(t, sqltypes.Int64BindVariable(2), exec.queries[0].BindVariables["b0__id_0"])
+}
+
+// insertBatchVCursor is a loggingVCursor that batches inserts.
+type insertBatchVCursor struct {
+	*loggingVCursor
+	batcher *InsertBatcher
+}
+
+func (vc *insertBatchVCursor) GetInsertBatcher(context.Context) (*InsertBatcher, *InsertBatchSession) {
+	return vc.batcher, &InsertBatchSession{}
+}
+
+func TestInsertShardedBatched(t *testing.T) {
+	invschema := &vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"sharded": {
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"hash": {
+						Type: "hash",
+					},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ColumnVindexes: []*vschemapb.ColumnVindex{{
+							Name:    "hash",
+							Columns: []string{"id"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	vs := vindexes.BuildVSchema(invschema, sqlparser.NewTestParser())
+	ks := vs.Keyspaces["sharded"]
+	newShardedInsert := func(ignore bool) *Insert {
+		return newInsert(
+			InsertSharded,
+			ignore,
+			ks.Keyspace,
+			[][][]evalengine.Expr{{{evalengine.NewLiteralInt(1)}}},
+			ks.Tables["t1"],
+			"prefix",
+			sqlparser.Values{
+				{&sqlparser.Argument{Name: "_id_0", Type: sqltypes.Int64}},
+			},
+			nil,
+		)
+	}
+
+	exec := &insertBatchExec{}
+	vc := &insertBatchVCursor{
+		loggingVCursor: newTestVCursor("-20", "20-"),
+		batcher:        NewInsertBatcher(time.Millisecond, 100, exec.exec),
+	}
+	vc.shardForKsid = []string{"20-"}
+
+	// A single-row autocommit insert is sent through the batcher.
+	result, err := newShardedInsert(false).TryExecute(t.Context(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, result.RowsAffected)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [value:"0"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+	})
+	assert.Equal(t, []string{"prefix(:_id_0 /* INT64 */)"}, exec.sent())
+
+	// An insert ignore is never batched.
+	vc.Rewind()
+	_, err = newShardedInsert(true).TryExecute(t.Context(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations sharded [value:"0"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+		`ExecuteMultiShard sharded.20-: prefix(:_id_0 /* INT64 */) {_id_0: type:INT64 value:"1"} true true`,
+	})
+	assert.Len(t, exec.sent(), 1)
+}
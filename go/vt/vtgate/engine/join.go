@@ -72,6 +72,8 @@ func (jn *Join) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 		result.Fields = joinFields(lresult.Fields, rresult.Fields, jn.Cols)
 		return result, nil
 	}
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 	for _, lrow := range lresult.Rows {
 		for k, col := range jn.Vars {
 			joinVars[k] = sqltypes.ValueBindVariable(lrow[col])
@@ -84,6 +86,7 @@ func (jn *Join) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 			wantfields = false
 			result.Fields = joinFields(lresult.Fields, rresult.Fields, jn.Cols)
 		}
+		joined := len(result.Rows)
 		for _, rrow := range rresult.Rows {
 			result.Rows = append(result.Rows, joinRows(lrow, rrow, jn.Cols))
 		}
@@ -93,6 +96,9 @@ func (jn *Join) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 		if vcursor.ExceedsMaxMemoryRows(len(result.Rows)) {
 			return nil, fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
 		}
+		if err := memory.holdRows(result.Rows[joined:]); err != nil {
+			return nil, err
+		}
 	}
 	return result, nil
 }
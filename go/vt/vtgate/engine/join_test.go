@@ -204,6 +204,62 @@ func TestJoinExecuteMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestJoinExecuteMaxMemoryBytes(t *testing.T) {
+	leftPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields(
+					"col1|col2",
+					"int64|varchar",
+				),
+				"1|a",
+				"2|b",
+			),
+		},
+	}
+	rightFields := sqltypes.MakeTestFields(
+		"col3|col4",
+		"int64|varchar",
+	)
+	rightPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				rightFields,
+				"3|c",
+				"4|d",
+			),
+			sqltypes.MakeTestResult(
+				rightFields,
+				"5|e",
+			),
+		},
+	}
+	jn := &Join{
+		Opcode: InnerJoin,
+		Left:   leftPrim,
+		Right:  rightPrim,
+		Cols:   []int{-1, -2, 1, 2},
+		Vars: map[string]int{
+			"bv": 1,
+		},
+	}
+
+	vc := &memoryVCursor{}
+	result, err := jn.TryExecute(t.Context(), vc, nil, true)
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 3)
+	// the joined rows are held by the join until it returns
+	joined := rowsMemory(result.Rows)
+	assert.Equal(t, joined, vc.peak.Load())
+	assert.Zero(t, vc.bytes.Load())
+
+	leftPrim.rewind()
+	rightPrim.rewind()
+	vc = &memoryVCursor{limit: joined - 1}
+	_, err = jn.TryExecute(t.Context(), vc, nil, true)
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+}
+
 func TestJoinExecuteNoResult(t *testing.T) {
 	leftPrim := &fakePrimitive{
 		results: []*sqltypes.Result{
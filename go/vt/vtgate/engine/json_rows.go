@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*JSONRows)(nil)
+
+// JSONRowsColumn is the name of the single column returned by JSONRows.
+const JSONRowsColumn = "json_row"
+
+// JSONRows is a primitive that returns each row of its input as a single JSON
+// object column, with the field names of the input as keys. Values are
+// converted to JSON the same way JSON_OBJECT() converts them. If the input has
+// several fields with the same name, the last one wins.
+type JSONRows struct {
+	Input Primitive
+}
+
+// NeedsTransaction implements the Primitive interface
+func (j *JSONRows) NeedsTransaction() bool {
+	return j.Input.NeedsTransaction()
+}
+
+// TryExecute implements the Primitive interface
+func (j *JSONRows) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	// The input fields are always needed, as they are the keys of the objects.
+	qr, err := vcursor.ExecutePrimitive(ctx, j.Input, bindVars, true)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := toJSONRows(qr.Fields, qr.Rows)
+	if err != nil {
+		return nil, err
+	}
+	qr.Rows = rows
+	qr.Fields = nil
+	if wantfields {
+		qr.Fields = jsonRowsFields()
+	}
+	return qr, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (j *JSONRows) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	var (
+		mu     sync.Mutex
+		fields []*querypb.Field
+	)
+	return vcursor.StreamExecutePrimitive(ctx, j.Input, bindVars, true, func(qr *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fields == nil {
+			fields = qr.Fields
+			if fields == nil && len(qr.Rows) > 0 {
+				res, err := j.Input.GetFields(ctx, vcursor, bindVars)
+				if err != nil {
+					return err
+				}
+				fields = res.Fields
+			}
+			if fields != nil && wantfields {
+				if err := callback(&sqltypes.Result{Fields: jsonRowsFields()}); err != nil {
+					return err
+				}
+			}
+		}
+		if len(qr.Rows) == 0 {
+			return nil
+		}
+
+		rows, err := toJSONRows(fields, qr.Rows)
+		if err != nil {
+			return err
+		}
+		return callback(&sqltypes.Result{Rows: rows})
+	})
+}
+
+// GetFields implements the Primitive interface
+func (j *JSONRows) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: jsonRowsFields()}, nil
+}
+
+// Inputs implements the Primitive interface
+func (j *JSONRows) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{j.Input}, nil
+}
+
+// description implements the Primitive interface
+func (j *JSONRows) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "JSONRows",
+	}
+}
+
+func jsonRowsFields() []*querypb.Field {
+	_, flags := sqltypes.TypeToMySQL(sqltypes.TypeJSON)
+	return []*querypb.Field{{
+		Name:    JSONRowsColumn,
+		Type:    sqltypes.TypeJSON,
+		Charset: collations.CollationUtf8mb4BinID,
+		Flags:   uint32(flags | int64(querypb.MySqlFlag_NOT_NULL_FLAG)),
+	}}
+}
+
+// toJSONRows converts each row into a JSON object keyed by the given fields.
+func toJSONRows(fields []*querypb.Field, rows []sqltypes.Row) ([]sqltypes.Row, error) {
+	out := make([]sqltypes.Row, 0, len(rows))
+	var buf []byte
+	for _, row := range rows {
+		var obj json.Object
+		for i, value := range row {
+			if i >= len(fields) {
+				break
+			}
+			jv := json.ValueNull
+			if !value.IsNull() {
+				var err error
+				jv, err = json.NewFromSQL(value)
+				if err != nil {
+					return nil, err
+				}
+			}
+			obj.Set(fields[i].Name, jv, json.Set)
+		}
+		buf = json.NewObject(obj).MarshalTo(buf[:0])
+		out = append(out, sqltypes.Row{sqltypes.MakeTrusted(sqltypes.TypeJSON, append([]byte(nil), buf...))})
+	}
+	return out, nil
+}
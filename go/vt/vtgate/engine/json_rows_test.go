@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func jsonRowsTestInput() *sqltypes.Result {
+	return sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|name|price|note",
+			"int64|varchar|decimal|varchar",
+		),
+		`1|a|1.50|null`,
+		`2|"b"|2.00|x`,
+		`3|c|3.25|y`,
+	)
+}
+
+func jsonRowsValues(t *testing.T, qr *sqltypes.Result) []string {
+	t.Helper()
+	var values []string
+	for _, row := range qr.Rows {
+		require.Len(t, row, 1)
+		assert.Equal(t, sqltypes.TypeJSON, row[0].Type())
+		values = append(values, row[0].ToString())
+	}
+	return values
+}
+
+func TestJSONRowsExecute(t *testing.T) {
+	fp := &fakePrimitive{results: []*sqltypes.Result{jsonRowsTestInput()}}
+	j := &JSONRows{Input: fp}
+
+	qr, err := j.TryExecute(t.Context(), &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	// The input is always asked for its fields, as they are the keys of the objects.
+	fp.ExpectLog(t, []string{`Execute  true`})
+	assert.Equal(t, jsonRowsFields(), qr.Fields)
+	assert.Equal(t, []string{
+		`{"id": 1, "name": "a", "note": null, "price": 1.50}`,
+		`{"id": 2, "name": "\"b\"", "note": "x", "price": 2.00}`,
+		`{"id": 3, "name": "c", "note": "y", "price": 3.25}`,
+	}, jsonRowsValues(t, qr))
+
+	fp.rewind()
+	qr, err = j.TryExecute(t.Context(), &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	assert.Nil(t, qr.Fields)
+	assert.Len(t, qr.Rows, 3)
+
+	fp = &fakePrimitive{sendErr: errors.New("input failed")}
+	j = &JSONRows{Input: fp}
+	_, err = j.TryExecute(t.Context(), &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "input failed")
+}
+
+func TestJSONRowsStreamExecute(t *testing.T) {
+	fp := &fakePrimitive{results: []*sqltypes.Result{jsonRowsTestInput()}}
+	j := &JSONRows{Input: fp}
+
+	qr, err := wrapStreamExecute(j, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	fp.ExpectLog(t, []string{`StreamExecute  true`})
+	assert.Equal(t, jsonRowsFields(), qr.Fields)
+	assert.Equal(t, []string{
+		`{"id": 1, "name": "a", "note": null, "price": 1.50}`,
+		`{"id": 2, "name": "\"b\"", "note": "x", "price": 2.00}`,
+		`{"id": 3, "name": "c", "note": "y", "price": 3.25}`,
+	}, jsonRowsValues(t, qr))
+
+	fp.rewind()
+	qr, err = wrapStreamExecute(j, &noopVCursor{}, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	assert.Nil(t, qr.Fields)
+	assert.Len(t, qr.Rows, 3)
+}
+
+func TestJSONRowsGetFields(t *testing.T) {
+	j := &JSONRows{Input: &fakePrimitive{}}
+	qr, err := j.GetFields(t.Context(), &noopVCursor{}, nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Fields, 1)
+	assert.Equal(t, JSONRowsColumn, qr.Fields[0].Name)
+	assert.Equal(t, sqltypes.TypeJSON, qr.Fields[0].Type)
+}
@@ -26,6 +26,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -194,18 +195,18 @@ func (l *Limit) NeedsTransaction() bool {
 
 func (l *Limit) getCountAndOffset(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (count int, offset int, err error) {
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	count, err = getIntFrom(env, vcursor, l.Count)
+	count, err = getIntFrom(env, vcursor.ConnCollation(), l.Count)
 	if err != nil {
 		return
 	}
-	offset, err = getIntFrom(env, vcursor, l.Offset)
+	offset, err = getIntFrom(env, vcursor.ConnCollation(), l.Offset)
 	if err != nil {
 		return
 	}
 	return
 }
 
-func getIntFrom(env *evalengine.ExpressionEnv, vcursor VCursor, expr evalengine.Expr) (int, error) {
+func getIntFrom(env *evalengine.ExpressionEnv, collation collations.ID, expr evalengine.Expr) (int, error) {
 	if expr == nil {
 		return 0, nil
 	}
@@ -213,7 +214,7 @@ func getIntFrom(env *evalengine.ExpressionEnv, vcursor VCursor, expr evalengine.
 	if err != nil {
 		return 0, err
 	}
-	value := evalResult.Value(vcursor.ConnCollation())
+	value := evalResult.Value(collation)
 	if value.IsNull() {
 		return 0, nil
 	}
@@ -0,0 +1,365 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var _ Primitive = (*LoadDataLocal)(nil)
+
+// loadDataBatchRows is the number of rows inserted by each of the INSERT
+// statements of a LOAD DATA LOCAL INFILE.
+const loadDataBatchRows = 500
+
+// LoadDataLocal executes a LOAD DATA LOCAL INFILE statement: it asks the
+// client for the content of the file, and inserts its rows in batches with
+// INSERT statements planned like any other, so that the rows are routed to
+// their shards. The batches are not atomic unless the statement runs in a
+// transaction.
+type LoadDataLocal struct {
+	noInputs
+	noTxNeeded
+	noFields
+
+	// Filename is the name of the file the client is asked for.
+	Filename string
+	// Insert is the beginning of the INSERT statements, up to their
+	// VALUES, e.g. "insert ignore into t(a, b) values ".
+	Insert string
+	// Columns is the number of columns of the column list of the
+	// statement, or 0 if it has none. Missing fields are then inserted as
+	// DEFAULT, and extra fields are ignored.
+	Columns int
+	// Format is how the fields and lines of the file are delimited.
+	Format LoadDataFormat
+	// IgnoreLines is the number of lines skipped at the start of the file.
+	IgnoreLines int
+}
+
+// LoadDataFormat is how the fields and lines of the file of a LOAD DATA
+// statement are delimited, as set by its FIELDS and LINES clauses.
+type LoadDataFormat struct {
+	FieldsTerminatedBy string
+	// FieldsEnclosedBy is the character the fields can be enclosed in, or
+	// 0 if none.
+	FieldsEnclosedBy byte
+	// FieldsEscapedBy is the escape character, or 0 if none.
+	FieldsEscapedBy   byte
+	LinesStartingBy   string
+	LinesTerminatedBy string
+}
+
+// DefaultLoadDataFormat is the format of a LOAD DATA statement without
+// FIELDS and LINES clauses.
+var DefaultLoadDataFormat = LoadDataFormat{
+	FieldsTerminatedBy: "\t",
+	FieldsEscapedBy:    '\\',
+	LinesTerminatedBy:  "\n",
+}
+
+// TryExecute implements the Primitive interface.
+func (l *LoadDataLocal) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (result *sqltypes.Result, err error) {
+	content, err := vcursor.LocalInfile(l.Filename)
+	if err != nil {
+		return nil, err
+	}
+	// The client sends the whole file whatever happens, the rest of it is
+	// read before the result is returned.
+	defer func() {
+		if cerr := content.Close(); err == nil && cerr != nil {
+			result, err = nil, cerr
+		}
+	}()
+
+	r := newLoadDataReader(content, l.Format)
+	for range l.IgnoreLines {
+		if _, err := r.readLine(); err == io.EOF {
+			return &sqltypes.Result{}, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	result = &sqltypes.Result{}
+	rows := make([][]loadDataField, 0, loadDataBatchRows)
+	for {
+		row, err := r.readLine()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if row != nil {
+			rows = append(rows, row)
+		}
+		if len(rows) == loadDataBatchRows || (err == io.EOF && len(rows) > 0) {
+			query, bv := l.insertQuery(rows)
+			qr, err := vcursor.Execute(ctx, "LoadDataLocal", query, bv, true, vtgatepb.CommitOrder_NORMAL)
+			if err != nil {
+				return nil, err
+			}
+			result.RowsAffected += qr.RowsAffected
+			rows = rows[:0]
+		}
+		if err == io.EOF {
+			return result, nil
+		}
+	}
+}
+
+// insertQuery returns the INSERT statement of rows, and its bind variables.
+func (l *LoadDataLocal) insertQuery(rows [][]loadDataField) (string, map[string]*querypb.BindVariable) {
+	bindVars := make(map[string]*querypb.BindVariable)
+	var buf strings.Builder
+	buf.WriteString(l.Insert)
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		columns := l.Columns
+		if columns == 0 {
+			columns = len(row)
+		}
+		buf.WriteByte('(')
+		for j := range columns {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			if j >= len(row) {
+				buf.WriteString("default")
+				continue
+			}
+			name := "ld" + strconv.Itoa(len(bindVars))
+			buf.WriteString(":" + name)
+			if row[j].null {
+				bindVars[name] = sqltypes.NullBindVariable
+			} else {
+				bindVars[name] = sqltypes.StringBindVariable(row[j].value)
+			}
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String(), bindVars
+}
+
+// TryStreamExecute implements the Primitive interface.
+func (l *LoadDataLocal) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	qr, err := l.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(qr)
+}
+
+func (l *LoadDataLocal) description() PrimitiveDescription {
+	other := map[string]any{
+		"Filename": l.Filename,
+		"Query":    l.Insert,
+	}
+	if l.IgnoreLines > 0 {
+		other["IgnoreLines"] = l.IgnoreLines
+	}
+	return PrimitiveDescription{
+		OperatorType: "LoadDataLocal",
+		Other:        other,
+	}
+}
+
+// loadDataField is a field of a line of a LOAD DATA file.
+type loadDataField struct {
+	value string
+	null  bool
+}
+
+// loadDataReader reads the lines of a LOAD DATA file, like MySQL.
+type loadDataReader struct {
+	r      *bufio.Reader
+	format LoadDataFormat
+	buf    bytes.Buffer
+}
+
+func newLoadDataReader(r io.Reader, format LoadDataFormat) *loadDataReader {
+	return &loadDataReader{r: bufio.NewReader(r), format: format}
+}
+
+// readLine returns the fields of the next line, or io.EOF after the last
+// one. Lines that do not contain the LINES STARTING BY prefix are skipped.
+func (lr *loadDataReader) readLine() ([]loadDataField, error) {
+	if prefix := lr.format.LinesStartingBy; prefix != "" {
+		for {
+			found, err := lr.consume(prefix)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				break
+			}
+			if _, err := lr.r.ReadByte(); err != nil {
+				return nil, err
+			}
+		}
+	} else if _, err := lr.r.Peek(1); err != nil {
+		return nil, err
+	}
+
+	var fields []loadDataField
+	for {
+		field, end, err := lr.readField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		if end {
+			return fields, nil
+		}
+	}
+}
+
+// readField reads the next field, and its terminator. end is set if the
+// field is the last one of its line.
+func (lr *loadDataReader) readField() (field loadDataField, end bool, err error) {
+	lr.buf.Reset()
+	quote := lr.format.FieldsEnclosedBy
+	enclosed := false
+	if quote != 0 {
+		if enclosed, err = lr.consume(string([]byte{quote})); err != nil {
+			return field, false, err
+		}
+	}
+	// null is set while the field is the escape sequence of NULL. When the
+	// fields can be enclosed, the word NULL is NULL too, unless enclosed.
+	null := false
+	done := func(end bool) (loadDataField, bool, error) {
+		if null || (!enclosed && quote != 0 && lr.buf.String() == "NULL") {
+			return loadDataField{null: true}, end, nil
+		}
+		return loadDataField{value: lr.buf.String()}, end, nil
+	}
+	for {
+		// The terminators only end an enclosed field after its closing
+		// character.
+		if !enclosed {
+			if end, found, err := lr.terminator(); err != nil {
+				return field, false, err
+			} else if found {
+				return done(end)
+			}
+		}
+
+		b, err := lr.r.ReadByte()
+		if err == io.EOF {
+			return done(true)
+		}
+		if err != nil {
+			return field, false, vterrors.Wrapf(err, "reading LOAD DATA file")
+		}
+
+		switch {
+		case b == lr.format.FieldsEscapedBy && b != 0:
+			next, err := lr.r.ReadByte()
+			if err == io.EOF {
+				null = false
+				lr.buf.WriteByte(b)
+				continue
+			}
+			if err != nil {
+				return field, false, vterrors.Wrapf(err, "reading LOAD DATA file")
+			}
+			null = !enclosed && next == 'N' && lr.buf.Len() == 0
+			lr.buf.WriteByte(unescapeLoadData(next))
+			continue
+		case enclosed && b == quote:
+			// A doubled enclosing character stands for itself.
+			doubled, err := lr.consume(string([]byte{b}))
+			if err != nil {
+				return field, false, err
+			}
+			if !doubled {
+				// The field ends if a terminator follows, otherwise the
+				// character is part of it.
+				end, found, err := lr.terminator()
+				if err != nil {
+					return field, false, err
+				}
+				if found {
+					return done(end)
+				}
+				if _, err := lr.r.Peek(1); err == io.EOF {
+					return done(true)
+				}
+			}
+		}
+		null = false
+		lr.buf.WriteByte(b)
+	}
+}
+
+// terminator consumes the field or line terminator at the current
+// position, if any. end is set for a line terminator.
+func (lr *loadDataReader) terminator() (end bool, found bool, err error) {
+	if found, err = lr.consume(lr.format.LinesTerminatedBy); err != nil || found {
+		return true, found, err
+	}
+	found, err = lr.consume(lr.format.FieldsTerminatedBy)
+	return false, found, err
+}
+
+// consume consumes s if the reader is positioned on it.
+func (lr *loadDataReader) consume(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	next, err := lr.r.Peek(len(s))
+	if err != nil && err != io.EOF {
+		return false, vterrors.Wrapf(err, "reading LOAD DATA file")
+	}
+	if string(next) != s {
+		return false, nil
+	}
+	_, err = lr.r.Discard(len(s))
+	return true, err
+}
+
+// unescapeLoadData returns the character of the escape sequence ending
+// with b.
+func unescapeLoadData(b byte) byte {
+	switch b {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	}
+	return b
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestLoadDataReader(t *testing.T) {
+	csv := LoadDataFormat{
+		FieldsTerminatedBy: ",",
+		FieldsEnclosedBy:   '"',
+		FieldsEscapedBy:    '\\',
+		LinesTerminatedBy:  "\r\n",
+	}
+	null := loadDataField{null: true}
+	f := func(value string) loadDataField {
+		return loadDataField{value: value}
+	}
+
+	testcases := []struct {
+		name    string
+		format  LoadDataFormat
+		content string
+		want    [][]loadDataField
+	}{{
+		name:    "default format",
+		format:  DefaultLoadDataFormat,
+		content: "1\tone\n2\t\\N\n3\ta\\ttab\\\\\n\n4",
+		want:    [][]loadDataField{{f("1"), f("one")}, {f("2"), null}, {f("3"), f("a\ttab\\")}, {f("")}, {f("4")}},
+	}, {
+		name:    "escaped characters",
+		format:  DefaultLoadDataFormat,
+		content: "\\0\\b\\n\\r\\Z\\x\t\\Nx\tN\n",
+		want:    [][]loadDataField{{f("\x00\b\n\r\x1ax"), f("Nx"), f("N")}},
+	}, {
+		name:    "enclosed fields",
+		format:  csv,
+		content: "1,\"a, \"\"quoted\"\" \\\"value\",NULL,\"NULL\"\r\n\"2\",\"multi\r\nline\",,\"a\"b\"\r\n",
+		want:    [][]loadDataField{{f("1"), f("a, \"quoted\" \"value"), null, f("NULL")}, {f("2"), f("multi\r\nline"), f(""), f("a\"b")}},
+	}, {
+		name: "no escape character",
+		format: LoadDataFormat{
+			FieldsTerminatedBy: "|",
+			LinesTerminatedBy:  "\n",
+		},
+		content: "a\\b|\\N\n",
+		want:    [][]loadDataField{{f("a\\b"), f("\\N")}},
+	}, {
+		name: "lines starting by",
+		format: LoadDataFormat{
+			FieldsTerminatedBy: ",",
+			FieldsEscapedBy:    '\\',
+			LinesStartingBy:    "xxx",
+			LinesTerminatedBy:  "\n",
+		},
+		content: "xxx1,one\nskipped\nignored xxx2,two\n",
+		want:    [][]loadDataField{{f("1"), f("one")}, {f("2"), f("two")}},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newLoadDataReader(strings.NewReader(tc.content), tc.format)
+			var got [][]loadDataField
+			for {
+				line, err := r.readLine()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, line)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoadDataLocal(t *testing.T) {
+	var content strings.Builder
+	content.WriteString("id\tname\n")
+	for i := range loadDataBatchRows + 2 {
+		fmt.Fprintf(&content, "%d\tname%d\n", i, i)
+	}
+	content.WriteString("last\n")
+
+	vc := &loggingVCursor{
+		localFiles: map[string]string{"data.tsv": content.String()},
+		results: []*sqltypes.Result{
+			{RowsAffected: loadDataBatchRows},
+			{RowsAffected: 3},
+		},
+	}
+	load := &LoadDataLocal{
+		Filename:    "data.tsv",
+		Insert:      "insert ignore into t(id, `name`) values ",
+		Columns:     2,
+		Format:      DefaultLoadDataFormat,
+		IgnoreLines: 1,
+	}
+	result, err := load.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, loadDataBatchRows+3, result.RowsAffected)
+
+	// The rows are inserted in batches, and a missing field is inserted
+	// as DEFAULT.
+	require.Len(t, vc.log, 3)
+	assert.Equal(t, "LocalInfile data.tsv", vc.log[0])
+	assert.True(t, strings.HasPrefix(vc.log[1], "Execute insert ignore into t(id, `name`) values (:ld0, :ld1), (:ld2, :ld3), "), vc.log[1])
+	assert.True(t, strings.HasPrefix(vc.log[2], "Execute insert ignore into t(id, `name`) values (:ld0, :ld1), (:ld2, :ld3), (:ld4, default) ld0: "), vc.log[2])
+
+	query, bindVars := load.insertQuery([][]loadDataField{{{value: "1"}, {null: true}, {value: "extra"}}})
+	assert.Equal(t, "insert ignore into t(id, `name`) values (:ld0, :ld1)", query)
+	assert.Equal(t, map[string]*querypb.BindVariable{
+		"ld0": sqltypes.StringBindVariable("1"),
+		"ld1": sqltypes.NullBindVariable,
+	}, bindVars)
+
+	// The file is read to the end even if an insert fails.
+	vc = &loggingVCursor{
+		localFiles: map[string]string{"data.tsv": content.String()},
+		resultErr:  errors.New("insert failed"),
+	}
+	_, err = load.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	assert.ErrorContains(t, err, "insert failed")
+
+	vc = &loggingVCursor{}
+	_, err = load.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	assert.ErrorContains(t, err, "no file data.tsv")
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// UnmaskedRole is the role (i.e. the group of the immediate caller) that
+// allows a user to see the values of masked columns.
+const UnmaskedRole = "unmasked"
+
+var _ Primitive = (*Mask)(nil)
+
+// Mask applies the masking policies of the vschema to the columns returned
+// by its input, unless the user has the UnmaskedRole.
+type Mask struct {
+	// Columns are the masked columns of the input.
+	Columns []MaskedColumn
+	Input   Primitive
+}
+
+// MaskedColumn is a column of the input of a Mask and the policy
+// applied to its values.
+type MaskedColumn struct {
+	Offset int
+	Mask   *vschemapb.ColumnMask
+}
+
+// NeedsTransaction implements the Primitive interface
+func (m *Mask) NeedsTransaction() bool {
+	return m.Input.NeedsTransaction()
+}
+
+// TryExecute performs a non-streaming exec.
+func (m *Mask) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	inner, err := vcursor.ExecutePrimitive(ctx, m.Input, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	if isUnmasked(ctx) {
+		return inner, nil
+	}
+	return m.buildResult(inner), nil
+}
+
+// TryStreamExecute performs a streaming exec.
+func (m *Mask) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	if isUnmasked(ctx) {
+		return vcursor.StreamExecutePrimitive(ctx, m.Input, bindVars, wantfields, callback)
+	}
+	return vcursor.StreamExecutePrimitive(ctx, m.Input, bindVars, wantfields, func(inner *sqltypes.Result) error {
+		return callback(m.buildResult(inner))
+	})
+}
+
+// GetFields fetches the field info.
+func (m *Mask) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	inner, err := m.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	if isUnmasked(ctx) {
+		return inner, nil
+	}
+	return &sqltypes.Result{Fields: m.buildFields(inner.Fields)}, nil
+}
+
+// Inputs returns the input to this primitive
+func (m *Mask) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{m.Input}, nil
+}
+
+// MasksResults returns true if executing the primitive masks values of its
+// results for the caller of ctx.
+func MasksResults(ctx context.Context, p Primitive) bool {
+	if isUnmasked(ctx) {
+		return false
+	}
+	return Exists(func(p Primitive) bool {
+		_, ok := p.(*Mask)
+		return ok
+	}, p)
+}
+
+func isUnmasked(ctx context.Context) bool {
+	im := callerid.ImmediateCallerIDFromContext(ctx)
+	return im != nil && slices.Contains(im.Groups, UnmaskedRole)
+}
+
+// buildResult returns a copy of inner with the values of the masked columns
+// replaced. The rows of inner are not modified, as they may be shared with
+// other results.
+func (m *Mask) buildResult(inner *sqltypes.Result) *sqltypes.Result {
+	result := inner.ShallowCopy()
+	result.Fields = m.buildFields(inner.Fields)
+	result.Rows = make([][]sqltypes.Value, 0, len(inner.Rows))
+	for _, innerRow := range inner.Rows {
+		row := slices.Clone(innerRow)
+		for _, col := range m.Columns {
+			if col.Offset < len(row) {
+				row[col.Offset] = maskValue(col.Mask, row[col.Offset])
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+// buildFields returns the fields of the masked result. The values of the
+// PARTIAL and HASH policies are always strings, whatever the type of the
+// column.
+func (m *Mask) buildFields(fields []*querypb.Field) []*querypb.Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	fields = slices.Clone(fields)
+	for _, col := range m.Columns {
+		if col.Offset >= len(fields) || col.Mask.Policy == vschemapb.ColumnMask_NULL_OUT {
+			continue
+		}
+		field := proto.Clone(fields[col.Offset]).(*querypb.Field)
+		field.Type = sqltypes.VarChar
+		field.Charset = uint32(collations.CollationUtf8mb4ID)
+		field.ColumnLength = 0
+		field.Decimals = 0
+		field.Flags = 0
+		fields[col.Offset] = field
+	}
+	return fields
+}
+
+func maskValue(mask *vschemapb.ColumnMask, v sqltypes.Value) sqltypes.Value {
+	if v.IsNull() {
+		return v
+	}
+	switch mask.Policy {
+	case vschemapb.ColumnMask_NULL_OUT:
+		return sqltypes.NULL
+	case vschemapb.ColumnMask_PARTIAL:
+		runes := []rune(v.ToString())
+		prefix, suffix := int(mask.VisiblePrefix), int(mask.VisibleSuffix)
+		if prefix+suffix >= len(runes) {
+			// MySQL's mask_inner leaves the values that are too short unmasked;
+			// we mask them completely instead so that no value is leaked.
+			prefix, suffix = 0, 0
+		}
+		for i := prefix; i < len(runes)-suffix; i++ {
+			runes[i] = 'X'
+		}
+		return sqltypes.NewVarChar(string(runes))
+	case vschemapb.ColumnMask_HASH:
+		sum := sha256.Sum256(v.Raw())
+		return sqltypes.NewVarChar(hex.EncodeToString(sum[:]))
+	default:
+		return v
+	}
+}
+
+func (m *Mask) description() PrimitiveDescription {
+	columns := make([]string, 0, len(m.Columns))
+	for _, col := range m.Columns {
+		columns = append(columns, fmt.Sprintf("%d:%s", col.Offset, maskString(col.Mask)))
+	}
+	return PrimitiveDescription{
+		OperatorType: "Mask",
+		Other: map[string]any{
+			"Columns": strings.Join(columns, ", "),
+		},
+	}
+}
+
+func maskString(mask *vschemapb.ColumnMask) string {
+	policy := strings.ToLower(mask.Policy.String())
+	if mask.Policy == vschemapb.ColumnMask_PARTIAL {
+		return fmt.Sprintf("%s(%d,%d)", policy, mask.VisiblePrefix, mask.VisibleSuffix)
+	}
+	return policy
+}
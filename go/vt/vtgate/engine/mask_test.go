@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func maskTestInput() *fakePrimitive {
+	return &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields(
+					"id|name|email|ssn|card",
+					"int64|varchar|varchar|varchar|int64",
+				),
+				"1|alice|alice@example.com|123-45-6789|4111111111111111",
+				"2|bo|null|987-65-4321|42",
+			),
+		},
+	}
+}
+
+func maskTestPrimitive(input Primitive) *Mask {
+	return &Mask{
+		Columns: []MaskedColumn{
+			{Offset: 1, Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_PARTIAL, VisiblePrefix: 1, VisibleSuffix: 1}},
+			{Offset: 2, Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_HASH}},
+			{Offset: 3, Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_NULL_OUT}},
+			{Offset: 4, Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_PARTIAL, VisibleSuffix: 4}},
+		},
+		Input: input,
+	}
+}
+
+func TestMaskExecute(t *testing.T) {
+	input := maskTestInput()
+	m := maskTestPrimitive(input)
+
+	ctx := callerid.NewContext(t.Context(), nil, &querypb.VTGateCallerID{Username: "app", Groups: []string{"readers"}})
+	r, err := m.TryExecute(ctx, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, `[[INT64(1) VARCHAR("aXXXe") VARCHAR("ff8d9819fc0e12bf0d24892e45987e249a28dce836a85cad60e28eaaa8c6d976") NULL VARCHAR("XXXXXXXXXXXX1111")] `+
+		`[INT64(2) VARCHAR("XX") NULL NULL VARCHAR("XX")]]`, fmt.Sprintf("%v", r.Rows))
+	assert.Equal(t, sqltypes.Int64, r.Fields[0].Type)
+	assert.Equal(t, sqltypes.VarChar, r.Fields[3].Type)
+	assert.Equal(t, sqltypes.VarChar, r.Fields[4].Type)
+
+	// the input is not modified
+	assert.Equal(t, "alice", input.results[0].Rows[0][1].ToString())
+	assert.Equal(t, sqltypes.Int64, input.results[0].Fields[4].Type)
+}
+
+func TestMaskUnmaskedRole(t *testing.T) {
+	input := maskTestInput()
+	m := maskTestPrimitive(input)
+
+	ctx := callerid.NewContext(t.Context(), nil, &querypb.VTGateCallerID{Username: "admin", Groups: []string{"readers", UnmaskedRole}})
+	r, err := m.TryExecute(ctx, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResult(t, r, input.results[0])
+
+	// without a caller, the values are masked
+	m.Input = maskTestInput()
+	r, err = m.TryExecute(t.Context(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	assert.True(t, r.Rows[0][3].IsNull())
+}
+
+func TestMaskStreamExecute(t *testing.T) {
+	m := maskTestPrimitive(maskTestInput())
+
+	r, err := wrapStreamExecute(m, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	require.Len(t, r.Rows, 2)
+	assert.Equal(t, "aXXXe", r.Rows[0][1].ToString())
+	assert.Equal(t, "XXXXXXXXXXXX1111", r.Rows[0][4].ToString())
+}
+
+func TestMasksResults(t *testing.T) {
+	input := maskTestInput()
+	plan := &Limit{Input: maskTestPrimitive(input)}
+
+	ctx := callerid.NewContext(t.Context(), nil, &querypb.VTGateCallerID{Username: "app", Groups: []string{"readers"}})
+	assert.True(t, MasksResults(ctx, plan))
+	assert.False(t, MasksResults(ctx, input))
+
+	ctx = callerid.NewContext(t.Context(), nil, &querypb.VTGateCallerID{Username: "admin", Groups: []string{UnmaskedRole}})
+	assert.False(t, MasksResults(ctx, plan))
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vthash"
+)
+
+// rowMemory returns the number of bytes of memory held by a row.
+func rowMemory(row sqltypes.Row) int64 {
+	size := hack.RuntimeAllocSize(int64(cap(row)) * 32)
+	for i := range row {
+		size += row[i].CachedSize(false)
+	}
+	return size
+}
+
+// rowsMemory returns the number of bytes of memory held by rows.
+func rowsMemory(rows []sqltypes.Row) int64 {
+	var size int64
+	for _, row := range rows {
+		size += rowMemory(row)
+	}
+	return size
+}
+
+// probeEntryMemory is the number of bytes of memory held by an entry of a
+// probeTable, which is the hash of a row.
+const probeEntryMemory = int64(len(vthash.Hash{}))
+
+// memoryTracker tracks the memory held by a primitive, so that it can
+// release it once it's done. A primitive only charges the rows it holds
+// itself, as it takes them, and releases them when it returns. The rows of
+// the results it receives from its inputs are released by the inputs once
+// they return, so that a row is only charged once when primitives are
+// stacked.
+type memoryTracker struct {
+	vcursor VCursor
+	bytes   int64
+}
+
+// hold records that the primitive holds bytes more bytes.
+func (mt *memoryTracker) hold(bytes int64) error {
+	mt.bytes += bytes
+	return mt.vcursor.TrackMemory(bytes)
+}
+
+// holdRow records that the primitive holds the row.
+func (mt *memoryTracker) holdRow(row sqltypes.Row) error {
+	return mt.hold(rowMemory(row))
+}
+
+// holdRows records that the primitive holds the rows.
+func (mt *memoryTracker) holdRows(rows []sqltypes.Row) error {
+	return mt.hold(rowsMemory(rows))
+}
+
+// resize records that the primitive now holds bytes bytes in total.
+func (mt *memoryTracker) resize(bytes int64) error {
+	if bytes <= mt.bytes {
+		_ = mt.vcursor.TrackMemory(bytes - mt.bytes)
+		mt.bytes = bytes
+		return nil
+	}
+	return mt.hold(bytes - mt.bytes)
+}
+
+// release records that the primitive released all the rows it held.
+func (mt *memoryTracker) release() {
+	_ = mt.vcursor.TrackMemory(-mt.bytes)
+	mt.bytes = 0
+}
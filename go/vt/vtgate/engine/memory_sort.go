@@ -55,6 +55,11 @@ func (ms *MemorySort) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 	if err != nil {
 		return nil, err
 	}
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
+	if err := memory.holdRows(result.Rows); err != nil {
+		return nil, err
+	}
 
 	if err = ms.OrderBy.SortResult(result); err != nil {
 		return nil, err
@@ -83,6 +88,9 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		Limit:   count,
 	}
 
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
+
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		mu.Lock()
@@ -93,7 +101,15 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 			}
 		}
 		for _, row := range qr.Rows {
+			n := sorter.Len()
 			sorter.Push(row)
+			// once the sorter holds as many rows as the limit, a pushed row
+			// replaces one of the rows it holds
+			if sorter.Len() > n {
+				if err := memory.holdRow(row); err != nil {
+					return err
+				}
+			}
 		}
 		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
 			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
@@ -610,6 +610,60 @@ func TestMemorySortMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestMemorySortMaxMemoryBytes(t *testing.T) {
+	input := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"c1|c2",
+			"varbinary|decimal",
+		),
+		"a|1",
+		"b|2",
+		"a|1",
+		"c|4",
+		"c|3",
+	)
+	fp := &fakePrimitive{results: []*sqltypes.Result{input}}
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+		}},
+		Input: fp,
+	}
+
+	vc := &memoryVCursor{limit: rowsMemory(input.Rows) - 1}
+	_, err := ms.TryExecute(t.Context(), vc, nil, false)
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+
+	fp.rewind()
+	vc = &memoryVCursor{limit: rowsMemory(input.Rows) - 1}
+	err = ms.TryStreamExecute(t.Context(), vc, nil, false, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+
+	// the sorted rows are released once they are sent
+	fp.rewind()
+	vc = &memoryVCursor{limit: rowsMemory(input.Rows)}
+	err = ms.TryStreamExecute(t.Context(), vc, nil, false, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, vc.bytes.Load())
+
+	// stacked sorts don't hold the same rows at the same time
+	fp.rewind()
+	stacked := &MemorySort{
+		OrderBy: ms.OrderBy,
+		Input:   &MemorySort{OrderBy: ms.OrderBy, Input: fp},
+	}
+	vc = &memoryVCursor{limit: rowsMemory(input.Rows)}
+	_, err = stacked.TryExecute(t.Context(), vc, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, rowsMemory(input.Rows), vc.peak.Load())
+	assert.Zero(t, vc.bytes.Load())
+}
+
 func TestMemorySortExecuteNoVarChar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2",
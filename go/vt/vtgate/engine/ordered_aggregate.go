@@ -129,11 +129,16 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 	if err != nil {
 		return nil, err
 	}
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
+	if err := memory.holdRows(result.Rows); err != nil {
+		return nil, err
+	}
 	if len(oa.Aggregates) == 0 {
 		return oa.executeGroupBy(result)
 	}
 
-	agg, fields, err := newAggregation(result.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+	agg, fields, err := newAggregation(result.Fields, oa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, oa.Aggregates))
 	if err != nil {
 		return nil, err
 	}
@@ -242,12 +247,16 @@ func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCurso
 	var agg *aggregationState
 	var fields []*querypb.Field
 	var currentKey []sqltypes.Value
+	// the aggregators of some aggregations, like GROUP_CONCAT, hold the rows
+	// of the current group
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 
 	visitor := func(qr *sqltypes.Result) error {
 		var err error
 
 		if agg == nil && len(qr.Fields) != 0 {
-			agg, fields, err = newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+			agg, fields, err = newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, oa.Aggregates))
 			if err != nil {
 				return err
 			}
@@ -281,6 +290,9 @@ func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCurso
 			if err := agg.add(row); err != nil {
 				return err
 			}
+			if err := memory.resize(agg.heldMemory()); err != nil {
+				return err
+			}
 		}
 		return nil
 	}
@@ -310,7 +322,7 @@ func (oa *OrderedAggregate) GetFields(ctx context.Context, vcursor VCursor, bind
 		return nil, err
 	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	_, fields, err := newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation())
+	_, fields, err := newAggregation(qr.Fields, oa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, oa.Aggregates))
 	if err != nil {
 		return nil, err
 	}
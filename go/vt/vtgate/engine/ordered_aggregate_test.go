@@ -69,6 +69,67 @@ func TestOrderedAggregateExecute(t *testing.T) {
 	utils.MustMatch(t, wantResult, result)
 }
 
+func TestOrderedAggregateExecuteMaxMemoryBytes(t *testing.T) {
+	input := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"col|count(*)",
+			"varbinary|decimal",
+		),
+		"a|1",
+		"a|1",
+		"b|2",
+	)
+	oa := &OrderedAggregate{
+		Aggregates:  []*AggregateParams{NewAggregateParam(AggregateSum, 1, nil, "", collations.MySQL8())},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0}},
+		Input:       &fakePrimitive{results: []*sqltypes.Result{input}},
+	}
+
+	_, err := oa.TryExecute(t.Context(), &memoryVCursor{limit: rowsMemory(input.Rows) - 1}, nil, false)
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+}
+
+func TestOrderedAggregateStreamMaxMemoryBytes(t *testing.T) {
+	collationEnv := collations.MySQL8()
+	input := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"col|val",
+			"varbinary|int64",
+		),
+		"a|1",
+		"a|2",
+		"a|3",
+		"b|4",
+	)
+	oa := &OrderedAggregate{
+		Aggregates: []*AggregateParams{{
+			Opcode: AggregateGroupConcat,
+			Col:    1,
+			Func:   &sqlparser.GroupConcatExpr{},
+			GroupConcat: &GroupConcatParams{
+				ArgCols: []int{1},
+				OrderBy: evalengine.Comparison{{Col: 1, WeightStringCol: -1, Desc: true, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID), CollationEnv: collationEnv}},
+			},
+			CollationEnv: collationEnv,
+		}},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0}},
+		Input:       &fakePrimitive{results: []*sqltypes.Result{input}},
+	}
+
+	// the ordered GROUP_CONCAT holds the rows of one group at a time
+	group := rowsMemory(input.Rows[:3])
+	vc := &memoryVCursor{limit: group}
+	qr, err := wrapStreamExecute(oa, vc, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARBINARY("a") TEXT("3,2,1")] [VARBINARY("b") TEXT("4")]]`, fmt.Sprintf("%v", qr.Rows))
+	assert.Equal(t, group, vc.peak.Load())
+	assert.Zero(t, vc.bytes.Load())
+
+	oa.Input = &fakePrimitive{results: []*sqltypes.Result{input}}
+	err = oa.TryStreamExecute(t.Context(), &memoryVCursor{limit: group - 1}, nil, false, func(*sqltypes.Result) error { return nil })
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit")
+}
+
 func TestOrderedAggregateExecuteTruncate(t *testing.T) {
 	fp := &fakePrimitive{
 		results: []*sqltypes.Result{sqltypes.MakeTestResult(
@@ -1080,7 +1141,7 @@ func TestGroupConcatWithAggrOnEngine(t *testing.T) {
 		t.Run(tcase.name, func(t *testing.T) {
 			fp := &fakePrimitive{results: []*sqltypes.Result{tcase.inputResult}}
 			agp := NewAggregateParam(AggregateGroupConcat, 1, nil, "group_concat(c2)", collations.MySQL8())
-			agp.Func = &sqlparser.GroupConcatExpr{Separator: ","}
+			agp.Func = &sqlparser.GroupConcatExpr{Separator: "','"}
 			oa := &OrderedAggregate{
 				Aggregates:  []*AggregateParams{agp},
 				GroupByKeys: []*GroupByParams{{KeyCol: 0}},
@@ -1161,7 +1222,7 @@ func TestGroupConcat(t *testing.T) {
 		t.Run(tcase.name, func(t *testing.T) {
 			fp := &fakePrimitive{results: []*sqltypes.Result{tcase.inputResult}}
 			agp := NewAggregateParam(AggregateGroupConcat, 1, nil, "", collations.MySQL8())
-			agp.Func = &sqlparser.GroupConcatExpr{Separator: ","}
+			agp.Func = &sqlparser.GroupConcatExpr{Separator: "','"}
 			oa := &OrderedAggregate{
 				Aggregates:  []*AggregateParams{agp},
 				GroupByKeys: []*GroupByParams{{KeyCol: 0}},
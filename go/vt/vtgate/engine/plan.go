@@ -50,6 +50,8 @@ type (
 		QueryHints   sqlparser.QueryHints    // QueryHints stores any SET_VAR hints that influenced plan generation.
 		ParamsCount  uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
 		Optimized    atomic.Bool             // Prepared queries need to be optimized before the first execution
+		Decisions    []PlannerDecision       // Decisions lists the cost-based choices of the planner, if recorded.
+		LockingRead  bool                    // LockingRead is true for SELECT ... FOR UPDATE and SELECT ... FOR SHARE queries.
 
 		ExecCount    uint64 // ExecCount is how many times this plan has been executed.
 		ExecTime     uint64 // ExecTime is the total accumulated execution time in nanoseconds.
@@ -59,6 +61,23 @@ type (
 		Errors       uint64 // Errors is the total count of errors encountered during execution.
 	}
 
+	// PlannerDecision is a cost-based choice made by the planner: the
+	// candidate it chose, and the candidates it rejected.
+	PlannerDecision struct {
+		Chosen   PlanCandidate
+		Rejected []PlanCandidate
+	}
+
+	// PlanCandidate is an operator tree considered by the planner, with its
+	// estimated cost. A lower cost is better.
+	PlanCandidate struct {
+		Plan string
+		Cost int
+		// DrivingRows is the number of rows of the left-hand side of the
+		// join, estimated from the sampled column statistics, or 0 if unknown.
+		DrivingRows int64
+	}
+
 	// PlanKey identifies a plan uniquely based on keyspace, destination, query,
 	// SET_VAR comment, and collation. It is primarily used as a cache key.
 	PlanKey struct {
@@ -137,6 +156,8 @@ func getPlanType(p Primitive) PlanType {
 		return PlanMultiShard
 	case *Limit:
 		return getPlanType(prim.Input)
+	case *JSONRows:
+		return getPlanType(prim.Input)
 	case *VindexLookup:
 		return PlanLookup
 	case *Join:
@@ -280,9 +301,16 @@ func NewPlan(query string, stmt sqlparser.Statement, primitive Primitive, bindVa
 		Instructions: primitive,
 		BindVarNeeds: bindVarNeeds,
 		TablesUsed:   tablesUsed,
+		LockingRead:  isLockingRead(stmt),
 	}
 }
 
+// isLockingRead returns true if the statement is a SELECT that locks the rows it reads.
+func isLockingRead(stmt sqlparser.Statement) bool {
+	sel, ok := stmt.(sqlparser.SelectStatement)
+	return ok && sel.GetLock() != sqlparser.NoLock
+}
+
 // GetRoutingIndexes walks the primitive tree rooted at p and collects vindex
 // routing information from any Route or DML primitives that use a vindex for
 // shard routing. The caller is expected to pass the post-PlanSwitcher root —
@@ -355,6 +383,7 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		RowsReturned uint64                `json:",omitempty"`
 		Errors       uint64                `json:",omitempty"`
 		TablesUsed   []string              `json:",omitempty"`
+		Decisions    []PlannerDecision     `json:",omitempty"`
 	}{
 		Type:         p.Type.String(),
 		QueryType:    p.QueryType.String(),
@@ -367,6 +396,7 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		RowsReturned: atomic.LoadUint64(&p.RowsReturned),
 		Errors:       atomic.LoadUint64(&p.Errors),
 		TablesUsed:   p.TablesUsed,
+		Decisions:    p.Decisions,
 	}
 
 	b := new(bytes.Buffer)
@@ -18,6 +18,7 @@ package engine
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"golang.org/x/sync/semaphore"
@@ -58,6 +59,12 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// TrackMemory records that the sorts, aggregations and joins of the
+		// query hold bytes more bytes of rows in memory, or that they released
+		// -bytes bytes if bytes is negative. It returns an error if the query
+		// holds more memory than the --max-memory-bytes flag value.
+		TrackMemory(bytes int64) error
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -114,6 +121,10 @@ type (
 
 		// ShowExec takes in show command and use executor to execute the query, they are used when topo access is involved.
 		ShowExec(ctx context.Context, command sqlparser.ShowCommandType, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		// ShowProcessList lists the client connections of vtgate the caller can see, truncating their statements unless full is set.
+		ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error)
+		// LocalInfile asks the client for the content of the file of a LOAD DATA LOCAL INFILE statement.
+		LocalInfile(filename string) (io.ReadCloser, error)
 		// SetExec takes in k,v pair and use executor to set them in topo metadata.
 		SetExec(ctx context.Context, name string, value string) error
 		// ThrottleApp sets a ThrottlerappRule in topo
@@ -131,6 +142,27 @@ type (
 		// GetWarmingReadsSemaphore returns the semaphore for limiting concurrent warming reads
 		GetWarmingReadsSemaphore() *semaphore.Weighted
 
+		// InListChunkSize returns the maximum number of values of an IN list
+		// that a route sends to a shard in one query, or 0 if routes don't
+		// split their IN lists.
+		InListChunkSize() int
+
+		// GetInListChunkSemaphore returns the semaphore that limits the number
+		// of streams that the sorted routes executed in chunks open at the
+		// same time.
+		GetInListChunkSemaphore() *semaphore.Weighted
+
+		// MaxExecutionTimeHint returns true if routes hint their SELECT
+		// statements with a MAX_EXECUTION_TIME set to the time left before the
+		// deadline of the query, so that MySQL stops running them once the
+		// query times out.
+		MaxExecutionTimeHint() bool
+
+		// GetInsertBatcher returns the batcher for the single-row autocommit
+		// inserts of the session, and the session they are batched under, or
+		// nil if the inserts of the session are not to be batched.
+		GetInsertBatcher(ctx context.Context) (*InsertBatcher, *InsertBatchSession)
+
 		// GetQueryPriority returns the current session's query priority as an int, defaulting to 0 if unset
 		GetQueryPriority() (int, error)
 
@@ -47,18 +47,9 @@ func (p *Projection) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 	}
 
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
-	var resultRows []sqltypes.Row
-	for _, row := range result.Rows {
-		resultRow := make(sqltypes.Row, 0, len(p.Exprs))
-		env.Row = row
-		for _, exp := range p.Exprs {
-			c, err := env.Evaluate(exp)
-			if err != nil {
-				return nil, err
-			}
-			resultRow = append(resultRow, c.Value(vcursor.ConnCollation()))
-		}
-		resultRows = append(resultRows, resultRow)
+	resultRows, err := env.ProjectBatch(p.Exprs, result.Rows, vcursor.ConnCollation())
+	if err != nil {
+		return nil, err
 	}
 	if wantfields {
 		result.Fields, err = p.evalFields(env, result.Fields, vcursor.ConnCollation())
@@ -96,20 +87,10 @@ func (p *Projection) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 		if err != nil {
 			return err
 		}
-		resultRows := make([]sqltypes.Row, 0, len(qr.Rows))
-		for _, r := range qr.Rows {
-			resultRow := make(sqltypes.Row, 0, len(p.Exprs))
-			env.Row = r
-			for _, exp := range p.Exprs {
-				c, err := env.Evaluate(exp)
-				if err != nil {
-					return err
-				}
-				resultRow = append(resultRow, c.Value(vcursor.ConnCollation()))
-			}
-			resultRows = append(resultRows, resultRow)
+		qr.Rows, err = env.ProjectBatch(p.Exprs, qr.Rows, vcursor.ConnCollation())
+		if err != nil {
+			return err
 		}
-		qr.Rows = resultRows
 		return callback(qr)
 	})
 }
@@ -22,7 +22,9 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -113,6 +115,18 @@ type Route struct {
 	NoRoutesSpecialHandling bool
 
 	FetchLastInsertID bool
+
+	// ChunkableLists are the list arguments of the query that can be split in
+	// chunks when they have more values than the IN list chunk size. The
+	// results of the chunks are merged like the results of the shards.
+	ChunkableLists []ChunkableList
+
+	// hintOnce guards the hinted query of the route, that is the query with
+	// a MAX_EXECUTION_TIME hint split around the value of the hint. hinted
+	// is false if the query is not hinted.
+	hintOnce               sync.Once
+	hinted                 bool
+	hintPrefix, hintSuffix string
 }
 
 // NewRoute creates a Route.
@@ -143,6 +157,9 @@ type cxtKey int
 
 const (
 	IgnoreReserveTxn cxtKey = iota
+	// TargetTabletAlias holds the *topodatapb.TabletAlias a query has to be
+	// sent to, regardless of the tablet targeted by the session.
+	TargetTabletAlias
 )
 
 func (route *Route) executeShards(
@@ -181,7 +198,47 @@ func (route *Route) executeShards(
 		}
 	}
 
-	queries := getQueries(route.Query, bvs)
+	chunks := route.chunkExecutions(vcursor, rss, bvs)
+	if chunks == nil {
+		chunks = []routeChunk{{rss: rss, bvs: bvs}}
+	}
+
+	var result *sqltypes.Result
+	for _, chunk := range chunks {
+		qr, err := route.executeChunk(ctx, vcursor, bindVars, chunk.rss, chunk.bvs)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = qr
+			continue
+		}
+		result.AppendResult(qr)
+		if vcursor.ExceedsMaxMemoryRows(len(result.Rows)) {
+			return nil, fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+	}
+
+	if len(route.OrderBy) > 0 && (len(rss) > 1 || len(chunks) > 1) {
+		var err error
+		result, err = route.sort(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result.Truncate(route.TruncateColumnCount), nil
+}
+
+// executeChunk executes the route once on each of the given shards.
+func (route *Route) executeChunk(
+	ctx context.Context,
+	vcursor VCursor,
+	bindVars map[string]*querypb.BindVariable,
+	rss []*srvtopo.ResolvedShard,
+	bvs []map[string]*querypb.BindVariable,
+) (*sqltypes.Result, error) {
+	queries := getQueries(route.query(ctx, vcursor), bvs)
 	result, errs := vcursor.ExecuteMultiShard(ctx, route, rss, queries, false /*rollbackOnError*/, false /*canAutocommit*/, route.FetchLastInsertID)
 
 	route.executeWarmingReplicaRead(ctx, vcursor, bindVars, queries)
@@ -199,16 +256,7 @@ func (route *Route) executeShards(
 			vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(serr.Num), Message: err.Error()})
 		}
 	}
-
-	if len(route.OrderBy) > 0 && len(rss) > 1 {
-		var err error
-		result, err = route.sort(result)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return result.Truncate(route.TruncateColumnCount), nil
+	return result, nil
 }
 
 func filterOutNilErrors(errs []error) []error {
@@ -278,25 +326,75 @@ func (route *Route) streamExecuteShards(
 		}
 	}
 
-	if len(route.OrderBy) == 0 || len(rss) == 1 {
-		errs := vcursor.StreamExecuteMulti(ctx, route, route.Query, rss, bvs, false /* rollbackOnError */, false /* autocommit */, route.FetchLastInsertID, func(qr *sqltypes.Result) error {
-			return callback(qr.Truncate(route.TruncateColumnCount))
-		})
-		if len(errs) > 0 {
-			if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
-				return vterrors.Aggregate(errs)
-			}
-			partialSuccessScatterQueries.Add(1)
-			for _, err := range errs {
-				sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
-				vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+	chunks := route.chunkExecutions(vcursor, rss, bvs)
+	if chunks == nil {
+		chunks = []routeChunk{{rss: rss, bvs: bvs}}
+	}
+
+	if len(route.OrderBy) == 0 || (len(rss) == 1 && len(chunks) == 1) {
+		for i, chunk := range chunks {
+			err := route.streamExecuteChunk(ctx, vcursor, func(qr *sqltypes.Result) error {
+				// only the first chunk sends the fields
+				if i > 0 && qr.Fields != nil {
+					if len(qr.Rows) == 0 {
+						return nil
+					}
+					qr = &sqltypes.Result{Rows: qr.Rows}
+				}
+				return callback(qr.Truncate(route.TruncateColumnCount))
+			}, chunk.rss, chunk.bvs)
+			if err != nil {
+				return err
 			}
 		}
 		return nil
 	}
 
+	if len(chunks) > 1 {
+		// The merge-sort streams every chunk from every shard at the same
+		// time. The chunks can't be streamed at the same time on the
+		// connection of a shard, and we don't open more streams than the
+		// semaphore allows, so otherwise we execute them one after the
+		// other and sort them.
+		var streams int64
+		for _, chunk := range chunks {
+			streams += int64(len(chunk.rss))
+		}
+		sem := vcursor.GetInListChunkSemaphore()
+		if vcursor.Session().InTransaction() || vcursor.Session().InReservedConn() || sem == nil || !sem.TryAcquire(streams) {
+			qr, err := route.executeShards(ctx, vcursor, bindVars, wantfields, rss, bvs)
+			if err != nil {
+				return err
+			}
+			return callback(qr)
+		}
+		defer sem.Release(streams)
+	}
+
 	// There is an order by. We have to merge-sort.
-	return route.mergeSort(ctx, vcursor, bindVars, wantfields, callback, rss, bvs)
+	return route.mergeSort(ctx, vcursor, bindVars, wantfields, callback, chunks)
+}
+
+// streamExecuteChunk streams the route once on each of the given shards.
+func (route *Route) streamExecuteChunk(
+	ctx context.Context,
+	vcursor VCursor,
+	callback func(*sqltypes.Result) error,
+	rss []*srvtopo.ResolvedShard,
+	bvs []map[string]*querypb.BindVariable,
+) error {
+	errs := vcursor.StreamExecuteMulti(ctx, route, route.query(ctx, vcursor), rss, bvs, false /* rollbackOnError */, false /* autocommit */, route.FetchLastInsertID, callback)
+	if len(errs) > 0 {
+		if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
+			return vterrors.Aggregate(errs)
+		}
+		partialSuccessScatterQueries.Add(1)
+		for _, err := range errs {
+			sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+			vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+		}
+	}
+	return nil
 }
 
 // this is used to make mergeSort easy to test
@@ -320,17 +418,19 @@ func (route *Route) mergeSort(
 	bindVars map[string]*querypb.BindVariable,
 	wantfields bool,
 	callback func(*sqltypes.Result) error,
-	rss []*srvtopo.ResolvedShard,
-	bvs []map[string]*querypb.BindVariable,
+	chunks []routeChunk,
 ) error {
-	prims := make([]StreamExecutor, 0, len(rss))
-	for i, rs := range rss {
-		prims = append(prims, &shardRoute{
-			query:     route.Query,
-			rs:        rs,
-			bv:        bvs[i],
-			primitive: route,
-		})
+	query := route.query(ctx, vcursor)
+	var prims []StreamExecutor
+	for _, chunk := range chunks {
+		for i, rs := range chunk.rss {
+			prims = append(prims, &shardRoute{
+				query:     query,
+				rs:        rs,
+				bv:        chunk.bvs[i],
+				primitive: route,
+			})
+		}
 	}
 
 	ms := createMergeSort(prims, route.OrderBy, route.ScatterErrorsAsWarnings, route.FetchLastInsertID)
@@ -433,6 +533,13 @@ func (route *Route) description() PrimitiveDescription {
 	if route.QueryTimeout > 0 {
 		other["QueryTimeout"] = route.QueryTimeout
 	}
+	if len(route.ChunkableLists) > 0 {
+		lists := make([]string, 0, len(route.ChunkableLists))
+		for _, list := range route.ChunkableLists {
+			lists = append(lists, list.Name)
+		}
+		other["ChunkableLists"] = lists
+	}
 	return PrimitiveDescription{
 		OperatorType:      "Route",
 		Variant:           route.Opcode.String(),
@@ -508,6 +615,60 @@ func execShard(
 	return result, vterrors.Aggregate(errs)
 }
 
+// maxExecutionTimePlaceholder is the MAX_EXECUTION_TIME value of the
+// hinted query of a route, that is replaced by the remaining time of the
+// query when it is executed.
+const maxExecutionTimePlaceholder = "MAX_EXECUTION_TIME(0)"
+
+// query returns the query the route sends to the tablets. When the
+// SELECT statements are hinted with MAX_EXECUTION_TIME, it is the query
+// with the hint, set to the time left before the deadline of the context,
+// unless the query is a locking read, which MySQL does not time out, or
+// already sets its own MAX_EXECUTION_TIME.
+func (route *Route) query(ctx context.Context, vcursor VCursor) string {
+	if !vcursor.MaxExecutionTimeHint() {
+		return route.Query
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return route.Query
+	}
+	route.hintOnce.Do(route.initHintedQuery)
+	if !route.hinted {
+		return route.Query
+	}
+	// MAX_EXECUTION_TIME(0) disables the limit, so the query is given at
+	// least a millisecond once the deadline has passed.
+	remaining := max(time.Until(deadline).Milliseconds(), 1)
+	return route.hintPrefix + "MAX_EXECUTION_TIME(" + strconv.FormatInt(remaining, 10) + ")" + route.hintSuffix
+}
+
+// initHintedQuery formats the query of the route with a placeholder
+// MAX_EXECUTION_TIME hint, once, so that the statement is not formatted
+// again on every execution.
+func (route *Route) initHintedQuery() {
+	sel, ok := route.QueryStatement.(*sqlparser.Select)
+	if !ok || sel.Lock != sqlparser.NoLock {
+		return
+	}
+	for _, comment := range sel.Comments.GetComments() {
+		if strings.Contains(strings.ToUpper(comment), "MAX_EXECUTION_TIME") {
+			return
+		}
+	}
+	comments, err := sel.Comments.AddQueryHint(maxExecutionTimePlaceholder)
+	if err != nil {
+		return
+	}
+	// Only the comments change, so the statement of the plan is copied
+	// rather than cloned.
+	hinted := *sel
+	hinted.SetComments(comments)
+	// The hints come first, and the other hints of the query do not set
+	// MAX_EXECUTION_TIME, so the first placeholder is the one of the hint.
+	route.hintPrefix, route.hintSuffix, route.hinted = strings.Cut(sqlparser.String(&hinted), maxExecutionTimePlaceholder)
+}
+
 func getQueries(query string, bvs []map[string]*querypb.BindVariable) []*querypb.BoundQuery {
 	queries := make([]*querypb.BoundQuery, len(bvs))
 	for i, bv := range bvs {
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"maps"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vthash"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var chunkedRouteQueries = stats.NewCounter("ChunkedRouteQueries", "Count of queries executed in chunks because of the size of their IN lists")
+
+// ChunkableList is the list argument of an IN predicate of a route that the
+// route can split in chunks, with the type of the expression that the values
+// of the list are compared with.
+type ChunkableList struct {
+	Name string
+	Type evalengine.Type
+}
+
+// routeChunk is an execution of a route on its shards with a chunk of the
+// values of a list argument.
+type routeChunk struct {
+	rss []*srvtopo.ResolvedShard
+	bvs []map[string]*querypb.BindVariable
+}
+
+// chunkExecutions splits the executions of the route on its shards in chunks
+// that have at most the IN list chunk size of the vcursor values in their
+// largest chunkable list argument. A shard is executed at most once in every
+// chunk. It returns nil if no list argument needs to be split, or if the
+// values of the list can't be split safely.
+func (route *Route) chunkExecutions(vcursor VCursor, rss []*srvtopo.ResolvedShard, bvs []map[string]*querypb.BindVariable) []routeChunk {
+	size := vcursor.InListChunkSize()
+	if size <= 0 || len(route.ChunkableLists) == 0 {
+		return nil
+	}
+
+	sqlmode := evalengine.ParseSQLMode(vcursor.SQLMode())
+
+	var chunks []routeChunk
+	var chunked bool
+	for i, rs := range rss {
+		lists := route.chunkLists(bvs[i], size, sqlmode)
+		chunked = chunked || len(lists) > 1
+		for c, list := range lists {
+			if c == len(chunks) {
+				chunks = append(chunks, routeChunk{})
+			}
+			chunks[c].rss = append(chunks[c].rss, rs)
+			chunks[c].bvs = append(chunks[c].bvs, list)
+		}
+	}
+	if !chunked {
+		return nil
+	}
+
+	chunkedRouteQueries.Add(1)
+	return chunks
+}
+
+// chunkLists returns the bind variables of the executions of the route on a
+// shard: one for every chunk of its largest list argument with more than size
+// values, or only the given bind variables if no list needs to be split.
+func (route *Route) chunkLists(bv map[string]*querypb.BindVariable, size int, sqlmode evalengine.SQLMode) []map[string]*querypb.BindVariable {
+	var largest *ChunkableList
+	for i, list := range route.ChunkableLists {
+		values := bv[list.Name].GetValues()
+		if len(values) > size && (largest == nil || len(values) > len(bv[largest.Name].GetValues())) {
+			largest = &route.ChunkableLists[i]
+		}
+	}
+	if largest == nil {
+		return []map[string]*querypb.BindVariable{bv}
+	}
+
+	chunks, ok := chunkValues(bv[largest.Name].Values, largest.Type, size, sqlmode)
+	if !ok {
+		return []map[string]*querypb.BindVariable{bv}
+	}
+
+	lists := make([]map[string]*querypb.BindVariable, 0, len(chunks))
+	for _, values := range chunks {
+		list := maps.Clone(bv)
+		list[largest.Name] = &querypb.BindVariable{
+			Type:   querypb.Type_TUPLE,
+			Values: values,
+		}
+		lists = append(lists, list)
+	}
+	return lists
+}
+
+// chunkValues splits the values of a list in chunks of about size values.
+// The values that compare equal with the type typ are kept in the same chunk,
+// so that no row matches the values of two chunks and the results of the
+// chunks can be merged. Values are only split if they compare with the type
+// like the values of that type do: strings with a string, and numbers or
+// strings with a number.
+func chunkValues(values []*querypb.Value, typ evalengine.Type, size int, sqlmode evalengine.SQLMode) ([][]*querypb.Value, bool) {
+	var coerceTo evalengine.Type
+	var valid func(sqltypes.Type) bool
+	switch t := typ.Type(); {
+	case t == sqltypes.Unknown:
+		return nil, false
+	case sqltypes.IsNumber(t):
+		coerceTo = evalengine.NewType(sqltypes.Float64, typ.Collation())
+		valid = func(vt sqltypes.Type) bool {
+			return sqltypes.IsNumber(vt) || sqltypes.IsText(vt) || sqltypes.IsBinary(vt)
+		}
+	case sqltypes.IsText(t):
+		coerceTo = evalengine.NewType(sqltypes.VarChar, typ.Collation())
+		valid = func(vt sqltypes.Type) bool {
+			return sqltypes.IsText(vt) || sqltypes.IsBinary(vt)
+		}
+	default:
+		return nil, false
+	}
+
+	// group the equal values in the order they come in
+	var groups [][]*querypb.Value
+	index := make(map[vthash.Hash]int, len(values))
+	for _, pv := range values {
+		v := sqltypes.ProtoToValue(pv)
+		if !v.IsNull() && !valid(v.Type()) {
+			return nil, false
+		}
+		code, err := evalengine.Hash128(v, coerceTo, sqlmode)
+		if err != nil {
+			return nil, false
+		}
+
+		if g, ok := index[code]; ok {
+			groups[g] = append(groups[g], pv)
+			continue
+		}
+		index[code] = len(groups)
+		groups = append(groups, []*querypb.Value{pv})
+	}
+
+	var chunks [][]*querypb.Value
+	var chunk []*querypb.Value
+	for _, group := range groups {
+		chunk = append(chunk, group...)
+		if len(chunk) >= size {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, true
+}
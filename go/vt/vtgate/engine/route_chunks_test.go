@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestChunkValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    evalengine.Type
+		values []any
+		want   [][]string
+	}{{
+		name:   "integers",
+		typ:    evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+		values: []any{1, 2, 3, 4, 5},
+		want:   [][]string{{"1", "2"}, {"3", "4"}, {"5"}},
+	}, {
+		name:   "numbers equal to an integer",
+		typ:    evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+		values: []any{1, 2, "1.0", 3, 1},
+		want:   [][]string{{"1", "1.0", "1"}, {"2", "3"}},
+	}, {
+		name:   "case insensitive strings",
+		typ:    evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID),
+		values: []any{"a", "b", "A", "c", "B"},
+		want:   [][]string{{"a", "A"}, {"b", "B"}, {"c"}},
+	}, {
+		name:   "binary strings",
+		typ:    evalengine.NewType(sqltypes.VarChar, collations.CollationBinaryID),
+		values: []any{"a", "b", "A", "c", "B"},
+		want:   [][]string{{"a", "b"}, {"A", "c"}, {"B"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bv := sqltypes.TestBindVariable(tt.values)
+			chunks, ok := chunkValues(bv.Values, tt.typ, 2, 0)
+			require.True(t, ok)
+
+			var got [][]string
+			for _, chunk := range chunks {
+				var values []string
+				for _, v := range chunk {
+					values = append(values, string(v.Value))
+				}
+				got = append(got, values)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// numbers are not split when they are compared with strings, nor are
+	// values compared with a type we don't know
+	bv := sqltypes.TestBindVariable([]any{1, 2, 3})
+	_, ok := chunkValues(bv.Values, evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID), 2, 0)
+	assert.False(t, ok)
+	_, ok = chunkValues(bv.Values, evalengine.NewType(sqltypes.Unknown, collations.Unknown), 2, 0)
+	assert.False(t, ok)
+}
+
+func TestRouteChunks(t *testing.T) {
+	sel := NewRoute(
+		Scatter,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.ChunkableLists = []ChunkableList{{
+		Name: "vals",
+		Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+	}}
+	sel.OrderBy = []evalengine.OrderByParams{{
+		Col:             0,
+		WeightStringCol: -1,
+		Type:            evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+	}}
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	results := []*sqltypes.Result{
+		sqltypes.MakeTestResult(fields, "2", "4"),
+		sqltypes.MakeTestResult(fields, "1", "3"),
+		sqltypes.MakeTestResult(fields, "5"),
+	}
+	bvs := map[string]*querypb.BindVariable{
+		"vals": sqltypes.TestBindVariable([]any{1, 2, 3, 4, 5}),
+	}
+	want := sqltypes.MakeTestResult(fields, "1", "2", "3", "4", "5")
+
+	vc := &loggingVCursor{
+		shards:          []string{"-20", "20-"},
+		results:         results,
+		inListChunkSize: 2,
+	}
+	result, err := sel.TryExecute(t.Context(), vc, bvs, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.-20: dummy_select {vals: type:TUPLE values:{type:INT64 value:"1"} values:{type:INT64 value:"2"}} ks.20-: dummy_select {vals: type:TUPLE values:{type:INT64 value:"1"} values:{type:INT64 value:"2"}} false false`,
+		`ExecuteMultiShard ks.-20: dummy_select {vals: type:TUPLE values:{type:INT64 value:"3"} values:{type:INT64 value:"4"}} ks.20-: dummy_select {vals: type:TUPLE values:{type:INT64 value:"3"} values:{type:INT64 value:"4"}} false false`,
+		`ExecuteMultiShard ks.-20: dummy_select {vals: type:TUPLE values:{type:INT64 value:"5"}} ks.20-: dummy_select {vals: type:TUPLE values:{type:INT64 value:"5"}} false false`,
+	})
+	expectResult(t, result, want)
+
+	// the streamed chunks of every shard are merge sorted together
+	vc = &loggingVCursor{
+		shards: []string{"-20", "20-"},
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "2"),
+			sqltypes.MakeTestResult(fields, "1", "4"),
+			sqltypes.MakeTestResult(fields, "3"),
+			sqltypes.MakeTestResult(fields),
+			sqltypes.MakeTestResult(fields, "5"),
+			sqltypes.MakeTestResult(fields),
+		},
+		inListChunkSize:      2,
+		inListChunkSemaphore: semaphore.NewWeighted(6),
+	}
+	result, err = wrapStreamExecute(sel, vc, bvs, true)
+	require.NoError(t, err)
+	expectResult(t, result, want)
+	assert.Len(t, vc.log, 7)
+	// the streams are released once the query is done
+	assert.True(t, vc.inListChunkSemaphore.TryAcquire(6))
+
+	// without enough streams available, the chunks are executed one after
+	// the other and sorted in memory
+	vc = &loggingVCursor{
+		shards:               []string{"-20", "20-"},
+		results:              results,
+		inListChunkSize:      2,
+		inListChunkSemaphore: semaphore.NewWeighted(5),
+	}
+	result, err = wrapStreamExecute(sel, vc, bvs, true)
+	require.NoError(t, err)
+	expectResult(t, result, want)
+	assert.Len(t, vc.log, 4)
+
+	// without a chunk size, the list is sent in one query
+	vc = &loggingVCursor{
+		shards:  []string{"-20", "20-"},
+		results: results[:1],
+	}
+	_, err = sel.TryExecute(t.Context(), vc, bvs, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.-20: dummy_select {vals: type:TUPLE values:{type:INT64 value:"1"} values:{type:INT64 value:"2"} values:{type:INT64 value:"3"} values:{type:INT64 value:"4"} values:{type:INT64 value:"5"}} ks.20-: dummy_select {vals: type:TUPLE values:{type:INT64 value:"1"} values:{type:INT64 value:"2"} values:{type:INT64 value:"3"} values:{type:INT64 value:"4"} values:{type:INT64 value:"5"}} false false`,
+	})
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package engine
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -231,6 +233,82 @@ func TestSelectScatter(t *testing.T) {
 	expectResult(t, result, defaultSelectResult)
 }
 
+func TestSelectMaxExecutionTime(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	newRoute := func(query string) *Route {
+		stmt, err := parser.Parse(query)
+		require.NoError(t, err)
+		sel := NewRoute(Scatter, &vindexes.Keyspace{Name: "ks", Sharded: true}, sqlparser.String(stmt), "dummy_select_field")
+		sel.QueryStatement = stmt
+		return sel
+	}
+
+	// The deadline of the query has passed, so the hint is set to the
+	// shortest time MySQL accepts.
+	ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	testcases := []struct {
+		query string
+		want  string
+	}{{
+		query: "select id from t",
+		want:  "select /*+ MAX_EXECUTION_TIME(1) */ id from t",
+	}, {
+		query: "select /*+ SET_VAR(sort_buffer_size = 16M) */ /* comment */ id from t",
+		want:  "select /*+ SET_VAR(sort_buffer_size = 16M) MAX_EXECUTION_TIME(1) */ /* comment */ id from t",
+	}, {
+		// The query sets its own time limit.
+		query: "select /*+ MAX_EXECUTION_TIME(100) */ id from t",
+		want:  "select /*+ MAX_EXECUTION_TIME(100) */ id from t",
+	}, {
+		// MySQL does not time out locking reads.
+		query: "select id from t for update",
+		want:  "select id from t for update",
+	}, {
+		query: "select id from t union select id from u",
+		want:  "select id from t union select id from u",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			sel := newRoute(tc.query)
+			vc := &loggingVCursor{
+				shards:               []string{"-20", "20-"},
+				results:              []*sqltypes.Result{defaultSelectResult},
+				maxExecutionTimeHint: true,
+			}
+			_, err := sel.TryExecute(ctx, vc, map[string]*querypb.BindVariable{}, false)
+			require.NoError(t, err)
+			vc.ExpectLog(t, []string{
+				`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+				`ExecuteMultiShard ks.-20: ` + tc.want + ` {} ks.20-: ` + tc.want + ` {} false false`,
+			})
+
+			vc.Rewind()
+			err = sel.TryStreamExecute(ctx, vc, map[string]*querypb.BindVariable{}, false, func(*sqltypes.Result) error { return nil })
+			require.NoError(t, err)
+			vc.ExpectLog(t, []string{
+				`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+				`StreamExecuteMulti ` + tc.want + ` ks.-20: {} ks.20-: {} `,
+			})
+		})
+	}
+
+	// The hint is the time left before the deadline of the query.
+	sel := newRoute("select id from t")
+	vc := &loggingVCursor{maxExecutionTimeHint: true}
+	ctx, cancel = context.WithTimeout(t.Context(), 1500*time.Millisecond)
+	defer cancel()
+	var remaining int64
+	_, err := fmt.Sscanf(sel.query(ctx, vc), "select /*+ MAX_EXECUTION_TIME(%d) */ id from t", &remaining)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, remaining, int64(1500))
+	assert.Greater(t, remaining, int64(1000))
+
+	// Without a deadline, the query is sent as is.
+	assert.Equal(t, "select id from t", sel.query(t.Context(), vc))
+}
+
 func TestSelectEqualUnique(t *testing.T) {
 	vindex, _ := vindexes.CreateVindex("hash", "", nil)
 	sel := NewRoute(
@@ -51,7 +51,7 @@ func (sa *ScalarAggregate) GetFields(ctx context.Context, vcursor VCursor, bindV
 	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
 
-	_, fields, err := newAggregation(qr.Fields, sa.Aggregates, env, vcursor.ConnCollation())
+	_, fields, err := newAggregation(qr.Fields, sa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, sa.Aggregates))
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +71,14 @@ func (sa *ScalarAggregate) TryExecute(ctx context.Context, vcursor VCursor, bind
 	if err != nil {
 		return nil, err
 	}
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
+	if err := memory.holdRows(result.Rows); err != nil {
+		return nil, err
+	}
 	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
 
-	agg, fields, err := newAggregation(result.Fields, sa.Aggregates, env, vcursor.ConnCollation())
+	agg, fields, err := newAggregation(result.Fields, sa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, sa.Aggregates))
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +111,8 @@ func (sa *ScalarAggregate) TryStreamExecute(ctx context.Context, vcursor VCursor
 	var agg *aggregationState
 	var fields []*querypb.Field
 	fieldsSent := !wantfields
+	memory := &memoryTracker{vcursor: vcursor}
+	defer memory.release()
 
 	err := vcursor.StreamExecutePrimitive(ctx, sa.Input, bindVars, true, func(result *sqltypes.Result) error {
 		// as the underlying primitive call is not sync
@@ -116,7 +123,7 @@ func (sa *ScalarAggregate) TryStreamExecute(ctx context.Context, vcursor VCursor
 
 		if agg == nil && len(result.Fields) != 0 {
 			var err error
-			agg, fields, err = newAggregation(result.Fields, sa.Aggregates, env, vcursor.ConnCollation())
+			agg, fields, err = newAggregation(result.Fields, sa.Aggregates, env, vcursor.ConnCollation(), groupConcatMaxLen(vcursor, sa.Aggregates))
 			if err != nil {
 				return err
 			}
@@ -132,6 +139,9 @@ func (sa *ScalarAggregate) TryStreamExecute(ctx context.Context, vcursor VCursor
 			if err := agg.add(row); err != nil {
 				return err
 			}
+			if err := memory.resize(agg.heldMemory()); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/sqlparser"
 	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
 
 func TestEmptyRows(outer *testing.T) {
@@ -236,7 +237,7 @@ func TestScalarGroupConcatWithAggrOnEngine(t *testing.T) {
 					Opcode: AggregateGroupConcat,
 					Col:    0,
 					Alias:  "group_concat(c2)",
-					Func:   &sqlparser.GroupConcatExpr{Separator: ","},
+					Func:   &sqlparser.GroupConcatExpr{Separator: "','"},
 				}},
 				Input: fp,
 			}
@@ -398,7 +399,7 @@ func TestScalarGroupConcat(t *testing.T) {
 				Aggregates: []*AggregateParams{{
 					Opcode: AggregateGroupConcat,
 					Col:    0,
-					Func:   &sqlparser.GroupConcatExpr{Separator: ","},
+					Func:   &sqlparser.GroupConcatExpr{Separator: "','"},
 				}},
 				Input: fp,
 			}
@@ -420,3 +421,80 @@ func TestScalarGroupConcat(t *testing.T) {
 		})
 	}
 }
+
+func TestScalarGroupConcatFromArguments(t *testing.T) {
+	fields := sqltypes.MakeTestFields("a|b", "varchar|int64")
+	fields[0].Charset = uint32(collations.CollationUtf8mb4ID)
+	input := sqltypes.MakeTestResult(fields,
+		"x|1", "y|2", "x|1", "z|3", "null|4", "w|0")
+	collationEnv := collations.MySQL8()
+
+	tcases := []struct {
+		name        string
+		gcFunc      *sqlparser.GroupConcatExpr
+		params      *GroupConcatParams
+		sysVars     map[string]string
+		inputResult *sqltypes.Result
+		expected    string
+	}{{
+		name:     "multiple arguments",
+		gcFunc:   &sqlparser.GroupConcatExpr{},
+		params:   &GroupConcatParams{ArgCols: []int{0, 1}},
+		expected: "x1,y2,x1,z3,w0",
+	}, {
+		name:   "distinct",
+		gcFunc: &sqlparser.GroupConcatExpr{Distinct: true},
+		params: &GroupConcatParams{
+			ArgCols:      []int{0},
+			DistinctCols: []CheckCol{{Col: 0, Type: evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID), CollationEnv: collationEnv}},
+		},
+		expected: "x,y,z,w",
+	}, {
+		name:   "distinct, order by, separator and limit",
+		gcFunc: &sqlparser.GroupConcatExpr{Distinct: true, Separator: "'-'"},
+		params: &GroupConcatParams{
+			ArgCols:      []int{0},
+			DistinctCols: []CheckCol{{Col: 0, Type: evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID), CollationEnv: collationEnv}},
+			OrderBy:      evalengine.Comparison{{Col: 1, WeightStringCol: -1, Desc: true, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID), CollationEnv: collationEnv}},
+			Limit:        evalengine.NewLiteralInt(2),
+			Offset:       evalengine.NewLiteralInt(1),
+		},
+		expected: "y-x",
+	}, {
+		name:     "truncated to group_concat_max_len",
+		gcFunc:   &sqlparser.GroupConcatExpr{},
+		sysVars:  map[string]string{"group_concat_max_len": "4"},
+		expected: "x,y,",
+	}, {
+		name:        "truncated without splitting a character",
+		gcFunc:      &sqlparser.GroupConcatExpr{},
+		sysVars:     map[string]string{"group_concat_max_len": "4"},
+		inputResult: sqltypes.MakeTestResult(fields, "ñ|1", "ñ|2", "ñ|3"),
+		expected:    "ñ,",
+	}}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			inputResult := input
+			if tcase.inputResult != nil {
+				inputResult = tcase.inputResult
+			}
+			oa := &ScalarAggregate{
+				Aggregates: []*AggregateParams{{
+					Opcode:       AggregateGroupConcat,
+					Col:          0,
+					Func:         tcase.gcFunc,
+					GroupConcat:  tcase.params,
+					CollationEnv: collationEnv,
+				}},
+				TruncateColumnCount: 1,
+				Input:               &fakePrimitive{results: []*sqltypes.Result{inputResult}},
+			}
+			vc := &loggingVCursor{systemVariables: tcase.sysVars}
+			qr, err := oa.TryExecute(t.Context(), vc, nil, false)
+			require.NoError(t, err)
+			require.Len(t, qr.Rows, 1)
+			assert.Equal(t, tcase.expected, qr.Rows[0][0].ToString())
+		})
+	}
+}
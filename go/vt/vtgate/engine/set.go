@@ -20,19 +20,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strings"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/sysvars"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
@@ -83,6 +87,19 @@ type (
 		SupportSetVar     bool
 	}
 
+	// SysVarSetGlobal implements the SetOp interface and will execute SET GLOBAL
+	// on the tablets of type TabletType of the targeted shards, or only on the
+	// tablet TabletAlias when it is set. Without a target directive, it is
+	// executed on the primary of every shard in the keyspace.
+	SysVarSetGlobal struct {
+		Name              string
+		Keyspace          *vindexes.Keyspace
+		TargetDestination key.ShardDestination `json:",omitempty"`
+		TabletType        topodatapb.TabletType
+		TabletAlias       *topodatapb.TabletAlias `json:",omitempty"`
+		Expr              string
+	}
+
 	// SysVarSetAware implements the SetOp interface and will write the changes variable into the session
 	// The special part is that these settings change the sessions behaviour in different ways
 	SysVarSetAware struct {
@@ -387,6 +404,85 @@ func sqlModeChangedValue(qr *sqltypes.Result) (bool, sqltypes.Value, error) {
 	return changed, qr.Rows[0][1], nil
 }
 
+var _ SetOp = (*SysVarSetGlobal)(nil)
+
+// MarshalJSON provides the type to SetOp for plan json
+func (svsg *SysVarSetGlobal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string
+		SysVarSetGlobal
+	}{
+		Type:            "SysVarSetGlobal",
+		SysVarSetGlobal: *svsg,
+	})
+}
+
+// VariableName implements the SetOp interface method
+func (svsg *SysVarSetGlobal) VariableName() string {
+	return svsg.Name
+}
+
+// Execute implements the SetOp interface method
+func (svsg *SysVarSetGlobal) Execute(ctx context.Context, vcursor VCursor, env *evalengine.ExpressionEnv) error {
+	dest := svsg.TargetDestination
+	if dest == nil {
+		dest = key.DestinationAllShards{}
+	}
+	resolved, _, err := vcursor.ResolveDestinations(ctx, svsg.Keyspace.Name, nil, []key.ShardDestination{dest})
+	if err != nil {
+		return err
+	}
+	if svsg.TabletAlias != nil && len(resolved) != 1 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "SET GLOBAL targeted at tablet %s must resolve to a single shard, got %d", topoproto.TabletAliasString(svsg.TabletAlias), len(resolved))
+	}
+	// The tablet type comes from the plan rather than from the session, so
+	// that the statement always lands on the tablets the directive names.
+	rss := make([]*srvtopo.ResolvedShard, len(resolved))
+	for i, rs := range resolved {
+		target := rs.Target.CloneVT()
+		target.TabletType = svsg.TabletType
+		shard := *rs
+		shard.Target = target
+		rss[i] = &shard
+	}
+	// A global variable is not part of the session, so the statement never
+	// runs on a reserved or transactional connection.
+	ctx = context.WithValue(ctx, IgnoreReserveTxn, true)
+	if svsg.TabletAlias != nil {
+		ctx = context.WithValue(ctx, TargetTabletAlias, svsg.TabletAlias)
+	}
+
+	shards := make([]string, 0, len(rss))
+	queries := make([]*querypb.BoundQuery, len(rss))
+	for i, rs := range rss {
+		shards = append(shards, rs.Target.Shard)
+		queries[i] = &querypb.BoundQuery{
+			Sql:           fmt.Sprintf("set @@global.%s = %s", svsg.Name, svsg.Expr),
+			BindVariables: env.BindVars,
+		}
+	}
+	_, errs := vcursor.ExecuteMultiShard(ctx, nil /*primitive*/, rss, queries, false /*rollbackOnError*/, false /*canAutocommit*/, false /*fetchLastInsertID*/)
+	err = vterrors.Aggregate(errs)
+
+	attrs := []slog.Attr{
+		slog.String("user", callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx))),
+		slog.String("variable", svsg.Name),
+		slog.String("value", svsg.Expr),
+		slog.String("keyspace", svsg.Keyspace.Name),
+		slog.Any("shards", shards),
+		slog.String("tablet_type", topoproto.TabletTypeLString(svsg.TabletType)),
+	}
+	if svsg.TabletAlias != nil {
+		attrs = append(attrs, slog.String("tablet", topoproto.TabletAliasString(svsg.TabletAlias)))
+	}
+	if err != nil {
+		log.Warn("SET GLOBAL through vtgate failed", append(attrs, slog.Any("error", err))...)
+		return err
+	}
+	log.Info("SET GLOBAL executed through vtgate", attrs...)
+	return nil
+}
+
 var _ SetOp = (*SysVarSetAware)(nil)
 
 // MarshalJSON marshals all the json
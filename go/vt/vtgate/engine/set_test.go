@@ -17,12 +17,14 @@ limitations under the License.
 package engine
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -32,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 func TestSetSystemVariableAsString(t *testing.T) {
@@ -83,6 +86,7 @@ func TestSetTable(t *testing.T) {
 		execErr          error
 		mysqlVersion     string
 		disableSetVar    bool
+		tabletType       topodatapb.TabletType
 	}
 
 	ks := &vindexes.Keyspace{Name: "ks", Sharded: true}
@@ -155,6 +159,51 @@ func TestSetTable(t *testing.T) {
 			`ResolveDestinations ks [] Destinations:DestinationAnyShard()`,
 			`ExecuteMultiShard ks.-20: select 1 from dual where @@x = dummy_expr {} false false`,
 		},
+	}, {
+		testName: "sysvar set global on all primaries",
+		setOps: []SetOp{
+			&SysVarSetGlobal{
+				Name:       "x",
+				Keyspace:   ks,
+				TabletType: topodatapb.TabletType_PRIMARY,
+				Expr:       "42",
+			},
+		},
+		tabletType: topodatapb.TabletType_REPLICA,
+		expectedQueryLog: []string{
+			`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+			`ExecuteMultiShard ks.-20: set @@global.x = 42 {} ks.20-: set @@global.x = 42 {} false false`,
+		},
+	}, {
+		testName: "sysvar set global on targeted shard",
+		setOps: []SetOp{
+			&SysVarSetGlobal{
+				Name:              "x",
+				Keyspace:          ks,
+				TargetDestination: key.DestinationShard("20-"),
+				TabletType:        topodatapb.TabletType_REPLICA,
+				Expr:              "'ON'",
+			},
+		},
+		expectedQueryLog: []string{
+			`ResolveDestinations ks [] Destinations:DestinationShard(20-)`,
+			`ExecuteMultiShard ks.DestinationShard(20-): set @@global.x = 'ON' {} false false`,
+		},
+	}, {
+		testName: "sysvar set global on a tablet needs a single shard",
+		setOps: []SetOp{
+			&SysVarSetGlobal{
+				Name:        "x",
+				Keyspace:    ks,
+				TabletType:  topodatapb.TabletType_REPLICA,
+				TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				Expr:        "42",
+			},
+		},
+		expectedQueryLog: []string{
+			`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		},
+		expectedError: "SET GLOBAL targeted at tablet zone1-0000000100 must resolve to a single shard, got 2",
 	}, {
 		testName: "sysvar check and error",
 		setOps: []SetOp{
@@ -578,6 +627,8 @@ func TestSetTable(t *testing.T) {
 				multiShardErrs: []error{tc.execErr},
 				disableSetVar:  tc.disableSetVar,
 				parser:         parser,
+
+				resolvedTargetTabletType: tc.tabletType,
 			}
 			_, err = set.TryExecute(t.Context(), vc, map[string]*querypb.BindVariable{}, false)
 			if tc.expectedError == "" {
@@ -624,3 +675,38 @@ func TestSysVarSetErr(t *testing.T) {
 	require.EqualError(t, err, "error")
 	vc.ExpectLog(t, expectedQueryLog)
 }
+
+func TestSysVarSetGlobalTarget(t *testing.T) {
+	alias := &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}
+	set := &Set{
+		Ops: []SetOp{
+			&SysVarSetGlobal{
+				Name:              "x",
+				Keyspace:          &vindexes.Keyspace{Name: "ks", Sharded: true},
+				TargetDestination: key.DestinationShard("20-"),
+				TabletType:        topodatapb.TabletType_REPLICA,
+				TabletAlias:       alias,
+				Expr:              "42",
+			},
+		},
+		Input: &SingleRow{},
+	}
+	var gotTarget *querypb.Target
+	var gotAlias any
+	vc := &loggingVCursor{
+		shards:                   []string{"-20", "20-"},
+		resolvedTargetTabletType: topodatapb.TabletType_PRIMARY,
+		onExecuteMultiShardFn: func(ctx context.Context, _ Primitive, rss []*srvtopo.ResolvedShard, _ []*querypb.BoundQuery, _, _ bool) {
+			gotTarget = rss[0].Target
+			gotAlias = ctx.Value(TargetTabletAlias)
+		},
+	}
+	_, err := set.TryExecute(t.Context(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationShard(20-)`,
+		`ExecuteMultiShard ks.DestinationShard(20-): set @@global.x = 42 {} false false`,
+	})
+	assert.Equal(t, topodatapb.TabletType_REPLICA, gotTarget.TabletType)
+	assert.Equal(t, alias, gotAlias)
+}
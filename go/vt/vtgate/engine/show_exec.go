@@ -33,6 +33,8 @@ type ShowExec struct {
 
 	Command    sqlparser.ShowCommandType
 	ShowFilter *sqlparser.ShowFilter
+	// Full is set for SHOW FULL PROCESSLIST.
+	Full bool
 }
 
 func (s *ShowExec) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*query.BindVariable) (*sqltypes.Result, error) {
@@ -45,6 +47,9 @@ func (s *ShowExec) GetFields(ctx context.Context, vcursor VCursor, bindVars map[
 }
 
 func (s *ShowExec) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*query.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if s.Command == sqlparser.ProcessList {
+		return vcursor.ShowProcessList(ctx, s.Full)
+	}
 	return vcursor.ShowExec(ctx, s.Command, s.ShowFilter)
 }
 
@@ -61,6 +66,9 @@ func (s *ShowExec) description() PrimitiveDescription {
 	if s.ShowFilter != nil {
 		other["Filter"] = sqlparser.String(s.ShowFilter)
 	}
+	if s.Full {
+		other["Full"] = true
+	}
 	return PrimitiveDescription{
 		OperatorType: "ShowExec",
 		Variant:      s.Command.ToString(),
@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+type (
+	// Window is a primitive that evaluates window functions over all the
+	// rows of its input. The values of the window functions are returned
+	// first in every row, followed by the columns of the input.
+	Window struct {
+		Input Primitive
+		Funcs []*WindowParams
+	}
+
+	// WindowParams specifies a window function, and the window it is
+	// evaluated over.
+	WindowParams struct {
+		Opcode opcode.WindowOpcode
+		// Col is the column of the argument of LAG, LEAD, FIRST_VALUE and
+		// LAST_VALUE, and DefaultCol the column of the default value of
+		// LAG and LEAD. They are -1 when the function has no such argument.
+		Col        int
+		DefaultCol int
+		// Offset is the number of rows LAG and LEAD look behind or ahead.
+		Offset int64
+
+		PartitionBy evalengine.Comparison
+		OrderBy     evalengine.Comparison
+		// Frame is the frame of FIRST_VALUE and LAST_VALUE. It is nil for the
+		// default frame.
+		Frame *evalengine.WindowFrame
+
+		Alias string
+		Type  evalengine.Type
+	}
+)
+
+// String returns a string. Used for plan descriptions
+func (wp *WindowParams) String() string {
+	var args []string
+	if wp.Col >= 0 {
+		args = append(args, strconv.Itoa(wp.Col))
+	}
+	switch wp.Opcode {
+	case opcode.WindowLag, opcode.WindowLead:
+		args = append(args, strconv.FormatInt(wp.Offset, 10))
+		if wp.DefaultCol >= 0 {
+			args = append(args, strconv.Itoa(wp.DefaultCol))
+		}
+	}
+
+	var window []string
+	if len(wp.PartitionBy) > 0 {
+		window = append(window, "partition by "+GenericJoin(wp.PartitionBy, partitionByParamsToString))
+	}
+	if len(wp.OrderBy) > 0 {
+		window = append(window, "order by "+GenericJoin(wp.OrderBy, orderByParamsToString))
+	}
+	if wp.Frame != nil {
+		window = append(window, wp.Frame.String())
+	}
+	return fmt.Sprintf("%s(%s) over (%s)", wp.Opcode.String(), strings.Join(args, ", "), strings.Join(window, " "))
+}
+
+func partitionByParamsToString(i any) string {
+	obp := i.(evalengine.OrderByParams)
+	if obp.WeightStringCol != -1 && obp.WeightStringCol != obp.Col {
+		return fmt.Sprintf("(%d|%d)", obp.Col, obp.WeightStringCol)
+	}
+	return strconv.Itoa(obp.Col)
+}
+
+// TryExecute satisfies the Primitive interface.
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	input, err := vcursor.ExecutePrimitive(ctx, w.Input, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.evaluate(input.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: w.fields(input.Fields), Rows: rows}, nil
+}
+
+// TryStreamExecute satisfies the Primitive interface.
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	var mu sync.Mutex
+	var rows []sqltypes.Row
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(qr.Fields) != 0 {
+			if err := callback(&sqltypes.Result{Fields: w.fields(qr.Fields)}); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, qr.Rows...)
+		if vcursor.ExceedsMaxMemoryRows(len(rows)) {
+			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	out, err := w.evaluate(rows)
+	if err != nil {
+		return err
+	}
+	return callback(&sqltypes.Result{Rows: out})
+}
+
+// GetFields satisfies the Primitive interface.
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: w.fields(qr.Fields)}, nil
+}
+
+// Inputs returns the input to the window
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func (w *Window) fields(input []*querypb.Field) []*querypb.Field {
+	if input == nil {
+		return nil
+	}
+	fields := make([]*querypb.Field, 0, len(w.Funcs)+len(input))
+	for _, wp := range w.Funcs {
+		fields = append(fields, wp.Type.ToField(wp.Alias))
+	}
+	return append(fields, input...)
+}
+
+// evaluate returns the rows with the values of the window functions in front.
+func (w *Window) evaluate(rows []sqltypes.Row) (out []sqltypes.Row, err error) {
+	defer evalengine.PanicHandler(&err)
+
+	out = make([]sqltypes.Row, len(rows))
+	for i, row := range rows {
+		out[i] = make(sqltypes.Row, len(w.Funcs), len(w.Funcs)+len(row))
+		out[i] = append(out[i], row...)
+	}
+
+	order := make([]int, len(rows))
+	partition := make([]sqltypes.Row, 0, len(rows))
+	for f, wp := range w.Funcs {
+		// sort the rows by partition, and by the ORDER BY of the window within
+		// every partition, keeping the order of the input between peers
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			if cmp := wp.PartitionBy.Compare(rows[a], rows[b]); cmp != 0 {
+				return cmp
+			}
+			return wp.OrderBy.Compare(rows[a], rows[b])
+		})
+
+		for start := 0; start < len(order); {
+			end := start + 1
+			for end < len(order) && wp.PartitionBy.Compare(rows[order[start]], rows[order[end]]) == 0 {
+				end++
+			}
+
+			partition = partition[:0]
+			for _, idx := range order[start:end] {
+				partition = append(partition, rows[idx])
+			}
+			p, err := evalengine.NewWindowPartition(partition, wp.OrderBy)
+			if err != nil {
+				return nil, err
+			}
+			for i, idx := range order[start:end] {
+				out[idx][f] = wp.evaluate(p, i)
+			}
+			start = end
+		}
+	}
+	return out, nil
+}
+
+// evaluate returns the value of the window function for a row of the partition.
+func (wp *WindowParams) evaluate(p *evalengine.WindowPartition, row int) sqltypes.Value {
+	switch wp.Opcode {
+	case opcode.WindowRowNumber:
+		return sqltypes.NewInt64(p.RowNumber(row))
+	case opcode.WindowRank:
+		return sqltypes.NewInt64(p.Rank(row))
+	case opcode.WindowDenseRank:
+		return sqltypes.NewInt64(p.DenseRank(row))
+	case opcode.WindowLag, opcode.WindowLead:
+		offset := wp.Offset
+		if wp.Opcode == opcode.WindowLag {
+			offset = -offset
+		}
+		if r, ok := p.Offset(row, offset); ok {
+			return r[wp.Col]
+		}
+		if wp.DefaultCol >= 0 {
+			return p.Rows[row][wp.DefaultCol]
+		}
+		return sqltypes.NULL
+	case opcode.WindowFirstValue, opcode.WindowLastValue:
+		start, end := p.Frame(row, wp.Frame)
+		if start == end {
+			return sqltypes.NULL
+		}
+		if wp.Opcode == opcode.WindowFirstValue {
+			return p.Rows[start][wp.Col]
+		}
+		return p.Rows[end-1][wp.Col]
+	default:
+		panic(vterrors.VT13001(fmt.Sprintf("unexpected window function %s", wp.Opcode.String())))
+	}
+}
+
+func (w *Window) description() PrimitiveDescription {
+	funcs := make([]string, 0, len(w.Funcs))
+	for _, wp := range w.Funcs {
+		funcs = append(funcs, wp.String())
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other: map[string]any{
+			"Functions": strings.Join(funcs, ", "),
+		},
+	}
+}
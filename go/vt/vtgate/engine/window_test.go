@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func windowOrderBy(col int, desc bool) evalengine.OrderByParams {
+	return evalengine.OrderByParams{
+		Col:             col,
+		WeightStringCol: -1,
+		Desc:            desc,
+		Type:            evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+		CollationEnv:    collations.MySQL8(),
+	}
+}
+
+func windowFuncs() []*WindowParams {
+	intType := evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)
+	partitionBy := evalengine.Comparison{windowOrderBy(0, false)}
+	orderBy := evalengine.Comparison{windowOrderBy(1, false)}
+	return []*WindowParams{{
+		Opcode:      opcode.WindowRowNumber,
+		Col:         -1,
+		DefaultCol:  -1,
+		PartitionBy: partitionBy,
+		OrderBy:     orderBy,
+		Alias:       "rn",
+		Type:        intType,
+	}, {
+		Opcode:      opcode.WindowRank,
+		Col:         -1,
+		DefaultCol:  -1,
+		PartitionBy: partitionBy,
+		OrderBy:     orderBy,
+		Alias:       "rnk",
+		Type:        intType,
+	}, {
+		Opcode:     opcode.WindowDenseRank,
+		Col:        -1,
+		DefaultCol: -1,
+		OrderBy:    evalengine.Comparison{windowOrderBy(1, true)},
+		Alias:      "drnk",
+		Type:       intType,
+	}, {
+		Opcode:      opcode.WindowLag,
+		Col:         1,
+		DefaultCol:  2,
+		Offset:      1,
+		PartitionBy: partitionBy,
+		OrderBy:     orderBy,
+		Alias:       "prev",
+		Type:        intType,
+	}, {
+		Opcode:      opcode.WindowLead,
+		Col:         1,
+		DefaultCol:  -1,
+		Offset:      2,
+		PartitionBy: partitionBy,
+		OrderBy:     orderBy,
+		Alias:       "next2",
+		Type:        intType,
+	}, {
+		Opcode:      opcode.WindowLastValue,
+		Col:         1,
+		DefaultCol:  -1,
+		PartitionBy: partitionBy,
+		OrderBy:     orderBy,
+		Frame: &evalengine.WindowFrame{
+			Unit:  evalengine.FrameRows,
+			Start: evalengine.WindowFrameBound{Type: evalengine.FrameCurrentRow},
+			End:   evalengine.WindowFrameBound{Type: evalengine.FrameFollowing, Offset: 1},
+		},
+		Alias: "lv",
+		Type:  intType,
+	}}
+}
+
+func TestWindowExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields("grp|val|dflt", "int64|int64|int64")
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"2|10|-1",
+			"1|30|-1",
+			"1|10|-1",
+			"2|20|-1",
+			"1|20|-1",
+			"1|20|-1",
+		)},
+	}
+
+	w := &Window{Input: fp, Funcs: windowFuncs()}
+	result, err := w.TryExecute(t.Context(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("rn|rnk|drnk|prev|next2|lv|grp|val|dflt", "int64|int64|int64|int64|int64|int64|int64|int64|int64"),
+		"1|1|3|-1|null|20|2|10|-1",
+		"4|4|1|20|null|30|1|30|-1",
+		"1|1|3|-1|20|20|1|10|-1",
+		"2|2|2|10|null|20|2|20|-1",
+		"2|2|2|10|30|20|1|20|-1",
+		"3|2|2|20|null|30|1|20|-1",
+	)
+	utils.MustMatch(t, wantResult.Rows, result.Rows)
+	assert.Equal(t, "rn", result.Fields[0].Name)
+	assert.Equal(t, "grp", result.Fields[len(w.Funcs)].Name)
+}
+
+func TestWindowStreamExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields("grp|val|dflt", "int64|int64|int64")
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1|20|0", "2|30|0", "1|10|0"),
+		},
+	}
+
+	w := &Window{Input: fp, Funcs: windowFuncs()[:1]}
+	result, err := wrapStreamExecute(w, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	want := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("rn|grp|val|dflt", "int64|int64|int64|int64"),
+		"2|1|20|0",
+		"1|2|30|0",
+		"1|1|10|0",
+	)
+	utils.MustMatch(t, want.Rows, result.Rows)
+	require.Len(t, result.Fields, 4)
+	assert.Equal(t, "rn", result.Fields[0].Name)
+}
+
+func TestWindowDescription(t *testing.T) {
+	w := &Window{Funcs: windowFuncs()}
+	assert.Equal(t,
+		"row_number() over (partition by 0 order by 1 ASC), "+
+			"rank() over (partition by 0 order by 1 ASC), "+
+			"dense_rank() over (order by 1 DESC), "+
+			"lag(1, 1, 2) over (partition by 0 order by 1 ASC), "+
+			"lead(1, 2) over (partition by 0 order by 1 ASC), "+
+			"last_value(1) over (partition by 0 order by 1 ASC rows between current row and 1 following)",
+		w.description().Other["Functions"])
+}
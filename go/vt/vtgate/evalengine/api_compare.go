@@ -24,6 +24,7 @@ import (
 	"strconv"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
 	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -207,7 +208,42 @@ func (obp *OrderByParams) String() string {
 	return val
 }
 
+// ComparableCollation returns true if the text values returned by MySQL can
+// be compared in vtgate with the given collation. MySQL returns text in the
+// charset of the connection, which is utf8mb4, so only the collations of
+// utf8mb4 and of its subset utf8mb3 apply to the values as they are returned.
+func ComparableCollation(coll collations.ID) bool {
+	c := colldata.Lookup(coll)
+	if c == nil {
+		return false
+	}
+	switch c.Charset().(type) {
+	case charset.Charset_utf8mb4, charset.Charset_utf8mb3, charset.Charset_binary:
+		return true
+	default:
+		return false
+	}
+}
+
+// sortsByWeightString returns true if the values must be compared with the
+// weight strings MySQL computed for them, because their collation cannot be
+// applied in vtgate to the values as MySQL returned them.
+func (obp *OrderByParams) sortsByWeightString() bool {
+	return obp.WeightStringCol != -1 && obp.Type.Type() != sqltypes.Unknown && sqltypes.IsText(obp.Type.Type()) && !ComparableCollation(obp.Type.Collation())
+}
+
 func (obp *OrderByParams) Compare(r1, r2 []sqltypes.Value) int {
+	if obp.sortsByWeightString() {
+		cmp, err := NullsafeCompare(r1[obp.WeightStringCol], r2[obp.WeightStringCol], obp.CollationEnv, collations.CollationBinaryID, nil)
+		if err != nil {
+			panic(err)
+		}
+		if obp.Desc {
+			cmp = -cmp
+		}
+		return cmp
+	}
+
 	v1 := r1[obp.Col]
 	v2 := r2[obp.Col]
 	cmp := v1.TinyWeightCmp(v2)
@@ -239,6 +275,9 @@ func (obp *OrderByParams) Compare(r1, r2 []sqltypes.Value) int {
 func (cmp Comparison) tinyWeighters(fields []*querypb.Field) []tinyWeighter {
 	weights := make([]tinyWeighter, 0, len(cmp))
 	for _, c := range cmp {
+		if c.sortsByWeightString() {
+			continue
+		}
 		if apply := TinyWeighter(fields[c.Col], c.Type.Collation()); apply != nil {
 			weights = append(weights, tinyWeighter{c.Col, apply})
 		}
@@ -1552,6 +1552,86 @@ func TestMerger(t *testing.T) {
 	})
 }
 
+func TestMergerCollations(t *testing.T) {
+	collationEnv := collations.MySQL8()
+	merge := func(t *testing.T, cmp Comparison, streams [][]sqltypes.Row) []string {
+		m := &Merger{Compare: cmp}
+		cursors := make([]int, len(streams))
+		for i, s := range streams {
+			fields := make([]*querypb.Field, len(s[0]))
+			for j, v := range s[0] {
+				fields[j] = &querypb.Field{Type: v.Type()}
+			}
+			cmp.ApplyTinyWeights(&sqltypes.Result{Fields: fields, Rows: s})
+			m.Push(s[0], i)
+			cursors[i] = 1
+		}
+		m.Init()
+
+		var result []string
+		for m.Len() > 0 {
+			row, src := m.Peek()
+			result = append(result, row[0].ToString())
+			if cursors[src] < len(streams[src]) {
+				m.ReplaceMin(streams[src][cursors[src]], src)
+				cursors[src]++
+			} else {
+				m.Pop()
+			}
+		}
+		return result
+	}
+	rows := func(values ...string) []sqltypes.Row {
+		var rows []sqltypes.Row
+		for _, v := range values {
+			rows = append(rows, sqltypes.Row{sqltypes.NewVarChar(v)})
+		}
+		return rows
+	}
+	comparison := func(collation string) Comparison {
+		return Comparison{{
+			Col:             0,
+			WeightStringCol: -1,
+			Type:            NewType(sqltypes.VarChar, collationEnv.LookupByName(collation)),
+			CollationEnv:    collationEnv,
+		}}
+	}
+
+	t.Run("utf8mb4_0900_as_cs", func(t *testing.T) {
+		streams := [][]sqltypes.Row{rows("a", "b"), rows("A", "B")}
+		assert.Equal(t, []string{"a", "A", "b", "B"}, merge(t, comparison("utf8mb4_0900_as_cs"), streams))
+	})
+
+	t.Run("utf8mb4_0900_ai_ci", func(t *testing.T) {
+		streams := [][]sqltypes.Row{rows("á", "c"), rows("B", "d")}
+		assert.Equal(t, []string{"á", "B", "c", "d"}, merge(t, comparison("utf8mb4_0900_ai_ci"), streams))
+	})
+
+	t.Run("utf8mb4_ja_0900_as_cs", func(t *testing.T) {
+		// the Japanese collation sorts kanji in the order of JIS X 0208, and not
+		// in the order of their code points
+		streams := [][]sqltypes.Row{rows("a", "亜"), rows("あ", "一")}
+		assert.Equal(t, []string{"a", "あ", "亜", "一"}, merge(t, comparison("utf8mb4_ja_0900_as_cs"), streams))
+		assert.Equal(t, []string{"a", "あ", "一", "亜"}, merge(t, comparison("utf8mb4_0900_as_cs"), streams))
+	})
+
+	t.Run("sjis_japanese_ci sorts with the weight strings", func(t *testing.T) {
+		// MySQL returns the values in utf8mb4, so vtgate cannot compare them with
+		// the sjis collation and must use the weight strings MySQL computed
+		row := func(v string, weight byte) sqltypes.Row {
+			return sqltypes.Row{sqltypes.NewVarChar(v), sqltypes.NewVarBinary(string([]byte{weight}))}
+		}
+		cmp := Comparison{{
+			Col:             0,
+			WeightStringCol: 1,
+			Type:            NewType(sqltypes.VarChar, collationEnv.LookupByName("sjis_japanese_ci")),
+			CollationEnv:    collationEnv,
+		}}
+		streams := [][]sqltypes.Row{{row("b", 1), row("a", 3)}, {row("c", 2)}}
+		assert.Equal(t, []string{"b", "c", "a"}, merge(t, cmp, streams))
+	})
+}
+
 // generateSortedStreams creates k sorted streams of int64 rows, each with n rows.
 func generateSortedStreams(k, n int) [][]sqltypes.Row {
 	streams := make([][]sqltypes.Row, k)
@@ -250,3 +250,65 @@ func nullsafeHashcode128Default(hash *vthash.Hasher, v sqltypes.Value, collation
 	}
 	return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected type %v", coerceTo)
 }
+
+// Hash128 returns the 128-bit hashcode of a value compared as a value of the
+// given type, which is the same for two values that are considered equal by
+// `NullsafeCompare` with the collation of the type. All NULL values have the
+// same hashcode.
+func Hash128(v sqltypes.Value, typ Type, sqlmode SQLMode) (vthash.Hash, error) {
+	hash := vthash.New()
+	if err := NullsafeHashcode128(&hash, v, typ.Collation(), typ.Type(), sqlmode, typ.Values()); err != nil {
+		return vthash.Hash{}, err
+	}
+	return hash.Sum128(), nil
+}
+
+// TupleHasher computes the 128-bit hashcodes of tuples of values, such as the
+// rows of DISTINCT, GROUP BY and set operations or the keys of a hash join.
+// Two tuples have the same hashcode when each of their values has the same
+// hashcode according to `Hash128`. A TupleHasher is not safe for concurrent use.
+type TupleHasher struct {
+	tuple   vthash.Hasher
+	value   vthash.Hasher
+	sqlmode SQLMode
+}
+
+// NewTupleHasher returns a TupleHasher that hashes the values with the given
+// SQL mode.
+func NewTupleHasher(sqlmode SQLMode) *TupleHasher {
+	return &TupleHasher{
+		tuple:   vthash.New(),
+		value:   vthash.New(),
+		sqlmode: sqlmode,
+	}
+}
+
+// Add adds the next value of the tuple, compared as a value of the given type.
+// When it returns an error, the value isn't added to the tuple, so that the
+// caller can add it again, e.g. as a weight string.
+func (th *TupleHasher) Add(v sqltypes.Value, typ Type) error {
+	// Every value is hashed on its own, and the tuple is the hash of the
+	// hashcodes of its values, so that the boundaries between the values
+	// are part of the hashcode of the tuple: ('a', 'bc') and ('ab', 'c')
+	// must not be equal.
+	th.value.Reset()
+	if err := NullsafeHashcode128(&th.value, v, typ.Collation(), typ.Type(), th.sqlmode, typ.Values()); err != nil {
+		return err
+	}
+	code := th.value.Sum128()
+	_, _ = th.tuple.Write(code[:])
+	return nil
+}
+
+// Sum128 returns the hashcode of the tuple of the values added since the last
+// call, and resets the hasher for the next tuple.
+func (th *TupleHasher) Sum128() vthash.Hash {
+	code := th.tuple.Sum128()
+	th.tuple.Reset()
+	return code
+}
+
+// Reset discards the values added since the last call to Sum128.
+func (th *TupleHasher) Reset() {
+	th.tuple.Reset()
+}
@@ -252,3 +252,67 @@ func coerceTo(v1, v2 sqltypes.Type) (sqltypes.Type, error) {
 	}
 	return 0, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "types does not support hashcode yet: %v vs %v", v1, v2)
 }
+
+func TestHash128(t *testing.T) {
+	ci := NewType(sqltypes.VarChar, collations.MySQL8().LookupByName("utf8mb4_general_ci"))
+	h1, err := Hash128(sqltypes.NewVarChar("Monkey"), ci, 0)
+	require.NoError(t, err)
+	h2, err := Hash128(sqltypes.NewVarChar("MONKEY"), ci, 0)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	bin := NewType(sqltypes.VarBinary, collations.CollationBinaryID)
+	h1, err = Hash128(sqltypes.NewVarBinary("Monkey"), bin, 0)
+	require.NoError(t, err)
+	h2, err = Hash128(sqltypes.NewVarBinary("MONKEY"), bin, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h2)
+
+	h1, err = Hash128(sqltypes.NULL, ci, 0)
+	require.NoError(t, err)
+	h2, err = Hash128(sqltypes.NULL, NewType(sqltypes.Int64, collations.CollationBinaryID), 0)
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	_, err = Hash128(sqltypes.NewVarChar("monkey"), NewType(sqltypes.VarChar, collations.Unknown), 0)
+	assert.ErrorIs(t, err, UnsupportedCollationHashError)
+}
+
+func TestTupleHasher(t *testing.T) {
+	bin := NewType(sqltypes.VarBinary, collations.CollationBinaryID)
+	hashTuple := func(th *TupleHasher, values ...sqltypes.Value) vthash.Hash {
+		for _, v := range values {
+			require.NoError(t, th.Add(v, bin))
+		}
+		return th.Sum128()
+	}
+
+	th := NewTupleHasher(0)
+	// the bytes the binary collation hashes between two values
+	sep := "\xcc\xcc\xbb\xbb\xbb\xbb\x00\x00\x00\x00"
+	assert.NotEqual(t,
+		hashTuple(th, sqltypes.NewVarBinary("a"+sep+"b"), sqltypes.NewVarBinary("c")),
+		hashTuple(th, sqltypes.NewVarBinary("a"), sqltypes.NewVarBinary("b"+sep+"c")),
+	)
+	assert.NotEqual(t,
+		hashTuple(th, sqltypes.NewVarBinary("a"), sqltypes.NULL),
+		hashTuple(th, sqltypes.NULL, sqltypes.NewVarBinary("a")),
+	)
+	assert.Equal(t,
+		hashTuple(th, sqltypes.NewVarBinary("a"), sqltypes.NULL),
+		hashTuple(th, sqltypes.NewVarBinary("a"), sqltypes.NULL),
+	)
+
+	// a value that can't be hashed isn't added to the tuple
+	want := hashTuple(th, sqltypes.NewVarBinary("a"), sqltypes.NewVarBinary("b"))
+	require.NoError(t, th.Add(sqltypes.NewVarBinary("a"), bin))
+	err := th.Add(sqltypes.NewVarChar("b"), NewType(sqltypes.VarChar, collations.Unknown))
+	require.ErrorIs(t, err, UnsupportedCollationHashError)
+	require.NoError(t, th.Add(sqltypes.NewVarBinary("b"), bin))
+	assert.Equal(t, want, th.Sum128())
+
+	want = hashTuple(th)
+	require.NoError(t, th.Add(sqltypes.NewVarBinary("a"), bin))
+	th.Reset()
+	assert.Equal(t, want, th.Sum128())
+}
@@ -19,7 +19,6 @@ package evalengine
 import (
 	"errors"
 	"math"
-	"math/big"
 	"unicode/utf8"
 
 	"vitess.io/vitess/go/hack"
@@ -159,16 +158,27 @@ func parseHexNumber(val []byte) ([]byte, error) {
 	return parseHexLiteral(val[1:])
 }
 
+// parseBitNum parses a bit literal of the form 0b0101. Like in MySQL, the
+// literal is a binary string with as many bytes as needed to hold all its
+// digits, including the leading zeroes: an empty literal is the empty string
+// and b'000000001' is two bytes long.
 func parseBitNum(val []byte) ([]byte, error) {
-	if val[0] != '0' || val[1] != 'b' {
+	if len(val) < 2 || val[0] != '0' || val[1] != 'b' {
 		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "malformed Bit literal: %q (missing 0b prefix)", val)
 	}
-	var i big.Int
-	_, ok := i.SetString(hack.String(val)[2:], 2)
-	if !ok {
-		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "malformed Bit literal: %q (not base 2)", val)
+	digits := val[2:]
+	raw := make([]byte, (len(digits)+7)/8)
+	for i, d := range digits {
+		switch d {
+		case '0':
+		case '1':
+			pos := len(digits) - 1 - i
+			raw[len(raw)-1-pos/8] |= 1 << (pos % 8)
+		default:
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "malformed Bit literal: %q (not base 2)", val)
+		}
 	}
-	return i.Bytes(), nil
+	return raw, nil
 }
 
 func NewLiteralBinary(val []byte) *Literal {
@@ -225,7 +235,7 @@ func NewColumn(offset int, typ Type, original sqlparser.Expr) *Column {
 		Type:              typ.Type(),
 		Size:              typ.size,
 		Scale:             typ.scale,
-		Collation:         typedCoercionCollation(typ.Type(), typ.Collation()),
+		Collation:         columnCollation(typ.Type(), typ.Collation()),
 		Original:          original,
 		Nullable:          typ.nullable,
 		Values:            typ.values,
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+type (
+	// WindowFrameUnit is the unit of the bounds of a window frame.
+	WindowFrameUnit int8
+
+	// WindowFrameBoundType is the type of a bound of a window frame.
+	WindowFrameBoundType int8
+
+	// WindowFrameBound is a bound of a window frame. Offset is the number of
+	// rows of a PRECEDING or FOLLOWING bound.
+	WindowFrameBound struct {
+		Type   WindowFrameBoundType
+		Offset int64
+	}
+
+	// WindowFrame is the frame of a window function: the rows of the
+	// partition, relative to the current row, the function is evaluated on.
+	WindowFrame struct {
+		Unit  WindowFrameUnit
+		Start WindowFrameBound
+		End   WindowFrameBound
+	}
+
+	// WindowPartition is a fully materialized partition of rows, sorted by
+	// the ORDER BY of a window. Rows which are equal by that ORDER BY are
+	// peers, and get the same rank.
+	WindowPartition struct {
+		Rows []sqltypes.Row
+
+		ordered bool
+		// peerStart and peerEnd are the half-open range of the peers of every row,
+		// and denseRank the number of groups of peers up to every row.
+		peerStart []int
+		peerEnd   []int
+		denseRank []int64
+	}
+)
+
+const (
+	FrameRows WindowFrameUnit = iota
+	FrameRange
+)
+
+const (
+	FrameUnboundedPreceding WindowFrameBoundType = iota
+	FramePreceding
+	FrameCurrentRow
+	FrameFollowing
+	FrameUnboundedFollowing
+)
+
+func (b WindowFrameBound) String() string {
+	switch b.Type {
+	case FrameUnboundedPreceding:
+		return "unbounded preceding"
+	case FramePreceding:
+		return fmt.Sprintf("%d preceding", b.Offset)
+	case FrameCurrentRow:
+		return "current row"
+	case FrameFollowing:
+		return fmt.Sprintf("%d following", b.Offset)
+	case FrameUnboundedFollowing:
+		return "unbounded following"
+	default:
+		return "unknown"
+	}
+}
+
+// String returns a string. Used for plan descriptions
+func (f *WindowFrame) String() string {
+	var sb strings.Builder
+	if f.Unit == FrameRange {
+		sb.WriteString("range")
+	} else {
+		sb.WriteString("rows")
+	}
+	fmt.Fprintf(&sb, " between %s and %s", f.Start, f.End)
+	return sb.String()
+}
+
+// NewWindowPartition returns the partition of the rows, which must be sorted
+// by the given ORDER BY already. An empty ORDER BY makes all the rows peers.
+func NewWindowPartition(rows []sqltypes.Row, orderBy Comparison) (p *WindowPartition, err error) {
+	defer PanicHandler(&err)
+
+	p = &WindowPartition{
+		Rows:      rows,
+		ordered:   len(orderBy) > 0,
+		peerStart: make([]int, len(rows)),
+		peerEnd:   make([]int, len(rows)),
+		denseRank: make([]int64, len(rows)),
+	}
+
+	var rank int64
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && orderBy.Compare(rows[start], rows[end]) == 0 {
+			end++
+		}
+		rank++
+		for i := start; i < end; i++ {
+			p.peerStart[i] = start
+			p.peerEnd[i] = end
+			p.denseRank[i] = rank
+		}
+		start = end
+	}
+	return p, nil
+}
+
+// RowNumber returns the number of the row within the partition, from 1.
+func (p *WindowPartition) RowNumber(row int) int64 {
+	return int64(row) + 1
+}
+
+// Rank returns the rank of the row within the partition, with gaps after
+// groups of peers.
+func (p *WindowPartition) Rank(row int) int64 {
+	return int64(p.peerStart[row]) + 1
+}
+
+// DenseRank returns the rank of the row within the partition, without gaps.
+func (p *WindowPartition) DenseRank(row int) int64 {
+	return p.denseRank[row]
+}
+
+// Offset returns the row at the given offset from the row, which is negative
+// for preceding rows, or false if it is out of the partition.
+func (p *WindowPartition) Offset(row int, offset int64) (sqltypes.Row, bool) {
+	target := int64(row) + offset
+	if target < 0 || target >= int64(len(p.Rows)) {
+		return nil, false
+	}
+	return p.Rows[target], true
+}
+
+// Frame returns the half-open range of rows within the frame of the row. A
+// nil frame is the default frame of MySQL: the whole partition without an
+// ORDER BY, or the rows up to the last peer of the row with one.
+func (p *WindowPartition) Frame(row int, frame *WindowFrame) (start, end int) {
+	if frame == nil {
+		if !p.ordered {
+			return 0, len(p.Rows)
+		}
+		return 0, p.peerEnd[row]
+	}
+
+	start = p.frameBound(row, frame.Unit, frame.Start, false)
+	end = p.frameBound(row, frame.Unit, frame.End, true)
+	if start >= end {
+		return 0, 0
+	}
+	return start, end
+}
+
+func (p *WindowPartition) frameBound(row int, unit WindowFrameUnit, bound WindowFrameBound, isEnd bool) int {
+	var pos int64
+	switch bound.Type {
+	case FrameUnboundedPreceding:
+		return 0
+	case FrameUnboundedFollowing:
+		return len(p.Rows)
+	case FrameCurrentRow:
+		if unit == FrameRange {
+			if isEnd {
+				return p.peerEnd[row]
+			}
+			return p.peerStart[row]
+		}
+		pos = int64(row)
+	case FramePreceding:
+		pos = int64(row) - bound.Offset
+	case FrameFollowing:
+		pos = int64(row) + bound.Offset
+	}
+	if isEnd {
+		pos++
+	}
+	return int(max(0, min(pos, int64(len(p.Rows)))))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func newTestWindowPartition(t *testing.T, ordered bool, values ...int64) *WindowPartition {
+	var rows []sqltypes.Row
+	for _, v := range values {
+		rows = append(rows, sqltypes.Row{sqltypes.NewInt64(v)})
+	}
+	var orderBy Comparison
+	if ordered {
+		orderBy = Comparison{{
+			Col:             0,
+			WeightStringCol: -1,
+			Type:            NewType(sqltypes.Int64, collations.CollationBinaryID),
+			CollationEnv:    collations.MySQL8(),
+		}}
+	}
+	p, err := NewWindowPartition(rows, orderBy)
+	require.NoError(t, err)
+	return p
+}
+
+func TestWindowPartitionRanks(t *testing.T) {
+	p := newTestWindowPartition(t, true, 1, 2, 2, 3, 3, 3, 4)
+
+	var rowNumbers, ranks, denseRanks []int64
+	for i := range p.Rows {
+		rowNumbers = append(rowNumbers, p.RowNumber(i))
+		ranks = append(ranks, p.Rank(i))
+		denseRanks = append(denseRanks, p.DenseRank(i))
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7}, rowNumbers)
+	assert.Equal(t, []int64{1, 2, 2, 4, 4, 4, 7}, ranks)
+	assert.Equal(t, []int64{1, 2, 2, 3, 3, 3, 4}, denseRanks)
+
+	unordered := newTestWindowPartition(t, false, 3, 1, 2)
+	for i := range unordered.Rows {
+		assert.EqualValues(t, 1, unordered.Rank(i))
+		assert.EqualValues(t, 1, unordered.DenseRank(i))
+	}
+}
+
+func TestWindowPartitionOffset(t *testing.T) {
+	p := newTestWindowPartition(t, true, 1, 2, 3)
+
+	row, ok := p.Offset(1, -1)
+	require.True(t, ok)
+	assert.Equal(t, sqltypes.NewInt64(1), row[0])
+
+	row, ok = p.Offset(0, 2)
+	require.True(t, ok)
+	assert.Equal(t, sqltypes.NewInt64(3), row[0])
+
+	_, ok = p.Offset(0, -1)
+	assert.False(t, ok)
+	_, ok = p.Offset(2, 1)
+	assert.False(t, ok)
+}
+
+func TestWindowPartitionFrame(t *testing.T) {
+	bound := func(typ WindowFrameBoundType, offset int64) WindowFrameBound {
+		return WindowFrameBound{Type: typ, Offset: offset}
+	}
+
+	tcases := []struct {
+		name    string
+		ordered bool
+		frame   *WindowFrame
+		want    [][2]int
+	}{{
+		name:    "default frame without order by",
+		ordered: false,
+		want:    [][2]int{{0, 5}, {0, 5}, {0, 5}, {0, 5}, {0, 5}},
+	}, {
+		name:    "default frame with order by",
+		ordered: true,
+		want:    [][2]int{{0, 1}, {0, 3}, {0, 3}, {0, 4}, {0, 5}},
+	}, {
+		name:    "rows between 1 preceding and 1 following",
+		ordered: true,
+		frame:   &WindowFrame{Unit: FrameRows, Start: bound(FramePreceding, 1), End: bound(FrameFollowing, 1)},
+		want:    [][2]int{{0, 2}, {0, 3}, {1, 4}, {2, 5}, {3, 5}},
+	}, {
+		name:    "rows between current row and unbounded following",
+		ordered: true,
+		frame:   &WindowFrame{Unit: FrameRows, Start: bound(FrameCurrentRow, 0), End: bound(FrameUnboundedFollowing, 0)},
+		want:    [][2]int{{0, 5}, {1, 5}, {2, 5}, {3, 5}, {4, 5}},
+	}, {
+		name:    "range between current row and current row",
+		ordered: true,
+		frame:   &WindowFrame{Unit: FrameRange, Start: bound(FrameCurrentRow, 0), End: bound(FrameCurrentRow, 0)},
+		want:    [][2]int{{0, 1}, {1, 3}, {1, 3}, {3, 4}, {4, 5}},
+	}, {
+		name:    "empty frame",
+		ordered: true,
+		frame:   &WindowFrame{Unit: FrameRows, Start: bound(FrameFollowing, 2), End: bound(FrameFollowing, 3)},
+		want:    [][2]int{{2, 4}, {3, 5}, {4, 5}, {0, 0}, {0, 0}},
+	}}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			p := newTestWindowPartition(t, tcase.ordered, 1, 2, 2, 3, 4)
+			var got [][2]int
+			for i := range p.Rows {
+				start, end := p.Frame(i, tcase.frame)
+				got = append(got, [2]int{start, end})
+			}
+			assert.Equal(t, tcase.want, got)
+		})
+	}
+}
+
+func TestWindowFrameString(t *testing.T) {
+	frame := &WindowFrame{
+		Unit:  FrameRows,
+		Start: WindowFrameBound{Type: FramePreceding, Offset: 2},
+		End:   WindowFrameBound{Type: FrameCurrentRow},
+	}
+	assert.Equal(t, "rows between 2 preceding and current row", frame.String())
+}
@@ -40,6 +40,21 @@ func (cached *ArithmeticExpr) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *AssignmentExpr) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field UnaryExpr vitess.io/vitess/go/vt/vtgate/evalengine.UnaryExpr
+	size += cached.UnaryExpr.CachedSize(false)
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	return size
+}
+
 func (cached *BinaryExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -302,6 +317,8 @@ func (cached *InExpr) CachedSize(alloc bool) int64 {
 	}
 	// field BinaryExpr vitess.io/vitess/go/vt/vtgate/evalengine.BinaryExpr
 	size += cached.BinaryExpr.CachedSize(false)
+	// field table *vitess.io/vitess/go/vt/vtgate/evalengine.inTable
+	size += cached.table.CachedSize(true)
 	return size
 }
 
@@ -352,10 +369,14 @@ func (cached *LikeExpr) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(64)
+		size += int64(80)
 	}
 	// field BinaryExpr vitess.io/vitess/go/vt/vtgate/evalengine.BinaryExpr
 	size += cached.BinaryExpr.CachedSize(false)
+	// field Escape vitess.io/vitess/go/vt/vtgate/evalengine.IR
+	if cc, ok := cached.Escape.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
 	// field Match vitess.io/vitess/go/mysql/collations/colldata.WildcardPattern
 	if cc, ok := cached.Match.(cachedObject); ok {
 		size += cc.CachedSize(true)
@@ -506,7 +527,7 @@ func (cached *UntypedExpr) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(96)
+		size += int64(112)
 	}
 	// field env *vitess.io/vitess/go/vt/vtenv.Environment
 	size += cached.env.CachedSize(true)
@@ -530,6 +551,26 @@ func (cached *UntypedExpr) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field speculative []*vitess.io/vitess/go/vt/vtgate/evalengine.typedExpr
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.speculative)) * int64(8))
+		for _, elem := range cached.speculative {
+			size += elem.CachedSize(true)
+		}
+	}
+	return size
+}
+
+func (cached *UserVariable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
 	return size
 }
 
@@ -552,6 +593,32 @@ func (cached *WhenThen) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinAESDecrypt) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinAESEncrypt) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinASCII) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -591,6 +658,32 @@ func (cached *builtinAcos) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinAsBinary) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinAsText) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinAsin) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -734,6 +827,19 @@ func (cached *builtinCoalesce) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinCoercibility) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinCollation) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -773,6 +879,19 @@ func (cached *builtinConcatWs) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinContains) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinConv) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -955,6 +1074,19 @@ func (cached *builtinDegrees) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinDistance) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinElt) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -981,6 +1113,19 @@ func (cached *builtinExp) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinExportSet) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinField) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -994,6 +1139,19 @@ func (cached *builtinField) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinFindInSet) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinFloor) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1007,6 +1165,19 @@ func (cached *builtinFloor) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinFormat) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinFromBase64) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1046,6 +1217,45 @@ func (cached *builtinFromUnixtime) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinGeomFromText) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinGeomFromWKB) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinGetFormat) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinHex) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1280,6 +1490,45 @@ func (cached *builtinJSONLength) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinJSONMergePatch) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinJSONMergePreserve) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinJSONModify) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinJSONObject) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1293,6 +1542,19 @@ func (cached *builtinJSONObject) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinJSONOverlaps) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinJSONRemove) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1449,6 +1711,19 @@ func (cached *builtinMD5) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinMakeSet) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinMakedate) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1475,6 +1750,19 @@ func (cached *builtinMaketime) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinMemberOf) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinMicrosecond) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1618,6 +1906,32 @@ func (cached *builtinPi) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinPoint) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
+func (cached *builtinPointCoordinate) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinPow) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1878,6 +2192,19 @@ func (cached *builtinSqrt) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *builtinStrToDate) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field CallExpr vitess.io/vitess/go/vt/vtgate/evalengine.CallExpr
+	size += cached.CallExpr.CachedSize(false)
+	return size
+}
+
 func (cached *builtinStrcmp) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -2348,6 +2675,24 @@ func (cached *frame) CachedSize(alloc bool) int64 {
 	return int64(0)
 }
 
+//go:nocheckptr
+func (cached *inTable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field hashes map[vitess.io/vitess/go/vt/vthash.Hash]struct{}
+	if cached.hashes != nil {
+		size += hack.RuntimeMapSize(cached.hashes)
+	}
+	// field tuple *vitess.io/vitess/go/vt/vtgate/evalengine.evalTuple
+	size += cached.tuple.CachedSize(true)
+	return size
+}
+
 func (cached *typedExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
 )
 
 func TestEvalResultToBooleanStrict(t *testing.T) {
@@ -66,3 +67,36 @@ func TestEvalResultToBooleanStrict(t *testing.T) {
 		})
 	}
 }
+
+func TestCoerceToBit(t *testing.T) {
+	bit := func(raw ...byte) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.Bit, raw)
+	}
+	bitType := func(size int32) Type {
+		return NewTypeEx(sqltypes.Bit, collations.CollationBinaryID, true, size, 0, nil)
+	}
+
+	testCases := []struct {
+		value    sqltypes.Value
+		typ      Type
+		expected sqltypes.Value
+	}{
+		{sqltypes.NewInt64(5), bitType(10), bit(0x00, 0x05)},
+		{sqltypes.NewInt64(1023), bitType(10), bit(0x03, 0xff)},
+		{sqltypes.NewInt64(1024), bitType(10), bit(0x03, 0xff)},
+		{sqltypes.NewInt64(256), bitType(0), bit(0x01, 0x00)},
+		{bit(0x00, 0x00, 0x01), bitType(8), bit(0x01)},
+		{bit(0x01, 0x00), bitType(8), bit(0xff)},
+		{bit(0x05), bitType(16), bit(0x00, 0x05)},
+		{bit(0x01, 0x05), NewType(sqltypes.Int64, collations.CollationBinaryID), sqltypes.NewInt64(261)},
+		{bit(0x01, 0x05), NewType(sqltypes.Float64, collations.CollationBinaryID), sqltypes.NewFloat64(261)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v %s", tc.value, tc.typ.Type()), func(t *testing.T) {
+			v, err := CoerceTo(tc.value, tc.typ, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v)
+		})
+	}
+}
@@ -39,6 +39,16 @@ func typedCoercionCollation(typ sqltypes.Type, id collations.ID) collations.Type
 	}
 }
 
+// columnCollation returns the collation of a column with the given type. Unlike
+// literals, the collation of a column is implicit.
+func columnCollation(typ sqltypes.Type, id collations.ID) collations.TypedCollation {
+	tc := typedCoercionCollation(typ, id)
+	if tc.Coercibility == collations.CoerceCoercible {
+		tc.Coercibility = collations.CoerceImplicit
+	}
+	return tc
+}
+
 func evalCollation(e eval) collations.TypedCollation {
 	switch e := e.(type) {
 	case nil:
@@ -56,6 +66,11 @@ func evalCollation(e eval) collations.TypedCollation {
 
 func mergeCollations(c1, c2 collations.TypedCollation, t1, t2 sqltypes.Type, env *collations.Environment) (collations.TypedCollation, colldata.Coercion, colldata.Coercion, error) {
 	if c1.Collation == c2.Collation {
+		// the merged collation keeps the strongest coercibility of both sides
+		if c2.Coercibility < c1.Coercibility {
+			c1.Coercibility = c2.Coercibility
+		}
+		c1.Repertoire |= c2.Repertoire
 		return c1, nil, nil, nil
 	}
 
@@ -237,6 +237,10 @@ func (c *compiler) compileToNumeric(ct ctype, offset int, fallback sqltypes.Type
 			return ctype{Type: sqltypes.Int64, Flag: ct.Flag, Col: collationNumeric}
 		}
 	}
+	if ct.Type == sqltypes.Bit {
+		c.asm.Convert_bit(offset)
+		return ctype{Type: sqltypes.Uint64, Flag: ct.Flag, Col: collationNumeric}
+	}
 
 	if sqltypes.IsDateOrTime(ct.Type) {
 		if preciseDatetime {
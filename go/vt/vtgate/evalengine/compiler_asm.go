@@ -51,7 +51,6 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
-	"vitess.io/vitess/go/vt/vthash"
 )
 
 type jump struct {
@@ -2062,6 +2061,14 @@ func (asm *assembler) Fn_COLLATION(collationEnv *collations.Environment, col col
 	}, "FN COLLATION (SP-1)")
 }
 
+func (asm *assembler) Fn_COERCIBILITY() {
+	asm.emit(func(env *ExpressionEnv) int {
+		v := evalCollation(env.vm.stack[env.vm.sp-1])
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalInt64(int64(v.Coercibility))
+		return 1
+	}, "FN COERCIBILITY (SP-1)")
+}
+
 func (asm *assembler) Fn_FROM_BASE64(t sqltypes.Type) {
 	asm.emit(func(env *ExpressionEnv) int {
 		str := env.vm.stack[env.vm.sp-1].(*evalBytes)
@@ -2340,6 +2347,44 @@ func (asm *assembler) Fn_JSON_KEYS(jp *json.Path) {
 	}
 }
 
+func (asm *assembler) Fn_JSON_OVERLAPS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		doc1 := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		doc2 := env.vm.stack[env.vm.sp-1].(*evalJSON)
+		var overlaps bool
+		overlaps, env.vm.err = jsonOverlaps(doc1, doc2)
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalBool(overlaps)
+		env.vm.sp--
+		return 1
+	}, "FN JSON_OVERLAPS (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_MEMBER_OF() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		value := env.vm.stack[env.vm.sp-2].(*evalJSON)
+		doc := env.vm.stack[env.vm.sp-1].(*evalJSON)
+		var found bool
+		found, env.vm.err = jsonMemberOf(value, doc)
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalBool(found)
+		env.vm.sp--
+		return 1
+	}, "FN MEMBER_OF (SP-2), (SP-1)")
+}
+
+// Fn_GEOMETRY calls a spatial function with the values of its args arguments,
+// which are replaced by the result of the function.
+func (asm *assembler) Fn_GEOMETRY(method string, args int, fn func(args []eval) (eval, error)) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		sp := env.vm.sp - args
+		env.vm.stack[sp], env.vm.err = fn(env.vm.stack[sp:env.vm.sp])
+		env.vm.sp = sp + 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", method, args)
+}
+
 func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	asm.adjustStack(-(args - 1))
 	asm.emit(func(env *ExpressionEnv) int {
@@ -2421,6 +2466,90 @@ func (asm *assembler) Fn_JSON_REMOVE(args int, staticPaths []staticPath) {
 	}, "FN JSON_REMOVE (SP-1) (SP-2)...(SP-N)")
 }
 
+func (sp staticPath) resolve(arg eval) (*json.Path, error) {
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	if sp.p != nil {
+		return sp.p, nil
+	}
+	pathBytes, err := evalToVarchar(arg, collations.CollationUtf8mb4ID, true)
+	if err != nil {
+		return nil, err
+	}
+	return intoJSONPath(pathBytes)
+}
+
+func (asm *assembler) Fn_JSON_MODIFY(method string, t json.Transformation, clone bool, staticPaths []staticPath) {
+	args := 2 * len(staticPaths)
+	asm.adjustStack(-args)
+	asm.emit(func(env *ExpressionEnv) int {
+		paths := make([]*json.Path, 0, len(staticPaths))
+		values := make([]*json.Value, 0, len(staticPaths))
+
+		doc := env.vm.stack[env.vm.sp-(args+1)].(*evalJSON)
+
+		for i, sp := range staticPaths {
+			arg := env.vm.stack[env.vm.sp-args+2*i]
+
+			if arg == nil {
+				env.vm.sp -= args
+				env.vm.stack[env.vm.sp-1] = nil
+				return 1
+			}
+
+			path, err := sp.resolve(arg)
+			if err != nil {
+				env.vm.err = err
+				return 1
+			}
+
+			if path.ContainsWildcards() {
+				env.vm.err = errInvalidPathForTransform
+				return 1
+			}
+
+			paths = append(paths, path)
+			values = append(values, env.vm.stack[env.vm.sp-args+2*i+1].(*evalJSON).Clone())
+		}
+
+		env.vm.sp -= args
+
+		if clone {
+			doc = doc.Clone()
+		}
+
+		err := json.ApplyTransform(t, doc, paths, values)
+		if err != nil {
+			env.vm.err = err
+			return 1
+		}
+
+		env.vm.stack[env.vm.sp-1] = doc
+		return 1
+	}, "FN %s (SP-%d) (SP-%d)...(SP-1)", method, args+1, args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PRESERVE(method string, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		result, err := builtin_JSON_MERGE_PRESERVE(method, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		env.vm.stack[env.vm.sp-1], env.vm.err = result, err
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", method, args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PATCH(method string, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		result, err := builtin_JSON_MERGE_PATCH(method, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		env.vm.stack[env.vm.sp-1], env.vm.err = result, err
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", method, args)
+}
+
 func (asm *assembler) Fn_JSON_UNQUOTE() {
 	asm.emit(func(env *ExpressionEnv) int {
 		j := env.vm.stack[env.vm.sp-1].(*evalJSON)
@@ -3158,6 +3287,31 @@ func (asm *assembler) Fn_SUBSTRING3(tt sqltypes.Type, cs types.Charset, col coll
 	}, "FN SUBSTRING VARCHAR(SP-3) INT64(SP-2) INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_FORMAT(col collations.TypedCollation, locale bool) {
+	if locale {
+		asm.adjustStack(-2)
+		asm.emit(func(env *ExpressionEnv) int {
+			num := env.vm.stack[env.vm.sp-3]
+			dec := env.vm.stack[env.vm.sp-2].(*evalInt64)
+			lc := lookupNumericLocale(env.vm.stack[env.vm.sp-1])
+
+			env.vm.stack[env.vm.sp-3] = env.vm.arena.newEvalText(formatNumber(num, dec.i, lc), col)
+			env.vm.sp -= 2
+			return 1
+		}, "FN FORMAT (SP-3) INT64(SP-2) VARCHAR(SP-1)")
+	} else {
+		asm.adjustStack(-1)
+		asm.emit(func(env *ExpressionEnv) int {
+			num := env.vm.stack[env.vm.sp-2]
+			dec := env.vm.stack[env.vm.sp-1].(*evalInt64)
+
+			env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalText(formatNumber(num, dec.i, localeEnUS), col)
+			env.vm.sp--
+			return 1
+		}, "FN FORMAT (SP-2) INT64(SP-1)")
+	}
+}
+
 func (asm *assembler) Fn_TO_BASE64(t sqltypes.Type, col collations.TypedCollation) {
 	asm.emit(func(env *ExpressionEnv) int {
 		str := env.vm.stack[env.vm.sp-1].(*evalBytes)
@@ -3182,27 +3336,34 @@ func (asm *assembler) Fn_WEIGHT_STRING(typ sqltypes.Type, length int) {
 	}, "FN WEIGHT_STRING (SP-1)")
 }
 
-func (asm *assembler) In_table(not bool, table map[vthash.Hash]struct{}) {
+func (asm *assembler) Fn_WEIGHT_STRING_enumset(values *EnumSetValues) {
+	asm.emit(func(env *ExpressionEnv) int {
+		input := env.vm.stack[env.vm.sp-1]
+		w := enumSetWeightString(nil, input, values)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalRaw(w, sqltypes.VarBinary, collationBinary)
+		return 1
+	}, "FN WEIGHT_STRING ENUM/SET (SP-1)")
+}
+
+func (asm *assembler) In_table(collationsEnv *collations.Environment, not bool, table *inTable) {
 	if not {
 		asm.emit(func(env *ExpressionEnv) int {
 			lhs := env.vm.stack[env.vm.sp-1]
-			if lhs != nil {
-				env.vm.hash.Reset()
-				lhs.(hashable).Hash(&env.vm.hash)
-				_, in := table[env.vm.hash.Sum128()]
-				env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBool(!in)
-			}
+
+			var in boolean
+			in, env.vm.err = table.lookup(collationsEnv, &env.vm.hash, lhs)
+
+			env.vm.stack[env.vm.sp-1] = in.not().eval()
 			return 1
 		}, "NOT IN (SP-1), [static table]")
 	} else {
 		asm.emit(func(env *ExpressionEnv) int {
 			lhs := env.vm.stack[env.vm.sp-1]
-			if lhs != nil {
-				env.vm.hash.Reset()
-				lhs.(hashable).Hash(&env.vm.hash)
-				_, in := table[env.vm.hash.Sum128()]
-				env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBool(in)
-			}
+
+			var in boolean
+			in, env.vm.err = table.lookup(collationsEnv, &env.vm.hash, lhs)
+
+			env.vm.stack[env.vm.sp-1] = in.eval()
 			return 1
 		}, "IN (SP-1), [static table]")
 	}
@@ -3277,10 +3438,23 @@ func (asm *assembler) Not_d() {
 	}, "NOT DECIMAL(SP-1)")
 }
 
-func (asm *assembler) Like_coerce(expr *LikeExpr, coercion *compiledCoercion) {
-	asm.adjustStack(-1)
+func (asm *assembler) Like_coerce(expr *LikeExpr, coercion *compiledCoercion, hasEscape bool) {
+	if hasEscape {
+		asm.adjustStack(-2)
+	} else {
+		asm.adjustStack(-1)
+	}
 
 	asm.emit(func(env *ExpressionEnv) int {
+		var escape rune
+		if hasEscape {
+			escape, env.vm.err = likeEscape(env.vm.stack[env.vm.sp-1], coercion.col)
+			if env.vm.err != nil {
+				return 0
+			}
+			env.vm.sp--
+		}
+
 		l := env.vm.stack[env.vm.sp-2].(*evalBytes)
 		r := env.vm.stack[env.vm.sp-1].(*evalBytes)
 		env.vm.sp--
@@ -3295,21 +3469,34 @@ func (asm *assembler) Like_coerce(expr *LikeExpr, coercion *compiledCoercion) {
 			return 0
 		}
 
-		match := expr.matchWildcard(bl, br, coercion.col.ID())
+		match := expr.matchWildcard(bl, br, coercion.col.ID(), escape)
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBool(match)
 		return 1
 	}, "LIKE VARCHAR(SP-2), VARCHAR(SP-1) COERCE AND COLLATE '%s'", coercion.col.Name())
 }
 
-func (asm *assembler) Like_collate(expr *LikeExpr, collation colldata.Collation) {
-	asm.adjustStack(-1)
+func (asm *assembler) Like_collate(expr *LikeExpr, collation colldata.Collation, hasEscape bool) {
+	if hasEscape {
+		asm.adjustStack(-2)
+	} else {
+		asm.adjustStack(-1)
+	}
 
 	asm.emit(func(env *ExpressionEnv) int {
+		var escape rune
+		if hasEscape {
+			escape, env.vm.err = likeEscape(env.vm.stack[env.vm.sp-1], collation)
+			if env.vm.err != nil {
+				return 0
+			}
+			env.vm.sp--
+		}
+
 		l := env.vm.stack[env.vm.sp-2].(*evalBytes)
 		r := env.vm.stack[env.vm.sp-1].(*evalBytes)
 		env.vm.sp--
 
-		match := expr.matchWildcard(l.bytes, r.bytes, collation.ID())
+		match := expr.matchWildcard(l.bytes, r.bytes, collation.ID(), escape)
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalBool(match)
 		return 1
 	}, "LIKE VARCHAR(SP-2), VARCHAR(SP-1) COLLATE '%s'", collation.Name())
@@ -3739,10 +3926,7 @@ func (asm *assembler) Fn_NowTime(prec uint8, utc bool) {
 func (asm *assembler) Fn_Sysdate(prec uint8) {
 	asm.adjustStack(1)
 	asm.emit(func(env *ExpressionEnv) int {
-		now := SystemTime()
-		if tz := env.currentTimezone(); tz != nil {
-			now = now.In(tz)
-		}
+		now := SystemTime().In(env.currentTimezone())
 		env.vm.stack[env.vm.sp] = env.vm.arena.newEvalDateTime(datetime.NewDateTimeFromStd(now), int(prec))
 		env.vm.sp++
 		return 1
@@ -3770,7 +3954,7 @@ func (asm *assembler) Fn_UtcDate() {
 func (asm *assembler) Fn_User() {
 	asm.adjustStack(1)
 	asm.emit(func(env *ExpressionEnv) int {
-		env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(env.currentUser()), collationUtf8mb3)
+		env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(env.currentUser()), collationSysconst)
 		env.vm.sp++
 		return 1
 	}, "FN USER")
@@ -3783,7 +3967,7 @@ func (asm *assembler) Fn_Database() {
 		if db == "" {
 			env.vm.stack[env.vm.sp] = nil
 		} else {
-			env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(db), collationUtf8mb3)
+			env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(db), collationSysconst)
 		}
 		env.vm.sp++
 		return 1
@@ -3793,7 +3977,7 @@ func (asm *assembler) Fn_Database() {
 func (asm *assembler) Fn_Version() {
 	asm.adjustStack(1)
 	asm.emit(func(env *ExpressionEnv) int {
-		env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(env.currentVersion()), collationUtf8mb3)
+		env.vm.stack[env.vm.sp] = env.vm.arena.newEvalText([]byte(env.currentVersion()), collationSysconst)
 		env.vm.sp++
 		return 1
 	}, "FN VERSION")
@@ -3884,6 +4068,28 @@ func (asm *assembler) Fn_RandomBytes() {
 	}, "FN RANDOM_BYTES INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_AES(method string, args int, decrypt bool) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-args]
+		key := env.vm.stack[env.vm.sp-args+1]
+		var iv eval
+		if args > 2 {
+			iv = env.vm.stack[env.vm.sp-1]
+		}
+
+		var out []byte
+		out, env.vm.err = aesCrypt(env.blockEncryptionMode(), method, str, key, iv, args > 2, decrypt)
+		if out == nil {
+			env.vm.stack[env.vm.sp-args] = nil
+		} else {
+			env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalBinary(out)
+		}
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN AES (SP-%d)...(SP-1)", args)
+}
+
 func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	asm.adjustStack(-1)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -3902,6 +4108,34 @@ func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	}, "FN DATE_FORMAT DATETIME(SP-2), VARBINARY(SP-1)")
 }
 
+func (asm *assembler) Fn_STR_TO_DATE(t sqltypes.Type, prec uint8) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		format := env.vm.stack[env.vm.sp-1].(*evalBytes)
+
+		if d := strToDate(t, prec, format.string(), str.string()); d != nil {
+			env.vm.stack[env.vm.sp-2] = d
+		} else {
+			env.vm.stack[env.vm.sp-2] = nil
+		}
+		env.vm.sp--
+		return 1
+	}, "FN STR_TO_DATE VARBINARY(SP-2), VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_GET_FORMAT(kind sqltypes.Type, col collations.TypedCollation) {
+	asm.emit(func(env *ExpressionEnv) int {
+		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		if format, ok := getFormat(kind, arg.string()); ok {
+			env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalText([]byte(format), col)
+		} else {
+			env.vm.stack[env.vm.sp-1] = nil
+		}
+		return 1
+	}, "FN GET_FORMAT VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_CONVERT_TZ() {
 	asm.adjustStack(-2)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -3980,10 +4214,7 @@ func (asm *assembler) Fn_FROM_UNIXTIME_i() {
 			env.vm.stack[env.vm.sp-1] = nil
 			return 1
 		}
-		t := time.Unix(arg.i, 0)
-		if tz := env.currentTimezone(); tz != nil {
-			t = t.In(tz)
-		}
+		t := time.Unix(arg.i, 0).In(env.currentTimezone())
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalDateTime(datetime.NewDateTimeFromStd(t), 0)
 		return 1
 	}, "FN FROM_UNIXTIME INT64(SP-1)")
@@ -3996,10 +4227,7 @@ func (asm *assembler) Fn_FROM_UNIXTIME_u() {
 			env.vm.stack[env.vm.sp-1] = nil
 			return 1
 		}
-		t := time.Unix(int64(arg.u), 0)
-		if tz := env.currentTimezone(); tz != nil {
-			t = t.In(tz)
-		}
+		t := time.Unix(int64(arg.u), 0).In(env.currentTimezone())
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalDateTime(datetime.NewDateTimeFromStd(t), 0)
 		return 1
 	}, "FN FROM_UNIXTIME UINT64(SP-1)")
@@ -4020,10 +4248,7 @@ func (asm *assembler) Fn_FROM_UNIXTIME_d() {
 			return 1
 		}
 		frac, _ := fd.Mul(decimal.New(1, 9)).Int64()
-		t := time.Unix(sec, frac)
-		if tz := env.currentTimezone(); tz != nil {
-			t = t.In(tz)
-		}
+		t := time.Unix(sec, frac).In(env.currentTimezone())
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalDateTime(datetime.NewDateTimeFromStd(t), int(arg.length))
 		return 1
 	}, "FN FROM_UNIXTIME DECIMAL(SP-1)")
@@ -4037,10 +4262,7 @@ func (asm *assembler) Fn_FROM_UNIXTIME_f() {
 			return 1
 		}
 		sec, frac := math.Modf(arg.f)
-		t := time.Unix(int64(sec), int64(frac*1e9))
-		if tz := env.currentTimezone(); tz != nil {
-			t = t.In(tz)
-		}
+		t := time.Unix(int64(sec), int64(frac*1e9)).In(env.currentTimezone())
 		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalDateTime(datetime.NewDateTimeFromStd(t), 6)
 		return 1
 	}, "FN FROM_UNIXTIME FLOAT(SP-1)")
@@ -4662,6 +4884,67 @@ func (asm *assembler) Fn_CONCAT_WS(tt querypb.Type, tc collations.TypedCollation
 	}, "FN CONCAT_WS VARCHAR(SP-1) VARCHAR(SP-2)...VARCHAR(SP-N)")
 }
 
+func (asm *assembler) Fn_FIND_IN_SET(col collations.ID) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		strlist := env.vm.stack[env.vm.sp-1].(*evalBytes)
+
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalInt64(findInSet(str.bytes, strlist.bytes, col))
+		env.vm.sp--
+		return 1
+	}, "FN FIND_IN_SET VARCHAR(SP-2) VARCHAR(SP-1)")
+}
+
+func (asm *assembler) Fn_MAKE_SET(args int, tt sqltypes.Type, tc collations.TypedCollation) {
+	asm.adjustStack(-args + 1)
+	asm.emit(func(env *ExpressionEnv) int {
+		var buf []byte
+		first := true
+		bits := uint64(env.vm.stack[env.vm.sp-args].(*evalInt64).i)
+		for i := 1; bits != 0 && i < args; i, bits = i+1, bits>>1 {
+			if bits&1 == 0 || env.vm.stack[env.vm.sp-args+i] == nil {
+				continue
+			}
+			if !first {
+				buf = append(buf, ',')
+			}
+			first = false
+			buf = append(buf, env.vm.stack[env.vm.sp-args+i].(*evalBytes).bytes...)
+		}
+
+		env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalRaw(buf, tt, tc)
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN MAKE_SET INT64(SP-%d) VARCHAR(SP-%d)...VARCHAR(SP-1)", args, args-1)
+}
+
+func (asm *assembler) Fn_EXPORT_SET(args int, tt sqltypes.Type, tc collations.TypedCollation) {
+	asm.adjustStack(-args + 1)
+	asm.emit(func(env *ExpressionEnv) int {
+		bits := uint64(env.vm.stack[env.vm.sp-args].(*evalInt64).i)
+		on := env.vm.stack[env.vm.sp-args+1].(*evalBytes).bytes
+		off := env.vm.stack[env.vm.sp-args+2].(*evalBytes).bytes
+
+		sep := []byte(",")
+		if args > 3 {
+			sep = env.vm.stack[env.vm.sp-args+3].(*evalBytes).bytes
+		}
+		n := uint64(64)
+		if args > 4 {
+			n = min(uint64(env.vm.stack[env.vm.sp-args+4].(*evalInt64).i), 64)
+		}
+
+		if res, ok := exportSet(bits, on, off, sep, n); ok {
+			env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalRaw(res, tt, tc)
+		} else {
+			env.vm.stack[env.vm.sp-args] = nil
+		}
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN EXPORT_SET INT64(SP-%d) VARCHAR(SP-%d)...(SP-1)", args, args-1)
+}
+
 func (asm *assembler) Fn_CHAR(tt querypb.Type, tc collations.TypedCollation, args int) {
 	cs := colldata.Lookup(tc.Collation).Charset()
 	asm.adjustStack(-(args - 1))
@@ -5310,3 +5593,10 @@ func (asm *assembler) Fn_LAST_INSERT_ID() {
 		return 1
 	}, "FN LAST_INSERT_ID UINT64(SP-1)")
 }
+
+func (asm *assembler) AssignUserVar(name string) {
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.err = env.setUserVar(name, env.vm.stack[env.vm.sp-1])
+		return 1
+	}, "ASSIGN USERVAR(@%s), SP-1", name)
+}
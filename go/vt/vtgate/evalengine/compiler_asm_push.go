@@ -127,6 +127,36 @@ func (asm *assembler) PushBVar_vector(key string) {
 	}, "PUSH VECTOR(:%q)", key)
 }
 
+func push_geometry(env *ExpressionEnv, raw []byte) int {
+	env.vm.stack[env.vm.sp] = newEvalGeometry(raw)
+	env.vm.sp++
+	return 1
+}
+
+func (asm *assembler) PushColumn_geometry(offset int) {
+	asm.adjustStack(1)
+	asm.emit(func(env *ExpressionEnv) int {
+		col := env.Row[offset]
+		if col.IsNull() {
+			return push_null(env)
+		}
+		return push_geometry(env, col.Raw())
+	}, "PUSH GEOMETRY(:%d)", offset)
+}
+
+func (asm *assembler) PushBVar_geometry(key string) {
+	asm.adjustStack(1)
+
+	asm.emit(func(env *ExpressionEnv) int {
+		var bvar *querypb.BindVariable
+		bvar, env.vm.err = env.lookupBindVar(key)
+		if env.vm.err != nil {
+			return 0
+		}
+		return push_geometry(env, bvar.Value)
+	}, "PUSH GEOMETRY(:%q)", key)
+}
+
 func push_d(env *ExpressionEnv, raw []byte) int {
 	var dec decimal.Decimal
 	dec, env.vm.err = decimal.NewFromMySQL(raw)
@@ -249,6 +279,37 @@ func (asm *assembler) PushBVar_bitnum(key string) {
 	}, "PUSH BITNUM(:%q)", key)
 }
 
+func push_bit(env *ExpressionEnv, raw []byte) int {
+	env.vm.stack[env.vm.sp] = newEvalBit(raw)
+	env.vm.sp++
+	return 1
+}
+
+func (asm *assembler) PushColumn_bit(offset int) {
+	asm.adjustStack(1)
+
+	asm.emit(func(env *ExpressionEnv) int {
+		col := env.Row[offset]
+		if col.IsNull() {
+			return push_null(env)
+		}
+		return push_bit(env, col.Raw())
+	}, "PUSH BIT(:%d)", offset)
+}
+
+func (asm *assembler) PushBVar_bit(key string) {
+	asm.adjustStack(1)
+
+	asm.emit(func(env *ExpressionEnv) int {
+		var bvar *querypb.BindVariable
+		bvar, env.vm.err = env.lookupBindVar(key)
+		if env.vm.err != nil {
+			return 0
+		}
+		return push_bit(env, bvar.Value)
+	}, "PUSH BIT(:%q)", key)
+}
+
 func push_hexnum(env *ExpressionEnv, raw []byte) int {
 	raw, env.vm.err = parseHexNumber(raw)
 	env.vm.stack[env.vm.sp] = newEvalBytesHex(raw)
@@ -600,3 +661,24 @@ func (asm *assembler) PushNull() {
 	asm.adjustStack(1)
 	asm.emit(push_null, "PUSH NULL")
 }
+
+func (asm *assembler) PushUserVar(uv *UserVariable, typ ctype) {
+	asm.adjustStack(1)
+
+	asm.emit(func(env *ExpressionEnv) int {
+		var e eval
+		e, env.vm.err = uv.eval(env)
+		if env.vm.err != nil {
+			return 0
+		}
+		// the variable may have been assigned a value of another type by the expression
+		// itself after it was compiled; the interpreter handles any type
+		if vt := userVariableType(e); !vt.equal(typ) {
+			env.vm.err = errDeoptimize
+			return 0
+		}
+		env.vm.stack[env.vm.sp] = e
+		env.vm.sp++
+		return 1
+	}, "PUSH USERVAR(@%s)", uv.Name)
+}
@@ -19,6 +19,7 @@ package evalengine_test
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 	"testing"
@@ -31,6 +32,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/datetime"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -142,6 +144,36 @@ func TestCompilerReference(t *testing.T) {
 	t.Logf("\n%s", track.String())
 }
 
+func TestFuzzerIsSeeded(t *testing.T) {
+	// A failure found by the fuzzer must reproduce with the same seed.
+	collect := func(seed uint64) []string {
+		var exprs []string
+		testcases.Fuzz(seed, 4, 100)(func(query string, _ []sqltypes.Value, _ bool) {
+			exprs = append(exprs, query)
+		})
+		return exprs
+	}
+	assert.Equal(t, collect(1), collect(1))
+	assert.NotEqual(t, collect(1), collect(2))
+}
+
+func TestFuzzFunctionsSupported(t *testing.T) {
+	// Every function in the fuzzer's inventory must be supported by the
+	// evalengine, or the fuzzer would only compare MySQL errors.
+	venv := vtenv.NewTestEnv()
+	for _, fn := range testcases.FuzzFunctions {
+		for args := fn.MinArgs; args <= fn.MaxArgs; args++ {
+			query := fn.Name + "(" + strings.TrimSuffix(strings.Repeat("'1', ", args), ", ") + ")"
+			t.Run(query, func(t *testing.T) {
+				expr, err := venv.Parser().ParseExpr(query)
+				require.NoError(t, err)
+				_, err = evalengine.Translate(expr, &evalengine.Config{Environment: venv, Collation: collations.CollationUtf8mb4ID, NoConstantFolding: true})
+				require.NoError(t, err)
+			})
+		}
+	}
+}
+
 func testCompilerCase(t *testing.T, query string, venv *vtenv.Environment, schema []*querypb.Field, env *evalengine.ExpressionEnv) {
 	stmt, err := venv.Parser().ParseExpr(query)
 	if err != nil {
@@ -781,10 +813,69 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `cast(_utf32 0x0000FF as binary)`,
 			result:     `VARBINARY("\x00\x00\x00\xff")`,
 		},
+		{
+			expression: `FORMAT(12332.123456, 4)`,
+			result:     `VARCHAR("12,332.1235")`,
+		},
+		{
+			expression: `FORMAT(12332.2, 0)`,
+			result:     `VARCHAR("12,332")`,
+		},
+		{
+			expression: `FORMAT(-1234567.891e0, 2)`,
+			result:     `VARCHAR("-1,234,567.89")`,
+		},
+		{
+			expression: `FORMAT(12332.2, 2, 'de_DE')`,
+			result:     `VARCHAR("12.332,20")`,
+		},
+		{
+			expression: `FORMAT(1234567890.5, 1, 'en_IN')`,
+			result:     `VARCHAR("1,23,45,67,890.5")`,
+		},
+		{
+			expression: `FORMAT(1234567.5, 2, 'fr_FR')`,
+			result:     `VARCHAR("1234567,50")`,
+		},
+		{
+			expression: `FORMAT(1234.5, 1, 'xx_XX')`,
+			result:     `VARCHAR("1,234.5")`,
+		},
+		{
+			expression: `FORMAT(column0, 2)`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
 		{
 			expression: `DATE_FORMAT(timestamp '2024-12-30 10:34:58', "%u")`,
 			result:     `VARCHAR("53")`,
 		},
+		{
+			expression: `STR_TO_DATE('2024 10 Fri', '%x %v %a')`,
+			result:     `DATE("2024-03-08")`,
+		},
+		{
+			expression: `STR_TO_DATE('2024 10 Fri', '%X %v %a')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `STR_TO_DATE('01:30:15.25 PM', '%h:%i:%s.%f %p')`,
+			result:     `TIME("13:30:15.250000")`,
+		},
+		{
+			expression: `STR_TO_DATE(column0, column1)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("15.03.2024 10.11.12"), sqltypes.NewVarChar("%d.%m.%Y %H.%i.%s")},
+			result:     `DATETIME("2024-03-15 10:11:12.000000")`,
+		},
+		{
+			expression: `GET_FORMAT(DATETIME, 'eur')`,
+			result:     `VARCHAR("%Y-%m-%d %H.%i.%s")`,
+		},
+		{
+			expression: `GET_FORMAT(TIME, column0)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("bogus")},
+			result:     `NULL`,
+		},
 		{
 			expression: `WEEK(timestamp '2024-12-30 10:34:58', 0)`,
 			result:     `INT64(52)`,
@@ -841,6 +932,347 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `GREATEST(JSON_OBJECT(), JSON_ARRAY())`,
 			result:     `VARCHAR("{}")`,
 		},
+		{
+			expression: `column0 LIKE 'a|%' ESCAPE '|'`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("a%")},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `column0 NOT LIKE 'a|%' ESCAPE '|'`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("ab")},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `'a_c' LIKE 'a\\_c' ESCAPE NULL`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `'abc' LIKE _latin1 'a%%'`,
+			result:     `INT64(1)`,
+		},
+		{
+			// 0xA7 is '§' in latin1; the escape character is converted to latin1 to match it.
+			expression: `_latin1 'a_c' LIKE _latin1 0x61A75F63 ESCAPE '§'`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `_latin1 'abc' LIKE _latin1 0x61A75F63 ESCAPE '§'`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `column0 LIKE 'a|_c' ESCAPE '|'`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `TIME'01:00:00' - INTERVAL 2 HOUR`,
+			result:     `TIME("-01:00:00")`,
+		},
+		{
+			expression: `INTERVAL 30 MINUTE + column0`,
+			values:     []sqltypes.Value{sqltypes.NewTime("-01:00:00")},
+			result:     `TIME("-00:30:00")`,
+		},
+		{
+			expression: `column0 - INTERVAL 1 SECOND`,
+			values:     []sqltypes.Value{sqltypes.NewTime("00:00:00.5")},
+			result:     `TIME("-00:00:00.5")`,
+		},
+		{
+			expression: `TIME'838:59:59' + INTERVAL 1 SECOND`,
+			result:     `NULL`,
+		},
+		{
+			expression: `DATE_SUB(TIME'-838:59:59', INTERVAL 1 SECOND)`,
+			result:     `NULL`,
+		},
+		{
+			expression: `'10:00:00' - INTERVAL 11 HOUR`,
+			result:     `CHAR("-01:00:00")`,
+		},
+		{
+			expression: `ST_AsText(ST_GeomFromText('multipoint(1 1, 2 2)'))`,
+			result:     `VARCHAR("MULTIPOINT((1 1),(2 2))")`,
+		},
+		{
+			expression: `ST_X(column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf8\x3f\x00\x00\x00\x00\x00\x00\x00\xc0"))},
+			result:     `FLOAT64(1.5)`,
+		},
+		{
+			expression: `ST_AsText(ST_Y(column0, 5))`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Geometry, []byte("\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf8\x3f\x00\x00\x00\x00\x00\x00\x00\xc0"))},
+			result:     `VARCHAR("POINT(1.5 5)")`,
+		},
+		{
+			expression: `ST_X(column0)`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `ST_AsText(ST_GeomFromWKB(ST_AsBinary(ST_PolygonFromText('POLYGON((0 0,4 0,4 4,0 0))'))))`,
+			result:     `VARCHAR("POLYGON((0 0,4 0,4 4,0 0))")`,
+		},
+		{
+			expression: `ST_Distance(POINT(0, 0), ST_GeomFromText('LINESTRING(3 -1, 3 4)'))`,
+			result:     `FLOAT64(3)`,
+		},
+		{
+			expression: `ST_Distance(POINT(0, 0), ST_GeomFromText('GEOMETRYCOLLECTION EMPTY'))`,
+			result:     `NULL`,
+		},
+		{
+			expression: `ST_Contains(ST_GeomFromText('POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 2,1 1))'), POINT(column0, column0))`,
+			values:     []sqltypes.Value{sqltypes.NewFloat64(3)},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `ST_Contains(ST_GeomFromText('POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 2,1 1))'), POINT(column0, column0))`,
+			values:     []sqltypes.Value{sqltypes.NewFloat64(1.5)},
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_SET('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_INSERT('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 1, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_REPLACE('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3]}")`,
+		},
+		{
+			expression: `JSON_SET(JSON_OBJECT('a', 1), column0, JSON_ARRAY(2))`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("$.a[3]")},
+			result:     `JSON("{\"a\": [1, [2]]}")`,
+		},
+		{
+			expression: `JSON_MERGE_PRESERVE('{ "a": 1, "b": 2 }', '{ "a": 3, "c": 4 }', '{ "a": 5, "d": 6 }')`,
+			result:     `JSON("{\"a\": [1, 3, 5], \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{ "a": 1, "b": 2 }', '{ "a": 3, "c": 4 }', '{ "a": 5, "d": 6 }')`,
+			result:     `JSON("{\"a\": 5, \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(column0, '{"a": 1}')`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(column0, '[1]')`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `JSON("[1]")`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[1, 3, 5, 7]', '[2, 5, 7]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[1, 3, 5, 7]', '[2, 6, 8]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[[1, 2], [3, 4], 5]', '[1, [2, 3], [4, 5]]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('{"a": 1, "b": 10, "d": 10}', '{"c": 1, "e": 10, "f": 1, "d": 10}')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('{"a": 1}', '[{"a": 1}]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[4, 5, 6, 7]', '6.0')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('5', '"5"')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `17 MEMBER OF ('[23, "abc", 17, "ab", 10]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `'ab' MEMBER OF ('[23, "abc", 17, "ab", 10]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `'17' MEMBER OF ('[23, "abc", 17, "ab", 10]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `'[4, 5]' MEMBER OF ('[[3, 4], [4, 5]]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `CAST('[4, 5]' AS JSON) MEMBER OF ('[[3, 4], [4, 5]]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `1 MEMBER OF ('1')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `column0 MEMBER OF ('[1, 2]')`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `column0 + 1`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Bit, []byte{0x00, 0x05})},
+			result:     `UINT64(6)`,
+		},
+		{
+			expression: `column0 + 0`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Bit, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})},
+			result:     `UINT64(18446744073709551615)`,
+		},
+		{
+			expression: `column0 = 5`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Bit, []byte{0x05})},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `column0 | 2`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Bit, []byte{0x05})},
+			result:     `UINT64(7)`,
+		},
+		{
+			expression: `column0 * 1.5`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Bit, []byte{0x02})},
+			result:     `DECIMAL(3.0)`,
+		},
+		{
+			expression: `b'1000001' + 1`,
+			result:     `INT64(66)`,
+		},
+		{
+			expression: `hex(b'0000000000000001')`,
+			result:     `VARCHAR("0001")`,
+		},
+		{
+			expression: `length(b'')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `coercibility('foo' collate utf8mb4_bin)`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `coercibility(concat('foo', column0))`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("bar")},
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `coercibility(concat('foo' collate utf8mb4_bin, column0))`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("bar")},
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `coercibility(column0)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar("bar")},
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `coercibility(user())`,
+			result:     `INT64(3)`,
+		},
+		{
+			expression: `coercibility(concat(user(), 'foo'))`,
+			result:     `INT64(3)`,
+		},
+		{
+			expression: `coercibility('foo')`,
+			result:     `INT64(4)`,
+		},
+		{
+			expression: `coercibility(concat(_latin1 'foo', _utf8mb4 'bar'))`,
+			result:     `INT64(4)`,
+		},
+		{
+			expression: `coercibility(column0)`,
+			values:     []sqltypes.Value{sqltypes.NewInt64(1)},
+			result:     `INT64(5)`,
+		},
+		{
+			expression: `coercibility(null)`,
+			result:     `INT64(6)`,
+		},
+		{
+			expression: `find_in_set('b', 'a,b,c,d')`,
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `find_in_set('B', 'a,b,c,d')`,
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `find_in_set('B' collate utf8mb4_bin, 'a,b,c,d')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `find_in_set('', 'a,b,')`,
+			result:     `INT64(3)`,
+		},
+		{
+			expression: `find_in_set('', '')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `find_in_set('a,b', 'a,b,c')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `find_in_set(2, '1,2,3')`,
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `find_in_set(null, 'a,b')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `find_in_set('c', column0)`,
+			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Set, []byte("a,c"))},
+			result:     `INT64(2)`,
+		},
+		{
+			expression: `make_set(1 | 4, 'hello', 'nice', 'world')`,
+			result:     `VARCHAR("hello,world")`,
+		},
+		{
+			expression: `make_set(1 | 4, 'hello', 'nice', null, 'world')`,
+			result:     `VARCHAR("hello")`,
+		},
+		{
+			expression: `make_set(0, 'a', 'b', 'c')`,
+			result:     `VARCHAR("")`,
+		},
+		{
+			expression: `make_set(null, 'a', 'b', 'c')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `export_set(5, 'Y', 'N', ',', 4)`,
+			result:     `VARCHAR("Y,N,Y,N")`,
+		},
+		{
+			expression: `export_set(6, '1', '0', ',', 10)`,
+			result:     `VARCHAR("0,1,1,0,0,0,0,0,0,0")`,
+		},
+		{
+			expression: `export_set(6, '1', '0', '', -1)`,
+			result:     `VARCHAR("0110000000000000000000000000000000000000000000000000000000000000")`,
+		},
+		{
+			expression: `export_set(6, '1', '0', null)`,
+			result:     `NULL`,
+		},
 	}
 
 	tz, _ := time.LoadLocation("Europe/Madrid")
@@ -893,6 +1325,133 @@ func TestCompilerSingle(t *testing.T) {
 	}
 }
 
+func TestCompilerTimeZone(t *testing.T) {
+	testCases := []struct {
+		tz         string
+		expression string
+		result     string
+	}{
+		{tz: "UTC", expression: `now()`, result: `DATETIME("2023-10-24 12:00:00")`},
+		{tz: "Europe/Madrid", expression: `now()`, result: `DATETIME("2023-10-24 14:00:00")`},
+		{tz: "+05:30", expression: `now()`, result: `DATETIME("2023-10-24 17:30:00")`},
+		{tz: "-13:00", expression: `curdate()`, result: `DATE("2023-10-23")`},
+		{tz: "+14:00", expression: `curdate()`, result: `DATE("2023-10-25")`},
+		{tz: "+05:30", expression: `utc_timestamp()`, result: `DATETIME("2023-10-24 12:00:00")`},
+		{tz: "UTC", expression: `from_unixtime(0)`, result: `DATETIME("1970-01-01 00:00:00")`},
+		{tz: "-03:00", expression: `from_unixtime(0)`, result: `DATETIME("1969-12-31 21:00:00")`},
+		{tz: "Europe/Madrid", expression: `from_unixtime(1698148800.5)`, result: `DATETIME("2023-10-24 14:00:00.5")`},
+		{tz: "UTC", expression: `unix_timestamp()`, result: `INT64(1698148800)`},
+		{tz: "UTC", expression: `unix_timestamp('2023-10-24 12:00:00')`, result: `INT64(1698148800)`},
+		{tz: "+02:00", expression: `unix_timestamp('2023-10-24 12:00:00')`, result: `INT64(1698141600)`},
+		{tz: "Europe/Madrid", expression: `unix_timestamp('2023-12-24 12:00:00')`, result: `INT64(1703415600)`},
+		{tz: "+14:00", expression: `cast(time '10:00:00' as date)`, result: `DATE("2023-10-25")`},
+		{tz: "-13:00", expression: `cast(time '10:00:00' as datetime)`, result: `DATETIME("2023-10-23 10:00:00")`},
+		{tz: "+00:00", expression: `convert_tz('2023-10-24 12:00:00', 'SYSTEM', 'SYSTEM')`, result: `DATETIME("2023-10-24 12:00:00")`},
+	}
+
+	venv := vtenv.NewTestEnv()
+	now := time.Date(2023, 10, 24, 12, 0, 0, 0, time.UTC)
+	for _, tc := range testCases {
+		t.Run(tc.tz+"/"+tc.expression, func(t *testing.T) {
+			tz, err := datetime.ParseTimeZone(tc.tz)
+			require.NoError(t, err)
+
+			expr, err := venv.Parser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			cfg := &evalengine.Config{
+				Collation:         collations.CollationUtf8mb4ID,
+				Environment:       venv,
+				NoConstantFolding: true,
+			}
+			converted, err := evalengine.Translate(expr, cfg)
+			require.NoError(t, err)
+
+			env := evalengine.NewExpressionEnv(t.Context(), nil, evalengine.NewEmptyVCursor(venv, time.Local))
+			env.SetTime(now)
+			env.SetTimeZone(tz)
+
+			res, err := env.EvaluateAST(converted)
+			require.NoError(t, err)
+			assert.Equal(t, tc.result, res.String(), "bad evaluation from eval engine")
+
+			res, err = env.Evaluate(converted)
+			require.NoError(t, err)
+			assert.Equal(t, tc.result, res.String(), "bad evaluation from compiler")
+		})
+	}
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	venv := vtenv.NewTestEnv()
+	rows := [][]sqltypes.Value{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")},
+		{sqltypes.NewInt64(5), sqltypes.NewVarChar("b")},
+		{sqltypes.NULL, sqltypes.NewVarChar("c")},
+		{sqltypes.NewInt64(3), sqltypes.NULL},
+	}
+	fields := evalengine.FieldResolver(makeFields(rows[0]))
+
+	for _, compiled := range []bool{true, false} {
+		cfg := &evalengine.Config{
+			ResolveColumn: fields.Column,
+			Collation:     collations.CollationUtf8mb4ID,
+			Environment:   venv,
+		}
+		if compiled {
+			cfg.ResolveType = fields.Type
+		}
+
+		translate := func(expression string) evalengine.Expr {
+			expr, err := venv.Parser().ParseExpr(expression)
+			require.NoError(t, err)
+			converted, err := evalengine.Translate(expr, cfg)
+			require.NoError(t, err)
+			_, isCompiled := converted.(*evalengine.CompiledExpr)
+			require.Equal(t, compiled, isCompiled)
+			return converted
+		}
+
+		t.Run(fmt.Sprintf("compiled=%t", compiled), func(t *testing.T) {
+			exprs := []evalengine.Expr{
+				translate("column0 + 1"),
+				translate("concat(column1, '-', column0)"),
+				translate("column0 > 2"),
+			}
+
+			env := evalengine.NewExpressionEnv(t.Context(), nil, evalengine.NewEmptyVCursor(venv, time.UTC))
+			var expected []sqltypes.Row
+			for _, row := range rows {
+				env.Row = row
+				var result sqltypes.Row
+				for _, expr := range exprs {
+					res, err := env.Evaluate(expr)
+					require.NoError(t, err)
+					result = append(result, res.Value(collations.CollationUtf8mb4ID))
+				}
+				expected = append(expected, result)
+			}
+
+			for i, expr := range exprs {
+				results, err := env.EvaluateBatch(expr, rows, collations.CollationUtf8mb4ID)
+				require.NoError(t, err)
+				require.Len(t, results, len(rows))
+				for j, result := range results {
+					assert.Equal(t, expected[j][i].String(), result.String())
+				}
+			}
+
+			projected, err := env.ProjectBatch(exprs, rows, collations.CollationUtf8mb4ID)
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprint(expected), fmt.Sprint(projected))
+
+			filtered, err := env.FilterBatch(exprs[2], rows)
+			require.NoError(t, err)
+			assert.Equal(t, [][]sqltypes.Value{rows[1], rows[3]}, filtered)
+		})
+	}
+}
+
 func TestBindVarLiteral(t *testing.T) {
 	testCases := []struct {
 		expression string
@@ -1099,3 +1658,367 @@ func TestCompilerNonConstant(t *testing.T) {
 		})
 	}
 }
+
+func TestInTable(t *testing.T) {
+	var large strings.Builder
+	for i := range 1500 {
+		if i > 0 {
+			large.WriteString(", ")
+		}
+		fmt.Fprintf(&large, "%d", i*2)
+	}
+
+	testCases := []struct {
+		expression string
+		values     []sqltypes.Value
+		result     string
+	}{
+		{
+			expression: "column0 in (" + large.String() + ")",
+			values:     []sqltypes.Value{sqltypes.NewInt64(2998)},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (" + large.String() + ")",
+			values:     []sqltypes.Value{sqltypes.NewInt64(2999)},
+			result:     "INT64(0)",
+		},
+		{
+			expression: "column0 in (1, 2, 3)",
+			values:     []sqltypes.Value{sqltypes.NewUint64(2)},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1, 2, 3)",
+			values:     []sqltypes.Value{sqltypes.NewVarChar("2")},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1, 2, 3)",
+			values:     []sqltypes.Value{sqltypes.NewDecimal("2.0")},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1.50, 2.0)",
+			values:     []sqltypes.Value{sqltypes.NewDecimal("1.5")},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1, 2, '3')",
+			values:     []sqltypes.Value{sqltypes.NewInt64(3)},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in ('foo', 'bar')",
+			values:     []sqltypes.Value{sqltypes.NewVarChar("FOO")},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in ('foo' collate utf8mb4_bin, 'bar' collate utf8mb4_bin)",
+			values:     []sqltypes.Value{sqltypes.NewVarChar("FOO")},
+			result:     "INT64(0)",
+		},
+		{
+			expression: "column0 in ('foo', 'bar')",
+			values:     []sqltypes.Value{sqltypes.NewVarBinary("foo")},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1, 2, null)",
+			values:     []sqltypes.Value{sqltypes.NewInt64(2)},
+			result:     "INT64(1)",
+		},
+		{
+			expression: "column0 in (1, 2, null)",
+			values:     []sqltypes.Value{sqltypes.NewInt64(3)},
+			result:     "NULL",
+		},
+		{
+			expression: "column0 not in (1, 2, null)",
+			values:     []sqltypes.Value{sqltypes.NewInt64(3)},
+			result:     "NULL",
+		},
+		{
+			expression: "column0 not in (1, 2)",
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     "NULL",
+		},
+		{
+			expression: "column0 not in (1, 2)",
+			values:     []sqltypes.Value{sqltypes.NewInt64(3)},
+			result:     "INT64(1)",
+		},
+	}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		for _, folding := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%.50s/folding=%v", tc.expression, folding), func(t *testing.T) {
+				expr, err := venv.Parser().ParseExpr(tc.expression)
+				require.NoError(t, err)
+
+				fields := evalengine.FieldResolver(makeFields(tc.values))
+				cfg := &evalengine.Config{
+					ResolveColumn:     fields.Column,
+					ResolveType:       fields.Type,
+					Collation:         collations.CollationUtf8mb4ID,
+					Environment:       venv,
+					NoConstantFolding: !folding,
+				}
+
+				converted, err := evalengine.Translate(expr, cfg)
+				require.NoError(t, err)
+
+				env := evalengine.EmptyExpressionEnv(venv)
+				env.Row = tc.values
+
+				expected, err := env.EvaluateAST(converted)
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, expected.String(), "bad evaluation from eval engine")
+
+				res, err := env.Evaluate(converted)
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, res.String(), "bad evaluation from compiler")
+			})
+		}
+	}
+}
+
+func TestUserVariables(t *testing.T) {
+	testCases := []struct {
+		expression string
+		vars       evalengine.UserVariableMap
+		result     string
+		want       evalengine.UserVariableMap
+	}{{
+		expression: `@x`,
+		result:     `NULL`,
+	}, {
+		expression: `@X + 1`,
+		vars:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(3)},
+		result:     `INT64(4)`,
+	}, {
+		expression: `@x := 1 + 2`,
+		result:     `INT64(3)`,
+		want:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(3)},
+	}, {
+		expression: `@x := @x + 1`,
+		vars:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(5)},
+		result:     `INT64(6)`,
+		want:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(6)},
+	}, {
+		expression: `@Name := concat('a', 'b')`,
+		result:     `VARCHAR("ab")`,
+		want:       evalengine.UserVariableMap{"name": sqltypes.StringBindVariable("ab")},
+	}, {
+		expression: `(@x := 2) * @y`,
+		vars:       evalengine.UserVariableMap{"y": sqltypes.DecimalBindVariable("1.5")},
+		result:     `DECIMAL(3.0)`,
+		want: evalengine.UserVariableMap{
+			"x": sqltypes.Int64BindVariable(2),
+			"y": sqltypes.DecimalBindVariable("1.5"),
+		},
+	}, {
+		expression: `@x := null`,
+		vars:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(1)},
+		result:     `NULL`,
+		want:       evalengine.UserVariableMap{"x": sqltypes.NullBindVariable},
+	}, {
+		// the type of @x changes while the expression is evaluated
+		expression: `concat(@x := 'a', @x)`,
+		vars:       evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(1)},
+		result:     `VARCHAR("aa")`,
+		want:       evalengine.UserVariableMap{"x": sqltypes.StringBindVariable("a")},
+	}}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr, err := venv.Parser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			cfg := &evalengine.Config{
+				Collation:         collations.CollationUtf8mb4ID,
+				Environment:       venv,
+				NoConstantFolding: true,
+			}
+			converted, err := evalengine.Translate(expr, cfg)
+			require.NoError(t, err)
+
+			// every path starts from its own copy of the variables
+			vars := func() evalengine.UserVariableMap {
+				m := evalengine.UserVariableMap{}
+				maps.Copy(m, tc.vars)
+				return m
+			}
+			want := tc.want
+			if want == nil {
+				want = vars()
+			}
+
+			t.Run("eval", func(t *testing.T) {
+				env := evalengine.EmptyExpressionEnv(venv)
+				env.UserVars = vars()
+				res, err := env.EvaluateAST(converted)
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, res.String())
+				assert.Equal(t, want, env.UserVars)
+			})
+			t.Run("compiled", func(t *testing.T) {
+				env := evalengine.EmptyExpressionEnv(venv)
+				env.UserVars = vars()
+				compiled, ok := converted.(*evalengine.CompiledExpr)
+				if !ok {
+					compiled, err = converted.(*evalengine.UntypedExpr).Compile(env)
+					require.NoError(t, err)
+				}
+				res, err := env.EvaluateVM(compiled)
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, res.String())
+				assert.Equal(t, want, env.UserVars)
+			})
+		})
+	}
+
+	t.Run("no user variables", func(t *testing.T) {
+		expr, err := venv.Parser().ParseExpr(`@x := 1`)
+		require.NoError(t, err)
+		converted, err := evalengine.Translate(expr, &evalengine.Config{Environment: venv, NoConstantFolding: true})
+		require.NoError(t, err)
+
+		env := evalengine.EmptyExpressionEnv(venv)
+		_, err = env.Evaluate(converted)
+		assert.ErrorContains(t, err, "cannot assign user-defined variable @x")
+	})
+
+	t.Run("vcursor user variables", func(t *testing.T) {
+		expr, err := venv.Parser().ParseExpr(`@x := @x + 1`)
+		require.NoError(t, err)
+		converted, err := evalengine.Translate(expr, &evalengine.Config{Environment: venv, NoConstantFolding: true})
+		require.NoError(t, err)
+
+		vc := &userVariablesVCursor{
+			VCursor:         evalengine.NewEmptyVCursor(venv, time.Local),
+			UserVariableMap: evalengine.UserVariableMap{"x": sqltypes.Int64BindVariable(1)},
+		}
+		env := evalengine.NewExpressionEnv(context.Background(), nil, vc)
+		res, err := env.Evaluate(converted)
+		require.NoError(t, err)
+		assert.Equal(t, "INT64(2)", res.String())
+		assert.Equal(t, sqltypes.Int64BindVariable(2), vc.UserVariableMap["x"])
+	})
+}
+
+// userVariablesVCursor is a VCursor that stores the user-defined variables of its session.
+type userVariablesVCursor struct {
+	evalengine.VCursor
+	evalengine.UserVariableMap
+}
+
+func TestBlockEncryptionMode(t *testing.T) {
+	testCases := []struct {
+		expression string
+		mode       string
+		result     string
+		err        string
+	}{{
+		expression: `hex(aes_encrypt('vitess', 'secret'))`,
+		result:     `VARCHAR("83BECB50E32466858B87C5AADEDAE112")`,
+	}, {
+		expression: `hex(aes_encrypt('', 'secret', null))`,
+		mode:       "aes-128-ecb",
+		result:     `VARCHAR("40CBBF790B8073B4DF503ACC4FF1495E")`,
+	}, {
+		expression: `hex(aes_encrypt('vitess', 'secret', '1234567890123456'))`,
+		mode:       "AES-256-CBC",
+		result:     `VARCHAR("2C1F43DF9C4144C652CD98FCBFADE814")`,
+	}, {
+		expression: `hex(aes_encrypt('vitess', 'secret', '1234567890123456 is truncated'))`,
+		mode:       "aes-192-cfb8",
+		result:     `VARCHAR("07E4BBA8A817")`,
+	}, {
+		expression: `aes_decrypt(unhex('2C1F43DF9C4144C652CD98FCBFADE814'), 'secret', '1234567890123456')`,
+		mode:       "aes-256-cbc",
+		result:     `VARBINARY("vitess")`,
+	}, {
+		expression: `aes_decrypt(aes_encrypt('vitess', 'secret', '1234567890123456'), 'secret', '1234567890123456')`,
+		mode:       "aes-128-cfb1",
+		result:     `VARBINARY("vitess")`,
+	}, {
+		expression: `aes_decrypt(aes_encrypt('vitess', 'secret', '1234567890123456'), 'secret', '1234567890123456')`,
+		mode:       "aes-256-ofb",
+		result:     `VARBINARY("vitess")`,
+	}, {
+		// the padding of the decrypted data is not valid
+		expression: `aes_decrypt(unhex('83BECB50E32466858B87C5AADEDAE112'), 'other secret')`,
+		result:     `NULL`,
+	}, {
+		expression: `aes_decrypt('not a multiple of the block size', 'secret')`,
+		result:     `NULL`,
+	}, {
+		expression: `aes_encrypt(null, 'secret')`,
+		result:     `NULL`,
+	}, {
+		expression: `aes_encrypt('vitess', 'secret')`,
+		mode:       "aes-128-cbc",
+		err:        "Incorrect parameter count in the call to native function 'aes_encrypt'",
+	}, {
+		expression: `aes_encrypt('vitess', 'secret', '123')`,
+		mode:       "aes-128-cbc",
+		err:        "The initialization vector supplied to aes_encrypt is too short. Must be at least 16 bytes long",
+	}, {
+		expression: `aes_decrypt('vitess', 'secret', null)`,
+		mode:       "aes-128-cfb128",
+		err:        "The initialization vector supplied to aes_decrypt is too short. Must be at least 16 bytes long",
+	}, {
+		expression: `aes_encrypt('vitess', 'secret')`,
+		mode:       "aes-512-ecb",
+		err:        "Variable 'block_encryption_mode' can't be set to the value of 'aes-512-ecb'",
+	}}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		t.Run(tc.mode+"/"+tc.expression, func(t *testing.T) {
+			expr, err := venv.Parser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			cfg := &evalengine.Config{
+				Collation:   collations.CollationUtf8mb4ID,
+				Environment: venv,
+			}
+			converted, err := evalengine.Translate(expr, cfg)
+			require.NoError(t, err)
+
+			newEnv := func() *evalengine.ExpressionEnv {
+				env := evalengine.EmptyExpressionEnv(venv)
+				if tc.mode != "" {
+					env.SysVars = evalengine.SystemVariableMap{"block_encryption_mode": tc.mode}
+				}
+				return env
+			}
+			check := func(t *testing.T, res evalengine.EvalResult, err error) {
+				if tc.err != "" {
+					assert.ErrorContains(t, err, tc.err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, res.String())
+			}
+
+			t.Run("eval", func(t *testing.T) {
+				res, err := newEnv().EvaluateAST(converted)
+				check(t, res, err)
+			})
+			t.Run("compiled", func(t *testing.T) {
+				env := newEnv()
+				compiled, ok := converted.(*evalengine.CompiledExpr)
+				if !ok {
+					compiled, err = converted.(*evalengine.UntypedExpr).Compile(env)
+					require.NoError(t, err)
+				}
+				res, err := env.EvaluateVM(compiled)
+				check(t, res, err)
+			})
+		})
+	}
+}
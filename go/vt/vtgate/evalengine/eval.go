@@ -90,6 +90,13 @@ func evalToSQLValue(e eval) sqltypes.Value {
 
 func evalToSQLValueWithType(e eval, resultType Type) sqltypes.Value {
 	tt := resultType.Type()
+	if b, ok := e.(*evalBytes); ok && b.isBitLiteral() && sqltypes.IsNumber(tt) {
+		bit, ok := b.toNumericBit()
+		if !ok {
+			return sqltypes.NULL
+		}
+		e = bit
+	}
 	switch {
 	case sqltypes.IsSigned(tt):
 		switch e := e.(type) {
@@ -125,6 +132,8 @@ func evalToSQLValueWithType(e eval, resultType Type) sqltypes.Value {
 			dec := numeric.toDecimal(resultType.size, resultType.scale)
 			return sqltypes.MakeTrusted(tt, dec.dec.FormatMySQL(dec.length))
 		}
+	case tt == sqltypes.Bit && e != nil:
+		return sqltypes.MakeTrusted(tt, evalToBit(e, resultType.size))
 	case e != nil:
 		return sqltypes.MakeTrusted(tt, e.ToRawBytes())
 	}
@@ -159,7 +168,7 @@ func evalIsTruthy(e eval) boolean {
 				// overflow
 				return makeboolean(true)
 			}
-			return makeboolean(bit.i != 0)
+			return makeboolean(bit.toUint64().u != 0)
 		}
 		f, _ := fastparse.ParseFloat64(e.string())
 		return makeboolean(f != 0.0)
@@ -365,6 +374,15 @@ func valueToEvalCast(v sqltypes.Value, typ sqltypes.Type, collation collations.I
 		return newEvalEnum(v.Raw(), values), nil
 	case typ == sqltypes.Set:
 		return newEvalSet(v.Raw(), values), nil
+	case typ == sqltypes.Bit:
+		if v.Type() == sqltypes.Bit {
+			return newEvalBit(v.Raw()), nil
+		}
+		e, err := valueToEval(v, typedCoercionCollation(v.Type(), collation), values)
+		if err != nil || e == nil {
+			return nil, err
+		}
+		return newEvalBit(evalToBit(e, 0)), nil
 	}
 	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "coercion should not try to coerce this value: %v", v)
 }
@@ -406,6 +424,10 @@ func valueToEval(value sqltypes.Value, collation collations.TypedCollation, valu
 		return newEvalSet(value.Raw(), values), nil
 	case tt == sqltypes.Vector:
 		return newEvalVector(value.Raw()), nil
+	case tt == sqltypes.Geometry:
+		return newEvalGeometry(value.Raw()), nil
+	case tt == sqltypes.Bit:
+		return newEvalBit(value.Raw()), nil
 	case sqltypes.IsText(tt):
 		switch tt {
 		case sqltypes.HexNum:
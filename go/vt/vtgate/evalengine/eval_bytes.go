@@ -55,6 +55,13 @@ func newEvalBytesBit(raw []byte) eval {
 	return &evalBytes{tt: int16(sqltypes.VarBinary), flag: flagBit, col: collationBinary, bytes: raw}
 }
 
+// newEvalBit creates a new evalBytes for the value of a BIT column. Like bit
+// literals, BIT values are binary strings that behave as integers in numeric
+// contexts.
+func newEvalBit(raw []byte) *evalBytes {
+	return &evalBytes{tt: int16(sqltypes.Bit), flag: flagBit, col: collationBinary, bytes: raw}
+}
+
 func newEvalBinary(raw []byte) *evalBytes {
 	return newEvalRaw(sqltypes.VarBinary, raw, collationBinary)
 }
@@ -74,6 +81,55 @@ func evalToBinary(e eval) *evalBytes {
 	return newEvalBinary(e.ToRawBytes())
 }
 
+// evalToBit returns the raw bytes of e as a value of a BIT(size) column.
+// Numbers are encoded as big-endian integers and strings are used as they
+// are. The result is padded on the left to the width of the column, and
+// values that do not fit in size bits are clamped to the largest BIT(size)
+// value, like MySQL does outside of strict mode. When size is not known,
+// numbers are encoded with as few bytes as possible.
+func evalToBit(e eval, size int32) []byte {
+	var raw []byte
+	switch e := e.(type) {
+	case *evalBytes:
+		raw = e.bytes
+	case evalNumeric:
+		raw = binary.BigEndian.AppendUint64(nil, e.toUint64().u)
+		for len(raw) > 1 && raw[0] == 0 {
+			raw = raw[1:]
+		}
+	default:
+		raw = e.ToRawBytes()
+	}
+	if size <= 0 || size > 64 {
+		return raw
+	}
+
+	width := int(size+7) / 8
+	overflow := false
+	if len(raw) > width {
+		for _, b := range raw[:len(raw)-width] {
+			overflow = overflow || b != 0
+		}
+		raw = raw[len(raw)-width:]
+	}
+	if bits := size % 8; bits != 0 && len(raw) == width && raw[0]>>bits != 0 {
+		overflow = true
+	}
+
+	bit := make([]byte, width)
+	if overflow {
+		for i := range bit {
+			bit[i] = 0xff
+		}
+		if bits := size % 8; bits != 0 {
+			bit[0] = 1<<bits - 1
+		}
+		return bit
+	}
+	copy(bit[width-len(raw):], raw)
+	return bit
+}
+
 func evalToVarchar(e eval, col collations.ID, convert bool) (*evalBytes, error) {
 	var bytes []byte
 	var typedcol collations.TypedCollation
@@ -219,11 +275,16 @@ func (e *evalBytes) toNumericHex() (*evalUint64, bool) {
 	return hex, true
 }
 
-func (e *evalBytes) toNumericBit() (*evalInt64, bool) {
+// toNumericBit returns the numeric value of a bit literal or of a BIT value.
+// Bit literals are signed integers, but the values of BIT columns are unsigned.
+func (e *evalBytes) toNumericBit() (evalNumeric, bool) {
 	var number [8]byte
 	if !e.parseNumericBytes(&number) {
 		return nil, false
 	}
+	if e.SQLType() == sqltypes.Bit {
+		return newEvalUint64(binary.BigEndian.Uint64(number[:])), true
+	}
 	bit := newEvalInt64(int64(binary.BigEndian.Uint64(number[:])))
 	bit.bitLiteral = true
 	return bit, true
@@ -0,0 +1,545 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/format"
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// geometryType is the type of a geometry, as encoded in WKB.
+type geometryType uint32
+
+const (
+	geometryAny geometryType = iota
+	geometryPoint
+	geometryLineString
+	geometryPolygon
+	geometryMultiPoint
+	geometryMultiLineString
+	geometryMultiPolygon
+	geometryCollection
+)
+
+var geometryTypeNames = [...]string{
+	geometryAny:             "GEOMETRY",
+	geometryPoint:           "POINT",
+	geometryLineString:      "LINESTRING",
+	geometryPolygon:         "POLYGON",
+	geometryMultiPoint:      "MULTIPOINT",
+	geometryMultiLineString: "MULTILINESTRING",
+	geometryMultiPolygon:    "MULTIPOLYGON",
+	geometryCollection:      "GEOMETRYCOLLECTION",
+}
+
+func (t geometryType) String() string {
+	if int(t) < len(geometryTypeNames) {
+		return geometryTypeNames[t]
+	}
+	return "GEOMETRY"
+}
+
+// elementType returns the type of the geometries that a multi geometry is
+// made of, or geometryAny for geometry collections.
+func (t geometryType) elementType() geometryType {
+	switch t {
+	case geometryMultiPoint:
+		return geometryPoint
+	case geometryMultiLineString:
+		return geometryLineString
+	case geometryMultiPolygon:
+		return geometryPolygon
+	default:
+		return geometryAny
+	}
+}
+
+type geomPoint struct {
+	x, y float64
+}
+
+// geometry is a decoded spatial value. Points and line strings store their
+// coordinates in points, polygons store their rings (the exterior ring
+// first) in rings, and multi geometries and geometry collections store their
+// elements in geoms.
+type geometry struct {
+	srid   uint32
+	typ    geometryType
+	points []geomPoint
+	rings  [][]geomPoint
+	geoms  []*geometry
+}
+
+// isEmpty returns whether the geometry is a geometry collection without any
+// non-empty elements.
+func (g *geometry) isEmpty() bool {
+	if g.typ != geometryCollection {
+		return false
+	}
+	for _, e := range g.geoms {
+		if !e.isEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+
+	// geometrySRIDLength is the length of the SRID that prefixes the WKB
+	// of a geometry in MySQL's internal format.
+	geometrySRIDLength = 4
+)
+
+func errInvalidGISData(method string) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid GIS data provided to function %s.", strings.ToLower(method))
+}
+
+func errUnexpectedGeometryType(method string, want, got geometryType) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s value is a geometry of unexpected type %s in %s.", want, got, strings.ToLower(method))
+}
+
+// errUnsupportedSRID is returned for geometries in a spatial reference
+// system other than the default Cartesian plane (SRID 0). Evaluating them
+// requires the definition of the system, which only MySQL knows about.
+func errUnsupportedSRID(method string, srid uint32) error {
+	return vterrors.VT12001(fmt.Sprintf("spatial reference system %d in %s", srid, strings.ToLower(method)))
+}
+
+func newEvalGeometry(raw []byte) *evalBytes {
+	return newEvalRaw(sqltypes.Geometry, raw, collationBinary)
+}
+
+// marshal returns the geometry in MySQL's internal format: the SRID as a
+// little endian 32 bit integer, followed by the WKB of the geometry.
+func (g *geometry) marshal() []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, g.srid)
+	return g.appendWKB(buf)
+}
+
+func (g *geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.typ))
+	switch g.typ {
+	case geometryPoint:
+		buf = appendWKBPoint(buf, g.points[0])
+	case geometryLineString:
+		buf = appendWKBPoints(buf, g.points)
+	case geometryPolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.rings)))
+		for _, ring := range g.rings {
+			buf = appendWKBPoints(buf, ring)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.geoms)))
+		for _, e := range g.geoms {
+			buf = e.appendWKB(buf)
+		}
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p geomPoint) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.x))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.y))
+}
+
+func appendWKBPoints(buf []byte, points []geomPoint) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+// parseGeometry decodes a geometry in MySQL's internal format.
+func parseGeometry(method string, raw []byte) (*geometry, error) {
+	if len(raw) < geometrySRIDLength {
+		return nil, errInvalidGISData(method)
+	}
+	srid := binary.LittleEndian.Uint32(raw)
+	return parseWKB(method, raw[geometrySRIDLength:], srid, geometryAny)
+}
+
+// parseWKB decodes a geometry from its WKB, in either byte order. The
+// geometry must be of type want, unless want is geometryAny.
+func parseWKB(method string, wkb []byte, srid uint32, want geometryType) (*geometry, error) {
+	p := &wkbParser{buf: wkb}
+	g := p.geometry(srid, want)
+	if p.err || len(p.buf) != 0 {
+		return nil, errInvalidGISData(method)
+	}
+	return g, nil
+}
+
+type wkbParser struct {
+	buf []byte
+	err bool
+}
+
+func (p *wkbParser) read(n int) []byte {
+	if p.err || len(p.buf) < n {
+		p.err = true
+		return nil
+	}
+	b := p.buf[:n]
+	p.buf = p.buf[n:]
+	return b
+}
+
+func (p *wkbParser) uint32(order binary.ByteOrder) uint32 {
+	if b := p.read(4); b != nil {
+		return order.Uint32(b)
+	}
+	return 0
+}
+
+func (p *wkbParser) point(order binary.ByteOrder) geomPoint {
+	b := p.read(16)
+	if b == nil {
+		return geomPoint{}
+	}
+	pt := geomPoint{
+		x: math.Float64frombits(order.Uint64(b)),
+		y: math.Float64frombits(order.Uint64(b[8:])),
+	}
+	if math.IsNaN(pt.x) || math.IsNaN(pt.y) || math.IsInf(pt.x, 0) || math.IsInf(pt.y, 0) {
+		p.err = true
+	}
+	return pt
+}
+
+func (p *wkbParser) points(order binary.ByteOrder, minimum int) []geomPoint {
+	n := p.uint32(order)
+	// every point takes 16 bytes, so a count larger than what is left in
+	// the buffer can only come from corrupt data
+	if p.err || n < uint32(minimum) || uint64(n)*16 > uint64(len(p.buf)) {
+		p.err = true
+		return nil
+	}
+	points := make([]geomPoint, 0, n)
+	for range n {
+		points = append(points, p.point(order))
+	}
+	return points
+}
+
+func (p *wkbParser) geometry(srid uint32, want geometryType) *geometry {
+	var order binary.ByteOrder
+	switch b := p.read(1); {
+	case b == nil:
+		return nil
+	case b[0] == wkbLittleEndian:
+		order = binary.LittleEndian
+	case b[0] == wkbBigEndian:
+		order = binary.BigEndian
+	default:
+		p.err = true
+		return nil
+	}
+
+	g := &geometry{srid: srid, typ: geometryType(p.uint32(order))}
+	if p.err || g.typ < geometryPoint || g.typ > geometryCollection || (want != geometryAny && g.typ != want) {
+		p.err = true
+		return nil
+	}
+
+	switch g.typ {
+	case geometryPoint:
+		g.points = []geomPoint{p.point(order)}
+	case geometryLineString:
+		g.points = p.points(order, 2)
+	case geometryPolygon:
+		n := p.uint32(order)
+		if n == 0 || uint64(n)*4 > uint64(len(p.buf)) {
+			p.err = true
+			return nil
+		}
+		for range n {
+			ring := p.points(order, 4)
+			if p.err || ring[0] != ring[len(ring)-1] {
+				p.err = true
+				return nil
+			}
+			g.rings = append(g.rings, ring)
+		}
+	default:
+		n := p.uint32(order)
+		// a multi geometry has at least one element, and every element
+		// takes at least 5 bytes
+		if (n == 0 && g.typ != geometryCollection) || uint64(n)*5 > uint64(len(p.buf)) {
+			p.err = true
+			return nil
+		}
+		for range n {
+			e := p.geometry(srid, g.typ.elementType())
+			if p.err {
+				return nil
+			}
+			g.geoms = append(g.geoms, e)
+		}
+	}
+	if p.err {
+		return nil
+	}
+	return g
+}
+
+// appendWKT appends the WKT of the geometry, formatted the way MySQL does.
+func (g *geometry) appendWKT(buf []byte) []byte {
+	buf = append(buf, g.typ.String()...)
+	if g.typ == geometryCollection && len(g.geoms) == 0 {
+		return append(buf, " EMPTY"...)
+	}
+	return g.appendWKTBody(buf)
+}
+
+func (g *geometry) appendWKTBody(buf []byte) []byte {
+	buf = append(buf, '(')
+	switch g.typ {
+	case geometryPoint:
+		buf = appendWKTPoint(buf, g.points[0])
+	case geometryLineString:
+		buf = appendWKTPoints(buf, g.points)
+	case geometryPolygon:
+		for i, ring := range g.rings {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, '(')
+			buf = appendWKTPoints(buf, ring)
+			buf = append(buf, ')')
+		}
+	case geometryCollection:
+		for i, e := range g.geoms {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = e.appendWKT(buf)
+		}
+	default:
+		for i, e := range g.geoms {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = e.appendWKTBody(buf)
+		}
+	}
+	return append(buf, ')')
+}
+
+func appendWKTPoint(buf []byte, p geomPoint) []byte {
+	buf = append(buf, format.FormatFloat(p.x)...)
+	buf = append(buf, ' ')
+	return append(buf, format.FormatFloat(p.y)...)
+}
+
+func appendWKTPoints(buf []byte, points []geomPoint) []byte {
+	for i, p := range points {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendWKTPoint(buf, p)
+	}
+	return buf
+}
+
+// parseWKT decodes a geometry from its WKT. Keywords are case insensitive
+// and the points of a MULTIPOINT may be written with or without parentheses.
+// The geometry must be of type want, unless want is geometryAny.
+func parseWKT(method string, wkt []byte, srid uint32, want geometryType) (*geometry, error) {
+	p := &wktParser{buf: wkt}
+	g := p.geometry(srid, want)
+	p.skipSpace()
+	if p.err || p.pos != len(p.buf) {
+		return nil, errInvalidGISData(method)
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	buf []byte
+	pos int
+	err bool
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.buf) {
+		switch p.buf[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.buf) {
+		c := p.buf[p.pos] | 0x20
+		if c < 'a' || c > 'z' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.buf[start:p.pos])
+}
+
+// consume skips the given byte if it is the next token, and returns whether
+// it was found.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.buf) && p.buf[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) {
+	if !p.err && !p.consume(c) {
+		p.err = true
+	}
+}
+
+func (p *wktParser) number() float64 {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.buf) {
+		c := p.buf[p.pos]
+		if (c < '0' || c > '9') && c != '.' && c != '-' && c != '+' && c != 'e' && c != 'E' {
+			break
+		}
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(string(p.buf[start:p.pos]), 64)
+	if err != nil || math.IsInf(f, 0) {
+		p.err = true
+	}
+	return f
+}
+
+func (p *wktParser) point() geomPoint {
+	return geomPoint{x: p.number(), y: p.number()}
+}
+
+// points parses a parenthesized list of points.
+func (p *wktParser) points(minimum int) []geomPoint {
+	p.expect('(')
+	var points []geomPoint
+	for !p.err {
+		points = append(points, p.point())
+		if !p.consume(',') {
+			break
+		}
+	}
+	p.expect(')')
+	if len(points) < minimum {
+		p.err = true
+	}
+	return points
+}
+
+func (p *wktParser) geometry(srid uint32, want geometryType) *geometry {
+	name := p.word()
+	g := &geometry{srid: srid}
+	for t := geometryPoint; t <= geometryCollection; t++ {
+		if strings.EqualFold(name, t.String()) {
+			g.typ = t
+		}
+	}
+	if g.typ == geometryAny || (want != geometryAny && g.typ != want) {
+		p.err = true
+		return nil
+	}
+	p.body(g)
+	return g
+}
+
+// body parses the parenthesized coordinates of a geometry whose type has
+// already been parsed.
+func (p *wktParser) body(g *geometry) {
+	switch g.typ {
+	case geometryPoint:
+		p.expect('(')
+		g.points = []geomPoint{p.point()}
+		p.expect(')')
+	case geometryLineString:
+		g.points = p.points(2)
+	case geometryPolygon:
+		p.expect('(')
+		for !p.err {
+			ring := p.points(4)
+			if p.err || ring[0] != ring[len(ring)-1] {
+				p.err = true
+				return
+			}
+			g.rings = append(g.rings, ring)
+			if !p.consume(',') {
+				break
+			}
+		}
+		p.expect(')')
+	case geometryCollection:
+		save := p.pos
+		if strings.EqualFold(p.word(), "empty") {
+			return
+		}
+		p.pos = save
+		p.expect('(')
+		if p.consume(')') {
+			return
+		}
+		for !p.err {
+			g.geoms = append(g.geoms, p.geometry(g.srid, geometryAny))
+			if !p.consume(',') {
+				break
+			}
+		}
+		p.expect(')')
+	default:
+		p.expect('(')
+		for !p.err {
+			e := &geometry{srid: g.srid, typ: g.typ.elementType()}
+			if e.typ == geometryPoint && !p.consume('(') {
+				e.points = []geomPoint{p.point()}
+			} else if e.typ == geometryPoint {
+				e.points = []geomPoint{p.point()}
+				p.expect(')')
+			} else {
+				p.body(e)
+			}
+			g.geoms = append(g.geoms, e)
+			if !p.consume(',') {
+				break
+			}
+		}
+		p.expect(')')
+	}
+}
@@ -319,7 +319,7 @@ func evalToInt64(e eval) *evalInt64 {
 				// overflow
 				return newEvalInt64(0)
 			}
-			return bit
+			return bit.toInt64()
 		}
 		i, _ := fastparse.ParseInt64(e.string(), 10)
 		return newEvalInt64(i)
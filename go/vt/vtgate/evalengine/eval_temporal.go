@@ -195,7 +195,7 @@ func (e *evalTemporal) addInterval(interval *datetime.Interval, coll collations.
 		tmp.dt.Date, ok = e.dt.Date.AddInterval(interval)
 	case tt == sqltypes.Time && !interval.Unit().HasDateParts():
 		tmp = &evalTemporal{t: e.t}
-		tmp.dt.Time, tmp.prec, ok = e.dt.Time.AddInterval(interval, coll != collations.Unknown)
+		tmp.dt.Time, tmp.prec, ok = e.dt.Time.AddInterval(interval, e.prec, coll != collations.Unknown)
 	case tt == sqltypes.Datetime || tt == sqltypes.Timestamp || (tt == sqltypes.Date && interval.Unit().HasTimeParts()) || (tt == sqltypes.Time && interval.Unit().HasDateParts()):
 		tmp = e.toDateTime(int(e.prec), now)
 		tmp.dt, tmp.prec, ok = e.dt.AddInterval(interval, tmp.prec, coll != collations.Unknown)
@@ -108,6 +108,8 @@ func (bv *BindVariable) typeof(env *ExpressionEnv) (ctype, error) {
 		return ctype{Type: sqltypes.VarBinary, Flag: flagHex | flagNullable, Col: collationNumeric}, nil
 	case sqltypes.BitNum:
 		return ctype{Type: sqltypes.VarBinary, Flag: flagBit | flagNullable, Col: collationNumeric}, nil
+	case sqltypes.Bit:
+		return ctype{Type: sqltypes.Bit, Flag: flagBit | flagNullable, Col: collationBinary}, nil
 	default:
 		return ctype{Type: tt, Flag: flagNullable, Col: typedCoercionCollation(tt, collations.CollationForType(tt, bv.Collation))}, nil
 	}
@@ -172,6 +174,11 @@ func (bvar *BindVariable) compile(c *compiler) (ctype, error) {
 		c.asm.PushBVar_time(bvar.Key)
 	case tt == sqltypes.Vector:
 		c.asm.PushBVar_vector(bvar.Key)
+	case tt == sqltypes.Geometry:
+		c.asm.PushBVar_geometry(bvar.Key)
+	case tt == sqltypes.Bit:
+		c.asm.PushBVar_bit(bvar.Key)
+		typ.Flag |= flagBit
 	case tt == sqltypes.Tuple:
 		c.asm.PushBVar_tuple(bvar.Key)
 	default:
@@ -55,6 +55,14 @@ var collationUtf8mb3 = collations.TypedCollation{
 	Repertoire:   collations.RepertoireUnicode,
 }
 
+// collationSysconst is the collation of the values of system functions such as
+// USER() or VERSION(), which are stronger than literals but weaker than columns
+var collationSysconst = collations.TypedCollation{
+	Collation:    collations.CollationUtf8mb3ID,
+	Coercibility: collations.CoerceSysconst,
+	Repertoire:   collations.RepertoireUnicode,
+}
+
 var collationRegexpFallback = collations.TypedCollation{
 	Collation:    collations.CollationLatin1Swedish,
 	Coercibility: collations.CoerceCoercible,
@@ -140,7 +148,7 @@ func introducerCast(e eval, col collations.ID) (*evalBytes, error) {
 
 	var bytes []byte
 	if b, ok := e.(*evalBytes); !ok {
-		bytes = b.ToRawBytes()
+		bytes = e.ToRawBytes()
 	} else {
 		cs := colldata.Lookup(col).Charset()
 		bytes = b.bytes
@@ -18,8 +18,10 @@ package evalengine
 
 import (
 	"bytes"
+	"cmp"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
 	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -40,6 +42,8 @@ type (
 
 	LikeExpr struct {
 		BinaryExpr
+		// Escape is the ESCAPE clause of the expression, or nil if it has none.
+		Escape         IR
 		Negate         bool
 		Match          colldata.WildcardPattern
 		MatchCollation collations.ID
@@ -48,6 +52,10 @@ type (
 	InExpr struct {
 		BinaryExpr
 		Negate bool
+
+		// table is the hash set of the right side of the expression when it
+		// is a tuple of literals, or nil.
+		table *inTable
 	}
 
 	ComparisonOp interface {
@@ -255,6 +263,8 @@ func evalCompare(left, right eval, collationEnv *collations.Environment) (comp i
 		return compareJSON(left, right)
 	case lt == sqltypes.Tuple || rt == sqltypes.Tuple:
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "BUG: evalCompare: tuple comparison should be handled early")
+	case lt == sqltypes.Bit && rt == sqltypes.Bit:
+		return compareBits(left.(*evalBytes), right.(*evalBytes)), nil
 	case lt == rt && fallbackBinary(lt):
 		return bytes.Compare(left.ToRawBytes(), right.ToRawBytes()), nil
 	default:
@@ -277,12 +287,23 @@ func evalCompare(left, right eval, collationEnv *collations.Environment) (comp i
 // TODO: Clean this up as we add more properly supported types and comparisons.
 func fallbackBinary(t sqltypes.Type) bool {
 	switch t {
-	case sqltypes.Bit, sqltypes.Enum, sqltypes.Set, sqltypes.Geometry, sqltypes.Vector:
+	case sqltypes.Enum, sqltypes.Set, sqltypes.Geometry, sqltypes.Vector:
 		return true
 	}
 	return false
 }
 
+// compareBits compares two BIT values by their numeric value, so that values
+// of columns with different widths are compared correctly.
+func compareBits(left, right *evalBytes) int {
+	l, lok := left.toNumericBit()
+	r, rok := right.toNumericBit()
+	if !lok || !rok {
+		return bytes.Compare(left.bytes, right.bytes)
+	}
+	return cmp.Compare(l.toUint64().u, r.toUint64().u)
+}
+
 func evalCompareTuplesNullSafe(left, right []eval, collationEnv *collations.Environment) (int, error) {
 	if len(left) != len(right) {
 		panic("did not typecheck cardinality")
@@ -399,6 +420,10 @@ func (expr *ComparisonExpr) compile(c *compiler) (ctype, error) {
 		}
 	case compareAsSameNumericType(lt.Type, rt.Type) || compareAsDecimal(lt.Type, rt.Type):
 		swapped = c.compareNumericTypes(lt, rt)
+	case lt.Type == sqltypes.Bit && rt.Type == sqltypes.Bit:
+		lt = c.compileToNumeric(lt, 2, sqltypes.Uint64, false)
+		rt = c.compileToNumeric(rt, 1, sqltypes.Uint64, false)
+		swapped = c.compareNumericTypes(lt, rt)
 	case compareAsDateAndString(lt.Type, rt.Type):
 		c.asm.CmpDateString()
 	case compareAsDateAndNumeric(lt.Type, rt.Type):
@@ -510,57 +535,142 @@ func evalInExpr(collationEnv *collations.Environment, lhs eval, rhs *evalTuple)
 	}
 }
 
-// eval implements the ComparisonOp interface
-func (i *InExpr) eval(env *ExpressionEnv) (eval, error) {
-	left, right, err := i.arguments(env)
-	if err != nil {
-		return nil, err
-	}
-	rtuple, ok := right.(*evalTuple)
-	if !ok {
-		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "rhs of an In operation should be a tuple")
-	}
-	in, err := evalInExpr(env.collationEnv, left, rtuple)
-	if err != nil {
-		return nil, err
-	}
-	if i.Negate {
-		in = in.not()
+// inTableKind is the kind of values of an inTable. Values of different kinds
+// have different hashes even if they compare equal, so a table only has values
+// of one kind.
+type inTableKind uint8
+
+const (
+	inTableIntegral inTableKind = iota + 1
+	inTableDecimal
+	inTableText
+)
+
+// inTable is the hash set of a tuple of literals on the right side of an IN
+// expression, so that looking a value up in a large tuple doesn't compare it
+// with every value of the tuple. Text values are hashed with the weight string
+// of their collation. The values that don't have the kind of the table, such
+// as the strings compared with a tuple of numbers, are compared one by one
+// with the values of the tuple like in evalInExpr.
+type inTable struct {
+	kind      inTableKind
+	collation collations.ID
+	hashes    map[vthash.Hash]struct{}
+	hasNull   bool
+	tuple     *evalTuple
+}
+
+func inTableKindOf(e eval) (inTableKind, collations.ID, bool) {
+	switch e := e.(type) {
+	case *evalInt64, *evalUint64:
+		return inTableIntegral, collations.Unknown, true
+	case *evalDecimal:
+		return inTableDecimal, collations.Unknown, true
+	case *evalBytes:
+		if sqltypes.IsText(e.SQLType()) {
+			return inTableText, e.col.Collation, true
+		}
 	}
-	return in.eval(), nil
+	return 0, collations.Unknown, false
 }
 
-func (i *InExpr) compileTable(lhs ctype, rhs TupleExpr) map[vthash.Hash]struct{} {
-	var (
-		table  = make(map[vthash.Hash]struct{})
-		hasher = vthash.New()
-	)
-
-	for _, expr := range rhs {
+// newInTable returns the hash set of a tuple of literals, or nil if the tuple
+// has values that aren't literals, or literals of different kinds.
+func newInTable(tuple TupleExpr) *inTable {
+	table := &inTable{
+		hashes: make(map[vthash.Hash]struct{}, len(tuple)),
+		tuple:  &evalTuple{t: make([]eval, 0, len(tuple))},
+	}
+	hasher := vthash.New()
+	for _, expr := range tuple {
 		lit, ok := expr.(*Literal)
 		if !ok {
 			return nil
 		}
-		inner, ok := lit.inner.(hashable)
+		table.tuple.t = append(table.tuple.t, lit.inner)
+		if lit.inner == nil {
+			table.hasNull = true
+			continue
+		}
+
+		kind, collation, ok := inTableKindOf(lit.inner)
 		if !ok {
 			return nil
 		}
-
-		thisColl := evalCollation(lit.inner).Collation
-		thisTyp := lit.inner.SQLType()
-
-		if thisTyp != lhs.Type || thisColl != lhs.Col.Collation {
+		if table.kind == 0 {
+			table.kind, table.collation = kind, collation
+		} else if kind != table.kind || collation != table.collation {
 			return nil
 		}
 
-		inner.Hash(&hasher)
-		table[hasher.Sum128()] = struct{}{}
+		lit.inner.(hashable).Hash(&hasher)
+		table.hashes[hasher.Sum128()] = struct{}{}
 		hasher.Reset()
 	}
-
+	if table.kind == 0 {
+		// a tuple of NULLs
+		return nil
+	}
 	return table
 }
 
+// lookup returns whether lhs is IN the tuple of the table, using the given
+// hasher if lhs can be looked up in the table.
+func (table *inTable) lookup(collationEnv *collations.Environment, hasher *vthash.Hasher, lhs eval) (boolean, error) {
+	if lhs == nil {
+		return boolNULL, nil
+	}
+	kind, collation, ok := inTableKindOf(lhs)
+	if !ok || kind != table.kind || collation != table.collation {
+		return evalInExpr(collationEnv, lhs, table.tuple)
+	}
+
+	hasher.Reset()
+	lhs.(hashable).Hash(hasher)
+	_, found := table.hashes[hasher.Sum128()]
+	switch {
+	case found:
+		return boolTrue, nil
+	case table.hasNull:
+		return boolNULL, nil
+	default:
+		return boolFalse, nil
+	}
+}
+
+// eval implements the ComparisonOp interface
+func (i *InExpr) eval(env *ExpressionEnv) (eval, error) {
+	var in boolean
+	if i.table != nil {
+		left, err := i.Left.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		hasher := vthash.New()
+		in, err = i.table.lookup(env.collationEnv, &hasher, left)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		left, right, err := i.arguments(env)
+		if err != nil {
+			return nil, err
+		}
+		rtuple, ok := right.(*evalTuple)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "rhs of an In operation should be a tuple")
+		}
+		in, err = evalInExpr(env.collationEnv, left, rtuple)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if i.Negate {
+		in = in.not()
+	}
+	return in.eval(), nil
+}
+
 func (expr *InExpr) compile(c *compiler) (ctype, error) {
 	lhs, err := expr.Left.compile(c)
 	if err != nil {
@@ -569,9 +679,17 @@ func (expr *InExpr) compile(c *compiler) (ctype, error) {
 
 	switch rhs := expr.Right.(type) {
 	case TupleExpr:
+		table := expr.table
+		if table == nil {
+			table = newInTable(rhs)
+		}
+
 		var rt ctype
-		if table := expr.compileTable(lhs, rhs); table != nil {
-			c.asm.In_table(expr.Negate, table)
+		if table != nil {
+			if table.hasNull {
+				rt.Flag |= flagNullable
+			}
+			c.asm.In_table(c.env.CollationEnv(), expr.Negate, table)
 		} else {
 			rt, err = rhs.compile(c)
 			if err != nil {
@@ -599,15 +717,45 @@ func (expr *InExpr) compile(c *compiler) (ctype, error) {
 	}
 }
 
-func (l *LikeExpr) matchWildcard(left, right []byte, coll collations.ID) bool {
+func (l *LikeExpr) matchWildcard(left, right []byte, coll collations.ID, escape rune) bool {
 	if l.Match != nil && l.MatchCollation == coll {
-		return l.Match.Match(left)
+		return l.Match.Match(left) == !l.Negate
 	}
 	fullColl := colldata.Lookup(coll)
-	wc := fullColl.Wildcard(right, 0, 0, 0)
+	wc := fullColl.Wildcard(right, 0, 0, escape)
 	return wc.Match(left) == !l.Negate
 }
 
+// likeEscape returns the escape character of a LIKE expression that compares
+// in the given collation. Like in MySQL, it is a byte in the collation's charset
+// for 8-bit charsets, and a Unicode code point for multi-byte charsets. A NULL
+// or empty ESCAPE clause leaves the default escape character, a backslash.
+func likeEscape(escape eval, coll colldata.Collation) (rune, error) {
+	if escape == nil {
+		return 0, nil
+	}
+	esc, err := evalToVarchar(escape, coll.ID(), true)
+	if err != nil {
+		return 0, err
+	}
+	if len(esc.bytes) == 0 {
+		return 0, nil
+	}
+
+	cs := coll.Charset()
+	if charset.Length(cs, esc.bytes) > 1 {
+		return 0, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongArguments, "Incorrect arguments to ESCAPE")
+	}
+	if cs.MaxWidth() == 1 {
+		return rune(esc.bytes[0]), nil
+	}
+	r, _ := cs.DecodeRune(esc.bytes)
+	if r == charset.RuneError {
+		return 0, nil
+	}
+	return r, nil
+}
+
 func (l *LikeExpr) eval(env *ExpressionEnv) (eval, error) {
 	left, err := l.Left.eval(env)
 	if err != nil || left == nil {
@@ -625,7 +773,19 @@ func (l *LikeExpr) eval(env *ExpressionEnv) (eval, error) {
 		return nil, err
 	}
 
-	matched := l.matchWildcard(left.ToRawBytes(), right.ToRawBytes(), col.Collation)
+	var escape rune
+	if l.Escape != nil {
+		esc, err := l.Escape.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		escape, err = likeEscape(esc, colldata.Lookup(col.Collation))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matched := l.matchWildcard(left.ToRawBytes(), right.ToRawBytes(), col.Collation, escape)
 
 	return newEvalBool(matched), nil
 }
@@ -643,7 +803,7 @@ func (expr *LikeExpr) compile(c *compiler) (ctype, error) {
 		return ctype{}, err
 	}
 
-	skip2 := c.compileNullCheck1(rt)
+	skip2 := c.compileNullCheck1r(rt)
 
 	if !lt.isTextual() {
 		c.asm.Convert_xc(2, sqltypes.VarChar, c.collation, nil)
@@ -679,8 +839,17 @@ func (expr *LikeExpr) compile(c *compiler) (ctype, error) {
 		return ctype{}, err
 	}
 
+	// The escape character is pushed after the arguments have been converted; a NULL
+	// escape does not make the result NULL, it leaves the default escape character.
+	hasEscape := expr.Escape != nil
+	if hasEscape {
+		if _, err := expr.Escape.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+
 	if coerceLeft == nil && coerceRight == nil {
-		c.asm.Like_collate(expr, colldata.Lookup(merged.Collation))
+		c.asm.Like_collate(expr, colldata.Lookup(merged.Collation), hasEscape)
 	} else {
 		if coerceLeft == nil {
 			coerceLeft = func(dst, in []byte) ([]byte, error) { return in, nil }
@@ -692,7 +861,7 @@ func (expr *LikeExpr) compile(c *compiler) (ctype, error) {
 			col:   colldata.Lookup(merged.Collation),
 			left:  coerceLeft,
 			right: coerceRight,
-		})
+		}, hasEscape)
 	}
 
 	c.asm.jumpDestination(skip1, skip2)
@@ -39,6 +39,17 @@ type VCursor interface {
 }
 
 type (
+	// SystemVariables are the session values of the MySQL system variables
+	// that change how expressions evaluate, such as block_encryption_mode.
+	SystemVariables interface {
+		// GetSystemVariable returns the session value of a system variable,
+		// or false if the session uses the server default.
+		GetSystemVariable(name string) (string, bool)
+	}
+
+	// SystemVariableMap is a SystemVariables stored in a map.
+	SystemVariableMap map[string]string
+
 	// ExpressionEnv contains the environment that the expression
 	// evaluates in, such as the current row and bindvars
 	ExpressionEnv struct {
@@ -48,8 +59,20 @@ type (
 		Row      []sqltypes.Value
 		Fields   []*querypb.Field
 
+		// UserVars are the user-defined variables read and assigned by
+		// the expression. NewExpressionEnv sets them to the VCursor if it
+		// implements UserVariables. If nil, all variables are NULL and
+		// cannot be assigned.
+		UserVars UserVariables
+
+		// SysVars are the session system variables read by the expression.
+		// NewExpressionEnv sets them to the VCursor if it implements
+		// SystemVariables. If nil, the server defaults are used.
+		SysVars SystemVariables
+
 		// internal state
 		now          time.Time
+		tz           *time.Location
 		vc           VCursor
 		user         *querypb.VTGateCallerID
 		sqlmode      SQLMode
@@ -84,12 +107,38 @@ func (env *ExpressionEnv) currentVersion() string {
 }
 
 func (env *ExpressionEnv) currentTimezone() *time.Location {
-	return env.vc.TimeZone()
+	if env.tz != nil {
+		return env.tz
+	}
+	if tz := env.vc.TimeZone(); tz != nil {
+		return tz
+	}
+	return time.Local
+}
+
+func (env *ExpressionEnv) blockEncryptionMode() string {
+	if env.SysVars != nil {
+		if mode, ok := env.SysVars.GetSystemVariable("block_encryption_mode"); ok {
+			return mode
+		}
+	}
+	return defaultBlockEncryptionMode
+}
+
+// GetSystemVariable implements SystemVariables.
+func (m SystemVariableMap) GetSystemVariable(name string) (string, bool) {
+	v, ok := m[name]
+	return v, ok
 }
 
 func (env *ExpressionEnv) Evaluate(expr Expr) (EvalResult, error) {
-	if p, ok := expr.(*CompiledExpr); ok {
-		return env.EvaluateVM(p)
+	switch expr := expr.(type) {
+	case *CompiledExpr:
+		return env.EvaluateVM(expr)
+	case *UntypedExpr:
+		if p := expr.loadSpeculative(env); p != nil {
+			return env.EvaluateVM(p)
+		}
 	}
 	e, err := expr.eval(env)
 	return EvalResult{v: e, collationEnv: env.collationEnv}, err
@@ -111,10 +160,16 @@ func (env *ExpressionEnv) TypeOf(expr Expr) (Type, error) {
 func (env *ExpressionEnv) SetTime(now time.Time) {
 	// This function is called only once by NewExpressionEnv to ensure that all expressions in the same
 	// ExpressionEnv evaluate NOW() and similar SQL functions to the same value.
-	env.now = now
-	if tz := env.currentTimezone(); tz != nil {
-		env.now = env.now.In(tz)
-	}
+	env.now = now.In(env.currentTimezone())
+}
+
+// SetTimeZone sets the session time zone that the expressions evaluate in,
+// overriding the one of the VCursor. It changes the results of NOW(),
+// CURDATE(), FROM_UNIXTIME(), UNIX_TIMESTAMP() and of the conversions between
+// temporal types. A nil tz restores the time zone of the VCursor.
+func (env *ExpressionEnv) SetTimeZone(tz *time.Location) {
+	env.tz = tz
+	env.now = env.now.In(env.currentTimezone())
 }
 
 func (env *ExpressionEnv) VCursor() VCursor {
@@ -155,6 +210,12 @@ func EmptyExpressionEnv(env *vtenv.Environment) *ExpressionEnv {
 // NewExpressionEnv returns an expression environment with no current row, but with bindvars
 func NewExpressionEnv(ctx context.Context, bindVars map[string]*querypb.BindVariable, vc VCursor) *ExpressionEnv {
 	env := &ExpressionEnv{BindVars: bindVars, vc: vc}
+	if sysVars, ok := vc.(SystemVariables); ok {
+		env.SysVars = sysVars
+	}
+	if userVars, ok := vc.(UserVariables); ok {
+		env.UserVars = userVars
+	}
 	env.user = callerid.ImmediateCallerIDFromContext(ctx)
 	env.SetTime(time.Now())
 	env.sqlmode = ParseSQLMode(vc.SQLMode())
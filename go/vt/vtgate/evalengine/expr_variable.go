@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"bytes"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+type (
+	// UserVariables stores the user-defined variables of a session, which
+	// expressions read with @name and assign with @name := expr.
+	UserVariables interface {
+		// GetUDV returns the value of a variable, or nil if it was never set.
+		GetUDV(name string) *querypb.BindVariable
+		// SetUserDefinedVariable sets the value of a variable.
+		SetUserDefinedVariable(name string, value *querypb.BindVariable)
+	}
+
+	// UserVariableMap is an UserVariables stored in a map.
+	UserVariableMap map[string]*querypb.BindVariable
+
+	// UserVariable is the value of a user-defined variable. Its type is the
+	// type of the last value assigned to the variable, so expressions that
+	// read variables are always compiled lazily.
+	UserVariable struct {
+		Name      string
+		Collation collations.ID
+
+		dynamicTypeOffset int
+	}
+
+	// AssignmentExpr assigns the value of its expression to a user-defined
+	// variable, and evaluates to that value.
+	AssignmentExpr struct {
+		UnaryExpr
+		Name string
+	}
+)
+
+var (
+	_ IR            = (*UserVariable)(nil)
+	_ IR            = (*AssignmentExpr)(nil)
+	_ UserVariables = UserVariableMap(nil)
+)
+
+// GetUDV implements UserVariables.
+func (m UserVariableMap) GetUDV(name string) *querypb.BindVariable {
+	return m[name]
+}
+
+// SetUserDefinedVariable implements UserVariables.
+func (m UserVariableMap) SetUserDefinedVariable(name string, value *querypb.BindVariable) {
+	m[name] = value
+}
+
+func (env *ExpressionEnv) lookupUserVar(name string) *querypb.BindVariable {
+	if env.UserVars == nil {
+		return nil
+	}
+	return env.UserVars.GetUDV(name)
+}
+
+func (env *ExpressionEnv) setUserVar(name string, e eval) error {
+	if env.UserVars == nil {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "cannot assign user-defined variable @%s (user-defined variables not supported here)", name)
+	}
+	value := sqltypes.NULL
+	if e != nil {
+		// the raw bytes of the value can be shared with the expression, so they are copied
+		value = sqltypes.MakeTrusted(e.SQLType(), bytes.Clone(e.ToRawBytes()))
+	}
+	env.UserVars.SetUserDefinedVariable(name, sqltypes.ValueBindVariable(value))
+	return nil
+}
+
+func (uv *UserVariable) eval(env *ExpressionEnv) (eval, error) {
+	bvar := env.lookupUserVar(uv.Name)
+	if bvar == nil || bvar.Type == sqltypes.Null {
+		return nil, nil
+	}
+	return valueToEval(sqltypes.MakeTrusted(bvar.Type, bvar.Value), typedCoercionCollation(bvar.Type, collations.CollationForType(bvar.Type, uv.Collation)), nil)
+}
+
+// typeof returns the type of the current value of the variable.
+func (uv *UserVariable) typeof(env *ExpressionEnv) (ctype, error) {
+	e, err := uv.eval(env)
+	if err != nil {
+		return ctype{}, err
+	}
+	return userVariableType(e), nil
+}
+
+func userVariableType(e eval) ctype {
+	if e == nil {
+		return ctype{Type: sqltypes.Null, Flag: flagNull | flagNullable, Col: collationNull}
+	}
+	return ctype{Type: e.SQLType(), Flag: flagNullable, Col: evalCollation(e)}
+}
+
+func (uv *UserVariable) compile(c *compiler) (ctype, error) {
+	if c.dynamicTypes == nil {
+		return ctype{}, c.unsupported(uv)
+	}
+	typ := c.dynamicTypes[uv.dynamicTypeOffset]
+	c.asm.PushUserVar(uv, typ)
+	return typ, nil
+}
+
+func (expr *AssignmentExpr) eval(env *ExpressionEnv) (eval, error) {
+	e, err := expr.Inner.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if err := env.setUserVar(expr.Name, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (expr *AssignmentExpr) compile(c *compiler) (ctype, error) {
+	typ, err := expr.Inner.compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	c.asm.AssignUserVar(expr.Name)
+	return typ, nil
+}
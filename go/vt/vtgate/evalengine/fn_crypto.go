@@ -17,15 +17,20 @@ limitations under the License.
 package evalengine
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
 )
 
 type builtinMD5 struct {
@@ -246,3 +251,304 @@ func (call *builtinRandomBytes) compile(c *compiler) (ctype, error) {
 	c.asm.jumpDestination(skip)
 	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: nullableFlags(arg.Flag) | flagNullable}, nil
 }
+
+type builtinAESEncrypt struct {
+	CallExpr
+}
+
+type builtinAESDecrypt struct {
+	CallExpr
+}
+
+var _ IR = (*builtinAESEncrypt)(nil)
+var _ IR = (*builtinAESDecrypt)(nil)
+
+func (call *builtinAESEncrypt) eval(env *ExpressionEnv) (eval, error) {
+	return evalAES(env, &call.CallExpr, false)
+}
+
+// constant returns false because the result depends on the
+// block_encryption_mode of the session.
+func (call *builtinAESEncrypt) constant() bool {
+	return false
+}
+
+func (call *builtinAESEncrypt) compile(c *compiler) (ctype, error) {
+	return compileAES(c, &call.CallExpr, false)
+}
+
+func (call *builtinAESDecrypt) eval(env *ExpressionEnv) (eval, error) {
+	return evalAES(env, &call.CallExpr, true)
+}
+
+// constant returns false because the result depends on the
+// block_encryption_mode of the session.
+func (call *builtinAESDecrypt) constant() bool {
+	return false
+}
+
+func (call *builtinAESDecrypt) compile(c *compiler) (ctype, error) {
+	return compileAES(c, &call.CallExpr, true)
+}
+
+func evalAES(env *ExpressionEnv, call *CallExpr, decrypt bool) (eval, error) {
+	str, key, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || key == nil {
+		return nil, nil
+	}
+	var iv eval
+	if len(call.Arguments) > 2 {
+		iv, err = call.Arguments[2].eval(env)
+		if err != nil {
+			return nil, err
+		}
+	}
+	out, err := aesCrypt(env.blockEncryptionMode(), call.Method, str, key, iv, len(call.Arguments) > 2, decrypt)
+	if out == nil || err != nil {
+		return nil, err
+	}
+	return newEvalBinary(out), nil
+}
+
+func compileAES(c *compiler, call *CallExpr, decrypt bool) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(str)
+
+	key, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(key)
+
+	// the initialization vector is only read by the modes that use it, so
+	// it is not checked for NULL here
+	if len(call.Arguments) > 2 {
+		if _, err := call.Arguments[2].compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+
+	c.asm.Fn_AES(call.Method, len(call.Arguments), decrypt)
+	c.asm.jumpDestination(skip1, skip2)
+
+	flag := nullableFlags(str.Flag | key.Flag)
+	if decrypt {
+		// decrypting data that was not encrypted with the same key and mode
+		// returns NULL
+		flag |= flagNullable
+	}
+	return ctype{Type: sqltypes.VarBinary, Col: collationBinary, Flag: flag}, nil
+}
+
+const (
+	defaultBlockEncryptionMode = "aes-128-ecb"
+	aesIVSize                  = aes.BlockSize
+)
+
+// aesMode is a value of the block_encryption_mode system variable,
+// in the form aes-<key length>-<mode>.
+type aesMode struct {
+	keySize int
+	mode    string
+}
+
+func parseAESMode(s string) (aesMode, error) {
+	var m aesMode
+	parts := strings.Split(strings.ToLower(s), "-")
+	if len(parts) == 3 && parts[0] == "aes" {
+		switch parts[1] {
+		case "128":
+			m.keySize = 16
+		case "192":
+			m.keySize = 24
+		case "256":
+			m.keySize = 32
+		}
+		switch parts[2] {
+		case "ecb", "cbc", "cfb1", "cfb8", "cfb128", "ofb":
+			m.mode = parts[2]
+		}
+	}
+	if m.keySize == 0 || m.mode == "" {
+		return m, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "Variable 'block_encryption_mode' can't be set to the value of '%s'", s)
+	}
+	return m, nil
+}
+
+func (m aesMode) needsIV() bool {
+	return m.mode != "ecb"
+}
+
+func (m aesMode) padded() bool {
+	return m.mode == "ecb" || m.mode == "cbc"
+}
+
+// aesKey folds a key of any length into a key of the given size, by XOR-ing
+// every byte of the key into the position of its index modulo the size.
+// This is how MySQL derives the AES key when no key derivation function
+// is given.
+func aesKey(key []byte, size int) []byte {
+	rkey := make([]byte, size)
+	for i, b := range key {
+		rkey[i%size] ^= b
+	}
+	return rkey
+}
+
+// aesCrypt encrypts or decrypts str with AES, using key and iv in the given
+// block_encryption_mode. It returns nil if str cannot be decrypted.
+func aesCrypt(blockEncryptionMode, method string, str, key, iv eval, hasIV, decrypt bool) ([]byte, error) {
+	mode, err := parseAESMode(blockEncryptionMode)
+	if err != nil {
+		return nil, err
+	}
+
+	var ivBytes []byte
+	if mode.needsIV() {
+		if !hasIV {
+			return nil, argError(method)
+		}
+		if iv != nil {
+			ivBytes = evalToBinary(iv).bytes
+		}
+		if len(ivBytes) < aesIVSize {
+			return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongArguments, "The initialization vector supplied to %s is too short. Must be at least %d bytes long", method, aesIVSize)
+		}
+		ivBytes = ivBytes[:aesIVSize]
+	}
+
+	block, err := aes.NewCipher(aesKey(evalToBinary(key).bytes, mode.keySize))
+	if err != nil {
+		return nil, err
+	}
+	src := evalToBinary(str).bytes
+	if decrypt {
+		return aesDecrypt(block, mode, ivBytes, src), nil
+	}
+	return aesEncrypt(block, mode, ivBytes, src), nil
+}
+
+func aesEncrypt(block cipher.Block, mode aesMode, iv, src []byte) []byte {
+	if !mode.padded() {
+		dst := make([]byte, len(src))
+		aesStream(block, mode, iv, dst, src, false)
+		return dst
+	}
+
+	// PKCS#7 padding, which always adds between 1 and a full block of bytes
+	pad := aes.BlockSize - len(src)%aes.BlockSize
+	dst := make([]byte, len(src)+pad)
+	copy(dst, src)
+	for i := len(src); i < len(dst); i++ {
+		dst[i] = byte(pad)
+	}
+	if mode.mode == "cbc" {
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst, dst)
+	} else {
+		for i := 0; i < len(dst); i += aes.BlockSize {
+			block.Encrypt(dst[i:], dst[i:])
+		}
+	}
+	return dst
+}
+
+func aesDecrypt(block cipher.Block, mode aesMode, iv, src []byte) []byte {
+	if !mode.padded() {
+		dst := make([]byte, len(src))
+		aesStream(block, mode, iv, dst, src, true)
+		return dst
+	}
+
+	if len(src) == 0 || len(src)%aes.BlockSize != 0 {
+		return nil
+	}
+	dst := make([]byte, len(src))
+	if mode.mode == "cbc" {
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(dst, src)
+	} else {
+		for i := 0; i < len(dst); i += aes.BlockSize {
+			block.Decrypt(dst[i:], src[i:])
+		}
+	}
+
+	pad := int(dst[len(dst)-1])
+	if pad == 0 || pad > aes.BlockSize {
+		return nil
+	}
+	for _, b := range dst[len(dst)-pad:] {
+		if int(b) != pad {
+			return nil
+		}
+	}
+	return dst[:len(dst)-pad]
+}
+
+// aesStream runs the CFB and OFB stream modes, which do not pad the data.
+// The CFB modes differ in the number of bits that are encrypted with each
+// block of key stream: a single bit (cfb1), a byte (cfb8) or a full block
+// (cfb128).
+func aesStream(block cipher.Block, mode aesMode, iv, dst, src []byte, decrypt bool) {
+	reg := make([]byte, aes.BlockSize)
+	copy(reg, iv)
+	ks := make([]byte, aes.BlockSize)
+
+	switch mode.mode {
+	case "ofb":
+		for i := 0; i < len(src); i += aes.BlockSize {
+			block.Encrypt(reg, reg)
+			for j := i; j < len(src) && j < i+aes.BlockSize; j++ {
+				dst[j] = src[j] ^ reg[j-i]
+			}
+		}
+	case "cfb128":
+		for i := 0; i < len(src); i += aes.BlockSize {
+			block.Encrypt(ks, reg)
+			for j := i; j < len(src) && j < i+aes.BlockSize; j++ {
+				c := src[j]
+				dst[j] = c ^ ks[j-i]
+				if !decrypt {
+					c = dst[j]
+				}
+				reg[j-i] = c
+			}
+		}
+	case "cfb8":
+		for i := range src {
+			block.Encrypt(ks, reg)
+			c := src[i]
+			dst[i] = c ^ ks[0]
+			if !decrypt {
+				c = dst[i]
+			}
+			copy(reg, reg[1:])
+			reg[aes.BlockSize-1] = c
+		}
+	case "cfb1":
+		for i := range src {
+			var out byte
+			for bit := 7; bit >= 0; bit-- {
+				block.Encrypt(ks, reg)
+				c := (src[i] >> bit) & 1
+				o := c ^ (ks[0] >> 7)
+				out |= o << bit
+				if !decrypt {
+					c = o
+				}
+				// shift the register left by one bit, feeding in the cipher bit
+				for j := 0; j < aes.BlockSize-1; j++ {
+					reg[j] = reg[j]<<1 | reg[j+1]>>7
+				}
+				reg[aes.BlockSize-1] = reg[aes.BlockSize-1]<<1 | c
+			}
+			dst[i] = out
+		}
+	}
+}
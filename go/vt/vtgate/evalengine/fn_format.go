@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/decimal"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+type builtinFormat struct {
+	CallExpr
+	collate collations.ID
+}
+
+var _ IR = (*builtinFormat)(nil)
+
+// formatMaxDecimals is the upper bound MySQL applies to the number of
+// decimals requested from FORMAT (FORMAT_MAX_DECIMALS).
+const formatMaxDecimals = 30
+
+// numericLocale holds the LC_NUMERIC properties of a MySQL locale
+// that are used to format numbers.
+type numericLocale struct {
+	decimalPoint byte
+	thousandsSep byte
+	// grouping is the number of digits in each group, starting from
+	// the decimal point. The last entry repeats for the remaining
+	// digits, and a nil grouping means digits are not grouped at all.
+	grouping []int
+}
+
+var (
+	localeEnUS = &numericLocale{'.', ',', []int{3, 3}}
+
+	// numericLocales is the subset of MySQL's locale table (sql_locale.cc)
+	// that FORMAT supports, keyed by the lowercase locale name. Locales
+	// that are not in this table are formatted as en_US, which is what
+	// MySQL does for unknown locale names.
+	numericLocales = map[string]*numericLocale{
+		"en_au": {'.', ',', []int{3, 3}},
+		"en_ca": {'.', ',', []int{3, 3}},
+		"en_gb": {'.', ',', []int{3, 3}},
+		"en_in": {'.', ',', []int{3, 2}},
+		"en_us": localeEnUS,
+		"de_ch": {'.', '\'', []int{3, 3}},
+		"de_de": {',', '.', []int{3, 3}},
+		"es_mx": {'.', ',', []int{3, 3}},
+		"fr_fr": {',', 0, nil},
+		"hi_in": {'.', ',', []int{3}},
+		"it_it": {',', 0, nil},
+		"ja_jp": {'.', ',', []int{3}},
+		"ko_kr": {'.', ',', []int{3, 3}},
+		"nl_nl": {',', 0, nil},
+		"pl_pl": {',', 0, nil},
+		"pt_br": {',', '.', []int{3, 3}},
+		"pt_pt": {',', 0, nil},
+		"ru_ru": {',', ' ', []int{3, 3}},
+		"sv_se": {',', ' ', []int{3, 3}},
+		"tr_tr": {',', '.', []int{3, 3}},
+		"zh_cn": {'.', ',', []int{3}},
+	}
+)
+
+func lookupNumericLocale(name eval) *numericLocale {
+	if name == nil {
+		return localeEnUS
+	}
+	if lc, ok := numericLocales[strings.ToLower(string(name.ToRawBytes()))]; ok {
+		return lc
+	}
+	return localeEnUS
+}
+
+// formatNumber returns the textual representation of num rounded to dec
+// decimals, grouped and punctuated according to the given locale.
+// Exact-value numbers are rounded half away from zero, while any other
+// value is converted to a double and rounded the way ROUND() does.
+func formatNumber(num eval, dec int64, lc *numericLocale) []byte {
+	dec = min(max(dec, 0), formatMaxDecimals)
+
+	var str string
+	switch num := num.(type) {
+	case *evalInt64:
+		str = decimal.NewFromInt(num.i).StringFixed(int32(dec))
+	case *evalUint64:
+		str = decimal.NewFromUint(num.u).StringFixed(int32(dec))
+	case *evalDecimal:
+		str = num.dec.StringFixed(int32(dec))
+	default:
+		f, _ := evalToFloat(num)
+		v := f.f
+		if factor := math.Pow(10, float64(dec)); !math.IsInf(v*factor, 0) {
+			v = math.RoundToEven(v*factor) / factor
+		}
+		str = strconv.FormatFloat(v, 'f', int(dec), 64)
+	}
+	return formatGroupDigits([]byte(str), int(dec), lc)
+}
+
+// formatGroupDigits rewrites a plain decimal number with dec fractional
+// digits following the locale rules, the same way Item_func_format does.
+func formatGroupDigits(str []byte, dec int, lc *numericLocale) []byte {
+	decLength := 0
+	if dec > 0 {
+		decLength = dec + 1
+	}
+
+	if len(lc.grouping) == 0 || len(str) < decLength+1+lc.grouping[0] {
+		if decLength > 0 && lc.decimalPoint != '.' {
+			str[len(str)-decLength] = lc.decimalPoint
+		}
+		return str
+	}
+
+	signLength := 0
+	if str[0] == '-' {
+		signLength = 1
+	}
+	digits := str[signLength : len(str)-decLength]
+
+	buf := make([]byte, 0, len(str)+len(digits)/lc.grouping[len(lc.grouping)-1]+1)
+	if decLength > 0 {
+		// Collect the fractional part first, since the result is built
+		// backwards and reversed at the end.
+		for i := len(str) - 1; i > len(str)-decLength; i-- {
+			buf = append(buf, str[i])
+		}
+		buf = append(buf, lc.decimalPoint)
+	}
+
+	g := 0
+	count := lc.grouping[g]
+	for i := len(digits) - 1; i >= 0; i-- {
+		if count == 0 {
+			buf = append(buf, lc.thousandsSep)
+			if g+1 < len(lc.grouping) {
+				g++
+			}
+			count = lc.grouping[g]
+		}
+		buf = append(buf, digits[i])
+		count--
+	}
+	if signLength > 0 {
+		buf = append(buf, '-')
+	}
+
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return buf
+}
+
+func (call *builtinFormat) eval(env *ExpressionEnv) (eval, error) {
+	num, d, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if num == nil || d == nil {
+		return nil, nil
+	}
+
+	lc := localeEnUS
+	if len(call.Arguments) > 2 {
+		name, err := call.Arguments[2].eval(env)
+		if err != nil {
+			return nil, err
+		}
+		lc = lookupNumericLocale(name)
+	}
+
+	dec := evalToInt64(d).i
+	return newEvalText(formatNumber(num, dec, lc), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinFormat) compile(c *compiler) (ctype, error) {
+	num, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(num)
+
+	d, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(d)
+
+	if d.Type != sqltypes.Int64 {
+		c.asm.Convert_xi(1)
+	}
+
+	locale := false
+	if len(call.Arguments) > 2 {
+		if _, err := call.Arguments[2].compile(c); err != nil {
+			return ctype{}, err
+		}
+		locale = true
+	}
+
+	col := typedCoercionCollation(sqltypes.VarChar, c.collation)
+	c.asm.Fn_FORMAT(col, locale)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: nullableFlags(num.Flag | d.Flag)}, nil
+}
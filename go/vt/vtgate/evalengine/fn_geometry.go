@@ -0,0 +1,711 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+type (
+	// builtinGeomFromText implements ST_GeomFromText and the functions
+	// that construct a geometry of a specific type from its WKT.
+	builtinGeomFromText struct {
+		CallExpr
+		want geometryType
+	}
+
+	// builtinGeomFromWKB implements ST_GeomFromWKB and the functions
+	// that construct a geometry of a specific type from its WKB.
+	builtinGeomFromWKB struct {
+		CallExpr
+		want geometryType
+	}
+
+	builtinAsText struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinAsBinary struct {
+		CallExpr
+	}
+
+	builtinPoint struct {
+		CallExpr
+	}
+
+	// builtinPointCoordinate implements ST_X and ST_Y, which return a
+	// coordinate of a point, or a copy of the point with the coordinate
+	// replaced when called with a second argument.
+	builtinPointCoordinate struct {
+		CallExpr
+		y bool
+	}
+
+	builtinDistance struct {
+		CallExpr
+	}
+
+	builtinContains struct {
+		CallExpr
+	}
+)
+
+var geometryFromWktTypes = map[sqlparser.GeomFromWktType]geometryType{
+	sqlparser.GeometryFromText:           geometryAny,
+	sqlparser.GeometryCollectionFromText: geometryCollection,
+	sqlparser.PointFromText:              geometryPoint,
+	sqlparser.LineStringFromText:         geometryLineString,
+	sqlparser.PolygonFromText:            geometryPolygon,
+	sqlparser.MultiPointFromText:         geometryMultiPoint,
+	sqlparser.MultiPolygonFromText:       geometryMultiPolygon,
+	sqlparser.MultiLinestringFromText:    geometryMultiLineString,
+}
+
+var geometryFromWkbTypes = map[sqlparser.GeomFromWkbType]geometryType{
+	sqlparser.GeometryFromWKB:           geometryAny,
+	sqlparser.GeometryCollectionFromWKB: geometryCollection,
+	sqlparser.PointFromWKB:              geometryPoint,
+	sqlparser.LineStringFromWKB:         geometryLineString,
+	sqlparser.PolygonFromWKB:            geometryPolygon,
+	sqlparser.MultiPointFromWKB:         geometryMultiPoint,
+	sqlparser.MultiPolygonFromWKB:       geometryMultiPolygon,
+	sqlparser.MultiLinestringFromWKB:    geometryMultiLineString,
+}
+
+var (
+	_ IR = (*builtinGeomFromText)(nil)
+	_ IR = (*builtinGeomFromWKB)(nil)
+	_ IR = (*builtinAsText)(nil)
+	_ IR = (*builtinAsBinary)(nil)
+	_ IR = (*builtinPoint)(nil)
+	_ IR = (*builtinPointCoordinate)(nil)
+	_ IR = (*builtinDistance)(nil)
+	_ IR = (*builtinContains)(nil)
+)
+
+// geometryArgs evaluates the arguments of a spatial function. All of them
+// are nil if any of the arguments is NULL.
+func (c *CallExpr) geometryArgs(env *ExpressionEnv) ([]eval, error) {
+	args, err := c.args(env)
+	if err != nil || slices.Contains(args, nil) {
+		return nil, err
+	}
+	return args, nil
+}
+
+// compileGeometryCall compiles the arguments of a spatial function and a call
+// to fn with their values. The result is NULL if any of the arguments is NULL.
+func (c *compiler) compileGeometryCall(call *CallExpr, fn func(args []eval) (eval, error)) (typeFlag, error) {
+	var flag typeFlag
+	var types []ctype
+	for _, arg := range call.Arguments {
+		ct, err := arg.compile(c)
+		if err != nil {
+			return 0, err
+		}
+		flag |= ct.Flag & flagNullable
+		types = append(types, ct)
+	}
+
+	var skip *jump
+	switch len(types) {
+	case 1:
+		skip = c.compileNullCheck1(types[0])
+	case 2:
+		skip = c.compileNullCheck2(types[0], types[1])
+	case 3:
+		skip = c.compileNullCheck3(types[0], types[1], types[2])
+	default:
+		return 0, c.unsupported(call)
+	}
+
+	c.asm.Fn_GEOMETRY(call.Method, len(types), fn)
+	c.asm.jumpDestination(skip)
+	return flag, nil
+}
+
+// intoGeometry decodes the geometry stored in a value.
+func intoGeometry(method string, e eval) (*geometry, error) {
+	g, err := parseGeometry(method, e.ToRawBytes())
+	if err != nil {
+		return nil, err
+	}
+	if g.srid != 0 {
+		return nil, errUnsupportedSRID(method, g.srid)
+	}
+	return g, nil
+}
+
+// geometrySRID returns the SRID passed as the optional argument of a function
+// that constructs a geometry.
+func geometrySRID(method string, args []eval) (uint32, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	srid := evalToInt64(args[0]).i
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "SRID value is out of range in '%s'", strings.ToLower(method))
+	}
+	if srid != 0 {
+		return 0, errUnsupportedSRID(method, uint32(srid))
+	}
+	return 0, nil
+}
+
+func builtin_ST_GeomFromText(method string, want geometryType, args []eval) (eval, error) {
+	srid, err := geometrySRID(method, args[1:])
+	if err != nil {
+		return nil, err
+	}
+	g, err := parseWKT(method, args[0].ToRawBytes(), srid, want)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalGeometry(g.marshal()), nil
+}
+
+func (call *builtinGeomFromText) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_GeomFromText(call.Method, call.want, args)
+}
+
+func (call *builtinGeomFromText) compile(c *compiler) (ctype, error) {
+	flag, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_GeomFromText(call.Method, call.want, args)
+	})
+	return ctype{Type: sqltypes.Geometry, Flag: flag, Col: collationBinary}, err
+}
+
+func builtin_ST_GeomFromWKB(method string, want geometryType, args []eval) (eval, error) {
+	srid, err := geometrySRID(method, args[1:])
+	if err != nil {
+		return nil, err
+	}
+	g, err := parseWKB(method, args[0].ToRawBytes(), srid, want)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalGeometry(g.marshal()), nil
+}
+
+func (call *builtinGeomFromWKB) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_GeomFromWKB(call.Method, call.want, args)
+}
+
+func (call *builtinGeomFromWKB) compile(c *compiler) (ctype, error) {
+	flag, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_GeomFromWKB(call.Method, call.want, args)
+	})
+	return ctype{Type: sqltypes.Geometry, Flag: flag, Col: collationBinary}, err
+}
+
+func builtin_ST_AsText(method string, col collations.TypedCollation, args []eval) (eval, error) {
+	g, err := intoGeometry(method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	return newEvalText(g.appendWKT(nil), col), nil
+}
+
+func (call *builtinAsText) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_AsText(call.Method, typedCoercionCollation(sqltypes.VarChar, call.collate), args)
+}
+
+func (call *builtinAsText) compile(c *compiler) (ctype, error) {
+	col := typedCoercionCollation(sqltypes.VarChar, call.collate)
+	flag, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_AsText(call.Method, col, args)
+	})
+	return ctype{Type: sqltypes.VarChar, Flag: flag, Col: col}, err
+}
+
+func builtin_ST_AsBinary(method string, args []eval) (eval, error) {
+	g, err := intoGeometry(method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBinary(g.appendWKB(nil)), nil
+}
+
+func (call *builtinAsBinary) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_AsBinary(call.Method, args)
+}
+
+func (call *builtinAsBinary) compile(c *compiler) (ctype, error) {
+	flag, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_AsBinary(call.Method, args)
+	})
+	return ctype{Type: sqltypes.VarBinary, Flag: flag, Col: collationBinary}, err
+}
+
+func builtin_POINT(args []eval) (eval, error) {
+	x, _ := evalToFloat(args[0])
+	y, _ := evalToFloat(args[1])
+	g := &geometry{typ: geometryPoint, points: []geomPoint{{x: x.f, y: y.f}}}
+	return newEvalGeometry(g.marshal()), nil
+}
+
+func (call *builtinPoint) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_POINT(args)
+}
+
+func (call *builtinPoint) compile(c *compiler) (ctype, error) {
+	flag, err := c.compileGeometryCall(&call.CallExpr, builtin_POINT)
+	return ctype{Type: sqltypes.Geometry, Flag: flag, Col: collationBinary}, err
+}
+
+func builtin_ST_X_Y(method string, y bool, args []eval) (eval, error) {
+	g, err := intoGeometry(method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if g.typ != geometryPoint {
+		return nil, errUnexpectedGeometryType(method, geometryPoint, g.typ)
+	}
+
+	p := g.points[0]
+	if len(args) == 1 {
+		if y {
+			return newEvalFloat(p.y), nil
+		}
+		return newEvalFloat(p.x), nil
+	}
+
+	v, _ := evalToFloat(args[1])
+	if y {
+		p.y = v.f
+	} else {
+		p.x = v.f
+	}
+	g.points[0] = p
+	return newEvalGeometry(g.marshal()), nil
+}
+
+func (call *builtinPointCoordinate) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_X_Y(call.Method, call.y, args)
+}
+
+func (call *builtinPointCoordinate) compile(c *compiler) (ctype, error) {
+	flag, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_X_Y(call.Method, call.y, args)
+	})
+	if len(call.Arguments) == 2 {
+		return ctype{Type: sqltypes.Geometry, Flag: flag, Col: collationBinary}, err
+	}
+	return ctype{Type: sqltypes.Float64, Flag: flag, Col: collationNumeric}, err
+}
+
+// intoGeometryPair decodes the two geometries that a spatial relation
+// function operates on. The geometries are nil if any of them is empty, in
+// which case the result of the function is NULL.
+func intoGeometryPair(method string, args []eval) (*geometry, *geometry, error) {
+	g1, err := intoGeometry(method, args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	g2, err := intoGeometry(method, args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if g1.isEmpty() || g2.isEmpty() {
+		return nil, nil, nil
+	}
+	return g1, g2, nil
+}
+
+func builtin_ST_Distance(method string, args []eval) (eval, error) {
+	g1, g2, err := intoGeometryPair(method, args)
+	if g1 == nil {
+		return nil, err
+	}
+	return newEvalFloat(geometryDistance(g1, g2)), nil
+}
+
+func (call *builtinDistance) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_Distance(call.Method, args)
+}
+
+func (call *builtinDistance) compile(c *compiler) (ctype, error) {
+	_, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_Distance(call.Method, args)
+	})
+	return ctype{Type: sqltypes.Float64, Flag: flagNullable, Col: collationNumeric}, err
+}
+
+func builtin_ST_Contains(method string, args []eval) (eval, error) {
+	g1, g2, err := intoGeometryPair(method, args)
+	if g1 == nil {
+		return nil, err
+	}
+	contains, err := geometryContains(method, g1, g2)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(contains), nil
+}
+
+func (call *builtinContains) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.geometryArgs(env)
+	if args == nil {
+		return nil, err
+	}
+	return builtin_ST_Contains(call.Method, args)
+}
+
+func (call *builtinContains) compile(c *compiler) (ctype, error) {
+	_, err := c.compileGeometryCall(&call.CallExpr, func(args []eval) (eval, error) {
+		return builtin_ST_Contains(call.Method, args)
+	})
+	return ctype{Type: sqltypes.Int64, Flag: flagNullable | flagIsBoolean, Col: collationNumeric}, err
+}
+
+// shapes appends the points, line strings and polygons that the geometry is
+// made of.
+func (g *geometry) shapes(out []*geometry) []*geometry {
+	switch g.typ {
+	case geometryPoint, geometryLineString, geometryPolygon:
+		return append(out, g)
+	default:
+		for _, e := range g.geoms {
+			out = e.shapes(out)
+		}
+		return out
+	}
+}
+
+// segments returns the segments of a point, line string or polygon. A point
+// is a single segment of length zero, and the segments of a polygon are the
+// edges of all its rings.
+func (g *geometry) segments() [][2]geomPoint {
+	if g.typ == geometryPoint {
+		return [][2]geomPoint{{g.points[0], g.points[0]}}
+	}
+	lines := g.rings
+	if g.typ == geometryLineString {
+		lines = [][]geomPoint{g.points}
+	}
+	var segments [][2]geomPoint
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			segments = append(segments, [2]geomPoint{line[i-1], line[i]})
+		}
+	}
+	return segments
+}
+
+func (g *geometry) vertex() geomPoint {
+	if g.typ == geometryPolygon {
+		return g.rings[0][0]
+	}
+	return g.points[0]
+}
+
+type pointLocation int
+
+const (
+	pointOutside pointLocation = iota
+	pointOnBoundary
+	pointInside
+)
+
+// locate returns the location of a point relative to a polygon.
+func (g *geometry) locate(p geomPoint) pointLocation {
+	loc := locateInRing(g.rings[0], p)
+	if loc != pointInside {
+		return loc
+	}
+	for _, hole := range g.rings[1:] {
+		switch locateInRing(hole, p) {
+		case pointOnBoundary:
+			return pointOnBoundary
+		case pointInside:
+			return pointOutside
+		}
+	}
+	return pointInside
+}
+
+func locateInRing(ring []geomPoint, p geomPoint) pointLocation {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return pointOnBoundary
+		}
+		if (a.y > p.y) != (b.y > p.y) && p.x < (b.x-a.x)*(p.y-a.y)/(b.y-a.y)+a.x {
+			inside = !inside
+		}
+	}
+	if inside {
+		return pointInside
+	}
+	return pointOutside
+}
+
+func locateInPolygons(polygons []*geometry, p geomPoint) pointLocation {
+	loc := pointOutside
+	for _, poly := range polygons {
+		switch poly.locate(p) {
+		case pointInside:
+			return pointInside
+		case pointOnBoundary:
+			loc = pointOnBoundary
+		}
+	}
+	return loc
+}
+
+func orientation(a, b, c geomPoint) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
+
+func onSegment(p, a, b geomPoint) bool {
+	return orientation(a, b, p) == 0 &&
+		min(a.x, b.x) <= p.x && p.x <= max(a.x, b.x) &&
+		min(a.y, b.y) <= p.y && p.y <= max(a.y, b.y)
+}
+
+// crosses returns whether two segments cross at a single point in the
+// interior of both of them.
+func crosses(a, b [2]geomPoint) bool {
+	d1 := orientation(b[0], b[1], a[0])
+	d2 := orientation(b[0], b[1], a[1])
+	d3 := orientation(a[0], a[1], b[0])
+	d4 := orientation(a[0], a[1], b[1])
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func intersects(a, b [2]geomPoint) bool {
+	return crosses(a, b) ||
+		onSegment(a[0], b[0], b[1]) || onSegment(a[1], b[0], b[1]) ||
+		onSegment(b[0], a[0], a[1]) || onSegment(b[1], a[0], a[1])
+}
+
+func pointSegmentDistance(p geomPoint, s [2]geomPoint) float64 {
+	dx, dy := s[1].x-s[0].x, s[1].y-s[0].y
+	t := 0.0
+	if l := dx*dx + dy*dy; l != 0 {
+		t = min(max(((p.x-s[0].x)*dx+(p.y-s[0].y)*dy)/l, 0), 1)
+	}
+	return math.Hypot(p.x-(s[0].x+t*dx), p.y-(s[0].y+t*dy))
+}
+
+func segmentDistance(a, b [2]geomPoint) float64 {
+	if intersects(a, b) {
+		return 0
+	}
+	return min(
+		pointSegmentDistance(a[0], b), pointSegmentDistance(a[1], b),
+		pointSegmentDistance(b[0], a), pointSegmentDistance(b[1], a),
+	)
+}
+
+// geometryDistance returns the Cartesian distance between two non-empty
+// geometries, which is zero if they intersect.
+func geometryDistance(g1, g2 *geometry) float64 {
+	d := math.Inf(1)
+	for _, s1 := range g1.shapes(nil) {
+		for _, s2 := range g2.shapes(nil) {
+			d = min(d, shapeDistance(s1, s2))
+		}
+	}
+	return d
+}
+
+func shapeDistance(s1, s2 *geometry) float64 {
+	// a shape that does not cross the boundary of a polygon is either fully
+	// inside or fully outside of it, so checking any of its vertices and
+	// the distance between the segments of both shapes is enough
+	if s1.typ == geometryPolygon && s1.locate(s2.vertex()) != pointOutside {
+		return 0
+	}
+	if s2.typ == geometryPolygon && s2.locate(s1.vertex()) != pointOutside {
+		return 0
+	}
+	d := math.Inf(1)
+	for _, a := range s1.segments() {
+		for _, b := range s2.segments() {
+			d = min(d, segmentDistance(a, b))
+		}
+	}
+	return d
+}
+
+// pieceMidpoints splits a segment at all the points where it meets any of
+// the cuts, and returns the midpoints of the pieces. Every piece is then
+// either fully inside, fully outside, or on the boundary of a polygon whose
+// edges are the cuts.
+func pieceMidpoints(s [2]geomPoint, cuts [][2]geomPoint) []geomPoint {
+	dx, dy := s[1].x-s[0].x, s[1].y-s[0].y
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return nil
+	}
+	param := func(p geomPoint) float64 {
+		return ((p.x-s[0].x)*dx + (p.y-s[0].y)*dy) / l
+	}
+
+	ts := []float64{0, 1}
+	for _, cut := range cuts {
+		for _, p := range cut {
+			if onSegment(p, s[0], s[1]) {
+				ts = append(ts, param(p))
+			}
+		}
+		if crosses(s, cut) {
+			d1 := orientation(cut[0], cut[1], s[0])
+			d2 := orientation(cut[0], cut[1], s[1])
+			ts = append(ts, d1/(d1-d2))
+		}
+	}
+	slices.Sort(ts)
+
+	var mids []geomPoint
+	for i := 1; i < len(ts); i++ {
+		if ts[i] == ts[i-1] {
+			continue
+		}
+		t := (ts[i-1] + ts[i]) / 2
+		mids = append(mids, geomPoint{x: s[0].x + t*dx, y: s[0].y + t*dy})
+	}
+	return mids
+}
+
+// geometryContains returns whether no point of g2 lies outside of g1, and at
+// least one point of the interior of g2 lies in the interior of g1.
+func geometryContains(method string, g1, g2 *geometry) (bool, error) {
+	outer, inner := g1.shapes(nil), g2.shapes(nil)
+	switch {
+	case allShapes(outer, geometryPoint):
+		return pointsContain(outer, inner), nil
+	case allShapes(outer, geometryPolygon):
+		return polygonsContain(outer, inner), nil
+	default:
+		return false, vterrors.VT12001(fmt.Sprintf("%s with a %s that is not made of points or polygons", strings.ToLower(method), g1.typ))
+	}
+}
+
+func allShapes(shapes []*geometry, typ geometryType) bool {
+	for _, s := range shapes {
+		if s.typ != typ {
+			return false
+		}
+	}
+	return true
+}
+
+func pointsContain(outer, inner []*geometry) bool {
+	for _, s := range inner {
+		if s.typ != geometryPoint {
+			return false
+		}
+		if !slices.ContainsFunc(outer, func(p *geometry) bool { return p.points[0] == s.points[0] }) {
+			return false
+		}
+	}
+	return true
+}
+
+func polygonsContain(polygons, inner []*geometry) bool {
+	var edges [][2]geomPoint
+	for _, poly := range polygons {
+		edges = append(edges, poly.segments()...)
+	}
+
+	interior := false
+	check := func(p geomPoint) bool {
+		switch locateInPolygons(polygons, p) {
+		case pointOutside:
+			return false
+		case pointInside:
+			interior = true
+		}
+		return true
+	}
+
+	for _, s := range inner {
+		if s.typ == geometryPoint {
+			if !check(s.points[0]) {
+				return false
+			}
+			continue
+		}
+
+		segments := s.segments()
+		for _, seg := range segments {
+			if !check(seg[0]) {
+				return false
+			}
+			for _, mid := range pieceMidpoints(seg, edges) {
+				if !check(mid) {
+					return false
+				}
+			}
+		}
+
+		if s.typ == geometryPolygon {
+			// the boundary of the polygon is covered, but the boundary of
+			// the containing polygons could still cut through its inside
+			for _, edge := range edges {
+				for _, mid := range pieceMidpoints(edge, segments) {
+					if s.locate(mid) == pointInside {
+						return false
+					}
+				}
+			}
+			interior = true
+		}
+	}
+	return interior
+}
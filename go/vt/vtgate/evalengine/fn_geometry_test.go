@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, wkt string) *geometry {
+	t.Helper()
+	g, err := parseWKT("st_geomfromtext", []byte(wkt), 0, geometryAny)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestWKTRoundTrip(t *testing.T) {
+	tcases := []struct {
+		in, out string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5   2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0,1 1,2 0.25)", "LINESTRING(0 0,1 1,2 0.25)"},
+		{"POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,2 2,1 1))"},
+		{"MULTIPOINT(1 1, 2 2)", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTIPOINT((1 1), (2 2))", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+		{"POINT(1e20 0.000000000000001)", "POINT(1e20 0.000000000000001)"},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.in, func(t *testing.T) {
+			g := mustParseWKT(t, tc.in)
+			assert.Equal(t, tc.out, string(g.appendWKT(nil)))
+
+			// the WKB of the geometry decodes back to the same geometry
+			decoded, err := parseGeometry("st_astext", g.marshal())
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, string(decoded.appendWKT(nil)))
+		})
+	}
+}
+
+func TestWKTInvalid(t *testing.T) {
+	for _, wkt := range []string{
+		"",
+		"POINT",
+		"POINT EMPTY",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"POINT(a b)",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"MULTIPOINT()",
+		"CIRCLE(0 0)",
+	} {
+		_, err := parseWKT("st_geomfromtext", []byte(wkt), 0, geometryAny)
+		assert.EqualError(t, err, "Invalid GIS data provided to function st_geomfromtext.", wkt)
+	}
+
+	_, err := parseWKT("st_pointfromtext", []byte("LINESTRING(0 0,1 1)"), 0, geometryPoint)
+	assert.EqualError(t, err, "Invalid GIS data provided to function st_pointfromtext.")
+}
+
+func TestWKBBigEndian(t *testing.T) {
+	wkb, err := hex.DecodeString("00000000013ff80000000000004000000000000000")
+	require.NoError(t, err)
+	g, err := parseWKB("st_geomfromwkb", wkb, 0, geometryAny)
+	require.NoError(t, err)
+	assert.Equal(t, "POINT(1.5 2)", string(g.appendWKT(nil)))
+
+	// the result is always encoded as little endian
+	assert.Equal(t, "0101000000000000000000f83f0000000000000040", hex.EncodeToString(g.appendWKB(nil)))
+
+	for _, truncated := range [][]byte{nil, wkb[:1], wkb[:5], wkb[:len(wkb)-1]} {
+		_, err := parseWKB("st_geomfromwkb", truncated, 0, geometryAny)
+		assert.Error(t, err)
+	}
+}
+
+func TestGeometrySRID(t *testing.T) {
+	raw, err := hex.DecodeString("e61000000101000000000000000000f83f00000000000000c0")
+	require.NoError(t, err)
+
+	_, err = intoGeometry("st_x", newEvalGeometry(raw))
+	assert.EqualError(t, err, "VT12001: unsupported: spatial reference system 4326 in st_x")
+
+	_, err = geometrySRID("st_geomfromtext", []eval{newEvalInt64(-1)})
+	assert.EqualError(t, err, "SRID value is out of range in 'st_geomfromtext'")
+}
+
+func TestGeometryDistance(t *testing.T) {
+	tcases := []struct {
+		g1, g2 string
+		want   float64
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5},
+		{"POINT(0 0)", "LINESTRING(-1 1,1 1)", 1},
+		{"LINESTRING(0 0,2 2)", "LINESTRING(0 2,2 0)", 0},
+		{"LINESTRING(0 0,1 0)", "LINESTRING(3 0,4 0)", 2},
+		{"POINT(2 2)", "POLYGON((0 0,4 0,4 4,0 4,0 0))", 0},
+		{"POINT(6 0)", "POLYGON((0 0,4 0,4 4,0 4,0 0))", 2},
+		{"POINT(2 2)", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))", 1},
+		{"POLYGON((1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0))", 0},
+		{"MULTIPOINT(10 10,0 5)", "GEOMETRYCOLLECTION(POINT(0 0),LINESTRING(8 10,9 10))", 1},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.g1+" "+tc.g2, func(t *testing.T) {
+			g1, g2 := mustParseWKT(t, tc.g1), mustParseWKT(t, tc.g2)
+			assert.Equal(t, tc.want, geometryDistance(g1, g2))
+			assert.Equal(t, tc.want, geometryDistance(g2, g1))
+		})
+	}
+}
+
+func TestGeometryContains(t *testing.T) {
+	const square = "POLYGON((0 0,4 0,4 4,0 4,0 0))"
+	const squareWithHole = "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))"
+	const concave = "POLYGON((0 0,4 0,4 4,3 4,3 1,1 1,1 4,0 4,0 0))"
+
+	tcases := []struct {
+		g1, g2 string
+		want   bool
+	}{
+		{square, "POINT(2 2)", true},
+		{square, "POINT(4 2)", false},
+		{square, "POINT(5 2)", false},
+		{squareWithHole, "POINT(2 2)", false},
+		{squareWithHole, "POINT(0.5 0.5)", true},
+		{square, "MULTIPOINT(4 4,2 2)", true},
+		{square, "MULTIPOINT(4 4,0 0)", false},
+		{square, "LINESTRING(1 1,3 3)", true},
+		{square, "LINESTRING(0 0,4 0)", false},
+		{square, "LINESTRING(0 0,4 4)", true},
+		{square, "LINESTRING(1 1,5 5)", false},
+		{concave, "LINESTRING(0.5 3,3.5 3)", false},
+		{concave, "LINESTRING(0.5 0.5,3.5 0.5)", true},
+		{square, "POLYGON((1 1,2 1,2 2,1 1))", true},
+		{square, square, true},
+		{squareWithHole, "POLYGON((0.5 0.5,3.5 0.5,3.5 3.5,0.5 3.5,0.5 0.5))", false},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOINT(0.8 0.2,2.8 2.2)", true},
+		{"POINT(1 1)", "POINT(1 1)", true},
+		{"MULTIPOINT(1 1,2 2)", "POINT(3 3)", false},
+		{"POINT(1 1)", "LINESTRING(1 1,2 2)", false},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.g1+" "+tc.g2, func(t *testing.T) {
+			got, err := geometryContains("st_contains", mustParseWKT(t, tc.g1), mustParseWKT(t, tc.g2))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := geometryContains("st_contains", mustParseWKT(t, "LINESTRING(0 0,1 1)"), mustParseWKT(t, "POINT(0 0)"))
+	assert.EqualError(t, err, "VT12001: unsupported: st_contains with a LINESTRING that is not made of points or polygons")
+}
@@ -27,12 +27,12 @@ type builtinUser struct {
 var _ IR = (*builtinUser)(nil)
 
 func (call *builtinUser) eval(env *ExpressionEnv) (eval, error) {
-	return newEvalText([]byte(env.currentUser()), collationUtf8mb3), nil
+	return newEvalText([]byte(env.currentUser()), collationSysconst), nil
 }
 
 func (*builtinUser) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_User()
-	return ctype{Type: sqltypes.VarChar, Col: collationUtf8mb3}, nil
+	return ctype{Type: sqltypes.VarChar, Col: collationSysconst}, nil
 }
 
 func (call *builtinUser) constant() bool {
@@ -46,12 +46,12 @@ type builtinVersion struct {
 var _ IR = (*builtinVersion)(nil)
 
 func (call *builtinVersion) eval(env *ExpressionEnv) (eval, error) {
-	return newEvalText([]byte(env.currentVersion()), collationUtf8mb3), nil
+	return newEvalText([]byte(env.currentVersion()), collationSysconst), nil
 }
 
 func (*builtinVersion) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_Version()
-	return ctype{Type: sqltypes.VarChar, Col: collationUtf8mb3}, nil
+	return ctype{Type: sqltypes.VarChar, Col: collationSysconst}, nil
 }
 
 type builtinDatabase struct {
@@ -65,12 +65,12 @@ func (call *builtinDatabase) eval(env *ExpressionEnv) (eval, error) {
 	if db == "" {
 		return nil, nil
 	}
-	return newEvalText([]byte(db), collationUtf8mb3), nil
+	return newEvalText([]byte(db), collationSysconst), nil
 }
 
 func (*builtinDatabase) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_Database()
-	return ctype{Type: sqltypes.VarChar, Col: collationUtf8mb3}, nil
+	return ctype{Type: sqltypes.VarChar, Col: collationSysconst}, nil
 }
 
 func (call *builtinDatabase) constant() bool {
@@ -34,6 +34,19 @@ type (
 		CallExpr
 	}
 
+	builtinJSONModify struct {
+		CallExpr
+		transform json.Transformation
+	}
+
+	builtinJSONMergePreserve struct {
+		CallExpr
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
 	builtinJSONUnquote struct {
 		CallExpr
 	}
@@ -61,11 +74,22 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONOverlaps struct {
+		CallExpr
+	}
+
+	builtinMemberOf struct {
+		CallExpr
+	}
 )
 
 var (
 	_ IR = (*builtinJSONExtract)(nil)
 	_ IR = (*builtinJSONRemove)(nil)
+	_ IR = (*builtinJSONModify)(nil)
+	_ IR = (*builtinJSONMergePreserve)(nil)
+	_ IR = (*builtinJSONMergePatch)(nil)
 	_ IR = (*builtinJSONUnquote)(nil)
 	_ IR = (*builtinJSONObject)(nil)
 	_ IR = (*builtinJSONArray)(nil)
@@ -73,6 +97,8 @@ var (
 	_ IR = (*builtinJSONLength)(nil)
 	_ IR = (*builtinJSONContainsPath)(nil)
 	_ IR = (*builtinJSONKeys)(nil)
+	_ IR = (*builtinJSONOverlaps)(nil)
+	_ IR = (*builtinMemberOf)(nil)
 )
 
 var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
@@ -268,6 +294,219 @@ func (call *builtinJSONRemove) compile(c *compiler) (ctype, error) {
 	return jt, nil
 }
 
+// intoMutableJSON is like intoJSON, but the returned document can be modified in place:
+// documents that are already JSON values may be shared, e.g. by a literal, so they are copied.
+func intoMutableJSON(fn string, e eval) (*evalJSON, error) {
+	if j, ok := e.(*evalJSON); ok {
+		return j.Clone(), nil
+	}
+	return intoJSON(fn, e)
+}
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	doc, err := intoMutableJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]*json.Path, 0, len(args)/2)
+	values := make([]*json.Value, 0, len(args)/2)
+	for i := 1; i < len(args); i += 2 {
+		if args[i] == nil {
+			return nil, nil
+		}
+
+		path, err := intoJSONPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if path.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+
+		value, err := argToJSON(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, path)
+		values = append(values, value.Clone())
+	}
+
+	if err := json.ApplyTransform(call.transform, doc, paths, values); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (call *builtinJSONModify) compile(c *compiler) (ctype, error) {
+	doct, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	nullable := doct.nullable()
+	skip := c.compileNullCheck1(doct)
+
+	jt, err := c.compileParseJSON(call.Method, doct, 1)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	staticPaths := make([]staticPath, 0, len(call.Arguments)/2)
+	for i := 1; i < len(call.Arguments); i += 2 {
+		arg := call.Arguments[i]
+		argType, err := arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+
+		if !nullable {
+			nullable = argType.nullable()
+		}
+
+		if arg.constant() {
+			staticEnv := EmptyExpressionEnv(c.env)
+			arg, err = simplifyExpr(staticEnv, arg)
+			if err != nil {
+				return ctype{}, err
+			}
+
+			p, err := c.jsonExtractPath(arg)
+			staticPaths = append(staticPaths, staticPath{p, err})
+		} else {
+			staticPaths = append(staticPaths, staticPath{nil, nil})
+		}
+
+		valueType, err := call.Arguments[i+1].compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+
+		_, err = c.compileArgToJSON(valueType, 1)
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+
+	// documents that are already JSON values may be shared and must be copied before
+	// being modified, while the ones that were parsed from strings are new
+	c.asm.Fn_JSON_MODIFY(call.Method, call.transform, doct.Type == sqltypes.TypeJSON, staticPaths)
+	c.asm.jumpDestination(skip)
+
+	if nullable {
+		// If any argument is nullable, the result is nullable too
+		jt.Flag |= flagNullable
+	}
+
+	return jt, nil
+}
+
+func (call *builtinJSONMergePreserve) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PRESERVE(call.Method, args)
+}
+
+func builtin_JSON_MERGE_PRESERVE(fn string, args []eval) (eval, error) {
+	var result *evalJSON
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+		doc, err := intoJSON(fn, arg)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = doc
+		} else {
+			result = json.MergePreserve(result, doc)
+		}
+	}
+	return result, nil
+}
+
+func (call *builtinJSONMergePreserve) compile(c *compiler) (ctype, error) {
+	return c.compileJSONMerge(call.Arguments, call.Method, c.asm.Fn_JSON_MERGE_PRESERVE)
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PATCH(call.Method, args)
+}
+
+func builtin_JSON_MERGE_PATCH(fn string, args []eval) (eval, error) {
+	var result *evalJSON
+	var null bool
+	for i, arg := range args {
+		if arg == nil {
+			// the result is unknown, unless a later patch that is not an
+			// object replaces it
+			null = true
+			continue
+		}
+		doc, err := intoJSON(fn, arg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case null:
+			if doc.Type() != json.TypeObject {
+				result, null = doc, false
+			}
+		case i == 0:
+			result = doc
+		default:
+			result = json.MergePatch(result, doc)
+		}
+	}
+	if null {
+		return nil, nil
+	}
+	return result, nil
+}
+
+func (call *builtinJSONMergePatch) compile(c *compiler) (ctype, error) {
+	return c.compileJSONMerge(call.Arguments, call.Method, c.asm.Fn_JSON_MERGE_PATCH)
+}
+
+func (c *compiler) compileJSONMerge(args []IR, method string, merge func(method string, args int)) (ctype, error) {
+	var nullable bool
+	for _, arg := range args {
+		argType, err := arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		nullable = nullable || argType.nullable()
+	}
+
+	// the arguments are parsed while being merged, because any of them may be NULL
+	merge(method, len(args))
+
+	jt := ctype{Type: sqltypes.TypeJSON, Col: collationJSON}
+	if nullable {
+		jt.Flag |= flagNullable
+	}
+	return jt, nil
+}
+
 func (call *builtinJSONUnquote) eval(env *ExpressionEnv) (eval, error) {
 	arg, err := call.arg1(env)
 	if err != nil {
@@ -638,3 +877,177 @@ func (call *builtinJSONKeys) compile(c *compiler) (ctype, error) {
 
 	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
 }
+
+// jsonArrayContains returns whether any of the elements is equal to the value.
+func jsonArrayContains(elements []*json.Value, value *json.Value) (bool, error) {
+	for _, elem := range elements {
+		cmp, err := compareJSONValue(elem, value)
+		if err != nil {
+			return false, err
+		}
+		if cmp == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// jsonMemberOf returns whether the value is an element of the array.
+// If the document is not an array, it is compared with the value as if
+// it was an array with a single element.
+func jsonMemberOf(value, doc *json.Value) (bool, error) {
+	if elements, ok := doc.Array(); ok {
+		return jsonArrayContains(elements, value)
+	}
+	return jsonArrayContains([]*json.Value{doc}, value)
+}
+
+// jsonOverlaps returns whether two JSON documents have any array element or any
+// key-value pair in common. Like in MySQL, a scalar or an object compared with an
+// array is treated as an array with a single element, two objects overlap when they
+// share a key with the same value, and two scalars overlap when they are equal.
+func jsonOverlaps(a, b *json.Value) (bool, error) {
+	if a.Type() != json.TypeArray && b.Type() == json.TypeArray {
+		a, b = b, a
+	}
+
+	switch a.Type() {
+	case json.TypeArray:
+		elements, _ := a.Array()
+		others, ok := b.Array()
+		if !ok {
+			return jsonArrayContains(elements, b)
+		}
+		for _, other := range others {
+			found, err := jsonArrayContains(elements, other)
+			if err != nil || found {
+				return found, err
+			}
+		}
+		return false, nil
+	case json.TypeObject:
+		if b.Type() != json.TypeObject {
+			return false, nil
+		}
+		ao, _ := a.Object()
+		bo, _ := b.Object()
+		for _, key := range bo.Keys() {
+			av := ao.Get(key)
+			if av == nil {
+				continue
+			}
+			cmp, err := compareJSONValue(av, bo.Get(key))
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		cmp, err := compareJSONValue(a, b)
+		return cmp == 0, err
+	}
+}
+
+func (call *builtinJSONOverlaps) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	doc1, err := intoJSON("JSON_OVERLAPS", arg1)
+	if err != nil {
+		return nil, err
+	}
+	doc2, err := intoJSON("JSON_OVERLAPS", arg2)
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps, err := jsonOverlaps(doc1, doc2)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(overlaps), nil
+}
+
+func (call *builtinJSONOverlaps) compile(c *compiler) (ctype, error) {
+	doc1, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	doc2, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(doc1, doc2)
+
+	if _, err := c.compileParseJSON("JSON_OVERLAPS", doc1, 2); err != nil {
+		return ctype{}, err
+	}
+	if _, err := c.compileParseJSON("JSON_OVERLAPS", doc2, 1); err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_JSON_OVERLAPS()
+	c.asm.jumpDestination(skip)
+
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | nullableFlags(doc1.Flag|doc2.Flag)}, nil
+}
+
+func (call *builtinMemberOf) eval(env *ExpressionEnv) (eval, error) {
+	value, arr, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil || arr == nil {
+		return nil, nil
+	}
+
+	// unlike the JSON array, the value is not parsed: a string is a JSON string
+	val, err := argToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := intoJSON("MEMBER OF", arr)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := jsonMemberOf(val, doc)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(found), nil
+}
+
+func (call *builtinMemberOf) compile(c *compiler) (ctype, error) {
+	value, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	arr, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(value, arr)
+
+	if _, err := c.compileArgToJSON(value, 2); err != nil {
+		return ctype{}, err
+	}
+	if _, err := c.compileParseJSON("MEMBER OF", arr, 1); err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_MEMBER_OF()
+	c.asm.jumpDestination(skip)
+
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | nullableFlags(value.Flag|arr.Flag)}, nil
+}
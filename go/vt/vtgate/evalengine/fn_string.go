@@ -87,6 +87,10 @@ type (
 		CallExpr
 	}
 
+	builtinCoercibility struct {
+		CallExpr
+	}
+
 	builtinWeightString struct {
 		CallExpr
 		Cast string
@@ -146,6 +150,21 @@ type (
 		collate collations.ID
 	}
 
+	builtinFindInSet struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinMakeSet struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinExportSet struct {
+		CallExpr
+		collate collations.ID
+	}
+
 	builtinReplace struct {
 		CallExpr
 		collate collations.ID
@@ -165,6 +184,7 @@ var (
 	_ IR = (*builtinOrd)(nil)
 	_ IR = (*builtinBitLength)(nil)
 	_ IR = (*builtinCollation)(nil)
+	_ IR = (*builtinCoercibility)(nil)
 	_ IR = (*builtinWeightString)(nil)
 	_ IR = (*builtinLeftRight)(nil)
 	_ IR = (*builtinPad)(nil)
@@ -176,6 +196,9 @@ var (
 	_ IR = (*builtinRepeat)(nil)
 	_ IR = (*builtinConcat)(nil)
 	_ IR = (*builtinConcatWs)(nil)
+	_ IR = (*builtinFindInSet)(nil)
+	_ IR = (*builtinMakeSet)(nil)
+	_ IR = (*builtinExportSet)(nil)
 	_ IR = (*builtinReplace)(nil)
 )
 
@@ -1020,6 +1043,24 @@ func (expr *builtinCollation) compile(c *compiler) (ctype, error) {
 	return ctype{Type: sqltypes.VarChar, Col: collationUtf8mb3}, nil
 }
 
+func (c *builtinCoercibility) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := c.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalInt64(int64(evalCollation(arg).Coercibility)), nil
+}
+
+func (expr *builtinCoercibility) compile(c *compiler) (ctype, error) {
+	_, err := expr.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	c.asm.Fn_COERCIBILITY()
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric}, nil
+}
+
 func (c *builtinWeightString) eval(env *ExpressionEnv) (eval, error) {
 	var weights []byte
 
@@ -1046,6 +1087,14 @@ func (c *builtinWeightString) eval(env *ExpressionEnv) (eval, error) {
 	switch val := input.(type) {
 	case *evalInt64, *evalUint64, *evalTemporal:
 		weights, _, err = evalWeightString(weights, val, 0, 0)
+	case *evalEnum, *evalSet:
+		// The weight string of ENUM and SET values depends on the number of
+		// values of their column.
+		col, ok := c.Arguments[0].(*Column)
+		if !ok || col.Values == nil {
+			return nil, nil
+		}
+		weights = enumSetWeightString(weights, val, col.Values)
 	case *evalJSON:
 		// JSON doesn't actually use a sortable weight string for this function, but
 		// returns the weight string directly for the string based representation. This
@@ -1123,6 +1172,13 @@ func (call *builtinWeightString) compile(c *compiler) (ctype, error) {
 		typ = sqltypes.Blob
 		c.asm.Convert_xce(1, sqltypes.VarChar, collationJSON.Collation)
 		c.asm.Fn_WEIGHT_STRING(typ, 0)
+	case sqltypes.Enum, sqltypes.Set:
+		if str.Values == nil {
+			c.asm.SetNull(1)
+			flag = flag | flagNull | flagNullable
+			break
+		}
+		c.asm.Fn_WEIGHT_STRING_enumset(str.Values)
 	case sqltypes.VarChar, sqltypes.Char, sqltypes.Text:
 		if str.Type == sqltypes.Text {
 			typ = sqltypes.Blob
@@ -2004,6 +2060,367 @@ func (call *builtinConcatWs) compile(c *compiler) (ctype, error) {
 	return ctype{Type: tt, Flag: args[0].Flag, Col: tc}, nil
 }
 
+// compileConvertToCollation converts the string argument at the given offset
+// of the stack to the collation of the result, unless its bytes are already
+// valid in the charset of the result.
+func compileConvertToCollation(c *compiler, arg ctype, offset int, tc collations.TypedCollation) {
+	switch arg.Type {
+	case sqltypes.VarBinary, sqltypes.Binary, sqltypes.Blob:
+		if tc.Collation != collations.CollationBinaryID {
+			c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+		}
+	case sqltypes.VarChar, sqltypes.Char, sqltypes.Text:
+		fromCharset := colldata.Lookup(arg.Col.Collation).Charset()
+		toCharset := colldata.Lookup(tc.Collation).Charset()
+		if fromCharset != toCharset && !toCharset.IsSuperset(fromCharset) {
+			c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+		}
+	default:
+		c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+	}
+}
+
+// findInSet returns the 1-based position of str in the comma separated list
+// strlist, or 0 if it is not one of its elements. Like MySQL, an empty list
+// has no elements, and the search stops at the first invalid character.
+func findInSet(str, strlist []byte, col collations.ID) int64 {
+	if len(strlist) == 0 || len(strlist) < len(str) {
+		return 0
+	}
+
+	coll := colldata.Lookup(col)
+	cs := coll.Charset()
+
+	pos := int64(1)
+	start := 0
+	for i := 0; i < len(strlist); {
+		r, size := cs.DecodeRune(strlist[i:])
+		if r == charset.RuneError && size < 2 {
+			return 0
+		}
+		if r == ',' {
+			if coll.Collate(strlist[start:i], str, false) == 0 {
+				return pos
+			}
+			pos++
+			start = i + size
+		}
+		i += size
+	}
+	if coll.Collate(strlist[start:], str, false) == 0 {
+		return pos
+	}
+	return 0
+}
+
+func (call *builtinFindInSet) eval(env *ExpressionEnv) (eval, error) {
+	str, strlist, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || strlist == nil {
+		return nil, nil
+	}
+
+	var ca collationAggregation
+	if err := ca.add(evalCollation(str), env.collationEnv); err != nil {
+		return nil, err
+	}
+	if err := ca.add(evalCollation(strlist), env.collationEnv); err != nil {
+		return nil, err
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(sqltypes.VarChar, call.collate)
+	}
+
+	s, err := evalToVarchar(str, tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	l, err := evalToVarchar(strlist, tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEvalInt64(findInSet(s.bytes, l.bytes, tc.Collation)), nil
+}
+
+func (call *builtinFindInSet) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	strlist, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(str, strlist)
+
+	var ca collationAggregation
+	if err := ca.add(str.Col, c.env.CollationEnv()); err != nil {
+		return ctype{}, err
+	}
+	if err := ca.add(strlist.Col, c.env.CollationEnv()); err != nil {
+		return ctype{}, err
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(sqltypes.VarChar, call.collate)
+	}
+
+	compileConvertToCollation(c, str, 2, tc)
+	compileConvertToCollation(c, strlist, 1, tc)
+
+	c.asm.Fn_FIND_IN_SET(tc.Collation)
+	c.asm.jumpDestination(skip)
+
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: nullableFlags(str.Flag | strlist.Flag)}, nil
+}
+
+func (call *builtinMakeSet) eval(env *ExpressionEnv) (eval, error) {
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	for _, arg := range args[1:] {
+		if arg != nil {
+			tt = concatSQLType(arg.SQLType(), tt)
+		}
+		err = ca.add(evalCollation(arg), env.collationEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, call.collate)
+	}
+
+	var buf []byte
+	first := true
+	bits := uint64(evalToInt64(args[0]).i)
+	for i := 1; bits != 0 && i < len(args); i, bits = i+1, bits>>1 {
+		// Like CONCAT_WS, MAKE_SET skips nil arguments.
+		if bits&1 == 0 || args[i] == nil {
+			continue
+		}
+		b, err := evalToVarchar(args[i], tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, b.bytes...)
+	}
+
+	return newEvalRaw(tt, buf, tc), nil
+}
+
+func (call *builtinMakeSet) compile(c *compiler) (ctype, error) {
+	args := make([]ctype, len(call.Arguments))
+
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+
+	var skip *jump
+	for i, arg := range call.Arguments {
+		var err error
+		args[i], err = arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+
+		if i == 0 {
+			skip = c.compileNullCheck1(args[i])
+			continue
+		}
+
+		tt = concatSQLType(args[i].Type, tt)
+		err = ca.add(args[i].Col, c.env.CollationEnv())
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, call.collate)
+	}
+
+	_ = c.compileToInt64(args[0], len(args))
+
+	for i, arg := range args[1:] {
+		offset := len(args) - (i + 1)
+		skip := c.compileNullCheckOffset(arg, offset)
+		compileConvertToCollation(c, arg, offset, tc)
+		c.asm.jumpDestination(skip)
+	}
+
+	c.asm.Fn_MAKE_SET(len(args), tt, tc)
+	c.asm.jumpDestination(skip)
+
+	return ctype{Type: tt, Col: tc, Flag: nullableFlags(args[0].Flag)}, nil
+}
+
+func (call *builtinExportSet) eval(env *ExpressionEnv) (eval, error) {
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	// The result has the collation of the ON, OFF and separator strings.
+	for _, arg := range args[1:min(len(args), 4)] {
+		tt = concatSQLType(arg.SQLType(), tt)
+		err = ca.add(evalCollation(arg), env.collationEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, call.collate)
+	}
+
+	on, err := evalToVarchar(args[1], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	off, err := evalToVarchar(args[2], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sep := []byte(",")
+	if len(args) > 3 {
+		s, err := evalToVarchar(args[3], tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		sep = s.bytes
+	}
+
+	n := uint64(64)
+	if len(args) > 4 {
+		n = min(uint64(evalToInt64(args[4]).i), 64)
+	}
+
+	res, ok := exportSet(uint64(evalToInt64(args[0]).i), on.bytes, off.bytes, sep, n)
+	if !ok {
+		return nil, nil
+	}
+	return newEvalRaw(tt, res, tc), nil
+}
+
+// exportSet returns the ON string for every bit set in bits, and the OFF
+// string for every bit that is not set, from the lowest bit up to n bits.
+// Like MySQL, negative or larger numbers of bits export all 64 bits, and
+// results longer than the maximum length of a string are not valid.
+func exportSet(bits uint64, on, off, sep []byte, n uint64) ([]byte, bool) {
+	length := int64(n) * int64(max(len(on), len(off)))
+	if n > 0 {
+		length += int64(n-1) * int64(len(sep))
+	}
+	if !validMaxLength(length, 1) {
+		return nil, false
+	}
+
+	buf := make([]byte, 0, length)
+	for i := range n {
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+		if bits&(1<<i) != 0 {
+			buf = append(buf, on...)
+		} else {
+			buf = append(buf, off...)
+		}
+	}
+	return buf, true
+}
+
+func (call *builtinExportSet) compile(c *compiler) (ctype, error) {
+	args := make([]ctype, len(call.Arguments))
+
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+
+	var skips []*jump
+	for i, arg := range call.Arguments {
+		var err error
+		args[i], err = arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		skips = append(skips, c.compileNullCheckArg(args[i], i))
+
+		if i == 0 || i > 3 {
+			continue
+		}
+
+		tt = concatSQLType(args[i].Type, tt)
+		err = ca.add(args[i].Col, c.env.CollationEnv())
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, call.collate)
+	}
+
+	_ = c.compileToInt64(args[0], len(args))
+	for i, arg := range args[1:min(len(args), 4)] {
+		compileConvertToCollation(c, arg, len(args)-(i+1), tc)
+	}
+	if len(args) > 4 {
+		_ = c.compileToInt64(args[4], 1)
+	}
+
+	c.asm.Fn_EXPORT_SET(len(args), tt, tc)
+	c.asm.jumpDestination(skips...)
+
+	return ctype{Type: tt, Col: tc, Flag: flagNullable}, nil
+}
+
 func (call *builtinChar) eval(env *ExpressionEnv) (eval, error) {
 	vals := make([]eval, 0, len(call.Arguments))
 	for _, arg := range call.Arguments {
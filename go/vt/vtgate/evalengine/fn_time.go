@@ -18,6 +18,7 @@ package evalengine
 
 import (
 	"math"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/hack"
@@ -67,6 +68,21 @@ type (
 		collate collations.ID
 	}
 
+	builtinStrToDate struct {
+		CallExpr
+		// typ and prec are the type and precision of the result, which
+		// depend on the parts of the date and time in the format.
+		typ  sqltypes.Type
+		prec uint8
+	}
+
+	builtinGetFormat struct {
+		CallExpr
+		// kind is the type of the format: DATE, TIME or DATETIME.
+		kind    sqltypes.Type
+		collate collations.ID
+	}
+
 	builtinDate struct {
 		CallExpr
 	}
@@ -199,6 +215,8 @@ var (
 	_ IR = (*builtinCurdate)(nil)
 	_ IR = (*builtinUtcDate)(nil)
 	_ IR = (*builtinDateFormat)(nil)
+	_ IR = (*builtinStrToDate)(nil)
+	_ IR = (*builtinGetFormat)(nil)
 	_ IR = (*builtinDate)(nil)
 	_ IR = (*builtinDayOfMonth)(nil)
 	_ IR = (*builtinDayOfWeek)(nil)
@@ -257,10 +275,7 @@ func (call *builtinNow) constant() bool {
 }
 
 func (call *builtinSysdate) eval(env *ExpressionEnv) (eval, error) {
-	now := SystemTime()
-	if tz := env.currentTimezone(); tz != time.Local {
-		now = now.In(tz)
-	}
+	now := SystemTime().In(env.currentTimezone())
 	return newEvalDateTime(datetime.NewDateTimeFromStd(now), int(call.prec), false), nil
 }
 
@@ -361,6 +376,159 @@ func (call *builtinDateFormat) compile(c *compiler) (ctype, error) {
 	return ctype{Type: sqltypes.VarChar, Col: col, Flag: arg.Flag | flagNullable}, nil
 }
 
+// strToDateType returns the type and precision of the result of
+// STR_TO_DATE with format.
+func strToDateType(format string) (sqltypes.Type, uint8) {
+	hasDate, hasTime, hasFrac := datetime.FormatParts(format)
+	var prec uint8
+	if hasFrac {
+		prec = datetime.DefaultPrecision
+	}
+	switch {
+	case hasDate && hasTime:
+		return sqltypes.Datetime, prec
+	case hasTime:
+		return sqltypes.Time, prec
+	default:
+		return sqltypes.Date, 0
+	}
+}
+
+// strToDate parses s with format into a value of type typ. Like MySQL, it
+// returns nil for dates with zero parts.
+func strToDate(typ sqltypes.Type, prec uint8, format, s string) *evalTemporal {
+	dt, ok := datetime.StrToDate(format, s)
+	if !ok {
+		return nil
+	}
+	if typ == sqltypes.Time {
+		return newEvalTime(dt.Time, int(prec))
+	}
+	if dt.Date.Year() == 0 || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+		return nil
+	}
+	if typ == sqltypes.Date {
+		return newEvalDate(dt.Date, false)
+	}
+	return newEvalDateTime(dt, int(prec), false)
+}
+
+func (call *builtinStrToDate) eval(env *ExpressionEnv) (eval, error) {
+	str, format, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || format == nil {
+		return nil, nil
+	}
+	t := strToDate(call.typ, call.prec, evalToBinary(format).string(), evalToBinary(str).string())
+	if t == nil {
+		return nil, nil
+	}
+	return t, nil
+}
+
+func (call *builtinStrToDate) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(str)
+
+	switch str.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	format, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(format)
+
+	switch format.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	c.asm.Fn_STR_TO_DATE(call.typ, call.prec)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: call.typ, Col: collationBinary, Flag: flagNullable, Size: int32(call.prec)}, nil
+}
+
+// getFormats are the formats returned by GET_FORMAT for each of its types.
+var getFormats = map[sqltypes.Type][]struct{ name, format string }{
+	sqltypes.Date: {
+		{"USA", "%m.%d.%Y"},
+		{"JIS", "%Y-%m-%d"},
+		{"ISO", "%Y-%m-%d"},
+		{"EUR", "%d.%m.%Y"},
+		{"INTERNAL", "%Y%m%d"},
+	},
+	sqltypes.Datetime: {
+		{"USA", "%Y-%m-%d %H.%i.%s"},
+		{"JIS", "%Y-%m-%d %H:%i:%s"},
+		{"ISO", "%Y-%m-%d %H:%i:%s"},
+		{"EUR", "%Y-%m-%d %H.%i.%s"},
+		{"INTERNAL", "%Y%m%d%H%i%s"},
+	},
+	sqltypes.Time: {
+		{"USA", "%h:%i:%s %p"},
+		{"JIS", "%H:%i:%s"},
+		{"ISO", "%H:%i:%s"},
+		{"EUR", "%H.%i.%s"},
+		{"INTERNAL", "%H%i%s"},
+	},
+}
+
+func getFormat(kind sqltypes.Type, name string) (string, bool) {
+	for _, f := range getFormats[kind] {
+		if strings.EqualFold(f.name, name) {
+			return f.format, true
+		}
+	}
+	return "", false
+}
+
+func (call *builtinGetFormat) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	format, ok := getFormat(call.kind, evalToBinary(arg).string())
+	if !ok {
+		return nil, nil
+	}
+	return newEvalText([]byte(format), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinGetFormat) compile(c *compiler) (ctype, error) {
+	arg, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(arg)
+
+	switch arg.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	col := typedCoercionCollation(sqltypes.VarChar, c.collation)
+	c.asm.Fn_GET_FORMAT(call.kind, col)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
+
 type builtinConvertTz struct {
 	CallExpr
 }
@@ -702,10 +870,7 @@ func (b *builtinFromUnixtime) eval(env *ExpressionEnv) (eval, error) {
 		prec = maxTimePrec
 	}
 
-	t := time.Unix(sec, frac)
-	if tz := env.currentTimezone(); tz != time.Local {
-		t = t.In(tz)
-	}
+	t := time.Unix(sec, frac).In(env.currentTimezone())
 
 	dt := newEvalDateTime(datetime.NewDateTimeFromStd(t), prec, env.sqlmode.AllowZeroDate())
 
@@ -18,6 +18,7 @@ package evalengine
 
 import (
 	"fmt"
+	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
@@ -41,6 +42,8 @@ func precedenceFor(in IR) sqlparser.Precendence {
 		return sqlparser.P11
 	case *IsExpr:
 		return sqlparser.P11
+	case *builtinMemberOf:
+		return sqlparser.P11
 	case *BitwiseExpr:
 		switch node.Op.(type) {
 		case opBitOr:
@@ -172,6 +175,18 @@ func (c *Column) FormatFast(buf *sqlparser.TrackedBuffer) {
 	c.format(buf)
 }
 
+func (uv *UserVariable) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteString("@")
+	buf.WriteString(sqlparser.String(sqlparser.NewIdentifierCI(uv.Name)))
+}
+
+func (expr *AssignmentExpr) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteString("@")
+	buf.WriteString(sqlparser.String(sqlparser.NewIdentifierCI(expr.Name)))
+	buf.WriteString(" := ")
+	expr.Inner.format(buf)
+}
+
 func (c *Column) format(buf *sqlparser.TrackedBuffer) {
 	if c.Original != nil {
 		c.Original.FormatFast(buf)
@@ -194,6 +209,10 @@ func (c *LikeExpr) format(buf *sqlparser.TrackedBuffer) {
 		op = "not like"
 	}
 	formatBinary(buf, c, c.Left, op, c.Right)
+	if c.Escape != nil {
+		buf.WriteString(" escape ")
+		formatExpr(buf, c, c.Escape, false)
+	}
 }
 
 func (c *InExpr) format(buf *sqlparser.TrackedBuffer) {
@@ -278,6 +297,25 @@ func (c *builtinWeightString) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte(')')
 }
 
+func (c *builtinMemberOf) format(buf *sqlparser.TrackedBuffer) {
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteLiteral(" member of (")
+	formatExpr(buf, c, c.Arguments[1], true)
+	buf.WriteByte(')')
+}
+
+func (c *builtinGetFormat) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("get_format(")
+	if c.kind == sqltypes.Datetime {
+		buf.WriteLiteral("datetime")
+	} else {
+		buf.WriteLiteral(strings.ToLower(c.kind.String()))
+	}
+	buf.WriteString(", ")
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteByte(')')
+}
+
 func (n *NegateExpr) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte('-')
 	formatExpr(buf, n, n.Inner, true)
@@ -21,8 +21,10 @@ package integration
 import (
 	"fmt"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -50,6 +52,8 @@ var (
 	debugNormalize       = true
 	debugSimplify        = time.Now().UnixNano()&1 != 0
 	debugCheckCollations = true
+
+	comparisonWorkers = 1
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -57,6 +61,7 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&debugNormalize, "normalize", debugNormalize, "normalize comparisons against MySQL values")
 	fs.BoolVar(&debugSimplify, "simplify", debugSimplify, "simplify expressions before evaluating them")
 	fs.BoolVar(&debugCheckCollations, "check-collations", debugCheckCollations, "check the returned collations for all queries")
+	fs.IntVar(&comparisonWorkers, "workers", comparisonWorkers, "number of MySQL connections to run the comparison cases on in parallel")
 }
 
 // normalizeValue returns a normalized form of this value that matches the output
@@ -82,7 +87,9 @@ func normalizeValue(v sqltypes.Value, coll collations.ID) sqltypes.Value {
 	return v
 }
 
-func compareRemoteExprEnv(t *testing.T, collationEnv *collations.Environment, env *evalengine.ExpressionEnv, conn *mysql.Conn, expr string, fields []*querypb.Field, cmp *testcases.Comparison, skipCollationCheck bool) {
+// compareRemoteExprEnv compares the result of evaluating expr locally and in MySQL. When
+// generating golden files, it only returns the result of MySQL instead.
+func compareRemoteExprEnv(t *testing.T, collationEnv *collations.Environment, env *evalengine.ExpressionEnv, conn *mysql.Conn, expr string, fields []*querypb.Field, cmp *testcases.Comparison, skipCollationCheck bool) *GoldenTest {
 	t.Helper()
 
 	localQuery := "SELECT " + expr
@@ -194,17 +201,15 @@ func compareRemoteExprEnv(t *testing.T, collationEnv *collations.Environment, en
 		} else {
 			g.Value = remoteVal.String()
 		}
-		seenGoldenTests = append(seenGoldenTests, g)
-		return
+		return &g
 	}
 
 	if err := compareResult(localResult, remoteResult, cmp); err != nil {
 		assert.Failf(t, "compareResult failed", "%s\nquery: %s (SIMPLIFY=%v)\nrow: %v", err, localQuery, debugSimplify, env.Row)
 	}
+	return nil
 }
 
-var seenGoldenTests []GoldenTest
-
 type vcursor struct {
 	env *vtenv.Environment
 }
@@ -248,33 +253,55 @@ func initTimezoneData(t *testing.T, conn *mysql.Conn) {
 
 func TestMySQL(t *testing.T) {
 	defer utils.EnsureNoLeaks(t)
-	conn := mysqlconn(t)
-	defer conn.Close()
+
+	// Every worker runs its share of the cases on its own connection: worker w
+	// runs the cases w, w+workers, w+2*workers... so that the assignment of the
+	// cases to the connections does not depend on timing.
+	workers := max(comparisonWorkers, 1)
+	conns := make([]*mysql.Conn, workers)
+	for w := range conns {
+		conns[w] = mysqlconn(t)
+		defer conns[w].Close()
+	}
 
 	// We require MySQL 8.0 collations for the comparisons in the tests
 
-	collationEnv := collations.NewEnvironment(conn.ServerVersion)
+	collationEnv := collations.NewEnvironment(conns[0].ServerVersion)
 	servenv.OnParse(registerFlags)
-	initTimezoneData(t, conn)
+	initTimezoneData(t, conns[0])
 
 	venv, err := vtenv.New(vtenv.Options{
-		MySQLServerVersion: conn.ServerVersion,
+		MySQLServerVersion: conns[0].ServerVersion,
 	})
 	require.NoError(t, err)
-	for _, tc := range testcases.Cases {
-		t.Run(tc.Name(), func(t *testing.T) {
-			ctx := callerid.NewContext(t.Context(), &vtrpc.CallerID{Principal: "testuser"}, &querypb.VTGateCallerID{
-				Username: "vt_dba",
-			})
-			env := evalengine.NewExpressionEnv(ctx, nil, &vcursor{env: venv})
-			tc.Run(func(query string, row []sqltypes.Value, skipCollationCheck bool) {
-				env.Row = row
-				compareRemoteExprEnv(t, collationEnv, env, conn, query, tc.Schema, tc.Compare, skipCollationCheck)
-			})
+
+	// golden holds the golden tests of every case, so they are written in the
+	// order of the cases whatever the number of workers.
+	golden := make([][]GoldenTest, len(testcases.Cases))
+
+	var wg sync.WaitGroup
+	for w, conn := range conns {
+		wg.Go(func() {
+			for i := w; i < len(testcases.Cases); i += workers {
+				tc := testcases.Cases[i]
+				t.Run(tc.Name(), func(t *testing.T) {
+					ctx := callerid.NewContext(t.Context(), &vtrpc.CallerID{Principal: "testuser"}, &querypb.VTGateCallerID{
+						Username: "vt_dba",
+					})
+					env := evalengine.NewExpressionEnv(ctx, nil, &vcursor{env: venv})
+					tc.Run(func(query string, row []sqltypes.Value, skipCollationCheck bool) {
+						env.Row = row
+						if g := compareRemoteExprEnv(t, collationEnv, env, conn, query, tc.Schema, tc.Compare, skipCollationCheck); g != nil {
+							golden[i] = append(golden[i], *g)
+						}
+					})
+				})
+			}
 		})
 	}
+	wg.Wait()
 
 	if debugGolden {
-		writeGolden(t, seenGoldenTests)
+		writeGolden(t, slices.Concat(golden...))
 	}
 }
@@ -90,6 +90,8 @@ var (
 	fuzzMaxTime     = 30 * time.Second
 	fuzzMaxFailures = 0
 	fuzzSeed        = time.Now().Unix()
+	fuzzExpressions = 0
+	fuzzDepth       = 4
 	extractError    = regexp.MustCompile(`(.*?) \(errno (\d+)\) \(sqlstate (\w+)\) during query: (.*?)`)
 	knownErrors     = []*regexp.Regexp{
 		regexp.MustCompile(`value is out of range in '(.*?)'`),
@@ -112,6 +114,8 @@ func init() {
 	pflag.DurationVar(&fuzzMaxTime, "fuzz-duration", fuzzMaxTime, "Maximum time to fuzz for")
 	pflag.IntVar(&fuzzMaxFailures, "fuzz-total", fuzzMaxFailures, "Maximum number of failures to fuzz for")
 	pflag.Int64Var(&fuzzSeed, "fuzz-seed", fuzzSeed, "RNG seed when generating fuzz expressions")
+	pflag.IntVar(&fuzzExpressions, "fuzz-expressions", fuzzExpressions, "Number of random expression trees to compare against MySQL")
+	pflag.IntVar(&fuzzDepth, "fuzz-depth", fuzzDepth, "Maximum depth of the random expression trees")
 }
 
 func errorsMatch(remote, local error) bool {
@@ -289,6 +293,37 @@ func TestGenerateFuzzCases(t *testing.T) {
 	writeGolden(t, golden)
 }
 
+// TestFuzzExpressions compares the evalengine against MySQL for random expression
+// trees built out of the functions and operators supported by the evalengine.
+// The expressions only depend on the seed, so a failure can be reproduced by
+// running the test again with the same -fuzz-seed and -fuzz-depth.
+func TestFuzzExpressions(t *testing.T) {
+	if fuzzExpressions <= 0 {
+		t.Skipf("skipping expression fuzzing")
+	}
+
+	conn := mysqlconn(t)
+	defer conn.Close()
+
+	collationEnv := collations.NewEnvironment(conn.ServerVersion)
+	venv, err := vtenv.New(vtenv.Options{
+		MySQLServerVersion: conn.ServerVersion,
+	})
+	require.NoError(t, err)
+
+	t.Logf("fuzzing %d expressions with seed %d and depth %d", fuzzExpressions, fuzzSeed, fuzzDepth)
+
+	env := evalengine.NewExpressionEnv(t.Context(), nil, &vcursor{env: venv})
+	start := time.Now()
+	testcases.Fuzz(uint64(fuzzSeed), fuzzDepth, fuzzExpressions)(func(query string, row []sqltypes.Value, skipCollationCheck bool) {
+		if time.Since(start) > fuzzMaxTime {
+			return
+		}
+		env.Row = row
+		compareRemoteExprEnv(t, collationEnv, env, conn, query, nil, nil, skipCollationCheck)
+	})
+}
+
 func writeGolden(t *testing.T, golden []GoldenTest) {
 	out, err := os.Create(fmt.Sprintf("testdata/mysql_golden_%d.json", time.Now().Unix()))
 	require.NoError(t, err)
@@ -23,6 +23,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/testcases"
 )
 
 func BenchmarkCompilerExpressions(b *testing.B) {
@@ -81,5 +82,53 @@ func BenchmarkCompilerExpressions(b *testing.B) {
 				_, _ = env.EvaluateVM(compiled)
 			}
 		})
+
+		b.Run(tc.name+"/eval=batch", func(b *testing.B) {
+			rows := make([][]sqltypes.Value, 1024)
+			for i := range rows {
+				rows[i] = tc.values
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			var env evalengine.ExpressionEnv
+			for n := 0; n < b.N; n += len(rows) {
+				_, _ = env.FilterBatch(translated, rows)
+			}
+		})
+	}
+}
+
+func BenchmarkLargeDecimals(b *testing.B) {
+	venv := vtenv.NewTestEnv()
+	cfg := &evalengine.Config{
+		Collation:   collations.CollationUtf8mb4ID,
+		Environment: venv,
+		// the queries only have literals, which would be folded at translation
+		NoConstantFolding: true,
+	}
+
+	var compiled []*evalengine.CompiledExpr
+	testcases.LargeDecimalDivision(func(query string, _ []sqltypes.Value, _ bool) {
+		expr, err := venv.Parser().ParseExpr(query)
+		if err != nil {
+			b.Fatal(err)
+		}
+		translated, err := evalengine.Translate(expr, cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		compiled = append(compiled, translated.(*evalengine.CompiledExpr))
+	})
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	var env evalengine.ExpressionEnv
+	for n := 0; n < b.N; n++ {
+		for _, expr := range compiled {
+			_, _ = env.EvaluateVM(expr)
+		}
 	}
 }
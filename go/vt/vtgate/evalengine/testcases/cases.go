@@ -21,6 +21,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -33,7 +34,11 @@ var Cases = []TestCase{
 	{Run: FnJSONKeys},
 	{Run: FnJSONExtract},
 	{Run: FnJSONRemove},
+	{Run: FnJSONModify},
+	{Run: FnJSONMerge},
 	{Run: FnJSONContainsPath},
+	{Run: FnJSONOverlaps},
+	{Run: FnMemberOf},
 	{Run: FnJSONUnquote},
 	{Run: JSONArray},
 	{Run: JSONObject},
@@ -44,6 +49,7 @@ var Cases = []TestCase{
 	{Run: Base64},
 	{Run: Conversion},
 	{Run: LargeDecimals},
+	{Run: LargeDecimalDivision},
 	{Run: LargeIntegers},
 	{Run: DecimalClamping},
 	{Run: BitwiseOperatorsUnary},
@@ -92,6 +98,9 @@ var Cases = []TestCase{
 	{Run: FnReplace},
 	{Run: FnConcat},
 	{Run: FnConcatWs},
+	{Run: FnFindInSet},
+	{Run: FnMakeSet},
+	{Run: FnExportSet},
 	{Run: FnChar},
 	{Run: FnHex},
 	{Run: FnUnhex},
@@ -121,6 +130,7 @@ var Cases = []TestCase{
 	{Run: FnSign},
 	{Run: FnSqrt},
 	{Run: FnRound},
+	{Run: FnFormat},
 	{Run: FnTruncate},
 	{Run: FnCrc32},
 	{Run: FnConv},
@@ -130,7 +140,10 @@ var Cases = []TestCase{
 	{Run: FnSHA1},
 	{Run: FnSHA2},
 	{Run: FnRandomBytes},
+	{Run: FnAESEncrypt},
 	{Run: FnDateFormat},
+	{Run: FnStrToDate},
+	{Run: FnGetFormat},
 	{Run: FnConvertTz},
 	{Run: FnDate},
 	{Run: FnDayOfMonth},
@@ -178,6 +191,7 @@ var Cases = []TestCase{
 	{Run: RegexpInstr},
 	{Run: RegexpSubstr},
 	{Run: RegexpReplace},
+	{Run: FnSpatial},
 }
 
 func FnJSONKeys(yield Query) {
@@ -242,6 +256,47 @@ func FnJSONRemove(yield Query) {
 	}
 }
 
+func FnJSONModify(yield Query) {
+	for _, fn := range []string{"JSON_SET", "JSON_INSERT", "JSON_REPLACE"} {
+		for _, obj := range inputJSONObjects {
+			for _, path1 := range inputJSONPaths {
+				yield(fmt.Sprintf("%s(%s, '%s', 42)", fn, obj, path1), nil, false)
+				yield(fmt.Sprintf("%s(%s, '%s', '[1]', '%s', JSON_ARRAY(1))", fn, obj, path1, path1), nil, false)
+			}
+			for _, value := range inputJSONPrimitives {
+				yield(fmt.Sprintf("%s(%s, '$[3]', %s, '$.z', %s)", fn, obj, value, value), nil, false)
+			}
+		}
+
+		yield(fmt.Sprintf("%s('1', '$[0]', 2)", fn), nil, false)
+		yield(fmt.Sprintf("%s('1', '$[1]', 2)", fn), nil, false)
+		yield(fmt.Sprintf("%s('[1, 2]', '$[9]', 3, '$[last]', 4)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{\"a\": 1}', '$.a[1]', 2, '$.b.c', 3)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{\"a\": 1}', NULL, 2)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{\"a\": 1}', '$.a', NULL)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{\"a\": 1}', 'invalid', 2)", fn), nil, false)
+		yield(fmt.Sprintf("%s('{invalid}', '$.a', 2)", fn), nil, false)
+		yield(fmt.Sprintf("%s(1, '$.a', 2)", fn), nil, false)
+	}
+}
+
+func FnJSONMerge(yield Query) {
+	for _, fn := range []string{"JSON_MERGE_PRESERVE", "JSON_MERGE_PATCH"} {
+		for _, obj1 := range inputJSONObjects {
+			for _, obj2 := range inputJSONObjects {
+				yield(fmt.Sprintf("%s(%s, %s)", fn, obj1, obj2), nil, false)
+			}
+			yield(fmt.Sprintf("%s(%s, '{\"a\": null, \"b\": {\"c\": 1}}', '3')", fn, obj1), nil, false)
+			yield(fmt.Sprintf("%s(%s, NULL, '{\"z\": 1}')", fn, obj1), nil, false)
+		}
+
+		yield(fmt.Sprintf("%s('1', 'true', '\"a\"')", fn), nil, false)
+		yield(fmt.Sprintf("%s('{invalid}', '[]')", fn), nil, false)
+		yield(fmt.Sprintf("%s(NULL, '{invalid}')", fn), nil, false)
+		yield(fmt.Sprintf("%s('[]', 1)", fn), nil, false)
+	}
+}
+
 func FnJSONContainsPath(yield Query) {
 	for _, obj := range inputJSONObjects {
 		for _, path1 := range inputJSONPaths {
@@ -256,6 +311,45 @@ func FnJSONContainsPath(yield Query) {
 	}
 }
 
+func FnJSONOverlaps(yield Query) {
+	docs := append([]string{
+		`'[1, 3, 5, 7]'`, `'[2, 5, 7]'`, `'[2, 6, 8]'`, `'[1.0, "a"]'`, `'[[1, 2], [3, 4]]'`, `'[1, 2]'`,
+		`'{"a": 1, "b": 10, "d": 10}'`, `'{"c": 1, "e": 10, "a": 10}'`, `'{"a": [1, 2]}'`,
+		`'5'`, `'5.0'`, `'"a"'`, `'null'`, `'[null]'`, `'true'`, `'[true, false]'`,
+		`CAST(5 AS JSON)`, `JSON_ARRAY(1, '2', 3.5)`, `JSON_OBJECT('a', 1)`,
+	}, inputJSONObjects...)
+
+	for _, doc1 := range docs {
+		for _, doc2 := range docs {
+			yield(fmt.Sprintf("JSON_OVERLAPS(%s, %s)", doc1, doc2), nil, false)
+		}
+	}
+
+	yield(`JSON_OVERLAPS('[1', '[1]')`, nil, false)
+	yield(`JSON_OVERLAPS('[1]', 'not json')`, nil, false)
+}
+
+func FnMemberOf(yield Query) {
+	arrays := []string{
+		`'[1, 2, 3]'`, `'[1.5, "1", "a", true, null]'`, `'["abc", "def"]'`, `'[[1, 2], {"a": 1}]'`,
+		`'1'`, `'"a"'`, `'{"a": 1}'`, `'[]'`, `JSON_ARRAY(1, 'a', 2.5)`, `CAST('[3, "b"]' AS JSON)`, `NULL`,
+	}
+	values := append([]string{
+		`1`, `1.0`, `1.5`, `2.5e0`, `3`, `-1`, `18446744073709551615`, `'1'`, `'a'`, `'abc'`, `'"abc"'`,
+		`CAST(1 AS JSON)`, `CAST('[1, 2]' AS JSON)`, `JSON_OBJECT('a', 1)`, `CAST('null' AS JSON)`,
+		`_utf8mb4 'ABC' COLLATE utf8mb4_0900_ai_ci`,
+	}, inputJSONPrimitives...)
+
+	for _, arr := range arrays {
+		for _, value := range values {
+			yield(fmt.Sprintf("%s MEMBER OF (%s)", value, arr), nil, false)
+		}
+	}
+
+	yield(`1 MEMBER OF ('[1')`, nil, false)
+	yield(`'a' MEMBER OF ('a')`, nil, false)
+}
+
 func FnJSONUnquote(yield Query) {
 	yield("JSON_UNQUOTE(NULL)", nil, false)
 }
@@ -678,6 +772,31 @@ func FnRound(yield Query) {
 	}
 }
 
+func FnFormat(yield Query) {
+	decimals := []string{"0", "2", "-1", "1.5", "'3'", "31", "NULL"}
+	for _, num := range inputConversions {
+		for _, dec := range decimals {
+			yield(fmt.Sprintf("FORMAT(%s, %s)", num, dec), nil, false)
+		}
+	}
+
+	numbers := []string{
+		"0", "-1", "123", "1234", "-1234", "12345678", "1234567890123",
+		"1234.5", "-1234.5678", "0.5", "-0.5", "1234567.891e0", "-987654321.5e0",
+		"18446744073709551615", "'1234567.891'",
+	}
+	locales := []string{
+		"'en_US'", "'EN_us'", "'en_IN'", "'de_DE'", "'de_CH'", "'fr_FR'", "'ru_RU'",
+		"'sv_SE'", "'pt_BR'", "'ja_JP'", "'hi_IN'", "'xx_XX'", "''", "NULL", "1",
+	}
+	for _, num := range numbers {
+		for _, lc := range locales {
+			yield(fmt.Sprintf("FORMAT(%s, 0, %s)", num, lc), nil, false)
+			yield(fmt.Sprintf("FORMAT(%s, 3, %s)", num, lc), nil, false)
+		}
+	}
+}
+
 func FnTruncate(yield Query) {
 	for _, num1 := range radianInputs {
 		for _, num2 := range radianInputs {
@@ -813,6 +932,23 @@ func FnSHA2(yield Query) {
 	}
 }
 
+func FnAESEncrypt(yield Query) {
+	keys := []string{"'secret'", "'a key that is longer than thirty-two bytes'", "''", "1234", "0xFF00", "NULL"}
+	for _, key := range keys {
+		for _, str := range inputConversions {
+			yield(fmt.Sprintf("AES_ENCRYPT(%s, %s)", str, key), nil, false)
+			yield(fmt.Sprintf("AES_DECRYPT(AES_ENCRYPT(%s, %s), %s)", str, key, key), nil, false)
+			yield(fmt.Sprintf("AES_DECRYPT(%s, %s)", str, key), nil, false)
+		}
+	}
+
+	// the initialization vector is ignored by the default aes-128-ecb mode
+	ivs := []string{"'1234567890123456'", "'short'", "NULL"}
+	for _, iv := range ivs {
+		yield(fmt.Sprintf("AES_ENCRYPT('vitess', 'secret', %s)", iv), nil, false)
+	}
+}
+
 func FnRandomBytes(yield Query) {
 	for _, num := range radianInputs {
 		yield(fmt.Sprintf("LENGTH(RANDOM_BYTES(%s))", num), nil, false)
@@ -895,6 +1031,26 @@ func LargeDecimals(yield Query) {
 	}
 }
 
+func LargeDecimalDivision(yield Query) {
+	divisors := []string{
+		"3",
+		"-7.5",
+		"271828.182845904523536028747135266249",
+		"-98765432109876543210987654321098765.432109876543210987654321098765",
+	}
+
+	// the dividends are DECIMAL(63, 30) down to DECIMAL(63, 0)
+	for pos := len(inputPi) - 30; pos <= len(inputPi); pos += 3 {
+		lhs := strings.TrimSuffix(fmt.Sprintf("%s.%s", inputPi[:pos], inputPi[pos:]), ".")
+		for _, rhs := range divisors {
+			yield(fmt.Sprintf("%s / %s", lhs, rhs), nil, false)
+			yield(fmt.Sprintf("%s %% %s", lhs, rhs), nil, false)
+			yield(fmt.Sprintf("-%s / %s", lhs, rhs), nil, false)
+			yield(fmt.Sprintf("-%s %% %s", lhs, rhs), nil, false)
+		}
+	}
+}
+
 func LargeIntegers(yield Query) {
 	largepi := inputPi + inputPi
 
@@ -1164,6 +1320,26 @@ func CollationOperations(yield Query) {
 		"COLLATION(_utf8mb4 'foobar' COLLATE utf8mb4_general_ci)",
 		"COLLATION('foobar' COLLATE utf8mb4_general_ci)",
 		"COLLATION(_latin1 'foobar' COLLATE utf8mb4_general_ci)",
+		"COLLATION(CONCAT(_latin1 'foo', _utf8mb4 'bar'))",
+		"COLLATION(CONCAT(_latin1 'foo' COLLATE latin1_bin, _utf8mb4 'bar'))",
+		"COLLATION(CONCAT('foo', 'bar' COLLATE utf8mb4_bin))",
+		"COERCIBILITY('foobar')",
+		"COERCIBILITY(_latin1 'foobar')",
+		"COERCIBILITY(_binary 'foobar')",
+		"COERCIBILITY(_utf8mb4 'foobar' COLLATE utf8mb4_general_ci)",
+		"COERCIBILITY('foobar' COLLATE utf8mb4_general_ci)",
+		"COERCIBILITY(_latin1 'foobar' COLLATE utf8mb4_general_ci)",
+		"COERCIBILITY(CONCAT(_latin1 'foo', _utf8mb4 'bar'))",
+		"COERCIBILITY(CONCAT(_latin1 'foo' COLLATE latin1_bin, _utf8mb4 'bar'))",
+		"COERCIBILITY(CONCAT('foo', 'bar' COLLATE utf8mb4_bin))",
+		"COERCIBILITY(CONCAT('foo', 'bar' COLLATE utf8mb4_0900_ai_ci))",
+		"COERCIBILITY(NULL)",
+		"COERCIBILITY(1)",
+		"COERCIBILITY(1.5)",
+		"COERCIBILITY(NOW())",
+		"COERCIBILITY(JSON_OBJECT())",
+		"COERCIBILITY(USER())",
+		"COERCIBILITY(VERSION())",
 	}
 
 	for _, expr := range cases {
@@ -1176,7 +1352,9 @@ func LikeComparison(yield Query) {
 		`'foobar'`, `'FOOBAR'`,
 		`'1234'`, `1234`,
 		`_utf8mb4 'foobar' COLLATE utf8mb4_0900_as_cs`,
-		`_utf8mb4 'FOOBAR' COLLATE utf8mb4_0900_as_cs`)
+		`_utf8mb4 'FOOBAR' COLLATE utf8mb4_0900_as_cs`,
+		`_latin1 'foobar'`,
+		`_latin1 'FOOBAR' COLLATE latin1_bin`)
 
 	right := append(left,
 		`NULL`, `1`, `0`,
@@ -1185,7 +1363,9 @@ func LikeComparison(yield Query) {
 		`_utf8mb4 'foo%' COLLATE utf8mb4_0900_as_cs`,
 		`_utf8mb4 'FOO%' COLLATE utf8mb4_0900_as_cs`,
 		`_utf8mb4 'foo_ar' COLLATE utf8mb4_0900_as_cs`,
-		`_utf8mb4 'FOO_AR' COLLATE utf8mb4_0900_as_cs`)
+		`_utf8mb4 'FOO_AR' COLLATE utf8mb4_0900_as_cs`,
+		`_latin1 'foo%%'`, `_latin1 'foo_%'`,
+		`_latin1 'FOO%' COLLATE latin1_bin`)
 
 	for _, lhs := range left {
 		for _, rhs := range right {
@@ -1194,6 +1374,48 @@ func LikeComparison(yield Query) {
 			}
 		}
 	}
+
+	escapeLeft := []string{
+		`'a_c'`, `'abc'`, `'a%c'`, `'a|c'`, `'a\\c'`,
+		`_latin1 'a_c'`, `_latin1 'abc'`,
+	}
+	escapeRight := []string{
+		`'a|_c'`, `'a|%c'`, `'a||c'`, `'a\\_c'`, `'a%|c'`, `'a|_%'`,
+		`_latin1 'a|_c'`,
+		// 0xA7 is '§' in latin1
+		`_latin1 0x61A75F63`, `_latin1 0x61A725`,
+	}
+	escapes := []string{`'|'`, `''`, `NULL`, `'\\'`, `'§'`, `_latin1 0xA7`, `'||'`}
+
+	for _, lhs := range escapeLeft {
+		for _, rhs := range escapeRight {
+			for _, escape := range escapes {
+				for _, op := range []string{"LIKE", "NOT LIKE"} {
+					yield(fmt.Sprintf("%s %s %s ESCAPE %s", lhs, op, rhs, escape), nil, false)
+				}
+			}
+		}
+	}
+
+	// In sjis, '表' is 0x955C and its trailing byte is a backslash; in gb2312 it is 0xB1ED.
+	multibyteLeft := []string{
+		`_sjis 0x955C41`, `_sjis 0x955C25`, `_sjis 0x41955C`, `_sjis 0x955C955C`,
+		`_gb2312 0xB1ED41`, `_gb2312 0x41B1ED`, `_gb2312 0xB1EDB1ED`,
+	}
+	multibyteRight := []string{
+		`'_A'`, `'A_'`, `'__'`, `'%A'`,
+		`_sjis 0x955C25`, `_sjis 0x5F41`, `_sjis 0x955C5F`, `_sjis 0x7C25`,
+		`_gb2312 0xB1ED25`, `_gb2312 0x5F41`, `_gb2312 0x25B1ED`,
+	}
+
+	for _, lhs := range multibyteLeft {
+		for _, rhs := range multibyteRight {
+			for _, op := range []string{"LIKE", "NOT LIKE"} {
+				yield(fmt.Sprintf("%s %s %s", lhs, op, rhs), nil, false)
+				yield(fmt.Sprintf("%s %s %s ESCAPE '|'", lhs, op, rhs), nil, false)
+			}
+		}
+	}
 }
 
 func StrcmpComparison(yield Query) {
@@ -1842,6 +2064,86 @@ func FnConcatWs(yield Query) {
 	}
 }
 
+func FnFindInSet(yield Query) {
+	for _, str1 := range inputStrings {
+		for _, str2 := range inputStrings {
+			yield(fmt.Sprintf("FIND_IN_SET(%s, %s)", str1, str2), nil, false)
+		}
+	}
+
+	lists := []string{
+		"''", "','", "'a'", "'a,'", "',a'", "'a,b,c'", "'b,a,c'", "'c,b,a'", "'A,B,C'",
+		"'a,,c'", "'1,2,3'", "_latin1 'a,b,c'", "_binary 'a,b,c'", "_utf16 'a,b,c'", "_utf32 'a,b,c'",
+		"'Å,å,a'", "'中文,测试'", "'😊,😂,🤢'", "NULL",
+	}
+	for _, str := range []string{"''", "'a'", "'A'", "'c'", "'a,b'", "'å'", "'测试'", "'🤢'", "2", "_binary 'a'", "_latin1 'a'", "NULL"} {
+		for _, list := range lists {
+			yield(fmt.Sprintf("FIND_IN_SET(%s, %s)", str, list), nil, false)
+		}
+	}
+
+	mysqlDocSamples := []string{
+		"FIND_IN_SET('b', 'a,b,c,d')",
+		"FIND_IN_SET('b' COLLATE utf8mb4_bin, 'a,B,b')",
+		"FIND_IN_SET('b' COLLATE utf8mb4_0900_as_ci, 'a,B,b')",
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
+func FnMakeSet(yield Query) {
+	for _, str1 := range inputStrings {
+		for _, str2 := range inputStrings {
+			for _, n := range inputBitwise {
+				yield(fmt.Sprintf("MAKE_SET(%s, %s, %s)", n, str1, str2), nil, false)
+			}
+		}
+	}
+
+	mysqlDocSamples := []string{
+		"MAKE_SET(1, 'a', 'b', 'c')",
+		"MAKE_SET(1 | 4, 'hello', 'nice', 'world')",
+		"MAKE_SET(1 | 4, 'hello', 'nice', NULL, 'world')",
+		"MAKE_SET(0, 'a', 'b', 'c')",
+		"MAKE_SET(-1, 'a', 'b', 'c')",
+		"MAKE_SET(6, _latin1 'a', 'b', _binary 'c')",
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
+func FnExportSet(yield Query) {
+	for _, n := range inputBitwise {
+		yield(fmt.Sprintf("EXPORT_SET(%s, 'Y', 'N')", n), nil, false)
+		yield(fmt.Sprintf("EXPORT_SET(5, 'Y', 'N', ',', %s)", n), nil, false)
+	}
+
+	for _, str1 := range inputStrings {
+		for _, str2 := range inputStrings {
+			yield(fmt.Sprintf("EXPORT_SET(5, %s, %s, '', 4)", str1, str2), nil, false)
+			yield(fmt.Sprintf("EXPORT_SET(6, 'on', 'off', %s, %s)", str1, str2), nil, false)
+		}
+	}
+
+	mysqlDocSamples := []string{
+		"EXPORT_SET(5, 'Y', 'N', ',', 4)",
+		"EXPORT_SET(6, '1', '0', ',', 10)",
+		"EXPORT_SET(6, '1', '0', '', 0)",
+		"EXPORT_SET(6, '1', '0', NULL)",
+		"EXPORT_SET(NULL, '1', '0')",
+		"EXPORT_SET(6, _latin1 'y', 'n', _binary '|', 3)",
+		"EXPORT_SET(6, _utf16 'y', 'n', '|', 3)",
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
 func FnChar(yield Query) {
 	mysqlDocSamples := []string{
 		`CHAR(77,121,83,81,'76')`,
@@ -1972,6 +2274,70 @@ func FnDateFormat(yield Query) {
 	}
 }
 
+func FnStrToDate(yield Query) {
+	cases := []struct{ str, format string }{
+		{"2024-03-15", "%Y-%m-%d"},
+		{"2024-3-5", "%Y-%m-%d"},
+		{" 2024 - 03 - 15 trailing", "%Y-%m-%d"},
+		{"2023-02-29", "%Y-%m-%d"},
+		{"2024-00-15", "%Y-%m-%d"},
+		{"04/30/04", "%m/%d/%y"},
+		{"2004.12.12 22:30:59", "%Y.%m.%d %T"},
+		{"2004.12.12 22:30:61", "%Y.%m.%d %T"},
+		{"15 march 2024", "%d %M %Y"},
+		{"15 Mar 2024", "%d %b %Y"},
+		{"March 22nd, 2024", "%M %D, %Y"},
+		{"Friday 15 March 2024", "%W %d %M %Y"},
+		{"10:11:12.5", "%H:%i:%s.%f"},
+		{"10:11:12", "%T"},
+		{"01:30:15 PM", "%r"},
+		{"12:30 am", "%h:%i %p"},
+		{"2024-03-15 10:11:12.123456", "%Y-%m-%d %H:%i:%s.%f"},
+		{"2024 060", "%Y %j"},
+		{"2024 09 5", "%Y %U %w"},
+		{"2024 10 Friday", "%Y %u %W"},
+		{"2024 09 Fri", "%X %V %a"},
+		{"2024 10 Fri", "%x %v %a"},
+		{"2021 01 Mon", "%x %v %a"},
+		{"2024 10 Fri", "%X %v %a"},
+		{"2024 10 Fri", "%Y %v %a"},
+		{"20240315--10", "%Y%m%d%.%H"},
+		{"20240315abc10", "%Y%m%d%@%H"},
+		{"20240315", "%Y%m%d"},
+		{"20240315101112", "%Y%m%d%H%i%s"},
+		{"03.15.2024", "%m.%d.%Y"},
+		{"15.03.2024", "%d.%m.%Y"},
+		{"10.11.12", "%H.%i.%s"},
+	}
+
+	for _, tc := range cases {
+		yield(fmt.Sprintf("STR_TO_DATE(%q, %q)", tc.str, tc.format), nil, false)
+	}
+
+	for _, d := range inputConversions {
+		yield(fmt.Sprintf("STR_TO_DATE(%s, '%%Y-%%m-%%d %%H:%%i:%%s')", d), nil, false)
+		yield(fmt.Sprintf("STR_TO_DATE('2024-03-15', %s)", d), nil, false)
+	}
+}
+
+func FnGetFormat(yield Query) {
+	kinds := []string{"DATE", "TIME", "DATETIME", "TIMESTAMP"}
+	standards := []string{"'USA'", "'JIS'", "'ISO'", "'EUR'", "'INTERNAL'", "'usa'", "'bogus'", "NULL", "1"}
+
+	for _, k := range kinds {
+		for _, s := range standards {
+			yield(fmt.Sprintf("GET_FORMAT(%s, %s)", k, s), nil, false)
+		}
+	}
+
+	for _, k := range kinds {
+		for _, s := range []string{"USA", "JIS", "ISO", "EUR", "INTERNAL"} {
+			yield(fmt.Sprintf("DATE_FORMAT('2024-03-15 13:04:05', GET_FORMAT(%s, '%s'))", k, s), nil, false)
+			yield(fmt.Sprintf("STR_TO_DATE(DATE_FORMAT('2024-03-15 13:04:05', GET_FORMAT(%s, '%s')), GET_FORMAT(%s, '%s'))", k, s, k, s), nil, false)
+		}
+	}
+}
+
 func FnConvertTz(yield Query) {
 	timezoneInputs := []string{
 		"UTC",
@@ -2513,6 +2879,22 @@ func DateMath(yield Query) {
 		`TIMESTAMPADD(MONTH, 1, DATE '2024-03-30')`,
 		`TIMESTAMPADD(MONTH, 1, DATE '2024-03-31')`,
 	}
+	// The results of TIME arithmetic can be negative, and are NULL if they
+	// are out of the range of TIME.
+	times := []string{
+		`TIME'00:00:00'`,
+		`TIME'01:00:00'`,
+		`TIME'-01:00:00'`,
+		`TIME'00:00:00.5'`,
+		`TIME'-00:00:01.25'`,
+		`TIME'838:59:59'`,
+		`TIME'-838:59:59'`,
+		`TIME'837:59:59.5'`,
+	}
+	timeIntervals := []string{
+		`1 SECOND`, `-1 SECOND`, `1.5 SECOND`, `2 HOUR`, `-2 HOUR`, `'1:30' HOUR_MINUTE`, `'-1:30' MINUTE_SECOND`,
+		`'1:00:00' HOUR_SECOND`, `'0.5' SECOND_MICROSECOND`, `1000 HOUR`, `-1000 HOUR`,
+	}
 
 	for _, q := range mysqlDocSamples {
 		yield(q, nil, false)
@@ -2527,6 +2909,22 @@ func DateMath(yield Query) {
 			}
 		}
 	}
+
+	for _, d := range slices.Concat(dates, times) {
+		for _, i := range timeIntervals {
+			yield(fmt.Sprintf("%s + INTERVAL %s", d, i), nil, false)
+			yield(fmt.Sprintf("INTERVAL %s + %s", i, d), nil, false)
+			yield(fmt.Sprintf("%s - INTERVAL %s", d, i), nil, false)
+			yield(fmt.Sprintf("%s - INTERVAL %s < %s", d, i, d), nil, false)
+		}
+	}
+
+	for _, t := range times {
+		for _, i := range inputIntervals {
+			yield(fmt.Sprintf("DATE_ADD(%s, INTERVAL 1 %s)", t, i), nil, false)
+			yield(fmt.Sprintf("DATE_SUB(%s, INTERVAL 1 %s)", t, i), nil, false)
+		}
+	}
 }
 
 func RegexpLike(yield Query) {
@@ -2812,3 +3210,48 @@ func RegexpReplace(yield Query) {
 		yield(q, nil, false)
 	}
 }
+
+func FnSpatial(yield Query) {
+	geometries := []string{
+		`'POINT(1 2)'`,
+		`'point ( -1.5   2e3 )'`,
+		`'LINESTRING(0 0, 4 4)'`,
+		`'LINESTRING(0 0, 4 0)'`,
+		`'POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))'`,
+		`'POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 3 1, 3 3, 1 3, 1 1))'`,
+		`'MULTIPOINT(1 1, 2 2)'`,
+		`'MULTIPOINT((2 2), (0.5 0.5))'`,
+		`'MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2)))'`,
+		`'GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(0 0, 1 1))'`,
+		`'GEOMETRYCOLLECTION EMPTY'`,
+		`'POINT(1)'`,
+		`'POLYGON((0 0, 1 0, 1 1))'`,
+		`NULL`,
+	}
+
+	for _, g := range geometries {
+		yield(fmt.Sprintf("ST_AsText(ST_GeomFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_AsText(ST_GeomFromWKB(ST_AsBinary(ST_GeomFromText(%s))))", g), nil, false)
+		yield(fmt.Sprintf("ST_AsText(ST_PointFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_X(ST_GeomFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_Y(ST_GeomFromText(%s))", g), nil, false)
+		yield(fmt.Sprintf("ST_AsText(ST_X(ST_GeomFromText(%s), 10))", g), nil, false)
+	}
+
+	for _, g1 := range geometries {
+		for _, g2 := range geometries {
+			yield(fmt.Sprintf("ST_Distance(ST_GeomFromText(%s), ST_GeomFromText(%s))", g1, g2), nil, false)
+			yield(fmt.Sprintf("ST_Contains(ST_GeomFromText(%s), ST_GeomFromText(%s))", g1, g2), nil, false)
+		}
+	}
+
+	yield(`ST_AsText(POINT(1, 2))`, nil, false)
+	yield(`ST_AsText(POINT(NULL, 2))`, nil, false)
+	yield(`ST_AsText(POINT('1.5', 2))`, nil, false)
+	yield(`ST_Distance(POINT(0, 0), POINT(3, 4))`, nil, false)
+	yield(`ST_Contains(ST_GeomFromText('POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))'), POINT(2, 2))`, nil, false)
+	yield(`ST_AsText(ST_GeomFromText('POINT(1 2)', 0))`, nil, false)
+	yield(`ST_AsText(ST_GeomFromText('POINT(1 2)', NULL))`, nil, false)
+	yield(`ST_AsText(ST_GeomFromText('POINT(1 2)', -1))`, nil, false)
+	yield(`ST_AsText('POINT(1 2)')`, nil, false)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testcases
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// FuzzFunction is a SQL function that can be used by the Fuzzer when
+// building random expressions.
+type FuzzFunction struct {
+	Name    string
+	MinArgs int
+	MaxArgs int
+}
+
+// FuzzFunctions is the inventory of functions supported by the evalengine
+// that are deterministic, i.e. that return the same value in MySQL and in
+// Vitess for the same arguments. Functions that depend on the current time,
+// the session or on randomness are not part of the inventory, and neither
+// are the functions that can legitimately return values as large as
+// `max_allowed_packet` (REPEAT, SPACE, LPAD, RPAD). AES_ENCRYPT and
+// AES_DECRYPT only take the key, so they use the default
+// block_encryption_mode on both sides.
+var FuzzFunctions = []FuzzFunction{
+	{"isnull", 1, 1}, {"ifnull", 2, 2}, {"nullif", 2, 2}, {"if", 3, 3},
+	{"coalesce", 1, 4}, {"greatest", 2, 4}, {"least", 2, 4},
+	{"bit_count", 1, 1}, {"hex", 1, 1}, {"unhex", 1, 1},
+	{"ceil", 1, 1}, {"floor", 1, 1}, {"abs", 1, 1}, {"pi", 0, 0},
+	{"acos", 1, 1}, {"asin", 1, 1}, {"atan", 1, 2}, {"atan2", 2, 2},
+	{"cos", 1, 1}, {"cot", 1, 1}, {"sin", 1, 1}, {"tan", 1, 1},
+	{"degrees", 1, 1}, {"radians", 1, 1}, {"exp", 1, 1},
+	{"ln", 1, 1}, {"log", 1, 2}, {"log10", 1, 1}, {"log2", 1, 1},
+	{"mod", 2, 2}, {"pow", 2, 2}, {"sign", 1, 1}, {"sqrt", 1, 1},
+	{"round", 1, 2}, {"truncate", 2, 2}, {"crc32", 1, 1},
+	{"conv", 3, 3}, {"bin", 1, 1}, {"oct", 1, 1},
+	{"left", 2, 2}, {"right", 2, 2}, {"field", 2, 4}, {"elt", 2, 4},
+	{"lower", 1, 1}, {"upper", 1, 1}, {"char_length", 1, 1},
+	{"length", 1, 1}, {"bit_length", 1, 1}, {"ascii", 1, 1},
+	{"reverse", 1, 1}, {"ord", 1, 1}, {"format", 2, 3},
+	{"concat", 1, 4}, {"concat_ws", 2, 4},
+	{"from_base64", 1, 1}, {"to_base64", 1, 1},
+	{"find_in_set", 2, 2}, {"make_set", 2, 4}, {"export_set", 3, 5},
+	{"coercibility", 1, 1},
+	{"json_overlaps", 2, 2},
+	{"json_set", 3, 3}, {"json_insert", 3, 3}, {"json_replace", 3, 3},
+	{"json_merge_patch", 2, 3}, {"json_merge_preserve", 2, 3},
+	{"aes_encrypt", 2, 2}, {"aes_decrypt", 2, 2},
+	{"point", 2, 2}, {"st_x", 1, 1}, {"st_y", 1, 1},
+	{"st_geomfromtext", 1, 1}, {"st_astext", 1, 1},
+	{"st_distance", 2, 2}, {"st_contains", 2, 2},
+	{"date_format", 2, 2}, {"str_to_date", 2, 2},
+	{"date", 1, 1}, {"dayofmonth", 1, 1},
+	{"dayofweek", 1, 1}, {"dayofyear", 1, 1}, {"hour", 1, 1},
+	{"makedate", 2, 2}, {"maketime", 3, 3}, {"microsecond", 1, 1},
+	{"minute", 1, 1}, {"month", 1, 1}, {"monthname", 1, 1},
+	{"last_day", 1, 1}, {"to_days", 1, 1}, {"from_days", 1, 1},
+	{"sec_to_time", 1, 1}, {"time_to_sec", 1, 1}, {"to_seconds", 1, 1},
+	{"quarter", 1, 1}, {"second", 1, 1}, {"time", 1, 1},
+	{"week", 1, 2}, {"weekday", 1, 1}, {"weekofyear", 1, 1},
+	{"year", 1, 1}, {"yearweek", 1, 2},
+	{"period_add", 2, 2}, {"period_diff", 2, 2},
+	{"inet_aton", 1, 1}, {"inet_ntoa", 1, 1},
+	{"inet6_aton", 1, 1}, {"inet6_ntoa", 1, 1},
+	{"is_ipv4", 1, 1}, {"is_ipv4_compat", 1, 1},
+	{"is_ipv4_mapped", 1, 1}, {"is_ipv6", 1, 1},
+	{"is_uuid", 1, 1}, {"md5", 1, 1}, {"sha1", 1, 1}, {"sha2", 2, 2},
+	{"strcmp", 2, 2}, {"instr", 2, 2}, {"replace", 3, 3},
+}
+
+// FuzzOperators are the binary operators used by the Fuzzer.
+var FuzzOperators = []string{
+	"+", "-", "*", "/", "DIV", "%",
+	"=", "!=", "<=>", "<", "<=", ">", ">=",
+	"AND", "OR", "XOR",
+	"&", "|", "^", "<<", ">>",
+	"LIKE", "NOT LIKE", "MEMBER OF",
+}
+
+// Fuzzer builds random expression trees out of FuzzFunctions, FuzzOperators
+// and a set of primitive values. The expressions generated by a Fuzzer only
+// depend on its seed, so any expression can be reproduced by running a new
+// Fuzzer with the same seed and parameters.
+type Fuzzer struct {
+	rng *rand.Rand
+
+	// MaxDepth is the maximum depth of the generated expression trees.
+	MaxDepth int
+	// Functions and Operators are used for the inner nodes of the trees.
+	Functions []FuzzFunction
+	Operators []string
+	// Primitives are used for the leaves of the trees.
+	Primitives []string
+}
+
+// NewFuzzer returns a Fuzzer seeded with the given seed that builds
+// expressions of at most maxDepth levels.
+func NewFuzzer(seed uint64, maxDepth int) *Fuzzer {
+	primitives := make([]string, 0, len(inputConversions)+len(ipInputs)+len(uuidInputs))
+	primitives = append(primitives, inputConversions...)
+	primitives = append(primitives, ipInputs...)
+	primitives = append(primitives, uuidInputs...)
+
+	return &Fuzzer{
+		rng:        rand.New(rand.NewPCG(seed, seed)),
+		MaxDepth:   maxDepth,
+		Functions:  FuzzFunctions,
+		Operators:  FuzzOperators,
+		Primitives: primitives,
+	}
+}
+
+// Expr returns a new random expression.
+func (f *Fuzzer) Expr() string {
+	var buf strings.Builder
+	f.expr(&buf, f.MaxDepth)
+	return buf.String()
+}
+
+func (f *Fuzzer) expr(buf *strings.Builder, depth int) {
+	// Leaves become more likely the deeper we are in the tree, so that
+	// expressions don't always reach the maximum depth.
+	if depth <= 0 || f.rng.IntN(f.MaxDepth+1) > depth {
+		buf.WriteString(f.Primitives[f.rng.IntN(len(f.Primitives))])
+		return
+	}
+
+	switch f.rng.IntN(8) {
+	case 0:
+		buf.WriteString("-(")
+		f.expr(buf, depth-1)
+		buf.WriteString(")")
+	case 1:
+		buf.WriteString("NOT (")
+		f.expr(buf, depth-1)
+		buf.WriteString(")")
+	case 2:
+		buf.WriteString("(")
+		f.expr(buf, depth-1)
+		buf.WriteString(") IS ")
+		buf.WriteString(fuzzRhsOfIs[f.rng.IntN(len(fuzzRhsOfIs))])
+	case 3, 4:
+		buf.WriteString("(")
+		f.expr(buf, depth-1)
+		fmt.Fprintf(buf, ") %s (", f.Operators[f.rng.IntN(len(f.Operators))])
+		f.expr(buf, depth-1)
+		buf.WriteString(")")
+	default:
+		fn := f.Functions[f.rng.IntN(len(f.Functions))]
+		args := fn.MinArgs + f.rng.IntN(fn.MaxArgs-fn.MinArgs+1)
+		buf.WriteString(fn.Name)
+		buf.WriteString("(")
+		for i := range args {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			f.expr(buf, depth-1)
+		}
+		buf.WriteString(")")
+	}
+}
+
+var fuzzRhsOfIs = []string{"NULL", "NOT NULL", "TRUE", "NOT TRUE", "FALSE", "NOT FALSE"}
+
+// Fuzz returns a Runner that yields count random expressions of at most
+// maxDepth levels, built by a Fuzzer with the given seed.
+func Fuzz(seed uint64, maxDepth, count int) Runner {
+	return func(yield Query) {
+		f := NewFuzzer(seed, maxDepth)
+		for range count {
+			yield(f.Expr(), nil, false)
+		}
+	}
+}
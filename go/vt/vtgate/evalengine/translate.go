@@ -17,6 +17,7 @@ limitations under the License.
 package evalengine
 
 import (
+	"io"
 	"slices"
 	"strings"
 	"sync"
@@ -36,7 +37,7 @@ var (
 	ErrEvaluatedExprNotSupported = "expr cannot be evaluated, not supported"
 )
 
-func (ast *astCompiler) translateComparisonExpr(op sqlparser.ComparisonExprOperator, left, right sqlparser.Expr) (IR, error) {
+func (ast *astCompiler) translateComparisonExpr(op sqlparser.ComparisonExprOperator, left, right, escape sqlparser.Expr) (IR, error) {
 	l, err := ast.translateExpr(left)
 	if err != nil {
 		return nil, err
@@ -45,7 +46,20 @@ func (ast *astCompiler) translateComparisonExpr(op sqlparser.ComparisonExprOpera
 	if err != nil {
 		return nil, err
 	}
-	return ast.translateComparisonExpr2(op, l, r)
+	expr, err := ast.translateComparisonExpr2(op, l, r)
+	if err != nil || escape == nil {
+		return expr, err
+	}
+
+	like, ok := expr.(*LikeExpr)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "ESCAPE clause is only supported with LIKE")
+	}
+	like.Escape, err = ast.translateExpr(escape)
+	if err != nil {
+		return nil, err
+	}
+	return like, nil
 }
 
 func (ast *astCompiler) translateComparisonExpr2(op sqlparser.ComparisonExprOperator, left, right IR) (IR, error) {
@@ -205,6 +219,39 @@ func (ast *astCompiler) translateBindVar(arg *sqlparser.Argument) (IR, error) {
 	return bvar, nil
 }
 
+func (ast *astCompiler) translateUserVariable(node *sqlparser.Variable) (IR, error) {
+	if node.Scope != sqlparser.VariableScope {
+		return nil, translateExprNotSupported(node)
+	}
+	uv := &UserVariable{
+		Name:              userVariableName(node),
+		Collation:         ast.cfg.Collation,
+		dynamicTypeOffset: len(ast.untyped),
+	}
+	ast.untyped = append(ast.untyped, uv)
+	return uv, nil
+}
+
+func (ast *astCompiler) translateAssignmentExpr(node *sqlparser.AssignmentExpr) (IR, error) {
+	variable, ok := node.Left.(*sqlparser.Variable)
+	if !ok || variable.Scope != sqlparser.VariableScope {
+		return nil, translateExprNotSupported(node)
+	}
+	inner, err := ast.translateExpr(node.Right)
+	if err != nil {
+		return nil, err
+	}
+	return &AssignmentExpr{
+		UnaryExpr: UnaryExpr{inner},
+		Name:      userVariableName(variable),
+	}, nil
+}
+
+// userVariableName returns the name of a user-defined variable, which is case-insensitive.
+func userVariableName(node *sqlparser.Variable) string {
+	return strings.ToLower(node.Name.CompliantName())
+}
+
 func (ast *astCompiler) translateColOffset(col *sqlparser.Offset) (IR, error) {
 	var typ Type
 	if ast.cfg.ResolveType != nil {
@@ -247,6 +294,29 @@ func (ast *astCompiler) translateColName(colname *sqlparser.ColName) (IR, error)
 	return column, nil
 }
 
+// translateDefault translates DEFAULT(col) into the default value of the column.
+// As in MySQL, the default of the column must be a constant: a default that
+// references other columns cannot be evaluated.
+func (ast *astCompiler) translateDefault(def *sqlparser.Default) (IR, error) {
+	if def.ColName == "" || ast.cfg.ResolveDefault == nil {
+		return nil, translateExprNotSupported(def)
+	}
+	expr, ok := ast.cfg.ResolveDefault(def.ColName)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Field '%s' doesn't have a default value", def.ColName)
+	}
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if _, ok := node.(*sqlparser.ColName); ok {
+			return false, io.EOF
+		}
+		return true, nil
+	}, expr)
+	if err != nil {
+		return nil, translateExprNotSupported(def)
+	}
+	return ast.translateExpr(expr)
+}
+
 func translateLiteral(lit *sqlparser.Literal, collation collations.ID) (*Literal, error) {
 	switch lit.Type {
 	case sqlparser.IntVal:
@@ -360,7 +430,7 @@ func (ast *astCompiler) translateIntroducerExpr(introduced *sqlparser.Introducer
 	} else {
 		defaultCollation := ast.cfg.Environment.CollationEnv().DefaultCollationForCharset(introduced.CharacterSet[1:])
 		if defaultCollation == collations.Unknown {
-			panic("unknown character set: " + introduced.CharacterSet)
+			return nil, translateExprNotSupported(introduced)
 		}
 		collation = defaultCollation
 	}
@@ -386,7 +456,7 @@ func (ast *astCompiler) translateIntroducerExpr(introduced *sqlparser.Introducer
 			UnaryExpr: UnaryExpr{expr},
 			TypedCollation: collations.TypedCollation{
 				Collation:    collation,
-				Coercibility: collations.CoerceExplicit,
+				Coercibility: collations.CoerceCoercible,
 				Repertoire:   collations.RepertoireUnicode,
 			},
 			CollationEnv: ast.cfg.Environment.CollationEnv(),
@@ -511,7 +581,7 @@ func (ast *astCompiler) translateExpr(e sqlparser.Expr) (IR, error) {
 	case *sqlparser.Offset:
 		return ast.translateColOffset(node)
 	case *sqlparser.ComparisonExpr:
-		return ast.translateComparisonExpr(node.Operator, node.Left, node.Right)
+		return ast.translateComparisonExpr(node.Operator, node.Left, node.Right, node.Escape)
 	case *sqlparser.Argument:
 		return ast.translateBindVar(node)
 	case sqlparser.ListArg:
@@ -550,6 +620,12 @@ func (ast *astCompiler) translateExpr(e sqlparser.Expr) (IR, error) {
 		return ast.translateCaseExpr(node)
 	case *sqlparser.BetweenExpr:
 		return ast.translateBetweenExpr(node)
+	case *sqlparser.Variable:
+		return ast.translateUserVariable(node)
+	case *sqlparser.AssignmentExpr:
+		return ast.translateAssignmentExpr(node)
+	case *sqlparser.Default:
+		return ast.translateDefault(node)
 	case *predicates.JoinPredicate:
 		return ast.translateExpr(node.Current())
 	default:
@@ -565,17 +641,28 @@ type astCompiler struct {
 type (
 	ColumnResolver func(name *sqlparser.ColName) (int, error)
 	TypeResolver   func(expr sqlparser.Expr) (Type, bool)
+	// DefaultResolver returns the expression of the default value of a column,
+	// or false if the column has no default value.
+	DefaultResolver func(column string) (sqlparser.Expr, bool)
 )
 
 type Config struct {
-	ResolveColumn ColumnResolver
-	ResolveType   TypeResolver
+	ResolveColumn  ColumnResolver
+	ResolveType    TypeResolver
+	ResolveDefault DefaultResolver
 
 	Collation         collations.ID
 	NoConstantFolding bool
 	NoCompilation     bool
 	SQLMode           SQLMode
 	Environment       *vtenv.Environment
+
+	// SpeculativeTypes compiles the expressions whose only dynamic types are
+	// the ones of their bind variables ahead of time, once for every
+	// combination of the speculativeTypes of the bind variables, so that they
+	// are evaluated by the VM instead of the AST interpreter when the types of
+	// the bind variables sent by the user match one of the combinations.
+	SpeculativeTypes bool
 }
 
 func Translate(e sqlparser.Expr, cfg *Config) (Expr, error) {
@@ -607,14 +694,27 @@ func Translate(e sqlparser.Expr, cfg *Config) (Expr, error) {
 		return comp.compile(expr)
 	}
 
-	return &UntypedExpr{
+	untyped := &UntypedExpr{
 		env:       cfg.Environment,
 		ir:        expr,
 		collation: cfg.Collation,
 		needTypes: ast.untyped,
-	}, nil
+	}
+	if cfg.SpeculativeTypes && !cfg.NoCompilation {
+		untyped.compileSpeculative(cfg.SQLMode)
+	}
+	return untyped, nil
 }
 
+// speculativeTypes are the types an untyped bind variable is speculatively
+// compiled with: the types of the bind variables of the normalized literals.
+var speculativeTypes = []sqltypes.Type{sqltypes.Int64, sqltypes.Decimal, sqltypes.VarChar}
+
+// maxSpeculativeBindVars is the maximum number of untyped bind variables of an
+// expression compiled with speculative types, as the number of compiled
+// programs grows exponentially with it.
+const maxSpeculativeBindVars = 3
+
 // typedExpr is a lazily compiled expression from an UntypedExpr. This expression
 // can only be compiled when it's evaluated with a fixed set of user-supplied types.
 // These static types are stored in the types slice so the next time the expression
@@ -662,6 +762,10 @@ type UntypedExpr struct {
 	mu sync.Mutex
 	// typed contains the lazily compiled versions of ir for every type set
 	typed []*typedExpr
+
+	// speculative contains the versions of ir compiled ahead of time for the
+	// combinations of speculative types of its bind variables, if any.
+	speculative []*typedExpr
 }
 
 var _ Expr = (*UntypedExpr)(nil)
@@ -674,7 +778,7 @@ func (u *UntypedExpr) eval(env *ExpressionEnv) (eval, error) {
 	return u.ir.eval(env)
 }
 
-func (u *UntypedExpr) loadTypedExpression(env *ExpressionEnv) (*typedExpr, error) {
+func (u *UntypedExpr) dynamicTypes(env *ExpressionEnv) ([]ctype, error) {
 	dynamicTypes := make([]ctype, 0, len(u.needTypes))
 	for _, expr := range u.needTypes {
 		typ, err := expr.typeof(env)
@@ -683,6 +787,14 @@ func (u *UntypedExpr) loadTypedExpression(env *ExpressionEnv) (*typedExpr, error
 		}
 		dynamicTypes = append(dynamicTypes, typ)
 	}
+	return dynamicTypes, nil
+}
+
+func (u *UntypedExpr) loadTypedExpression(env *ExpressionEnv) (*typedExpr, error) {
+	dynamicTypes, err := u.dynamicTypes(env)
+	if err != nil {
+		return nil, err
+	}
 
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -699,6 +811,61 @@ func (u *UntypedExpr) loadTypedExpression(env *ExpressionEnv) (*typedExpr, error
 	return typed, nil
 }
 
+// compileSpeculative compiles ir for every combination of the speculative
+// types of its bind variables. It does nothing if ir has dynamic types other
+// than the ones of bind variables, or too many bind variables.
+func (u *UntypedExpr) compileSpeculative(sqlmode SQLMode) {
+	if len(u.needTypes) > maxSpeculativeBindVars {
+		return
+	}
+	bvars := make([]*BindVariable, 0, len(u.needTypes))
+	for _, expr := range u.needTypes {
+		bvar, ok := expr.(*BindVariable)
+		if !ok {
+			return
+		}
+		bvars = append(bvars, bvar)
+	}
+
+	types := make([]ctype, len(bvars))
+	var speculate func(i int)
+	speculate = func(i int) {
+		if i == len(bvars) {
+			typed := &typedExpr{types: slices.Clone(types)}
+			if _, err := typed.compile(u.env, u.ir, u.collation, sqlmode); err == nil {
+				u.speculative = append(u.speculative, typed)
+			}
+			return
+		}
+		for _, tt := range speculativeTypes {
+			// the same type the bind variable has when it's evaluated, see BindVariable.typeof
+			types[i] = ctype{Type: tt, Flag: flagNullable, Col: typedCoercionCollation(tt, collations.CollationForType(tt, bvars[i].Collation))}
+			speculate(i + 1)
+		}
+	}
+	speculate(0)
+}
+
+// loadSpeculative returns the version of ir compiled ahead of time for the
+// types of the bind variables of env, or nil if there is none.
+func (u *UntypedExpr) loadSpeculative(env *ExpressionEnv) *CompiledExpr {
+	if len(u.speculative) == 0 {
+		return nil
+	}
+	dynamicTypes, err := u.dynamicTypes(env)
+	if err != nil {
+		return nil
+	}
+	for _, typed := range u.speculative {
+		if slices.EqualFunc(typed.types, dynamicTypes, func(a, b ctype) bool {
+			return a.equal(b)
+		}) {
+			return typed.compiled
+		}
+	}
+	return nil
+}
+
 func (u *UntypedExpr) Compile(env *ExpressionEnv) (*CompiledExpr, error) {
 	typed, err := u.loadTypedExpression(env)
 	if err != nil {
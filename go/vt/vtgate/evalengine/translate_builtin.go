@@ -21,6 +21,9 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -45,6 +48,10 @@ func (ast *astCompiler) translateFuncArgs(fnargs []sqlparser.Expr) ([]IR, error)
 }
 
 func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
+	if fn.Name.EqualString("get_format") {
+		return ast.translateGetFormat(fn)
+	}
+
 	var args TupleExpr
 	for _, expr := range fn.Exprs {
 		convertedExpr, err := ast.translateExpr(expr)
@@ -86,6 +93,11 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinCollation{CallExpr: call}, nil
+	case "coercibility":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCoercibility{CallExpr: call}, nil
 	case "bit_count":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -338,6 +350,13 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinRepeat{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "format":
+		switch len(args) {
+		case 2, 3:
+			return &builtinFormat{CallExpr: call, collate: ast.cfg.Collation}, nil
+		default:
+			return nil, argError(method)
+		}
 	case "concat":
 		if len(args) < 1 {
 			return nil, argError(method)
@@ -348,6 +367,21 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinConcatWs{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "find_in_set":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinFindInSet{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "make_set":
+		if len(args) < 2 {
+			return nil, argError(method)
+		}
+		return &builtinMakeSet{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "export_set":
+		if len(args) < 3 || len(args) > 5 {
+			return nil, argError(method)
+		}
+		return &builtinExportSet{CallExpr: call, collate: ast.cfg.Collation}, nil
 	case "from_base64":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -385,6 +419,17 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinDateFormat{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "str_to_date":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		// like MySQL, the type of the result depends on the format, if it
+		// is known; otherwise it is a DATETIME(6)
+		typ, prec := sqltypes.Datetime, uint8(datetime.DefaultPrecision)
+		if lit, ok := args[1].(*Literal); ok && lit.inner != nil {
+			typ, prec = strToDateType(evalToBinary(lit.inner).string())
+		}
+		return &builtinStrToDate{CallExpr: call, typ: typ, prec: prec}, nil
 	case "date":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -626,6 +671,19 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinMD5{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "aes_encrypt", "aes_decrypt":
+		switch len(args) {
+		case 2, 3:
+		case 4, 5, 6:
+			// the key derivation functions are left to MySQL
+			return nil, translateExprNotSupported(fn)
+		default:
+			return nil, argError(method)
+		}
+		if method == "aes_encrypt" {
+			return &builtinAESEncrypt{CallExpr: call}, nil
+		}
+		return &builtinAESDecrypt{CallExpr: call}, nil
 	case "random_bytes":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -667,6 +725,22 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinLastInsertID{CallExpr: call}, nil
+	case "st_distance":
+		switch len(args) {
+		case 2:
+			return &builtinDistance{CallExpr: call}, nil
+		case 3:
+			// the unit argument only applies to geographic spatial
+			// reference systems, which we cannot evaluate
+			return nil, translateExprNotSupported(fn)
+		default:
+			return nil, argError(method)
+		}
+	case "st_contains":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinContains{CallExpr: call}, nil
 	default:
 		return nil, translateExprNotSupported(fn)
 	}
@@ -724,6 +798,51 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			},
 		}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		var transform json.Transformation
+		switch call.Type {
+		case sqlparser.JSONSetType:
+			transform = json.Set
+		case sqlparser.JSONInsertType:
+			transform = json.Insert
+		case sqlparser.JSONReplaceType:
+			transform = json.Replace
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+		exprs := make([]sqlparser.Expr, 0, 1+2*len(call.Params))
+		exprs = append(exprs, call.JSONDoc)
+		for _, param := range call.Params {
+			exprs = append(exprs, param.Key, param.Value)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    strings.ToUpper(call.Type.ToString()),
+			},
+			transform: transform,
+		}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		cexpr := CallExpr{
+			Arguments: args,
+			Method:    strings.ToUpper(call.Type.ToString()),
+		}
+		if call.Type == sqlparser.JSONMergePatchType {
+			return &builtinJSONMergePatch{CallExpr: cexpr}, nil
+		}
+		// JSON_MERGE is a deprecated synonym of JSON_MERGE_PRESERVE
+		return &builtinJSONMergePreserve{CallExpr: cexpr}, nil
+
 	case *sqlparser.JSONUnquoteExpr:
 		arg, err := ast.translateExpr(call.JSONValue)
 		if err != nil {
@@ -779,6 +898,26 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			Method:    "JSON_CONTAINS_PATH",
 		}}, nil
 
+	case *sqlparser.JSONOverlapsExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc1, call.JSONDoc2})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONOverlaps{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_OVERLAPS",
+		}}, nil
+
+	case *sqlparser.MemberOfExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.Value, call.JSONArr})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinMemberOf{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "MEMBER OF",
+		}}, nil
+
 	case *sqlparser.JSONKeysExpr:
 		var args []IR
 		doc, err := ast.translateExpr(call.JSONDoc)
@@ -1137,6 +1276,80 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			CallExpr: cexpr,
 			collate:  coll,
 		}, nil
+
+	case *sqlparser.PointExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.XCordinate, call.YCordinate})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinPoint{CallExpr: CallExpr{Arguments: args, Method: "point"}}, nil
+
+	case *sqlparser.GeomFromTextExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		exprs := []sqlparser.Expr{call.WktText}
+		if call.Srid != nil {
+			exprs = append(exprs, call.Srid)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinGeomFromText{
+			CallExpr: CallExpr{Arguments: args, Method: call.Type.ToString()},
+			want:     geometryFromWktTypes[call.Type],
+		}, nil
+
+	case *sqlparser.GeomFromWKBExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		exprs := []sqlparser.Expr{call.WkbBlob}
+		if call.Srid != nil {
+			exprs = append(exprs, call.Srid)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinGeomFromWKB{
+			CallExpr: CallExpr{Arguments: args, Method: call.Type.ToString()},
+			want:     geometryFromWkbTypes[call.Type],
+		}, nil
+
+	case *sqlparser.GeomFormatExpr:
+		if call.AxisOrderOpt != nil {
+			return nil, translateExprNotSupported(call)
+		}
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.Geom})
+		if err != nil {
+			return nil, err
+		}
+		cexpr := CallExpr{Arguments: args, Method: call.FormatType.ToString()}
+		if call.FormatType == sqlparser.BinaryFormat {
+			return &builtinAsBinary{CallExpr: cexpr}, nil
+		}
+		return &builtinAsText{CallExpr: cexpr, collate: ast.cfg.Collation}, nil
+
+	case *sqlparser.PointPropertyFuncExpr:
+		// latitudes and longitudes only exist in geographic spatial
+		// reference systems, which we cannot evaluate
+		if call.Property != sqlparser.XCordinate && call.Property != sqlparser.YCordinate {
+			return nil, translateExprNotSupported(call)
+		}
+		exprs := []sqlparser.Expr{call.Point}
+		if call.ValueToSet != nil {
+			exprs = append(exprs, call.ValueToSet)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinPointCoordinate{
+			CallExpr: CallExpr{Arguments: args, Method: call.Property.ToString()},
+			y:        call.Property == sqlparser.YCordinate,
+		}, nil
 	default:
 		return nil, translateExprNotSupported(call)
 	}
@@ -1234,3 +1447,33 @@ func builtinIfRewrite(args []IR) (IR, error) {
 		Else: args[2],
 	}, nil
 }
+
+// translateGetFormat translates GET_FORMAT, whose first argument is one of
+// the DATE, TIME, DATETIME or TIMESTAMP keywords, parsed as a column name.
+func (ast *astCompiler) translateGetFormat(fn *sqlparser.FuncExpr) (IR, error) {
+	if len(fn.Exprs) != 2 {
+		return nil, argError("get_format")
+	}
+	col, ok := fn.Exprs[0].(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() {
+		return nil, translateExprNotSupported(fn)
+	}
+	var kind sqltypes.Type
+	switch col.Name.Lowered() {
+	case "date":
+		kind = sqltypes.Date
+	case "time":
+		kind = sqltypes.Time
+	case "datetime", "timestamp":
+		kind = sqltypes.Datetime
+	default:
+		return nil, translateExprNotSupported(fn)
+	}
+
+	arg, err := ast.translateExpr(fn.Exprs[1])
+	if err != nil {
+		return nil, err
+	}
+	call := CallExpr{Arguments: []IR{arg}, Method: "GET_FORMAT"}
+	return &builtinGetFormat{CallExpr: call, kind: kind, collate: ast.cfg.Collation}, nil
+}
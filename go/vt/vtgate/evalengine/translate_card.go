@@ -135,6 +135,8 @@ func (ast *astCompiler) cardExpr(expr IR) error {
 		return ast.cardUnary(expr.Inner)
 	case *IsExpr:
 		return ast.cardUnary(expr.Inner)
+	case *AssignmentExpr:
+		return ast.cardUnary(expr.Inner)
 	case *BitwiseNotExpr:
 		return ast.cardUnary(expr.Inner)
 	case *NotExpr:
@@ -146,7 +148,13 @@ func (ast *astCompiler) cardExpr(expr IR) error {
 	case *BitwiseExpr:
 		return ast.cardBinary(expr.Left, expr.Right)
 	case *LikeExpr:
-		return ast.cardBinary(expr.Left, expr.Right)
+		if err := ast.cardBinary(expr.Left, expr.Right); err != nil {
+			return err
+		}
+		if expr.Escape != nil {
+			return ast.cardUnary(expr.Escape)
+		}
+		return nil
 	case *ComparisonExpr:
 		return ast.cardComparison(expr.Left, expr.Right)
 	case *InExpr:
@@ -185,7 +193,7 @@ func (ast *astCompiler) cardExpr(expr IR) error {
 		}
 	case callable:
 		return ast.cardExpr(TupleExpr(expr.callable()))
-	case *Literal, *Column, *BindVariable, *CaseExpr: // noop
+	case *Literal, *Column, *BindVariable, *UserVariable, *CaseExpr: // noop
 	default:
 		panic(fmt.Sprintf("unhandled cardinality: %T", expr))
 	}
@@ -34,10 +34,23 @@ func (expr *Column) constant() bool {
 	return false
 }
 
+func (expr *UserVariable) constant() bool {
+	return false
+}
+
+func (expr *AssignmentExpr) constant() bool {
+	// assignments cannot be folded, as they have side effects
+	return false
+}
+
 func (expr *BinaryExpr) constant() bool {
 	return expr.Left.constant() && expr.Right.constant()
 }
 
+func (expr *LikeExpr) constant() bool {
+	return expr.BinaryExpr.constant() && (expr.Escape == nil || expr.Escape.constant())
+}
+
 func (tuple TupleExpr) constant() bool {
 	for _, subexpr := range tuple {
 		if !subexpr.constant() {
@@ -67,6 +80,10 @@ func (expr *Column) simplify(_ *ExpressionEnv) error {
 	return nil
 }
 
+func (expr *UserVariable) simplify(_ *ExpressionEnv) error {
+	return nil
+}
+
 func (expr *BinaryExpr) simplify(env *ExpressionEnv) error {
 	var err error
 	expr.Left, err = simplifyExpr(env, expr.Left)
@@ -85,11 +102,30 @@ func (expr *LikeExpr) simplify(env *ExpressionEnv) error {
 		return err
 	}
 
+	var escape eval
+	if expr.Escape != nil {
+		var err error
+		expr.Escape, err = simplifyExpr(env, expr.Escape)
+		if err != nil {
+			return err
+		}
+		lit, ok := expr.Escape.(*Literal)
+		if !ok {
+			return nil
+		}
+		escape = lit.inner
+	}
+
 	if lit, ok := expr.Right.(*Literal); ok {
 		if b, ok := lit.inner.(*evalBytes); ok && (b.isVarChar() || b.isBinary()) {
+			coll := colldata.Lookup(b.col.Collation)
+			esc, err := likeEscape(escape, coll)
+			if err != nil {
+				// an invalid escape character is reported when the expression is evaluated
+				return nil
+			}
 			expr.MatchCollation = b.col.Collation
-			coll := colldata.Lookup(expr.MatchCollation)
-			expr.Match = coll.Wildcard(b.bytes, 0, 0, 0)
+			expr.Match = coll.Wildcard(b.bytes, 0, 0, esc)
 		}
 	}
 	return nil
@@ -104,6 +140,9 @@ func (inexpr *InExpr) simplify(env *ExpressionEnv) error {
 		return err
 	}
 
+	if tuple, ok := inexpr.Right.(TupleExpr); ok {
+		inexpr.table = newInTable(tuple)
+	}
 	return nil
 }
 
@@ -56,6 +56,13 @@ func TestTranslateSimplification(t *testing.T) {
 		{"1 + (1 + 1) * 8", ok("1 + (1 + 1) * 8"), ok("17")},
 		{"1.0e0 + (1 + 1) * 8.0e0", ok("1 + (1 + 1) * 8"), ok("17")},
 		{"'pokemon' LIKE 'poke%'", ok("'pokemon' like 'poke%'"), ok("1")},
+		{"_gbk 'pokemon' LIKE 'poke%'", err("expr cannot be translated, not supported: _gbk 'pokemon'"), err("expr cannot be translated, not supported: _gbk 'pokemon'")},
+		{"'pokemon' NOT LIKE 'poke%'", ok("'pokemon' not like 'poke%'"), ok("0")},
+		{"'a_c' LIKE 'a|_c' ESCAPE '|'", ok("'a_c' like 'a|_c' escape '|'"), ok("1")},
+		{"'abc' LIKE 'a|_c' ESCAPE '|'", ok("'abc' like 'a|_c' escape '|'"), ok("0")},
+		{"'abc' NOT LIKE 'a|_c' ESCAPE '|'", ok("'abc' not like 'a|_c' escape '|'"), ok("1")},
+		{"'a_c' LIKE 'a\\_c' ESCAPE ''", ok("'a_c' like 'a\\_c' escape ''"), ok("1")},
+		{"'a_c' LIKE 'a|_c' ESCAPE '||'", ok("'a_c' like 'a|_c' escape '||'"), err("Incorrect arguments to ESCAPE")},
 		{
 			"'foo' COLLATE utf8mb4_general_ci IN ('bar' COLLATE latin1_swedish_ci, 'baz')",
 			ok(`'foo' COLLATE utf8mb4_general_ci in ('bar' COLLATE latin1_swedish_ci, 'baz')`),
@@ -418,6 +425,82 @@ func TestTranslationFailures(t *testing.T) {
 	}
 }
 
+func TestTranslateDefault(t *testing.T) {
+	defaults := map[string]string{
+		"a": "42",
+		"b": "'abc'",
+		"c": "null",
+		"d": "-1.5",
+		"e": "a + 1",
+	}
+	resolveDefault := func(column string) (sqlparser.Expr, bool) {
+		def, ok := defaults[column]
+		if !ok {
+			return nil, false
+		}
+		expr, err := sqlparser.NewTestParser().ParseExpr(def)
+		require.NoError(t, err)
+		return expr, true
+	}
+
+	testcases := []struct {
+		expression  string
+		expected    sqltypes.Value
+		expectedErr string
+	}{{
+		expression: "default(a)",
+		expected:   sqltypes.NewInt64(42),
+	}, {
+		expression: "default(a) + 1",
+		expected:   sqltypes.NewInt64(43),
+	}, {
+		expression: "concat(default(b), 'def')",
+		expected:   sqltypes.NewVarChar("abcdef"),
+	}, {
+		expression: "default(c)",
+		expected:   sqltypes.NULL,
+	}, {
+		expression: "default(d)",
+		expected:   sqltypes.NewDecimal("-1.5"),
+	}, {
+		expression:  "default(e)",
+		expectedErr: "expr cannot be translated, not supported: default(e)",
+	}, {
+		expression:  "default(f)",
+		expectedErr: "Field 'f' doesn't have a default value",
+	}}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testcases {
+		t.Run(tc.expression, func(t *testing.T) {
+			astExpr, err := sqlparser.NewTestParser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			// DEFAULT(col) cannot be evaluated without the defaults of the columns
+			_, err = Translate(astExpr, &Config{
+				Collation:   venv.CollationEnv().DefaultConnectionCharset(),
+				Environment: venv,
+			})
+			require.ErrorContains(t, err, ErrTranslateExprNotSupported)
+
+			expr, err := Translate(astExpr, &Config{
+				ResolveDefault: resolveDefault,
+				Collation:      venv.CollationEnv().DefaultConnectionCharset(),
+				Environment:    venv,
+			})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			r, err := EmptyExpressionEnv(venv).Evaluate(expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r.Value(collations.MySQL8().DefaultConnectionCharset()))
+		})
+	}
+}
+
 func TestCardinalityWithBindVariables(t *testing.T) {
 	testcases := []struct {
 		expr string
@@ -480,3 +563,87 @@ func TestBindVarType(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestTranslateSpeculativeTypes(t *testing.T) {
+	testcases := []struct {
+		expression  string
+		bindVars    map[string]*querypb.BindVariable
+		compiled    int
+		speculative bool
+		expected    sqltypes.Value
+	}{{
+		expression:  ":a + 1",
+		bindVars:    map[string]*querypb.BindVariable{"a": sqltypes.Int64BindVariable(41)},
+		compiled:    3,
+		speculative: true,
+		expected:    sqltypes.NewInt64(42),
+	}, {
+		expression:  ":a + 1",
+		bindVars:    map[string]*querypb.BindVariable{"a": sqltypes.DecimalBindVariable("1.5")},
+		compiled:    3,
+		speculative: true,
+		expected:    sqltypes.NewDecimal("2.5"),
+	}, {
+		expression:  "concat(:a, :b)",
+		bindVars:    map[string]*querypb.BindVariable{"a": sqltypes.StringBindVariable("foo"), "b": sqltypes.Int64BindVariable(1)},
+		compiled:    9,
+		speculative: true,
+		expected:    sqltypes.NewVarChar("foo1"),
+	}, {
+		// the types of the bind variables were not speculated
+		expression: ":a + 1",
+		bindVars:   map[string]*querypb.BindVariable{"a": sqltypes.Float64BindVariable(1.5)},
+		compiled:   3,
+		expected:   sqltypes.NewFloat64(2.5),
+	}, {
+		expression: ":a + 1",
+		bindVars:   map[string]*querypb.BindVariable{"a": sqltypes.NullBindVariable},
+		compiled:   3,
+		expected:   sqltypes.NULL,
+	}, {
+		// too many bind variables to speculate on their types
+		expression: ":a + :b + :c + :d",
+		bindVars: map[string]*querypb.BindVariable{
+			"a": sqltypes.Int64BindVariable(1),
+			"b": sqltypes.Int64BindVariable(2),
+			"c": sqltypes.Int64BindVariable(3),
+			"d": sqltypes.Int64BindVariable(4),
+		},
+		expected: sqltypes.NewInt64(10),
+	}, {
+		// the types of the user variables are not speculated
+		expression: "@a + :b",
+		bindVars:   map[string]*querypb.BindVariable{"b": sqltypes.Int64BindVariable(1)},
+		expected:   sqltypes.NULL,
+	}}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testcases {
+		t.Run(tc.expression, func(t *testing.T) {
+			astExpr, err := sqlparser.NewTestParser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			expr, err := Translate(astExpr, &Config{
+				Collation:        venv.CollationEnv().DefaultConnectionCharset(),
+				Environment:      venv,
+				SpeculativeTypes: true,
+			})
+			require.NoError(t, err)
+			untyped, ok := expr.(*UntypedExpr)
+			require.True(t, ok)
+			assert.Len(t, untyped.speculative, tc.compiled)
+
+			env := NewExpressionEnv(t.Context(), tc.bindVars, NewEmptyVCursor(venv, time.UTC))
+			assert.Equal(t, tc.speculative, untyped.loadSpeculative(env) != nil)
+
+			r, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r.Value(collations.MySQL8().DefaultConnectionCharset()))
+
+			// the VM and the interpreter evaluate the expression to the same value
+			ast, err := env.EvaluateAST(expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ast.Value(collations.MySQL8().DefaultConnectionCharset()))
+		})
+	}
+}
@@ -19,6 +19,8 @@ package evalengine
 import (
 	"errors"
 
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vthash"
 )
@@ -71,12 +73,127 @@ func (p *CompiledExpr) FormatFast(buf *sqlparser.TrackedBuffer) {
 var _ Expr = (*CompiledExpr)(nil)
 
 func (env *ExpressionEnv) EvaluateVM(p *CompiledExpr) (EvalResult, error) {
+	env.vm.reserve(p.stack)
+	e, err := env.run(p)
+	return EvalResult{v: e, collationEnv: env.collationEnv}, err
+}
+
+// EvaluateBatch evaluates the expression for each of the rows, and returns
+// the result of each row converted to the collation id. The stack of the VM
+// is set up, and the expression is checked for being compiled, once for all
+// the rows instead of once per row. The expression env's Row is left set to
+// the last row.
+func (env *ExpressionEnv) EvaluateBatch(expr Expr, rows [][]sqltypes.Value, id collations.ID) ([]sqltypes.Value, error) {
+	results := make([]sqltypes.Value, 0, len(rows))
+	err := env.evaluateBatch(expr, rows, func(_ []sqltypes.Value, e eval) {
+		results = append(results, EvalResult{v: e}.Value(id))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FilterBatch evaluates the predicate for each of the rows, and returns the
+// rows for which it is true. Like EvaluateBatch, it sets up the VM once for
+// all the rows.
+func (env *ExpressionEnv) FilterBatch(predicate Expr, rows [][]sqltypes.Value) ([][]sqltypes.Value, error) {
+	var filtered [][]sqltypes.Value
+	err := env.evaluateBatch(predicate, rows, func(row []sqltypes.Value, e eval) {
+		if evalIsTruthy(e) == boolTrue {
+			filtered = append(filtered, row)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
+
+// ProjectBatch evaluates the expressions for each of the rows, and returns a
+// row with their results converted to the collation id for each of the rows.
+// The expressions of a row are evaluated in order, before the ones of the
+// next row, so that the assignments of user variables behave like in MySQL.
+func (env *ExpressionEnv) ProjectBatch(exprs []Expr, rows [][]sqltypes.Value, id collations.ID) ([]sqltypes.Row, error) {
+	if len(exprs) == 1 {
+		results := make([]sqltypes.Row, 0, len(rows))
+		err := env.evaluateBatch(exprs[0], rows, func(_ []sqltypes.Value, e eval) {
+			results = append(results, sqltypes.Row{EvalResult{v: e}.Value(id)})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	for _, expr := range exprs {
+		if p, ok := expr.(*CompiledExpr); ok {
+			env.vm.reserve(p.stack)
+		}
+	}
+
+	results := make([]sqltypes.Row, 0, len(rows))
+	for _, row := range rows {
+		env.Row = row
+		result := make(sqltypes.Row, 0, len(exprs))
+		for _, expr := range exprs {
+			var e eval
+			var err error
+			if p, ok := expr.(*CompiledExpr); ok {
+				e, err = env.run(p)
+			} else {
+				e, err = expr.eval(env)
+			}
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, EvalResult{v: e}.Value(id))
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// evaluateBatch evaluates expr for each of the rows and passes the row and
+// its result to yield. The result is only valid until yield returns.
+func (env *ExpressionEnv) evaluateBatch(expr Expr, rows [][]sqltypes.Value, yield func([]sqltypes.Value, eval)) error {
+	p, ok := expr.(*CompiledExpr)
+	if !ok {
+		for _, row := range rows {
+			env.Row = row
+			e, err := expr.eval(env)
+			if err != nil {
+				return err
+			}
+			yield(row, e)
+		}
+		return nil
+	}
+
+	env.vm.reserve(p.stack)
+	for _, row := range rows {
+		env.Row = row
+		e, err := env.run(p)
+		if err != nil {
+			return err
+		}
+		yield(row, e)
+	}
+	return nil
+}
+
+func (vm *vmstate) reserve(stack int) {
+	if len(vm.stack) < stack {
+		vm.stack = make([]eval, stack)
+	}
+}
+
+// run runs the code of p for the current row. The stack of the VM must have
+// been reserved for p.
+func (env *ExpressionEnv) run(p *CompiledExpr) (eval, error) {
 	env.vm.arena.reset()
 	env.vm.sp = 0
 	env.vm.err = nil
-	if len(env.vm.stack) < p.stack {
-		env.vm.stack = make([]eval, p.stack)
-	}
 
 	code := p.code
 	ip := 0
@@ -87,12 +204,11 @@ func (env *ExpressionEnv) EvaluateVM(p *CompiledExpr) (EvalResult, error) {
 			goto err
 		}
 	}
-	return EvalResult{v: env.vm.stack[env.vm.sp-1], collationEnv: env.collationEnv}, nil
+	return env.vm.stack[env.vm.sp-1], nil
 
 err:
 	if env.vm.err == errDeoptimize {
-		e, err := p.ir.eval(env)
-		return EvalResult{v: e, collationEnv: env.collationEnv}, err
+		return p.ir.eval(env)
 	}
-	return EvalResult{collationEnv: env.collationEnv}, env.vm.err
+	return nil, env.vm.err
 }
@@ -120,6 +120,28 @@ func WeightString(dst []byte, v sqltypes.Value, coerceTo sqltypes.Type, col coll
 		return evalWeightString(dst, newEvalEnum(v.Raw(), values), length, precision)
 	case coerceTo == sqltypes.Set:
 		return evalWeightString(dst, newEvalSet(v.Raw(), values), length, precision)
+	case coerceTo == sqltypes.Bit:
+		return evalWeightString(dst, newEvalBit(v.Raw()), length, precision)
+	case coerceTo == sqltypes.Date:
+		t, err := parseDate(v.Raw())
+		if err != nil {
+			return dst, false, err
+		}
+		return t.dt.WeightString(dst), true, nil
+	case coerceTo == sqltypes.Datetime, coerceTo == sqltypes.Timestamp:
+		// The weight string of DATETIME and TIMESTAMP values includes their
+		// fractional seconds, whatever their precision.
+		t, err := parseDateTime(v.Raw())
+		if err != nil {
+			return dst, false, err
+		}
+		return t.dt.WeightString(dst), true, nil
+	case coerceTo == sqltypes.Time:
+		t, err := parseTime(v.Raw())
+		if err != nil {
+			return dst, false, err
+		}
+		return t.dt.WeightString(dst), true, nil
 	default:
 		return fallbackWeightString(dst, v, coerceTo, col, length, precision, values, sqlmode)
 	}
@@ -154,6 +176,12 @@ func evalWeightString(dst []byte, e eval, length, precision int) ([]byte, bool,
 	case *evalDecimal:
 		return e.dec.WeightString(dst, int32(length), int32(precision)), true, nil
 	case *evalBytes:
+		if e.SQLType() == sqltypes.Bit {
+			// BIT values are sorted by their numeric value, whatever their width
+			if bit, ok := e.toNumericBit(); ok {
+				return binary.BigEndian.AppendUint64(dst, bit.toUint64().u), true, nil
+			}
+		}
 		if e.isBinary() {
 			b := e.bytes
 			if length != 0 {
@@ -191,6 +219,37 @@ func evalWeightString(dst []byte, e eval, length, precision int) ([]byte, bool,
 	return dst, false, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected type %v", e.SQLType())
 }
 
+// enumSetWeightString returns the weight string of an ENUM or SET value as
+// returned by MySQL's WEIGHT_STRING function for a column: the 1-based index of
+// the ENUM value, or the bitmask of the SET value, as a big-endian integer as
+// wide as the storage of the column, which depends on its number of values.
+func enumSetWeightString(dst []byte, e eval, values *EnumSetValues) []byte {
+	var raw uint64
+	var size int
+	switch e := e.(type) {
+	case *evalEnum:
+		// Values that are not in the list have the index of the empty string,
+		// which MySQL stores for invalid values.
+		if e.value >= 0 {
+			raw = uint64(enumNumeric(e.value))
+		}
+		size = 1
+		if len(*values) >= 256 {
+			size = 2
+		}
+	case *evalSet:
+		raw = e.set
+		size = (len(*values) + 7) / 8
+		if size > 4 {
+			size = 8
+		}
+	}
+	for i := size - 1; i >= 0; i-- {
+		dst = append(dst, byte(raw>>(8*i)))
+	}
+	return dst
+}
+
 // TinyWeighter returns a callback to apply a Tiny Weight string to a sqltypes.Value.
 // A tiny weight string is a compressed 4-byte representation of the value's full weight string that
 // sorts identically to its full weight. Obviously, the tiny weight string can collide because
@@ -298,6 +357,32 @@ func TinyWeighter(f *querypb.Field, collation collations.ID) func(v *sqltypes.Va
 			v.SetTinyWeight(raw)
 		}
 
+	case sqltypes.IsDateOrTime(f.Type):
+		return func(v *sqltypes.Value) {
+			if v.IsNull() {
+				return
+			}
+			var t *evalTemporal
+			var err error
+			switch f.Type {
+			case sqltypes.Date:
+				t, err = parseDate(v.Raw())
+			case sqltypes.Time:
+				t, err = parseTime(v.Raw())
+			default:
+				t, err = parseDateTime(v.Raw())
+			}
+			if err != nil {
+				return
+			}
+			// The full weight string of a temporal value is a 64-bit integer with
+			// the date and the time in its top bits and the microseconds in its
+			// bottom 24 bits, so its top 32 bits sort like the full weight string.
+			var w64 [8]byte
+			t.dt.WeightString(w64[:0])
+			v.SetTinyWeight(binary.BigEndian.Uint32(w64[:4]))
+		}
+
 	case f.Type == sqltypes.TypeJSON:
 		return func(v *sqltypes.Value) {
 			if v.IsNull() {
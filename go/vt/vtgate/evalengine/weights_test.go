@@ -18,14 +18,19 @@ package evalengine
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"slices"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 )
 
 func TestTinyWeightStrings(t *testing.T) {
@@ -50,6 +55,10 @@ func TestTinyWeightStrings(t *testing.T) {
 		{typ: sqltypes.TypeJSON, gen: sqltypes.RandomGenerators[sqltypes.TypeJSON], col: collations.CollationBinaryID},
 		{typ: sqltypes.Enum, gen: sqltypes.RandomGenerators[sqltypes.Enum], col: collations.CollationBinaryID, values: &EnumSetValues{"'xxsmall'", "'xsmall'", "'small'", "'medium'", "'large'", "'xlarge'", "'xxlarge'"}},
 		{typ: sqltypes.Set, gen: sqltypes.RandomGenerators[sqltypes.Set], col: collations.CollationBinaryID, values: &EnumSetValues{"'a'", "'b'", "'c'", "'d'", "'e'", "'f'", "'g'"}},
+		{typ: sqltypes.Date, gen: sqltypes.RandomGenerators[sqltypes.Date], col: collations.CollationBinaryID},
+		{typ: sqltypes.Datetime, gen: randomFractionalTemporal(sqltypes.Datetime), col: collations.CollationBinaryID},
+		{typ: sqltypes.Timestamp, gen: randomFractionalTemporal(sqltypes.Timestamp), col: collations.CollationBinaryID},
+		{typ: sqltypes.Time, gen: randomFractionalTemporal(sqltypes.Time), col: collations.CollationBinaryID},
 	}
 
 	for _, tc := range cases {
@@ -130,8 +139,19 @@ func TestWeightStrings(t *testing.T) {
 		{name: "datetime", gen: sqltypes.RandomGenerators[sqltypes.Datetime], types: []sqltypes.Type{sqltypes.Datetime, sqltypes.VarChar, sqltypes.TypeJSON}, col: collations.CollationBinaryID},
 		{name: "timestamp", gen: sqltypes.RandomGenerators[sqltypes.Timestamp], types: []sqltypes.Type{sqltypes.Timestamp, sqltypes.VarChar, sqltypes.TypeJSON}, col: collations.CollationBinaryID},
 		{name: "time", gen: sqltypes.RandomGenerators[sqltypes.Time], types: []sqltypes.Type{sqltypes.Time, sqltypes.VarChar, sqltypes.TypeJSON}, col: collations.CollationBinaryID},
+		{name: "datetime(6)", gen: randomFractionalTemporal(sqltypes.Datetime), types: []sqltypes.Type{sqltypes.Datetime, sqltypes.VarChar}, col: collations.CollationBinaryID},
+		{name: "timestamp(6)", gen: randomFractionalTemporal(sqltypes.Timestamp), types: []sqltypes.Type{sqltypes.Timestamp, sqltypes.VarChar}, col: collations.CollationBinaryID},
+		{name: "time(6)", gen: randomFractionalTemporal(sqltypes.Time), types: []sqltypes.Type{sqltypes.Time, sqltypes.VarChar}, col: collations.CollationBinaryID},
 		{name: "enum", gen: sqltypes.RandomGenerators[sqltypes.Enum], types: []sqltypes.Type{sqltypes.Enum, sqltypes.VarChar, sqltypes.TypeJSON}, col: collations.CollationBinaryID, values: &EnumSetValues{"'xxsmall'", "'xsmall'", "'small'", "'medium'", "'large'", "'xlarge'", "'xxlarge'"}},
 		{name: "set", gen: sqltypes.RandomGenerators[sqltypes.Set], types: []sqltypes.Type{sqltypes.Set, sqltypes.VarChar, sqltypes.TypeJSON}, col: collations.CollationBinaryID, values: &EnumSetValues{"'a'", "'b'", "'c'", "'d'", "'e'", "'f'", "'g'"}},
+		{name: "bit", gen: func() sqltypes.Value {
+			// BIT values of columns with different widths
+			raw := make([]byte, 1+rand.IntN(8))
+			for i := range raw {
+				raw[i] = byte(rand.IntN(256))
+			}
+			return sqltypes.MakeTrusted(sqltypes.Bit, raw)
+		}, types: []sqltypes.Type{sqltypes.Bit}, col: collations.CollationBinaryID},
 	}
 
 	for _, tc := range cases {
@@ -179,3 +199,78 @@ func TestWeightStrings(t *testing.T) {
 		}
 	}
 }
+
+// randomFractionalTemporal returns a generator of random temporal values of the
+// given type with fractional seconds, which often only differ by them.
+func randomFractionalTemporal(typ sqltypes.Type) func() sqltypes.Value {
+	return func() sqltypes.Value {
+		raw := sqltypes.RandomGenerators[typ]().Raw()
+		if typ == sqltypes.Time && rand.IntN(2) == 0 {
+			raw = append([]byte("-"), raw...)
+		}
+		return sqltypes.MakeTrusted(typ, fmt.Appendf(raw, ".%06d", rand.IntN(3)*250000+rand.IntN(2)))
+	}
+}
+
+func TestWeightStringFunctionEnumSet(t *testing.T) {
+	enumValues := &EnumSetValues{"'small'", "'medium'", "'large'"}
+	largeEnumValues := make(EnumSetValues, 0, 300)
+	for i := range 300 {
+		largeEnumValues = append(largeEnumValues, fmt.Sprintf("'v%d'", i))
+	}
+	setValues := &EnumSetValues{"'a'", "'b'", "'c'"}
+	largeSetValues := make(EnumSetValues, 0, 40)
+	for i := range 40 {
+		largeSetValues = append(largeSetValues, fmt.Sprintf("'s%d'", i))
+	}
+
+	testcases := []struct {
+		typ      sqltypes.Type
+		values   *EnumSetValues
+		value    string
+		expected []byte
+	}{
+		{typ: sqltypes.Enum, values: enumValues, value: "medium", expected: []byte{2}},
+		// values that aren't in the list sort like the empty string
+		{typ: sqltypes.Enum, values: enumValues, value: "huge", expected: []byte{0}},
+		{typ: sqltypes.Enum, values: &largeEnumValues, value: "v299", expected: []byte{0x01, 0x2c}},
+		{typ: sqltypes.Set, values: setValues, value: "a,c", expected: []byte{0b101}},
+		{typ: sqltypes.Set, values: setValues, value: "", expected: []byte{0}},
+		{typ: sqltypes.Set, values: &largeSetValues, value: "s0,s39", expected: []byte{0, 0, 0, 0x80, 0, 0, 0, 1}},
+		// the weight string depends on the values of the column
+		{typ: sqltypes.Enum, value: "medium"},
+		{typ: sqltypes.Set, value: "a,c"},
+	}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%v/%s", tc.typ, tc.value), func(t *testing.T) {
+			expr, err := venv.Parser().ParseExpr("weight_string(col)")
+			require.NoError(t, err)
+
+			for _, compiled := range []bool{false, true} {
+				cfg := &Config{
+					ResolveColumn: FieldResolver([]*querypb.Field{{Name: "col", Type: tc.typ}}).Column,
+					ResolveType: func(sqlparser.Expr) (Type, bool) {
+						return NewTypeEx(tc.typ, collations.CollationUtf8mb4ID, true, 0, 0, tc.values), true
+					},
+					Collation:     collations.CollationUtf8mb4ID,
+					Environment:   venv,
+					NoCompilation: !compiled,
+				}
+				converted, err := Translate(expr, cfg)
+				require.NoError(t, err)
+
+				env := NewExpressionEnv(t.Context(), nil, NewEmptyVCursor(venv, time.UTC))
+				env.Row = []sqltypes.Value{sqltypes.MakeTrusted(tc.typ, []byte(tc.value))}
+				res, err := env.Evaluate(converted)
+				require.NoError(t, err)
+				if tc.expected == nil {
+					assert.True(t, res.Value(collations.CollationUtf8mb4ID).IsNull(), "compiled=%v", compiled)
+					continue
+				}
+				assert.Equal(t, tc.expected, res.Value(collations.CollationUtf8mb4ID).Raw(), "compiled=%v", compiled)
+			}
+		})
+	}
+}
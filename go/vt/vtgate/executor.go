@@ -24,6 +24,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -88,6 +89,10 @@ var (
 	commitMode       = stats.NewTimings("CommitModeTimings", "Commit Mode Time", "mode")
 	commitUnresolved = stats.NewCounter("CommitUnresolved", "Atomic Commit failed to conclude after commit decision is made")
 
+	resultCacheInvalidations = stats.NewCounter("ResultCacheInvalidations", "Number of times a table was invalidated in the result cache")
+
+	replicaLockingReadsCount = stats.NewCountersWithSingleLabel("ReplicaLockingReads", "Locking reads of the sessions that target replicas, by the action taken", "Action")
+
 	exceedMemoryRowsLogger = logutil.NewThrottledLogger("ExceedMemoryRows", 1*time.Minute)
 
 	errorTransform errorTransformer = nullErrorTransformer{}
@@ -97,6 +102,18 @@ const (
 	bindVarPrefix = "__vt"
 )
 
+// The policies for the locking reads, SELECT ... FOR UPDATE and SELECT ... FOR
+// SHARE, of the sessions that target replicas.
+const (
+	// ReplicaLockingReadsAllow executes them on the replicas, where they do
+	// not lock the rows they read on the primary.
+	ReplicaLockingReadsAllow = "allow"
+	// ReplicaLockingReadsRedirect executes them on the primary.
+	ReplicaLockingReadsRedirect = "redirect"
+	// ReplicaLockingReadsReject rejects them with a VT09033 error.
+	ReplicaLockingReadsReject = "reject"
+)
+
 func init() {
 	registerTabletTypeFlag := func(fs *pflag.FlagSet) {
 		utils.SetFlagVar(fs, (*topoproto.TabletTypeFlag)(&defaultTabletType), "default-tablet-type", "The default tablet type to set for queries, when one is not explicitly selected.")
@@ -122,6 +139,21 @@ type (
 		PreventCrossKeyspaceReads bool
 		WarmingReadsPercent       int
 		QueryLogToFile            string
+		// InsertBatchWindow is how long single-row autocommit inserts wait to
+		// be batched with other inserts to the same shard. Zero disables
+		// insert batching.
+		InsertBatchWindow time.Duration
+		// InsertBatchMaxRows is the maximum number of rows of an insert batch.
+		InsertBatchMaxRows int
+		// RecordPlannerDecisions makes the planner record its cost-based
+		// decisions in the plans, for vtexplain.
+		RecordPlannerDecisions bool
+		// ResultCacheMemory is the capacity in bytes of the cache of the results
+		// of the queries that use the CACHE_TTL directive. Zero disables it.
+		ResultCacheMemory int64
+		// ReplicaLockingReads is the policy for the locking reads of the
+		// sessions that target replicas. The zero value allows them.
+		ReplicaLockingReads string
 	}
 
 	Executor struct {
@@ -143,6 +175,9 @@ type (
 		plans *PlanCache
 		epoch atomic.Uint32
 
+		// results is nil when the result cache is disabled.
+		results *resultCache
+
 		vm            *VSchemaManager
 		schemaTracker SchemaInfo
 
@@ -150,9 +185,15 @@ type (
 		queryLogger *streamlog.StreamLogger[*logstats.LogStats]
 
 		warmingReadsSemaphore *semaphore.Weighted
+		inListChunkSemaphore  *semaphore.Weighted
+		insertBatcher         *engine.InsertBatcher
 
 		vConfig   econtext.VCursorConfig
 		ddlConfig dynamicconfig.DDL
+
+		// processes lists the client connections for SHOW PROCESSLIST.
+		// It is nil unless the MySQL protocol server is enabled.
+		processes processLister
 	}
 
 	Metrics struct {
@@ -206,8 +247,15 @@ func NewExecutor(
 		schemaTracker:         schemaTracker,
 		plans:                 plans,
 		warmingReadsSemaphore: newWarmingReadsSemaphore(warmingReadsConcurrency),
+		inListChunkSemaphore:  semaphore.NewWeighted(int64(max(inListChunkConcurrency, 0))),
 		ddlConfig:             ddlConfig,
 	}
+	if eConfig.ResultCacheMemory > 0 {
+		e.results = newResultCache(eConfig.ResultCacheMemory)
+	}
+	if eConfig.InsertBatchWindow > 0 && eConfig.InsertBatchMaxRows > 1 {
+		e.insertBatcher = engine.NewInsertBatcher(eConfig.InsertBatchWindow, eConfig.InsertBatchMaxRows, e.executeInsertBatch)
+	}
 	// setting the vcursor config.
 	e.initVConfig(warnOnShardedOnly, pv)
 	e.metrics = &Metrics{
@@ -243,6 +291,20 @@ func NewExecutor(
 		stats.NewCounterFunc("QueryPlanCacheMisses", "Query plan cache misses", func() int64 {
 			return e.plans.Metrics.Misses()
 		})
+		if e.results != nil {
+			stats.NewGaugeFunc("ResultCacheLength", "Result cache length", func() int64 {
+				return int64(e.results.store.Len())
+			})
+			stats.NewGaugeFunc("ResultCacheSize", "Result cache size", func() int64 {
+				return int64(e.results.store.UsedCapacity())
+			})
+			stats.NewCounterFunc("ResultCacheHits", "Result cache hits", func() int64 {
+				return e.results.store.Metrics.Hits()
+			})
+			stats.NewCounterFunc("ResultCacheMisses", "Result cache misses", func() int64 {
+				return e.results.store.Metrics.Misses()
+			})
+		}
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
@@ -345,6 +407,15 @@ func (e *Executor) StreamExecute(
 				// the framework currently sends all results as one packet.
 				byteCount := 0
 				if len(qr.Fields) > 0 {
+					// The rows left of the previous result set, like one
+					// of those of a stored procedure call, are sent before
+					// the next result set starts.
+					if qr.NewResult && len(result.Rows) > 0 {
+						if err := callback(result); err != nil {
+							return err
+						}
+						result = &sqltypes.Result{}
+					}
 					result.Fields = qr.Fields
 					if err := callback(qr.Metadata()); err != nil {
 						return err
@@ -703,7 +774,7 @@ func (e *Executor) handleRollback(ctx context.Context, vcursor *econtext.VCursor
 	return &sqltypes.Result{}, err
 }
 
-func (e *Executor) handleSavepoint(ctx context.Context, vcursor *econtext.VCursorImpl, safeSession *econtext.SafeSession, sql string, queryType string, logStats *logstats.LogStats, nonTxResponse func(query string) (*sqltypes.Result, error), ignoreMaxMemoryRows bool) (*sqltypes.Result, error) {
+func (e *Executor) handleSavepoint(ctx context.Context, vcursor *econtext.VCursorImpl, safeSession *econtext.SafeSession, sql string, queryType string, logStats *logstats.LogStats, nonTxResponse func(query string) (*sqltypes.Result, error), maxMemoryRows int) (*sqltypes.Result, error) {
 	execStart := time.Now()
 	logStats.PlanTime = execStart.Sub(logStats.StartTime)
 	logStats.StmtType = queryType
@@ -729,7 +800,7 @@ func (e *Executor) handleSavepoint(ctx context.Context, vcursor *econtext.VCurso
 		return nonTxResponse(sql)
 	}
 	orig := safeSession.GetCommitOrder()
-	qr, err := e.executeSPInAllSessions(ctx, safeSession, sql, ignoreMaxMemoryRows)
+	qr, err := e.executeSPInAllSessions(ctx, safeSession, sql, maxMemoryRows)
 	safeSession.SetCommitOrder(orig)
 	if err != nil {
 		return nil, err
@@ -740,7 +811,7 @@ func (e *Executor) handleSavepoint(ctx context.Context, vcursor *econtext.VCurso
 
 // executeSPInAllSessions function executes the savepoint query in all open shard sessions (pre, normal and post)
 // which has non-zero transaction id (i.e. an open transaction on the shard connection).
-func (e *Executor) executeSPInAllSessions(ctx context.Context, safeSession *econtext.SafeSession, sql string, ignoreMaxMemoryRows bool) (*sqltypes.Result, error) {
+func (e *Executor) executeSPInAllSessions(ctx context.Context, safeSession *econtext.SafeSession, sql string, maxMemoryRows int) (*sqltypes.Result, error) {
 	var qr *sqltypes.Result
 	var errs []error
 	for _, co := range []vtgatepb.CommitOrder{vtgatepb.CommitOrder_PRE, vtgatepb.CommitOrder_NORMAL, vtgatepb.CommitOrder_POST} {
@@ -760,7 +831,7 @@ func (e *Executor) executeSPInAllSessions(ctx context.Context, safeSession *econ
 			})
 			queries = append(queries, &querypb.BoundQuery{Sql: sql})
 		}
-		qr, errs = e.ExecuteMultiShard(ctx, nil, rss, queries, safeSession, false /*autocommit*/, ignoreMaxMemoryRows, nullResultsObserver{}, false)
+		qr, errs = e.ExecuteMultiShard(ctx, nil, rss, queries, safeSession, false /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
 		err := vterrors.Aggregate(errs)
 		if err != nil {
 			return nil, err
@@ -1141,6 +1212,62 @@ func (e *Executor) MessageStream(ctx context.Context, keyspace string, shard str
 	return formatError(err)
 }
 
+// MessageAckBatch acks messages of a message table. The ids are sent to
+// their shards, which ack them in chunks.
+func (e *Executor) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	rss, rssIDs, err := e.resolveMessageIDs(ctx, "MessageAckBatch", keyspace, name, ids)
+	if err != nil {
+		return 0, formatError(err)
+	}
+	count, err := e.scatterConn.MessageAckBatch(ctx, rss, rssIDs, name)
+	return count, formatError(err)
+}
+
+// MessagePostpone postpones messages of a message table. The ids are sent
+// to their shards, which postpone them in chunks.
+func (e *Executor) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	rss, rssIDs, err := e.resolveMessageIDs(ctx, "MessagePostpone", keyspace, name, ids)
+	if err != nil {
+		return 0, formatError(err)
+	}
+	count, err := e.scatterConn.MessagePostpone(ctx, rss, rssIDs, name)
+	return count, formatError(err)
+}
+
+// resolveMessageIDs returns the primary shards of the messages, and the ids
+// of the messages on each of them. In a sharded keyspace, the ids are mapped
+// with the primary vindex of the message table, which must be unique.
+func (e *Executor) resolveMessageIDs(ctx context.Context, method, keyspace, name string, ids []*querypb.Value) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
+	table, err := e.VSchema().FindTable(keyspace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	destinations := make([]key.ShardDestination, len(ids))
+	if !table.Keyspace.Sharded {
+		for i := range destinations {
+			destinations[i] = key.DestinationAllShards{}
+		}
+		return e.resolver.resolver.ResolveDestinations(ctx, table.Keyspace.Name, topodatapb.TabletType_PRIMARY, ids, destinations)
+	}
+
+	if len(table.ColumnVindexes) == 0 || !table.ColumnVindexes[0].IsUnique() {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "message table %s has no unique primary vindex", name)
+	}
+	vcursor, err := e.newVCursor(econtext.NewSafeSession(nil), sqlparser.MarginComments{}, logstats.NewLogStats(ctx, method, "", "", nil, streamlog.GetQueryLogConfig()))
+	if err != nil {
+		return nil, nil, err
+	}
+	rowsColValues := make([][]sqltypes.Value, 0, len(ids))
+	for _, id := range ids {
+		rowsColValues = append(rowsColValues, []sqltypes.Value{sqltypes.ProtoToValue(id)})
+	}
+	destinations, err = vindexes.Map(ctx, table.ColumnVindexes[0].Vindex, vcursor, rowsColValues)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e.resolver.resolver.ResolveDestinations(ctx, table.Keyspace.Name, topodatapb.TabletType_PRIMARY, ids, destinations)
+}
+
 // VSchema returns the VSchema.
 func (e *Executor) VSchema() *vindexes.VSchema {
 	e.mu.Lock()
@@ -1157,6 +1284,9 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	}
 	e.vschemaStats = stats
 	e.ClearPlans()
+	if e.insertBatcher != nil {
+		e.insertBatcher.ClearTables()
+	}
 
 	if vschemaCounters != nil {
 		vschemaCounters.Add("Reload", 1)
@@ -1194,7 +1324,49 @@ func (e *Executor) fetchOrCreatePlan(
 
 	query, comments := sqlparser.SplitMarginComments(queryString)
 	vcursor, _ = e.newVCursor(safeSession, comments, logStats)
+	plan, stmt, err = e.fetchOrCreateVCursorPlan(ctx, vcursor, query, comments, bindVars, parameterize, preparedPlan, logStats, isExecutePath)
+	if err != nil {
+		return nil, nil, stmt, err
+	}
+	return plan, vcursor, stmt, nil
+}
+
+// fetchOrCreatePrimaryPlan is like fetchOrCreatePlan, but plans the query for
+// the primary tablets, whatever the tablet type of the target of the session.
+func (e *Executor) fetchOrCreatePrimaryPlan(
+	ctx context.Context,
+	safeSession *econtext.SafeSession,
+	queryString string,
+	bindVars map[string]*querypb.BindVariable,
+	parameterize bool,
+	preparedPlan bool,
+	logStats *logstats.LogStats,
+) (
+	plan *engine.Plan, vcursor *econtext.VCursorImpl, stmt sqlparser.Statement, err error,
+) {
+	query, comments := sqlparser.SplitMarginComments(queryString)
+	vcursor, _ = e.newVCursor(safeSession, comments, logStats)
+	vcursor.RedirectToPrimary()
+	plan, stmt, err = e.fetchOrCreateVCursorPlan(ctx, vcursor, query, comments, bindVars, parameterize, preparedPlan, logStats, true)
+	if err != nil {
+		return nil, nil, stmt, err
+	}
+	return plan, vcursor, stmt, nil
+}
 
+func (e *Executor) fetchOrCreateVCursorPlan(
+	ctx context.Context,
+	vcursor *econtext.VCursorImpl,
+	query string,
+	comments sqlparser.MarginComments,
+	bindVars map[string]*querypb.BindVariable,
+	parameterize bool,
+	preparedPlan bool,
+	logStats *logstats.LogStats,
+	isExecutePath bool,
+) (
+	plan *engine.Plan, stmt sqlparser.Statement, err error,
+) {
 	var setVarComment string
 	if e.vConfig.SetVarEnabled {
 		setVarComment = vcursor.PrepareSetVarComment()
@@ -1214,7 +1386,7 @@ func (e *Executor) fetchOrCreatePlan(
 		}
 	}
 	if err != nil {
-		return nil, nil, stmt, err
+		return nil, stmt, err
 	}
 
 	if shouldOptimizePlan(preparedPlan, isExecutePath, plan) {
@@ -1236,7 +1408,7 @@ func (e *Executor) fetchOrCreatePlan(
 	logStats.SQL = comments.Leading + plan.Original + comments.Trailing
 	logStats.BindVariables = sqltypes.CopyBindVariables(bindVars)
 
-	return plan, vcursor, stmt, nil
+	return plan, stmt, nil
 }
 
 func (e *Executor) newVCursor(safeSession *econtext.SafeSession, comments sqlparser.MarginComments, logStats *logstats.LogStats) (*econtext.VCursorImpl, error) {
@@ -1283,6 +1455,7 @@ func shouldOptimizePlan(preparedPlan, isExecutePath bool, plan *engine.Plan) boo
 // applyQueryHints applies query hints to the vcursor
 func (e *Executor) applyQueryHints(vcursor *econtext.VCursorImpl, plan *engine.Plan) {
 	qh := plan.QueryHints
+	vcursor.SetKeyspaceQueryDefaults(planKeyspaces(vcursor, plan))
 	vcursor.SetIgnoreMaxMemoryRows(qh.IgnoreMaxMemoryRows)
 	vcursor.SetConsolidator(qh.Consolidator)
 	vcursor.SetWorkloadName(qh.Workload)
@@ -1290,6 +1463,24 @@ func (e *Executor) applyQueryHints(vcursor *econtext.VCursorImpl, plan *engine.P
 	vcursor.SetExecQueryTimeout(qh.Timeout)
 }
 
+// planKeyspaces returns the keyspaces of the tables accessed by the plan,
+// or the current keyspace if the plan does not access any table.
+func planKeyspaces(vcursor *econtext.VCursorImpl, plan *engine.Plan) []string {
+	if len(plan.TablesUsed) == 0 {
+		if keyspace := vcursor.GetKeyspace(); keyspace != "" {
+			return []string{keyspace}
+		}
+		return nil
+	}
+	keyspaces := make([]string, 0, len(plan.TablesUsed))
+	for _, table := range plan.TablesUsed {
+		keyspace, _, _ := strings.Cut(table, ".")
+		keyspaces = append(keyspaces, keyspace)
+	}
+	slices.Sort(keyspaces)
+	return slices.Compact(keyspaces)
+}
+
 func (e *Executor) getCachedOrBuildPlan(
 	ctx context.Context,
 	vcursor *econtext.VCursorImpl,
@@ -1441,6 +1632,7 @@ func (e *Executor) buildStatement(
 
 	plan.ParamsCount = paramsCount
 	plan.Warnings = vcursor.GetAndEmptyWarnings()
+	plan.Decisions = vcursor.GetAndEmptyPlannerDecisions()
 	plan.QueryHints = qh
 
 	err = e.checkThatPlanIsValid(stmt, plan)
@@ -1617,15 +1809,19 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 		DefaultTabletType: defaultTabletType,
 		PlannerVersion:    pv,
 
-		QueryTimeout:  queryTimeout,
-		MaxMemoryRows: maxMemoryRows,
+		RecordPlannerDecisions: e.config.RecordPlannerDecisions,
+
+		QueryTimeout:   queryTimeout,
+		MaxMemoryRows:  maxMemoryRows,
+		MaxMemoryBytes: maxMemoryBytes,
 
-		SetVarEnabled:         setVarEnabled,
-		DeniedSystemVariables: buildDeniedSystemVariables(deniedSystemVariables),
-		EnableViews:           enableViews,
-		ForeignKeyMode:        fkMode(foreignKeyMode),
-		EnableShardRouting:    enableShardRouting,
-		WarnShardedOnly:       warnOnShardedOnly,
+		SetVarEnabled:                setVarEnabled,
+		DeniedSystemVariables:        buildDeniedSystemVariables(deniedSystemVariables),
+		AllowedGlobalSystemVariables: buildSystemVariableSet("--allowed-global-system-variables", allowedGlobalSystemVariables),
+		EnableViews:                  enableViews,
+		ForeignKeyMode:               fkMode(foreignKeyMode),
+		EnableShardRouting:           enableShardRouting,
+		WarnShardedOnly:              warnOnShardedOnly,
 
 		DBDDLPlugin: dbDDLPlugin,
 
@@ -1633,6 +1829,10 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 		WarmingReadsPercent:       e.config.WarmingReadsPercent,
 		WarmingReadsTimeout:       warmingReadsQueryTimeout,
 		WarmingReadsSemaphore:     e.warmingReadsSemaphore,
+		InListChunkSize:           inListChunkSize,
+		InListChunkSemaphore:      e.inListChunkSemaphore,
+		MaxExecutionTimeHint:      maxExecutionTimeHint,
+		InsertBatcher:             e.insertBatcher,
 	}
 }
 
@@ -1640,10 +1840,17 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 // slice into the lowercased set form used by VCursorConfig. Returns nil for
 // an empty input so VCursorImpl.IsSystemVariableDenied can short-circuit.
 func buildDeniedSystemVariables(names []string) map[string]struct{} {
+	return buildSystemVariableSet("--denied-system-variables", names)
+}
+
+// buildSystemVariableSet normalizes a system variable list flag into the
+// lowercased set form used by VCursorConfig, warning about names that are not
+// known system variables. Returns nil for an empty input.
+func buildSystemVariableSet(flagName string, names []string) map[string]struct{} {
 	if len(names) == 0 {
 		return nil
 	}
-	denied := make(map[string]struct{}, len(names))
+	set := make(map[string]struct{}, len(names))
 	for _, n := range names {
 		n = strings.TrimSpace(n)
 		if n == "" {
@@ -1651,14 +1858,14 @@ func buildDeniedSystemVariables(names []string) map[string]struct{} {
 		}
 		n = strings.ToLower(n)
 		if _, ok := sysvars.AllSystemVariables[n]; !ok {
-			log.Warn("unknown system variable in --denied-system-variables", slog.String("name", n))
+			log.Warn("unknown system variable in "+flagName, slog.String("name", n))
 		}
-		denied[n] = struct{}{}
+		set[n] = struct{}{}
 	}
-	if len(denied) == 0 {
+	if len(set) == 0 {
 		return nil
 	}
-	return denied
+	return set
 }
 
 func newWarmingReadsSemaphore(concurrency int) *semaphore.Weighted {
@@ -1763,8 +1970,23 @@ func parseAndValidateQuery(query string, parser *sqlparser.Parser) (sqlparser.St
 }
 
 // ExecuteMultiShard implements the IExecutor interface
-func (e *Executor) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *econtext.SafeSession, autocommit bool, ignoreMaxMemoryRows bool, resultsObserver econtext.ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error) {
-	return e.scatterConn.ExecuteMultiShard(ctx, primitive, rss, queries, session, autocommit, ignoreMaxMemoryRows, resultsObserver, fetchLastInsertID)
+func (e *Executor) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *econtext.SafeSession, autocommit bool, maxMemoryRows int, resultsObserver econtext.ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error) {
+	return e.scatterConn.ExecuteMultiShard(ctx, primitive, rss, queries, session, autocommit, maxMemoryRows, resultsObserver, fetchLastInsertID)
+}
+
+// executeInsertBatch sends an insert batch to its shard in an autocommit
+// session of its own.
+func (e *Executor) executeInsertBatch(ctx context.Context, primitive engine.Primitive, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, batchSession *engine.InsertBatchSession) (*sqltypes.Result, error) {
+	safeSession := econtext.NewAutocommitSession(&vtgatepb.Session{Options: batchSession.Options})
+	query = &querypb.BoundQuery{
+		Sql:           batchSession.Comments.Leading + query.Sql + batchSession.Comments.Trailing,
+		BindVariables: query.BindVariables,
+	}
+	qr, errs := e.ExecuteMultiShard(ctx, primitive, []*srvtopo.ResolvedShard{rs}, []*querypb.BoundQuery{query}, safeSession, true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false /*fetchLastInsertID*/)
+	if errs != nil {
+		return nil, vterrors.Aggregate(errs)
+	}
+	return qr, nil
 }
 
 // StreamExecuteMulti implements the IExecutor interface
@@ -1907,6 +2129,9 @@ func (e *Executor) Close() {
 	}
 	topo.Close()
 	e.plans.Close()
+	if e.results != nil {
+		e.results.store.Close()
+	}
 }
 
 func (e *Executor) Environment() *vtenv.Environment {
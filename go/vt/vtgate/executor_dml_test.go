@@ -18,7 +18,9 @@ package vtgate
 
 import (
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1888,6 +1890,64 @@ func TestInsertAutoincSharded(t *testing.T) {
 	assert.EqualValues(t, 2, session.LastInsertId)
 }
 
+func TestInsertShardedBatched(t *testing.T) {
+	eConfig := createExecutorConfig()
+	eConfig.InsertBatchWindow = time.Hour
+	eConfig.InsertBatchMaxRows = 2
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	// The first insert into a table is sent on its own, to learn whether
+	// its rows can be batched.
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	_, err := executorExec(ctx, executor, session, "insert into user_extra(user_id) values (1)", nil)
+	require.NoError(t, err)
+	sbc1.Queries = nil
+
+	// The second insert fills the batch opened by the first one, and both
+	// are sent to the shard as one insert.
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+			result, err := executorExec(ctx, executor, session, "insert into user_extra(user_id) values (1)", nil)
+			assert.NoError(t, err)
+			assert.EqualValues(t, 1, result.RowsAffected)
+		})
+	}
+	wg.Wait()
+
+	wantQueries := []*querypb.BoundQuery{{
+		Sql: "insert into user_extra(user_id) values (:b0__user_id_0),(:b1__user_id_0)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"b0__user_id_0": sqltypes.Int64BindVariable(1),
+			"b1__user_id_0": sqltypes.Int64BindVariable(1),
+		},
+	}}
+	assertQueries(t, sbc1, wantQueries)
+
+	// Inserts in a transaction are never batched.
+	sbc1.Queries = nil
+	session = &vtgatepb.Session{TargetString: "@primary", Autocommit: true, InTransaction: true}
+	_, err = executorExec(ctx, executor, session, "insert into user_extra(user_id) values (1)", nil)
+	require.NoError(t, err)
+	wantQueries = []*querypb.BoundQuery{{
+		Sql: "insert into user_extra(user_id) values (:_user_id_0)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.Int64BindVariable(1),
+		},
+	}}
+	assertQueries(t, sbc1, wantQueries)
+
+	// After a vschema change, which may follow a schema change, the next
+	// insert into the table is sent on its own again.
+	sbc1.Queries = nil
+	executor.SaveVSchema(executor.VSchema(), executor.vschemaStats)
+	session = &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	_, err = executorExec(ctx, executor, session, "insert into user_extra(user_id) values (1)", nil)
+	require.NoError(t, err)
+	assertQueries(t, sbc1, wantQueries)
+}
+
 func TestInsertGeneratorUnsharded(t *testing.T) {
 	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
 	session := &vtgatepb.Session{
@@ -234,6 +234,39 @@ func TestSystemVariablesWithSetVarDisabled(t *testing.T) {
 	utils.MustMatch(t, wantQueries, sbc1.Queries)
 }
 
+func TestSelectMaxExecutionTimeHint(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+	executor.vConfig.MaxExecutionTimeHint = true
+	session := econtext.NewAutocommitSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	// Without a query timeout, the query is not hinted.
+	_, err := executorExecSession(t.Context(), executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	// The timeout of the comment directive, then the one of the session.
+	_, err = executorExecSession(t.Context(), executor, session, "select /*vt+ QUERY_TIMEOUT_MS=1000 */ id from user where id = 1", nil)
+	require.NoError(t, err)
+	session.QueryTimeout = 2000
+	_, err = executorExecSession(t.Context(), executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+
+	// The hints are the time left before the timeouts.
+	require.Len(t, sbc1.Queries, 3)
+	assert.Equal(t, "select id from `user` where id = 1", sbc1.Queries[0].Sql)
+	for i, tc := range []struct {
+		format  string
+		timeout int64
+	}{
+		{format: "select /*+ MAX_EXECUTION_TIME(%d) */ /*vt+ QUERY_TIMEOUT_MS=1000 */ id from `user` where id = 1", timeout: 1000},
+		{format: "select /*+ MAX_EXECUTION_TIME(%d) */ id from `user` where id = 1", timeout: 2000},
+	} {
+		var remaining int64
+		_, err = fmt.Sscanf(sbc1.Queries[i+1].Sql, tc.format, &remaining)
+		require.NoError(t, err, sbc1.Queries[i+1].Sql)
+		assert.LessOrEqual(t, remaining, tc.timeout)
+		assert.Positive(t, remaining)
+	}
+}
+
 func TestDeniedSystemVariablesWithSetVarHint(t *testing.T) {
 	executor, sbc1, _, _, _ := createExecutorEnv(t)
 	executor.vConfig.DeniedSystemVariables = map[string]struct{}{"unique_checks": {}}
@@ -1690,6 +1723,45 @@ func TestSelectScatter(t *testing.T) {
 	testQueryLog(t, executor, logChan, "TestExecute", "SELECT", sql, 8)
 }
 
+func TestSelectKeyspaceRowLimits(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	ks := executor.vschema.Keyspaces[KsTestSharded]
+
+	// Each of the 8 shards returns a row.
+	sql := "select id from `user`"
+	session := &vtgatepb.Session{TargetString: "@primary"}
+	ks.MaxRows = 7
+	_, err := executorExec(ctx, executor, session, sql, nil)
+	require.EqualError(t, err, "row count exceeded the max_rows limit of 7 set in the vschema")
+
+	ks.MaxRows = 8
+	qr, err := executorExec(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 8)
+
+	// sql_select_limit overrides max_rows.
+	ks.MaxRows = 1
+	session.Options = &querypb.ExecuteOptions{SqlSelectLimit: 100}
+	qr, err = executorExec(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 8)
+
+	ks.MaxRows = 0
+	ks.MaxMemoryRows = 7
+	_, err = executorExec(ctx, executor, session, sql, nil)
+	require.EqualError(t, err, "in-memory row count exceeded allowed limit of 7")
+
+	_, err = executorExec(ctx, executor, session, "select /*vt+ IGNORE_MAX_MEMORY_ROWS=1 */ id from `user`", nil)
+	require.NoError(t, err)
+
+	// A keyspace max_memory_rows above the flag value is honored too.
+	executor.vConfig.MaxMemoryRows = 7
+	ks.MaxMemoryRows = 8
+	qr, err = executorExec(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 8)
+}
+
 func TestSelectScatterPartial(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -3291,7 +3363,7 @@ func TestOnlyOptimizedPlan(t *testing.T) {
 func TestPrepareWithUnsupportedQuery(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 
-	sql := "select a, b, c, row_number() over (partition by x) from user where c1 = ? and c2 = ?"
+	sql := "select a, b, c, ntile(2) over (partition by x) from user where c1 = ? and c2 = ?"
 	session := econtext.NewAutocommitSession(&vtgatepb.Session{})
 	fields, paramsCount, err := executorPrepare(ctx, executor, session.Session, sql)
 	require.NoError(t, err)
@@ -3300,7 +3372,7 @@ func TestPrepareWithUnsupportedQuery(t *testing.T) {
 		{Name: "a", Type: querypb.Type_NULL_TYPE},
 		{Name: "b", Type: querypb.Type_NULL_TYPE},
 		{Name: "c", Type: querypb.Type_NULL_TYPE},
-		{Name: "row_number() over (partition by x)", Type: querypb.Type_NULL_TYPE},
+		{Name: "ntile(2) over (partition by x)", Type: querypb.Type_NULL_TYPE},
 	}
 	require.Equal(t, wantFields, fields)
 
@@ -4602,6 +4674,100 @@ func TestWarmingReads(t *testing.T) {
 	require.Nil(t, replica.GetQueries())
 }
 
+func TestReplicaLockingReads(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	testcases := []struct {
+		policy        string
+		inTransaction bool
+		wantErr       string
+		wantPrimary   bool
+	}{
+		{policy: ReplicaLockingReadsAllow},
+		{policy: ReplicaLockingReadsRedirect, wantPrimary: true},
+		// a transaction on a replica cannot span the primary
+		{policy: ReplicaLockingReadsRedirect, inTransaction: true, wantErr: "VT09033: locking read with a replica target"},
+		{policy: ReplicaLockingReadsReject, wantErr: "VT09033: locking read with a replica target"},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s/inTransaction=%v", tc.policy, tc.inTransaction), func(t *testing.T) {
+			executor, primary, replica := createExecutorEnvWithPrimaryReplicaConn(t, ctx, 0)
+			executor.config.ReplicaLockingReads = tc.policy
+			session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded + "@replica", Autocommit: true})
+			if tc.inTransaction {
+				_, err := executor.Execute(ctx, nil, "TestReplicaLockingReads", session, "begin", nil, false)
+				require.NoError(t, err)
+			}
+
+			// reads that don't lock are not affected by the policy
+			_, err := executor.Execute(ctx, nil, "TestReplicaLockingReads", session, "select id from user", nil, false)
+			require.NoError(t, err)
+			assert.Len(t, replica.GetQueries(), 1)
+			replica.ClearQueries()
+
+			for _, query := range []string{"select id from user for update", "select id from user for share", "select id from user lock in share mode"} {
+				_, err = executor.Execute(ctx, nil, "TestReplicaLockingReads", session, query, nil, false)
+				if tc.wantErr != "" {
+					require.ErrorContains(t, err, tc.wantErr)
+					continue
+				}
+				require.NoError(t, err)
+			}
+
+			var wantQueries []*querypb.BoundQuery
+			if tc.wantErr == "" {
+				wantQueries = []*querypb.BoundQuery{
+					{Sql: "select id from `user` for update"},
+					{Sql: "select id from `user` for share"},
+					{Sql: "select id from `user` lock in share mode"},
+				}
+			}
+			if tc.wantPrimary {
+				utils.MustMatch(t, wantQueries, primary.GetQueries())
+				assert.Empty(t, replica.GetQueries())
+			} else {
+				utils.MustMatch(t, wantQueries, replica.GetQueries())
+				assert.Empty(t, primary.GetQueries())
+			}
+			// the target of the session is unchanged
+			assert.Equal(t, KsTestUnsharded+"@replica", session.TargetString)
+		})
+	}
+}
+
+func TestReplicaLockingReadsAutocommitOff(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	for _, policy := range []string{ReplicaLockingReadsRedirect, ReplicaLockingReadsReject} {
+		t.Run(policy, func(t *testing.T) {
+			executor, primary, replica := createExecutorEnvWithPrimaryReplicaConn(t, ctx, 0)
+			executor.config.ReplicaLockingReads = policy
+			session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded + "@replica"})
+
+			// the locking read is not in the implicit transaction it would start
+			_, err := executor.Execute(ctx, nil, "TestReplicaLockingReadsAutocommitOff", session, "select id from user for update", nil, false)
+			if policy == ReplicaLockingReadsReject {
+				require.ErrorContains(t, err, "VT09033: locking read with a replica target")
+				assert.Empty(t, primary.GetQueries())
+				assert.Empty(t, replica.GetQueries())
+				assert.False(t, session.InTransaction())
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, []*querypb.BoundQuery{{Sql: "select id from `user` for update"}}, primary.GetQueries())
+			assert.Empty(t, replica.GetQueries())
+			assert.False(t, session.InTransaction())
+
+			// a read starts an implicit transaction on the replicas, which cannot span the primary
+			_, err = executor.Execute(ctx, nil, "TestReplicaLockingReadsAutocommitOff", session, "select id from user", nil, false)
+			require.NoError(t, err)
+			assert.True(t, session.InTransaction())
+			_, err = executor.Execute(ctx, nil, "TestReplicaLockingReadsAutocommitOff", session, "select id from user for update", nil, false)
+			require.ErrorContains(t, err, "VT09033: locking read with a replica target")
+		})
+	}
+}
+
 // waitUntilQueryCount waits until the number of queries run on the tablet reach the specified count.
 func waitUntilQueryCount(t *testing.T, tab *sandboxconn.SandboxConn, count int) {
 	t.Helper()
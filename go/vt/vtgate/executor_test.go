@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"sort"
@@ -57,12 +58,15 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 )
 
 type fakeResolver struct {
@@ -167,9 +171,7 @@ func TestExecutorResultsExceeded(t *testing.T) {
 func TestExecutorMaxMemoryRowsExceeded(t *testing.T) {
 	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
 
-	save := maxMemoryRows
-	maxMemoryRows = 3
-	defer func() { maxMemoryRows = save }()
+	executor.vConfig.MaxMemoryRows = 3
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
 	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "int64"), "1", "2", "3", "4")
@@ -1037,6 +1039,7 @@ func TestExecutorShow(t *testing.T) {
 	session.Warnings = []*querypb.QueryWarning{
 		{Code: uint32(sqlerror.ERBadTable), Message: "bad table"},
 		{Code: uint32(sqlerror.EROutOfResources), Message: "ks/-40: query timed out"},
+		{Level: "Note", Code: uint32(sqlerror.ERBadTable), Message: "Unknown table 'ks.t'"},
 	}
 	qr, err = executorExecSession(ctx, executor, session, query, nil)
 	require.NoError(t, err)
@@ -1050,6 +1053,7 @@ func TestExecutorShow(t *testing.T) {
 		Rows: [][]sqltypes.Value{
 			{sqltypes.NewVarChar("Warning"), sqltypes.NewUint32(uint32(sqlerror.ERBadTable)), sqltypes.NewVarChar("bad table")},
 			{sqltypes.NewVarChar("Warning"), sqltypes.NewUint32(uint32(sqlerror.EROutOfResources)), sqltypes.NewVarChar("ks/-40: query timed out")},
+			{sqltypes.NewVarChar("Note"), sqltypes.NewUint32(uint32(sqlerror.ERBadTable)), sqltypes.NewVarChar("Unknown table 'ks.t'")},
 		},
 	}
 	utils.MustMatch(t, wantqr, qr, query)
@@ -3228,10 +3232,43 @@ func TestExecutorKillStmt(t *testing.T) {
 	}
 }
 
+// TestExecutorLoadDataLocal checks that the rows of the file of a LOAD DATA
+// LOCAL INFILE are inserted in the shards they belong to.
+func TestExecutorLoadDataLocal(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	query := "load data local infile 'user_extra.tsv' into table user_extra (user_id, extra)"
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	mysqlCtx := &fakeMysqlConnection{localFiles: map[string]string{"user_extra.tsv": "1\tfoo\n3\t\\N\n"}}
+	result, err := executor.Execute(ctx, mysqlCtx, "TestExecutorLoadDataLocal", econtext.NewSafeSession(session), query, nil, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.RowsAffected)
+	assert.Equal(t, "local infile: user_extra.tsv", mysqlCtx.Log[0])
+	assertQueries(t, sbc1, []*querypb.BoundQuery{{
+		Sql: "insert ignore into user_extra(user_id, extra) values (:_user_id_0, :ld1)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.StringBindVariable("1"),
+			"ld1":        sqltypes.StringBindVariable("foo"),
+		},
+	}})
+	assertQueries(t, sbc2, []*querypb.BoundQuery{{
+		Sql: "insert ignore into user_extra(user_id, extra) values (:_user_id_1, :ld3)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_1": sqltypes.StringBindVariable("3"),
+			"ld3":        sqltypes.NullBindVariable,
+		},
+	}})
+
+	// The file can only be sent over the MySQL protocol.
+	_, err = executor.Execute(ctx, nil, "TestExecutorLoadDataLocal", econtext.NewSafeSession(session), query, nil, false)
+	assert.ErrorContains(t, err, "VT12001: unsupported: LOAD DATA LOCAL INFILE outside of a MySQL protocol connection")
+}
+
 type fakeMysqlConnection struct {
 	ErrMsg       string
 	Log          []string
 	ingressBytes uint64
+	localFiles   map[string]string
 }
 
 func (f *fakeMysqlConnection) KillQuery(connID uint32) error {
@@ -3258,6 +3295,15 @@ func (f *fakeMysqlConnection) IngressBytes() uint64 {
 	return f.ingressBytes
 }
 
+func (f *fakeMysqlConnection) LocalInfile(filename string) (io.ReadCloser, error) {
+	f.Log = append(f.Log, "local infile: "+filename)
+	content, ok := f.localFiles[filename]
+	if !ok {
+		return nil, fmt.Errorf("no file %s", filename)
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
 var _ vtgateservice.MySQLConnection = (*fakeMysqlConnection)(nil)
 
 // TestFinalizeLogStatsAttributesIngressBytes verifies that MySQL connection
@@ -3683,3 +3729,54 @@ func TestExecutorShowShards(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutorMessageAckBatch(t *testing.T) {
+	executor, sbc1, sbc2, sbclookup, ctx := createExecutorEnv(t)
+	hc := executor.scatterConn.gateway.hc.(*discovery.FakeHealthCheck)
+	for _, sbc := range []*sandboxconn.SandboxConn{sbc1, sbc2, sbclookup} {
+		hc.SetCapabilities(sbc.Tablet(), queryservice.TabletCapabilities())
+	}
+
+	ids := []*querypb.Value{
+		sqltypes.ValueToProto(sqltypes.NewInt64(1)),
+		sqltypes.ValueToProto(sqltypes.NewInt64(3)),
+		sqltypes.ValueToProto(sqltypes.NewInt64(1)),
+	}
+
+	// In a sharded keyspace, the ids are routed with the primary vindex.
+	count, err := executor.MessageAckBatch(ctx, KsTestSharded, "sharded_user_msgs", ids)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	utils.MustMatch(t, []*querypb.Value{ids[0], ids[2]}, sbc1.MessageIDs)
+	utils.MustMatch(t, []*querypb.Value{ids[1]}, sbc2.MessageIDs)
+
+	sbc1.MessageIDs = nil
+	sbc2.MessageIDs = nil
+	count, err = executor.MessagePostpone(ctx, KsTestSharded, "sharded_user_msgs", ids[1:2])
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Nil(t, sbc1.MessageIDs)
+	utils.MustMatch(t, ids[1:2], sbc2.MessageIDs)
+
+	// In an unsharded keyspace, all the ids go to the only shard.
+	count, err = executor.MessageAckBatch(ctx, KsTestUnsharded, "msg", ids)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	utils.MustMatch(t, ids, sbclookup.MessageIDs)
+
+	_, err = executor.MessageAckBatch(ctx, KsTestSharded, "nonexistent", ids)
+	require.ErrorContains(t, err, "table nonexistent not found")
+
+	// Tablets that predate the batch RPCs get a MessageAck, but can't
+	// postpone messages.
+	hc.SetCapabilities(sbc2.Tablet(), nil)
+	sbc2.MessageIDs = nil
+	count, err = executor.MessageAckBatch(ctx, KsTestSharded, "sharded_user_msgs", ids[1:2])
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	utils.MustMatch(t, ids[1:2], sbc2.MessageIDs)
+
+	_, err = executor.MessagePostpone(ctx, KsTestSharded, "sharded_user_msgs", ids[1:2])
+	require.ErrorContains(t, err, "the tablets of TestExecutor/40-60 do not all support MessagePostpone yet")
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+}
@@ -19,6 +19,7 @@ package executorcontext
 import (
 	"context"
 	"fmt"
+	"io"
 	"maps"
 	"sort"
 	"strconv"
@@ -30,6 +31,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/sync/semaphore"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -55,6 +57,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
@@ -64,9 +67,11 @@ import (
 )
 
 var (
-	_ engine.VCursor      = (*VCursorImpl)(nil)
-	_ plancontext.VSchema = (*VCursorImpl)(nil)
-	_ vindexes.VCursor    = (*VCursorImpl)(nil)
+	_ engine.VCursor             = (*VCursorImpl)(nil)
+	_ plancontext.VSchema        = (*VCursorImpl)(nil)
+	_ vindexes.VCursor           = (*VCursorImpl)(nil)
+	_ evalengine.SystemVariables = (*VCursorImpl)(nil)
+	_ evalengine.UserVariables   = (*VCursorImpl)(nil)
 )
 
 var ErrNoKeyspace = vterrors.VT09005()
@@ -90,6 +95,16 @@ type (
 		WarnShardedOnly    bool
 		PlannerVersion     plancontext.PlannerVersion
 
+		// RecordPlannerDecisions makes the planner record its cost-based
+		// decisions in the plans it builds. It is meant for tools like
+		// vtexplain, as it makes planning slower.
+		RecordPlannerDecisions bool
+
+		// MaxMemoryBytes is the maximum number of bytes of rows that the
+		// sorts, aggregations and joins of a query can hold in memory, or 0
+		// if unlimited.
+		MaxMemoryBytes int64
+
 		PreventCrossKeyspaceReads bool
 
 		// DeniedSystemVariables is the set of system variable names (lowercased)
@@ -98,15 +113,39 @@ type (
 		// denylist entirely, preserving the historical behavior.
 		DeniedSystemVariables map[string]struct{}
 
+		// AllowedGlobalSystemVariables is the set of system variable names
+		// (lowercased) that clients may change with SET GLOBAL through this
+		// VTGate. The statement is then executed on the targeted tablets instead
+		// of being checked and ignored. A nil or empty map keeps the historical
+		// behavior for all variables.
+		AllowedGlobalSystemVariables map[string]struct{}
+
 		WarmingReadsPercent   int
 		WarmingReadsTimeout   time.Duration
 		WarmingReadsSemaphore *semaphore.Weighted
+
+		// InListChunkSize is the maximum number of values of an IN list that a
+		// route sends to a shard in one query. 0 disables the chunking.
+		InListChunkSize int
+
+		// InListChunkSemaphore limits the number of streams that the sorted
+		// routes executed in chunks open at the same time.
+		InListChunkSemaphore *semaphore.Weighted
+
+		// MaxExecutionTimeHint makes the routes hint their SELECT statements
+		// with MAX_EXECUTION_TIME, set to the time left before the query
+		// times out.
+		MaxExecutionTimeHint bool
+
+		// InsertBatcher batches single-row autocommit inserts. It is nil if
+		// insert batching is disabled.
+		InsertBatcher *engine.InsertBatcher
 	}
 
 	// vcursor_impl needs these facilities to be able to be able to execute queries for vindexes
 	iExecute interface {
 		Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, method string, session *SafeSession, s string, vars map[string]*querypb.BindVariable, prepared bool) (*sqltypes.Result, error)
-		ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, autocommit bool, ignoreMaxMemoryRows bool, resultsObserver ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error)
+		ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, autocommit bool, maxMemoryRows int, resultsObserver ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error)
 		StreamExecuteMulti(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, vars []map[string]*querypb.BindVariable, session *SafeSession, autocommit bool, callback func(reply *sqltypes.Result) error, observer ResultsObserver, fetchLastInsertID bool) []error
 		ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *SafeSession, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error)
 		Commit(ctx context.Context, safeSession *SafeSession) error
@@ -118,6 +157,7 @@ type (
 		ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error)
 		ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error)
 		SetVitessMetadata(ctx context.Context, name, value string) error
 
 		// TODO: remove when resolver is gone
@@ -179,22 +219,38 @@ type (
 		// A nil value represents that no foreign_key_checks value was provided.
 		fkChecksState       *bool
 		ignoreMaxMemoryRows bool
-		vschema             *vindexes.VSchema
-		vm                  VSchemaOperator
-		semTable            *semantics.SemTable
-		queryTimeout        time.Duration
-		transactionTimeout  time.Duration
+		// memoryBytes is the number of bytes of rows that the primitives of
+		// the query hold in memory.
+		memoryBytes atomic.Int64
+		// keyspaceDefaults are the query defaults set in the vschema
+		// of the keyspaces accessed by the query.
+		keyspaceDefaults   KeyspaceQueryDefaults
+		vschema            *vindexes.VSchema
+		vm                 VSchemaOperator
+		semTable           *semantics.SemTable
+		queryTimeout       time.Duration
+		transactionTimeout time.Duration
 
 		warnings []*querypb.QueryWarning // any warnings that are accumulated during the planning phase are stored here
+		// decisions are the cost-based decisions of the planner, if recorded
+		decisions []engine.PlannerDecision
 
 		observer ResultsObserver
 
-		// this protects the interOpStats and shardsStats fields from concurrent writes
+		// contentProvider asks the client for the files of LOAD DATA LOCAL
+		// INFILE statements. It is nil unless the query comes from a MySQL
+		// protocol connection.
+		contentProvider mysql.ContentProvider
+
+		// this protects the interOpStats, shardsStats and staleKeyspaces fields from concurrent writes
 		mu sync.Mutex
 		// this is a map of the number of rows that every primitive has returned
 		// if this field is nil, it means that we are not logging operator traffic
 		interOpStats map[engine.Primitive]engine.RowsReceived
 		shardsStats  map[engine.Primitive]engine.ShardsQueried
+		// staleKeyspaces are the keyspaces that were routed using cached
+		// topology information, and that a warning was already recorded for
+		staleKeyspaces map[string]bool
 
 		// For specializing plans for the current query
 		bindVars map[string]*querypb.BindVariable
@@ -301,6 +357,7 @@ func (vc *VCursorImpl) CloneForMirroring(ctx context.Context) engine.VCursor {
 		metrics:        vc.metrics,
 
 		ignoreMaxMemoryRows: vc.ignoreMaxMemoryRows,
+		keyspaceDefaults:    vc.keyspaceDefaults,
 		vschema:             vc.vschema,
 		vm:                  vc.vm,
 		semTable:            vc.semTable,
@@ -328,6 +385,7 @@ func (vc *VCursorImpl) CloneForReplicaWarming(ctx context.Context) engine.VCurso
 		metrics:        vc.metrics,
 
 		ignoreMaxMemoryRows: vc.ignoreMaxMemoryRows,
+		keyspaceDefaults:    vc.keyspaceDefaults,
 		vschema:             vc.vschema,
 		vm:                  vc.vm,
 		semTable:            vc.semTable,
@@ -423,21 +481,103 @@ func (vc *VCursorImpl) TimeZone() *time.Location {
 	return vc.SafeSession.TimeZone()
 }
 
+// GetSystemVariable implements evalengine.SystemVariables.
+func (vc *VCursorImpl) GetSystemVariable(name string) (string, bool) {
+	vc.SafeSession.mu.Lock()
+	expr, ok := vc.SafeSession.SystemVariables[name]
+	vc.SafeSession.mu.Unlock()
+	if !ok {
+		return "", false
+	}
+	value, err := sqltypes.DecodeStringSQL(expr)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
 func (vc *VCursorImpl) SQLMode() string {
 	// TODO: Implement return the current sql_mode.
 	// This is currently hardcoded to the default in MySQL 8.0.
 	return config.DefaultSQLMode
 }
 
-// MaxMemoryRows returns the maxMemoryRows flag value.
+// MaxMemoryRows returns the max_memory_rows of the keyspaces accessed by
+// the query if set, or the maxMemoryRows flag value.
 func (vc *VCursorImpl) MaxMemoryRows() int {
+	if vc.keyspaceDefaults.MaxMemoryRows > 0 {
+		return vc.keyspaceDefaults.MaxMemoryRows
+	}
 	return vc.config.MaxMemoryRows
 }
 
 // ExceedsMaxMemoryRows returns a boolean indicating whether the maxMemoryRows value has been exceeded.
 // Returns false if the max memory rows override directive is set to true.
 func (vc *VCursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
-	return !vc.ignoreMaxMemoryRows && numRows > vc.config.MaxMemoryRows
+	return !vc.ignoreMaxMemoryRows && numRows > vc.MaxMemoryRows()
+}
+
+// TrackMemory is part of the engine.VCursor interface.
+func (vc *VCursorImpl) TrackMemory(bytes int64) error {
+	used := vc.memoryBytes.Add(bytes)
+	if limit := vc.config.MaxMemoryBytes; limit > 0 && bytes > 0 && used > limit {
+		return vterrors.VT08001(limit)
+	}
+	return nil
+}
+
+// KeyspaceQueryDefaults are the query defaults set in the vschema of
+// keyspaces. Zero values are unset.
+type KeyspaceQueryDefaults struct {
+	QueryTimeout  int
+	MaxRows       int
+	MaxMemoryRows int
+}
+
+// SetKeyspaceQueryDefaults sets the query defaults of the query from the
+// vschema of the keyspaces it accesses. When several keyspaces set a
+// default, the strictest one applies.
+func (vc *VCursorImpl) SetKeyspaceQueryDefaults(keyspaces []string) {
+	stricter := func(current, value int) int {
+		if value <= 0 || (current > 0 && current <= value) {
+			return current
+		}
+		return value
+	}
+	var defaults KeyspaceQueryDefaults
+	for _, keyspace := range keyspaces {
+		ks := vc.vschema.Keyspaces[keyspace]
+		if ks == nil {
+			continue
+		}
+		defaults.QueryTimeout = stricter(defaults.QueryTimeout, ks.QueryTimeout)
+		defaults.MaxRows = stricter(defaults.MaxRows, ks.MaxRows)
+		defaults.MaxMemoryRows = stricter(defaults.MaxMemoryRows, ks.MaxMemoryRows)
+	}
+	vc.keyspaceDefaults = defaults
+}
+
+// MaxRows returns the maximum number of rows a SELECT can return, or 0 if
+// unlimited. The limit comes from the vschema of the keyspaces accessed by
+// the query, and does not apply if the session sets sql_select_limit.
+func (vc *VCursorImpl) MaxRows() int {
+	if vc.SafeSession.GetSelectLimit() > 0 {
+		return 0
+	}
+	return vc.keyspaceDefaults.MaxRows
+}
+
+// UnlimitedMemoryRows is the memory rows limit of the queries that ignore
+// the limit. A limit of 0 allows no rows.
+const UnlimitedMemoryRows = -1
+
+// MemoryRowsLimit returns the number of rows the executor may hold in memory
+// for the query, or UnlimitedMemoryRows if the limit is ignored.
+func (vc *VCursorImpl) MemoryRowsLimit() int {
+	if vc.ignoreMaxMemoryRows {
+		return UnlimitedMemoryRows
+	}
+	return vc.MaxMemoryRows()
 }
 
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
@@ -687,6 +827,15 @@ func (vc *VCursorImpl) HasDeniedSystemVariables() bool {
 	return len(vc.config.DeniedSystemVariables) > 0
 }
 
+// IsGlobalSystemVariableAllowed implements the plancontext.VSchema interface.
+func (vc *VCursorImpl) IsGlobalSystemVariableAllowed(name string) bool {
+	if len(vc.config.AllowedGlobalSystemVariables) == 0 {
+		return false
+	}
+	_, allowed := vc.config.AllowedGlobalSystemVariables[strings.ToLower(name)]
+	return allowed
+}
+
 // KeyspaceExists provides whether the keyspace exists or not.
 func (vc *VCursorImpl) KeyspaceExists(ks string) bool {
 	return vc.vschema.Keyspaces[ks] != nil
@@ -894,7 +1043,7 @@ func (vc *VCursorImpl) ExecuteMultiShard(ctx context.Context, primitive engine.P
 		return nil, []error{err}
 	}
 
-	qr, errs := vc.executor.ExecuteMultiShard(ctx, primitive, rss, commentedShardQueries(queries, vc.marginComments), vc.SafeSession, canAutocommit, vc.ignoreMaxMemoryRows, vc.observer, fetchLastInsertID)
+	qr, errs := vc.executor.ExecuteMultiShard(ctx, primitive, rss, commentedShardQueries(queries, vc.marginComments), vc.SafeSession, canAutocommit, vc.MemoryRowsLimit(), vc.observer, fetchLastInsertID)
 	vc.setRollbackOnPartialExecIfRequired(len(errs) != len(rss), rollbackOnError)
 	vc.logShardsQueried(primitive, len(rss))
 	if qr != nil && qr.InsertIDUpdated() {
@@ -937,7 +1086,7 @@ func (vc *VCursorImpl) ExecuteStandalone(ctx context.Context, primitive engine.P
 	}
 	// The autocommit flag is always set to false because we currently don't
 	// execute DMLs through ExecuteStandalone.
-	qr, errs := vc.executor.ExecuteMultiShard(ctx, primitive, rss, bqs, NewAutocommitSession(vc.SafeSession.Session), false /* autocommit */, vc.ignoreMaxMemoryRows, vc.observer, fetchLastInsertID)
+	qr, errs := vc.executor.ExecuteMultiShard(ctx, primitive, rss, bqs, NewAutocommitSession(vc.SafeSession.Session), false /* autocommit */, vc.MemoryRowsLimit(), vc.observer, fetchLastInsertID)
 	vc.logShardsQueried(primitive, len(rss))
 	if qr.InsertIDUpdated() {
 		vc.SafeSession.LastInsertId = qr.InsertID
@@ -1006,6 +1155,33 @@ func (vc *VCursorImpl) setRollbackOnPartialExecIfRequired(atleastOneSuccess bool
 	}
 }
 
+// recordStaleRouting records a warning, once per keyspace, when the shards
+// were resolved using cached topology information because the topo server
+// was unavailable.
+func (vc *VCursorImpl) recordStaleRouting(rss []*srvtopo.ResolvedShard) {
+	for _, rs := range rss {
+		if rs.Staleness <= 0 {
+			continue
+		}
+		vc.mu.Lock()
+		recorded := vc.staleKeyspaces[rs.Target.Keyspace]
+		if !recorded {
+			if vc.staleKeyspaces == nil {
+				vc.staleKeyspaces = make(map[string]bool)
+			}
+			vc.staleKeyspaces[rs.Target.Keyspace] = true
+		}
+		vc.mu.Unlock()
+		if recorded {
+			continue
+		}
+		vc.RecordWarning(&querypb.QueryWarning{
+			Code:    uint32(sqlerror.ERUnknownError),
+			Message: fmt.Sprintf("keyspace %s was routed using topology information cached %v ago because the topo server is unavailable", rs.Target.Keyspace, rs.Staleness.Round(time.Millisecond)),
+		})
+	}
+}
+
 // fixupPartiallyMovedShards checks if any of the shards in the route has a ShardRoutingRule (true when a keyspace
 // is in the middle of being moved to another keyspace using MoveTables moving a subset of shards at a time
 func (vc *VCursorImpl) fixupPartiallyMovedShards(rss []*srvtopo.ResolvedShard) ([]*srvtopo.ResolvedShard, error) {
@@ -1030,6 +1206,7 @@ func (vc *VCursorImpl) ResolveDestinations(ctx context.Context, keyspace string,
 	if err != nil {
 		return nil, nil, err
 	}
+	vc.recordStaleRouting(rss)
 	if vc.config.EnableShardRouting {
 		rss, err = vc.fixupPartiallyMovedShards(rss)
 		if err != nil {
@@ -1044,6 +1221,7 @@ func (vc *VCursorImpl) ResolveDestinationsMultiCol(ctx context.Context, keyspace
 	if err != nil {
 		return nil, nil, err
 	}
+	vc.recordStaleRouting(rss)
 	if vc.config.EnableShardRouting {
 		rss, err = vc.fixupPartiallyMovedShards(rss)
 		if err != nil {
@@ -1149,6 +1327,13 @@ func (vc *VCursorImpl) TabletType() topodatapb.TabletType {
 	return vc.tabletType
 }
 
+// RedirectToPrimary makes the queries of the vcursor run on the primary
+// tablets, whatever the tablet type of the target of the session. It must be
+// called before the query is planned, as the plan depends on the tablet type.
+func (vc *VCursorImpl) RedirectToPrimary() {
+	vc.tabletType = topodatapb.TabletType_PRIMARY
+}
+
 func commentedShardQueries(shardQueries []*querypb.BoundQuery, marginComments sqlparser.MarginComments) []*querypb.BoundQuery {
 	if marginComments.Leading == "" && marginComments.Trailing == "" {
 		return shardQueries
@@ -1277,12 +1462,15 @@ func (vc *VCursorImpl) SetExecQueryTimeout(timeout *int) {
 }
 
 // getQueryTimeout returns timeout based on the priority
-// session setting > global default specified by a flag.
+// session setting > keyspace default set in the vschema > global default specified by a flag.
 func (vc *VCursorImpl) getQueryTimeout() int {
 	sessionQueryTimeout := int(vc.SafeSession.GetQueryTimeout())
 	if sessionQueryTimeout != 0 {
 		return sessionQueryTimeout
 	}
+	if vc.keyspaceDefaults.QueryTimeout > 0 {
+		return vc.keyspaceDefaults.QueryTimeout
+	}
 	return vc.config.QueryTimeout
 }
 
@@ -1458,6 +1646,24 @@ func (vc *VCursorImpl) PlannerWarning(message string) {
 	})
 }
 
+// PlannerDecisionsEnabled implements the VSchema interface
+func (vc *VCursorImpl) PlannerDecisionsEnabled() bool {
+	return vc.config.RecordPlannerDecisions
+}
+
+// PlannerDecision implements the VSchema interface
+func (vc *VCursorImpl) PlannerDecision(decision engine.PlannerDecision) {
+	vc.decisions = append(vc.decisions, decision)
+}
+
+// GetAndEmptyPlannerDecisions returns the planner decisions recorded since
+// the last call, and empties them.
+func (vc *VCursorImpl) GetAndEmptyPlannerDecisions() []engine.PlannerDecision {
+	d := vc.decisions
+	vc.decisions = nil
+	return d
+}
+
 // ForeignKeyMode implements the VCursor interface
 func (vc *VCursorImpl) ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error) {
 	if vc.config.ForeignKeyMode == vschemapb.Keyspace_disallow {
@@ -1498,6 +1704,10 @@ func (vc *VCursorImpl) GetAggregateUDFs() []string {
 	return vc.vschema.GetAggregateUDFs()
 }
 
+func (vc *VCursorImpl) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	return vc.vschema.GetUDFReturnTypes(keyspace)
+}
+
 // FindMirrorRule finds the mirror rule for the requested table name and
 // VSchema tablet type.
 func (vc *VCursorImpl) FindMirrorRule(name sqlparser.TableName) (*vindexes.MirrorRule, error) {
@@ -1580,6 +1790,24 @@ func (vc *VCursorImpl) ShowExec(ctx context.Context, command sqlparser.ShowComma
 	}
 }
 
+func (vc *VCursorImpl) ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error) {
+	return vc.executor.ShowProcessList(ctx, full)
+}
+
+// SetContentProvider sets where the files of LOAD DATA LOCAL INFILE
+// statements are read from.
+func (vc *VCursorImpl) SetContentProvider(provider mysql.ContentProvider) {
+	vc.contentProvider = provider
+}
+
+// LocalInfile implements the VCursor interface.
+func (vc *VCursorImpl) LocalInfile(filename string) (io.ReadCloser, error) {
+	if vc.contentProvider == nil {
+		return nil, vterrors.VT12001("LOAD DATA LOCAL INFILE outside of a MySQL protocol connection")
+	}
+	return vc.contentProvider.LocalInfile(filename)
+}
+
 func (vc *VCursorImpl) GetVSchema() *vindexes.VSchema {
 	return vc.vschema
 }
@@ -1676,6 +1904,11 @@ func (vc *VCursorImpl) GetUDV(name string) *querypb.BindVariable {
 	return vc.SafeSession.GetUDV(name)
 }
 
+// SetUserDefinedVariable implements evalengine.UserVariables.
+func (vc *VCursorImpl) SetUserDefinedVariable(name string, value *querypb.BindVariable) {
+	vc.SafeSession.SetUserDefinedVariable(name, value)
+}
+
 func (vc *VCursorImpl) PlanPrepareStatement(ctx context.Context, query string) (*engine.Plan, error) {
 	return vc.executor.PlanPrepareStmt(ctx, vc.SafeSession, query)
 }
@@ -1700,6 +1933,55 @@ func (vc *VCursorImpl) GetWarmingReadsSemaphore() *semaphore.Weighted {
 	return vc.config.WarmingReadsSemaphore
 }
 
+// InListChunkSize is part of the engine.VCursor interface.
+func (vc *VCursorImpl) InListChunkSize() int {
+	return vc.config.InListChunkSize
+}
+
+// GetInListChunkSemaphore is part of the engine.VCursor interface.
+func (vc *VCursorImpl) GetInListChunkSemaphore() *semaphore.Weighted {
+	return vc.config.InListChunkSemaphore
+}
+
+// MaxExecutionTimeHint is part of the engine.VCursor interface.
+func (vc *VCursorImpl) MaxExecutionTimeHint() bool {
+	return vc.config.MaxExecutionTimeHint
+}
+
+// GetInsertBatcher is part of the engine.VCursor interface.
+func (vc *VCursorImpl) GetInsertBatcher(ctx context.Context) (*engine.InsertBatcher, *engine.InsertBatchSession) {
+	if vc.config.InsertBatcher == nil {
+		return nil, nil
+	}
+	// A batch is sent in an autocommit session of its own, so only the
+	// inserts of sessions without state on the tablets can be batched. The
+	// insert itself has the autocommit approval, so no transaction is open.
+	session := vc.SafeSession
+	if session.InReservedConn() || session.HasSystemVariables() || session.InLockSession() || session.GetLogger() != nil {
+		return nil, nil
+	}
+
+	// Inserts are only batched with those of the same callers and with the
+	// same execute options, for the tablets to check and run them alike.
+	var options []byte
+	batchSession := &engine.InsertBatchSession{Comments: vc.marginComments}
+	if session.Options != nil {
+		batchSession.Options = session.Options.CloneVT()
+		var err error
+		if options, err = batchSession.Options.MarshalVT(); err != nil {
+			return nil, nil
+		}
+	}
+	batchSession.Key = strings.Join([]string{
+		callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)),
+		callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)),
+		vc.marginComments.Leading,
+		vc.marginComments.Trailing,
+		string(options),
+	}, "\x00")
+	return vc.config.InsertBatcher, batchSession
+}
+
 func (vc *VCursorImpl) GetQueryPriority() (int, error) {
 	if vc.SafeSession.Options != nil && vc.SafeSession.Options.Priority != "" {
 		priority, err := strconv.Atoi(vc.SafeSession.Options.Priority)
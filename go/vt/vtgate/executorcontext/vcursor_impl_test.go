@@ -32,6 +32,7 @@ import (
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 
@@ -45,6 +46,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 var _ VSchemaOperator = (*fakeVSchemaOperator)(nil)
@@ -309,6 +311,68 @@ func TestSetExecQueryTimeout(t *testing.T) {
 	require.Nil(t, safeSession.Options.Timeout)
 }
 
+func TestSetKeyspaceQueryDefaults(t *testing.T) {
+	vschema := &vindexes.VSchema{
+		Keyspaces: map[string]*vindexes.KeyspaceSchema{
+			"ks1": {Keyspace: &vindexes.Keyspace{Name: "ks1"}, QueryTimeout: 100, MaxRows: 10},
+			"ks2": {Keyspace: &vindexes.Keyspace{Name: "ks2"}, QueryTimeout: 50, MaxMemoryRows: 5},
+			"ks3": {Keyspace: &vindexes.Keyspace{Name: "ks3"}},
+		},
+	}
+	safeSession := NewSafeSession(nil)
+	vc, err := NewVCursorImpl(safeSession, sqlparser.MarginComments{}, nil, nil, nil, vschema, nil, nil, fakeObserver{}, VCursorConfig{
+		QueryTimeout:  20,
+		MaxMemoryRows: 300000,
+	}, nil)
+	require.NoError(t, err)
+
+	// keyspace without defaults
+	vc.SetKeyspaceQueryDefaults([]string{"ks3"})
+	vc.SetExecQueryTimeout(nil)
+	require.Equal(t, 20*time.Millisecond, vc.queryTimeout)
+	require.Zero(t, vc.MaxRows())
+	require.Equal(t, 300000, vc.MaxMemoryRows())
+
+	// the strictest keyspace default applies
+	vc.SetKeyspaceQueryDefaults([]string{"ks1", "ks2", "ks3"})
+	vc.SetExecQueryTimeout(nil)
+	require.Equal(t, 50*time.Millisecond, vc.queryTimeout)
+	require.Equal(t, 10, vc.MaxRows())
+	require.Equal(t, 5, vc.MaxMemoryRows())
+	require.True(t, vc.ExceedsMaxMemoryRows(6))
+
+	vc.SetIgnoreMaxMemoryRows(true)
+	require.False(t, vc.ExceedsMaxMemoryRows(6))
+
+	// session settings override the keyspace defaults
+	safeSession.SetQueryTimeout(40)
+	safeSession.GetOrCreateOptions().SqlSelectLimit = 100
+	vc.SetExecQueryTimeout(nil)
+	require.Equal(t, 40*time.Millisecond, vc.queryTimeout)
+	require.Zero(t, vc.MaxRows())
+}
+
+func TestTrackMemory(t *testing.T) {
+	vc, err := NewVCursorImpl(NewSafeSession(nil), sqlparser.MarginComments{}, nil, nil, nil, &vindexes.VSchema{}, nil, nil, fakeObserver{}, VCursorConfig{
+		MaxMemoryBytes: 100,
+	}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, vc.TrackMemory(60))
+	require.NoError(t, vc.TrackMemory(40))
+	err = vc.TrackMemory(1)
+	require.ErrorContains(t, err, "VT08001: query exceeded the vtgate memory limit of 100 bytes")
+	require.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	// released memory can be held again
+	require.NoError(t, vc.TrackMemory(-51))
+	require.NoError(t, vc.TrackMemory(50))
+
+	// no limit
+	vc.config.MaxMemoryBytes = 0
+	require.NoError(t, vc.TrackMemory(1000))
+}
+
 func TestRecordMirrorStats(t *testing.T) {
 	safeSession := NewSafeSession(nil)
 	logStats := logstats.NewLogStats(t.Context(), t.Name(), "select 1", "", nil, streamlog.NewQueryLogConfigForTest())
@@ -333,7 +397,7 @@ func (f fakeExecutor) Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLC
 	panic("implement me")
 }
 
-func (f fakeExecutor) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, autocommit bool, ignoreMaxMemoryRows bool, resultsObserver ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error) {
+func (f fakeExecutor) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, autocommit bool, maxMemoryRows int, resultsObserver ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error) {
 	// TODO implement me
 	panic("implement me")
 }
@@ -388,6 +452,11 @@ func (f fakeExecutor) ShowVitessMetadata(ctx context.Context, filter *sqlparser.
 	panic("implement me")
 }
 
+func (f fakeExecutor) ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) SetVitessMetadata(ctx context.Context, name, value string) error {
 	// TODO implement me
 	panic("implement me")
@@ -624,3 +693,51 @@ func TestGetQueryPriority(t *testing.T) {
 		})
 	}
 }
+
+type staleResolver struct {
+	staleness time.Duration
+}
+
+func (r *staleResolver) GetGateway() srvtopo.Gateway {
+	return nil
+}
+
+func (r *staleResolver) ResolveDestinations(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ids []*querypb.Value, destinations []key.ShardDestination) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
+	return []*srvtopo.ResolvedShard{
+		{Target: &querypb.Target{Keyspace: keyspace, Shard: "-80"}, Staleness: r.staleness},
+		{Target: &querypb.Target{Keyspace: keyspace, Shard: "80-"}, Staleness: r.staleness},
+	}, nil, nil
+}
+
+func (r *staleResolver) ResolveDestinationsMultiCol(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ids [][]sqltypes.Value, destinations []key.ShardDestination) ([]*srvtopo.ResolvedShard, [][][]sqltypes.Value, error) {
+	return []*srvtopo.ResolvedShard{
+		{Target: &querypb.Target{Keyspace: keyspace, Shard: "-80"}, Staleness: r.staleness},
+	}, nil, nil
+}
+
+func TestStaleRoutingWarning(t *testing.T) {
+	ctx := t.Context()
+	safeSession := NewSafeSession(nil)
+	resolver := &staleResolver{}
+	vc, err := NewVCursorImpl(safeSession, sqlparser.MarginComments{}, nil, nil, nil, &vindexes.VSchema{}, resolver, nil, fakeObserver{}, VCursorConfig{}, nil)
+	require.NoError(t, err)
+
+	// Fresh topology information doesn't record a warning.
+	_, _, err = vc.ResolveDestinations(ctx, "ks", nil, []key.ShardDestination{key.DestinationAllShards{}})
+	require.NoError(t, err)
+	require.Empty(t, safeSession.GetWarnings())
+
+	resolver.staleness = 3 * time.Second
+	_, _, err = vc.ResolveDestinations(ctx, "ks", nil, []key.ShardDestination{key.DestinationAllShards{}})
+	require.NoError(t, err)
+	_, _, err = vc.ResolveDestinationsMultiCol(ctx, "ks", nil, []key.ShardDestination{key.DestinationAllShards{}})
+	require.NoError(t, err)
+	_, _, err = vc.ResolveDestinations(ctx, "ks2", nil, []key.ShardDestination{key.DestinationAllShards{}})
+	require.NoError(t, err)
+
+	// One warning per keyspace.
+	warnings := safeSession.GetWarnings()
+	require.Len(t, warnings, 2)
+	require.Equal(t, "keyspace ks was routed using topology information cached 3s ago because the topo server is unavailable", warnings[0].Message)
+	require.Equal(t, "keyspace ks2 was routed using topology information cached 3s ago because the topo server is unavailable", warnings[1].Message)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// defaultExportBatchSize is the number of rows read from a shard per
+	// query by StreamExport, when the request doesn't specify it.
+	defaultExportBatchSize = 1000
+	// maxExportBatchSize caps the batch size of a request, so that a single
+	// query doesn't buffer an unbounded number of rows in VTGate.
+	maxExportBatchSize = 10000
+)
+
+// primaryKeyQuery returns the primary key columns of a table, in order.
+const primaryKeyQuery = "select column_name from information_schema.key_column_usage " +
+	"where table_schema = database() and table_name = :table_name and constraint_name = 'PRIMARY' " +
+	"order by ordinal_position"
+
+// StreamExport streams all the rows of a table, reading the shards one after
+// the other in primary key order, with keyset pagination. Every response
+// contains a checkpoint of the progress on every shard, which resumes the
+// export after the rows of that response when passed in a new request.
+//
+// The queries go through the executor, targeted at one shard at a time, so
+// that they are subject to the same checks and logging as other queries.
+func (vtg *VTGate) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	if req.Keyspace == "" || req.Table == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "stream export requires keyspace and table")
+	}
+	if req.BatchSize < 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid batch size %d", req.BatchSize)
+	}
+
+	exp := &tableExport{
+		executor:   vtg.executor,
+		keyspace:   req.Keyspace,
+		table:      req.Table,
+		tabletType: req.TabletType,
+		batchSize:  req.BatchSize,
+		send:       send,
+	}
+	if exp.tabletType == topodatapb.TabletType_UNKNOWN {
+		exp.tabletType = topodatapb.TabletType_PRIMARY
+	}
+	switch {
+	case exp.batchSize == 0:
+		exp.batchSize = defaultExportBatchSize
+	case exp.batchSize > maxExportBatchSize:
+		exp.batchSize = maxExportBatchSize
+	}
+
+	shards, err := vtg.exportShards(ctx, req.Keyspace, exp.tabletType, req.KeyRange)
+	if err != nil {
+		return err
+	}
+	exp.checkpoint, err = newExportCheckpoint(req.Keyspace, shards, req.Checkpoint)
+	if err != nil {
+		return err
+	}
+	return exp.run(ctx)
+}
+
+// exportShards returns the serving shards of the keyspace within the key range.
+func (vtg *VTGate) exportShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, keyRange *topodatapb.KeyRange) ([]string, error) {
+	_, _, allShards, err := vtg.resolver.resolver.GetKeyspaceShards(ctx, keyspace, tabletType)
+	if err != nil {
+		return nil, err
+	}
+
+	var shards []string
+	for _, shard := range allShards {
+		if keyRange != nil {
+			if !key.KeyRangeIntersect(keyRange, shard.KeyRange) {
+				continue
+			}
+			if !key.KeyRangeContainsKeyRange(keyRange, shard.KeyRange) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "key range %s is not aligned with the shards of keyspace %s", key.KeyRangeString(keyRange), keyspace)
+			}
+		}
+		shards = append(shards, shard.Name)
+	}
+	if len(shards) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no shards of keyspace %s within key range %s", keyspace, key.KeyRangeString(keyRange))
+	}
+	return shards, nil
+}
+
+// newExportCheckpoint returns the checkpoint to start an export of the shards
+// from. A checkpoint from a previous export must be for the same shards, as
+// the primary keys in it are meaningless after a resharding.
+func newExportCheckpoint(keyspace string, shards []string, previous *vtgatepb.ExportCheckpoint) (*vtgatepb.ExportCheckpoint, error) {
+	if previous == nil {
+		checkpoint := &vtgatepb.ExportCheckpoint{}
+		for _, shard := range shards {
+			checkpoint.Shards = append(checkpoint.Shards, &vtgatepb.ShardExportCheckpoint{Shard: shard})
+		}
+		return checkpoint, nil
+	}
+
+	var previousShards []string
+	for _, shard := range previous.Shards {
+		previousShards = append(previousShards, shard.Shard)
+	}
+	if strings.Join(previousShards, ",") != strings.Join(shards, ",") {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the checkpoint is for shards %s, but the shards of keyspace %s are now %s",
+			strings.Join(previousShards, ","), keyspace, strings.Join(shards, ","))
+	}
+	return previous.CloneVT(), nil
+}
+
+// tableExport streams the rows of a table from the shards of a checkpoint.
+type tableExport struct {
+	executor   *Executor
+	keyspace   string
+	table      string
+	tabletType topodatapb.TabletType
+	batchSize  int64
+	send       func(*vtgatepb.StreamExportResponse) error
+
+	checkpoint *vtgatepb.ExportCheckpoint
+	// pkColumns are the primary key columns of the table, and pkFields their
+	// fields in the results.
+	pkColumns  []string
+	pkFields   []*querypb.Field
+	pkOffsets  []int
+	fieldsSent bool
+}
+
+func (exp *tableExport) run(ctx context.Context) error {
+	for _, shard := range exp.checkpoint.Shards {
+		if shard.Done {
+			continue
+		}
+		if exp.pkColumns == nil {
+			if err := exp.loadPrimaryKey(ctx, shard.Shard); err != nil {
+				return err
+			}
+		}
+		if err := exp.exportShard(ctx, shard); err != nil {
+			return vterrors.Wrapf(err, "failed to export shard %s/%s", exp.keyspace, shard.Shard)
+		}
+	}
+	return nil
+}
+
+// execute runs a query on a shard through the executor.
+func (exp *tableExport) execute(ctx context.Context, shard, sql string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	session := econtext.NewSafeSession(&vtgatepb.Session{
+		TargetString: fmt.Sprintf("%s:%s@%s", exp.keyspace, shard, topoproto.TabletTypeLString(exp.tabletType)),
+		Autocommit:   true,
+	})
+	return exp.executor.Execute(ctx, nil, "StreamExport", session, sql, bindVars, false)
+}
+
+func (exp *tableExport) loadPrimaryKey(ctx context.Context, shard string) error {
+	bindVars := map[string]*querypb.BindVariable{
+		"table_name": sqltypes.StringBindVariable(exp.table),
+	}
+	qr, err := exp.execute(ctx, shard, primaryKeyQuery, bindVars)
+	if err != nil {
+		return vterrors.Wrapf(err, "failed to read the primary key of table %s", exp.table)
+	}
+	if len(qr.Rows) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table %s does not exist or has no primary key", exp.table)
+	}
+	for _, row := range qr.Rows {
+		exp.pkColumns = append(exp.pkColumns, row[0].ToString())
+	}
+	return nil
+}
+
+// query returns the query reading the next batch of rows of a shard, after
+// the last primary key of the checkpoint, if any.
+func (exp *tableExport) query(shard *vtgatepb.ShardExportCheckpoint) (string, map[string]*querypb.BindVariable) {
+	var columns, values []string
+	for _, col := range exp.pkColumns {
+		columns = append(columns, sqlparser.String(sqlparser.NewIdentifierCI(col)))
+	}
+	orderBy := strings.Join(columns, ", ")
+
+	var where string
+	bindVars := map[string]*querypb.BindVariable{}
+	if shard.LastPk != nil && len(shard.LastPk.Rows) == 1 {
+		lastPK := sqltypes.Proto3ToResult(shard.LastPk)
+		for i, value := range lastPK.Rows[0] {
+			name := fmt.Sprintf("lastpk%d", i)
+			bindVars[name] = sqltypes.ValueBindVariable(value)
+			values = append(values, ":"+name)
+		}
+		if len(columns) == 1 {
+			where = fmt.Sprintf(" where %s > %s", columns[0], values[0])
+		} else {
+			where = fmt.Sprintf(" where (%s) > (%s)", orderBy, strings.Join(values, ", "))
+		}
+	}
+
+	table := sqlparser.String(sqlparser.NewIdentifierCS(exp.table))
+	return fmt.Sprintf("select * from %s%s order by %s limit %d", table, where, orderBy, exp.batchSize), bindVars
+}
+
+func (exp *tableExport) exportShard(ctx context.Context, shard *vtgatepb.ShardExportCheckpoint) error {
+	for !shard.Done {
+		sql, bindVars := exp.query(shard)
+		qr, err := exp.execute(ctx, shard.Shard, sql, bindVars)
+		if err != nil {
+			return err
+		}
+		if !exp.fieldsSent {
+			if err := exp.sendFields(qr.Fields); err != nil {
+				return err
+			}
+		}
+
+		if len(qr.Rows) > 0 {
+			lastRow := qr.Rows[len(qr.Rows)-1]
+			lastPK := make([]sqltypes.Value, 0, len(exp.pkOffsets))
+			for _, offset := range exp.pkOffsets {
+				lastPK = append(lastPK, lastRow[offset])
+			}
+			shard.LastPk = sqltypes.ResultToProto3(&sqltypes.Result{
+				Fields: exp.pkFields,
+				Rows:   [][]sqltypes.Value{lastPK},
+			})
+		}
+		shard.Done = int64(len(qr.Rows)) < exp.batchSize
+
+		err = exp.send(&vtgatepb.StreamExportResponse{
+			Result:     sqltypes.ResultToProto3(&sqltypes.Result{Rows: qr.Rows}),
+			Checkpoint: exp.checkpoint.CloneVT(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendFields sends the fields of the table in the first response, and finds
+// the primary key columns in them.
+func (exp *tableExport) sendFields(fields []*querypb.Field) error {
+	for _, col := range exp.pkColumns {
+		offset := -1
+		for i, field := range fields {
+			if strings.EqualFold(field.Name, col) {
+				offset = i
+				break
+			}
+		}
+		if offset < 0 {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "primary key column %s of table %s is not in the results", col, exp.table)
+		}
+		exp.pkOffsets = append(exp.pkOffsets, offset)
+		exp.pkFields = append(exp.pkFields, &querypb.Field{Name: fields[offset].Name, Type: fields[offset].Type})
+	}
+	exp.fieldsSent = true
+	return exp.send(&vtgatepb.StreamExportResponse{
+		Result:     &querypb.QueryResult{Fields: fields},
+		Checkpoint: exp.checkpoint.CloneVT(),
+	})
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func exportPKResult(columns ...string) *sqltypes.Result {
+	return sqltypes.MakeTestResult(sqltypes.MakeTestFields("column_name", "varchar"), columns...)
+}
+
+func TestStreamExport(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	sbclookup.SetResults([]*sqltypes.Result{
+		exportPKResult("id"),
+		sqltypes.MakeTestResult(fields, "1|a", "2|b"),
+		sqltypes.MakeTestResult(fields, "3|c"),
+	})
+
+	var responses []*vtgatepb.StreamExportResponse
+	err := vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace:  KsTestUnsharded,
+		Table:     "t1",
+		BatchSize: 2,
+	}, func(response *vtgatepb.StreamExportResponse) error {
+		responses = append(responses, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sbclookup.Queries, 3)
+	assert.Equal(t, "select `column_name` from information_schema.key_column_usage where table_schema = database() and `table_name` = :table_name and `constraint_name` = 'PRIMARY' order by ordinal_position asc", sbclookup.Queries[0].Sql)
+	assert.Equal(t, "t1", string(sbclookup.Queries[0].BindVariables["table_name"].Value))
+	assert.Equal(t, "select * from t1 order by id asc limit 2", sbclookup.Queries[1].Sql)
+	assert.Equal(t, "select * from t1 where id > :lastpk0 order by id asc limit 2", sbclookup.Queries[2].Sql)
+	assert.Equal(t, "2", string(sbclookup.Queries[2].BindVariables["lastpk0"].Value))
+
+	require.Len(t, responses, 3)
+	assert.Equal(t, fields, sqltypes.Proto3ToResult(responses[0].Result).Fields)
+	assert.Empty(t, responses[0].Result.Rows)
+	assert.Len(t, responses[1].Result.Rows, 2)
+	assert.False(t, responses[1].Checkpoint.Shards[0].Done)
+	assert.Len(t, responses[2].Result.Rows, 1)
+	assert.True(t, responses[2].Checkpoint.Shards[0].Done)
+
+	lastPK := sqltypes.Proto3ToResult(responses[2].Checkpoint.Shards[0].LastPk)
+	require.Len(t, lastPK.Rows, 1)
+	assert.Equal(t, "id", lastPK.Fields[0].Name)
+	assert.Equal(t, sqltypes.NewInt64(3), lastPK.Rows[0][0])
+}
+
+func TestStreamExportResume(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+
+	fields := sqltypes.MakeTestFields("a|b|name", "int64|int64|varchar")
+	sbclookup.SetResults([]*sqltypes.Result{
+		exportPKResult("a", "b"),
+		sqltypes.MakeTestResult(fields, "1|3|c"),
+	})
+
+	lastPK := sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("a|b", "int64|int64"), "1|2"))
+	err := vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace: KsTestUnsharded,
+		Table:    "t1",
+		Checkpoint: &vtgatepb.ExportCheckpoint{
+			Shards: []*vtgatepb.ShardExportCheckpoint{{Shard: "0", LastPk: lastPK}},
+		},
+	}, func(*vtgatepb.StreamExportResponse) error { return nil })
+	require.NoError(t, err)
+
+	require.Len(t, sbclookup.Queries, 2)
+	assert.Equal(t, "select * from t1 where (a, b) > (:lastpk0, :lastpk1) order by a asc, b asc limit 1000", sbclookup.Queries[1].Sql)
+	assert.Equal(t, "1", string(sbclookup.Queries[1].BindVariables["lastpk0"].Value))
+	assert.Equal(t, "2", string(sbclookup.Queries[1].BindVariables["lastpk1"].Value))
+}
+
+func TestStreamExportKeyRange(t *testing.T) {
+	conns := map[string]*sandboxconn.SandboxConn{}
+	executor, ctx := createExecutorEnvCallback(t, createExecutorConfig(), func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks == KsTestSharded {
+			conns[shard] = conn
+		}
+	})
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	conns["-20"].SetResults([]*sqltypes.Result{
+		exportPKResult("id"),
+		sqltypes.MakeTestResult(fields, "1|a"),
+	})
+	conns["20-40"].SetResults([]*sqltypes.Result{
+		sqltypes.MakeTestResult(fields, "2|b"),
+	})
+
+	keyRange, err := key.ParseShardingSpec("-40")
+	require.NoError(t, err)
+
+	var checkpoint *vtgatepb.ExportCheckpoint
+	var rows int
+	err = vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace: KsTestSharded,
+		Table:    "user",
+		KeyRange: keyRange[0],
+	}, func(response *vtgatepb.StreamExportResponse) error {
+		checkpoint = response.Checkpoint
+		rows += len(response.Result.Rows)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, rows)
+
+	require.Len(t, checkpoint.Shards, 2)
+	for i, shard := range []string{"-20", "20-40"} {
+		assert.Equal(t, shard, checkpoint.Shards[i].Shard)
+		assert.True(t, checkpoint.Shards[i].Done)
+	}
+
+	assert.Len(t, conns["-20"].Queries, 2)
+	require.Len(t, conns["20-40"].Queries, 1)
+	assert.Equal(t, "select * from `user` order by id asc limit 1000", conns["20-40"].Queries[0].Sql)
+	assert.Empty(t, conns["40-60"].Queries)
+}
+
+func TestStreamExportErrors(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+	send := func(*vtgatepb.StreamExportResponse) error { return nil }
+
+	err := vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{Keyspace: KsTestUnsharded}, send)
+	assert.ErrorContains(t, err, "stream export requires keyspace and table")
+
+	err = vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{Keyspace: KsTestUnsharded, Table: "t1", BatchSize: -1}, send)
+	assert.ErrorContains(t, err, "invalid batch size -1")
+
+	keyRange, err := key.ParseShardingSpec("-30")
+	require.NoError(t, err)
+	err = vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{Keyspace: KsTestSharded, Table: "user", KeyRange: keyRange[0]}, send)
+	assert.ErrorContains(t, err, "key range -30 is not aligned with the shards of keyspace TestExecutor")
+
+	err = vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace: KsTestUnsharded,
+		Table:    "t1",
+		Checkpoint: &vtgatepb.ExportCheckpoint{
+			Shards: []*vtgatepb.ShardExportCheckpoint{{Shard: "-80"}, {Shard: "80-"}},
+		},
+	}, send)
+	assert.ErrorContains(t, err, "the checkpoint is for shards -80,80-, but the shards of keyspace TestUnsharded are now 0")
+
+	sbclookup.SetResults([]*sqltypes.Result{exportPKResult()})
+	err = vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{Keyspace: KsTestUnsharded, Table: "t1"}, send)
+	assert.ErrorContains(t, err, "table t1 does not exist or has no primary key")
+}
+
+func TestStreamExportGoesThroughExecutor(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+
+	logChan := executor.queryLogger.Subscribe("Test")
+	defer executor.queryLogger.Unsubscribe(logChan)
+
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	sbclookup.SetResults([]*sqltypes.Result{
+		exportPKResult("id"),
+		sqltypes.MakeTestResult(fields, "1|a"),
+	})
+
+	// A batch size above the maximum is clamped.
+	err := vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace:  KsTestUnsharded,
+		Table:     "t1",
+		BatchSize: 1000000,
+	}, func(*vtgatepb.StreamExportResponse) error { return nil })
+	require.NoError(t, err)
+
+	require.Len(t, sbclookup.Queries, 2)
+	assert.Equal(t, "select * from t1 order by id asc limit 10000", sbclookup.Queries[1].Sql)
+
+	// The queries are logged like the other queries of the executor.
+	for range 2 {
+		logStats := getQueryLog(logChan)
+		require.NotNil(t, logStats)
+		assert.Equal(t, "StreamExport", logStats.Method)
+	}
+}
+
+func TestStreamExportMaskedTable(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	vtg := newVTGate(executor, executor.resolver, nil, nil, executor.scatterConn.gateway)
+
+	maskExportTable(executor)
+	sbclookup.SetResults([]*sqltypes.Result{exportPKResult("id")})
+
+	// The rows would be sent unmasked, so the export is rejected.
+	err := vtg.StreamExport(ctx, &vtgatepb.StreamExportRequest{
+		Keyspace: KsTestUnsharded,
+		Table:    "t1",
+	}, func(*vtgatepb.StreamExportResponse) error { return nil })
+	require.ErrorContains(t, err, "targeting a shard with a query that reads table 't1', which has masked columns")
+	for _, query := range sbclookup.Queries {
+		assert.NotContains(t, query.Sql, "from t1")
+	}
+}
+
+func TestExportOptionMaskedColumns(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	maskExportTable(executor)
+
+	session := &vtgatepb.Session{
+		TargetString: KsTestUnsharded,
+		Options:      &querypb.ExecuteOptions{Export: &querypb.ExportOptions{Directory: "exports"}},
+	}
+
+	// The tablet would write the rows before they are masked.
+	_, err := executorExec(ctx, executor, session, "select id, name from t1", nil)
+	require.ErrorContains(t, err, "VT12001: unsupported: exporting the results of a query that reads masked columns")
+	assert.Empty(t, sbclookup.Queries)
+
+	// The unmasked columns and the callers that see unmasked values can be exported.
+	_, err = executorExec(ctx, executor, session, "select id from t1", nil)
+	require.NoError(t, err)
+	unmaskedCtx := callerid.NewContext(ctx, nil, &querypb.VTGateCallerID{Username: "admin", Groups: []string{engine.UnmaskedRole}})
+	_, err = executorExec(unmaskedCtx, executor, session, "select id, name from t1", nil)
+	require.NoError(t, err)
+	assert.Len(t, sbclookup.Queries, 2)
+}
+
+// maskExportTable gives t1 of the unsharded keyspace a masked column.
+func maskExportTable(executor *Executor) {
+	ks := executor.vschema.Keyspaces[KsTestUnsharded]
+	ks.Tables["t1"] = &vindexes.BaseTable{
+		Name:     sqlparser.NewIdentifierCS("t1"),
+		Keyspace: ks.Keyspace,
+		Columns: []vindexes.Column{
+			{Name: sqlparser.NewIdentifierCI("id"), Type: sqltypes.Int64},
+			{Name: sqlparser.NewIdentifierCI("name"), Type: sqltypes.VarChar, Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_NULL_OUT}},
+		},
+		ColumnListAuthoritative: true,
+	}
+}
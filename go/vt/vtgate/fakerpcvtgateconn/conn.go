@@ -214,6 +214,21 @@ func (conn *FakeVTGateConn) BinlogDumpGTID(ctx context.Context, keyspace, shard
 	return nil, errors.New("NYI")
 }
 
+// MessageAckBatch please see vtgateconn.Impl.MessageAckBatch
+func (conn *FakeVTGateConn) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, errors.New("NYI")
+}
+
+// MessagePostpone please see vtgateconn.Impl.MessagePostpone
+func (conn *FakeVTGateConn) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, errors.New("NYI")
+}
+
+// StreamExport streams all the rows of a table.
+func (conn *FakeVTGateConn) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest) (vtgateconn.StreamExportReader, error) {
+	return nil, errors.New("NYI")
+}
+
 // Close please see vtgateconn.Impl.Close
 func (conn *FakeVTGateConn) Close() {
 }
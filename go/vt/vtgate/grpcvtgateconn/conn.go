@@ -184,7 +184,8 @@ func (a *streamExecuteAdapter) Recv() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, vterrors.FromGRPC(err)
 	}
-	if a.fields == nil {
+	// Each result set of a query that returns several starts with its own fields.
+	if a.fields == nil || qr.NewResult {
 		a.fields = qr.Fields
 	}
 	return sqltypes.CustomProto3ToResult(a.fields, qr), nil
@@ -318,6 +319,34 @@ func (conn *vtgateConn) CloseSession(ctx context.Context, session *vtgatepb.Sess
 	return nil
 }
 
+func (conn *vtgateConn) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	request := &vtgatepb.MessageAckBatchRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		Keyspace: keyspace,
+		Name:     name,
+		Ids:      ids,
+	}
+	response, err := conn.c.MessageAckBatch(ctx, request)
+	if err != nil {
+		return 0, vterrors.FromGRPC(err)
+	}
+	return int64(response.RowsAffected), vterrors.FromVTRPC(response.Error)
+}
+
+func (conn *vtgateConn) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	request := &vtgatepb.MessagePostponeRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		Keyspace: keyspace,
+		Name:     name,
+		Ids:      ids,
+	}
+	response, err := conn.c.MessagePostpone(ctx, request)
+	if err != nil {
+		return 0, vterrors.FromGRPC(err)
+	}
+	return int64(response.RowsAffected), vterrors.FromVTRPC(response.Error)
+}
+
 type vstreamAdapter struct {
 	stream vtgateservicepb.Vitess_VStreamClient
 }
@@ -380,6 +409,28 @@ func (conn *vtgateConn) BinlogDumpGTID(ctx context.Context, keyspace, shard stri
 	return &binlogDumpGTIDAdapter{stream: stream}, nil
 }
 
+type streamExportAdapter struct {
+	stream vtgateservicepb.Vitess_StreamExportClient
+}
+
+func (a *streamExportAdapter) Recv() (*vtgatepb.StreamExportResponse, error) {
+	r, err := a.stream.Recv()
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return r, nil
+}
+
+func (conn *vtgateConn) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest) (vtgateconn.StreamExportReader, error) {
+	req = req.CloneVT()
+	req.CallerId = callerid.EffectiveCallerIDFromContext(ctx)
+	stream, err := conn.c.StreamExport(ctx, req)
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return &streamExportAdapter{stream: stream}, nil
+}
+
 func (conn *vtgateConn) Close() {
 	conn.cc.Close()
 }
@@ -301,6 +301,35 @@ func (f *fakeVTGateService) BinlogDumpGTID(ctx context.Context, req *vtgatepb.Bi
 	panic("unimplemented")
 }
 
+// MessageAckBatch is part of the VTGateService interface
+func (f *fakeVTGateService) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return f.messageDML(keyspace, name, ids)
+}
+
+// MessagePostpone is part of the VTGateService interface
+func (f *fakeVTGateService) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return f.messageDML(keyspace, name, ids)
+}
+
+// messageDML affects all the ids, and fails after the first one if the
+// message table is "fail".
+func (f *fakeVTGateService) messageDML(keyspace, name string, ids []*querypb.Value) (int64, error) {
+	if f.panics {
+		panic(errors.New("test forced panic"))
+	}
+	if keyspace != "ks" {
+		return 0, fmt.Errorf("unexpected keyspace %s", keyspace)
+	}
+	if name == "fail" {
+		return 1, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "shard unavailable")
+	}
+	return int64(len(ids)), nil
+}
+
+func (f *fakeVTGateService) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	panic("unimplemented")
+}
+
 // CreateFakeServer returns the fake server for the tests
 func CreateFakeServer(t *testing.T) vtgateservice.VTGateService {
 	return &fakeVTGateService{
@@ -343,6 +372,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testStreamExecuteMulti(t, session)
 	testExecuteBatch(t, session)
 	testPrepare(t, session)
+	testMessageDML(t, conn)
 
 	// force a panic at every call, then test that works
 	fs.panics = true
@@ -352,6 +382,7 @@ func RunTests(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGat
 	testStreamExecutePanic(t, session)
 	testStreamExecuteMultiPanic(t, session)
 	testPreparePanic(t, session)
+	testMessageDMLPanic(t, conn)
 	fs.panics = false
 }
 
@@ -650,6 +681,36 @@ func testPreparePanic(t *testing.T, session *vtgateconn.VTGateSession) {
 	expectPanic(t, err)
 }
 
+func testMessageDML(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	ids := []*querypb.Value{sqltypes.ValueToProto(sqltypes.NewInt64(1)), sqltypes.ValueToProto(sqltypes.NewInt64(2))}
+
+	count, err := conn.MessageAckBatch(ctx, "ks", "msg", ids)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+	count, err = conn.MessagePostpone(ctx, "ks", "msg", ids)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+
+	// The count of the shards that succeeded is returned with the error.
+	count, err = conn.MessageAckBatch(ctx, "ks", "fail", ids)
+	require.EqualError(t, err, "shard unavailable")
+	require.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+	require.EqualValues(t, 1, count)
+	count, err = conn.MessagePostpone(ctx, "ks", "fail", ids)
+	require.EqualError(t, err, "shard unavailable")
+	require.EqualValues(t, 1, count)
+}
+
+func testMessageDMLPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	ids := []*querypb.Value{sqltypes.ValueToProto(sqltypes.NewInt64(1))}
+	_, err := conn.MessageAckBatch(ctx, "ks", "msg", ids)
+	expectPanic(t, err)
+	_, err = conn.MessagePostpone(ctx, "ks", "msg", ids)
+	expectPanic(t, err)
+}
+
 var testCallerID = &vtrpcpb.CallerID{
 	Principal:    "test_principal",
 	Component:    "test_component",
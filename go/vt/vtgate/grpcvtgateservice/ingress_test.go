@@ -118,6 +118,18 @@ func (m *mockVTGateService) BinlogDumpGTID(ctx context.Context, req *vtgatepb.Bi
 	return nil
 }
 
+func (m *mockVTGateService) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockVTGateService) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockVTGateService) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error {
+	return nil
+}
+
 func (m *mockVTGateService) HandlePanic(err *error) {}
 
 type fakeStreamExecuteServer struct {
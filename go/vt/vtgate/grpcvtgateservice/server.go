@@ -307,6 +307,28 @@ func (vtg *VTGate) CloseSession(ctx context.Context, request *vtgatepb.CloseSess
 	}, nil
 }
 
+// MessageAckBatch is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) MessageAckBatch(ctx context.Context, request *vtgatepb.MessageAckBatchRequest) (response *vtgatepb.MessageAckBatchResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withVTGateContext(ctx, request.CallerId)
+	count, err := vtg.server.MessageAckBatch(ctx, request.Keyspace, request.Name, request.Ids)
+	return &vtgatepb.MessageAckBatchResponse{
+		Error:        vterrors.ToVTRPC(err),
+		RowsAffected: uint64(count),
+	}, nil
+}
+
+// MessagePostpone is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) MessagePostpone(ctx context.Context, request *vtgatepb.MessagePostponeRequest) (response *vtgatepb.MessagePostponeResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withVTGateContext(ctx, request.CallerId)
+	count, err := vtg.server.MessagePostpone(ctx, request.Keyspace, request.Name, request.Ids)
+	return &vtgatepb.MessagePostponeResponse{
+		Error:        vterrors.ToVTRPC(err),
+		RowsAffected: uint64(count),
+	}, nil
+}
+
 // VStream is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) VStream(request *vtgatepb.VStreamRequest, stream vtgateservicepb.Vitess_VStreamServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -345,6 +367,17 @@ func (vtg *VTGate) BinlogDumpGTID(request *vtgatepb.BinlogDumpGTIDRequest, strea
 	return vterrors.ToGRPC(vtgErr)
 }
 
+// StreamExport is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) StreamExport(request *vtgatepb.StreamExportRequest, stream vtgateservicepb.Vitess_StreamExportServer) (err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx := withVTGateContext(stream.Context(), request.CallerId)
+	vtgErr := vtg.server.StreamExport(ctx, request,
+		func(response *vtgatepb.StreamExportResponse) error {
+			return stream.Send(response)
+		})
+	return vterrors.ToGRPC(vtgErr)
+}
+
 func init() {
 	vtgate.RegisterVTGates = append(vtgate.RegisterVTGates, func(vtGate vtgateservice.VTGateService) {
 		if servenv.GRPCCheckServiceMap("vtgateservice") {
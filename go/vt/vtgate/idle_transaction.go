@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var idleTransactionsRolledBack = stats.NewCountersWithSingleLabel("MysqlServerIdleTransactionsRolledBack", "Transactions rolled back because they were idle for longer than the idle transaction timeout, per user", "User")
+
+// idleTransactionTimeouts holds how long the transaction of a connection
+// can stay idle before it is rolled back.
+type idleTransactionTimeouts struct {
+	timeout time.Duration
+	perUser map[string]time.Duration
+}
+
+// newIdleTransactionTimeouts returns the idle transaction timeouts set by
+// the flags, or nil if no connection can have one.
+func newIdleTransactionTimeouts(timeout time.Duration, perUser map[string]string) (*idleTransactionTimeouts, error) {
+	t := &idleTransactionTimeouts{timeout: timeout, perUser: make(map[string]time.Duration, len(perUser))}
+	enabled := timeout > 0
+	for user, value := range perUser {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid idle transaction timeout for user %v: %w", user, err)
+		}
+		t.perUser[user] = d
+		enabled = enabled || d > 0
+	}
+	if !enabled {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// forUser returns the idle transaction timeout of user. Zero means the
+// transactions of user are never rolled back.
+func (t *idleTransactionTimeouts) forUser(user string) time.Duration {
+	if d, ok := t.perUser[user]; ok {
+		return d
+	}
+	return t.timeout
+}
+
+// idleTransaction tracks the transaction of a connection between two
+// commands.
+type idleTransaction struct {
+	// mu is held while a command of the connection runs, so the rollback
+	// never races with it.
+	mu    sync.Mutex
+	timer *time.Timer
+	// rolledBackAfter is the timeout the transaction was rolled back
+	// after, until the next command reports it to the client.
+	rolledBackAfter time.Duration
+}
+
+func (vh *vtgateHandler) idleTransaction(c *mysql.Conn) *idleTransaction {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	it, ok := vh.idleTransactions[c.ConnectionID]
+	if !ok {
+		it = &idleTransaction{}
+		vh.idleTransactions[c.ConnectionID] = it
+	}
+	return it
+}
+
+// startCommand must be called before a command of c runs. It returns an
+// error if the transaction of c was rolled back while the connection was
+// idle. Otherwise, the returned function must be called once the command is
+// done: if c is then in a transaction, the transaction is rolled back unless
+// another command starts within the idle transaction timeout.
+func (vh *vtgateHandler) startCommand(c *mysql.Conn) (func(), error) {
+	if vh.idleTimeouts == nil {
+		return func() {}, nil
+	}
+	it := vh.idleTransaction(c)
+	it.mu.Lock()
+	if it.timer != nil {
+		it.timer.Stop()
+		it.timer = nil
+	}
+	if timeout := it.rolledBackAfter; timeout != 0 {
+		it.rolledBackAfter = 0
+		fillInTxStatusFlags(c, vh.session(c))
+		it.mu.Unlock()
+		return nil, sqlerror.NewSQLErrorf(sqlerror.ERClientInteractionTimeout, sqlerror.SSUnknownSQLState,
+			"transaction was rolled back after being idle for more than %v", timeout)
+	}
+	return func() {
+		defer it.mu.Unlock()
+		timeout := vh.idleTimeouts.forUser(c.User)
+		if timeout <= 0 || !vh.session(c).InTransaction {
+			return
+		}
+		var timer *time.Timer
+		timer = time.AfterFunc(timeout, func() {
+			vh.rollbackIdleTransaction(c, it, timer, timeout)
+		})
+		it.timer = timer
+	}, nil
+}
+
+// rollbackIdleTransaction rolls back the transaction of c once timer fires,
+// unless a command started in the meantime.
+func (vh *vtgateHandler) rollbackIdleTransaction(c *mysql.Conn, it *idleTransaction, timer *time.Timer, timeout time.Duration) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.timer != timer {
+		return
+	}
+	it.timer = nil
+
+	session := vh.session(c)
+	if !session.InTransaction {
+		return
+	}
+	ctx := context.Background()
+	if mysqlQueryTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+		defer cancel()
+	}
+	// Only the transaction is rolled back: the reserved connections of the
+	// session, and the state they hold, are kept.
+	safeSession := econtext.NewSafeSession(session)
+	if err := vh.vtg.executor.txConn.Rollback(ctx, safeSession); err != nil {
+		log.Error(fmt.Sprintf("Error rolling back the idle transaction of %s: %v", c, err))
+	}
+	safeSession.RecordWarning(&querypb.QueryWarning{
+		Code:    uint32(sqlerror.ERClientInteractionTimeout),
+		Message: fmt.Sprintf("transaction was rolled back after being idle for more than %v", timeout),
+	})
+	vh.busyConnections.Add(-1)
+	it.rolledBackAfter = timeout
+	idleTransactionsRolledBack.Add(c.User, 1)
+	log.Info(fmt.Sprintf("Rolled back the transaction of %s after being idle for more than %v", c, timeout))
+}
+
+// closeIdleTransaction stops tracking the transaction of c, which is being
+// reset or closed. It returns with the lock of the transaction held, if any;
+// the returned function releases it.
+func (vh *vtgateHandler) closeIdleTransaction(c *mysql.Conn, remove bool) func() {
+	if vh.idleTimeouts == nil {
+		return func() {}
+	}
+	it := vh.idleTransaction(c)
+	it.mu.Lock()
+	if it.timer != nil {
+		it.timer.Stop()
+		it.timer = nil
+	}
+	it.rolledBackAfter = 0
+	if remove {
+		vh.mu.Lock()
+		delete(vh.idleTransactions, c.ConnectionID)
+		vh.mu.Unlock()
+	}
+	return it.mu.Unlock
+}
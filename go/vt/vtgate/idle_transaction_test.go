@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestNewIdleTransactionTimeouts(t *testing.T) {
+	timeouts, err := newIdleTransactionTimeouts(0, nil)
+	require.NoError(t, err)
+	assert.Nil(t, timeouts)
+
+	timeouts, err = newIdleTransactionTimeouts(0, map[string]string{"batch": "0s"})
+	require.NoError(t, err)
+	assert.Nil(t, timeouts)
+
+	timeouts, err = newIdleTransactionTimeouts(time.Minute, map[string]string{"batch": "0s", "app": "10s"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, timeouts.forUser("other"))
+	assert.Equal(t, 10*time.Second, timeouts.forUser("app"))
+	assert.Zero(t, timeouts.forUser("batch"))
+
+	_, err = newIdleTransactionTimeouts(time.Minute, map[string]string{"app": "soon"})
+	assert.ErrorContains(t, err, "invalid idle transaction timeout for user app")
+}
+
+func TestIdleTransactionRolledBack(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.User = "app"
+	mysqlConn.UserData = &mysql.StaticUserData{}
+
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	vh.connections[1] = mysqlConn
+	vh.idleTimeouts = &idleTransactionTimeouts{timeout: 10 * time.Millisecond}
+	before := idleTransactionsRolledBack.Counts()["app"]
+
+	comQuery := func(query string) error {
+		return vh.ComQuery(mysqlConn, query, func(*sqltypes.Result) error { return nil })
+	}
+	require.NoError(t, comQuery("begin"))
+	require.NoError(t, comQuery("select id from user where id = 1"))
+	assert.NotZero(t, mysqlConn.StatusFlags&mysql.ServerStatusInTrans)
+
+	require.Eventually(t, func() bool {
+		return idleTransactionsRolledBack.Counts()["app"] == before+1
+	}, 5*time.Second, 5*time.Millisecond)
+	// only the transaction is rolled back, the connections are not released
+	assert.EqualValues(t, 1, sbc1.RollbackCount.Load())
+	assert.Zero(t, sbc1.ReleaseCount.Load())
+	assert.Zero(t, vh.busyConnections.Load())
+
+	// the next statement reports the rollback, and the ones after it run
+	// outside of the transaction.
+	err = comQuery("select id from user where id = 1")
+	var sqlErr *sqlerror.SQLError
+	require.ErrorAs(t, err, &sqlErr)
+	assert.Equal(t, sqlerror.ERClientInteractionTimeout, sqlErr.Number())
+	assert.Contains(t, sqlErr.Message, "transaction was rolled back after being idle for more than 10ms")
+	assert.Zero(t, mysqlConn.StatusFlags&mysql.ServerStatusInTrans)
+
+	// the rollback is also recorded as a warning of the session
+	var warnings *sqltypes.Result
+	err = vh.ComQuery(mysqlConn, "show warnings", func(result *sqltypes.Result) error {
+		warnings = result
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, warnings)
+	require.Len(t, warnings.Rows, 1)
+	assert.Equal(t, fmt.Sprint(int(sqlerror.ERClientInteractionTimeout)), warnings.Rows[0][1].ToString())
+	assert.Equal(t, "transaction was rolled back after being idle for more than 10ms", warnings.Rows[0][2].ToString())
+
+	require.NoError(t, comQuery("select id from user where id = 1"))
+	assert.False(t, vh.session(mysqlConn).InTransaction)
+}
+
+func TestIdleTransactionKeptAlive(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.User = "batch"
+	mysqlConn.UserData = &mysql.StaticUserData{}
+
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	vh.connections[1] = mysqlConn
+	// the timeout is disabled for the user of the connection
+	vh.idleTimeouts = &idleTransactionTimeouts{timeout: 10 * time.Millisecond, perUser: map[string]time.Duration{"batch": 0}}
+
+	comQuery := func(query string) error {
+		return vh.ComQuery(mysqlConn, query, func(*sqltypes.Result) error { return nil })
+	}
+	require.NoError(t, comQuery("begin"))
+	require.NoError(t, comQuery("select id from user where id = 1"))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, comQuery("commit"))
+	assert.Zero(t, sbc1.ReleaseCount.Load())
+	assert.EqualValues(t, 1, sbc1.CommitCount.Load())
+
+	// closing the connection stops tracking it
+	vh.ConnectionClosed(mysqlConn)
+	assert.Empty(t, vh.idleTransactions)
+}
@@ -100,7 +100,7 @@ func TestLegacyExecuteFailOnAutocommit(t *testing.T) {
 		},
 		Autocommit: false,
 	}
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
 	err := vterrors.Aggregate(errs)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "in autocommit mode, transactionID should be zero but was: 123")
@@ -124,7 +124,7 @@ func TestScatterConnExecuteMulti(t *testing.T) {
 			}
 		}
 
-		qr, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(nil), false /*autocommit*/, false, nullResultsObserver{}, false)
+		qr, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(nil), false /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
 		return qr, vterrors.Aggregate(errs)
 	})
 }
@@ -281,21 +281,26 @@ func TestMaxMemoryRows(t *testing.T) {
 		InsertID:     1,
 	}
 
+	// The limit is the one of the query, which may be above the
+	// --max-memory-rows flag value.
 	testCases := []struct {
-		ignoreMaxMemoryRows bool
-		err                 string
+		maxMemoryRows int
+		err           string
 	}{
-		{true, ""},
-		{false, "in-memory row count exceeded allowed limit of 3"},
+		{econtext.UnlimitedMemoryRows, ""},
+		{0, "in-memory row count exceeded allowed limit of 0"},
+		{3, "in-memory row count exceeded allowed limit of 3"},
+		{10, ""},
 	}
 
 	for _, test := range testCases {
 		sbc0.SetResults([]*sqltypes.Result{tworows, tworows})
 		sbc1.SetResults([]*sqltypes.Result{tworows, tworows})
 
-		_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, test.ignoreMaxMemoryRows, nullResultsObserver{}, false)
-		if test.ignoreMaxMemoryRows {
-			require.NoError(t, err)
+		qr, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, test.maxMemoryRows, nullResultsObserver{}, false)
+		if test.err == "" {
+			require.NoError(t, vterrors.Aggregate(errs))
+			assert.Len(t, qr.Rows, 4)
 		} else {
 			assert.EqualError(t, errs[0], test.err)
 		}
@@ -325,7 +330,7 @@ func TestLegaceHealthCheckFailsOnReservedConnections(t *testing.T) {
 		})
 	}
 
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Error(t, vterrors.Aggregate(errs))
 }
 
@@ -348,7 +353,7 @@ func executeOnShardsReturnsErr(t *testing.T, ctx context.Context, res *srvtopo.R
 		})
 	}
 
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	return vterrors.Aggregate(errs)
 }
 
@@ -413,7 +418,7 @@ func TestMultiExecs(t *testing.T) {
 	observer := recordingResultsObserver{}
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{})
-	_, err := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, false, &observer, false)
+	_, err := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, false, maxMemoryRows, &observer, false)
 	require.NoError(t, vterrors.Aggregate(err))
 	if len(sbc0.Queries) == 0 || len(sbc1.Queries) == 0 {
 		require.Fail(t, "didn't get expected query")
@@ -488,27 +493,27 @@ func TestScatterConnSingleDB(t *testing.T) {
 	// TransactionMode_SINGLE in session
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true, TransactionMode: vtgatepb.TransactionMode_SINGLE})
 	queries := []*querypb.BoundQuery{{Sql: "query1"}}
-	_, errors := sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors := sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errors)
-	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Error(t, errors[0])
 	assert.Contains(t, errors[0].Error(), want)
 
 	// TransactionMode_SINGLE in txconn
 	sc.txConn.txMode = &StaticConfig{TxMode: vtgatepb.TransactionMode_SINGLE}
 	session = econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	_, errors = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errors)
-	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Error(t, errors[0])
 	assert.Contains(t, errors[0].Error(), want)
 
 	// TransactionMode_MULTI in txconn. Should not fail.
 	sc.txConn.txMode = &StaticConfig{TxMode: vtgatepb.TransactionMode_MULTI}
 	session = econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	_, errors = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errors)
-	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	_, errors = sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errors)
 }
 
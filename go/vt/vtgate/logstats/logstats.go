@@ -226,6 +226,11 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	log.Bool(stats.SlowQuery)
 	log.Key("EmitReason")
 	log.String(emitReason)
+	program, os := callinfo.MysqlClientFromContext(stats.Ctx)
+	log.Key("ClientProgram")
+	log.String(program)
+	log.Key("ClientOS")
+	log.String(os)
 
 	return log.Flush(w)
 }
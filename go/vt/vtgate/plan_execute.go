@@ -23,9 +23,11 @@ import (
 	"strings"
 	"time"
 
+	"vitess.io/vitess/go/cache/theine"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -120,19 +122,43 @@ func (e *Executor) newExecute(
 		// When buffering ends, many queries might be getting planned at the same time and we then
 		// take full advatange of the cached plan.
 		plan, vcursor, stmt, err = e.fetchOrCreatePlan(ctx, safeSession, sql, bindVars, parameterize, prepared, logStats, true)
+
+		// The policy for the locking reads of the sessions that target replicas is applied
+		// before an implicit transaction starts, and a redirected locking read is planned
+		// again for the primary, as the plan depends on the tablet type of the target.
+		var redirected bool
+		if err == nil {
+			redirected, err = e.applyReplicaLockingReads(plan, vcursor, safeSession)
+		}
+		if redirected {
+			plan, vcursor, stmt, err = e.fetchOrCreatePrimaryPlan(ctx, safeSession, sql, bindVars, parameterize, prepared, logStats)
+		}
 		execStart := e.logPlanningFinished(logStats, plan)
 
 		if err != nil {
 			safeSession.ClearWarnings()
 			return err
 		}
+		if mysqlCtx != nil {
+			vcursor.SetContentProvider(mysqlCtx)
+		}
+
+		// The tablets write the rows of an export before they reach the
+		// engine.Mask primitive.
+		if safeSession.GetOptions().GetExport() != nil && engine.MasksResults(ctx, plan.Instructions) {
+			return vterrors.VT12001("exporting the results of a query that reads masked columns")
+		}
 
 		// Start an implicit transaction if necessary. This is done after plan
 		// creation so we can check whether the plan actually accesses real table
 		// data, matching MySQL's behavior where only data-accessing statements
-		// start implicit transactions when autocommit=0.
-		if err = e.startTxIfNecessary(ctx, plan, stmt, safeSession); err != nil {
-			return err
+		// start implicit transactions when autocommit=0. A locking read redirected
+		// to the primary runs outside of the transaction of the session, which
+		// targets the replicas.
+		if !redirected {
+			if err = e.startTxIfNecessary(ctx, plan, stmt, safeSession); err != nil {
+				return err
+			}
 		}
 
 		if plan.QueryType != sqlparser.StmtShow {
@@ -171,6 +197,15 @@ func (e *Executor) newExecute(
 		// Set the session variable to indicate if the query is a read query or not.
 		safeSession.SetExecReadQuery(plan.QueryType.IsReadStatement())
 
+		// Buffer the query while an Online DDL cut-over of one of its tables is imminent, rather
+		// than have the tablet hold or fail it. Like for failovers, transactions are not buffered.
+		if !safeSession.InTransaction() && vcursor.TabletType() == topodatapb.TabletType_PRIMARY {
+			if err = e.resolver.scatterConn.gateway.WaitForTableCutOvers(ctx, plan.TablesUsed); err != nil {
+				logStats.Error = err
+				return err
+			}
+		}
+
 		// Execute the plan.
 		if plan.Instructions.NeedsTransaction() {
 			err = e.insideTransaction(ctx, safeSession, logStats,
@@ -252,19 +287,19 @@ func (e *Executor) handleTransactions(
 		qr, err := e.handleSavepoint(ctx, vcursor, safeSession, plan.Original, plan.QueryType.String(), logStats, func(_ string) (*sqltypes.Result, error) {
 			// Safely to ignore as there is no transaction.
 			return &sqltypes.Result{}, nil
-		}, vcursor.IgnoreMaxMemoryRows())
+		}, vcursor.MemoryRowsLimit())
 		return qr, err
 	case sqlparser.StmtSRollback:
 		qr, err := e.handleSavepoint(ctx, vcursor, safeSession, plan.Original, plan.QueryType.String(), logStats, func(query string) (*sqltypes.Result, error) {
 			// Error as there is no transaction, so there is no savepoint that exists.
 			return nil, vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", query)
-		}, vcursor.IgnoreMaxMemoryRows())
+		}, vcursor.MemoryRowsLimit())
 		return qr, err
 	case sqlparser.StmtRelease:
 		qr, err := e.handleSavepoint(ctx, vcursor, safeSession, plan.Original, plan.QueryType.String(), logStats, func(query string) (*sqltypes.Result, error) {
 			// Error as there is no transaction, so there is no savepoint that exists.
 			return nil, vterrors.NewErrorf(vtrpcpb.Code_NOT_FOUND, vterrors.SPDoesNotExist, "SAVEPOINT does not exist: %s", query)
-		}, vcursor.IgnoreMaxMemoryRows())
+		}, vcursor.MemoryRowsLimit())
 		return qr, err
 	case sqlparser.StmtKill:
 		return e.handleKill(ctx, mysqlCtx, vcursor, stmt, logStats)
@@ -407,8 +442,34 @@ func (e *Executor) executePlan(
 	logStats *logstats.LogStats,
 	execStart time.Time,
 ) (*sqltypes.Result, error) {
+	var (
+		cacheKey         theine.HashKey256
+		cacheGenerations []uint64
+	)
+	useCache := e.useResultCache(plan, safeSession)
+	if useCache {
+		var err error
+		cacheKey, err = resultCacheKey(ctx, vcursor, plan, bindVars)
+		if err != nil {
+			return nil, err
+		}
+		if qr, ok := e.results.get(cacheKey); ok {
+			e.setLogStats(logStats, plan, vcursor, execStart, nil, qr)
+			return qr, nil
+		}
+		cacheGenerations = e.results.currentGenerations(plan.TablesUsed)
+	}
+
 	// 4: Execute!
 	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	if err == nil && plan.QueryType == sqlparser.StmtSelect {
+		if maxRows := vcursor.MaxRows(); maxRows > 0 && len(qr.Rows) > maxRows {
+			qr, err = nil, vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "row count exceeded the max_rows limit of %d set in the vschema", maxRows)
+		}
+	}
+	if err == nil && useCache {
+		e.results.set(cacheKey, qr, plan.QueryHints.CacheTTL, plan.TablesUsed, cacheGenerations)
+	}
 
 	// 5: Log and add statistics
 	e.setLogStats(logStats, plan, vcursor, execStart, err, qr)
@@ -420,6 +481,16 @@ func (e *Executor) executePlan(
 	return qr, nil
 }
 
+// useResultCache returns whether the result of the plan can be served from and stored in the result cache.
+// Queries in a transaction or on a reserved connection always run, so that they see their own changes.
+func (e *Executor) useResultCache(plan *engine.Plan, safeSession *econtext.SafeSession) bool {
+	return e.results != nil &&
+		plan.QueryHints.CacheTTL > 0 &&
+		plan.QueryType == sqlparser.StmtSelect &&
+		!safeSession.InTransaction() &&
+		!safeSession.InReservedConn()
+}
+
 // rollbackExecIfNeeded rollbacks the partial execution if earlier it was detected that it needs partial query execution to be rolled back.
 func (e *Executor) rollbackExecIfNeeded(ctx context.Context, safeSession *econtext.SafeSession, bindVars map[string]*querypb.BindVariable, logStats *logstats.LogStats, err error) error {
 	if !safeSession.InTransaction() {
@@ -528,6 +599,31 @@ func (e *Executor) logPlanningFinished(logStats *logstats.LogStats, plan *engine
 	return execStart
 }
 
+// applyReplicaLockingReads applies the policy for the locking reads of the
+// sessions that target replicas, which do not lock the rows they read on the
+// primary: it either lets them run on the replicas, redirects them to the
+// primary, or rejects them. It returns true if the locking read must be
+// redirected. Locking reads in a transaction on replicas are never redirected,
+// as the transaction cannot span the primary.
+func (e *Executor) applyReplicaLockingReads(plan *engine.Plan, vcursor *econtext.VCursorImpl, safeSession *econtext.SafeSession) (bool, error) {
+	if !plan.LockingRead || vcursor.TabletType() == topodatapb.TabletType_PRIMARY {
+		return false, nil
+	}
+	switch e.config.ReplicaLockingReads {
+	case ReplicaLockingReadsRedirect:
+		if !safeSession.InTransaction() {
+			replicaLockingReadsCount.Add("Redirected", 1)
+			return true, nil
+		}
+	case ReplicaLockingReadsReject:
+	default:
+		replicaLockingReadsCount.Add("Allowed", 1)
+		return false, nil
+	}
+	replicaLockingReadsCount.Add("Rejected", 1)
+	return false, vterrors.VT09033()
+}
+
 func shouldBlockQueries(plan *engine.Plan, safeSession *econtext.SafeSession) bool {
 	block := safeSession.IsErrorUntilRollback()
 	if plan.QueryType != sqlparser.StmtRollback && block {
@@ -119,6 +119,14 @@ func BuildFromStmt(ctx context.Context, query string, stmt sqlparser.Statement,
 		primitive = planResult.primitive
 		tablesUsed = planResult.tables
 	}
+
+	jsonRows, err := sqlparser.JSONResultFormatDirective(stmt)
+	if err != nil {
+		return nil, err
+	}
+	if jsonRows && primitive != nil {
+		primitive = &engine.JSONRows{Input: primitive}
+	}
 	return engine.NewPlan(query, stmt, primitive, bindVarNeeds, tablesUsed), nil
 }
 
@@ -330,6 +338,15 @@ func buildDBDDLPlan(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vschema
 }
 
 func buildLoadPlan(query string, vschema plancontext.VSchema) (*planResult, error) {
+	load, ok, err := buildLoadDataLocal(query, vschema.Environment().Parser())
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		// The client sends the file to vtgate, which inserts its rows.
+		return newPlanResult(load), nil
+	}
+
 	keyspace, err := vschema.SelectedKeyspace()
 	if err != nil {
 		return nil, err
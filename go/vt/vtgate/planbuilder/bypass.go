@@ -17,6 +17,8 @@ limitations under the License.
 package planbuilder
 
 import (
+	"fmt"
+
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -49,6 +51,10 @@ func buildPlanForBypass(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vsc
 		}
 	}
 
+	if err := checkBypassMaskedTables(stmt, vschema); err != nil {
+		return nil, err
+	}
+
 	hints := &queryHints{}
 	if comments, ok := stmt.(sqlparser.Commented); ok {
 		if qh := getHints(comments.GetParsedComments()); qh != nil {
@@ -77,3 +83,35 @@ func GetShardRoute(vschema plancontext.VSchema, keyspace, shard string) (*vindex
 	}
 	return vschema.FindKeyspace(targetKeyspaceName)
 }
+
+// checkBypassMaskedTables rejects the queries sent unchanged to a shard that
+// read tables with masked columns: their results would not be masked.
+func checkBypassMaskedTables(stmt sqlparser.Statement, vschema plancontext.VSchema) error {
+	var read sqlparser.SQLNode
+	switch stmt := stmt.(type) {
+	case sqlparser.SelectStatement:
+		read = stmt
+	case *sqlparser.Insert:
+		sel, ok := stmt.Rows.(sqlparser.SelectStatement)
+		if !ok {
+			return nil
+		}
+		read = sel
+	default:
+		return nil
+	}
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		name, ok := node.(sqlparser.TableName)
+		if !ok {
+			return true, nil
+		}
+		table, _, _, _, err := vschema.FindTable(name)
+		if err != nil || table == nil {
+			return false, nil
+		}
+		if table.HasMaskedColumns() {
+			return false, vterrors.VT12001(fmt.Sprintf("targeting a shard with a query that reads table '%s', which has masked columns", table.Name.String()))
+		}
+		return false, nil
+	}, read)
+}
@@ -131,3 +131,74 @@ func TestOrderedAggregateCollations(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderByCollations(t *testing.T) {
+	collid := func(collname string) collations.ID {
+		return collations.MySQL8().LookupByName(collname)
+	}
+	testCases := []struct {
+		query     string
+		collation string
+		// weightString is true if the rows must be sorted with the weight
+		// strings MySQL computed for them.
+		weightString bool
+	}{
+		{
+			query:     "select textcol1 from user order by textcol1 collate utf8mb4_0900_as_cs",
+			collation: "utf8mb4_0900_as_cs",
+		},
+		{
+			query:     "select textcol1 from user order by textcol1 collate utf8mb4_0900_ai_ci desc",
+			collation: "utf8mb4_0900_ai_ci",
+		},
+		{
+			query:     "select textcol1 from user order by textcol1 collate utf8mb4_ja_0900_as_cs",
+			collation: "utf8mb4_ja_0900_as_cs",
+		},
+		{
+			query:     "select textcol1 from user order by textcol1 collate utf8mb4_ja_0900_as_cs_ks",
+			collation: "utf8mb4_ja_0900_as_cs_ks",
+		},
+		{
+			query:        "select textcol1 from user order by textcol1 collate sjis_japanese_ci",
+			collation:    "sjis_japanese_ci",
+			weightString: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d %s", i+1, tc.query), func(t *testing.T) {
+			ctc := collationTestCase{
+				collations: []collationInTable{{ks: "user", table: "user", collationName: "utf8mb4_0900_ai_ci", colName: "textcol1"}},
+				query:      tc.query,
+				check: func(t *testing.T, colls []collationInTable, primitive engine.Primitive) {
+					route, isRoute := primitive.(*engine.Route)
+					require.True(t, isRoute, "should be a Route")
+					require.Len(t, route.OrderBy, 1)
+					require.Equal(t, collid(tc.collation), route.OrderBy[0].Type.Collation())
+					if tc.weightString {
+						require.NotEqual(t, -1, route.OrderBy[0].WeightStringCol)
+					} else {
+						require.Equal(t, -1, route.OrderBy[0].WeightStringCol)
+					}
+				},
+			}
+			ctc.run(t)
+		})
+	}
+
+	t.Run("union all", func(t *testing.T) {
+		ctc := collationTestCase{
+			collations: []collationInTable{{ks: "user", table: "user", collationName: "utf8mb4_0900_ai_ci", colName: "textcol1"}},
+			query:      "select textcol1 from user union all select col4 from unsharded_fk_allow.u_tbl4 order by textcol1 collate utf8mb4_0900_as_cs",
+			check: func(t *testing.T, colls []collationInTable, primitive engine.Primitive) {
+				concatenate, isConcatenate := primitive.(*engine.Concatenate)
+				require.True(t, isConcatenate, "should be a Concatenate")
+				require.Len(t, concatenate.OrderBy, 1)
+				require.Equal(t, collid("utf8mb4_0900_as_cs"), concatenate.OrderBy[0].Type.Collation())
+				require.Equal(t, -1, concatenate.OrderBy[0].WeightStringCol)
+			},
+		}
+		ctc.run(t)
+	})
+}
@@ -33,6 +33,10 @@ func gen4InsertStmtPlanner(version querypb.ExecuteOptions_PlannerVersion, insStm
 		return nil, err
 	}
 
+	if err := checkInsertSelectMasks(ctx, insStmt); err != nil {
+		return nil, err
+	}
+
 	err = queryRewrite(ctx, insStmt)
 	if err != nil {
 		return nil, err
@@ -56,6 +60,11 @@ func gen4InsertStmtPlanner(version querypb.ExecuteOptions_PlannerVersion, insStm
 			return newPlanResult(plan, operators.QualifiedTables(ks, tables)...), nil
 		}
 	}
+	// RETURNING is emulated by vttablet, so the statement has to reach a
+	// single tablet unchanged.
+	if insStmt.Returning != nil {
+		return nil, vterrors.VT12001("RETURNING on an insert that is not sent unchanged to a single unsharded keyspace")
+	}
 
 	tblInfo, err := ctx.SemTable.TableInfoFor(ctx.SemTable.TableSetFor(insStmt.Table))
 	if err != nil {
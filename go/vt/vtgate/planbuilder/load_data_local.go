@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// buildLoadDataLocal builds the plan of a LOAD DATA LOCAL INFILE statement,
+// which inserts the rows of the file sent by the client. ok is false if the
+// statement is not a LOAD DATA LOCAL INFILE. The parser does not build an
+// AST for LOAD DATA, so the statement is parsed from its tokens:
+//
+//	LOAD DATA [LOW_PRIORITY | CONCURRENT] LOCAL INFILE 'file_name'
+//	    [REPLACE | IGNORE] INTO TABLE tbl_name
+//	    [CHARACTER SET charset_name]
+//	    [{FIELDS | COLUMNS} [TERMINATED BY 'string'] [[OPTIONALLY] ENCLOSED BY 'char'] [ESCAPED BY 'char']]
+//	    [LINES [STARTING BY 'string'] [TERMINATED BY 'string']]
+//	    [IGNORE number {LINES | ROWS}]
+//	    [(col_name [, col_name] ...)]
+//
+// Like MySQL, the rows that duplicate a unique key are skipped unless
+// REPLACE is given. The PARTITION and SET clauses, and user variables in
+// the column list, are not supported.
+func buildLoadDataLocal(query string, parser *sqlparser.Parser) (load *engine.LoadDataLocal, ok bool, err error) {
+	s := &loadDataScanner{tkn: parser.NewStringTokenizer(query)}
+	s.next()
+	if !s.accept(sqlparser.LOAD) || !s.accept(sqlparser.DATA) {
+		return nil, false, nil
+	}
+	if !s.accept(sqlparser.LOW_PRIORITY) {
+		s.acceptWord("concurrent")
+	}
+	if !s.accept(sqlparser.LOCAL) {
+		return nil, false, nil
+	}
+
+	load = &engine.LoadDataLocal{Format: engine.DefaultLoadDataFormat}
+	if !s.acceptWord("infile") {
+		return nil, true, s.syntaxError()
+	}
+	if load.Filename, err = s.str(); err != nil {
+		return nil, true, err
+	}
+
+	insert := "insert ignore into "
+	switch {
+	case s.accept(sqlparser.REPLACE):
+		insert = "replace into "
+	case s.accept(sqlparser.IGNORE):
+	}
+	if !s.accept(sqlparser.INTO) || !s.accept(sqlparser.TABLE) {
+		return nil, true, s.syntaxError()
+	}
+	table, err := s.ident()
+	if err != nil {
+		return nil, true, err
+	}
+	tableName := sqlparser.NewTableName(table)
+	if s.accept('.') {
+		if table, err = s.ident(); err != nil {
+			return nil, true, err
+		}
+		tableName = sqlparser.NewTableNameWithQualifier(table, tableName.Name.String())
+	}
+	insert += sqlparser.String(tableName)
+
+	if s.typ == sqlparser.PARTITION {
+		return nil, true, vterrors.VT12001("PARTITION in LOAD DATA LOCAL INFILE")
+	}
+	if err := s.charset(); err != nil {
+		return nil, true, err
+	}
+	if err := s.format(&load.Format); err != nil {
+		return nil, true, err
+	}
+
+	if s.accept(sqlparser.IGNORE) {
+		if s.typ != sqlparser.INTEGRAL {
+			return nil, true, s.syntaxError()
+		}
+		if load.IgnoreLines, err = strconv.Atoi(s.val); err != nil {
+			return nil, true, s.syntaxError()
+		}
+		s.next()
+		if !s.accept(sqlparser.LINES) && !s.accept(sqlparser.ROWS) {
+			return nil, true, s.syntaxError()
+		}
+	}
+
+	if s.accept('(') {
+		var columns sqlparser.Columns
+		for {
+			if s.typ == sqlparser.AT_ID {
+				return nil, true, vterrors.VT12001("user variables in LOAD DATA LOCAL INFILE")
+			}
+			column, err := s.ident()
+			if err != nil {
+				return nil, true, err
+			}
+			columns = append(columns, sqlparser.NewIdentifierCI(column))
+			if s.accept(')') {
+				break
+			}
+			if !s.accept(',') {
+				return nil, true, s.syntaxError()
+			}
+		}
+		insert += sqlparser.String(columns)
+		load.Columns = len(columns)
+	}
+
+	if s.typ == sqlparser.SET {
+		return nil, true, vterrors.VT12001("SET in LOAD DATA LOCAL INFILE")
+	}
+	s.accept(';')
+	if s.typ != 0 {
+		return nil, true, s.syntaxError()
+	}
+	load.Insert = insert + " values "
+	return load, true, nil
+}
+
+// loadDataScanner scans the tokens of a LOAD DATA statement, skipping the
+// comments.
+type loadDataScanner struct {
+	tkn *sqlparser.Tokenizer
+	// typ and val are the current token.
+	typ int
+	val string
+}
+
+func (s *loadDataScanner) next() {
+	for {
+		if s.typ, s.val = s.tkn.Scan(); s.typ != sqlparser.COMMENT {
+			return
+		}
+	}
+}
+
+// accept moves to the next token if the current one is typ.
+func (s *loadDataScanner) accept(typ int) bool {
+	if s.typ != typ {
+		return false
+	}
+	s.next()
+	return true
+}
+
+// acceptWord moves to the next token if the current one is the given word,
+// which the parser does not use, like INFILE.
+func (s *loadDataScanner) acceptWord(word string) bool {
+	if s.typ == sqlparser.STRING || !strings.EqualFold(s.val, word) {
+		return false
+	}
+	s.next()
+	return true
+}
+
+// str returns the current token, which must be a string.
+func (s *loadDataScanner) str() (string, error) {
+	if s.typ != sqlparser.STRING {
+		return "", s.syntaxError()
+	}
+	val := s.val
+	s.next()
+	return val, nil
+}
+
+// ident returns the current token, which must be an identifier or a
+// keyword that can be used as one.
+func (s *loadDataScanner) ident() (string, error) {
+	if s.typ != sqlparser.ID && sqlparser.KeywordString(s.typ) == "" {
+		return "", s.syntaxError()
+	}
+	val := s.val
+	s.next()
+	return val, nil
+}
+
+// charset parses the CHARACTER SET clause. The fields are inserted as
+// strings, so only the character sets of the connection are supported.
+func (s *loadDataScanner) charset() error {
+	if !s.accept(sqlparser.CHARSET) {
+		if !s.accept(sqlparser.CHARACTER) {
+			return nil
+		}
+		if !s.accept(sqlparser.SET) {
+			return s.syntaxError()
+		}
+	}
+	charset, err := s.ident()
+	if err != nil {
+		if charset, err = s.str(); err != nil {
+			return err
+		}
+	}
+	switch strings.ToLower(charset) {
+	case "utf8mb4", "utf8mb3", "utf8", "ascii", "binary":
+		return nil
+	}
+	return vterrors.VT12001("CHARACTER SET " + charset + " in LOAD DATA LOCAL INFILE")
+}
+
+// format parses the FIELDS and LINES clauses into format.
+func (s *loadDataScanner) format(format *engine.LoadDataFormat) error {
+	if s.accept(sqlparser.FIELDS) || s.accept(sqlparser.COLUMNS) {
+		if err := s.fields(format); err != nil {
+			return err
+		}
+	}
+	if s.accept(sqlparser.LINES) {
+		return s.lines(format)
+	}
+	return nil
+}
+
+func (s *loadDataScanner) fields(format *engine.LoadDataFormat) error {
+	for {
+		var err error
+		switch {
+		case s.accept(sqlparser.TERMINATED):
+			format.FieldsTerminatedBy, err = s.by()
+		case s.accept(sqlparser.OPTIONALLY) || s.typ == sqlparser.ENCLOSED:
+			if !s.accept(sqlparser.ENCLOSED) {
+				return s.syntaxError()
+			}
+			format.FieldsEnclosedBy, err = s.byChar()
+		case s.accept(sqlparser.ESCAPED):
+			format.FieldsEscapedBy, err = s.byChar()
+		default:
+			if format.FieldsTerminatedBy == "" {
+				return vterrors.VT12001("empty FIELDS TERMINATED BY in LOAD DATA LOCAL INFILE")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *loadDataScanner) lines(format *engine.LoadDataFormat) error {
+	for {
+		var err error
+		switch {
+		case s.accept(sqlparser.STARTING):
+			format.LinesStartingBy, err = s.by()
+		case s.accept(sqlparser.TERMINATED):
+			format.LinesTerminatedBy, err = s.by()
+		default:
+			if format.LinesTerminatedBy == "" {
+				return vterrors.VT12001("empty LINES TERMINATED BY in LOAD DATA LOCAL INFILE")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// by parses BY 'string'.
+func (s *loadDataScanner) by() (string, error) {
+	if !s.accept(sqlparser.BY) {
+		return "", s.syntaxError()
+	}
+	return s.str()
+}
+
+// byChar parses BY 'char', where the character can be empty.
+func (s *loadDataScanner) byChar() (byte, error) {
+	val, err := s.by()
+	if err != nil {
+		return 0, err
+	}
+	switch len(val) {
+	case 0:
+		return 0, nil
+	case 1:
+		return val[0], nil
+	}
+	return 0, vterrors.VT12001("multi-character ENCLOSED BY or ESCAPED BY in LOAD DATA LOCAL INFILE")
+}
+
+func (s *loadDataScanner) syntaxError() error {
+	near := s.val
+	if near == "" && s.typ > 0 && s.typ < 256 {
+		near = string(rune(s.typ))
+	}
+	return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.SyntaxError, "syntax error in LOAD DATA LOCAL INFILE near '%s'", near)
+}
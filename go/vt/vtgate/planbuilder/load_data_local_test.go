@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+)
+
+func TestBuildLoadDataLocal(t *testing.T) {
+	testcases := []struct {
+		query string
+		want  *engine.LoadDataLocal
+		err   string
+	}{{
+		query: "load data local infile '/tmp/t.tsv' into table t",
+		want: &engine.LoadDataLocal{
+			Filename: "/tmp/t.tsv",
+			Insert:   "insert ignore into t values ",
+			Format:   engine.DefaultLoadDataFormat,
+		},
+	}, {
+		query: "LOAD DATA LOW_PRIORITY LOCAL INFILE 'data.csv' REPLACE INTO TABLE ks.`order` CHARACTER SET utf8mb4 " +
+			"FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '' " +
+			"LINES STARTING BY '>' TERMINATED BY '\\r\\n' IGNORE 1 LINES (id, `name`, /* comment */ `data`);",
+		want: &engine.LoadDataLocal{
+			Filename: "data.csv",
+			Insert:   "replace into ks.`order`(id, `name`, `data`) values ",
+			Columns:  3,
+			Format: engine.LoadDataFormat{
+				FieldsTerminatedBy: ",",
+				FieldsEnclosedBy:   '"',
+				LinesStartingBy:    ">",
+				LinesTerminatedBy:  "\r\n",
+			},
+			IgnoreLines: 1,
+		},
+	}, {
+		query: "load data concurrent local infile 'f' ignore into table t columns escaped by '|' ignore 2 rows",
+		want: &engine.LoadDataLocal{
+			Filename: "f",
+			Insert:   "insert ignore into t values ",
+			Format: engine.LoadDataFormat{
+				FieldsTerminatedBy: "\t",
+				FieldsEscapedBy:    '|',
+				LinesTerminatedBy:  "\n",
+			},
+			IgnoreLines: 2,
+		},
+	}, {
+		query: "load data local infile 'f' into table t set a = 1",
+		err:   "VT12001: unsupported: SET in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into table t (a, @b)",
+		err:   "VT12001: unsupported: user variables in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into table t partition (p0)",
+		err:   "VT12001: unsupported: PARTITION in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into table t character set latin1",
+		err:   "VT12001: unsupported: CHARACTER SET latin1 in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into table t fields enclosed by '\"\"'",
+		err:   "VT12001: unsupported: multi-character ENCLOSED BY or ESCAPED BY in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into table t fields terminated by ''",
+		err:   "VT12001: unsupported: empty FIELDS TERMINATED BY in LOAD DATA LOCAL INFILE",
+	}, {
+		query: "load data local infile 'f' into t",
+		err:   "syntax error in LOAD DATA LOCAL INFILE near 't'",
+	}, {
+		query: "load data local infile 'f' into table t (a, b",
+		err:   "syntax error in LOAD DATA LOCAL INFILE near ''",
+	}}
+	parser := sqlparser.NewTestParser()
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			load, ok, err := buildLoadDataLocal(tc.query, parser)
+			assert.True(t, ok)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, load)
+		})
+	}
+
+	// The other LOAD DATA statements are sent to the keyspace as is.
+	for _, query := range []string{
+		"load data infile 'f' into table t",
+		"load data from s3 'x.txt' into table x",
+	} {
+		_, ok, err := buildLoadDataLocal(query, parser)
+		require.NoError(t, err)
+		assert.False(t, ok, query)
+	}
+}
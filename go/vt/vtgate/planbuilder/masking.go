@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+// nullOutMask is used for the columns that depend on masked columns with
+// different policies.
+var nullOutMask = &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_NULL_OUT}
+
+// maskPlan wraps the plan in an engine.Mask if any of the columns it
+// returns is masked.
+func maskPlan(plan engine.Primitive, masks []engine.MaskedColumn) engine.Primitive {
+	if len(masks) == 0 {
+		return plan
+	}
+	return &engine.Mask{Columns: masks, Input: plan}
+}
+
+// columnMasks returns the masked columns returned by the statement. An
+// expression that depends on a masked column is masked with the policy of
+// that column, and with the NULL_OUT policy if it depends on several masked
+// columns with different policies.
+func columnMasks(ctx *plancontext.PlanningContext, stmt sqlparser.SelectStatement) ([]engine.MaskedColumn, error) {
+	ts, ok := stmt.(sqlparser.TableStatement)
+	if !ok {
+		return nil, nil
+	}
+	var masks []*vschemapb.ColumnMask
+	for _, sel := range sqlparser.GetAllSelects(ts) {
+		for i, expr := range sel.GetColumns() {
+			if i == len(masks) {
+				masks = append(masks, nil)
+			}
+			switch expr := expr.(type) {
+			case *sqlparser.AliasedExpr:
+				masks[i] = mergeMasks(masks[i], exprMask(ctx, expr.Expr))
+			case *sqlparser.StarExpr:
+				if ctx.SemTable.HasMaskedColumns() {
+					return nil, vterrors.VT12001("expanding '*' on tables with masked columns when the column list is not authoritative")
+				}
+			}
+		}
+	}
+
+	var result []engine.MaskedColumn
+	for offset, mask := range masks {
+		if mask != nil {
+			result = append(result, engine.MaskedColumn{Offset: offset, Mask: mask})
+		}
+	}
+	return result, nil
+}
+
+// checkInsertSelectMasks rejects the INSERT ... SELECT statements that read
+// masked columns: the values would be copied unmasked to the inserted rows.
+func checkInsertSelectMasks(ctx *plancontext.PlanningContext, ins *sqlparser.Insert) error {
+	sel, ok := ins.Rows.(sqlparser.SelectStatement)
+	if !ok || !ctx.SemTable.HasMaskedColumns() {
+		return nil
+	}
+	masks, err := columnMasks(ctx, sel)
+	if err != nil {
+		return err
+	}
+	if len(masks) > 0 {
+		return vterrors.VT12001("INSERT ... SELECT of masked columns")
+	}
+	return nil
+}
+
+// exprMask returns the mask of an expression, or nil if it does not depend
+// on any masked column.
+func exprMask(ctx *plancontext.PlanningContext, expr sqlparser.Expr) *vschemapb.ColumnMask {
+	var mask *vschemapb.ColumnMask
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		col, ok := node.(*sqlparser.ColName)
+		if !ok {
+			return true, nil
+		}
+		mask = mergeMasks(mask, columnMask(ctx, col))
+		return false, nil
+	}, expr)
+	return mask
+}
+
+// columnMask returns the mask of a column, following the columns of derived
+// tables and CTEs down to the columns of the tables of the vschema.
+func columnMask(ctx *plancontext.PlanningContext, col *sqlparser.ColName) *vschemapb.ColumnMask {
+	ti, err := ctx.SemTable.TableInfoForExpr(col)
+	if err != nil {
+		return nil
+	}
+	if vt := ti.GetVindexTable(); vt != nil {
+		for _, c := range vt.Columns {
+			if c.Name.Equal(col.Name) {
+				return c.Mask
+			}
+		}
+		return nil
+	}
+	// the columns that are not defined by the table are rewritten to
+	// copies that are unknown to the semantic table, so they are not masked
+	return exprMask(ctx, semantics.RewriteDerivedTableExpression(col, ti))
+}
+
+func mergeMasks(a, b *vschemapb.ColumnMask) *vschemapb.ColumnMask {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || proto.Equal(a, b):
+		return a
+	default:
+		return nullOutMask
+	}
+}
@@ -362,8 +362,12 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 
 		aggrParam := engine.NewAggregateParam(aggr.OpCode, aggr.ColOffset, nil, aggr.Alias, ctx.VSchema.Environment().CollationEnv())
 		aggrParam.Func = aggr.Func
-		if gcFunc, isGc := aggrParam.Func.(*sqlparser.GroupConcatExpr); isGc && gcFunc.Separator == "" {
-			gcFunc.Separator = sqlparser.GroupConcatDefaultSeparator
+		if aggr.GroupConcat != nil {
+			gc, err := createGroupConcatParams(ctx, aggr)
+			if err != nil {
+				return nil, err
+			}
+			aggrParam.GroupConcat = gc
 		}
 		aggrParam.Original = aggr.Original
 		aggrParam.OrigOpcode = aggr.OriginalOpCode
@@ -418,18 +422,74 @@ func transformOrdering(ctx *plancontext.PlanningContext, op *operators.Ordering)
 		return nil, err
 	}
 
+	if concatenate, ok := plan.(*engine.Concatenate); ok && op.Merge {
+		// the sources of the UNION are sorted already, so merging them is enough
+		concatenate.OrderBy = createMemorySortOrderBy(ctx, op)
+		concatenate.TruncateColumnCount = op.ResultColumns
+		return concatenate, nil
+	}
+
 	return createMemorySort(ctx, plan, op)
 }
 
+func createGroupConcatParams(ctx *plancontext.PlanningContext, aggr operators.Aggr) (*engine.GroupConcatParams, error) {
+	gcFunc := aggr.Func.(*sqlparser.GroupConcatExpr)
+	gc := &engine.GroupConcatParams{
+		ArgCols: aggr.GroupConcat.ArgOffsets,
+	}
+	if gcFunc.Distinct {
+		for idx, arg := range gcFunc.Exprs {
+			typ, _ := ctx.TypeForExpr(arg)
+			gc.DistinctCols = append(gc.DistinctCols, engine.CheckCol{
+				Col:          aggr.GroupConcat.ArgOffsets[idx],
+				Type:         typ,
+				CollationEnv: ctx.VSchema.Environment().CollationEnv(),
+			})
+		}
+	}
+	for _, order := range aggr.GroupConcat.OrderBy {
+		typ, _ := ctx.TypeForExpr(order.Expr)
+		gc.OrderBy = append(gc.OrderBy, evalengine.OrderByParams{
+			Col:             order.ColOffset,
+			WeightStringCol: order.WSOffset,
+			Desc:            order.Desc,
+			Type:            typ,
+			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
+		})
+	}
+	if gcFunc.Limit != nil {
+		cfg := &evalengine.Config{
+			Collation:   ctx.VSchema.ConnCollation(),
+			Environment: ctx.VSchema.Environment(),
+		}
+		var err error
+		gc.Limit, err = evalengine.Translate(gcFunc.Limit.Rowcount, cfg)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "unexpected expression in LIMIT")
+		}
+		if gcFunc.Limit.Offset != nil {
+			gc.Offset, err = evalengine.Translate(gcFunc.Limit.Offset, cfg)
+			if err != nil {
+				return nil, vterrors.Wrap(err, "unexpected expression in OFFSET")
+			}
+		}
+	}
+	return gc, nil
+}
+
 func createMemorySort(ctx *plancontext.PlanningContext, src engine.Primitive, ordering *operators.Ordering) (engine.Primitive, error) {
-	prim := &engine.MemorySort{
+	return &engine.MemorySort{
 		Input:               src,
 		TruncateColumnCount: ordering.ResultColumns,
-	}
+		OrderBy:             createMemorySortOrderBy(ctx, ordering),
+	}, nil
+}
 
+func createMemorySortOrderBy(ctx *plancontext.PlanningContext, ordering *operators.Ordering) evalengine.Comparison {
+	var orderBy evalengine.Comparison
 	for idx, order := range ordering.Order {
 		typ, _ := ctx.TypeForExpr(order.SimplifiedExpr)
-		prim.OrderBy = append(prim.OrderBy, evalengine.OrderByParams{
+		orderBy = append(orderBy, evalengine.OrderByParams{
 			Col:             ordering.Offset[idx],
 			WeightStringCol: ordering.WOffset[idx],
 			Desc:            order.Inner.Direction == sqlparser.DescOrder,
@@ -437,8 +497,7 @@ func createMemorySort(ctx *plancontext.PlanningContext, src engine.Primitive, or
 			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
 		})
 	}
-
-	return prim, nil
+	return orderBy
 }
 
 func transformProjection(ctx *plancontext.PlanningContext, op *operators.Projection) (engine.Primitive, error) {
@@ -615,6 +674,10 @@ func transformRoutePlan(ctx *plancontext.PlanningContext, op *operators.Route) (
 		stmtWithComments.SetComments(comments)
 	}
 
+	if err := checkPartitionSelection(op, stmt); err != nil {
+		return nil, err
+	}
+
 	hints := getHints(op.Comments)
 	switch stmt := stmt.(type) {
 	case sqlparser.SelectStatement:
@@ -653,6 +716,7 @@ func buildRoutePrimitive(ctx *plancontext.PlanningContext, op *operators.Route,
 	}
 
 	prepareTheAST(stmt)
+	eroute.ChunkableLists = chunkableLists(ctx, op, eroute, stmt)
 
 	res, err := WireupRoute(ctx, eroute, stmt)
 	if err != nil {
@@ -796,13 +860,43 @@ func buildUpdatePrimitive(
 	}, nil
 }
 
+// checkPartitionSelection fails for a route that can span several shards if
+// its statement selects partitions. Partitions are defined per shard by
+// MySQL, so we only pass the selection through to a single shard. Inserts
+// are split in single shard statements, so they are not checked.
+func checkPartitionSelection(op *operators.Route, stmt sqlparser.Statement) error {
+	opCode := op.Routing.OpCode()
+	if opCode.IsSingleShard() || opCode == engine.None {
+		return nil
+	}
+	var table string
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return nil
+	case *sqlparser.Delete:
+		if len(stmt.Partitions) > 0 {
+			table = sqlparser.String(stmt.Targets)
+		}
+	}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if tbl, ok := node.(*sqlparser.AliasedTableExpr); ok && len(tbl.Partitions) > 0 && table == "" {
+			table = sqlparser.String(tbl.Expr)
+		}
+		return table == "", nil
+	}, stmt)
+	if table == "" {
+		return nil
+	}
+	return vterrors.VT12001(fmt.Sprintf("partition selection on %s in a cross-shard query", table))
+}
+
 func buildDeletePrimitive(ctx *plancontext.PlanningContext, rb *operators.Route, dmlOp operators.Operator, stmt *sqlparser.Delete, hints *queryHints) (engine.Primitive, error) {
 	del := dmlOp.(*operators.Delete)
 
 	var vindexes []*vindexes.ColumnVindex
 	vQuery := ""
 	if del.OwnedVindexQuery != nil {
-		del.OwnedVindexQuery.From = stmt.GetFrom()
+		del.OwnedVindexQuery.From = fromWithPartitions(stmt)
 		del.OwnedVindexQuery.Where = stmt.Where
 		vQuery = sqlparser.String(del.OwnedVindexQuery)
 		vindexes = del.Target.VTable.Owned
@@ -813,6 +907,23 @@ func buildDeletePrimitive(ctx *plancontext.PlanningContext, rb *operators.Route,
 	return &engine.Delete{DML: edml}, nil
 }
 
+// fromWithPartitions returns the FROM clause of the delete, with the
+// partition selection of a single table delete moved to its table, so that
+// the owned vindex query selects the same rows as the delete.
+func fromWithPartitions(del *sqlparser.Delete) sqlparser.TableExprs {
+	from := del.GetFrom()
+	if len(del.Partitions) == 0 || len(from) != 1 {
+		return from
+	}
+	tbl, ok := from[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return from
+	}
+	tbl = sqlparser.Clone(tbl)
+	tbl.Partitions = del.Partitions
+	return sqlparser.TableExprs{tbl}
+}
+
 func createDMLPrimitive(ctx *plancontext.PlanningContext, rb *operators.Route, hints *queryHints, vTbl *vindexes.BaseTable, query string, colVindexes []*vindexes.ColumnVindex, vindexQuery string) *engine.DML {
 	rp := newRoutingParams(ctx, rb.Routing.OpCode())
 	rb.Routing.UpdateRoutingParams(ctx, rp)
@@ -77,7 +77,7 @@ func (qb *queryBuilder) includeTable(op *Table) bool {
 	return cteTbl.Merged
 }
 
-func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.TableSet, hints sqlparser.IndexHints) {
+func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.TableSet, partitions sqlparser.Partitions, hints sqlparser.IndexHints) {
 	if tableID.NumberOfTables() == 1 && qb.ctx.SemTable != nil {
 		tblInfo, err := qb.ctx.SemTable.TableInfoFor(tableID)
 		if err != nil {
@@ -93,13 +93,14 @@ func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.
 		Name:      sqlparser.NewIdentifierCS(tableName),
 		Qualifier: sqlparser.NewIdentifierCS(db),
 	}
-	qb.addTableExpr(tableName, alias, tableID, tableExpr, hints, nil)
+	qb.addTableExpr(tableName, alias, tableID, tableExpr, partitions, hints, nil)
 }
 
 func (qb *queryBuilder) addTableExpr(
 	tableName, alias string,
 	tableID semantics.TableSet,
 	tblExpr sqlparser.SimpleTableExpr,
+	partitions sqlparser.Partitions,
 	hints sqlparser.IndexHints,
 	columnAliases sqlparser.Columns,
 ) {
@@ -108,7 +109,7 @@ func (qb *queryBuilder) addTableExpr(
 	}
 	tbl := &sqlparser.AliasedTableExpr{
 		Expr:       tblExpr,
-		Partitions: nil,
+		Partitions: partitions,
 		As:         sqlparser.NewIdentifierCS(alias),
 		Hints:      hints,
 		Columns:    columnAliases,
@@ -270,7 +271,7 @@ func (qb *queryBuilder) recursiveCteWith(other *queryBuilder, name, alias string
 		},
 	}
 
-	qb.addTable("", name, alias, "", nil)
+	qb.addTable("", name, alias, "", nil, nil)
 }
 
 type FromStatement interface {
@@ -357,6 +358,10 @@ func (qb *queryBuilder) sortTables() {
 		if !isSel {
 			return true, nil
 		}
+		if hasLateralDerivedTable(sel.From) {
+			// a LATERAL derived table must stay after the tables it references
+			return true, nil
+		}
 		ts := &tableSorter{
 			sel: sel,
 			tbl: qb.ctx.SemTable,
@@ -366,6 +371,19 @@ func (qb *queryBuilder) sortTables() {
 	}, qb.stmt)
 }
 
+func hasLateralDerivedTable(from []sqlparser.TableExpr) bool {
+	for _, expr := range from {
+		ate, ok := expr.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			continue
+		}
+		if dt, ok := ate.Expr.(*sqlparser.DerivedTable); ok && dt.Lateral {
+			return true
+		}
+	}
+	return false
+}
+
 type tableSorter struct {
 	sel *sqlparser.Select
 	tbl *semantics.SemTable
@@ -451,13 +469,7 @@ func buildQuery(op Operator, qb *queryBuilder) {
 	case *Union:
 		buildUnion(op, qb)
 	case *Distinct:
-		buildQuery(op.Source, qb)
-		statement := qb.asSelectStatement()
-		d, ok := statement.(sqlparser.Distinctable)
-		if !ok {
-			panic(vterrors.VT13001("expected a select statement with distinct"))
-		}
-		d.MakeDistinct()
+		buildDistinct(op, qb)
 	case *Update:
 		buildUpdate(op, qb)
 	case *Delete:
@@ -473,8 +485,9 @@ func buildQuery(op Operator, qb *queryBuilder) {
 
 func buildDelete(op *Delete, qb *queryBuilder) {
 	qb.stmt = &sqlparser.Delete{
-		Ignore:  op.Ignore,
-		Targets: sqlparser.TableNames{op.Target.Name},
+		Ignore:     op.Ignore,
+		Targets:    sqlparser.TableNames{op.Target.Name},
+		Partitions: op.Partitions,
 	}
 	buildQuery(op.Source, qb)
 
@@ -538,8 +551,9 @@ func buildAggregation(op *Aggregator, qb *queryBuilder) {
 		sel := qb.asSelectStatement()
 		qb.stmt = nil
 		qb.addTableExpr(op.DT.Alias, op.DT.Alias, TableID(op), &sqlparser.DerivedTable{
-			Select: sel,
-		}, nil, op.DT.Columns)
+			Lateral: op.DT.Lateral,
+			Select:  sel,
+		}, nil, nil, op.DT.Columns)
 	}
 }
 
@@ -575,7 +589,7 @@ func buildTable(op *Table, qb *queryBuilder) {
 	if op.QTable.IsInfSchema {
 		dbName = op.QTable.Table.Qualifier.String()
 	}
-	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Hints)
+	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Partitions, op.QTable.Alias.Hints)
 	for _, pred := range op.QTable.Predicates {
 		qb.addPredicate(pred)
 	}
@@ -602,8 +616,9 @@ func buildProjection(op *Projection, qb *queryBuilder) {
 		sel := qb.asSelectStatement()
 		qb.stmt = nil
 		qb.addTableExpr(op.DT.Alias, op.DT.Alias, TableID(op), &sqlparser.DerivedTable{
-			Select: sel,
-		}, nil, op.DT.Columns)
+			Lateral: op.DT.Lateral,
+			Select:  sel,
+		}, nil, nil, op.DT.Columns)
 	}
 
 	if !isSel {
@@ -613,6 +628,32 @@ func buildProjection(op *Projection, qb *queryBuilder) {
 	}
 }
 
+func buildDistinct(op *Distinct, qb *queryBuilder) {
+	buildQuery(op.Source, qb)
+	statement := qb.asSelectStatement()
+	d, ok := statement.(sqlparser.Distinctable)
+	if !ok {
+		panic(vterrors.VT13001("expected a select statement with distinct"))
+	}
+	d.MakeDistinct()
+
+	// a derived table projection leaves the outer SELECT without columns, expecting an operator
+	// above it to add them. DISTINCT needs the derived columns, or it would be applied to a constant
+	proj, ok := op.Source.(*Projection)
+	sel, isSel := statement.(*sqlparser.Select)
+	if !ok || proj.DT == nil || !isSel || sel.GetColumnCount() > 0 {
+		return
+	}
+	tableName := sqlparser.NewTableName(proj.DT.Alias)
+	for i, col := range proj.GetColumns(qb.ctx) {
+		columnName := col.ColumnName()
+		if i < len(proj.DT.Columns) {
+			columnName = proj.DT.Columns[i].String()
+		}
+		qb.addProjection(aeWrap(sqlparser.NewColNameWithQualifier(columnName, tableName)))
+	}
+}
+
 func buildApplyJoin(op *ApplyJoin, qb *queryBuilder) {
 	preds := slice.Map(op.JoinPredicates.columns, func(jc applyJoinColumn) sqlparser.Expr {
 		if jc.JoinPredicateID != nil {
@@ -689,8 +730,9 @@ func buildDerivedUnion(op *Horizon, qb *queryBuilder, union *sqlparser.Union) {
 	union.Distinct = opQuery.Distinct
 
 	qb.addTableExpr(op.Alias, op.Alias, TableID(op), &sqlparser.DerivedTable{
-		Select: union,
-	}, nil, op.ColumnAliases)
+		Lateral: op.Lateral,
+		Select:  union,
+	}, nil, nil, op.ColumnAliases)
 }
 
 func buildDerivedSelect(op *Horizon, qb *queryBuilder, sel *sqlparser.Select) {
@@ -705,8 +747,9 @@ func buildDerivedSelect(op *Horizon, qb *queryBuilder, sel *sqlparser.Select) {
 	sel.SelectExprs = opQuery.SelectExprs
 	sel.Distinct = opQuery.Distinct
 	qb.addTableExpr(op.Alias, op.Alias, TableID(op), &sqlparser.DerivedTable{
-		Select: sel,
-	}, nil, op.ColumnAliases)
+		Lateral: op.Lateral,
+		Select:  sel,
+	}, nil, nil, op.ColumnAliases)
 	for _, col := range op.Columns {
 		qb.addProjection(&sqlparser.AliasedExpr{Expr: col})
 	}
@@ -55,6 +55,19 @@ func tryPushAggregator(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 		return splitAvgAggregations(ctx, aggregator)
 	}
 
+	// a GROUP_CONCAT that needs all the rows of the group can't be split,
+	// so the whole aggregation is evaluated at the vtgate
+	if slices.ContainsFunc(aggregator.Aggregations, Aggr.needsAllRows) {
+		canPushDistinctAggr, distinctExprs := checkIfWeCanPush(ctx, aggregator)
+		if !canPushDistinctAggr {
+			if len(distinctExprs) != 1 {
+				errDistinctAggrWithMultiExpr(nil)
+			}
+			aggregator.DistinctExpr = distinctExprs[0]
+		}
+		return aggregator, NoRewrite
+	}
+
 	switch src := aggregator.Source.(type) {
 	case *Route:
 		// if we have a single sharded route, we can push it down
@@ -212,7 +225,8 @@ func checkIfWeCanPush(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 	var differentExpr *sqlparser.AliasedExpr
 
 	for _, aggr := range aggregator.Aggregations {
-		if !aggr.Distinct {
+		// the vtgate removes the duplicate values of a GROUP_CONCAT itself
+		if !aggr.Distinct || aggr.OpCode == opcode.AggregateGroupConcat {
 			continue
 		}
 
@@ -19,6 +19,7 @@ package operators
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/slice"
@@ -432,9 +433,7 @@ func (aggr Aggr) getPushColumn() sqlparser.Expr {
 	case opcode.AggregateCountStar:
 		return sqlparser.NewIntLiteral("1")
 	case opcode.AggregateGroupConcat:
-		if len(aggr.Func.GetArgs()) > 1 {
-			panic(vterrors.VT12001("group_concat with more than 1 column"))
-		}
+		// any other arguments are added by planGroupConcatOffsets
 		return aggr.Func.GetArg()
 	default:
 		if len(aggr.Func.GetArgs()) > 1 {
@@ -475,6 +474,46 @@ func (a *Aggregator) planOffsetsNotPushed(ctx *plancontext.PlanningContext) {
 	}
 
 	a.pushRemainingGroupingColumnsAndWeightStrings(ctx)
+	a.planGroupConcatOffsets(ctx)
+}
+
+// planGroupConcatOffsets adds the columns needed to evaluate a GROUP_CONCAT
+// from the values of its arguments, when it has more than one argument or
+// needs all the rows of the group.
+func (a *Aggregator) planGroupConcatOffsets(ctx *plancontext.PlanningContext) {
+	for idx, aggr := range a.Aggregations {
+		f, ok := aggr.Func.(*sqlparser.GroupConcatExpr)
+		if !ok || (len(f.Exprs) == 1 && !aggr.needsAllRows()) {
+			continue
+		}
+
+		gc := &GroupConcatOffsets{ArgOffsets: []int{aggr.ColOffset}}
+		for _, arg := range f.Exprs[1:] {
+			gc.ArgOffsets = append(gc.ArgOffsets, a.internalAddColumn(ctx, aeWrap(arg), false))
+		}
+		for _, order := range f.OrderBy {
+			expr := order.Expr
+			// a position refers to an argument of the GROUP_CONCAT
+			if lit, ok := expr.(*sqlparser.Literal); ok && lit.Type == sqlparser.IntVal {
+				pos, err := strconv.Atoi(lit.Val)
+				if err != nil || pos < 1 || pos > len(f.Exprs) {
+					panic(vterrors.VT03014(lit.Val, "order clause"))
+				}
+				expr = f.Exprs[pos-1]
+			}
+			orderBy := GroupConcatOrder{
+				Expr:      expr,
+				Desc:      order.Direction == sqlparser.DescOrder,
+				ColOffset: a.internalAddColumn(ctx, aeWrap(expr), false),
+				WSOffset:  -1,
+			}
+			if ctx.NeedsWeightString(expr) {
+				orderBy.WSOffset = a.internalAddWSColumn(ctx, orderBy.ColOffset, aeWrap(weightStringFor(expr)))
+			}
+			gc.OrderBy = append(gc.OrderBy, orderBy)
+		}
+		a.Aggregations[idx].GroupConcat = gc
+	}
 }
 
 func (a *Aggregator) addIfAggregationColumn(ctx *plancontext.PlanningContext, colIdx int) int {
@@ -169,10 +169,6 @@ func (jpc *joinPredicateCollector) inspectPredicate(
 }
 
 func createOperatorFromUnion(ctx *plancontext.PlanningContext, node *sqlparser.Union) Operator {
-	_, isRHSUnion := node.Right.(*sqlparser.Union)
-	if isRHSUnion {
-		panic(vterrors.VT12001("nesting of UNIONs on the right-hand side"))
-	}
 	opLHS := translateQueryToOpForUnion(ctx, node.Left)
 	opRHS := translateQueryToOpForUnion(ctx, node.Right)
 	lexprs := ctx.SemTable.SelectExprs(node.Left)
@@ -329,6 +325,7 @@ func getOperatorFromAliasedTableExpr(ctx *plancontext.PlanningContext, tableExpr
 			horizon.TableId = &tableID
 			horizon.Alias = tableExpr.As.String()
 			horizon.ColumnAliases = tableExpr.Columns
+			horizon.Lateral = tbl.Lateral
 			qp := CreateQPFromSelectStatement(ctx, tbl.Select)
 			horizon.QP = qp
 		}
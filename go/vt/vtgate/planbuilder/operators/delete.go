@@ -29,6 +29,9 @@ import (
 type Delete struct {
 	*DMLCommon
 
+	// Partitions is the partition selection of a single table delete.
+	Partitions sqlparser.Partitions
+
 	noColumns
 	noPredicates
 }
@@ -252,6 +255,7 @@ func createDeleteOperator(ctx *plancontext.PlanningContext, del *sqlparser.Delet
 			Target:           targetTbl,
 			OwnedVindexQuery: ovq,
 		},
+		Partitions: del.Partitions,
 	}
 
 	if del.Limit != nil {
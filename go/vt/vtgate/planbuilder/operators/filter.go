@@ -102,9 +102,10 @@ func (f *Filter) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
 
 func (f *Filter) planOffsets(ctx *plancontext.PlanningContext) Operator {
 	cfg := &evalengine.Config{
-		ResolveType: ctx.TypeForExpr,
-		Collation:   ctx.SemTable.Collation,
-		Environment: ctx.VSchema.Environment(),
+		ResolveType:      ctx.TypeForExpr,
+		Collation:        ctx.SemTable.Collation,
+		Environment:      ctx.VSchema.Environment(),
+		SpeculativeTypes: true,
 	}
 
 	predicate := sqlparser.AndExpressions(f.Predicates...)
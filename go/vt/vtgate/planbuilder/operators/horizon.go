@@ -41,6 +41,7 @@ type Horizon struct {
 	TableId       *semantics.TableSet
 	Alias         string
 	ColumnAliases sqlparser.Columns // derived tables can have their column aliases specified outside the subquery
+	Lateral       bool              // LATERAL derived tables can reference the tables that come before them in the FROM clause
 
 	// QP contains the QueryProjection for this op
 	QP *QueryProjection
@@ -96,7 +97,8 @@ func (h *Horizon) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.
 	}
 
 	newExpr := semantics.RewriteDerivedTableExpression(expr, tableInfo)
-	if ctx.ContainsAggr(newExpr) {
+	if ctx.ContainsAggr(newExpr) || ctx.ContainsWindowFunc(newExpr) {
+		// window functions are evaluated after WHERE, so they can't be filtered on inside the derived table
 		return newFilter(h, expr)
 	}
 	h.Source = h.Source.AddPredicate(ctx, newExpr)
@@ -58,6 +58,7 @@ func expandUnionHorizon(ctx *plancontext.PlanningContext, horizon *Horizon, unio
 			TableID: *horizon.TableId,
 			Alias:   horizon.Alias,
 			Columns: horizon.ColumnAliases,
+			Lateral: horizon.Lateral,
 		}
 		op = proj
 	}
@@ -87,6 +88,19 @@ func expandSelectHorizon(ctx *plancontext.PlanningContext, horizon *Horizon, sel
 		}
 	}
 
+	var vtgateWindow bool
+	if qp.HasWindow && windowNeedsVTGate(horizon.src(), qp) {
+		// The rows of a window partition can come from several shards, so the
+		// window functions are evaluated at vtgate, under the projection that
+		// uses their values
+		if qp.NeedsAggregation() || sel.Having != nil {
+			panic(vterrors.VT12001("window functions with aggregation or HAVING in a cross-shard query"))
+		}
+		horizon.Source = newVTGateWindow(ctx, horizon.src(), qp)
+		vtgateWindow = true
+		extracted = append(extracted, "Window")
+	}
+
 	op := createProjectionFromSelect(ctx, horizon)
 	if qp.HasAggr {
 		extracted = append(extracted, "Aggregation")
@@ -101,7 +115,7 @@ func expandSelectHorizon(ctx *plancontext.PlanningContext, horizon *Horizon, sel
 		extracted = append(extracted, "Filter")
 	}
 
-	if qp.HasWindow {
+	if qp.HasWindow && !vtgateWindow {
 		// Window functions are evaluated after HAVING but before DISTINCT, ORDER BY, and LIMIT.
 		// SQL execution order: Projection → Aggregation → HAVING → Window → Distinct → Order → Limit
 		// We wrap the current operator (which is either a Projection or Aggregation)
@@ -202,6 +216,7 @@ func createProjectionFromSelect(ctx *plancontext.PlanningContext, horizon *Horiz
 			TableID: *horizon.TableId,
 			Alias:   horizon.Alias,
 			Columns: horizon.ColumnAliases,
+			Lateral: horizon.Lateral,
 		}
 	}
 
@@ -254,6 +254,26 @@ func findDefault(vTbl *vindexes.BaseTable, pCol sqlparser.IdentifierCI) sqlparse
 	panic(vterrors.VT03014(pCol.String(), vTbl.Name.String()))
 }
 
+// columnDefaults resolves the DEFAULT(col) expressions of the columns of the table.
+// A nullable column without a default value defaults to NULL.
+func columnDefaults(vTbl *vindexes.BaseTable) evalengine.DefaultResolver {
+	return func(name string) (sqlparser.Expr, bool) {
+		for _, column := range vTbl.Columns {
+			if !column.Name.EqualString(name) {
+				continue
+			}
+			if column.Default != nil {
+				return column.Default, true
+			}
+			if column.Nullable {
+				return &sqlparser.NullVal{}, true
+			}
+			return nil, false
+		}
+		return nil, false
+	}
+}
+
 type uComp struct {
 	idx int
 	def sqlparser.Expr
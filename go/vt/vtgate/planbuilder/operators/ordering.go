@@ -32,6 +32,10 @@ type Ordering struct {
 
 	Order         []OrderBy
 	ResultColumns int
+
+	// Merge is set when the input is a UNION ALL whose sources are each sorted by
+	// Order, so the rows of the sources only have to be merged.
+	Merge bool
 }
 
 func (o *Ordering) Clone(inputs []Operator) Operator {
@@ -109,6 +113,9 @@ func (o *Ordering) ShortDescription() string {
 	ordering := slice.Map(o.Order, func(o OrderBy) string {
 		return sqlparser.String(o.SimplifiedExpr)
 	})
+	if o.Merge {
+		return "merge " + strings.Join(ordering, ", ")
+	}
 	return strings.Join(ordering, ", ")
 }
 
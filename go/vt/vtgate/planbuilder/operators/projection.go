@@ -47,6 +47,7 @@ type (
 		TableID semantics.TableSet
 		Alias   string
 		Columns sqlparser.Columns
+		Lateral bool
 	}
 )
 
@@ -331,6 +332,22 @@ func (p *Projection) AddWSColumn(ctx *plancontext.PlanningContext, offset int, u
 	return p.addProjExpr(pe)
 }
 
+// evalExprFor returns the eval expression of the projected column the expression is, or nil
+// if the expression is not projected
+func (p *Projection) evalExprFor(ctx *plancontext.PlanningContext, expr sqlparser.Expr) sqlparser.Expr {
+	cols, ok := p.Columns.(AliasedProjections)
+	if !ok {
+		panic(vterrors.VT09015())
+	}
+	var evalExpr sqlparser.Expr
+	for _, projExpr := range cols {
+		if ctx.SemTable.EqualsExprWithDeps(expr, projExpr.ColExpr) {
+			evalExpr = projExpr.EvalExpr
+		}
+	}
+	return evalExpr
+}
+
 func (p *Projection) AddColumn(ctx *plancontext.PlanningContext, reuse bool, addToGroupBy bool, ae *sqlparser.AliasedExpr) int {
 	return p.addColumn(ctx, reuse, addToGroupBy, ae, true)
 }
@@ -351,19 +368,22 @@ func (p *Projection) addColumn(
 		}
 	}
 
-	// ok, we need to add the expression. let's check if we should rewrite a ws expression first
-	ws, ok := expr.(*sqlparser.WeightStringFuncExpr)
-	if ok {
-		cols, ok := p.Columns.(AliasedProjections)
-		if !ok {
-			panic(vterrors.VT09015())
+	// ok, we need to add the expression. let's check if we should rewrite a ws or a collate expression first
+	switch e := expr.(type) {
+	case *sqlparser.WeightStringFuncExpr:
+		// if someone is asking for the ws of something we are projecting,
+		// we need push down the ws of the eval expression
+		if evalExpr := p.evalExprFor(ctx, e.Expr); evalExpr != nil {
+			e.Expr = evalExpr
 		}
-		for _, projExpr := range cols {
-			if ctx.SemTable.EqualsExprWithDeps(ws.Expr, projExpr.ColExpr) {
-				// if someone is asking for the ws of something we are projecting,
-				// we need push down the ws of the eval expression
-				ws.Expr = projExpr.EvalExpr
-			}
+	case *sqlparser.CollateExpr:
+		// the same goes for a COLLATE clause on something we are projecting, such as
+		// the ORDER BY expression of a UNION that is wrapped in a derived table.
+		// The expression is copied, since it is the one the ordering refers to.
+		if evalExpr := p.evalExprFor(ctx, e.Expr); evalExpr != nil {
+			collated := &sqlparser.CollateExpr{Expr: evalExpr, Collation: e.Collation}
+			ctx.SemTable.CopyExprInfo(e, collated)
+			expr = collated
 		}
 	}
 
@@ -588,9 +608,10 @@ func (p *Projection) planOffsets(ctx *plancontext.PlanningContext) Operator {
 
 		// for everything else, we'll turn to the evalengine
 		eexpr, err := evalengine.Translate(rewritten, &evalengine.Config{
-			ResolveType: ctx.TypeForExpr,
-			Collation:   ctx.SemTable.Collation,
-			Environment: ctx.VSchema.Environment(),
+			ResolveType:      ctx.TypeForExpr,
+			Collation:        ctx.SemTable.Collation,
+			Environment:      ctx.VSchema.Environment(),
+			SpeculativeTypes: true,
 		})
 		if err != nil {
 			panic(err)
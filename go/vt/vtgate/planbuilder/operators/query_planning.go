@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators/predicates"
 
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -595,6 +596,11 @@ func setUpperLimit(in *Limit) (Operator, *ApplyResult) {
 				// we can't push limits down if we have a group by
 				return SkipChildren
 			}
+		case *Window:
+			if op.evaluatedAtVTGate() {
+				// the window functions need all the rows of the input
+				return SkipChildren
+			}
 		case *Route:
 			ast := &sqlparser.Limit{Rowcount: sqlparser.NewArgument(engine.UpperLimitStr)}
 			src := op.Source
@@ -663,6 +669,8 @@ func tryPushOrdering(ctx *plancontext.PlanningContext, in *Ordering) (Operator,
 		return pushOrderingUnderAggr(ctx, in, src)
 	case *SubQueryContainer:
 		return pushOrderingToOuterOfSubqueryContainer(ctx, in, src)
+	case *Union:
+		return pushOrderingUnderUnion(ctx, in, src)
 	}
 	debugNoRewrite("ordering push blocked: unsupported source operator type %T", in.Source)
 	return in, NoRewrite
@@ -724,6 +732,97 @@ ordering:
 	return true
 }
 
+// pushOrderingUnderUnion sorts every source of a UNION ALL, so that each source can be sorted
+// where it runs, usually on the shards. The ordering stays on top of the UNION, where it only
+// has to merge the sorted rows of the sources.
+func pushOrderingUnderUnion(ctx *plancontext.PlanningContext, in *Ordering, union *Union) (Operator, *ApplyResult) {
+	if in.Merge {
+		debugNoRewrite("ordering push blocked: UNION sources are already sorted")
+		return in, NoRewrite
+	}
+	if union.distinct {
+		debugNoRewrite("ordering push blocked: cannot push ordering under UNION DISTINCT")
+		return in, NoRewrite
+	}
+
+	for _, src := range union.Sources {
+		if containsLimit(src) {
+			// an ordering on top of a LIMIT can't be pushed into the query of the source
+			debugNoRewrite("ordering push blocked: UNION source has a LIMIT")
+			return in, NoRewrite
+		}
+	}
+
+	sourceOrders := make([][]OrderBy, len(union.Sources))
+	for _, order := range in.Order {
+		orderExpr := order.SimplifiedExpr
+		// an explicit COLLATE clause decides how every source sorts the column
+		collate, hasCollate := orderExpr.(*sqlparser.CollateExpr)
+		if hasCollate {
+			orderExpr = collate.Expr
+		}
+		offset := union.columnOffset(ctx, orderExpr)
+		if offset < 0 {
+			debugNoRewrite("ordering push blocked: %s is not a column of the UNION", sqlparser.String(order.SimplifiedExpr))
+			return in, NoRewrite
+		}
+		// the sources must sort the rows the same way vtgate compares them when merging,
+		// so they all have to produce the same known type and collation
+		var typ evalengine.Type
+		for i, src := range union.Sources {
+			expr := src.GetColumns(ctx)[offset].Expr
+			if sqlparser.IsLiteral(expr) {
+				// ORDER BY <literal> means something else to MySQL
+				debugNoRewrite("ordering push blocked: UNION source column %s is a literal", sqlparser.String(expr))
+				return in, NoRewrite
+			}
+			srcTyp, found := ctx.TypeForExpr(expr)
+			if !found || srcTyp.Type() == sqltypes.Unknown {
+				debugNoRewrite("ordering push blocked: unknown type for UNION source column %s", sqlparser.String(expr))
+				return in, NoRewrite
+			}
+			switch {
+			case hasCollate:
+				// the collation of the sources doesn't matter, but they must all be strings
+				if !sqltypes.IsText(srcTyp.Type()) {
+					debugNoRewrite("ordering push blocked: UNION source column %s is not a string", sqlparser.String(expr))
+					return in, NoRewrite
+				}
+				collated := &sqlparser.CollateExpr{Expr: expr, Collation: collate.Collation}
+				ctx.SemTable.CopyExprInfo(collate, collated)
+				expr = collated
+			case i == 0:
+				typ = srcTyp
+			case srcTyp.Type() != typ.Type() || srcTyp.Collation() != typ.Collation():
+				debugNoRewrite("ordering push blocked: UNION source column %s has a different type", sqlparser.String(expr))
+				return in, NoRewrite
+			}
+			sourceOrders[i] = append(sourceOrders[i], OrderBy{
+				Inner:          &sqlparser.Order{Expr: expr, Direction: order.Inner.Direction},
+				SimplifiedExpr: expr,
+			})
+		}
+	}
+
+	for i, src := range union.Sources {
+		union.Sources[i] = newOrdering(src, sourceOrders[i])
+	}
+	in.Merge = true
+	return in, Rewrote("push ordering under UNION ALL")
+}
+
+func containsLimit(op Operator) bool {
+	found := false
+	_ = Visit(op, func(op Operator) error {
+		if _, isLimit := op.(*Limit); isLimit {
+			found = true
+			return io.EOF
+		}
+		return nil
+	})
+	return found
+}
+
 // pushOrderingUnderAggr pushes the ORDER BY clause under the aggregation if possible,
 // to optimize the query plan by aligning the GROUP BY and ORDER BY clauses and
 // potentially removing redundant ORDER BY clauses.
@@ -861,6 +960,11 @@ func tryPushFilter(ctx *plancontext.PlanningContext, in *Filter) (Operator, *App
 
 func pushFilterUnderProjection(ctx *plancontext.PlanningContext, filter *Filter, projection *Projection) (Operator, *ApplyResult) {
 	for _, p := range filter.Predicates {
+		if ctx.ContainsWindowFunc(projection.DT.RewriteExpression(ctx, p)) {
+			debugNoRewrite("filter push blocked: predicate uses a window function of the projection")
+			return filter, NoRewrite
+		}
+
 		cantPush := false
 		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
 			if !mustFetchFromInput(ctx, node) {
@@ -931,6 +1035,10 @@ func tryPushDistinct(in *Distinct) (Operator, *ApplyResult) {
 		in.Source = src.Source
 		return in, Rewrote("remove ordering under distinct")
 	case *Window:
+		if src.evaluatedAtVTGate() {
+			debugNoRewrite("distinct push blocked: window functions are evaluated at vtgate")
+			return in, NoRewrite
+		}
 		if isDistinct(src.Source) {
 			debugNoRewrite("distinct push blocked: window source already has distinct")
 			return in, NoRewrite
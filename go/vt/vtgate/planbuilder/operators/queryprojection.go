@@ -86,6 +86,25 @@ type (
 		SubQueryExpression []*SubQuery // Subqueries associated with this aggregation
 
 		PushedDown bool // Whether the aggregation has been pushed down to the next layer
+
+		// GroupConcat is planned for a GROUP_CONCAT that is evaluated at the vtgate
+		// from the values of its arguments, instead of being pushed down.
+		GroupConcat *GroupConcatOffsets
+	}
+
+	// GroupConcatOffsets are the offsets of the arguments and of the ORDER BY
+	// expressions of a GROUP_CONCAT within the same aggregator
+	GroupConcatOffsets struct {
+		ArgOffsets []int
+		OrderBy    []GroupConcatOrder
+	}
+
+	// GroupConcatOrder is an ORDER BY expression of a GROUP_CONCAT
+	GroupConcatOrder struct {
+		Expr      sqlparser.Expr
+		Desc      bool
+		ColOffset int
+		WSOffset  int
 	}
 )
 
@@ -93,6 +112,14 @@ func (aggr Aggr) NeedsWeightString(ctx *plancontext.PlanningContext) bool {
 	return aggr.OpCode.NeedsComparableValues() && ctx.NeedsWeightString(aggr.Func.GetArg())
 }
 
+// needsAllRows returns true for a GROUP_CONCAT that has to be evaluated from
+// all the rows of a group, as concatenating the results of a GROUP_CONCAT on
+// every shard would not remove duplicates, nor order or limit the values.
+func (aggr Aggr) needsAllRows() bool {
+	f, ok := aggr.Func.(*sqlparser.GroupConcatExpr)
+	return ok && (f.Distinct || len(f.OrderBy) > 0 || f.Limit != nil)
+}
+
 func (aggr Aggr) GetTypeCollation(ctx *plancontext.PlanningContext) evalengine.Type {
 	if aggr.Func == nil {
 		return evalengine.NewUnknownType()
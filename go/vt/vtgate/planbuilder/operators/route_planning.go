@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"slices"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -239,6 +240,12 @@ func findBestJoin(
 	planCache opCacheMap,
 	crossJoinsOK bool,
 ) (bestPlan Operator, lIdx int, rIdx int) {
+	var candidates []Operator
+	if ctx.VSchema.PlannerDecisionsEnabled() {
+		defer func() {
+			recordJoinDecision(ctx, bestPlan, candidates)
+		}()
+	}
 	for i, lhs := range plans {
 		for j, rhs := range plans {
 			if i == j {
@@ -252,11 +259,12 @@ func findBestJoin(
 				continue
 			}
 			plan := getJoinFor(ctx, planCache, lhs, rhs, joinPredicates)
+			candidates = append(candidates, plan)
 			if _, ok := plan.(*Route); ok {
 				// we were able to merge the two inputs - we're done for now
 				return plan, i, j
 			}
-			if bestPlan == nil || CostOf(plan) < CostOf(bestPlan) {
+			if bestPlan == nil || cheaper(plan, bestPlan) {
 				bestPlan = plan
 				// remember which plans we based on, so we can remove them later
 				lIdx = i
@@ -267,6 +275,46 @@ func findBestJoin(
 	return bestPlan, lIdx, rIdx
 }
 
+// cheaper returns true if the join plan is cheaper than the other one. Of two
+// joins of the same cost, the one whose left-hand side is estimated to return
+// fewer rows is cheaper, as its right-hand side is queried for each of them.
+func cheaper(plan, other Operator) bool {
+	cost, otherCost := CostOf(plan), CostOf(other)
+	if cost != otherCost {
+		return cost < otherCost
+	}
+	rows, ok := drivingRows(plan)
+	otherRows, otherOK := drivingRows(other)
+	return ok && otherOK && rows < otherRows
+}
+
+// recordJoinDecision records the join chosen by findBestJoin, and the
+// candidates it rejected, as a planner decision.
+func recordJoinDecision(ctx *plancontext.PlanningContext, chosen Operator, candidates []Operator) {
+	if chosen == nil || len(candidates) < 2 {
+		// there was no choice to make
+		return
+	}
+	decision := engine.PlannerDecision{
+		Chosen: planCandidate(chosen),
+	}
+	for _, candidate := range candidates {
+		if candidate == chosen {
+			continue
+		}
+		decision.Rejected = append(decision.Rejected, planCandidate(candidate))
+	}
+	ctx.VSchema.PlannerDecision(decision)
+}
+
+func planCandidate(op Operator) engine.PlanCandidate {
+	candidate := engine.PlanCandidate{Plan: ToTree(op), Cost: CostOf(op)}
+	if rows, ok := drivingRows(op); ok {
+		candidate.DrivingRows = int64(math.Ceil(rows))
+	}
+	return candidate
+}
+
 func getJoinFor(ctx *plancontext.PlanningContext, cm opCacheMap, lhs, rhs Operator, joinPredicates []sqlparser.Expr) Operator {
 	solves := tableSetPair{left: TableID(lhs), right: TableID(rhs)}
 	cachedPlan := cm[solves]
@@ -295,6 +343,19 @@ func requiresSwitchingSides(ctx *plancontext.PlanningContext, op Operator) (requ
 	return
 }
 
+// isLateral returns true if the operator contains a LATERAL derived table,
+// which has to stay on the RHS of the join since it can depend on the LHS
+func isLateral(op Operator) (lateral bool) {
+	_ = Visit(op, func(current Operator) error {
+		if horizon, isHorizon := current.(*Horizon); isHorizon && horizon.Lateral {
+			lateral = true
+			return io.EOF
+		}
+		return nil
+	})
+	return
+}
+
 func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr, joinType sqlparser.JoinType) (Operator, *ApplyResult) {
 	jm := newJoinMerge(joinPredicates, joinType)
 	newPlan := jm.mergeJoinInputs(ctx, lhs, rhs)
@@ -304,7 +365,7 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 
 	checkCrossKeyspaceOp(ctx, lhs, rhs, "JOIN")
 
-	if len(joinPredicates) > 0 && requiresSwitchingSides(ctx, rhs) {
+	if len(joinPredicates) > 0 && requiresSwitchingSides(ctx, rhs) && !isLateral(rhs) {
 		if !joinType.IsCommutative() || requiresSwitchingSides(ctx, lhs) {
 			// we can't switch sides, so let's see if we can use a HashJoin to solve it
 			join := NewHashJoin(lhs, rhs, !joinType.IsInner())
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"io"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// drivingRows estimates the number of rows of the left-hand side of an
+// ApplyJoin, for each of which its right-hand side is queried. ok is false if
+// op is not an ApplyJoin, or if its rows can't be estimated.
+func drivingRows(op Operator) (rows float64, ok bool) {
+	join, isJoin := op.(*ApplyJoin)
+	if !isJoin {
+		return 0, false
+	}
+	return estimatedRows(join.LHS)
+}
+
+// estimatedRows estimates the number of rows returned by op from the sampled
+// column statistics of its table. ok is false if op reads from more than one
+// table, or if its table has no statistics.
+func estimatedRows(op Operator) (rows float64, ok bool) {
+	var table *Table
+	_ = Visit(op, func(current Operator) error {
+		tbl, isTable := current.(*Table)
+		if !isTable {
+			return nil
+		}
+		if table != nil {
+			table = nil
+			return io.EOF
+		}
+		table = tbl
+		return nil
+	})
+	if table == nil || table.VTable == nil || table.VTable.Statistics == nil {
+		return 0, false
+	}
+
+	stats := table.VTable.Statistics
+	rows = float64(stats.Rows)
+	if table.QTable != nil {
+		for _, pred := range table.QTable.Predicates {
+			rows *= selectivity(stats, pred)
+		}
+	}
+	return rows, true
+}
+
+// selectivity estimates the fraction of the rows of the table for which the
+// predicate is true. Values are assumed to be evenly distributed, and the
+// predicates the statistics say nothing about to be always true.
+func selectivity(stats *vindexes.TableStatistics, pred sqlparser.Expr) float64 {
+	switch pred := pred.(type) {
+	case *sqlparser.AndExpr:
+		return selectivity(stats, pred.Left) * selectivity(stats, pred.Right)
+	case *sqlparser.IsExpr:
+		col, ok := columnStatistics(stats, pred.Left)
+		if !ok {
+			return 1
+		}
+		switch pred.Right {
+		case sqlparser.IsNullOp:
+			return col.NullFraction
+		case sqlparser.IsNotNullOp:
+			return 1 - col.NullFraction
+		}
+	case *sqlparser.ComparisonExpr:
+		col, ok := columnStatistics(stats, pred.Left)
+		if !ok || col.DistinctValues == 0 {
+			return 1
+		}
+		if _, isCol := pred.Right.(*sqlparser.ColName); isCol {
+			return 1
+		}
+		perValue := (1 - col.NullFraction) / float64(col.DistinctValues)
+		switch pred.Operator {
+		case sqlparser.EqualOp, sqlparser.NullSafeEqualOp:
+			return perValue
+		case sqlparser.InOp:
+			if values, isTuple := pred.Right.(sqlparser.ValTuple); isTuple {
+				return min(1, perValue*float64(len(values)))
+			}
+		}
+	}
+	return 1
+}
+
+func columnStatistics(stats *vindexes.TableStatistics, expr sqlparser.Expr) (vindexes.ColumnStatistics, bool) {
+	col, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return vindexes.ColumnStatistics{}, false
+	}
+	cs, ok := stats.Columns[col.Name.Lowered()]
+	return cs, ok
+}
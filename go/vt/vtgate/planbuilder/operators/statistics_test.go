@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+func TestSelectivity(t *testing.T) {
+	stats := &vindexes.TableStatistics{
+		Rows: 1000,
+		Columns: map[string]vindexes.ColumnStatistics{
+			"a": {DistinctValues: 100},
+			"b": {DistinctValues: 10, NullFraction: 0.5},
+		},
+	}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"a = 1", 0.01},
+		{"A = 1", 0.01},
+		{"a <=> 1", 0.01},
+		{"a in (1, 2, 3)", 0.03},
+		{"b = 'x'", 0.05},
+		{"b is null", 0.5},
+		{"b is not null", 0.5},
+		{"a = 1 and b = 'x'", 0.0005},
+		// The statistics say nothing about these.
+		{"a = b", 1},
+		{"a > 1", 1},
+		{"c = 1", 1},
+		{"a = 1 or b = 'x'", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := sqlparser.NewTestParser().ParseExpr(tt.expr)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, selectivity(stats, expr), 1e-9)
+		})
+	}
+}
@@ -55,6 +55,17 @@ func isMergeable(ctx *plancontext.PlanningContext, query sqlparser.TableStatemen
 			return slices.ContainsFunc(node.GroupBy.Exprs, validVindex)
 		}
 
+		if node.Distinct {
+			// DISTINCT is a grouping on all the selected columns, so the same check applies
+			for _, col := range node.GetColumns() {
+				ae, ok := col.(*sqlparser.AliasedExpr)
+				if ok && validVindex(ae.Expr) {
+					return true
+				}
+			}
+			return false
+		}
+
 		// if we have grouping, we have already checked that it's safe, and don't need to check for aggregations
 		// but if we don't have groupings, we need to check if there are aggregations that will mess with us
 		if ctx.ContainsAggr(node.SelectExprs) {
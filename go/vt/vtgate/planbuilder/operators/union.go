@@ -312,6 +312,20 @@ func (u *Union) addWeightStringToOffset(ctx *plancontext.PlanningContext, argIdx
 	return
 }
 
+// columnOffset returns the offset of the UNION column that expr refers to, or -1
+func (u *Union) columnOffset(ctx *plancontext.PlanningContext, expr sqlparser.Expr) int {
+	if offset := u.FindCol(ctx, expr, false); offset >= 0 {
+		return offset
+	}
+	col, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return -1
+	}
+	return slices.IndexFunc(u.GetColumns(ctx), func(column *sqlparser.AliasedExpr) bool {
+		return col.Name.EqualString(column.ColumnName())
+	})
+}
+
 func (u *Union) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
 	columns := u.GetColumns(ctx)
 
@@ -364,6 +378,31 @@ func (u *Union) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.Sel
 
 func (u *Union) NoLHSTableSet() {}
 
+// Compact makes sure all sources of the UNION return the same number of columns. A source can
+// have columns that the other sources don't have, such as the weight_string() columns a
+// DISTINCT under the UNION added for its own use, and these have to be cut off.
+func (u *Union) Compact(ctx *plancontext.PlanningContext) (Operator, *ApplyResult) {
+	width := -1
+	for _, src := range u.Sources {
+		if cols := len(src.GetColumns(ctx)); width == -1 || cols < width {
+			width = cols
+		}
+	}
+
+	var res *ApplyResult
+	for i, src := range u.Sources {
+		selExprs := src.GetSelectExprs(ctx)
+		if len(selExprs) == width {
+			continue
+		}
+		if !tryTruncateColumnsAt(src, width) {
+			u.Sources[i] = createSimpleProjection(ctx, selExprs[:width], src)
+		}
+		res = res.Merge(Rewrote("truncated extra columns of UNION source"))
+	}
+	return u, res
+}
+
 func (u *Union) ShortDescription() string {
 	if u.distinct {
 		return "DISTINCT"
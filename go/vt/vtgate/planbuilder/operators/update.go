@@ -1056,7 +1056,7 @@ func buildChangedVindexesValues(
 		vindexValueMap := make(map[string]evalengine.Expr)
 		var compExprs []sqlparser.Expr
 		for _, vcol := range vindex.Columns {
-			subQueriesArgOnChangedVindex, compExprs = createAssignmentExpressions(ctx, assignments, vcol, subQueriesArgOnChangedVindex, vindexValueMap, compExprs)
+			subQueriesArgOnChangedVindex, compExprs = createAssignmentExpressions(ctx, table, assignments, vcol, subQueriesArgOnChangedVindex, vindexValueMap, compExprs)
 		}
 		if len(vindexValueMap) == 0 {
 			// Vindex not changing, continue
@@ -1108,6 +1108,7 @@ func initialQuery(ksidCols []sqlparser.IdentifierCI, table *vindexes.BaseTable)
 
 func createAssignmentExpressions(
 	ctx *plancontext.PlanningContext,
+	table *vindexes.BaseTable,
 	assignments []SetExpr,
 	vcol sqlparser.IdentifierCI,
 	subQueriesArgOnChangedVindex []string,
@@ -1125,9 +1126,10 @@ func createAssignmentExpressions(
 		}
 		found = true
 		pv, err := evalengine.Translate(assignment.Expr.EvalExpr, &evalengine.Config{
-			ResolveType: ctx.TypeForExpr,
-			Collation:   ctx.SemTable.Collation,
-			Environment: ctx.VSchema.Environment(),
+			ResolveType:    ctx.TypeForExpr,
+			ResolveDefault: columnDefaults(table),
+			Collation:      ctx.SemTable.Collation,
+			Environment:    ctx.VSchema.Environment(),
 		})
 		if err != nil {
 			panic(invalidUpdateExpr(assignment.Name.Name.String(), assignment.Expr.EvalExpr))
@@ -112,6 +112,12 @@ func createUpsertOperator(ctx *plancontext.PlanningContext, ins *sqlparser.Inser
 				if idx == -1 {
 					panic(vterrors.VT03014(sqlparser.String(vfExpr.Name), "field list"))
 				}
+				if def, ok := row[idx].(*sqlparser.Default); ok && def.ColName == "" {
+					// VALUES(col) of a row that inserts the DEFAULT keyword is the
+					// default of the column, which the update has to name.
+					cursor.Replace(&sqlparser.Default{ColName: vfExpr.Name.Name.String()})
+					return
+				}
 				cursor.Replace(row[idx])
 			}, nil).(sqlparser.Expr)
 			updExprs = append(updExprs, &sqlparser.UpdateExpr{
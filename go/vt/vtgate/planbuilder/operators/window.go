@@ -17,13 +17,67 @@ limitations under the License.
 package operators
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
+// Window is the place in the plan where the window functions of a query are
+// evaluated. When the window functions can be sent to MySQL along with the
+// rest of the query, the Window just passes its input through. Otherwise,
+// Funcs holds the window functions vtgate evaluates over all the rows of the
+// input, and the values of these functions are the first columns of the
+// Window, followed by the columns of the input.
 type Window struct {
 	unaryOperator
 	QP *QueryProjection
+
+	Funcs []*WindowFunc
+}
+
+// WindowFunc is a window function evaluated by vtgate.
+type WindowFunc struct {
+	Original sqlparser.WindowFunc
+	Alias    string
+	Opcode   opcode.WindowOpcode
+
+	// Offset is the number of rows LAG and LEAD look behind or ahead.
+	Offset int64
+	Frame  *evalengine.WindowFrame
+
+	// These are filled in during offset planning
+	ArgOffset, DefaultOffset int
+	PartitionBy              []WindowColumn
+	OrderBy                  []WindowColumn
+}
+
+// WindowColumn is a PARTITION BY or ORDER BY expression of a window, and the
+// offsets of its value and weight string in the input.
+type WindowColumn struct {
+	Expr      sqlparser.Expr
+	Desc      bool
+	ColOffset int
+	WSOffset  int
+}
+
+// windowFunctionsForVTGate are the window functions vtgate can evaluate
+var windowFunctionsForVTGate = []opcode.WindowOpcode{
+	opcode.WindowRowNumber,
+	opcode.WindowRank,
+	opcode.WindowDenseRank,
+	opcode.WindowLag,
+	opcode.WindowLead,
+	opcode.WindowFirstValue,
+	opcode.WindowLastValue,
 }
 
 func newWindow(source Operator, qp *QueryProjection) *Window {
@@ -33,39 +87,403 @@ func newWindow(source Operator, qp *QueryProjection) *Window {
 	}
 }
 
+// newVTGateWindow returns a Window evaluating all the window functions of the
+// query projection at vtgate.
+func newVTGateWindow(ctx *plancontext.PlanningContext, source Operator, qp *QueryProjection) *Window {
+	w := newWindow(source, qp)
+
+	var exprs []sqlparser.Expr
+	for _, se := range qp.SelectExprs {
+		ae, err := se.GetAliasedExpr()
+		if err != nil {
+			panic(err)
+		}
+		exprs = append(exprs, ae.Expr)
+	}
+	for _, order := range qp.OrderExprs {
+		exprs = append(exprs, order.SimplifiedExpr)
+	}
+
+	for _, expr := range exprs {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			wf, ok := node.(sqlparser.WindowFunc)
+			if !ok || wf.GetOverClause() == nil {
+				return true, nil
+			}
+			if w.findFunc(ctx, wf) < 0 {
+				w.Funcs = append(w.Funcs, newWindowFunc(wf))
+			}
+			return false, nil
+		}, expr)
+	}
+	return w
+}
+
+func newWindowFunc(wf sqlparser.WindowFunc) *WindowFunc {
+	code, ok := opcode.SupportedWindowFunctions[wf.WindowFuncName()]
+	if !ok || !slices.Contains(windowFunctionsForVTGate, code) {
+		panic(vterrors.VT12001(fmt.Sprintf("window function '%s' in a cross-shard query", wf.WindowFuncName())))
+	}
+
+	over := wf.GetOverClause()
+	if !over.WindowName.IsEmpty() || over.WindowSpec == nil || !over.WindowSpec.Name.IsEmpty() {
+		panic(vterrors.VT12001("named windows in a cross-shard query"))
+	}
+	fn := &WindowFunc{
+		Original:      wf,
+		Alias:         sqlparser.String(wf),
+		Opcode:        code,
+		ArgOffset:     -1,
+		DefaultOffset: -1,
+		Frame:         newWindowFrame(over.WindowSpec.FrameClause),
+	}
+	if ll, ok := wf.(*sqlparser.LagLeadExpr); ok {
+		if ll.NullTreatmentClause != nil && ll.NullTreatmentClause.Type == sqlparser.IgnoreNullsType {
+			panic(vterrors.VT12001("IGNORE NULLS in a cross-shard query"))
+		}
+		fn.Offset = 1
+		if ll.N != nil {
+			fn.Offset = windowOffset(wf, ll.N)
+		}
+	}
+	if fl, ok := wf.(*sqlparser.FirstOrLastValueExpr); ok {
+		if fl.NullTreatmentClause != nil && fl.NullTreatmentClause.Type == sqlparser.IgnoreNullsType {
+			panic(vterrors.VT12001("IGNORE NULLS in a cross-shard query"))
+		}
+	}
+	return fn
+}
+
+// newWindowFrame returns the frame of a window, or nil for the default frame
+func newWindowFrame(frame *sqlparser.FrameClause) *evalengine.WindowFrame {
+	if frame == nil {
+		return nil
+	}
+	wf := &evalengine.WindowFrame{Unit: evalengine.FrameRows}
+	if frame.Unit == sqlparser.FrameRangeType {
+		wf.Unit = evalengine.FrameRange
+		for _, point := range []*sqlparser.FramePoint{frame.Start, frame.End} {
+			if point != nil && (point.Type == sqlparser.ExprPrecedingType || point.Type == sqlparser.ExprFollowingType) {
+				panic(vterrors.VT12001("RANGE frames with offsets in a cross-shard query"))
+			}
+		}
+	}
+
+	wf.Start = newWindowFrameBound(frame.Start)
+	// a frame without an end ends at the current row
+	wf.End = evalengine.WindowFrameBound{Type: evalengine.FrameCurrentRow}
+	if frame.End != nil {
+		wf.End = newWindowFrameBound(frame.End)
+	}
+	return wf
+}
+
+func newWindowFrameBound(point *sqlparser.FramePoint) evalengine.WindowFrameBound {
+	switch point.Type {
+	case sqlparser.UnboundedPrecedingType:
+		return evalengine.WindowFrameBound{Type: evalengine.FrameUnboundedPreceding}
+	case sqlparser.UnboundedFollowingType:
+		return evalengine.WindowFrameBound{Type: evalengine.FrameUnboundedFollowing}
+	case sqlparser.ExprPrecedingType:
+		return evalengine.WindowFrameBound{Type: evalengine.FramePreceding, Offset: windowOffset(point, point.Expr)}
+	case sqlparser.ExprFollowingType:
+		return evalengine.WindowFrameBound{Type: evalengine.FrameFollowing, Offset: windowOffset(point, point.Expr)}
+	default:
+		return evalengine.WindowFrameBound{Type: evalengine.FrameCurrentRow}
+	}
+}
+
+// windowOffset returns the value of an offset of a window function or frame,
+// which vtgate only supports as an integer literal
+func windowOffset(node sqlparser.SQLNode, expr sqlparser.Expr) int64 {
+	lit, ok := expr.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.IntVal {
+		panic(vterrors.VT12001(fmt.Sprintf("non-literal offset in '%s' in a cross-shard query", sqlparser.String(node))))
+	}
+	offset, err := strconv.ParseInt(lit.Val, 10, 64)
+	if err != nil {
+		panic(vterrors.VT13001(fmt.Sprintf("invalid offset %s: %v", lit.Val, err)))
+	}
+	return offset
+}
+
+// evaluatedAtVTGate returns true when vtgate evaluates the window functions,
+// and false when MySQL does.
+func (w *Window) evaluatedAtVTGate() bool {
+	return len(w.Funcs) > 0
+}
+
+func (w *Window) findFunc(ctx *plancontext.PlanningContext, expr sqlparser.Expr) int {
+	return slices.IndexFunc(w.Funcs, func(fn *WindowFunc) bool {
+		return ctx.SemTable.EqualsExprWithDeps(fn.Original, expr)
+	})
+}
+
 func (w *Window) Clone(inputs []Operator) Operator {
-	return newWindow(inputs[0], w.QP)
+	kopy := *w
+	kopy.Source = inputs[0]
+	kopy.Funcs = slice.Map(w.Funcs, func(fn *WindowFunc) *WindowFunc {
+		fnCopy := *fn
+		return &fnCopy
+	})
+	return &kopy
 }
 
 func (w *Window) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	if w.evaluatedAtVTGate() {
+		// the window functions have to see all the rows of the input
+		return newFilter(w, expr)
+	}
 	w.Source = w.Source.AddPredicate(ctx, expr)
 	return w
 }
 
 func (w *Window) AddColumn(ctx *plancontext.PlanningContext, reuseExisting bool, addToGroupBy bool, expr *sqlparser.AliasedExpr) int {
-	return w.Source.AddColumn(ctx, reuseExisting, addToGroupBy, expr)
+	if !w.evaluatedAtVTGate() {
+		return w.Source.AddColumn(ctx, reuseExisting, addToGroupBy, expr)
+	}
+	if idx := w.findFunc(ctx, expr.Expr); idx >= 0 {
+		return idx
+	}
+	return len(w.Funcs) + w.Source.AddColumn(ctx, reuseExisting, addToGroupBy, expr)
 }
 
 func (w *Window) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
-	return w.Source.AddWSColumn(ctx, offset, underRoute)
+	if !w.evaluatedAtVTGate() {
+		return w.Source.AddWSColumn(ctx, offset, underRoute)
+	}
+	if offset < len(w.Funcs) {
+		panic(vterrors.VT12001(fmt.Sprintf("weight_string of window function '%s' in a cross-shard query", w.Funcs[offset].Alias)))
+	}
+	return len(w.Funcs) + w.Source.AddWSColumn(ctx, offset-len(w.Funcs), underRoute)
 }
 
 func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
-	return w.Source.FindCol(ctx, expr, underRoute)
+	if !w.evaluatedAtVTGate() {
+		return w.Source.FindCol(ctx, expr, underRoute)
+	}
+	if idx := w.findFunc(ctx, expr); idx >= 0 {
+		return idx
+	}
+	offset := w.Source.FindCol(ctx, expr, underRoute)
+	if offset < 0 {
+		return offset
+	}
+	return len(w.Funcs) + offset
 }
 
 func (w *Window) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
-	return w.Source.GetColumns(ctx)
+	if !w.evaluatedAtVTGate() {
+		return w.Source.GetColumns(ctx)
+	}
+	cols := slice.Map(w.Funcs, func(fn *WindowFunc) *sqlparser.AliasedExpr {
+		return aeWrap(fn.Original)
+	})
+	return append(cols, w.Source.GetColumns(ctx)...)
 }
 
 func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
-	return w.Source.GetSelectExprs(ctx)
+	if !w.evaluatedAtVTGate() {
+		return w.Source.GetSelectExprs(ctx)
+	}
+	return transformColumnsToSelectExprs(ctx, w)
 }
 
 func (w *Window) ShortDescription() string {
-	return "Window"
+	if !w.evaluatedAtVTGate() {
+		return "Window"
+	}
+	return strings.Join(slice.Map(w.Funcs, func(fn *WindowFunc) string {
+		return fn.Alias
+	}), ", ")
 }
 
 func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
 	return w.Source.GetOrdering(ctx)
 }
+
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	for _, fn := range w.Funcs {
+		if arg := fn.Original.GetArg(); arg != nil {
+			fn.ArgOffset = w.Source.AddColumn(ctx, true, false, aeWrap(arg))
+		}
+		if ll, ok := fn.Original.(*sqlparser.LagLeadExpr); ok && ll.Default != nil {
+			fn.DefaultOffset = w.Source.AddColumn(ctx, true, false, aeWrap(ll.Default))
+		}
+
+		spec := fn.Original.GetOverClause().WindowSpec
+		for _, expr := range spec.PartitionClause {
+			fn.PartitionBy = append(fn.PartitionBy, w.newWindowColumn(ctx, expr, false))
+		}
+		for _, order := range spec.OrderClause {
+			fn.OrderBy = append(fn.OrderBy, w.newWindowColumn(ctx, order.Expr, order.Direction == sqlparser.DescOrder))
+		}
+	}
+
+	// weight strings are added once all the other columns are there, since
+	// adding one can make the source plan its offsets
+	for _, fn := range w.Funcs {
+		for _, cols := range [][]WindowColumn{fn.PartitionBy, fn.OrderBy} {
+			for i, col := range cols {
+				if ctx.NeedsWeightString(col.Expr) {
+					cols[i].WSOffset = w.Source.AddWSColumn(ctx, col.ColOffset, false)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Window) newWindowColumn(ctx *plancontext.PlanningContext, expr sqlparser.Expr, desc bool) WindowColumn {
+	return WindowColumn{
+		Expr:      expr,
+		Desc:      desc,
+		ColOffset: w.Source.AddColumn(ctx, true, false, aeWrap(expr)),
+		WSOffset:  -1,
+	}
+}
+
+// windowNeedsVTGate returns true when MySQL can't evaluate the window
+// functions of the query on the rows of the source: when the source is a
+// route to several shards, and the rows of a partition can be on different
+// shards, or when the source combines the rows of several routes.
+func windowNeedsVTGate(src Operator, qp *QueryProjection) bool {
+	switch src := src.(type) {
+	case *Route:
+		return !src.IsSingleShard() && !CanPushDownWindow(qp, src)
+	case *ApplyJoin, *Join, *Union:
+		return true
+	}
+	return false
+}
+
+type windowTableInfo struct {
+	vTable *vindexes.BaseTable
+	alias  sqlparser.IdentifierCS
+}
+
+// CanPushDownWindow checks if a window function partitions by a unique vindex.
+// Returns false if PARTITION BY is missing or covers non-vindex columns.
+// Examples:
+//
+//	OK: SELECT ... FROM user WHERE id=1 PARTITION BY id (single shard)
+//	OK: SELECT ... FROM user PARTITION BY id (id is primary vindex, same-shard partitions)
+//	NO: SELECT ... FROM user PARTITION BY region (region scattered across shards)
+func CanPushDownWindow(qp *QueryProjection, route *Route) bool {
+	// Collect tables with their aliases
+	var tables []windowTableInfo
+	_ = Visit(route, func(o Operator) error {
+		if t, ok := o.(*Table); ok && t.VTable != nil {
+			alias := t.QTable.Alias.As
+			if alias.IsEmpty() {
+				alias = sqlparser.NewIdentifierCS(t.QTable.Table.Name.String())
+			}
+			tables = append(tables, windowTableInfo{vTable: t.VTable, alias: alias})
+		}
+		return nil
+	})
+
+	// Collect window functions from SELECT and ORDER BY expressions
+	var windowFuncs []sqlparser.WindowFunc
+	collect := func(node sqlparser.SQLNode) (bool, error) {
+		if wf, ok := node.(sqlparser.WindowFunc); ok {
+			windowFuncs = append(windowFuncs, wf)
+		}
+		return true, nil
+	}
+	for _, expr := range qp.SelectExprs {
+		_ = sqlparser.Walk(collect, expr.Col)
+	}
+	for _, order := range qp.OrderExprs {
+		_ = sqlparser.Walk(collect, order.SimplifiedExpr)
+	}
+
+	if len(windowFuncs) == 0 {
+		return true
+	}
+
+	// Validate each window function partitions by unique vindex
+	for _, wf := range windowFuncs {
+		if !isPartitionedByUniqueVindex(wf, tables) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPartitionedByUniqueVindex checks if a window function's PARTITION BY covers:
+//  1. Primary vindex columns (ensures same-shard partitions), or
+//  2. Unique vindex columns (each partition has ≤1 row, trivially single-shard)
+func isPartitionedByUniqueVindex(wf sqlparser.WindowFunc, tables []windowTableInfo) bool {
+	overClause := wf.GetOverClause()
+	if overClause == nil || overClause.WindowSpec == nil || len(overClause.WindowSpec.PartitionClause) == 0 {
+		return false
+	}
+
+	partitionBy := overClause.WindowSpec.PartitionClause
+
+	for _, table := range tables {
+		if len(table.vTable.ColumnVindexes) == 0 {
+			continue
+		}
+
+		// Pre-build column lookup map for column validation
+		var columnSet map[string]bool
+		if table.vTable.ColumnListAuthoritative {
+			columnSet = make(map[string]bool, len(table.vTable.Columns))
+			for _, col := range table.vTable.Columns {
+				columnSet[col.Name.Lowered()] = true
+			}
+		}
+
+		// Build set of partition columns matching this table - O(p) where p = partition columns
+		coveredCols := make(map[string]bool)
+		for _, pExpr := range partitionBy {
+			colName, ok := pExpr.(*sqlparser.ColName)
+			if !ok {
+				continue
+			}
+
+			// Skip if qualified to different table
+			if !colName.Qualifier.IsEmpty() && colName.Qualifier.Name.String() != table.alias.String() {
+				continue
+			}
+
+			// Validate column exists in schema if authoritative - O(1) lookup instead of O(c)
+			if columnSet != nil {
+				if !columnSet[colName.Name.Lowered()] {
+					if !colName.Qualifier.IsEmpty() || len(tables) == 1 {
+						return false
+					}
+					continue
+				}
+			}
+
+			coveredCols[colName.Name.Lowered()] = true
+		}
+
+		checkVindex := func(vindex *vindexes.ColumnVindex) bool {
+			for _, vCol := range vindex.Columns {
+				if !coveredCols[vCol.Lowered()] {
+					return false
+				}
+			}
+			return true
+		}
+
+		// Check primary vindex (determines shard routing)
+		primaryVindex := table.vTable.ColumnVindexes[0]
+		if checkVindex(primaryVindex) {
+			return true
+		}
+
+		// Check unique vindexes (each partition has ≤1 row)
+		for _, vindex := range table.vTable.ColumnVindexes[1:] {
+			if vindex.IsUnique() && checkVindex(vindex) {
+				return true
+			}
+		}
+	}
+	return false
+}
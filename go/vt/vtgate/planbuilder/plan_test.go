@@ -37,6 +37,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/test/vschemawrapper"
 	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/sidecardb"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -482,6 +483,21 @@ func (s *planTestSuite) TestBypassPlanningShardTargetFromFile() {
 	s.testFile("bypass_shard_cases.json", vschema, false)
 }
 
+func (s *planTestSuite) TestBypassPlanningMaskedTables() {
+	vschema := &vschemawrapper.VSchemaWrapper{
+		V: loadSchema(s.T(), "vschemas/masking_schema.json", true),
+		Keyspace: &vindexes.Keyspace{
+			Name:    "main",
+			Sharded: false,
+		},
+		TabletType_: topodatapb.TabletType_PRIMARY,
+		Dest:        key.DestinationShard("-80"),
+		Env:         vtenv.NewTestEnv(),
+	}
+
+	s.testFile("bypass_masking_cases.json", vschema, false)
+}
+
 func (s *planTestSuite) TestBypassPlanningKeyrangeTargetFromFile() {
 	env := vtenv.NewTestEnv()
 	vschema := loadSchema(s.T(), "vschemas/schema.json", true)
@@ -609,6 +625,15 @@ func (s *planTestSuite) TestMirrorPlanning() {
 	s.testFile("mirror_cases.json", vw, false)
 }
 
+func (s *planTestSuite) TestMaskingPlanning() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/masking_schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	s.Require().NoError(err)
+
+	s.testFile("masking_cases.json", vw, false)
+}
+
 func (s *planTestSuite) TestOneMirror() {
 	reset := operators.EnableDebugPrinting()
 	defer reset()
@@ -635,6 +660,10 @@ func loadSchema(t testing.TB, filename string, setCollation bool) *vindexes.VSch
 			require.NoError(t, err)
 			err = vschema.AddUDF(ks.Keyspace.Name, "udf_aggr")
 			require.NoError(t, err)
+			err = vschema.AddUDFReturnType(ks.Keyspace.Name, "udf_int", querypb.Type_INT64)
+			require.NoError(t, err)
+			err = vschema.AddUDFReturnType(ks.Keyspace.Name, "udf_aggr", querypb.Type_FLOAT64)
+			require.NoError(t, err)
 		}
 
 		// setting a default value to all the text columns in the tables of this keyspace
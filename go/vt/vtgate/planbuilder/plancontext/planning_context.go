@@ -260,6 +260,12 @@ func (ctx *PlanningContext) NeedsWeightString(e sqlparser.Expr) bool {
 			return false
 		}
 
+		if _, isCollate := e.(*sqlparser.CollateExpr); isCollate && !evalengine.ComparableCollation(typ.Collation()) {
+			// MySQL returns the values in the charset of the connection and not in
+			// the one of the collation, so vtgate needs their weight strings
+			return true
+		}
+
 		return !ctx.VSchema.Environment().CollationEnv().IsSupported(typ.Collation())
 	}
 }
@@ -255,6 +255,10 @@ func (v *vschema) HasDeniedSystemVariables() bool {
 	return false
 }
 
+func (v *vschema) IsGlobalSystemVariableAllowed(string) bool {
+	return false
+}
+
 func (v *vschema) KeyspaceExists(keyspace string) bool {
 	// TODO implement me
 	panic("implement me")
@@ -309,6 +313,15 @@ func (v *vschema) PlannerWarning(message string) {
 	panic("implement me")
 }
 
+func (v *vschema) PlannerDecisionsEnabled() bool {
+	return false
+}
+
+func (v *vschema) PlannerDecision(engine.PlannerDecision) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (v *vschema) ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error) {
 	// TODO implement me
 	panic("implement me")
@@ -368,6 +381,11 @@ func (v *vschema) GetAggregateUDFs() []string {
 	panic("implement me")
 }
 
+func (v *vschema) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	// TODO implement me
+	panic("implement me")
+}
+
 // FindMirrorRule implements VSchema.
 func (v *vschema) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error) {
 	panic("unimplemented")
@@ -61,6 +61,10 @@ type VSchema interface {
 	// HasDeniedSystemVariables reports whether the VTGate-configured denylist
 	// contains any system variables.
 	HasDeniedSystemVariables() bool
+	// IsGlobalSystemVariableAllowed reports whether the given system variable
+	// name is in the VTGate-configured allowlist of variables that may be set
+	// with SET GLOBAL. Names are compared case-insensitively.
+	IsGlobalSystemVariableAllowed(name string) bool
 	KeyspaceExists(keyspace string) bool
 	AllKeyspace() ([]*vindexes.Keyspace, error)
 	FindKeyspace(keyspace string) (*vindexes.Keyspace, error)
@@ -82,6 +86,13 @@ type VSchema interface {
 	// PlannerWarning records warning created during planning.
 	PlannerWarning(message string)
 
+	// PlannerDecisionsEnabled returns true if the cost-based decisions of the
+	// planner should be recorded with PlannerDecision.
+	PlannerDecisionsEnabled() bool
+
+	// PlannerDecision records a cost-based decision made during planning.
+	PlannerDecision(decision engine.PlannerDecision)
+
 	// ForeignKeyMode returns the foreign_key flag value
 	ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error)
 
@@ -118,6 +129,10 @@ type VSchema interface {
 	// GetAggregateUDFs returns the list of aggregate UDFs.
 	GetAggregateUDFs() []string
 
+	// GetUDFReturnTypes returns the known return types of the UDFs of a keyspace,
+	// keyed by lowercase name.
+	GetUDFReturnTypes(keyspace string) map[string]querypb.Type
+
 	// FindMirrorRule finds the mirror rule for the requested keyspace, table
 	// name, and the tablet type in the VSchema.
 	FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error)
@@ -17,10 +17,13 @@ limitations under the License.
 package planbuilder
 
 import (
+	"io"
+
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
@@ -88,3 +91,69 @@ func prepareTheAST(sel sqlparser.SelectStatement) {
 		return true, nil
 	}, sel)
 }
+
+// chunkableLists returns the list arguments of the IN predicates of the query
+// of a route that the route can split in chunks, and merge the results of the
+// chunks like the results of its shards. That is only possible if every row of
+// the result comes from rows that match a single value of the list, if the
+// route can merge-sort the chunks, and if the LIMIT of the query also runs at
+// the vtgate.
+func chunkableLists(ctx *plancontext.PlanningContext, op *operators.Route, eroute *engine.Route, stmt sqlparser.SelectStatement) []engine.ChunkableList {
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Where == nil || ctx.SemTable == nil {
+		return nil
+	}
+	if sel.Distinct || sel.GroupBy != nil || sel.Having != nil || len(sel.Windows) > 0 || sel.SQLCalcFoundRows || sel.Into != nil {
+		return nil
+	}
+	if len(sel.OrderBy) > 0 && len(eroute.OrderBy) == 0 {
+		return nil
+	}
+	if sel.Limit != nil && (sel.Limit.Offset != nil || op.IsSingleShardOrByDestination()) {
+		return nil
+	}
+
+	aggregates := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node.(type) {
+		case sqlparser.AggrFunc, sqlparser.WindowFunc, *sqlparser.OverClause:
+			aggregates = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, sel.SelectExprs, sel.OrderBy)
+	if aggregates {
+		return nil
+	}
+
+	// a list used more than once would filter the rows with the same chunk
+	// of its values in every place
+	uses := map[string]int{}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if arg, ok := node.(sqlparser.ListArg); ok {
+			uses[string(arg)]++
+		}
+		return true, nil
+	}, sel)
+
+	var lists []engine.ChunkableList
+	for _, pred := range sqlparser.SplitAndExpression(nil, sel.Where.Expr) {
+		cmp, ok := pred.(*sqlparser.ComparisonExpr)
+		if !ok || cmp.Operator != sqlparser.InOp {
+			continue
+		}
+		arg, ok := cmp.Right.(sqlparser.ListArg)
+		if !ok || uses[string(arg)] != 1 {
+			continue
+		}
+		if _, isTuple := cmp.Left.(sqlparser.ValTuple); isTuple {
+			continue
+		}
+		typ, found := ctx.TypeForExpr(cmp.Left)
+		if !found {
+			continue
+		}
+		lists = append(lists, engine.ChunkableList{Name: string(arg), Type: typ})
+	}
+	return lists
+}
@@ -84,7 +84,11 @@ func gen4SelectStmtPlanner(
 	// All other engine primitives can handle this, so we only need it when
 	// Route is the last (and only) instruction before the user sees a result
 	if isOnlyDual(sel) || (sel.GroupBy == nil && sel.SelectExprs.AllAggregation()) {
-		switch prim := plan.(type) {
+		prim := plan
+		if mask, ok := prim.(*engine.Mask); ok {
+			prim = mask.Input
+		}
+		switch prim := prim.(type) {
 		case *engine.Route:
 			prim.NoRoutesSpecialHandling = true
 		case *engine.VindexLookup:
@@ -138,12 +142,12 @@ func buildSQLCalcFoundRowsPlan(
 	selectExprs := &sqlparser.SelectExprs{
 		Exprs: []sqlparser.SelectExpr{countStar},
 	}
-	if sel2.GroupBy == nil && sel2.Having == nil {
+	if sel2.GroupBy == nil && sel2.Having == nil && !sel2.Distinct {
 		// if there is no grouping, we can use the same query and
 		// just replace the SELECT sub-clause to have a single count(*)
 		sel2.SetSelectExprs(countStar)
 	} else {
-		// when there is grouping, we have to move the original query into a derived table.
+		// when there is grouping or DISTINCT, we have to move the original query into a derived table.
 		//                       select id, sum(12) from user group by id =>
 		// select count(*) from (select id, sum(12) from user group by id) t
 		sel3 := &sqlparser.Select{
@@ -201,13 +205,19 @@ func newBuildSelectPlan(
 		return nil, nil, err
 	}
 
+	// the masks are computed before planning, which rewrites the statement
+	masks, err := columnMasks(ctx, selStmt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if ks, ok := ctx.SemTable.CanTakeSelectUnshardedShortcut(); ok {
 		plan, tablesUsed, err = selectUnshardedShortcut(ctx, selStmt, ks)
 		if err != nil {
 			return nil, nil, err
 		}
 		setCommentDirectivesOnPlan(plan, selStmt)
-		return plan, tablesUsed, err
+		return maskPlan(plan, masks), tablesUsed, err
 	}
 
 	if ctx.SemTable.NotUnshardedErr != nil {
@@ -224,7 +234,7 @@ func newBuildSelectPlan(
 		return nil, nil, err
 	}
 
-	return plan, operators.TablesUsed(op), nil
+	return maskPlan(plan, masks), operators.TablesUsed(op), nil
 }
 
 func createSelectOperator(ctx *plancontext.PlanningContext, selStmt sqlparser.SelectStatement) (operators.Operator, error) {
@@ -22,8 +22,10 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/sysvars"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -66,7 +68,12 @@ func buildSetPlan(stmt *sqlparser.Set, vschema plancontext.VSchema) (*planResult
 			if vschema.IsSystemVariableDenied(expr.Var.Name.Lowered()) {
 				return nil, vterrors.VT12001(fmt.Sprintf("system setting: %s", expr.Var.Name))
 			}
-			setOp, err := planSysVarCheckIgnore(expr, vschema, true)
+			var setOp engine.SetOp
+			if vschema.IsGlobalSystemVariableAllowed(expr.Var.Name.Lowered()) {
+				setOp, err = planSysVarSetGlobal(expr, stmt.Comments, vschema)
+			} else {
+				setOp, err = planSysVarCheckIgnore(expr, vschema, true)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -174,6 +181,41 @@ func planSysVarCheckIgnore(expr *sqlparser.SetExpr, schema plancontext.VSchema,
 	}, nil
 }
 
+// planSysVarSetGlobal plans a SET GLOBAL of an allowlisted system variable.
+// Without a TARGET directive the statement is sent to the primary of every
+// shard of the selected keyspace.
+func planSysVarSetGlobal(expr *sqlparser.SetExpr, comments *sqlparser.ParsedComments, vschema plancontext.VSchema) (engine.SetOp, error) {
+	value, err := extractValue(expr, true)
+	if err != nil {
+		return nil, err
+	}
+	setOp := &engine.SysVarSetGlobal{
+		Name:       expr.Var.Name.Lowered(),
+		TabletType: topodatapb.TabletType_PRIMARY,
+		Expr:       value,
+	}
+
+	var keyspaceName string
+	if target, _ := comments.Directives().GetString(sqlparser.DirectiveTarget, ""); target != "" {
+		keyspaceName, setOp.TabletType, setOp.TargetDestination, setOp.TabletAlias, err = topoproto.ParseDestination(target, topodatapb.TabletType_PRIMARY)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if keyspaceName == "" {
+		setOp.Keyspace, err = vschema.SelectedKeyspace()
+	} else {
+		setOp.Keyspace, err = vschema.FindKeyspace(keyspaceName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if setOp.Keyspace == nil {
+		return nil, vterrors.VT05003(keyspaceName)
+	}
+	return setOp, nil
+}
+
 func buildSetOpReservedConn(s setting) planFunc {
 	return func(expr *sqlparser.SetExpr, vschema plancontext.VSchema, _ *expressionConverter) (engine.SetOp, error) {
 		if !vschema.SysVarSetEnabled() {
@@ -107,7 +107,7 @@ func buildShowBasicPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema)
 	case sqlparser.Charset:
 		return buildCharsetPlan(show)
 	case sqlparser.Collation, sqlparser.Function, sqlparser.Privilege, sqlparser.Procedure,
-		sqlparser.Errors, sqlparser.Events, sqlparser.ProcessList, sqlparser.Profiles,
+		sqlparser.Errors, sqlparser.Events, sqlparser.Profiles,
 		sqlparser.FunctionC, sqlparser.ProcedureC:
 		return buildSendAnywherePlan(show, vschema)
 	case sqlparser.VariableGlobal, sqlparser.VariableSession:
@@ -137,6 +137,11 @@ func buildShowBasicPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema)
 			Command:    show.Command,
 			ShowFilter: show.Filter,
 		}, nil
+	case sqlparser.ProcessList:
+		return &engine.ShowExec{
+			Command: show.Command,
+			Full:    show.Full,
+		}, nil
 	case sqlparser.VitessTarget:
 		return buildShowTargetPlan(vschema)
 	case sqlparser.VschemaTables:
@@ -623,8 +628,12 @@ func buildWarnings() (engine.Primitive, error) {
 		rows := make([][]sqltypes.Value, 0, len(warns))
 
 		for _, warn := range warns {
+			level := warn.Level
+			if level == "" {
+				level = "Warning"
+			}
 			rows = append(rows, []sqltypes.Value{
-				sqltypes.NewVarChar("Warning"),
+				sqltypes.NewVarChar(level),
 				sqltypes.NewUint32(warn.Code),
 				sqltypes.NewVarChar(warn.Message),
 			})
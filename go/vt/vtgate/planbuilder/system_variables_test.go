@@ -23,9 +23,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/test/vschemawrapper"
+	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func TestDeniedSystemVariables(t *testing.T) {
@@ -113,3 +117,125 @@ func TestDeniedSystemVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowedGlobalSystemVariables(t *testing.T) {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(t, "vschemas/schema.json", true)
+
+	cases := []struct {
+		name     string
+		allowed  map[string]struct{}
+		denied   map[string]struct{}
+		query    string
+		wantOp   engine.SetOp
+		wantErr  string
+		wantCode vtrpcpb.Code
+	}{{
+		name:    "allowed global variable is executed",
+		allowed: map[string]struct{}{"max_connections": {}},
+		query:   "set @@global.max_connections = 1000",
+		wantOp: &engine.SysVarSetGlobal{
+			Name:       "max_connections",
+			TabletType: topodatapb.TabletType_PRIMARY,
+			Expr:       "1000",
+		},
+	}, {
+		name:    "allowlist match is case-insensitive",
+		allowed: map[string]struct{}{"max_connections": {}},
+		query:   "set global MAX_CONNECTIONS = 1000",
+		wantOp: &engine.SysVarSetGlobal{
+			Name:       "max_connections",
+			TabletType: topodatapb.TabletType_PRIMARY,
+			Expr:       "1000",
+		},
+	}, {
+		name:    "target directive picks the shard and tablet type",
+		allowed: map[string]struct{}{"max_connections": {}},
+		query:   "set /*vt+ TARGET=user:-80@replica */ global max_connections = 1000",
+		wantOp: &engine.SysVarSetGlobal{
+			Name:              "max_connections",
+			TargetDestination: key.DestinationShard("-80"),
+			TabletType:        topodatapb.TabletType_REPLICA,
+			Expr:              "1000",
+		},
+	}, {
+		name:    "target directive picks a tablet",
+		allowed: map[string]struct{}{"max_connections": {}},
+		query:   "set /*vt+ TARGET=user:-80@replica|zone1-0000000100 */ global max_connections = 1000",
+		wantOp: &engine.SysVarSetGlobal{
+			Name:              "max_connections",
+			TargetDestination: key.DestinationShard("-80"),
+			TabletType:        topodatapb.TabletType_REPLICA,
+			TabletAlias:       &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Expr:              "1000",
+		},
+	}, {
+		name:     "target directive needs a shard to pick a tablet",
+		allowed:  map[string]struct{}{"max_connections": {}},
+		query:    "set /*vt+ TARGET=user@replica|zone1-0000000100 */ global max_connections = 1000",
+		wantErr:  "tablet alias must be used with a shard",
+		wantCode: vtrpcpb.Code_INVALID_ARGUMENT,
+	}, {
+		name:     "target directive keyspace must exist",
+		allowed:  map[string]struct{}{"max_connections": {}},
+		query:    "set /*vt+ TARGET=unknown:-80 */ global max_connections = 1000",
+		wantErr:  "VT05003: unknown database 'unknown' in vschema",
+		wantCode: vtrpcpb.Code_NOT_FOUND,
+	}, {
+		name:    "other global variables are checked and ignored",
+		allowed: map[string]struct{}{"max_connections": {}},
+		query:   "set @@global.unique_checks = 0",
+		wantOp: &engine.SysVarCheckAndIgnore{
+			Name:              "unique_checks",
+			TargetDestination: key.DestinationAnyShard{},
+			Expr:              "0",
+		},
+	}, {
+		name:    "session scope is unaffected",
+		allowed: map[string]struct{}{"unique_checks": {}},
+		query:   "set @@session.unique_checks = 0",
+		wantOp: &engine.SysVarReservedConn{
+			Name:          "unique_checks",
+			Expr:          "0",
+			SupportSetVar: true,
+		},
+	}, {
+		name:     "denylist takes precedence",
+		allowed:  map[string]struct{}{"max_connections": {}},
+		denied:   map[string]struct{}{"max_connections": {}},
+		query:    "set @@global.max_connections = 1000",
+		wantErr:  "VT12001: unsupported: system setting: max_connections",
+		wantCode: vtrpcpb.Code_UNIMPLEMENTED,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+			require.NoError(t, err)
+			vw.Keyspace = &vindexes.Keyspace{Name: "user", Sharded: true}
+			vw.AllowedGlobalSysVars = tc.allowed
+			vw.DeniedSysVars = tc.denied
+
+			plan, err := TestBuilder(tc.query, vw, "")
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.wantErr, err.Error())
+				assert.Equal(t, tc.wantCode, vterrors.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			set, ok := plan.Instructions.(*engine.Set)
+			require.True(t, ok)
+			require.Len(t, set.Ops, 1)
+			switch op := set.Ops[0].(type) {
+			case *engine.SysVarSetGlobal:
+				op.Keyspace = nil
+			case *engine.SysVarCheckAndIgnore:
+				op.Keyspace = nil
+			case *engine.SysVarReservedConn:
+				op.Keyspace = nil
+			}
+			assert.Equal(t, tc.wantOp, set.Ops[0])
+		})
+	}
+}
@@ -17,12 +17,12 @@ limitations under the License.
 package planbuilder
 
 import (
-	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
-	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (engine.Primitive, error) {
@@ -31,6 +31,15 @@ func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (en
 		return nil, err
 	}
 
+	if len(op.Funcs) > 0 {
+		return &engine.Window{
+			Input: prim,
+			Funcs: slice.Map(op.Funcs, func(fn *operators.WindowFunc) *engine.WindowParams {
+				return createWindowParams(ctx, fn)
+			}),
+		}, nil
+	}
+
 	// Multi-source primitives (Join, HashJoin, ValuesJoin, SemiJoin, Concatenate, Sequential)
 	// cannot guarantee partitions stay on single shard
 	switch prim.(type) {
@@ -42,7 +51,7 @@ func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (en
 	// E.g., PARTITION BY id (primary vindex) OK; PARTITION BY region NOT OK
 	if route, ok := prim.(*engine.Route); ok && !isSingleShardPrimitive(route) {
 		if routeOp, ok := op.Source.(*operators.Route); ok {
-			if canPushDownWindow(op, routeOp) {
+			if operators.CanPushDownWindow(op.QP, routeOp) {
 				// Partition is based on unique vindex - safe to execute on multi-shard route
 				return prim, nil
 			}
@@ -60,129 +69,29 @@ func isSingleShardPrimitive(route *engine.Route) bool {
 	return route.Opcode.IsSingleShard()
 }
 
-type windowTableInfo struct {
-	vTable *vindexes.BaseTable
-	alias  sqlparser.IdentifierCS
-}
-
-// canPushDownWindow checks if a window function partitions by a unique vindex.
-// Returns false if PARTITION BY is missing or covers non-vindex columns.
-// Examples:
-//
-//	OK: SELECT ... FROM user WHERE id=1 PARTITION BY id (single shard)
-//	OK: SELECT ... FROM user PARTITION BY id (id is primary vindex, same-shard partitions)
-//	NO: SELECT ... FROM user PARTITION BY region (region scattered across shards)
-func canPushDownWindow(op *operators.Window, route *operators.Route) bool {
-	// Collect tables with their aliases
-	var tables []windowTableInfo
-	_ = operators.Visit(route, func(o operators.Operator) error {
-		if t, ok := o.(*operators.Table); ok && t.VTable != nil {
-			alias := t.QTable.Alias.As
-			if alias.IsEmpty() {
-				alias = sqlparser.NewIdentifierCS(t.QTable.Table.Name.String())
-			}
-			tables = append(tables, windowTableInfo{vTable: t.VTable, alias: alias})
-		}
-		return nil
-	})
-
-	// Collect window functions from SELECT expressions
-	var windowFuncs []sqlparser.WindowFunc
-	for _, expr := range op.QP.SelectExprs {
-		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
-			if wf, ok := node.(sqlparser.WindowFunc); ok {
-				windowFuncs = append(windowFuncs, wf)
-			}
-			return true, nil
-		}, expr.Col)
-	}
-
-	if len(windowFuncs) == 0 {
-		return true
+func createWindowParams(ctx *plancontext.PlanningContext, fn *operators.WindowFunc) *engine.WindowParams {
+	typ, _ := ctx.TypeForExpr(fn.Original)
+	wp := &engine.WindowParams{
+		Opcode:     fn.Opcode,
+		Col:        fn.ArgOffset,
+		DefaultCol: fn.DefaultOffset,
+		Offset:     fn.Offset,
+		Frame:      fn.Frame,
+		Alias:      fn.Alias,
+		Type:       typ,
 	}
-
-	// Validate each window function partitions by unique vindex
-	for _, wf := range windowFuncs {
-		if !isPartitionedByUniqueVindex(wf, tables) {
-			return false
+	toOrderBy := func(col operators.WindowColumn) evalengine.OrderByParams {
+		typ, _ := ctx.TypeForExpr(col.Expr)
+		return evalengine.OrderByParams{
+			Col:             col.ColOffset,
+			WeightStringCol: col.WSOffset,
+			Desc:            col.Desc,
+			Type:            typ,
+			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
 		}
 	}
+	wp.PartitionBy = slice.Map(fn.PartitionBy, toOrderBy)
+	wp.OrderBy = slice.Map(fn.OrderBy, toOrderBy)
 
-	return true
-}
-
-// isPartitionedByUniqueVindex checks if a window function's PARTITION BY covers:
-//  1. Primary vindex columns (ensures same-shard partitions), or
-//  2. Unique vindex columns (each partition has ≤1 row, trivially single-shard)
-func isPartitionedByUniqueVindex(wf sqlparser.WindowFunc, tables []windowTableInfo) bool {
-	overClause := wf.GetOverClause()
-	if overClause == nil || overClause.WindowSpec == nil || len(overClause.WindowSpec.PartitionClause) == 0 {
-		return false
-	}
-
-	partitionBy := overClause.WindowSpec.PartitionClause
-
-	for _, table := range tables {
-		if len(table.vTable.ColumnVindexes) == 0 {
-			continue
-		}
-
-		// Pre-build column lookup map for column validation
-		var columnSet map[string]bool
-		if table.vTable.ColumnListAuthoritative {
-			columnSet = make(map[string]bool, len(table.vTable.Columns))
-			for _, col := range table.vTable.Columns {
-				columnSet[col.Name.Lowered()] = true
-			}
-		}
-
-		// Build set of partition columns matching this table - O(p) where p = partition columns
-		coveredCols := make(map[string]bool)
-		for _, pExpr := range partitionBy {
-			colName, ok := pExpr.(*sqlparser.ColName)
-			if !ok {
-				continue
-			}
-
-			// Skip if qualified to different table
-			if !colName.Qualifier.IsEmpty() && colName.Qualifier.Name.String() != table.alias.String() {
-				continue
-			}
-
-			// Validate column exists in schema if authoritative - O(1) lookup instead of O(c)
-			if columnSet != nil {
-				if !columnSet[colName.Name.Lowered()] {
-					if !colName.Qualifier.IsEmpty() || len(tables) == 1 {
-						return false
-					}
-					continue
-				}
-			}
-
-			coveredCols[colName.Name.Lowered()] = true
-		}
-
-		checkVindex := func(vindex *vindexes.ColumnVindex) bool {
-			for _, vCol := range vindex.Columns {
-				if !coveredCols[vCol.Lowered()] {
-					return false
-				}
-			}
-			return true
-		}
-
-		// Check primary vindex (determines shard routing)
-		primaryVindex := table.vTable.ColumnVindexes[0]
-		if checkVindex(primaryVindex) {
-			return true
-		}
-
-		// Check unique vindexes (each partition has ≤1 row)
-		for _, vindex := range table.vTable.ColumnVindexes[1:] {
-			if vindex.IsUnique() && checkVindex(vindex) {
-				return true
-			}
-		}
-	}
-	return false
+	return wp
 }
@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
@@ -34,6 +35,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/pflag"
 
+	"vitess.io/vitess/go/flagutil"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -50,10 +52,12 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/utils"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/adminacl"
 	"vitess.io/vitess/go/vt/vtgate/binlogacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 	"vitess.io/vitess/go/vt/vttls"
@@ -67,6 +71,7 @@ var (
 	mysqlAuthServerImpl               = "static"
 	mysqlAllowClearTextWithoutTLS     bool
 	mysqlProxyProtocol                bool
+	mysqlProxyProtocolTrustedCIDRs    []string
 	mysqlServerRequireSecureTransport bool
 	mysqlSslCert                      string
 	mysqlSslKey                       string
@@ -74,6 +79,7 @@ var (
 	mysqlSslCrl                       string
 	mysqlSslServerCA                  string
 	mysqlTLSMinVersion                string
+	mysqlCachingSha2PrivateKey        string
 
 	mysqlKeepAlivePeriod          time.Duration
 	mysqlConnReadTimeout          time.Duration
@@ -86,8 +92,18 @@ var (
 	mysqlDefaultWorkload     int32
 	mysqlDrainOnTerm         bool
 
+	mysqlSessionCheckpoint    bool
+	mysqlSessionCheckpointTTL = 5 * time.Minute
+
 	mysqlServerFlushDelay = 100 * time.Millisecond
 	mysqlServerMultiQuery = false
+
+	mysqlServerCompressionAlgorithms []string
+	mysqlServerAllowLocalInfile      bool
+	mysqlServerMaxCursorSize         int64 = 64 * 1024 * 1024
+
+	mysqlIdleTransactionTimeout        time.Duration
+	mysqlIdleTransactionTimeoutPerUser flagutil.StringMapValue
 )
 
 func registerPluginFlags(fs *pflag.FlagSet) {
@@ -98,12 +114,14 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &mysqlAuthServerImpl, "mysql-auth-server-impl", mysqlAuthServerImpl, "Which auth server implementation to use. Options: none, ldap, clientcert, static, vault.")
 	utils.SetFlagBoolVar(fs, &mysqlAllowClearTextWithoutTLS, "mysql-allow-clear-text-without-tls", mysqlAllowClearTextWithoutTLS, "If set, the server will allow the use of a clear text password over non-SSL connections.")
 	utils.SetFlagBoolVar(fs, &mysqlProxyProtocol, "proxy-protocol", mysqlProxyProtocol, "Enable HAProxy PROXY protocol on MySQL listener socket")
+	utils.SetFlagStringSliceVar(fs, &mysqlProxyProtocolTrustedCIDRs, "proxy-protocol-trusted-cidrs", mysqlProxyProtocolTrustedCIDRs, "Comma-separated list of the IP addresses and CIDR ranges of the proxies allowed to send a PROXY protocol header when --proxy-protocol is set. The connections from other addresses are handled as plain MySQL connections. If empty, all addresses are allowed.")
 	utils.SetFlagBoolVar(fs, &mysqlServerRequireSecureTransport, "mysql-server-require-secure-transport", mysqlServerRequireSecureTransport, "Reject insecure connections but only if mysql-server-ssl-cert and mysql-server-ssl-key are provided")
 	utils.SetFlagStringVar(fs, &mysqlSslCert, "mysql-server-ssl-cert", mysqlSslCert, "Path to the ssl cert for mysql server plugin SSL")
 	utils.SetFlagStringVar(fs, &mysqlSslKey, "mysql-server-ssl-key", mysqlSslKey, "Path to ssl key for mysql server plugin SSL")
 	utils.SetFlagStringVar(fs, &mysqlSslCa, "mysql-server-ssl-ca", mysqlSslCa, "Path to ssl CA for mysql server plugin SSL. If specified, server will require and validate client certs.")
 	utils.SetFlagStringVar(fs, &mysqlSslCrl, "mysql-server-ssl-crl", mysqlSslCrl, "Path to ssl CRL for mysql server plugin SSL")
 	utils.SetFlagStringVar(fs, &mysqlTLSMinVersion, "mysql-server-tls-min-version", mysqlTLSMinVersion, "Configures the minimal TLS version negotiated when SSL is enabled. Defaults to TLSv1.2. Options: TLSv1.0, TLSv1.1, TLSv1.2, TLSv1.3.")
+	utils.SetFlagStringVar(fs, &mysqlCachingSha2PrivateKey, "mysql-server-caching-sha2-password-private-key", mysqlCachingSha2PrivateKey, "Path to the PEM encoded RSA private key with whose public key the clients encrypt their password for the full authentication of caching_sha2_password over connections without TLS. If empty, caching_sha2_password is only offered over TLS and Unix sockets.")
 	utils.SetFlagStringVar(fs, &mysqlSslServerCA, "mysql-server-ssl-server-ca", mysqlSslServerCA, "path to server CA in PEM format, which will be combine with server cert, return full certificate chain to clients")
 	utils.SetFlagDurationVar(fs, &mysqlSlowConnectWarnThreshold, "mysql-slow-connect-warn-threshold", mysqlSlowConnectWarnThreshold, "Warn if it takes more than the given threshold for a mysql connection to establish")
 	utils.SetFlagDurationVar(fs, &mysqlConnReadTimeout, "mysql-server-read-timeout", mysqlConnReadTimeout, "connection read timeout")
@@ -114,7 +132,14 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	utils.SetFlagDurationVar(fs, &mysqlServerFlushDelay, "mysql-server-flush-delay", mysqlServerFlushDelay, "Delay after which buffered response will be flushed to the client.")
 	utils.SetFlagStringVar(fs, &mysqlDefaultWorkloadName, "mysql-default-workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
 	fs.BoolVar(&mysqlDrainOnTerm, "mysql-server-drain-onterm", mysqlDrainOnTerm, "If set, the server waits for --onterm-timeout for already connected clients to complete their in flight work")
+	utils.SetFlagBoolVar(fs, &mysqlSessionCheckpoint, "mysql-server-session-checkpoint", mysqlSessionCheckpoint, "If set, the state of idle sessions is saved to the global topo when vtgate shuts down, and clients can resume it on another vtgate by reconnecting with the vitess_session_checkpoint connection attribute set to the token returned in the shutdown error.")
+	utils.SetFlagDurationVar(fs, &mysqlSessionCheckpointTTL, "mysql-server-session-checkpoint-ttl", mysqlSessionCheckpointTTL, "How long a session checkpoint saved at shutdown can be resumed for (see --mysql-server-session-checkpoint).")
 	utils.SetFlagBoolVar(fs, &mysqlServerMultiQuery, "mysql-server-multi-query-protocol", mysqlServerMultiQuery, "If set, the server will use the new implementation of handling queries where-in multiple queries are sent together.")
+	utils.SetFlagDurationVar(fs, &mysqlIdleTransactionTimeout, "mysql-server-idle-transaction-timeout", mysqlIdleTransactionTimeout, "If set, the transaction of a connection that stays idle for longer than this is rolled back, and the next statement of the connection fails with an error. 0 disables the timeout.")
+	utils.SetFlagStringSliceVar(fs, &mysqlServerCompressionAlgorithms, "mysql-server-compression-algorithms", mysqlServerCompressionAlgorithms, "Comma-separated list of the protocol compression algorithms (zlib, zstd) offered to the clients connecting over TCP. Compression is disabled if empty.")
+	utils.SetFlagBoolVar(fs, &mysqlServerAllowLocalInfile, "mysql-server-allow-local-infile", mysqlServerAllowLocalInfile, "If set, clients can run LOAD DATA LOCAL INFILE statements: the rows of the file they send are inserted in batches of INSERT statements.")
+	utils.SetFlagInt64Var(fs, &mysqlServerMaxCursorSize, "mysql-server-max-cursor-size", mysqlServerMaxCursorSize, "Maximum size, in bytes, of the rows a read-only cursor of a prepared statement keeps in memory until the client fetches them. Executions opening a larger cursor fail. 0 means no limit.")
+	utils.SetFlagVar(fs, &mysqlIdleTransactionTimeoutPerUser, "mysql-server-idle-transaction-timeout-per-user", "Comma-separated list of user:duration pairs that override --mysql-server-idle-transaction-timeout for the given users. A duration of 0 disables the timeout for the user.")
 }
 
 // vtgateHandler implements the Listener interface.
@@ -125,8 +150,22 @@ type vtgateHandler struct {
 
 	vtg         *VTGate
 	connections map[uint32]*mysql.Conn
+	// processes tracks what the authenticated connections are doing, for
+	// SHOW PROCESSLIST.
+	processes map[uint32]*process
+
+	// checkpoints is set if session checkpointing is enabled.
+	checkpoints *sessionCheckpointStore
 
 	busyConnections atomic.Int32
+
+	// questions and slowQueries are reported to COM_STATISTICS.
+	questions   atomic.Int64
+	slowQueries atomic.Int64
+
+	// idleTimeouts is set if idle transactions are rolled back.
+	idleTimeouts     *idleTransactionTimeouts
+	idleTransactions map[uint32]*idleTransaction
 }
 
 type vtgateMySQLConnection struct {
@@ -144,6 +183,9 @@ func (vmc *vtgateMySQLConnection) KillConnection(ctx context.Context, connection
 }
 
 func (vmc *vtgateMySQLConnection) SetQueryWasSlow(slow bool) {
+	if slow {
+		vmc.handler.slowQueries.Add(1)
+	}
 	setSlowQueryStatus(vmc.conn, slow)
 	vmc.slowQueryStates = append(vmc.slowQueryStates, slow)
 }
@@ -152,10 +194,16 @@ func (vmc *vtgateMySQLConnection) IngressBytes() uint64 {
 	return vmc.conn.IngressBytes()
 }
 
+func (vmc *vtgateMySQLConnection) LocalInfile(filename string) (io.ReadCloser, error) {
+	return vmc.conn.LocalInfile(filename)
+}
+
 func newVtgateHandler(vtg *VTGate) *vtgateHandler {
 	return &vtgateHandler{
-		vtg:         vtg,
-		connections: make(map[uint32]*mysql.Conn),
+		vtg:              vtg,
+		connections:      make(map[uint32]*mysql.Conn),
+		processes:        make(map[uint32]*process),
+		idleTransactions: make(map[uint32]*idleTransaction),
 	}
 }
 
@@ -169,6 +217,19 @@ func (vh *vtgateHandler) NewConnection(c *mysql.Conn) {
 	vh.connections[c.ConnectionID] = c
 }
 
+// openConnections returns the connections that are open.
+func (vh *vtgateHandler) openConnections() []*mysql.Conn {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	conns := make([]*mysql.Conn, 0, len(vh.connections))
+	for _, c := range vh.connections {
+		if c != nil {
+			conns = append(conns, c)
+		}
+	}
+	return conns
+}
+
 func (vh *vtgateHandler) numConnections() int {
 	vh.mu.Lock()
 	defer vh.mu.Unlock()
@@ -176,6 +237,7 @@ func (vh *vtgateHandler) numConnections() int {
 }
 
 func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
+	defer vh.closeIdleTransaction(c, false)()
 	ctx := context.Background()
 	session := vh.session(c)
 	if session.InTransaction {
@@ -187,13 +249,40 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	}
 }
 
+// ComStatistics returns the vtgate-level statistics of the MySQL server.
+func (vh *vtgateHandler) ComStatistics(c *mysql.Conn) mysql.ServerStatistics {
+	return mysql.ServerStatistics{
+		Uptime:      servenv.Uptime(),
+		Threads:     int64(vh.numConnections()),
+		Questions:   vh.questions.Load(),
+		SlowQueries: vh.slowQueries.Load(),
+	}
+}
+
+// ComDebug logs the server statistics on behalf of a user that holds the
+// administrative role.
+func (vh *vtgateHandler) ComDebug(c *mysql.Conn) error {
+	im := c.UserData.Get()
+	if !adminacl.Authorized(im) {
+		return vterrors.NewErrorf(vtrpcpb.Code_PERMISSION_DENIED, vterrors.AccessDeniedError, "User '%s' is not authorized to run COM_DEBUG", im.GetUsername())
+	}
+	log.Info("COM_DEBUG",
+		slog.String("user", im.GetUsername()),
+		slog.String("conn", c.String()),
+		slog.String("statistics", vh.ComStatistics(c).String()),
+		slog.Int("busy_connections", int(vh.busyConnections.Load())))
+	return nil
+}
+
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 	// Rollback if there is an ongoing transaction. Ignore error.
 	defer func() {
 		vh.mu.Lock()
 		delete(vh.connections, c.ConnectionID)
+		delete(vh.processes, c.ConnectionID)
 		vh.mu.Unlock()
 	}()
+	defer vh.closeIdleTransaction(c, true)()
 
 	var ctx context.Context
 	var cancel context.CancelFunc
@@ -290,11 +379,20 @@ func startSpanFromPrepare(ctx context.Context, prepare *mysql.PrepareData, label
 }
 
 func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	vh.questions.Add(1)
+	commandDone, err := vh.startCommand(c)
+	if err != nil {
+		return err
+	}
+	defer commandDone()
+	defer vh.startProcess(c, "Query", query)()
+
 	session := vh.session(c)
 	if c.IsShuttingDown() && !session.InTransaction {
 		c.MarkForClose()
-		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
+		return shutdownError(vh.checkpointSession(c, session))
 	}
+	setQueryAttributes(c, session)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
@@ -334,7 +432,9 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		}
 	}()
 
-	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+	// A stored procedure call can return several result sets, which only the
+	// streaming path returns, so it is streamed whatever the workload.
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP || sqlparser.Preview(query) == sqlparser.StmtCallProc {
 		streamCallback, deferredResult := deferFirstOKOnlyResult(callback)
 		session, err := vh.vtg.StreamExecute(ctx, mysqlCtx, session, query, make(map[string]*querypb.BindVariable), false, streamCallback)
 		if err != nil {
@@ -357,11 +457,20 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 
 // ComQueryMulti is a newer version of ComQuery that supports running multiple queries in a single call.
 func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error) error {
+	vh.questions.Add(1)
+	commandDone, err := vh.startCommand(c)
+	if err != nil {
+		return err
+	}
+	defer commandDone()
+	defer vh.startProcess(c, "Query", sql)()
+
 	session := vh.session(c)
 	if c.IsShuttingDown() && !session.InTransaction {
 		c.MarkForClose()
-		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
+		return shutdownError(vh.checkpointSession(c, session))
 	}
+	setQueryAttributes(c, session)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
@@ -396,7 +505,9 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		}
 	}()
 
-	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+	// A stored procedure call can return several result sets, which only the
+	// streaming path returns, so it is streamed whatever the workload.
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP || sqlparser.Preview(sql) == sqlparser.StmtCallProc {
 		if c.Capabilities&mysql.CapabilityClientMultiStatements != 0 {
 			session, err = vh.streamExecuteMultiQuery(ctx, c, mysqlCtx, session, sql, callback)
 		} else {
@@ -411,7 +522,7 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 				defer func() {
 					firstPacket = false
 				}()
-				return callback(sqltypes.QueryResponse{QueryResult: result}, false, firstPacket)
+				return callback(sqltypes.QueryResponse{QueryResult: result}, false, firstPacket || result.NewResult)
 			})
 			if err == nil && deferredResult != nil {
 				fillInTxStatusFlags(c, session)
@@ -485,7 +596,7 @@ func (vh *vtgateHandler) streamExecuteMultiQuery(ctx context.Context, c *mysql.C
 				defer func() {
 					firstPacket = false
 				}()
-				return callback(sqltypes.QueryResponse{QueryResult: result}, more, firstPacket)
+				return callback(sqltypes.QueryResponse{QueryResult: result}, more, firstPacket || result.NewResult)
 			})
 		}()
 		if err != nil {
@@ -546,6 +657,13 @@ func slowQueryStatusFlags(statusFlags uint16, slow bool) uint16 {
 
 // ComPrepare is the handler for command prepare.
 func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Field, uint16, error) {
+	commandDone, err := vh.startCommand(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer commandDone()
+	defer vh.startProcess(c, "Prepare", query)()
+
 	var ctx context.Context
 	var cancel context.CancelFunc
 	if mysqlQueryTimeout != 0 {
@@ -571,6 +689,7 @@ func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Fie
 	ctx = vtgateservice.ContextWithIngressBytes(ctx, c.IngressBytes())
 
 	session := vh.session(c)
+	setQueryAttributes(c, session)
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -589,6 +708,14 @@ func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Fie
 }
 
 func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	vh.questions.Add(1)
+	commandDone, err := vh.startCommand(c)
+	if err != nil {
+		return err
+	}
+	defer commandDone()
+	defer vh.startProcess(c, "Execute", prepare.PrepareStmt)()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
 
@@ -619,6 +746,7 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	mysqlCtx := &vtgateMySQLConnection{handler: vh, conn: c}
 
 	session := vh.session(c)
+	setQueryAttributes(c, session)
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -1012,11 +1140,85 @@ func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 		if c.Capabilities&mysql.CapabilityClientFoundRows != 0 {
 			session.Options.ClientFoundRows = true
 		}
+		if restored := vh.restoreSession(c); restored != nil {
+			session = restored
+		}
 		c.ClientData = session
 	}
 	return session
 }
 
+// setQueryAttributes passes the query attributes the client sent along with
+// the command c executes down in the execute options of session, replacing
+// those of the previous command: they only apply to one command.
+func setQueryAttributes(c *mysql.Conn, session *vtgatepb.Session) {
+	attributes := c.QueryAttributes()
+	if len(attributes) == 0 {
+		session.Options.QueryAttributes = nil
+		return
+	}
+	queryAttributes := make(map[string]string, len(attributes))
+	for _, attr := range attributes {
+		// NULL attributes are not passed down.
+		if !attr.Value.IsNull() {
+			queryAttributes[attr.Name] = attr.Value.ToString()
+		}
+	}
+	session.Options.QueryAttributes = queryAttributes
+}
+
+// checkpointSession saves the state of the session of c, which is being
+// closed because vtgate is shutting down, and returns the token the client
+// can resume it with. It returns an empty token if checkpointing is disabled
+// or failed.
+func (vh *vtgateHandler) checkpointSession(c *mysql.Conn, session *vtgatepb.Session) string {
+	if vh.checkpoints == nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	token, err := vh.checkpoints.Save(ctx, c.User, session)
+	if err != nil {
+		log.Warn("Failed to checkpoint session", slog.Any("connection_id", c.ConnectionID), slog.Any("error", err))
+		return ""
+	}
+	return token
+}
+
+// closeIdleConnection checkpoints the session of c and closes it, if
+// checkpointing is enabled and c is waiting for its next command. The
+// client reads the shutdown error, with the checkpoint token, as the
+// response to its next command. It returns false if c was left open.
+func (vh *vtgateHandler) closeIdleConnection(c *mysql.Conn) bool {
+	if vh.checkpoints == nil {
+		return false
+	}
+	return c.CloseIfIdle(func() error {
+		return shutdownError(vh.checkpointSession(c, vh.session(c)))
+	})
+}
+
+// restoreSession returns the session checkpointed under the token in the
+// connection attributes of c, if any. Failing to restore a session is not
+// fatal: the client gets a new session instead.
+func (vh *vtgateHandler) restoreSession(c *mysql.Conn) *vtgatepb.Session {
+	token, ok := c.Attributes[SessionCheckpointAttribute]
+	if vh.checkpoints == nil || !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	session, err := vh.checkpoints.Restore(ctx, c.User, token)
+	if err != nil {
+		log.Warn("Failed to restore session checkpoint", slog.Any("connection_id", c.ConnectionID), slog.Any("error", err))
+		return nil
+	}
+	if session.Options == nil {
+		session.Options = &querypb.ExecuteOptions{IncludedFields: querypb.ExecuteOptions_ALL}
+	}
+	return session
+}
+
 type mysqlServer struct {
 	tcpListener  *mysql.Listener
 	unixListener *mysql.Listener
@@ -1093,6 +1295,26 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	var err error
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
+	vtgate.executor.setProcessLister(srv.vtgateHandle)
+	srv.vtgateHandle.idleTimeouts, err = newIdleTransactionTimeouts(mysqlIdleTransactionTimeout, mysqlIdleTransactionTimeoutPerUser)
+	if err != nil {
+		log.Error(fmt.Sprintf("Invalid --mysql-server-idle-transaction-timeout-per-user: %v", err))
+		os.Exit(1)
+	}
+	if mysqlSessionCheckpoint {
+		ts, err := vtgate.executor.serv.GetTopoServer()
+		if err != nil {
+			log.Error(fmt.Sprintf("Session checkpointing disabled, cannot get topo server: %v", err))
+		} else {
+			srv.vtgateHandle.checkpoints = newSessionCheckpointStore(ts, mysqlSessionCheckpointTTL)
+			srv.vtgateHandle.checkpoints.Open()
+		}
+	}
+	compressionAlgorithms, err := mysql.ParseCompressionAlgorithms(mysqlServerCompressionAlgorithms)
+	if err != nil {
+		log.Error(fmt.Sprintf("Invalid --mysql-server-compression-algorithms: %v", err))
+		os.Exit(1)
+	}
 	if mysqlServerPort >= 0 {
 		listener, err := servenv.Listen(mysqlTCPVersion, net.JoinHostPort(mysqlServerBindAddress, strconv.Itoa(mysqlServerPort)))
 		if err != nil {
@@ -1124,7 +1346,27 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 
 			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
 		}
+		if len(mysqlProxyProtocolTrustedCIDRs) > 0 {
+			if !mysqlProxyProtocol {
+				log.Error("--proxy-protocol-trusted-cidrs requires --proxy-protocol")
+				os.Exit(1)
+			}
+			if err := srv.tcpListener.TrustProxyProtocolFrom(mysqlProxyProtocolTrustedCIDRs); err != nil {
+				log.Error(fmt.Sprintf("Invalid --proxy-protocol-trusted-cidrs: %v", err))
+				os.Exit(1)
+			}
+		}
+		if mysqlCachingSha2PrivateKey != "" {
+			srv.tcpListener.CachingSha2PasswordPrivateKey, err = mysql.LoadCachingSha2PasswordPrivateKey(mysqlCachingSha2PrivateKey)
+			if err != nil {
+				log.Error(fmt.Sprintf("Invalid --mysql-server-caching-sha2-password-private-key: %v", err))
+				os.Exit(1)
+			}
+		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		srv.tcpListener.CompressionAlgorithms = compressionAlgorithms
+		srv.tcpListener.AllowLocalInfile = mysqlServerAllowLocalInfile
+		srv.tcpListener.MaxCursorSize = mysqlServerMaxCursorSize
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Info(fmt.Sprintf("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold))
@@ -1263,21 +1505,18 @@ func (srv *mysqlServer) rollbackAtShutdown() {
 		// we still haven't been able to initialise the vtgateHandler, so we don't need to rollback anything
 		return
 	}
+	if srv.vtgateHandle.checkpoints != nil {
+		defer srv.vtgateHandle.checkpoints.Close()
+	}
 
 	// Close all open connections. If they're waiting for reads, this will cause
 	// them to error out, which will automatically rollback open transactions.
-	func() {
-		if srv.vtgateHandle != nil {
-			srv.vtgateHandle.mu.Lock()
-			defer srv.vtgateHandle.mu.Unlock()
-			for id, c := range srv.vtgateHandle.connections {
-				if c != nil {
-					log.Info(fmt.Sprintf("Rolling back transactions associated with connection ID: %v", id))
-					c.Close()
-				}
-			}
+	for _, c := range srv.vtgateHandle.openConnections() {
+		log.Info(fmt.Sprintf("Rolling back transactions associated with connection ID: %v", c.ConnectionID))
+		if !srv.vtgateHandle.closeIdleConnection(c) {
+			c.Close()
 		}
-	}()
+	}
 
 	// If vtgate is instead busy executing a query, the number of open conns
 	// will be non-zero. Give another second for those queries to finish.
@@ -21,6 +21,7 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -44,10 +45,14 @@ import (
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/adminacl"
 	"vitess.io/vitess/go/vt/vtgate/binlogacl"
 )
 
@@ -367,6 +372,74 @@ func TestDefaultWorkloadOLAP(t *testing.T) {
 	require.Equal(t, querypb.ExecuteOptions_OLAP, sess.Options.Workload, "Expected default workload OLAP")
 }
 
+func TestSessionRestoredFromCheckpoint(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	vh := &vtgateHandler{checkpoints: newSessionCheckpointStore(ts, time.Minute)}
+
+	token, err := vh.checkpoints.Save(ctx, "user1", &vtgatepb.Session{
+		Autocommit:      true,
+		TargetString:    "ks",
+		SystemVariables: map[string]string{"sql_mode": "''"},
+	})
+	require.NoError(t, err)
+
+	sess := vh.session(&mysql.Conn{User: "user1", Attributes: mysql.ConnectionAttributes{SessionCheckpointAttribute: token}})
+	assert.Equal(t, "ks", sess.TargetString)
+	assert.Equal(t, map[string]string{"sql_mode": "''"}, sess.SystemVariables)
+	assert.Equal(t, querypb.ExecuteOptions_ALL, sess.Options.IncludedFields)
+
+	// An unknown token results in a new session.
+	sess = vh.session(&mysql.Conn{User: "user1", Attributes: mysql.ConnectionAttributes{SessionCheckpointAttribute: token}})
+	assert.Empty(t, sess.TargetString)
+	assert.NotEmpty(t, sess.SessionUUID)
+}
+
+func TestRollbackAtShutdownCheckpointsIdleSessions(t *testing.T) {
+	vtgate, _, _ := createVtgateEnv(t)
+	ts := memorytopo.NewServer(t.Context(), "cell1")
+	defer ts.Close()
+
+	vh := newVtgateHandler(vtgate)
+	vh.checkpoints = newSessionCheckpointStore(ts, time.Minute)
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), vh, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+	go listener.Accept()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	params := &mysql.ConnParams{
+		Host:  addr.IP.String(),
+		Port:  addr.Port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	conn, err := mysql.Connect(t.Context(), params)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecuteFetch("set @a = 1", 1, false)
+	require.NoError(t, err)
+
+	listener.Shutdown()
+	srv := &mysqlServer{vtgateHandle: vh}
+	srv.rollbackAtShutdown()
+	assert.Zero(t, vh.numConnections())
+
+	// The idle connection got the shutdown error, with the checkpoint token
+	// as the argument of the VT14006 error, before it was closed.
+	err = conn.Ping()
+	sqlErr, ok := err.(*sqlerror.SQLError)
+	require.True(t, ok, "Wrong error type: %T", err)
+	assert.Equal(t, sqlerror.ERServerShutdown, sqlErr.Number())
+	prefix := "VT14006: server shutdown in progress, resume the session with the vitess_session_checkpoint connection attribute set to "
+	require.True(t, strings.HasPrefix(sqlErr.Message, prefix), sqlErr.Message)
+
+	session, err := vh.checkpoints.Restore(t.Context(), "user1", strings.TrimPrefix(sqlErr.Message, prefix))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*querypb.BindVariable{"a": sqltypes.Int64BindVariable(1)}, session.UserDefinedVariables)
+}
+
 func TestInitTLSConfigWithoutServerCA(t *testing.T) {
 	testInitTLSConfig(t, false)
 }
@@ -446,6 +519,115 @@ func TestKillMethods(t *testing.T) {
 	require.True(t, mysqlConn.IsMarkedForClose())
 }
 
+// TestShowProcessList checks that SHOW PROCESSLIST lists the connections of
+// the MySQL protocol server with the IDs that KILL takes.
+func TestShowProcessList(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	executor.setProcessLister(vh)
+
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	conns := make([]*mysql.Conn, 2)
+	for i := range conns {
+		c := mysql.GetTestServerConn(listener)
+		c.ConnectionID = uint32(i + 1)
+		c.User = fmt.Sprintf("user%d", i+1)
+		c.UserData = &mysql.StaticUserData{Username: c.User}
+		if i == 1 {
+			c.Attributes = mysql.ConnectionAttributes{mysql.ConnAttrProgramName: "reporter"}
+		}
+		vh.NewConnection(c)
+		vh.ConnectionReady(c)
+		conns[i] = c
+	}
+	vh.session(conns[1]).TargetString = KsTestSharded
+
+	// The second connection runs a long statement.
+	longQuery := "select /* " + strings.Repeat("x", 200) + " */ 1"
+	done := vh.startProcess(conns[1], "Query", longQuery)
+
+	showProcessList := func(query string) [][]sqltypes.Value {
+		var rows [][]sqltypes.Value
+		err := vh.ComQuery(conns[0], query, func(result *sqltypes.Result) error {
+			rows = append(rows, result.Rows...)
+			return nil
+		})
+		require.NoError(t, err)
+		return rows
+	}
+
+	// Without the administrative role, a user only sees its own connections.
+	rows := showProcessList("show full processlist")
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, "1", rows[0][0].ToString())
+
+	adminacl.AuthorizedAdminUsers.Set(adminacl.NewAuthorizedAdminUsers("user1"))
+	t.Cleanup(func() {
+		adminacl.AuthorizedAdminUsers.Set(adminacl.NewAuthorizedAdminUsers(""))
+	})
+	rows = showProcessList("show full processlist")
+	require.Len(t, rows, 2)
+	assert.Equal(t, `[UINT64(1) VARCHAR("user1") VARCHAR("a") NULL VARCHAR("Query") INT32(0) VARCHAR("executing") VARCHAR("show full processlist") NULL]`, fmt.Sprint(rows[0]))
+	assert.Equal(t, `[UINT64(2) VARCHAR("user2") VARCHAR("a") VARCHAR("TestExecutor") VARCHAR("Query") INT32(0) VARCHAR("executing") VARCHAR("`+longQuery+`") VARCHAR("reporter")]`, fmt.Sprint(rows[1]))
+
+	// Without FULL, the statements are truncated.
+	rows = showProcessList("show processlist")
+	require.Len(t, rows, 2)
+	assert.Equal(t, longQuery[:100], rows[1][7].ToString())
+
+	done()
+	rows = showProcessList("show processlist")
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Sleep", rows[1][4].ToString())
+	assert.Equal(t, "", rows[1][6].ToString())
+	assert.True(t, rows[1][7].IsNull())
+
+	// The closed connections are gone.
+	vh.ConnectionClosed(conns[1])
+	rows = showProcessList("show processlist")
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, "1", rows[0][0].ToString())
+}
+
+func TestQueryAttributesPassedDown(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	c := mysql.GetTestServerConn(listener)
+	c.UserData = &mysql.StaticUserData{}
+	vh.session(c).TargetString = KsTestSharded
+
+	execute := func(attributes []mysql.QueryAttribute) *querypb.ExecuteOptions {
+		sbc1.ClearOptions()
+		mysql.SetTestQueryAttributes(c, attributes)
+		err := vh.ComQuery(c, "select id from user where id = 1", func(*sqltypes.Result) error { return nil })
+		require.NoError(t, err)
+		options := sbc1.GetOptions()
+		require.Len(t, options, 1)
+		return options[0]
+	}
+
+	options := execute([]mysql.QueryAttribute{
+		{Name: "workload", Value: sqltypes.NewVarChar("reporting")},
+		{Name: "priority", Value: sqltypes.NewInt64(3)},
+		{Name: "none", Value: sqltypes.NULL},
+	})
+	assert.Equal(t, map[string]string{"workload": "reporting", "priority": "3"}, options.QueryAttributes)
+
+	// The attributes only apply to the query they are sent with.
+	options = execute(nil)
+	assert.Empty(t, options.QueryAttributes)
+}
+
 func TestComQueryMulti(t *testing.T) {
 	testcases := []struct {
 		name           string
@@ -945,6 +1127,84 @@ func TestComQueryMulti(t *testing.T) {
 	}
 }
 
+// TestCallProcResultSets verifies that every result set of a stored procedure
+// call is returned, each starting with a new result, also for the OLTP
+// workload.
+func TestCallProcResultSets(t *testing.T) {
+	executor, _, _, sbclookup, _ := createExecutorEnv(t)
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	vh.connections[1] = mysqlConn
+	vh.session(mysqlConn).TargetString = KsTestUnsharded
+
+	fields1 := sqltypes.MakeTestFields("a", "int64")
+	fields2 := sqltypes.MakeTestFields("b", "varchar")
+	testcases := []struct {
+		name string
+		exec func(add func(result *sqltypes.Result, newResult bool)) error
+	}{{
+		name: "ComQuery",
+		exec: func(add func(result *sqltypes.Result, newResult bool)) error {
+			first := true
+			return vh.ComQuery(mysqlConn, "call proc()", func(qr *sqltypes.Result) error {
+				add(qr, first || qr.NewResult)
+				first = false
+				return nil
+			})
+		},
+	}, {
+		name: "ComQueryMulti",
+		exec: func(add func(result *sqltypes.Result, newResult bool)) error {
+			return vh.ComQueryMulti(mysqlConn, "call proc()", func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error {
+				require.NoError(t, qr.QueryError)
+				assert.False(t, more)
+				add(qr.QueryResult, firstPacket)
+				return nil
+			})
+		},
+	}, {
+		name: "ComQueryMulti with multi statements",
+		exec: func(add func(result *sqltypes.Result, newResult bool)) error {
+			mysqlConn.Capabilities |= mysql.CapabilityClientMultiStatements
+			defer func() {
+				mysqlConn.Capabilities &^= mysql.CapabilityClientMultiStatements
+			}()
+			return vh.ComQueryMulti(mysqlConn, "call proc()", func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error {
+				require.NoError(t, qr.QueryError)
+				assert.False(t, more)
+				add(qr.QueryResult, firstPacket)
+				return nil
+			})
+		},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			second := sqltypes.MakeTestResult(fields2, "x", "y")
+			second.NewResult = true
+			sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(fields1, "1"), second})
+
+			var results []*sqltypes.Result
+			err := tc.exec(func(result *sqltypes.Result, newResult bool) {
+				if newResult {
+					results = append(results, &sqltypes.Result{Fields: result.Fields})
+				}
+				results[len(results)-1].Rows = append(results[len(results)-1].Rows, result.Rows...)
+			})
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			assert.True(t, sqltypes.MakeTestResult(fields1, "1").Equal(results[0]), "got: %v", results[0])
+			assert.True(t, sqltypes.MakeTestResult(fields2, "x", "y").Equal(results[1]), "got: %v", results[1])
+		})
+	}
+}
+
 func TestSlowQueryStatusFlagsComQuery(t *testing.T) {
 	executor, sbc1, _, _, _ := createExecutorEnv(t)
 
@@ -987,6 +1247,62 @@ func TestSlowQueryStatusFlagsComQuery(t *testing.T) {
 	assert.Zero(t, mysqlConn.StatusFlags&mysql.ServerQueryWasSlow)
 }
 
+func TestComStatistics(t *testing.T) {
+	executor, sbc1, _, _, _ := createExecutorEnv(t)
+
+	oldThreshold := slowQueryThreshold
+	slowQueryThreshold = 5 * time.Millisecond
+	t.Cleanup(func() {
+		slowQueryThreshold = oldThreshold
+		sbc1.ExecDelayResponse = 0
+	})
+
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{}
+
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	vh.connections[1] = mysqlConn
+
+	stats := vh.ComStatistics(mysqlConn)
+	assert.EqualValues(t, 1, stats.Threads)
+	assert.Zero(t, stats.Questions)
+	assert.Zero(t, stats.SlowQueries)
+
+	sbc1.ExecDelayResponse = 20 * time.Millisecond
+	err = vh.ComQuery(mysqlConn, "select id from user where id = 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	sbc1.ExecDelayResponse = 0
+	err = vh.ComQuery(mysqlConn, "select id from user where id = 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	stats = vh.ComStatistics(mysqlConn)
+	assert.EqualValues(t, 2, stats.Questions)
+	assert.EqualValues(t, 1, stats.SlowQueries)
+	assert.Positive(t, stats.Uptime)
+
+	// ComDebug requires the administrative role.
+	mysqlConn.UserData = &mysql.StaticUserData{Username: "dba"}
+	err = vh.ComDebug(mysqlConn)
+	require.ErrorContains(t, err, "User 'dba' is not authorized to run COM_DEBUG")
+	assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+
+	adminacl.AuthorizedAdminUsers.Set(adminacl.NewAuthorizedAdminUsers("dba"))
+	t.Cleanup(func() {
+		adminacl.AuthorizedAdminUsers.Set(adminacl.NewAuthorizedAdminUsers(""))
+	})
+	require.NoError(t, vh.ComDebug(mysqlConn))
+}
+
 // waitForConnectionsClosed waits until the handler has run ConnectionClosed
 // for every wire connection. Tests that accept real connections must drain
 // them before returning: the server-side connection goroutine reads package
@@ -1931,7 +2247,9 @@ func TestComQueryIngressBytes(t *testing.T) {
 	select {
 	case sentStats := <-subscriber:
 		require.NotNil(t, sentStats)
-		assert.Equal(t, uint64(mysql.PacketHeaderSize+1+len(query)), sentStats.IngressBytes)
+		// The query follows an empty list of query attributes: their count
+		// and the parameter set count.
+		assert.Equal(t, uint64(mysql.PacketHeaderSize+1+2+len(query)), sentStats.IngressBytes)
 		assert.Equal(t, "select id from `user` where id = :id /* INT64 */", sentStats.SQL)
 	case <-time.After(30 * time.Second):
 		require.FailNow(t, "LogStats should have been sent to queryLogger")
@@ -1997,7 +2315,9 @@ func TestComQueryMultiOLAPIngressBytes(t *testing.T) {
 
 	queries, err := vtgate.executor.Environment().Parser().SplitStatementToPieces(query)
 	require.NoError(t, err)
-	expectedIngressBytes := allocateStatementIngressBytes(uint64(mysql.PacketHeaderSize+1+len(query)), queries)
+	// The query follows an empty list of query attributes: their count and
+	// the parameter set count.
+	expectedIngressBytes := allocateStatementIngressBytes(uint64(mysql.PacketHeaderSize+1+2+len(query)), queries)
 
 	for i, expectedIngressBytes := range expectedIngressBytes {
 		select {
@@ -34,6 +34,8 @@ func setupUnixSocket(srv *mysqlServer, authServer mysql.AuthServer, path string)
 	if err != nil {
 		return err
 	}
+	srv.unixListener.AllowLocalInfile = mysqlServerAllowLocalInfile
+	srv.unixListener.MaxCursorSize = mysqlServerMaxCursorSize
 	// Listen for unix socket
 	go srv.unixListener.Accept()
 	return nil
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/adminacl"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// processListInfoLength is how much of the statement SHOW PROCESSLIST
+// returns in the Info column, like MySQL. SHOW FULL PROCESSLIST returns
+// the whole statement.
+const processListInfoLength = 100
+
+// processInfo is a row of SHOW PROCESSLIST.
+type processInfo struct {
+	id      uint32
+	user    string
+	host    string
+	db      string
+	command string
+	time    time.Duration
+	state   string
+	info    string
+	program string
+}
+
+// processLister lists the client connections of vtgate for SHOW PROCESSLIST.
+type processLister interface {
+	processList() []processInfo
+}
+
+// process tracks what a MySQL protocol connection is doing. It is updated
+// by the goroutine serving the connection when a command starts and ends,
+// and read by the connections running SHOW PROCESSLIST, which must not
+// look at the session of another connection.
+type process struct {
+	user string
+	host string
+	// program is the program_name connection attribute of the client.
+	program string
+
+	mu sync.Mutex
+	// command is the MySQL command the connection is running, e.g. Query,
+	// or Sleep when it waits for the next one.
+	command string
+	query   string
+	// since is when the current command started, or when the last one
+	// ended if the connection sleeps.
+	since time.Time
+	db    string
+	// inTransaction and tablets describe the session: whether it has an
+	// open transaction, and the tablets it holds connections to.
+	inTransaction bool
+	tablets       []string
+}
+
+func newProcess(c *mysql.Conn) *process {
+	p := &process{user: c.User, program: callinfo.MysqlClientProgram(c.Attributes), command: "Sleep", since: time.Now()}
+	if addr := c.RemoteAddr(); addr != nil {
+		p.host = addr.String()
+	}
+	return p
+}
+
+// update records the state of session, and the command the connection
+// runs next.
+func (p *process) update(session *vtgatepb.Session, command, query string) {
+	var tablets []string
+	for _, shardSessions := range [][]*vtgatepb.Session_ShardSession{session.PreSessions, session.ShardSessions, session.PostSessions} {
+		for _, ss := range shardSessions {
+			if ss.TabletAlias != nil {
+				tablets = append(tablets, topoproto.TabletAliasString(ss.TabletAlias))
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.command = command
+	p.query = query
+	p.since = time.Now()
+	p.db = session.TargetString
+	p.inTransaction = session.InTransaction
+	p.tablets = tablets
+}
+
+// state returns the State column of the connection.
+func (p *process) state() string {
+	var state string
+	switch {
+	case p.command != "Sleep":
+		state = "executing"
+	case p.inTransaction:
+		state = "in transaction"
+	}
+	if len(p.tablets) > 0 {
+		if state != "" {
+			state += " "
+		}
+		state += "on " + strings.Join(p.tablets, ", ")
+	}
+	return state
+}
+
+// startProcess records that c starts running command, and returns the
+// function to call once it is done.
+func (vh *vtgateHandler) startProcess(c *mysql.Conn, command, query string) func() {
+	vh.mu.Lock()
+	p := vh.processes[c.ConnectionID]
+	vh.mu.Unlock()
+	if p == nil {
+		return func() {}
+	}
+	p.update(vh.session(c), command, query)
+	return func() {
+		p.update(vh.session(c), "Sleep", "")
+	}
+}
+
+// ConnectionReady is part of the mysql.Handler interface. The connections
+// are listed by SHOW PROCESSLIST once they are authenticated.
+func (vh *vtgateHandler) ConnectionReady(c *mysql.Conn) {
+	p := newProcess(c)
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	vh.processes[c.ConnectionID] = p
+}
+
+// processList implements processLister.
+func (vh *vtgateHandler) processList() []processInfo {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+
+	now := time.Now()
+	list := make([]processInfo, 0, len(vh.processes))
+	for id, p := range vh.processes {
+		p.mu.Lock()
+		list = append(list, processInfo{
+			id:      id,
+			user:    p.user,
+			host:    p.host,
+			db:      p.db,
+			command: p.command,
+			time:    now.Sub(p.since),
+			state:   p.state(),
+			info:    p.query,
+			program: p.program,
+		})
+		p.mu.Unlock()
+	}
+	slices.SortFunc(list, func(a, b processInfo) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	return list
+}
+
+// setProcessLister sets where SHOW PROCESSLIST lists the client connections from.
+func (e *Executor) setProcessLister(pl processLister) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.processes = pl
+}
+
+// ShowProcessList returns the client connections of vtgate in the format
+// of the SHOW [FULL] PROCESSLIST statement of MySQL. The Id column is the
+// connection ID that the KILL statement takes, and the State column lists
+// the tablets the session holds connections to. The extra Program column
+// is the program name the client sent in its connection attributes. Like
+// MySQL without the PROCESS privilege, callers that do not hold the
+// administrative role only see their own connections.
+func (e *Executor) ShowProcessList(ctx context.Context, full bool) (*sqltypes.Result, error) {
+	e.mu.Lock()
+	pl := e.processes
+	e.mu.Unlock()
+
+	nullableVarChar := func(name string) *querypb.Field {
+		return &querypb.Field{Name: name, Type: sqltypes.VarChar, Charset: uint32(collations.SystemCollation.Collation)}
+	}
+	fields := []*querypb.Field{
+		{Name: "Id", Type: sqltypes.Uint64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NOT_NULL_FLAG | querypb.MySqlFlag_UNSIGNED_FLAG | querypb.MySqlFlag_NUM_FLAG)},
+	}
+	fields = append(fields, buildVarCharFields("User", "Host")...)
+	fields = append(fields, nullableVarChar("db"))
+	fields = append(fields, buildVarCharFields("Command")...)
+	fields = append(fields,
+		&querypb.Field{Name: "Time", Type: sqltypes.Int32, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NOT_NULL_FLAG | querypb.MySqlFlag_NUM_FLAG)},
+		nullableVarChar("State"),
+		nullableVarChar("Info"),
+		nullableVarChar("Program"),
+	)
+
+	result := &sqltypes.Result{Fields: fields}
+	if pl == nil {
+		return result, nil
+	}
+	caller := callerid.ImmediateCallerIDFromContext(ctx)
+	admin := adminacl.Authorized(caller)
+	for _, pi := range pl.processList() {
+		if !admin && pi.user != caller.GetUsername() {
+			continue
+		}
+		db, info, program := sqltypes.NULL, sqltypes.NULL, sqltypes.NULL
+		if pi.db != "" {
+			db = sqltypes.NewVarChar(pi.db)
+		}
+		if pi.info != "" {
+			if !full && len(pi.info) > processListInfoLength {
+				if r := []rune(pi.info); len(r) > processListInfoLength {
+					pi.info = string(r[:processListInfoLength])
+				}
+			}
+			info = sqltypes.NewVarChar(pi.info)
+		}
+		if pi.program != "" {
+			program = sqltypes.NewVarChar(pi.program)
+		}
+		result.Rows = append(result.Rows, []sqltypes.Value{
+			sqltypes.NewUint64(uint64(pi.id)),
+			sqltypes.NewVarChar(pi.user),
+			sqltypes.NewVarChar(pi.host),
+			db,
+			sqltypes.NewVarChar(pi.command),
+			sqltypes.NewInt32(int32(pi.time / time.Second)),
+			sqltypes.NewVarChar(pi.state),
+			info,
+			program,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cache/theine"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vthash"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+type (
+	// resultCache caches the results of the SELECT queries that use the CACHE_TTL directive.
+	//
+	// Every table has a generation, which is bumped when the table is invalidated. A cached result
+	// remembers the generations of its tables at the time the query started, and is discarded when
+	// any of them has changed since.
+	resultCache struct {
+		store *theine.Store[theine.HashKey256, *resultCacheEntry]
+		epoch uint32
+
+		mu          sync.Mutex
+		generations map[string]uint64
+
+		// onNewTables is called with the tables of every cached result that were not seen before.
+		onNewTables func(tables []string)
+	}
+
+	resultCacheEntry struct {
+		result      *sqltypes.Result
+		expires     time.Time
+		tables      []string
+		generations []uint64
+	}
+)
+
+func newResultCache(memory int64) *resultCache {
+	return &resultCache{
+		store:       theine.NewStore[theine.HashKey256, *resultCacheEntry](memory, false),
+		generations: make(map[string]uint64),
+	}
+}
+
+// CachedSize returns the memory used by the entry, so that it can be stored in a theine.Store.
+func (entry *resultCacheEntry) CachedSize(alloc bool) int64 {
+	if entry == nil {
+		return 0
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(96)
+	}
+	size += entry.result.CachedSize(true)
+	size += int64(cap(entry.tables)) * int64(16)
+	for _, table := range entry.tables {
+		size += int64(len(table))
+	}
+	size += int64(cap(entry.generations)) * int64(8)
+	return size
+}
+
+// resultCacheKey returns the key of the result of a query. It is the key of its plan, with the
+// bind variables and the identity of the caller: the table ACLs and masking policies that apply
+// to the query depend on the caller, so callers must not be served each other's results.
+func resultCacheKey(ctx context.Context, vcursor *econtext.VCursorImpl, plan *engine.Plan, bindVars map[string]*querypb.BindVariable) (theine.HashKey256, error) {
+	planKey := buildPlanKey(ctx, vcursor, plan.Original, "").Hash()
+
+	hasher := vthash.New256()
+	_, _ = hasher.Write(planKey[:])
+
+	var buf []byte
+	writeString := func(s string) {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(s)))
+		buf = append(buf, s...)
+		_, _ = hasher.Write(buf)
+	}
+	writeGroups := func(groups []string) {
+		groups = slices.Clone(groups)
+		slices.Sort(groups)
+		buf = binary.AppendUvarint(buf[:0], uint64(len(groups)))
+		_, _ = hasher.Write(buf)
+		for _, group := range groups {
+			writeString(group)
+		}
+	}
+	// The immediate caller groups decide whether masked columns are
+	// returned unmasked, so they are part of the key too.
+	immediateCaller := callerid.ImmediateCallerIDFromContext(ctx)
+	writeString(immediateCaller.GetUsername())
+	writeGroups(immediateCaller.GetGroups())
+	effectiveCaller := callerid.EffectiveCallerIDFromContext(ctx)
+	writeString(effectiveCaller.GetPrincipal())
+	writeGroups(effectiveCaller.GetGroups())
+
+	names := make([]string, 0, len(bindVars))
+	for name := range bindVars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		bv, err := bindVars[name].MarshalVT()
+		if err != nil {
+			return theine.HashKey256{}, err
+		}
+		buf = binary.AppendUvarint(buf[:0], uint64(len(name)))
+		buf = append(buf, name...)
+		buf = binary.AppendUvarint(buf, uint64(len(bv)))
+		buf = append(buf, bv...)
+		_, _ = hasher.Write(buf)
+	}
+
+	var key theine.HashKey256
+	hasher.Sum(key[:0])
+	return key, nil
+}
+
+// get returns a copy of the cached result for key, if it has not expired or been invalidated.
+func (rc *resultCache) get(key theine.HashKey256) (*sqltypes.Result, bool) {
+	entry, ok := rc.store.Get(key, rc.epoch)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) || !slices.Equal(entry.generations, rc.currentGenerations(entry.tables)) {
+		rc.store.Delete(key)
+		return nil, false
+	}
+	return entry.result.Copy(), true
+}
+
+// currentGenerations returns the generations of the given tables. It must be called before the
+// query runs, and the returned generations passed to set along with its result.
+func (rc *resultCache) currentGenerations(tables []string) []uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	generations := make([]uint64, len(tables))
+	for i, table := range tables {
+		generations[i] = rc.generations[table]
+	}
+	return generations
+}
+
+// set caches a copy of result for ttl. generations are the generations of tables before the query ran,
+// so that an invalidation received while it was running is not lost.
+func (rc *resultCache) set(key theine.HashKey256, result *sqltypes.Result, ttl time.Duration, tables []string, generations []uint64) {
+	entry := &resultCacheEntry{
+		result:      result.Copy(),
+		expires:     time.Now().Add(ttl),
+		tables:      tables,
+		generations: generations,
+	}
+	rc.store.Set(key, entry, 0, rc.epoch)
+
+	rc.mu.Lock()
+	var newTables []string
+	for _, table := range tables {
+		if _, ok := rc.generations[table]; !ok {
+			rc.generations[table] = 0
+			newTables = append(newTables, table)
+		}
+	}
+	rc.mu.Unlock()
+
+	if len(newTables) > 0 && rc.onNewTables != nil {
+		rc.onNewTables(newTables)
+	}
+}
+
+// invalidate discards the cached results that read from any of the given tables.
+func (rc *resultCache) invalidate(tables ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, table := range tables {
+		rc.generations[table]++
+	}
+	resultCacheInvalidations.Add(int64(len(tables)))
+}
+
+// invalidateKeyspace discards the cached results that read from any table of keyspace.
+func (rc *resultCache) invalidateKeyspace(keyspace string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	prefix := keyspace + "."
+	for table := range rc.generations {
+		if strings.HasPrefix(table, prefix) {
+			rc.generations[table]++
+			resultCacheInvalidations.Add(1)
+		}
+	}
+}
+
+type (
+	// resultCacheInvalidator invalidates the result cache from the changes to the cached tables.
+	// It runs a VStream per keyspace, on the tables of the keyspace that have a cached result.
+	//
+	// Invalidation is best effort: a change made while a stream is restarted can be missed, in
+	// which case the result is served until its TTL expires.
+	resultCacheInvalidator struct {
+		ctx        context.Context
+		cache      *resultCache
+		vstream    vstreamFunc
+		retryDelay time.Duration
+
+		mu      sync.Mutex
+		streams map[string]*invalidationStream
+	}
+
+	vstreamFunc func(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func(events []*binlogdatapb.VEvent) error) error
+
+	invalidationStream struct {
+		tables []string
+		cancel context.CancelFunc
+	}
+)
+
+// startResultCacheInvalidation invalidates the cached results of the executor from the changes
+// streamed by vstream, until ctx is done.
+func (e *Executor) startResultCacheInvalidation(ctx context.Context, vstream vstreamFunc) {
+	if e.results == nil {
+		return
+	}
+	rci := &resultCacheInvalidator{
+		ctx:        ctx,
+		cache:      e.results,
+		vstream:    vstream,
+		retryDelay: 5 * time.Second,
+		streams:    make(map[string]*invalidationStream),
+	}
+	e.results.onNewTables = rci.watch
+}
+
+// watch restarts the streams of the keyspaces of tables, so that they include tables.
+func (rci *resultCacheInvalidator) watch(tables []string) {
+	rci.mu.Lock()
+	defer rci.mu.Unlock()
+
+	added := make(map[string][]string)
+	for _, table := range tables {
+		keyspace, _, ok := strings.Cut(table, ".")
+		if !ok {
+			continue
+		}
+		added[keyspace] = append(added[keyspace], table)
+	}
+
+	for keyspace, newTables := range added {
+		var watched []string
+		if stream := rci.streams[keyspace]; stream != nil {
+			stream.cancel()
+			watched = stream.tables
+		}
+		watched = append(slices.Clone(watched), newTables...)
+
+		ctx, cancel := context.WithCancel(rci.ctx)
+		rci.streams[keyspace] = &invalidationStream{tables: watched, cancel: cancel}
+		go rci.stream(ctx, keyspace, watched)
+	}
+}
+
+// stream runs the VStream of keyspace until ctx is done, restarting it when it fails.
+func (rci *resultCacheInvalidator) stream(ctx context.Context, keyspace string, tables []string) {
+	filter := &binlogdatapb.Filter{}
+	for _, table := range tables {
+		_, name, _ := strings.Cut(table, ".")
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: name})
+	}
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: keyspace, Gtid: "current"}},
+	}
+
+	for {
+		// The changes made before the stream starts are not streamed.
+		rci.cache.invalidateKeyspace(keyspace)
+
+		err := rci.vstream(ctx, topodatapb.TabletType_PRIMARY, vgtid, filter, &vtgatepb.VStreamFlags{}, func(events []*binlogdatapb.VEvent) error {
+			for _, event := range events {
+				switch event.Type {
+				case binlogdatapb.VEventType_ROW:
+					rci.cache.invalidate(event.RowEvent.TableName)
+				case binlogdatapb.VEventType_FIELD:
+					rci.cache.invalidate(event.FieldEvent.TableName)
+				case binlogdatapb.VEventType_DDL:
+					rci.cache.invalidateKeyspace(keyspace)
+				}
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn(fmt.Sprintf("result cache invalidation stream for keyspace %s failed, retrying in %v: %v", keyspace, rci.retryDelay, err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rci.retryDelay):
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/cache/theine"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestResultCache(t *testing.T) {
+	rc := newResultCache(1024 * 1024)
+	defer rc.store.Close()
+
+	key := theine.HashKey256{1}
+	tables := []string{"ks.t1", "ks.t2"}
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2")
+
+	_, ok := rc.get(key)
+	assert.False(t, ok)
+
+	rc.set(key, result, time.Hour, tables, rc.currentGenerations(tables))
+	got, ok := rc.get(key)
+	require.True(t, ok)
+	assert.Equal(t, result, got)
+
+	// The cached result is not shared with the callers.
+	got.Rows = nil
+	got, ok = rc.get(key)
+	require.True(t, ok)
+	assert.Len(t, got.Rows, 2)
+
+	// Invalidating another table keeps the result.
+	rc.invalidate("ks.t3")
+	_, ok = rc.get(key)
+	assert.True(t, ok)
+
+	rc.invalidate("ks.t2")
+	_, ok = rc.get(key)
+	assert.False(t, ok)
+
+	// An invalidation received while the query runs makes its result stale.
+	generations := rc.currentGenerations(tables)
+	rc.invalidateKeyspace("ks")
+	rc.set(key, result, time.Hour, tables, generations)
+	_, ok = rc.get(key)
+	assert.False(t, ok)
+
+	// Expired results are not returned.
+	rc.set(key, result, time.Nanosecond, tables, rc.currentGenerations(tables))
+	time.Sleep(time.Millisecond)
+	_, ok = rc.get(key)
+	assert.False(t, ok)
+}
+
+func TestResultCacheInvalidator(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	rc := newResultCache(1024 * 1024)
+	defer rc.store.Close()
+	e := &Executor{results: rc}
+
+	var mu sync.Mutex
+	filters := make(map[string]*binlogdatapb.Filter)
+	sends := make(map[string]func([]*binlogdatapb.VEvent) error)
+	e.startResultCacheInvalidation(ctx, func(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
+		assert.Equal(t, topodatapb.TabletType_PRIMARY, tabletType)
+		keyspace := vgtid.ShardGtids[0].Keyspace
+		mu.Lock()
+		filters[keyspace] = filter
+		sends[keyspace] = send
+		mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	streaming := func(keyspace string, tables int) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(filters[keyspace].GetRules()) == tables
+		}
+	}
+
+	key := theine.HashKey256{1}
+	tables := []string{"ks.t1"}
+	rc.set(key, &sqltypes.Result{}, time.Hour, tables, rc.currentGenerations(tables))
+	require.Eventually(t, streaming("ks", 1), 5*time.Second, time.Millisecond)
+
+	// A new table of the keyspace restarts its stream with all its tables.
+	rc.set(theine.HashKey256{2}, &sqltypes.Result{}, time.Hour, []string{"ks.t2"}, rc.currentGenerations([]string{"ks.t2"}))
+	require.Eventually(t, streaming("ks", 2), 5*time.Second, time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, "t1", filters["ks"].Rules[0].Match)
+	assert.Equal(t, "t2", filters["ks"].Rules[1].Match)
+	send := sends["ks"]
+	mu.Unlock()
+
+	rc.set(key, &sqltypes.Result{}, time.Hour, tables, rc.currentGenerations(tables))
+	_, ok := rc.get(key)
+	require.True(t, ok)
+
+	err := send([]*binlogdatapb.VEvent{{
+		Type:     binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{TableName: "ks.t1"},
+	}})
+	require.NoError(t, err)
+	_, ok = rc.get(key)
+	assert.False(t, ok)
+}
+
+func TestExecutorResultCache(t *testing.T) {
+	eConfig := createExecutorConfig()
+	eConfig.ResultCacheMemory = 1024 * 1024
+	executor, _, _, sbclookup, ctx := createExecutorEnvWithConfig(t, eConfig)
+
+	executed := func() int64 {
+		return sbclookup.ExecCount.Load()
+	}
+	start := executed()
+
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	query := "select /*vt+ CACHE_TTL=1h */ id from main1 where id = 1"
+	for range 3 {
+		_, err := executorExec(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, executed()-start)
+
+	// Queries without the directive are not cached.
+	_, err := executorExec(ctx, executor, session, "select id from main1 where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, executed()-start)
+
+	// The bind variables are part of the key.
+	_, err = executorExec(ctx, executor, session, "select /*vt+ CACHE_TTL=1h */ id from main1 where id = :id", map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(1)})
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "select /*vt+ CACHE_TTL=1h */ id from main1 where id = :id", map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(2)})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, executed()-start)
+
+	// Transactions do not use the cache.
+	_, err = executorExec(ctx, executor, &vtgatepb.Session{TargetString: "@primary", Autocommit: true, InTransaction: true}, query, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, executed()-start)
+
+	executor.results.invalidate(KsTestUnsharded + ".main1")
+	_, err = executorExec(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, executed()-start)
+
+	// Callers with a different identity do not share cached results.
+	ctxRedUser := callerid.NewContext(ctx, &vtrpcpb.CallerID{Principal: "red"}, &querypb.VTGateCallerID{Username: "redUser"})
+	ctxBlueUser := callerid.NewContext(ctx, &vtrpcpb.CallerID{Principal: "blue"}, &querypb.VTGateCallerID{Username: "blueUser"})
+	ctxBlueAdmin := callerid.NewContext(ctx, &vtrpcpb.CallerID{Principal: "blue", Groups: []string{"admin"}}, &querypb.VTGateCallerID{Username: "blueUser"})
+	ctxBlueUnmasked := callerid.NewContext(ctx, &vtrpcpb.CallerID{Principal: "blue"}, &querypb.VTGateCallerID{Username: "blueUser", Groups: []string{engine.UnmaskedRole}})
+	for range 2 {
+		for _, callerCtx := range []context.Context{ctxRedUser, ctxBlueUser, ctxBlueAdmin, ctxBlueUnmasked} {
+			_, err = executorExec(callerCtx, executor, session, query, nil)
+			require.NoError(t, err)
+		}
+	}
+	assert.EqualValues(t, 10, executed()-start)
+}
@@ -18,12 +18,14 @@ package vtgate
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -143,6 +145,9 @@ const (
 // It always returns a non-nil query result and an array of
 // shard errors which may be nil so that callers can optionally
 // process a partially-successful operation.
+//
+// The execution fails if the shards return more than maxMemoryRows rows
+// in total, unless it is econtext.UnlimitedMemoryRows.
 func (stc *ScatterConn) ExecuteMultiShard(
 	ctx context.Context,
 	primitive engine.Primitive,
@@ -150,7 +155,7 @@ func (stc *ScatterConn) ExecuteMultiShard(
 	queries []*querypb.BoundQuery,
 	session *econtext.SafeSession,
 	autocommit bool,
-	ignoreMaxMemoryRows bool,
+	maxMemoryRows int,
 	resultsObserver econtext.ResultsObserver,
 	fetchLastInsertID bool,
 ) (qr *sqltypes.Result, errs []error) {
@@ -161,6 +166,8 @@ func (stc *ScatterConn) ExecuteMultiShard(
 	// mu protects qr
 	var mu sync.Mutex
 	qr = new(sqltypes.Result)
+	// warnings holds the warnings of each shard, to record them in shard order
+	warnings := make([][]*querypb.QueryWarning, len(rss))
 
 	if session.InLockSession() && triggerLockHeartBeat(session) {
 		go stc.runLockQuery(ctx, session)
@@ -177,6 +184,7 @@ func (stc *ScatterConn) ExecuteMultiShard(
 	if session != nil && session.Session != nil && session.Session.Options != nil {
 		callOptions = session.Session.Options.CloneVT()
 		callOptions.FetchLastInsertId = fetchLastInsertID
+		callOptions.JsonEncoding = stc.jsonEncoding(callOptions.JsonEncoding, rss)
 	}
 
 	allErrors := stc.multiGoTransaction(
@@ -207,6 +215,10 @@ func (stc *ScatterConn) ExecuteMultiShard(
 				if transactionID != int64(0) {
 					return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "in autocommit mode, transactionID should be zero but was: %d", transactionID)
 				}
+				if dmlIdempotencyKeys && info.actionNeeded == nothing && reservedID == 0 &&
+					stc.gateway.TargetHasCapability(rs.Target, queryservice.CapabilityIdempotencyKey) {
+					opts = withIdempotencyKey(opts)
+				}
 			}
 
 			qs, err = getQueryService(ctx, rs, info, session, false)
@@ -285,29 +297,80 @@ func (stc *ScatterConn) ExecuteMultiShard(
 
 			if innerqr != nil {
 				resultsObserver.Observe(innerqr)
+				warnings[i] = innerqr.Warnings
 			}
 
 			// Don't append more rows if row count is exceeded.
-			if ignoreMaxMemoryRows || len(qr.Rows) <= maxMemoryRows {
+			if maxMemoryRows == econtext.UnlimitedMemoryRows || len(qr.Rows) <= maxMemoryRows {
 				qr.AppendResult(innerqr)
 			}
 			return newInfo, nil
 		},
 	)
 
-	if !ignoreMaxMemoryRows && len(qr.Rows) > maxMemoryRows {
+	// The warnings are kept in the session for SHOW WARNINGS, rather than
+	// in the result, which the primitives above do not carry over.
+	qr.Warnings = nil
+	recordShardWarnings(session, rss, warnings)
+
+	if maxMemoryRows != econtext.UnlimitedMemoryRows && len(qr.Rows) > maxMemoryRows {
 		return nil, []error{vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "in-memory row count exceeded allowed limit of %d", maxMemoryRows)}
 	}
 
 	return qr, allErrors.GetErrors()
 }
 
+// recordShardWarnings records the warnings MySQL raised on each shard in the
+// session. When the query ran on more than one shard, the message of each
+// warning is prefixed with the target of its shard, the same way errors are.
+func recordShardWarnings(session *econtext.SafeSession, rss []*srvtopo.ResolvedShard, warnings [][]*querypb.QueryWarning) {
+	for i, shardWarnings := range warnings {
+		for _, warning := range shardWarnings {
+			if len(rss) > 1 {
+				target := rss[i].Target
+				warning = warning.CloneVT()
+				warning.Message = fmt.Sprintf("target: %s.%s.%s: %s", target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType), warning.Message)
+			}
+			session.RecordWarning(warning)
+		}
+	}
+}
+
 func triggerLockHeartBeat(session *econtext.SafeSession) bool {
 	now := time.Now().Unix()
 	lastHeartbeat := session.GetLockHeartbeat()
 	return now-lastHeartbeat >= int64(lockHeartbeatTime.Seconds())
 }
 
+// withIdempotencyKey returns a copy of opts with a new idempotency key. The
+// gateway sends the same options when it retries the DML, so the tablet
+// doesn't apply it twice.
+func withIdempotencyKey(opts *querypb.ExecuteOptions) *querypb.ExecuteOptions {
+	if opts == nil {
+		opts = &querypb.ExecuteOptions{}
+	} else {
+		opts = opts.CloneVT()
+	}
+	opts.IdempotencyKey = uuid.NewString()
+	return opts
+}
+
+// jsonEncoding returns the encoding of the values of JSON columns to ask the
+// tablets of rss for. The binary encoding is only asked for if all of them
+// support it, so that the values of a column are encoded the same way in the
+// results of every shard.
+func (stc *ScatterConn) jsonEncoding(encoding querypb.ExecuteOptions_JSONEncoding, rss []*srvtopo.ResolvedShard) querypb.ExecuteOptions_JSONEncoding {
+	if encoding != querypb.ExecuteOptions_JSON_BINARY {
+		return encoding
+	}
+	for _, rs := range rss {
+		if !stc.gateway.TargetHasCapability(rs.Target, queryservice.CapabilityBinaryJSON) {
+			return querypb.ExecuteOptions_JSON_TEXT
+		}
+	}
+	return encoding
+}
+
 func (stc *ScatterConn) runLockQuery(ctx context.Context, session *econtext.SafeSession) {
 	rs := &srvtopo.ResolvedShard{Target: session.LockSession.Target, Gateway: stc.gateway}
 	query := &querypb.BoundQuery{Sql: "select 1", BindVariables: nil}
@@ -416,6 +479,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 	if session != nil && session.Session != nil && session.Session.Options != nil {
 		callOptions = session.Session.Options.CloneVT()
 		callOptions.FetchLastInsertId = fetchLastInsertID
+		callOptions.JsonEncoding = stc.jsonEncoding(callOptions.JsonEncoding, rss)
 	}
 
 	allErrors := stc.multiGoTransaction(
@@ -445,6 +509,10 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				if transactionID != int64(0) {
 					return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "in autocommit mode, transactionID should be zero but was: %d", transactionID)
 				}
+				if dmlIdempotencyKeys && info.actionNeeded == nothing && reservedID == 0 &&
+					stc.gateway.TargetHasCapability(rs.Target, queryservice.CapabilityIdempotencyKey) {
+					opts = withIdempotencyKey(opts)
+				}
 			}
 
 			qs, err = getQueryService(ctx, rs, info, session, false)
@@ -611,6 +679,46 @@ func (stc *ScatterConn) MessageStream(ctx context.Context, rss []*srvtopo.Resolv
 	return allErrors.AggrError(vterrors.Aggregate)
 }
 
+// MessageAckBatch acks messages on the specified shards. The ids are the
+// ids of the messages on each shard, in the same order as rss. Shards whose
+// tablets predate the batch RPCs get a MessageAck instead.
+func (stc *ScatterConn) MessageAckBatch(ctx context.Context, rss []*srvtopo.ResolvedShard, ids [][]*querypb.Value, name string) (int64, error) {
+	return stc.messageDML(ctx, "MessageAckBatch", rss, ids, func(rs *srvtopo.ResolvedShard, ids []*querypb.Value) (int64, error) {
+		if !stc.gateway.TargetHasCapability(rs.Target, queryservice.CapabilityMessageBatch) {
+			return rs.Gateway.MessageAck(ctx, rs.Target, name, ids)
+		}
+		return rs.Gateway.MessageAckBatch(ctx, rs.Target, name, ids)
+	})
+}
+
+// MessagePostpone postpones messages on the specified shards. The ids are
+// the ids of the messages on each shard, in the same order as rss. It fails
+// on the shards whose tablets predate the batch RPCs.
+func (stc *ScatterConn) MessagePostpone(ctx context.Context, rss []*srvtopo.ResolvedShard, ids [][]*querypb.Value, name string) (int64, error) {
+	return stc.messageDML(ctx, "MessagePostpone", rss, ids, func(rs *srvtopo.ResolvedShard, ids []*querypb.Value) (int64, error) {
+		if !stc.gateway.TargetHasCapability(rs.Target, queryservice.CapabilityMessageBatch) {
+			return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the tablets of %s do not all support MessagePostpone yet", topoproto.KeyspaceShardString(rs.Target.Keyspace, rs.Target.Shard))
+		}
+		return rs.Gateway.MessagePostpone(ctx, rs.Target, name, ids)
+	})
+}
+
+// messageDML runs the message action on the shards in parallel, and returns
+// the total count of messages affected, including those of the shards that
+// succeeded when others failed.
+func (stc *ScatterConn) messageDML(ctx context.Context, name string, rss []*srvtopo.ResolvedShard, ids [][]*querypb.Value, action func(rs *srvtopo.ResolvedShard, ids []*querypb.Value) (int64, error)) (int64, error) {
+	var totalCount atomic.Int64
+	allErrors := stc.multiGo(name, rss, func(rs *srvtopo.ResolvedShard, i int) error {
+		if len(ids[i]) == 0 {
+			return nil
+		}
+		count, err := action(rs, ids[i])
+		totalCount.Add(count)
+		return err
+	})
+	return totalCount.Load(), allErrors.AggrError(vterrors.Aggregate)
+}
+
 // Close closes the underlying Gateway.
 func (stc *ScatterConn) Close() error {
 	return stc.gateway.Close(context.Background())
@@ -872,6 +980,13 @@ func requireNewQS(err error, target *querypb.Target) bool {
 
 // actionInfo looks at the current session, and returns information about what needs to be done for this tablet
 func actionInfo(ctx context.Context, target *querypb.Target, session *econtext.SafeSession, autocommit bool, txMode vtgatepb.TransactionMode) (*shardActionInfo, *vtgatepb.Session_ShardSession, error) {
+	// A tablet picked by the plan itself, e.g. by a query directive, runs outside of the session.
+	if alias, ok := ctx.Value(engine.TargetTabletAlias).(*topodatapb.TabletAlias); ok {
+		return &shardActionInfo{
+			actionNeeded: nothing,
+			alias:        alias,
+		}, nil, nil
+	}
 	if !session.InTransaction() && !session.InReservedConn() {
 		// Check for tablet-specific routing for non-transactional queries
 		if alias := session.GetTargetTabletAlias(); alias != nil {
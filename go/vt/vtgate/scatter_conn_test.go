@@ -17,6 +17,7 @@ limitations under the License.
 package vtgate
 
 import (
+	"context"
 	"log/slog"
 	"sync"
 	"testing"
@@ -36,7 +37,9 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
 )
 
 // This file uses the sandbox_test framework.
@@ -97,7 +100,7 @@ func TestExecuteFailOnAutocommit(t *testing.T) {
 		},
 		Autocommit: false,
 	}
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
 	err := vterrors.Aggregate(errs)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "in autocommit mode, transactionID should be zero but was: 123")
@@ -198,7 +201,7 @@ func TestFetchLastInsertIDResets(t *testing.T) {
 				sbc0.Options = nil
 			}
 
-			_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true /*autocommit*/, false, nullResultsObserver{}, tt.fetchLastInsertID)
+			_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, tt.fetchLastInsertID)
 			require.NoError(t, vterrors.Aggregate(errs))
 
 			// The shared session options must not be mutated by the call; the
@@ -219,6 +222,59 @@ func TestFetchLastInsertIDResets(t *testing.T) {
 	}
 }
 
+func TestExecuteMultiShardWarnings(t *testing.T) {
+	ks := "TestExecuteMultiShardWarnings"
+	ctx := utils.LeakCheckContext(t)
+
+	createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	sbc0 := hc.AddTestTablet("aa", "0", 1, ks, "-80", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	sbc1 := hc.AddTestTablet("aa", "1", 1, ks, "80-", topodatapb.TabletType_PRIMARY, true, 1, nil)
+
+	rss := []*srvtopo.ResolvedShard{{
+		Target:  &querypb.Target{Keyspace: ks, Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY},
+		Gateway: sbc0,
+	}, {
+		Target:  &querypb.Target{Keyspace: ks, Shard: "80-", TabletType: topodatapb.TabletType_PRIMARY},
+		Gateway: sbc1,
+	}}
+	queries := []*querypb.BoundQuery{{Sql: "query1"}, {Sql: "query2"}}
+
+	sbc0.SetResults([]*sqltypes.Result{{
+		RowsAffected: 1,
+		Warnings:     []*querypb.QueryWarning{{Level: "Warning", Code: 1265, Message: "Data truncated for column 'a' at row 1"}},
+	}})
+	sbc1.SetResults([]*sqltypes.Result{{
+		RowsAffected: 2,
+		Warnings: []*querypb.QueryWarning{
+			{Level: "Warning", Code: 1264, Message: "Out of range value for column 'b' at row 1"},
+			{Level: "Note", Code: 1592, Message: "Unsafe statement written to the binary log"},
+		},
+	}})
+	session := econtext.NewSafeSession(nil)
+	qr, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
+	require.NoError(t, vterrors.Aggregate(errs))
+	assert.EqualValues(t, 3, qr.RowsAffected)
+	assert.Nil(t, qr.Warnings)
+	utils.MustMatch(t, []*querypb.QueryWarning{
+		{Level: "Warning", Code: 1265, Message: "target: TestExecuteMultiShardWarnings.-80.primary: Data truncated for column 'a' at row 1"},
+		{Level: "Warning", Code: 1264, Message: "target: TestExecuteMultiShardWarnings.80-.primary: Out of range value for column 'b' at row 1"},
+		{Level: "Note", Code: 1592, Message: "target: TestExecuteMultiShardWarnings.80-.primary: Unsafe statement written to the binary log"},
+	}, session.GetWarnings())
+
+	// The warnings of a single shard keep the message of MySQL.
+	sbc0.SetResults([]*sqltypes.Result{{
+		Warnings: []*querypb.QueryWarning{{Level: "Warning", Code: 1265, Message: "Data truncated for column 'a' at row 1"}},
+	}})
+	session = econtext.NewSafeSession(nil)
+	_, errs = sc.ExecuteMultiShard(ctx, nil, rss[:1], queries[:1], session, true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
+	require.NoError(t, vterrors.Aggregate(errs))
+	utils.MustMatch(t, []*querypb.QueryWarning{
+		{Level: "Warning", Code: 1265, Message: "Data truncated for column 'a' at row 1"},
+	}, session.GetWarnings())
+}
+
 func TestScatterConnSharedOptionsNoRace(t *testing.T) {
 	// A streamed UNION runs each source through its own StreamExecuteMulti
 	// against the same session. Setting FetchLastInsertId on the shared session
@@ -264,7 +320,7 @@ func TestScatterConnSharedOptionsNoRace(t *testing.T) {
 			assert.NoError(t, vterrors.Aggregate(errs))
 		})
 		wg.Go(func() {
-			_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true /*autocommit*/, false, nullResultsObserver{}, fetchLastInsertID)
+			_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, fetchLastInsertID)
 			assert.NoError(t, vterrors.Aggregate(errs))
 		})
 	}
@@ -274,6 +330,84 @@ func TestScatterConnSharedOptionsNoRace(t *testing.T) {
 	assert.False(t, session.Options.FetchLastInsertId)
 }
 
+func TestExecuteMultiShardIdempotencyKey(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	hc := executor.scatterConn.gateway.hc.(*discovery.FakeHealthCheck)
+	hc.SetCapabilities(sbc1.Tablet(), queryservice.TabletCapabilities())
+
+	defer func(old bool) { dmlIdempotencyKeys = old }(dmlIdempotencyKeys)
+	dmlIdempotencyKeys = true
+
+	// An autocommit DML sent to a tablet that supports idempotency keys
+	// carries one.
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	_, err := executorExec(ctx, executor, session, "update `user` set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	key := sbc1.Options[0].GetIdempotencyKey()
+	assert.NotEmpty(t, key)
+
+	// Every DML gets its own key.
+	_, err = executorExec(ctx, executor, session, "update `user` set a = 3 where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 2)
+	assert.NotEmpty(t, sbc1.Options[1].GetIdempotencyKey())
+	assert.NotEqual(t, key, sbc1.Options[1].GetIdempotencyKey())
+
+	// Tablets that predate idempotency keys don't get any.
+	_, err = executorExec(ctx, executor, session, "update `user` set a = 2 where id = 3", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc2.Options, 1)
+	assert.Empty(t, sbc2.Options[0].GetIdempotencyKey())
+
+	// Nor do DMLs executed in a transaction.
+	sbc1.ClearOptions()
+	session = &vtgatepb.Session{TargetString: "@primary", InTransaction: true}
+	_, err = executorExec(ctx, executor, session, "update `user` set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	assert.Empty(t, sbc1.Options[0].GetIdempotencyKey())
+
+	// Nor DMLs when the flag is off.
+	dmlIdempotencyKeys = false
+	sbc1.ClearOptions()
+	session = &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	_, err = executorExec(ctx, executor, session, "update `user` set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	assert.Empty(t, sbc1.Options[0].GetIdempotencyKey())
+}
+
+func TestExecuteMultiShardJSONEncoding(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	hc := executor.scatterConn.gateway.hc.(*discovery.FakeHealthCheck)
+	hc.SetCapabilities(sbc1.Tablet(), queryservice.TabletCapabilities())
+
+	// A tablet that supports the binary encoding of JSON values gets asked
+	// for it.
+	session := &vtgatepb.Session{
+		TargetString: "@primary",
+		Options:      &querypb.ExecuteOptions{JsonEncoding: querypb.ExecuteOptions_JSON_BINARY},
+	}
+	_, err := executorExec(ctx, executor, session, "select id from `user` where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	assert.Equal(t, querypb.ExecuteOptions_JSON_BINARY, sbc1.Options[0].GetJsonEncoding())
+
+	// Unless another shard of the query doesn't support it, so that all the
+	// shards return the values in the same encoding.
+	sbc1.ClearOptions()
+	_, err = executorExec(ctx, executor, session, "select id from `user` where id in (1, 3)", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	assert.Equal(t, querypb.ExecuteOptions_JSON_TEXT, sbc1.Options[0].GetJsonEncoding())
+	require.Len(t, sbc2.Options, 1)
+	assert.Equal(t, querypb.ExecuteOptions_JSON_TEXT, sbc2.Options[0].GetJsonEncoding())
+
+	// The session options are left as they are.
+	assert.Equal(t, querypb.ExecuteOptions_JSON_BINARY, session.Options.JsonEncoding)
+}
+
 func TestExecutePanic(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -343,7 +477,7 @@ func TestExecutePanic(t *testing.T) {
 	}
 
 	assert.Panics(t, func() {
-		_, _ = sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, false, nullResultsObserver{}, false)
+		_, _ = sc.ExecuteMultiShard(ctx, nil, rss, queries, econtext.NewSafeSession(session), true /*autocommit*/, maxMemoryRows, nullResultsObserver{}, false)
 	})
 	require.Contains(t, logMessage, "(*ScatterConn).multiGoTransaction")
 }
@@ -747,6 +881,19 @@ func TestActionInfoWithTabletAlias(t *testing.T) {
 		require.ErrorContains(t, err, "cannot change tablet target mid-transaction")
 	})
 
+	t.Run("tablet alias from the plan ignores the transaction", func(t *testing.T) {
+		session := econtext.NewSafeSession(&vtgatepb.Session{
+			InTransaction: true,
+		})
+		planCtx := context.WithValue(ctx, engine.TargetTabletAlias, tabletAlias)
+
+		info, shardSession, err := actionInfo(planCtx, target, session, false, vtgatepb.TransactionMode_MULTI)
+		require.NoError(t, err)
+		assert.Nil(t, shardSession)
+		assert.Equal(t, nothing, info.actionNeeded)
+		assert.Equal(t, tabletAlias, info.alias)
+	})
+
 	t.Run("no tablet alias - existing behavior", func(t *testing.T) {
 		session := econtext.NewSafeSession(&vtgatepb.Session{})
 
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"maps"
-	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -47,9 +46,12 @@ type (
 		mu     sync.Mutex
 		tables *tableMap
 		views  *viewMap
-		udfs   map[keyspaceStr][]string
-		ctx    context.Context
-		signal func() // a function that we'll call whenever we have new schema data
+		udfs   map[keyspaceStr][]*querypb.UDFInfo
+		// columnStatistics are the sampled column statistics of the
+		// tables, or nil if they are not tracked.
+		columnStatistics map[keyspaceStr]map[tableNameStr]*vindexes.TableStatistics
+		ctx              context.Context
+		signal           func() // a function that we'll call whenever we have new schema data
 
 		// map of keyspace currently tracked
 		trackedMu    sync.Mutex
@@ -64,7 +66,7 @@ type (
 const defaultConsumeDelay = 1 * time.Second
 
 // NewTracker creates the tracker object.
-func NewTracker(ch chan *discovery.TabletHealth, enableViews, enableUDFs bool, parser *sqlparser.Parser) *Tracker {
+func NewTracker(ch chan *discovery.TabletHealth, enableViews, enableUDFs, enableColumnStatistics bool, parser *sqlparser.Parser) *Tracker {
 	t := &Tracker{
 		ctx:          context.Background(),
 		ch:           ch,
@@ -78,7 +80,10 @@ func NewTracker(ch chan *discovery.TabletHealth, enableViews, enableUDFs bool, p
 		t.views = &viewMap{m: map[keyspaceStr]map[viewNameStr]sqlparser.TableStatement{}, parser: parser}
 	}
 	if enableUDFs {
-		t.udfs = map[keyspaceStr][]string{}
+		t.udfs = map[keyspaceStr][]*querypb.UDFInfo{}
+	}
+	if enableColumnStatistics {
+		t.columnStatistics = map[keyspaceStr]map[tableNameStr]*vindexes.TableStatistics{}
 	}
 	return t
 }
@@ -97,6 +102,7 @@ func (t *Tracker) LoadKeyspace(conn queryservice.QueryService, target *querypb.T
 	if err != nil {
 		return err
 	}
+	t.loadColumnStatistics(conn, target)
 
 	t.setLoaded(target.Keyspace, true)
 	return nil
@@ -166,14 +172,9 @@ func (t *Tracker) loadUDFs(conn queryservice.QueryService, target *querypb.Targe
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	var udfs []string
+	var udfs []*querypb.UDFInfo
 	err := conn.GetSchema(t.ctx, target, querypb.SchemaTableType_UDFS, nil, func(schemaRes *querypb.GetSchemaResponse) error {
-		for _, udf := range schemaRes.Udfs {
-			if !udf.Aggregating {
-				continue
-			}
-			udfs = append(udfs, udf.Name)
-		}
+		udfs = append(udfs, schemaRes.Udfs...)
 		return nil
 	})
 	if err != nil {
@@ -185,6 +186,42 @@ func (t *Tracker) loadUDFs(conn queryservice.QueryService, target *querypb.Targe
 	return nil
 }
 
+// loadColumnStatistics loads the sampled column statistics of the tables of
+// the keyspace. The statistics only help the planner, so failing to load them
+// does not fail the schema tracking.
+func (t *Tracker) loadColumnStatistics(conn queryservice.QueryService, target *querypb.Target) {
+	if t.columnStatistics == nil {
+		// This happens only when column statistics are not enabled.
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tables := map[tableNameStr]*vindexes.TableStatistics{}
+	err := conn.GetSchema(t.ctx, target, querypb.SchemaTableType_COLUMN_STATISTICS, nil, func(schemaRes *querypb.GetSchemaResponse) error {
+		for _, cs := range schemaRes.ColumnStatistics {
+			stats := tables[cs.TableName]
+			if stats == nil {
+				stats = &vindexes.TableStatistics{Columns: map[string]vindexes.ColumnStatistics{}}
+				tables[cs.TableName] = stats
+			}
+			stats.Rows = max(stats.Rows, cs.TableRows)
+			stats.Columns[strings.ToLower(cs.ColumnName)] = vindexes.ColumnStatistics{
+				DistinctValues: cs.DistinctValues,
+				NullFraction:   cs.NullFraction,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn(fmt.Sprintf("error fetching column statistics for %v: %v", target.Keyspace, err))
+		return
+	}
+	t.columnStatistics[target.Keyspace] = tables
+	log.Info(fmt.Sprintf("finished loading column statistics of %d tables for keyspace %s", len(tables), target.Keyspace))
+}
+
 // Start starts the schema tracking.
 func (t *Tracker) Start() {
 	log.Info("Starting schema tracking")
@@ -291,7 +328,15 @@ func (t *Tracker) Tables(ks string) map[string]*vindexes.TableInfo {
 		return map[string]*vindexes.TableInfo{} // we know nothing about this KS, so that is the info we can give out
 	}
 
-	return maps.Clone(m)
+	tables := maps.Clone(m)
+	for name, stats := range t.columnStatistics[ks] {
+		if ti, ok := tables[name]; ok {
+			withStats := *ti
+			withStats.Statistics = stats
+			tables[name] = &withStats
+		}
+	}
+	return tables
 }
 
 // Views returns all known views in the keyspace with their definition.
@@ -307,6 +352,7 @@ func (t *Tracker) Views(ks string) map[string]sqlparser.TableStatement {
 	return maps.Clone(m)
 }
 
+// UDFs returns the names of the aggregate UDFs in the keyspace.
 func (t *Tracker) UDFs(ks string) []string {
 	if t.udfs == nil {
 		return nil
@@ -315,7 +361,36 @@ func (t *Tracker) UDFs(ks string) []string {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	return slices.Clone(t.udfs[ks])
+	var udfs []string
+	for _, udf := range t.udfs[ks] {
+		if udf.Aggregating {
+			udfs = append(udfs, udf.Name)
+		}
+	}
+	return udfs
+}
+
+// UDFReturnTypes returns the known return types of the UDFs in the keyspace,
+// keyed by lowercase function name. UDFs without a known return type are left out.
+func (t *Tracker) UDFReturnTypes(ks string) map[string]querypb.Type {
+	if t.udfs == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var types map[string]querypb.Type
+	for _, udf := range t.udfs[ks] {
+		if udf.ReturnType == querypb.Type_NULL_TYPE {
+			continue
+		}
+		if types == nil {
+			types = make(map[string]querypb.Type)
+		}
+		types[strings.ToLower(udf.Name)] = udf.ReturnType
+	}
+	return types
 }
 
 func (t *Tracker) updateSchema(th *discovery.TabletHealth) bool {
@@ -332,6 +407,10 @@ func (t *Tracker) updateSchema(th *discovery.TabletHealth) bool {
 		success = t.updatedViewSchema(th)
 	}
 
+	if success && th.Stats.ColumnStatisticsChanged {
+		t.loadColumnStatistics(th.Conn, th.Target)
+	}
+
 	if !success || !th.Stats.UdfsChanged {
 		return success
 	}
@@ -82,7 +82,7 @@ func TestTrackingUnHealthyTablet(t *testing.T) {
 
 	sbc := sandboxconn.NewSandboxConn(tablet)
 	ch := make(chan *discovery.TabletHealth)
-	tracker := NewTracker(ch, false, false, sqlparser.NewTestParser())
+	tracker := NewTracker(ch, false, false, false, sqlparser.NewTestParser())
 	tracker.consumeDelay = 1 * time.Millisecond
 	tracker.Start()
 	defer tracker.Stop()
@@ -172,7 +172,7 @@ func TestTrackerGetKeyspaceUpdateController(t *testing.T) {
 // TestTrackerNoLock tests that processing of health check is not blocked while tracking is making GetSchema rpc calls.
 func TestTrackerNoLock(t *testing.T) {
 	ch := make(chan *discovery.TabletHealth)
-	tracker := NewTracker(ch, true, false, sqlparser.NewTestParser())
+	tracker := NewTracker(ch, true, false, false, sqlparser.NewTestParser())
 	tracker.consumeDelay = 1 * time.Millisecond
 	tracker.Start()
 	defer tracker.Stop()
@@ -496,6 +496,40 @@ func TestUDFRetrieval(t *testing.T) {
 	testTracker(t, true, schemaDefResult, testcases)
 }
 
+// TestUDFReturnTypeRetrieval tests that the tracker keeps the return types of all UDFs,
+// while only reporting the aggregating ones as aggregate UDFs.
+func TestUDFReturnTypeRetrieval(t *testing.T) {
+	schemaDefResult := []sandboxconn.SchemaResult{
+		empty(), // initial load of table
+		empty(),
+		udfs(
+			udf("my_aggr", true, sqltypes.Float64),
+			udf("My_Func", false, sqltypes.Int64),
+			udf("untyped", false, sqltypes.Null),
+		),
+		udfs(
+			udf("my_func", false, sqltypes.VarChar),
+		),
+	}
+
+	testcases := []testCases{{
+		testName: "initial load",
+		expUDFs:  []string{"my_aggr"},
+		expUDFTypes: map[string]querypb.Type{
+			"my_aggr": sqltypes.Float64,
+			"my_func": sqltypes.Int64,
+		},
+	}, {
+		testName: "next load",
+		updUdfs:  true,
+		expUDFTypes: map[string]querypb.Type{
+			"my_func": sqltypes.VarChar,
+		},
+	}}
+
+	testTracker(t, true, schemaDefResult, testcases)
+}
+
 func udfs(udfs ...*querypb.UDFInfo) sandboxconn.SchemaResult {
 	return sandboxconn.SchemaResult{
 		TablesAndViews: map[string]string{},
@@ -503,6 +537,67 @@ func udfs(udfs ...*querypb.UDFInfo) sandboxconn.SchemaResult {
 	}
 }
 
+// TestTrackerColumnStatistics tests that the sampled column statistics are
+// loaded with the keyspace, reloaded when the tablet signals that they
+// changed, and returned with the tables.
+func TestTrackerColumnStatistics(t *testing.T) {
+	ch := make(chan *discovery.TabletHealth)
+	tracker := NewTracker(ch, false, false, true, sqlparser.NewTestParser())
+	tracker.consumeDelay = 1 * time.Millisecond
+	tracker.Start()
+	defer tracker.Stop()
+
+	wg := sync.WaitGroup{}
+	tracker.RegisterSignalReceiver(func() {
+		wg.Done()
+	})
+
+	target := &querypb.Target{Cell: cell, Keyspace: keyspace, Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	tablet := &topodatapb.Tablet{Keyspace: target.Keyspace, Shard: target.Shard, Type: target.TabletType}
+
+	sbc := sandboxconn.NewSandboxConn(tablet)
+	sbc.SetSchemaResult([]sandboxconn.SchemaResult{{
+		TablesAndViews: map[string]string{
+			"t1": "CREATE TABLE `t1` (`id` int, `name` varchar(10))",
+			"t2": "CREATE TABLE `t2` (`id` int)",
+		},
+	}, {
+		ColumnStatistics: []*querypb.ColumnStatistics{
+			{TableName: "t1", ColumnName: "id", TableRows: 1000, SampleRows: 1000, DistinctValues: 1000},
+			{TableName: "t1", ColumnName: "Name", TableRows: 1000, SampleRows: 1000, DistinctValues: 10, NullFraction: 0.5},
+		},
+	}, {
+		ColumnStatistics: []*querypb.ColumnStatistics{
+			{TableName: "t1", ColumnName: "id", TableRows: 2000, SampleRows: 1000, DistinctValues: 2000},
+		},
+	}})
+
+	wg.Add(1)
+	ch <- &discovery.TabletHealth{Conn: sbc, Tablet: tablet, Target: target, Serving: true, Stats: &querypb.RealtimeStats{}}
+	require.False(t, waitTimeout(&wg, time.Second), "schema was loaded but received no signal")
+
+	tables := tracker.Tables(keyspace)
+	assert.Nil(t, tables["t2"].Statistics)
+	assert.Equal(t, &vindexes.TableStatistics{
+		Rows: 1000,
+		Columns: map[string]vindexes.ColumnStatistics{
+			"id":   {DistinctValues: 1000},
+			"name": {DistinctValues: 10, NullFraction: 0.5},
+		},
+	}, tables["t1"].Statistics)
+
+	wg.Add(1)
+	ch <- &discovery.TabletHealth{Conn: sbc, Tablet: tablet, Target: target, Serving: true, Stats: &querypb.RealtimeStats{ColumnStatisticsChanged: true}}
+	require.False(t, waitTimeout(&wg, time.Second), "column statistics were updated but received no signal")
+	assert.EqualValues(t, 3, sbc.GetSchemaCount.Load())
+	assert.Equal(t, &vindexes.TableStatistics{
+		Rows: 2000,
+		Columns: map[string]vindexes.ColumnStatistics{
+			"id": {DistinctValues: 2000},
+		},
+	}, tracker.Tables(keyspace)["t1"].Statistics)
+}
+
 func udf(name string, aggr bool, typ querypb.Type) *querypb.UDFInfo {
 	return &querypb.UDFInfo{
 		Name:        name,
@@ -522,13 +617,14 @@ type testCases struct {
 	updView []string
 	expView map[string]string
 
-	updUdfs bool
-	expUDFs []string
+	updUdfs     bool
+	expUDFs     []string
+	expUDFTypes map[string]querypb.Type
 }
 
 func testTracker(t *testing.T, enableUDFs bool, schemaDefResult []sandboxconn.SchemaResult, tcases []testCases) {
 	ch := make(chan *discovery.TabletHealth)
-	tracker := NewTracker(ch, true, enableUDFs, sqlparser.NewTestParser())
+	tracker := NewTracker(ch, true, enableUDFs, false, sqlparser.NewTestParser())
 	tracker.consumeDelay = 1 * time.Millisecond
 	tracker.Start()
 	defer tracker.Stop()
@@ -589,6 +685,9 @@ func testTracker(t *testing.T, enableUDFs bool, schemaDefResult []sandboxconn.Sc
 			}
 
 			assert.Equal(t, tcase.expUDFs, tracker.UDFs(keyspace), "mismatch for udfs")
+			if tcase.expUDFTypes != nil {
+				assert.Equal(t, tcase.expUDFTypes, tracker.UDFReturnTypes(keyspace), "mismatch for udf return types")
+			}
 		})
 	}
 }
@@ -113,6 +113,9 @@ func (u *updateController) getItemFromQueueLocked() *discovery.TabletHealth {
 					item.Stats.ViewSchemaChanged = append(item.Stats.ViewSchemaChanged, view)
 				}
 			}
+			if u.queue.items[i].Stats.ColumnStatisticsChanged {
+				item.Stats.ColumnStatisticsChanged = true
+			}
 		}
 	}
 	// emptying queue's items as all items from 0 to i (length of the queue) are merged
@@ -137,7 +140,7 @@ func (u *updateController) add(th *discovery.TabletHealth) {
 	}
 
 	// If the keyspace schema is loaded and there is no schema change detected. Then there is nothing to process.
-	if len(th.Stats.TableSchemaChanged) == 0 && len(th.Stats.ViewSchemaChanged) == 0 && !th.Stats.UdfsChanged && u.loaded {
+	if len(th.Stats.TableSchemaChanged) == 0 && len(th.Stats.ViewSchemaChanged) == 0 && !th.Stats.UdfsChanged && !th.Stats.ColumnStatisticsChanged && u.loaded {
 		return
 	}
 
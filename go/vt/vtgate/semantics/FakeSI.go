@@ -21,6 +21,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -37,6 +38,7 @@ type FakeSI struct {
 	KsForeignKeyMode map[string]vschemapb.Keyspace_ForeignKeyMode
 	KsError          map[string]error
 	UDFs             []string
+	UDFReturnTypes   map[string]map[string]querypb.Type
 }
 
 // FindTableOrVindex implements the SchemaInformation interface
@@ -90,6 +92,10 @@ func (s *FakeSI) GetAggregateUDFs() []string {
 	return s.UDFs
 }
 
+func (s *FakeSI) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	return s.UDFReturnTypes[keyspace]
+}
+
 // FindMirrorRule implements SchemaInformation.
 func (s *FakeSI) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error) {
 	return nil, nil
@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"vitess.io/vitess/go/mysql/collations"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -59,11 +60,11 @@ func newAnalyzer(dbName string, si SchemaInformation, fullAnalysis bool) *analyz
 	a := &analyzer{
 		scoper:       s,
 		earlyTables:  newEarlyTableCollector(si, dbName),
-		typer:        newTyper(si.Environment().CollationEnv()),
 		si:           si,
 		currentDb:    dbName,
 		fullAnalysis: fullAnalysis,
 	}
+	a.typer = newTyper(si.Environment().CollationEnv(), a.udfReturnType)
 	s.org = a
 	return a
 }
@@ -423,6 +424,11 @@ func (a *analyzer) canShortCut(statement sqlparser.Statement) (canShortCut bool)
 		return false
 	}
 
+	if hasMaskedColumns(a.earlyTables.Tables) {
+		// the planner needs the columns bound to their tables to mask them
+		return false
+	}
+
 	defer func() {
 		a.canShortcut = canShortCut
 	}()
@@ -450,6 +456,41 @@ func (a *analyzer) canShortCut(statement sqlparser.Statement) (canShortCut bool)
 	return true
 }
 
+// udfReturnType returns the return type of a call to a UDF in the keyspace
+// the call is sent to. UDFs are created per MySQL instance, so the keyspace
+// is the one of the tables the arguments of the call depend on, or of all
+// the tables of the query if they depend on none. When they are in several
+// keyspaces, the type is not known.
+func (a *analyzer) udfReturnType(node *sqlparser.FuncExpr) (querypb.Type, bool) {
+	var tables []TableInfo
+	deps := a.binder.recursive.dependencies(node)
+	if deps.IsEmpty() {
+		tables = a.tables.Tables
+	} else {
+		deps.ForEachTable(func(offset int) {
+			tables = append(tables, a.tables.Tables[offset])
+		})
+	}
+
+	keyspace := ""
+	for _, table := range tables {
+		vtbl := table.GetVindexTable()
+		if vtbl == nil {
+			continue
+		}
+		if vtbl.Keyspace == nil || (keyspace != "" && keyspace != vtbl.Keyspace.Name) {
+			return 0, false
+		}
+		keyspace = vtbl.Keyspace.Name
+	}
+	if keyspace == "" {
+		keyspace = a.currentDb
+	}
+
+	typ, ok := a.si.GetUDFReturnTypes(keyspace)[node.Name.Lowered()]
+	return typ, ok
+}
+
 func (a *analyzer) shouldContinue() bool {
 	return a.err == nil
 }
@@ -585,6 +585,50 @@ func TestScopeForSubqueries(t *testing.T) {
 	}
 }
 
+func TestScopingLateralDerivedTables(t *testing.T) {
+	tcases := []struct {
+		sql  string
+		deps TableSet
+	}{
+		{
+			sql:  `select dt.col from x as t, lateral (select t.col from y) as dt`,
+			deps: TS0,
+		}, {
+			sql:  `select dt.col from x as t join lateral (select t.col from y) as dt on true`,
+			deps: TS0,
+		}, {
+			sql:  `select dt.col from x as t left join lateral (select y.col from y where y.id = t.id) as dt on true`,
+			deps: TS1,
+		}, {
+			sql:  `select dt.col from x as t, lateral (select t.col from y as t) as dt`,
+			deps: TS1,
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.sql, func(t *testing.T) {
+			stmt, semTable := parseAndAnalyze(t, tc.sql, "d")
+
+			var inner *sqlparser.Select
+			_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+				if dt, ok := node.(*sqlparser.DerivedTable); ok {
+					inner = dt.Select.(*sqlparser.Select)
+					return false, nil
+				}
+				return true, nil
+			}, stmt)
+			require.NotNil(t, inner)
+
+			assert.Equal(t, tc.deps, semTable.RecursiveDeps(extract(inner, 0)))
+			// lateral derived tables can only be planned as a single shard query
+			assert.EqualError(t, semTable.NotSingleShardErr, "VT12001: unsupported: LATERAL derived table that is not routed to a single shard")
+		})
+	}
+
+	// without LATERAL, the derived table can't see the tables before it
+	_, semTable := parseAndAnalyze(t, `select dt.col from x as t, (select t.col from y) as dt`, "d")
+	require.EqualError(t, semTable.NotUnshardedErr, "column 't.col' not found")
+}
+
 func TestSubqueryOrderByBinding(t *testing.T) {
 	queries := []struct {
 		query    string
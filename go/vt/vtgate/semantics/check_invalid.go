@@ -92,7 +92,9 @@ func (a *analyzer) checkSubqueryColumns(parent sqlparser.SQLNode, subq *sqlparse
 
 func checkDerived(node *sqlparser.DerivedTable) error {
 	if node.Lateral {
-		return vterrors.VT12001("lateral derived tables")
+		// a lateral derived table can only be sent to MySQL as is,
+		// we can't evaluate it at the vtgate level
+		return NotSingleShardError{Inner: &UnsupportedConstruct{errString: "LATERAL derived table that is not routed to a single shard"}}
 	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/proto/query"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -1671,6 +1672,10 @@ func (i *infoSchemaWithColumns) GetAggregateUDFs() []string {
 	return i.inner.GetAggregateUDFs()
 }
 
+func (i *infoSchemaWithColumns) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	return i.inner.GetUDFReturnTypes(keyspace)
+}
+
 // FindMirrorRule implements SchemaInformation.
 func (i *infoSchemaWithColumns) FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error) {
 	return i.inner.FindMirrorRule(tablename)
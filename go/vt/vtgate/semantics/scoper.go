@@ -177,6 +177,10 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 		// To create this special context, we will find the parent scope of the select statement involved.
 		currScope := s.currentScope()
 		stmtScope := currScope.findParentScopeOfStatement()
+		if isLateral(cursor.Node()) {
+			// a LATERAL derived table can also see the tables that come before it in the FROM clause
+			stmtScope = currScope
+		}
 		nScope := newScope(stmtScope)
 		if stmtScope == nil {
 			// TODO: this feels hacky. revisit with a better plan
@@ -187,6 +191,15 @@ func (s *scoper) enterJoinScope(cursor *sqlparser.Cursor) {
 	}
 }
 
+func isLateral(node sqlparser.SQLNode) bool {
+	ate, ok := node.(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return false
+	}
+	dt, ok := ate.Expr.(*sqlparser.DerivedTable)
+	return ok && dt.Lateral
+}
+
 func (s *scoper) pushSelectScope(node *sqlparser.Select) {
 	currScope := newScope(s.currentScope())
 	currScope.stmtScope = true
@@ -22,6 +22,7 @@ import (
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -172,6 +173,8 @@ type (
 		GetForeignKeyChecksState() *bool
 		KeyspaceError(keyspace string) error
 		GetAggregateUDFs() []string
+		// GetUDFReturnTypes returns the known return types of the UDFs of a keyspace, keyed by lowercase name
+		GetUDFReturnTypes(keyspace string) map[string]querypb.Type
 		FindMirrorRule(tablename sqlparser.TableName) (*vindexes.MirrorRule, error)
 	}
 
@@ -768,6 +771,20 @@ func (st *SemTable) ColumnLookup(*sqlparser.ColName) (int, error) {
 	return 0, columnNotSupportedErr
 }
 
+// HasMaskedColumns returns true if any of the tables in the query has a column with a masking policy
+func (st *SemTable) HasMaskedColumns() bool {
+	return hasMaskedColumns(st.Tables)
+}
+
+func hasMaskedColumns(tableInfos []TableInfo) bool {
+	for _, ti := range tableInfos {
+		if vt := ti.GetVindexTable(); vt != nil && vt.HasMaskedColumns() {
+			return true
+		}
+	}
+	return false
+}
+
 // SingleUnshardedKeyspace returns the single keyspace if all tables in the query are in the same, unsharded keyspace
 func (st *SemTable) SingleUnshardedKeyspace() (ks *vindexes.Keyspace, tables []*vindexes.BaseTable) {
 	return singleUnshardedKeyspace(st.Tables)
@@ -18,6 +18,8 @@ package semantics
 
 import (
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -28,12 +30,16 @@ import (
 type typer struct {
 	m            map[sqlparser.Expr]evalengine.Type
 	collationEnv *collations.Environment
+
+	// udfReturnType returns the return type of a call to a UDF, if it is known
+	udfReturnType func(*sqlparser.FuncExpr) (querypb.Type, bool)
 }
 
-func newTyper(collationEnv *collations.Environment) *typer {
+func newTyper(collationEnv *collations.Environment, udfReturnType func(*sqlparser.FuncExpr) (querypb.Type, bool)) *typer {
 	return &typer{
-		m:            map[sqlparser.Expr]evalengine.Type{},
-		collationEnv: collationEnv,
+		m:             map[sqlparser.Expr]evalengine.Type{},
+		collationEnv:  collationEnv,
+		udfReturnType: udfReturnType,
 	}
 }
 
@@ -75,6 +81,17 @@ func (t *typer) up(cursor *sqlparser.Cursor) error {
 			}
 			t.m[node] = code.ResolveType(inputType, t.collationEnv)
 		}
+	case *sqlparser.FuncExpr:
+		if typ, ok := t.udfReturnType(node); ok {
+			t.m[node] = evalengine.NewType(typ, collations.CollationForType(typ, t.collationEnv.DefaultConnectionCharset()))
+		}
+	case *sqlparser.CollateExpr:
+		// Whatever the type of the expression it applies to, a COLLATE clause
+		// results in a string with its collation, so the expression can be
+		// compared in vtgate even if the type of its input is unknown.
+		if coll := t.collationEnv.LookupByName(node.Collation); coll != collations.Unknown {
+			t.m[node] = evalengine.NewTypeEx(sqltypes.VarChar, coll, true, 0, 0, nil)
+		}
 	}
 	return nil
 }
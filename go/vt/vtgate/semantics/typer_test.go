@@ -139,3 +139,47 @@ func TestWindowFunctionTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestUDFReturnTypes(t *testing.T) {
+	tests := []struct {
+		query, typ string
+		found      bool
+	}{
+		{query: "select my_udf(id) from t1", typ: "INT64", found: true},
+		{query: "select MY_UDF(id) from t1", typ: "INT64", found: true},
+		{query: "select str_udf('x') from t1", typ: "VARCHAR", found: true},
+		{query: "select my_udf(uid) from t2", typ: "VARCHAR", found: true},
+		{query: "select my_udf(t1.id) from t1 join t2", typ: "INT64", found: true},
+		{query: "select my_udf(id) from (select id from t1) as dt", typ: "INT64", found: true},
+		{query: "select str_udf(uid) from t2", found: false},
+		{query: "select my_udf(1) from t1 join t2", found: false},
+		{query: "select unknown_udf(id) from t1", found: false},
+	}
+
+	// The return types of UDFs are per keyspace: t1 is in ks2, and t2 in ks3.
+	si := fakeSchemaInfo()
+	si.UDFReturnTypes = map[string]map[string]querypb.Type{
+		"ks2": {
+			"my_udf":  querypb.Type_INT64,
+			"str_udf": querypb.Type_VARCHAR,
+		},
+		"ks3": {
+			"my_udf": querypb.Type_VARCHAR,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			parse, err := sqlparser.NewTestParser().Parse(test.query)
+			require.NoError(t, err)
+
+			st, err := Analyze(parse, "d", si)
+			require.NoError(t, err)
+
+			typ, found := st.TypeForExpr(extract(parse.(*sqlparser.Select), 0))
+			require.Equal(t, test.found, found)
+			if test.found {
+				require.Equal(t, test.typ, typ.Type().String())
+			}
+		})
+	}
+}
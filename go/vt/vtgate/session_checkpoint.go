@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"path"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// SessionCheckpointAttribute is the connection attribute a client sets
+	// when reconnecting, to resume the session state checkpointed by the
+	// vtgate it was previously connected to.
+	SessionCheckpointAttribute = "vitess_session_checkpoint"
+
+	// sessionCheckpointsPath is the global topo directory the checkpoints
+	// are stored in.
+	sessionCheckpointsPath = "vtgate_session_checkpoints"
+)
+
+var sessionCheckpoints = stats.NewCountersWithSingleLabel("SessionCheckpoints", "Number of MySQL session checkpoint operations by result", "Result")
+
+// sessionCheckpoint is the record stored in the topo for a checkpointed
+// session.
+type sessionCheckpoint struct {
+	User      string    `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	// Session is the marshaled vtgatepb.Session.
+	Session []byte `json:"session"`
+}
+
+// sessionCheckpointStore persists the state of idle sessions in the global
+// topo when vtgate shuts down, so their clients can resume them on another
+// vtgate. Checkpoints are keyed by a random token, which is the only thing
+// handed to the client, and can only be restored once, by the same user,
+// within ttl. Checkpoints that are never restored are deleted by a periodic
+// purge once they expire.
+type sessionCheckpointStore struct {
+	ts     *topo.Server
+	ttl    time.Duration
+	now    func() time.Time
+	purger *timer.Timer
+}
+
+func newSessionCheckpointStore(ts *topo.Server, ttl time.Duration) *sessionCheckpointStore {
+	return &sessionCheckpointStore{ts: ts, ttl: ttl, now: time.Now, purger: timer.NewTimer(ttl)}
+}
+
+// Open starts purging the expired checkpoints, every ttl.
+func (s *sessionCheckpointStore) Open() {
+	s.purger.Start(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+		defer cancel()
+		if _, err := s.PurgeExpired(ctx); err != nil {
+			log.Warn("Failed to purge expired session checkpoints", slog.Any("error", err))
+		}
+	})
+}
+
+// Close stops the purge started by Open.
+func (s *sessionCheckpointStore) Close() {
+	s.purger.Stop()
+}
+
+// Save stores a checkpoint of session for user and returns its token. It
+// returns an empty token if the session holds state that cannot be carried
+// over to another vtgate, such as an open transaction.
+func (s *sessionCheckpointStore) Save(ctx context.Context, user string, session *vtgatepb.Session) (string, error) {
+	checkpoint := resumableSession(session)
+	if checkpoint == nil {
+		sessionCheckpoints.Add("Skipped", 1)
+		return "", nil
+	}
+	data, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return "", err
+	}
+	contents, err := json.Marshal(&sessionCheckpoint{User: user, CreatedAt: s.now(), Session: data})
+	if err != nil {
+		return "", err
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+
+	conn, err := s.ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return "", err
+	}
+	if _, err := conn.Create(ctx, path.Join(sessionCheckpointsPath, token), contents); err != nil {
+		sessionCheckpoints.Add("Error", 1)
+		return "", err
+	}
+	sessionCheckpoints.Add("Saved", 1)
+	return token, nil
+}
+
+// Restore returns the session checkpointed under token and deletes the
+// checkpoint. It fails if the checkpoint does not exist, belongs to another
+// user or is older than the store's ttl.
+func (s *sessionCheckpointStore) Restore(ctx context.Context, user, token string) (*vtgatepb.Session, error) {
+	if _, err := hex.DecodeString(token); err != nil || token == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid session checkpoint token %q", token)
+	}
+	conn, err := s.ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return nil, err
+	}
+	filePath := path.Join(sessionCheckpointsPath, token)
+	contents, version, err := conn.Get(ctx, filePath)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			sessionCheckpoints.Add("NotFound", 1)
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "session checkpoint %s not found", token)
+		}
+		return nil, err
+	}
+	checkpoint := &sessionCheckpoint{}
+	if err := json.Unmarshal(contents, checkpoint); err != nil {
+		return nil, err
+	}
+	if checkpoint.User != user {
+		sessionCheckpoints.Add("Rejected", 1)
+		return nil, vterrors.Errorf(vtrpcpb.Code_PERMISSION_DENIED, "session checkpoint %s belongs to another user", token)
+	}
+
+	// A checkpoint is single use, whether or not it has expired.
+	if err := conn.Delete(ctx, filePath, version); err != nil {
+		if topo.IsErrType(err, topo.NoNode) || topo.IsErrType(err, topo.BadVersion) {
+			sessionCheckpoints.Add("NotFound", 1)
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "session checkpoint %s was already restored", token)
+		}
+		return nil, err
+	}
+	if s.now().Sub(checkpoint.CreatedAt) > s.ttl {
+		sessionCheckpoints.Add("Expired", 1)
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "session checkpoint %s has expired", token)
+	}
+
+	session := &vtgatepb.Session{}
+	if err := proto.Unmarshal(checkpoint.Session, session); err != nil {
+		return nil, err
+	}
+	sessionCheckpoints.Add("Restored", 1)
+	return session, nil
+}
+
+// PurgeExpired deletes the checkpoints that are older than the store's ttl,
+// and returns how many were deleted. A checkpoint that is restored or
+// deleted concurrently is skipped.
+func (s *sessionCheckpointStore) PurgeExpired(ctx context.Context) (int, error) {
+	conn, err := s.ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := conn.ListDir(ctx, sessionCheckpointsPath, false)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	purged := 0
+	for _, entry := range entries {
+		filePath := path.Join(sessionCheckpointsPath, entry.Name)
+		contents, version, err := conn.Get(ctx, filePath)
+		if err != nil {
+			if topo.IsErrType(err, topo.NoNode) {
+				continue
+			}
+			return purged, err
+		}
+		checkpoint := &sessionCheckpoint{}
+		// A record that can't be read can't be restored either.
+		if err := json.Unmarshal(contents, checkpoint); err == nil && s.now().Sub(checkpoint.CreatedAt) <= s.ttl {
+			continue
+		}
+		if err := conn.Delete(ctx, filePath, version); err != nil {
+			if topo.IsErrType(err, topo.NoNode) || topo.IsErrType(err, topo.BadVersion) {
+				continue
+			}
+			return purged, err
+		}
+		purged++
+	}
+	sessionCheckpoints.Add("Purged", int64(purged))
+	return purged, nil
+}
+
+// resumableSession returns a new session holding the parts of session that
+// can be resumed on another vtgate: the target, settings, system and user defined
+// variables and SQL-level prepared statements. It returns nil if the
+// session is bound to tablet connections, i.e. it has an open or failed
+// transaction, reserved connections or advisory locks.
+func resumableSession(session *vtgatepb.Session) *vtgatepb.Session {
+	if session.InTransaction || session.InReservedConn || session.ErrorUntilRollback ||
+		len(session.ShardSessions) > 0 || len(session.PreSessions) > 0 || len(session.PostSessions) > 0 ||
+		session.LockSession != nil || len(session.AdvisoryLock) > 0 {
+		return nil
+	}
+	return &vtgatepb.Session{
+		Autocommit:           session.Autocommit,
+		TargetString:         session.TargetString,
+		Options:              proto.Clone(session.Options).(*querypb.ExecuteOptions),
+		TransactionMode:      session.TransactionMode,
+		UserDefinedVariables: session.UserDefinedVariables,
+		SystemVariables:      session.SystemVariables,
+		ReadAfterWrite:       session.ReadAfterWrite,
+		DDLStrategy:          session.DDLStrategy,
+		SessionUUID:          session.SessionUUID,
+		EnableSystemSettings: session.EnableSystemSettings,
+		QueryTimeout:         session.QueryTimeout,
+		PrepareStatement:     session.PrepareStatement,
+		MigrationContext:     session.MigrationContext,
+	}
+}
+
+// shutdownError returns the error sent to a client whose connection is
+// closed because vtgate is shutting down. If the session was checkpointed,
+// it is a VT14006 error, whose argument is the checkpoint token. Both have
+// the Server shutdown in progress error code.
+func shutdownError(token string) error {
+	if token == "" {
+		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
+	}
+	return sqlerror.NewSQLErrorFromError(vterrors.VT14006(token))
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func idleTestSession() *vtgatepb.Session {
+	return &vtgatepb.Session{
+		Autocommit:   true,
+		TargetString: "ks@replica",
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+			Workload:       querypb.ExecuteOptions_OLAP,
+		},
+		SystemVariables:      map[string]string{"sql_mode": "''"},
+		UserDefinedVariables: map[string]*querypb.BindVariable{"a": sqltypes.Int64BindVariable(1)},
+		PrepareStatement:     map[string]*vtgatepb.PrepareData{"s1": {PrepareStatement: "select ?", ParamsCount: 1}},
+		SessionUUID:          "uuid",
+		LastInsertId:         42,
+	}
+}
+
+func TestSessionCheckpointSaveRestore(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	store := newSessionCheckpointStore(ts, time.Minute)
+
+	token, err := store.Save(ctx, "user1", idleTestSession())
+	require.NoError(t, err)
+	require.Len(t, token, 32)
+
+	// Only the owner can restore the checkpoint.
+	_, err = store.Restore(ctx, "user2", token)
+	assert.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+
+	session, err := store.Restore(ctx, "user1", token)
+	require.NoError(t, err)
+	want := idleTestSession()
+	want.LastInsertId = 0
+	assert.True(t, proto.Equal(want, session), "got %v, want %v", session, want)
+
+	// Checkpoints are single use.
+	_, err = store.Restore(ctx, "user1", token)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+
+	_, err = store.Restore(ctx, "user1", "not a token")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+}
+
+func TestSessionCheckpointExpired(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	store := newSessionCheckpointStore(ts, time.Minute)
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	token, err := store.Save(ctx, "user1", idleTestSession())
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	_, err = store.Restore(ctx, "user1", token)
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+
+	// The expired checkpoint was deleted.
+	_, err = store.Restore(ctx, "user1", token)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+}
+
+func TestSessionCheckpointPurgeExpired(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	store := newSessionCheckpointStore(ts, time.Minute)
+
+	// Purging before any checkpoint was saved is a no-op.
+	purged, err := store.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	expired, err := store.Save(ctx, "user1", idleTestSession())
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	live, err := store.Save(ctx, "user1", idleTestSession())
+	require.NoError(t, err)
+
+	purged, err = store.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = store.Restore(ctx, "user1", expired)
+	assert.Equal(t, vtrpcpb.Code_NOT_FOUND, vterrors.Code(err))
+	_, err = store.Restore(ctx, "user1", live)
+	assert.NoError(t, err)
+}
+
+func TestSessionCheckpointSkipsBoundSessions(t *testing.T) {
+	testcases := []struct {
+		name   string
+		modify func(*vtgatepb.Session)
+	}{{
+		name:   "in transaction",
+		modify: func(s *vtgatepb.Session) { s.InTransaction = true },
+	}, {
+		name:   "reserved connection",
+		modify: func(s *vtgatepb.Session) { s.InReservedConn = true },
+	}, {
+		name: "shard sessions",
+		modify: func(s *vtgatepb.Session) {
+			s.ShardSessions = []*vtgatepb.Session_ShardSession{{ReservedId: 1}}
+		},
+	}, {
+		name:   "advisory lock",
+		modify: func(s *vtgatepb.Session) { s.AdvisoryLock = map[string]int64{"lock": 1} },
+	}, {
+		name:   "error until rollback",
+		modify: func(s *vtgatepb.Session) { s.ErrorUntilRollback = true },
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			ts := memorytopo.NewServer(ctx, "cell1")
+			defer ts.Close()
+			store := newSessionCheckpointStore(ts, time.Minute)
+
+			session := idleTestSession()
+			tc.modify(session)
+			token, err := store.Save(ctx, "user1", session)
+			require.NoError(t, err)
+			assert.Empty(t, token)
+		})
+	}
+}
+
+func TestShutdownError(t *testing.T) {
+	err := shutdownError("")
+	assert.EqualError(t, err, "Server shutdown in progress (errno 1053) (sqlstate 08S01)")
+
+	err = shutdownError("abc")
+	sqlErr, ok := err.(*sqlerror.SQLError)
+	require.True(t, ok, "Wrong error type: %T", err)
+	assert.Equal(t, sqlerror.ERServerShutdown, sqlErr.Number())
+	assert.Equal(t, sqlerror.SSNetError, sqlErr.SQLState())
+	assert.Equal(t, "VT14006: server shutdown in progress, resume the session with the vitess_session_checkpoint connection attribute set to abc", sqlErr.Message)
+}
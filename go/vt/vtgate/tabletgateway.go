@@ -161,6 +161,14 @@ func (gw *TabletGateway) setupBuffering(ctx context.Context) {
 	gw.buffer = buffer.New(cfg)
 
 	gw.kev = discovery.NewKeyspaceEventWatcher(ctx, gw.srvTopoServer, gw.hc, gw.localCell)
+	if cfg.WatchShardRecords && gw.srvTopoServer != nil {
+		ts, err := gw.srvTopoServer.GetTopoServer()
+		if err != nil {
+			log.Warn(fmt.Sprintf("Not watching the shard records for buffering requests: %v", err))
+		} else {
+			gw.buffer.WatchShardRecords(ts, gw.kev)
+		}
+	}
 	ksChan := gw.kev.Subscribe()
 	bufferCtx, bufferCancel := context.WithCancel(ctx)
 
@@ -238,6 +246,32 @@ func (gw *TabletGateway) GetServingKeyspaces() []string {
 	return gw.kev.GetServingKeyspaces()
 }
 
+// TargetHasCapability returns whether all the healthy tablets of the target
+// support an optional feature, so that requests relying on it can be sent to
+// any of them. Callers should fall back to what older tablets support when it
+// returns false, which keeps mixed-version deployments working.
+func (gw *TabletGateway) TargetHasCapability(target *querypb.Target, feature string) bool {
+	tablets := gw.hc.GetHealthyTabletStats(target)
+	if len(tablets) == 0 {
+		return false
+	}
+	for _, th := range tablets {
+		if !th.HasCapability(feature) {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForTableCutOvers holds a request while an Online DDL cut-over of one of the
+// keyspace-qualified tables is imminent. It returns right away when buffering is disabled.
+func (gw *TabletGateway) WaitForTableCutOvers(ctx context.Context, tables []string) error {
+	if gw.buffer == nil {
+		return nil
+	}
+	return gw.buffer.WaitForTableCutOvers(ctx, gw.kev, tables)
+}
+
 // RegisterStats registers the stats to export the lag since the last refresh
 // and the checksum of the topology
 func (gw *TabletGateway) RegisterStats() {
@@ -168,6 +168,31 @@ func TestTabletGatewayShuffleTablets(t *testing.T) {
 	}
 }
 
+func TestTabletGatewayTargetHasCapability(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "ks"
+	shard := "0"
+	target := &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, nil, "cell")
+	defer tg.Close(ctx)
+
+	// no tablets to send requests to
+	assert.False(t, tg.TargetHasCapability(target, queryservice.CapabilityMessageBatch))
+
+	sc1 := hc.AddTestTablet("cell", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil)
+	sc2 := hc.AddTestTablet("cell", "1.1.1.1", 1002, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil)
+	hc.SetCapabilities(sc1.Tablet(), queryservice.TabletCapabilities())
+
+	// the second tablet predates capabilities
+	assert.False(t, tg.TargetHasCapability(target, queryservice.CapabilityMessageBatch))
+
+	hc.SetCapabilities(sc2.Tablet(), queryservice.TabletCapabilities())
+	assert.True(t, tg.TargetHasCapability(target, queryservice.CapabilityMessageBatch))
+	assert.False(t, tg.TargetHasCapability(target, "unknown_feature"))
+}
+
 func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
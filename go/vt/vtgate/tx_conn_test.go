@@ -60,7 +60,7 @@ func TestTxConnBegin(t *testing.T) {
 	require.NoError(t, err)
 	wantSession := vtgatepb.Session{InTransaction: true}
 	utils.MustMatch(t, &wantSession, session, "Session")
-	_, errors := sc.ExecuteMultiShard(ctx, nil, rss0, queries, safeSession, false, false, nullResultsObserver{}, false)
+	_, errors := sc.ExecuteMultiShard(ctx, nil, rss0, queries, safeSession, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errors)
 
 	// Begin again should cause a commit and a new begin.
@@ -80,7 +80,7 @@ func TestTxConnCommitFailure(t *testing.T) {
 	// Sequence the executes to ensure commit order
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rssm[0], queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rssm[0], queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession := vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -95,7 +95,7 @@ func TestTxConnCommitFailure(t *testing.T) {
 	}
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
-	sc.ExecuteMultiShard(ctx, nil, rssm[1], queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rssm[1], queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -118,7 +118,7 @@ func TestTxConnCommitFailure(t *testing.T) {
 	}
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
-	sc.ExecuteMultiShard(ctx, nil, rssa, threeQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rssa, threeQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -187,7 +187,7 @@ func TestTxConnCommitFailureAfterNonAtomicCommitMaxShards(t *testing.T) {
 	}
 
 	for i := range 18 {
-		sc.ExecuteMultiShard(ctx, nil, rssm[i], queries, session, false, false, nullResultsObserver{}, false)
+		sc.ExecuteMultiShard(ctx, nil, rssm[i], queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 		wantSession.ShardSessions = append(wantSession.ShardSessions, &vtgatepb.Session_ShardSession{
 			Target: &querypb.Target{
 				Keyspace:   "TestTxConn",
@@ -241,7 +241,7 @@ func TestTxConnCommitFailureBeforeNonAtomicCommitMaxShards(t *testing.T) {
 	}
 
 	for i := range 17 {
-		sc.ExecuteMultiShard(ctx, nil, rssm[i], queries, session, false, false, nullResultsObserver{}, false)
+		sc.ExecuteMultiShard(ctx, nil, rssm[i], queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 		wantSession.ShardSessions = append(wantSession.ShardSessions, &vtgatepb.Session_ShardSession{
 			Target: &querypb.Target{
 				Keyspace:   "TestTxConn",
@@ -287,7 +287,7 @@ func TestTxConnCommitSuccess(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession := vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -301,7 +301,7 @@ func TestTxConnCommitSuccess(t *testing.T) {
 		}},
 	}
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -340,7 +340,7 @@ func TestTxConnReservedCommitSuccess(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true, InReservedConn: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession := vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -356,7 +356,7 @@ func TestTxConnReservedCommitSuccess(t *testing.T) {
 		}},
 	}
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -427,9 +427,9 @@ func TestTxConnReservedOn2ShardTxOn1ShardAndCommit(t *testing.T) {
 	session := econtext.NewSafeSession(&vtgatepb.Session{InReservedConn: true})
 
 	// this will create reserved connections against all tablets
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errs)
-	_, errs = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errs)
 
 	wantSession := vtgatepb.Session{
@@ -457,7 +457,7 @@ func TestTxConnReservedOn2ShardTxOn1ShardAndCommit(t *testing.T) {
 	session.Session.InTransaction = true
 
 	// start a transaction against rss0
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -522,9 +522,9 @@ func TestTxConnReservedOn2ShardTxOn1ShardAndRollback(t *testing.T) {
 	session := econtext.NewSafeSession(&vtgatepb.Session{InReservedConn: true})
 
 	// this will create reserved connections against all tablets
-	_, errs := sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs := sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errs)
-	_, errs = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	_, errs = sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.Empty(t, errs)
 
 	wantSession := vtgatepb.Session{
@@ -552,7 +552,7 @@ func TestTxConnReservedOn2ShardTxOn1ShardAndRollback(t *testing.T) {
 	session.Session.InTransaction = true
 
 	// start a transaction against rss0
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -616,13 +616,13 @@ func TestTxConnCommitOrderFailure1(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_PRE)
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_POST)
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc0.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
 	err := sc.txConn.Commit(ctx, session)
@@ -651,13 +651,13 @@ func TestTxConnCommitOrderFailure2(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(t.Context(), nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(t.Context(), nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_PRE)
-	sc.ExecuteMultiShard(t.Context(), nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(t.Context(), nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_POST)
-	sc.ExecuteMultiShard(t.Context(), nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(t.Context(), nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc1.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
 	err := sc.txConn.Commit(ctx, session)
@@ -685,13 +685,13 @@ func TestTxConnCommitOrderFailure3(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_PRE)
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_POST)
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc1.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
 	require.NoError(t,
@@ -727,7 +727,7 @@ func TestTxConnCommitOrderSuccess(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession := vtgatepb.Session{
 		InTransaction: true,
 		ShardSessions: []*vtgatepb.Session_ShardSession{{
@@ -743,7 +743,7 @@ func TestTxConnCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_PRE)
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction: true,
 		PreSessions: []*vtgatepb.Session_ShardSession{{
@@ -768,7 +768,7 @@ func TestTxConnCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_POST)
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction: true,
 		PreSessions: []*vtgatepb.Session_ShardSession{{
@@ -802,7 +802,7 @@ func TestTxConnCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	// Ensure nothing changes if we reuse a transaction.
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	require.NoError(t,
@@ -825,7 +825,7 @@ func TestTxConnReservedCommitOrderSuccess(t *testing.T) {
 
 	// Sequence the executes to ensure commit order
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true, InReservedConn: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession := vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -843,7 +843,7 @@ func TestTxConnReservedCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_PRE)
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -871,7 +871,7 @@ func TestTxConnReservedCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	session.SetCommitOrder(vtgatepb.CommitOrder_POST)
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	wantSession = vtgatepb.Session{
 		InTransaction:  true,
 		InReservedConn: true,
@@ -909,7 +909,7 @@ func TestTxConnReservedCommitOrderSuccess(t *testing.T) {
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	// Ensure nothing changes if we reuse a transaction.
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	utils.MustMatch(t, &wantSession, session.Session, "Session")
 
 	require.NoError(t,
@@ -962,8 +962,8 @@ func TestTxConnCommit2PC(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PC")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
 	require.NoError(t,
 		sc.txConn.Commit(ctx, session))
@@ -979,7 +979,7 @@ func TestTxConnCommit2PCOneParticipant(t *testing.T) {
 
 	sc, sbc0, _, rss0, _, _ := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCOneParticipant")
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
 	require.NoError(t,
 		sc.txConn.Commit(ctx, session))
@@ -992,8 +992,8 @@ func TestTxConnCommit2PCCreateTransactionFail(t *testing.T) {
 	sc, sbc0, sbc1, rss0, rss1, _ := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCCreateTransactionFail")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss1, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc0.MustFailCreateTransaction = 1
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
@@ -1014,8 +1014,8 @@ func TestTxConnCommit2PCPrepareFail(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCPrepareFail")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc1.MustFailPrepare = 1
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
@@ -1040,8 +1040,8 @@ func TestTxConnCommit2PCStartCommitFail(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCStartCommitFail")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc0.MustFailStartCommit = 1
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
@@ -1059,8 +1059,8 @@ func TestTxConnCommit2PCStartCommitFail(t *testing.T) {
 	sbc1.ResetCounter()
 
 	session = econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	// Here the StartCommit failure is in uncertain state so rollback is not called and neither conclude.
 	sbc0.MustFailStartCommitUncertain = 1
@@ -1082,8 +1082,8 @@ func TestTxConnCommit2PCCommitPreparedFail(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCCommitPreparedFail")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc1.MustFailCommitPrepared = 1
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
@@ -1102,8 +1102,8 @@ func TestTxConnCommit2PCConcludeTransactionFail(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConnCommit2PCConcludeTransactionFail")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc0.MustFailConcludeTransaction = 1
 	session.TransactionMode = vtgatepb.TransactionMode_TWOPC
@@ -1122,8 +1122,8 @@ func TestTxConnRollback(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TxConnRollback")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.NoError(t,
 		sc.txConn.Rollback(ctx, session))
 	wantSession := vtgatepb.Session{}
@@ -1138,8 +1138,8 @@ func TestTxConnReservedRollback(t *testing.T) {
 	sc, sbc0, sbc1, rss0, _, rss01 := newTestTxConnEnv(t, ctx, "TxConnReservedRollback")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true, InReservedConn: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 	require.NoError(t,
 		sc.txConn.Rollback(ctx, session))
 	wantSession := vtgatepb.Session{
@@ -1175,8 +1175,8 @@ func TestTxConnReservedRollbackFailure(t *testing.T) {
 	sc, sbc0, sbc1, rss0, rss1, rss01 := newTestTxConnEnv(t, ctx, "TxConnReservedRollback")
 
 	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true, InReservedConn: true})
-	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, false, nullResultsObserver{}, false)
-	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss0, queries, session, false, maxMemoryRows, nullResultsObserver{}, false)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, maxMemoryRows, nullResultsObserver{}, false)
 
 	sbc1.MustFailCodes[vtrpcpb.Code_INVALID_ARGUMENT] = 1
 	require.Error(t,
@@ -1608,7 +1608,7 @@ func TestTxConnMetrics(t *testing.T) {
 			safeSession := econtext.NewAutocommitSession(session)
 			err := sc.txConn.Begin(ctx, safeSession, nil)
 			require.NoError(t, err)
-			_, errors := sc.ExecuteMultiShard(ctx, nil, tc.rss, tc.queries, safeSession, false, false, nullResultsObserver{}, false)
+			_, errors := sc.ExecuteMultiShard(ctx, nil, tc.rss, tc.queries, safeSession, false, maxMemoryRows, nullResultsObserver{}, false)
 			require.Empty(t, errors)
 			require.NoError(t,
 				sc.txConn.Commit(ctx, safeSession))
@@ -19,6 +19,7 @@ package vindexes
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"os"
@@ -28,12 +29,14 @@ import (
 
 	"vitess.io/vitess/go/json2"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -173,6 +176,10 @@ type BaseTable struct {
 	// MySQL error message: ERROR 3756 (HY000): The primary key cannot be a functional index
 	PrimaryKey sqlparser.Columns  `json:"primary_key,omitempty"`
 	UniqueKeys [][]sqlparser.Expr `json:"unique_keys,omitempty"`
+
+	// Statistics are the sampled column statistics of the table, or nil if
+	// the table is not sampled.
+	Statistics *TableStatistics `json:"statistics,omitempty"`
 }
 
 // GetTableName gets the sqlparser.TableName for the vindex Table.
@@ -204,6 +211,28 @@ type TableInfo struct {
 	Columns     []Column
 	ForeignKeys []*sqlparser.ForeignKeyDefinition
 	Indexes     []*sqlparser.IndexDefinition
+	// Statistics are the sampled column statistics of the table, or nil if
+	// the table is not sampled.
+	Statistics *TableStatistics
+}
+
+// TableStatistics are the statistics of a table, estimated by the tablets
+// from a sample of its rows. The planner only uses them to choose between
+// join orders of the same cost.
+type TableStatistics struct {
+	// Rows is the estimated number of rows of the table.
+	Rows int64 `json:"rows"`
+	// Columns are the statistics of the sampled columns, keyed by lowercase
+	// column name.
+	Columns map[string]ColumnStatistics `json:"columns,omitempty"`
+}
+
+// ColumnStatistics are the statistics of a column.
+type ColumnStatistics struct {
+	// DistinctValues is the estimated number of distinct non-NULL values.
+	DistinctValues int64 `json:"distinct_values"`
+	// NullFraction is the fraction of the rows where the column is NULL.
+	NullFraction float64 `json:"null_fraction,omitempty"`
 }
 
 // IsUnique is used to tell whether the ColumnVindex
@@ -243,6 +272,10 @@ type Column struct {
 	Nullable  bool  `json:"nullable,omitempty"`
 	// Values contains the list of values for enum and set types.
 	Values []string `json:"values,omitempty"`
+	// Mask is the masking policy applied to the values of this column
+	// for users that lack the unmasked role. It is nil for columns that
+	// are not masked.
+	Mask *vschemapb.ColumnMask `json:"mask,omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of Column.
@@ -256,6 +289,11 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale     int32    `json:"scale,omitempty"`
 		Nullable  bool     `json:"nullable,omitempty"`
 		Values    []string `json:"values,omitempty"`
+		Mask      *struct {
+			Policy        string `json:"policy"`
+			VisiblePrefix int32  `json:"visible_prefix,omitempty"`
+			VisibleSuffix int32  `json:"visible_suffix,omitempty"`
+		} `json:"mask,omitempty"`
 	}{
 		Name:      col.Name.String(),
 		Type:      querypb.Type_name[int32(col.Type)],
@@ -268,6 +306,17 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 	if col.Default != nil {
 		cj.Default = sqlparser.String(col.Default)
 	}
+	if col.Mask != nil {
+		cj.Mask = &struct {
+			Policy        string `json:"policy"`
+			VisiblePrefix int32  `json:"visible_prefix,omitempty"`
+			VisibleSuffix int32  `json:"visible_suffix,omitempty"`
+		}{
+			Policy:        col.Mask.Policy.String(),
+			VisiblePrefix: col.Mask.VisiblePrefix,
+			VisibleSuffix: col.Mask.VisibleSuffix,
+		}
+	}
 	return json.Marshal(cj)
 }
 
@@ -281,25 +330,52 @@ func (col *Column) ToEvalengineType(collationEnv *collations.Environment) evalen
 	return evalengine.NewTypeEx(col.Type, collation, col.Nullable, col.Size, col.Scale, new(evalengine.EnumSetValues(col.Values)))
 }
 
+// buildColumnMask validates the masking policy of a column. It returns nil
+// when the column is not masked.
+func buildColumnMask(mask *vschemapb.ColumnMask) (*vschemapb.ColumnMask, error) {
+	if mask == nil || mask.Policy == vschemapb.ColumnMask_NONE {
+		return nil, nil
+	}
+	if mask.VisiblePrefix < 0 || mask.VisibleSuffix < 0 {
+		return nil, errors.New("the visible prefix and suffix cannot be negative")
+	}
+	if mask.Policy != vschemapb.ColumnMask_PARTIAL && (mask.VisiblePrefix > 0 || mask.VisibleSuffix > 0) {
+		return nil, fmt.Errorf("the visible prefix and suffix are only supported by the %s policy", vschemapb.ColumnMask_PARTIAL)
+	}
+	return mask, nil
+}
+
 // KeyspaceSchema contains the schema(table) for a keyspace.
 type KeyspaceSchema struct {
 	Keyspace                  *Keyspace
 	ForeignKeyMode            vschemapb.Keyspace_ForeignKeyMode
 	PreventCrossKeyspaceReads bool
-	Tables                    map[string]*BaseTable
-	Vindexes                  map[string]Vindex
-	Views                     map[string]*View
-	Error                     error
-	MultiTenantSpec           *vschemapb.MultiTenantSpec
+	// QueryTimeout, MaxRows and MaxMemoryRows are the query defaults
+	// of the keyspace. Zero means that the vtgate default applies.
+	QueryTimeout    int
+	MaxRows         int
+	MaxMemoryRows   int
+	Tables          map[string]*BaseTable
+	Vindexes        map[string]Vindex
+	Views           map[string]*View
+	Error           error
+	MultiTenantSpec *vschemapb.MultiTenantSpec
 
 	// These are the UDFs that exist in the schema and are aggregations
 	AggregateUDFs []string
+
+	// UDFReturnTypes holds the return types of the UDFs in the schema,
+	// keyed by lowercase function name
+	UDFReturnTypes map[string]querypb.Type
 }
 
 type ksJSON struct {
 	Sharded                   bool                       `json:"sharded,omitempty"`
 	ForeignKeyMode            string                     `json:"foreignKeyMode,omitempty"`
 	PreventCrossKeyspaceReads bool                       `json:"preventCrossKeyspaceReads,omitempty"`
+	QueryTimeout              int                        `json:"queryTimeoutMs,omitempty"`
+	MaxRows                   int                        `json:"maxRows,omitempty"`
+	MaxMemoryRows             int                        `json:"maxMemoryRows,omitempty"`
 	Tables                    map[string]*BaseTable      `json:"tables,omitempty"`
 	Vindexes                  map[string]Vindex          `json:"vindexes,omitempty"`
 	Views                     map[string]string          `json:"views,omitempty"`
@@ -337,6 +413,9 @@ func (ks *KeyspaceSchema) MarshalJSON() ([]byte, error) {
 		Tables:                    ks.Tables,
 		ForeignKeyMode:            ks.ForeignKeyMode.String(),
 		PreventCrossKeyspaceReads: ks.PreventCrossKeyspaceReads,
+		QueryTimeout:              ks.QueryTimeout,
+		MaxRows:                   ks.MaxRows,
+		MaxMemoryRows:             ks.MaxMemoryRows,
 		Vindexes:                  ks.Vindexes,
 		MultiTenantSpec:           ks.MultiTenantSpec,
 	}
@@ -431,6 +510,9 @@ func buildKeyspaces(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sqlp
 			},
 			ForeignKeyMode:            replaceUnspecifiedForeignKeyMode(ks.ForeignKeyMode),
 			PreventCrossKeyspaceReads: ks.PreventCrossKeyspaceReads,
+			QueryTimeout:              int(ks.QueryTimeoutMs),
+			MaxRows:                   int(ks.MaxRows),
+			MaxMemoryRows:             int(ks.MaxMemoryRows),
 			Tables:                    make(map[string]*BaseTable),
 			Vindexes:                  make(map[string]Vindex),
 			MultiTenantSpec:           ks.MultiTenantSpec,
@@ -488,6 +570,21 @@ func (vschema *VSchema) AddUDF(ksname, udfName string) error {
 	return nil
 }
 
+// AddUDFReturnType adds the return type of a UDF to an existing keyspace in the VSchema.
+// It's only used from tests.
+func (vschema *VSchema) AddUDFReturnType(ksname, udfName string, typ querypb.Type) error {
+	ks, ok := vschema.Keyspaces[ksname]
+	if !ok {
+		return fmt.Errorf("keyspace %s not found in vschema", ksname)
+	}
+
+	if ks.UDFReturnTypes == nil {
+		ks.UDFReturnTypes = make(map[string]querypb.Type)
+	}
+	ks.UDFReturnTypes[strings.ToLower(udfName)] = typ
+	return nil
+}
+
 func buildGlobalTables(source *vschemapb.SrvVSchema, vschema *VSchema) {
 	for ksname, ks := range source.Keyspaces {
 		ksvschema := vschema.Keyspaces[ksname]
@@ -786,6 +883,11 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 						"could not parse the '%s' column's default expression '%s' for table '%s'", col.Name, col.Default, tname)
 				}
 			}
+			mask, err := buildColumnMask(col.Mask)
+			if err != nil {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT,
+					"invalid mask for the '%s' column of table '%s': %v", col.Name, tname, err)
+			}
 			nullable := true
 			if col.Nullable != nil {
 				nullable = *col.Nullable
@@ -801,6 +903,7 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 				Scale:         col.Scale,
 				Nullable:      nullable,
 				Values:        col.Values,
+				Mask:          mask,
 			})
 		}
 
@@ -1034,8 +1137,22 @@ func buildRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sq
 	if source.RoutingRules == nil {
 		return
 	}
+	now := time.Now()
 outer:
 	for _, rule := range source.RoutingRules.Rules {
+		if rule.ActiveFrom != nil && rule.ActiveUntil != nil && !protoutil.TimeFromProto(rule.ActiveFrom).Before(protoutil.TimeFromProto(rule.ActiveUntil)) {
+			vschema.RoutingRules[rule.FromTable] = &RoutingRule{
+				Error: vterrors.Errorf(
+					vtrpcpb.Code_INVALID_ARGUMENT,
+					"rule for table %v expires before it becomes active",
+					rule.FromTable,
+				),
+			}
+			continue
+		}
+		if !RoutingRuleActive(rule, now) {
+			continue
+		}
 		rr := &RoutingRule{}
 		if len(rule.ToTables) > 1 {
 			vschema.RoutingRules[rule.FromTable] = &RoutingRule{
@@ -1124,6 +1241,35 @@ outer:
 	}
 }
 
+// RoutingRuleActive returns true if rule is in effect at the given time.
+func RoutingRuleActive(rule *vschemapb.RoutingRule, now time.Time) bool {
+	if rule.ActiveFrom != nil && now.Before(protoutil.TimeFromProto(rule.ActiveFrom)) {
+		return false
+	}
+	if rule.ActiveUntil != nil && !now.Before(protoutil.TimeFromProto(rule.ActiveUntil)) {
+		return false
+	}
+	return true
+}
+
+// NextRoutingRuleTransition returns the earliest time after now at which a
+// routing rule of source takes or stops taking effect, or the zero time if
+// there is none. The routing rules must be rebuilt at that time.
+func NextRoutingRuleTransition(source *vschemapb.SrvVSchema, now time.Time) time.Time {
+	var next time.Time
+	for _, rule := range source.GetRoutingRules().GetRules() {
+		for _, t := range []*vttimepb.Time{rule.ActiveFrom, rule.ActiveUntil} {
+			if t == nil {
+				continue
+			}
+			if at := protoutil.TimeFromProto(t); at.After(now) && (next.IsZero() || at.Before(next)) {
+				next = at
+			}
+		}
+	}
+	return next
+}
+
 func buildShardRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
 	if source.ShardRoutingRules == nil || len(source.ShardRoutingRules.Rules) == 0 {
 		return
@@ -1683,6 +1829,17 @@ func (vschema *VSchema) GetAggregateUDFs() (udfs []string) {
 	return
 }
 
+// GetUDFReturnTypes returns the return types of the UDFs of a keyspace.
+// UDFs are created per MySQL instance, so the same name can have a
+// different return type in another keyspace.
+func (vschema *VSchema) GetUDFReturnTypes(keyspace string) map[string]querypb.Type {
+	ks, ok := vschema.Keyspaces[keyspace]
+	if !ok {
+		return nil
+	}
+	return ks.UDFReturnTypes
+}
+
 // FindMirrorRule finds a mirror rule from the keyspace, table name and
 // tablet type.
 func (vschema *VSchema) FindMirrorRule(keyspace, tablename string, tabletType topodatapb.TabletType) (*MirrorRule, error) {
@@ -1893,3 +2050,13 @@ func (v *View) GetName() string {
 func (t *BaseTable) GetName() string {
 	return t.Name.String()
 }
+
+// HasMaskedColumns returns true if any column of the table has a masking policy.
+func (t *BaseTable) HasMaskedColumns() bool {
+	for _, col := range t.Columns {
+		if col.Mask != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -25,12 +25,14 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/key"
@@ -403,6 +405,49 @@ func TestVSchemaColumns(t *testing.T) {
 	assertColumnWithDefault(t, t1.Columns[3], "c4", sqltypes.TypeJSON, &sqlparser.JSONArrayExpr{})
 }
 
+func TestVSchemaColumnMasks(t *testing.T) {
+	partial := &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_PARTIAL, VisibleSuffix: 4}
+	good := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"unsharded": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						Columns: []*vschemapb.Column{
+							{Name: "c1", Mask: &vschemapb.ColumnMask{}},
+							{Name: "c2", Type: sqltypes.VarChar, Mask: partial},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := BuildVSchema(&good, sqlparser.NewTestParser())
+	require.NoError(t, got.Keyspaces["unsharded"].Error)
+
+	t1, err := got.FindTable("unsharded", "t1")
+	require.NoError(t, err)
+	assert.Nil(t, t1.Columns[0].Mask)
+	assert.Equal(t, partial, t1.Columns[1].Mask)
+
+	bad := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"unsharded": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						Columns: []*vschemapb.Column{
+							{Name: "c1", Mask: &vschemapb.ColumnMask{Policy: vschemapb.ColumnMask_HASH, VisiblePrefix: 2}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got = BuildVSchema(&bad, sqlparser.NewTestParser())
+	require.EqualError(t, got.Keyspaces["unsharded"].Error, "invalid mask for the 'c1' column of table 't1': the visible prefix and suffix are only supported by the PARTIAL policy")
+}
+
 func TestVSchemaViews(t *testing.T) {
 	good := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -2715,6 +2760,67 @@ func TestFindTable(t *testing.T) {
 	require.EqualError(t, err, "VT05003: unknown database 'none' in vschema")
 }
 
+func TestScheduledRoutingRules(t *testing.T) {
+	now := time.Now()
+	past := protoutil.TimeToProto(now.Add(-time.Hour))
+	future := protoutil.TimeToProto(now.Add(time.Hour))
+	input := vschemapb.SrvVSchema{
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{{
+				// The cutover has not happened yet.
+				FromTable:   "t1",
+				ToTables:    []string{"ksa.t1"},
+				ActiveUntil: future,
+			}, {
+				FromTable:  "t1",
+				ToTables:   []string{"ksb.t1"},
+				ActiveFrom: future,
+			}, {
+				// The cutover has happened.
+				FromTable:   "t2",
+				ToTables:    []string{"ksa.t2"},
+				ActiveUntil: past,
+			}, {
+				FromTable:  "t2",
+				ToTables:   []string{"ksb.t2"},
+				ActiveFrom: past,
+			}, {
+				FromTable:   "t3",
+				ToTables:    []string{"ksb.t2"},
+				ActiveFrom:  future,
+				ActiveUntil: past,
+			}},
+		},
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ksa": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {},
+					"t2": {},
+				},
+			},
+			"ksb": {
+				Tables: map[string]*vschemapb.Table{
+					"t1": {},
+					"t2": {},
+				},
+			},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	require.Contains(t, vschema.RoutingRules, "t1")
+	require.NoError(t, vschema.RoutingRules["t1"].Error)
+	assert.Equal(t, vschema.Keyspaces["ksa"].Tables["t1"], vschema.RoutingRules["t1"].Tables[0])
+	require.Contains(t, vschema.RoutingRules, "t2")
+	require.NoError(t, vschema.RoutingRules["t2"].Error)
+	assert.Equal(t, vschema.Keyspaces["ksb"].Tables["t2"], vschema.RoutingRules["t2"].Tables[0])
+	require.Contains(t, vschema.RoutingRules, "t3")
+	assert.EqualError(t, vschema.RoutingRules["t3"].Error, "rule for table t3 expires before it becomes active")
+
+	assert.Equal(t, protoutil.TimeFromProto(future), NextRoutingRuleTransition(&input, now))
+	assert.True(t, NextRoutingRuleTransition(&input, now.Add(2*time.Hour)).IsZero())
+}
+
 func TestFindTableOrVindex(t *testing.T) {
 	input := vschemapb.SrvVSchema{
 		RoutingRules: &vschemapb.RoutingRules{
@@ -3020,6 +3126,8 @@ func TestVSchemaJSON(t *testing.T) {
 		},
 		"sharded": {
 			ForeignKeyMode: vschemapb.Keyspace_disallow,
+			QueryTimeout:   1000,
+			MaxRows:        500,
 			Keyspace: &Keyspace{
 				Name:    "k2",
 				Sharded: true,
@@ -3045,6 +3153,8 @@ func TestVSchemaJSON(t *testing.T) {
   "sharded": {
     "sharded": true,
     "foreignKeyMode": "disallow",
+    "queryTimeoutMs": 1000,
+    "maxRows": 500,
     "tables": {
       "t3": {
         "name": "n3",
@@ -3596,3 +3706,31 @@ func assertColumnWithDefault(t *testing.T, col Column, expectedName string, expe
 		assert.Equal(t, expDefault, col.Default, "column default does not match")
 	}
 }
+
+func TestGetUDFReturnTypes(t *testing.T) {
+	vschema := &VSchema{
+		Keyspaces: map[string]*KeyspaceSchema{
+			"ks1": {UDFReturnTypes: map[string]querypb.Type{
+				"f1": querypb.Type_INT64,
+				"f2": querypb.Type_VARCHAR,
+				"f3": querypb.Type_FLOAT64,
+			}},
+			"ks2": {UDFReturnTypes: map[string]querypb.Type{
+				"f1": querypb.Type_INT64,
+				"f2": querypb.Type_DECIMAL,
+			}},
+			"ks3": {},
+		},
+	}
+
+	// The same UDF can have different return types in different keyspaces.
+	assert.Equal(t, querypb.Type_VARCHAR, vschema.GetUDFReturnTypes("ks1")["f2"])
+	assert.Equal(t, querypb.Type_DECIMAL, vschema.GetUDFReturnTypes("ks2")["f2"])
+	assert.NotContains(t, vschema.GetUDFReturnTypes("ks2"), "f3")
+	assert.Empty(t, vschema.GetUDFReturnTypes("ks3"))
+	assert.Empty(t, vschema.GetUDFReturnTypes("unknown"))
+
+	require.NoError(t, vschema.AddUDFReturnType("ks3", "My_Func", querypb.Type_INT64))
+	assert.Equal(t, map[string]querypb.Type{"my_func": querypb.Type_INT64}, vschema.GetUDFReturnTypes("ks3"))
+	require.Error(t, vschema.AddUDFReturnType("unknown", "my_func", querypb.Type_INT64))
+}
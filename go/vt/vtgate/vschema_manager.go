@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"vitess.io/vitess/go/vt/graph"
 	"vitess.io/vitess/go/vt/log"
@@ -31,6 +32,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 )
@@ -50,6 +52,12 @@ type VSchemaManager struct {
 	subscriber        func(vschema *vindexes.VSchema, stats *VSchemaStats)
 	schema            SchemaInfo
 	parser            *sqlparser.Parser
+
+	// routingRulesTimer rebuilds the vschema when a scheduled routing rule
+	// takes or stops taking effect, and routingRulesBuiltAt is the time the
+	// routing rules were last built at.
+	routingRulesTimer   *time.Timer
+	routingRulesBuiltAt time.Time
 }
 
 // SchemaInfo is an interface to schema tracker.
@@ -57,6 +65,7 @@ type SchemaInfo interface {
 	Tables(ks string) map[string]*vindexes.TableInfo
 	Views(ks string) map[string]sqlparser.TableStatement
 	UDFs(ks string) []string
+	UDFReturnTypes(ks string) map[string]querypb.Type
 }
 
 // GetCurrentSrvVschema returns a copy of the latest SrvVschema from the
@@ -144,8 +153,10 @@ func (vm *VSchemaManager) VSchemaUpdate(v *vschemapb.SrvVSchema, err error) bool
 			vschema = vindexes.BuildVSchema(&vschemapb.SrvVSchema{}, vm.parser)
 		}
 	} else {
+		now := time.Now()
 		vschema = vm.buildAndEnhanceVSchema(v)
 		vm.currentVschema = vschema
+		vm.scheduleRoutingRulesTransition(v, now)
 	}
 
 	if vm.subscriber != nil {
@@ -183,9 +194,11 @@ func (vm *VSchemaManager) Rebuild() {
 		return
 	}
 
+	now := time.Now()
 	vschema := vm.buildAndEnhanceVSchema(v)
 	vm.mu.Lock()
 	vm.currentVschema = vschema
+	vm.scheduleRoutingRulesTransition(v, now)
 	vm.mu.Unlock()
 
 	if vm.subscriber != nil {
@@ -194,6 +207,40 @@ func (vm *VSchemaManager) Rebuild() {
 	}
 }
 
+// scheduleRoutingRulesTransition schedules a rebuild of the vschema for the
+// next time a routing rule of v takes or stops taking effect, and logs the
+// rules that did so since the routing rules were last built. The routing rules
+// of v must have been built at now. vm.mu must be held.
+func (vm *VSchemaManager) scheduleRoutingRulesTransition(v *vschemapb.SrvVSchema, now time.Time) {
+	if vm.routingRulesTimer != nil {
+		vm.routingRulesTimer.Stop()
+		vm.routingRulesTimer = nil
+	}
+	if !vm.routingRulesBuiltAt.IsZero() {
+		logRoutingRuleTransitions(v, vm.routingRulesBuiltAt, now)
+	}
+	vm.routingRulesBuiltAt = now
+
+	next := vindexes.NextRoutingRuleTransition(v, now)
+	if next.IsZero() {
+		return
+	}
+	log.Info(fmt.Sprintf("Routing rules will be rebuilt at %v for a scheduled rule change", next.UTC()))
+	vm.routingRulesTimer = time.AfterFunc(next.Sub(now), vm.Rebuild)
+}
+
+func logRoutingRuleTransitions(v *vschemapb.SrvVSchema, from, to time.Time) {
+	for _, rule := range v.GetRoutingRules().GetRules() {
+		wasActive, isActive := vindexes.RoutingRuleActive(rule, from), vindexes.RoutingRuleActive(rule, to)
+		switch {
+		case !wasActive && isActive:
+			log.Info(fmt.Sprintf("Routing rule %s => %v took effect", rule.FromTable, rule.ToTables))
+		case wasActive && !isActive:
+			log.Info(fmt.Sprintf("Routing rule %s => %v expired", rule.FromTable, rule.ToTables))
+		}
+	}
+}
+
 // buildAndEnhanceVSchema builds a new VSchema and uses information from the schema tracker to update it
 func (vm *VSchemaManager) buildAndEnhanceVSchema(v *vschemapb.SrvVSchema) *vindexes.VSchema {
 	vschema := vindexes.BuildVSchema(v, vm.parser)
@@ -243,7 +290,8 @@ func (vm *VSchemaManager) updateTableInfo(vschema *vindexes.VSchema, ks *vindexe
 	// are created in the Vschema, so that later when we try to find the routed tables, we don't end up
 	// getting dummy tables.
 	for tblName, tblInfo := range m {
-		setColumns(ks, tblName, tblInfo.Columns)
+		tbl := setColumns(ks, tblName, tblInfo.Columns)
+		tbl.Statistics = tblInfo.Statistics
 	}
 
 	// Now that we have ensured that all the tables are created, we can start populating the foreign keys
@@ -299,9 +347,10 @@ func (vm *VSchemaManager) updateTableInfo(vschema *vindexes.VSchema, ks *vindexe
 	}
 }
 
-// updateUDFsInfo updates the aggregate UDFs in the Vschema.
+// updateUDFsInfo updates the aggregate UDFs and the UDF return types in the Vschema.
 func (vm *VSchemaManager) updateUDFsInfo(ks *vindexes.KeyspaceSchema, ksName string) {
 	ks.AggregateUDFs = vm.schema.UDFs(ksName)
+	ks.UDFReturnTypes = vm.schema.UDFReturnTypes(ksName)
 }
 
 func markErrorIfCyclesInFk(vschema *vindexes.VSchema) {
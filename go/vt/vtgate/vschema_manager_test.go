@@ -17,11 +17,14 @@ limitations under the License.
 package vtgate
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/test/utils"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
@@ -534,6 +537,35 @@ func TestVSchemaUDFsUpdate(t *testing.T) {
 	utils.MustMatch(t, vs, vm.currentVschema, "currentVschema does not match Vschema")
 }
 
+// TestVSchemaColumnStatisticsUpdate tests that the sampled column statistics
+// of the tables are set in the VSchema.
+func TestVSchemaColumnStatisticsUpdate(t *testing.T) {
+	vm := &VSchemaManager{}
+	var vs *vindexes.VSchema
+	vm.subscriber = func(vschema *vindexes.VSchema, _ *VSchemaStats) {
+		vs = vschema
+	}
+	stats := &vindexes.TableStatistics{
+		Rows:    1000,
+		Columns: map[string]vindexes.ColumnStatistics{"id": {DistinctValues: 1000}},
+	}
+	vm.schema = &fakeSchema{t: map[string]*vindexes.TableInfo{
+		"t1": {Columns: []vindexes.Column{{Name: sqlparser.NewIdentifierCI("id"), Type: querypb.Type_INT64}}, Statistics: stats},
+		"t2": {Columns: []vindexes.Column{{Name: sqlparser.NewIdentifierCI("id"), Type: querypb.Type_INT64}}},
+	}}
+	vm.VSchemaUpdate(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks": {
+				Sharded: true,
+				Tables:  map[string]*vschemapb.Table{"t1": {}},
+			},
+		},
+	}, nil)
+
+	assert.Equal(t, stats, vs.Keyspaces["ks"].Tables["t1"].Statistics)
+	assert.Nil(t, vs.Keyspaces["ks"].Tables["t2"].Statistics)
+}
+
 // TestVSchemaViewsUpdate tests that the views are updated in the VSchema.
 func TestVSchemaViewsUpdate(t *testing.T) {
 	vm := &VSchemaManager{}
@@ -1051,6 +1083,47 @@ func TestViewRoutingRules(t *testing.T) {
 	assert.Equal(t, "v1", vs.ViewRoutingRules["source_ks.v1"].TargetViewName)
 }
 
+// TestScheduledRoutingRulesRebuild tests that the vschema is rebuilt when a
+// scheduled routing rule takes effect.
+func TestScheduledRoutingRulesRebuild(t *testing.T) {
+	vm := &VSchemaManager{}
+	var mu sync.Mutex
+	var vs *vindexes.VSchema
+	vm.subscriber = func(vschema *vindexes.VSchema, _ *VSchemaStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		vs = vschema
+	}
+	routedTo := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return vs.RoutingRules["t1"].Tables[0].Keyspace.Name
+	}
+
+	cutover := protoutil.TimeToProto(time.Now().Add(100 * time.Millisecond))
+	vm.VSchemaUpdate(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"source_ks": {Tables: map[string]*vschemapb.Table{"t1": {}}},
+			"target_ks": {Tables: map[string]*vschemapb.Table{"t1": {}}},
+		},
+		RoutingRules: &vschemapb.RoutingRules{
+			Rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"source_ks.t1"}, ActiveUntil: cutover},
+				{FromTable: "t1", ToTables: []string{"target_ks.t1"}, ActiveFrom: cutover},
+			},
+		},
+	}, nil)
+
+	require.Equal(t, "source_ks", routedTo())
+	assert.Eventually(t, func() bool {
+		return routedTo() == "target_ks"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	assert.Nil(t, vm.routingRulesTimer)
+}
+
 // TestViewRoutingRulesRebuild tests that view routing rules are correctly created when views
 // are added after the initial vschema is built.
 func TestViewRoutingRulesRebuild(t *testing.T) {
@@ -1219,6 +1292,7 @@ type fakeSchema struct {
 	tables            map[string]map[string]*vindexes.TableInfo
 	views             map[string]map[string]sqlparser.TableStatement
 	multiKeyspaceUDFs map[string][]string
+	udfReturnTypes    map[string]map[string]querypb.Type
 }
 
 func (f *fakeSchema) Tables(ks string) map[string]*vindexes.TableInfo {
@@ -1242,4 +1316,8 @@ func (f *fakeSchema) UDFs(ks string) []string {
 	return f.udfs // Single keyspace mode (backward compatibility)
 }
 
+func (f *fakeSchema) UDFReturnTypes(ks string) map[string]querypb.Type {
+	return f.udfReturnTypes[ks]
+}
+
 var _ SchemaInfo = (*fakeSchema)(nil)
@@ -97,6 +97,9 @@ var (
 	sysVarSetEnabled      = true
 	setVarEnabled         = true
 	deniedSystemVariables []string
+	// allowedGlobalSystemVariables is the list of system variables that can be
+	// changed with SET GLOBAL through vtgate.
+	allowedGlobalSystemVariables []string
 
 	// lockHeartbeatTime is used to set the next heartbeat time.
 	lockHeartbeatTime = 5 * time.Second
@@ -165,6 +168,7 @@ var (
 	enableSchemaChangeSignal = true
 	enableViews              = true
 	enableUdfs               bool
+	trackColumnStatistics    bool
 
 	// vtgate views flags
 	queryTimeout int
@@ -184,6 +188,24 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	insertBatchWindow  time.Duration
+	insertBatchMaxRows = 100
+
+	resultCacheMemory       int64 = 16 * 1024 * 1024 // 16mb
+	resultCacheInvalidation bool
+
+	inListChunkSize        = 0
+	inListChunkConcurrency = 1000
+
+	maxExecutionTimeHint bool
+
+	maxMemoryBytes int64
+
+	replicaLockingReads = ReplicaLockingReadsAllow
+
+	// dmlIdempotencyKeys sends an idempotency key with the autocommit DMLs.
+	dmlIdempotencyKeys bool
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -207,6 +229,7 @@ func registerFlags(fs *pflag.FlagSet) {
 	utils.SetFlagBoolVar(fs, &sysVarSetEnabled, "enable-system-settings", sysVarSetEnabled, "This will enable the system settings to be changed per session at the database connection level")
 	utils.SetFlagBoolVar(fs, &setVarEnabled, "enable-set-var", setVarEnabled, "This will enable the use of MySQL's SET_VAR query hint for certain system variables instead of using reserved connections")
 	fs.StringSliceVar(&deniedSystemVariables, "denied-system-variables", deniedSystemVariables, "Comma-separated list of system variables that clients are not allowed to SET; attempts return an unsupported error. Names are matched case-insensitively.")
+	fs.StringSliceVar(&allowedGlobalSystemVariables, "allowed-global-system-variables", allowedGlobalSystemVariables, "Comma-separated list of system variables that clients are allowed to change with SET GLOBAL. The statement is executed on the primary of every shard in the selected keyspace, or on the shard targeted with USE. Names are matched case-insensitively.")
 	utils.SetFlagDurationVar(fs, &lockHeartbeatTime, "lock-heartbeat-time", lockHeartbeatTime, "If there is lock function used. This will keep the lock connection active by using this heartbeat")
 	utils.SetFlagBoolVar(fs, &warnShardedOnly, "warn-sharded-only", warnShardedOnly, "If any features that are only available in unsharded mode are used, query execution warnings will be added to the session")
 	utils.SetFlagStringVar(fs, &foreignKeyMode, "foreign-key-mode", foreignKeyMode, "This is to provide how to handle foreign key constraint in create/alter table. Valid values are: allow, disallow")
@@ -218,13 +241,24 @@ func registerFlags(fs *pflag.FlagSet) {
 	utils.SetFlagDurationVar(fs, &slowQueryThreshold, "slow-query-threshold", slowQueryThreshold, "Mark vtgate queries as slow when their total execution time meets or exceeds this duration. 0 disables slow-query detection.")
 	utils.SetFlagStringVar(fs, &queryLogToFile, "log-queries-to-file", queryLogToFile, "Enable query logging to the specified file")
 	fs.IntVar(&queryLogBufferSize, "querylog-buffer-size", queryLogBufferSize, "Maximum number of buffered query logs before throttling log output")
+	utils.SetFlagBoolVar(fs, &dmlIdempotencyKeys, "dml-idempotency-keys", dmlIdempotencyKeys, "Send an idempotency key with every single-shard autocommit DML to the tablets that support it, so that a DML retried by vtgate is not applied twice")
 	utils.SetFlagDurationVar(fs, &messageStreamGracePeriod, "message-stream-grace-period", messageStreamGracePeriod, "the amount of time to give for a vttablet to resume if it ends a message stream, usually because of a reparent.")
 	fs.BoolVar(&enableViews, "enable-views", enableViews, "Enable views support in vtgate.")
 	fs.BoolVar(&enableUdfs, "track-udfs", enableUdfs, "Track UDFs in vtgate.")
+	fs.BoolVar(&trackColumnStatistics, "track-column-statistics", trackColumnStatistics, "Track the column statistics the primary tablets sample with --column-statistics-tables, and use them to order joins.")
 	fs.BoolVar(&allowKillStmt, "allow-kill-statement", allowKillStmt, "Allows the execution of kill statement")
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.DurationVar(&insertBatchWindow, "insert-batch-window", insertBatchWindow, "How long single-row autocommit inserts into sharded tables wait to be batched with other inserts to the same shard into one multi-row insert. Batched inserts do not report insert ids generated by MySQL. 0 disables insert batching.")
+	fs.IntVar(&insertBatchMaxRows, "insert-batch-max-rows", insertBatchMaxRows, "Maximum number of rows of an insert batch. A batch is sent as soon as it has this many rows.")
+	fs.IntVar(&inListChunkSize, "in-list-chunk-size", inListChunkSize, "Maximum number of values of an IN list that a SELECT query sends to a shard in one query. Queries with larger IN lists are executed in chunks whose results are merged by vtgate, when the type of the compared column is known. 0 disables the chunking.")
+	utils.SetFlagIntVar(fs, &inListChunkConcurrency, "in-list-chunk-concurrency", inListChunkConcurrency, "Maximum number of queries that the sorted queries executed in chunks, because of --in-list-chunk-size, stream from the shards at the same time. A sorted query whose chunks need more streams than are available executes its chunks one after the other and sorts their rows in memory instead.")
+	fs.BoolVar(&maxExecutionTimeHint, "max-execution-time-hint", maxExecutionTimeHint, "Add a MAX_EXECUTION_TIME optimizer hint with the time left before the query times out to the SELECT statements sent to the tablets, so that MySQL stops running them once vtgate has timed them out.")
+	fs.Int64Var(&maxMemoryBytes, "max-memory-bytes", maxMemoryBytes, "Maximum number of bytes of rows that the sorts, aggregations and joins of a query can hold in memory. Queries that hold more fail with a VT08001 error. 0 means no limit.")
+	fs.Int64Var(&resultCacheMemory, "result-cache-memory", resultCacheMemory, "Maximum amount of memory in bytes used to cache the results of the SELECT queries that use the CACHE_TTL directive. 0 disables the result cache.")
+	fs.StringVar(&replicaLockingReads, "replica-locking-reads", replicaLockingReads, "Policy for the locking reads, SELECT ... FOR UPDATE and SELECT ... FOR SHARE, of the sessions that target replicas, where they do not lock the rows they read on the primary. Valid values are: allow (run them on the replicas), redirect (run them on the primary, unless the session is in a transaction), reject (fail them with a VT09033 error).")
+	fs.BoolVar(&resultCacheInvalidation, "result-cache-invalidation", resultCacheInvalidation, "Invalidate the cached results of the queries that use the CACHE_TTL directive before their TTL expires, by streaming the changes to their tables with VStream.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -376,6 +410,12 @@ func Init(
 		log.Error(fmt.Sprintf("Invalid value for -ddl-strategy: %v", err.Error()))
 		os.Exit(1)
 	}
+	switch replicaLockingReads {
+	case ReplicaLockingReadsAllow, ReplicaLockingReadsRedirect, ReplicaLockingReadsReject:
+	default:
+		log.Error(fmt.Sprintf("Invalid value for --replica-locking-reads: %v", replicaLockingReads))
+		os.Exit(1)
+	}
 	tc := NewTxConn(gw, dynamicConfig)
 	// ScatterConn depends on TxConn to perform forced rollbacks.
 	sc := NewScatterConn("VttabletCall", tc, gw)
@@ -403,7 +443,7 @@ func Init(
 	var si SchemaInfo // default nil
 	var st *vtschema.Tracker
 	if enableSchemaChangeSignal {
-		st = vtschema.NewTracker(gw.hc.Subscribe(schemaTrackerHcName), enableViews, enableUdfs, env.Parser())
+		st = vtschema.NewTracker(gw.hc.Subscribe(schemaTrackerHcName), enableViews, enableUdfs, trackColumnStatistics, env.Parser())
 		addKeyspacesToTracker(ctx, srvResolver, st, gw)
 		si = st
 	}
@@ -417,9 +457,16 @@ func Init(
 		PreventCrossKeyspaceReads: preventCrossKeyspaceReads,
 		WarmingReadsPercent:       warmingReadsPercent,
 		QueryLogToFile:            queryLogToFile,
+		InsertBatchWindow:         insertBatchWindow,
+		InsertBatchMaxRows:        insertBatchMaxRows,
+		ResultCacheMemory:         resultCacheMemory,
+		ReplicaLockingReads:       replicaLockingReads,
 	}
 
 	executor := NewExecutor(ctx, env, serv, cell, resolver, eConfig, warnShardedOnly, plans, si, pv, dynamicConfig)
+	if resultCacheInvalidation {
+		executor.startResultCacheInvalidation(ctx, vsm.VStream)
+	}
 
 	if err := executor.defaultQueryLogger(); err != nil {
 		log.Error(fmt.Sprintf("error initializing query logger: %v", err))
@@ -817,7 +864,8 @@ func (vtg *VTGate) StreamExecuteMulti(ctx context.Context, mysqlCtx vtgateservic
 				defer func() {
 					firstPacket = false
 				}()
-				return callback(sqltypes.QueryResponse{QueryResult: result}, more, firstPacket)
+				// A stored procedure call can return several result sets.
+				return callback(sqltypes.QueryResponse{QueryResult: result}, more, firstPacket || result.NewResult)
 			})
 		}()
 		if err != nil {
@@ -840,6 +888,24 @@ func (vtg *VTGate) CloseSession(ctx context.Context, session *vtgatepb.Session)
 	return vtg.executor.CloseSession(ctx, econtext.NewSafeSession(session))
 }
 
+// MessageAckBatch acks messages of a message table.
+// It returns the number of messages acked, including those of the shards
+// that succeeded when others failed.
+func (vtg *VTGate) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	statsKey := []string{"MessageAckBatch", keyspace, "primary"}
+	defer vtg.timings.Record(statsKey, time.Now())
+	return vtg.executor.MessageAckBatch(ctx, keyspace, name, ids)
+}
+
+// MessagePostpone postpones messages of a message table.
+// It returns the number of messages postponed, including those of the
+// shards that succeeded when others failed.
+func (vtg *VTGate) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	statsKey := []string{"MessagePostpone", keyspace, "primary"}
+	defer vtg.timings.Record(statsKey, time.Now())
+	return vtg.executor.MessagePostpone(ctx, keyspace, name, ids)
+}
+
 // Prepare supports non-streaming prepare statement query with multi shards
 func (vtg *VTGate) Prepare(ctx context.Context, session *vtgatepb.Session, sql string) (newSession *vtgatepb.Session, fld []*querypb.Field, paramsCount uint16, err error) {
 	// In this context, we don't care if we can't fully parse destination
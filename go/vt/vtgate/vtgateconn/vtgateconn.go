@@ -120,6 +120,30 @@ func (conn *VTGateConn) BinlogDumpGTID(ctx context.Context, keyspace, shard stri
 	return conn.impl.BinlogDumpGTID(ctx, keyspace, shard, tabletType, tabletAlias, binlogFilename, binlogPosition, gtidSet, flags)
 }
 
+// StreamExportReader is returned by StreamExport.
+type StreamExportReader interface {
+	// Recv returns the next response on the stream.
+	// It will return io.EOF if the stream ended.
+	Recv() (*vtgatepb.StreamExportResponse, error)
+}
+
+// StreamExport streams all the rows of a table, with checkpoints to resume the export.
+func (conn *VTGateConn) StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest) (StreamExportReader, error) {
+	return conn.impl.StreamExport(ctx, req)
+}
+
+// MessageAckBatch acks messages of a message table. It returns the number
+// of messages acked, even if there was an error on some shards.
+func (conn *VTGateConn) MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return conn.impl.MessageAckBatch(ctx, keyspace, name, ids)
+}
+
+// MessagePostpone postpones messages of a message table. It returns the
+// number of messages postponed, even if there was an error on some shards.
+func (conn *VTGateConn) MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error) {
+	return conn.impl.MessagePostpone(ctx, keyspace, name, ids)
+}
+
 // VTGateSession exposes the Vitess Execution API to the clients.
 // The object maintains client-side state and is comparable to a native MySQL connection.
 // For example, if you enable autocommit on a Session object, all subsequent calls will respect this.
@@ -218,12 +242,21 @@ type Impl interface {
 	// CloseSession closes the session provided by rolling back any active transaction.
 	CloseSession(ctx context.Context, session *vtgatepb.Session) error
 
+	// MessageAckBatch acks messages of a message table.
+	MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error)
+
+	// MessagePostpone postpones messages of a message table.
+	MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error)
+
 	// VStream streams binlogevents
 	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) (VStreamReader, error)
 
 	// BinlogDumpGTID streams raw binlog events from a specific keyspace/shard.
 	BinlogDumpGTID(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, tabletAlias *topodatapb.TabletAlias, binlogFilename string, binlogPosition uint64, gtidSet string, flags uint32) (BinlogDumpGTIDReader, error)
 
+	// StreamExport streams all the rows of a table, with checkpoints to resume the export.
+	StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest) (StreamExportReader, error)
+
 	// Close must be called for releasing resources.
 	Close()
 }
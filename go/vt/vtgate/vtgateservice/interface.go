@@ -20,6 +20,7 @@ package vtgateservice
 
 import (
 	"context"
+	"io"
 
 	"vitess.io/vitess/go/sqltypes"
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -48,12 +49,21 @@ type VTGateService interface {
 	// but does not affect the query statistics.
 	CloseSession(ctx context.Context, session *vtgatepb.Session) error
 
+	// Messaging methods.
+	// MessageAckBatch and MessagePostpone return the number of messages
+	// affected, even if there was an error on some shards.
+	MessageAckBatch(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error)
+	MessagePostpone(ctx context.Context, keyspace, name string, ids []*querypb.Value) (int64, error)
+
 	// Update Stream methods
 	VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error
 
 	// BinlogDumpGTID streams raw binlog events from a specific keyspace/shard.
 	BinlogDumpGTID(ctx context.Context, req *vtgatepb.BinlogDumpGTIDRequest, send func(*vtgatepb.BinlogDumpResponse) error) error
 
+	// StreamExport streams all the rows of a table, with checkpoints to resume the export.
+	StreamExport(ctx context.Context, req *vtgatepb.StreamExportRequest, send func(*vtgatepb.StreamExportResponse) error) error
+
 	// HandlePanic should be called with defer at the beginning of each
 	// RPC implementation method, before calling any of the previous methods
 	HandlePanic(err *error)
@@ -72,4 +82,7 @@ type MySQLConnection interface {
 	// IngressBytes returns the number of bytes read for the command currently
 	// being handled.
 	IngressBytes() uint64
+	// LocalInfile asks the client for the content of the file of a LOAD DATA
+	// LOCAL INFILE statement.
+	LocalInfile(filename string) (io.ReadCloser, error)
 }
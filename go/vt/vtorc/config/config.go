@@ -267,6 +267,24 @@ var (
 			Dynamic:  true,
 		},
 	)
+
+	notificationWebhookURL = viperutil.Configure(
+		"notification-webhook-url",
+		viperutil.Options[string]{
+			FlagName: "notification-webhook-url",
+			Default:  "",
+			Dynamic:  true,
+		},
+	)
+
+	notificationWebhookTimeout = viperutil.Configure(
+		"notification-webhook-timeout",
+		viperutil.Options[time.Duration]{
+			FlagName: "notification-webhook-timeout",
+			Default:  5 * time.Second,
+			Dynamic:  true,
+		},
+	)
 )
 
 func init() {
@@ -300,6 +318,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.Duration("shard-tablet-health-freshness", shardTabletHealthFreshness.Default(), "Maximum age of an observer's shard-peer report for it to count toward quorum. Must exceed --instance-poll-time (ideally 2-3x), since reports only refresh when VTOrc polls each observer")
 	fs.Float64("shard-tablet-health-quorum-fraction", shardQuorumFraction.Default(), "Required fraction of 'down' votes among eligible observers to declare the primary unreachable (1.0 = unanimous). Values below 1.0 make detection more tolerant of partial agreement, but give up the guarantee that a single fresh 'up' report vetoes the failover")
 	fs.Int("shard-tablet-health-quorum-min-observers", shardQuorumMinObservers.Default(), "Minimum number of eligible observers required before a quorum-based emergency reparent may run; at 1, a single-observer shard relies on that observer plus VTOrc's own check")
+	fs.String("notification-webhook-url", notificationWebhookURL.Default(), "URL VTOrc POSTs a JSON incident payload to when it detects a problem and when it completes a recovery. Notifications are disabled when empty")
+	fs.Duration("notification-webhook-timeout", notificationWebhookTimeout.Default(), "Timeout for each POST to --notification-webhook-url")
 
 	viperutil.BindFlags(
 		fs,
@@ -328,6 +348,8 @@ func registerFlags(fs *pflag.FlagSet) {
 		shardTabletHealthFreshness,
 		shardQuorumFraction,
 		shardQuorumMinObservers,
+		notificationWebhookURL,
+		notificationWebhookTimeout,
 	)
 }
 
@@ -503,6 +525,21 @@ func GetShardQuorumMinObservers() int {
 	return shardQuorumMinObservers.Get()
 }
 
+// GetNotificationWebhookURL is a getter function.
+func GetNotificationWebhookURL() string {
+	return notificationWebhookURL.Get()
+}
+
+// SetNotificationWebhookURL is a setter function. This should only be used from tests.
+func SetNotificationWebhookURL(v string) {
+	notificationWebhookURL.Set(v)
+}
+
+// GetNotificationWebhookTimeout is a getter function.
+func GetNotificationWebhookTimeout() time.Duration {
+	return notificationWebhookTimeout.Get()
+}
+
 // MarkConfigurationLoaded is called once configuration has first been loaded.
 // Listeners on ConfigurationLoaded will get a notification
 func MarkConfigurationLoaded() {
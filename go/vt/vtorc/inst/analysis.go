@@ -162,7 +162,11 @@ type DetectionAnalysis struct {
 	MaxReplicaGTIDErrant                      string
 	IsReadOnly                                bool
 	IsDiskStalled                             bool
+	IsGroupReplicationMember                  bool
 	QuorumDetail                              *QuorumResult `json:",omitempty"`
+
+	// DetectionTime is the time the problem was recorded as detected.
+	DetectionTime time.Time
 }
 
 // hasMinSemiSyncAckers returns true if there are a minimum number of semi-sync ackers enabled and replicating.
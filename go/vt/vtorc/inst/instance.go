@@ -90,6 +90,10 @@ type Instance struct {
 	SemiSyncReplicaStatus              bool
 	SemiSyncBlocked                    bool
 
+	// IsGroupReplicationMember is true if the tablet fronts a MySQL group
+	// replication member managed outside of Vitess.
+	IsGroupReplicationMember bool
+
 	LastSeenTimestamp    string
 	IsLastCheckValid     bool
 	IsUpToDate           bool
@@ -265,6 +265,7 @@ func ReadTopologyInstanceBufferable(tabletAlias *topodatapb.TabletAlias, latency
 		instance.SemiSyncPrimaryStatus = fs.SemiSyncPrimaryStatus
 		instance.SemiSyncReplicaStatus = fs.SemiSyncReplicaStatus
 		instance.SemiSyncBlocked = fs.SemiSyncBlocked
+		instance.IsGroupReplicationMember = fs.GroupReplicationStatus != nil
 
 		if instance.IsOracleMySQL() || instance.IsPercona() {
 			// Stuff only supported on Oracle / Percona MySQL
@@ -641,6 +642,7 @@ func readInstanceRow(m sqlutils.RowMap) (*Instance, error) {
 	instance.SemiSyncPrimaryClients = m.GetUint("semi_sync_primary_clients")
 	instance.SemiSyncReplicaStatus = m.GetBool("semi_sync_replica_status")
 	instance.SemiSyncBlocked = m.GetBool("semi_sync_blocked")
+	instance.IsGroupReplicationMember = m.GetBool("is_group_replication_member")
 	instance.ReplicationDepth = m.GetUint("replication_depth")
 	instance.IsCoPrimary = m.GetBool("is_co_primary")
 	instance.HasReplicationCredentials = m.GetBool("has_replication_credentials")
@@ -962,6 +964,7 @@ func mkInsertForInstances(instances []*Instance, instanceWasActuallyFound bool,
 		"semi_sync_blocked",
 		"last_discovery_latency",
 		"is_disk_stalled",
+		"is_group_replication_member",
 	}
 
 	values := make([]string, len(columns))
@@ -1042,6 +1045,7 @@ func mkInsertForInstances(instances []*Instance, instanceWasActuallyFound bool,
 		args = append(args, instance.SemiSyncBlocked)
 		args = append(args, instance.LastDiscoveryLatency.Nanoseconds())
 		args = append(args, instance.StalledDisk)
+		args = append(args, instance.IsGroupReplicationMember)
 	}
 
 	sql, err := mkInsert("database_instance", columns, values, len(instances), insertIgnore)
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// IncidentEvent is the kind of event an Incident notifies about.
+type IncidentEvent string
+
+const (
+	// IncidentProblemDetected is sent when VTOrc first detects a problem.
+	IncidentProblemDetected IncidentEvent = "ProblemDetected"
+	// IncidentRecoverySucceeded is sent when a recovery completes successfully.
+	IncidentRecoverySucceeded IncidentEvent = "RecoverySucceeded"
+	// IncidentRecoveryFailed is sent when a recovery completes with errors.
+	IncidentRecoveryFailed IncidentEvent = "RecoveryFailed"
+)
+
+// notificationQueueCapacity is the number of incidents that can be waiting
+// to be sent. Incidents are dropped when the queue is full, so a slow or
+// unreachable webhook never holds up recoveries.
+const notificationQueueCapacity = 1000
+
+// Incident is the JSON payload VTOrc POSTs to --notification-webhook-url.
+type Incident struct {
+	Event          IncidentEvent `json:"event"`
+	Analysis       string        `json:"analysis"`
+	TabletAlias    string        `json:"tablet_alias"`
+	Keyspace       string        `json:"keyspace"`
+	Shard          string        `json:"shard"`
+	RecoveryType   string        `json:"recovery_type,omitempty"`
+	SuccessorAlias string        `json:"successor_alias,omitempty"`
+	Errors         []string      `json:"errors,omitempty"`
+	StartTime      time.Time     `json:"start_time,omitzero"`
+	EndTime        time.Time     `json:"end_time,omitzero"`
+	Reporter       string        `json:"reporter"`
+	Time           time.Time     `json:"time"`
+}
+
+var (
+	// webhookNotificationsCounter counts the incidents sent to the webhook, by outcome.
+	webhookNotificationsCounter = stats.NewCountersWithMultiLabels("WebhookNotifications", "Count of the incident notifications sent to the webhook", []string{"Event", "Result"})
+
+	notificationQueue     chan *Incident
+	notificationQueueOnce sync.Once
+	notificationReporter  string
+)
+
+func init() {
+	notificationReporter, _ = os.Hostname()
+}
+
+// newDetectionIncident returns the incident for a newly detected problem.
+func newDetectionIncident(analysisEntry *inst.DetectionAnalysis) *Incident {
+	return &Incident{
+		Event:       IncidentProblemDetected,
+		Analysis:    string(analysisEntry.Analysis),
+		TabletAlias: topoproto.TabletAliasString(analysisEntry.AnalyzedInstanceAlias),
+		Keyspace:    analysisEntry.AnalyzedKeyspace,
+		Shard:       analysisEntry.AnalyzedShard,
+	}
+}
+
+// newRecoveryIncident returns the incident for a recovery that ran between
+// start and end.
+func newRecoveryIncident(analysisEntry *inst.DetectionAnalysis, recoveryName string, topologyRecovery *TopologyRecovery, err error, start, end time.Time) *Incident {
+	incident := newDetectionIncident(analysisEntry)
+	incident.Event = IncidentRecoverySucceeded
+	incident.RecoveryType = recoveryName
+	incident.StartTime = start
+	incident.EndTime = end
+	if topologyRecovery != nil {
+		if topologyRecovery.SuccessorAlias != nil {
+			incident.SuccessorAlias = topoproto.TabletAliasString(topologyRecovery.SuccessorAlias)
+		}
+		incident.Errors = topologyRecovery.AllErrors
+	}
+	if err != nil {
+		incident.Event = IncidentRecoveryFailed
+		if len(incident.Errors) == 0 {
+			incident.Errors = []string{err.Error()}
+		}
+	}
+	return incident
+}
+
+// notifyIncident queues the incident to be sent to the notification webhook,
+// if one is configured. It never blocks.
+func notifyIncident(incident *Incident) {
+	if config.GetNotificationWebhookURL() == "" {
+		return
+	}
+	notificationQueueOnce.Do(func() {
+		notificationQueue = make(chan *Incident, notificationQueueCapacity)
+		go sendNotifications(notificationQueue)
+	})
+
+	incident.Reporter = notificationReporter
+	incident.Time = time.Now()
+	select {
+	case notificationQueue <- incident:
+	default:
+		log.Warn(fmt.Sprintf("Notification queue is full, dropping %v incident for %v on %v", incident.Event, incident.Analysis, incident.TabletAlias))
+		webhookNotificationsCounter.Add([]string{string(incident.Event), "Dropped"}, 1)
+	}
+}
+
+// sendNotifications sends the incidents of the queue to the notification
+// webhook one at a time, so that they are received in order.
+func sendNotifications(queue <-chan *Incident) {
+	client := &http.Client{}
+	for incident := range queue {
+		if err := postIncident(client, config.GetNotificationWebhookURL(), incident); err != nil {
+			log.Warn(fmt.Sprintf("Failed to send %v incident for %v on %v: %v", incident.Event, incident.Analysis, incident.TabletAlias, err))
+			webhookNotificationsCounter.Add([]string{string(incident.Event), "Failed"}, 1)
+			continue
+		}
+		webhookNotificationsCounter.Add([]string{string(incident.Event), "Sent"}, 1)
+	}
+}
+
+// postIncident POSTs the incident to the given URL as JSON.
+func postIncident(client *http.Client, url string, incident *Incident) error {
+	if url == "" {
+		return errors.New("no notification webhook is configured")
+	}
+	body, err := json.Marshal(incident)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.GetNotificationWebhookTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func TestNewRecoveryIncident(t *testing.T) {
+	analysisEntry := &inst.DetectionAnalysis{
+		Analysis:              inst.DeadPrimary,
+		AnalyzedInstanceAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		AnalyzedKeyspace:      "ks",
+		AnalyzedShard:         "0",
+	}
+	start := time.Now()
+	end := start.Add(time.Second)
+
+	topologyRecovery := NewTopologyRecovery(*analysisEntry)
+	topologyRecovery.SuccessorAlias = &topodatapb.TabletAlias{Cell: "zone1", Uid: 101}
+	incident := newRecoveryIncident(analysisEntry, RecoverDeadPrimaryRecoveryName, topologyRecovery, nil, start, end)
+	assert.Equal(t, IncidentRecoverySucceeded, incident.Event)
+	assert.Equal(t, "DeadPrimary", incident.Analysis)
+	assert.Equal(t, "zone1-0000000100", incident.TabletAlias)
+	assert.Equal(t, "ks", incident.Keyspace)
+	assert.Equal(t, "0", incident.Shard)
+	assert.Equal(t, RecoverDeadPrimaryRecoveryName, incident.RecoveryType)
+	assert.Equal(t, "zone1-0000000101", incident.SuccessorAlias)
+	assert.Empty(t, incident.Errors)
+	assert.Equal(t, start, incident.StartTime)
+	assert.Equal(t, end, incident.EndTime)
+
+	// A failed recovery without a topology recovery reports the error it failed with.
+	incident = newRecoveryIncident(analysisEntry, RecoverDeadPrimaryRecoveryName, nil, errors.New("no valid candidate"), start, end)
+	assert.Equal(t, IncidentRecoveryFailed, incident.Event)
+	assert.Empty(t, incident.SuccessorAlias)
+	assert.Equal(t, []string{"no valid candidate"}, incident.Errors)
+}
+
+func TestNotifyIncident(t *testing.T) {
+	received := make(chan *Incident, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		incident := &Incident{}
+		if assert.NoError(t, json.NewDecoder(r.Body).Decode(incident)) {
+			received <- incident
+		}
+	}))
+	defer server.Close()
+
+	analysisEntry := &inst.DetectionAnalysis{
+		Analysis:              inst.ReplicationStopped,
+		AnalyzedInstanceAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		AnalyzedKeyspace:      "ks",
+		AnalyzedShard:         "0",
+	}
+
+	// Nothing is sent when no webhook is configured.
+	notifyIncident(newDetectionIncident(analysisEntry))
+
+	prevURL := config.GetNotificationWebhookURL()
+	config.SetNotificationWebhookURL(server.URL)
+	defer config.SetNotificationWebhookURL(prevURL)
+
+	notifyIncident(newDetectionIncident(analysisEntry))
+	select {
+	case incident := <-received:
+		assert.Equal(t, IncidentProblemDetected, incident.Event)
+		assert.Equal(t, "ReplicationStopped", incident.Analysis)
+		assert.Equal(t, "zone1-0000000100", incident.TabletAlias)
+		assert.Equal(t, "ks", incident.Keyspace)
+		assert.Equal(t, "0", incident.Shard)
+		assert.Equal(t, notificationReporter, incident.Reporter)
+		assert.False(t, incident.Time.IsZero())
+		assert.True(t, incident.StartTime.IsZero())
+	case <-time.After(30 * time.Second):
+		require.FailNow(t, "timed out waiting for the incident")
+	}
+	assert.Empty(t, received)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/patrickmn/go-cache"
 	"golang.org/x/sync/errgroup"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
@@ -77,6 +78,7 @@ const (
 	RecoverySkipERSDisabled
 	RecoverySkipStaleAnalysis
 	RecoverySkipPrimaryRecovery
+	RecoverySkipGroupReplication
 )
 
 // String represents a RecoverySkip as a string.
@@ -92,6 +94,8 @@ func (rsc RecoverySkipCode) String() string {
 		return "StaleAnalysis"
 	case RecoverySkipPrimaryRecovery:
 		return "PrimaryRecovery"
+	case RecoverySkipGroupReplication:
+		return "GroupReplication"
 	default:
 		return "None"
 	}
@@ -116,6 +120,14 @@ var (
 		"Shard",
 	})
 
+	// problemsDetectedCounter counts the number of times each problem was newly detected,
+	// as opposed to detectedProblems which tracks the problems that are currently active.
+	problemsDetectedCounter = stats.NewCountersWithMultiLabels("ProblemsDetected", "Count of the times each problem was newly detected", []string{
+		"Analysis",
+		"Keyspace",
+		"Shard",
+	})
+
 	// shardsLockCounter is a count of in-flight shard locks.
 	shardsLockCounter atomic.Int64
 
@@ -134,6 +146,9 @@ var (
 	// recoveriesSkippedCounter counts the number of skipped recoveries that VTOrc has performed
 	recoveriesSkippedCounter = stats.NewCountersWithMultiLabels("SkippedRecoveries", "Count of the different skipped recoveries performed", append(recoveriesCounterLabels, "Reason"))
 
+	// recoveryTimings measures the time the recoveries that VTOrc has performed took, by outcome.
+	recoveryTimings = stats.NewMultiTimings("RecoveryTimings", "Timings of the different recoveries performed", []string{"RecoveryType", "Result"})
+
 	// shardLockTimings measures the timing of LockShard operations.
 	shardLockTimingsActions = []string{"Lock", "Unlock"}
 	shardLockTimings        = stats.NewTimings("ShardLockTimings", "Timings of global shard locks", "Action", shardLockTimingsActions...)
@@ -365,6 +380,13 @@ func runEmergencyReparentOp(ctx context.Context, analysisEntry *inst.DetectionAn
 			WaitReplicasTimeout:       config.GetWaitReplicasTimeout(),
 			PreventCrossCellPromotion: config.GetPreventCrossCellFailover(),
 			WaitAllTablets:            waitForAllTablets,
+			Initiator:                 "VTOrc " + recoveryName,
+			Detections: []*topodatapb.ReparentReport_Detection{{
+				Time:        protoutil.TimeToProto(analysisEntry.DetectionTime),
+				Tablet:      analysisEntry.AnalyzedInstanceAlias,
+				Analysis:    string(analysisEntry.Analysis),
+				Description: analysisEntry.Description,
+			}},
 		},
 	)
 	if err != nil {
@@ -708,6 +730,13 @@ func getCheckAndRecoverFunctionCode(analysisEntry *inst.DetectionAnalysis) (reco
 	// case inst.AllPrimaryReplicasStale:
 	//   recoveryFunc = recoverGenericProblemFunc
 
+	// A MySQL group replication cluster elects its own primary and manages
+	// its own replication, so VTOrc must not act on it.
+	if analysisEntry.IsGroupReplicationMember && recoverySkipCode == RecoverySkipNone && hasActionableRecovery(recoveryFunc) {
+		log.Info(fmt.Sprintf("%v is a group replication member, skipping recovering %v", topoproto.TabletAliasString(analysisEntry.AnalyzedInstanceAlias), analysisCode))
+		recoverySkipCode = RecoverySkipGroupReplication
+	}
+
 	return recoveryFunc, recoverySkipCode
 }
 
@@ -1013,19 +1042,24 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.DetectionAnalysis) (err
 			logger.Info(fmt.Sprintf("Analysis: %v, %v %+v", analysisEntry.Analysis, recoveryName, analyzedInstanceAliasString))
 		}
 	}
+	recoveryStart := time.Now()
 	recoveryAttempted, topologyRecovery, err := getCheckAndRecoverFunction(checkAndRecoverFunctionCode)(ctx, analysisEntry, logger)
 	if !recoveryAttempted {
 		logger.Error(fmt.Sprintf("Recovery not attempted: %+v", err))
 		return err
 	}
+	recoveryEnd := time.Now()
 	recoveriesCounter.Add(recoveryLabels, 1)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to recover: %+v", err))
 		recoveriesFailureCounter.Add(recoveryLabels, 1)
+		recoveryTimings.Record([]string{recoveryName, "Failure"}, recoveryStart)
 	} else {
 		logger.Info("Recovery succeeded")
 		recoveriesSuccessfulCounter.Add(recoveryLabels, 1)
+		recoveryTimings.Record([]string{recoveryName, "Success"}, recoveryStart)
 	}
+	notifyIncident(newRecoveryIncident(analysisEntry, recoveryName, topologyRecovery, err, recoveryStart, recoveryEnd))
 	if topologyRecovery == nil {
 		logger.Error("Topology recovery is nil - recovery might have failed")
 		return err
@@ -1149,6 +1183,8 @@ func CheckAndRecover() {
 	// Regardless of if the problem is solved or not we want to monitor active
 	// issues, we use a map of labels and set a counter to `1` for each problem
 	// then we reset any counter that is not present in the current analysis.
+	// Problems that were not active in the previous run are newly detected.
+	previouslyActive := detectedProblems.Counts()
 	active := make(map[string]struct{})
 	for _, shardAnalyses := range analysisByShard {
 		for _, e := range shardAnalyses {
@@ -1162,6 +1198,10 @@ func CheckAndRecover() {
 
 				key := detectedProblems.GetLabelName(names[:]...)
 				active[key] = struct{}{}
+				if previouslyActive[key] == 0 {
+					problemsDetectedCounter.Add([]string{string(e.Analysis), e.AnalyzedKeyspace, e.AnalyzedShard}, 1)
+					notifyIncident(newDetectionIncident(e))
+				}
 				detectedProblems.Set(names[:], 1)
 			}
 		}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"vitess.io/vitess/go/vt/external/golib/sqlutils"
 	"vitess.io/vitess/go/vt/log"
@@ -60,6 +61,7 @@ func InsertRecoveryDetection(analysisEntry *inst.DetectionAnalysis) error {
 		return err
 	}
 	analysisEntry.RecoveryId = id
+	analysisEntry.DetectionTime = time.Now()
 	return nil
 }
 
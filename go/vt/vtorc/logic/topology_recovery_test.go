@@ -275,6 +275,17 @@ func TestGetCheckAndRecoverFunctionCode(t *testing.T) {
 			},
 			wantRecoveryFunction: recoverDeadPrimaryFunc,
 			wantRecoverySkipCode: RecoverySkipERSDisabled,
+		}, {
+			name:       "DeadPrimary in a group replication cluster",
+			ersEnabled: true,
+			analysisEntry: &inst.DetectionAnalysis{
+				Analysis:                 inst.DeadPrimary,
+				AnalyzedKeyspace:         keyspace,
+				AnalyzedShard:            shard,
+				IsGroupReplicationMember: true,
+			},
+			wantRecoveryFunction: recoverDeadPrimaryFunc,
+			wantRecoverySkipCode: RecoverySkipGroupReplication,
 		}, {
 			name:       "IncapacitatedPrimary",
 			ersEnabled: true,
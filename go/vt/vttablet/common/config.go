@@ -20,10 +20,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+
+	"vitess.io/vitess/go/mysql/replication"
 )
 
 /*
@@ -49,8 +53,14 @@ type VReplicationConfig struct {
 	HeartbeatUpdateInterval int
 	StoreCompressedGTID     bool
 	ParallelInsertWorkers   int
+	CopyRangeWorkers        int
 	TabletTypesStr          string
 	MaxRowJSONBytes         int64
+	ConflictPolicy          string
+	ConflictTimestampColumn string
+	// ThrottlerAppName is an additional throttler app name for the workflow, which lets
+	// operators throttle a group of workflows without affecting the others.
+	ThrottlerAppName string
 
 	// Config parameters applicable to the source side (vstreamer)
 	// The coresponding Override fields are used to determine if the user has provided a value for the parameter so
@@ -61,6 +71,11 @@ type VReplicationConfig struct {
 	VStreamDynamicPacketSizeOverride       bool
 	VStreamBinlogRotationThreshold         int64
 	VStreamBinlogRotationThresholdOverride bool
+	// VStreamExcludedServerUUIDs lists the server UUIDs whose transactions are not streamed: the
+	// changes that MySQL replication brought back to the source with their original GTIDs, and
+	// must not be replayed. The changes applied by VReplication get the GTIDs of its target, so
+	// this cannot break the loop of two workflows replicating in opposite directions.
+	VStreamExcludedServerUUIDs []string
 
 	// Overrides is a map of user-provided configuration values that override the default configuration.
 	Overrides map[string]string
@@ -94,8 +109,10 @@ func GetVReplicationConfigDefaults(useCached bool) *VReplicationConfig {
 		HeartbeatUpdateInterval: vreplicationHeartbeatUpdateInterval,
 		StoreCompressedGTID:     vreplicationStoreCompressedGTID,
 		ParallelInsertWorkers:   vreplicationParallelInsertWorkers,
+		CopyRangeWorkers:        vreplicationCopyRangeWorkers,
 		TabletTypesStr:          vreplicationTabletTypesStr,
 		MaxRowJSONBytes:         vreplicationMaxRowJSONBytes,
+		ConflictPolicy:          ConflictPolicyNone,
 
 		VStreamPacketSizeOverride:              false,
 		VStreamPacketSize:                      VStreamerDefaultPacketSize,
@@ -220,6 +237,13 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 			} else {
 				c.ParallelInsertWorkers = value
 			}
+		case "vreplication-copy-range-workers":
+			value, err := strconv.Atoi(v)
+			if err != nil {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.CopyRangeWorkers = value
+			}
 		case "vstream-packet-size", "vstream_packet_size":
 			value, err := strconv.Atoi(v)
 			if err != nil {
@@ -244,6 +268,23 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 				c.VStreamBinlogRotationThresholdOverride = true
 				c.VStreamBinlogRotationThreshold = value
 			}
+		case "vstream-exclude-server-uuids":
+			// The UUIDs are separated by spaces, since --config-overrides splits
+			// its values on commas, or by commas.
+			var uuids []string
+			for _, uuid := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				sid, err := replication.ParseSID(uuid)
+				if err != nil {
+					uuids = nil
+					break
+				}
+				uuids = append(uuids, sid.String())
+			}
+			if len(uuids) == 0 {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.VStreamExcludedServerUUIDs = uuids
+			}
 		case "max-row-json-bytes":
 			value, err := strconv.ParseInt(v, 10, 64)
 			if err != nil || value < 0 {
@@ -251,6 +292,21 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 			} else {
 				c.MaxRowJSONBytes = value
 			}
+		case "vreplication-conflict-policy":
+			if !slices.Contains(ConflictPolicies, v) {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.ConflictPolicy = v
+			}
+		case "vreplication-conflict-timestamp-column":
+			c.ConflictTimestampColumn = v
+		case "vreplication-throttler-app-name":
+			// The throttler splits app names on ':' and app lists on ','.
+			if strings.ContainsAny(v, ":, \t") {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.ThrottlerAppName = v
+			}
 		default:
 			errors = append(errors, "unknown vreplication config flag: "+k)
 		}
@@ -280,12 +336,17 @@ func (c VReplicationConfig) Map() map[string]string {
 		"vreplication-heartbeat-update-interval":  strconv.Itoa(c.HeartbeatUpdateInterval),
 		"vreplication-store-compressed-gtid":      strconv.FormatBool(c.StoreCompressedGTID),
 		"vreplication-parallel-insert-workers":    strconv.Itoa(c.ParallelInsertWorkers),
+		"vreplication-copy-range-workers":         strconv.Itoa(c.CopyRangeWorkers),
 		"vstream-packet-size":                     strconv.Itoa(c.VStreamPacketSize),
 		"vstream_packet_size":                     strconv.Itoa(c.VStreamPacketSize),
 		"vstream-dynamic-packet-size":             strconv.FormatBool(c.VStreamDynamicPacketSize),
 		"vstream_dynamic_packet_size":             strconv.FormatBool(c.VStreamDynamicPacketSize),
 		"vstream_binlog_rotation_threshold":       strconv.FormatInt(c.VStreamBinlogRotationThreshold, 10),
+		"vstream-exclude-server-uuids":            strings.Join(c.VStreamExcludedServerUUIDs, " "),
 		"max-row-json-bytes":                      strconv.FormatInt(c.MaxRowJSONBytes, 10),
+		"vreplication-conflict-policy":            c.ConflictPolicy,
+		"vreplication-conflict-timestamp-column":  c.ConflictTimestampColumn,
+		"vreplication-throttler-app-name":         c.ThrottlerAppName,
 	}
 }
 
@@ -51,11 +51,16 @@ func TestNewVReplicationConfig(t *testing.T) {
 				"vreplication-heartbeat-update-interval":  "2",
 				"vreplication-store-compressed-gtid":      "true",
 				"vreplication-parallel-insert-workers":    "4",
+				"vreplication-copy-range-workers":         "8",
 				"vstream-packet-size":                     "1024",
 				"vstream_packet_size":                     "1024",
 				"vstream-dynamic-packet-size":             "false",
 				"vstream_dynamic_packet_size":             "false",
 				"vstream_binlog_rotation_threshold":       "2048",
+				"vreplication-conflict-policy":            "latest-timestamp",
+				"vreplication-conflict-timestamp-column":  "updated_at",
+				"vreplication-throttler-app-name":         "nightly-reshard",
+				"vstream-exclude-server-uuids":            "00000000-0000-0000-0000-000000000001 3E11FA47-71CA-11E1-9E33-C80AA9429562",
 			},
 			wantErr: 0,
 			want: &VReplicationConfig{
@@ -71,6 +76,7 @@ func TestNewVReplicationConfig(t *testing.T) {
 				HeartbeatUpdateInterval:                2,
 				StoreCompressedGTID:                    true,
 				ParallelInsertWorkers:                  4,
+				CopyRangeWorkers:                       8,
 				VStreamPacketSize:                      1024,
 				VStreamDynamicPacketSize:               false,
 				VStreamBinlogRotationThreshold:         2048,
@@ -78,6 +84,10 @@ func TestNewVReplicationConfig(t *testing.T) {
 				VStreamPacketSizeOverride:              true,
 				VStreamDynamicPacketSizeOverride:       true,
 				VStreamBinlogRotationThresholdOverride: true,
+				ConflictPolicy:                         ConflictPolicyLatestTimestamp,
+				ConflictTimestampColumn:                "updated_at",
+				ThrottlerAppName:                       "nightly-reshard",
+				VStreamExcludedServerUUIDs:             []string{"00000000-0000-0000-0000-000000000001", "3e11fa47-71ca-11e1-9e33-c80aa9429562"},
 			},
 		},
 		{
@@ -95,13 +105,17 @@ func TestNewVReplicationConfig(t *testing.T) {
 				"vreplication-heartbeat-update-interval":  "invalid",
 				"vreplication-store-compressed-gtid":      "nottrue",
 				"vreplication-parallel-insert-workers":    "invalid",
+				"vreplication-copy-range-workers":         "invalid",
 				"vstream-packet-size":                     "invalid",
 				"vstream_packet_size":                     "invalid",
 				"vstream-dynamic-packet-size":             "waar",
 				"vstream_dynamic_packet_size":             "waar",
 				"vstream_binlog_rotation_threshold":       "invalid",
+				"vreplication-conflict-policy":            "invalid",
+				"vreplication-throttler-app-name":         "online-ddl:vreplication",
+				"vstream-exclude-server-uuids":            "00000000-0000-0000-0000-000000000001,not-a-uuid",
 			},
-			wantErr: 17,
+			wantErr: 21,
 		},
 		{
 			name: "Partial values",
@@ -125,11 +139,13 @@ func TestNewVReplicationConfig(t *testing.T) {
 				HeartbeatUpdateInterval:          DefaultVReplicationConfig.HeartbeatUpdateInterval,
 				StoreCompressedGTID:              !DefaultVReplicationConfig.StoreCompressedGTID,
 				ParallelInsertWorkers:            DefaultVReplicationConfig.ParallelInsertWorkers,
+				CopyRangeWorkers:                 DefaultVReplicationConfig.CopyRangeWorkers,
 				VStreamPacketSize:                DefaultVReplicationConfig.VStreamPacketSize,
 				VStreamDynamicPacketSize:         !DefaultVReplicationConfig.VStreamDynamicPacketSize,
 				VStreamBinlogRotationThreshold:   DefaultVReplicationConfig.VStreamBinlogRotationThreshold,
 				VStreamDynamicPacketSizeOverride: true,
 				TabletTypesStr:                   DefaultVReplicationConfig.TabletTypesStr,
+				ConflictPolicy:                   DefaultVReplicationConfig.ConflictPolicy,
 			},
 		},
 	}
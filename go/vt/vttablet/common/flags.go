@@ -35,12 +35,32 @@ const (
 	VReplicationExperimentalFlagVPlayerBatching           = int64(4)
 )
 
-type (
-	nonNegativeInt64Flag struct {
-		value *int64
-	}
+// Conflict policies for VReplication workflows that apply rows to tables which
+// may also be written to locally. A conflict is a source row whose primary key
+// already exists in the target table when it is inserted, or, with
+// ConflictPolicyLatestTimestamp, a source update of a row that was updated
+// more recently in the target.
+const (
+	// ConflictPolicyNone fails the workflow on conflicts. This is the default.
+	ConflictPolicyNone = "none"
+	// ConflictPolicySourceWins overwrites the target row with the source row.
+	ConflictPolicySourceWins = "source-wins"
+	// ConflictPolicyTargetWins keeps the target row.
+	ConflictPolicyTargetWins = "target-wins"
+	// ConflictPolicyLatestTimestamp keeps the row with the latest value in the
+	// column set by the vreplication-conflict-timestamp-column workflow config.
+	// The source row wins ties. Conflict policies are set per workflow, and
+	// are not supported by MoveTables, Reshard and OnlineDDL workflows.
+	ConflictPolicyLatestTimestamp = "latest-timestamp"
 )
 
+// ConflictPolicies are the supported conflict policies.
+var ConflictPolicies = []string{ConflictPolicyNone, ConflictPolicySourceWins, ConflictPolicyTargetWins, ConflictPolicyLatestTimestamp}
+
+type nonNegativeInt64Flag struct {
+	value *int64
+}
+
 func (f nonNegativeInt64Flag) Set(v string) error {
 	value, err := strconv.ParseInt(v, 10, 64)
 	if err != nil {
@@ -83,14 +103,23 @@ var (
 
 	vreplicationStoreCompressedGTID   = false
 	vreplicationParallelInsertWorkers = 1
+	vreplicationCopyRangeWorkers      = 1
 	vreplicationMaxRowJSONBytes       = int64(0)
 
+	vreplicationConflictsRetention = 7 * 24 * time.Hour
+
 	// VStreamerBinlogRotationThreshold is the threshold, above which we rotate binlogs, before taking a GTID snapshot
 	VStreamerBinlogRotationThreshold = int64(64 * 1024 * 1024) // 64MiB
 	VStreamerDefaultPacketSize       = 250000
 	VStreamerUseDynamicPacketSize    = true
 )
 
+// GetVReplicationConflictsRetention returns how long resolved conflicts are
+// kept in the vreplication_conflicts table. 0 means forever.
+func GetVReplicationConflictsRetention() time.Duration {
+	return vreplicationConflictsRetention
+}
+
 func GetVReplicationNetReadTimeout() int {
 	return vreplicationNetReadTimeout
 }
@@ -128,11 +157,14 @@ func registerFlags(fs *pflag.FlagSet) {
 	utils.SetFlagBoolVar(fs, &vreplicationStoreCompressedGTID, "vreplication-store-compressed-gtid", vreplicationStoreCompressedGTID, "Store compressed gtids in the pos column of the sidecar database's vreplication table")
 
 	fs.IntVar(&vreplicationParallelInsertWorkers, "vreplication-parallel-insert-workers", vreplicationParallelInsertWorkers, "Number of parallel insertion workers to use during copy phase. Set <= 1 to disable parallelism, or > 1 to enable concurrent insertion during copy phase.")
+	fs.IntVar(&vreplicationCopyRangeWorkers, "vreplication-copy-range-workers", vreplicationCopyRangeWorkers, "Number of primary key ranges to split the copy of a table into, each streamed concurrently from the source using a single snapshot. Set <= 1 to copy each table with a single stream. Only tables with an integer first primary key column are split.")
 
 	fs.Uint64Var(&mysql.ZstdInMemoryDecompressorMaxSize, "binlog-in-memory-decompressor-max-size", mysql.ZstdInMemoryDecompressorMaxSize, "This value sets the uncompressed transaction payload size at which we switch from in-memory buffer based decompression to the slower streaming mode.")
 
 	fs.Var(nonNegativeInt64Flag{value: &vreplicationMaxRowJSONBytes}, "vreplication-max-row-json-bytes", "Maximum combined byte size of JSON columns in a single row during VReplication copy and replay phases. 0 means unlimited.")
 
+	fs.DurationVar(&vreplicationConflictsRetention, "vreplication-conflicts-retention", vreplicationConflictsRetention, "How long the conflicts resolved by the conflict policies of VReplication workflows are kept in the sidecar database's vreplication_conflicts table. If 0, the conflicts are never purged.")
+
 	fs.Bool("vreplication-enable-http-log", false, "(DEPRECATED) This flag is a no-op: the /debug/vrlog HTTP endpoint it enabled has been removed.")
 	_ = fs.MarkDeprecated("vreplication-enable-http-log", "this flag is a no-op and will be removed in v26")
 }
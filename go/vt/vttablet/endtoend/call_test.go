@@ -86,8 +86,9 @@ func TestCallProcedure(t *testing.T) {
 	tcases := []testcases{{
 		query: "call proc_dml()",
 	}, {
-		query:   "call proc_select1()",
-		wantErr: true,
+		// The resultset is returned, the trailing OK packet only tells the
+		// state of the connection after the call.
+		query: "call proc_select1()",
 	}, {
 		query:   "call proc_select4()",
 		wantErr: true,
@@ -101,7 +102,7 @@ func TestCallProcedure(t *testing.T) {
 		t.Run(tc.query, func(t *testing.T) {
 			_, err := client.Execute(tc.query, nil)
 			if tc.wantErr {
-				require.EqualError(t, err, "Multi-Resultset not supported in stored procedure (CallerID: dev)")
+				require.EqualError(t, err, "Multi-Resultset not supported in stored procedure, stream the call to return all its result sets (CallerID: dev)")
 				return
 			}
 			require.NoError(t, err)
@@ -111,38 +112,44 @@ func TestCallProcedure(t *testing.T) {
 
 func TestCallProcedureStreaming(t *testing.T) {
 	client := framework.NewClient()
+	want, err := client.Execute("select intval from vitess_test", nil)
+	require.NoError(t, err)
+
 	type testcases struct {
-		query   string
-		wantErr string
+		query       string
+		wantResults int
 	}
 	tcases := []testcases{{
-		// A single-resultset procedure legitimately benefits from streaming and
-		// is accepted, even though the buffered Execute path rejects it because
-		// of the trailing OK packet that follows the resultset.
-		query: "call proc_select1()",
+		query:       "call proc_select1()",
+		wantResults: 1,
 	}, {
-		// A multi-resultset procedure must be rejected, not silently truncated
-		// to its first resultset.
-		query:   "call proc_select4()",
-		wantErr: "Multi-Resultset not supported in stored procedure (CallerID: dev)",
+		// Every result set is streamed, each starting with a new result.
+		query:       "call proc_select4()",
+		wantResults: 4,
 	}, {
 		// A procedure that returns no resultset and concludes its own transaction
 		// streams fine.
 		query: "call proc_dml()",
 	}, {
-		// Make sure the streaming connection isn't left dirty by the rejected
+		// Make sure the streaming connection isn't left dirty by the
 		// multi-resultset procedure above.
 		query: "call proc_dml()",
 	}}
 
 	for _, tc := range tcases {
 		t.Run(tc.query, func(t *testing.T) {
-			_, err := client.StreamExecute(tc.query, nil)
-			if tc.wantErr != "" {
-				require.EqualError(t, err, tc.wantErr)
+			results, err := client.StreamExecuteResults(tc.query, nil)
+			require.NoError(t, err)
+			if tc.wantResults == 0 {
+				require.Len(t, results, 1)
+				assert.Empty(t, results[0].Fields)
 				return
 			}
-			require.NoError(t, err)
+			require.Len(t, results, tc.wantResults)
+			for _, result := range results {
+				assert.Equal(t, want.Fields[0].Name, result.Fields[0].Name)
+				assert.Equal(t, want.Rows, result.Rows)
+			}
 		})
 	}
 }
@@ -163,7 +170,7 @@ func TestCallProcedureStreamingMultiResultsetTxLeakClosesConnection(t *testing.T
 	beforeConnID := qr.Rows[0][0].ToString()
 
 	_, err = client.StreamExecute("call proc_select2_tx_insert()", nil)
-	require.EqualError(t, err, "Multi-Resultset not supported in stored procedure (CallerID: dev)")
+	require.EqualError(t, err, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed (CallerID: dev)")
 
 	qr, err = client.StreamExecute("select connection_id()", nil)
 	require.NoError(t, err)
@@ -189,8 +196,9 @@ func TestCallProcedureStreamingMultiResultsetCleanConnectionReused(t *testing.T)
 	require.Len(t, qr.Rows, 1)
 	beforeConnID := qr.Rows[0][0].ToString()
 
-	_, err = client.StreamExecute("call proc_select4()", nil)
-	require.EqualError(t, err, "Multi-Resultset not supported in stored procedure (CallerID: dev)")
+	results, err := client.StreamExecuteResults("call proc_select4()", nil)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
 
 	qr, err = client.StreamExecute("select connection_id()", nil)
 	require.NoError(t, err)
@@ -264,6 +264,32 @@ func (client *QueryClient) StreamExecuteWithOptions(query string, bindvars map[s
 	return result, nil
 }
 
+// StreamExecuteResults executes a query & returns each of its result sets,
+// like those of a stored procedure call that returns several.
+func (client *QueryClient) StreamExecuteResults(query string, bindvars map[string]*querypb.BindVariable) ([]*sqltypes.Result, error) {
+	var results []*sqltypes.Result
+	err := client.server.StreamExecute(client.ctx,
+		nil,
+		client.target,
+		query,
+		bindvars,
+		client.transactionID,
+		client.reservedID,
+		&querypb.ExecuteOptions{IncludedFields: querypb.ExecuteOptions_ALL},
+		func(res *sqltypes.Result) error {
+			if len(results) == 0 || res.NewResult {
+				results = append(results, &sqltypes.Result{Fields: res.Fields})
+			}
+			result := results[len(results)-1]
+			result.Rows = append(result.Rows, res.Rows...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // StreamBeginExecuteWithOptions starts a tx and executes a query using 'options', returning the results .
 func (client *QueryClient) StreamBeginExecuteWithOptions(query string, preQueries []string, bindvars map[string]*querypb.BindVariable, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
 	result := &sqltypes.Result{}
@@ -304,6 +330,20 @@ func (client *QueryClient) MessageStream(name string, callback func(*sqltypes.Re
 
 // MessageAck acks messages
 func (client *QueryClient) MessageAck(name string, ids []string) (int64, error) {
+	return client.server.MessageAck(client.ctx, client.target, name, messageIDs(ids))
+}
+
+// MessageAckBatch acks messages in chunks.
+func (client *QueryClient) MessageAckBatch(name string, ids []string) (int64, error) {
+	return client.server.MessageAckBatch(client.ctx, client.target, name, messageIDs(ids))
+}
+
+// MessagePostpone postpones messages in chunks.
+func (client *QueryClient) MessagePostpone(name string, ids []string) (int64, error) {
+	return client.server.MessagePostpone(client.ctx, client.target, name, messageIDs(ids))
+}
+
+func messageIDs(ids []string) []*querypb.Value {
 	bids := make([]*querypb.Value, 0, len(ids))
 	for _, id := range ids {
 		bids = append(bids, &querypb.Value{
@@ -311,7 +351,7 @@ func (client *QueryClient) MessageAck(name string, ids []string) (int64, error)
 			Value: []byte(id),
 		})
 	}
-	return client.server.MessageAck(client.ctx, client.target, name, bids)
+	return bids
 }
 
 // ReserveExecute performs a ReserveExecute.
@@ -131,6 +131,11 @@ func (client *FakeTabletManagerClient) GetSchema(ctx context.Context, tablet *to
 	return client.tmc.GetSchema(ctx, tablet, request)
 }
 
+// GetSchemaSnapshot is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetSchemaSnapshot(ctx context.Context, tablet *topodatapb.Tablet, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	return &tabletmanagerdatapb.GetSchemaSnapshotResponse{Full: true}, nil
+}
+
 // GetPermissions is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
 	return &tabletmanagerdatapb.Permissions{}, nil
@@ -313,6 +318,11 @@ func (client *FakeTabletManagerClient) ResetReplicationParameters(ctx context.Co
 	return nil
 }
 
+// PurgeBinaryLogs is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) PurgeBinaryLogs(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	return &tabletmanagerdatapb.PurgeBinaryLogsResponse{}, nil
+}
+
 // ReplicaWasRestarted is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) ReplicaWasRestarted(ctx context.Context, tablet *topodatapb.Tablet, parent *topodatapb.TabletAlias) error {
 	return nil
@@ -422,6 +432,11 @@ func (client *FakeTabletManagerClient) RestoreFromBackup(ctx context.Context, ta
 	return &eofEventStream{}, nil
 }
 
+// CloneFromTablet is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) CloneFromTablet(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CloneFromTabletRequest) (logutil.EventStream, error) {
+	return &eofEventStream{}, nil
+}
+
 // Throttler related methods
 
 func (client *FakeTabletManagerClient) CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
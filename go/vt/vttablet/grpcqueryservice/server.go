@@ -331,6 +331,42 @@ func (q *query) MessageAck(ctx context.Context, request *querypb.MessageAckReque
 	}, nil
 }
 
+// MessageAckBatch is part of the queryservice.QueryServer interface
+func (q *query) MessageAckBatch(ctx context.Context, request *querypb.MessageAckBatchRequest) (response *querypb.MessageAckBatchResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	count, err := q.server.MessageAckBatch(ctx, request.Target, request.Name, request.Ids)
+	if err != nil {
+		return nil, vterrors.ToGRPC(err)
+	}
+	return &querypb.MessageAckBatchResponse{
+		Result: &querypb.QueryResult{
+			RowsAffected: uint64(count),
+		},
+	}, nil
+}
+
+// MessagePostpone is part of the queryservice.QueryServer interface
+func (q *query) MessagePostpone(ctx context.Context, request *querypb.MessagePostponeRequest) (response *querypb.MessagePostponeResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	count, err := q.server.MessagePostpone(ctx, request.Target, request.Name, request.Ids)
+	if err != nil {
+		return nil, vterrors.ToGRPC(err)
+	}
+	return &querypb.MessagePostponeResponse{
+		Result: &querypb.QueryResult{
+			RowsAffected: uint64(count),
+		},
+	}, nil
+}
+
 // StreamHealth is part of the queryservice.QueryServer interface
 func (q *query) StreamHealth(request *querypb.StreamHealthRequest, stream queryservicepb.Query_StreamHealthServer) (err error) {
 	defer q.server.HandlePanic(&err)
@@ -185,7 +185,8 @@ func (conn *gRPCQueryClient) StreamExecute(ctx context.Context, _ queryservice.S
 		if err != nil {
 			return tabletconn.ErrorFromGRPC(err)
 		}
-		if fields == nil {
+		// Each result set of a query that returns several starts with its own fields.
+		if fields == nil || ser.Result.NewResult {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -561,7 +562,7 @@ func (conn *gRPCQueryClient) BeginStreamExecute(ctx context.Context, _ queryserv
 			return state, nil
 		}
 
-		if fields == nil {
+		if fields == nil || ser.Result.NewResult {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -640,6 +641,48 @@ func (conn *gRPCQueryClient) MessageAck(ctx context.Context, target *querypb.Tar
 	return int64(reply.Result.RowsAffected), nil
 }
 
+// MessageAckBatch acks a large number of messages.
+func (conn *gRPCQueryClient) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return 0, tabletconn.ConnClosed
+	}
+	req := &querypb.MessageAckBatchRequest{
+		Target:            target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Name:              name,
+		Ids:               ids,
+	}
+	reply, err := conn.c.MessageAckBatch(ctx, req)
+	if err != nil {
+		return 0, tabletconn.ErrorFromGRPC(err)
+	}
+	return int64(reply.Result.RowsAffected), nil
+}
+
+// MessagePostpone postpones a large number of messages.
+func (conn *gRPCQueryClient) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (int64, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return 0, tabletconn.ConnClosed
+	}
+	req := &querypb.MessagePostponeRequest{
+		Target:            target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Name:              name,
+		Ids:               ids,
+	}
+	reply, err := conn.c.MessagePostpone(ctx, req)
+	if err != nil {
+		return 0, tabletconn.ErrorFromGRPC(err)
+	}
+	return int64(reply.Result.RowsAffected), nil
+}
+
 // StreamHealth starts a streaming RPC for VTTablet health status updates.
 func (conn *gRPCQueryClient) StreamHealth(ctx context.Context, callback func(*querypb.StreamHealthResponse) error) error {
 	// Please see comments in StreamExecute to see how this works.
@@ -1014,7 +1057,7 @@ func (conn *gRPCQueryClient) ReserveBeginStreamExecute(ctx context.Context, _ qu
 			return state, nil
 		}
 
-		if fields == nil {
+		if fields == nil || ser.Result.NewResult {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -1122,7 +1165,7 @@ func (conn *gRPCQueryClient) ReserveStreamExecute(ctx context.Context, _ queryse
 			return state, nil
 		}
 
-		if fields == nil {
+		if fields == nil || ser.Result.NewResult {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -566,6 +566,22 @@ func (client *Client) GetSchema(ctx context.Context, tablet *topodatapb.Tablet,
 	return response.SchemaDefinition, nil
 }
 
+// GetSchemaSnapshot is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetSchemaSnapshot(ctx context.Context, tablet *topodatapb.Tablet, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	response, err := c.GetSchemaSnapshot(ctx, &tabletmanagerdatapb.GetSchemaSnapshotRequest{
+		SinceVersion: sinceVersion,
+	})
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return response, nil
+}
+
 // GetPermissions is part of the tmclient.TabletManagerClient interface.
 func (client *Client) GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error) {
 	c, closer, err := client.dialer.dial(ctx, tablet)
@@ -1475,6 +1491,20 @@ func (client *Client) ResetReplicationParameters(ctx context.Context, tablet *to
 	return vterrors.FromGRPC(err)
 }
 
+// PurgeBinaryLogs is part of the tmclient.TabletManagerClient interface.
+func (client *Client) PurgeBinaryLogs(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	response, err := c.PurgeBinaryLogs(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return response, nil
+}
+
 // SetReplicationSource is part of the tmclient.TabletManagerClient interface.
 func (client *Client) SetReplicationSource(ctx context.Context, tablet *topodatapb.Tablet, parent *topodatapb.TabletAlias, timeCreatedNS int64, waitPosition string, forceStartReplication bool, semiSync bool, heartbeatInterval float64) error {
 	c, closer, err := client.dialer.dial(ctx, tablet)
@@ -1661,6 +1691,38 @@ func (client *Client) RestoreFromBackup(ctx context.Context, tablet *topodatapb.
 	}, nil
 }
 
+type cloneFromTabletStreamAdapter struct {
+	stream tabletmanagerservicepb.TabletManager_CloneFromTabletClient
+	closer io.Closer
+}
+
+func (e *cloneFromTabletStreamAdapter) Recv() (*logutilpb.Event, error) {
+	br, err := e.stream.Recv()
+	if err != nil {
+		e.closer.Close()
+		return nil, vterrors.FromGRPC(err)
+	}
+	return br.Event, nil
+}
+
+// CloneFromTablet is part of the tmclient.TabletManagerClient interface.
+func (client *Client) CloneFromTablet(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CloneFromTabletRequest) (logutil.EventStream, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.CloneFromTablet(ctx, req)
+	if err != nil {
+		closer.Close()
+		return nil, vterrors.FromGRPC(err)
+	}
+	return &cloneFromTabletStreamAdapter{
+		stream: stream,
+		closer: closer,
+	}, nil
+}
+
 // Close is part of the tmclient.TabletManagerClient interface.
 func (client *Client) Close() {
 	client.dialer.Close()
@@ -92,6 +92,12 @@ func (s *server) GetSchema(ctx context.Context, request *tabletmanagerdatapb.Get
 	return response, err
 }
 
+func (s *server) GetSchemaSnapshot(ctx context.Context, request *tabletmanagerdatapb.GetSchemaSnapshotRequest) (response *tabletmanagerdatapb.GetSchemaSnapshotResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetSchemaSnapshot", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.GetSchemaSnapshot(ctx, request)
+}
+
 func (s *server) GetPermissions(ctx context.Context, request *tabletmanagerdatapb.GetPermissionsRequest) (response *tabletmanagerdatapb.GetPermissionsResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "GetPermissions", request, response, false /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
@@ -641,6 +647,12 @@ func (s *server) ResetReplicationParameters(ctx context.Context, request *tablet
 	return response, s.tm.ResetReplicationParameters(ctx)
 }
 
+func (s *server) PurgeBinaryLogs(ctx context.Context, request *tabletmanagerdatapb.PurgeBinaryLogsRequest) (response *tabletmanagerdatapb.PurgeBinaryLogsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "PurgeBinaryLogs", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.PurgeBinaryLogs(ctx, request)
+}
+
 func (s *server) SetReplicationSource(ctx context.Context, request *tabletmanagerdatapb.SetReplicationSourceRequest) (response *tabletmanagerdatapb.SetReplicationSourceResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "SetReplicationSource", request, response, true /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
@@ -714,6 +726,24 @@ func (s *server) RestoreFromBackup(request *tabletmanagerdatapb.RestoreFromBacku
 	return s.tm.RestoreFromBackup(ctx, logger, request)
 }
 
+func (s *server) CloneFromTablet(request *tabletmanagerdatapb.CloneFromTabletRequest, stream tabletmanagerservicepb.TabletManager_CloneFromTabletServer) (err error) {
+	ctx := stream.Context()
+	defer s.tm.HandleRPCPanic(ctx, "CloneFromTablet", request, nil, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+
+	// create a logger, send the result back to the caller
+	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
+		// If the client disconnects, we will just fail
+		// to send the log events, but won't interrupt
+		// the clone.
+		stream.Send(&tabletmanagerdatapb.CloneFromTabletResponse{
+			Event: e,
+		})
+	})
+
+	return s.tm.CloneFromTablet(ctx, logger, request)
+}
+
 func (s *server) CheckThrottler(ctx context.Context, request *tabletmanagerdatapb.CheckThrottlerRequest) (response *tabletmanagerdatapb.CheckThrottlerResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "CheckThrottler", request, response, false /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
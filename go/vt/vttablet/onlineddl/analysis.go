@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 
 	"vitess.io/vitess/go/mysql/capabilities"
+	"vitess.io/vitess/go/mysql/sqlerror"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/schemadiff"
@@ -82,6 +83,21 @@ func analyzeInstantDDL(alterTable *sqlparser.AlterTable, createTable *sqlparser.
 	return op, nil
 }
 
+// isInstantDDLRejection returns true when the error is MySQL refusing to run an ALTER with ALGORITHM=INSTANT,
+// e.g. because the table has reached its maximum number of row versions. Such an ALTER did not change anything,
+// and can run again with another algorithm.
+func isInstantDDLRejection(err error) bool {
+	sqlErr, isSQLErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+	if !isSQLErr || sqlErr == nil {
+		return false
+	}
+	switch sqlErr.Num {
+	case sqlerror.ERAlterOperationNotSupported, sqlerror.ERAlterOperationNotSupportedReason, sqlerror.ERInnodbMaxRowVersion:
+		return true
+	}
+	return false
+}
+
 // analyzeSpecialAlterPlan checks if the given ALTER onlineDDL, and for the current state of affected table,
 // can be executed in a special way. If so, it returns with a "special plan"
 func (e *Executor) analyzeSpecialAlterPlan(ctx context.Context, onlineDDL *schema.OnlineDDL, capableOf capabilities.CapableOf) (*SpecialAlterPlan, error) {
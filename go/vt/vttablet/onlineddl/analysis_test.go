@@ -21,13 +21,16 @@ Functionality of this Executor is tested in go/test/endtoend/onlineddl/...
 package onlineddl
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
 )
 
 func TestAnalyzeInstantDDL(t *testing.T) {
@@ -238,3 +241,22 @@ func TestAnalyzeInstantDDL(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInstantDDLRejection(t *testing.T) {
+	tt := []struct {
+		err      error
+		rejected bool
+	}{
+		{err: sqlerror.NewSQLError(sqlerror.ERAlterOperationNotSupportedReason, sqlerror.SSUnknownSQLState, "ALGORITHM=INSTANT is not supported. Reason: Need to rebuild the table to change column type. Try ALGORITHM=COPY/INPLACE."), rejected: true},
+		{err: sqlerror.NewSQLError(sqlerror.ERAlterOperationNotSupported, sqlerror.SSUnknownSQLState, "ALGORITHM=INSTANT is not supported for this operation. Try ALGORITHM=COPY/INPLACE."), rejected: true},
+		{err: sqlerror.NewSQLError(sqlerror.ERInnodbMaxRowVersion, sqlerror.SSUnknownSQLState, "Maximum row versions reached for table test/t1. No more columns can be added or dropped instantly. Please use COPY/INPLACE."), rejected: true},
+		{err: vterrors.Wrap(sqlerror.NewSQLError(sqlerror.ERInnodbMaxRowVersion, sqlerror.SSUnknownSQLState, "Maximum row versions reached"), "wrapped"), rejected: true},
+		{err: sqlerror.NewSQLError(sqlerror.ERLockWaitTimeout, sqlerror.SSUnknownSQLState, "Lock wait timeout exceeded"), rejected: false},
+		{err: errors.New("connection refused"), rejected: false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tc.rejected, isInstantDDLRejection(tc.err))
+		})
+	}
+}
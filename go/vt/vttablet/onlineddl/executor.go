@@ -2735,11 +2735,25 @@ func (e *Executor) executeSpecialAlterDDLActionMigrationIfApplicable(ctx context
 
 	switch specialPlan.operation {
 	case instantDDLSpecialOperation:
+		originalSQL := onlineDDL.SQL
 		schemadiff.AddInstantAlgorithm(specialPlan.alterTable)
 		onlineDDL.SQL = sqlparser.CanonicalString(specialPlan.alterTable)
 		if err := e.executeSpecialAlterDirectDDLActionMigration(ctx, onlineDDL); err != nil {
-			return false, err
+			if !isInstantDDLRejection(err) {
+				return false, err
+			}
+			// Our analysis found the ALTER to be eligible for INSTANT DDL, but MySQL disagrees, e.g. because
+			// the table has run out of row versions. The ALTER did not change anything, so we fall back to
+			// running it with the migration's strategy.
+			onlineDDL.SQL = originalSQL
+			specialPlan.SetDetail("fallback", string(onlineDDL.Strategy)).SetDetail("instant-ddl-error", err.Error())
+			if err := e.updateMigrationSpecialPlan(ctx, onlineDDL.UUID, specialPlan.String()); err != nil {
+				return false, err
+			}
+			_ = e.updateMigrationStage(ctx, onlineDDL.UUID, "ALGORITHM=INSTANT rejected, falling back to %s strategy: %v", onlineDDL.Strategy, err)
+			return false, nil
 		}
+		_ = e.updateMigrationStage(ctx, onlineDDL.UUID, "executed with ALGORITHM=INSTANT")
 	case rangePartitionSpecialOperation:
 		if err := e.executeSpecialAlterDirectDDLActionMigration(ctx, onlineDDL); err != nil {
 			return false, err
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queryservice
+
+import (
+	"slices"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The optional features that a tablet advertises in its health stream.
+// Clients must check that a tablet supports a feature before relying on it,
+// because older tablets in a mixed-version deployment may not.
+const (
+	// CapabilityMessageBatch is the support of the MessageAckBatch and
+	// MessagePostpone RPCs.
+	CapabilityMessageBatch = "message_batch"
+
+	// CapabilityCutOverSignal is the signaling of imminent Online DDL
+	// cut-overs in the cutover_tables of the health stream.
+	CapabilityCutOverSignal = "cutover_signal"
+
+	// CapabilityIdempotencyKey is the support of the idempotency_key of the
+	// ExecuteOptions, which deduplicates retried autocommit DMLs.
+	CapabilityIdempotencyKey = "idempotency_key"
+
+	// CapabilityBinaryJSON is the support of the json_encoding of the
+	// ExecuteOptions, which returns the values of JSON columns in MySQL's
+	// binary JSON format.
+	CapabilityBinaryJSON = "binary_json"
+)
+
+// CapabilitiesVersion is the version of the capabilities of this tablet. It
+// must be incremented every time a feature is added.
+const CapabilitiesVersion = 3
+
+// capabilities are the features that this version of the tablet supports.
+var capabilities = []string{
+	CapabilityMessageBatch,
+	CapabilityCutOverSignal,
+	CapabilityIdempotencyKey,
+	CapabilityBinaryJSON,
+}
+
+// TabletCapabilities returns the capabilities of this version of the tablet.
+func TabletCapabilities() *querypb.TabletCapabilities {
+	return &querypb.TabletCapabilities{
+		Version:  CapabilitiesVersion,
+		Features: slices.Clone(capabilities),
+	}
+}
+
+// HasCapability returns whether the capabilities include the feature. Tablets
+// that predate capabilities don't send any, and support none of the features.
+func HasCapability(caps *querypb.TabletCapabilities, feature string) bool {
+	return slices.Contains(caps.GetFeatures(), feature)
+}
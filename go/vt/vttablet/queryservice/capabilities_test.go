@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queryservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasCapability(t *testing.T) {
+	caps := TabletCapabilities()
+	assert.EqualValues(t, CapabilitiesVersion, caps.Version)
+	assert.True(t, HasCapability(caps, CapabilityMessageBatch))
+	assert.True(t, HasCapability(caps, CapabilityCutOverSignal))
+	assert.True(t, HasCapability(caps, CapabilityIdempotencyKey))
+	assert.True(t, HasCapability(caps, CapabilityBinaryJSON))
+	assert.False(t, HasCapability(caps, "unknown_feature"))
+
+	// tablets that predate capabilities support none of the features
+	assert.False(t, HasCapability(nil, CapabilityMessageBatch))
+
+	// the returned capabilities can be modified by the caller
+	caps.Features = caps.Features[:0]
+	assert.True(t, HasCapability(TabletCapabilities(), CapabilityMessageBatch))
+}
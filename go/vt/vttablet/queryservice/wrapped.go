@@ -309,6 +309,26 @@ func (ws *wrappedService) MessageAck(ctx context.Context, target *querypb.Target
 	return count, err
 }
 
+func (ws *wrappedService) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	opts := WrapOpts{InTransaction: false}
+	err = ws.wrapper(ctx, target, ws.impl, "MessageAckBatch", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
+		var innerErr error
+		count, innerErr = conn.MessageAckBatch(ctx, target, name, ids)
+		return canRetry(ctx, innerErr), innerErr
+	})
+	return count, err
+}
+
+func (ws *wrappedService) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	opts := WrapOpts{InTransaction: false}
+	err = ws.wrapper(ctx, target, ws.impl, "MessagePostpone", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
+		var innerErr error
+		count, innerErr = conn.MessagePostpone(ctx, target, name, ids)
+		return canRetry(ctx, innerErr), innerErr
+	})
+	return count, err
+}
+
 func (ws *wrappedService) VStream(ctx context.Context, request *binlogdatapb.VStreamRequest, send func([]*binlogdatapb.VEvent) error) error {
 	opts := WrapOpts{InTransaction: false}
 	return ws.wrapper(ctx, request.Target, ws.impl, "VStream", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
@@ -153,8 +153,9 @@ type SandboxConn struct {
 }
 
 type SchemaResult struct {
-	TablesAndViews map[string]string
-	UDFs           []*querypb.UDFInfo
+	TablesAndViews   map[string]string
+	UDFs             []*querypb.UDFInfo
+	ColumnStatistics []*querypb.ColumnStatistics
 }
 
 var _ queryservice.QueryService = (*SandboxConn)(nil) // compile-time interface check
@@ -595,6 +596,18 @@ func (sbc *SandboxConn) MessageAck(ctx context.Context, target *querypb.Target,
 	return int64(len(ids)), nil
 }
 
+// MessageAckBatch is part of the QueryService interface.
+func (sbc *SandboxConn) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	sbc.MessageIDs = ids
+	return int64(len(ids)), nil
+}
+
+// MessagePostpone is part of the QueryService interface.
+func (sbc *SandboxConn) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	sbc.MessageIDs = ids
+	return int64(len(ids)), nil
+}
+
 // SandboxSQRowCount is the default number of fake splits returned.
 var SandboxSQRowCount = int64(10)
 
@@ -825,8 +838,9 @@ func (sbc *SandboxConn) GetSchema(ctx context.Context, target *querypb.Target, t
 	sbc.getSchemaResult = sbc.getSchemaResult[1:]
 
 	response := &querypb.GetSchemaResponse{
-		TableDefinition: resp.TablesAndViews,
-		Udfs:            resp.UDFs,
+		TableDefinition:  resp.TablesAndViews,
+		Udfs:             resp.UDFs,
+		ColumnStatistics: resp.ColumnStatistics,
 	}
 	return callback(response)
 }
@@ -669,6 +669,16 @@ func (f *FakeQueryService) MessageAck(ctx context.Context, target *querypb.Targe
 	return 1, nil
 }
 
+// MessageAckBatch is part of the queryservice.QueryService interface
+func (f *FakeQueryService) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	return f.MessageAck(ctx, target, name, ids)
+}
+
+// MessagePostpone is part of the queryservice.QueryService interface
+func (f *FakeQueryService) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	return f.MessageAck(ctx, target, name, ids)
+}
+
 // TestStreamHealthStreamHealthResponse is a test stream health response.
 var TestStreamHealthStreamHealthResponse = &querypb.StreamHealthResponse{
 	Target: &querypb.Target{
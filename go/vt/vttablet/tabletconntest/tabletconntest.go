@@ -907,6 +907,54 @@ func testMessageAckPanics(t *testing.T, conn queryservice.QueryService, f *FakeQ
 	})
 }
 
+func testMessageAckBatch(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
+	t.Log("testMessageAckBatch")
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
+	count, err := conn.MessageAckBatch(ctx, TestTarget, MessageName, MessageIDs)
+	if err != nil {
+		t.Fatalf("MessageAckBatch failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Unexpected result from MessageAckBatch: got %v wanted 1", count)
+	}
+}
+
+func testMessageAckBatchError(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
+	t.Log("testMessageAckBatchError")
+	f.HasError = true
+	testErrorHelper(t, f, "MessageAckBatch", func(ctx context.Context) error {
+		ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
+		_, err := conn.MessageAckBatch(ctx, TestTarget, MessageName, MessageIDs)
+		return err
+	})
+	f.HasError = false
+}
+
+func testMessagePostpone(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
+	t.Log("testMessagePostpone")
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
+	count, err := conn.MessagePostpone(ctx, TestTarget, MessageName, MessageIDs)
+	if err != nil {
+		t.Fatalf("MessagePostpone failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Unexpected result from MessagePostpone: got %v wanted 1", count)
+	}
+}
+
+func testMessagePostponeError(t *testing.T, conn queryservice.QueryService, f *FakeQueryService) {
+	t.Log("testMessagePostponeError")
+	f.HasError = true
+	testErrorHelper(t, f, "MessagePostpone", func(ctx context.Context) error {
+		ctx = callerid.NewContext(ctx, TestCallerID, TestVTGateCallerID)
+		_, err := conn.MessagePostpone(ctx, TestTarget, MessageName, MessageIDs)
+		return err
+	})
+	f.HasError = false
+}
+
 // this test is a bit of a hack: we write something on the channel
 // upon registration, and we also return an error, so the streaming query
 // ends right there. Otherwise we have no real way to trigger a real
@@ -976,6 +1024,8 @@ func TestSuite(ctx context.Context, t *testing.T, protocol string, tablet *topod
 		testBeginStreamExecute,
 		testMessageStream,
 		testMessageAck,
+		testMessageAckBatch,
+		testMessagePostpone,
 		testReserveStreamExecute,
 
 		// error test cases
@@ -1001,6 +1051,8 @@ func TestSuite(ctx context.Context, t *testing.T, protocol string, tablet *topod
 		testReserveStreamExecuteErrorInExecute,
 		testMessageStreamError,
 		testMessageAckError,
+		testMessageAckBatchError,
+		testMessagePostponeError,
 
 		// panic test cases
 		testBeginPanics,
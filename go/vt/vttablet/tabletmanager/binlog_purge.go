@@ -0,0 +1,379 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/utils"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	binlogPurgeInterval      time.Duration
+	binlogPurgeMinBinaryLogs = 2
+	binlogPurgeTabletTypes   topoproto.TabletTypeListFlag
+)
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerBinlogPurgeFlags)
+	servenv.OnParseFor("vttablet", registerBinlogPurgeFlags)
+}
+
+func registerBinlogPurgeFlags(fs *pflag.FlagSet) {
+	utils.SetFlagDurationVar(fs, &binlogPurgeInterval, "binlog-purge-interval", binlogPurgeInterval,
+		"Interval at which the tablet rotates its binary logs and purges the ones that are no longer needed, see --binlog-purge-tablet-types. Disabled when 0.")
+	utils.SetFlagIntVar(fs, &binlogPurgeMinBinaryLogs, "binlog-purge-min-binary-logs", binlogPurgeMinBinaryLogs,
+		"Number of most recent binary logs that are never purged by --binlog-purge-interval.")
+	utils.SetFlagVar(fs, &binlogPurgeTabletTypes, "binlog-purge-tablet-types",
+		"Comma-separated list of tablet types whose binary logs can be purged, by --binlog-purge-interval or the PurgeBinaryLogs RPC. Purging is disabled when empty. The binary logs of a primary are never purged.")
+}
+
+// checkBinlogPurgeAllowed returns an error unless the binary logs of a
+// tablet of the given type can be purged. A primary's binary logs are
+// never purged: its replicas may still need them.
+func checkBinlogPurgeAllowed(tabletType topodatapb.TabletType) error {
+	if tabletType == topodatapb.TabletType_PRIMARY {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot purge the binary logs of a %v tablet", tabletType)
+	}
+	if !topoproto.IsTypeInList(tabletType, binlogPurgeTabletTypes) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot purge the binary logs of a %v tablet: --binlog-purge-tablet-types is %q", tabletType, binlogPurgeTabletTypes.String())
+	}
+	return nil
+}
+
+// startBinlogPurger starts the background loop that periodically purges
+// the binary logs, if --binlog-purge-interval and --binlog-purge-tablet-types
+// are set.
+func (tm *TabletManager) startBinlogPurger() {
+	if binlogPurgeInterval <= 0 || len(binlogPurgeTabletTypes) == 0 {
+		return
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm._binlogPurgeDone = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	tm._binlogPurgeCancel = cancel
+
+	go tm.binlogPurgeLoop(ctx, binlogPurgeInterval, tm._binlogPurgeDone)
+}
+
+func (tm *TabletManager) stopBinlogPurger() {
+	tm.mutex.Lock()
+	if tm._binlogPurgeCancel != nil {
+		tm._binlogPurgeCancel()
+	}
+	doneChan := tm._binlogPurgeDone
+	tm.mutex.Unlock()
+
+	// If the loop was running, wait for it to fully stop.
+	if doneChan != nil {
+		<-doneChan
+	}
+}
+
+func (tm *TabletManager) binlogPurgeLoop(ctx context.Context, interval time.Duration, doneChan chan<- struct{}) {
+	defer close(doneChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if checkBinlogPurgeAllowed(tm.Tablet().Type) != nil {
+			// The tablet type can change at any time, e.g. on a reparent.
+			continue
+		}
+
+		purgeCtx, cancel := context.WithTimeout(ctx, interval)
+		resp, err := tm.purgeBinaryLogs(purgeCtx, true /* flush */, binlogPurgeMinBinaryLogs, false /* dryRun */)
+		cancel()
+		if err != nil {
+			log.Warn("Failed to purge binary logs", slog.Any("error", err))
+			continue
+		}
+		if len(resp.PurgedBinaryLogs) > 0 {
+			log.Info(fmt.Sprintf("Purged binary logs %v", resp.PurgedBinaryLogs))
+		}
+	}
+}
+
+// purgeBinaryLogs purges the binary logs that are no longer needed, keeping
+// at least the last minBinaryLogs binary logs. A binary log is needed until it
+// was read by every other tablet of the shard, any of which may replicate
+// from this tablet after a reparent, and by every VReplication stream reading
+// from the shard. The binary logs after the latest backup of the shard, which
+// incremental backups copy, and those that MySQL itself would keep for
+// binlog_expire_logs_seconds, are kept too. When flush is set, the binary
+// logs are rotated first so the entries of the active binary log can be
+// purged too.
+func (tm *TabletManager) purgeBinaryLogs(ctx context.Context, flush bool, minBinaryLogs int, dryRun bool) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	if err := checkBinlogPurgeAllowed(tm.Tablet().Type); err != nil {
+		return nil, err
+	}
+	if tm.IsBackupRunning() {
+		// Incremental backups copy binary logs, which must not be purged
+		// from under them.
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot purge binary logs while a backup is running on tablet %v", tm.tabletAlias)
+	}
+
+	// Read the positions before listing the binary logs, so that any binary
+	// log rotated in the meantime is only ever kept, never purged.
+	required, err := tm.binlogReplicaPositions(ctx)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot read the positions of the other tablets of the shard")
+	}
+	backupPos, err := tm.latestBackupPosition(ctx)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot read the position of the latest backup")
+	}
+	if backupPos != nil {
+		required = append(required, backupPos)
+	}
+	retention, err := tm.binlogRetention(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if flush && !dryRun {
+		if err := tm.MysqlDaemon.FlushBinaryLogs(ctx); err != nil {
+			return nil, vterrors.Wrap(err, "cannot flush binary logs")
+		}
+	}
+
+	binaryLogs, err := tm.MysqlDaemon.GetBinaryLogs(ctx)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot list binary logs")
+	}
+	maxBinaryLogs, err := tm.expiredBinaryLogs(binaryLogs, retention)
+	if err != nil {
+		return nil, err
+	}
+	previousGTIDs := make(map[string]string)
+	pgtids := func(ctx context.Context, binlog string) (string, error) {
+		if gtids, ok := previousGTIDs[binlog]; ok {
+			return gtids, nil
+		}
+		gtids, err := tm.MysqlDaemon.GetPreviousGTIDs(ctx, binlog)
+		if err == nil {
+			previousGTIDs[binlog] = gtids
+		}
+		return gtids, err
+	}
+	purgeTo, purged, err := mysqlctl.ChooseBinlogsToPurge(ctx, required, minBinaryLogs, maxBinaryLogs, binaryLogs, pgtids)
+	if err != nil {
+		return nil, err
+	}
+	if purgeTo != "" {
+		// Reading the VReplication streams asks the primary of every shard,
+		// so it is only done once there is something to purge.
+		consumers, err := tm.binlogConsumerPositions(ctx)
+		if err != nil {
+			return nil, vterrors.Wrap(err, "cannot read the positions of the binary log consumers")
+		}
+		purgeTo, purged, err = mysqlctl.ChooseBinlogsToPurge(ctx, append(required, consumers...), minBinaryLogs, len(purged), binaryLogs, pgtids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if purgeTo != "" && !dryRun {
+		if err := tm.MysqlDaemon.PurgeBinaryLogs(ctx, purgeTo); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot purge binary logs to %v", purgeTo)
+		}
+	}
+	return &tabletmanagerdatapb.PurgeBinaryLogsResponse{
+		PurgedBinaryLogs:   purged,
+		RetainedBinaryLogs: binaryLogs[len(purged):],
+	}, nil
+}
+
+// binlogReplicaPositions returns the positions of the other tablets of the
+// tablet's shard. An error is returned if any of them cannot be reached,
+// since purging without its position could break its replication.
+func (tm *TabletManager) binlogReplicaPositions(ctx context.Context) ([]replication.GTIDSet, error) {
+	tablet := tm.Tablet()
+	tablets, err := tm.TopoServer.GetTabletMapForShard(ctx, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []replication.GTIDSet
+	for _, ti := range tablets {
+		if topoproto.TabletAliasEqual(ti.Alias, tablet.Alias) {
+			continue
+		}
+		position, err := tm.tmc.PrimaryPosition(ctx, ti.Tablet)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the position of tablet %v", topoproto.TabletAliasString(ti.Alias))
+		}
+		pos, err := replication.DecodePosition(position)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot decode the position of tablet %v", topoproto.TabletAliasString(ti.Alias))
+		}
+		positions = append(positions, pos.GTIDSet)
+	}
+	return positions, nil
+}
+
+// latestBackupPosition returns the position of the latest complete backup
+// of the tablet's shard, or nil if there is no backup storage or no backup.
+func (tm *TabletManager) latestBackupPosition(ctx context.Context) (replication.GTIDSet, error) {
+	if backupstorage.BackupStorageImplementation == "" {
+		return nil, nil
+	}
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	tablet := tm.Tablet()
+	bhs, err := bs.ListBackups(ctx, mysqlctl.GetBackupDir(tablet.Keyspace, tablet.Shard))
+	if err != nil {
+		return nil, err
+	}
+	for _, bh := range slices.Backward(bhs) {
+		manifest, err := mysqlctl.GetBackupManifest(ctx, bh)
+		if err != nil {
+			// Incomplete backups have no MANIFEST.
+			continue
+		}
+		return manifest.Position.GTIDSet, nil
+	}
+	return nil, nil
+}
+
+// binlogRetention returns binlog_expire_logs_seconds, for which MySQL keeps
+// the binary logs.
+func (tm *TabletManager) binlogRetention(ctx context.Context) (time.Duration, error) {
+	qr, err := tm.MysqlDaemon.FetchSuperQuery(ctx, "SELECT @@global.binlog_expire_logs_seconds")
+	if err != nil {
+		return 0, vterrors.Wrap(err, "cannot read binlog_expire_logs_seconds")
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for binlog_expire_logs_seconds: %v", qr.Rows)
+	}
+	seconds, err := qr.Rows[0][0].ToInt64()
+	if err != nil {
+		return 0, vterrors.Wrap(err, "cannot read binlog_expire_logs_seconds")
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// expiredBinaryLogs returns how many of the oldest binary logs were last
+// written more than retention ago, the same way MySQL expires binary logs.
+// All of them are expired when retention is 0.
+func (tm *TabletManager) expiredBinaryLogs(binaryLogs []string, retention time.Duration) (int, error) {
+	if retention <= 0 {
+		return len(binaryLogs), nil
+	}
+	if tm.Cnf == nil || tm.Cnf.BinLogPath == "" {
+		return 0, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot find the binary logs to honor binlog_expire_logs_seconds")
+	}
+	dir := filepath.Dir(tm.Cnf.BinLogPath)
+	cutoff := time.Now().Add(-retention)
+	for i, binlog := range binaryLogs {
+		info, err := os.Stat(filepath.Join(dir, binlog))
+		if err != nil {
+			return 0, vterrors.Wrapf(err, "cannot read the modification time of binary log %v", binlog)
+		}
+		if info.ModTime().After(cutoff) {
+			return i, nil
+		}
+	}
+	return len(binaryLogs), nil
+}
+
+// binlogConsumerPositions returns the positions of all the VReplication
+// streams, in any keyspace, that read from the tablet's shard. Frozen
+// workflows never stream again and are ignored, as are streams that have
+// not recorded a position yet. An error is returned if the streams of any
+// shard cannot be read, since purging without them could break a workflow.
+func (tm *TabletManager) binlogConsumerPositions(ctx context.Context) ([]replication.GTIDSet, error) {
+	tablet := tm.Tablet()
+	keyspaces, err := tm.TopoServer.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []replication.GTIDSet
+	for _, keyspace := range keyspaces {
+		shards, err := tm.TopoServer.FindAllShardsInKeyspace(ctx, keyspace, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, si := range shards {
+			if !si.HasPrimary() {
+				continue
+			}
+			primary, err := tm.TopoServer.GetTablet(ctx, si.PrimaryAlias)
+			if err != nil {
+				if topo.IsErrType(err, topo.NoNode) {
+					continue
+				}
+				return nil, err
+			}
+			resp, err := tm.tmc.ReadVReplicationWorkflows(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowsRequest{
+				ExcludeFrozen: true,
+			})
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "cannot read the VReplication workflows of %v/%v", keyspace, si.ShardName())
+			}
+			for _, workflow := range resp.Workflows {
+				for _, stream := range workflow.Streams {
+					bls := stream.Bls
+					if bls == nil || bls.ExternalCluster != "" || bls.Keyspace != tablet.Keyspace || bls.Shard != tablet.Shard {
+						continue
+					}
+					if stream.Pos == "" {
+						continue
+					}
+					pos, err := replication.DecodePosition(stream.Pos)
+					if err != nil {
+						return nil, vterrors.Wrapf(err, "cannot decode the position of workflow %v stream %d", workflow.Workflow, stream.Id)
+					}
+					positions = append(positions, pos.GTIDSet)
+				}
+			}
+		}
+	}
+	return positions, nil
+}
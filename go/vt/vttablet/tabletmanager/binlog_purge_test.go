@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type binlogPurgeTMClient struct {
+	tmclient.TabletManagerClient
+	streams   []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream
+	positions map[uint32]string
+
+	readWorkflows int
+}
+
+func (tmc *binlogPurgeTMClient) PrimaryPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	position, ok := tmc.positions[tablet.Alias.Uid]
+	if !ok {
+		return "", assert.AnError
+	}
+	return position, nil
+}
+
+func (tmc *binlogPurgeTMClient) ReadVReplicationWorkflows(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReadVReplicationWorkflowsRequest) (*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse, error) {
+	if !req.ExcludeFrozen {
+		return nil, assert.AnError
+	}
+	tmc.readWorkflows++
+	return &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+		Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{{
+			Workflow: "wf",
+			Streams:  tmc.streams,
+		}},
+	}, nil
+}
+
+// setBinlogPurgeTabletTypes allows purging the binary logs of the given
+// tablet types for the duration of the test.
+func setBinlogPurgeTabletTypes(t *testing.T, tabletTypes ...topodatapb.TabletType) {
+	old := binlogPurgeTabletTypes
+	binlogPurgeTabletTypes = tabletTypes
+	t.Cleanup(func() { binlogPurgeTabletTypes = old })
+}
+
+// setBinlogRetention sets the binlog_expire_logs_seconds of the tablet.
+func setBinlogRetention(fmd *mysqlctl.FakeMysqlDaemon, retention time.Duration) {
+	if fmd.FetchSuperQueryMap == nil {
+		fmd.FetchSuperQueryMap = make(map[string]*sqltypes.Result)
+	}
+	fmd.FetchSuperQueryMap["SELECT @@global.binlog_expire_logs_seconds"] = sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("@@global.binlog_expire_logs_seconds", "int64"),
+		strconv.Itoa(int(retention.Seconds())),
+	)
+}
+
+func TestPurgeBinaryLogs(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+	setBinlogPurgeTabletTypes(t, topodatapb.TabletType_REPLICA)
+
+	// A workflow in another keyspace streams from the tablet's shard.
+	target := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 200},
+		Keyspace: "target",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}
+	require.NoError(t, ts.CreateKeyspace(ctx, "target", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "target", "0"))
+	require.NoError(t, ts.CreateTablet(ctx, target))
+	_, err := ts.UpdateShardFields(ctx, "target", "0", func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = target.Alias
+		return nil
+	})
+	require.NoError(t, err)
+	tmc := &binlogPurgeTMClient{
+		streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+			{
+				Id:  1,
+				Bls: &binlogdatapb.BinlogSource{Keyspace: keyspace, Shard: shard},
+				Pos: "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-70",
+			},
+			{
+				// Streams from other shards are ignored.
+				Id:  2,
+				Bls: &binlogdatapb.BinlogSource{Keyspace: "other", Shard: shard},
+				Pos: "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-10",
+			},
+		},
+	}
+	tm.tmc = tmc
+
+	fmd := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+	setBinlogRetention(fmd, 0)
+	fmd.BinaryLogs = []string{"vt-bin.000001", "vt-bin.000002", "vt-bin.000003", "vt-bin.000004"}
+	fmd.PreviousGTIDs = map[string]string{
+		"vt-bin.000002": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-60",
+		"vt-bin.000003": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-78",
+		"vt-bin.000004": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-243",
+	}
+	fmd.ExpectedExecuteSuperQueryList = []string{
+		// dry run
+		"FAKE SHOW BINARY LOGS",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000002' LIMIT 2",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000003' LIMIT 2",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000004' LIMIT 2",
+		// purge
+		"FAKE FLUSH BINARY LOGS",
+		"FAKE SHOW BINARY LOGS",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000002' LIMIT 2",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000003' LIMIT 2",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000004' LIMIT 2",
+		"FAKE PURGE BINARY LOGS TO 'vt-bin.000002'",
+	}
+
+	resp, err := tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{Flush: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vt-bin.000001"}, resp.PurgedBinaryLogs)
+	assert.Equal(t, []string{"vt-bin.000002", "vt-bin.000003", "vt-bin.000004"}, resp.RetainedBinaryLogs)
+
+	resp, err = tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{Flush: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vt-bin.000001"}, resp.PurgedBinaryLogs)
+	require.NoError(t, fmd.CheckSuperQueryList())
+	assert.Equal(t, 2, tmc.readWorkflows)
+
+	// Binary logs are not purged from under a running backup.
+	require.NoError(t, tm.beginBackup("test"))
+	defer tm.endBackup("test")
+	_, err = tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{})
+	assert.ErrorContains(t, err, "backup is running")
+}
+
+func TestPurgeBinaryLogsTabletTypes(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+
+	// Purging is disabled by default.
+	_, err := tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{DryRun: true})
+	assert.ErrorContains(t, err, "--binlog-purge-tablet-types")
+
+	// The binary logs of a primary are never purged.
+	setBinlogPurgeTabletTypes(t, topodatapb.TabletType_REPLICA, topodatapb.TabletType_PRIMARY)
+	assert.NoError(t, checkBinlogPurgeAllowed(topodatapb.TabletType_REPLICA))
+	assert.ErrorContains(t, checkBinlogPurgeAllowed(topodatapb.TabletType_PRIMARY), "cannot purge the binary logs of a PRIMARY tablet")
+	assert.ErrorContains(t, checkBinlogPurgeAllowed(topodatapb.TabletType_RDONLY), "cannot purge the binary logs of a RDONLY tablet")
+}
+
+func TestPurgeBinaryLogsKeepsNeededBinaryLogs(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+	setBinlogPurgeTabletTypes(t, topodatapb.TabletType_REPLICA)
+
+	// The primary of the shard has not read vt-bin.000002 yet.
+	primary := newTestTablet(t, 101, keyspace, shard, nil)
+	primary.Type = topodatapb.TabletType_PRIMARY
+	require.NoError(t, ts.CreateTablet(ctx, primary))
+	_, err := ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = primary.Alias
+		return nil
+	})
+	require.NoError(t, err)
+	tmc := &binlogPurgeTMClient{
+		positions: map[uint32]string{101: "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-70"},
+	}
+	tm.tmc = tmc
+
+	fmd := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+	fmd.BinaryLogs = []string{"vt-bin.000001", "vt-bin.000002", "vt-bin.000003", "vt-bin.000004"}
+	fmd.PreviousGTIDs = map[string]string{
+		"vt-bin.000002": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-60",
+		"vt-bin.000003": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-78",
+		"vt-bin.000004": "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-243",
+	}
+	fmd.ExpectedExecuteSuperQueryList = []string{
+		"FAKE SHOW BINARY LOGS",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000002' LIMIT 2",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000003' LIMIT 2",
+		"FAKE SHOW BINARY LOGS",
+		"FAKE SHOW BINARY LOGS",
+		"FAKE SHOW BINLOG EVENTS IN 'vt-bin.000002' LIMIT 2",
+	}
+
+	setBinlogRetention(fmd, 0)
+	resp, err := tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vt-bin.000001"}, resp.PurgedBinaryLogs)
+
+	// MySQL keeps the binary logs written in the last three and a half hours.
+	binlogDir := t.TempDir()
+	tm.Cnf = &mysqlctl.Mycnf{BinLogPath: filepath.Join(binlogDir, "vt-bin")}
+	for i, binlog := range fmd.BinaryLogs {
+		path := filepath.Join(binlogDir, binlog)
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		modTime := time.Now().Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	setBinlogRetention(fmd, 210*time.Minute)
+	resp, err = tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, resp.PurgedBinaryLogs)
+
+	// The workflows are only read when there is something to purge.
+	tmc.positions[101] = "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-10"
+	setBinlogRetention(fmd, 0)
+	resp, err = tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, resp.PurgedBinaryLogs)
+	assert.Equal(t, 1, tmc.readWorkflows)
+	require.NoError(t, fmd.CheckSuperQueryList())
+
+	// An unreachable tablet blocks the purge.
+	delete(tmc.positions, 101)
+	_, err = tm.PurgeBinaryLogs(ctx, &tabletmanagerdatapb.PurgeBinaryLogsRequest{DryRun: true})
+	assert.ErrorContains(t, err, "cannot read the position of tablet cell1-0000000101")
+}
+
+func TestLatestBackupPosition(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+
+	// Without backup storage, there is no backup to keep binary logs for.
+	pos, err := tm.latestBackupPosition(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, pos)
+
+	oldImplementation, oldRoot := backupstorage.BackupStorageImplementation, filebackupstorage.FileBackupStorageRoot
+	defer func() {
+		backupstorage.BackupStorageImplementation, filebackupstorage.FileBackupStorageRoot = oldImplementation, oldRoot
+	}()
+	backupstorage.BackupStorageImplementation = "file"
+	filebackupstorage.FileBackupStorageRoot = t.TempDir()
+
+	backupDir := filepath.Join(filebackupstorage.FileBackupStorageRoot, keyspace, shard)
+	backups := map[string]string{
+		"2026-01-01.000000.cell1-0000000101": `{"Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-60"}`,
+		"2026-01-02.000000.cell1-0000000101": `{"Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:1-70"}`,
+		// The latest backup is incomplete.
+		"2026-01-03.000000.cell1-0000000101": "",
+	}
+	for name, manifest := range backups {
+		require.NoError(t, os.MkdirAll(filepath.Join(backupDir, name), 0o700))
+		if manifest != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(backupDir, name, "MANIFEST"), []byte(manifest), 0o600))
+		}
+	}
+
+	pos, err = tm.latestBackupPosition(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "16b1039f-22b6-11ed-b765-0a43f95f28a3:1-70", pos.String())
+}
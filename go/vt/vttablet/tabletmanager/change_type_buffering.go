@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/utils"
+)
+
+// changeTypeBufferingWait is how long ChangeType and DemotePrimary wait,
+// after requesting buffering in the shard record, before a primary tablet
+// stops serving. 0 disables the buffering handshake.
+var changeTypeBufferingWait time.Duration
+
+func registerChangeTypeFlags(fs *pflag.FlagSet) {
+	utils.SetFlagDurationVar(fs, &changeTypeBufferingWait, "change-type-buffering-wait", changeTypeBufferingWait,
+		"How long ChangeTabletType and DemotePrimary wait, after asking vtgates through the shard record to buffer the primary traffic, before a primary tablet stops serving. "+
+			"DemotePrimary is called by PlannedReparentShard. "+
+			"The vtgates must run with --buffer-watch-shard-records. 0 disables the handshake.")
+}
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerChangeTypeFlags)
+	servenv.OnParseFor("vttablet", registerChangeTypeFlags)
+}
+
+// requestBuffering asks the vtgates to buffer the primary traffic of the
+// shard, by setting buffering_requested_time in the shard record, and waits
+// for --change-type-buffering-wait so that they start buffering before the
+// tablet stops serving, in ChangeType or in a planned DemotePrimary.
+func (tm *TabletManager) requestBuffering(ctx context.Context) error {
+	tablet := tm.Tablet()
+	_, err := tm.TopoServer.UpdateShardFields(ctx, tablet.Keyspace, tablet.Shard, func(si *topo.ShardInfo) error {
+		si.BufferingRequestedTime = protoutil.TimeToProto(time.Now())
+		return nil
+	})
+	if err != nil {
+		// The handshake only avoids errors during the change, so we go on without it.
+		log.Warn(fmt.Sprintf("Failed to request buffering in the shard record of %v before changing the type of %v: %v",
+			topoproto.KeyspaceShardString(tablet.Keyspace, tablet.Shard), topoproto.TabletAliasString(tm.tabletAlias), err))
+		return nil
+	}
+	log.Info(fmt.Sprintf("Requested buffering in the shard record of %v, waiting %v before changing the type of %v",
+		topoproto.KeyspaceShardString(tablet.Keyspace, tablet.Shard), changeTypeBufferingWait, topoproto.TabletAliasString(tm.tabletAlias)))
+
+	select {
+	case <-ctx.Done():
+		tm.clearBufferingRequest(ctx)
+		return ctx.Err()
+	case <-time.After(changeTypeBufferingWait):
+		return nil
+	}
+}
+
+// clearBufferingRequest clears buffering_requested_time in the shard record.
+// The vtgates stop buffering when they see a serving primary again, so this
+// only prevents a later watch from acting on a stale request.
+func (tm *TabletManager) clearBufferingRequest(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), topo.RemoteOperationTimeout)
+	defer cancel()
+
+	tablet := tm.Tablet()
+	_, err := tm.TopoServer.UpdateShardFields(ctx, tablet.Keyspace, tablet.Shard, func(si *topo.ShardInfo) error {
+		if si.BufferingRequestedTime == nil {
+			return topo.NewError(topo.NoUpdateNeeded, si.Keyspace()+"/"+si.ShardName())
+		}
+		si.BufferingRequestedTime = nil
+		return nil
+	})
+	if err != nil {
+		log.Warn(fmt.Sprintf("Failed to clear the buffering request in the shard record of %v: %v",
+			topoproto.KeyspaceShardString(tablet.Keyspace, tablet.Shard), err))
+	}
+}
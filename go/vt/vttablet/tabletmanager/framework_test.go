@@ -311,6 +311,16 @@ func (ftc *fakeTabletConn) MessageAck(ctx context.Context, target *querypb.Targe
 	return 0, nil
 }
 
+// fakeTabletConn implements the QueryService interface.
+func (ftc *fakeTabletConn) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	return 0, nil
+}
+
+// fakeTabletConn implements the QueryService interface.
+func (ftc *fakeTabletConn) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	return 0, nil
+}
+
 // fakeTabletConn implements the QueryService interface.
 func (ftc *fakeTabletConn) VStream(ctx context.Context, request *binlogdatapb.VStreamRequest, send func([]*binlogdatapb.VEvent) error) error {
 	return nil
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/utils"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	externalGroupReplication      = false
+	groupReplicationCheckInterval = 5 * time.Second
+)
+
+func init() {
+	servenv.OnParseFor("vttablet", registerGroupReplicationFlags)
+}
+
+func registerGroupReplicationFlags(fs *pflag.FlagSet) {
+	utils.SetFlagBoolVar(fs, &externalGroupReplication, "external-group-replication", externalGroupReplication,
+		"If set, the tablet's MySQL is a member of a MySQL group replication cluster managed outside of Vitess. The tablet follows the group's elections, becoming PRIMARY when its member is the group primary, and active reparents are disabled. Use together with --db-flavor=MysqlGR.")
+	utils.SetFlagDurationVar(fs, &groupReplicationCheckInterval, "group-replication-check-interval", groupReplicationCheckInterval,
+		"Interval at which the tablet checks the group replication primary when --external-group-replication is set.")
+}
+
+// activeReparentsDisabled returns true if the tablet must not change the
+// replication of its MySQL: either active reparents are disabled, or the
+// group the MySQL is a member of elects its own primary.
+func (tm *TabletManager) activeReparentsDisabled() bool {
+	return mysqlctl.DisableActiveReparents || externalGroupReplication
+}
+
+// startGroupReplicationMonitor starts the background loop that keeps the
+// tablet type in sync with the role of the tablet's MySQL in its group.
+func (tm *TabletManager) startGroupReplicationMonitor() {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm._groupReplicationDone = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	tm._groupReplicationCancel = cancel
+
+	go tm.groupReplicationLoop(ctx, groupReplicationCheckInterval, tm._groupReplicationDone)
+}
+
+func (tm *TabletManager) stopGroupReplicationMonitor() {
+	tm.mutex.Lock()
+	if tm._groupReplicationCancel != nil {
+		tm._groupReplicationCancel()
+	}
+	doneChan := tm._groupReplicationDone
+	tm.mutex.Unlock()
+
+	// If the loop was running, wait for it to fully stop.
+	if doneChan != nil {
+		<-doneChan
+	}
+}
+
+func (tm *TabletManager) groupReplicationLoop(ctx context.Context, interval time.Duration, doneChan chan<- struct{}) {
+	defer close(doneChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := tm.syncGroupReplicationRole(ctx); err != nil {
+			log.Warn("Failed to sync tablet type with the group replication primary", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncGroupReplicationRole makes the tablet PRIMARY when its MySQL is the
+// group's primary, and demotes it back to its base type when it is not.
+// The group elects its own primary, so only the tablet type changes; MySQL
+// is left untouched. The shard record follows through the shard sync loop,
+// which also demotes the previous primary tablet if it cannot see the
+// group itself.
+func (tm *TabletManager) syncGroupReplicationRole(ctx context.Context) error {
+	status, err := tm.MysqlDaemon.GroupReplicationStatus(ctx)
+	if err != nil && !errors.Is(err, mysql.ErrNoGroupStatus) {
+		return err
+	}
+	isPrimary := status.GetIsPrimary()
+
+	tablet := tm.Tablet()
+	var tabletType topodatapb.TabletType
+	switch {
+	case isPrimary && tablet.Type == tm.baseTabletType:
+		tabletType = topodatapb.TabletType_PRIMARY
+	case !isPrimary && tablet.Type == topodatapb.TabletType_PRIMARY:
+		tabletType = tm.baseTabletType
+	default:
+		// In sync, or the tablet is not serving, e.g. it is DRAINED or
+		// taking a backup, and must not be promoted.
+		return nil
+	}
+
+	if err := tm.lock(ctx); err != nil {
+		return err
+	}
+	defer tm.unlock()
+
+	// Re-check under the lock, the tablet type may have changed meanwhile.
+	if tm.Tablet().Type != tablet.Type {
+		return nil
+	}
+	log.Info(fmt.Sprintf("Group replication member is primary: %v, changing tablet type %v -> %v", isPrimary, tablet.Type, tabletType))
+	changeTypeCtx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+	return tm.changeTypeLocked(changeTypeCtx, tabletType, DBActionNone, SemiSyncActionNone)
+}
+
+// groupReplicationStatus returns the group replication state reported in
+// FullStatus, or nil when the tablet does not front a group member.
+func (tm *TabletManager) groupReplicationStatus(ctx context.Context) (*replicationdatapb.GroupReplicationStatus, error) {
+	if !externalGroupReplication {
+		return nil, nil
+	}
+	status, err := tm.MysqlDaemon.GroupReplicationStatus(ctx)
+	if errors.Is(err, mysql.ErrNoGroupStatus) {
+		return nil, nil
+	}
+	return status, err
+}
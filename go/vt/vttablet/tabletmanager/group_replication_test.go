@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestSyncGroupReplicationRole(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+	fmd := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+
+	// Not a group member: nothing changes.
+	require.NoError(t, tm.syncGroupReplicationRole(ctx))
+	assert.Equal(t, topodatapb.TabletType_REPLICA, tm.Tablet().Type)
+
+	// The member becomes the group primary.
+	fmd.GroupReplication = &replicationdatapb.GroupReplicationStatus{MemberState: "ONLINE", IsPrimary: true}
+	require.NoError(t, tm.syncGroupReplicationRole(ctx))
+	assert.Equal(t, topodatapb.TabletType_PRIMARY, tm.Tablet().Type)
+	ti, err := ts.GetTablet(ctx, tm.tabletAlias)
+	require.NoError(t, err)
+	assert.Equal(t, topodatapb.TabletType_PRIMARY, ti.Type)
+	assert.NotNil(t, ti.PrimaryTermStartTime)
+
+	// Another member is elected.
+	fmd.GroupReplication = &replicationdatapb.GroupReplicationStatus{MemberState: "ONLINE", PrimaryHost: "host2", PrimaryPort: 3306}
+	require.NoError(t, tm.syncGroupReplicationRole(ctx))
+	assert.Equal(t, topodatapb.TabletType_REPLICA, tm.Tablet().Type)
+
+	// A tablet that is not serving is not promoted.
+	require.NoError(t, tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_DRAINED, DBActionNone))
+	fmd.GroupReplication = &replicationdatapb.GroupReplicationStatus{MemberState: "ONLINE", IsPrimary: true}
+	require.NoError(t, tm.syncGroupReplicationRole(ctx))
+	assert.Equal(t, topodatapb.TabletType_DRAINED, tm.Tablet().Type)
+}
+
+func TestFullStatusGroupReplication(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+	fmd := tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon)
+	fmd.GroupReplication = &replicationdatapb.GroupReplicationStatus{MemberState: "ONLINE", IsPrimary: true}
+
+	status, err := tm.groupReplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	externalGroupReplication = true
+	defer func() { externalGroupReplication = false }()
+	status, err = tm.groupReplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.IsPrimary)
+
+	fmd.GroupReplication = nil
+	status, err = tm.groupReplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestActiveReparentsDisabledByGroupReplication(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 100, keyspace, shard, nil)
+	defer tm.Stop()
+	assert.False(t, tm.activeReparentsDisabled())
+
+	// The flag disables active reparents for this tablet only, without
+	// changing the process-wide setting.
+	externalGroupReplication = true
+	defer func() { externalGroupReplication = false }()
+	assert.True(t, tm.activeReparentsDisabled())
+	assert.False(t, mysqlctl.DisableActiveReparents)
+}
@@ -314,7 +314,7 @@ func (tm *TabletManager) restoreFromClone(ctx context.Context, logger logutil.Lo
 
 	startTime = time.Now()
 
-	err = tm.restoreFromCloneLocked(ctx, logger, deleteBeforeRestore)
+	err = tm.restoreFromCloneLocked(ctx, logger, deleteBeforeRestore, nil /* donorAlias */)
 	if err != nil {
 		return err
 	}
@@ -322,10 +322,13 @@ func (tm *TabletManager) restoreFromClone(ctx context.Context, logger logutil.Lo
 	return nil
 }
 
+// restoreFromCloneLocked clones the data of donorAlias, or of the donor set
+// by the --clone-from-primary or --clone-from-tablet flags if donorAlias is nil.
 func (tm *TabletManager) restoreFromCloneLocked(
 	ctx context.Context,
 	logger logutil.Logger,
 	deleteBeforeRestore bool,
+	donorAlias *topodatapb.TabletAlias,
 ) error {
 	rsm := tm.newRestoreStateManager(logger, deleteBeforeRestore)
 
@@ -338,10 +341,29 @@ func (tm *TabletManager) restoreFromCloneLocked(
 		return nil
 	}
 
+	// A running tablet may still be replicating into the data that is replaced.
+	if deleteBeforeRestore {
+		if err := tm.MysqlDaemon.StopReplication(ctx, nil); err != nil {
+			err = vterrors.Wrap(err, "failed to stop replication")
+			if err := rsm.abort(); err != nil {
+				logger.Errorf("Failed to abort restore: %v", err)
+			}
+			return err
+		}
+	}
+
 	tablet := tm.Tablet()
 	// Pass tm.Cnf so clone restore can fall back to a local mysqld restart if
 	// CLONE completes but MySQL cannot restart itself.
-	pos, err := mysqlctl.CloneFromDonor(ctx, tm.TopoServer, tm.MysqlDaemon, tm.Cnf, tablet.Keyspace, tablet.Shard)
+	var (
+		pos replication.Position
+		err error
+	)
+	if donorAlias != nil {
+		pos, err = mysqlctl.CloneFromTablet(ctx, tm.TopoServer, tm.MysqlDaemon, tm.Cnf, donorAlias, logger)
+	} else {
+		pos, err = mysqlctl.CloneFromDonor(ctx, tm.TopoServer, tm.MysqlDaemon, tm.Cnf, tablet.Keyspace, tablet.Shard)
+	}
 	if err != nil {
 		err = vterrors.Wrap(err, "failed to clone from donor")
 		if err := rsm.abort(); err != nil {
@@ -563,6 +585,10 @@ func (rt *restoreStateManager) finish(ctx context.Context, replCmd replicationCo
 		}
 	}
 
+	// Warm up the query pools before serving, so that the restored tablet
+	// doesn't serve production traffic with cold caches.
+	rt.tm.QueryServiceControl.WarmUpOnNextServe()
+
 	// Transition to next tablet type.
 	if err := rt.tm.tmState.ChangeTabletType(context.Background(), rt.nextTabletType, DBActionNone); err != nil {
 		return vterrors.Wrapf(err, "failed to change tablet type to %q", topoproto.TabletTypeLString(rt.nextTabletType))
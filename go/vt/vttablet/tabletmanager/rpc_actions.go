@@ -131,6 +131,22 @@ func (tm *TabletManager) ChangeType(ctx context.Context, tabletType topodatapb.T
 		return err
 	}
 
+	if changeTypeBufferingWait > 0 {
+		switch {
+		case tm.Tablet().Type == topodatapb.TabletType_PRIMARY && tabletType != topodatapb.TabletType_PRIMARY:
+			// The primary is about to stop serving: let the vtgates
+			// start buffering first.
+			if err := tm.requestBuffering(ctx); err != nil {
+				return err
+			}
+			defer tm.clearBufferingRequest(ctx)
+		case tabletType == topodatapb.TabletType_PRIMARY:
+			// Clear any request left behind by a primary that failed
+			// to change its type.
+			defer tm.clearBufferingRequest(ctx)
+		}
+	}
+
 	return tm.changeTypeLocked(ctx, tabletType, DBActionNone, semiSyncAction)
 }
 
@@ -41,6 +41,8 @@ type RPCTM interface {
 
 	GetSchema(ctx context.Context, request *tabletmanagerdatapb.GetSchemaRequest) (*tabletmanagerdatapb.SchemaDefinition, error)
 
+	GetSchemaSnapshot(ctx context.Context, request *tabletmanagerdatapb.GetSchemaSnapshotRequest) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error)
+
 	GetPermissions(ctx context.Context) (*tabletmanagerdatapb.Permissions, error)
 
 	// GetGlobalStatusVars returns the server's global status variables asked for.
@@ -156,6 +158,8 @@ type RPCTM interface {
 
 	ResetReplicationParameters(ctx context.Context) error
 
+	PurgeBinaryLogs(ctx context.Context, req *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error)
+
 	SetReplicationSource(ctx context.Context, parent *topodatapb.TabletAlias, timeCreatedNS int64, waitPosition string, forceStartReplication bool, semiSync bool, heartbeatInterval float64) error
 
 	StopReplicationAndGetStatus(ctx context.Context, stopReplicationMode replicationdatapb.StopReplicationMode) (StopReplicationAndGetStatusResponse, error)
@@ -170,6 +174,8 @@ type RPCTM interface {
 
 	RestoreFromBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.RestoreFromBackupRequest) error
 
+	CloneFromTablet(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.CloneFromTabletRequest) error
+
 	IsBackupRunning() bool
 
 	// HandleRPCPanic is to be called in a defer statement in each
@@ -95,6 +95,7 @@ func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req
 		MysqlShutdownTimeout: shutdownTimeout(l, req.MysqlShutdownTimeout),
 		BackupEngine:         backupEngine,
 		InitSQL:              req.InitSql.CloneVT(),
+		ExternalReplication:  externalGroupReplication,
 	}
 
 	// Perform any requested pre backup initialization queries.
@@ -149,7 +150,7 @@ func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req
 			}
 
 			// Do not do anything for primary tablets or when active reparenting is disabled
-			if mysqlctl.DisableActiveReparents || tabletInfo.Type == topodatapb.TabletType_PRIMARY {
+			if tm.activeReparentsDisabled() || tabletInfo.Type == topodatapb.TabletType_PRIMARY {
 				return
 			}
 
@@ -230,6 +231,52 @@ func (tm *TabletManager) RestoreFromBackup(ctx context.Context, logger logutil.L
 	return restoreErr
 }
 
+// CloneFromTablet deletes all local data and then clones the data of the donor tablet with
+// the MySQL CLONE plugin.
+func (tm *TabletManager) CloneFromTablet(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.CloneFromTabletRequest) error {
+	if request.DonorAlias == nil {
+		return errors.New("donor tablet alias is required")
+	}
+	if topoproto.TabletAliasEqual(request.DonorAlias, tm.tabletAlias) {
+		return fmt.Errorf("tablet %v cannot clone its own data", topoproto.TabletAliasString(tm.tabletAlias))
+	}
+
+	var (
+		startTime time.Time
+		cloneErr  error
+	)
+
+	// Declare the hook defer before the lock so it runs after unlock (LIFO).
+	defer func() {
+		if startTime.IsZero() {
+			return
+		}
+		tm.QueryServiceControl.BroadcastHealth()
+		tm.invokeRestoreDoneHook(startTime, cloneErr, "")
+	}()
+
+	if err := tm.lock(ctx); err != nil {
+		return err
+	}
+	defer tm.unlock()
+
+	tablet, err := tm.TopoServer.GetTablet(ctx, tm.tabletAlias)
+	if err != nil {
+		return err
+	}
+	if tablet.Type == topodatapb.TabletType_PRIMARY {
+		return errors.New("type PRIMARY cannot clone from another tablet, if you really need to do this, restart vttablet in replica mode")
+	}
+
+	// Create the logger: tee to console and source.
+	l := logutil.NewTeeLogger(logutil.NewConsoleLogger(), logger)
+
+	startTime = time.Now()
+	cloneErr = tm.restoreFromCloneLocked(ctx, l, true /* deleteBeforeRestore */, request.DonorAlias)
+
+	return cloneErr
+}
+
 func (tm *TabletManager) IsBackupRunning() bool {
 	return tm._isBackupRunning
 }
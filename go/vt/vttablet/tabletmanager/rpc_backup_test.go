@@ -24,6 +24,9 @@ import (
 
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/proto/vttime"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
 func TestShutdownTimeout(t *testing.T) {
@@ -52,3 +55,16 @@ func TestShutdownTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneFromTabletArgs(t *testing.T) {
+	tm := &TabletManager{tabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}}
+	l := logutil.NewMemoryLogger()
+
+	err := tm.CloneFromTablet(t.Context(), l, &tabletmanagerdatapb.CloneFromTabletRequest{})
+	assert.EqualError(t, err, "donor tablet alias is required")
+
+	err = tm.CloneFromTablet(t.Context(), l, &tabletmanagerdatapb.CloneFromTabletRequest{
+		DonorAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+	})
+	assert.EqualError(t, err, "tablet zone1-0000000100 cannot clone its own data")
+}
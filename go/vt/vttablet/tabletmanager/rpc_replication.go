@@ -41,6 +41,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver"
 
 	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
@@ -171,6 +172,12 @@ func (tm *TabletManager) FullStatus(ctx context.Context) (*replicationdatapb.Ful
 		return nil, err
 	}
 
+	// Group replication member state - "performance_schema.replication_group_members"
+	groupReplicationStatus, err := tm.groupReplicationStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &replicationdatapb.FullStatus{
 		ServerId:                    serverID,
 		ServerUuid:                  serverUUID,
@@ -197,6 +204,7 @@ func (tm *TabletManager) FullStatus(ctx context.Context) (*replicationdatapb.Ful
 		ReplicationConfiguration:    replConfiguration,
 		TabletType:                  tm.Tablet().Type,
 		ShardPeerHealth:             tm.shardPeerHealthSnapshot(),
+		GroupReplicationStatus:      groupReplicationStatus,
 	}, nil
 }
 
@@ -653,6 +661,15 @@ func (tm *TabletManager) demotePrimary(ctx context.Context, revertPartialFailure
 		// have to be killed at the end of their timeout, this will be
 		// considered successful. If we are already not serving, this will be
 		// idempotent.
+		// A planned demotion lets the vtgates start buffering first. A
+		// forced one happens after another tablet was promoted, whose
+		// traffic must not be buffered.
+		if changeTypeBufferingWait > 0 && !force {
+			if err := tm.requestBuffering(ctx); err != nil {
+				return nil, err
+			}
+			defer tm.clearBufferingRequest(ctx)
+		}
 		log.Info("DemotePrimary disabling query service")
 		if err := tm.QueryServiceControl.SetServingType(tablet.Type, protoutil.TimeFromProto(tablet.PrimaryTermStartTime).UTC(), false, "demotion in progress"); err != nil {
 			return nil, vterrors.Wrap(err, "SetServingType(serving=false) failed")
@@ -884,6 +901,16 @@ func (tm *TabletManager) ResetReplicationParameters(ctx context.Context) error {
 	return nil
 }
 
+// PurgeBinaryLogs purges the binary logs that are no longer needed by any
+// VReplication stream reading from the tablet's shard.
+func (tm *TabletManager) PurgeBinaryLogs(ctx context.Context, req *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	log.Info(fmt.Sprintf("PurgeBinaryLogs: flush: %v min_binary_logs: %d dry_run: %v", req.Flush, req.MinBinaryLogs, req.DryRun))
+	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
+		return nil, err
+	}
+	return tm.purgeBinaryLogs(ctx, req.Flush, int(req.MinBinaryLogs), req.DryRun)
+}
+
 // SetReplicationSource sets replication primary, and waits for the
 // reparent_journal table entry up to context timeout
 func (tm *TabletManager) SetReplicationSource(ctx context.Context, parentAlias *topodatapb.TabletAlias, timeCreatedNS int64, waitPosition string, forceStartReplication bool, semiSync bool, heartbeatInterval float64) error {
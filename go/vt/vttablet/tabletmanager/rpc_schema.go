@@ -35,6 +35,12 @@ func (tm *TabletManager) GetSchema(ctx context.Context, request *tabletmanagerda
 	return tm.MysqlDaemon.GetSchema(ctx, topoproto.TabletDbName(tm.Tablet()), request)
 }
 
+// GetSchemaSnapshot returns a snapshot of the schema, or the changes of the
+// schema since a previous snapshot.
+func (tm *TabletManager) GetSchemaSnapshot(ctx context.Context, request *tabletmanagerdatapb.GetSchemaSnapshotRequest) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	return tm.QueryServiceControl.SchemaEngine().Snapshot(ctx, request.SinceVersion)
+}
+
 // ReloadSchema will reload the schema
 // This doesn't need the action mutex because periodic schema reloads happen
 // in the background anyway.
@@ -217,6 +217,23 @@ type TabletManager struct {
 	// _shardSyncCancel is the function to stop the background shard sync goroutine.
 	_shardSyncCancel context.CancelFunc
 
+	// _groupReplicationDone is a channel for waiting until the group
+	// replication monitor goroutine has finished after _groupReplicationCancel
+	// was called. It is nil unless --external-group-replication is set.
+	_groupReplicationDone chan struct{}
+
+	// _groupReplicationCancel is the function to stop the group replication
+	// monitor goroutine.
+	_groupReplicationCancel context.CancelFunc
+
+	// _binlogPurgeDone is a channel for waiting until the binary log purge
+	// goroutine has finished after _binlogPurgeCancel was called. It is nil
+	// unless --binlog-purge-interval is set.
+	_binlogPurgeDone chan struct{}
+
+	// _binlogPurgeCancel is the function to stop the binary log purge goroutine.
+	_binlogPurgeCancel context.CancelFunc
+
 	// _rebuildKeyspaceDone is a channel for waiting until the current keyspace
 	// has been rebuilt
 	_rebuildKeyspaceDone chan struct{}
@@ -477,6 +494,10 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, config *tabletenv.Tabl
 	// The following initializations don't need to be done
 	// in any specific order.
 	tm.startShardSync()
+	if externalGroupReplication {
+		tm.startGroupReplicationMonitor()
+	}
+	tm.startBinlogPurger()
 	tm.exportStats()
 	servenv.OnRun(tm.registerTabletManager)
 
@@ -576,6 +597,8 @@ func (tm *TabletManager) Close() {
 	// rather than registering it as an OnTerm hook so the shard sync loop keeps
 	// running during lame duck.
 	tm.stopShardSync()
+	tm.stopGroupReplicationMonitor()
+	tm.stopBinlogPurger()
 	tm.stopRebuildKeyspace()
 
 	// cleanup initialized fields in the tablet entry
@@ -611,6 +634,8 @@ func (tm *TabletManager) Stop() {
 	// Stop the shard sync loop and wait for it to exit. This needs to be done
 	// here in addition to in Close() because tests do not call Close().
 	tm.stopShardSync()
+	tm.stopGroupReplicationMonitor()
+	tm.stopBinlogPurger()
 	tm.stopRebuildKeyspace()
 
 	if tm.QueryServiceControl != nil {
@@ -1141,7 +1166,7 @@ func (tm *TabletManager) hookExtraEnv() map[string]string {
 func (tm *TabletManager) initializeReplication(ctx context.Context, tabletType topodatapb.TabletType) (string, error) {
 	// If active reparents are disabled, we do not touch replication.
 	// There is nothing to do
-	if mysqlctl.DisableActiveReparents {
+	if tm.activeReparentsDisabled() {
 		return "", nil
 	}
 
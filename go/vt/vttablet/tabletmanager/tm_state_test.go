@@ -136,7 +136,7 @@ func TestStateDenyList(t *testing.T) {
 			}},
 			wantDeniedTables:               map[topodatapb.TabletType][]string{topodatapb.TabletType_REPLICA: {"t1"}},
 			wantAllowReadsFromDeniedTables: map[topodatapb.TabletType]bool{topodatapb.TabletType_REPLICA: true},
-			wantQueryRules:                 `[{"Description":"enforce denied tables","Name":"denied_table","Plans":["Nextval","Insert","InsertMessage","Update","UpdateLimit","Delete","DeleteLimit","DDL","Set","OtherRead","OtherAdmin","MessageStream","Savepoint","Release","RollbackSavepoint","Show","Load","Flush","UnlockTables","CallProcedure","AlterMigration","RevertMigration","ShowMigrations","ShowMigrationLogs","ShowThrottledApps","ShowThrottlerStatus"],"TableNames":["t1"],"Action":"FAIL_RETRY"}]`,
+			wantQueryRules:                 `[{"Description":"enforce denied tables","Name":"denied_table","Plans":["Nextval","Insert","InsertMessage","Update","UpdateLimit","Delete","DeleteLimit","DDL","Set","OtherRead","OtherAdmin","MessageStream","Savepoint","Release","RollbackSavepoint","Show","Load","Flush","UnlockTables","CallProcedure","AlterMigration","RevertMigration","ShowMigrations","ShowMigrationLogs","ShowThrottledApps","ShowThrottlerStatus","InsertReturning"],"TableNames":["t1"],"Action":"FAIL_RETRY"}]`,
 		},
 	}
 
@@ -420,6 +420,83 @@ func TestStateChangeTabletType(t *testing.T) {
 	assert.Equal(t, int64(2), statsTabletTypeCount.Counts()["replica"])
 }
 
+// TestChangeTypeBufferingHandshake tests that a primary requests buffering in
+// the shard record before it stops serving, and clears the request after.
+func TestChangeTypeBufferingHandshake(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 1, "ks", "0", nil)
+	defer tm.Stop()
+
+	require.NoError(t, tm.ChangeType(ctx, topodatapb.TabletType_PRIMARY, false))
+
+	oldWait := changeTypeBufferingWait
+	changeTypeBufferingWait = time.Second
+	defer func() { changeTypeBufferingWait = oldWait }()
+
+	changed := make(chan error, 1)
+	go func() {
+		changed <- tm.ChangeType(ctx, topodatapb.TabletType_REPLICA, false)
+	}()
+
+	// The tablet keeps serving as a primary while the request is in the shard record.
+	require.Eventually(t, func() bool {
+		si, err := ts.GetShard(ctx, "ks", "0")
+		return err == nil && si.BufferingRequestedTime != nil
+	}, 30*time.Second, 10*time.Millisecond)
+	assert.Equal(t, topodatapb.TabletType_PRIMARY, tm.Tablet().Type)
+
+	require.NoError(t, <-changed)
+	assert.Equal(t, topodatapb.TabletType_REPLICA, tm.Tablet().Type)
+	si, err := ts.GetShard(ctx, "ks", "0")
+	require.NoError(t, err)
+	assert.Nil(t, si.BufferingRequestedTime)
+}
+
+// TestDemotePrimaryBufferingHandshake tests that a planned demotion requests
+// buffering in the shard record before the primary stops serving, and that a
+// forced one does not.
+func TestDemotePrimaryBufferingHandshake(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 1, "ks", "0", nil)
+	defer tm.Stop()
+
+	require.NoError(t, tm.ChangeType(ctx, topodatapb.TabletType_PRIMARY, false))
+
+	oldWait := changeTypeBufferingWait
+	changeTypeBufferingWait = time.Second
+	defer func() { changeTypeBufferingWait = oldWait }()
+
+	qsc := tm.QueryServiceControl.(*tabletservermock.Controller)
+	demoted := make(chan error, 1)
+	go func() {
+		_, err := tm.demotePrimary(ctx, true /* revertPartialFailure */, false /* force */)
+		demoted <- err
+	}()
+
+	// The tablet keeps serving while the request is in the shard record.
+	require.Eventually(t, func() bool {
+		si, err := ts.GetShard(ctx, "ks", "0")
+		return err == nil && si.BufferingRequestedTime != nil
+	}, 30*time.Second, 10*time.Millisecond)
+	assert.True(t, qsc.IsServing())
+
+	require.NoError(t, <-demoted)
+	assert.False(t, qsc.IsServing())
+	si, err := ts.GetShard(ctx, "ks", "0")
+	require.NoError(t, err)
+	assert.Nil(t, si.BufferingRequestedTime)
+
+	// A forced demotion does not wait for the vtgates.
+	tm.MysqlDaemon.(*mysqlctl.FakeMysqlDaemon).ReadOnly = false
+	require.NoError(t, qsc.SetServingType(topodatapb.TabletType_PRIMARY, time.Now(), true, ""))
+	start := time.Now()
+	_, err = tm.demotePrimary(ctx, true /* revertPartialFailure */, true /* force */)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), changeTypeBufferingWait)
+}
+
 /*
 	This test verifies, even if SetServingType returns error we should still publish
 
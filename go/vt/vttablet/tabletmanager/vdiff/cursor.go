@@ -18,18 +18,22 @@ package vdiff
 
 import (
 	"context"
+	"time"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 )
 
-// contextVCursor satisfies VCursor, but only implements Context().
-// MergeSort only requires Context to be implemented.
+// contextVCursor satisfies VCursor, but only implements the methods used by
+// MergeSort and by the OrderedAggregate that re-aggregates the source rows.
 type contextVCursor struct {
 	engine.VCursor
 	ctx context.Context
+	env *vtenv.Environment
 }
 
 func (vc *contextVCursor) ConnCollation() collations.ID {
@@ -43,3 +47,21 @@ func (vc *contextVCursor) ExecutePrimitive(ctx context.Context, primitive engine
 func (vc *contextVCursor) StreamExecutePrimitive(ctx context.Context, primitive engine.Primitive, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	return primitive.TryStreamExecute(ctx, vc, bindVars, wantfields, callback)
 }
+
+// TrackMemory does not limit the memory of the VDiff, which must read all
+// the rows of the table.
+func (vc *contextVCursor) TrackMemory(bytes int64) error {
+	return nil
+}
+
+func (vc *contextVCursor) TimeZone() *time.Location {
+	return time.Local
+}
+
+func (vc *contextVCursor) SQLMode() string {
+	return config.DefaultSQLMode
+}
+
+func (vc *contextVCursor) Environment() *vtenv.Environment {
+	return vc.env
+}
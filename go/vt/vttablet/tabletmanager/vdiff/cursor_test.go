@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+)
+
+// resultsPrimitive streams its results.
+type resultsPrimitive struct {
+	engine.Primitive
+	results []*sqltypes.Result
+}
+
+func (rp *resultsPrimitive) TryStreamExecute(ctx context.Context, vcursor engine.VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	for _, qr := range rp.results {
+		if err := callback(qr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestContextVCursorAggregates runs the OrderedAggregate that re-aggregates
+// the rows of the sources through the contextVCursor, which embeds a nil
+// VCursor.
+func TestContextVCursorAggregates(t *testing.T) {
+	collationEnv := collations.MySQL8()
+	prim := &engine.OrderedAggregate{
+		Aggregates: []*engine.AggregateParams{
+			engine.NewAggregateParam(opcode.AggregateSum, 1, nil, "", collationEnv),
+		},
+		GroupByKeys: pkColsToGroupByParams([]int{0}, collationEnv),
+		Input: &resultsPrimitive{
+			results: sqltypes.MakeTestStreamingResults(sqltypes.MakeTestFields("c1|c2", "int64|int64"),
+				"1|3",
+				"1|4",
+				"2|5",
+			),
+		},
+	}
+
+	pe := newPrimitiveExecutor(t.Context(), vtenv.NewTestEnv(), prim, "source")
+	var rows []string
+	for {
+		row, err := pe.next()
+		require.NoError(t, err)
+		if row == nil {
+			break
+		}
+		rows = append(rows, fmt.Sprintf("%v", row))
+	}
+	assert.Equal(t, []string{"[INT64(1) DECIMAL(7)]", "[INT64(2) DECIMAL(5)]"}, rows)
+}
@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 )
@@ -69,18 +70,20 @@ type Engine struct {
 	// NOT be set in production.
 	fortests bool
 
+	env          *vtenv.Environment
 	collationEnv *collations.Environment
 	parser       *sqlparser.Parser
 }
 
-func NewEngine(ts *topo.Server, tablet *topodata.Tablet, collationEnv *collations.Environment, parser *sqlparser.Parser) *Engine {
+func NewEngine(ts *topo.Server, tablet *topodata.Tablet, env *vtenv.Environment) *Engine {
 	vde := &Engine{
 		controllers:     make(map[int64]*controller),
 		ts:              ts,
 		thisTablet:      tablet,
 		tmClientFactory: func() tmclient.TabletManagerClient { return tmclient.NewTabletManagerClient() },
-		collationEnv:    collationEnv,
-		parser:          parser,
+		env:             env,
+		collationEnv:    env.CollationEnv(),
+		parser:          env.Parser(),
 	}
 	return vde
 }
@@ -98,6 +101,7 @@ func NewTestEngine(ts *topo.Server, tablet *topodata.Tablet, dbn string, dbcf fu
 		dbClientFactoryDba:      dbcf,
 		tmClientFactory:         tmcf,
 		fortests:                true,
+		env:                     vtenv.NewTestEnv(),
 		collationEnv:            collations.MySQL8(),
 		parser:                  sqlparser.NewTestParser(),
 	}
@@ -21,6 +21,7 @@ import (
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	vtgateEngine "vitess.io/vitess/go/vt/vtgate/engine"
 )
@@ -51,13 +52,13 @@ type primitiveExecutor struct {
 	name string // for debug purposes only
 }
 
-func newPrimitiveExecutor(ctx context.Context, prim vtgateEngine.Primitive, name string) *primitiveExecutor {
+func newPrimitiveExecutor(ctx context.Context, env *vtenv.Environment, prim vtgateEngine.Primitive, name string) *primitiveExecutor {
 	pe := &primitiveExecutor{
 		prim:     prim,
 		resultch: make(chan *sqltypes.Result, 1),
 		name:     name,
 	}
-	vcursor := &contextVCursor{ctx: ctx}
+	vcursor := &contextVCursor{ctx: ctx, env: env}
 
 	// handles each callback from the merge sorter, waits for a result set from the shard streamer and pushes it on the result channel
 	go func() {
@@ -562,8 +562,8 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 	execCtx, cancelExec := context.WithCancel(ctx)
 	defer cancelExec()
 
-	sourceExecutor := newPrimitiveExecutor(execCtx, td.sourcePrimitive, "source")
-	targetExecutor := newPrimitiveExecutor(execCtx, td.targetPrimitive, "target")
+	sourceExecutor := newPrimitiveExecutor(execCtx, td.wd.ct.vde.env, td.sourcePrimitive, "source")
+	targetExecutor := newPrimitiveExecutor(execCtx, td.wd.ct.vde.env, td.targetPrimitive, "target")
 	var sourceRow, lastProcessedRow, targetRow []sqltypes.Value
 	advanceSource := true
 	advanceTarget := true
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// tableCopyState is the progress of the copy of a table that has started
+// but not finished. A table copied by a single row stream has the last
+// primary key copied. A table copied in concurrent ranges of its first
+// primary key column, see --vreplication-copy-range-workers, has the
+// progress of each range instead.
+type tableCopyState struct {
+	lastpk *sqltypes.Result
+	ranges *binlogdatapb.CopyRanges
+}
+
+// getLastpk returns the last primary key copied by a single row stream.
+func (cs *tableCopyState) getLastpk() *sqltypes.Result {
+	if cs == nil {
+		return nil
+	}
+	return cs.lastpk
+}
+
+// getRanges returns the progress of a copy split into ranges.
+func (cs *tableCopyState) getRanges() *binlogdatapb.CopyRanges {
+	if cs == nil {
+		return nil
+	}
+	return cs.ranges
+}
+
+// parseTableCopyState parses the lastpk and copy_ranges columns of a
+// _vt.copy_state row. It returns nil if nothing was copied yet.
+func parseTableCopyState(lastpk, copyRanges string) (*tableCopyState, error) {
+	if copyRanges != "" {
+		ranges := &binlogdatapb.CopyRanges{}
+		if err := prototext.Unmarshal([]byte(copyRanges), ranges); err != nil {
+			return nil, err
+		}
+		if !copyRangesStarted(ranges) {
+			return nil, nil
+		}
+		return &tableCopyState{ranges: ranges}, nil
+	}
+	if lastpk != "" {
+		var r querypb.QueryResult
+		if err := prototext.Unmarshal([]byte(lastpk), &r); err != nil {
+			return nil, err
+		}
+		return &tableCopyState{lastpk: sqltypes.Proto3ToResult(&r)}, nil
+	}
+	return nil, nil
+}
+
+// copyRangesStarted returns true if rows were copied in any of the ranges.
+func copyRangesStarted(ranges *binlogdatapb.CopyRanges) bool {
+	for _, copyRange := range ranges.GetRanges() {
+		if copyRange.Lastpk != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/constants/sidecar"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/discovery"
@@ -39,6 +40,7 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
@@ -50,10 +52,11 @@ import (
 )
 
 const (
-	reshardingJournalTableName = "resharding_journal"
-	vreplicationTableName      = "vreplication"
-	copyStateTableName         = "copy_state"
-	postCopyActionTableName    = "post_copy_action"
+	reshardingJournalTableName     = "resharding_journal"
+	vreplicationTableName          = "vreplication"
+	copyStateTableName             = "copy_state"
+	postCopyActionTableName        = "post_copy_action"
+	vreplicationConflictsTableName = "vreplication_conflicts"
 
 	maxRows = 10000
 )
@@ -79,6 +82,10 @@ var rowsCopiedUpdateInterval = 30 * time.Second
 // By default, do it in between every 2nd and 3rd rows copied update.
 var copyStateGCInterval = (rowsCopiedUpdateInterval * 3) - (rowsCopiedUpdateInterval / 2)
 
+// How frequently the engine purges the conflicts older than
+// --vreplication-conflicts-retention, at most.
+var conflictsPurgeInterval = 1 * time.Hour
+
 // Engine is the engine for handling vreplication.
 type Engine struct {
 	// mu synchronizes isOpen, cancelRetry, controllers and wg.
@@ -109,6 +116,10 @@ type Engine struct {
 
 	throttlerClient *throttle.Client
 
+	// conflictsPurgeTicks purges the expired rows of the conflicts table
+	// while the engine is open.
+	conflictsPurgeTicks *timer.Timer
+
 	// This should only be set in Test Engines in order to short
 	// circuit functions as needed in unit tests. It's automatically
 	// enabled in NewSimpleTestEngine. This should NOT be used in
@@ -246,9 +257,49 @@ func (vre *Engine) openLocked(ctx context.Context) error {
 	vre.isOpen = true
 	vre.initControllers(rows)
 	vre.updateStats()
+	vre.startConflictsPurge()
 	return nil
 }
 
+// startConflictsPurge starts purging the conflicts older than the retention,
+// if there is one.
+func (vre *Engine) startConflictsPurge() {
+	retention := vttablet.GetVReplicationConflictsRetention()
+	if retention <= 0 {
+		return
+	}
+	vre.conflictsPurgeTicks = timer.NewTimer(min(retention/2, conflictsPurgeInterval))
+	vre.conflictsPurgeTicks.Start(func() {
+		dbClient := vre.dbClientFactoryFiltered()
+		if err := dbClient.Connect(); err != nil {
+			log.Warn(fmt.Sprintf("Could not purge the vreplication conflicts: %v", err))
+			return
+		}
+		defer dbClient.Close()
+		if _, err := purgeConflicts(dbClient, retention); err != nil {
+			log.Warn(fmt.Sprintf("Could not purge the vreplication conflicts: %v", err))
+		}
+	})
+}
+
+// purgeConflicts deletes the conflicts older than the retention, in batches
+// of maxRows, and returns the number of conflicts deleted.
+func purgeConflicts(dbClient binlogplayer.DBClient, retention time.Duration) (uint64, error) {
+	query := fmt.Sprintf("delete from %s.%s where created_at < now() - interval %d second limit %d",
+		sidecar.GetIdentifier(), vreplicationConflictsTableName, int64(retention.Seconds()), maxRows)
+	var purged uint64
+	for {
+		qr, err := dbClient.ExecuteFetch(query, 0)
+		if err != nil {
+			return purged, err
+		}
+		purged += qr.RowsAffected
+		if qr.RowsAffected < maxRows {
+			return purged, nil
+		}
+	}
+}
+
 var openRetryInterval atomic.Int64
 
 func init() {
@@ -333,6 +384,10 @@ func (vre *Engine) Close() {
 	// Wait for long-running functions to exit.
 	vre.wg.Wait()
 
+	if vre.conflictsPurgeTicks != nil {
+		vre.conflictsPurgeTicks.Stop()
+		vre.conflictsPurgeTicks = nil
+	}
 	vre.isOpen = false
 
 	vre.updateStats()
@@ -444,3 +444,14 @@ func TestGetDBClient(t *testing.T) {
 	shouldBeFilteredClient := vre.getDBClient(false /*runAsAdmin*/)
 	assert.Equal(t, shouldBeFilteredClient, dbClientFiltered)
 }
+
+func TestPurgeConflicts(t *testing.T) {
+	dbClient := binlogplayer.NewMockDBClient(t)
+	query := "delete from _vt.vreplication_conflicts where created_at < now() - interval 3600 second limit 10000"
+	dbClient.ExpectRequest(query, &sqltypes.Result{RowsAffected: maxRows}, nil)
+	dbClient.ExpectRequest(query, &sqltypes.Result{RowsAffected: 5}, nil)
+	purged, err := purgeConflicts(dbClient, time.Hour)
+	require.NoError(t, err)
+	assert.EqualValues(t, maxRows+5, purged)
+	dbClient.Wait()
+}
@@ -65,6 +65,7 @@ type ReplicatorPlan struct {
 	Source         *binlogdatapb.BinlogSource
 	collationEnv   *collations.Environment
 	workflowConfig *vttablet.VReplicationConfig
+	vreplID        int32
 }
 
 // buildExecution plan uses the field info as input and the partially built
@@ -91,7 +92,7 @@ func (rp *ReplicatorPlan) buildExecutionPlan(fieldEvent *binlogdatapb.FieldEvent
 		return &tplanv, nil
 	}
 	// select * construct was used. We need to use the field names.
-	tplan, err := rp.buildFromFields(prelim.TargetName, prelim.Lastpk, fieldEvent.Fields)
+	tplan, err := rp.buildFromFields(prelim.TargetName, prelim.Lastpk, prelim.CopyRanges, fieldEvent.Fields)
 	if err != nil {
 		return nil, vterrors.Wrapf(err, "failed to build replication plan for %s table", fieldEvent.TableName)
 	}
@@ -102,15 +103,17 @@ func (rp *ReplicatorPlan) buildExecutionPlan(fieldEvent *binlogdatapb.FieldEvent
 // buildFromFields builds a full TablePlan, but uses the field info as the
 // full column list. This happens when the query used was a 'select *', which
 // requires us to wait for the field info sent by the source.
-func (rp *ReplicatorPlan) buildFromFields(tableName string, lastpk *sqltypes.Result, fields []*querypb.Field) (*TablePlan, error) {
+func (rp *ReplicatorPlan) buildFromFields(tableName string, lastpk *sqltypes.Result, copyRanges *binlogdatapb.CopyRanges, fields []*querypb.Field) (*TablePlan, error) {
 	tpb := &tablePlanBuilder{
 		name:           sqlparser.NewIdentifierCS(tableName),
 		lastpk:         lastpk,
+		copyRanges:     copyRanges,
 		colInfos:       rp.ColInfoMap[tableName],
 		stats:          rp.stats,
 		source:         rp.Source,
 		collationEnv:   rp.collationEnv,
 		workflowConfig: rp.workflowConfig,
+		vreplID:        rp.vreplID,
 	}
 	for _, field := range fields {
 		colName := sqlparser.NewIdentifierCI(field.Name)
@@ -143,6 +146,9 @@ func (rp *ReplicatorPlan) buildFromFields(tableName string, lastpk *sqltypes.Res
 	if err := tpb.analyzePK(rp.ColInfoMap[tableName]); err != nil {
 		return nil, err
 	}
+	if err := tpb.analyzeConflictPolicy(); err != nil {
+		return nil, err
+	}
 	return tpb.generate(), nil
 }
 
@@ -170,9 +176,9 @@ func (rp *ReplicatorPlan) MarshalJSON() ([]byte, error) {
 // select *), then only TargetName, SendRule and Lastpk are initialized.
 // When the stream returns the field info, those are used as column
 // names to build the final plan.
-// Lastpk comes from copyState. If it's set, then the generated plans
-// are significantly different because any events that fall beyond
-// Lastpk must be excluded.
+// Lastpk, or CopyRanges for a table copied in ranges, comes from
+// copyState. If it's set, then the generated plans are significantly
+// different because any events that fall beyond Lastpk must be excluded.
 // If column names were known upfront, then all fields of TablePlan
 // are built except for Fields. This member is populated only after
 // the field info is received from the stream.
@@ -189,6 +195,9 @@ type TablePlan struct {
 	// will be used for building the final plan after field info
 	// is received.
 	Lastpk *sqltypes.Result
+	// CopyRanges is set instead of Lastpk for a table that is copied in
+	// ranges, and is used the same way.
+	CopyRanges *binlogdatapb.CopyRanges
 	// BulkInsertFront, BulkInsertValues and BulkInsertOnDup are used
 	// by vcopier. These three parts are combined to build bulk insert
 	// statements. This is functionally equivalent to generating
@@ -201,12 +210,20 @@ type TablePlan struct {
 	// If the plan is an insertIgnore type, then Insert
 	// and Update contain 'insert ignore' statements and
 	// Delete is nil.
-	Insert           *sqlparser.ParsedQuery
-	Update           *sqlparser.ParsedQuery
-	Delete           *sqlparser.ParsedQuery
-	MultiDelete      *sqlparser.ParsedQuery
-	Fields           []*querypb.Field
-	ConvertIntToEnum map[string]bool
+	Insert      *sqlparser.ParsedQuery
+	Update      *sqlparser.ParsedQuery
+	Delete      *sqlparser.ParsedQuery
+	MultiDelete *sqlparser.ParsedQuery
+	// ConflictInsertAudit and ConflictUpdateAudit record the rows that
+	// conflict with the target in the conflicts table when the workflow
+	// has a conflict policy. They are executed before the Insert and
+	// Update they audit, which resolve the conflicts themselves.
+	// ConflictCopyAudit does the same for the rows of the copy phase.
+	ConflictInsertAudit *sqlparser.ParsedQuery
+	ConflictUpdateAudit *sqlparser.ParsedQuery
+	ConflictCopyAudit   *sqlparser.ParsedQuery
+	Fields              []*querypb.Field
+	ConvertIntToEnum    map[string]bool
 	// PKReferences is used to check if an event changed
 	// a primary key column (row move).
 	PKReferences []string
@@ -232,25 +249,31 @@ type TablePlan struct {
 // MarshalJSON performs a custom JSON Marshalling.
 func (tp *TablePlan) MarshalJSON() ([]byte, error) {
 	v := struct {
-		TargetName   string
-		SendRule     string
-		InsertFront  *sqlparser.ParsedQuery `json:",omitempty"`
-		InsertValues *sqlparser.ParsedQuery `json:",omitempty"`
-		InsertOnDup  *sqlparser.ParsedQuery `json:",omitempty"`
-		Insert       *sqlparser.ParsedQuery `json:",omitempty"`
-		Update       *sqlparser.ParsedQuery `json:",omitempty"`
-		Delete       *sqlparser.ParsedQuery `json:",omitempty"`
-		PKReferences []string               `json:",omitempty"`
+		TargetName          string
+		SendRule            string
+		InsertFront         *sqlparser.ParsedQuery `json:",omitempty"`
+		InsertValues        *sqlparser.ParsedQuery `json:",omitempty"`
+		InsertOnDup         *sqlparser.ParsedQuery `json:",omitempty"`
+		Insert              *sqlparser.ParsedQuery `json:",omitempty"`
+		Update              *sqlparser.ParsedQuery `json:",omitempty"`
+		Delete              *sqlparser.ParsedQuery `json:",omitempty"`
+		PKReferences        []string               `json:",omitempty"`
+		ConflictInsertAudit *sqlparser.ParsedQuery `json:",omitempty"`
+		ConflictUpdateAudit *sqlparser.ParsedQuery `json:",omitempty"`
+		ConflictCopyAudit   *sqlparser.ParsedQuery `json:",omitempty"`
 	}{
-		TargetName:   tp.TargetName,
-		SendRule:     tp.SendRule.Match,
-		InsertFront:  tp.BulkInsertFront,
-		InsertValues: tp.BulkInsertValues,
-		InsertOnDup:  tp.BulkInsertOnDup,
-		Insert:       tp.Insert,
-		Update:       tp.Update,
-		Delete:       tp.Delete,
-		PKReferences: tp.PKReferences,
+		TargetName:          tp.TargetName,
+		SendRule:            tp.SendRule.Match,
+		InsertFront:         tp.BulkInsertFront,
+		InsertValues:        tp.BulkInsertValues,
+		InsertOnDup:         tp.BulkInsertOnDup,
+		Insert:              tp.Insert,
+		Update:              tp.Update,
+		Delete:              tp.Delete,
+		PKReferences:        tp.PKReferences,
+		ConflictInsertAudit: tp.ConflictInsertAudit,
+		ConflictUpdateAudit: tp.ConflictUpdateAudit,
+		ConflictCopyAudit:   tp.ConflictCopyAudit,
 	}
 	return json.Marshal(&v)
 }
@@ -444,6 +467,9 @@ func (tp *TablePlan) applyBulkInsert(sqlbuffer *bytes2.Buffer, rows []*querypb.R
 				return nil, err
 			}
 		}
+		if err := tp.auditCopyConflict(row, executor); err != nil {
+			return nil, err
+		}
 		beforeLen := sqlbuffer.Len()
 		if rowCount > 0 {
 			sqlbuffer.WriteString(", ")
@@ -707,6 +733,9 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 		if err := tp.bindAfterJSONFieldVals(rowChange, afterVals, bindvars); err != nil {
 			return nil, err
 		}
+		if err := tp.auditConflict(tp.ConflictInsertAudit, bindvars, executor); err != nil {
+			return nil, err
+		}
 		if tp.isPartial(rowChange) {
 			ins, err := tp.getPartialInsertQuery(rowChange.DataColumns)
 			if err != nil {
@@ -732,6 +761,9 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 			if err := tp.bindAfterJSONFieldVals(rowChange, afterVals, bindvars); err != nil {
 				return nil, err
 			}
+			if err := tp.auditConflict(tp.ConflictUpdateAudit, bindvars, executor); err != nil {
+				return nil, err
+			}
 			if tp.isPartial(rowChange) {
 				upd, err := tp.getPartialUpdateQuery(rowChange.DataColumns)
 				if err != nil {
@@ -824,12 +856,44 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 				}
 			}
 		}
+		if err := tp.auditConflict(tp.ConflictInsertAudit, bindvars, executor); err != nil {
+			return nil, err
+		}
 		return execParsedQuery(tp.Insert, bindvars, executor)
 	}
 	// Unreachable.
 	return nil, nil
 }
 
+// auditConflict records the conflict the row change may cause with the target
+// row, if the workflow has a conflict policy. This must be done before the
+// change is applied, as the resolution depends on the current target row.
+func (tp *TablePlan) auditConflict(audit *sqlparser.ParsedQuery, bindvars map[string]*querypb.BindVariable, executor func(string) (*sqltypes.Result, error)) error {
+	if audit == nil {
+		return nil
+	}
+	_, err := execParsedQuery(audit, bindvars, executor)
+	return err
+}
+
+// auditCopyConflict records the conflict a copied row may cause with the
+// target row, if the workflow has a conflict policy.
+func (tp *TablePlan) auditCopyConflict(row *querypb.Row, executor func(string) (*sqltypes.Result, error)) error {
+	if tp.ConflictCopyAudit == nil {
+		return nil
+	}
+	vals := sqltypes.MakeRowTrusted(tp.Fields, row)
+	bindvars := make(map[string]*querypb.BindVariable, len(tp.Fields))
+	for i, field := range tp.Fields {
+		bindVar, err := tp.bindFieldVal(field, &vals[i])
+		if err != nil {
+			return err
+		}
+		bindvars["a_"+field.Name] = bindVar
+	}
+	return tp.auditConflict(tp.ConflictCopyAudit, bindvars, executor)
+}
+
 // applyBulkDeleteChanges applies a bulk DELETE statement from the row changes
 // to the target table -- which resulted from a DELETE statement executed on the
 // source that deleted N rows -- using an IN clause with the primary key values
@@ -747,14 +747,14 @@ func TestBuildPlayerPlan(t *testing.T) {
 		"t1": {&ColumnInfo{Name: "c1", IsPK: true}},
 	}
 
-	copyState := map[string]*sqltypes.Result{
-		"t1": sqltypes.MakeTestResult(
+	copyState := map[string]*tableCopyState{
+		"t1": {lastpk: sqltypes.MakeTestResult(
 			sqltypes.MakeTestFields(
 				"pk1|pk2",
 				"int64|varchar",
 			),
 			"1|aaa",
-		),
+		)},
 	}
 
 	vttablet.InitVReplicationConfigDefaults()
@@ -848,6 +848,65 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 	assert.Equal(t, string(wantPlan), string(gotPlan))
 }
 
+func TestBuildPlayerPlanCopyRanges(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "c1", IsPK: true}},
+	}
+	input := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select c1, c2 from t1",
+		}},
+	}
+	// The first and last ranges copied some rows, the middle one did not
+	// start yet.
+	copyState := map[string]*tableCopyState{
+		"t1": {ranges: &binlogdatapb.CopyRanges{
+			Pkfields: sqltypes.MakeTestFields("c1", "int64"),
+			Ranges: []*binlogdatapb.CopyRange{{
+				End:    sqltypes.ValueToProto(sqltypes.NewInt64(10)),
+				Lastpk: sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(5)}),
+			}, {
+				Start: sqltypes.ValueToProto(sqltypes.NewInt64(10)),
+				End:   sqltypes.ValueToProto(sqltypes.NewInt64(20)),
+			}, {
+				Start:  sqltypes.ValueToProto(sqltypes.NewInt64(20)),
+				Lastpk: sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(25)}),
+			}},
+		}},
+	}
+	vr := &vreplicator{
+		workflowConfig: vttablet.DefaultVReplicationConfig,
+	}
+	plan, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, copyState, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	require.NoError(t, err)
+
+	want := &TestReplicatorPlan{
+		VStreamFilter: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, c2, c1 from t1",
+			}},
+		},
+		TargetTables: []string{"t1"},
+		TablePlans: map[string]*TestTablePlan{
+			"t1": {
+				TargetName:   "t1",
+				SendRule:     "t1",
+				PKReferences: []string{"c1"},
+				InsertFront:  "insert into t1(c1,c2)",
+				InsertValues: "(:a_c1,:a_c2)",
+				Insert:       "insert into t1(c1,c2) select :a_c1, :a_c2 from dual where ((:a_c1) <= (5) or :a_c1 >= 20 and (:a_c1) <= (25))",
+				Update:       "update t1 set c2=:a_c2 where c1=:b_c1 and ((:b_c1) <= (5) or :b_c1 >= 20 and (:b_c1) <= (25))",
+				Delete:       "delete from t1 where c1=:b_c1 and ((:b_c1) <= (5) or :b_c1 >= 20 and (:b_c1) <= (25))",
+			},
+		},
+	}
+	gotPlan, _ := json.Marshal(plan)
+	wantPlan, _ := json.Marshal(want)
+	assert.Equal(t, string(wantPlan), string(gotPlan))
+}
+
 func TestAppendFromRow(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -2052,3 +2111,234 @@ func BenchmarkAppendFromRowLargeJSON(b *testing.B) {
 		}
 	}
 }
+
+func TestBuildPlayerPlanConflictPolicy(t *testing.T) {
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}, {Name: "updated_at"}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match:  "t1",
+			Filter: "select c1, c2, updated_at from t1",
+		}},
+	}
+	testcases := []struct {
+		name                string
+		policy              string
+		tsColumn            string
+		filter              string
+		insert              string
+		update              string
+		conflictInsertAudit string
+		conflictUpdateAudit string
+		err                 string
+	}{{
+		name:   "none",
+		policy: vttablet.ConflictPolicyNone,
+		insert: "insert into t1(c1,c2,updated_at) values (:a_c1,:a_c2,:a_updated_at)",
+		update: "update t1 set c2=:a_c2, updated_at=:a_updated_at where c1=:b_c1",
+	}, {
+		name:                "source wins",
+		policy:              vttablet.ConflictPolicySourceWins,
+		insert:              "insert into t1(c1,c2,updated_at) values (:a_c1,:a_c2,:a_updated_at) on duplicate key update c2=values(c2), updated_at=values(updated_at)",
+		update:              "update t1 set c2=:a_c2, updated_at=:a_updated_at where c1=:b_c1",
+		conflictInsertAudit: "insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'insert', 'source-wins', 'source' from t1 where c1=:a_c1",
+	}, {
+		name:                "target wins",
+		policy:              vttablet.ConflictPolicyTargetWins,
+		insert:              "insert into t1(c1,c2,updated_at) values (:a_c1,:a_c2,:a_updated_at) on duplicate key update c1=c1",
+		update:              "update t1 set c2=:a_c2, updated_at=:a_updated_at where c1=:b_c1",
+		conflictInsertAudit: "insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'insert', 'target-wins', 'target' from t1 where c1=:a_c1",
+	}, {
+		name:                "latest timestamp",
+		policy:              vttablet.ConflictPolicyLatestTimestamp,
+		tsColumn:            "updated_at",
+		insert:              "insert into t1(c1,c2,updated_at) values (:a_c1,:a_c2,:a_updated_at) on duplicate key update c2=if(values(updated_at)>=updated_at, values(c2), c2), updated_at=if(values(updated_at)>=updated_at, values(updated_at), updated_at)",
+		update:              "update t1 set c2=:a_c2, updated_at=:a_updated_at where c1=:b_c1 and updated_at<=:a_updated_at",
+		conflictInsertAudit: "insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'insert', 'latest-timestamp', if(:a_updated_at>=updated_at, 'source', 'target') from t1 where c1=:a_c1",
+		conflictUpdateAudit: "insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'update', 'latest-timestamp', 'target' from t1 where c1=:b_c1 and (updated_at<=:a_updated_at) is not true",
+	}, {
+		name:   "latest timestamp without column",
+		policy: vttablet.ConflictPolicyLatestTimestamp,
+		err:    "vreplication-conflict-timestamp-column must be set for the latest-timestamp conflict policy",
+	}, {
+		name:     "latest timestamp with unknown column",
+		policy:   vttablet.ConflictPolicyLatestTimestamp,
+		tsColumn: "modified_at",
+		err:      "conflict timestamp column modified_at is not a replicated column of table t1",
+	}, {
+		name:   "grouped expressions",
+		policy: vttablet.ConflictPolicySourceWins,
+		filter: "select c1, count(*) as c2 from t1 group by c1",
+		insert: "insert into t1(c1,c2) values (:a_c1,1) on duplicate key update c2=c2+1",
+		update: "update t1 set c2=c2 where c1=:b_c1",
+	}}
+	vttablet.InitVReplicationConfigDefaults()
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			workflowConfig := *vttablet.DefaultVReplicationConfig
+			workflowConfig.ConflictPolicy = tcase.policy
+			workflowConfig.ConflictTimestampColumn = tcase.tsColumn
+			vr := &vreplicator{
+				id:             7,
+				workflowConfig: &workflowConfig,
+			}
+			input := filter.CloneVT()
+			if tcase.filter != "" {
+				input.Rules[0].Filter = tcase.filter
+			}
+			plan, err := vr.buildReplicatorPlan(getSource(input), colInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+			if tcase.err != "" {
+				require.ErrorContains(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+			tp := plan.TargetTables["t1"]
+			require.NotNil(t, tp)
+			queryOf := func(pq *sqlparser.ParsedQuery) string {
+				if pq == nil {
+					return ""
+				}
+				return pq.Query
+			}
+			assert.Equal(t, tcase.insert, queryOf(tp.Insert))
+			assert.Equal(t, tcase.update, queryOf(tp.Update))
+			assert.Equal(t, tcase.conflictInsertAudit, queryOf(tp.ConflictInsertAudit))
+			assert.Equal(t, tcase.conflictUpdateAudit, queryOf(tp.ConflictUpdateAudit))
+		})
+	}
+}
+
+func TestApplyChangeAuditsConflicts(t *testing.T) {
+	vttablet.InitVReplicationConfigDefaults()
+	workflowConfig := *vttablet.DefaultVReplicationConfig
+	workflowConfig.ConflictPolicy = vttablet.ConflictPolicyLatestTimestamp
+	workflowConfig.ConflictTimestampColumn = "updated_at"
+	vr := &vreplicator{
+		id:             7,
+		workflowConfig: &workflowConfig,
+	}
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}, {Name: "updated_at"}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{Match: "t1"}},
+	}
+	plan, err := vr.buildReplicatorPlan(getSource(filter), colInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	require.NoError(t, err)
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{
+		TableName: "t1",
+		Fields:    sqltypes.MakeTestFields("c1|c2|updated_at", "int64|varchar|int64"),
+	})
+	require.NoError(t, err)
+
+	var executed []string
+	executor := func(sql string) (*sqltypes.Result, error) {
+		executed = append(executed, sql)
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}
+	before := sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NewInt64(10)})
+	after := sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("b"), sqltypes.NewInt64(20)})
+
+	_, err = tp.applyChange(&binlogdatapb.RowChange{After: before}, executor)
+	require.NoError(t, err)
+	_, err = tp.applyChange(&binlogdatapb.RowChange{Before: before, After: after}, executor)
+	require.NoError(t, err)
+	_, err = tp.applyChange(&binlogdatapb.RowChange{Before: after}, executor)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'insert', 'latest-timestamp', if(10>=updated_at, 'source', 'target') from t1 where c1=1",
+		"insert into t1(c1,c2,updated_at) values (1,'a',10) on duplicate key update c2=if(values(updated_at)>=updated_at, values(c2), c2), updated_at=if(values(updated_at)>=updated_at, values(updated_at), updated_at)",
+		"insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'update', 'latest-timestamp', 'target' from t1 where c1=1 and (updated_at<=20) is not true",
+		"update t1 set c2='b', updated_at=20 where c1=1 and updated_at<=20",
+		"delete from t1 where c1=1",
+	}, executed)
+}
+
+func TestApplyBulkInsertAuditsCopyConflicts(t *testing.T) {
+	vttablet.InitVReplicationConfigDefaults()
+	workflowConfig := *vttablet.DefaultVReplicationConfig
+	workflowConfig.ConflictPolicy = vttablet.ConflictPolicyTargetWins
+	vr := &vreplicator{
+		id:             7,
+		workflowConfig: &workflowConfig,
+	}
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{Match: "t1"}},
+	}
+	lastpk := sqltypes.MakeTestResult(sqltypes.MakeTestFields("c1", "int64"), "1")
+	copyState := map[string]*tableCopyState{"t1": {lastpk: lastpk}}
+	plan, err := vr.buildReplicatorPlan(getSource(filter), colInfos, copyState, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	require.NoError(t, err)
+	tp, err := plan.buildExecutionPlan(&binlogdatapb.FieldEvent{
+		TableName: "t1",
+		Fields:    sqltypes.MakeTestFields("c1|c2", "int64|varchar"),
+	})
+	require.NoError(t, err)
+	// Replayed inserts are only audited for the rows already copied, while
+	// copied rows are all beyond the lastpk.
+	assert.Equal(t, "insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'insert', 'target-wins', 'target' from t1 where c1=:a_c1 and (:a_c1) <= (1)", tp.ConflictInsertAudit.Query)
+
+	var executed []string
+	rows := []*querypb.Row{
+		sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(2), sqltypes.NewVarChar("a")}),
+		sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(3), sqltypes.NewVarChar("b")}),
+	}
+	_, err = tp.applyBulkInsert(&bytes2.Buffer{}, rows, func(sql string) (*sqltypes.Result, error) {
+		executed = append(executed, sql)
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'copy', 'target-wins', 'target' from t1 where c1=2",
+		"insert into _vt.vreplication_conflicts(vrepl_id, table_name, pk, event, policy, resolution) select 7, 't1', concat_ws(',', c1), 'copy', 'target-wins', 'target' from t1 where c1=3",
+		"insert into t1(c1,c2) values (2,'a'), (3,'b') on duplicate key update c1=c1",
+	}, executed)
+}
+
+func TestBuildPlayerPlanConflictPolicyWorkflowTypes(t *testing.T) {
+	vttablet.InitVReplicationConfigDefaults()
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{Match: "t1"}},
+	}
+	testcases := []struct {
+		workflowType binlogdatapb.VReplicationWorkflowType
+		err          string
+	}{{
+		workflowType: binlogdatapb.VReplicationWorkflowType_Materialize,
+	}, {
+		workflowType: binlogdatapb.VReplicationWorkflowType_Migrate,
+	}, {
+		workflowType: binlogdatapb.VReplicationWorkflowType_MoveTables,
+		err:          "the source-wins conflict policy is not supported for MoveTables workflows",
+	}, {
+		workflowType: binlogdatapb.VReplicationWorkflowType_Reshard,
+		err:          "the source-wins conflict policy is not supported for Reshard workflows",
+	}, {
+		workflowType: binlogdatapb.VReplicationWorkflowType_OnlineDDL,
+		err:          "the source-wins conflict policy is not supported for OnlineDDL workflows",
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.workflowType.String(), func(t *testing.T) {
+			workflowConfig := *vttablet.DefaultVReplicationConfig
+			workflowConfig.ConflictPolicy = vttablet.ConflictPolicySourceWins
+			vr := &vreplicator{
+				id:             7,
+				workflowConfig: &workflowConfig,
+				WorkflowType:   int32(tcase.workflowType),
+			}
+			_, err := vr.buildReplicatorPlan(getSource(filter), colInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+			if tcase.err != "" {
+				require.ErrorContains(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 
+	"vitess.io/vitess/go/constants/sidecar"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/textutil"
@@ -57,6 +58,7 @@ type tablePlanBuilder struct {
 	pkCols            []*colExpr
 	extraSourcePkCols []*colExpr
 	lastpk            *sqltypes.Result
+	copyRanges        *binlogdatapb.CopyRanges
 	colInfos          []*ColumnInfo
 	stats             *binlogplayer.Stats
 	source            *binlogdatapb.BinlogSource
@@ -64,6 +66,12 @@ type tablePlanBuilder struct {
 
 	collationEnv   *collations.Environment
 	workflowConfig *vttablet.VReplicationConfig
+
+	// vreplID is the id of the workflow stream, recorded in conflict audits.
+	vreplID int32
+	// conflictTSCol is the column compared to resolve conflicts when the
+	// workflow uses vttablet.ConflictPolicyLatestTimestamp.
+	conflictTSCol *colExpr
 }
 
 // colExpr describes the processing to be performed to
@@ -127,14 +135,18 @@ const (
 // copyState is a map of tables that have not been fully copied yet.
 // If a table is not present in copyState, then it has been fully copied. If so,
 // all replication events are applied. The table still has to match a Filter.Rule.
-// If it has a non-nil entry, then the value is the last primary key (lastpk)
-// that was copied.  If so, only replication events < lastpk are applied.
+// If it has a non-nil entry, then the value has the last primary key (lastpk)
+// that was copied, or that of each range for a table copied in ranges. If so,
+// only replication events < lastpk are applied.
 // If the entry is nil, then copying of the table has not started yet. If so,
 // no events are applied.
 // The TablePlan built is a partial plan. The full plan for a table is built
 // when we receive field information from events or rows sent by the source.
 // buildExecutionPlan is the function that builds the full plan.
-func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*sqltypes.Result, stats *binlogplayer.Stats, collationEnv *collations.Environment, parser *sqlparser.Parser) (*ReplicatorPlan, error) {
+func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*tableCopyState, stats *binlogplayer.Stats, collationEnv *collations.Environment, parser *sqlparser.Parser) (*ReplicatorPlan, error) {
+	if err := vr.validateConflictPolicy(); err != nil {
+		return nil, err
+	}
 	filter := source.Filter
 	plan := &ReplicatorPlan{
 		VStreamFilter:  &binlogdatapb.Filter{FieldEventMode: filter.FieldEventMode},
@@ -145,10 +157,11 @@ func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, co
 		Source:         source,
 		collationEnv:   collationEnv,
 		workflowConfig: vr.workflowConfig,
+		vreplID:        vr.id,
 	}
 	for tableName := range colInfoMap {
-		lastpk, ok := copyState[tableName]
-		if ok && lastpk == nil {
+		state, ok := copyState[tableName]
+		if ok && state == nil {
 			// Don't replicate uncopied tables.
 			continue
 		}
@@ -163,7 +176,7 @@ func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, co
 		if !ok {
 			return nil, fmt.Errorf("table %s not found in schema", tableName)
 		}
-		tablePlan, err := buildTablePlan(tableName, rule, colInfos, lastpk, stats, source, collationEnv, parser, vr.workflowConfig)
+		tablePlan, err := buildTablePlan(tableName, rule, colInfos, state, stats, source, collationEnv, parser, vr.workflowConfig, vr.id)
 		if err != nil {
 			return nil, vterrors.Wrapf(err, "failed to build table replication plan for %s table", tableName)
 		}
@@ -202,9 +215,9 @@ func MatchTable(tableName string, filter *binlogdatapb.Filter) (*binlogdatapb.Ru
 	return nil, nil
 }
 
-func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*ColumnInfo, lastpk *sqltypes.Result,
+func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*ColumnInfo, copyState *tableCopyState,
 	stats *binlogplayer.Stats, source *binlogdatapb.BinlogSource, collationEnv *collations.Environment,
-	parser *sqlparser.Parser, workflowConfig *vttablet.VReplicationConfig,
+	parser *sqlparser.Parser, workflowConfig *vttablet.VReplicationConfig, vreplID int32,
 ) (*TablePlan, error) {
 	planError := func(err error, query string) error {
 		// Use the error string here to ensure things are uniform across
@@ -248,7 +261,8 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 		tablePlan := &TablePlan{
 			TargetName:       tableName,
 			SendRule:         sendRule,
-			Lastpk:           lastpk,
+			Lastpk:           copyState.getLastpk(),
+			CopyRanges:       copyState.getRanges(),
 			Stats:            stats,
 			ConvertCharset:   rule.ConvertCharset,
 			ConvertIntToEnum: rule.ConvertIntToEnum,
@@ -265,12 +279,14 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 			From:  sel.From,
 			Where: sel.Where,
 		},
-		lastpk:         lastpk,
+		lastpk:         copyState.getLastpk(),
+		copyRanges:     copyState.getRanges(),
 		colInfos:       colInfos,
 		stats:          stats,
 		source:         source,
 		collationEnv:   collationEnv,
 		workflowConfig: workflowConfig,
+		vreplID:        vreplID,
 	}
 
 	if err := tpb.analyzeExprs(sel.SelectExprs.Exprs); err != nil {
@@ -283,10 +299,8 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	// the missing columns so we can compare against those values.
 	// If there is no lastpk to validate against, then we don't
 	// care.
-	if tpb.lastpk != nil {
-		for _, f := range tpb.lastpk.Fields {
-			tpb.addCol(sqlparser.NewIdentifierCI(f.Name))
-		}
+	for _, f := range tpb.pkConstraintFields() {
+		tpb.addCol(sqlparser.NewIdentifierCI(f.Name))
 	}
 	if err := tpb.analyzeGroupBy(sel.GroupBy); err != nil {
 		return nil, planError(err, sqlparser.String(sel))
@@ -307,6 +321,9 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	if err := tpb.analyzeExtraSourcePkCols(colInfos, sourceKeyTargetColumnNames); err != nil {
 		return nil, err
 	}
+	if err := tpb.analyzeConflictPolicy(); err != nil {
+		return nil, err
+	}
 
 	// if there are no columns being selected the select expression can be empty, so we "select 1" so we have a valid
 	// select to get a row back
@@ -344,10 +361,8 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 			refmap[k] = true
 		}
 	}
-	if tpb.lastpk != nil {
-		for _, f := range tpb.lastpk.Fields {
-			refmap[f.Name] = true
-		}
+	for _, f := range tpb.pkConstraintFields() {
+		refmap[f.Name] = true
 	}
 	pkrefs := make([]string, 0, len(refmap))
 	for k := range refmap {
@@ -366,6 +381,7 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 	return &TablePlan{
 		TargetName:              tpb.name.String(),
 		Lastpk:                  tpb.lastpk,
+		CopyRanges:              tpb.copyRanges,
 		BulkInsertFront:         tpb.generateInsertPart(sqlparser.NewTrackedBuffer(bvf.formatter)),
 		BulkInsertValues:        tpb.generateValuesPart(sqlparser.NewTrackedBuffer(bvf.formatter), bvf),
 		BulkInsertOnDup:         tpb.generateOnDupPart(sqlparser.NewTrackedBuffer(bvf.formatter)),
@@ -373,6 +389,9 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 		Update:                  tpb.generateUpdateStatement(),
 		Delete:                  tpb.generateDeleteStatement(),
 		MultiDelete:             tpb.generateMultiDeleteStatement(),
+		ConflictInsertAudit:     tpb.generateConflictInsertAudit("insert", true),
+		ConflictCopyAudit:       tpb.generateConflictInsertAudit("copy", false),
+		ConflictUpdateAudit:     tpb.generateConflictUpdateAudit(),
 		PKReferences:            pkrefs,
 		PKIndices:               tpb.pkIndices,
 		Stats:                   tpb.stats,
@@ -657,6 +676,38 @@ func (tpb *tablePlanBuilder) analyzeExtraSourcePkCols(colInfos []*ColumnInfo, so
 	return nil
 }
 
+// analyzeConflictPolicy validates the conflict policy of the workflow against
+// the table. Conflict policies only apply to plain row copies: for grouped
+// expressions, duplicate keys are how rows are aggregated.
+func (tpb *tablePlanBuilder) analyzeConflictPolicy() error {
+	if !tpb.resolvesConflicts() || tpb.workflowConfig.ConflictPolicy != vttablet.ConflictPolicyLatestTimestamp {
+		return nil
+	}
+	if tpb.workflowConfig.ConflictTimestampColumn == "" {
+		return errors.New("vreplication-conflict-timestamp-column must be set for the latest-timestamp conflict policy")
+	}
+	name := sqlparser.NewIdentifierCI(tpb.workflowConfig.ConflictTimestampColumn)
+	cexpr := tpb.findCol(name)
+	if cexpr == nil || cexpr.operation != opExpr || cexpr.isGenerated {
+		return fmt.Errorf("conflict timestamp column %s is not a replicated column of table %s", sqlparser.String(name), sqlparser.String(tpb.name))
+	}
+	tpb.conflictTSCol = cexpr
+	return nil
+}
+
+// resolvesConflicts returns true if rows that collide with existing target
+// rows must be resolved using the conflict policy of the workflow.
+func (tpb *tablePlanBuilder) resolvesConflicts() bool {
+	if tpb.onInsert != insertNormal || tpb.workflowConfig == nil {
+		return false
+	}
+	switch tpb.workflowConfig.ConflictPolicy {
+	case "", vttablet.ConflictPolicyNone:
+		return false
+	}
+	return true
+}
+
 // findCol finds a column in a list of expressions
 func findCol(name sqlparser.IdentifierCI, exprs []*colExpr) *colExpr {
 	for _, cexpr := range exprs {
@@ -676,7 +727,7 @@ func (tpb *tablePlanBuilder) generateInsertStatement() *sqlparser.ParsedQuery {
 	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
 
 	tpb.generateInsertPart(buf)
-	if tpb.lastpk == nil {
+	if !tpb.hasPKConstraint() {
 		// If there's no lastpk, generate straight values.
 		buf.Myprintf(" values ", tpb.name)
 		tpb.generateValuesPart(buf, bvf)
@@ -769,6 +820,9 @@ func (tpb *tablePlanBuilder) generateSelectPart(buf *sqlparser.TrackedBuffer, bv
 }
 
 func (tpb *tablePlanBuilder) generateOnDupPart(buf *sqlparser.TrackedBuffer) *sqlparser.ParsedQuery {
+	if tpb.resolvesConflicts() {
+		return tpb.generateConflictOnDupPart(buf, nil)
+	}
 	if tpb.onInsert != insertOnDup {
 		return nil
 	}
@@ -803,6 +857,152 @@ func (tpb *tablePlanBuilder) generateOnDupPart(buf *sqlparser.TrackedBuffer) *sq
 	return buf.ParsedQuery()
 }
 
+// generateConflictOnDupPart generates the on duplicate key clause that
+// resolves an insert colliding with an existing target row. If dataColumns
+// is set, only those columns are resolved, as for partial row images.
+func (tpb *tablePlanBuilder) generateConflictOnDupPart(buf *sqlparser.TrackedBuffer, dataColumns *binlogdatapb.RowChange_Bitmap) *sqlparser.ParsedQuery {
+	buf.Myprintf(" on duplicate key update ")
+	// A no-op assignment keeps the target row as is.
+	keepTarget := func() *sqlparser.ParsedQuery {
+		buf.Myprintf("%v=%v", tpb.pkCols[0].colName, tpb.pkCols[0].colName)
+		return buf.ParsedQuery()
+	}
+	if tpb.workflowConfig.ConflictPolicy == vttablet.ConflictPolicyTargetWins {
+		return keepTarget()
+	}
+	separator := ""
+	assign := func(cexpr *colExpr) {
+		buf.Myprintf("%s%v=", separator, cexpr.colName)
+		separator = ", "
+		if ts := tpb.conflictTSCol; ts != nil {
+			buf.Myprintf("if(values(%v)>=%v, values(%v), %v)", ts.colName, ts.colName, cexpr.colName, cexpr.colName)
+			return
+		}
+		buf.Myprintf("values(%v)", cexpr.colName)
+	}
+	tsIncluded := false
+	for i, cexpr := range tpb.colExprs {
+		if cexpr.isPK || cexpr.isGenerated {
+			continue
+		}
+		if dataColumns != nil && !isBitSet(dataColumns.Cols, i) {
+			continue
+		}
+		if cexpr == tpb.conflictTSCol {
+			tsIncluded = true
+			continue
+		}
+		assign(cexpr)
+	}
+	if tpb.conflictTSCol != nil {
+		if !tsIncluded {
+			// The row image does not allow comparing the rows.
+			return nil
+		}
+		// Assignments are evaluated in order, so the timestamp must be
+		// assigned last for the preceding ones to compare the target's.
+		assign(tpb.conflictTSCol)
+	}
+	if separator == "" {
+		return keepTarget()
+	}
+	return buf.ParsedQuery()
+}
+
+// generateConflictTimestamp generates the after value of the conflict
+// timestamp column, converted the same way as the value being written.
+func (tpb *tablePlanBuilder) generateConflictTimestamp(buf *sqlparser.TrackedBuffer, bvf *bindvarFormatter) {
+	bvf.mode = bvAfter
+	ts := tpb.conflictTSCol
+	sourceTZ := tpb.source.SourceTimeZone
+	targetTZ := tpb.source.TargetTimeZone
+	if ts.colType == querypb.Type_DATETIME && sourceTZ != "" && targetTZ != "" {
+		buf.Myprintf("convert_tz(%v, '%s', '%s')", ts.expr, sourceTZ, targetTZ)
+		return
+	}
+	buf.Myprintf("%v", ts.expr)
+}
+
+// generateConflictUpdateCondition generates the condition that restricts
+// updates to target rows that are not more recent than the source row.
+func (tpb *tablePlanBuilder) generateConflictUpdateCondition(buf *sqlparser.TrackedBuffer, bvf *bindvarFormatter) {
+	if tpb.conflictTSCol == nil {
+		return
+	}
+	buf.Myprintf(" and %v<=", tpb.conflictTSCol.colName)
+	tpb.generateConflictTimestamp(buf, bvf)
+}
+
+// generateConflictAuditPart generates the front of a statement that records a
+// conflict for every target row selected by the rest of the statement.
+func (tpb *tablePlanBuilder) generateConflictAuditPart(buf *sqlparser.TrackedBuffer, event string) {
+	buf.Myprintf("insert into %s.%s(vrepl_id, table_name, pk, event, policy, resolution) select %d, %v, concat_ws(',', ",
+		sidecar.GetIdentifier(), vreplicationConflictsTableName, tpb.vreplID, sqlparser.NewStrLiteral(tpb.name.String()))
+	separator := ""
+	for _, cexpr := range tpb.pkCols {
+		buf.Myprintf("%s%v", separator, cexpr.colName)
+		separator = ", "
+	}
+	buf.Myprintf("), %v, %v, ", sqlparser.NewStrLiteral(event), sqlparser.NewStrLiteral(tpb.workflowConfig.ConflictPolicy))
+}
+
+// generateConflictInsertAudit generates the statement that records an insert
+// colliding with an existing target row. It must be executed before the
+// insert, while the target row is unchanged. Rows inserted by the copy phase
+// are beyond the copied primary keys, so their audit has no PK constraint.
+func (tpb *tablePlanBuilder) generateConflictInsertAudit(event string, pkConstraint bool) *sqlparser.ParsedQuery {
+	if !tpb.resolvesConflicts() {
+		return nil
+	}
+	bvf := &bindvarFormatter{}
+	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
+	tpb.generateConflictAuditPart(buf, event)
+	switch tpb.workflowConfig.ConflictPolicy {
+	case vttablet.ConflictPolicySourceWins:
+		buf.WriteString("'source'")
+	case vttablet.ConflictPolicyTargetWins:
+		buf.WriteString("'target'")
+	case vttablet.ConflictPolicyLatestTimestamp:
+		buf.WriteString("if(")
+		tpb.generateConflictTimestamp(buf, bvf)
+		buf.Myprintf(">=%v, 'source', 'target')", tpb.conflictTSCol.colName)
+	}
+	buf.Myprintf(" from %v where ", tpb.name)
+	bvf.mode = bvAfter
+	separator := ""
+	for _, cexpr := range tpb.pkCols {
+		if _, ok := cexpr.expr.(*sqlparser.ColName); ok {
+			buf.Myprintf("%s%v=%v", separator, cexpr.colName, cexpr.expr)
+		} else {
+			buf.Myprintf("%s%v=(%v)", separator, cexpr.colName, cexpr.expr)
+		}
+		separator = " and "
+	}
+	if pkConstraint && tpb.hasPKConstraint() {
+		buf.WriteString(" and ")
+		tpb.generatePKConstraint(buf, bvf)
+	}
+	return buf.ParsedQuery()
+}
+
+// generateConflictUpdateAudit generates the statement that records an update
+// discarded because the target row is more recent. It must be executed
+// before the update.
+func (tpb *tablePlanBuilder) generateConflictUpdateAudit() *sqlparser.ParsedQuery {
+	if tpb.conflictTSCol == nil {
+		return nil
+	}
+	bvf := &bindvarFormatter{}
+	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
+	tpb.generateConflictAuditPart(buf, "update")
+	buf.Myprintf("'target' from %v", tpb.name)
+	tpb.generateWhere(buf, bvf)
+	buf.Myprintf(" and (%v<=", tpb.conflictTSCol.colName)
+	tpb.generateConflictTimestamp(buf, bvf)
+	buf.WriteString(") is not true")
+	return buf.ParsedQuery()
+}
+
 func (tpb *tablePlanBuilder) generateUpdateStatement() *sqlparser.ParsedQuery {
 	if tpb.onInsert == insertIgnore {
 		return tpb.generateInsertStatement()
@@ -849,6 +1049,7 @@ func (tpb *tablePlanBuilder) generateUpdateStatement() *sqlparser.ParsedQuery {
 		}
 	}
 	tpb.generateWhere(buf, bvf)
+	tpb.generateConflictUpdateCondition(buf, bvf)
 	return buf.ParsedQuery()
 }
 
@@ -918,7 +1119,7 @@ func (tpb *tablePlanBuilder) generateWhere(buf *sqlparser.TrackedBuffer, bvf *bi
 	}
 	addWhereColumns(tpb.pkCols)
 	addWhereColumns(tpb.extraSourcePkCols)
-	if tpb.lastpk != nil {
+	if tpb.hasPKConstraint() {
 		buf.WriteString(" and ")
 		tpb.generatePKConstraint(buf, bvf)
 	}
@@ -938,21 +1139,70 @@ func (tpb *tablePlanBuilder) getCharsetAndCollation(pkname string) (charSet stri
 	return charSet, collation
 }
 
+// hasPKConstraint returns true if the table is being copied, in which case
+// events must only be applied to the rows that were copied so far.
+func (tpb *tablePlanBuilder) hasPKConstraint() bool {
+	return tpb.lastpk != nil || tpb.copyRanges != nil
+}
+
+// pkConstraintFields returns the primary key fields that the rows of events
+// are compared against to check whether they were copied.
+func (tpb *tablePlanBuilder) pkConstraintFields() []*querypb.Field {
+	switch {
+	case tpb.copyRanges != nil:
+		return tpb.copyRanges.Pkfields
+	case tpb.lastpk != nil:
+		return tpb.lastpk.Fields
+	}
+	return nil
+}
+
 func (tpb *tablePlanBuilder) generatePKConstraint(buf *sqlparser.TrackedBuffer, bvf *bindvarFormatter) {
+	if tpb.copyRanges == nil {
+		tpb.generateLastPKConstraint(buf, tpb.lastpk.Fields, tpb.lastpk.Rows[0])
+		return
+	}
+	// For a table copied in ranges, a row was copied if it is within one
+	// of the ranges, and not beyond the range's lastpk.
+	pkfields := tpb.copyRanges.Pkfields
+	separator := "("
+	for _, copyRange := range tpb.copyRanges.Ranges {
+		if copyRange.Lastpk == nil {
+			continue
+		}
+		buf.WriteString(separator)
+		separator = " or "
+		if copyRange.Start != nil {
+			buf.Myprintf("%v >= ", &sqlparser.ColName{Name: sqlparser.NewIdentifierCI(pkfields[0].Name)})
+			sqltypes.ProtoToValue(copyRange.Start).EncodeSQL(buf)
+			buf.WriteString(" and ")
+		}
+		tpb.generateLastPKConstraint(buf, pkfields, sqltypes.MakeRowTrusted(pkfields, copyRange.Lastpk))
+	}
+	if separator == "(" {
+		// Nothing was copied yet.
+		buf.WriteString("(false")
+	}
+	buf.WriteString(")")
+}
+
+// generateLastPKConstraint generates the condition matching the primary keys
+// up to and including lastpk.
+func (tpb *tablePlanBuilder) generateLastPKConstraint(buf *sqlparser.TrackedBuffer, pkfields []*querypb.Field, lastpk []sqltypes.Value) {
 	type charSetCollation struct {
 		charSet   string
 		collation string
 	}
 	var charSetCollations []*charSetCollation
 	separator := "("
-	for _, pkname := range tpb.lastpk.Fields {
+	for _, pkname := range pkfields {
 		charSet, collation := tpb.getCharsetAndCollation(pkname.Name)
 		charSetCollations = append(charSetCollations, &charSetCollation{charSet: charSet, collation: collation})
 		buf.Myprintf("%s%s%v%s", separator, charSet, &sqlparser.ColName{Name: sqlparser.NewIdentifierCI(pkname.Name)}, collation)
 		separator = ","
 	}
 	separator = ") <= ("
-	for i, val := range tpb.lastpk.Rows[0] {
+	for i, val := range lastpk {
 		buf.WriteString(separator)
 		buf.WriteString(charSetCollations[i].charSet)
 		separator = ","
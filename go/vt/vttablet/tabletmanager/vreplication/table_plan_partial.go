@@ -18,6 +18,7 @@ package vreplication
 
 import (
 	"encoding/hex"
+	"slices"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -126,7 +127,7 @@ func (tpb *tablePlanBuilder) createPartialInsertQuery(dataColumns *binlogdatapb.
 	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
 
 	tpb.generatePartialInsertPart(buf, dataColumns)
-	if tpb.lastpk == nil {
+	if !tpb.hasPKConstraint() {
 		// If there's no lastpk, generate straight values.
 		buf.Myprintf(" values ", tpb.name)
 		tpb.generatePartialValuesPart(buf, bvf, dataColumns)
@@ -135,6 +136,10 @@ func (tpb *tablePlanBuilder) createPartialInsertQuery(dataColumns *binlogdatapb.
 		// where the pks < lastpk
 		tpb.generatePartialSelectPart(buf, bvf, dataColumns)
 	}
+	if tpb.resolvesConflicts() && tpb.generateConflictOnDupPart(buf, dataColumns) == nil {
+		log.Error("Conflict timestamp column missing from the row image trying to generate query for " + tpb.name.CompliantName())
+		return nil
+	}
 	return buf.ParsedQuery()
 }
 
@@ -173,6 +178,13 @@ func (tpb *tablePlanBuilder) createPartialUpdateQuery(dataColumns *binlogdatapb.
 		}
 	}
 	tpb.generateWhere(buf, bvf)
+	if ts := tpb.conflictTSCol; ts != nil {
+		if !isBitSet(dataColumns.Cols, slices.Index(tpb.colExprs, ts)) {
+			log.Error("Conflict timestamp column missing from the row image trying to generate query for " + tpb.name.CompliantName())
+			return nil
+		}
+		tpb.generateConflictUpdateCondition(buf, bvf)
+	}
 	return buf.ParsedQuery()
 }
 
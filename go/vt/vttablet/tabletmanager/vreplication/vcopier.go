@@ -60,6 +60,9 @@ type vcopierCopyTask struct {
 type vcopierCopyTaskArgs struct {
 	lastpk *querypb.Row
 	rows   []*querypb.Row
+	// copyRanges is the progress of all the ranges, including this task,
+	// when the table is copied in ranges. It is stored instead of lastpk.
+	copyRanges *binlogdatapb.CopyRanges
 }
 
 // vcopierCopyTaskHooks contains callback functions to be triggered as a copy
@@ -294,25 +297,20 @@ func (vc *vcopier) initTablesForCopy(ctx context.Context) error {
 // primary key that was copied. A nil Result means that nothing has been copied.
 // A table that was fully copied is removed from copyState.
 func (vc *vcopier) copyNext(ctx context.Context, settings binlogplayer.VRSettings) error {
-	qr, err := vc.vr.dbClient.Execute(fmt.Sprintf("select table_name, lastpk from _vt.copy_state where vrepl_id = %d and id in (select max(id) from _vt.copy_state group by vrepl_id, table_name) order by table_name", vc.vr.id))
+	qr, err := vc.vr.dbClient.Execute(fmt.Sprintf("select table_name, lastpk, copy_ranges from _vt.copy_state where vrepl_id = %d and id in (select max(id) from _vt.copy_state group by vrepl_id, table_name) order by table_name", vc.vr.id))
 	if err != nil {
 		return err
 	}
 	var tableToCopy string
-	copyState := make(map[string]*sqltypes.Result)
+	copyState := make(map[string]*tableCopyState)
 	for _, row := range qr.Rows {
 		tableName := row[0].ToString()
-		lastpk := row[1].ToString()
 		if tableToCopy == "" {
 			tableToCopy = tableName
 		}
-		copyState[tableName] = nil
-		if lastpk != "" {
-			var r querypb.QueryResult
-			if err := prototext.Unmarshal([]byte(lastpk), &r); err != nil {
-				return err
-			}
-			copyState[tableName] = sqltypes.Proto3ToResult(&r)
+		copyState[tableName], err = parseTableCopyState(row[1].ToString(), row[2].ToString())
+		if err != nil {
+			return err
 		}
 	}
 	if len(copyState) == 0 {
@@ -327,7 +325,7 @@ func (vc *vcopier) copyNext(ctx context.Context, settings binlogplayer.VRSetting
 // catchup replays events to the subset of the tables that have been copied
 // until replication is caught up. In order to stop, the seconds behind primary has
 // to fall below replicationLagTolerance.
-func (vc *vcopier) catchup(ctx context.Context, copyState map[string]*sqltypes.Result) error {
+func (vc *vcopier) catchup(ctx context.Context, copyState map[string]*tableCopyState) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	defer vc.vr.stats.PhaseTimings.Record("catchup", time.Now())
@@ -378,12 +376,12 @@ func (vc *vcopier) catchup(ctx context.Context, copyState map[string]*sqltypes.R
 // copyTable performs the synchronized copy of the next set of rows from
 // the current table being copied. Each packet received is transactionally
 // committed with the lastpk. This allows for consistent resumability.
-func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState map[string]*sqltypes.Result) error {
+func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState map[string]*tableCopyState) error {
 	defer vc.vr.dbClient.Rollback()
 	defer vc.vr.stats.PhaseTimings.Record("copy", time.Now())
 	defer vc.vr.stats.CopyLoopCount.Add(1)
 
-	log.Info(fmt.Sprintf("Copying table %s, lastpk: %v", tableName, copyState[tableName]))
+	log.Info(fmt.Sprintf("Copying table %s, lastpk: %v, copy ranges: %v", tableName, copyState[tableName].getLastpk(), copyState[tableName].getRanges()))
 
 	plan, err := vc.vr.buildReplicatorPlan(vc.vr.source, vc.vr.colInfoMap, nil, vc.vr.stats, vc.vr.vre.env.CollationEnv(), vc.vr.vre.env.Parser())
 	if err != nil {
@@ -399,7 +397,7 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	defer cancel()
 
 	var lastpkpb *querypb.QueryResult
-	if lastpkqr := copyState[tableName]; lastpkqr != nil {
+	if lastpkqr := copyState[tableName].getLastpk(); lastpkqr != nil {
 		lastpkpb = sqltypes.ResultToProto3(lastpkqr)
 	}
 
@@ -420,6 +418,8 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 
 	var lastpk *querypb.Row
 	var pkfields []*querypb.Field
+	// The progress of the ranges, when the source splits the copy into ranges.
+	var copyRanges *binlogdatapb.CopyRanges
 
 	// Errors observed inside the VStreamRows callback. The callback returns
 	// io.EOF on the first Fail; the post-VStreamRows drain reports them
@@ -432,6 +432,14 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	vstreamOptions := &binlogdatapb.VStreamOptions{
 		ConfigOverrides: vc.vr.workflowConfig.Overrides,
 	}
+	// A copy that was split into ranges is resumed with the same ranges. A
+	// copy that has not started yet may be split, in which case the source
+	// tells us the ranges along with the fields.
+	if ranges := copyState[tableName].getRanges(); ranges != nil {
+		vstreamOptions.CopyRanges = ranges.Ranges
+	} else if lastpkpb == nil && vc.vr.workflowConfig.CopyRangeWorkers > 1 {
+		vstreamOptions.CopyRangeWorkers = int32(vc.vr.workflowConfig.CopyRangeWorkers)
+	}
 	serr := vc.vr.sourceVStreamer.VStreamRows(ctx, initialPlan.SendRule.Filter, lastpkpb, func(rows *binlogdatapb.VStreamRowsResponse) error {
 		for {
 			select {
@@ -503,11 +511,28 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 				pkfields = append(pkfields, f.CloneVT())
 			}
 			buf := sqlparser.NewTrackedBuffer(nil)
-			buf.Myprintf(
-				"insert into _vt.copy_state (lastpk, vrepl_id, table_name) values (%a, %s, %s)", ":lastpk",
-				strconv.Itoa(int(vc.vr.id)),
-				encodeString(tableName),
-			)
+			switch {
+			case len(rows.CopyRanges) > 0:
+				copyRanges = &binlogdatapb.CopyRanges{Pkfields: pkfields}
+				for _, copyRange := range rows.CopyRanges {
+					copyRanges.Ranges = append(copyRanges.Ranges, copyRange.CloneVT())
+				}
+				buf.Myprintf(
+					"insert into _vt.copy_state (copy_ranges, vrepl_id, table_name) values (%a, %s, %s)", ":copy_ranges",
+					strconv.Itoa(int(vc.vr.id)),
+					encodeString(tableName),
+				)
+			case len(vstreamOptions.CopyRanges) > 0:
+				// Restarting the copy from scratch would duplicate the rows
+				// copied so far.
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the source did not resume the copy of table %s in ranges, it may not support --vreplication-copy-range-workers", tableName)
+			default:
+				buf.Myprintf(
+					"insert into _vt.copy_state (lastpk, vrepl_id, table_name) values (%a, %s, %s)", ":lastpk",
+					strconv.Itoa(int(vc.vr.id)),
+					encodeString(tableName),
+				)
+			}
 			addLatestCopyState := buf.ParsedQuery()
 			copyWorkQueue.open(addLatestCopyState, pkfields, tablePlan)
 		}
@@ -531,6 +556,16 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		// TODO(maxeng) see if using a pre-allocated pool will speed things up.
 		currCh := make(chan *vcopierCopyTaskResult, 1)
 		currT := newVCopierCopyTask(newVCopierCopyTaskArgs(rows.Rows, rows.Lastpk))
+		if copyRanges != nil {
+			if int(rows.CopyRange) >= len(copyRanges.Ranges) {
+				return fmt.Errorf("unexpected copy range %d for table %s, there are %d ranges", rows.CopyRange, tableName, len(copyRanges.Ranges))
+			}
+			// The tasks commit their copy state in the order they are
+			// enqueued, so each one records the progress of all the ranges
+			// as of its own rows.
+			copyRanges.Ranges[rows.CopyRange].Lastpk = rows.Lastpk.CloneVT()
+			currT.args.copyRanges = copyRanges.CloneVT()
+		}
 
 		// Send result to the global resultCh and currCh. resultCh is used by
 		// the loop to return results to VStreamRows. currCh will be used to
@@ -709,7 +744,7 @@ func (vc *vcopier) updatePos(ctx context.Context, gtid string) error {
 	return err
 }
 
-func (vc *vcopier) fastForward(ctx context.Context, copyState map[string]*sqltypes.Result, gtid string) error {
+func (vc *vcopier) fastForward(ctx context.Context, copyState map[string]*tableCopyState, gtid string) error {
 	defer vc.vr.stats.PhaseTimings.Record("fastforward", time.Now())
 	pos, err := replication.DecodePosition(gtid)
 	if err != nil {
@@ -1169,7 +1204,7 @@ func (vbc *vcopierCopyWorker) execute(ctx context.Context, task *vcopierCopyTask
 					log.Info("Skipping copy_state insert")
 					return nil
 				}
-				if err := vbc.insertCopyState(ctx, args.lastpk); err != nil {
+				if err := vbc.insertCopyState(ctx, args); err != nil {
 					return vterrors.Wrapf(err, "error updating _vt.copy_state")
 				}
 				return nil
@@ -1214,20 +1249,33 @@ END:
 	return result
 }
 
-func (vbc *vcopierCopyWorker) insertCopyState(ctx context.Context, lastpk *querypb.Row) error {
-	var buf []byte
-	buf, err := prototext.Marshal(&querypb.QueryResult{
-		Fields: vbc.pkfields,
-		Rows:   []*querypb.Row{lastpk},
-	})
-	if err != nil {
-		return err
-	}
-	bv := map[string]*querypb.BindVariable{
-		"lastpk": {
-			Type:  sqltypes.VarBinary,
-			Value: buf,
-		},
+func (vbc *vcopierCopyWorker) insertCopyState(ctx context.Context, args *vcopierCopyTaskArgs) error {
+	var bv map[string]*querypb.BindVariable
+	if args.copyRanges != nil {
+		buf, err := prototext.Marshal(args.copyRanges)
+		if err != nil {
+			return err
+		}
+		bv = map[string]*querypb.BindVariable{
+			"copy_ranges": {
+				Type:  sqltypes.VarBinary,
+				Value: buf,
+			},
+		}
+	} else {
+		buf, err := prototext.Marshal(&querypb.QueryResult{
+			Fields: vbc.pkfields,
+			Rows:   []*querypb.Row{args.lastpk},
+		})
+		if err != nil {
+			return err
+		}
+		bv = map[string]*querypb.BindVariable{
+			"lastpk": {
+				Type:  sqltypes.VarBinary,
+				Value: buf,
+			},
+		}
 	}
 	copyStateInsert, err := vbc.copyStateInsert.GenerateQuery(bv, nil)
 	if err != nil {
@@ -58,7 +58,7 @@ type vplayer struct {
 	startPos  replication.Position
 	stopPos   replication.Position
 	saveStop  bool
-	copyState map[string]*sqltypes.Result
+	copyState map[string]*tableCopyState
 
 	replicatorPlan *ReplicatorPlan
 	tablePlans     map[string]*TablePlan
@@ -122,7 +122,7 @@ const NoForeignKeyCheckFlagBitmask uint32 = 1 << 1
 // pausePos: if set, replication will stop at that position without updating the state to "Stopped".
 //
 //	This is used by the fastForward function during copying.
-func newVPlayer(vr *vreplicator, settings binlogplayer.VRSettings, copyState map[string]*sqltypes.Result, pausePos replication.Position, phase string) *vplayer {
+func newVPlayer(vr *vreplicator, settings binlogplayer.VRSettings, copyState map[string]*tableCopyState, pausePos replication.Position, phase string) *vplayer {
 	saveStop := true
 	if !pausePos.IsZero() {
 		settings.StopPos = pausePos
@@ -420,7 +420,9 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 		// If we're done with the copy phase then we will be replicating all INSERTS
 		// regardless of the PK value and can use a single INSERT statment with
 		// multiple VALUES clauses.
-		if len(vp.copyState) == 0 && insertsOnly {
+		// Rows are inserted one at a time when they have to be checked for
+		// conflicts with the target, so that every conflict is audited.
+		if len(vp.copyState) == 0 && insertsOnly && tplan.ConflictInsertAudit == nil {
 			_, err := tplan.applyBulkInsertChanges(rowEvent.RowChanges, applyFunc, vp.vr.dbClient.maxBatchSize)
 			return err
 		}
@@ -246,6 +246,28 @@ func (vr *vreplicator) validateBinlogRowImage() error {
 	return nil
 }
 
+// validateConflictPolicy checks that the workflow type supports conflict
+// policies. MoveTables, Reshard and OnlineDDL workflows own their target
+// tables, so a conflict there means the data is corrupt and must fail the
+// workflow rather than be resolved.
+func (vr *vreplicator) validateConflictPolicy() error {
+	if vr.workflowConfig == nil {
+		return nil
+	}
+	switch vr.workflowConfig.ConflictPolicy {
+	case "", vttablet.ConflictPolicyNone:
+		return nil
+	}
+	switch binlogdatapb.VReplicationWorkflowType(vr.WorkflowType) {
+	case binlogdatapb.VReplicationWorkflowType_MoveTables,
+		binlogdatapb.VReplicationWorkflowType_Reshard,
+		binlogdatapb.VReplicationWorkflowType_OnlineDDL:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s conflict policy is not supported for %s workflows",
+			vr.workflowConfig.ConflictPolicy, binlogdatapb.VReplicationWorkflowType_name[vr.WorkflowType])
+	}
+	return nil
+}
+
 func (vr *vreplicator) replicate(ctx context.Context) error {
 	// Manage SQL_MODE in the same way that mysqldump does.
 	// Save the original sql_mode, set it to a permissive mode,
@@ -702,8 +724,14 @@ func (vr *vreplicator) setSQLMode(ctx context.Context, dbClient *vdbClient) (fun
 //     Note that with such name, it's possible to throttle
 //     the workflow by either "vreplication" and/or "online-ddl"
 //     This is useful when we want to throttle all migrations. We throttle "online-ddl".
+//   - "vreplication:my-app" for flows with a vreplication-throttler-app-name of "my-app",
+//     which makes it possible to throttle a group of workflows without affecting others.
 func (vr *vreplicator) throttlerAppName() string {
-	names := []string{vr.WorkflowName, throttlerapp.VReplicationName.String()}
+	names := []string{vr.WorkflowName}
+	if vr.workflowConfig.ThrottlerAppName != "" {
+		names = append(names, vr.workflowConfig.ThrottlerAppName)
+	}
+	names = append(names, throttlerapp.VReplicationName.String())
 	if vr.WorkflowType == int32(binlogdatapb.VReplicationWorkflowType_OnlineDDL) {
 		names = append(names, throttlerapp.OnlineDDLName.String())
 	}
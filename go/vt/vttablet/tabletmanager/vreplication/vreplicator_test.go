@@ -986,6 +986,20 @@ func TestThrottlerAppNames(t *testing.T) {
 	assert.NotContains(t, vc.throttlerAppName, "vplayer")
 }
 
+func TestThrottlerAppNameWithOverride(t *testing.T) {
+	config, err := vttablet.NewVReplicationConfig(map[string]string{"vreplication-throttler-app-name": "nightly-reshard"})
+	require.NoError(t, err)
+
+	vr := &vreplicator{WorkflowName: "wf1", workflowConfig: config}
+	assert.Equal(t, "wf1:nightly-reshard:vreplication", vr.throttlerAppName())
+
+	vr.WorkflowType = int32(binlogdatapb.VReplicationWorkflowType_OnlineDDL)
+	assert.Equal(t, "wf1:nightly-reshard:vreplication:online-ddl", vr.throttlerAppName())
+
+	vr = &vreplicator{WorkflowName: "wf1", workflowConfig: vttablet.GetDefaultVReplicationConfig()}
+	assert.Equal(t, "wf1:vreplication", vr.throttlerAppName())
+}
+
 // TestStateMetricNotStuckAfterFailedErrorWrite reproduces issue #20012.
 //
 // setState() advances the in-memory state metric (stats.State) *before* it
@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analyze refreshes the statistics of tables whose row count
+// estimate drifted since they were last analyzed.
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// information_schema.tables caches the statistics of tables for a day
+	// by default. We need the current estimates.
+	sqlDisableStatsExpiry = "set @@session.information_schema_stats_expiry = 0"
+	sqlSelectTableRows    = "select table_name, table_rows from information_schema.tables where table_schema = %a and table_type = 'BASE TABLE'"
+	sqlSelectOneTableRows = "select table_rows from information_schema.tables where table_schema = %a and table_name = %a"
+	sqlSelectAnalyzed     = "select table_name, table_rows from %s.table_analyze where table_schema = %a"
+	sqlInsertBaseline     = "insert ignore into %s.table_analyze (table_schema, table_name, table_rows) values (%a, %a, %a)"
+	sqlUpsertAnalyzed     = "insert into %s.table_analyze (table_schema, table_name, table_rows, last_analyzed, analyze_count) values (%a, %a, %a, now(6), 1) on duplicate key update table_rows = values(table_rows), last_analyzed = values(last_analyzed), analyze_count = analyze_count + 1"
+	sqlDeleteAnalyzed     = "delete from %s.table_analyze where table_schema = %a and table_name = %a"
+	sqlAnalyzeTable       = "analyze table %s"
+)
+
+// Analyzer runs ANALYZE TABLE on the tables of the primary whose row count
+// estimate drifted by more than --analyze-table-drift-threshold since they
+// were last analyzed. The row count of each table at the time it was last
+// analyzed is kept in the sidecar database's table_analyze table. Tables are
+// only analyzed within the maintenance window, and while the lag throttler
+// allows it, as ANALYZE TABLE is replicated.
+type Analyzer struct {
+	env             tabletenv.Env
+	pool            *connpool.Pool
+	throttlerClient *throttle.Client
+
+	dbName string
+
+	mu     sync.Mutex
+	isOpen bool
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// now is overridden by tests.
+	now func() time.Time
+
+	analyzeCount  *stats.CountersWithSingleLabel
+	analyzeErrors *stats.CountersWithSingleLabel
+}
+
+// NewAnalyzer creates a new Analyzer.
+func NewAnalyzer(env tabletenv.Env, lagThrottler *throttle.Throttler) *Analyzer {
+	return &Analyzer{
+		env:             env,
+		throttlerClient: throttle.NewBackgroundClient(lagThrottler, throttlerapp.AnalyzeTableName, base.UndefinedScope),
+		pool: connpool.NewPool(env, "AnalyzeTablePool", tabletenv.ConnPoolConfig{
+			Size:        1,
+			IdleTimeout: env.Config().OltpReadPool.IdleTimeout,
+		}),
+		now:           time.Now,
+		analyzeCount:  env.Exporter().NewCountersWithSingleLabel("AnalyzeTableCount", "Number of times each table was analyzed automatically", "Table"),
+		analyzeErrors: env.Exporter().NewCountersWithSingleLabel("AnalyzeTableErrors", "Number of errors analyzing each table automatically", "Table"),
+	}
+}
+
+// InitDBConfig initializes the database name.
+func (a *Analyzer) InitDBConfig(dbName string) {
+	a.dbName = dbName
+}
+
+// Open starts analyzing tables, if enabled.
+func (a *Analyzer) Open() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.isOpen || !a.env.Config().AnalyzeTable.Enable {
+		return nil
+	}
+
+	log.Info("Analyzer: opening")
+	a.pool.Open(a.env.Config().DB.AllPrivsWithDB(), a.env.Config().DB.DbaWithDB(), a.env.Config().DB.AppDebugWithDB())
+	var ctx context.Context
+	ctx, a.cancel = context.WithCancel(context.Background())
+	a.wg.Add(1)
+	go a.operate(ctx)
+	a.isOpen = true
+	return nil
+}
+
+// Close stops analyzing tables. It waits for a running ANALYZE TABLE to
+// complete.
+func (a *Analyzer) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.isOpen {
+		return
+	}
+
+	log.Info("Analyzer: closing")
+	a.cancel()
+	a.wg.Wait()
+	a.pool.Close()
+	a.isOpen = false
+}
+
+func (a *Analyzer) operate(ctx context.Context) {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.env.Config().AnalyzeTable.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.analyzeTables(ctx); err != nil && ctx.Err() == nil {
+				log.Error(fmt.Sprintf("Analyzer: error analyzing tables: %v", err))
+			}
+		}
+	}
+}
+
+// analyzeTables analyzes the tables whose row count estimate drifted, the
+// ones that drifted the most first. It stops when the maintenance window ends
+// or the throttler pushes back, leaving the remaining tables to the next check.
+func (a *Analyzer) analyzeTables(ctx context.Context) error {
+	cfg := a.env.Config().AnalyzeTable
+	if !cfg.InMaintenanceWindow(a.now()) {
+		return nil
+	}
+
+	conn, err := a.pool.Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Recycle()
+	exec := func(query string, maxrows int) (*sqltypes.Result, error) {
+		return conn.Conn.Exec(ctx, query, maxrows, false)
+	}
+
+	if _, err := exec(sqlDisableStatsExpiry, 0); err != nil {
+		return err
+	}
+	current, err := a.readTableRows(exec)
+	if err != nil {
+		return err
+	}
+	analyzed, err := a.readAnalyzed(exec)
+	if err != nil {
+		return err
+	}
+
+	for name := range analyzed {
+		if _, ok := current[name]; !ok {
+			// The table no longer exists.
+			if _, err := exec(a.buildQuery(sqlDeleteAnalyzed, name), 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	toAnalyze, baseline := tablesToAnalyze(current, analyzed, cfg.DriftThreshold, cfg.MinRows)
+	for _, name := range baseline {
+		if _, err := exec(a.buildQuery(sqlInsertBaseline, name, sqltypes.Int64BindVariable(current[name])), 0); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range toAnalyze {
+		if ctx.Err() != nil || !cfg.InMaintenanceWindow(a.now()) {
+			return nil
+		}
+		if _, ok := a.throttlerClient.ThrottleCheckOK(ctx, ""); !ok {
+			log.Info("Analyzer: throttled, will retry on the next check")
+			return nil
+		}
+		if err := a.analyzeTable(exec, name); err != nil {
+			a.analyzeErrors.Add(name, 1)
+			log.Error(fmt.Sprintf("Analyzer: error analyzing table %s: %v", name, err))
+			continue
+		}
+		a.analyzeCount.Add(name, 1)
+	}
+	return nil
+}
+
+// analyzeTable analyzes the table and records its new row count estimate.
+func (a *Analyzer) analyzeTable(exec func(string, int) (*sqltypes.Result, error), name string) error {
+	log.Info("Analyzer: analyzing table " + name)
+	qr, err := exec(fmt.Sprintf(sqlAnalyzeTable, sqlescape.EscapeID(name)), 100)
+	if err != nil {
+		return err
+	}
+	// ANALYZE TABLE reports errors in its result set.
+	for _, row := range qr.Named().Rows {
+		if strings.EqualFold(row.AsString("Msg_type", ""), "error") {
+			return vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "%s", row.AsString("Msg_text", ""))
+		}
+	}
+
+	bound, err := sqlparser.ParseAndBind(sqlSelectOneTableRows, sqltypes.StringBindVariable(a.dbName), sqltypes.StringBindVariable(name))
+	if err != nil {
+		return err
+	}
+	qr, err = exec(bound, 1)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) == 0 {
+		// The table was dropped.
+		return nil
+	}
+	rows, _ := qr.Rows[0][0].ToInt64()
+	_, err = exec(a.buildQuery(sqlUpsertAnalyzed, name, sqltypes.Int64BindVariable(rows)), 0)
+	return err
+}
+
+// readTableRows returns the row count estimate of the tables of the database.
+func (a *Analyzer) readTableRows(exec func(string, int) (*sqltypes.Result, error)) (map[string]int64, error) {
+	bound, err := sqlparser.ParseAndBind(sqlSelectTableRows, sqltypes.StringBindVariable(a.dbName))
+	if err != nil {
+		return nil, err
+	}
+	qr, err := exec(bound, -1)
+	if err != nil {
+		return nil, err
+	}
+	return rowsByTable(qr), nil
+}
+
+// readAnalyzed returns the row count estimate of the tables at the time they
+// were last analyzed.
+func (a *Analyzer) readAnalyzed(exec func(string, int) (*sqltypes.Result, error)) (map[string]int64, error) {
+	parsed := sqlparser.BuildParsedQuery(sqlSelectAnalyzed, sidecar.GetIdentifier(), ":table_schema")
+	bound, err := parsed.GenerateQuery(map[string]*querypb.BindVariable{
+		"table_schema": sqltypes.StringBindVariable(a.dbName),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := exec(bound, -1)
+	if err != nil {
+		return nil, err
+	}
+	return rowsByTable(qr), nil
+}
+
+// buildQuery binds the schema and table name, and optionally the row count,
+// to one of the table_analyze statements.
+func (a *Analyzer) buildQuery(query string, name string, tableRows ...*querypb.BindVariable) string {
+	bindVars := map[string]*querypb.BindVariable{
+		"table_schema": sqltypes.StringBindVariable(a.dbName),
+		"table_name":   sqltypes.StringBindVariable(name),
+	}
+	args := []any{sidecar.GetIdentifier(), ":table_schema", ":table_name"}
+	if len(tableRows) > 0 {
+		bindVars["table_rows"] = tableRows[0]
+		args = append(args, ":table_rows")
+	}
+	// The bind variables are all present, so this can't fail.
+	bound, _ := sqlparser.BuildParsedQuery(query, args...).GenerateQuery(bindVars, nil)
+	return bound
+}
+
+func rowsByTable(qr *sqltypes.Result) map[string]int64 {
+	tables := make(map[string]int64, len(qr.Rows))
+	for _, row := range qr.Rows {
+		// table_rows is NULL for some engines.
+		rows, _ := row[1].ToInt64()
+		tables[row[0].ToString()] = rows
+	}
+	return tables
+}
+
+// tablesToAnalyze returns the tables whose row count estimate drifted by at
+// least threshold since they were last analyzed, the ones that drifted the
+// most first, and the tables that were never seen before. The current row
+// count of the latter is recorded as a baseline, without analyzing them.
+func tablesToAnalyze(current, analyzed map[string]int64, threshold float64, minRows int64) (toAnalyze, baseline []string) {
+	drifts := make(map[string]float64)
+	for name, rows := range current {
+		last, ok := analyzed[name]
+		if !ok {
+			baseline = append(baseline, name)
+			continue
+		}
+		if max(rows, last) < minRows {
+			continue
+		}
+		drift := math.Abs(float64(rows-last)) / float64(max(last, 1))
+		if drift >= threshold {
+			toAnalyze = append(toAnalyze, name)
+			drifts[name] = drift
+		}
+	}
+	sort.Slice(toAnalyze, func(i, j int) bool {
+		if drifts[toAnalyze[i]] != drifts[toAnalyze[j]] {
+			return drifts[toAnalyze[i]] > drifts[toAnalyze[j]]
+		}
+		return toAnalyze[i] < toAnalyze[j]
+	})
+	sort.Strings(baseline)
+	return toAnalyze, baseline
+}
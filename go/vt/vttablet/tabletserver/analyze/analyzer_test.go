@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+func TestTablesToAnalyze(t *testing.T) {
+	current := map[string]int64{
+		"grown":    100000,
+		"shrunk":   20000,
+		"stable":   52000,
+		"small":    500,
+		"new":      30000,
+		"was_tiny": 15000,
+	}
+	analyzed := map[string]int64{
+		"grown":    50000,
+		"shrunk":   40000,
+		"stable":   50000,
+		"small":    100,
+		"was_tiny": 0,
+		"dropped":  1000,
+	}
+	toAnalyze, baseline := tablesToAnalyze(current, analyzed, 0.2, 10000)
+	assert.Equal(t, []string{"was_tiny", "grown", "shrunk"}, toAnalyze)
+	assert.Equal(t, []string{"new"}, baseline)
+}
+
+func newTestAnalyzer(t *testing.T, db *fakesqldb.DB, maintenanceWindow string, now time.Time) *Analyzer {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = dbconfigs.NewTestDBConfigs(*db.ConnParams(), *db.ConnParams(), "fakesqldb")
+	cfg.AnalyzeTable.Enable = true
+	cfg.AnalyzeTable.MaintenanceWindow = maintenanceWindow
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+
+	a := NewAnalyzer(env, nil)
+	a.InitDBConfig("fakesqldb")
+	a.now = func() time.Time { return now }
+	a.pool.Open(cfg.DB.AllPrivsWithDB(), cfg.DB.DbaWithDB(), cfg.DB.AppDebugWithDB())
+	t.Cleanup(a.pool.Close)
+	return a
+}
+
+func TestAnalyzeTables(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	a := newTestAnalyzer(t, db, "", time.Now())
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddQuery("select table_name, table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_type = 'BASE TABLE'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_name|table_rows", "varchar|uint64"),
+		"t1|100000",
+		"t2|50000",
+		"t3|20000",
+	))
+	db.AddQuery("select table_name, table_rows from _vt.table_analyze where table_schema = 'fakesqldb'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_name|table_rows", "varchar|uint64"),
+		"t1|50000",
+		"t2|48000",
+		"t4|1000",
+	))
+	deleteDropped := "delete from _vt.table_analyze where table_schema = 'fakesqldb' and table_name = 't4'"
+	db.AddQuery(deleteDropped, &sqltypes.Result{})
+	insertBaseline := "insert ignore into _vt.table_analyze (table_schema, table_name, table_rows) values ('fakesqldb', 't3', 20000)"
+	db.AddQuery(insertBaseline, &sqltypes.Result{})
+	analyzeT1 := "analyze table `t1`"
+	db.AddQuery(analyzeT1, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("Table|Op|Msg_type|Msg_text", "varchar|varchar|varchar|varchar"),
+		"fakesqldb.t1|analyze|status|OK",
+	))
+	db.AddQuery("select table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_name = 't1'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_rows", "uint64"),
+		"98000",
+	))
+	upsertT1 := "insert into _vt.table_analyze (table_schema, table_name, table_rows, last_analyzed, analyze_count) values ('fakesqldb', 't1', 98000, now(6), 1) on duplicate key update table_rows = values(table_rows), last_analyzed = values(last_analyzed), analyze_count = analyze_count + 1"
+	db.AddQuery(upsertT1, &sqltypes.Result{})
+
+	require.NoError(t, a.analyzeTables(t.Context()))
+	assert.Equal(t, 1, db.GetQueryCalledNum(deleteDropped))
+	assert.Equal(t, 1, db.GetQueryCalledNum(insertBaseline))
+	assert.Equal(t, 1, db.GetQueryCalledNum(analyzeT1))
+	assert.Equal(t, 1, db.GetQueryCalledNum(upsertT1))
+	assert.Equal(t, int64(1), a.analyzeCount.Counts()["t1"])
+	assert.Zero(t, a.analyzeErrors.Counts()["t1"])
+}
+
+func TestAnalyzeTablesError(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	a := newTestAnalyzer(t, db, "", time.Now())
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddQuery("select table_name, table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_type = 'BASE TABLE'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_name|table_rows", "varchar|uint64"),
+		"t1|100000",
+	))
+	db.AddQuery("select table_name, table_rows from _vt.table_analyze where table_schema = 'fakesqldb'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_name|table_rows", "varchar|uint64"),
+		"t1|50000",
+	))
+	db.AddQuery("analyze table `t1`", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("Table|Op|Msg_type|Msg_text", "varchar|varchar|varchar|varchar"),
+		"fakesqldb.t1|analyze|Error|Table 'fakesqldb.t1' is marked as crashed",
+	))
+
+	// Errors analyzing a table are counted, not returned.
+	require.NoError(t, a.analyzeTables(t.Context()))
+	assert.Zero(t, a.analyzeCount.Counts()["t1"])
+	assert.Equal(t, int64(1), a.analyzeErrors.Counts()["t1"])
+}
+
+func TestAnalyzeTablesOutsideMaintenanceWindow(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	a := newTestAnalyzer(t, db, "01:00-05:00", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+
+	require.NoError(t, a.analyzeTables(t.Context()))
+	assert.Zero(t, db.GetQueryCalledNum(sqlDisableStatsExpiry))
+}
@@ -19,13 +19,14 @@ package tabletserver
 
 import hack "vitess.io/vitess/go/hack"
 
+//go:nocheckptr
 func (cached *TabletPlan) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(112)
+		size += int64(128)
 	}
 	// field Plan *vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder.Plan
 	size += cached.Plan.CachedSize(true)
@@ -40,5 +41,13 @@ func (cached *TabletPlan) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field augmentedQueries map[*vitess.io/vitess/go/vt/vttablet/tabletserver/rules.Augment]*vitess.io/vitess/go/vt/sqlparser.ParsedQuery
+	if cached.augmentedQueries != nil {
+		size += hack.RuntimeMapSize(cached.augmentedQueries)
+		for k, v := range cached.augmentedQueries {
+			size += k.CachedSize(true)
+			size += v.CachedSize(true)
+		}
+	}
 	return size
 }
@@ -0,0 +1,465 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package colstats estimates the number of distinct values and the fraction
+// of NULLs of the columns of tables from a sample of their rows.
+//
+// The estimates are coarse, and vtgate only uses them to pick between join
+// orders of the same cost: they never change how a query is routed.
+package colstats
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// information_schema.tables caches the statistics of tables for a day
+	// by default. We need the current estimates.
+	sqlDisableStatsExpiry = "set @@session.information_schema_stats_expiry = 0"
+	sqlSelectTableRows    = "select table_rows from information_schema.tables where table_schema = %a and table_name = %a"
+	sqlSelectColumns      = "select column_name, data_type from information_schema.columns where table_schema = %a and table_name = %a order by ordinal_position"
+	sqlSelectPrimaryKey   = "select column_name from information_schema.statistics where table_schema = %a and table_name = %a and index_name = 'PRIMARY' and seq_in_index = 1"
+	sqlSelectKeyRange     = "select min(%s), max(%s) from %s"
+	sqlUpsertStatistics   = "insert into %s.column_statistics (table_schema, table_name, column_name, table_rows, sample_rows, distinct_values, null_fraction, last_sampled) values %s on duplicate key update table_rows = values(table_rows), sample_rows = values(sample_rows), distinct_values = values(distinct_values), null_fraction = values(null_fraction), last_sampled = values(last_sampled)"
+	sqlDeleteColumns      = "delete from %s.column_statistics where table_schema = %a and table_name = %a and column_name not in %a"
+	sqlDeleteTables       = "delete from %s.column_statistics where table_schema = %a and table_name not in %a"
+	sqlSelectStatistics   = "select table_name, column_name, table_rows, sample_rows, distinct_values, null_fraction from %s.column_statistics where table_schema = database()"
+
+	// sampleRanges is the number of ranges of the primary key the sample of
+	// a table is read from.
+	sampleRanges = 10
+)
+
+// randUint64N returns a random number in [0, n). It is replaced by tests.
+var randUint64N = rand.Uint64N
+
+// Collector periodically samples the rows of the tables listed in
+// --column-statistics-tables, and records the estimated statistics of their
+// columns in the sidecar database's column_statistics table, from where they
+// are served to vtgate. It only runs on the primary, as the statistics are
+// written to the sidecar database, and replicated to the other tablets.
+type Collector struct {
+	env  tabletenv.Env
+	pool *connpool.Pool
+
+	dbName string
+	// onChange is called after the statistics were refreshed.
+	onChange func()
+
+	mu     sync.Mutex
+	isOpen bool
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	collectCount  *stats.CountersWithSingleLabel
+	collectErrors *stats.CountersWithSingleLabel
+}
+
+// NewCollector creates a new Collector. onChange is called after the
+// statistics were refreshed.
+func NewCollector(env tabletenv.Env, onChange func()) *Collector {
+	return &Collector{
+		env: env,
+		pool: connpool.NewPool(env, "ColumnStatisticsPool", tabletenv.ConnPoolConfig{
+			Size:        1,
+			IdleTimeout: env.Config().OltpReadPool.IdleTimeout,
+		}),
+		onChange:      onChange,
+		collectCount:  env.Exporter().NewCountersWithSingleLabel("ColumnStatisticsCount", "Number of times the column statistics of each table were collected", "Table"),
+		collectErrors: env.Exporter().NewCountersWithSingleLabel("ColumnStatisticsErrors", "Number of errors collecting the column statistics of each table", "Table"),
+	}
+}
+
+// InitDBConfig initializes the database name.
+func (c *Collector) InitDBConfig(dbName string) {
+	c.dbName = dbName
+}
+
+// Open starts collecting statistics, if tables are configured.
+func (c *Collector) Open() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isOpen || len(c.env.Config().ColumnStatistics.Tables) == 0 {
+		return nil
+	}
+
+	log.Info("Collector: opening")
+	c.pool.Open(c.env.Config().DB.AllPrivsWithDB(), c.env.Config().DB.DbaWithDB(), c.env.Config().DB.AppDebugWithDB())
+	var ctx context.Context
+	ctx, c.cancel = context.WithCancel(context.Background())
+	c.wg.Add(1)
+	go c.operate(ctx)
+	c.isOpen = true
+	return nil
+}
+
+// Close stops collecting statistics.
+func (c *Collector) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.isOpen {
+		return
+	}
+
+	log.Info("Collector: closing")
+	c.cancel()
+	c.wg.Wait()
+	c.pool.Close()
+	c.isOpen = false
+}
+
+func (c *Collector) operate(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.env.Config().ColumnStatistics.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.collect(ctx); err != nil && ctx.Err() == nil {
+				log.Error(fmt.Sprintf("Collector: error collecting column statistics: %v", err))
+			}
+		}
+	}
+}
+
+// collect samples the configured tables, and removes the statistics of the
+// tables that are no longer configured.
+func (c *Collector) collect(ctx context.Context) error {
+	conn, err := c.pool.Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Recycle()
+	exec := func(query string, maxrows int) (*sqltypes.Result, error) {
+		return conn.Conn.Exec(ctx, query, maxrows, false)
+	}
+
+	if _, err := exec(sqlDisableStatsExpiry, 0); err != nil {
+		return err
+	}
+	tables := c.env.Config().ColumnStatistics.Tables
+	for _, name := range tables {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := c.collectTable(exec, name); err != nil {
+			c.collectErrors.Add(name, 1)
+			log.Error(fmt.Sprintf("Collector: error collecting the column statistics of table %s: %v", name, err))
+			continue
+		}
+		c.collectCount.Add(name, 1)
+	}
+
+	if _, err := exec(c.buildQuery(sqlDeleteTables, map[string]*querypb.BindVariable{
+		"table_names": tupleBindVariable(tables),
+	}, "::table_names"), 0); err != nil {
+		return err
+	}
+	if c.onChange != nil {
+		c.onChange()
+	}
+	return nil
+}
+
+// collectTable samples the rows of the table, and records the statistics of
+// its columns.
+func (c *Collector) collectTable(exec func(string, int) (*sqltypes.Result, error), name string) error {
+	bound, err := sqlparser.ParseAndBind(sqlSelectColumns, sqltypes.StringBindVariable(c.dbName), sqltypes.StringBindVariable(name))
+	if err != nil {
+		return err
+	}
+	qr, err := exec(bound, -1)
+	if err != nil {
+		return err
+	}
+	var columns []string
+	dataTypes := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		dataTypes[row[0].ToString()] = row[1].ToString()
+		if sampled(row[1].ToString()) {
+			columns = append(columns, row[0].ToString())
+		}
+	}
+	if len(columns) == 0 {
+		// The table was dropped, or none of its columns can be sampled.
+		return nil
+	}
+
+	bound, err = sqlparser.ParseAndBind(sqlSelectTableRows, sqltypes.StringBindVariable(c.dbName), sqltypes.StringBindVariable(name))
+	if err != nil {
+		return err
+	}
+	qr, err = exec(bound, 1)
+	if err != nil {
+		return err
+	}
+	var tableRows int64
+	if len(qr.Rows) > 0 {
+		// table_rows is NULL for some engines.
+		tableRows, _ = qr.Rows[0][0].ToInt64()
+	}
+
+	sampleRows := c.env.Config().ColumnStatistics.SampleRows
+	query := sampleQuery(name, columns, sampleRows)
+	ranged := false
+	if tableRows > sampleRows {
+		// The first rows of a large table are not a fair sample of it, they
+		// are usually its oldest rows, so random ranges of its primary key
+		// are read instead.
+		key, starts, err := c.sampleRanges(exec, name, dataTypes, sampleRows)
+		if err != nil {
+			return err
+		}
+		if len(starts) > 0 {
+			query, ranged = sampleRangesQuery(name, columns, key, starts, sampleRows), true
+		}
+	}
+	qr, err = exec(query, 1)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) == 0 {
+		return nil
+	}
+	row := qr.Rows[0]
+	n, _ := row[0].ToInt64()
+	if n < sampleRows && !ranged {
+		// The whole table was read.
+		tableRows = n
+	}
+	tableRows = max(tableRows, n)
+
+	var values strings.Builder
+	for i, column := range columns {
+		distinct, _ := row[1+2*i].ToInt64()
+		nonNull, _ := row[2+2*i].ToInt64()
+		nullFraction := 0.0
+		if n > 0 {
+			nullFraction = float64(n-nonNull) / float64(n)
+		}
+		tuple, err := sqlparser.ParseAndBind("(%a, %a, %a, %a, %a, %a, %a, now(6))",
+			sqltypes.StringBindVariable(c.dbName),
+			sqltypes.StringBindVariable(name),
+			sqltypes.StringBindVariable(column),
+			sqltypes.Int64BindVariable(tableRows),
+			sqltypes.Int64BindVariable(n),
+			sqltypes.Int64BindVariable(estimateDistinct(distinct, n, tableRows)),
+			sqltypes.Float64BindVariable(nullFraction),
+		)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			values.WriteString(", ")
+		}
+		values.WriteString(tuple)
+	}
+	if _, err := exec(sqlparser.BuildParsedQuery(sqlUpsertStatistics, sidecar.GetIdentifier(), values.String()).Query, 0); err != nil {
+		return err
+	}
+	// The statistics of the columns that were dropped are removed.
+	_, err = exec(c.buildQuery(sqlDeleteColumns, map[string]*querypb.BindVariable{
+		"table_name":   sqltypes.StringBindVariable(name),
+		"column_names": tupleBindVariable(columns),
+	}, ":table_name", "::column_names"), 0)
+	return err
+}
+
+// sampled returns true if the statistics of the columns of the MySQL data
+// type are collected. Large and spatial values are not compared.
+func sampled(dataType string) bool {
+	dataType = strings.ToLower(dataType)
+	switch {
+	case strings.HasSuffix(dataType, "blob"), strings.HasSuffix(dataType, "text"):
+		return false
+	}
+	switch dataType {
+	case "json", "geometry", "point", "linestring", "polygon", "multipoint", "multilinestring", "multipolygon", "geometrycollection", "geomcollection":
+		return false
+	}
+	return true
+}
+
+// sampleQuery returns the query that counts the rows of the sample of the
+// table read from its first rows, and the distinct and non-NULL values of
+// each of its columns.
+func sampleQuery(table string, columns []string, sampleRows int64) string {
+	var selected strings.Builder
+	for i, column := range columns {
+		if i > 0 {
+			selected.WriteString(", ")
+		}
+		selected.WriteString(sqlescape.EscapeID(column))
+	}
+	return fmt.Sprintf("select %s from (select %s from %s limit %d) as sample", countExprs(columns), selected.String(), sqlescape.EscapeID(table), sampleRows)
+}
+
+// countExprs returns the expressions that count the rows of a sample, and
+// the distinct and non-NULL values of each of its columns.
+func countExprs(columns []string) string {
+	var counts strings.Builder
+	counts.WriteString("count(*)")
+	for _, column := range columns {
+		column = sqlescape.EscapeID(column)
+		fmt.Fprintf(&counts, ", count(distinct %s), count(%s)", column, column)
+	}
+	return counts.String()
+}
+
+// sampleRanges returns the first column of the primary key of the table, and
+// the sorted random values of that column from which the ranges of the
+// sample are read. starts is empty if the column is not an integer, or if
+// its values span fewer than sampleRows values, in which case the first rows
+// of the table are sampled.
+func (c *Collector) sampleRanges(exec func(string, int) (*sqltypes.Result, error), name string, dataTypes map[string]string, sampleRows int64) (key string, starts []int64, err error) {
+	bound, err := sqlparser.ParseAndBind(sqlSelectPrimaryKey, sqltypes.StringBindVariable(c.dbName), sqltypes.StringBindVariable(name))
+	if err != nil {
+		return "", nil, err
+	}
+	qr, err := exec(bound, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(qr.Rows) == 0 {
+		return "", nil, nil
+	}
+	key = qr.Rows[0][0].ToString()
+	if !integer(dataTypes[key]) {
+		return "", nil, nil
+	}
+
+	escapedKey := sqlescape.EscapeID(key)
+	qr, err = exec(sqlparser.BuildParsedQuery(sqlSelectKeyRange, escapedKey, escapedKey, sqlescape.EscapeID(name)).Query, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(qr.Rows) == 0 {
+		return "", nil, nil
+	}
+	// The bounds are NULL if the table is empty, and unsigned values above
+	// the int64 range are not handled.
+	low, err := qr.Rows[0][0].ToInt64()
+	if err != nil {
+		return "", nil, nil
+	}
+	high, err := qr.Rows[0][1].ToInt64()
+	if err != nil || high < low {
+		return "", nil, nil
+	}
+	span := uint64(high) - uint64(low)
+	if span < uint64(sampleRows) {
+		return "", nil, nil
+	}
+	starts = make([]int64, 0, sampleRanges)
+	for range sampleRanges {
+		starts = append(starts, low+int64(randUint64N(span)))
+	}
+	slices.Sort(starts)
+	return key, slices.Compact(starts), nil
+}
+
+// sampleRangesQuery returns the query that counts the rows of the sample of
+// the table read from the ranges of its key that begin at starts, and the
+// distinct and non-NULL values of each of its columns. Each range ends where
+// the next one begins, so that no row is read twice.
+func sampleRangesQuery(table string, columns []string, key string, starts []int64, sampleRows int64) string {
+	var selected strings.Builder
+	for i, column := range columns {
+		if i > 0 {
+			selected.WriteString(", ")
+		}
+		selected.WriteString(sqlescape.EscapeID(column))
+	}
+	key = sqlescape.EscapeID(key)
+	rangeRows := (sampleRows + int64(len(starts)) - 1) / int64(len(starts))
+
+	var ranges strings.Builder
+	for i, start := range starts {
+		if i > 0 {
+			ranges.WriteString(" union all ")
+		}
+		fmt.Fprintf(&ranges, "(select %s from %s where %s >= %d", selected.String(), sqlescape.EscapeID(table), key, start)
+		if i+1 < len(starts) {
+			fmt.Fprintf(&ranges, " and %s < %d", key, starts[i+1])
+		}
+		fmt.Fprintf(&ranges, " order by %s limit %d)", key, rangeRows)
+	}
+	return fmt.Sprintf("select %s from (%s) as sample", countExprs(columns), ranges.String())
+}
+
+// integer returns true if the MySQL data type is an integer type.
+func integer(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		return true
+	}
+	return false
+}
+
+// estimateDistinct estimates the number of distinct values of a column of a
+// table of tableRows rows, from the distinct values found in a sample of
+// sampleRows of its rows. If the sample is the whole table, or if its values
+// repeat a lot, all the values are assumed to be in the sample. Otherwise
+// the values are assumed to be mostly unique, and their number grows with
+// the number of rows.
+func estimateDistinct(distinct, sampleRows, tableRows int64) int64 {
+	if sampleRows >= tableRows || distinct*10 <= sampleRows {
+		return distinct
+	}
+	return int64(float64(distinct) * float64(tableRows) / float64(sampleRows))
+}
+
+// buildQuery binds the schema, and the given bind variables, to one of the
+// column_statistics statements.
+func (c *Collector) buildQuery(query string, bindVars map[string]*querypb.BindVariable, args ...any) string {
+	bindVars["table_schema"] = sqltypes.StringBindVariable(c.dbName)
+	args = append([]any{sidecar.GetIdentifier(), ":table_schema"}, args...)
+	// The bind variables are all present, so this can't fail.
+	bound, _ := sqlparser.BuildParsedQuery(query, args...).GenerateQuery(bindVars, nil)
+	return bound
+}
+
+func tupleBindVariable(values []string) *querypb.BindVariable {
+	// A list of strings can't fail to be bound.
+	bv, _ := sqltypes.BuildBindVariable(values)
+	return bv
+}
+
+// FetchQuery returns the query that reads the column statistics of the
+// tables of the database.
+func FetchQuery() string {
+	return sqlparser.BuildParsedQuery(sqlSelectStatistics, sidecar.GetIdentifier()).Query
+}
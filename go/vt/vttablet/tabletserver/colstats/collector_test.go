@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package colstats
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+func TestEstimateDistinct(t *testing.T) {
+	// The sample is the whole table.
+	assert.EqualValues(t, 500, estimateDistinct(500, 1000, 1000))
+	// The values repeat a lot.
+	assert.EqualValues(t, 50, estimateDistinct(50, 1000, 1000000))
+	// The values are mostly unique.
+	assert.EqualValues(t, 900000, estimateDistinct(900, 1000, 1000000))
+}
+
+func TestSampled(t *testing.T) {
+	for _, dataType := range []string{"int", "bigint", "varchar", "char", "datetime", "decimal", "enum"} {
+		assert.True(t, sampled(dataType), dataType)
+	}
+	for _, dataType := range []string{"text", "mediumtext", "BLOB", "longblob", "json", "geometry", "point"} {
+		assert.False(t, sampled(dataType), dataType)
+	}
+}
+
+func newTestCollector(t *testing.T, db *fakesqldb.DB, tables ...string) *Collector {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = dbconfigs.NewTestDBConfigs(*db.ConnParams(), *db.ConnParams(), "fakesqldb")
+	cfg.ColumnStatistics.Tables = tables
+	cfg.ColumnStatistics.SampleRows = 1000
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+
+	c := NewCollector(env, nil)
+	c.InitDBConfig("fakesqldb")
+	c.pool.Open(cfg.DB.AllPrivsWithDB(), cfg.DB.DbaWithDB(), cfg.DB.AppDebugWithDB())
+	t.Cleanup(c.pool.Close)
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	c := newTestCollector(t, db, "t1", "t2")
+	changed := 0
+	c.onChange = func() { changed++ }
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddQuery("select column_name, data_type from information_schema.columns where table_schema = 'fakesqldb' and table_name = 't1' order by ordinal_position", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name|data_type", "varchar|varchar"),
+		"id|bigint",
+		"name|varchar",
+		"body|text",
+	))
+	db.AddQuery("select table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_name = 't1'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_rows", "uint64"),
+		"100000",
+	))
+	// The sample is read from random ranges of the primary key.
+	db.AddQuery("select column_name from information_schema.statistics where table_schema = 'fakesqldb' and table_name = 't1' and index_name = 'PRIMARY' and seq_in_index = 1", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name", "varchar"),
+		"id",
+	))
+	db.AddQuery("select min(`id`), max(`id`) from `t1`", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("a|b", "int64|int64"),
+		"1|100001",
+	))
+	starts := []uint64{60000, 20000, 20000, 0, 40000, 80000, 0, 0, 0, 0}
+	randUint64N = func(n uint64) uint64 {
+		assert.EqualValues(t, 100000, n)
+		start := starts[0]
+		starts = starts[1:]
+		return start
+	}
+	t.Cleanup(func() { randUint64N = rand.Uint64N })
+	db.AddQuery("select count(*), count(distinct `id`), count(`id`), count(distinct `name`), count(`name`) from ("+
+		"(select `id`, `name` from `t1` where `id` >= 1 and `id` < 20001 order by `id` limit 200) union all "+
+		"(select `id`, `name` from `t1` where `id` >= 20001 and `id` < 40001 order by `id` limit 200) union all "+
+		"(select `id`, `name` from `t1` where `id` >= 40001 and `id` < 60001 order by `id` limit 200) union all "+
+		"(select `id`, `name` from `t1` where `id` >= 60001 and `id` < 80001 order by `id` limit 200) union all "+
+		"(select `id`, `name` from `t1` where `id` >= 80001 order by `id` limit 200)) as sample", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("a|b|c|d|e", "int64|int64|int64|int64|int64"),
+		"1000|1000|1000|20|750",
+	))
+	upsertT1 := "insert into _vt.column_statistics (table_schema, table_name, column_name, table_rows, sample_rows, distinct_values, null_fraction, last_sampled) values " +
+		"('fakesqldb', 't1', 'id', 100000, 1000, 100000, 0, now(6)), ('fakesqldb', 't1', 'name', 100000, 1000, 20, 0.25, now(6)) " +
+		"on duplicate key update table_rows = values(table_rows), sample_rows = values(sample_rows), distinct_values = values(distinct_values), null_fraction = values(null_fraction), last_sampled = values(last_sampled)"
+	db.AddQuery(upsertT1, &sqltypes.Result{})
+	deleteColumnsT1 := "delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name = 't1' and column_name not in ('id', 'name')"
+	db.AddQuery(deleteColumnsT1, &sqltypes.Result{})
+	// t2 does not exist.
+	db.AddQuery("select column_name, data_type from information_schema.columns where table_schema = 'fakesqldb' and table_name = 't2' order by ordinal_position", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name|data_type", "varchar|varchar"),
+	))
+	deleteTables := "delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name not in ('t1', 't2')"
+	db.AddQuery(deleteTables, &sqltypes.Result{})
+
+	require.NoError(t, c.collect(t.Context()))
+	assert.Equal(t, 1, db.GetQueryCalledNum(upsertT1))
+	assert.Equal(t, 1, db.GetQueryCalledNum(deleteColumnsT1))
+	assert.Equal(t, 1, db.GetQueryCalledNum(deleteTables))
+	assert.Equal(t, int64(1), c.collectCount.Counts()["t1"])
+	assert.Zero(t, c.collectErrors.Counts()["t1"])
+	assert.Equal(t, 1, changed)
+}
+
+func TestCollectWholeTable(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	c := newTestCollector(t, db, "t1")
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddQuery("select column_name, data_type from information_schema.columns where table_schema = 'fakesqldb' and table_name = 't1' order by ordinal_position", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name|data_type", "varchar|varchar"),
+		"id|bigint",
+	))
+	// The estimate of information_schema is off, the sample has all the rows.
+	db.AddQuery("select table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_name = 't1'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_rows", "uint64"),
+		"800",
+	))
+	db.AddQuery("select count(*), count(distinct `id`), count(`id`) from (select `id` from `t1` limit 1000) as sample", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("a|b|c", "int64|int64|int64"),
+		"500|500|500",
+	))
+	upsert := "insert into _vt.column_statistics (table_schema, table_name, column_name, table_rows, sample_rows, distinct_values, null_fraction, last_sampled) values " +
+		"('fakesqldb', 't1', 'id', 500, 500, 500, 0, now(6)) " +
+		"on duplicate key update table_rows = values(table_rows), sample_rows = values(sample_rows), distinct_values = values(distinct_values), null_fraction = values(null_fraction), last_sampled = values(last_sampled)"
+	db.AddQuery(upsert, &sqltypes.Result{})
+	db.AddQuery("delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name = 't1' and column_name not in ('id')", &sqltypes.Result{})
+	db.AddQuery("delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name not in ('t1')", &sqltypes.Result{})
+
+	require.NoError(t, c.collect(t.Context()))
+	assert.Equal(t, 1, db.GetQueryCalledNum(upsert))
+}
+
+func TestCollectNoIntegerKey(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	c := newTestCollector(t, db, "t1")
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddQuery("select column_name, data_type from information_schema.columns where table_schema = 'fakesqldb' and table_name = 't1' order by ordinal_position", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name|data_type", "varchar|varchar"),
+		"name|varchar",
+	))
+	db.AddQuery("select table_rows from information_schema.tables where table_schema = 'fakesqldb' and table_name = 't1'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("table_rows", "uint64"),
+		"100000",
+	))
+	db.AddQuery("select column_name from information_schema.statistics where table_schema = 'fakesqldb' and table_name = 't1' and index_name = 'PRIMARY' and seq_in_index = 1", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("column_name", "varchar"),
+		"name",
+	))
+	// The key can't be split in ranges, the first rows are sampled.
+	sample := "select count(*), count(distinct `name`), count(`name`) from (select `name` from `t1` limit 1000) as sample"
+	db.AddQuery(sample, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("a|b|c", "int64|int64|int64"),
+		"1000|1000|1000",
+	))
+	upsert := "insert into _vt.column_statistics (table_schema, table_name, column_name, table_rows, sample_rows, distinct_values, null_fraction, last_sampled) values " +
+		"('fakesqldb', 't1', 'name', 100000, 1000, 100000, 0, now(6)) " +
+		"on duplicate key update table_rows = values(table_rows), sample_rows = values(sample_rows), distinct_values = values(distinct_values), null_fraction = values(null_fraction), last_sampled = values(last_sampled)"
+	db.AddQuery(upsert, &sqltypes.Result{})
+	db.AddQuery("delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name = 't1' and column_name not in ('name')", &sqltypes.Result{})
+	db.AddQuery("delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name not in ('t1')", &sqltypes.Result{})
+
+	require.NoError(t, c.collect(t.Context()))
+	assert.Equal(t, 1, db.GetQueryCalledNum(sample))
+	assert.Equal(t, 1, db.GetQueryCalledNum(upsert))
+}
+
+func TestCollectError(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	c := newTestCollector(t, db, "t1")
+
+	db.AddQuery(sqlDisableStatsExpiry, &sqltypes.Result{})
+	db.AddRejectedQuery("select column_name, data_type from information_schema.columns where table_schema = 'fakesqldb' and table_name = 't1' order by ordinal_position", assert.AnError)
+	db.AddQuery("delete from _vt.column_statistics where table_schema = 'fakesqldb' and table_name not in ('t1')", &sqltypes.Result{})
+
+	// Errors collecting a table are counted, not returned.
+	require.NoError(t, c.collect(t.Context()))
+	assert.Zero(t, c.collectCount.Counts()["t1"])
+	assert.Equal(t, int64(1), c.collectErrors.Counts()["t1"])
+}
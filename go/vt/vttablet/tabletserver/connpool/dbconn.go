@@ -25,6 +25,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/sqltypes"
@@ -62,6 +64,11 @@ type Conn struct {
 	err   error
 
 	killTimeout time.Duration
+
+	// stmts holds the statements prepared by ExecPrepared. It is created
+	// on first use, and dropped with the statements when the underlying
+	// connection is closed.
+	stmts *stmtCache
 }
 
 // NewConnection creates a new DBConn. It triggers a CheckMySQL if creation fails.
@@ -94,7 +101,7 @@ func NewConn(ctx context.Context, params dbconfigs.Connector, dbaPool *dbconnpoo
 	dbconn := &Conn{
 		conn:        c,
 		dbaPool:     dbaPool,
-		stats:       tabletenv.NewStats(servenv.NewExporter("Temp", "Tablet")),
+		stats:       tabletenv.NewStats(servenv.NewExporter("Temp", "Tablet"), 0),
 		env:         env,
 		killTimeout: defaultKillTimeout,
 	}
@@ -124,34 +131,67 @@ func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields
 	span, ctx := trace.NewSpan(ctx, "DBConn.Exec")
 	defer span.Finish()
 
-	for attempt := 1; attempt <= 2; attempt++ {
-		r, err := dbc.execOnce(ctx, query, maxrows, wantfields, false)
+	return dbc.exec(ctx, query, "", nil, maxrows, wantfields)
+}
+
+// ExecPrepared executes preparedQuery as a prepared statement with args bound
+// to its placeholders, if it was already executed on the connection before.
+// Otherwise, or if the statement cannot be prepared, it executes the
+// equivalent text query instead. Connection errors are retried like Exec does.
+func (dbc *Conn) ExecPrepared(ctx context.Context, query string, preparedQuery string, args []sqltypes.Value, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	span, ctx := trace.NewSpan(ctx, "DBConn.ExecPrepared")
+	defer span.Finish()
+
+	return dbc.exec(ctx, query, preparedQuery, args, maxrows, wantfields)
+}
+
+func (dbc *Conn) exec(ctx context.Context, query string, preparedQuery string, args []sqltypes.Value, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	var r *sqltypes.Result
+	err := dbc.retry(ctx, func() (err error) {
+		if preparedQuery != "" {
+			r, err = dbc.execPreparedOnce(ctx, query, preparedQuery, args, maxrows, wantfields)
+		} else {
+			r, err = dbc.execOnce(ctx, query, maxrows, wantfields, false)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// retry runs attempt, and runs it again on a new connection if it failed
+// with a connection error before the query could be sent.
+func (dbc *Conn) retry(ctx context.Context, attempt func() error) error {
+	for attempts := 1; attempts <= 2; attempts++ {
+		err := attempt()
 		switch {
 		case err == nil:
 			// Success.
-			return r, nil
+			return nil
 		case sqlerror.IsConnLostDuringQuery(err):
 			// Query probably killed. Don't retry.
-			return nil, err
+			return err
 		case !sqlerror.IsConnErr(err):
 			// Not a connection error. Don't retry.
-			return nil, err
-		case attempt == 2:
+			return err
+		case attempts == 2:
 			// Reached the retry limit.
-			return nil, err
+			return err
 		}
 
 		// Conn error. Retry if context has not expired.
 		select {
 		case <-ctx.Done():
-			return nil, err
+			return err
 		default:
 		}
 
 		if reconnectErr := dbc.Reconnect(ctx); reconnectErr != nil {
 			dbc.env.CheckMySQL()
 			// Return the error of the reconnect and not the original connection error.
-			return nil, reconnectErr
+			return reconnectErr
 		}
 
 		// Reconnect succeeded. Retry query at second attempt.
@@ -160,13 +200,116 @@ func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields
 }
 
 func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfields bool, insideTxn bool) (*sqltypes.Result, error) {
+	return dbc.execute(ctx, query, nil, nil, maxrows, wantfields, insideTxn)
+}
+
+// execPreparedOnce executes preparedQuery as a prepared statement if it is
+// prepared on the connection, and query otherwise.
+func (dbc *Conn) execPreparedOnce(ctx context.Context, query string, preparedQuery string, args []sqltypes.Value, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	stmt, err := dbc.prepare(preparedQuery)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return dbc.execute(ctx, query, nil, nil, maxrows, wantfields, false)
+	}
+	dbc.stats.PreparedStatements.Add("Execute", 1)
+	result, err := dbc.execute(ctx, query, stmt, args, maxrows, wantfields, false)
+	if errors.Is(err, mysql.ErrPreparedStatementUnsupported) {
+		// The arguments or the result cannot be represented faithfully in
+		// the binary protocol this time around.
+		dbc.stats.PreparedStatements.Add("Unsupported", 1)
+		return dbc.execute(ctx, query, nil, nil, maxrows, wantfields, false)
+	}
+	return result, err
+}
+
+// prepare returns the statement prepared for preparedQuery, preparing it if
+// the query was executed on the connection before. It returns nil if the
+// query should be executed as text, and an error only if the connection
+// failed.
+func (dbc *Conn) prepare(preparedQuery string) (*mysql.PreparedStatement, error) {
+	if dbc.stmts == nil {
+		dbc.stmts = newStmtCache(dbc.env.Config().PreparedStatements.CacheSize)
+	}
+	entry, evicted := dbc.stmts.get(preparedQuery)
+	for _, stmt := range evicted {
+		dbc.stats.PreparedStatements.Add("Close", 1)
+		if err := dbc.conn.ClosePrepared(stmt); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case entry == nil, entry.unsupported:
+		return nil, nil
+	case entry.stmt != nil:
+		return entry.stmt, nil
+	}
+
+	dbc.stats.PreparedStatements.Add("Prepare", 1)
+	stmt, err := dbc.conn.Prepare(preparedQuery)
+	if err == nil {
+		entry.stmt = stmt
+		return stmt, nil
+	}
+	if sqlerror.IsConnErr(err) {
+		return nil, err
+	}
+	if sqlErr, ok := err.(*sqlerror.SQLError); ok && sqlErr.Num == sqlerror.ERMaxPreparedStmtCountReached {
+		// The server is out of statements for now, so try again next time.
+		return nil, nil
+	}
+	dbc.stats.PreparedStatements.Add("Unsupported", 1)
+	entry.unsupported = true
+	return nil, nil
+}
+
+// execute executes stmt with args if stmt is set, and query otherwise.
+func (dbc *Conn) execute(ctx context.Context, query string, stmt *mysql.PreparedStatement, args []sqltypes.Value, maxrows int, wantfields bool, insideTxn bool) (*sqltypes.Result, error) {
+	var result *sqltypes.Result
+	err := dbc.run(ctx, query, insideTxn, func() (err error) {
+		if stmt != nil {
+			result, err = dbc.conn.ExecutePrepared(stmt, args, maxrows, wantfields)
+		} else {
+			result, err = dbc.conn.ExecuteFetch(query, maxrows, wantfields)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// executeResults executes query, and returns all the results it returned.
+func (dbc *Conn) executeResults(ctx context.Context, query string, maxrows int, wantfields bool, insideTxn bool) ([]*sqltypes.Result, error) {
+	var results []*sqltypes.Result
+	err := dbc.run(ctx, query, insideTxn, func() error {
+		for result, err := range dbc.conn.ExecuteFetchResults(query, maxrows, wantfields) {
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// run runs fetch, which executes query on the connection. If ctx is done
+// before fetch returns, the query is killed, or the connection if it is
+// inside a transaction.
+func (dbc *Conn) run(ctx context.Context, query string, insideTxn bool, fetch func() error) error {
 	dbc.current.Store(&query)
 	defer dbc.current.Store(nil)
 
 	// Check if the context is already past its deadline before
 	// trying to execute the query.
 	if err := ctx.Err(); err != nil {
-		return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s before execution started", dbc.getErrorMessageFromContextError(ctx))
+		return vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s before execution started", dbc.getErrorMessageFromContextError(ctx))
 	}
 
 	now := time.Now()
@@ -182,20 +325,20 @@ func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfi
 		defer wg.Done()
 		dbc.terminate(ctx, insideTxn, now)
 	})
-	result, err := dbc.conn.ExecuteFetch(query, maxrows, wantfields)
+	err := fetch()
 	if !stop() {
 		// The context was cancelled and terminate has started. Wait for
 		// it to finish so that the kill statement completes and the dba
 		// pool connection is released before we return.
 		wg.Wait()
-		return nil, dbc.Err()
+		return dbc.Err()
 	}
 	// Check for errors set by an explicit Kill call from another
 	// goroutine (not triggered by context cancellation).
 	if dbcErr := dbc.Err(); dbcErr != nil {
-		return nil, dbcErr
+		return dbcErr
 	}
-	return result, err
+	return err
 }
 
 // getErrorMessageFromContextError gets the error message from context error.
@@ -228,6 +371,56 @@ func (dbc *Conn) ExecOnce(ctx context.Context, query string, maxrows int, wantfi
 	return dbc.execOnce(ctx, query, maxrows, wantfields, true /* Once means we are in a txn*/)
 }
 
+// ExecResults executes the specified query, and returns all the results it
+// returned, like the result sets of a stored procedure call followed by its
+// final status. maxrows applies to each result. If there is a connection
+// error, it will reconnect and retry like Exec does.
+func (dbc *Conn) ExecResults(ctx context.Context, query string, maxrows int, wantfields bool) ([]*sqltypes.Result, error) {
+	span, ctx := trace.NewSpan(ctx, "DBConn.ExecResults")
+	defer span.Finish()
+
+	var results []*sqltypes.Result
+	err := dbc.retry(ctx, func() (err error) {
+		results, err = dbc.executeResults(ctx, query, maxrows, wantfields, false)
+		return err
+	})
+	return results, err
+}
+
+// ExecResultsOnce is ExecResults, but does not retry on connection errors.
+func (dbc *Conn) ExecResultsOnce(ctx context.Context, query string, maxrows int, wantfields bool) ([]*sqltypes.Result, error) {
+	return dbc.executeResults(ctx, query, maxrows, wantfields, true /* Once means we are in a txn*/)
+}
+
+// Warnings returns the warnings MySQL raised for the last statement executed
+// on the connection, read with SHOW WARNINGS. MySQL is not queried if the
+// statement raised no warnings.
+func (dbc *Conn) Warnings(ctx context.Context) ([]*querypb.QueryWarning, error) {
+	if dbc.conn.LastWarningCount() == 0 {
+		return nil, nil
+	}
+	qr, err := dbc.ExecOnce(ctx, "show warnings", mysql.FETCH_ALL_ROWS, false)
+	if err != nil {
+		return nil, err
+	}
+	warnings := make([]*querypb.QueryWarning, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		if len(row) != 3 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected row in SHOW WARNINGS: %v", row)
+		}
+		code, err := row[1].ToUint32()
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, &querypb.QueryWarning{
+			Level:   row[0].ToString(),
+			Code:    code,
+			Message: row[2].ToString(),
+		})
+	}
+	return warnings, nil
+}
+
 // FetchNext returns the next result set.
 func (dbc *Conn) FetchNext(ctx context.Context, maxrows int, wantfields bool) (*sqltypes.Result, error) {
 	// Check if the context is already past its deadline before
@@ -235,13 +428,59 @@ func (dbc *Conn) FetchNext(ctx context.Context, maxrows int, wantfields bool) (*
 	if err := ctx.Err(); err != nil {
 		return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s before reading next result set", dbc.getErrorMessageFromContextError(ctx))
 	}
+	// MySQL is still running the statements that produce the next result
+	// sets, such as the rest of a stored procedure, so they are killed if ctx
+	// is done before they are read.
+	stopKill := dbc.conn.KillOnCancel(ctx, dbc.killTimeout)
 	res, _, _, err := dbc.conn.ReadQueryResult(maxrows, wantfields)
+	if !stopKill() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s, while reading next result set", dbc.getErrorMessageFromContextError(ctx))
+	}
 	if err != nil {
 		return nil, err
 	}
 	return res, err
 }
 
+// MoreStreamResults returns true if the streaming query has more results
+// to stream with StreamNextResult.
+func (dbc *Conn) MoreStreamResults() bool {
+	return dbc.conn.MoreStreamResults()
+}
+
+// StreamNextResult streams the next result of the streaming query, once
+// MoreStreamResults returns true, stripping the metadata of its fields like
+// StreamOnce.
+func (dbc *Conn) StreamNextResult(
+	ctx context.Context,
+	callback func(*sqltypes.Result) error,
+	alloc func() *sqltypes.Result,
+	streamBufferSize int,
+	includedFields querypb.ExecuteOptions_IncludedFields,
+) error {
+	if err := ctx.Err(); err != nil {
+		return vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s before reading next result set", dbc.getErrorMessageFromContextError(ctx))
+	}
+	// As in FetchNext, MySQL is still running the statements that produce
+	// the next result, so they are killed if ctx is done before it is read.
+	stopKill := dbc.conn.KillOnCancel(ctx, dbc.killTimeout)
+	resultSent := false
+	err := dbc.conn.StreamNextResult(func(r *sqltypes.Result) error {
+		if ctx.Err() != nil {
+			return vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s, while streaming results", dbc.getErrorMessageFromContextError(ctx))
+		}
+		if !resultSent {
+			resultSent = true
+			r = r.StripMetadata(includedFields)
+		}
+		return callback(r)
+	}, alloc, streamBufferSize)
+	if !stopKill() {
+		return vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s, while reading next result set", dbc.getErrorMessageFromContextError(ctx))
+	}
+	return err
+}
+
 // StreamOKResult returns the OK-packet result of the most recent streaming query
 // when it returned no resultset (e.g. a CALL of a procedure that performs DML),
 // or nil when it returned a resultset.
@@ -322,7 +561,15 @@ func (dbc *Conn) streamOnce(
 		defer wg.Done()
 		dbc.terminate(ctx, insideTxn, now)
 	})
-	err := dbc.conn.ExecuteStreamFetch(query, callback, alloc, streamBufferSize)
+	err := dbc.conn.ExecuteStreamFetch(query, func(qr *sqltypes.Result) error {
+		// Stop forwarding results as soon as the context is done. The kill
+		// triggered above only stops MySQL, and the rows it already sent
+		// would otherwise still be streamed to the client.
+		if ctx.Err() != nil {
+			return vterrors.Errorf(vtrpcpb.Code_CANCELED, "%s, while streaming results", dbc.getErrorMessageFromContextError(ctx))
+		}
+		return callback(qr)
+	}, alloc, streamBufferSize)
 	if !stop() {
 		wg.Wait()
 		return dbc.Err()
@@ -408,6 +655,7 @@ func (dbc *Conn) VerifyMode(strictTransTables bool) error {
 
 // Close closes the DBConn.
 func (dbc *Conn) Close() {
+	dbc.stmts = nil
 	dbc.conn.Close()
 }
 
@@ -422,6 +670,12 @@ func (dbc *Conn) ApplySetting(ctx context.Context, setting *smartconnpool.Settin
 
 // ResetSetting implements the pools.Resource interface.
 func (dbc *Conn) ResetSetting(ctx context.Context) error {
+	if ok, err := dbc.resetConnection(); err != nil {
+		return err
+	} else if ok {
+		dbc.setting = nil
+		return nil
+	}
 	if _, err := dbc.execOnce(ctx, dbc.setting.ResetQuery(), 1, false, false); err != nil {
 		return err
 	}
@@ -429,6 +683,30 @@ func (dbc *Conn) ResetSetting(ctx context.Context) error {
 	return nil
 }
 
+// resetConnection clears the session state with COM_RESET_CONNECTION if it
+// is enabled and the server supports it. It returns false if the reset query
+// of the setting must be executed instead.
+func (dbc *Conn) resetConnection() (bool, error) {
+	if dbc.env == nil || dbc.env.Config() == nil || !dbc.env.Config().ResetConnection {
+		return false, nil
+	}
+	if ok, _ := dbc.conn.SupportsCapability(capabilities.ResetConnectionCapability); !ok {
+		return false, nil
+	}
+	start := time.Now()
+	defer dbc.stats.MySQLTimings.Record("ResetConnection", start)
+	err := dbc.conn.ResetConnection()
+	if sqlErr, ok := err.(*sqlerror.SQLError); ok && sqlErr.Num == sqlerror.ERUnknownComError {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// The statements prepared on the connection were dropped by the reset.
+	dbc.stmts = nil
+	return true, nil
+}
+
 func (dbc *Conn) Setting() *smartconnpool.Setting {
 	return dbc.setting
 }
@@ -595,6 +873,8 @@ func (dbc *Conn) ConnCheck(ctx context.Context) error {
 }
 
 func (dbc *Conn) Reconnect(ctx context.Context) error {
+	// The prepared statements are gone with the old connection.
+	dbc.stmts = nil
 	err := dbc.conn.Reconnect(ctx)
 	if err != nil {
 		return err
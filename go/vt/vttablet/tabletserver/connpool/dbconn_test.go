@@ -110,6 +110,45 @@ func TestDBConnExec(t *testing.T) {
 	compareTimingCounts(t, "PoolTest.Exec", 1, startCounts, mysqlTimings.Counts())
 }
 
+func TestDBConnExecResults(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	db.AddQuery("select 1", sqltypes.MakeTestResult(fields, "1"))
+	db.AddQuery("select 2", sqltypes.MakeTestResult(fields, "2"))
+	db.AddQuery("delete from t1", &sqltypes.Result{RowsAffected: 3})
+	connPool := newPool()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+	dbConn, err := newPooledConn(t.Context(), connPool, params)
+	if dbConn != nil {
+		defer dbConn.Close()
+	}
+	require.NoError(t, err)
+
+	results, err := dbConn.ExecResults(t.Context(), "select 1;select 2;delete from t1", 10, true)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "[[INT64(1)]]", fmt.Sprint(results[0].Rows))
+	assert.True(t, results[0].IsMoreResultsExists())
+	assert.Equal(t, "[[INT64(2)]]", fmt.Sprint(results[1].Rows))
+	assert.True(t, results[1].IsMoreResultsExists())
+	assert.EqualValues(t, 3, results[2].RowsAffected)
+	assert.False(t, results[2].IsMoreResultsExists())
+
+	// The connection is clean for the next query.
+	result, err := dbConn.Exec(t.Context(), "select 2", 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, "[[INT64(2)]]", fmt.Sprint(result.Rows))
+
+	// The iteration stops at the first error.
+	db.AddRejectedQuery("select 2", errors.New("cannot run query"))
+	_, err = dbConn.ExecResultsOnce(t.Context(), "select 1;select 2;delete from t1", 10, true)
+	require.ErrorContains(t, err, "cannot run query")
+}
+
 func TestDBConnExecLost(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -518,6 +557,43 @@ func TestDBConnStream(t *testing.T) {
 	require.ErrorContains(t, err, "no such file or directory (errno 2002)")
 }
 
+// TestDBConnStreamCancelMidStream tests that a stream stops forwarding
+// results as soon as its context is done, even though the rows already
+// fetched from MySQL are still there to be read.
+func TestDBConnStreamCancelMidStream(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	sql := "select * from test_table"
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{{Type: sqltypes.VarChar}},
+	}
+	for range 10 {
+		result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar("123")})
+	}
+	db.AddQuery(sql, result)
+	db.AddQueryPattern(`kill query \d+`, &sqltypes.Result{})
+	connPool := newPool()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+	dbConn, err := newPooledConn(t.Context(), connPool, params)
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var rows int
+	err = dbConn.Stream(ctx, sql, func(r *sqltypes.Result) error {
+		rows += len(r.Rows)
+		if rows > 0 {
+			cancel()
+		}
+		return nil
+	}, alloc, 1, querypb.ExecuteOptions_ALL)
+	require.ErrorContains(t, err, "(errno 1317) (sqlstate 70100): Query execution was interrupted")
+	assert.Equal(t, 1, rows)
+}
+
 // TestDBConnKillCall tests that direct Kill method calls work as expected.
 func TestDBConnKillCall(t *testing.T) {
 	t.Run("stream exec", func(t *testing.T) {
@@ -536,6 +612,90 @@ func TestDBConnKillCall(t *testing.T) {
 	})
 }
 
+func TestDBConnFetchNextKillOnCancel(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	sql := "select * from test_table limit 1000"
+	db.AddQuery(sql, &sqltypes.Result{Fields: []*querypb.Field{{Type: sqltypes.VarChar}}})
+	db.SetBeforeFunc(sql, func() {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	connPool := newPool()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+	dbConn, err := newPooledConn(t.Context(), connPool, params)
+	require.NoError(t, err)
+	defer dbConn.Close()
+	killQuery := fmt.Sprintf("kill query %d", dbConn.ID())
+	db.AddQuery(killQuery, &sqltypes.Result{})
+
+	// The result is read while MySQL is still running the query.
+	require.NoError(t, dbConn.conn.WriteComQuery(sql))
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = dbConn.FetchNext(ctx, 10, false)
+	assert.ErrorContains(t, err, "(errno 1317) (sqlstate 70100): Query execution was interrupted, while reading next result set")
+	assert.Equal(t, 1, db.GetQueryCalledNum(killQuery))
+}
+
+func TestDBConnStreamNextResult(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	result1 := &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "a", Type: sqltypes.VarChar}},
+		Rows:   [][]sqltypes.Value{{sqltypes.NewVarChar("1")}, {sqltypes.NewVarChar("2")}},
+	}
+	result2 := &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "b", Type: sqltypes.Int64}},
+		Rows:   [][]sqltypes.Value{{sqltypes.NewInt64(3)}},
+	}
+	db.AddQuery("select a from t1", result1)
+	db.AddQuery("select b from t2", result2)
+
+	connPool := newPool()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+	dbConn, err := newPooledConn(t.Context(), connPool, params)
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	var results []*sqltypes.Result
+	callback := func(r *sqltypes.Result) error {
+		if r.Fields != nil {
+			results = append(results, &sqltypes.Result{Fields: r.Fields})
+			return nil
+		}
+		results[len(results)-1].Rows = append(results[len(results)-1].Rows, r.Rows...)
+		return nil
+	}
+	err = dbConn.Stream(t.Context(), "select a from t1;select b from t2", callback, alloc, 10, querypb.ExecuteOptions_ALL)
+	require.NoError(t, err)
+	require.True(t, dbConn.MoreStreamResults())
+	err = dbConn.StreamNextResult(t.Context(), callback, alloc, 10, querypb.ExecuteOptions_ALL)
+	require.NoError(t, err)
+	assert.False(t, dbConn.MoreStreamResults())
+	require.Len(t, results, 2)
+	assert.True(t, result1.Equal(results[0]))
+	assert.True(t, result2.Equal(results[1]))
+
+	err = dbConn.StreamNextResult(t.Context(), callback, alloc, 10, querypb.ExecuteOptions_ALL)
+	assert.ErrorContains(t, err, "no more results")
+
+	// A canceled context is reported before the next result is read.
+	err = dbConn.Stream(t.Context(), "select a from t1;select b from t2", callback, alloc, 10, querypb.ExecuteOptions_ALL)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err = dbConn.StreamNextResult(ctx, callback, alloc, 10, querypb.ExecuteOptions_ALL)
+	assert.ErrorContains(t, err, "Query execution was interrupted before reading next result set")
+}
+
 func testKill(t *testing.T, exec func(context.Context, string, *Conn) error) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -595,6 +755,71 @@ func TestDBConnReconnect(t *testing.T) {
 	require.NotEqual(t, oldConnID, dbConn.conn.ID())
 }
 
+func TestDBConnExecPrepared(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.PreparedStatements.CacheSize = 1
+	connPool := NewPool(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "PoolTest"), "TestPool", tabletenv.ConnPoolConfig{
+		Size:        1,
+		IdleTimeout: 10 * time.Second,
+	})
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+
+	dbConn, err := newPooledConn(t.Context(), connPool, params)
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	query := "select name from t where id = 1"
+	preparedQuery := "select name from t where id = ?"
+	expected := &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "name", Type: sqltypes.VarChar}},
+		Rows:   [][]sqltypes.Value{{sqltypes.NewVarChar("a")}},
+	}
+	db.AddQuery(query, expected)
+	counts := connPool.env.Stats().PreparedStatements
+	startCounts := counts.Counts()
+
+	execPrepared := func(query, preparedQuery string, args ...sqltypes.Value) {
+		t.Helper()
+		result, err := dbConn.ExecPrepared(t.Context(), query, preparedQuery, args, 10, true)
+		require.NoError(t, err)
+		assert.True(t, expected.Equal(result), "got %v", result)
+	}
+
+	// The query is executed as text the first time it is seen, and prepared
+	// the second time.
+	execPrepared(query, preparedQuery, sqltypes.NewInt64(1))
+	compareTimingCounts(t, "Prepare", 0, startCounts, counts.Counts())
+	execPrepared(query, preparedQuery, sqltypes.NewInt64(1))
+	execPrepared(query, preparedQuery, sqltypes.NewInt64(1))
+	compareTimingCounts(t, "Prepare", 1, startCounts, counts.Counts())
+	compareTimingCounts(t, "Execute", 2, startCounts, counts.Counts())
+	assert.Equal(t, 1, dbConn.stmts.len())
+
+	// Another query evicts the statement.
+	db.AddQuery("select name from t", expected)
+	execPrepared("select name from t", "select name from t")
+	compareTimingCounts(t, "Close", 1, startCounts, counts.Counts())
+	assert.Equal(t, 0, dbConn.stmts.len())
+
+	// Arguments that cannot be bound fall back to the text query.
+	execPrepared("select name from t", "select name from t")
+	db.AddQuery("select name from t where id = 0x01", expected)
+	execPrepared("select name from t where id = 0x01", preparedQuery, sqltypes.NewHexNum([]byte("0x01")))
+	execPrepared("select name from t where id = 0x01", preparedQuery, sqltypes.NewHexNum([]byte("0x01")))
+	compareTimingCounts(t, "Prepare", 3, startCounts, counts.Counts())
+	compareTimingCounts(t, "Unsupported", 1, startCounts, counts.Counts())
+	assert.Equal(t, 2, db.GetQueryCalledNum("select name from t where id = 0x01"))
+
+	// The statements are gone after a reconnect.
+	require.NoError(t, dbConn.Reconnect(t.Context()))
+	assert.Nil(t, dbConn.stmts)
+}
+
 func TestDBConnReApplySetting(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -632,6 +857,53 @@ func TestDBConnReApplySetting(t *testing.T) {
 	db.VerifyAllExecutedOrFail()
 }
 
+func TestDBConnResetSetting(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	db.OrderMatters()
+
+	cfg := tabletenv.NewDefaultConfig()
+	connPool := NewPool(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "PoolTest"), "TestPool", tabletenv.ConnPoolConfig{
+		Size:        1,
+		IdleTimeout: 10 * time.Second,
+	})
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+
+	ctx := t.Context()
+	dbConn, err := newPooledConn(ctx, connPool, params)
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	setQ := "set @@sql_mode='ANSI_QUOTES'"
+	resetQ := "set @@sql_mode = default"
+	setting := smartconnpool.NewSetting(setQ, resetQ)
+
+	// By default, the setting is reset with its reset query.
+	db.AddExpectedQuery(setQ, nil)
+	db.AddExpectedQuery(resetQ, nil)
+	require.NoError(t, dbConn.ApplySetting(ctx, setting))
+	require.NoError(t, dbConn.ResetSetting(ctx))
+	assert.Nil(t, dbConn.Setting())
+
+	// With COM_RESET_CONNECTION, the reset query is not executed, and the
+	// connection is kept.
+	cfg.ResetConnection = true
+	connID := dbConn.conn.ID()
+	timings := connPool.env.Stats().MySQLTimings
+	startCounts := timings.Counts()
+	db.AddExpectedQuery(setQ, nil)
+	require.NoError(t, dbConn.ApplySetting(ctx, setting))
+	require.NoError(t, dbConn.ResetSetting(ctx))
+	assert.Nil(t, dbConn.Setting())
+	assert.Nil(t, dbConn.stmts)
+	assert.Equal(t, connID, dbConn.conn.ID())
+	compareTimingCounts(t, "PoolTest.ResetConnection", 1, startCounts, timings.Counts())
+
+	db.VerifyAllExecutedOrFail()
+}
+
 func TestDBExecOnceKillTimeout(t *testing.T) {
 	executeWithTimeout(t, `kill \d+`, 150*time.Millisecond, func(ctx context.Context, dbConn *Conn) (*sqltypes.Result, error) {
 		return dbConn.ExecOnce(ctx, "select 1", 1, false)
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connpool
+
+import (
+	"container/list"
+
+	"vitess.io/vitess/go/mysql"
+)
+
+// stmtCache is an LRU cache of the queries executed on a connection, with
+// the statements prepared for them. A query is only prepared the second time
+// it is executed on the connection, so that queries which are not repeated,
+// e.g. because of their comments, neither prepare statements on MySQL nor
+// evict the statements of the queries which are.
+type stmtCache struct {
+	capacity int
+	list     *list.List
+	table    map[string]*list.Element
+}
+
+type stmtCacheEntry struct {
+	query string
+	// stmt is the statement prepared for the query, if any.
+	stmt *mysql.PreparedStatement
+	// unsupported is set if the query cannot be executed as a prepared
+	// statement.
+	unsupported bool
+}
+
+func newStmtCache(capacity int) *stmtCache {
+	return &stmtCache{
+		capacity: capacity,
+		list:     list.New(),
+		table:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry of query, and marks it as most recently used. If
+// query was not executed before, it adds an entry for it and returns nil,
+// along with the statements of the entries evicted to make room for it.
+func (sc *stmtCache) get(query string) (entry *stmtCacheEntry, evicted []*mysql.PreparedStatement) {
+	if element, ok := sc.table[query]; ok {
+		sc.list.MoveToFront(element)
+		return element.Value.(*stmtCacheEntry), nil
+	}
+	sc.table[query] = sc.list.PushFront(&stmtCacheEntry{query: query})
+	for sc.list.Len() > sc.capacity {
+		oldest := sc.list.Remove(sc.list.Back()).(*stmtCacheEntry)
+		delete(sc.table, oldest.query)
+		if oldest.stmt != nil {
+			evicted = append(evicted, oldest.stmt)
+		}
+	}
+	return nil, evicted
+}
+
+// len returns the number of statements prepared for the cached queries.
+func (sc *stmtCache) len() int {
+	var n int
+	for element := sc.list.Front(); element != nil; element = element.Next() {
+		if element.Value.(*stmtCacheEntry).stmt != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -125,6 +125,9 @@ type Controller interface {
 
 	// IsDiskStalled returns if the disk is stalled.
 	IsDiskStalled() bool
+
+	// WarmUpOnNextServe makes the query service warm up its query pools before it next starts serving.
+	WarmUpOnNextServe()
 }
 
 // Ensure TabletServer satisfies Controller interface.
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	sqlInsertDMLJournal = "insert into %s.dml_journal(idempotency_key, time_created) values (%a, %a)"
+	sqlReadDMLJournal   = "select rows_affected, insert_id from %s.dml_journal where idempotency_key = %a"
+	sqlUpdateDMLJournal = "update %s.dml_journal set rows_affected = %a, insert_id = %a where idempotency_key = %a"
+	sqlPurgeDMLJournal  = "delete from %s.dml_journal where time_created < %a limit %a"
+
+	// dmlJournalPurgeBatchSize is the number of journal entries deleted by
+	// each purge statement, to keep the purge statements short.
+	dmlJournalPurgeBatchSize = 1000
+)
+
+// DMLJournal records the idempotency keys of autocommit DMLs in the
+// dml_journal sidecar table. The key is recorded in the same transaction
+// as the DML, so after a crash or a lost connection the journal tells
+// whether the DML was applied: a DML retried with a recorded key is not
+// executed again, and the rows affected and insert id of the first execution
+// are returned.
+type DMLJournal struct {
+	replays *stats.Counter
+}
+
+// NewDMLJournal creates a DMLJournal.
+func NewDMLJournal(env tabletenv.Env) *DMLJournal {
+	return &DMLJournal{
+		replays: env.Exporter().NewCounter("DMLJournalReplays", "Number of retried DMLs that were not executed again because their idempotency key was already journaled"),
+	}
+}
+
+// Begin records the idempotency key in the transaction of conn. It must be
+// called before the DML is executed. If the key was already recorded by a
+// committed transaction, the result recorded for it is returned and the
+// DML must not be executed. If another transaction holding the key is still
+// in flight, Begin blocks until that transaction ends.
+func (dj *DMLJournal) Begin(ctx context.Context, conn *StatefulConnection, key string) (*sqltypes.Result, error) {
+	_, err := dj.exec(ctx, conn, sqlInsertDMLJournal, map[string]*querypb.BindVariable{
+		"idempotency_key": sqltypes.StringBindVariable(key),
+		"time_created":    sqltypes.Int64BindVariable(time.Now().UnixNano()),
+	}, ":idempotency_key", ":time_created")
+	if err == nil {
+		return nil, nil
+	}
+	if sqlErr, ok := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError); !ok || sqlErr.Number() != sqlerror.ERDupEntry {
+		return nil, err
+	}
+
+	qr, err := dj.exec(ctx, conn, sqlReadDMLJournal, map[string]*querypb.BindVariable{
+		"idempotency_key": sqltypes.StringBindVariable(key),
+	}, ":idempotency_key")
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) != 1 {
+		// The entry was purged since the insert failed, which only happens
+		// to keys older than the journal retention.
+		return nil, vterrors.Errorf(vtrpcpb.Code_ABORTED, "journal entry of idempotency key %q was purged", key)
+	}
+	rowsAffected, err := qr.Rows[0][0].ToCastUint64()
+	if err != nil {
+		return nil, err
+	}
+	insertID, err := qr.Rows[0][1].ToCastUint64()
+	if err != nil {
+		return nil, err
+	}
+	dj.replays.Add(1)
+	return &sqltypes.Result{RowsAffected: rowsAffected, InsertID: insertID, InsertIDChanged: insertID > 0}, nil
+}
+
+// Complete records the result of the DML for the idempotency key, in the
+// transaction of conn. It must be called after the DML was executed and
+// before the transaction is committed.
+func (dj *DMLJournal) Complete(ctx context.Context, conn *StatefulConnection, key string, result *sqltypes.Result) error {
+	_, err := dj.exec(ctx, conn, sqlUpdateDMLJournal, map[string]*querypb.BindVariable{
+		"rows_affected":   sqltypes.Uint64BindVariable(result.RowsAffected),
+		"insert_id":       sqltypes.Uint64BindVariable(result.InsertID),
+		"idempotency_key": sqltypes.StringBindVariable(key),
+	}, ":rows_affected", ":insert_id", ":idempotency_key")
+	return err
+}
+
+// Purge deletes the journal entries created before the given time, in
+// batches. It returns the number of entries deleted.
+func (dj *DMLJournal) Purge(ctx context.Context, conn *StatefulConnection, before time.Time) (uint64, error) {
+	bindVars := map[string]*querypb.BindVariable{
+		"time_created": sqltypes.Int64BindVariable(before.UnixNano()),
+		"limit":        sqltypes.Int64BindVariable(dmlJournalPurgeBatchSize),
+	}
+	var purged uint64
+	for {
+		qr, err := dj.exec(ctx, conn, sqlPurgeDMLJournal, bindVars, ":time_created", ":limit")
+		if err != nil {
+			return purged, err
+		}
+		purged += qr.RowsAffected
+		if qr.RowsAffected < dmlJournalPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (dj *DMLJournal) exec(ctx context.Context, conn *StatefulConnection, query string, bindVars map[string]*querypb.BindVariable, args ...any) (*sqltypes.Result, error) {
+	q, err := sqlparser.BuildParsedQuery(query, append([]any{sidecar.GetIdentifier()}, args...)...).GenerateQuery(bindVars, nil)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Exec(ctx, q, 1, false)
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sqltypes/sqlarrow"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// csvNull is how NULL values are written in the exported CSV files. It is
+// the representation used by SELECT ... INTO OUTFILE.
+const csvNull = `\N`
+
+// parquetRowGroupRows is the number of rows of the row groups of the
+// exported Parquet files.
+const parquetRowGroupRows = 64 * 1024
+
+// exportFields are the fields of the manifest returned by an export.
+var exportFields = []*querypb.Field{
+	{Name: "file", Type: sqltypes.VarChar},
+	{Name: "rows", Type: sqltypes.Int64},
+	{Name: "bytes", Type: sqltypes.Int64},
+}
+
+// exportStream runs the streaming query of qre and writes its results to the
+// backup storage of the tablet. Instead of the rows of the query, callback
+// receives a single result: the manifest of the files that were written.
+func (tsv *TabletServer) exportStream(ctx context.Context, qre *QueryExecutor, options *querypb.ExportOptions, callback func(*sqltypes.Result) error) error {
+	if !tsv.config.EnableResultExport {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "result export is not enabled on this tablet")
+	}
+	switch options.Format {
+	case querypb.ExportOptions_CSV, querypb.ExportOptions_PARQUET:
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported export format: %v", options.Format)
+	}
+	if err := validateExportDirectory(options.Directory); err != nil {
+		return err
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot export results")
+	}
+	defer bs.Close()
+
+	handle, err := bs.StartBackup(ctx, options.Directory, topoproto.TabletAliasString(tsv.alias))
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot start export to %v", options.Directory)
+	}
+
+	exp := &resultExporter{ctx: ctx, handle: handle, format: options.Format, rowsPerFile: options.RowsPerFile}
+	err = qre.Stream(exp.write)
+	if closeErr := exp.closeFile(); err == nil {
+		err = closeErr
+	}
+	handle.Wait()
+	if err == nil && handle.HasErrors() {
+		err = handle.Error()
+	}
+	if err != nil {
+		if abortErr := handle.AbortBackup(ctx); abortErr != nil {
+			log.Error(fmt.Sprintf("Failed to abort the export to %v/%v: %v", handle.Directory(), handle.Name(), abortErr))
+		}
+		return err
+	}
+	if err := handle.EndBackup(ctx); err != nil {
+		return vterrors.Wrapf(err, "cannot end export to %v", options.Directory)
+	}
+	return callback(exp.manifest())
+}
+
+// validateExportDirectory checks that the directory of an export stays within
+// the backup storage.
+func validateExportDirectory(directory string) error {
+	if directory == "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the directory of the export is required")
+	}
+	if path.IsAbs(directory) || strings.HasPrefix(directory, `\`) {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the directory of the export must be a relative path: %v", directory)
+	}
+	for _, elem := range strings.FieldsFunc(directory, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the directory of the export must not contain '..': %v", directory)
+		}
+	}
+	return nil
+}
+
+// resultExporter writes the results of a streaming query as the files of
+// an export.
+type resultExporter struct {
+	ctx         context.Context
+	handle      backupstorage.BackupHandle
+	format      querypb.ExportOptions_Format
+	rowsPerFile int64
+	fields      []*querypb.Field
+
+	// file is the file being written, if any.
+	file   io.WriteCloser
+	writer exportWriter
+	count  *countingWriter
+	rows   int64
+
+	files []exportedFile
+}
+
+type exportedFile struct {
+	name  string
+	rows  int64
+	bytes int64
+}
+
+// write is the callback of the streaming query.
+func (exp *resultExporter) write(result *sqltypes.Result) error {
+	if result.Fields != nil {
+		exp.fields = result.Fields
+	}
+	for _, row := range result.Rows {
+		if exp.file != nil && exp.rowsPerFile > 0 && exp.rows >= exp.rowsPerFile {
+			if err := exp.closeFile(); err != nil {
+				return err
+			}
+		}
+		if exp.file == nil {
+			if err := exp.openFile(); err != nil {
+				return err
+			}
+		}
+		if err := exp.writer.writeRow(row); err != nil {
+			return err
+		}
+		exp.rows++
+	}
+	return nil
+}
+
+func (exp *resultExporter) openFile() error {
+	extension := "csv"
+	if exp.format == querypb.ExportOptions_PARQUET {
+		extension = "parquet"
+	}
+	name := fmt.Sprintf("%05d.%s", len(exp.files), extension)
+	file, err := exp.handle.AddFile(exp.ctx, name, backupstorage.FileSizeUnknown)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot add file %v to the export", name)
+	}
+	exp.file = file
+	exp.count = &countingWriter{w: file}
+	exp.rows = 0
+	exp.files = append(exp.files, exportedFile{name: name})
+
+	if exp.format == querypb.ExportOptions_PARQUET {
+		exp.writer, err = newParquetWriter(exp.count, exp.fields)
+	} else {
+		exp.writer, err = newCSVWriter(exp.count, exp.fields)
+	}
+	return err
+}
+
+// closeFile flushes and closes the file being written, if any.
+func (exp *resultExporter) closeFile() error {
+	if exp.file == nil {
+		return nil
+	}
+	var err error
+	if exp.writer != nil {
+		err = exp.writer.close()
+		exp.writer = nil
+	}
+	if closeErr := exp.file.Close(); err == nil {
+		err = closeErr
+	}
+	last := &exp.files[len(exp.files)-1]
+	last.rows = exp.rows
+	last.bytes = exp.count.n
+	exp.file = nil
+	return err
+}
+
+// manifest returns the result that describes the files of the export.
+func (exp *resultExporter) manifest() *sqltypes.Result {
+	result := &sqltypes.Result{Fields: exportFields}
+	for _, f := range exp.files {
+		result.Rows = append(result.Rows, []sqltypes.Value{
+			sqltypes.NewVarChar(path.Join(exp.handle.Directory(), exp.handle.Name(), f.name)),
+			sqltypes.NewInt64(f.rows),
+			sqltypes.NewInt64(f.bytes),
+		})
+	}
+	return result
+}
+
+// exportWriter writes the rows of a file of an export in the format of the
+// export.
+type exportWriter interface {
+	writeRow(row sqltypes.Row) error
+	// close writes the rows that are buffered, if any, and the end of the
+	// file. It does not close the file.
+	close() error
+}
+
+// csvWriter writes CSV files. Every file starts with a header that holds the
+// names of the fields.
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, fields []*querypb.Field) (*csvWriter, error) {
+	cw := &csvWriter{writer: csv.NewWriter(w)}
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.Name
+	}
+	return cw, cw.writer.Write(header)
+}
+
+func (cw *csvWriter) writeRow(row sqltypes.Row) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		if v.IsNull() {
+			record[i] = csvNull
+		} else {
+			record[i] = v.ToString()
+		}
+	}
+	return cw.writer.Write(record)
+}
+
+func (cw *csvWriter) close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// parquetWriter writes Parquet files compressed with Snappy. The columns have
+// the Arrow types of the sqlarrow package, and the files store the Arrow
+// schema, with the MySQL types of the columns in the metadata of its fields.
+// Parquet has no type for the range of the TIME values, which are written as
+// 64-bit integers of microseconds without the MySQL type.
+type parquetWriter struct {
+	builder *sqlarrow.Builder
+	schema  *arrow.Schema
+	writer  *pqarrow.FileWriter
+}
+
+func newParquetWriter(w io.Writer, fields []*querypb.Field) (*parquetWriter, error) {
+	builder, err := sqlarrow.NewBuilder(memory.DefaultAllocator, fields)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot export the results as Parquet")
+	}
+	schemaFields := builder.Schema().Fields()
+	for i, f := range schemaFields {
+		if f.Type.ID() == arrow.DURATION {
+			schemaFields[i] = arrow.Field{Name: f.Name, Type: arrow.PrimitiveTypes.Int64, Nullable: f.Nullable}
+		}
+	}
+	schema := arrow.NewSchema(schemaFields, nil)
+
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	writer, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		builder.Release()
+		return nil, vterrors.Wrapf(err, "cannot export the results as Parquet")
+	}
+	return &parquetWriter{builder: builder, schema: schema, writer: writer}, nil
+}
+
+func (pw *parquetWriter) writeRow(row sqltypes.Row) error {
+	if err := pw.builder.Append([]sqltypes.Row{row}); err != nil {
+		return err
+	}
+	if pw.builder.Len() >= parquetRowGroupRows {
+		return pw.writeRowGroup()
+	}
+	return nil
+}
+
+// writeRowGroup writes the rows appended to the builder as a row group.
+func (pw *parquetWriter) writeRowGroup() error {
+	rec := pw.builder.NewRecordBatch()
+	defer rec.Release()
+
+	columns := slices.Clone(rec.Columns())
+	for i, column := range columns {
+		if column.DataType().ID() != arrow.DURATION {
+			continue
+		}
+		// Durations and 64-bit integers have the same layout.
+		data := column.Data()
+		int64Data := array.NewData(arrow.PrimitiveTypes.Int64, data.Len(), data.Buffers(), nil, data.NullN(), data.Offset())
+		columns[i] = array.MakeFromData(int64Data)
+		int64Data.Release()
+		defer columns[i].Release()
+	}
+	rowGroup := array.NewRecordBatch(pw.schema, columns, rec.NumRows())
+	defer rowGroup.Release()
+	return pw.writer.Write(rowGroup)
+}
+
+func (pw *parquetWriter) close() error {
+	defer pw.builder.Release()
+	var err error
+	if pw.builder.Len() > 0 {
+		err = pw.writeRowGroup()
+	}
+	if closeErr := pw.writer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/sqltypes/sqlarrow"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+func setupExportStorage(t *testing.T) string {
+	root := t.TempDir()
+	oldImplementation, oldRoot := backupstorage.BackupStorageImplementation, filebackupstorage.FileBackupStorageRoot
+	backupstorage.BackupStorageImplementation, filebackupstorage.FileBackupStorageRoot = "file", root
+	t.Cleanup(func() {
+		backupstorage.BackupStorageImplementation, filebackupstorage.FileBackupStorageRoot = oldImplementation, oldRoot
+	})
+	return root
+}
+
+func TestTabletServerStreamExecuteExport(t *testing.T) {
+	root := setupExportStorage(t)
+
+	ctx := t.Context()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.EnableResultExport = true
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|name", "int64|varchar"),
+		"1|alice",
+		"2|null",
+		"3|carol, jr.",
+	))
+
+	var results []*sqltypes.Result
+	callback := func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	}
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{Export: &querypb.ExportOptions{Directory: "extract", RowsPerFile: 2}}
+	err := tsv.StreamExecute(ctx, nil, &target, executeSQL, nil, 0, 0, options, callback)
+	require.NoError(t, err)
+
+	// the rows are not returned, only the manifest of the files
+	require.Len(t, results, 1)
+	assert.Equal(t, exportFields, results[0].Fields)
+	require.Len(t, results[0].Rows, 2)
+	assert.Equal(t, "extract/-0000000000/00000.csv", results[0].Rows[0][0].ToString())
+	assert.Equal(t, "2", results[0].Rows[0][1].ToString())
+	assert.Equal(t, "extract/-0000000000/00001.csv", results[0].Rows[1][0].ToString())
+	assert.Equal(t, "1", results[0].Rows[1][1].ToString())
+
+	content, err := os.ReadFile(filepath.Join(root, "extract", "-0000000000", "00000.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n1,alice\n2,\\N\n", string(content))
+	assert.Equal(t, strconv.Itoa(len(content)), results[0].Rows[0][2].ToString())
+
+	content, err = os.ReadFile(filepath.Join(root, "extract", "-0000000000", "00001.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n3,\"carol, jr.\"\n", string(content))
+}
+
+func TestTabletServerStreamExecuteExportParquet(t *testing.T) {
+	root := setupExportStorage(t)
+
+	ctx := t.Context()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.EnableResultExport = true
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|name|elapsed", "int64|varchar|time"),
+		"1|alice|01:02:03",
+		"2|null|-838:59:59",
+		"3|carol|null",
+	))
+
+	var results []*sqltypes.Result
+	callback := func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	}
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{Export: &querypb.ExportOptions{Directory: "extract", Format: querypb.ExportOptions_PARQUET, RowsPerFile: 2}}
+	err := tsv.StreamExecute(ctx, nil, &target, executeSQL, nil, 0, 0, options, callback)
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Rows, 2)
+	assert.Equal(t, "extract/-0000000000/00000.parquet", results[0].Rows[0][0].ToString())
+	assert.Equal(t, "2", results[0].Rows[0][1].ToString())
+	assert.Equal(t, "extract/-0000000000/00001.parquet", results[0].Rows[1][0].ToString())
+	assert.Equal(t, "1", results[0].Rows[1][1].ToString())
+
+	readFile := func(name string) *sqltypes.Result {
+		content, err := os.ReadFile(filepath.Join(root, "extract", "-0000000000", name))
+		require.NoError(t, err)
+		table, err := pqarrow.ReadTable(ctx, bytes.NewReader(content), nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+		require.NoError(t, err)
+		defer table.Release()
+		reader := array.NewTableReader(table, -1)
+		defer reader.Release()
+
+		result := &sqltypes.Result{}
+		err = sqlarrow.StreamRecordBatches(reader, func(r *sqltypes.Result) error {
+			if r.Fields != nil {
+				result.Fields = r.Fields
+			}
+			result.Rows = append(result.Rows, r.Rows...)
+			return nil
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// the MySQL types are kept, except for TIME, which is written in
+	// microseconds
+	result := readFile("00000.parquet")
+	require.Len(t, result.Fields, 3)
+	assert.Equal(t, sqltypes.Int64, result.Fields[0].Type)
+	assert.Equal(t, sqltypes.VarChar, result.Fields[1].Type)
+	assert.Equal(t, sqltypes.Int64, result.Fields[2].Type)
+	assert.Equal(t, []sqltypes.Row{
+		{sqltypes.NewInt64(1), sqltypes.NewVarChar("alice"), sqltypes.NewInt64(3723000000)},
+		{sqltypes.NewInt64(2), sqltypes.NULL, sqltypes.NewInt64(-3020399000000)},
+	}, result.Rows)
+
+	result = readFile("00001.parquet")
+	assert.Equal(t, []sqltypes.Row{
+		{sqltypes.NewInt64(3), sqltypes.NewVarChar("carol"), sqltypes.NULL},
+	}, result.Rows)
+}
+
+func TestTabletServerStreamExecuteExportErrors(t *testing.T) {
+	setupExportStorage(t)
+
+	ctx := t.Context()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, &sqltypes.Result{})
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	callback := func(*sqltypes.Result) error { return nil }
+	streamExport := func(export *querypb.ExportOptions) error {
+		return tsv.StreamExecute(ctx, nil, &target, executeSQL, nil, 0, 0, &querypb.ExecuteOptions{Export: export}, callback)
+	}
+
+	err := streamExport(&querypb.ExportOptions{Directory: "extract"})
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+
+	tsv.config.EnableResultExport = true
+	err = streamExport(&querypb.ExportOptions{Directory: "extract", Format: 2})
+	assert.Equal(t, vtrpcpb.Code_UNIMPLEMENTED, vterrors.Code(err))
+
+	for _, directory := range []string{"", "/tmp/extract", "../extract", "extract/../../backups", `extract\..\..`, `\\server\share`} {
+		err = streamExport(&querypb.ExportOptions{Directory: directory})
+		assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err), directory)
+	}
+}
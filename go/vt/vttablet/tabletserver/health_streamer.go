@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -38,6 +40,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 )
@@ -88,6 +91,11 @@ type healthStreamer struct {
 	signalWhenSchemaChange bool
 
 	viewsEnabled bool
+
+	// cutOverTables has a timer for every table with an imminent Online DDL
+	// cut-over, which clears the table from the health state if the cut-over
+	// isn't done by then.
+	cutOverTables map[string]*time.Timer
 }
 
 func newHealthStreamer(env tabletenv.Env, alias *topodatapb.TabletAlias, engine *schema.Engine) *healthStreamer {
@@ -102,12 +110,14 @@ func newHealthStreamer(env tabletenv.Env, alias *topodatapb.TabletAlias, engine
 			RealtimeStats: &querypb.RealtimeStats{
 				HealthError: errUnintialized,
 			},
+			Capabilities: queryservice.TabletCapabilities(),
 		},
 
 		history:                history.New(5),
 		signalWhenSchemaChange: env.Config().SignalWhenSchemaChange,
 		viewsEnabled:           env.Config().EnableViews,
 		se:                     engine,
+		cutOverTables:          make(map[string]*time.Timer),
 	}
 	hs.unhealthyThreshold.Store(env.Config().Healthcheck.UnhealthyThreshold.Nanoseconds())
 	return hs
@@ -374,3 +384,56 @@ func (hs *healthStreamer) sendUnresolvedTransactionSignal() {
 	hs.broadCastToClients(shr)
 	hs.state.RealtimeStats.TxUnresolved = false
 }
+
+// sendColumnStatisticsSignal sends broadcast message about refreshed column statistics.
+func (hs *healthStreamer) sendColumnStatisticsSignal() {
+	hs.fieldsMu.Lock()
+	defer hs.fieldsMu.Unlock()
+	// send signal only when primary is serving.
+	if !hs.isServingPrimary {
+		return
+	}
+
+	hs.state.RealtimeStats.ColumnStatisticsChanged = true
+	shr := hs.state.CloneVT()
+	hs.broadCastToClients(shr)
+	hs.state.RealtimeStats.ColumnStatisticsChanged = false
+}
+
+// setCutOverImminent adds the table to the tables with an imminent Online DDL cut-over in the
+// health state, or removes it when the cut-over is done. The table is removed after timeout
+// at the latest.
+func (hs *healthStreamer) setCutOverImminent(table string, imminent bool, timeout time.Duration) {
+	hs.fieldsMu.Lock()
+	defer hs.fieldsMu.Unlock()
+
+	if timer, ok := hs.cutOverTables[table]; ok {
+		timer.Stop()
+		delete(hs.cutOverTables, table)
+	}
+	if imminent {
+		var timer *time.Timer
+		timer = time.AfterFunc(timeout, func() {
+			hs.fieldsMu.Lock()
+			defer hs.fieldsMu.Unlock()
+			if hs.cutOverTables[table] != timer {
+				// The cut-over was done, or a new one started.
+				return
+			}
+			log.Warn(fmt.Sprintf("Online DDL cut-over of table %s wasn't done after %v, removing it from the health state", table, timeout))
+			delete(hs.cutOverTables, table)
+			hs.broadcastCutOverTablesLocked()
+		})
+		hs.cutOverTables[table] = timer
+	}
+	hs.broadcastCutOverTablesLocked()
+}
+
+func (hs *healthStreamer) broadcastCutOverTablesLocked() {
+	tables := slices.Sorted(maps.Keys(hs.cutOverTables))
+	if slices.Equal(tables, hs.state.RealtimeStats.CutoverTables) {
+		return
+	}
+	hs.state.RealtimeStats.CutoverTables = tables
+	hs.broadCastToClients(hs.state.CloneVT())
+}
@@ -35,6 +35,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 )
@@ -108,8 +109,9 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 
 	shr := <-ch
 	want := &querypb.StreamHealthResponse{
-		Target:      &querypb.Target{},
-		TabletAlias: alias,
+		Target:       &querypb.Target{},
+		TabletAlias:  alias,
+		Capabilities: queryservice.TabletCapabilities(),
 		RealtimeStats: &querypb.RealtimeStats{
 			HealthError: "tabletserver uninitialized",
 		},
@@ -122,7 +124,8 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 		Target: &querypb.Target{
 			TabletType: topodatapb.TabletType_REPLICA,
 		},
-		TabletAlias: alias,
+		TabletAlias:  alias,
+		Capabilities: queryservice.TabletCapabilities(),
 		RealtimeStats: &querypb.RealtimeStats{
 			FilteredReplicationLagSeconds: 1,
 			BinlogPlayersCount:            2,
@@ -139,6 +142,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 			TabletType: topodatapb.TabletType_PRIMARY,
 		},
 		TabletAlias:               alias,
+		Capabilities:              queryservice.TabletCapabilities(),
 		Serving:                   true,
 		PrimaryTermStartTimestamp: now.Unix(),
 		RealtimeStats: &querypb.RealtimeStats{
@@ -155,7 +159,8 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 		Target: &querypb.Target{
 			TabletType: topodatapb.TabletType_REPLICA,
 		},
-		TabletAlias: alias,
+		TabletAlias:  alias,
+		Capabilities: queryservice.TabletCapabilities(),
 		RealtimeStats: &querypb.RealtimeStats{
 			ReplicationLagSeconds:         1,
 			FilteredReplicationLagSeconds: 1,
@@ -171,7 +176,8 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 		Target: &querypb.Target{
 			TabletType: topodatapb.TabletType_REPLICA,
 		},
-		TabletAlias: alias,
+		TabletAlias:  alias,
+		Capabilities: queryservice.TabletCapabilities(),
 		RealtimeStats: &querypb.RealtimeStats{
 			HealthError:                   "repl err",
 			FilteredReplicationLagSeconds: 1,
@@ -621,3 +627,42 @@ func TestDeadlockBwCloseAndReload(t *testing.T) {
 	// Wait for wait group to finish.
 	wg.Wait()
 }
+
+func TestHealthStreamerCutOverImminent(t *testing.T) {
+	cfg := newConfig(nil)
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TestHealthStreamerCutOverImminent")
+	alias := &topodatapb.TabletAlias{
+		Cell: "cell",
+		Uid:  1,
+	}
+	hs := newHealthStreamer(env, alias, &schema.Engine{})
+	hs.Open()
+	defer hs.Close()
+
+	ch, cancel := testStream(hs)
+	defer cancel()
+	<-ch
+
+	hs.setCutOverImminent("t2", true, time.Minute)
+	shr := <-ch
+	assert.Equal(t, []string{"t2"}, shr.RealtimeStats.CutoverTables)
+
+	hs.setCutOverImminent("t1", true, 10*time.Millisecond)
+	shr = <-ch
+	assert.Equal(t, []string{"t1", "t2"}, shr.RealtimeStats.CutoverTables)
+
+	// The signal of t1 expires, as its cut-over is never done.
+	shr = <-ch
+	assert.Equal(t, []string{"t2"}, shr.RealtimeStats.CutoverTables)
+
+	// The signal is part of the state that new streams receive.
+	ch2, cancel2 := testStream(hs)
+	defer cancel2()
+	shr = <-ch2
+	assert.Equal(t, []string{"t2"}, shr.RealtimeStats.CutoverTables)
+
+	hs.setCutOverImminent("t2", false, time.Minute)
+	shr = <-ch
+	assert.Empty(t, shr.RealtimeStats.CutoverTables)
+	assert.Empty(t, hs.cutOverTables)
+}
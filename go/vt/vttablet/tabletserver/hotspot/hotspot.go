@@ -0,0 +1,370 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hotspot samples the primary key values accessed by the queries
+// executed on a tablet and reports the key ranges of each table that receive
+// the most queries.
+//
+// Primary key values are never kept: they are hashed into a keyspace id, and
+// only the number of samples per key range is recorded. A single-column
+// primary key is hashed like the xxhash vindex, so that the reported key
+// ranges match the shards of a table sharded by xxhash on its primary key.
+package hotspot
+
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	// numKeyRanges is the number of key ranges the keyspace ids are counted
+	// in: one per value of their first byte.
+	numKeyRanges = 256
+	// maxKeys bounds the number of keys recorded for a single query, e.g.
+	// for a large IN list.
+	maxKeys = 100
+)
+
+type (
+	// Detector samples the primary key values of the executed queries and
+	// counts them per key range of each table.
+	Detector struct {
+		enabled bool
+		parser  *sqlparser.Parser
+		topN    int
+
+		samples *stats.CountersWithSingleLabel
+
+		// sample returns true if a query should be sampled.
+		sample func() bool
+
+		mu     sync.Mutex
+		tables map[string]*[numKeyRanges]int64
+	}
+
+	// KeyRangeCount is the number of sampled keys of a table in a key range.
+	KeyRangeCount struct {
+		KeyRange string
+		Samples  int64
+		// Share is the fraction of the samples of the table in the key range.
+		Share float64
+	}
+)
+
+// New creates a new Detector. If hotspot detection is not enabled in the
+// config, the returned Detector ignores all observations.
+func New(env tabletenv.Env) *Detector {
+	config := env.Config().HotspotDetection
+	if !config.Enable {
+		return &Detector{}
+	}
+	d := &Detector{
+		enabled: true,
+		parser:  env.Environment().Parser(),
+		topN:    config.TopN,
+		samples: env.Exporter().NewCountersWithSingleLabel("HotspotSamples", "Number of primary keys sampled for hotspot detection by table", "Table"),
+		sample: func() bool {
+			return rand.Float64() < config.SampleRate
+		},
+		tables: make(map[string]*[numKeyRanges]int64),
+	}
+	env.Exporter().NewGaugesFuncWithMultiLabels("HotspotKeyRangeSamples", "Number of primary keys sampled in the hottest key ranges of each table", []string{"Table", "KeyRange"}, d.keyRangeSamples)
+	return d
+}
+
+// Observe samples the primary key values used by a query. original is the
+// SQL of the query as received by the tablet, and bindVars its bind variables.
+func (d *Detector) Observe(plan *planbuilder.Plan, original string, bindVars map[string]*querypb.BindVariable) {
+	if !d.enabled || plan.Table == nil || !plan.Table.HasPrimary() || !observable(plan.PlanID) {
+		return
+	}
+	if !d.sample() {
+		return
+	}
+	stmt, err := d.parser.Parse(original)
+	if err != nil {
+		return
+	}
+	keys := primaryKeys(stmt, plan.Table, bindVars)
+	if len(keys) == 0 {
+		return
+	}
+
+	table := plan.Table.Name.String()
+	d.samples.Add(table, int64(len(keys)))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	counts := d.tables[table]
+	if counts == nil {
+		counts = new([numKeyRanges]int64)
+		d.tables[table] = counts
+	}
+	for _, k := range keys {
+		counts[keyspaceID(k)>>56]++
+	}
+}
+
+// Hotspots returns the hottest key ranges of each table, hottest first.
+func (d *Detector) Hotspots() map[string][]KeyRangeCount {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	hotspots := make(map[string][]KeyRangeCount, len(d.tables))
+	for table, counts := range d.tables {
+		var total int64
+		var ranges []KeyRangeCount
+		for i, count := range counts {
+			total += count
+			if count > 0 {
+				ranges = append(ranges, KeyRangeCount{KeyRange: keyRangeString(i), Samples: count})
+			}
+		}
+		slices.SortStableFunc(ranges, func(a, b KeyRangeCount) int {
+			return cmp.Compare(b.Samples, a.Samples)
+		})
+		if len(ranges) > d.topN {
+			ranges = ranges[:d.topN]
+		}
+		for i := range ranges {
+			ranges[i].Share = float64(ranges[i].Samples) / float64(total)
+		}
+		hotspots[table] = ranges
+	}
+	return hotspots
+}
+
+func (d *Detector) keyRangeSamples() map[string]int64 {
+	samples := make(map[string]int64)
+	for table, ranges := range d.Hotspots() {
+		for _, r := range ranges {
+			samples[table+"."+r.KeyRange] = r.Samples
+		}
+	}
+	return samples
+}
+
+// ServeHTTP serves the hottest key ranges of each table as JSON.
+func (d *Detector) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
+		acl.SendError(response, err)
+		return
+	}
+	response.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if !d.enabled {
+		response.Write([]byte("{}\n"))
+		return
+	}
+	b, err := json.MarshalIndent(d.Hotspots(), "", "  ")
+	if err != nil {
+		response.Write([]byte(err.Error()))
+		return
+	}
+	response.Write(b)
+}
+
+// observable returns true for the plan types whose primary key values can
+// be sampled.
+func observable(planID planbuilder.PlanType) bool {
+	switch planID {
+	case planbuilder.PlanSelect, planbuilder.PlanInsert, planbuilder.PlanUpdate, planbuilder.PlanDelete,
+		planbuilder.PlanUpdateLimit, planbuilder.PlanDeleteLimit, planbuilder.PlanInsertReturning:
+		return true
+	}
+	return false
+}
+
+// primaryKeys returns the primary key values of the rows inserted by stmt,
+// or selected by its WHERE clause. It returns nil if stmt does not fully
+// identify its rows by primary key.
+func primaryKeys(stmt sqlparser.Statement, table *schema.Table, bindVars map[string]*querypb.BindVariable) [][]sqltypes.Value {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return insertKeys(stmt, table, bindVars)
+	case *sqlparser.Select:
+		return whereKeys(stmt.Where, table, bindVars)
+	case *sqlparser.Update:
+		return whereKeys(stmt.Where, table, bindVars)
+	case *sqlparser.Delete:
+		return whereKeys(stmt.Where, table, bindVars)
+	}
+	return nil
+}
+
+func insertKeys(ins *sqlparser.Insert, table *schema.Table, bindVars map[string]*querypb.BindVariable) [][]sqltypes.Value {
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return nil
+	}
+	positions := make([]int, len(table.PKColumns))
+	for i := range table.PKColumns {
+		positions[i] = slices.IndexFunc(ins.Columns, func(col sqlparser.IdentifierCI) bool {
+			return col.EqualString(table.GetPKColumn(i).Name)
+		})
+		if positions[i] < 0 {
+			return nil
+		}
+	}
+
+	var keys [][]sqltypes.Value
+	for _, row := range rows {
+		if len(keys) == maxKeys {
+			break
+		}
+		k := make([]sqltypes.Value, len(positions))
+		for i, pos := range positions {
+			if pos >= len(row) {
+				return nil
+			}
+			values := exprValues(row[pos], bindVars)
+			if len(values) != 1 {
+				return nil
+			}
+			k[i] = values[0]
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func whereKeys(where *sqlparser.Where, table *schema.Table, bindVars map[string]*querypb.BindVariable) [][]sqltypes.Value {
+	if where == nil {
+		return nil
+	}
+	columnValues := make([][]sqltypes.Value, len(table.PKColumns))
+	for _, expr := range sqlparser.SplitAndExpression(nil, where.Expr) {
+		comparison, ok := expr.(*sqlparser.ComparisonExpr)
+		if !ok || (comparison.Operator != sqlparser.EqualOp && comparison.Operator != sqlparser.InOp) {
+			continue
+		}
+		col, ok := comparison.Left.(*sqlparser.ColName)
+		if !ok {
+			continue
+		}
+		for i := range table.PKColumns {
+			if col.Name.EqualString(table.GetPKColumn(i).Name) && columnValues[i] == nil {
+				columnValues[i] = exprValues(comparison.Right, bindVars)
+			}
+		}
+	}
+
+	keys := [][]sqltypes.Value{nil}
+	for _, values := range columnValues {
+		if len(values) == 0 || len(keys)*len(values) > maxKeys {
+			return nil
+		}
+		var product [][]sqltypes.Value
+		for _, k := range keys {
+			for _, v := range values {
+				product = append(product, append(slices.Clip(k), v))
+			}
+		}
+		keys = product
+	}
+	return keys
+}
+
+// exprValues returns the values of a literal, a bind variable or a tuple of
+// them. It returns nil for any other expression.
+func exprValues(expr sqlparser.Expr, bindVars map[string]*querypb.BindVariable) []sqltypes.Value {
+	switch expr := expr.(type) {
+	case *sqlparser.Literal:
+		v, err := sqlparser.LiteralToValue(expr)
+		if err != nil {
+			return nil
+		}
+		return []sqltypes.Value{v}
+	case *sqlparser.Argument:
+		bv, ok := bindVars[expr.Name]
+		if !ok {
+			return nil
+		}
+		v, err := sqltypes.BindVariableToValue(bv)
+		if err != nil {
+			return nil
+		}
+		return []sqltypes.Value{v}
+	case sqlparser.ListArg:
+		bv, ok := bindVars[string(expr)]
+		if !ok {
+			return nil
+		}
+		values := make([]sqltypes.Value, 0, len(bv.Values))
+		for _, v := range bv.Values {
+			values = append(values, sqltypes.ProtoToValue(v))
+		}
+		return values
+	case sqlparser.ValTuple:
+		var values []sqltypes.Value
+		for _, e := range expr {
+			v := exprValues(e, bindVars)
+			if len(v) != 1 {
+				return nil
+			}
+			values = append(values, v[0])
+		}
+		return values
+	}
+	return nil
+}
+
+// keyspaceID hashes a primary key. A single value is hashed like the
+// xxhash vindex does.
+func keyspaceID(k []sqltypes.Value) uint64 {
+	if len(k) == 1 {
+		return xxhash.Sum64(k[0].Raw())
+	}
+	digest := xxhash.New()
+	var buf []byte
+	for _, v := range k {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(v.Raw())))
+		_, _ = digest.Write(buf)
+		_, _ = digest.Write(v.Raw())
+	}
+	return digest.Sum64()
+}
+
+// keyRangeString returns the key range of the keyspace ids starting with
+// the byte b, e.g. 40-41, named like the shards.
+func keyRangeString(b int) string {
+	kr := &topodatapb.KeyRange{}
+	if b > 0 {
+		kr.Start = []byte{byte(b)}
+	}
+	if b < numKeyRanges-1 {
+		kr.End = []byte{byte(b + 1)}
+	}
+	return key.KeyRangeString(kr)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspot
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func newTable(name string, columns []string, pkColumns ...int) *schema.Table {
+	table := schema.NewTable(name, schema.NoType)
+	for _, col := range columns {
+		table.Fields = append(table.Fields, &querypb.Field{Name: col, Type: sqltypes.Int64})
+	}
+	table.PKColumns = pkColumns
+	return table
+}
+
+func newDetector(t *testing.T) *Detector {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotspotDetection.Enable = true
+	cfg.HotspotDetection.TopN = 2
+
+	d := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name()))
+	d.sample = func() bool { return true }
+	return d
+}
+
+func TestPrimaryKeys(t *testing.T) {
+	single := newTable("t", []string{"id", "val"}, 0)
+	composite := newTable("t", []string{"a", "b", "val"}, 0, 1)
+	bindVars := map[string]*querypb.BindVariable{
+		"id":   sqltypes.Int64BindVariable(7),
+		"list": sqltypes.TestBindVariable([]any{1, 2}),
+	}
+
+	testCases := []struct {
+		query string
+		table *schema.Table
+		want  []string
+	}{{
+		query: "select val from t where id = 1",
+		table: single,
+		want:  []string{"1"},
+	}, {
+		query: "select val from t where val > 3 and id = :id",
+		table: single,
+		want:  []string{"7"},
+	}, {
+		query: "update t set val = 1 where id in (1, 2, :id)",
+		table: single,
+		want:  []string{"1", "2", "7"},
+	}, {
+		query: "delete from t where id in ::list",
+		table: single,
+		want:  []string{"1", "2"},
+	}, {
+		query: "insert into t(val, id) values (1, 2), (3, :id)",
+		table: single,
+		want:  []string{"2", "7"},
+	}, {
+		query: "select val from t where a in (1, 2) and b = 3",
+		table: composite,
+		want:  []string{"1,3", "2,3"},
+	}, {
+		// The rows are not identified by their primary key.
+		query: "select val from t where val = 1",
+		table: single,
+	}, {
+		query: "select val from t where a = 1",
+		table: composite,
+	}, {
+		query: "select val from t where id = 1 or id = 2",
+		table: single,
+	}, {
+		query: "insert into t(val) values (1)",
+		table: single,
+	}, {
+		query: "insert into t(id, val) select id, val from t2",
+		table: single,
+	}}
+
+	parser := sqlparser.NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+
+			var got []string
+			for _, k := range primaryKeys(stmt, tc.table, bindVars) {
+				var s string
+				for i, v := range k {
+					if i > 0 {
+						s += ","
+					}
+					s += v.ToString()
+				}
+				got = append(got, s)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDetector(t *testing.T) {
+	d := newDetector(t)
+	table := newTable("t", []string{"id", "val"}, 0)
+	plan := &planbuilder.Plan{PlanID: planbuilder.PlanSelect, Table: table}
+
+	for range 3 {
+		d.Observe(plan, "select val from t where id = 1", nil)
+	}
+	d.Observe(plan, "select val from t where id = 2", nil)
+	// Queries that are not sampled, or whose keys are unknown, are not counted.
+	d.Observe(plan, "select val from t where val = 1", nil)
+	d.Observe(&planbuilder.Plan{PlanID: planbuilder.PlanDDL, Table: table}, "alter table t add column c int", nil)
+
+	// A single-column key is hashed like the xxhash vindex.
+	hot := keyRangeString(int(xxhash.Sum64String("1") >> 56))
+	cold := keyRangeString(int(xxhash.Sum64String("2") >> 56))
+	require.NotEqual(t, hot, cold)
+
+	want := map[string][]KeyRangeCount{
+		"t": {
+			{KeyRange: hot, Samples: 3, Share: 0.75},
+			{KeyRange: cold, Samples: 1, Share: 0.25},
+		},
+	}
+	assert.Equal(t, want, d.Hotspots())
+	assert.EqualValues(t, 4, d.samples.Counts()["t"])
+
+	response := httptest.NewRecorder()
+	d.ServeHTTP(response, httptest.NewRequest("GET", "/debug/hotspots", nil))
+	var got map[string][]KeyRangeCount
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &got))
+	assert.Equal(t, want, got)
+}
+
+func TestKeyRangeString(t *testing.T) {
+	assert.Equal(t, "-01", keyRangeString(0))
+	assert.Equal(t, "40-41", keyRangeString(0x40))
+	assert.Equal(t, "ff-", keyRangeString(0xff))
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	sqlInsertJob      = "insert into %s.jobs (job_name, job_type, job_state, job_args) values (%a, %a, 'queued', %a)"
+	sqlSelectJobs     = "select job_name, job_type, job_state, job_args, progress, message, lease_owner, attempts, created_at, started_at, updated_at, completed_at from %s.jobs order by created_at, job_name"
+	sqlSelectRunnable = "select job_name, job_type from %s.jobs where job_state in ('queued', 'running') and (lease_owner in ('', %a) or lease_expires < now(6)) order by created_at, job_name"
+	sqlAcquireLease   = "update %s.jobs set job_state = 'running', lease_owner = %a, lease_expires = now(6) + interval %a second, attempts = attempts + 1, started_at = ifnull(started_at, now(6)) where job_name = %a and job_state in ('queued', 'running') and (lease_owner in ('', %a) or lease_expires < now(6))"
+	sqlSelectJob      = "select job_args, checkpoint from %s.jobs where job_name = %a"
+	sqlRenewLease     = "update %s.jobs set lease_expires = now(6) + interval %a second where job_name = %a and job_state = 'running' and lease_owner = %a"
+	sqlUpdateProgress = "update %s.jobs set progress = %a, checkpoint = %a, message = %a, lease_expires = now(6) + interval %a second where job_name = %a and job_state = 'running' and lease_owner = %a"
+	sqlCompleteJob    = "update %s.jobs set job_state = 'complete', progress = 1, message = '', lease_owner = '', lease_expires = null, completed_at = now(6) where job_name = %a and job_state = 'running' and lease_owner = %a"
+	sqlFailJob        = "update %s.jobs set job_state = 'failed', message = %a, lease_owner = '', lease_expires = null, completed_at = now(6) where job_name = %a and job_state = 'running' and lease_owner = %a"
+	sqlReleaseLease   = "update %s.jobs set lease_owner = '', lease_expires = null where job_name = %a and lease_owner = %a"
+	sqlPauseJob       = "update %s.jobs set job_state = 'paused' where job_name = %a and job_state in ('queued', 'running')"
+	sqlResumeJob      = "update %s.jobs set job_state = 'queued' where job_name = %a and job_state = 'paused'"
+	sqlCancelJob      = "update %s.jobs set job_state = 'cancelled', completed_at = now(6) where job_name = %a and job_state in ('queued', 'running', 'paused')"
+)
+
+var (
+	// leaseDuration is how long a tablet holds the lease of a job it doesn't
+	// renew. If a tablet dies while it runs a job, another tablet can only
+	// take the job over once the lease expired; the tablet itself takes it
+	// back right away when it restarts.
+	leaseDuration = time.Minute
+	// pollInterval is the interval between checks for jobs to run.
+	pollInterval = 10 * time.Second
+)
+
+// Engine runs the jobs of the jobs table on the primary tablet, one at a time
+// in the order they were submitted. It's only open while the tablet is a
+// primary: the jobs it was running when it's closed are resumed by the next
+// tablet that opens an Engine, from their last checkpoint.
+type Engine struct {
+	env   tabletenv.Env
+	pool  *connpool.Pool
+	owner string
+
+	mu     sync.Mutex
+	isOpen atomic.Bool
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	wakeup chan struct{}
+
+	runningMu sync.Mutex
+	running   *Job
+
+	jobRuns *stats.CountersWithMultiLabels
+}
+
+// NewEngine creates a new Engine. The jobs it runs are leased by the tablet
+// with the given alias.
+func NewEngine(env tabletenv.Env, alias *topodatapb.TabletAlias) *Engine {
+	e := &Engine{
+		env:   env,
+		owner: topoproto.TabletAliasString(alias),
+		pool: connpool.NewPool(env, "JobsPool", tabletenv.ConnPoolConfig{
+			Size:        2,
+			IdleTimeout: env.Config().OltpReadPool.IdleTimeout,
+		}),
+		wakeup:  make(chan struct{}, 1),
+		jobRuns: env.Exporter().NewCountersWithMultiLabels("JobRuns", "Number of runs of the jobs of each type, by outcome", []string{"Type", "Outcome"}),
+	}
+	env.Exporter().HandleFunc("/debug/jobs", e.handleHTTP)
+	return e
+}
+
+// Open starts running jobs, if a type of jobs is registered.
+func (e *Engine) Open() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.isOpen.Load() || !hasRunners() {
+		return nil
+	}
+
+	log.Info("Jobs engine: opening")
+	e.pool.Open(e.env.Config().DB.AllPrivsWithDB(), e.env.Config().DB.DbaWithDB(), e.env.Config().DB.AppDebugWithDB())
+	var ctx context.Context
+	ctx, e.cancel = context.WithCancel(context.Background())
+	e.wg.Add(1)
+	e.isOpen.Store(true)
+	go e.operate(ctx)
+	return nil
+}
+
+// Close stops running jobs. It interrupts the running job and releases its
+// lease, so that the next primary resumes it right away.
+func (e *Engine) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.isOpen.Load() {
+		return
+	}
+
+	log.Info("Jobs engine: closing")
+	e.cancel()
+	e.wg.Wait()
+	e.isOpen.Store(false)
+	e.pool.Close()
+}
+
+func (e *Engine) operate(ctx context.Context) {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		ran, err := e.runNextJob(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error(fmt.Sprintf("Jobs engine: error running jobs: %v", err))
+		}
+		if ran && err == nil {
+			// There may be more jobs waiting.
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-e.wakeup:
+		}
+	}
+}
+
+// runNextJob leases the next job that can run, and runs it. It returns
+// whether it ran a job.
+func (e *Engine) runNextJob(ctx context.Context) (bool, error) {
+	qr, err := e.exec(ctx, sqlSelectRunnable, -1, sqltypes.StringBindVariable(e.owner))
+	if err != nil {
+		return false, err
+	}
+	for _, row := range qr.Rows {
+		name, jobType := row[0].ToString(), row[1].ToString()
+		runner, ok := runnerFor(jobType)
+		if !ok {
+			// The job is meant for a newer version of vttablet.
+			continue
+		}
+		qr, err := e.exec(ctx, sqlAcquireLease, 0,
+			sqltypes.StringBindVariable(e.owner),
+			sqltypes.Int64BindVariable(int64(leaseDuration.Seconds())),
+			sqltypes.StringBindVariable(name),
+			sqltypes.StringBindVariable(e.owner))
+		if err != nil {
+			return false, err
+		}
+		if qr.RowsAffected == 0 {
+			// Another tablet took the job.
+			continue
+		}
+		return true, e.runJob(ctx, name, jobType, runner)
+	}
+	return false, nil
+}
+
+// runJob runs a job whose lease the engine holds, renewing the lease until
+// the job returns.
+func (e *Engine) runJob(ctx context.Context, name, jobType string, runner Runner) error {
+	qr, err := e.exec(ctx, sqlSelectJob, 1, sqltypes.StringBindVariable(name))
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) == 0 {
+		return nil
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	job := &Job{
+		Name:       name,
+		Type:       jobType,
+		Args:       qr.Rows[0][0].Raw(),
+		Checkpoint: qr.Rows[0][1].Raw(),
+		engine:     e,
+		cancel:     cancel,
+	}
+	e.setRunning(job)
+	defer e.setRunning(nil)
+
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		e.renewLease(jobCtx, job)
+	}()
+	log.Info(fmt.Sprintf("Jobs engine: running job %s of type %s", name, jobType))
+	runErr := runner.Run(jobCtx, job)
+	interrupted := jobCtx.Err() != nil
+	cancel()
+	<-renewed
+
+	// The context of the engine is done when it's closed, but the outcome of
+	// the job must still be recorded.
+	ctx = context.WithoutCancel(ctx)
+	switch {
+	case runErr == nil:
+		e.jobRuns.Add([]string{jobType, StateComplete}, 1)
+		_, err = e.exec(ctx, sqlCompleteJob, 0, sqltypes.StringBindVariable(name), sqltypes.StringBindVariable(e.owner))
+	case interrupted:
+		// The job was paused or cancelled, or the engine is closing: the job
+		// resumes from its last checkpoint the next time it runs.
+		e.jobRuns.Add([]string{jobType, "interrupted"}, 1)
+		_, err = e.exec(ctx, sqlReleaseLease, 0, sqltypes.StringBindVariable(name), sqltypes.StringBindVariable(e.owner))
+	default:
+		log.Error(fmt.Sprintf("Jobs engine: job %s failed: %v", name, runErr))
+		e.jobRuns.Add([]string{jobType, StateFailed}, 1)
+		_, err = e.exec(ctx, sqlFailJob, 0, sqltypes.StringBindVariable(runErr.Error()), sqltypes.StringBindVariable(name), sqltypes.StringBindVariable(e.owner))
+	}
+	return err
+}
+
+// renewLease renews the lease of the job until its context is done. It
+// cancels the context of the job if the lease is lost.
+func (e *Engine) renewLease(ctx context.Context, job *Job) {
+	ticker := time.NewTicker(leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		qr, err := e.exec(ctx, sqlRenewLease, 0,
+			sqltypes.Int64BindVariable(int64(leaseDuration.Seconds())),
+			sqltypes.StringBindVariable(job.Name),
+			sqltypes.StringBindVariable(e.owner))
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error(fmt.Sprintf("Jobs engine: error renewing the lease of job %s: %v", job.Name, err))
+			}
+			continue
+		}
+		if qr.RowsAffected == 0 {
+			log.Info(fmt.Sprintf("Jobs engine: stopping job %s, which is no longer leased by this tablet", job.Name))
+			job.cancel()
+			return
+		}
+	}
+}
+
+func (e *Engine) updateProgress(ctx context.Context, name string, progress float64, checkpoint []byte, message string) (bool, error) {
+	qr, err := e.exec(ctx, sqlUpdateProgress, 0,
+		sqltypes.Float64BindVariable(progress),
+		sqltypes.BytesBindVariable(checkpoint),
+		sqltypes.StringBindVariable(message),
+		sqltypes.Int64BindVariable(int64(leaseDuration.Seconds())),
+		sqltypes.StringBindVariable(name),
+		sqltypes.StringBindVariable(e.owner))
+	if err != nil {
+		return false, err
+	}
+	return qr.RowsAffected > 0, nil
+}
+
+func (e *Engine) setRunning(job *Job) {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	e.running = job
+}
+
+// interrupt cancels the context of the job if the engine is running it.
+func (e *Engine) interrupt(name string) {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	if e.running != nil && e.running.Name == name {
+		e.running.cancel()
+	}
+}
+
+func (e *Engine) wake() {
+	select {
+	case e.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Submit submits a job of a registered type, which runs once the jobs that
+// were submitted before it are done.
+func (e *Engine) Submit(ctx context.Context, name, jobType string, args []byte) error {
+	if _, ok := runnerFor(jobType); !ok {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown job type: %s", jobType)
+	}
+	if _, err := e.exec(ctx, sqlInsertJob, 0, sqltypes.StringBindVariable(name), sqltypes.StringBindVariable(jobType), sqltypes.BytesBindVariable(args)); err != nil {
+		return err
+	}
+	e.wake()
+	return nil
+}
+
+// Pause pauses a queued or running job. A running job is interrupted, and
+// resumes from its last checkpoint once it's resumed.
+func (e *Engine) Pause(ctx context.Context, name string) error {
+	if err := e.updateState(ctx, sqlPauseJob, name, StatePaused); err != nil {
+		return err
+	}
+	e.interrupt(name)
+	return nil
+}
+
+// Resume queues a paused job again.
+func (e *Engine) Resume(ctx context.Context, name string) error {
+	if err := e.updateState(ctx, sqlResumeJob, name, StateQueued); err != nil {
+		return err
+	}
+	e.wake()
+	return nil
+}
+
+// Cancel cancels a job that isn't complete yet. A running job is interrupted.
+func (e *Engine) Cancel(ctx context.Context, name string) error {
+	if err := e.updateState(ctx, sqlCancelJob, name, StateCancelled); err != nil {
+		return err
+	}
+	e.interrupt(name)
+	return nil
+}
+
+func (e *Engine) updateState(ctx context.Context, query, name, state string) error {
+	qr, err := e.exec(ctx, query, 0, sqltypes.StringBindVariable(name))
+	if err != nil {
+		return err
+	}
+	if qr.RowsAffected == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "job %s does not exist or cannot be %s", name, state)
+	}
+	return nil
+}
+
+// Jobs returns the status of all the jobs, in the order they were submitted.
+func (e *Engine) Jobs(ctx context.Context) ([]*Status, error) {
+	qr, err := e.exec(ctx, sqlSelectJobs, -1)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Status, 0, len(qr.Rows))
+	for _, row := range qr.Named().Rows {
+		jobs = append(jobs, &Status{
+			Name:        row.AsString("job_name", ""),
+			Type:        row.AsString("job_type", ""),
+			State:       row.AsString("job_state", ""),
+			Args:        row.AsString("job_args", ""),
+			Progress:    row.AsFloat64("progress", 0),
+			Message:     row.AsString("message", ""),
+			LeaseOwner:  row.AsString("lease_owner", ""),
+			Attempts:    row.AsInt64("attempts", 0),
+			CreatedAt:   row.AsString("created_at", ""),
+			StartedAt:   row.AsString("started_at", ""),
+			UpdatedAt:   row.AsString("updated_at", ""),
+			CompletedAt: row.AsString("completed_at", ""),
+		})
+	}
+	return jobs, nil
+}
+
+// exec runs one of the jobs table statements, binding the arguments to its
+// %a in order.
+func (e *Engine) exec(ctx context.Context, query string, maxrows int, args ...*querypb.BindVariable) (*sqltypes.Result, error) {
+	if !e.isOpen.Load() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "jobs only run on the primary tablet")
+	}
+
+	vars := []any{sidecar.GetIdentifier()}
+	bindVars := make(map[string]*querypb.BindVariable, len(args))
+	for i, arg := range args {
+		name := fmt.Sprintf("a%d", i)
+		vars = append(vars, ":"+name)
+		bindVars[name] = arg
+	}
+	bound, err := sqlparser.BuildParsedQuery(query, vars...).GenerateQuery(bindVars, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := e.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	return conn.Conn.Exec(ctx, bound, maxrows, false)
+}
+
+// handleHTTP lists the jobs, or pauses, resumes or cancels the job named by
+// the name parameter when the action parameter is set.
+func (e *Engine) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	action := r.FormValue("action")
+	role := acl.DEBUGGING
+	if action != "" {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	var err error
+	switch action {
+	case "":
+	case "pause":
+		err = e.Pause(ctx, r.FormValue("name"))
+	case "resume":
+		err = e.Resume(ctx, r.FormValue("name"))
+	case "cancel":
+		err = e.Cancel(ctx, r.FormValue("name"))
+	default:
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown action: %s", action)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobs, err := e.Jobs(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(jobs)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	selectRunnable = "select job_name, job_type from _vt.jobs where job_state in ('queued', 'running') and (lease_owner in ('', 'zone1-0000000100') or lease_expires < now(6)) order by created_at, job_name"
+	acquireLease   = "update _vt.jobs set job_state = 'running', lease_owner = 'zone1-0000000100', lease_expires = now(6) + interval 60 second, attempts = attempts + 1, started_at = ifnull(started_at, now(6)) where job_name = 'job1' and job_state in ('queued', 'running') and (lease_owner in ('', 'zone1-0000000100') or lease_expires < now(6))"
+	selectJob      = "select job_args, checkpoint from _vt.jobs where job_name = 'job1'"
+	updateProgress = "update _vt.jobs set progress = 0.5, checkpoint = _binary'row:50', message = 'halfway', lease_expires = now(6) + interval 60 second where job_name = 'job1' and job_state = 'running' and lease_owner = 'zone1-0000000100'"
+	completeJob    = "update _vt.jobs set job_state = 'complete', progress = 1, message = '', lease_owner = '', lease_expires = null, completed_at = now(6) where job_name = 'job1' and job_state = 'running' and lease_owner = 'zone1-0000000100'"
+	releaseLease   = "update _vt.jobs set lease_owner = '', lease_expires = null where job_name = 'job1' and lease_owner = 'zone1-0000000100'"
+)
+
+func newTestEngine(t *testing.T, db *fakesqldb.DB) *Engine {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = dbconfigs.NewTestDBConfigs(*db.ConnParams(), *db.ConnParams(), "fakesqldb")
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+
+	e := NewEngine(env, &topodatapb.TabletAlias{Cell: "zone1", Uid: 100})
+	e.jobRuns.ResetAll()
+	// The engine is opened without running jobs in the background, so that
+	// the tests run them one by one.
+	e.pool.Open(cfg.DB.AllPrivsWithDB(), cfg.DB.DbaWithDB(), cfg.DB.AppDebugWithDB())
+	e.isOpen.Store(true)
+	t.Cleanup(e.pool.Close)
+	return e
+}
+
+// registerForTest registers the runner of the job type named after the test.
+func registerForTest(t *testing.T, runner RunnerFunc) {
+	Register(t.Name(), runner)
+	t.Cleanup(func() {
+		runnersMu.Lock()
+		defer runnersMu.Unlock()
+		delete(runners, t.Name())
+	})
+}
+
+// addRunnableJob makes job1, of the given type, the next job to run.
+func addRunnableJob(db *fakesqldb.DB, jobType, checkpoint string) {
+	db.AddQuery(selectRunnable, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("job_name|job_type", "varchar|varchar"),
+		"job1|"+jobType,
+	))
+	db.AddQuery(acquireLease, &sqltypes.Result{RowsAffected: 1})
+	db.AddQuery(selectJob, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("job_args|checkpoint", "varbinary|varbinary"),
+		"table1|"+checkpoint,
+	))
+}
+
+func TestRunJob(t *testing.T) {
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		assert.Equal(t, "job1", job.Name)
+		assert.Equal(t, []byte("table1"), job.Args)
+		// the job resumes from its last checkpoint
+		assert.Equal(t, []byte("row:10"), job.Checkpoint)
+		return job.Progress(ctx, 0.5, []byte("row:50"), "halfway")
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	addRunnableJob(db, t.Name(), "row:10")
+	db.AddQuery(updateProgress, &sqltypes.Result{RowsAffected: 1})
+	db.AddQuery(completeJob, &sqltypes.Result{RowsAffected: 1})
+
+	ran, err := e.runNextJob(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, db.GetQueryCalledNum(updateProgress))
+	assert.Equal(t, 1, db.GetQueryCalledNum(completeJob))
+	assert.Equal(t, int64(1), e.jobRuns.Counts()[t.Name()+".complete"])
+}
+
+func TestRunJobFailed(t *testing.T) {
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		return errors.New("table1 does not exist")
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	addRunnableJob(db, t.Name(), "")
+	failJob := "update _vt.jobs set job_state = 'failed', message = 'table1 does not exist', lease_owner = '', lease_expires = null, completed_at = now(6) where job_name = 'job1' and job_state = 'running' and lease_owner = 'zone1-0000000100'"
+	db.AddQuery(failJob, &sqltypes.Result{RowsAffected: 1})
+
+	ran, err := e.runNextJob(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, db.GetQueryCalledNum(failJob))
+	assert.Equal(t, int64(1), e.jobRuns.Counts()[t.Name()+".failed"])
+}
+
+func TestRunJobLeaseLost(t *testing.T) {
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		err := job.Progress(ctx, 0.5, []byte("row:50"), "halfway")
+		assert.ErrorIs(t, err, errLeaseLost)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+		return err
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	addRunnableJob(db, t.Name(), "")
+	// the job was paused or cancelled, or another tablet took it over
+	db.AddQuery(updateProgress, &sqltypes.Result{})
+	db.AddQuery(releaseLease, &sqltypes.Result{})
+
+	ran, err := e.runNextJob(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, db.GetQueryCalledNum(releaseLease))
+	assert.Equal(t, int64(1), e.jobRuns.Counts()[t.Name()+".interrupted"])
+}
+
+func TestPauseRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	addRunnableJob(db, t.Name(), "")
+	db.AddQuery(releaseLease, &sqltypes.Result{RowsAffected: 1})
+	pauseJob := "update _vt.jobs set job_state = 'paused' where job_name = 'job1' and job_state in ('queued', 'running')"
+	db.AddQuery(pauseJob, &sqltypes.Result{RowsAffected: 1})
+
+	go func() {
+		<-started
+		assert.NoError(t, e.Pause(t.Context(), "job1"))
+	}()
+	ran, err := e.runNextJob(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, 1, db.GetQueryCalledNum(pauseJob))
+	assert.Equal(t, 1, db.GetQueryCalledNum(releaseLease))
+}
+
+func TestCloseInterruptsRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	e.pool.Close()
+	e.isOpen.Store(false)
+	addRunnableJob(db, t.Name(), "")
+	db.AddQuery(releaseLease, &sqltypes.Result{RowsAffected: 1})
+
+	require.NoError(t, e.Open())
+	<-started
+	// the lease is released so that the next primary resumes the job right away
+	e.Close()
+	assert.Equal(t, 1, db.GetQueryCalledNum(releaseLease))
+	assert.Equal(t, int64(1), e.jobRuns.Counts()[t.Name()+".interrupted"])
+}
+
+func TestRunNextJobUnknownType(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	// the job was submitted to a newer version of vttablet
+	db.AddQuery(selectRunnable, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("job_name|job_type", "varchar|varchar"),
+		"job1|unknown",
+	))
+
+	ran, err := e.runNextJob(t.Context())
+	require.NoError(t, err)
+	assert.False(t, ran)
+	assert.Zero(t, db.GetQueryCalledNum(acquireLease))
+}
+
+func TestSubmitAndChangeState(t *testing.T) {
+	registerForTest(t, RunnerFunc(func(ctx context.Context, job *Job) error {
+		return nil
+	}))
+
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	insertJob := "insert into _vt.jobs (job_name, job_type, job_state, job_args) values ('job1', 'TestSubmitAndChangeState', 'queued', _binary'table1')"
+	db.AddQuery(insertJob, &sqltypes.Result{RowsAffected: 1})
+
+	require.NoError(t, e.Submit(t.Context(), "job1", "TestSubmitAndChangeState", []byte("table1")))
+	assert.Equal(t, 1, db.GetQueryCalledNum(insertJob))
+	// the engine is woken up to run the job
+	assert.Len(t, e.wakeup, 1)
+
+	err := e.Submit(t.Context(), "job2", "unknown", nil)
+	assert.ErrorContains(t, err, "unknown job type: unknown")
+
+	db.AddQuery("update _vt.jobs set job_state = 'queued' where job_name = 'job1' and job_state = 'paused'", &sqltypes.Result{})
+	err = e.Resume(t.Context(), "job1")
+	assert.ErrorContains(t, err, "job job1 does not exist or cannot be queued")
+
+	db.AddQuery("update _vt.jobs set job_state = 'cancelled', completed_at = now(6) where job_name = 'job1' and job_state in ('queued', 'running', 'paused')", &sqltypes.Result{RowsAffected: 1})
+	require.NoError(t, e.Cancel(t.Context(), "job1"))
+}
+
+func TestClosedEngine(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	e := newTestEngine(t, db)
+	e.isOpen.Store(false)
+
+	_, err := e.Jobs(t.Context())
+	assert.ErrorContains(t, err, "jobs only run on the primary tablet")
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jobs runs long-running maintenance jobs on the primary tablet.
+//
+// A job is a row of the sidecar database's jobs table: the state, progress
+// and checkpoint of every job are persisted there, so that a job can be
+// paused and resumed, and so that it resumes from its last checkpoint when the
+// tablet restarts or another tablet is promoted. The tablet that runs a job
+// holds a lease on it, which it renews while the job runs, so that a job never
+// runs on two tablets at once, e.g. on the old and the new primary during a
+// reparent. The work of a job is done by the Runner registered for its type.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The states of a job.
+const (
+	// StateQueued is the state of a job waiting to run.
+	StateQueued = "queued"
+	// StateRunning is the state of a job that is running, or that was running
+	// when its tablet stopped and waits to be resumed by the next primary.
+	StateRunning = "running"
+	// StatePaused is the state of a job that was paused.
+	StatePaused = "paused"
+	// StateComplete is the state of a job whose runner completed.
+	StateComplete = "complete"
+	// StateFailed is the state of a job whose runner failed.
+	StateFailed = "failed"
+	// StateCancelled is the state of a job that was cancelled.
+	StateCancelled = "cancelled"
+)
+
+// Runner does the work of the jobs of a type.
+type Runner interface {
+	// Run runs the job, from the last checkpoint of the job if it ran before.
+	// It must return as soon as ctx is done, which happens when the job is
+	// paused or cancelled, or when the tablet stops running jobs. It returns
+	// nil once the job is complete: the job fails when it returns an error
+	// that isn't the result of ctx being done.
+	Run(ctx context.Context, job *Job) error
+}
+
+// RunnerFunc is a Runner that is a function.
+type RunnerFunc func(ctx context.Context, job *Job) error
+
+// Run implements Runner.
+func (f RunnerFunc) Run(ctx context.Context, job *Job) error {
+	return f(ctx, job)
+}
+
+var (
+	runnersMu sync.Mutex
+	runners   = make(map[string]Runner)
+)
+
+// Register registers the Runner of a type of jobs. It panics if a Runner is
+// already registered for the type.
+func Register(jobType string, runner Runner) {
+	runnersMu.Lock()
+	defer runnersMu.Unlock()
+	if _, ok := runners[jobType]; ok {
+		panic(fmt.Sprintf("jobs: runner for %q is already registered", jobType))
+	}
+	runners[jobType] = runner
+}
+
+func hasRunners() bool {
+	runnersMu.Lock()
+	defer runnersMu.Unlock()
+	return len(runners) > 0
+}
+
+func runnerFor(jobType string) (Runner, bool) {
+	runnersMu.Lock()
+	defer runnersMu.Unlock()
+	runner, ok := runners[jobType]
+	return runner, ok
+}
+
+// errLeaseLost is returned by Job.Progress when the tablet no longer holds
+// the lease of the job, because the job was paused or cancelled, or because
+// the lease expired and another tablet took the job over.
+var errLeaseLost = vterrors.Errorf(vtrpcpb.Code_ABORTED, "the lease of the job was lost")
+
+// Job is a job run by a Runner.
+type Job struct {
+	// Name is the unique name of the job.
+	Name string
+	// Type is the type of the job.
+	Type string
+	// Args are the arguments the job was submitted with.
+	Args []byte
+	// Checkpoint is the checkpoint the job last reported, if it ran before.
+	// The job resumes from it.
+	Checkpoint []byte
+
+	engine *Engine
+	cancel context.CancelFunc
+}
+
+// Progress records the progress of the job, a fraction between 0 and 1, and
+// a checkpoint the job can resume from if it's interrupted, along with a
+// message describing what the job is doing. It renews the lease of the job.
+// If the lease was lost, it cancels the context of the job and returns an
+// error.
+func (job *Job) Progress(ctx context.Context, progress float64, checkpoint []byte, message string) error {
+	ok, err := job.engine.updateProgress(ctx, job.Name, progress, checkpoint, message)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		job.cancel()
+		return errLeaseLost
+	}
+	job.Checkpoint = checkpoint
+	return nil
+}
+
+// Status is the status of a job, as persisted in the jobs table.
+type Status struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	State       string  `json:"state"`
+	Args        string  `json:"args,omitempty"`
+	Progress    float64 `json:"progress"`
+	Message     string  `json:"message,omitempty"`
+	LeaseOwner  string  `json:"lease_owner,omitempty"`
+	Attempts    int64   `json:"attempts"`
+	CreatedAt   string  `json:"created_at"`
+	StartedAt   string  `json:"started_at,omitempty"`
+	UpdatedAt   string  `json:"updated_at"`
+	CompletedAt string  `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"slices"
+
+	"vitess.io/vitess/go/mysql/binlog"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// binaryJSONEncoder encodes the values of the JSON columns of results in
+// MySQL's binary JSON format, for the queries that ask for it with the
+// json_encoding of their ExecuteOptions.
+type binaryJSONEncoder struct {
+	parser json.Parser
+	// columns are the indexes of the JSON columns of the results.
+	columns []int
+}
+
+// setFields records the JSON columns of the results that have fields, and
+// returns the fields with the JSON columns marked as encoded in binary.
+func (e *binaryJSONEncoder) setFields(fields []*querypb.Field) []*querypb.Field {
+	if fields == nil {
+		return nil
+	}
+	e.columns = e.columns[:0]
+	for i, field := range fields {
+		if field.Type == querypb.Type_JSON {
+			e.columns = append(e.columns, i)
+		}
+	}
+	if len(e.columns) == 0 {
+		return fields
+	}
+	marked := slices.Clone(fields)
+	for _, col := range e.columns {
+		marked[col] = marked[col].CloneVT()
+		marked[col].JsonEncoding = querypb.ExecuteOptions_JSON_BINARY
+	}
+	return marked
+}
+
+// encode returns a copy of the result with the values of its JSON columns
+// encoded in binary. The result itself is not modified, because it can be
+// shared with the other queries it was consolidated with.
+func (e *binaryJSONEncoder) encode(result *sqltypes.Result) (*sqltypes.Result, error) {
+	fields := e.setFields(result.Fields)
+	if len(e.columns) == 0 {
+		return result, nil
+	}
+
+	encoded := result.ShallowCopy()
+	encoded.Fields = fields
+	if len(result.Rows) == 0 {
+		return encoded, nil
+	}
+	encoded.Rows = make([]sqltypes.Row, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		row = append(sqltypes.Row(nil), row...)
+		for _, col := range e.columns {
+			if col >= len(row) || row[col].IsNull() {
+				continue
+			}
+			v, err := e.parser.ParseBytes(row[col].Raw())
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "cannot encode the value of JSON column %d in binary", col)
+			}
+			data, err := binlog.EncodeBinaryJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			row[col] = sqltypes.MakeTrusted(querypb.Type_JSON, data)
+		}
+		encoded.Rows = append(encoded.Rows, row)
+	}
+	return encoded, nil
+}
+
+// encodeJSONInBinary returns true if the query asks for the values of its
+// JSON columns in binary.
+func encodeJSONInBinary(options *querypb.ExecuteOptions) bool {
+	return options.GetJsonEncoding() == querypb.ExecuteOptions_JSON_BINARY
+}
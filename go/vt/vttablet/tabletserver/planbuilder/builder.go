@@ -126,7 +126,10 @@ func analyzeDelete(del *sqlparser.Delete, tables map[string]*schema.Table) (plan
 	return plan, nil
 }
 
-func analyzeInsert(ins *sqlparser.Insert, tables map[string]*schema.Table) (plan *Plan, err error) {
+func analyzeInsert(env *vtenv.Environment, ins *sqlparser.Insert, tables map[string]*schema.Table) (plan *Plan, err error) {
+	if ins.Returning != nil {
+		return analyzeInsertReturning(env, ins, tables)
+	}
 	plan = &Plan{
 		PlanID:    PlanInsert,
 		FullQuery: GenerateFullQuery(ins),
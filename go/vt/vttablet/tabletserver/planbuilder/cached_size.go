@@ -23,6 +23,33 @@ type cachedObject interface {
 	CachedSize(alloc bool) int64
 }
 
+func (cached *InsertReturning) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Select *vitess.io/vitess/go/vt/sqlparser.ParsedQuery
+	size += cached.Select.CachedSize(true)
+	// field Keys [][]vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Keys)) * int64(24))
+		for _, elem := range cached.Keys {
+			{
+				size += hack.RuntimeAllocSize(int64(cap(elem)) * int64(16))
+				for _, elem := range elem {
+					if cc, ok := elem.(cachedObject); ok {
+						size += cc.CachedSize(true)
+					}
+				}
+			}
+		}
+	}
+	return size
+}
+
 func (cached *Permission) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -72,5 +99,7 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	if cc, ok := cached.FullStmt.(cachedObject); ok {
 		size += cc.CachedSize(true)
 	}
+	// field Returning *vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder.InsertReturning
+	size += cached.Returning.CachedSize(true)
 	return size
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+)
+
+// Planner builds the plan of a statement. Build is the Planner that serves
+// the queries of vttablet.
+type Planner func(env *vtenv.Environment, statement sqlparser.Statement, tables map[string]*schema.Table, dbName string, noRowsLimit bool) (*Plan, error)
+
+// CurrentPlanner is the name of the candidate planner that is Build itself.
+// Comparing it with Build checks that planning is deterministic.
+const CurrentPlanner = "current"
+
+var (
+	candidatesMu sync.Mutex
+	candidates   = map[string]Planner{
+		CurrentPlanner: Build,
+	}
+)
+
+// RegisterCandidatePlanner registers a planner whose plans vttablet can compare
+// with the ones of Build before it serves queries, e.g. the planner of the
+// next release of a fork. It panics if a planner is already registered with
+// the same name, and is meant to be called from an init function.
+func RegisterCandidatePlanner(name string, planner Planner) {
+	candidatesMu.Lock()
+	defer candidatesMu.Unlock()
+	if _, ok := candidates[name]; ok {
+		panic(fmt.Sprintf("candidate planner %q is already registered", name))
+	}
+	candidates[name] = planner
+}
+
+// CandidatePlanner returns the candidate planner registered with the given name.
+func CandidatePlanner(name string) (Planner, bool) {
+	candidatesMu.Lock()
+	defer candidatesMu.Unlock()
+	planner, ok := candidates[name]
+	return planner, ok
+}
+
+// CandidatePlanners returns the sorted names of the registered candidate planners.
+func CandidatePlanners() []string {
+	candidatesMu.Lock()
+	defer candidatesMu.Unlock()
+	return slices.Sorted(maps.Keys(candidates))
+}
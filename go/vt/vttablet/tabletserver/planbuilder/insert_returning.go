@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ReturningKeysBindVar is the bind variable of InsertReturning.Select
+// that receives the primary keys of the inserted rows.
+const ReturningKeysBindVar = "#returning_keys"
+
+// InsertReturning is the plan to read back the rows of an
+// INSERT ... RETURNING. MySQL does not support RETURNING, so the insert
+// runs without it and the inserted rows are then selected by primary key
+// in the same transaction.
+type InsertReturning struct {
+	// Select reads the returned expressions of the rows whose primary key
+	// is in the ReturningKeysBindVar list, followed by the primary key
+	// columns.
+	Select *sqlparser.ParsedQuery
+	// Keys has the primary key values of each inserted row, in primary key
+	// order. The value of the auto-increment column is nil if the insert
+	// leaves it to MySQL to generate.
+	Keys [][]evalengine.Expr
+	// AutoIncrement is the position in the primary key of the
+	// auto-increment column, or -1 if the primary key has none.
+	AutoIncrement int
+}
+
+// analyzeInsertReturning plans an insert with a RETURNING clause. The
+// primary key of every inserted row must be known once the insert has run,
+// so only plain inserts of values into a table with a primary key are
+// supported, and their primary key values must be literals or bind
+// variables unless they are generated by an auto-increment column.
+func analyzeInsertReturning(env *vtenv.Environment, ins *sqlparser.Insert, tables map[string]*schema.Table) (*Plan, error) {
+	switch {
+	case ins.Action != sqlparser.InsertAct:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with REPLACE")
+	case bool(ins.Ignore):
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with INSERT IGNORE")
+	case len(ins.OnDup) > 0:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with ON DUPLICATE KEY UPDATE")
+	}
+	rows, ok := ins.Rows.(sqlparser.Values)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with INSERT ... SELECT")
+	}
+	table := lookupTables(sqlparser.TableExprs{ins.Table}, tables)
+	if table == nil || len(table.PKColumns) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING on table %s without a primary key", sqlparser.String(ins.Table.Expr))
+	}
+
+	// Without a column list, the values are for all the columns of the table.
+	var columns []string
+	if len(ins.Columns) > 0 {
+		for _, col := range ins.Columns {
+			columns = append(columns, col.Lowered())
+		}
+	} else if len(rows[0]) > 0 {
+		for _, field := range table.Fields {
+			columns = append(columns, sqlparser.NewIdentifierCI(field.Name).Lowered())
+		}
+	}
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			return nil, vterrors.VT03006()
+		}
+	}
+
+	cfg := &evalengine.Config{
+		Environment: env,
+		Collation:   env.CollationEnv().DefaultConnectionCharset(),
+	}
+	returning := &InsertReturning{
+		Keys:          make([][]evalengine.Expr, len(rows)),
+		AutoIncrement: -1,
+	}
+	for i := range rows {
+		returning.Keys[i] = make([]evalengine.Expr, len(table.PKColumns))
+	}
+	pkCols := make([]sqlparser.Expr, 0, len(table.PKColumns))
+	for k, pkIdx := range table.PKColumns {
+		field := table.Fields[pkIdx]
+		pkCols = append(pkCols, sqlparser.NewColName(field.Name))
+		autoIncrement := field.Flags&uint32(querypb.MySqlFlag_AUTO_INCREMENT_FLAG) != 0
+		if autoIncrement {
+			returning.AutoIncrement = k
+		}
+		colIdx := -1
+		for i, col := range columns {
+			if sqlparser.NewIdentifierCI(field.Name).EqualString(col) {
+				colIdx = i
+				break
+			}
+		}
+		if colIdx == -1 {
+			if !autoIncrement {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING without a value for primary key column %s", field.Name)
+			}
+			continue
+		}
+		for i, row := range rows {
+			switch expr := row[colIdx].(type) {
+			case *sqlparser.NullVal, *sqlparser.Default:
+				if autoIncrement {
+					continue
+				}
+			case *sqlparser.Literal, *sqlparser.Argument:
+				key, err := evalengine.Translate(expr, cfg)
+				if err != nil {
+					return nil, err
+				}
+				returning.Keys[i][k] = key
+				continue
+			}
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with value %s for primary key column %s", sqlparser.String(row[colIdx]), field.Name)
+		}
+	}
+
+	sel := &sqlparser.Select{
+		From: []sqlparser.TableExpr{sqlparser.NewAliasedTableExpr(ins.Table.Expr, "")},
+	}
+	for _, expr := range ins.Returning.Exprs {
+		sel.AddSelectExpr(sqlparser.Clone(expr))
+	}
+	for _, col := range pkCols {
+		sel.AddSelectExpr(&sqlparser.AliasedExpr{Expr: col})
+	}
+	var left sqlparser.Expr = sqlparser.ValTuple(pkCols)
+	if len(pkCols) == 1 {
+		left = pkCols[0]
+	}
+	sel.Where = sqlparser.NewWhere(sqlparser.WhereClause, &sqlparser.ComparisonExpr{
+		Operator: sqlparser.InOp,
+		Left:     left,
+		Right:    sqlparser.NewListArg(ReturningKeysBindVar),
+	})
+	returning.Select = GenerateFullQuery(sel)
+
+	// The insert itself runs without the RETURNING clause.
+	stmt := sqlparser.Clone(ins)
+	stmt.Returning = nil
+	return &Plan{
+		PlanID:    PlanInsertReturning,
+		Table:     table,
+		FullQuery: GenerateFullQuery(stmt),
+		Returning: returning,
+	}, nil
+}
@@ -85,6 +85,9 @@ const (
 	// LegacyStreamRulePlan), and only on the streaming path. To be removed
 	// in v26.
 	PlanSelectStream
+	// PlanInsertReturning is for INSERT ... RETURNING, which is emulated
+	// by reading back the inserted rows.
+	PlanInsertReturning
 	NumPlans
 )
 
@@ -121,6 +124,7 @@ var planName = []string{
 	"ShowThrottledApps",
 	"ShowThrottlerStatus",
 	"SelectStream",
+	"InsertReturning",
 }
 
 func (pt PlanType) String() string {
@@ -200,6 +204,9 @@ type Plan struct {
 
 	// NeedsReservedConn indicates at a reserved connection is needed to execute this plan
 	NeedsReservedConn bool
+
+	// Returning is set for PlanInsertReturning.
+	Returning *InsertReturning
 }
 
 // TableName returns the table name for the plan.
@@ -277,7 +284,7 @@ func BuildStreaming(env *vtenv.Environment, statement sqlparser.Statement, table
 		// We send down a string, and get a table result back.
 		plan = &Plan{PlanID: PlanSelect, FullQuery: GenerateFullQuery(stmt)}
 	case *sqlparser.Insert:
-		plan, err = analyzeInsert(stmt, tables)
+		plan, err = analyzeInsert(env, stmt, tables)
 	case *sqlparser.Update:
 		plan, err = analyzeUpdate(stmt, tables)
 	case *sqlparser.Delete:
@@ -49,6 +49,7 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		NextCount         string                 `json:",omitempty"`
 		WhereClause       *sqlparser.ParsedQuery `json:",omitempty"`
 		NeedsReservedConn bool                   `json:",omitempty"`
+		Returning         *sqlparser.ParsedQuery `json:",omitempty"`
 	}{
 		PlanID:      p.PlanID,
 		TableName:   p.TableName(),
@@ -62,6 +63,9 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 	if p.NeedsReservedConn {
 		mplan.NeedsReservedConn = true
 	}
+	if p.Returning != nil {
+		mplan.Returning = p.Returning.Select
+	}
 	return json.Marshal(&mplan)
 }
 
@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plancapture logs the MySQL execution plan of queries whose latency
+// falls in the slow tail of recently observed queries.
+package plancapture
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// windowSize is the number of most recent latencies used to compute
+	// the percentile threshold.
+	windowSize = 1000
+	// minSamples is the number of observations required before any
+	// capture is attempted, so that a cold tablet does not treat its
+	// first few queries as outliers.
+	minSamples = 100
+	// recomputeEvery controls how often the threshold is recomputed from
+	// the window, to keep sorting off the per-query path.
+	recomputeEvery = 100
+	// queueSize bounds the number of pending captures. Captures that do
+	// not fit are dropped rather than delaying the query path.
+	queueSize = 16
+	// maxRows bounds the size of the plan that is read back.
+	maxRows = 1000
+)
+
+type (
+	// Capturer observes query latencies and runs EXPLAIN for a sample of
+	// the queries that exceed the configured latency percentile. Plans are
+	// captured in the background on a dedicated connection and written to
+	// the log.
+	Capturer struct {
+		env     tabletenv.Env
+		enabled bool
+
+		percentile float64
+		minLatency time.Duration
+		analyze    bool
+		timeout    time.Duration
+
+		captures *stats.CountersWithSingleLabel
+
+		// sample returns true if a candidate should be captured.
+		sample func() bool
+
+		mu        sync.Mutex
+		window    []time.Duration
+		next      int
+		observed  int
+		threshold time.Duration
+
+		runMu  sync.Mutex
+		isOpen bool
+		pool   *connpool.Pool
+		queue  chan capture
+		done   chan struct{}
+		wg     sync.WaitGroup
+	}
+
+	capture struct {
+		planID   planbuilder.PlanType
+		sql      string
+		duration time.Duration
+	}
+)
+
+// New creates a new Capturer. If plan capture is not enabled in the
+// config, the returned Capturer ignores all observations.
+func New(env tabletenv.Env) *Capturer {
+	config := env.Config().PlanCapture
+	if !config.Enable {
+		return &Capturer{}
+	}
+	return &Capturer{
+		env:        env,
+		enabled:    true,
+		percentile: config.Percentile,
+		minLatency: config.MinLatency,
+		analyze:    config.Analyze,
+		timeout:    config.Timeout,
+		captures:   env.Exporter().NewCountersWithSingleLabel("PlanCaptures", "Number of slow query plan captures by result", "Result"),
+		sample: func() bool {
+			return rand.Float64() < config.SampleRate
+		},
+		window: make([]time.Duration, 0, windowSize),
+		pool: connpool.NewPool(env, "PlanCapturePool", tabletenv.ConnPoolConfig{
+			Size:        1,
+			IdleTimeout: env.Config().OltpReadPool.IdleTimeout,
+		}),
+	}
+}
+
+// Open starts the background capture worker and opens the db pool.
+func (c *Capturer) Open() {
+	if !c.enabled {
+		return
+	}
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if c.isOpen {
+		return
+	}
+	log.Info("Plan Capture: opening")
+
+	c.pool.Open(c.env.Config().DB.AppWithDB(), c.env.Config().DB.DbaWithDB(), c.env.Config().DB.AppDebugWithDB())
+	c.queue = make(chan capture, queueSize)
+	c.done = make(chan struct{})
+	c.wg.Add(1)
+	go c.run(c.queue, c.done)
+	c.isOpen = true
+}
+
+// Close stops the background capture worker, discarding any pending
+// captures, and closes the db pool.
+func (c *Capturer) Close() {
+	if !c.enabled {
+		return
+	}
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if !c.isOpen {
+		return
+	}
+	close(c.done)
+	c.wg.Wait()
+	c.pool.Close()
+	c.isOpen = false
+	log.Info("Plan Capture: closed")
+}
+
+// Observe records the latency of a successfully executed query and, if the
+// latency is an outlier and the query is selected by sampling, schedules a
+// background capture of its plan. The final SQL is only generated for
+// queries that are scheduled for capture.
+func (c *Capturer) Observe(planID planbuilder.PlanType, query *sqlparser.ParsedQuery, bindVars map[string]*querypb.BindVariable, duration time.Duration) {
+	if !c.enabled || query == nil || !explainable(planID) {
+		return
+	}
+	if !c.isOutlier(duration) || !c.sample() {
+		return
+	}
+	sql, err := query.GenerateQuery(bindVars, nil)
+	if err != nil {
+		return
+	}
+
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+	if !c.isOpen {
+		return
+	}
+	select {
+	case c.queue <- capture{planID: planID, sql: sql, duration: duration}:
+	default:
+		c.captures.Add("Dropped", 1)
+	}
+}
+
+// Threshold returns the current outlier latency threshold. It returns 0
+// until enough queries have been observed.
+func (c *Capturer) Threshold() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.threshold
+}
+
+// isOutlier adds duration to the latency window and reports whether it is
+// at or above both the percentile threshold and the configured floor.
+func (c *Capturer) isOutlier(duration time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.window) < windowSize {
+		c.window = append(c.window, duration)
+	} else {
+		c.window[c.next] = duration
+	}
+	c.next = (c.next + 1) % windowSize
+	c.observed++
+
+	if len(c.window) < minSamples {
+		return false
+	}
+	if c.threshold == 0 || c.observed%recomputeEvery == 0 {
+		sorted := slices.Clone(c.window)
+		slices.Sort(sorted)
+		idx := int(float64(len(sorted)-1) * c.percentile / 100)
+		c.threshold = sorted[idx]
+	}
+	return duration >= c.threshold && duration >= c.minLatency
+}
+
+func (c *Capturer) run(queue <-chan capture, done <-chan struct{}) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-done:
+			return
+		case cp := <-queue:
+			c.capture(cp)
+		}
+	}
+}
+
+func (c *Capturer) capture(cp capture) {
+	defer c.env.LogError()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	explain := "explain "
+	if c.analyze && cp.planID == planbuilder.PlanSelect {
+		explain = "explain analyze "
+	}
+	res, err := c.explain(ctx, explain+cp.sql)
+	if err != nil {
+		c.captures.Add("Error", 1)
+		log.Warn("Plan Capture: failed to explain slow query",
+			slog.String("plan", cp.planID.String()),
+			slog.Duration("duration", cp.duration),
+			slog.Any("error", err))
+		return
+	}
+	c.captures.Add("Captured", 1)
+	log.Info("Plan Capture: slow query plan",
+		slog.String("plan", cp.planID.String()),
+		slog.Duration("duration", cp.duration),
+		slog.String("query", c.redact(cp.sql)),
+		slog.String("explain", formatPlan(res)))
+}
+
+func (c *Capturer) explain(ctx context.Context, sql string) (*sqltypes.Result, error) {
+	conn, err := c.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	return conn.Conn.Exec(ctx, sql, maxRows, false)
+}
+
+func (c *Capturer) redact(sql string) string {
+	if !c.env.Config().SanitizeLogMessages {
+		return sql
+	}
+	redacted, err := c.env.Environment().Parser().RedactSQLQuery(sql)
+	if err != nil {
+		return "[could not redact query]"
+	}
+	return redacted
+}
+
+// explainable returns true for the plan types whose statements MySQL can
+// EXPLAIN.
+func explainable(planID planbuilder.PlanType) bool {
+	switch planID {
+	case planbuilder.PlanSelect, planbuilder.PlanInsert, planbuilder.PlanUpdate, planbuilder.PlanDelete,
+		planbuilder.PlanUpdateLimit, planbuilder.PlanDeleteLimit, planbuilder.PlanInsertReturning:
+		return true
+	}
+	return false
+}
+
+// formatPlan joins the rows of an EXPLAIN result into a single string,
+// one row per line and columns separated by tabs.
+func formatPlan(res *sqltypes.Result) string {
+	var buf strings.Builder
+	for i, row := range res.Rows {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		for j, v := range row {
+			if j > 0 {
+				buf.WriteByte('\t')
+			}
+			buf.WriteString(v.ToString())
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plancapture
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func newCapturer(t *testing.T, db *fakesqldb.DB, analyze bool) *Capturer {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.PlanCapture.Enable = true
+	cfg.PlanCapture.MinLatency = 0
+	cfg.PlanCapture.Analyze = analyze
+	cp := *db.ConnParams()
+	cfg.DB = dbconfigs.NewTestDBConfigs(cp, cp, "")
+
+	c := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name()))
+	c.sample = func() bool { return true }
+	c.Open()
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestCapturerThreshold(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	c := newCapturer(t, db, false)
+
+	for i := 1; i < minSamples; i++ {
+		assert.False(t, c.isOutlier(time.Duration(i)*time.Millisecond))
+	}
+	assert.Zero(t, c.Threshold())
+
+	// The window now holds 1ms..100ms, so the 99th percentile is 99ms.
+	assert.True(t, c.isOutlier(100*time.Millisecond))
+	assert.Equal(t, 99*time.Millisecond, c.Threshold())
+	assert.False(t, c.isOutlier(50*time.Millisecond))
+
+	c.minLatency = time.Second
+	assert.False(t, c.isOutlier(200*time.Millisecond))
+}
+
+func TestCapturerCapture(t *testing.T) {
+	testcases := []struct {
+		name    string
+		analyze bool
+		planID  planbuilder.PlanType
+		query   string
+		explain string
+	}{{
+		name:    "select",
+		planID:  planbuilder.PlanSelect,
+		query:   "select * from t1 where id = %a",
+		explain: "explain select * from t1 where id = 1",
+	}, {
+		name:    "select with analyze",
+		analyze: true,
+		planID:  planbuilder.PlanSelect,
+		query:   "select * from t1 where id = %a",
+		explain: "explain analyze select * from t1 where id = 1",
+	}, {
+		name:    "update is never analyzed",
+		analyze: true,
+		planID:  planbuilder.PlanUpdate,
+		query:   "update t1 set val = 2 where id = %a",
+		explain: "explain update t1 set val = 2 where id = 1",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := fakesqldb.New(t)
+			defer db.Close()
+			db.AddQuery(tc.explain, sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|select_type|table", "int64|varchar|varchar"), "1|SIMPLE|t1"))
+			c := newCapturer(t, db, tc.analyze)
+
+			query := sqlparser.BuildParsedQuery(tc.query, ":id")
+			bindVars := map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(1)}
+			for range minSamples - 1 {
+				c.Observe(tc.planID, query, bindVars, time.Millisecond)
+			}
+			assert.Zero(t, db.GetQueryCalledNum(tc.explain))
+
+			c.Observe(tc.planID, query, bindVars, time.Second)
+			assert.Eventually(t, func() bool {
+				return c.captures.Counts()["Captured"] == 1
+			}, 30*time.Second, 10*time.Millisecond)
+			assert.Equal(t, 1, db.GetQueryCalledNum(tc.explain))
+		})
+	}
+}
+
+func TestCapturerError(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	db.AddRejectedQuery("explain select * from t1", assert.AnError)
+	c := newCapturer(t, db, false)
+
+	query := sqlparser.BuildParsedQuery("select * from t1")
+	for range minSamples - 1 {
+		c.Observe(planbuilder.PlanSelect, query, nil, time.Millisecond)
+	}
+	c.Observe(planbuilder.PlanSelect, query, nil, time.Second)
+	assert.Eventually(t, func() bool {
+		return c.captures.Counts()["Error"] == 1
+	}, 30*time.Second, 10*time.Millisecond)
+}
+
+func TestCapturerIgnores(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+
+	t.Run("disabled", func(t *testing.T) {
+		c := New(tabletenv.NewEnv(vtenv.NewTestEnv(), tabletenv.NewDefaultConfig(), t.Name()))
+		c.Open()
+		defer c.Close()
+		c.Observe(planbuilder.PlanSelect, sqlparser.BuildParsedQuery("select 1"), nil, time.Hour)
+		require.Nil(t, c.window)
+	})
+
+	t.Run("not explainable", func(t *testing.T) {
+		c := newCapturer(t, db, false)
+		c.Observe(planbuilder.PlanDDL, sqlparser.BuildParsedQuery("alter table t1 add column c int"), nil, time.Hour)
+		require.Empty(t, c.window)
+	})
+}
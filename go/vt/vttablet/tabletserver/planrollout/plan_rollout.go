@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planrollout compares the plans of a candidate query planner with
+// the ones of the serving planner, to de-risk upgrades that change the
+// planner: the plans that differ are logged before the candidate serves any
+// query.
+package planrollout
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+// Comparer plans a sample of the queries with the candidate planner and
+// compares the plans with the ones of the serving planner. The candidate
+// plans are only compared, never executed.
+type Comparer struct {
+	env     tabletenv.Env
+	enabled bool
+
+	name    string
+	planner planbuilder.Planner
+
+	comparisons *stats.CountersWithSingleLabel
+
+	// sample returns true if a query should be planned by the candidate.
+	sample func() bool
+}
+
+// New creates a new Comparer. If no candidate planner is configured, or if
+// the configured one is not registered, the returned Comparer never samples.
+func New(env tabletenv.Env) *Comparer {
+	config := env.Config().PlanRollout
+	if config.Planner == "" {
+		return &Comparer{}
+	}
+	planner, ok := planbuilder.CandidatePlanner(config.Planner)
+	if !ok {
+		log.Error("Plan Rollout: unknown candidate planner, plans are not compared",
+			slog.String("planner", config.Planner),
+			slog.Any("registered", planbuilder.CandidatePlanners()))
+		return &Comparer{}
+	}
+	return &Comparer{
+		env:         env,
+		enabled:     true,
+		name:        config.Planner,
+		planner:     planner,
+		comparisons: env.Exporter().NewCountersWithSingleLabel("PlanRolloutComparisons", "Number of plans compared with the candidate planner by result", "Result"),
+		sample: func() bool {
+			return rand.Float64() < config.SampleRate
+		},
+	}
+}
+
+// Sample returns true if the plan of the next query must be compared. The
+// statement of a sampled query must be cloned before the serving planner
+// plans it, since planning can rewrite it.
+func (c *Comparer) Sample() bool {
+	return c.enabled && c.sample()
+}
+
+// Compare plans the statement with the candidate planner, and logs the
+// differences with the plan of the serving planner, or with its error.
+func (c *Comparer) Compare(sql string, statement sqlparser.Statement, tables map[string]*schema.Table, dbName string, noRowsLimit bool, served *planbuilder.Plan, servedErr error) {
+	candidate, candidateErr := c.plan(statement, tables, dbName, noRowsLimit)
+
+	servedDesc, candidateDesc := describe(served, servedErr), describe(candidate, candidateErr)
+	if servedDesc == candidateDesc {
+		c.comparisons.Add("Match", 1)
+		return
+	}
+	c.comparisons.Add("Diverged", 1)
+	log.Warn("Plan Rollout: the candidate plan differs from the serving plan",
+		slog.String("planner", c.name),
+		slog.String("query", c.redact(sql)),
+		slog.String("serving", c.redactPlan(servedDesc, served)),
+		slog.String("candidate", c.redactPlan(candidateDesc, candidate)))
+}
+
+// Comparisons returns the number of compared plans by result.
+func (c *Comparer) Comparisons() map[string]int64 {
+	if !c.enabled {
+		return nil
+	}
+	return c.comparisons.Counts()
+}
+
+// plan plans the statement with the candidate planner, which must not be able
+// to crash the tablet.
+func (c *Comparer) plan(statement sqlparser.Statement, tables map[string]*schema.Table, dbName string, noRowsLimit bool) (plan *planbuilder.Plan, err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			plan, err = nil, fmt.Errorf("candidate planner panicked: %v", x)
+		}
+	}()
+	return c.planner(c.env.Environment(), statement, tables, dbName, noRowsLimit)
+}
+
+// describe returns the parts of the plan that the comparison checks: its
+// type, the rewritten queries and the tables it accesses.
+func describe(plan *planbuilder.Plan, err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	var buf strings.Builder
+	buf.WriteString(plan.PlanID.String())
+	if plan.FullQuery != nil {
+		fmt.Fprintf(&buf, " query=%q", plan.FullQuery.Query)
+	}
+	if plan.WhereClause != nil {
+		fmt.Fprintf(&buf, " where=%q", plan.WhereClause.Query)
+	}
+	fmt.Fprintf(&buf, " tables=%v", plan.TableNames())
+	if plan.NeedsReservedConn {
+		buf.WriteString(" reserved")
+	}
+	return buf.String()
+}
+
+func (c *Comparer) redact(sql string) string {
+	if !c.env.Config().SanitizeLogMessages {
+		return sql
+	}
+	redacted, err := c.env.Environment().Parser().RedactSQLQuery(sql)
+	if err != nil {
+		return "[could not redact query]"
+	}
+	return redacted
+}
+
+// redactPlan returns the description of the plan, or only its type if the
+// rewritten queries must not be logged.
+func (c *Comparer) redactPlan(desc string, plan *planbuilder.Plan) string {
+	if !c.env.Config().SanitizeLogMessages || plan == nil {
+		return desc
+	}
+	return plan.PlanID.String() + " [redacted]"
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planrollout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+// withoutLimit plans selects without their safety LIMIT, a change in the
+// rewritten SQL that the comparison must report.
+func withoutLimit(env *vtenv.Environment, statement sqlparser.Statement, tables map[string]*schema.Table, dbName string, _ bool) (*planbuilder.Plan, error) {
+	return planbuilder.Build(env, statement, tables, dbName, true)
+}
+
+func init() {
+	planbuilder.RegisterCandidatePlanner("test-without-limit", withoutLimit)
+}
+
+func newComparer(t *testing.T, planner string) *Comparer {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.PlanRollout.Planner = planner
+	cfg.PlanRollout.SampleRate = 1
+	return New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name()))
+}
+
+func testTables() map[string]*schema.Table {
+	return map[string]*schema.Table{
+		"t1": {Name: sqlparser.NewIdentifierCS("t1"), Type: schema.NoType},
+	}
+}
+
+// compare plans the query with Build and compares the plan with the candidate.
+func compare(t *testing.T, c *Comparer, query string) {
+	statement, err := sqlparser.NewTestParser().Parse(query)
+	require.NoError(t, err)
+	candidateStmt := sqlparser.Clone(statement)
+	tables := testTables()
+	plan, err := planbuilder.Build(vtenv.NewTestEnv(), statement, tables, "dbName", false)
+	c.Compare(query, candidateStmt, tables, "dbName", false, plan, err)
+}
+
+func TestComparerDisabled(t *testing.T) {
+	c := newComparer(t, "")
+	assert.False(t, c.Sample())
+
+	c = newComparer(t, "no-such-planner")
+	assert.False(t, c.Sample())
+}
+
+func TestComparerMatch(t *testing.T) {
+	c := newComparer(t, planbuilder.CurrentPlanner)
+	require.True(t, c.Sample())
+
+	compare(t, c, "select * from t1 where id = 1")
+	compare(t, c, "update t1 set a = 1 where id = 1")
+	compare(t, c, "select * from no_such_table")
+	assert.Equal(t, map[string]int64{"Match": 3}, c.comparisons.Counts())
+}
+
+func TestComparerDiverged(t *testing.T) {
+	c := newComparer(t, "test-without-limit")
+	require.True(t, c.Sample())
+
+	compare(t, c, "update t1 set a = 1 where id = 1")
+	assert.Equal(t, map[string]int64{"Match": 1}, c.comparisons.Counts())
+
+	compare(t, c, "select * from t1 where id = 1")
+	assert.Equal(t, map[string]int64{"Match": 1, "Diverged": 1}, c.comparisons.Counts())
+}
+
+func TestComparerPanic(t *testing.T) {
+	c := newComparer(t, planbuilder.CurrentPlanner)
+	c.planner = func(*vtenv.Environment, sqlparser.Statement, map[string]*schema.Table, string, bool) (*planbuilder.Plan, error) {
+		panic("boom")
+	}
+
+	assert.NotPanics(t, func() {
+		compare(t, c, "select * from t1 where id = 1")
+	})
+	assert.Equal(t, map[string]int64{"Diverged": 1}, c.comparisons.Counts())
+}
+
+func TestDescribe(t *testing.T) {
+	statement, err := sqlparser.NewTestParser().Parse("select * from t1 where id = 1")
+	require.NoError(t, err)
+	plan, err := planbuilder.Build(vtenv.NewTestEnv(), statement, testTables(), "dbName", false)
+	require.NoError(t, err)
+	assert.Equal(t, `Select query="select * from t1 where id = 1 limit :#maxLimit" tables=[t1]`, describe(plan, nil))
+
+	c := newComparer(t, planbuilder.CurrentPlanner)
+	c.env.Config().SanitizeLogMessages = true
+	assert.Equal(t, "Select [redacted]", c.redactPlan(describe(plan, nil), plan))
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,13 +34,16 @@ import (
 	"vitess.io/vitess/go/cache/theine"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -47,7 +51,10 @@ import (
 	tacl "vitess.io/vitess/go/vt/tableacl/acl"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/hotspot"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/plancapture"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planrollout"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
@@ -64,6 +71,14 @@ type TabletPlan struct {
 	Rules      *rules.Rules
 	Authorized []*tableacl.ACLResult
 
+	// augmentedQueries replace FullQuery when a rule with the QRAugment
+	// action rewrites the query.
+	augmentedQueries map[*rules.Augment]*sqlparser.ParsedQuery
+
+	// prepare is set if the query may be executed as a prepared statement
+	// when prepared statements are enabled.
+	prepare bool
+
 	QueryCount   uint64
 	Time         uint64
 	MysqlTime    uint64
@@ -93,6 +108,29 @@ func (ep *TabletPlan) Stats() (queryCount uint64, duration, mysqlTime time.Durat
 	return
 }
 
+// buildAugmentedQueries builds 'augmentedQueries' with build, which must
+// plan the statements like the plan itself was planned.
+func (ep *TabletPlan) buildAugmentedQueries(statement sqlparser.Statement, build func(sqlparser.Statement) (*planbuilder.Plan, error)) error {
+	for _, augment := range ep.Rules.Augments() {
+		augmented := augment.Apply(statement)
+		if augmented == nil {
+			continue
+		}
+		splan, err := build(augmented)
+		if err != nil {
+			return err
+		}
+		if splan.FullQuery == nil {
+			continue
+		}
+		if ep.augmentedQueries == nil {
+			ep.augmentedQueries = make(map[*rules.Augment]*sqlparser.ParsedQuery)
+		}
+		ep.augmentedQueries[augment] = splan.FullQuery
+	}
+	return nil
+}
+
 // buildAuthorized builds 'Authorized', which is the runtime part for 'Permissions'.
 func (ep *TabletPlan) buildAuthorized() {
 	ep.Authorized = make([]*tableacl.ACLResult, len(ep.Permissions))
@@ -173,6 +211,13 @@ type QueryEngine struct {
 	// that we start more than one transaction per hot row (range).
 	// For implementation details, please see BeginExecute() in tabletserver.go.
 	txSerializer *txserializer.TxSerializer
+	// planCapturer logs the MySQL plan of a sample of slow queries.
+	planCapturer *plancapture.Capturer
+	// planRollout compares the plans of a sample of queries with the ones of
+	// a candidate planner.
+	planRollout *planrollout.Comparer
+	// hotspots counts the primary keys of a sample of queries per key range.
+	hotspots *hotspot.Detector
 
 	// Vars
 	maxResultSize    atomic.Int64
@@ -192,7 +237,7 @@ type QueryEngine struct {
 
 	// stats
 	// Note: queryErrorCountsWithCode is similar to queryErrorCounts except it contains error code as an additional dimension
-	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed *stats.CountersWithMultiLabels
+	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed *stats.BoundedCountersWithMultiLabels
 	queryEnginePlanCacheHits, queryEnginePlanCacheMisses                                                                                                          *stats.CounterFunc
 
 	// stats flags
@@ -244,6 +289,9 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		log.Info("Stream consolidator is not enabled.")
 	}
 	qe.txSerializer = txserializer.New(env)
+	qe.planCapturer = plancapture.New(env)
+	qe.planRollout = planrollout.New(env)
+	qe.hotspots = hotspot.New(env)
 
 	qe.strictTableACL = config.StrictTableACL
 	qe.enableTableACLDryRun = config.EnableTableACLDryRun
@@ -305,14 +353,15 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		labels = []string{"Table", "Plan", "Workload"}
 	}
 
-	qe.queryCounts = env.Exporter().NewCountersWithMultiLabels("QueryCounts", "query counts", labels)
-	qe.queryCountsWithTabletType = env.Exporter().NewCountersWithMultiLabels("QueryCountsWithTabletType", "query counts with tablet type labels", []string{"Table", "Plan", "TabletType"})
-	qe.queryTimes = env.Exporter().NewCountersWithMultiLabels("QueryTimesNs", "query times in ns", labels)
-	qe.queryRowsAffected = env.Exporter().NewCountersWithMultiLabels("QueryRowsAffected", "query rows affected", labels)
-	qe.queryRowsReturned = env.Exporter().NewCountersWithMultiLabels("QueryRowsReturned", "query rows returned", labels)
-	qe.queryTextCharsProcessed = env.Exporter().NewCountersWithMultiLabels("QueryTextCharactersProcessed", "query text characters processed", labels)
-	qe.queryErrorCounts = env.Exporter().NewCountersWithMultiLabels("QueryErrorCounts", "query error counts", labels)
-	qe.queryErrorCountsWithCode = env.Exporter().NewCountersWithMultiLabels("QueryErrorCountsWithCode", "query error counts with error code", []string{"Table", "Plan", "Code"})
+	maxCardinality := config.TableMetricsMaxCardinality
+	qe.queryCounts = env.Exporter().NewBoundedCountersWithMultiLabels("QueryCounts", "query counts", labels, maxCardinality)
+	qe.queryCountsWithTabletType = env.Exporter().NewBoundedCountersWithMultiLabels("QueryCountsWithTabletType", "query counts with tablet type labels", []string{"Table", "Plan", "TabletType"}, maxCardinality)
+	qe.queryTimes = env.Exporter().NewBoundedCountersWithMultiLabels("QueryTimesNs", "query times in ns", labels, maxCardinality)
+	qe.queryRowsAffected = env.Exporter().NewBoundedCountersWithMultiLabels("QueryRowsAffected", "query rows affected", labels, maxCardinality)
+	qe.queryRowsReturned = env.Exporter().NewBoundedCountersWithMultiLabels("QueryRowsReturned", "query rows returned", labels, maxCardinality)
+	qe.queryTextCharsProcessed = env.Exporter().NewBoundedCountersWithMultiLabels("QueryTextCharactersProcessed", "query text characters processed", labels, maxCardinality)
+	qe.queryErrorCounts = env.Exporter().NewBoundedCountersWithMultiLabels("QueryErrorCounts", "query error counts", labels, maxCardinality)
+	qe.queryErrorCountsWithCode = env.Exporter().NewBoundedCountersWithMultiLabels("QueryErrorCountsWithCode", "query error counts with error code", []string{"Table", "Plan", "Code"}, maxCardinality)
 
 	env.Exporter().HandleFunc("/debug/hotrows", qe.txSerializer.ServeHTTP)
 	env.Exporter().HandleFunc("/debug/tablet_plans", qe.handleHTTPQueryPlans)
@@ -320,6 +369,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	env.Exporter().HandleFunc("/debug/query_rules", qe.handleHTTPQueryRules)
 	env.Exporter().HandleFunc("/debug/consolidations", qe.handleHTTPConsolidations)
 	env.Exporter().HandleFunc("/debug/acl", qe.handleHTTPAclJSON)
+	env.Exporter().HandleFunc("/debug/hotspots", qe.hotspots.ServeHTTP)
 
 	return qe
 }
@@ -351,6 +401,7 @@ func (qe *QueryEngine) Open() error {
 	}
 
 	qe.streamConns.Open(config.DB.AppWithDB(), config.DB.DbaWithDB(), config.DB.AppDebugWithDB())
+	qe.planCapturer.Open()
 	qe.se.RegisterNotifier("qe", qe.schemaChanged, true)
 	qe.plans.EnsureOpen()
 	qe.settings.EnsureOpen()
@@ -371,11 +422,104 @@ func (qe *QueryEngine) Close() {
 	qe.plans.Close()
 	qe.settings.Close()
 
+	qe.planCapturer.Close()
 	qe.streamConns.Close()
 	qe.conns.Close()
 	log.Info("Query Engine: closed")
 }
 
+// WarmUp pre-opens connections in the query and stream pools and runs the
+// warm-up queries on the query pool, as configured by --restore-warmup-*.
+// It gives up once --restore-warmup-timeout is reached. Errors are logged and
+// returned, but the warm-up is best effort: the pools stay open either way.
+func (qe *QueryEngine) WarmUp(ctx context.Context) error {
+	cfg := qe.env.Config().RestoreWarmup
+	if !cfg.Enabled() || !qe.isOpen.Load() {
+		return nil
+	}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var queries []string
+	if cfg.QueriesFile != "" {
+		data, err := os.ReadFile(cfg.QueriesFile)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot read warm-up queries file %s", cfg.QueriesFile)
+		}
+		pieces, err := qe.env.Environment().Parser().SplitStatementToPieces(string(data))
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot parse warm-up queries file %s", cfg.QueriesFile)
+		}
+		for _, piece := range pieces {
+			if query := strings.TrimSpace(piece); query != "" {
+				queries = append(queries, query)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	var rec concurrency.AllErrorRecorder
+	wg.Go(func() {
+		rec.RecordError(warmUpPool(ctx, qe.streamConns, cfg.Connections, nil))
+	})
+	wg.Go(func() {
+		rec.RecordError(warmUpPool(ctx, qe.conns, cfg.Connections, func(conn *connpool.PooledConn, query string) error {
+			err := conn.Conn.Stream(ctx, query, func(*sqltypes.Result) error { return nil }, allocStreamResult, int(qe.streamBufferSize.Load()), querypb.ExecuteOptions_TYPE_ONLY)
+			if err != nil {
+				return vterrors.Wrapf(err, "warm-up query %q failed", qe.env.Environment().Parser().TruncateForLog(query))
+			}
+			return nil
+		}, queries...))
+	})
+	wg.Wait()
+
+	if err := rec.Error(); err != nil {
+		log.Warn(fmt.Sprintf("Query Engine: warm-up failed after %v: %v", time.Since(start), err))
+		return err
+	}
+	log.Info(fmt.Sprintf("Query Engine: warmed up %d connections and ran %d queries in %v", cfg.Connections, len(queries), time.Since(start)))
+	return nil
+}
+
+// warmUpPool holds up to count connections of the pool at once, so that the
+// pool has to open them, and runs the queries spread across them.
+func warmUpPool(ctx context.Context, pool *connpool.Pool, count int, run func(*connpool.PooledConn, string) error, queries ...string) error {
+	count = min(count, int(pool.Capacity()))
+	if count == 0 && len(queries) > 0 {
+		count = 1
+	}
+
+	conns := make([]*connpool.PooledConn, 0, count)
+	defer func() {
+		for _, conn := range conns {
+			conn.Recycle()
+		}
+	}()
+	for range count {
+		conn, err := pool.Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, conn)
+	}
+
+	var wg sync.WaitGroup
+	var rec concurrency.FirstErrorRecorder
+	for i, conn := range conns {
+		wg.Go(func() {
+			for j := i; j < len(queries); j += len(conns) {
+				if err := run(conn, queries[j]); err != nil {
+					rec.RecordError(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	return rec.Error()
+}
+
 var errNoCache = errors.New("plan should not be cached")
 
 func (qe *QueryEngine) getPlan(curSchema *currentSchema, sql string, noRowsLimit bool) (*TabletPlan, error) {
@@ -383,13 +527,27 @@ func (qe *QueryEngine) getPlan(curSchema *currentSchema, sql string, noRowsLimit
 	if err != nil {
 		return nil, err
 	}
+	var candidateStmt sqlparser.Statement
+	if qe.planRollout.Sample() {
+		candidateStmt = sqlparser.Clone(statement)
+	}
 	splan, err := planbuilder.Build(qe.env.Environment(), statement, curSchema.tables, qe.env.Config().DB.DBName, noRowsLimit)
+	if candidateStmt != nil {
+		qe.planRollout.Compare(sql, candidateStmt, curSchema.tables, qe.env.Config().DB.DBName, noRowsLimit, splan, err)
+	}
 	if err != nil {
 		return nil, err
 	}
 	plan := &TabletPlan{Plan: splan, Original: sql}
 	plan.Rules = qe.queryRuleSources.FilterByPlan(sql, []planbuilder.PlanType{plan.PlanID}, plan.TableNames()...)
+	err = plan.buildAugmentedQueries(statement, func(stmt sqlparser.Statement) (*planbuilder.Plan, error) {
+		return planbuilder.Build(qe.env.Environment(), stmt, curSchema.tables, qe.env.Config().DB.DBName, noRowsLimit)
+	})
+	if err != nil {
+		return nil, err
+	}
 	plan.buildAuthorized()
+	plan.prepare = plan.PlanID == planbuilder.PlanSelect && sqlparser.CanPrepare(statement)
 	if sqlparser.CachePlan(statement) {
 		return plan, nil
 	}
@@ -452,6 +610,12 @@ func (qe *QueryEngine) getStreamPlan(curSchema *currentSchema, sql string) (*Tab
 		qe.queryRuleSources.PlanMatchesExclusively(sql, []planbuilder.PlanType{plan.PlanID}, legacyID, plan.TableNames()...) {
 		warnAnalyzeLegacyRuleMatch(sql)
 	}
+	err = plan.buildAugmentedQueries(statement, func(stmt sqlparser.Statement) (*planbuilder.Plan, error) {
+		return planbuilder.BuildStreaming(qe.env.Environment(), stmt, curSchema.tables, qe.env.Config().DB.DBName)
+	})
+	if err != nil {
+		return nil, err
+	}
 	plan.buildAuthorized()
 
 	if sqlparser.CachePlan(statement) {
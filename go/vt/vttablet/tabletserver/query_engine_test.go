@@ -90,6 +90,44 @@ func TestStrictMode(t *testing.T) {
 	qe.Close()
 }
 
+func TestQueryEngineWarmUp(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	schematest.AddDefaultQueries(db)
+	db.AddQuery("select * from t1", &sqltypes.Result{})
+	db.AddQuery("select * from t2", &sqltypes.Result{})
+
+	queriesFile := path.Join(t.TempDir(), "warmup.sql")
+	require.NoError(t, os.WriteFile(queriesFile, []byte("select * from t1;\nselect * from t2;\n"), 0o600))
+
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(db)
+	cfg.RestoreWarmup.Connections = 3
+	cfg.RestoreWarmup.QueriesFile = queriesFile
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TabletServerTest")
+	se := schema.NewEngine(env)
+	qe := NewQueryEngine(env, se)
+	qe.se.InitDBConfig(newDBConfigs(db).DbaWithDB())
+	qe.se.Open()
+	require.NoError(t, qe.Open())
+	defer qe.Close()
+
+	require.NoError(t, qe.WarmUp(t.Context()))
+	assert.EqualValues(t, 3, qe.conns.Active())
+	assert.EqualValues(t, 3, qe.streamConns.Active())
+	assert.Equal(t, 1, db.GetQueryCalledNum("select * from t1"))
+	assert.Equal(t, 1, db.GetQueryCalledNum("select * from t2"))
+
+	// A failing warm-up query is reported.
+	require.NoError(t, os.WriteFile(queriesFile, []byte("select * from unknown_table"), 0o600))
+	err := qe.WarmUp(t.Context())
+	require.ErrorContains(t, err, "warm-up query \"select * from unknown_table\" failed")
+
+	cfg.RestoreWarmup.QueriesFile = path.Join(t.TempDir(), "missing.sql")
+	err = qe.WarmUp(t.Context())
+	require.ErrorContains(t, err, "cannot read warm-up queries file")
+}
+
 func TestGetPlanPanicDuetoEmptyQuery(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -105,6 +143,33 @@ func TestGetPlanPanicDuetoEmptyQuery(t *testing.T) {
 	require.EqualError(t, err, "Query was empty")
 }
 
+func TestGetPlanRollout(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	schematest.AddDefaultQueries(db)
+
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(db)
+	cfg.PlanRollout.Planner = planbuilder.CurrentPlanner
+	cfg.PlanRollout.SampleRate = 1
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, t.Name())
+	se := schema.NewEngine(env)
+	qe := NewQueryEngine(env, se)
+	se.InitDBConfig(cfg.DB.DbaWithDB())
+	qe.se.Open()
+	qe.Open()
+	defer qe.Close()
+
+	ctx := t.Context()
+	logStats := tabletenv.NewLogStats(ctx, "GetPlanStats", streamlog.NewQueryLogConfigForTest())
+	_, err := qe.GetPlan(ctx, logStats, "select * from test_table_01", true, false)
+	require.NoError(t, err)
+	_, err = qe.GetPlan(ctx, logStats, "select * from", true, false)
+	require.Error(t, err)
+	// the statement that cannot be parsed is not planned at all
+	assert.Equal(t, map[string]int64{"Match": 1}, qe.planRollout.Comparisons())
+}
+
 func addSchemaEngineQueries(db *fakesqldb.DB) {
 	db.AddQueryPattern(baseShowTablesWithSizesPattern, &sqltypes.Result{
 		Fields: mysql.BaseShowTablesWithSizesFields,
@@ -1037,6 +1102,26 @@ func TestAddQueryStats(t *testing.T) {
 	}
 }
 
+func TestAddQueryStatsMaxCardinality(t *testing.T) {
+	plan := &TabletPlan{
+		Plan: &planbuilder.Plan{
+			PlanID:    planbuilder.PlanSelect,
+			FullQuery: &sqlparser.ParsedQuery{Query: `select * from something`},
+		},
+	}
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(fakesqldb.New(t))
+	cfg.TableMetricsMaxCardinality = 2
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TestAddQueryStatsMaxCardinality")
+	qe := NewQueryEngine(env, schema.NewEngine(env))
+	for _, table := range []string{"A", "B", "C", "A", "D"} {
+		qe.AddStats(plan, table, "", topodata.TabletType_PRIMARY, 1, 0, 0, 0, 1, 0, "")
+	}
+	assert.Equal(t, map[string]int64{"A.Select": 2, "B.Select": 1, "other.other": 2}, qe.queryCounts.Counts())
+	assert.Equal(t, map[string]int64{"A.Select.PRIMARY": 2, "B.Select.PRIMARY": 1, "other.other.other": 2}, qe.queryCountsWithTabletType.Counts())
+	assert.EqualValues(t, 2, qe.queryCounts.Overflows())
+}
+
 func TestPlanPoolUnsafe(t *testing.T) {
 	tcases := []struct {
 		name, query, err string
@@ -18,14 +18,15 @@ package tabletserver
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"strings"
 	"sync"
 	"time"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
@@ -43,6 +44,7 @@ import (
 	"vitess.io/vitess/go/vt/tableacl/acl"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/colstats"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 	p "vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
@@ -67,6 +69,9 @@ type QueryExecutor struct {
 	// The target type we requested might be different from tsv's tablet type, if we had a change to the tablet type recently.
 	targetTabletType topodatapb.TabletType
 	setting          *smartconnpool.Setting
+	// augmentedQuery replaces the FullQuery of the plan if a query rule
+	// rewrote the query.
+	augmentedQuery *sqlparser.ParsedQuery
 }
 
 const (
@@ -89,6 +94,9 @@ var (
 		},
 	}
 	errTxThrottled = vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "Transaction throttled")
+	// errCallProcMultiResultset is returned by a stored procedure call that
+	// returned more than one result set when it is not streamed.
+	errCallProcMultiResultset = vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure, stream the call to return all its result sets")
 )
 
 func returnStreamResult(result *sqltypes.Result) error {
@@ -152,6 +160,8 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		qre.logStats.RowsAffected = int(reply.RowsAffected)
 		qre.logStats.Rows = reply.Rows
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
+		qre.tsv.qe.planCapturer.Observe(qre.plan.PlanID, qre.plan.FullQuery, qre.bindVars, duration)
+		qre.tsv.qe.hotspots.Observe(qre.plan.Plan, qre.plan.Original, qre.bindVars)
 	}(time.Now())
 
 	if err = qre.checkPermissions(); err != nil {
@@ -167,6 +177,9 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	}
 
 	if qre.connID != 0 {
+		if err := qre.checkNoIdempotencyKey(); err != nil {
+			return nil, err
+		}
 		var conn *StatefulConnection
 		// Need upfront connection for DMLs and transactions
 		conn, err = qre.tsv.te.txPool.GetAndLock(qre.connID, "for query")
@@ -205,12 +218,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return qr, nil
 	case p.PlanOtherRead, p.PlanOtherAdmin, p.PlanFlush, p.PlanSavepoint, p.PlanRelease, p.PlanSRollback:
 		return qre.execOther()
-	case p.PlanInsert, p.PlanUpdate, p.PlanDelete, p.PlanInsertMessage, p.PlanLoad:
-		return qre.execAutocommit(qre.txConnExec)
+	case p.PlanInsert, p.PlanUpdate, p.PlanDelete, p.PlanInsertMessage, p.PlanLoad,
+		p.PlanUpdateLimit, p.PlanDeleteLimit, p.PlanInsertReturning:
+		return qre.execAutocommitDML()
 	case p.PlanDDL:
 		return qre.execDDL(nil)
-	case p.PlanUpdateLimit, p.PlanDeleteLimit:
-		return qre.execAsTransaction(qre.txConnExec)
 	case p.PlanCallProc:
 		return qre.execCallProc()
 	case p.PlanAlterMigration:
@@ -237,6 +249,55 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] %s unexpected plan type", qre.plan.PlanID.String())
 }
 
+// execAutocommitDML executes a DML outside of a transaction. DMLs that have to
+// read before they write run in a transaction of their own, the others run in
+// autocommit mode. A DML with an idempotency key always runs in a transaction
+// of its own, which also records the key in the DML journal.
+func (qre *QueryExecutor) execAutocommitDML() (*sqltypes.Result, error) {
+	if key := qre.options.GetIdempotencyKey(); key != "" {
+		return qre.execAsTransaction(func(conn *StatefulConnection) (*sqltypes.Result, error) {
+			return qre.execJournaled(conn, key)
+		})
+	}
+	switch qre.plan.PlanID {
+	case p.PlanUpdateLimit, p.PlanDeleteLimit, p.PlanInsertReturning:
+		return qre.execAsTransaction(qre.txConnExec)
+	}
+	return qre.execAutocommit(qre.txConnExec)
+}
+
+// checkNoIdempotencyKey rejects an idempotency key on a query executed in a
+// transaction or on a reserved connection: the DML journal only covers
+// autocommit DMLs, and a retry of the transaction would not be deduplicated.
+func (qre *QueryExecutor) checkNoIdempotencyKey() error {
+	if qre.options.GetIdempotencyKey() != "" {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "idempotency key is only supported for DMLs executed outside of a transaction")
+	}
+	return nil
+}
+
+// execJournaled executes the DML in the transaction of conn, unless the DML
+// journal shows that a previous execution with the same idempotency key was
+// committed, in which case the result recorded for it is returned.
+func (qre *QueryExecutor) execJournaled(conn *StatefulConnection, key string) (*sqltypes.Result, error) {
+	journal := qre.tsv.te.dmlJournal
+	recorded, err := journal.Begin(qre.ctx, conn, key)
+	if err != nil {
+		return nil, err
+	}
+	if recorded != nil {
+		return recorded, nil
+	}
+	qr, err := qre.txConnExec(conn)
+	if err != nil {
+		return nil, err
+	}
+	if err := journal.Complete(qre.ctx, conn, key, qr); err != nil {
+		return nil, err
+	}
+	return qr, nil
+}
+
 func (qre *QueryExecutor) execAutocommit(f func(conn *StatefulConnection) (*sqltypes.Result, error)) (reply *sqltypes.Result, err error) {
 	if qre.options == nil {
 		qre.options = &querypb.ExecuteOptions{}
@@ -300,6 +361,8 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		return qre.txFetch(conn, true)
 	case p.PlanUpdateLimit, p.PlanDeleteLimit:
 		return qre.execDMLLimit(conn)
+	case p.PlanInsertReturning:
+		return qre.execInsertReturning(conn)
 	case p.PlanOtherRead, p.PlanOtherAdmin, p.PlanFlush, p.PlanUnlockTables:
 		return qre.execStatefulConn(conn, qre.query, true)
 	case p.PlanSavepoint:
@@ -391,12 +454,13 @@ func (qre *QueryExecutor) txConnStreamExec() (*sqltypes.Result, error) {
 	return qre.txConnExec(conn)
 }
 
-// planStreamsUnbounded reports whether a plan is exempt from the query
-// timeout on the streaming path. Transactionless streaming carries no query
-// timeout so OLAP reads can stream indefinitely; that exemption is only for
-// reads, including CALL, which can stream a procedure's result set and ran
-// without a timeout before state-changing plans were admitted to this path.
-func planStreamsUnbounded(planID p.PlanType) bool {
+// planIsOLAPRead reports whether a plan is bounded by the OLAP query timeout
+// on the streaming path, rather than by the query timeout Execute would apply.
+// Transactionless streaming has no query timeout by default so OLAP reads can
+// stream indefinitely; that exemption is only for reads, including CALL, which
+// can stream a procedure's result set and ran without a timeout before
+// state-changing plans were admitted to this path.
+func planIsOLAPRead(planID p.PlanType) bool {
 	switch planID {
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanSelectLockFunc, p.PlanShow,
 		p.PlanOtherRead, p.PlanCallProc, p.PlanShowMigrations, p.PlanShowMigrationLogs,
@@ -406,19 +470,32 @@ func planStreamsUnbounded(planID p.PlanType) bool {
 	return false
 }
 
+// planIsDML reports whether a plan modifies rows, and so is bounded by the
+// DML timeout when one is configured.
+func planIsDML(planID p.PlanType) bool {
+	switch planID {
+	case p.PlanInsert, p.PlanInsertMessage, p.PlanInsertReturning, p.PlanUpdate,
+		p.PlanUpdateLimit, p.PlanDelete, p.PlanDeleteLimit, p.PlanLoad:
+		return true
+	}
+	return false
+}
+
 // Stream performs a streaming query execution.
 func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 	qre.logStats.PlanType = qre.plan.PlanID.String()
 
-	// State-changing plans get the query timeout Execute would apply, since
-	// the streaming path's unbounded execution is only for OLAP reads. Inside
-	// a transaction this stacks with the transaction timeout applied by
-	// streamExecute, like Execute's own min(query, transaction) bound.
-	if !planStreamsUnbounded(qre.plan.PlanID) {
-		var cancel context.CancelFunc
-		qre.ctx, cancel = withTimeout(qre.ctx, qre.tsv.loadQueryTimeoutWithOptions(qre.options), qre.options)
-		defer cancel()
+	// Reads get the OLAP query timeout, which is unbounded unless configured,
+	// and state-changing plans get the timeout Execute would apply to them.
+	// Inside a transaction this stacks with the transaction timeout applied
+	// by streamExecute, like Execute's own min(query, transaction) bound.
+	timeout := qre.tsv.loadOLAPQueryTimeout()
+	if !planIsOLAPRead(qre.plan.PlanID) {
+		timeout = qre.tsv.loadPlanQueryTimeout(qre.plan.PlanID, 0, qre.options)
 	}
+	var cancel context.CancelFunc
+	qre.ctx, cancel = withTimeout(qre.ctx, timeout, qre.options)
+	defer cancel()
 
 	defer func(start time.Time) {
 		qre.tsv.stats.QueryTimings.Record(qre.plan.PlanID.String(), start)
@@ -430,7 +507,7 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 	// inside streamDML, so its stats defer is registered before those checks and
 	// records per-table error stats for their rejections too, matching Execute.
 	switch qre.plan.PlanID {
-	case p.PlanInsert, p.PlanUpdate, p.PlanDelete, p.PlanUpdateLimit, p.PlanDeleteLimit, p.PlanLoad:
+	case p.PlanInsert, p.PlanUpdate, p.PlanDelete, p.PlanUpdateLimit, p.PlanDeleteLimit, p.PlanLoad, p.PlanInsertReturning:
 		return qre.streamDML(callback)
 	}
 
@@ -576,7 +653,7 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 	var sqlWithoutComments string
 	if qre.plan.FullQuery != nil {
 		var err error
-		sql, sqlWithoutComments, err = qre.generateFinalSQL(qre.plan.FullQuery, qre.bindVars)
+		sql, sqlWithoutComments, err = qre.generateFinalSQL(qre.fullQuery(), qre.bindVars)
 		if err != nil {
 			return err
 		}
@@ -653,24 +730,13 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 		conn := txConn.UnderlyingDBConn()
 		err = qre.execStreamSQL(conn, true, sql, streamCallback)
 		if qre.plan.PlanID == p.PlanCallProc {
-			if err != nil {
-				txConn.Close()
-				return err
-			}
-			trailing, multipleResultsets, err := qre.streamedCallProcTrailingStatus(conn.Conn)
 			if err != nil {
 				txConn.Close()
 				return err
 			}
 			// The procedure must not change the transaction state.
-			changedTx := txConn.IsInTransaction() != trailing.IsInTransaction()
-			if changedTx {
+			if txConn.IsInTransaction() != conn.Conn.StreamOKResult().IsInTransaction() {
 				txConn.Close()
-			}
-			if multipleResultsets {
-				return vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
-			}
-			if changedTx {
 				return vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
 			}
 			return nil
@@ -686,24 +752,13 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 
 	err = qre.execStreamSQL(dbConn, false, sql, streamCallback)
 	if qre.plan.PlanID == p.PlanCallProc {
-		if err != nil {
-			dbConn.Close()
-			return err
-		}
-		trailing, multipleResultsets, err := qre.streamedCallProcTrailingStatus(dbConn.Conn)
 		if err != nil {
 			dbConn.Close()
 			return err
 		}
 		// The procedure must not leak a transaction onto the pooled connection.
-		leakedTx := trailing.IsInTransaction()
-		if leakedTx {
+		if dbConn.Conn.StreamOKResult().IsInTransaction() {
 			dbConn.Close()
-		}
-		if multipleResultsets {
-			return vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
-		}
-		if leakedTx {
 			return vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
 		}
 		return nil
@@ -770,6 +825,8 @@ func (qre *QueryExecutor) streamDML(callback StreamCallback) (err error) {
 		qre.logStats.RowsAffected = int(reply.RowsAffected)
 		qre.logStats.Rows = reply.Rows
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
+		qre.tsv.qe.planCapturer.Observe(qre.plan.PlanID, qre.plan.FullQuery, qre.bindVars, duration)
+		qre.tsv.qe.hotspots.Observe(qre.plan.Plan, qre.plan.Original, qre.bindVars)
 	}(time.Now())
 
 	if err = qre.checkPermissions(); err != nil {
@@ -785,11 +842,12 @@ func (qre *QueryExecutor) streamDML(callback StreamCallback) (err error) {
 		// Run the DML on the existing transaction or reserved connection, just
 		// like Execute's connID != 0 branch (e.g. a DML following Begin via
 		// BeginStreamExecute).
+		if err = qre.checkNoIdempotencyKey(); err != nil {
+			return err
+		}
 		reply, err = qre.txConnStreamExec()
-	case qre.plan.PlanID == p.PlanUpdateLimit || qre.plan.PlanID == p.PlanDeleteLimit:
-		reply, err = qre.execAsTransaction(qre.txConnExec)
 	default:
-		reply, err = qre.execAutocommit(qre.txConnExec)
+		reply, err = qre.execAutocommitDML()
 	}
 	if err != nil {
 		return err
@@ -870,6 +928,7 @@ func (qre *QueryExecutor) checkPermissions() error {
 	default:
 		// no rules against this query. Good to proceed
 	}
+	qre.applyAugment(qre.plan.Rules.GetAugment(remoteAddr, username, qre.bindVars, qre.marginComments))
 
 	// Skip the ACL check if the connecting user is an exempted superuser.
 	if qre.tsv.qe.exemptACL != nil && qre.tsv.qe.exemptACL.IsMember(&querypb.VTGateCallerID{Username: username}) {
@@ -900,6 +959,33 @@ func (qre *QueryExecutor) checkPermissions() error {
 	return nil
 }
 
+// applyAugment applies the changes of a query rule with the QRAugment action
+// to the execution.
+func (qre *QueryExecutor) applyAugment(augment *rules.Augment) {
+	if augment == nil {
+		return
+	}
+	if query, ok := qre.plan.augmentedQueries[augment]; ok {
+		qre.augmentedQuery = query
+	}
+	if augment.WorkloadName != "" {
+		options := qre.options.CloneVT()
+		if options == nil {
+			options = &querypb.ExecuteOptions{}
+		}
+		options.WorkloadName = augment.WorkloadName
+		qre.options = options
+	}
+}
+
+// fullQuery returns the query to send to MySQL for the plan.
+func (qre *QueryExecutor) fullQuery() *sqlparser.ParsedQuery {
+	if qre.augmentedQuery != nil {
+		return qre.augmentedQuery
+	}
+	return qre.plan.FullQuery
+}
+
 func (qre *QueryExecutor) checkAccess(authorized *tableacl.ACLResult, tableName string, callerID *querypb.VTGateCallerID) error {
 	var aclState acl.ACLState
 	defer func() {
@@ -1011,7 +1097,12 @@ func (qre *QueryExecutor) execDDL(conn *StatefulConnection) (result *sqltypes.Re
 			return nil, err
 		}
 	}
-	return qre.execStatefulConn(conn, sql, true)
+	result, err = qre.execStatefulConn(conn, sql, true)
+	if err != nil {
+		return nil, err
+	}
+	conn.trackTempTables(qre.plan.FullStmt)
+	return result, nil
 }
 
 // checkCreateTableLimit rejects a CREATE TABLE/VIEW that would exceed the
@@ -1111,10 +1202,11 @@ func (qre *QueryExecutor) execNextval() (*sqltypes.Result, error) {
 // execSelect sends a query to mysql only if another identical query is not running. Otherwise, it waits and
 // reuses the result. If the plan is missing field info, it sends the query to mysql requesting full info.
 func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
-	sql, sqlWithoutComments, err := qre.generateFinalSQL(qre.plan.FullQuery, qre.bindVars)
+	sql, sqlWithoutComments, err := qre.generateFinalSQL(qre.fullQuery(), qre.bindVars)
 	if err != nil {
 		return nil, err
 	}
+	preparedSQL, args := qre.generatePreparedSQL()
 	// Check tablet type.
 	if qre.shouldConsolidate() {
 		q, original := qre.tsv.qe.consolidator.Create(sqlWithoutComments)
@@ -1127,7 +1219,7 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 				q.SetErr(err)
 			} else {
 				defer conn.Recycle()
-				res, err := qre.execDBConn(conn.Conn, sql, true)
+				res, err := qre.execDBConnPrepared(conn.Conn, sql, preparedSQL, args, true)
 				if qre.tsv.config.ConsolidatorCacheProto3Rows && q.HasWaiters() {
 					res.CacheProto3Rows()
 				}
@@ -1165,7 +1257,7 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 		return nil, err
 	}
 	defer conn.Recycle()
-	res, err := qre.execDBConn(conn.Conn, sql, true)
+	res, err := qre.execDBConnPrepared(conn.Conn, sql, preparedSQL, args, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1187,6 +1279,147 @@ func (qre *QueryExecutor) execDMLLimit(conn *StatefulConnection) (*sqltypes.Resu
 	return result, nil
 }
 
+// execInsertReturning runs an INSERT ... RETURNING, which MySQL does not
+// support: it runs the insert without the RETURNING clause and then reads
+// the returned expressions of the inserted rows by primary key, using the
+// values generated by the auto-increment column if any.
+func (qre *QueryExecutor) execInsertReturning(conn *StatefulConnection) (*sqltypes.Result, error) {
+	returning := qre.plan.Returning
+	keys, generated, err := qre.insertReturningKeys()
+	if err != nil {
+		return nil, err
+	}
+	result, err := qre.txFetch(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	if generated {
+		if result.InsertID == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "insert into %s did not generate auto-increment values for RETURNING", qre.plan.TableName())
+		}
+		// The values generated by a multi-row insert are consecutive, spaced
+		// by auto_increment_increment.
+		step := uint64(1)
+		if len(keys) > 1 {
+			qr, err := qre.execStatefulConn(conn, "select @@session.auto_increment_increment", false)
+			if err != nil {
+				return nil, err
+			}
+			if len(qr.Rows) != 1 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for auto_increment_increment: %v", qr.Rows)
+			}
+			if step, err = qr.Rows[0][0].ToCastUint64(); err != nil {
+				return nil, err
+			}
+		}
+		for i, key := range keys {
+			key[returning.AutoIncrement] = sqltypes.NewUint64(result.InsertID + uint64(i)*step)
+		}
+	}
+
+	keysBindVar := &querypb.BindVariable{Type: querypb.Type_TUPLE}
+	for _, key := range keys {
+		if len(key) == 1 {
+			keysBindVar.Values = append(keysBindVar.Values, sqltypes.ValueToProto(key[0]))
+		} else {
+			keysBindVar.Values = append(keysBindVar.Values, sqltypes.TupleToProto(key))
+		}
+	}
+	// The keys are bound on a copy so that they don't leak into the bind
+	// variables of the caller, which are logged and may be reused.
+	bindVars := maps.Clone(qre.bindVars)
+	bindVars[p.ReturningKeysBindVar] = keysBindVar
+	sql, _, err := qre.generateFinalSQL(returning.Select, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := qre.execStatefulConn(conn, sql, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) != len(keys) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "RETURNING read %d rows back from %s, expected %d", len(qr.Rows), qre.plan.TableName(), len(keys))
+	}
+
+	// Return the rows in the order they were inserted, without the primary
+	// key columns that follow the returned expressions.
+	numPK := len(keys[0])
+	numCols := len(qr.Fields) - numPK
+	pkFields := qr.Fields[numCols:]
+	rows := make([]sqltypes.Row, len(keys))
+	for _, row := range qr.Rows {
+		i, err := qre.findReturningKey(keys, rows, pkFields, row[numCols:])
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = row[:numCols]
+	}
+	return &sqltypes.Result{
+		Fields:       qr.Fields[:numCols],
+		Rows:         rows,
+		RowsAffected: result.RowsAffected,
+		InsertID:     result.InsertID,
+	}, nil
+}
+
+// insertReturningKeys evaluates the primary keys of the rows inserted by an
+// INSERT ... RETURNING. The auto-increment values are left NULL if MySQL
+// generates them, in which case generated is true. MySQL may not generate
+// consecutive values for an insert that mixes generated and explicit
+// values, so it is not supported.
+func (qre *QueryExecutor) insertReturningKeys() (keys []sqltypes.Row, generated bool, err error) {
+	returning := qre.plan.Returning
+	env := evalengine.NewExpressionEnv(qre.ctx, qre.bindVars, evalengine.NewEmptyVCursor(qre.tsv.Environment(), time.Local))
+	collation := qre.tsv.env.CollationEnv().DefaultConnectionCharset()
+	keys = make([]sqltypes.Row, len(returning.Keys))
+	var numGenerated int
+	for i, exprs := range returning.Keys {
+		keys[i] = make(sqltypes.Row, len(exprs))
+		for k, expr := range exprs {
+			v := sqltypes.NULL
+			if expr != nil {
+				res, err := env.Evaluate(expr)
+				if err != nil {
+					return nil, false, err
+				}
+				v = res.Value(collation)
+			}
+			if k == returning.AutoIncrement && (v.IsNull() || v.ToString() == "0") {
+				numGenerated++
+				v = sqltypes.NULL
+			}
+			keys[i][k] = v
+		}
+	}
+	if numGenerated > 0 && numGenerated < len(keys) {
+		return nil, false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported: RETURNING with both generated and explicit auto-increment values")
+	}
+	return keys, numGenerated > 0, nil
+}
+
+// findReturningKey returns the index of the inserted row whose primary key
+// is pk, skipping the rows that were already found.
+func (qre *QueryExecutor) findReturningKey(keys []sqltypes.Row, found []sqltypes.Row, pkFields []*querypb.Field, pk sqltypes.Row) (int, error) {
+	collationEnv := qre.tsv.env.CollationEnv()
+next:
+	for i, key := range keys {
+		if found[i] != nil {
+			continue
+		}
+		for k, v := range key {
+			cmp, err := evalengine.NullsafeCompare(v, pk[k], collationEnv, collations.ID(pkFields[k].Charset), nil)
+			if err != nil {
+				return 0, err
+			}
+			if cmp != 0 {
+				continue next
+			}
+		}
+		return i, nil
+	}
+	return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "RETURNING read back an unexpected row with primary key %v", pk)
+}
+
 func (qre *QueryExecutor) verifyRowCount(count, maxrows int64) error {
 	if count > maxrows {
 		callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
@@ -1232,7 +1465,7 @@ func (qre *QueryExecutor) getStreamConn() (*connpool.PooledConn, error) {
 
 // txFetch fetches from a TxConnection.
 func (qre *QueryExecutor) txFetch(conn *StatefulConnection, record bool) (*sqltypes.Result, error) {
-	sql, _, err := qre.generateFinalSQL(qre.plan.FullQuery, qre.bindVars)
+	sql, _, err := qre.generateFinalSQL(qre.fullQuery(), qre.bindVars)
 	if err != nil {
 		return nil, err
 	}
@@ -1320,6 +1553,21 @@ func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, b
 	return buf.String(), query, nil
 }
 
+// generatePreparedSQL returns the query to prepare for the plan, with the
+// margin comments added by generateFinalSQL, and the arguments to execute it
+// with. It returns an empty query if the plan is not executed as a prepared
+// statement.
+func (qre *QueryExecutor) generatePreparedSQL() (string, []sqltypes.Value) {
+	if !qre.tsv.config.PreparedStatements.Enable || !qre.plan.prepare {
+		return "", nil
+	}
+	query, args, ok := qre.fullQuery().GeneratePreparedQuery(qre.bindVars)
+	if !ok {
+		return "", nil
+	}
+	return qre.marginComments.Leading + query + qre.marginComments.Trailing, args
+}
+
 func rewriteOUTParamError(err error) error {
 	sqlErr, ok := err.(*sqlerror.SQLError)
 	if !ok {
@@ -1342,25 +1590,20 @@ func (qre *QueryExecutor) execCallProc() (*sqltypes.Result, error) {
 		return nil, err
 	}
 
-	qr, err := qre.execDBConn(conn.Conn, sql, true)
-	if errors.Is(err, mysql.ErrExecuteFetchMultipleResults) {
-		return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	qr, trailing, err := qre.execDBConnCallProc(conn.Conn, sql, true)
+	// The procedure must not leak a transaction onto the pooled connection,
+	// even if its result sets could not be returned.
+	leakedTx := trailing != nil && trailing.IsInTransaction()
+	if leakedTx {
+		conn.Close()
 	}
 	if err != nil {
 		return nil, rewriteOUTParamError(err)
 	}
-	if !qr.IsMoreResultsExists() {
-		if qr.IsInTransaction() {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
-		}
-		return qr, nil
-	}
-	err = qre.drainResultSetOnConn(conn.Conn)
-	if err != nil {
-		return nil, err
+	if leakedTx {
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
 	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	return qr, nil
 }
 
 func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result, error) {
@@ -1369,71 +1612,39 @@ func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result,
 	if err != nil {
 		return nil, err
 	}
-	qr, err := qre.execStatefulConn(conn, sql, true)
-	if err != nil {
-		return nil, rewriteOUTParamError(err)
-	}
-	if !qr.IsMoreResultsExists() {
-		afterInTx := qr.IsInTransaction()
-		if beforeInTx != afterInTx {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
-		}
-		return qr, nil
-	}
-	err = qre.drainResultSetOnConn(conn.UnderlyingDBConn().Conn)
-	if err != nil {
-		return nil, err
-	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
-}
-
-// streamedCallProcTrailingStatus reads what follows a streamed stored procedure
-// call's first resultset to determine the connection's final state. It returns
-// the trailing status — whose flags describe the post-call transaction state —
-// and whether the call produced more than one resultset (already drained). A
-// stored procedure call is always followed by a trailing status packet, so a
-// streamed resultset's EOF always reports more results; reading one more result
-// tells a single-resultset call (only the trailing packet remains) from a
-// multi-resultset one.
-func (qre *QueryExecutor) streamedCallProcTrailingStatus(conn *connpool.Conn) (trailing *sqltypes.Result, multipleResultsets bool, err error) {
-	if okResult := conn.StreamOKResult(); okResult != nil {
-		// No resultset was streamed, so the OK packet's status flags are all there
-		// is to inspect.
-		return &sqltypes.Result{StatusFlags: okResult.StatusFlags}, false, nil
+	qr, trailing, err := qre.execStatefulConnCallProc(conn, sql, true)
+	// The procedure must not change the transaction state, even if its
+	// result sets could not be returned.
+	changedTx := trailing != nil && beforeInTx != trailing.IsInTransaction()
+	if changedTx {
+		conn.Close()
 	}
-
-	trailing, err = conn.FetchNext(qre.ctx, mysql.FETCH_NO_ROWS, false)
 	if err != nil {
-		return nil, false, err
-	}
-	if !trailing.IsMoreResultsExists() {
-		// Only the trailing status packet followed the single resultset.
-		return trailing, false, nil
+		return nil, rewriteOUTParamError(err)
 	}
-
-	// More than one resultset: drain the rest without buffering so the final
-	// status reflects the connection state and the connection stays clean.
-	trailing, err = qre.drainStreamedResultSets(conn)
-	if err != nil {
-		return nil, true, err
+	if changedTx {
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
 	}
-	return trailing, true, nil
+	return qr, nil
 }
 
-// drainStreamedResultSets discards any remaining resultsets on a streaming
-// connection without buffering their rows and returns the final result, whose
-// status flags describe the connection state after the stored procedure.
-func (qre *QueryExecutor) drainStreamedResultSets(conn *connpool.Conn) (*sqltypes.Result, error) {
-	for {
-		qr, err := conn.FetchNext(qre.ctx, mysql.FETCH_NO_ROWS, false)
-		if err != nil {
-			return nil, err
-		}
-		if !qr.IsMoreResultsExists() {
-			return qr, nil
-		}
-	}
+// callProcResult picks the result of a stored procedure call among the results
+// MySQL returned for it: one result set for each statement of the procedure
+// that returned rows, followed by the status of the call, which is returned as
+// trailing. qr is nil if the procedure returned more than one result set,
+// since a query that is not streamed returns a single result.
+func callProcResult(results []*sqltypes.Result) (qr *sqltypes.Result, trailing *sqltypes.Result) {
+	trailing = results[len(results)-1]
+	switch len(results) {
+	case 1:
+		// The procedure returned no rows.
+		return trailing, trailing
+	case 2:
+		qr = results[0]
+		qr.StatusFlags = trailing.StatusFlags
+		return qr, trailing
+	}
+	return nil, trailing
 }
 
 func (qre *QueryExecutor) execAlterMigration() (*sqltypes.Result, error) {
@@ -1578,24 +1789,49 @@ func (qre *QueryExecutor) execShowThrottlerStatus() (*sqltypes.Result, error) {
 	return result, nil
 }
 
-func (qre *QueryExecutor) drainResultSetOnConn(conn *connpool.Conn) error {
-	more := true
-	for more {
-		qr, err := conn.FetchNext(qre.ctx, int(qre.getSelectLimit()), true)
-		if err != nil {
-			return err
-		}
-		more = qr.IsMoreResultsExists()
-	}
-	return nil
-}
-
 func (qre *QueryExecutor) getSelectLimit() int64 {
 	return qre.tsv.qe.maxResultSize.Load()
 }
 
 func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields bool) (*sqltypes.Result, error) {
-	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.execDBConn")
+	return qre.execDBConnPrepared(conn, sql, "", nil, wantfields)
+}
+
+// execDBConnPrepared executes preparedSQL with args through
+// connpool.Conn.ExecPrepared if it is set, and sql otherwise.
+func (qre *QueryExecutor) execDBConnPrepared(conn *connpool.Conn, sql string, preparedSQL string, args []sqltypes.Value, wantfields bool) (*sqltypes.Result, error) {
+	return qre.execOnDBConn("QueryExecutor.execDBConn", conn, sql, func(ctx context.Context) (*sqltypes.Result, error) {
+		if preparedSQL != "" {
+			return conn.ExecPrepared(ctx, sql, preparedSQL, args, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+		}
+		return conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+	})
+}
+
+// execDBConnCallProc executes the stored procedure call sql, and returns its
+// result and the status of the call, as picked by callProcResult. The status
+// is also returned with the error of a procedure that returned more than one
+// result set.
+func (qre *QueryExecutor) execDBConnCallProc(conn *connpool.Conn, sql string, wantfields bool) (qr *sqltypes.Result, trailing *sqltypes.Result, err error) {
+	qr, err = qre.execOnDBConn("QueryExecutor.execDBConnCallProc", conn, sql, func(ctx context.Context) (*sqltypes.Result, error) {
+		results, err := conn.ExecResults(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+		if err != nil {
+			return nil, err
+		}
+		qr, trailing = callProcResult(results)
+		if qr == nil {
+			return nil, errCallProcMultiResultset
+		}
+		return qr, nil
+	})
+	return qr, trailing, err
+}
+
+// execOnDBConn runs exec, which executes sql on conn outside of a
+// transaction, under a span named spanName. The query is tracked so that it
+// can be killed, and its warnings and last insert id are added to its result.
+func (qre *QueryExecutor) execOnDBConn(spanName string, conn *connpool.Conn, sql string, exec func(ctx context.Context) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
+	span, ctx := trace.NewSpan(qre.ctx, spanName)
 	defer span.Finish()
 	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
 
@@ -1610,20 +1846,45 @@ func (qre *QueryExecutor) execDBConn(conn *connpool.Conn, sql string, wantfields
 		return nil, err
 	}
 
-	exec, err := conn.Exec(ctx, sql, int(qre.tsv.qe.maxResultSize.Load()), wantfields)
+	qr, err := exec(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := qre.fetchLastInsertID(ctx, conn, exec); err != nil {
+	qr.Warnings = qre.readWarnings(ctx, conn)
+
+	if err := qre.fetchLastInsertID(ctx, conn, qr); err != nil {
 		return nil, err
 	}
 
-	return exec, nil
+	return qr, nil
 }
 
 func (qre *QueryExecutor) execStatefulConn(conn *StatefulConnection, sql string, wantfields bool) (*sqltypes.Result, error) {
-	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.execStatefulConn")
+	return qre.execOnStatefulConn("QueryExecutor.execStatefulConn", conn, sql, func(ctx context.Context) (*sqltypes.Result, error) {
+		return conn.Exec(ctx, sql, qre.getMaxResultSize(), wantfields)
+	})
+}
+
+// execStatefulConnCallProc is execDBConnCallProc for a stateful connection.
+func (qre *QueryExecutor) execStatefulConnCallProc(conn *StatefulConnection, sql string, wantfields bool) (qr *sqltypes.Result, trailing *sqltypes.Result, err error) {
+	qr, err = qre.execOnStatefulConn("QueryExecutor.execStatefulConnCallProc", conn, sql, func(ctx context.Context) (*sqltypes.Result, error) {
+		results, err := conn.ExecResults(ctx, sql, qre.getMaxResultSize(), wantfields)
+		if err != nil {
+			return nil, err
+		}
+		qr, trailing = callProcResult(results)
+		if qr == nil {
+			return nil, errCallProcMultiResultset
+		}
+		return qr, nil
+	})
+	return qr, trailing, err
+}
+
+// execOnStatefulConn is execOnDBConn for a stateful connection.
+func (qre *QueryExecutor) execOnStatefulConn(spanName string, conn *StatefulConnection, sql string, exec func(ctx context.Context) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
+	span, ctx := trace.NewSpan(qre.ctx, spanName)
 	defer span.Finish()
 	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
 
@@ -1638,16 +1899,32 @@ func (qre *QueryExecutor) execStatefulConn(conn *StatefulConnection, sql string,
 		return nil, err
 	}
 
-	exec, err := conn.Exec(ctx, sql, qre.getMaxResultSize(), wantfields)
+	qr, err := exec(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := qre.fetchLastInsertID(ctx, conn.UnderlyingDBConn().Conn, exec); err != nil {
+	qr.Warnings = qre.readWarnings(ctx, conn.UnderlyingDBConn().Conn)
+
+	if err := qre.fetchLastInsertID(ctx, conn.UnderlyingDBConn().Conn, qr); err != nil {
 		return nil, err
 	}
 
-	return exec, nil
+	return qr, nil
+}
+
+// readWarnings returns the warnings MySQL raised for the last statement
+// executed on conn. Reading them is best-effort: the statement already ran,
+// and may even have committed, so a failure to read its warnings is logged
+// and counted instead of failing the statement.
+func (qre *QueryExecutor) readWarnings(ctx context.Context, conn *connpool.Conn) []*querypb.QueryWarning {
+	warnings, err := conn.Warnings(ctx)
+	if err != nil {
+		qre.tsv.Stats().InternalErrors.Add("Warnings", 1)
+		log.Warn(fmt.Sprintf("Failed to read the warnings of a query: %v", err))
+		return nil
+	}
+	return warnings
 }
 
 func (qre *QueryExecutor) getMaxResultSize() int {
@@ -1734,6 +2011,9 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 		defer qre.tsv.olapql.Remove(qd)
 		err = conn.Conn.Stream(ctx, sql, cb, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
 	}
+	if err == nil && qre.plan.PlanID == p.PlanCallProc {
+		err = qre.streamCallProcResults(ctx, conn.Conn, cb)
+	}
 
 	if err != nil || lastInsertIDSet || !qre.options.GetFetchLastInsertId() {
 		return err
@@ -1748,6 +2028,34 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 	return nil
 }
 
+// streamCallProcResults streams the result sets of a stored procedure call
+// that follow the first one, each starting with a packet that has NewResult
+// set, and reads the status of the call that follows them. The status is then
+// returned by conn.StreamOKResult.
+func (qre *QueryExecutor) streamCallProcResults(ctx context.Context, conn *connpool.Conn, callback func(*sqltypes.Result) error) error {
+	for conn.MoreStreamResults() {
+		newResult := true
+		err := conn.StreamNextResult(ctx, func(result *sqltypes.Result) error {
+			if newResult {
+				newResult = false
+				if len(result.Fields) == 0 {
+					// This is the status of the call, not a result set.
+					return nil
+				}
+				result.NewResult = true
+			}
+			return callback(result)
+		}, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+		if err != nil {
+			return err
+		}
+	}
+	if conn.StreamOKResult() == nil {
+		return vterrors.New(vtrpcpb.Code_INTERNAL, "[BUG] stored procedure call ended without a status")
+	}
+	return nil
+}
+
 func (qre *QueryExecutor) recordUserQuery(queryType string, duration int64) {
 	var username string
 	if qre.tsv.config.SkipUserMetrics {
@@ -1783,6 +2091,8 @@ func (qre *QueryExecutor) GetSchemaDefinitions(tableType querypb.SchemaTableType
 		return qre.getTableDefinitions(tableNames, callback)
 	case querypb.SchemaTableType_UDFS:
 		return qre.getUDFs(callback)
+	case querypb.SchemaTableType_COLUMN_STATISTICS:
+		return qre.getColumnStatistics(callback)
 	}
 	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid table type %v", tableType)
 }
@@ -1851,7 +2161,7 @@ func (qre *QueryExecutor) getUDFs(callback func(schemaRes *querypb.GetSchemaResp
 			udf := &querypb.UDFInfo{
 				Name:        row[0].ToString(),
 				Aggregating: aggr,
-				ReturnType:  sqlparser.SQLTypeToQueryType(row[1].ToString(), false),
+				ReturnType:  eschema.UDFReturnType(row[1].ToString()),
 			}
 			udfs = append(udfs, udf)
 		}
@@ -1860,3 +2170,32 @@ func (qre *QueryExecutor) getUDFs(callback func(schemaRes *querypb.GetSchemaResp
 		})
 	})
 }
+
+func (qre *QueryExecutor) getColumnStatistics(callback func(schemaRes *querypb.GetSchemaResponse) error) error {
+	conn, err := qre.getStreamConn()
+	if err != nil {
+		return err
+	}
+	defer conn.Recycle()
+
+	return qre.execStreamSQL(conn, false /* isTransaction */, colstats.FetchQuery(), func(result *sqltypes.Result) error {
+		var columnStatistics []*querypb.ColumnStatistics
+		for _, row := range result.Rows {
+			tableRows, _ := row[2].ToInt64()
+			sampleRows, _ := row[3].ToInt64()
+			distinctValues, _ := row[4].ToInt64()
+			nullFraction, _ := row[5].ToFloat64()
+			columnStatistics = append(columnStatistics, &querypb.ColumnStatistics{
+				TableName:      row[0].ToString(),
+				ColumnName:     row[1].ToString(),
+				TableRows:      tableRows,
+				SampleRows:     sampleRows,
+				DistinctValues: distinctValues,
+				NullFraction:   nullFraction,
+			})
+		}
+		return callback(&querypb.GetSchemaResponse{
+			ColumnStatistics: columnStatistics,
+		})
+	})
+}
@@ -18,6 +18,7 @@ package tabletserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand/v2"
@@ -35,7 +36,9 @@ import (
 	"vitess.io/vitess/go/vt/vtenv"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/vt/callerid"
@@ -843,6 +846,313 @@ func TestQueryExecutorPlanPassSelectWithLockOutsideATransaction(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestQueryExecutorPlanSelectPrepared(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt32(1),
+			sqltypes.NewInt32(10),
+			sqltypes.NewInt32(100),
+		}},
+	}
+	db.AddQuery("select * from test_table where pk = 1 limit 10001", want)
+	db.AddQuery("select /*vt+ SKIP_PREPARED_STATEMENT */ * from test_table where pk = 1 limit 10001", want)
+	ctx := t.Context()
+	tsv := newTestTabletServer(ctx, enablePreparedStatements, db)
+	defer tsv.StopService()
+	prepared := tsv.stats.PreparedStatements
+	prepares, executes := prepared.Counts()["Prepare"], prepared.Counts()["Execute"]
+
+	for range 3 {
+		qre := newTestQueryExecutor(ctx, tsv, "select * from test_table where pk = 1", 0)
+		assert.Equal(t, planbuilder.PlanSelect, qre.plan.PlanID)
+		assert.True(t, qre.plan.prepare)
+		got, err := qre.Execute()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	assert.EqualValues(t, 1, prepared.Counts()["Prepare"]-prepares)
+	assert.EqualValues(t, 2, prepared.Counts()["Execute"]-executes)
+	assert.Equal(t, 3, db.GetQueryCalledNum("select * from test_table where pk = 1 limit 10001"))
+
+	// The directive opts the query out of being prepared.
+	for range 3 {
+		qre := newTestQueryExecutor(ctx, tsv, "select /*vt+ SKIP_PREPARED_STATEMENT */ * from test_table where pk = 1", 0)
+		assert.False(t, qre.plan.prepare)
+		_, err := qre.Execute()
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, prepared.Counts()["Prepare"]-prepares)
+}
+
+func TestQueryExecutorInsertReturning(t *testing.T) {
+	type dbResponse struct {
+		query  string
+		result *sqltypes.Result
+	}
+
+	testcases := []struct {
+		name        string
+		input       string
+		dbResponses []dbResponse
+		resultWant  *sqltypes.Result
+		logWant     string
+		inTxWant    string
+		errorWant   string
+	}{{
+		name:  "explicit primary key",
+		input: "insert into test_table(pk, addr) values (1, 2) returning pk, addr + 1 as a1",
+		dbResponses: []dbResponse{{
+			query:  "insert into test_table(pk, addr) values (1, 2)",
+			result: &sqltypes.Result{RowsAffected: 1},
+		}, {
+			query:  "select pk, addr + 1 as a1, pk from test_table where pk in (1)",
+			result: sqltypes.MakeTestResult(sqltypes.MakeTestFields("pk|a1|pk", "int32|int64|int32"), "1|3|1"),
+		}},
+		resultWant: &sqltypes.Result{
+			Fields:       sqltypes.MakeTestFields("pk|a1", "int32|int64"),
+			Rows:         [][]sqltypes.Value{{sqltypes.NewInt32(1), sqltypes.NewInt64(3)}},
+			RowsAffected: 1,
+		},
+		logWant:  "begin; insert into test_table(pk, addr) values (1, 2); select pk, addr + 1 as a1, pk from test_table where pk in (1); commit",
+		inTxWant: "insert into test_table(pk, addr) values (1, 2); select pk, addr + 1 as a1, pk from test_table where pk in (1)",
+	}, {
+		name:  "generated primary keys are returned in insert order",
+		input: "insert into test_table(addr) values (2), (4) returning pk, addr",
+		dbResponses: []dbResponse{{
+			query:  "insert into test_table(addr) values (2), (4)",
+			result: &sqltypes.Result{RowsAffected: 2, InsertID: 10},
+		}, {
+			query:  "select @@session.auto_increment_increment",
+			result: sqltypes.MakeTestResult(sqltypes.MakeTestFields("@@session.auto_increment_increment", "uint64"), "2"),
+		}, {
+			query:  "select pk, addr, pk from test_table where pk in (10, 12)",
+			result: sqltypes.MakeTestResult(sqltypes.MakeTestFields("pk|addr|pk", "int32|int32|int32"), "12|4|12", "10|2|10"),
+		}},
+		resultWant: &sqltypes.Result{
+			Fields: sqltypes.MakeTestFields("pk|addr", "int32|int32"),
+			Rows: [][]sqltypes.Value{
+				{sqltypes.NewInt32(10), sqltypes.NewInt32(2)},
+				{sqltypes.NewInt32(12), sqltypes.NewInt32(4)},
+			},
+			RowsAffected: 2,
+			InsertID:     10,
+		},
+		logWant:  "begin; insert into test_table(addr) values (2), (4); select @@session.auto_increment_increment; select pk, addr, pk from test_table where pk in (10, 12); commit",
+		inTxWant: "insert into test_table(addr) values (2), (4); select @@session.auto_increment_increment; select pk, addr, pk from test_table where pk in (10, 12)",
+	}, {
+		name:      "generated and explicit primary keys",
+		input:     "insert into test_table(pk, addr) values (1, 2), (null, 4) returning pk",
+		errorWant: "unsupported: RETURNING with both generated and explicit auto-increment values",
+	}, {
+		name:  "rows not read back",
+		input: "insert into test_table(pk) values (1) returning pk",
+		dbResponses: []dbResponse{{
+			query:  "insert into test_table(pk) values (1)",
+			result: &sqltypes.Result{RowsAffected: 1},
+		}, {
+			query:  "select pk, pk from test_table where pk in (1)",
+			result: &sqltypes.Result{Fields: sqltypes.MakeTestFields("pk|pk", "int32|int32")},
+		}},
+		errorWant: "RETURNING read 0 rows back from test_table, expected 1",
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			db := setUpQueryExecutorTest(t)
+			defer db.Close()
+			// The flags are only sent along with the column length or charset.
+			db.MockQueriesForTable("test_table", &sqltypes.Result{
+				Fields: []*querypb.Field{{
+					Name:         "pk",
+					Type:         sqltypes.Int32,
+					ColumnLength: 11,
+					Charset:      uint32(collations.CollationBinaryID),
+					Flags:        uint32(querypb.MySqlFlag_PRI_KEY_FLAG | querypb.MySqlFlag_AUTO_INCREMENT_FLAG),
+				}, {
+					Name: "name",
+					Type: sqltypes.Int32,
+				}, {
+					Name: "addr",
+					Type: sqltypes.Int32,
+				}},
+			})
+			for _, dbr := range tcase.dbResponses {
+				db.AddQuery(dbr.query, dbr.result)
+			}
+			ctx := t.Context()
+			tsv := newTestTabletServer(ctx, noFlags, db)
+			defer tsv.StopService()
+
+			qre := newTestQueryExecutor(ctx, tsv, tcase.input, 0)
+			assert.Equal(t, planbuilder.PlanInsertReturning, qre.plan.PlanID)
+			got, err := qre.Execute()
+			if tcase.errorWant != "" {
+				require.ErrorContains(t, err, tcase.errorWant)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.resultWant, got)
+			assert.Equal(t, tcase.logWant, qre.logStats.RewrittenSQL())
+			assert.NotContains(t, qre.bindVars, planbuilder.ReturningKeysBindVar)
+
+			txID := newTransaction(tsv, nil)
+			defer tsv.Commit(ctx, tsv.sm.Target(), txID)
+			qre = newTestQueryExecutor(ctx, tsv, tcase.input, txID)
+			got, err = qre.Execute()
+			require.NoError(t, err)
+			assert.Equal(t, tcase.resultWant, got)
+			assert.Equal(t, tcase.inTxWant, qre.logStats.RewrittenSQL())
+		})
+	}
+}
+
+func TestQueryExecutorDMLJournal(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	journalInsert := `insert into _vt\.dml_journal\(idempotency_key, time_created\) values \('key1', \d+\)`
+	db.AddQueryPattern(journalInsert, &sqltypes.Result{RowsAffected: 1})
+	insert := "insert into test_table(pk, addr) values (1, 2)"
+	db.AddQuery(insert, &sqltypes.Result{RowsAffected: 1, InsertID: 7})
+	journalUpdate := "update _vt.dml_journal set rows_affected = 1, insert_id = 7 where idempotency_key = 'key1'"
+	db.AddQuery(journalUpdate, &sqltypes.Result{RowsAffected: 1})
+	ctx := t.Context()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	want := &sqltypes.Result{RowsAffected: 1, InsertID: 7, InsertIDChanged: true}
+	qre := newTestQueryExecutor(ctx, tsv, insert, 0)
+	qre.options = &querypb.ExecuteOptions{IdempotencyKey: "key1"}
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, "begin; insert into test_table(pk, addr) values (1, 2); commit", qre.logStats.RewrittenSQL())
+	assert.Equal(t, 1, db.GetQueryCalledNum(journalUpdate))
+
+	// The retried DML finds its key in the journal and is not executed again.
+	db.RemoveQueryPattern(journalInsert)
+	db.RejectQueryPatternWithError(journalInsert, sqlerror.NewSQLError(sqlerror.ERDupEntry, sqlerror.SSConstraintViolation, "Duplicate entry 'key1' for key 'dml_journal.PRIMARY'"))
+	db.AddQuery("select rows_affected, insert_id from _vt.dml_journal where idempotency_key = 'key1'",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("rows_affected|insert_id", "uint64|uint64"), "1|7"))
+	qre = newTestQueryExecutor(ctx, tsv, insert, 0)
+	qre.options = &querypb.ExecuteOptions{IdempotencyKey: "key1"}
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, 1, db.GetQueryCalledNum(insert))
+	assert.Equal(t, 1, db.GetQueryCalledNum(journalUpdate))
+	assert.EqualValues(t, 1, tsv.te.dmlJournal.replays.Get())
+
+	// Without an idempotency key, the DML runs in autocommit mode.
+	qre = newTestQueryExecutor(ctx, tsv, insert, 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, "insert into test_table(pk, addr) values (1, 2)", qre.logStats.RewrittenSQL())
+
+	// An idempotency key is rejected in a transaction.
+	txID := newTransaction(tsv, nil)
+	defer tsv.Rollback(ctx, tsv.sm.Target(), txID)
+	qre = newTestQueryExecutor(ctx, tsv, insert, txID)
+	qre.options = &querypb.ExecuteOptions{IdempotencyKey: "key2"}
+	_, err = qre.Execute()
+	require.ErrorContains(t, err, "idempotency key is only supported for DMLs executed outside of a transaction")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+}
+
+func TestQueryExecutorWarnings(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	insert := "insert into test_table(pk, addr) values (1, 2)"
+	db.AddQuery(insert, &sqltypes.Result{RowsAffected: 1})
+	db.AddQueryWarnings(insert, 2)
+	db.AddQuery("show warnings", sqltypes.MakeTestResult(sqltypes.MakeTestFields("Level|Code|Message", "varchar|uint32|varchar"),
+		"Warning|1265|Data truncated for column 'addr' at row 1",
+		"Note|1592|Unsafe statement written to the binary log",
+	))
+	selectQuery := "select * from test_table limit 10001"
+	db.AddQuery(selectQuery, &sqltypes.Result{})
+	ctx := t.Context()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, insert, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []*querypb.QueryWarning{
+		{Level: "Warning", Code: 1265, Message: "Data truncated for column 'addr' at row 1"},
+		{Level: "Note", Code: 1592, Message: "Unsafe statement written to the binary log"},
+	}, got.Warnings)
+	assert.Equal(t, 1, db.GetQueryCalledNum("show warnings"))
+
+	// Warnings are not read for queries that raise none.
+	qre = newTestQueryExecutor(ctx, tsv, "select * from test_table", 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Nil(t, got.Warnings)
+	assert.Equal(t, 1, db.GetQueryCalledNum("show warnings"))
+
+	// A failure to read the warnings does not fail the query, which already ran.
+	db.AddRejectedQuery("show warnings", errors.New("connection lost"))
+	before := tsv.Stats().InternalErrors.Counts()["Warnings"]
+	qre = newTestQueryExecutor(ctx, tsv, insert, 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, got.RowsAffected)
+	assert.Nil(t, got.Warnings)
+	assert.Equal(t, before+1, tsv.Stats().InternalErrors.Counts()["Warnings"])
+}
+
+func TestQueryExecutorCallProc(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	call := "call update_users()"
+	db.AddQuery(call, &sqltypes.Result{RowsAffected: 2})
+	ctx := t.Context()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, call, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, got.RowsAffected)
+
+	// The same, on a reserved connection.
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	db.AddQueryPattern("create temporary table .*", &sqltypes.Result{})
+	state, _, err := tsv.ReserveExecute(ctx, nil, &target, nil, "create temporary table t1 (id int)", nil, 0, nil)
+	require.NoError(t, err)
+	got, err = tsv.Execute(ctx, nil, &target, call, nil, 0, state.ReservedID, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, got.RowsAffected)
+	require.NoError(t, tsv.Release(ctx, &target, 0, state.ReservedID))
+}
+
+func TestCallProcResult(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id", "int64")
+	status := &sqltypes.Result{RowsAffected: 1, StatusFlags: mysql.ServerStatusAutocommit}
+
+	// The procedure returned no rows.
+	qr, trailing := callProcResult([]*sqltypes.Result{status})
+	assert.Same(t, status, qr)
+	assert.Same(t, status, trailing)
+
+	// The procedure returned one result set, which takes the final status.
+	rows := sqltypes.MakeTestResult(fields, "1")
+	rows.StatusFlags = mysql.ServerMoreResultsExists | mysql.ServerStatusInTrans
+	qr, trailing = callProcResult([]*sqltypes.Result{rows, status})
+	assert.Same(t, rows, qr)
+	assert.Same(t, status, trailing)
+	assert.False(t, qr.IsInTransaction())
+	assert.False(t, qr.IsMoreResultsExists())
+
+	// Only one result set can be returned without streaming.
+	qr, trailing = callProcResult([]*sqltypes.Result{sqltypes.MakeTestResult(fields, "1"), sqltypes.MakeTestResult(fields, "2"), status})
+	assert.Nil(t, qr)
+	assert.Same(t, status, trailing)
+}
+
 func TestQueryExecutorPlanNextval(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -1428,6 +1738,64 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	require.Equalf(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err), "tsv.qe.queryRuleSources.SetRules: %v, want %v", vterrors.Code(err), vtrpcpb.Code_FAILED_PRECONDITION)
 }
 
+func TestQueryExecutorAugmentRule(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+
+	query := "select * from test_table where `name` = 1"
+	augmentedQuery := "select * from test_table force index (idx_name) where `name` = 1 limit 10"
+	augmentedResult := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{{sqltypes.NewInt32(1), sqltypes.NewInt32(2), sqltypes.NewInt32(3)}},
+	}
+	db.AddQuery(query+" limit 10001", &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQuery(query, &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQuery(augmentedQuery, augmentedResult)
+
+	augmentRule := rules.NewQueryRule("augment batch queries", "augment", rules.QRAugment)
+	require.NoError(t, augmentRule.SetUserCond("batch"))
+	augmentRule.AddTableCond("test_table")
+	augmentRule.SetAugment(&rules.Augment{
+		IndexHints:   []*rules.IndexHint{{Table: "test_table", Type: "FORCE", Indexes: []string{"idx_name"}}},
+		Limit:        10,
+		WorkloadName: "batch_workload",
+	})
+	qrs := rules.New()
+	qrs.Add(augmentRule)
+
+	tsv := newTestTabletServer(t.Context(), noFlags, db)
+	defer tsv.StopService()
+	rulesName := "augmentRules"
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+
+	// Queries that don't match the rule are not changed.
+	ctx := callinfo.NewContext(t.Context(), &fakecallinfo.FakeCallInfo{User: "other"})
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Empty(t, got.Rows)
+	assert.Empty(t, qre.options.GetWorkloadName())
+
+	ctx = callinfo.NewContext(t.Context(), &fakecallinfo.FakeCallInfo{User: "batch"})
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, augmentedResult.Rows, got.Rows)
+	assert.Equal(t, "batch_workload", qre.options.GetWorkloadName())
+
+	// The streaming path sends the augmented query too.
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	var rows [][]sqltypes.Value
+	require.NoError(t, qre.Stream(func(result *sqltypes.Result) error {
+		rows = append(rows, result.Rows...)
+		return nil
+	}))
+	assert.Equal(t, augmentedResult.Rows, rows)
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -1751,6 +2119,40 @@ func TestQueryExecutorStreamDMLAppliesQueryTimeout(t *testing.T) {
 		"the deadline must come from the query timeout")
 }
 
+// TestQueryExecutorStreamAppliesPlanTimeouts verifies that the streaming path
+// bounds each plan by the timeout of its class: DML by the DML timeout, and
+// reads by the OLAP query timeout, which leaves them unbounded by default.
+func TestQueryExecutorStreamAppliesPlanTimeouts(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	db.AddQuery("insert into test_table(a) values (1)", &sqltypes.Result{RowsAffected: 1})
+	db.AddQuery("select * from test_table", &sqltypes.Result{Fields: getTestTableFields()})
+
+	ctx := t.Context()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.config.Oltp.DMLTimeout = 5 * time.Second
+
+	qre := newTestQueryExecutorStreaming(ctx, tsv, "insert into test_table(a) values(1)", 0)
+	require.NoError(t, qre.Stream(func(*sqltypes.Result) error { return nil }))
+	deadline, ok := qre.ctx.Deadline()
+	require.True(t, ok)
+	assert.LessOrEqual(t, time.Until(deadline), tsv.config.Oltp.DMLTimeout)
+
+	qre = newTestQueryExecutorStreaming(ctx, tsv, "select * from test_table", 0)
+	require.Equal(t, planbuilder.PlanSelect, qre.plan.PlanID)
+	require.NoError(t, qre.Stream(func(*sqltypes.Result) error { return nil }))
+	_, ok = qre.ctx.Deadline()
+	assert.False(t, ok, "streaming reads must not have a timeout unless the OLAP query timeout is set")
+
+	tsv.config.Olap.QueryTimeout = 2 * time.Second
+	qre = newTestQueryExecutorStreaming(ctx, tsv, "select * from test_table", 0)
+	require.NoError(t, qre.Stream(func(*sqltypes.Result) error { return nil }))
+	deadline, ok = qre.ctx.Deadline()
+	require.True(t, ok)
+	assert.LessOrEqual(t, time.Until(deadline), tsv.config.Olap.QueryTimeout)
+}
+
 func TestQueryExecutorShouldConsolidate(t *testing.T) {
 	testCases := []struct {
 		// whether or not the consolidator is enabled by default on the tablet
@@ -2106,6 +2508,7 @@ const (
 	smallResultSize
 	disableOnlineDDL
 	enableConsolidator
+	enablePreparedStatements
 )
 
 // newTestQueryExecutor uses a package level variable testTabletServer defined in tabletserver_test.go
@@ -2142,6 +2545,7 @@ func newTestTabletServer(ctx context.Context, flags executorFlags, db *fakesqldb
 	} else {
 		cfg.Consolidator = tabletenv.Disable
 	}
+	cfg.PreparedStatements.Enable = flags&enablePreparedStatements > 0
 	dbconfigs := newDBConfigs(db)
 	cfg.DB = dbconfigs
 	srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
@@ -60,6 +60,7 @@ type ReplTracker struct {
 	hw     *heartbeatWriter
 	hr     *heartbeatReader
 	poller *poller
+	tl     *tableLagTracker
 }
 
 // NewReplTracker creates a new ReplTracker.
@@ -69,6 +70,7 @@ func NewReplTracker(env tabletenv.Env, alias *topodatapb.TabletAlias) *ReplTrack
 		hw:     newHeartbeatWriter(env, alias),
 		hr:     newHeartbeatReader(env),
 		poller: &poller{},
+		tl:     newTableLagTracker(env),
 	}
 }
 
@@ -77,6 +79,13 @@ func (rt *ReplTracker) HeartbeatWriter() heartbeat.HeartbeatWriter {
 	return rt.hw
 }
 
+// SetVStreamer sets the VStreamer that the tracker uses to measure the apply
+// lag of the tables on a replica. It must be called before the tracker goes
+// into non-primary mode.
+func (rt *ReplTracker) SetVStreamer(vs VStreamer) {
+	rt.tl.vs = vs
+}
+
 // InitDBConfig initializes the target name.
 func (rt *ReplTracker) InitDBConfig(target *querypb.Target, mysqld mysqlctl.MysqlDaemon) {
 	rt.hw.InitDBConfig(target)
@@ -91,6 +100,7 @@ func (rt *ReplTracker) MakePrimary() {
 	log.Info("Replication Tracker: going into primary mode")
 
 	rt.isPrimary = true
+	rt.tl.Close()
 	if rt.mode == tabletenv.Heartbeat {
 		rt.hr.Close()
 		rt.hw.Open()
@@ -115,10 +125,12 @@ func (rt *ReplTracker) MakeNonPrimary() {
 		rt.poller.Status()
 	}
 	rt.hw.Close()
+	rt.tl.Open()
 }
 
 // Close closes ReplTracker.
 func (rt *ReplTracker) Close() {
+	rt.tl.Close()
 	rt.hw.Close()
 	rt.hr.Close()
 	log.Info("Replication Tracker: closed")
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repltracker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+// TableReplicationApplyLag is the lag of every transaction applied on a replica,
+// recorded for each of the tables the transaction changed.
+var tableApplyLag = stats.NewTimings("TableReplicationApplyLag", "Time between the commit of a transaction on the primary and its apply on this replica, by table", "Table")
+
+// VStreamer defines the functions of VStreamer
+// that the table lag tracker needs.
+type VStreamer interface {
+	Stream(ctx context.Context, startPos string, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter,
+		throttlerApp throttlerapp.Name, send func([]*binlogdatapb.VEvent) error, options *binlogdatapb.VStreamOptions) error
+}
+
+// tableLagTracker measures the apply lag of the tables on a replica. It streams
+// the binlog of the replica, and compares the time a transaction shows up in
+// it, which is when the replica applied the transaction, with the timestamp of
+// the commit event of the transaction, which the replica keeps from the binlog
+// of the primary. The binlog timestamps have a resolution of one second, so
+// the lag of a table is only meant to be compared with the lag of the other
+// tables: the replication lag of the tablet is still the one of the heartbeat.
+type tableLagTracker struct {
+	enabled  bool
+	env      tabletenv.Env
+	vs       VStreamer
+	errorLog *logutil.ThrottledLogger
+	wait     func(context.Context, time.Duration) bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newTableLagTracker(env tabletenv.Env) *tableLagTracker {
+	return &tableLagTracker{
+		enabled:  env.Config().TrackTableReplicationLag,
+		env:      env,
+		errorLog: logutil.NewThrottledLogger("TableLagTracker", 60*time.Second),
+		wait:     waitWithContext,
+	}
+}
+
+// Open starts streaming the binlog, if the tracker is enabled.
+func (tl *tableLagTracker) Open() {
+	if !tl.enabled || tl.vs == nil {
+		return
+	}
+
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if tl.cancel != nil {
+		return
+	}
+	log.Info("Table Lag Tracker: opening")
+
+	ctx, cancel := context.WithCancel(tabletenv.LocalContext())
+	tl.cancel = cancel
+	tl.wg.Add(1)
+	go tl.process(ctx)
+}
+
+// Close stops streaming the binlog.
+func (tl *tableLagTracker) Close() {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if tl.cancel == nil {
+		return
+	}
+
+	tl.cancel()
+	tl.cancel = nil
+	tl.wg.Wait()
+	log.Info("Table Lag Tracker: closed")
+}
+
+func (tl *tableLagTracker) process(ctx context.Context) {
+	defer tl.env.LogError()
+	defer tl.wg.Done()
+
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match: "/.*",
+		}},
+	}
+	options := &binlogdatapb.VStreamOptions{
+		EventTypes: []binlogdatapb.VEventType{
+			binlogdatapb.VEventType_ROW,
+			binlogdatapb.VEventType_COMMIT,
+		},
+	}
+
+	// The lag is only measured from the current position: the transactions
+	// that were applied while the tracker wasn't streaming are skipped.
+	for {
+		tables := make(map[string]bool)
+		err := tl.vs.Stream(ctx, "current", nil, filter, throttlerapp.TableLagTrackerName, func(events []*binlogdatapb.VEvent) error {
+			for _, event := range events {
+				switch event.Type {
+				case binlogdatapb.VEventType_ROW:
+					tables[event.RowEvent.TableName] = true
+				case binlogdatapb.VEventType_COMMIT:
+					tl.recordLag(event, tables)
+					clear(tables)
+				}
+			}
+			return nil
+		}, options)
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		tl.errorLog.Warningf("Table Lag Tracker's vstream ended (error: %v), retrying in 5 seconds...", err)
+		if !tl.wait(ctx, 5*time.Second) {
+			return
+		}
+	}
+}
+
+// recordLag records the lag of the transaction that ends with the commit event
+// for each of the tables it changed.
+func (tl *tableLagTracker) recordLag(commit *binlogdatapb.VEvent, tables map[string]bool) {
+	if len(tables) == 0 || commit.Timestamp == 0 {
+		return
+	}
+	lag := time.Unix(0, commit.CurrentTime).Sub(time.Unix(commit.Timestamp, 0))
+	if lag < 0 {
+		// the clocks of the primary and the replica aren't in sync
+		lag = 0
+	}
+	for table := range tables {
+		tableApplyLag.Add(table, lag)
+	}
+}
+
+func waitWithContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
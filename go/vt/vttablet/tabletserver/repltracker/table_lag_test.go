@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repltracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+type fakeVStreamer struct {
+	events [][]*binlogdatapb.VEvent
+	sent   chan struct{}
+}
+
+func (f *fakeVStreamer) Stream(ctx context.Context, startPos string, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter,
+	throttlerApp throttlerapp.Name, send func([]*binlogdatapb.VEvent) error, options *binlogdatapb.VStreamOptions) error {
+	for _, events := range f.events {
+		if err := send(events); err != nil {
+			return err
+		}
+	}
+	close(f.sent)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTableLagTracker(t *testing.T) {
+	tableApplyLag.Reset()
+	defer tableApplyLag.Reset()
+
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.TrackTableReplicationLag = true
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TableLagTrackerTest")
+
+	commit := time.Now().Add(-3 * time.Second).Truncate(time.Second)
+	row := func(table string) *binlogdatapb.VEvent {
+		return &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: table}}
+	}
+	vs := &fakeVStreamer{
+		events: [][]*binlogdatapb.VEvent{{
+			row("t1"),
+			row("t2"),
+			row("t1"),
+		}, {
+			{Type: binlogdatapb.VEventType_COMMIT, Timestamp: commit.Unix(), CurrentTime: commit.Add(2 * time.Second).UnixNano()},
+		}, {
+			row("t1"),
+			{Type: binlogdatapb.VEventType_COMMIT, Timestamp: commit.Unix(), CurrentTime: commit.Add(4 * time.Second).UnixNano()},
+			// a commit of a transaction without rows, such as a DDL, has no lag
+			{Type: binlogdatapb.VEventType_COMMIT, Timestamp: commit.Unix(), CurrentTime: commit.Add(8 * time.Second).UnixNano()},
+			// the clock of the primary is ahead of the one of the replica
+			row("t2"),
+			{Type: binlogdatapb.VEventType_COMMIT, Timestamp: commit.Unix(), CurrentTime: commit.Add(-time.Second).UnixNano()},
+		}},
+		sent: make(chan struct{}),
+	}
+
+	rt := NewReplTracker(env, &topodatapb.TabletAlias{Cell: "cell", Uid: 1})
+	rt.SetVStreamer(vs)
+	rt.MakeNonPrimary()
+	select {
+	case <-vs.sent:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "the tracker didn't stream the binlog")
+	}
+	rt.Close()
+
+	assert.Equal(t, map[string]int64{"All": 4, "t1": 2, "t2": 2}, tableApplyLag.Counts())
+	assert.Equal(t, 6*time.Second, time.Duration(tableApplyLag.Histograms()["t1"].Total()))
+	assert.Equal(t, 2*time.Second, time.Duration(tableApplyLag.Histograms()["t2"].Total()))
+	assert.Nil(t, rt.tl.cancel)
+}
+
+func TestTableLagTrackerDisabled(t *testing.T) {
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), tabletenv.NewDefaultConfig(), "TableLagTrackerTest")
+
+	rt := NewReplTracker(env, &topodatapb.TabletAlias{Cell: "cell", Uid: 1})
+	rt.SetVStreamer(&fakeVStreamer{sent: make(chan struct{})})
+	rt.MakeNonPrimary()
+	assert.Nil(t, rt.tl.cancel)
+	rt.Close()
+}
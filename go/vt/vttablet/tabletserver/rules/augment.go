@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Augment describes how a rule with the QRAugment action modifies the
+// queries it matches. It lets operators mitigate problematic queries without
+// changing the application.
+type Augment struct {
+	// IndexHints are added to the tables of SELECT statements.
+	IndexHints []*IndexHint
+	// Limit is added to SELECT and UNION statements without a LIMIT, and
+	// lowers larger literal limits. Zero adds no limit.
+	Limit int
+	// WorkloadName replaces the workload name of the request, which is used
+	// by the throttlers and the per-workload metrics.
+	WorkloadName string
+}
+
+// IndexHint is an index hint that an Augment adds to a table.
+type IndexHint struct {
+	// Table is the name of the table, as it appears in the query.
+	Table string
+	// Type is USE, IGNORE or FORCE.
+	Type string
+	// Indexes are the names of the indexes.
+	Indexes []string
+}
+
+var indexHintTypes = map[string]sqlparser.IndexHintType{
+	"USE":    sqlparser.UseOp,
+	"IGNORE": sqlparser.IgnoreOp,
+	"FORCE":  sqlparser.ForceOp,
+}
+
+// RewritesQuery returns true if the Augment changes the SQL of the queries.
+func (a *Augment) RewritesQuery() bool {
+	return a != nil && (len(a.IndexHints) > 0 || a.Limit > 0)
+}
+
+// Apply returns a copy of stmt with the index hints and the limit of the
+// Augment applied. It returns nil if they do not apply to stmt.
+func (a *Augment) Apply(stmt sqlparser.Statement) sqlparser.Statement {
+	if !a.RewritesQuery() {
+		return nil
+	}
+	sel, ok := stmt.(sqlparser.SelectStatement)
+	if !ok {
+		return nil
+	}
+	sel = sqlparser.Clone(sel)
+
+	if len(a.IndexHints) > 0 {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			ate, ok := node.(*sqlparser.AliasedTableExpr)
+			if !ok {
+				return true, nil
+			}
+			tbl, ok := ate.Expr.(sqlparser.TableName)
+			if !ok {
+				return true, nil
+			}
+			for _, hint := range a.IndexHints {
+				if hint.Table != tbl.Name.String() {
+					continue
+				}
+				indexHint := &sqlparser.IndexHint{Type: indexHintTypes[hint.Type]}
+				for _, index := range hint.Indexes {
+					indexHint.Indexes = append(indexHint.Indexes, sqlparser.NewIdentifierCI(index))
+				}
+				ate.Hints = append(ate.Hints, indexHint)
+			}
+			return true, nil
+		}, sel)
+	}
+
+	if a.Limit > 0 {
+		limit := sel.GetLimit()
+		switch {
+		case limit == nil:
+			sel.SetLimit(sqlparser.NewLimitWithoutOffset(a.Limit))
+		case limit.Rowcount != nil:
+			lit, ok := limit.Rowcount.(*sqlparser.Literal)
+			if !ok || lit.Type != sqlparser.IntVal {
+				break
+			}
+			if rowcount, err := strconv.ParseUint(lit.Val, 10, 64); err == nil && rowcount > uint64(a.Limit) {
+				limit.Rowcount = sqlparser.NewIntLiteral(strconv.Itoa(a.Limit))
+			}
+		}
+	}
+	return sel
+}
+
+func buildAugment(v any) (*Augment, error) {
+	info, ok := v.(map[string]any)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want json object for Augment")
+	}
+	a := &Augment{}
+	for k, v := range info {
+		switch k {
+		case "IndexHints":
+			lv, ok := v.([]any)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for IndexHints")
+			}
+			for _, hv := range lv {
+				hint, err := buildIndexHint(hv)
+				if err != nil {
+					return nil, err
+				}
+				a.IndexHints = append(a.IndexHints, hint)
+			}
+		case "Limit":
+			num, ok := v.(json.Number)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want number for Limit")
+			}
+			limit, err := num.Int64()
+			if err != nil || limit <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive number for Limit")
+			}
+			a.Limit = int(limit)
+		case "WorkloadName":
+			a.WorkloadName, ok = v.(string)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for WorkloadName")
+			}
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s in Augment", k)
+		}
+	}
+	if !a.RewritesQuery() && a.WorkloadName == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Augment must set IndexHints, Limit or WorkloadName")
+	}
+	return a, nil
+}
+
+func buildIndexHint(v any) (*IndexHint, error) {
+	info, ok := v.(map[string]any)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want json object for IndexHints")
+	}
+	hint := &IndexHint{}
+	for k, v := range info {
+		switch k {
+		case "Table":
+			hint.Table, ok = v.(string)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for Table in IndexHints")
+			}
+		case "Type":
+			typ, ok := v.(string)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for Type in IndexHints")
+			}
+			hint.Type = strings.ToUpper(typ)
+			if _, ok := indexHintTypes[hint.Type]; !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Type %s in IndexHints", typ)
+			}
+		case "Indexes":
+			lv, ok := v.([]any)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for Indexes in IndexHints")
+			}
+			for _, index := range lv {
+				name, ok := index.(string)
+				if !ok {
+					return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for Indexes in IndexHints")
+				}
+				hint.Indexes = append(hint.Indexes, name)
+			}
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s in IndexHints", k)
+		}
+	}
+	if hint.Table == "" || hint.Type == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Table and Type are required in IndexHints")
+	}
+	// USE INDEX () is valid and prevents the use of any index, but IGNORE
+	// and FORCE need at least one index.
+	if len(hint.Indexes) == 0 && hint.Type != "USE" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Indexes are required for %s in IndexHints", hint.Type)
+	}
+	return hint, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestAugmentImport(t *testing.T) {
+	qrs := New()
+	jsondata := `[{
+		"Name": "r1",
+		"Action": "AUGMENT",
+		"Augment": {
+			"IndexHints": [{"Table": "a", "Type": "force", "Indexes": ["idx_b"]}],
+			"Limit": 100,
+			"WorkloadName": "batch"
+		}
+	}]`
+	require.NoError(t, qrs.UnmarshalJSON([]byte(jsondata)))
+	require.Equal(t, QRAugment, qrs.rules[0].act)
+	require.Equal(t, &Augment{
+		IndexHints:   []*IndexHint{{Table: "a", Type: "FORCE", Indexes: []string{"idx_b"}}},
+		Limit:        100,
+		WorkloadName: "batch",
+	}, qrs.rules[0].augment)
+
+	// The marshaled rules can be imported again.
+	data, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	other := New()
+	require.NoError(t, other.UnmarshalJSON(data), string(data))
+	require.True(t, qrs.Equal(other))
+}
+
+func TestAugmentApply(t *testing.T) {
+	augment := &Augment{
+		IndexHints: []*IndexHint{
+			{Table: "a", Type: "FORCE", Indexes: []string{"idx_b", "idx_c"}},
+			{Table: "b", Type: "USE"},
+		},
+		Limit: 10,
+	}
+	testcases := []struct {
+		in, out string
+	}{{
+		in:  "select * from a where b = 1",
+		out: "select * from a force index (idx_b, idx_c) where b = 1 limit 10",
+	}, {
+		in:  "select * from a as x join b on x.id = b.id join c on c.id = b.id limit 5",
+		out: "select * from a as x force index (idx_b, idx_c) join b use index () on x.id = b.id join c on c.id = b.id limit 5",
+	}, {
+		in:  "select * from c limit 1, 1000",
+		out: "select * from c limit 1, 10",
+	}, {
+		in:  "select * from c limit :n",
+		out: "select * from c limit :n",
+	}, {
+		in:  "select * from a union select * from c",
+		out: "select * from a force index (idx_b, idx_c) union select * from c limit 10",
+	}, {
+		in:  "update a set b = 1",
+		out: "",
+	}}
+	parser := sqlparser.NewTestParser()
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.in)
+			require.NoError(t, err)
+			augmented := augment.Apply(stmt)
+			if tc.out == "" {
+				require.Nil(t, augmented)
+				return
+			}
+			require.Equal(t, tc.out, sqlparser.String(augmented))
+			// The statement itself is not changed.
+			require.Equal(t, tc.in, sqlparser.String(stmt))
+		})
+	}
+
+	stmt, err := parser.Parse("select * from a")
+	require.NoError(t, err)
+	assert.Nil(t, (&Augment{WorkloadName: "batch"}).Apply(stmt))
+}
+
+func TestGetAugment(t *testing.T) {
+	augment := &Augment{WorkloadName: "batch"}
+	qr1 := NewQueryRule("augment", "r1", QRAugment)
+	qr1.SetAugment(augment)
+	require.NoError(t, qr1.SetUserCond("batch_user"))
+	qr2 := NewQueryRule("deny", "r2", QRFailRetry)
+	require.NoError(t, qr2.SetIPCond("1.2.3.4"))
+
+	qrs := New()
+	qrs.Add(qr1)
+	qrs.Add(qr2)
+	mc := sqlparser.MarginComments{}
+
+	// Augment rules don't decide the action, so they don't shadow the
+	// rules after them.
+	action, _, _, desc := qrs.GetAction("1.2.3.4", "batch_user", nil, mc)
+	assert.Equal(t, QRFailRetry, action)
+	assert.Equal(t, "deny", desc)
+	action, _, _, _ = qrs.GetAction("5.6.7.8", "batch_user", nil, mc)
+	assert.Equal(t, QRContinue, action)
+
+	assert.Same(t, augment, qrs.GetAugment("5.6.7.8", "batch_user", nil, mc))
+	assert.Nil(t, qrs.GetAugment("5.6.7.8", "other_user", nil, mc))
+	assert.Equal(t, []*Augment{augment}, qrs.Augments())
+
+	// Filtered rules share the Augment.
+	filtered := qrs.FilterByPlan("select * from a", nil)
+	assert.Same(t, augment, filtered.GetAugment("5.6.7.8", "batch_user", nil, mc))
+}
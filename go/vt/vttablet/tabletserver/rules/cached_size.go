@@ -23,6 +23,26 @@ type cachedObject interface {
 	CachedSize(alloc bool) int64
 }
 
+func (cached *Augment) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field IndexHints []*vitess.io/vitess/go/vt/vttablet/tabletserver/rules.IndexHint
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.IndexHints)) * int64(8))
+		for _, elem := range cached.IndexHints {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field WorkloadName string
+	size += hack.RuntimeAllocSize(int64(len(cached.WorkloadName)))
+	return size
+}
+
 func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -40,13 +60,35 @@ func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *IndexHint) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Table string
+	size += hack.RuntimeAllocSize(int64(len(cached.Table)))
+	// field Type string
+	size += hack.RuntimeAllocSize(int64(len(cached.Type)))
+	// field Indexes []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Indexes)) * int64(16))
+		for _, elem := range cached.Indexes {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
+
 func (cached *Rule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(288)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -80,6 +122,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field augment *vitess.io/vitess/go/vt/vttablet/tabletserver/rules.Augment
+	size += cached.augment.CachedSize(true)
 	return size
 }
 
@@ -190,6 +190,8 @@ func (qrs *Rules) PlanMatchesExclusively(query string, baseIDs []planbuilder.Pla
 }
 
 // GetAction runs the input against the rules engine and returns the action to be performed.
+// Rules with the QRAugment action are skipped, as they don't decide whether
+// the query runs; see GetAugment.
 func (qrs *Rules) GetAction(
 	ip,
 	user string,
@@ -202,13 +204,40 @@ func (qrs *Rules) GetAction(
 	desc string,
 ) {
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
+		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue && act != QRAugment {
 			return act, qr.cancelCtx, qr.timeout, qr.Description
 		}
 	}
 	return QRContinue, nil, 0, ""
 }
 
+// GetAugment returns the Augment of the first rule with the QRAugment action
+// that matches the input, or nil.
+func (qrs *Rules) GetAugment(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) *Augment {
+	for _, qr := range qrs.rules {
+		if qr.act == QRAugment && qr.GetAction(ip, user, bindVars, marginComments) == QRAugment {
+			return qr.augment
+		}
+	}
+	return nil
+}
+
+// Augments returns the Augments of the rules with the QRAugment action.
+func (qrs *Rules) Augments() []*Augment {
+	var augments []*Augment
+	for _, qr := range qrs.rules {
+		if qr.act == QRAugment {
+			augments = append(augments, qr.augment)
+		}
+	}
+	return augments
+}
+
 // -----------------------------------------------
 
 // Rule represents one rule (conditions-action).
@@ -245,6 +274,9 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// augment is set for the QRAugment action.
+	augment *Augment
 }
 
 type namedRegexp struct {
@@ -293,6 +325,7 @@ func (qr *Rule) Equal(other *Rule) bool {
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
+		reflect.DeepEqual(qr.augment, other.augment) &&
 		qr.act == other.act)
 }
 
@@ -309,6 +342,7 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		act:             qr.act,
 		cancelCtx:       qr.cancelCtx,
 		timeout:         qr.timeout,
+		augment:         qr.augment,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -360,10 +394,19 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.augment != nil {
+		safeEncode(b, `,"Augment":`, qr.augment)
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
 
+// SetAugment sets how the queries are modified by the QRAugment action.
+// The Augment must not be changed afterwards.
+func (qr *Rule) SetAugment(augment *Augment) {
+	qr.augment = augment
+}
+
 // SetIPCond adds a regular expression condition for the client IP.
 // It has to be a full match (not substring).
 func (qr *Rule) SetIPCond(pattern string) (err error) {
@@ -605,6 +648,7 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRAugment
 )
 
 // MarshalJSON marshals to JSON.
@@ -618,6 +662,8 @@ func (act Action) MarshalJSON() ([]byte, error) {
 		str = "FAIL_RETRY"
 	case QRBuffer:
 		str = "BUFFER"
+	case QRAugment:
+		str = "AUGMENT"
 	default:
 		str = "INVALID"
 	}
@@ -918,6 +964,7 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "Augment":
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -996,11 +1043,21 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "AUGMENT":
+				qr.act = QRAugment
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+		case "Augment":
+			qr.augment, err = buildAugment(v)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
+	if (qr.act == QRAugment) != (qr.augment != nil) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Augment must be set for, and only for, the AUGMENT Action")
+	}
 	return qr, nil
 }
 
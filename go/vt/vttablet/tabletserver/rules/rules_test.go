@@ -743,6 +743,19 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Action": "AUGMENT" }]`, "Augment must be set for, and only for, the AUGMENT Action"},
+	{`[{"Action": "FAIL", "Augment": {"Limit": 1}}]`, "Augment must be set for, and only for, the AUGMENT Action"},
+	{`[{"Action": "AUGMENT", "Augment": 1}]`, "want json object for Augment"},
+	{`[{"Action": "AUGMENT", "Augment": {}}]`, "Augment must set IndexHints, Limit or WorkloadName"},
+	{`[{"Action": "AUGMENT", "Augment": {"Limit": 0}}]`, "want positive number for Limit"},
+	{`[{"Action": "AUGMENT", "Augment": {"Limit": "1"}}]`, "want number for Limit"},
+	{`[{"Action": "AUGMENT", "Augment": {"WorkloadName": 1}}]`, "want string for WorkloadName"},
+	{`[{"Action": "AUGMENT", "Augment": {"Unknown": 1}}]`, "unrecognized tag Unknown in Augment"},
+	{`[{"Action": "AUGMENT", "Augment": {"IndexHints": 1}}]`, "want list for IndexHints"},
+	{`[{"Action": "AUGMENT", "Augment": {"IndexHints": [{"Table": "a"}]}}]`, "Table and Type are required in IndexHints"},
+	{`[{"Action": "AUGMENT", "Augment": {"IndexHints": [{"Table": "a", "Type": "PREFER"}]}}]`, "invalid Type PREFER in IndexHints"},
+	{`[{"Action": "AUGMENT", "Augment": {"IndexHints": [{"Table": "a", "Type": "FORCE"}]}}]`, "Indexes are required for FORCE in IndexHints"},
+	{`[{"Action": "AUGMENT", "Augment": {"IndexHints": [{"Table": "a", "Type": "USE", "Indexes": [1]}]}}]`, "want string for Indexes in IndexHints"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -19,6 +19,7 @@ package schema
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/vt/log"
@@ -112,8 +113,11 @@ LIMIT 1
 	deleteAllUdfs = `delete from %s.udfs`
 
 	// copyUdfs copies user defined function to the udfs table.
+	// The return type of a function that is not loaded is not in
+	// performance_schema, so we get it from the ret column of mysql.func:
+	// 0 is STRING_RESULT, 1 REAL_RESULT, 2 INT_RESULT and 4 DECIMAL_RESULT.
 	copyUdfs = `INSERT INTO %s.udfs(FUNCTION_NAME, FUNCTION_RETURN_TYPE, FUNCTION_TYPE) 
-SELECT f.name, i.UDF_RETURN_TYPE, f.type FROM mysql.func f left join performance_schema.user_defined_functions i on f.name = i.udf_name
+SELECT f.name, coalesce(i.UDF_RETURN_TYPE, elt(f.ret + 1, 'char', 'double', 'integer', '', 'decimal'), ''), f.type FROM mysql.func f left join performance_schema.user_defined_functions i on f.name = i.udf_name
 `
 	// fetchAggregateUdfs queries fetches all the aggregate user defined functions.
 	fetchAggregateUdfs = `select function_name, function_return_type, function_type from %s.udfs`
@@ -571,6 +575,23 @@ func GetFetchTableAndViewsQuery(tableNames []string, parser *sqlparser.Parser) (
 	return parsedQuery.GenerateQuery(bv, nil)
 }
 
+// UDFReturnType returns the type of the values returned by a user defined
+// function, from its return type in the udfs table. MySQL does not record
+// the types of the arguments of user defined functions.
+func UDFReturnType(returnType string) querypb.Type {
+	switch strings.ToLower(returnType) {
+	case "char":
+		return sqltypes.VarChar
+	case "integer":
+		return sqltypes.Int64
+	case "double":
+		return sqltypes.Float64
+	case "decimal":
+		return sqltypes.Decimal
+	}
+	return sqltypes.Null
+}
+
 // GetFetchUDFsQuery gets the fetch query to retrieve all the UDFs.
 func GetFetchUDFsQuery(parser *sqlparser.Parser) (string, error) {
 	parsedQuery, err := generateFullQuery(fetchAggregateUdfs, parser)
@@ -1144,3 +1144,25 @@ func TestGetFetchTableAndViewsQuery(t *testing.T) {
 		})
 	}
 }
+
+// TestUDFReturnType tests the mapping of the return types in the udfs table to query types.
+func TestUDFReturnType(t *testing.T) {
+	testcases := []struct {
+		returnType string
+		expected   querypb.Type
+	}{
+		{returnType: "char", expected: sqltypes.VarChar},
+		{returnType: "integer", expected: sqltypes.Int64},
+		{returnType: "INTEGER", expected: sqltypes.Int64},
+		{returnType: "double", expected: sqltypes.Float64},
+		{returnType: "decimal", expected: sqltypes.Decimal},
+		{returnType: "", expected: sqltypes.Null},
+		{returnType: "row", expected: sqltypes.Null},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.returnType, func(t *testing.T) {
+			require.Equal(t, testcase.expected, UDFReturnType(testcase.returnType))
+		})
+	}
+}
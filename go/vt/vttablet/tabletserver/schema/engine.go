@@ -86,6 +86,8 @@ type Engine struct {
 
 	historian *historian
 
+	snapshotVersions snapshotVersions
+
 	conns           *connpool.Pool
 	ticks           *timer.Timer
 	reloadTimeout   time.Duration
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// snapshotColumnsQuery fetches the full type definition and the nullability of
+// every column of the database.
+const snapshotColumnsQuery = "select isc.table_name, isc.column_name, isc.column_type, isc.is_nullable from information_schema.columns as isc " +
+	"where isc.table_schema=database() order by isc.table_name, isc.ordinal_position"
+
+// snapshotIndexesQuery fetches the columns of every index of the database.
+// The column name of a functional key part is null.
+const snapshotIndexesQuery = "select iss.table_name, iss.index_name, iss.non_unique, iss.column_name from information_schema.statistics as iss " +
+	"where iss.table_schema=database() order by iss.table_name, iss.index_name, iss.seq_in_index"
+
+// maxSnapshotVersions is the number of versions of the last snapshots of the
+// schema the engine remembers, to return the changes since any of them.
+const maxSnapshotVersions = 16
+
+// snapshotVersion is the version of a snapshot of the schema, along with the
+// versions of its tables.
+type snapshotVersion struct {
+	version string
+	tables  map[string]string
+}
+
+// snapshotVersions are the versions of the last snapshots of the schema.
+type snapshotVersions struct {
+	mu       sync.Mutex
+	versions []snapshotVersion
+}
+
+// find returns the versions of the tables of the snapshot with the given version.
+func (sv *snapshotVersions) find(version string) (map[string]string, bool) {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+	for _, v := range sv.versions {
+		if v.version == version {
+			return v.tables, true
+		}
+	}
+	return nil, false
+}
+
+// record records the version of a snapshot, forgetting the oldest one if there
+// are too many.
+func (sv *snapshotVersions) record(version string, tables map[string]string) {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+	for _, v := range sv.versions {
+		if v.version == version {
+			return
+		}
+	}
+	if len(sv.versions) == maxSnapshotVersions {
+		sv.versions = sv.versions[1:]
+	}
+	sv.versions = append(sv.versions, snapshotVersion{version: version, tables: tables})
+}
+
+// Snapshot returns a snapshot of the schema of the tablet: the columns, the
+// primary key and the indexes of all its tables. It is built from the tables
+// the engine loaded when it last reloaded the schema. If sinceVersion is the
+// version of one of the last snapshots, the snapshot only contains the tables
+// that changed since and the tables that were dropped.
+func (se *Engine) Snapshot(ctx context.Context, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	if !se.IsOpen() {
+		return nil, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "schema engine is not open")
+	}
+	tables, err := se.snapshotTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The version of the snapshot is the hash of the names and the versions of
+	// its tables, which are sorted by name.
+	tableVersions := make(map[string]string, len(tables))
+	var b []byte
+	for _, table := range tables {
+		tb, err := table.MarshalVT()
+		if err != nil {
+			return nil, err
+		}
+		table.Version = hashSnapshot(tb)
+		tableVersions[table.Name] = table.Version
+		b = append(b, table.Name...)
+		b = append(b, 0)
+		b = append(b, table.Version...)
+		b = append(b, 0)
+	}
+	snapshot := &tabletmanagerdatapb.GetSchemaSnapshotResponse{
+		Version: hashSnapshot(b),
+		Full:    true,
+		Tables:  tables,
+	}
+	se.snapshotVersions.record(snapshot.Version, tableVersions)
+
+	since, ok := se.snapshotVersions.find(sinceVersion)
+	if sinceVersion == "" || !ok {
+		return snapshot, nil
+	}
+	snapshot.Full = false
+	snapshot.Tables = slices.DeleteFunc(snapshot.Tables, func(table *tabletmanagerdatapb.SchemaSnapshotTable) bool {
+		return since[table.Name] == table.Version
+	})
+	for name := range since {
+		if _, ok := tableVersions[name]; !ok {
+			snapshot.DroppedTables = append(snapshot.DroppedTables, name)
+		}
+	}
+	slices.Sort(snapshot.DroppedTables)
+	return snapshot, nil
+}
+
+// snapshotTables returns the tables of a snapshot of the schema, sorted by name.
+func (se *Engine) snapshotTables(ctx context.Context) ([]*tabletmanagerdatapb.SchemaSnapshotTable, error) {
+	conn, err := se.conns.Get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+
+	columnsResult, err := conn.Conn.Exec(ctx, snapshotColumnsQuery, mysql.FETCH_ALL_ROWS, false)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to fetch the columns of the tables")
+	}
+	// the column types and nullability, by table and column name
+	type columnInfo struct {
+		columnType string
+		nullable   bool
+	}
+	columnInfos := make(map[[2]string]columnInfo, len(columnsResult.Rows))
+	for _, row := range columnsResult.Rows {
+		columnInfos[[2]string{row[0].ToString(), row[1].ToString()}] = columnInfo{
+			columnType: row[2].ToString(),
+			nullable:   row[3].ToString() == "YES",
+		}
+	}
+
+	indexesResult, err := conn.Conn.Exec(ctx, snapshotIndexesQuery, mysql.FETCH_ALL_ROWS, false)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to fetch the indexes of the tables")
+	}
+	indexes := make(map[string][]*tabletmanagerdatapb.SchemaSnapshotIndex)
+	for _, row := range indexesResult.Rows {
+		tableName, indexName := row[0].ToString(), row[1].ToString()
+		tableIndexes := indexes[tableName]
+		if len(tableIndexes) == 0 || tableIndexes[len(tableIndexes)-1].Name != indexName {
+			nonUnique, _ := row[2].ToCastInt64()
+			tableIndexes = append(tableIndexes, &tabletmanagerdatapb.SchemaSnapshotIndex{Name: indexName, Unique: nonUnique == 0})
+			indexes[tableName] = tableIndexes
+		}
+		if !row[3].IsNull() {
+			index := tableIndexes[len(tableIndexes)-1]
+			index.Columns = append(index.Columns, row[3].ToString())
+		}
+	}
+
+	collationEnv := se.env.Environment().CollationEnv()
+	schema := se.GetSchema()
+	tables := make([]*tabletmanagerdatapb.SchemaSnapshotTable, 0, len(schema))
+	for name, table := range schema {
+		st := &tabletmanagerdatapb.SchemaSnapshotTable{
+			Name:    name,
+			Columns: make([]*tabletmanagerdatapb.SchemaSnapshotColumn, 0, len(table.Fields)),
+			Indexes: indexes[name],
+		}
+		for _, field := range table.Fields {
+			info := columnInfos[[2]string{name, field.Name}]
+			column := &tabletmanagerdatapb.SchemaSnapshotColumn{
+				Name:       field.Name,
+				Type:       field.Type,
+				ColumnType: info.columnType,
+				Nullable:   info.nullable,
+			}
+			if sqltypes.IsText(field.Type) {
+				column.Collation = collationEnv.LookupName(collations.ID(field.Charset))
+			}
+			st.Columns = append(st.Columns, column)
+		}
+		for _, pk := range table.PKColumns {
+			st.PrimaryKeyColumns = append(st.PrimaryKeyColumns, table.Fields[pk].Name)
+		}
+		tables = append(tables, st)
+	}
+	slices.SortFunc(tables, func(a, b *tabletmanagerdatapb.SchemaSnapshotTable) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return tables, nil
+}
+
+// hashSnapshot returns the version of a snapshot, or of a table of a snapshot.
+func hashSnapshot(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestSnapshot(t *testing.T) {
+	se, db, cancel := getTestSchemaEngine(t, 0)
+	defer cancel()
+
+	se.ResetTablesForTests()
+	se.SetTableForTests(&Table{
+		Name: sqlparser.NewIdentifierCS("t1"),
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int64, Charset: collations.CollationBinaryID},
+			{Name: "name", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
+		},
+		PKColumns: []int{0},
+	})
+	se.SetTableForTests(&Table{
+		Name:   sqlparser.NewIdentifierCS("t2"),
+		Fields: []*querypb.Field{{Name: "val", Type: sqltypes.Float64, Charset: collations.CollationBinaryID}},
+	})
+	columnsFields := sqltypes.MakeTestFields("table_name|column_name|column_type|is_nullable", "varchar|varchar|varchar|varchar")
+	db.AddQuery(snapshotColumnsQuery, sqltypes.MakeTestResult(columnsFields,
+		"t1|id|bigint|NO",
+		"t1|name|varchar(64)|YES",
+		"t2|val|double|YES",
+	))
+	indexesFields := sqltypes.MakeTestFields("table_name|index_name|non_unique|column_name", "varchar|varchar|int64|varchar")
+	db.AddQuery(snapshotIndexesQuery, sqltypes.MakeTestResult(indexesFields,
+		"t1|PRIMARY|0|id",
+		"t1|name_idx|1|name",
+		"t1|name_idx|1|id",
+		// a functional key part
+		"t2|val_idx|0|null",
+	))
+
+	snapshot, err := se.Snapshot(t.Context(), "")
+	require.NoError(t, err)
+	assert.True(t, snapshot.Full)
+	assert.NotEmpty(t, snapshot.Version)
+	require.Len(t, snapshot.Tables, 2)
+	t1 := snapshot.Tables[0]
+	assert.NotEmpty(t, t1.Version)
+	t1.Version = ""
+	assert.Equal(t, &tabletmanagerdatapb.SchemaSnapshotTable{
+		Name: "t1",
+		Columns: []*tabletmanagerdatapb.SchemaSnapshotColumn{
+			{Name: "id", Type: sqltypes.Int64, ColumnType: "bigint"},
+			{Name: "name", Type: sqltypes.VarChar, ColumnType: "varchar(64)", Collation: "utf8mb4_0900_ai_ci", Nullable: true},
+		},
+		PrimaryKeyColumns: []string{"id"},
+		Indexes: []*tabletmanagerdatapb.SchemaSnapshotIndex{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "name_idx", Columns: []string{"name", "id"}},
+		},
+	}, t1)
+	assert.Equal(t, "t2", snapshot.Tables[1].Name)
+	assert.Equal(t, []*tabletmanagerdatapb.SchemaSnapshotIndex{{Name: "val_idx", Unique: true}}, snapshot.Tables[1].Indexes)
+
+	// nothing changed since the snapshot
+	version := snapshot.Version
+	delta, err := se.Snapshot(t.Context(), version)
+	require.NoError(t, err)
+	assert.False(t, delta.Full)
+	assert.Equal(t, version, delta.Version)
+	assert.Empty(t, delta.Tables)
+	assert.Empty(t, delta.DroppedTables)
+
+	// t1 is altered, t2 is dropped and t3 is created
+	se.ResetTablesForTests()
+	se.SetTableForTests(&Table{
+		Name: sqlparser.NewIdentifierCS("t1"),
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int64, Charset: collations.CollationBinaryID},
+			{Name: "name", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
+			{Name: "age", Type: sqltypes.Int32, Charset: collations.CollationBinaryID},
+		},
+		PKColumns: []int{0},
+	})
+	se.SetTableForTests(&Table{
+		Name:   sqlparser.NewIdentifierCS("t3"),
+		Fields: []*querypb.Field{{Name: "id", Type: sqltypes.Int64, Charset: collations.CollationBinaryID}},
+	})
+	db.AddQuery(snapshotColumnsQuery, sqltypes.MakeTestResult(columnsFields,
+		"t1|id|bigint|NO",
+		"t1|name|varchar(64)|YES",
+		"t1|age|int|YES",
+		"t3|id|bigint|NO",
+	))
+	db.AddQuery(snapshotIndexesQuery, sqltypes.MakeTestResult(indexesFields,
+		"t1|PRIMARY|0|id",
+	))
+
+	delta, err = se.Snapshot(t.Context(), version)
+	require.NoError(t, err)
+	assert.False(t, delta.Full)
+	assert.NotEqual(t, version, delta.Version)
+	require.Len(t, delta.Tables, 2)
+	assert.Equal(t, "t1", delta.Tables[0].Name)
+	assert.Len(t, delta.Tables[0].Columns, 3)
+	assert.Equal(t, "t3", delta.Tables[1].Name)
+	assert.Equal(t, []string{"t2"}, delta.DroppedTables)
+
+	// the tablet doesn't know the version, e.g. because it restarted
+	snapshot, err = se.Snapshot(t.Context(), "unknown")
+	require.NoError(t, err)
+	assert.True(t, snapshot.Full)
+	assert.Equal(t, delta.Version, snapshot.Version)
+	assert.Len(t, snapshot.Tables, 2)
+	assert.Empty(t, snapshot.DroppedTables)
+}
+
+func TestSnapshotVersions(t *testing.T) {
+	var sv snapshotVersions
+	for i := range maxSnapshotVersions + 1 {
+		sv.record(string(rune('a'+i)), nil)
+	}
+	// the same version is only recorded once
+	sv.record("q", nil)
+	assert.Len(t, sv.versions, maxSnapshotVersions)
+
+	_, ok := sv.find("a")
+	assert.False(t, ok, "the oldest version is forgotten")
+	_, ok = sv.find("b")
+	assert.True(t, ok)
+}
@@ -106,6 +106,9 @@ type stateManager struct {
 	alsoAllow            []topodatapb.TabletType
 	reason               string
 	transitionErr        error
+	// warmUpPending is set after a restore, so that the next transition
+	// to serving as a non-primary warms up the query pools first.
+	warmUpPending bool
 
 	rw *requestsWaiter
 
@@ -131,6 +134,9 @@ type stateManager struct {
 	throttler    lagThrottler
 	qThrottler   queryThrottler
 	tableGC      tableGarbageCollector
+	analyzer     tableAnalyzer
+	colStats     columnStatisticsCollector
+	jobs         jobEngine
 
 	// hcticks starts on initialization and runs forever.
 	hcticks *timer.Timer
@@ -164,6 +170,7 @@ type (
 
 	queryEngine interface {
 		Open() error
+		WarmUp(context.Context) error
 		IsMySQLReachable() error
 		Close()
 	}
@@ -200,6 +207,21 @@ type (
 		Close()
 	}
 
+	tableAnalyzer interface {
+		Open() error
+		Close()
+	}
+
+	columnStatisticsCollector interface {
+		Open() error
+		Close()
+	}
+
+	jobEngine interface {
+		Open() error
+		Close()
+	}
+
 	queryThrottler interface {
 		Open() error
 		Close()
@@ -235,9 +257,13 @@ func (sm *stateManager) SetServingType(tabletType topodatapb.TabletType, ptsTime
 		state = StateNotConnected
 	}
 
+	// A pending warm-up only applies to the transition that follows it,
+	// whatever its target state is.
+	warmUp := sm.takeWarmUpPending()
+
 	log.Info(fmt.Sprintf("Starting transition to %v %v, primary term start timestamp: %v", tabletType, state, ptsTimestamp))
 	if sm.mustTransition(tabletType, ptsTimestamp, state, reason) {
-		return sm.execTransition(tabletType, state)
+		return sm.execTransition(tabletType, state, warmUp)
 	}
 	return nil
 }
@@ -264,7 +290,7 @@ func (sm *stateManager) mustTransition(tabletType topodatapb.TabletType, ptsTime
 	return true
 }
 
-func (sm *stateManager) execTransition(tabletType topodatapb.TabletType, state servingState) error {
+func (sm *stateManager) execTransition(tabletType topodatapb.TabletType, state servingState, warmUp bool) error {
 	defer sm.transitioning.Release(1)
 
 	var err error
@@ -273,7 +299,7 @@ func (sm *stateManager) execTransition(tabletType topodatapb.TabletType, state s
 		if tabletType == topodatapb.TabletType_PRIMARY {
 			err = sm.servePrimary()
 		} else {
-			err = sm.serveNonPrimary(tabletType)
+			err = sm.serveNonPrimary(tabletType, warmUp)
 		}
 	case StateNotServing:
 		if tabletType == topodatapb.TabletType_PRIMARY {
@@ -323,7 +349,7 @@ func (sm *stateManager) recheckState() bool {
 	if !sm.transitioning.TryAcquire(1) {
 		return false
 	}
-	go sm.execTransition(sm.wantTabletType, sm.wantState)
+	go sm.execTransition(sm.wantTabletType, sm.wantState, false)
 	return false
 }
 
@@ -476,6 +502,9 @@ func (sm *stateManager) servePrimary() error {
 	sm.throttler.Open()
 	sm.qThrottler.Open()
 	sm.tableGC.Open()
+	sm.analyzer.Open()
+	sm.colStats.Open()
+	sm.jobs.Open()
 	sm.ddle.Open()
 	sm.setState(topodatapb.TabletType_PRIMARY, StateServing)
 	return nil
@@ -495,13 +524,16 @@ func (sm *stateManager) unservePrimary() error {
 	return nil
 }
 
-func (sm *stateManager) serveNonPrimary(wantTabletType topodatapb.TabletType) error {
+func (sm *stateManager) serveNonPrimary(wantTabletType topodatapb.TabletType, warmUp bool) error {
 	// We are likely transitioning from primary. We have to honor
 	// the shutdown grace period.
 	cancel := sm.terminateAllQueries(nil)
 	defer cancel()
 
 	sm.ddle.Close()
+	sm.jobs.Close()
+	sm.colStats.Close()
+	sm.analyzer.Close()
 	sm.tableGC.Close()
 	sm.messager.Close()
 	sm.tracker.Close()
@@ -511,6 +543,11 @@ func (sm *stateManager) serveNonPrimary(wantTabletType topodatapb.TabletType) er
 	if err := sm.connect(wantTabletType, true); err != nil {
 		return err
 	}
+	if warmUp {
+		// The warm-up is best effort and bounded by --restore-warmup-timeout:
+		// the tablet serves even if it fails or times out.
+		_ = sm.qe.WarmUp(context.Background())
+	}
 
 	sm.te.AcceptReadOnly()
 	sm.rt.MakeNonPrimary()
@@ -550,6 +587,24 @@ func (sm *stateManager) connect(tabletType topodatapb.TabletType, serving bool)
 	return sm.txThrottler.Open()
 }
 
+// WarmUpOnNextServe makes the next transition warm up the query pools before
+// the tablet is marked as serving, if it is a transition to serving as a
+// non-primary. Any other transition drops the warm-up.
+func (sm *stateManager) WarmUpOnNextServe() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.warmUpPending = true
+}
+
+// takeWarmUpPending returns true if a warm-up is pending, and clears it.
+func (sm *stateManager) takeWarmUpPending() bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	pending := sm.warmUpPending
+	sm.warmUpPending = false
+	return pending
+}
+
 func (sm *stateManager) unserveCommon() {
 	sm.markClusterAction(ClusterActionInProgress)
 	defer sm.markClusterAction(ClusterActionNotInProgress)
@@ -563,7 +618,13 @@ func (sm *stateManager) unserveCommon() {
 
 	log.Info("Started online ddl executor close")
 	sm.ddle.Close()
-	log.Info("Finished online ddl executor close. Started table garbage collector close")
+	log.Info("Finished online ddl executor close. Started job engine close")
+	sm.jobs.Close()
+	log.Info("Finished job engine close. Started column statistics collector close")
+	sm.colStats.Close()
+	log.Info("Finished column statistics collector close. Started table analyzer close")
+	sm.analyzer.Close()
+	log.Info("Finished table analyzer close. Started table garbage collector close")
 	sm.tableGC.Close()
 	log.Info("Finished table garbage collector close. Started lag throttler close")
 	sm.throttler.Close()
@@ -17,6 +17,7 @@ limitations under the License.
 package tabletserver
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -31,6 +32,7 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 
@@ -90,7 +92,10 @@ func TestStateManagerServePrimary(t *testing.T) {
 	verifySubcomponent(t, 10, sm.throttler, testStateOpen)
 	verifySubcomponent(t, 11, sm.qThrottler, testStateOpen)
 	verifySubcomponent(t, 12, sm.tableGC, testStateOpen)
-	verifySubcomponent(t, 13, sm.ddle, testStateOpen)
+	verifySubcomponent(t, 13, sm.analyzer, testStateOpen)
+	verifySubcomponent(t, 14, sm.colStats, testStateOpen)
+	verifySubcomponent(t, 15, sm.jobs, testStateOpen)
+	verifySubcomponent(t, 16, sm.ddle, testStateOpen)
 
 	assert.False(t, sm.se.(*testSchemaEngine).nonPrimary)
 	assert.True(t, sm.se.(*testSchemaEngine).ensureCalled)
@@ -106,24 +111,60 @@ func TestStateManagerServeNonPrimary(t *testing.T) {
 	require.NoError(t, err)
 
 	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.messager, testStateClosed)
-	verifySubcomponent(t, 4, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 2, sm.jobs, testStateClosed)
+	verifySubcomponent(t, 3, sm.colStats, testStateClosed)
+	verifySubcomponent(t, 4, sm.analyzer, testStateClosed)
+	verifySubcomponent(t, 5, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 6, sm.messager, testStateClosed)
+	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 5, sm.se, testStateOpen)
-	verifySubcomponent(t, 6, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 7, sm.binlogDumper, testStateOpen)
-	verifySubcomponent(t, 8, sm.qe, testStateOpen)
-	verifySubcomponent(t, 9, sm.txThrottler, testStateOpen)
-	verifySubcomponent(t, 10, sm.te, testStateNonPrimary)
-	verifySubcomponent(t, 11, sm.rt, testStateNonPrimary)
-	verifySubcomponent(t, 12, sm.throttler, testStateOpen)
+	verifySubcomponent(t, 8, sm.se, testStateOpen)
+	verifySubcomponent(t, 9, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 10, sm.binlogDumper, testStateOpen)
+	verifySubcomponent(t, 11, sm.qe, testStateOpen)
+	verifySubcomponent(t, 12, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 13, sm.te, testStateNonPrimary)
+	verifySubcomponent(t, 14, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 15, sm.throttler, testStateOpen)
 
 	assert.Equal(t, topodatapb.TabletType_REPLICA, sm.target.TabletType)
 	assert.Equal(t, StateServing, sm.state)
 }
 
+func TestStateManagerWarmUpOnNextServe(t *testing.T) {
+	sm := newTestStateManager()
+	defer sm.StopService()
+	qe := sm.qe.(*testQueryEngine)
+
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_RESTORE, testNow, StateNotServing, ""))
+	sm.WarmUpOnNextServe()
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	assert.Equal(t, 1, qe.warmUps)
+	assert.Equal(t, StateServing, sm.state)
+
+	// The warm-up only happens once.
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_RDONLY, testNow, StateServing, ""))
+	assert.Equal(t, 1, qe.warmUps)
+
+	// A pending warm-up is dropped when serving as a primary.
+	sm.WarmUpOnNextServe()
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_PRIMARY, testNow, StateServing, ""))
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	assert.Equal(t, 1, qe.warmUps)
+
+	// It is also dropped by a transition that doesn't serve, or that
+	// doesn't change the state.
+	sm.WarmUpOnNextServe()
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_DRAINED, testNow, StateNotServing, ""))
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	assert.Equal(t, 1, qe.warmUps)
+	sm.WarmUpOnNextServe()
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, ""))
+	require.NoError(t, sm.SetServingType(topodatapb.TabletType_RDONLY, testNow, StateServing, ""))
+	assert.Equal(t, 1, qe.warmUps)
+}
+
 func TestStateManagerUnservePrimary(t *testing.T) {
 	sm := newTestStateManager()
 	defer sm.StopService()
@@ -131,20 +172,23 @@ func TestStateManagerUnservePrimary(t *testing.T) {
 	require.NoError(t, err)
 
 	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
-
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
-	verifySubcomponent(t, 8, sm.se, testStateOpen)
-	verifySubcomponent(t, 9, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 10, sm.binlogDumper, testStateOpen)
-	verifySubcomponent(t, 11, sm.qe, testStateOpen)
-	verifySubcomponent(t, 12, sm.txThrottler, testStateOpen)
-
-	verifySubcomponent(t, 13, sm.rt, testStatePrimary)
+	verifySubcomponent(t, 2, sm.jobs, testStateClosed)
+	verifySubcomponent(t, 3, sm.colStats, testStateClosed)
+	verifySubcomponent(t, 4, sm.analyzer, testStateClosed)
+	verifySubcomponent(t, 5, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 6, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 7, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 8, sm.messager, testStateClosed)
+	verifySubcomponent(t, 9, sm.te, testStateClosed)
+
+	verifySubcomponent(t, 10, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 11, sm.se, testStateOpen)
+	verifySubcomponent(t, 12, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 13, sm.binlogDumper, testStateOpen)
+	verifySubcomponent(t, 14, sm.qe, testStateOpen)
+	verifySubcomponent(t, 15, sm.txThrottler, testStateOpen)
+
+	verifySubcomponent(t, 16, sm.rt, testStatePrimary)
 
 	assert.Equal(t, topodatapb.TabletType_PRIMARY, sm.target.TabletType)
 	assert.Equal(t, StateNotServing, sm.state)
@@ -189,22 +233,25 @@ func TestStateManagerUnserveNonPrimary(t *testing.T) {
 	require.NoError(t, err)
 
 	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
-
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 2, sm.jobs, testStateClosed)
+	verifySubcomponent(t, 3, sm.colStats, testStateClosed)
+	verifySubcomponent(t, 4, sm.analyzer, testStateClosed)
+	verifySubcomponent(t, 5, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 6, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 7, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 8, sm.messager, testStateClosed)
+	verifySubcomponent(t, 9, sm.te, testStateClosed)
+
+	verifySubcomponent(t, 10, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 8, sm.se, testStateOpen)
-	verifySubcomponent(t, 9, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 10, sm.binlogDumper, testStateOpen)
-	verifySubcomponent(t, 11, sm.qe, testStateOpen)
-	verifySubcomponent(t, 12, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 11, sm.se, testStateOpen)
+	verifySubcomponent(t, 12, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 13, sm.binlogDumper, testStateOpen)
+	verifySubcomponent(t, 14, sm.qe, testStateOpen)
+	verifySubcomponent(t, 15, sm.txThrottler, testStateOpen)
 
-	verifySubcomponent(t, 13, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 16, sm.rt, testStateNonPrimary)
 
 	assert.Equal(t, topodatapb.TabletType_RDONLY, sm.target.TabletType)
 	assert.Equal(t, StateNotServing, sm.state)
@@ -217,19 +264,22 @@ func TestStateManagerClose(t *testing.T) {
 	require.NoError(t, err)
 
 	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
-
-	verifySubcomponent(t, 8, sm.txThrottler, testStateClosed)
-	verifySubcomponent(t, 9, sm.qe, testStateClosed)
-	verifySubcomponent(t, 10, sm.binlogDumper, testStateClosed)
-	verifySubcomponent(t, 11, sm.vstreamer, testStateClosed)
-	verifySubcomponent(t, 12, sm.rt, testStateClosed)
-	verifySubcomponent(t, 13, sm.se, testStateClosed)
+	verifySubcomponent(t, 2, sm.jobs, testStateClosed)
+	verifySubcomponent(t, 3, sm.colStats, testStateClosed)
+	verifySubcomponent(t, 4, sm.analyzer, testStateClosed)
+	verifySubcomponent(t, 5, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 6, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 7, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 8, sm.messager, testStateClosed)
+	verifySubcomponent(t, 9, sm.te, testStateClosed)
+	verifySubcomponent(t, 10, sm.tracker, testStateClosed)
+
+	verifySubcomponent(t, 11, sm.txThrottler, testStateClosed)
+	verifySubcomponent(t, 12, sm.qe, testStateClosed)
+	verifySubcomponent(t, 13, sm.binlogDumper, testStateClosed)
+	verifySubcomponent(t, 14, sm.vstreamer, testStateClosed)
+	verifySubcomponent(t, 15, sm.rt, testStateClosed)
+	verifySubcomponent(t, 16, sm.se, testStateClosed)
 
 	assert.Equal(t, topodatapb.TabletType_RDONLY, sm.target.TabletType)
 	assert.Equal(t, StateNotConnected, sm.state)
@@ -292,19 +342,22 @@ func TestStateManagerSetServingTypeNoChange(t *testing.T) {
 	require.NoError(t, err)
 
 	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.messager, testStateClosed)
-	verifySubcomponent(t, 4, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 2, sm.jobs, testStateClosed)
+	verifySubcomponent(t, 3, sm.colStats, testStateClosed)
+	verifySubcomponent(t, 4, sm.analyzer, testStateClosed)
+	verifySubcomponent(t, 5, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 6, sm.messager, testStateClosed)
+	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 5, sm.se, testStateOpen)
-	verifySubcomponent(t, 6, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 7, sm.binlogDumper, testStateOpen)
-	verifySubcomponent(t, 8, sm.qe, testStateOpen)
-	verifySubcomponent(t, 9, sm.txThrottler, testStateOpen)
-	verifySubcomponent(t, 10, sm.te, testStateNonPrimary)
-	verifySubcomponent(t, 11, sm.rt, testStateNonPrimary)
-	verifySubcomponent(t, 12, sm.throttler, testStateOpen)
+	verifySubcomponent(t, 8, sm.se, testStateOpen)
+	verifySubcomponent(t, 9, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 10, sm.binlogDumper, testStateOpen)
+	verifySubcomponent(t, 11, sm.qe, testStateOpen)
+	verifySubcomponent(t, 12, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 13, sm.te, testStateNonPrimary)
+	verifySubcomponent(t, 14, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 15, sm.throttler, testStateOpen)
 
 	assert.Equal(t, topodatapb.TabletType_REPLICA, sm.target.TabletType)
 	assert.Equal(t, StateServing, sm.state)
@@ -647,8 +700,9 @@ func TestStateManagerNotify(t *testing.T) {
 		Target: &querypb.Target{
 			TabletType: topodatapb.TabletType_REPLICA,
 		},
-		Serving:     true,
-		TabletAlias: &topodatapb.TabletAlias{},
+		Serving:      true,
+		TabletAlias:  &topodatapb.TabletAlias{},
+		Capabilities: queryservice.TabletCapabilities(),
 	}
 	sm.hcticks.Stop()
 	assert.Truef(t, proto.Equal(gotshr, wantshr), "got: %v, want: %v", gotshr, wantshr)
@@ -785,6 +839,9 @@ func newTestStateManager() *stateManager {
 		throttler:         &testLagThrottler{},
 		qThrottler:        &testQueryThrottler{},
 		tableGC:           &testTableGC{},
+		analyzer:          &testTableAnalyzer{},
+		colStats:          &testColumnStatisticsCollector{},
+		jobs:              &testJobEngine{},
 		rw:                newRequestsWaiter(),
 	}
 	sm.Init(env, &querypb.Target{})
@@ -896,6 +953,7 @@ type testQueryEngine struct {
 	testOrderState
 
 	failMySQL bool
+	warmUps   int
 }
 
 func (te *testQueryEngine) Open() error {
@@ -904,6 +962,11 @@ func (te *testQueryEngine) Open() error {
 	return nil
 }
 
+func (te *testQueryEngine) WarmUp(context.Context) error {
+	te.warmUps++
+	return nil
+}
+
 func (te *testQueryEngine) IsMySQLReachable() error {
 	if te.failMySQL {
 		te.failMySQL = false
@@ -1026,3 +1089,48 @@ func (te *testTableGC) Close() {
 	te.order = order.Add(1)
 	te.state = testStateClosed
 }
+
+type testTableAnalyzer struct {
+	testOrderState
+}
+
+func (te *testTableAnalyzer) Open() error {
+	te.order = order.Add(1)
+	te.state = testStateOpen
+	return nil
+}
+
+func (te *testTableAnalyzer) Close() {
+	te.order = order.Add(1)
+	te.state = testStateClosed
+}
+
+type testColumnStatisticsCollector struct {
+	testOrderState
+}
+
+func (te *testColumnStatisticsCollector) Open() error {
+	te.order = order.Add(1)
+	te.state = testStateOpen
+	return nil
+}
+
+func (te *testColumnStatisticsCollector) Close() {
+	te.order = order.Add(1)
+	te.state = testStateClosed
+}
+
+type testJobEngine struct {
+	testOrderState
+}
+
+func (te *testJobEngine) Open() error {
+	te.order = order.Add(1)
+	te.state = testStateOpen
+	return nil
+}
+
+func (te *testJobEngine) Close() {
+	te.order = order.Add(1)
+	te.state = testStateClosed
+}
@@ -19,6 +19,8 @@ package tabletserver
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
@@ -50,6 +52,10 @@ type StatefulConnection struct {
 	enforceTimeout bool
 	timeout        time.Duration
 	expiryTime     time.Time
+
+	// tempTables are the names of the temporary tables created on the
+	// connection. They only live as long as its MySQL session.
+	tempTables map[string]struct{}
 }
 
 // Properties contains meta information about the connection
@@ -112,10 +118,44 @@ func (sc *StatefulConnection) Exec(ctx context.Context, query string, maxrows in
 	return r, nil
 }
 
+// ExecResults executes the statement in the dedicated connection, and
+// returns all the results it returned.
+func (sc *StatefulConnection) ExecResults(ctx context.Context, query string, maxrows int, wantfields bool) ([]*sqltypes.Result, error) {
+	if sc.IsClosed() {
+		if sc.IsInTransaction() {
+			return nil, vterrors.Errorf(vtrpcpb.Code_ABORTED, "transaction was aborted: %v", sc.txProps.Conclusion)
+		}
+		return nil, vterrors.New(vtrpcpb.Code_ABORTED, "connection was aborted")
+	}
+	results, err := sc.dbConn.Conn.ExecResultsOnce(ctx, query, maxrows, wantfields)
+	if err != nil {
+		if sqlerror.IsConnErr(err) {
+			select {
+			case <-ctx.Done():
+				// If the context is done, the query was killed.
+				// So, don't trigger a mysql check.
+			default:
+				sc.env.CheckMySQL()
+			}
+		}
+		return nil, err
+	}
+	return results, nil
+}
+
 func (sc *StatefulConnection) execWithRetry(ctx context.Context, query string, maxrows int, wantfields bool) (string, error) {
 	if sc.IsClosed() {
 		return "", vterrors.New(vtrpcpb.Code_CANCELED, "connection is closed")
 	}
+	if sc.HasTempTables() {
+		// Reconnecting would silently drop the temporary tables, and the
+		// next statements would read and write the tables they shadow.
+		res, err := sc.dbConn.Conn.ExecOnce(ctx, query, maxrows, wantfields)
+		if err != nil {
+			return "", vterrors.Wrapf(err, "connection with temporary tables %v lost", sc.TempTables())
+		}
+		return res.SessionStateChanges, nil
+	}
 	res, err := sc.dbConn.Conn.Exec(ctx, query, maxrows, wantfields)
 	if err != nil {
 		return "", err
@@ -177,6 +217,11 @@ func (sc *StatefulConnection) ReleaseString(reason string) {
 	if sc.pool != nil {
 		sc.pool.unregister(sc.ConnID, reason)
 	}
+	if sc.HasTempTables() && !sc.tainted {
+		// The temporary tables must not outlive the connection in the pool.
+		sc.dbConn.Close()
+	}
+	sc.tempTables = nil
 	sc.dbConn.Recycle()
 	sc.dbConn = nil
 	sc.logReservedConn(reason)
@@ -268,6 +313,39 @@ func (sc *StatefulConnection) Taint(ctx context.Context, stats *servenv.TimingsW
 	return nil
 }
 
+// trackTempTables records the temporary tables stmt created or dropped on the
+// connection, once it was executed.
+func (sc *StatefulConnection) trackTempTables(stmt sqlparser.Statement) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.CreateTable:
+		if !stmt.Temp {
+			return
+		}
+		if sc.tempTables == nil {
+			sc.tempTables = make(map[string]struct{})
+		}
+		sc.tempTables[stmt.Table.Name.String()] = struct{}{}
+	case *sqlparser.DropTable:
+		// DROP TABLE drops the temporary table of a name rather than the
+		// table it shadows, even without TEMPORARY.
+		for _, table := range stmt.FromTables {
+			delete(sc.tempTables, table.Name.String())
+		}
+	}
+}
+
+// HasTempTables returns true if temporary tables were created on the
+// connection and not dropped since.
+func (sc *StatefulConnection) HasTempTables() bool {
+	return len(sc.tempTables) > 0
+}
+
+// TempTables returns the sorted names of the temporary tables of the
+// connection.
+func (sc *StatefulConnection) TempTables() []string {
+	return slices.Sorted(maps.Keys(sc.tempTables))
+}
+
 // IsTainted tells us whether this connection is tainted
 func (sc *StatefulConnection) IsTainted() bool {
 	return sc.tainted
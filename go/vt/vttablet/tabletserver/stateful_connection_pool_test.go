@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/vt/dbconfigs"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 )
 
@@ -233,3 +234,38 @@ func newActivePool() *StatefulConnectionPool {
 
 	return NewStatefulConnPool(env)
 }
+
+func TestStatefulConnectionTempTables(t *testing.T) {
+	ctx := t.Context()
+	db := fakesqldb.New(t)
+	defer db.Close()
+	pool := newActivePool()
+	params := dbconfigs.New(db.ConnParams())
+	pool.Open(params, params, params)
+	defer pool.Close()
+
+	conn, err := pool.NewConn(ctx, &querypb.ExecuteOptions{}, nil)
+	require.NoError(t, err)
+	for _, query := range []string{
+		"create temporary table t1 (id int)",
+		"create temporary table if not exists t2 (id int)",
+		"create temporary table t3 (id int)",
+		"create table t4 (id int)",
+		"drop temporary table t1",
+		// A DROP TABLE drops the temporary table of the name.
+		"drop table t3",
+	} {
+		stmt, err := sqlparser.NewTestParser().Parse(query)
+		require.NoError(t, err)
+		conn.trackTempTables(stmt)
+	}
+	assert.True(t, conn.HasTempTables())
+	assert.Equal(t, []string{"t2"}, conn.TempTables())
+
+	// The temporary tables are dropped with the connection rather than
+	// left in the pool.
+	dbConn := conn.UnderlyingDBConn()
+	conn.Release(tx.TxClose)
+	assert.True(t, dbConn.Conn.IsClosed())
+	assert.False(t, conn.HasTempTables())
+}
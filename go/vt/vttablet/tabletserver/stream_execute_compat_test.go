@@ -857,12 +857,13 @@ func TestStreamExecuteCompat_StreamDispatchIsTotal(t *testing.T) {
 
 	// The dispatch tables in Stream(), by path.
 	dispatched := map[planbuilder.PlanType]string{
-		planbuilder.PlanInsert:      "dml",
-		planbuilder.PlanUpdate:      "dml",
-		planbuilder.PlanUpdateLimit: "dml",
-		planbuilder.PlanDelete:      "dml",
-		planbuilder.PlanDeleteLimit: "dml",
-		planbuilder.PlanLoad:        "dml",
+		planbuilder.PlanInsert:          "dml",
+		planbuilder.PlanInsertReturning: "dml",
+		planbuilder.PlanUpdate:          "dml",
+		planbuilder.PlanUpdateLimit:     "dml",
+		planbuilder.PlanDelete:          "dml",
+		planbuilder.PlanDeleteLimit:     "dml",
+		planbuilder.PlanLoad:            "dml",
 
 		planbuilder.PlanNextval:             "dedicated",
 		planbuilder.PlanDDL:                 "dedicated",
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -128,6 +129,7 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&currentConfig.OlapReadPool.Size, "queryserver-config-stream-pool-size", defaultConfig.OlapReadPool.Size, "query server stream connection pool size, stream pool is used by stream queries: queries that return results to client in a streaming fashion")
 	fs.IntVar(&currentConfig.TxPool.Size, "queryserver-config-transaction-cap", defaultConfig.TxPool.Size, "query server transaction cap is the maximum number of transactions allowed to happen at any given point of a time for a single vttablet. E.g. by setting transaction cap to 100, there are at most 100 transactions will be processed by a vttablet and the 101th transaction will be blocked (and fail if it cannot get connection within specified timeout)")
 	fs.IntVar(&currentConfig.MessagePostponeParallelism, "queryserver-config-message-postpone-cap", defaultConfig.MessagePostponeParallelism, "query server message postpone cap is the maximum number of messages that can be postponed at any given time. Set this number to substantially lower than transaction cap, so that the transaction pool isn't exhausted by the message subsystem.")
+	fs.IntVar(&currentConfig.MessageBatchChunkSize, "queryserver-config-message-batch-chunk-size", defaultConfig.MessageBatchChunkSize, "query server message batch chunk size is the maximum number of messages acked or postponed in one transaction by the MessageAckBatch and MessagePostpone RPCs.")
 	fs.DurationVar(&currentConfig.Oltp.TxTimeout, "queryserver-config-transaction-timeout", defaultConfig.Oltp.TxTimeout, "query server transaction timeout, a transaction will be killed if it takes longer than this value")
 	utils.SetFlagDurationVar(fs, &currentConfig.GracePeriods.Shutdown, "shutdown-grace-period", defaultConfig.GracePeriods.Shutdown, "how long to wait for queries and transactions to complete during graceful shutdown.")
 	fs.IntVar(&currentConfig.Oltp.MaxRows, "queryserver-config-max-result-size", defaultConfig.Oltp.MaxRows, "query server max result size, maximum number of rows allowed to return from vttablet for non-streaming queries.")
@@ -143,6 +145,8 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&currentConfig.SignalWhenSchemaChange, "queryserver-config-schema-change-signal", defaultConfig.SignalWhenSchemaChange, "query server schema signal, will signal connected vtgates that schema has changed whenever this is detected. VTGates will need to have -schema-change-signal enabled for this to work")
 	fs.DurationVar(&currentConfig.Olap.TxTimeout, "queryserver-config-olap-transaction-timeout", defaultConfig.Olap.TxTimeout, "query server transaction timeout (in seconds), after which a transaction in an OLAP session will be killed")
 	fs.DurationVar(&currentConfig.Oltp.QueryTimeout, "queryserver-config-query-timeout", defaultConfig.Oltp.QueryTimeout, "query server query timeout, this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
+	fs.DurationVar(&currentConfig.Oltp.DMLTimeout, "queryserver-config-dml-timeout", defaultConfig.Oltp.DMLTimeout, "query server DML timeout, if an INSERT, UPDATE or DELETE takes more than this timeout, it will be killed. If set to 0 (default) then the query timeout is used instead.")
+	fs.DurationVar(&currentConfig.Olap.QueryTimeout, "queryserver-config-olap-query-timeout", defaultConfig.Olap.QueryTimeout, "query server OLAP query timeout, if a streaming read takes more than this timeout, its query will be killed without closing the connection. If set to 0 (default) then streaming reads have no timeout.")
 	fs.DurationVar(&currentConfig.OltpReadPool.Timeout, "queryserver-config-query-pool-timeout", defaultConfig.OltpReadPool.Timeout, "query server query pool timeout, it is how long vttablet waits for a connection from the query pool. If set to 0 (default) then the overall query timeout is used instead.")
 	fs.DurationVar(&currentConfig.OlapReadPool.Timeout, "queryserver-config-stream-pool-timeout", defaultConfig.OlapReadPool.Timeout, "query server stream pool timeout, it is how long vttablet waits for a connection from the stream pool. If set to 0 (default) then there is no timeout.")
 	fs.DurationVar(&currentConfig.TxPool.Timeout, "queryserver-config-txpool-timeout", defaultConfig.TxPool.Timeout, "query server transaction pool timeout, it is how long vttablet waits if tx pool is full")
@@ -163,12 +167,14 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&currentConfig.TruncateErrorLen, "queryserver-config-truncate-error-len", defaultConfig.TruncateErrorLen, "truncate errors sent to client if they are longer than this value (0 means do not truncate)")
 	fs.BoolVar(&currentConfig.AnnotateQueries, "queryserver-config-annotate-queries", defaultConfig.AnnotateQueries, "prefix queries to MySQL backend with comment indicating vtgate principal (user) and target tablet type")
 	utils.SetFlagBoolVar(fs, &currentConfig.TrackSchemaVersions, "track-schema-versions", false, "When enabled, vttablet will store versions of schemas at each position that a DDL is applied and allow retrieval of the schema corresponding to a position")
+	fs.BoolVar(&currentConfig.TrackTableReplicationLag, "track-table-replication-lag", defaultConfig.TrackTableReplicationLag, "When enabled, a replica vttablet streams its binlog to measure the apply lag of every table, and exports it in the TableReplicationApplyLag metric.")
 	fs.Int64Var(&currentConfig.SchemaVersionMaxAgeSeconds, "schema-version-max-age-seconds", 0, "max age of schema version records to kept in memory by the vreplication historian")
 
 	_ = fs.Bool("twopc-enable", true, "TwoPC is enabled")
 	_ = fs.MarkDeprecated("twopc-enable", "TwoPC is always enabled, the transaction abandon age can be configured")
 	utils.SetFlagFloatDurationVar(fs, &currentConfig.TwoPCAbandonAge, "twopc-abandon-age", defaultConfig.TwoPCAbandonAge,
 		"Any unresolved transaction older than this time will be sent to the coordinator to be resolved. NOTE: Providing time as seconds (float64) is deprecated. Use time.Duration format (e.g., '1s', '2m', '1h').")
+	fs.DurationVar(&currentConfig.DMLJournalRetention, "dml-journal-retention", defaultConfig.DMLJournalRetention, "How long the idempotency keys of autocommit DMLs are kept in the dml_journal sidecar table. A DML retried with the same idempotency key within this time is not executed again. If 0, the keys are never purged.")
 
 	// Tx throttler config
 	utils.SetFlagBoolVar(fs, &currentConfig.EnableTxThrottler, "enable-tx-throttler", defaultConfig.EnableTxThrottler, "If true replication-lag-based throttling on transactions will be enabled.")
@@ -220,13 +226,47 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	utils.SetFlagInt64Var(fs, &currentConfig.RowStreamer.MaxMySQLReplLagSecs, "vreplication-copy-phase-max-mysql-replication-lag", 43200, "The maximum MySQL replication lag (in seconds) that can exist on a vstreamer (source) before starting another round of copying rows. This helps to limit the impact on the source tablet")
 
 	fs.BoolVar(&currentConfig.EnableViews, "queryserver-enable-views", false, "Enable views support in vttablet.")
+	fs.BoolVar(&currentConfig.EnableResultExport, "queryserver-enable-result-export", false, "Allow streaming queries to export their results to the backup storage of the tablet instead of returning them.")
 
 	fs.BoolVar(&currentConfig.EnablePerWorkloadTableMetrics, "enable-per-workload-table-metrics", defaultConfig.EnablePerWorkloadTableMetrics, "If true, query counts and query error metrics include a label that identifies the workload")
 	fs.BoolVar(&currentConfig.SkipUserMetrics, "skip-user-metrics", defaultConfig.SkipUserMetrics, "If true, user based stats are not recorded.")
+	utils.SetFlagIntVar(fs, &currentConfig.TableMetricsMaxCardinality, "table-metrics-max-cardinality", defaultConfig.TableMetricsMaxCardinality, "Maximum number of label combinations tracked by each of the query metrics labeled by table, such as QueryCounts, UserTableQueryCount and TableACLAllowed. Queries with new label combinations are counted under the \"other\" label values once it is reached. 0 means no limit.")
 
 	fs.DurationVar(&queryThrottlerConfigRefreshInterval, "query-throttler-config-refresh-interval", time.Minute, "How frequently to refresh configuration for the query throttler")
 
 	fs.BoolVar(&currentConfig.Unmanaged, "unmanaged", false, "Indicates an unmanaged tablet, i.e. using an external mysql-compatible database")
+
+	fs.BoolVar(&currentConfig.PlanCapture.Enable, "plan-capture-enable", defaultConfig.PlanCapture.Enable, "If true, vttablet logs the MySQL EXPLAIN output for a sample of the queries whose latency exceeds --plan-capture-percentile.")
+	fs.Float64Var(&currentConfig.PlanCapture.Percentile, "plan-capture-percentile", defaultConfig.PlanCapture.Percentile, "Latency percentile of recently executed queries above which a query is considered an outlier for plan capture.")
+	fs.DurationVar(&currentConfig.PlanCapture.MinLatency, "plan-capture-min-latency", defaultConfig.PlanCapture.MinLatency, "Minimum latency of a query for its plan to be captured, regardless of the latency percentile.")
+	fs.Float64Var(&currentConfig.PlanCapture.SampleRate, "plan-capture-sample-rate", defaultConfig.PlanCapture.SampleRate, "Fraction of the outlier queries for which the plan is captured.")
+	fs.BoolVar(&currentConfig.PlanCapture.Analyze, "plan-capture-analyze", defaultConfig.PlanCapture.Analyze, "If true, plans of SELECT queries are captured with EXPLAIN ANALYZE, which executes the query again.")
+	fs.DurationVar(&currentConfig.PlanCapture.Timeout, "plan-capture-timeout", defaultConfig.PlanCapture.Timeout, "Timeout for capturing the plan of a single query.")
+
+	fs.StringVar(&currentConfig.PlanRollout.Planner, "plan-rollout-planner", defaultConfig.PlanRollout.Planner, "Name of a candidate query planner to compare with the serving planner. A sample of the planned queries are also planned by the candidate, and the plans that differ are logged without affecting the served queries. Empty disables the comparison.")
+	fs.Float64Var(&currentConfig.PlanRollout.SampleRate, "plan-rollout-sample-rate", defaultConfig.PlanRollout.SampleRate, "Fraction of the planned queries that are also planned by --plan-rollout-planner.")
+
+	fs.BoolVar(&currentConfig.HotspotDetection.Enable, "hotspot-detection-enable", defaultConfig.HotspotDetection.Enable, "If true, vttablet samples the primary key values of the executed queries and reports the hottest key ranges of each table in /debug/hotspots.")
+	fs.Float64Var(&currentConfig.HotspotDetection.SampleRate, "hotspot-detection-sample-rate", defaultConfig.HotspotDetection.SampleRate, "Fraction of the executed queries whose primary key values are sampled for hotspot detection.")
+	fs.IntVar(&currentConfig.HotspotDetection.TopN, "hotspot-detection-top-n", defaultConfig.HotspotDetection.TopN, "Number of hottest key ranges reported for each table by hotspot detection.")
+
+	fs.BoolVar(&currentConfig.AnalyzeTable.Enable, "analyze-table-enable", defaultConfig.AnalyzeTable.Enable, "If true, the primary runs ANALYZE TABLE on tables whose row count estimate drifted by more than --analyze-table-drift-threshold since they were last analyzed.")
+	fs.DurationVar(&currentConfig.AnalyzeTable.CheckInterval, "analyze-table-check-interval", defaultConfig.AnalyzeTable.CheckInterval, "Interval between checks for tables that need to be analyzed.")
+	fs.Float64Var(&currentConfig.AnalyzeTable.DriftThreshold, "analyze-table-drift-threshold", defaultConfig.AnalyzeTable.DriftThreshold, "Fraction by which the row count estimate of a table must change since it was last analyzed for the table to be analyzed again.")
+	fs.Int64Var(&currentConfig.AnalyzeTable.MinRows, "analyze-table-min-rows", defaultConfig.AnalyzeTable.MinRows, "Tables with fewer estimated rows than this are never analyzed automatically.")
+	fs.StringVar(&currentConfig.AnalyzeTable.MaintenanceWindow, "analyze-table-maintenance-window", defaultConfig.AnalyzeTable.MaintenanceWindow, "Daily time window in UTC, as HH:MM-HH:MM, outside of which tables are not analyzed automatically. The window may wrap around midnight. If empty, tables are analyzed at any time.")
+
+	utils.SetFlagStringSliceVar(fs, &currentConfig.ColumnStatistics.Tables, "column-statistics-tables", defaultConfig.ColumnStatistics.Tables, "A comma-separated list of tables whose column statistics are estimated by the primary from a sample of their rows: the number of distinct values and the fraction of NULLs of each column. vtgate only uses them to choose between join orders of the same cost. If empty, no statistics are collected.")
+	fs.DurationVar(&currentConfig.ColumnStatistics.Interval, "column-statistics-interval", defaultConfig.ColumnStatistics.Interval, "Interval between two collections of the column statistics of --column-statistics-tables.")
+	fs.Int64Var(&currentConfig.ColumnStatistics.SampleRows, "column-statistics-sample-rows", defaultConfig.ColumnStatistics.SampleRows, "Number of rows of each table the column statistics are estimated from. They are read from random ranges of the primary key of the table if it is an integer, or from the start of the table otherwise.")
+
+	fs.BoolVar(&currentConfig.PreparedStatements.Enable, "prepared-statements-enable", defaultConfig.PreparedStatements.Enable, "If true, SELECT queries repeated on a query pool connection are executed as server-side prepared statements, which MySQL does not need to parse again. Queries can opt out with the SKIP_PREPARED_STATEMENT comment directive.")
+	fs.IntVar(&currentConfig.PreparedStatements.CacheSize, "prepared-statements-cache-size", defaultConfig.PreparedStatements.CacheSize, "Maximum number of queries whose prepared statements are cached on each query pool connection. The statement of the least recently executed query is closed when the cache is full.")
+	fs.BoolVar(&currentConfig.ResetConnection, "queryserver-reset-connection", defaultConfig.ResetConnection, "If true, the session settings of a pooled connection are cleared with COM_RESET_CONNECTION, which MySQL 5.7.3 and above support, instead of a SET statement or a reconnect. The reset also drops the temporary tables, user variables and prepared statements of the session.")
+
+	fs.IntVar(&currentConfig.RestoreWarmup.Connections, "restore-warmup-connections", defaultConfig.RestoreWarmup.Connections, "Number of connections to pre-open in each of the query and stream pools after a restore, before the tablet starts serving. It is capped at the size of each pool. If set to 0 (default) then no connections are pre-opened.")
+	fs.StringVar(&currentConfig.RestoreWarmup.QueriesFile, "restore-warmup-queries-file", defaultConfig.RestoreWarmup.QueriesFile, "Path to a file of semicolon-separated queries to run on the query pool after a restore, before the tablet starts serving, to warm up the MySQL caches. Results are discarded.")
+	fs.DurationVar(&currentConfig.RestoreWarmup.Timeout, "restore-warmup-timeout", defaultConfig.RestoreWarmup.Timeout, "Maximum time spent warming up the query pools after a restore, at most 2m. The tablet starts serving once it is reached, even if the warm-up is not complete.")
 }
 
 var (
@@ -348,11 +388,13 @@ type TabletConfig struct {
 	SchemaReloadInterval        time.Duration `json:"schemaReloadIntervalSeconds,omitempty"`
 	SchemaChangeReloadTimeout   time.Duration `json:"schemaChangeReloadTimeout,omitempty"`
 	TrackSchemaVersions         bool          `json:"trackSchemaVersions,omitempty"`
+	TrackTableReplicationLag    bool          `json:"trackTableReplicationLag,omitempty"`
 	SchemaVersionMaxAgeSeconds  int64         `json:"schemaVersionMaxAgeSeconds,omitempty"`
 	TerseErrors                 bool          `json:"terseErrors,omitempty"`
 	TruncateErrorLen            int           `json:"truncateErrorLen,omitempty"`
 	AnnotateQueries             bool          `json:"annotateQueries,omitempty"`
 	MessagePostponeParallelism  int           `json:"messagePostponeParallelism,omitempty"`
+	MessageBatchChunkSize       int           `json:"messageBatchChunkSize,omitempty"`
 	SignalWhenSchemaChange      bool          `json:"signalWhenSchemaChange,omitempty"`
 
 	ExternalConnections map[string]*dbconfigs.DBConfigs `json:"externalConnections,omitempty"`
@@ -362,6 +404,7 @@ type TabletConfig struct {
 	EnableTableACLDryRun bool          `json:"-"`
 	TableACLExemptACL    string        `json:"-"`
 	TwoPCAbandonAge      time.Duration `json:"-"`
+	DMLJournalRetention  time.Duration `json:"-"`
 
 	EnableTxThrottler              bool                          `json:"-"`
 	TxThrottlerConfig              *TxThrottlerConfigFlag        `json:"-"`
@@ -375,15 +418,32 @@ type TabletConfig struct {
 
 	TransactionLimitConfig `json:"-"`
 
+	PlanCapture PlanCaptureConfig `json:"-"`
+
+	PlanRollout PlanRolloutConfig `json:"-"`
+
+	HotspotDetection HotspotDetectionConfig `json:"-"`
+
+	AnalyzeTable AnalyzeTableConfig `json:"-"`
+
+	ColumnStatistics ColumnStatisticsConfig `json:"-"`
+
+	RestoreWarmup RestoreWarmupConfig `json:"-"`
+
+	PreparedStatements PreparedStatementsConfig `json:"-"`
+	ResetConnection    bool                     `json:"-"`
+
 	EnforceStrictTransTables bool `json:"-"`
 	EnableOnlineDDL          bool `json:"-"`
 
 	RowStreamer RowStreamerConfig `json:"rowStreamer"`
 
-	EnableViews bool `json:"-"`
+	EnableViews        bool `json:"-"`
+	EnableResultExport bool `json:"-"`
 
 	EnablePerWorkloadTableMetrics       bool          `json:"-"`
 	SkipUserMetrics                     bool          `json:"-"`
+	TableMetricsMaxCardinality          int           `json:"-"`
 	QueryThrottlerConfigRefreshInterval time.Duration `json:"-"`
 }
 
@@ -528,7 +588,8 @@ func (cfg *ConnPoolConfig) UnmarshalJSON(data []byte) (err error) {
 
 // OlapConfig contains the config for olap settings.
 type OlapConfig struct {
-	TxTimeout time.Duration `json:"txTimeoutSeconds,omitempty"`
+	QueryTimeout time.Duration `json:"queryTimeoutSeconds,omitempty"`
+	TxTimeout    time.Duration `json:"txTimeoutSeconds,omitempty"`
 }
 
 func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
@@ -536,11 +597,16 @@ func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
 
 	tmp := struct {
 		Proxy
-		TxTimeoutSeconds string `json:"txTimeoutSeconds,omitempty"`
+		QueryTimeoutSeconds string `json:"queryTimeoutSeconds,omitempty"`
+		TxTimeoutSeconds    string `json:"txTimeoutSeconds,omitempty"`
 	}{
 		Proxy: Proxy(*cfg),
 	}
 
+	if d := cfg.QueryTimeout; d != 0 {
+		tmp.QueryTimeoutSeconds = d.String()
+	}
+
 	if d := cfg.TxTimeout; d != 0 {
 		tmp.TxTimeoutSeconds = d.String()
 	}
@@ -550,13 +616,21 @@ func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
 
 func (cfg *OlapConfig) UnmarshalJSON(data []byte) (err error) {
 	var tmp struct {
-		TxTimeout string `json:"txTimeoutSeconds,omitempty"`
+		QueryTimeout string `json:"queryTimeoutSeconds,omitempty"`
+		TxTimeout    string `json:"txTimeoutSeconds,omitempty"`
 	}
 
 	if err = json.Unmarshal(data, &tmp); err != nil {
 		return err
 	}
 
+	if tmp.QueryTimeout != "" {
+		cfg.QueryTimeout, err = time.ParseDuration(tmp.QueryTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	if tmp.TxTimeout != "" {
 		cfg.TxTimeout, err = time.ParseDuration(tmp.TxTimeout)
 		if err != nil {
@@ -570,6 +644,7 @@ func (cfg *OlapConfig) UnmarshalJSON(data []byte) (err error) {
 // OltpConfig contains the config for oltp settings.
 type OltpConfig struct {
 	QueryTimeout time.Duration `json:"queryTimeoutSeconds,omitempty"`
+	DMLTimeout   time.Duration `json:"dmlTimeoutSeconds,omitempty"`
 	TxTimeout    time.Duration `json:"txTimeoutSeconds,omitempty"`
 	MaxRows      int           `json:"maxRows,omitempty"`
 	WarnRows     int           `json:"warnRows,omitempty"`
@@ -581,6 +656,7 @@ func (cfg *OltpConfig) MarshalJSON() ([]byte, error) {
 	tmp := struct {
 		Proxy
 		QueryTimeout string `json:"queryTimeoutSeconds,omitempty"`
+		DMLTimeout   string `json:"dmlTimeoutSeconds,omitempty"`
 		TxTimeout    string `json:"txTimeoutSeconds,omitempty"`
 	}{
 		Proxy: Proxy(*cfg),
@@ -590,6 +666,10 @@ func (cfg *OltpConfig) MarshalJSON() ([]byte, error) {
 		tmp.QueryTimeout = d.String()
 	}
 
+	if d := cfg.DMLTimeout; d != 0 {
+		tmp.DMLTimeout = d.String()
+	}
+
 	if d := cfg.TxTimeout; d != 0 {
 		tmp.TxTimeout = d.String()
 	}
@@ -601,6 +681,7 @@ func (cfg *OltpConfig) UnmarshalJSON(data []byte) (err error) {
 	var tmp struct {
 		OltpConfig
 		QueryTimeout string `json:"queryTimeoutSeconds,omitempty"`
+		DMLTimeout   string `json:"dmlTimeoutSeconds,omitempty"`
 		TxTimeout    string `json:"txTimeoutSeconds,omitempty"`
 	}
 
@@ -615,6 +696,13 @@ func (cfg *OltpConfig) UnmarshalJSON(data []byte) (err error) {
 		}
 	}
 
+	if tmp.DMLTimeout != "" {
+		cfg.DMLTimeout, err = time.ParseDuration(tmp.DMLTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	if tmp.TxTimeout != "" {
 		cfg.TxTimeout, err = time.ParseDuration(tmp.TxTimeout)
 		if err != nil {
@@ -855,6 +943,122 @@ type TransactionLimitConfig struct {
 	TransactionLimitBySubcomponent bool
 }
 
+// PlanCaptureConfig contains the config for capturing the execution plan
+// of slow queries.
+type PlanCaptureConfig struct {
+	Enable     bool
+	Percentile float64
+	MinLatency time.Duration
+	SampleRate float64
+	Analyze    bool
+	Timeout    time.Duration
+}
+
+// PlanRolloutConfig contains the config for comparing the plans of a
+// candidate query planner with the ones of the serving planner.
+type PlanRolloutConfig struct {
+	Planner    string
+	SampleRate float64
+}
+
+// HotspotDetectionConfig contains the config for sampling the primary key
+// values of the executed queries to find the hottest key ranges.
+type HotspotDetectionConfig struct {
+	Enable     bool
+	SampleRate float64
+	TopN       int
+}
+
+// AnalyzeTableConfig contains the config for automatically refreshing the
+// statistics of tables whose row count estimate drifted.
+type AnalyzeTableConfig struct {
+	Enable            bool
+	CheckInterval     time.Duration
+	DriftThreshold    float64
+	MinRows           int64
+	MaintenanceWindow string
+}
+
+// ColumnStatisticsConfig contains the config for sampling the column
+// statistics of tables.
+type ColumnStatisticsConfig struct {
+	Tables     []string
+	Interval   time.Duration
+	SampleRows int64
+}
+
+// InMaintenanceWindow returns true if tables can be analyzed at the given
+// time. It is always true when no maintenance window is configured.
+func (cfg AnalyzeTableConfig) InMaintenanceWindow(t time.Time) bool {
+	start, end, err := parseMaintenanceWindow(cfg.MaintenanceWindow)
+	if err != nil {
+		return false
+	}
+	if start == end {
+		// No maintenance window.
+		return true
+	}
+	t = t.UTC()
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if start < end {
+		return now >= start && now < end
+	}
+	// The window wraps around midnight.
+	return now >= start || now < end
+}
+
+// parseMaintenanceWindow parses a HH:MM-HH:MM window into offsets from
+// midnight. An empty window returns equal offsets.
+func parseMaintenanceWindow(window string) (start, end time.Duration, err error) {
+	if window == "" {
+		return 0, 0, nil
+	}
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", window)
+	}
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", window)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	if start, err = parse(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parse(to); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("invalid maintenance window %q, start and end are the same", window)
+	}
+	return start, end, nil
+}
+
+// maxRestoreWarmupTimeout is the longest a warm-up may delay serving.
+const maxRestoreWarmupTimeout = 2 * time.Minute
+
+// RestoreWarmupConfig contains the config for warming up the query pools
+// after a restore, before the tablet starts serving.
+type RestoreWarmupConfig struct {
+	Connections int
+	QueriesFile string
+	Timeout     time.Duration
+}
+
+// Enabled returns true if there is anything to warm up.
+func (cfg RestoreWarmupConfig) Enabled() bool {
+	return cfg.Connections > 0 || cfg.QueriesFile != ""
+}
+
+// PreparedStatementsConfig contains the config for executing queries as
+// server-side prepared statements on the query pool connections.
+type PreparedStatementsConfig struct {
+	Enable    bool
+	CacheSize int
+}
+
 // RowStreamerConfig contains configuration parameters for a vstreamer (source) that is
 // copying the contents of a table to a target
 type RowStreamerConfig struct {
@@ -917,6 +1121,30 @@ func (c *TabletConfig) Verify() error {
 	if err := c.verifyTxThrottlerConfig(); err != nil {
 		return err
 	}
+	if err := c.verifyPlanCaptureConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyPlanRolloutConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyHotspotDetectionConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyRestoreWarmupConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyPreparedStatementsConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyAnalyzeTableConfig(); err != nil {
+		return err
+	}
+	if err := c.verifyColumnStatisticsConfig(); err != nil {
+		return err
+	}
+	if v := c.DMLJournalRetention; v < 0 {
+		return fmt.Errorf("--dml-journal-retention must be >= 0 (specified value: %v)", v)
+	}
 	if v := c.HotRowProtection.MaxQueueSize; v <= 0 {
 		return fmt.Errorf("--hot-row-protection-max-queue-size must be > 0 (specified value: %v)", v)
 	}
@@ -929,6 +1157,9 @@ func (c *TabletConfig) Verify() error {
 	if v := c.HotRowProtection.MaxConcurrency; v <= 0 {
 		return fmt.Errorf("--hot-row-protection-concurrent-transactions must be > 0 (specified value: %v)", v)
 	}
+	if v := c.MessageBatchChunkSize; v <= 0 {
+		return fmt.Errorf("--queryserver-config-message-batch-chunk-size must be > 0 (specified value: %v)", v)
+	}
 	return nil
 }
 
@@ -986,6 +1217,109 @@ func (c *TabletConfig) checkConnectionForExternalMysql() error {
 	return conn.Ping()
 }
 
+// verifyPlanCaptureConfig checks PlanCaptureConfig for sanity
+func (c *TabletConfig) verifyPlanCaptureConfig() error {
+	if !c.PlanCapture.Enable {
+		return nil
+	}
+	if v := c.PlanCapture.Percentile; v <= 0 || v >= 100 {
+		return fmt.Errorf("--plan-capture-percentile must be > 0 and < 100 (specified value: %v)", v)
+	}
+	if v := c.PlanCapture.SampleRate; v <= 0 || v > 1 {
+		return fmt.Errorf("--plan-capture-sample-rate must be > 0 and <= 1 (specified value: %v)", v)
+	}
+	if v := c.PlanCapture.Timeout; v <= 0 {
+		return fmt.Errorf("--plan-capture-timeout must be > 0 (specified value: %v)", v)
+	}
+	return nil
+}
+
+// verifyPlanRolloutConfig checks PlanRolloutConfig for sanity
+func (c *TabletConfig) verifyPlanRolloutConfig() error {
+	if c.PlanRollout.Planner == "" {
+		return nil
+	}
+	if v := c.PlanRollout.SampleRate; v <= 0 || v > 1 {
+		return fmt.Errorf("--plan-rollout-sample-rate must be > 0 and <= 1 (specified value: %v)", v)
+	}
+	return nil
+}
+
+// verifyHotspotDetectionConfig checks HotspotDetectionConfig for sanity
+func (c *TabletConfig) verifyHotspotDetectionConfig() error {
+	if !c.HotspotDetection.Enable {
+		return nil
+	}
+	if v := c.HotspotDetection.SampleRate; v <= 0 || v > 1 {
+		return fmt.Errorf("--hotspot-detection-sample-rate must be > 0 and <= 1 (specified value: %v)", v)
+	}
+	if v := c.HotspotDetection.TopN; v <= 0 {
+		return fmt.Errorf("--hotspot-detection-top-n must be > 0 (specified value: %v)", v)
+	}
+	return nil
+}
+
+// verifyAnalyzeTableConfig checks AnalyzeTableConfig for sanity
+func (c *TabletConfig) verifyAnalyzeTableConfig() error {
+	if !c.AnalyzeTable.Enable {
+		return nil
+	}
+	if v := c.AnalyzeTable.CheckInterval; v <= 0 {
+		return fmt.Errorf("--analyze-table-check-interval must be > 0 (specified value: %v)", v)
+	}
+	if v := c.AnalyzeTable.DriftThreshold; v <= 0 {
+		return fmt.Errorf("--analyze-table-drift-threshold must be > 0 (specified value: %v)", v)
+	}
+	if v := c.AnalyzeTable.MinRows; v < 0 {
+		return fmt.Errorf("--analyze-table-min-rows must be >= 0 (specified value: %v)", v)
+	}
+	if _, _, err := parseMaintenanceWindow(c.AnalyzeTable.MaintenanceWindow); err != nil {
+		return fmt.Errorf("--analyze-table-maintenance-window: %w", err)
+	}
+	return nil
+}
+
+// verifyColumnStatisticsConfig checks ColumnStatisticsConfig for sanity
+func (c *TabletConfig) verifyColumnStatisticsConfig() error {
+	if len(c.ColumnStatistics.Tables) == 0 {
+		return nil
+	}
+	if v := c.ColumnStatistics.Interval; v <= 0 {
+		return fmt.Errorf("--column-statistics-interval must be > 0 (specified value: %v)", v)
+	}
+	if v := c.ColumnStatistics.SampleRows; v <= 0 {
+		return fmt.Errorf("--column-statistics-sample-rows must be > 0 (specified value: %v)", v)
+	}
+	return nil
+}
+
+// verifyPreparedStatementsConfig checks PreparedStatementsConfig for sanity
+func (c *TabletConfig) verifyPreparedStatementsConfig() error {
+	if !c.PreparedStatements.Enable {
+		return nil
+	}
+	if v := c.PreparedStatements.CacheSize; v <= 0 {
+		return fmt.Errorf("--prepared-statements-cache-size must be > 0 (specified value: %v)", v)
+	}
+	return nil
+}
+
+// verifyRestoreWarmupConfig checks RestoreWarmupConfig for sanity
+func (c *TabletConfig) verifyRestoreWarmupConfig() error {
+	if v := c.RestoreWarmup.Connections; v < 0 {
+		return fmt.Errorf("--restore-warmup-connections must be >= 0 (specified value: %v)", v)
+	}
+	if !c.RestoreWarmup.Enabled() {
+		return nil
+	}
+	// The warm-up holds up the state transition of the tablet, so it must
+	// not delay a reparent or a shutdown for long.
+	if v := c.RestoreWarmup.Timeout; v <= 0 || v > maxRestoreWarmupTimeout {
+		return fmt.Errorf("--restore-warmup-timeout must be > 0 and <= %v (specified value: %v)", maxRestoreWarmupTimeout, v)
+	}
+	return nil
+}
+
 // verifyTransactionLimitConfig checks TransactionLimitConfig for sanity
 func (c *TabletConfig) verifyTransactionLimitConfig() error {
 	actual, dryRun := c.EnableTransactionLimit, c.EnableTransactionLimitDryRun
@@ -1116,6 +1450,7 @@ var defaultConfig = TabletConfig{
 	// Therefore, the default value should be generous to ensure completion.
 	SchemaChangeReloadTimeout:  30 * time.Second,
 	MessagePostponeParallelism: 4,
+	MessageBatchChunkSize:      1000,
 	SignalWhenSchemaChange:     true,
 
 	EnableTxThrottler:              false,
@@ -1128,6 +1463,46 @@ var defaultConfig = TabletConfig{
 
 	TransactionLimitConfig: defaultTransactionLimitConfig(),
 
+	PlanCapture: PlanCaptureConfig{
+		Enable:     false,
+		Percentile: 99,
+		MinLatency: 100 * time.Millisecond,
+		SampleRate: 0.01,
+		Analyze:    false,
+		Timeout:    10 * time.Second,
+	},
+
+	PlanRollout: PlanRolloutConfig{
+		SampleRate: 0.01,
+	},
+
+	HotspotDetection: HotspotDetectionConfig{
+		Enable:     false,
+		SampleRate: 0.01,
+		TopN:       10,
+	},
+
+	AnalyzeTable: AnalyzeTableConfig{
+		Enable:         false,
+		CheckInterval:  time.Hour,
+		DriftThreshold: 0.2,
+		MinRows:        10000,
+	},
+
+	ColumnStatistics: ColumnStatisticsConfig{
+		Interval:   time.Hour,
+		SampleRows: 100000,
+	},
+
+	RestoreWarmup: RestoreWarmupConfig{
+		Timeout: 30 * time.Second,
+	},
+
+	PreparedStatements: PreparedStatementsConfig{
+		Enable:    false,
+		CacheSize: 100,
+	},
+
 	EnforceStrictTransTables: true,
 	EnableOnlineDDL:          true,
 	EnableTableGC:            true,
@@ -1141,6 +1516,8 @@ var defaultConfig = TabletConfig{
 
 	TwoPCAbandonAge: 15 * time.Minute,
 
+	DMLJournalRetention: 24 * time.Hour,
+
 	QueryThrottlerConfigRefreshInterval: time.Minute,
 }
 
@@ -145,6 +145,7 @@ hotRowProtection:
   maxGlobalQueueSize: 1000
   maxQueueSize: 20
   mode: disable
+messageBatchChunkSize: 1000
 messagePostponeParallelism: 4
 olap:
   txTimeoutSeconds: 30s
@@ -523,3 +524,84 @@ func TestVerifyUnmanagedTabletConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, config.DB.App.Password)
 }
+
+func TestVerifyAnalyzeTableConfig(t *testing.T) {
+	config := defaultConfig
+	require.NoError(t, config.verifyAnalyzeTableConfig())
+
+	config.AnalyzeTable.Enable = true
+	require.NoError(t, config.verifyAnalyzeTableConfig())
+
+	config.AnalyzeTable.MaintenanceWindow = "22:00-04:30"
+	require.NoError(t, config.verifyAnalyzeTableConfig())
+
+	config.AnalyzeTable.MaintenanceWindow = "22:00"
+	require.EqualError(t, config.verifyAnalyzeTableConfig(), `--analyze-table-maintenance-window: invalid maintenance window "22:00", expected HH:MM-HH:MM`)
+
+	config.AnalyzeTable.MaintenanceWindow = "22:00-22:00"
+	require.EqualError(t, config.verifyAnalyzeTableConfig(), `--analyze-table-maintenance-window: invalid maintenance window "22:00-22:00", start and end are the same`)
+
+	config.AnalyzeTable.MaintenanceWindow = ""
+	config.AnalyzeTable.DriftThreshold = 0
+	require.EqualError(t, config.verifyAnalyzeTableConfig(), "--analyze-table-drift-threshold must be > 0 (specified value: 0)")
+}
+
+func TestVerifyColumnStatisticsConfig(t *testing.T) {
+	config := defaultConfig
+	require.NoError(t, config.verifyColumnStatisticsConfig())
+
+	config.ColumnStatistics.Tables = []string{"t1"}
+	require.NoError(t, config.verifyColumnStatisticsConfig())
+
+	config.ColumnStatistics.SampleRows = 0
+	require.EqualError(t, config.verifyColumnStatisticsConfig(), "--column-statistics-sample-rows must be > 0 (specified value: 0)")
+}
+
+func TestVerifyPreparedStatementsConfig(t *testing.T) {
+	config := defaultConfig
+	require.NoError(t, config.verifyPreparedStatementsConfig())
+
+	config.PreparedStatements.Enable = true
+	require.NoError(t, config.verifyPreparedStatementsConfig())
+
+	config.PreparedStatements.CacheSize = 0
+	require.EqualError(t, config.verifyPreparedStatementsConfig(), "--prepared-statements-cache-size must be > 0 (specified value: 0)")
+}
+
+func TestVerifyRestoreWarmupConfig(t *testing.T) {
+	config := defaultConfig
+	require.NoError(t, config.verifyRestoreWarmupConfig())
+
+	config.RestoreWarmup.Connections = 4
+	require.NoError(t, config.verifyRestoreWarmupConfig())
+
+	config.RestoreWarmup.Timeout = 10 * time.Minute
+	require.EqualError(t, config.verifyRestoreWarmupConfig(), "--restore-warmup-timeout must be > 0 and <= 2m0s (specified value: 10m0s)")
+}
+
+func TestAnalyzeTableInMaintenanceWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tcases := []struct {
+		window string
+		time   time.Time
+		want   bool
+	}{
+		{window: "", time: at(12, 0), want: true},
+		{window: "01:00-05:00", time: at(0, 59), want: false},
+		{window: "01:00-05:00", time: at(1, 0), want: true},
+		{window: "01:00-05:00", time: at(4, 59), want: true},
+		{window: "01:00-05:00", time: at(5, 0), want: false},
+		{window: "22:00-02:00", time: at(23, 30), want: true},
+		{window: "22:00-02:00", time: at(1, 30), want: true},
+		{window: "22:00-02:00", time: at(12, 0), want: false},
+		{window: "invalid", time: at(12, 0), want: false},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.window+"@"+tcase.time.Format("15:04"), func(t *testing.T) {
+			cfg := AnalyzeTableConfig{MaintenanceWindow: tcase.window}
+			assert.Equal(t, tcase.want, cfg.InMaintenanceWindow(tcase.time))
+		})
+	}
+}
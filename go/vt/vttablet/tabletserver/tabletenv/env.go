@@ -49,10 +49,14 @@ type testEnv struct {
 // without an actual TabletServer.
 func NewEnv(env *vtenv.Environment, config *TabletConfig, exporterName string) Env {
 	exporter := servenv.NewExporter(exporterName, "Tablet")
+	var maxTableCardinality int
+	if config != nil {
+		maxTableCardinality = config.TableMetricsMaxCardinality
+	}
 	return &testEnv{
 		config:   config,
 		exporter: exporter,
-		stats:    NewStats(exporter),
+		stats:    NewStats(exporter, maxTableCardinality),
 		env:      env,
 	}
 }
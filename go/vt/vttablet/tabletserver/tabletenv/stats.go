@@ -34,14 +34,14 @@ type Stats struct {
 	ErrorCounters          *stats.CountersWithSingleLabel
 	InternalErrors         *stats.CountersWithSingleLabel
 	Warnings               *stats.CountersWithSingleLabel
-	UserTableQueryCount    *stats.CountersWithMultiLabels // Per CallerID/table counts
-	UserTableQueryTimesNs  *stats.CountersWithMultiLabels // Per CallerID/table latencies
-	UserTransactionCount   *stats.CountersWithMultiLabels // Per CallerID transaction counts
-	UserTransactionTimesNs *stats.CountersWithMultiLabels // Per CallerID transaction latencies
-	ResultHistogram        *stats.Histogram               // Row count histograms
-	TableaclAllowed        *stats.CountersWithMultiLabels // Number of allows
-	TableaclDenied         *stats.CountersWithMultiLabels // Number of denials
-	TableaclPseudoDenied   *stats.CountersWithMultiLabels // Number of pseudo denials
+	UserTableQueryCount    *stats.BoundedCountersWithMultiLabels // Per CallerID/table counts
+	UserTableQueryTimesNs  *stats.BoundedCountersWithMultiLabels // Per CallerID/table latencies
+	UserTransactionCount   *stats.CountersWithMultiLabels        // Per CallerID transaction counts
+	UserTransactionTimesNs *stats.CountersWithMultiLabels        // Per CallerID transaction latencies
+	ResultHistogram        *stats.Histogram                      // Row count histograms
+	TableaclAllowed        *stats.BoundedCountersWithMultiLabels // Number of allows
+	TableaclDenied         *stats.BoundedCountersWithMultiLabels // Number of denials
+	TableaclPseudoDenied   *stats.BoundedCountersWithMultiLabels // Number of pseudo denials
 
 	UserActiveReservedCount *stats.CountersWithSingleLabel // Per CallerID active reserved connection counts
 	UserReservedCount       *stats.CountersWithSingleLabel // Per CallerID reserved connection counts
@@ -49,14 +49,18 @@ type Stats struct {
 
 	QueryTimingsByTabletType *servenv.TimingsWrapper // Query timings split by current tablet type
 
+	PreparedStatements *stats.CountersWithSingleLabel // Prepared statement operations on pooled connections
+
 	// Atomic Transactions
 	Unresolved         *stats.GaugesWithSingleLabel
 	CommitPreparedFail *stats.CountersWithSingleLabel
 	RedoPreparedFail   *stats.CountersWithSingleLabel
 }
 
-// NewStats instantiates a new set of stats scoped by exporter.
-func NewStats(exporter *servenv.Exporter) *Stats {
+// NewStats instantiates a new set of stats scoped by exporter. The stats
+// labeled by table track at most maxTableCardinality label combinations,
+// or all of them if it is 0.
+func NewStats(exporter *servenv.Exporter, maxTableCardinality int) *Stats {
 	stats := &Stats{
 		MySQLTimings: exporter.NewTimings("Mysql", "MySQL query time", "operation"),
 		QueryTimings: exporter.NewTimings("Queries", "MySQL query timings", "plan_type"),
@@ -85,16 +89,16 @@ func NewStats(exporter *servenv.Exporter) *Stats {
 			vtrpcpb.Code_DATA_LOSS.String(),
 			vtrpcpb.Code_CLUSTER_EVENT.String(),
 		),
-		InternalErrors:         exporter.NewCountersWithSingleLabel("InternalErrors", "Internal component errors", "type", "Task", "StrayTransactions", "Panic", "HungQuery", "Schema", "TwopcCommit", "TwopcResurrection", "WatchdogFail", "Messages"),
+		InternalErrors:         exporter.NewCountersWithSingleLabel("InternalErrors", "Internal component errors", "type", "Task", "StrayTransactions", "Panic", "HungQuery", "Schema", "TwopcCommit", "TwopcResurrection", "WatchdogFail", "Messages", "Warnings"),
 		Warnings:               exporter.NewCountersWithSingleLabel("Warnings", "Warnings", "type", "ResultsExceeded"),
-		UserTableQueryCount:    exporter.NewCountersWithMultiLabels("UserTableQueryCount", "Queries received for each CallerID/table combination", []string{"TableName", "CallerID", "Type"}),
-		UserTableQueryTimesNs:  exporter.NewCountersWithMultiLabels("UserTableQueryTimesNs", "Total latency for each CallerID/table combination", []string{"TableName", "CallerID", "Type"}),
+		UserTableQueryCount:    exporter.NewBoundedCountersWithMultiLabels("UserTableQueryCount", "Queries received for each CallerID/table combination", []string{"TableName", "CallerID", "Type"}, maxTableCardinality),
+		UserTableQueryTimesNs:  exporter.NewBoundedCountersWithMultiLabels("UserTableQueryTimesNs", "Total latency for each CallerID/table combination", []string{"TableName", "CallerID", "Type"}, maxTableCardinality),
 		UserTransactionCount:   exporter.NewCountersWithMultiLabels("UserTransactionCount", "transactions received for each CallerID", []string{"CallerID", "Conclusion"}),
 		UserTransactionTimesNs: exporter.NewCountersWithMultiLabels("UserTransactionTimesNs", "Total transaction latency for each CallerID", []string{"CallerID", "Conclusion"}),
 		ResultHistogram:        exporter.NewHistogram("Results", "Distribution of rows returned", []int64{0, 1, 5, 10, 50, 100, 500, 1000, 5000, 10000}),
-		TableaclAllowed:        exporter.NewCountersWithMultiLabels("TableACLAllowed", "ACL acceptances", []string{"TableName", "TableGroup", "PlanID", "Username"}),
-		TableaclDenied:         exporter.NewCountersWithMultiLabels("TableACLDenied", "ACL denials", []string{"TableName", "TableGroup", "PlanID", "Username"}),
-		TableaclPseudoDenied:   exporter.NewCountersWithMultiLabels("TableACLPseudoDenied", "ACL pseudodenials", []string{"TableName", "TableGroup", "PlanID", "Username"}),
+		TableaclAllowed:        exporter.NewBoundedCountersWithMultiLabels("TableACLAllowed", "ACL acceptances", []string{"TableName", "TableGroup", "PlanID", "Username"}, maxTableCardinality),
+		TableaclDenied:         exporter.NewBoundedCountersWithMultiLabels("TableACLDenied", "ACL denials", []string{"TableName", "TableGroup", "PlanID", "Username"}, maxTableCardinality),
+		TableaclPseudoDenied:   exporter.NewBoundedCountersWithMultiLabels("TableACLPseudoDenied", "ACL pseudodenials", []string{"TableName", "TableGroup", "PlanID", "Username"}, maxTableCardinality),
 
 		UserActiveReservedCount: exporter.NewCountersWithSingleLabel("UserActiveReservedCount", "active reserved connection for each CallerID", "CallerID"),
 		UserReservedCount:       exporter.NewCountersWithSingleLabel("UserReservedCount", "reserved connection received for each CallerID", "CallerID"),
@@ -102,6 +106,8 @@ func NewStats(exporter *servenv.Exporter) *Stats {
 
 		QueryTimingsByTabletType: exporter.NewTimings("QueryTimingsByTabletType", "Query timings broken down by active tablet type", "TabletType"),
 
+		PreparedStatements: exporter.NewCountersWithSingleLabel("PreparedStatements", "Prepared statement operations on pooled connections", "operation", "Prepare", "Execute", "Close", "Unsupported"),
+
 		Unresolved:         exporter.NewGaugesWithSingleLabel("UnresolvedTransaction", "Current unresolved transactions", "ManagerType"),
 		CommitPreparedFail: exporter.NewCountersWithSingleLabel("CommitPreparedFail", "failed prepared transactions commit", "FailureType"),
 		RedoPreparedFail:   exporter.NewCountersWithSingleLabel("RedoPreparedFail", "failed prepared transactions on redo", "FailureType"),
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -64,7 +65,10 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/onlineddl"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/analyze"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/colstats"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/gc"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/jobs"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/messager"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/querythrottler"
@@ -131,6 +135,9 @@ type TabletServer struct {
 	lagThrottler *throttle.Throttler
 	qThrottler   *throttle.Throttler
 	tableGC      *gc.TableGC
+	analyzer     *analyze.Analyzer
+	colStats     *colstats.Collector
+	jobs         *jobs.Engine
 
 	// sm manages state transitions.
 	sm                *stateManager
@@ -165,7 +172,7 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	exporter := servenv.NewExporter(name, "Tablet")
 	tsv := &TabletServer{
 		exporter:               exporter,
-		stats:                  tabletenv.NewStats(exporter),
+		stats:                  tabletenv.NewStats(exporter, config.TableMetricsMaxCardinality),
 		config:                 config,
 		TerseErrors:            config.TerseErrors,
 		TruncateErrorLen:       config.TruncateErrorLen,
@@ -198,12 +205,16 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.vstreamer = vstreamer.NewEngine(tsv, srvTopoServer, tsv.se, tsv.lagThrottler, alias.Cell)
 	tsv.binlogDumper = NewBinlogDumpEngine()
 	tsv.tracker = schema.NewTracker(tsv, tsv.vstreamer, tsv.se)
+	tsv.rt.SetVStreamer(tsv.vstreamer)
 	tsv.qe = NewQueryEngine(tsv, tsv.se)
 	tsv.txThrottler = txthrottler.NewTxThrottler(tsv, topoServer)
 	tsv.te = NewTxEngine(tsv, tsv.hs.sendUnresolvedTransactionSignal)
 	tsv.messager = messager.NewEngine(tsv, tsv.se, tsv.vstreamer)
 
 	tsv.tableGC = gc.NewTableGC(tsv, topoServer, tsv.lagThrottler)
+	tsv.analyzer = analyze.NewAnalyzer(tsv, tsv.lagThrottler)
+	tsv.colStats = colstats.NewCollector(tsv, tsv.hs.sendColumnStatisticsSignal)
+	tsv.jobs = jobs.NewEngine(tsv, alias)
 	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, alias, topoServer, tsv.lagThrottler, tabletTypeFunc, tsv.onlineDDLExecutorToggleTableBuffer, tsv.tableGC.RequestChecks, tsv.te.preparedPool.IsEmptyForTable)
 
 	tsv.sm = &stateManager{
@@ -224,6 +235,9 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 		throttler:         tsv.lagThrottler,
 		qThrottler:        tsv.qThrottler,
 		tableGC:           tsv.tableGC,
+		analyzer:          tsv.analyzer,
+		colStats:          tsv.colStats,
+		jobs:              tsv.jobs,
 		rw:                newRequestsWaiter(),
 		diskHealthMonitor: newDiskHealthMonitor(ctx),
 	}
@@ -290,6 +304,28 @@ func (tsv *TabletServer) loadQueryTimeoutWithOptions(options *querypb.ExecuteOpt
 	return time.Duration(tsv.QueryTimeout.Load())
 }
 
+// loadPlanQueryTimeout returns the timeout for executing a plan of the given
+// type. DML is bounded by the DML timeout when one is configured, and every
+// other plan by the query timeout. The authoritative timeout from the options
+// and, inside a transaction, the transaction timeout apply as they do in
+// loadQueryTimeoutWithTxAndOptions.
+func (tsv *TabletServer) loadPlanQueryTimeout(planID planbuilder.PlanType, txID int64, options *querypb.ExecuteOptions) time.Duration {
+	dmlTimeout := tsv.config.Oltp.DMLTimeout
+	if !planIsDML(planID) || dmlTimeout == 0 || (options != nil && options.Timeout != nil) {
+		return tsv.loadQueryTimeoutWithTxAndOptions(txID, options)
+	}
+	if txID == 0 {
+		return dmlTimeout
+	}
+	return smallerTimeout(dmlTimeout, getTransactionTimeout(options, tsv.config, querypb.ExecuteOptions_OLTP))
+}
+
+// loadOLAPQueryTimeout returns the timeout for streaming reads. 0 means they
+// are not bounded.
+func (tsv *TabletServer) loadOLAPQueryTimeout() time.Duration {
+	return tsv.config.Olap.QueryTimeout
+}
+
 // onlineDDLExecutorToggleTableBuffer is called by onlineDDLExecutor as a callback function. onlineDDLExecutor
 // uses it to start/stop query buffering for a given table.
 // It is onlineDDLExecutor's responsibility to make sure buffering is stopped after some definite amount of time.
@@ -309,6 +345,8 @@ func (tsv *TabletServer) onlineDDLExecutorToggleTableBuffer(bufferingCtx context
 	} else {
 		tsv.UnRegisterQueryRuleSource(queryRuleSource) // new rules will not have buffering. Existing rules will be affected by bufferingContext.Done()
 	}
+	// Let vtgates know, so they can buffer the queries on the table themselves.
+	tsv.hs.setCutOverImminent(tableName, bufferQueries, timeout)
 }
 
 // InitDBConfig initializes the db config variables for TabletServer. You must call this function
@@ -330,6 +368,8 @@ func (tsv *TabletServer) InitDBConfig(target *querypb.Target, dbcfgs *dbconfigs.
 	tsv.lagThrottler.InitDBConfig(target.Keyspace, target.Shard)
 	tsv.qThrottler.InitDBConfig(target.Keyspace, target.Shard)
 	tsv.tableGC.InitDBConfig(target.Keyspace, target.Shard, dbcfgs.DBName)
+	tsv.analyzer.InitDBConfig(dbcfgs.DBName)
+	tsv.colStats.InitDBConfig(dbcfgs.DBName)
 	tsv.queryThrottler.InitDBConfig(target.Keyspace)
 
 	return nil
@@ -535,6 +575,11 @@ func (tsv *TabletServer) TableGC() *gc.TableGC {
 	return tsv.tableGC
 }
 
+// JobEngine returns the jobs.Engine part of TabletServer.
+func (tsv *TabletServer) JobEngine() *jobs.Engine {
+	return tsv.jobs
+}
+
 // SchemaEngine returns the SchemaEngine part of TabletServer.
 func (tsv *TabletServer) SchemaEngine() *schema.Engine {
 	return tsv.se
@@ -799,6 +844,13 @@ func (tsv *TabletServer) SetDemotePrimaryStalled(val bool) {
 	tsv.BroadcastHealth()
 }
 
+// WarmUpOnNextServe makes the next transition to serving as a non-primary
+// pre-open and warm up the query pools before the tablet starts serving.
+// It is used after a restore, so that new replicas don't serve with cold caches.
+func (tsv *TabletServer) WarmUpOnNextServe() {
+	tsv.sm.WarmUpOnNextServe()
+}
+
 // IsDiskStalled returns if the disk is stalled or not.
 func (tsv *TabletServer) IsDiskStalled() bool {
 	return tsv.sm.diskHealthMonitor.IsDiskStalled()
@@ -926,9 +978,10 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 	}
 
 	allowOnShutdown := transactionID != 0
-	timeout := tsv.loadQueryTimeoutWithTxAndOptions(transactionID, options)
+	// The timeout depends on the plan, so it is applied once the plan is
+	// known rather than by execRequest. Planning does not block.
 	err = tsv.execRequest(
-		ctx, timeout,
+		ctx, 0,
 		"Execute", sql, bindVariables,
 		target, options, allowOnShutdown,
 		func(ctx context.Context, logStats *tabletenv.LogStats) error {
@@ -941,6 +994,8 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 			if err != nil {
 				return err
 			}
+			ctx, cancel := withTimeout(ctx, tsv.loadPlanQueryTimeout(plan.PlanID, transactionID, options), options)
+			defer cancel()
 
 			if err = plan.IsValid(reservedID != 0, len(settings) > 0); err != nil {
 				return err
@@ -981,6 +1036,12 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 				result.Fields = nil
 				result.Rows = nil
 			} else {
+				if encodeJSONInBinary(options) {
+					var encoder binaryJSONEncoder
+					if result, err = encoder.encode(result); err != nil {
+						return err
+					}
+				}
 				result = result.StripMetadata(sqltypes.IncludeFieldsOrDefault(options))
 			}
 
@@ -1074,6 +1135,19 @@ func (tsv *TabletServer) streamExecute(ctx context.Context, target *querypb.Targ
 				targetTabletType: target.GetTabletType(),
 				setting:          connSetting,
 			}
+			if export := options.GetExport(); export != nil {
+				return tsv.exportStream(ctx, qre, export, callback)
+			}
+			if encodeJSONInBinary(options) {
+				var encoder binaryJSONEncoder
+				return qre.Stream(func(result *sqltypes.Result) error {
+					result, err := encoder.encode(result)
+					if err != nil {
+						return err
+					}
+					return callback(result)
+				})
+			}
 			return qre.Stream(callback)
 		},
 	)
@@ -1256,6 +1330,34 @@ func (tsv *TabletServer) MessageAck(ctx context.Context, target *querypb.Target,
 	return count, nil
 }
 
+// MessageAckBatch acks the list of messages for a given message table,
+// in chunks of at most MessageBatchChunkSize messages, each in its own
+// transaction. It returns the number of messages successfully acked,
+// including those of the chunks that succeeded before an error.
+func (tsv *TabletServer) MessageAckBatch(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	querygen, err := tsv.messager.GetGenerator(name)
+	if err != nil {
+		return 0, err
+	}
+	count, err = tsv.execChunkedDML(ctx, target, ids, querygen.GenerateAckQuery)
+	messager.MessageStats.Add([]string{name, "Acked"}, count)
+	return count, err
+}
+
+// MessagePostpone postpones the list of messages for a given message table,
+// in chunks of at most MessageBatchChunkSize messages, each in its own
+// transaction. It returns the number of messages successfully postponed,
+// including those of the chunks that succeeded before an error.
+func (tsv *TabletServer) MessagePostpone(ctx context.Context, target *querypb.Target, name string, ids []*querypb.Value) (count int64, err error) {
+	querygen, err := tsv.messager.GetGenerator(name)
+	if err != nil {
+		return 0, err
+	}
+	count, err = tsv.execChunkedDML(ctx, target, ids, querygen.GeneratePostponeQuery)
+	messager.MessageStats.Add([]string{name, "Postponed"}, count)
+	return count, err
+}
+
 // PostponeMessages postpones the list of messages for a given message table.
 // It returns the number of messages successfully postponed.
 func (tsv *TabletServer) PostponeMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, ids []string) (count int64, err error) {
@@ -1274,6 +1376,26 @@ func (tsv *TabletServer) PurgeMessages(ctx context.Context, target *querypb.Targ
 	})
 }
 
+// execChunkedDML runs the DML generated by generate for chunks of at most
+// MessageBatchChunkSize ids. It stops at the first chunk that fails.
+func (tsv *TabletServer) execChunkedDML(ctx context.Context, target *querypb.Target, ids []*querypb.Value, generate func(ids []string) (string, map[string]*querypb.BindVariable)) (count int64, err error) {
+	sids := make([]string, 0, len(ids))
+	for _, val := range ids {
+		sids = append(sids, sqltypes.ProtoToValue(val).ToString())
+	}
+	for chunk := range slices.Chunk(sids, tsv.config.MessageBatchChunkSize) {
+		chunkCount, err := tsv.execDML(ctx, target, func() (string, map[string]*querypb.BindVariable, error) {
+			query, bv := generate(chunk)
+			return query, bv, nil
+		})
+		count += chunkCount
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
 func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, queryGenerator func() (string, map[string]*querypb.BindVariable, error)) (count int64, err error) {
 	if err = tsv.sm.StartRequest(ctx, target, false /* allowOnShutdown */); err != nil {
 		return 0, err
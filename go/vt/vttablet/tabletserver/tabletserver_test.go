@@ -32,6 +32,7 @@ import (
 	"testing"
 	"time"
 
+	"vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/stats"
@@ -55,6 +56,7 @@ import (
 	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -530,6 +532,70 @@ func TestTabletServerExecuteNoResult(t *testing.T) {
 	assert.Empty(t, result.Rows)
 }
 
+func TestTabletServerExecuteBinaryJSON(t *testing.T) {
+	ctx := t.Context()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	executeSQL := "select * from test_table limit 1000"
+	executeSQLResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int64},
+			{Name: "doc", Type: sqltypes.TypeJSON},
+		},
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt64(1), sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"a": [1, "b"]}`))},
+			{sqltypes.NewInt64(2), sqltypes.NULL},
+		},
+	}
+	db.AddQuery(executeSQL, executeSQLResult)
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{JsonEncoding: querypb.ExecuteOptions_JSON_BINARY}
+	checkFields := func(fields []*querypb.Field) {
+		require.Len(t, fields, 2)
+		assert.Equal(t, querypb.ExecuteOptions_JSON_TEXT, fields[0].JsonEncoding)
+		assert.Equal(t, querypb.ExecuteOptions_JSON_BINARY, fields[1].JsonEncoding)
+	}
+	checkRows := func(rows [][]sqltypes.Value) {
+		require.Len(t, rows, 2)
+		assert.Equal(t, sqltypes.NewInt64(1), rows[0][0])
+		doc, err := binlog.ParseBinaryJSON(rows[0][1].Raw())
+		require.NoError(t, err)
+		assert.Equal(t, `{"a": [1, "b"]}`, doc.String())
+		assert.True(t, rows[1][1].IsNull())
+	}
+
+	result, err := tsv.Execute(ctx, nil, &target, executeSQL, nil, 0, 0, options)
+	require.NoError(t, err)
+	checkFields(result.Fields)
+	checkRows(result.Rows)
+
+	var fields []*querypb.Field
+	var rows [][]sqltypes.Value
+	err = tsv.StreamExecute(ctx, nil, &target, executeSQL, nil, 0, 0, options, func(result *sqltypes.Result) error {
+		if result.Fields != nil {
+			fields = result.Fields
+		}
+		rows = append(rows, result.Rows...)
+		return nil
+	})
+	require.NoError(t, err)
+	checkFields(fields)
+	checkRows(rows)
+
+	// The result of the query is not modified.
+	assert.Equal(t, querypb.ExecuteOptions_JSON_TEXT, executeSQLResult.Fields[1].JsonEncoding)
+	assert.Equal(t, `{"a": [1, "b"]}`, executeSQLResult.Rows[0][1].ToString())
+
+	// Without the option, the values are returned as text.
+	result, err = tsv.Execute(ctx, nil, &target, executeSQL, nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, querypb.ExecuteOptions_JSON_TEXT, result.Fields[1].JsonEncoding)
+	assert.Equal(t, `{"a": [1, "b"]}`, result.Rows[0][1].ToString())
+}
+
 func TestTabletServerCommiRollbacktFail(t *testing.T) {
 	ctx := t.Context()
 	db, tsv := setupTabletServerTest(t, ctx, "")
@@ -777,6 +843,70 @@ func TestLoadQueryTimeout(t *testing.T) {
 	}
 }
 
+func TestLoadPlanQueryTimeout(t *testing.T) {
+	ctx := t.Context()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	authoritative := func(timeout int64) *querypb.ExecuteOptions {
+		return &querypb.ExecuteOptions{
+			Timeout: &querypb.ExecuteOptions_AuthoritativeTimeout{AuthoritativeTimeout: timeout},
+		}
+	}
+	testcases := []struct {
+		name       string
+		planID     planbuilder.PlanType
+		txID       int64
+		dmlTimeout time.Duration
+		options    *querypb.ExecuteOptions
+
+		want time.Duration
+	}{{
+		name:   "select",
+		planID: planbuilder.PlanSelect,
+		want:   30 * time.Second,
+	}, {
+		name:   "dml without a dml timeout",
+		planID: planbuilder.PlanUpdate,
+		want:   30 * time.Second,
+	}, {
+		name:       "select with a dml timeout",
+		planID:     planbuilder.PlanSelect,
+		dmlTimeout: 5 * time.Second,
+		want:       30 * time.Second,
+	}, {
+		name:       "dml with a lower dml timeout",
+		planID:     planbuilder.PlanInsert,
+		dmlTimeout: 5 * time.Second,
+		want:       5 * time.Second,
+	}, {
+		name:       "dml with a higher dml timeout",
+		planID:     planbuilder.PlanDelete,
+		dmlTimeout: time.Minute,
+		want:       time.Minute,
+	}, {
+		name:       "dml in a transaction",
+		planID:     planbuilder.PlanDelete,
+		txID:       1234,
+		dmlTimeout: time.Minute,
+		want:       30 * time.Second,
+	}, {
+		name:       "dml with an authoritative timeout",
+		planID:     planbuilder.PlanUpdate,
+		dmlTimeout: 5 * time.Second,
+		options:    authoritative(40000),
+		want:       40 * time.Second,
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			tsv.config.Oltp.DMLTimeout = tcase.dmlTimeout
+			defer func() { tsv.config.Oltp.DMLTimeout = 0 }()
+			assert.Equal(t, tcase.want, tsv.loadPlanQueryTimeout(tcase.planID, tcase.txID, tcase.options))
+		})
+	}
+}
+
 func TestTabletServerReserveConnection(t *testing.T) {
 	ctx := t.Context()
 	db, tsv := setupTabletServerTest(t, ctx, "")
@@ -1726,6 +1856,59 @@ func TestMessageAck(t *testing.T) {
 	require.EqualValues(t, 1, count)
 }
 
+func TestMessageAckBatch(t *testing.T) {
+	ctx := t.Context()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	tsv.config.MessageBatchChunkSize = 2
+
+	var ids []*querypb.Value
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		ids = append(ids, &querypb.Value{Type: sqltypes.VarChar, Value: []byte(id)})
+	}
+	_, err := tsv.MessageAckBatch(ctx, &target, "nonmsg", ids)
+	require.ErrorContains(t, err, "message table nonmsg not found in schema")
+
+	db.AddQueryPattern(`update msg set time_acked = .* where id in \(1, 2\).*`, &sqltypes.Result{RowsAffected: 2})
+	db.AddQueryPattern(`update msg set time_acked = .* where id in \(3, 4\).*`, &sqltypes.Result{RowsAffected: 1})
+	count, err := tsv.MessageAckBatch(ctx, &target, "msg", ids)
+	// The last chunk fails, the previous ones stay acked.
+	require.ErrorContains(t, err, "query: 'update msg set time_acked")
+	require.EqualValues(t, 3, count)
+
+	db.AddQueryPattern(`update msg set time_acked = .* where id in \(5\).*`, &sqltypes.Result{RowsAffected: 1})
+	count, err = tsv.MessageAckBatch(ctx, &target, "msg", ids)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, count)
+}
+
+func TestMessagePostpone(t *testing.T) {
+	ctx := t.Context()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	tsv.config.MessageBatchChunkSize = 2
+
+	ids := []*querypb.Value{{
+		Type:  sqltypes.VarChar,
+		Value: []byte("1"),
+	}, {
+		Type:  sqltypes.VarChar,
+		Value: []byte("2"),
+	}, {
+		Type:  sqltypes.VarChar,
+		Value: []byte("3"),
+	}}
+	_, err := tsv.MessagePostpone(ctx, &target, "nonmsg", ids)
+	require.ErrorContains(t, err, "message table nonmsg not found in schema")
+
+	db.AddQueryPattern("update msg set time_next = .*", &sqltypes.Result{RowsAffected: 1})
+	count, err := tsv.MessagePostpone(ctx, &target, "msg", ids)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
+
 func TestRescheduleMessages(t *testing.T) {
 	ctx := t.Context()
 	_, tsv, db, closer := newTestTxExecutor(t, ctx)
@@ -2400,6 +2583,32 @@ func TestReserveExecute_WithoutTx(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReserveExecuteTempTables(t *testing.T) {
+	ctx := t.Context()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	db.AddQueryPattern("create temporary table .*", &sqltypes.Result{})
+	db.AddQueryPattern("drop temporary table .*", &sqltypes.Result{})
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	state, _, err := tsv.ReserveExecute(ctx, nil, &target, nil, "create temporary table t1 (id int)", nil, 0, nil)
+	require.NoError(t, err)
+	_, err = tsv.Execute(ctx, nil, &target, "create temporary table t2 (id int)", nil, 0, state.ReservedID, nil)
+	require.NoError(t, err)
+	_, err = tsv.Execute(ctx, nil, &target, "drop temporary table t1", nil, 0, state.ReservedID, nil)
+	require.NoError(t, err)
+
+	conn, err := tsv.te.txPool.GetAndLock(state.ReservedID, "for test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t2"}, conn.TempTables())
+	conn.Unlock()
+
+	err = tsv.Release(ctx, &target, 0, state.ReservedID)
+	require.NoError(t, err)
+}
+
 func TestReserveExecute_WithTx(t *testing.T) {
 	ctx := t.Context()
 	db, tsv := setupTabletServerTest(t, ctx, "")
@@ -63,8 +63,9 @@ const (
 	// ThrottlerStimulatorName is used by a replica tablet to stimulate the throttler on the Primary tablet
 	ThrottlerStimulatorName Name = "throttler-stimulator"
 
-	TableGCName   Name = "tablegc"
-	OnlineDDLName Name = "online-ddl"
+	TableGCName      Name = "tablegc"
+	OnlineDDLName    Name = "online-ddl"
+	AnalyzeTableName Name = "analyze-table"
 
 	VReplicationName      Name = "vreplication"
 	VStreamerName         Name = "vstreamer"
@@ -75,9 +76,10 @@ const (
 	ExternalConnectorName Name = "external-connector"
 	ReplicaConnectorName  Name = "replica-connector"
 
-	BinlogWatcherName Name = "binlog-watcher"
-	MessagerName      Name = "messager"
-	SchemaTrackerName Name = "schema-tracker"
+	BinlogWatcherName   Name = "binlog-watcher"
+	MessagerName        Name = "messager"
+	SchemaTrackerName   Name = "schema-tracker"
+	TableLagTrackerName Name = "table-lag-tracker"
 
 	TestingName                Name = "test"
 	TestingAlwaysThrottledName Name = "always-throttled-app"
@@ -85,9 +87,10 @@ const (
 )
 
 var exemptFromChecks = map[string]bool{
-	BinlogWatcherName.String(): true,
-	MessagerName.String():      true,
-	SchemaTrackerName.String(): true,
+	BinlogWatcherName.String():   true,
+	MessagerName.String():        true,
+	SchemaTrackerName.String():   true,
+	TableLagTrackerName.String(): true,
 }
 
 // ExemptFromChecks returns 'true' for apps that should skip the throttler checks. The throttler should
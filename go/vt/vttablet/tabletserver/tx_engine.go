@@ -95,6 +95,11 @@ type TxEngine struct {
 	preparedPool *TxPreparedPool
 	twoPC        *TwoPC
 	dxNotify     func()
+
+	// dmlJournal records the idempotency keys of autocommit DMLs.
+	dmlJournal          *DMLJournal
+	dmlJournalRetention time.Duration
+	dmlJournalTicks     *timer.Timer
 }
 
 // TwoPC can be disallowed for various reasons. These are the reasons we keep track off
@@ -144,6 +149,11 @@ func NewTxEngine(env tabletenv.Env, dxNotifier func()) *TxEngine {
 	})
 	te.twoPC = NewTwoPC(readPool)
 	te.dxNotify = dxNotifier
+	te.dmlJournal = NewDMLJournal(env)
+	te.dmlJournalRetention = config.DMLJournalRetention
+	if te.dmlJournalRetention > 0 {
+		te.dmlJournalTicks = timer.NewTimer(te.dmlJournalRetention / 2)
+	}
 	te.state = NotServing
 	return te
 }
@@ -187,6 +197,9 @@ func (te *TxEngine) transition(state txEngineState) {
 		}
 		te.startTransactionWatcher()
 	}
+	if te.state == AcceptingReadAndWrite {
+		te.startDMLJournalPurger()
+	}
 	te.txPool.Open(te.env.Config().DB.AppWithDB(), te.env.Config().DB.DbaWithDB(), te.env.Config().DB.AppDebugWithDB())
 }
 
@@ -345,6 +358,10 @@ func (te *TxEngine) shutdownLocked() {
 	log.Info("TxEngine - called shutdownLocked")
 	immediate := te.state != AcceptingReadAndWrite
 
+	// The purger uses the txPool directly, so it must be stopped before
+	// waiting for the txPool to be empty.
+	te.stopDMLJournalPurger()
+
 	// Unlock, wait for all begin requests to complete, and relock.
 	te.state = Transitioning
 	te.stateLock.Unlock()
@@ -622,6 +639,39 @@ func (te *TxEngine) stopTransactionWatcher() {
 	te.ticks.Stop()
 }
 
+// startDMLJournalPurger starts the goroutine that deletes the dml_journal
+// entries older than the journal retention.
+func (te *TxEngine) startDMLJournalPurger() {
+	if te.dmlJournalTicks == nil {
+		return
+	}
+	te.dmlJournalTicks.Start(func() {
+		ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), te.dmlJournalRetention/4)
+		defer cancel()
+
+		options := &querypb.ExecuteOptions{TransactionIsolation: querypb.ExecuteOptions_AUTOCOMMIT}
+		conn, _, _, err := te.txPool.Begin(ctx, options, false, 0, nil)
+		if err != nil {
+			te.env.Stats().InternalErrors.Add("DMLJournalPurge", 1)
+			log.Error(fmt.Sprintf("Error purging the DML journal: %v", err))
+			return
+		}
+		defer te.txPool.RollbackAndRelease(ctx, conn)
+		if _, err := te.dmlJournal.Purge(ctx, conn, time.Now().Add(-te.dmlJournalRetention)); err != nil {
+			te.env.Stats().InternalErrors.Add("DMLJournalPurge", 1)
+			log.Error(fmt.Sprintf("Error purging the DML journal: %v", err))
+		}
+	})
+}
+
+// stopDMLJournalPurger stops the DML journal purge goroutine.
+func (te *TxEngine) stopDMLJournalPurger() {
+	if te.dmlJournalTicks == nil {
+		return
+	}
+	te.dmlJournalTicks.Stop()
+}
+
 // ReserveBegin creates a reserved connection, and in it opens a transaction
 func (te *TxEngine) ReserveBegin(ctx context.Context, options *querypb.ExecuteOptions, preQueries []string) (int64, string, error) {
 	span, ctx := trace.NewSpan(ctx, "TxEngine.ReserveBegin")
@@ -26,6 +26,7 @@ import (
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 
@@ -203,6 +204,32 @@ func TestTxPoolBeginWithPoolConnectionError_Errno2006_Transient(t *testing.T) {
 	txConn.Release(tx.TxCommit)
 }
 
+func TestTxPoolBeginWithConnectionErrorOnTempTables(t *testing.T) {
+	ctx := t.Context()
+
+	db, txPool := primeTxPoolWithConnection(t, ctx)
+	defer db.Close()
+	defer txPool.Close()
+
+	conn, err := txPool.scp.NewConn(ctx, &querypb.ExecuteOptions{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Taint(ctx, txPool.env.Exporter().NewTimings("TempTablesTest", "", "operation")))
+	stmt, err := sqlparser.NewTestParser().Parse("create temporary table t1 (id int)")
+	require.NoError(t, err)
+	conn.trackTempTables(stmt)
+	conn.Unlock()
+
+	// Close the connection on the server side.
+	db.CloseAllConnections()
+	err = db.WaitForClose(2 * time.Second)
+	require.NoError(t, err)
+
+	// The connection is not silently replaced by one without the temporary
+	// table.
+	_, _, _, err = txPool.Begin(ctx, &querypb.ExecuteOptions{}, false, conn.ReservedID(), nil)
+	require.ErrorContains(t, err, "connection with temporary tables [t1] lost")
+}
+
 // primeTxPoolWithConnection is a helper function. It reconstructs the
 // scenario where future transactions are going to reuse an open db connection.
 func primeTxPoolWithConnection(t *testing.T, ctx context.Context) (*fakesqldb.DB, *TxPool) {
@@ -90,6 +90,7 @@ type Engine struct {
 	vstreamerCount                         *stats.Gauge
 	vstreamerEventsStreamed                *stats.Counter
 	vstreamerCompressedTransactionsDecoded *stats.Counter
+	vstreamerTransactionsExcluded          *stats.Counter
 	vstreamerPacketSize                    *stats.GaugeFunc
 	vstreamerNumPackets                    *stats.Counter
 	resultStreamerNumRows                  *stats.Counter
@@ -134,6 +135,7 @@ func NewEngine(env tabletenv.Env, ts srvtopo.Server, se *schema.Engine, lagThrot
 		vstreamerCount:                         env.Exporter().NewGauge("VStreamerCount", "Current number of vstreamers"),
 		vstreamerEventsStreamed:                env.Exporter().NewCounter("VStreamerEventsStreamed", "Count of events streamed in VStream API"),
 		vstreamerCompressedTransactionsDecoded: env.Exporter().NewCounter("VStreamerCompressedTransactionsDecoded", "Count of compressed transactions (MySQL's binlog_transaction_compression=ON) decoded in the VStream API"),
+		vstreamerTransactionsExcluded:          env.Exporter().NewCounter("VStreamerTransactionsExcluded", "Count of transactions not streamed by the VStream API because their GTID was generated by an excluded server"),
 		vstreamerPacketSize:                    env.Exporter().NewGaugeFunc("VStreamPacketSize", "Max packet size for sending vstreamer events", getPacketSize),
 		vstreamerNumPackets:                    env.Exporter().NewCounter("VStreamerNumPackets", "Number of packets in vstreamer"),
 		resultStreamerNumPackets:               env.Exporter().NewCounter("ResultStreamerNumPackets", "Number of packets in result streamer"),
@@ -21,9 +21,12 @@ import (
 	"fmt"
 	"net/url"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
@@ -45,6 +48,10 @@ import (
 
 var rowStreamertHeartbeatInterval = 10 * time.Second
 
+// rowStreamerKillTimeout bounds the time to kill the query of a canceled row
+// stream.
+const rowStreamerKillTimeout = 5 * time.Second
+
 type RowStreamerMode int32
 
 const (
@@ -72,6 +79,7 @@ type rowStreamer struct {
 	vschema *localVSchema
 
 	plan          *Plan
+	table         *binlogdatapb.MinimalTable
 	pkColumns     []int
 	ukColumnNames []string
 	sendQuery     string
@@ -126,25 +134,35 @@ func (rs *rowStreamer) Stream() error {
 		return err
 	}
 	if rs.conn == nil {
-		conn, err := snapshotConnect(rs.ctx, rs.cp)
+		conn, err := rs.connect()
 		if err != nil {
 			return err
 		}
 		rs.conn = conn
 		defer rs.conn.Close()
-		if _, err := rs.conn.ExecuteFetch("set names 'binary'", 1, false); err != nil {
-			return err
-		}
-		if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_read_timeout = %v", rs.config.NetReadTimeout), 1, false); err != nil {
-			return err
-		}
-		if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@session.net_write_timeout = %v", rs.config.NetReadTimeout), 1, false); err != nil {
-			return err
-		}
 	}
 	return rs.streamQuery(rs.send)
 }
 
+// connect opens a new connection set up for streaming rows.
+func (rs *rowStreamer) connect() (*snapshotConn, error) {
+	conn, err := snapshotConnect(rs.ctx, rs.cp)
+	if err != nil {
+		return nil, err
+	}
+	for _, query := range []string{
+		"set names 'binary'",
+		fmt.Sprintf("set @@session.net_read_timeout = %v", rs.config.NetReadTimeout),
+		fmt.Sprintf("set @@session.net_write_timeout = %v", rs.config.NetReadTimeout),
+	} {
+		if _, err := conn.ExecuteFetch(query, 1, false); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
 func (rs *rowStreamer) buildPlan() error {
 	// This pre-parsing is required to extract the table name
 	// and create its metadata.
@@ -193,7 +211,8 @@ func (rs *rowStreamer) buildPlan() error {
 	if err != nil {
 		return err
 	}
-	rs.sendQuery, err = rs.buildSelect(st)
+	rs.table = st
+	rs.sendQuery, err = rs.buildSelect(st, rs.lastpk, nil)
 	if err != nil {
 		return err
 	}
@@ -244,7 +263,9 @@ func (rs *rowStreamer) buildPKColumns(st *binlogdatapb.MinimalTable) ([]int, err
 	return pkColumns, nil
 }
 
-func (rs *rowStreamer) buildSelect(st *binlogdatapb.MinimalTable) (string, error) {
+// buildSelect builds the query streaming the rows of the table after lastpk,
+// limited to copyRange if it is set.
+func (rs *rowStreamer) buildSelect(st *binlogdatapb.MinimalTable, lastpk []sqltypes.Value, copyRange *binlogdatapb.CopyRange) (string, error) {
 	buf := sqlparser.NewTrackedBuffer(nil)
 	// We could have used select *, but being explicit is more predictable.
 	buf.Myprintf("select ")
@@ -288,17 +309,37 @@ func (rs *rowStreamer) buildSelect(st *binlogdatapb.MinimalTable) (string, error
 		indexHint = fmt.Sprintf(" force index (%s)", escapedPKIndexName)
 	}
 	buf.Myprintf(" from %v%s", sqlparser.NewIdentifierCS(rs.plan.Table.Name), indexHint)
-	if len(rs.lastpk) != 0 { // We're in the Nth copy phase cycle and need to resume
-		if len(rs.lastpk) != len(rs.pkColumns) {
-			return "", fmt.Errorf("cannot build a row streamer plan for the %s table as a lastpk value was provided and the number of primary key values within it (%v) does not match the number of primary key columns in the table (%d)",
-				st.Name, rs.lastpk, rs.pkColumns)
-		}
-		buf.WriteString(" where ")
-		// First we add any predicates that should be pushed down.
-		if len(rs.plan.whereExprsToPushDown) > 0 {
-			addPushdownExpressions()
-			// Only AND expressions are supported.
-			buf.Myprintf(" and ")
+	if len(lastpk) != 0 && len(lastpk) != len(rs.pkColumns) {
+		return "", fmt.Errorf("cannot build a row streamer plan for the %s table as a lastpk value was provided and the number of primary key values within it (%v) does not match the number of primary key columns in the table (%d)",
+			st.Name, lastpk, rs.pkColumns)
+	}
+	separator := " where "
+	// First we add any predicates that should be pushed down.
+	if len(rs.plan.whereExprsToPushDown) > 0 {
+		buf.WriteString(separator)
+		addPushdownExpressions()
+		// Only AND expressions are supported.
+		separator = " and "
+	}
+	if copyRange != nil {
+		pkName := sqlparser.NewIdentifierCI(rs.plan.Table.Fields[rs.pkColumns[0]].Name)
+		if copyRange.Start != nil {
+			buf.Myprintf("%s%v >= ", separator, pkName)
+			sqltypes.ProtoToValue(copyRange.Start).EncodeSQL(buf)
+			separator = " and "
+		}
+		if copyRange.End != nil {
+			buf.Myprintf("%s%v < ", separator, pkName)
+			sqltypes.ProtoToValue(copyRange.End).EncodeSQL(buf)
+			separator = " and "
+		}
+	}
+	if len(lastpk) != 0 { // We're in the Nth copy phase cycle and need to resume
+		buf.WriteString(separator)
+		// The range bounds must apply to all the terms below.
+		wrap := copyRange != nil && len(rs.pkColumns) > 1
+		if wrap {
+			buf.WriteString("(")
 		}
 		prefix := ""
 		// This loop handles the case for composite PKs. For example,
@@ -311,16 +352,16 @@ func (rs *rowStreamer) buildSelect(st *binlogdatapb.MinimalTable) (string, error
 			prefix = " or "
 			for i, pk := range rs.pkColumns[:lastcol] {
 				buf.Myprintf("%v = ", sqlparser.NewIdentifierCI(rs.plan.Table.Fields[pk].Name))
-				rs.lastpk[i].EncodeSQL(buf)
+				lastpk[i].EncodeSQL(buf)
 				buf.Myprintf(" and ")
 			}
 			buf.Myprintf("%v > ", sqlparser.NewIdentifierCI(rs.plan.Table.Fields[pkCol].Name))
-			rs.lastpk[lastcol].EncodeSQL(buf)
+			lastpk[lastcol].EncodeSQL(buf)
 			buf.Myprintf(")")
 		}
-	} else if len(rs.plan.whereExprsToPushDown) > 0 { // We're in the first copy phase cycle
-		buf.Myprintf(" where ")
-		addPushdownExpressions()
+		if wrap {
+			buf.WriteString(")")
+		}
 	}
 	buf.Myprintf(" order by ", sqlparser.NewIdentifierCS(rs.plan.Table.Name))
 	prefix = ""
@@ -348,13 +389,29 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 	if err := rs.vse.waitForMySQL(rs.ctx, rs.cp, rs.plan.Table.Name); err != nil {
 		return err
 	}
+	// If the stream is canceled, kill its query rather than leave MySQL
+	// running it until the connection is closed.
+	defer rs.conn.KillOnCancel(rs.ctx, rowStreamerKillTimeout)()
 	var (
 		gtid       string
 		rotatedLog bool
+		copyRanges []*binlogdatapb.CopyRange
+		rangeConns []*snapshotConn
 		err        error
 	)
 	log.Info(fmt.Sprintf("Streaming rows for query: %s\n", rs.sendQuery))
-	if rs.mode == RowStreamerModeSingleTable {
+	switch {
+	case rs.copiesRanges():
+		gtid, copyRanges, rangeConns, err = rs.startCopyRanges()
+		if err != nil {
+			return err
+		}
+		// The first connection is rs.conn, which is closed by Stream.
+		for _, conn := range rangeConns[1:] {
+			defer conn.Close()
+			defer conn.KillOnCancel(rs.ctx, rowStreamerKillTimeout)()
+		}
+	case rs.mode == RowStreamerModeSingleTable:
 		gtid, rotatedLog, err = rs.conn.streamWithSnapshot(rs.ctx, rs.plan.Table.Name, rs.sendQuery)
 		if err != nil {
 			return err
@@ -362,32 +419,18 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 		if rotatedLog {
 			rs.vse.vstreamerFlushedBinlogs.Add(1)
 		}
-	} else {
+	default:
 		// Comes here when we stream all tables. The snapshot is created just once at the start.
 		if err := rs.conn.ExecuteStreamFetch(rs.query); err != nil {
 			return err
 		}
 	}
 
-	pkfields := make([]*querypb.Field, len(rs.pkColumns))
-	for i, pk := range rs.pkColumns {
-		pkfields[i] = &querypb.Field{
-			Name:    rs.plan.Table.Fields[pk].Name,
-			Type:    rs.plan.Table.Fields[pk].Type,
-			Charset: rs.plan.Table.Fields[pk].Charset,
-			Flags:   rs.plan.Table.Fields[pk].Flags,
-		}
-	}
-
-	charsets := make([]collations.ID, len(rs.plan.Table.Fields))
-	for i, fld := range rs.plan.Table.Fields {
-		charsets[i] = collations.ID(fld.Charset)
-	}
-
 	err = safeSend(rs.ctx, &binlogdatapb.VStreamRowsResponse{
-		Fields:   rs.plan.fields(),
-		Pkfields: pkfields,
-		Gtid:     gtid,
+		Fields:     rs.plan.fields(),
+		Pkfields:   rs.pkFields(),
+		Gtid:       gtid,
+		CopyRanges: copyRanges,
 	})
 	if err != nil {
 		return fmt.Errorf("row stream send error: %w", err)
@@ -411,26 +454,68 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 		}
 	}()
 
+	if len(rangeConns) == 0 {
+		return rs.streamRows(rs.ctx, rs.conn, rs.pktsize, 0, safeSend, throttleResponseRateLimiter)
+	}
+	// The ranges are streamed concurrently, each with its own packet sizer
+	// since their throughput differs.
+	g, ctx := errgroup.WithContext(rs.ctx)
+	for i, conn := range rangeConns {
+		pktsize := DefaultPacketSizer(rs.config.VStreamDynamicPacketSize, rs.config.VStreamPacketSize)
+		g.Go(func() error {
+			return rs.streamRows(ctx, conn, pktsize, int32(i), safeSend, throttleResponseRateLimiter)
+		})
+	}
+	return g.Wait()
+}
+
+// pkFields returns the fields of the primary key columns.
+func (rs *rowStreamer) pkFields() []*querypb.Field {
+	pkfields := make([]*querypb.Field, len(rs.pkColumns))
+	for i, pk := range rs.pkColumns {
+		pkfields[i] = &querypb.Field{
+			Name:    rs.plan.Table.Fields[pk].Name,
+			Type:    rs.plan.Table.Fields[pk].Type,
+			Charset: rs.plan.Table.Fields[pk].Charset,
+			Flags:   rs.plan.Table.Fields[pk].Flags,
+		}
+	}
+	return pkfields
+}
+
+// streamRows sends the rows of the query running on conn, in packets sized
+// by pktsize. Every packet carries the index of the copy range the rows
+// belong to.
+func (rs *rowStreamer) streamRows(ctx context.Context, conn *snapshotConn, pktsize PacketSizer, copyRange int32,
+	safeSend func(context.Context, *binlogdatapb.VStreamRowsResponse) error, throttleResponseRateLimiter *timer.RateLimiter,
+) error {
+	charsets := make([]collations.ID, len(rs.plan.Table.Fields))
+	for i, fld := range rs.plan.Table.Fields {
+		charsets[i] = collations.ID(fld.Charset)
+	}
+
 	var (
 		response binlogdatapb.VStreamRowsResponse
 		rows     []*querypb.Row
 		rowCount int
 		mysqlrow []sqltypes.Value
+		err      error
 	)
+	response.CopyRange = copyRange
 
 	lastpk := make([]sqltypes.Value, len(rs.pkColumns))
 	byteCount := 0
 	logger := logutil.NewThrottledLogger(rs.vse.GetTabletInfo(), throttledLoggerInterval)
 	for {
-		if err := rs.ctx.Err(); err != nil {
+		if err := ctx.Err(); err != nil {
 			log.Info("Row stream ended because of ctx.Done")
 			return fmt.Errorf("row stream ended: %w", err)
 		}
 
 		// check throttler.
-		if checkResult, ok := rs.vse.throttlerClient.ThrottleCheckOKOrWaitAppName(rs.ctx, throttlerapp.RowStreamerName); !ok {
+		if checkResult, ok := rs.vse.throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, throttlerapp.RowStreamerName); !ok {
 			throttleResponseRateLimiter.Do(func() error {
-				return safeSend(rs.ctx, &binlogdatapb.VStreamRowsResponse{Throttled: true, ThrottledReason: checkResult.Summary()})
+				return safeSend(ctx, &binlogdatapb.VStreamRowsResponse{Throttled: true, ThrottledReason: checkResult.Summary()})
 			})
 			logger.Infof("Throttled streaming rows for %s", rs.sendQuery)
 			continue
@@ -439,7 +524,7 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 		if mysqlrow != nil {
 			mysqlrow = mysqlrow[:0]
 		}
-		mysqlrow, err = rs.conn.FetchNext(mysqlrow)
+		mysqlrow, err = conn.FetchNext(mysqlrow)
 		if err != nil {
 			return err
 		}
@@ -469,18 +554,18 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 			rowCount++
 		}
 
-		if rs.pktsize.ShouldSend(byteCount) {
+		if pktsize.ShouldSend(byteCount) {
 			response.Rows = rows[:rowCount]
 			response.Lastpk = sqltypes.RowToProto3(lastpk)
 
 			rs.vse.rowStreamerNumRows.Add(int64(len(response.Rows)))
 			rs.vse.rowStreamerNumPackets.Add(int64(1))
 			startSend := time.Now()
-			err = safeSend(rs.ctx, &response)
+			err = safeSend(ctx, &response)
 			if err != nil {
 				return err
 			}
-			rs.pktsize.Record(byteCount, time.Since(startSend))
+			pktsize.Record(byteCount, time.Since(startSend))
 			rowCount = 0
 			byteCount = 0
 		}
@@ -491,7 +576,7 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 		response.Lastpk = sqltypes.RowToProto3(lastpk)
 
 		rs.vse.rowStreamerNumRows.Add(int64(len(response.Rows)))
-		err = safeSend(rs.ctx, &response)
+		err = safeSend(ctx, &response)
 		if err != nil {
 			return err
 		}
@@ -500,6 +585,159 @@ func (rs *rowStreamer) streamQuery(send func(*binlogdatapb.VStreamRowsResponse)
 	return nil
 }
 
+// copiesRanges returns true if the table is to be copied in concurrent
+// ranges of its first primary key column. Only copies that have not
+// started as a single stream can be split.
+func (rs *rowStreamer) copiesRanges() bool {
+	return rs.mode == RowStreamerModeSingleTable && len(rs.lastpk) == 0 &&
+		(rs.options.GetCopyRangeWorkers() > 1 || len(rs.options.GetCopyRanges()) > 0)
+}
+
+// startCopyRanges starts the streaming query of each copy range on its own
+// connection, the first one being rs.conn. All connections read the table
+// from snapshots started while it was locked, so they all see it as of the
+// returned GTID set. The ranges are those being resumed, if any, or else
+// a split of the table into up to CopyRangeWorkers ranges.
+func (rs *rowStreamer) startCopyRanges() (string, []*binlogdatapb.CopyRange, []*snapshotConn, error) {
+	// See streamWithSnapshot.
+	if rotatedLog, err := rs.conn.limitOpenBinlogSize(); err != nil {
+		log.Warn(fmt.Sprintf("Failed in attempt to potentially flush binary logs in order to lessen overhead and improve performance of a VStream using query %q: %v", rs.sendQuery, err))
+	} else if rotatedLog {
+		rs.vse.vstreamerFlushedBinlogs.Add(1)
+	}
+
+	copyRanges := rs.options.GetCopyRanges()
+	workers := len(copyRanges)
+	if workers == 0 {
+		workers = int(rs.options.GetCopyRangeWorkers())
+	}
+	conns := []*snapshotConn{rs.conn}
+	closeConns := func(conns []*snapshotConn) {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	for len(conns) < workers {
+		conn, err := rs.connect()
+		if err != nil {
+			closeConns(conns[1:])
+			return "", nil, nil, err
+		}
+		conns = append(conns, conn)
+	}
+	gtid, err := startSharedSnapshot(rs.ctx, rs.cp, rs.plan.Table.Name, conns...)
+	if err != nil {
+		closeConns(conns[1:])
+		return "", nil, nil, err
+	}
+	if len(copyRanges) == 0 {
+		if copyRanges, err = rs.splitCopyRanges(conns[0], workers); err != nil {
+			closeConns(conns[1:])
+			return "", nil, nil, err
+		}
+		// The table may be split in fewer ranges than requested.
+		closeConns(conns[len(copyRanges):])
+		conns = conns[:len(copyRanges)]
+	}
+
+	pkfields := rs.pkFields()
+	for i, copyRange := range copyRanges {
+		var lastpk []sqltypes.Value
+		if copyRange.Lastpk != nil {
+			lastpk = sqltypes.MakeRowTrusted(pkfields, copyRange.Lastpk)
+		}
+		query, err := rs.buildSelect(rs.table, lastpk, copyRange)
+		if err == nil {
+			err = conns[i].ExecuteStreamFetch(query)
+		}
+		if err != nil {
+			closeConns(conns[1:])
+			return "", nil, nil, err
+		}
+		log.Info(fmt.Sprintf("Streaming rows of copy range %d for query: %s", i, query))
+	}
+	return gtid, copyRanges, conns, nil
+}
+
+// splitCopyRanges splits the table into up to n ranges of equal width of its
+// first primary key column, based on the minimum and maximum values of the
+// column in the snapshot read by conn. The first range has no lower bound and
+// the last one has no upper bound, so that together they cover the whole
+// table. If the column is not an integer, the table is not split.
+func (rs *rowStreamer) splitCopyRanges(conn *snapshotConn, n int) ([]*binlogdatapb.CopyRange, error) {
+	field := rs.plan.Table.Fields[rs.pkColumns[0]]
+	if !sqltypes.IsIntegral(field.Type) {
+		return []*binlogdatapb.CopyRange{{}}, nil
+	}
+	pkName := sqlparser.NewIdentifierCI(field.Name)
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("select min(%v), max(%v) from %v", pkName, pkName, sqlparser.NewIdentifierCS(rs.plan.Table.Name))
+	qr, err := conn.ExecuteFetch(buf.String(), 1, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) != 1 || qr.Rows[0][0].IsNull() {
+		// The table is empty.
+		return []*binlogdatapb.CopyRange{{}}, nil
+	}
+	bounds, err := copyRangeBounds(field.Type, qr.Rows[0][0], qr.Rows[0][1], n)
+	if err != nil {
+		return nil, err
+	}
+	copyRanges := make([]*binlogdatapb.CopyRange, len(bounds)+1)
+	for i := range copyRanges {
+		copyRange := &binlogdatapb.CopyRange{}
+		if i > 0 {
+			copyRange.Start = sqltypes.ValueToProto(bounds[i-1])
+		}
+		if i < len(bounds) {
+			copyRange.End = sqltypes.ValueToProto(bounds[i])
+		}
+		copyRanges[i] = copyRange
+	}
+	return copyRanges, nil
+}
+
+// copyRangeBounds returns the values that split [lo, hi] into n ranges of
+// equal width, or into fewer ranges if there are fewer than n values in it.
+// typ is the integral type of the values.
+func copyRangeBounds(typ querypb.Type, lo, hi sqltypes.Value, n int) ([]sqltypes.Value, error) {
+	// Signed values are mapped to unsigned ones preserving their order, so
+	// that the width of the interval cannot overflow.
+	signed := sqltypes.IsSigned(typ)
+	toUint64 := func(v sqltypes.Value) (uint64, error) {
+		if signed {
+			i, err := v.ToInt64()
+			return uint64(i) ^ (1 << 63), err
+		}
+		return v.ToUint64()
+	}
+	fromUint64 := func(u uint64) sqltypes.Value {
+		if signed {
+			return sqltypes.MakeTrusted(typ, strconv.AppendInt(nil, int64(u^(1<<63)), 10))
+		}
+		return sqltypes.MakeTrusted(typ, strconv.AppendUint(nil, u, 10))
+	}
+	low, err := toUint64(lo)
+	if err != nil {
+		return nil, err
+	}
+	high, err := toUint64(hi)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 1 || high <= low {
+		return nil, nil
+	}
+	width := high - low
+	step := width/uint64(n) + 1
+	var bounds []sqltypes.Value
+	for i := uint64(1); i < uint64(n) && i*step <= width; i++ {
+		bounds = append(bounds, fromUint64(low+i*step))
+	}
+	return bounds, nil
+}
+
 func GetVReplicationMaxExecutionTimeQueryHint(copyPhaseDuration time.Duration) string {
 	return fmt.Sprintf("/*+ MAX_EXECUTION_TIME(%v) */ ", copyPhaseDuration.Milliseconds())
 }
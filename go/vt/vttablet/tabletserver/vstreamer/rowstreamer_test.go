@@ -36,6 +36,7 @@ import (
 	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 )
 
 // TestRowStreamerQuery validates that the correct force index hint and order by is added to the rowstreamer query.
@@ -670,6 +671,135 @@ func TestStreamRowsHeartbeat(t *testing.T) {
 	}, 50*time.Millisecond, time.Millisecond, "expected context cancellation to stop row and heartbeat callbacks")
 }
 
+func TestStreamRowsCopyRanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	execStatements(t, []string{
+		"create table t1(id int, val varbinary(128), primary key(id))",
+		"insert into t1 values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, 'e'), (6, 'f'), (7, 'g'), (8, 'h'), (9, 'i'), (10, 'j')",
+		"create table t2(id varbinary(16), val varbinary(128), primary key(id))",
+		"insert into t2 values ('a', 'a'), ('b', 'b')",
+	})
+	defer execStatements(t, []string{
+		"drop table t1",
+		"drop table t2",
+	})
+
+	// streamRanges returns the ranges sent with the fields, and the ids
+	// streamed in each range.
+	streamRanges := func(t *testing.T, query string, options *binlogdatapb.VStreamOptions) ([]*binlogdatapb.CopyRange, map[int32][]string) {
+		var copyRanges []*binlogdatapb.CopyRange
+		ids := make(map[int32][]string)
+		err := engine.StreamRows(t.Context(), query, nil, func(rows *binlogdatapb.VStreamRowsResponse) error {
+			if rows.Fields != nil {
+				copyRanges = rows.CloneVT().CopyRanges
+			}
+			for _, row := range rows.Rows {
+				ids[rows.CopyRange] = append(ids[rows.CopyRange], string(row.Values[:row.Lengths[0]]))
+			}
+			return nil
+		}, options)
+		require.NoError(t, err)
+		return copyRanges, ids
+	}
+
+	copyRanges, ids := streamRanges(t, "select * from t1", &binlogdatapb.VStreamOptions{CopyRangeWorkers: 3})
+	require.Len(t, copyRanges, 3)
+	assert.Nil(t, copyRanges[0].Start)
+	assert.Equal(t, "5", string(copyRanges[0].End.Value))
+	assert.Equal(t, "5", string(copyRanges[1].Start.Value))
+	assert.Equal(t, "9", string(copyRanges[1].End.Value))
+	assert.Equal(t, "9", string(copyRanges[2].Start.Value))
+	assert.Nil(t, copyRanges[2].End)
+	assert.Equal(t, map[int32][]string{
+		0: {"1", "2", "3", "4"},
+		1: {"5", "6", "7", "8"},
+		2: {"9", "10"},
+	}, ids)
+
+	// Resume the copy: the first range copied up to 2, and the second one
+	// is done.
+	copyRanges[0].Lastpk = sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt32(2)})
+	copyRanges[1].Lastpk = sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt32(8)})
+	resumed, ids := streamRanges(t, "select * from t1", &binlogdatapb.VStreamOptions{CopyRanges: copyRanges})
+	require.Len(t, resumed, 3)
+	assert.Equal(t, map[int32][]string{
+		0: {"3", "4"},
+		2: {"9", "10"},
+	}, ids)
+
+	// A table whose primary key is not an integer is not split.
+	copyRanges, ids = streamRanges(t, "select * from t2", &binlogdatapb.VStreamOptions{CopyRangeWorkers: 3})
+	require.Len(t, copyRanges, 1)
+	assert.Nil(t, copyRanges[0].Start)
+	assert.Nil(t, copyRanges[0].End)
+	assert.Equal(t, map[int32][]string{0: {"a", "b"}}, ids)
+}
+
+func TestCopyRangeBounds(t *testing.T) {
+	testCases := []struct {
+		name   string
+		typ    querypb.Type
+		lo, hi string
+		n      int
+		want   []string
+	}{{
+		name: "even split",
+		typ:  querypb.Type_INT64,
+		lo:   "1",
+		hi:   "100",
+		n:    4,
+		want: []string{"26", "51", "76"},
+	}, {
+		name: "fewer values than ranges",
+		typ:  querypb.Type_INT32,
+		lo:   "1",
+		hi:   "3",
+		n:    8,
+		want: []string{"2", "3"},
+	}, {
+		name: "single value",
+		typ:  querypb.Type_INT32,
+		lo:   "7",
+		hi:   "7",
+		n:    4,
+	}, {
+		name: "single range",
+		typ:  querypb.Type_INT32,
+		lo:   "1",
+		hi:   "100",
+		n:    1,
+	}, {
+		name: "negative values",
+		typ:  querypb.Type_INT64,
+		lo:   "-9223372036854775808",
+		hi:   "9223372036854775807",
+		n:    2,
+		want: []string{"0"},
+	}, {
+		name: "unsigned values",
+		typ:  querypb.Type_UINT64,
+		lo:   "0",
+		hi:   "18446744073709551615",
+		n:    2,
+		want: []string{"9223372036854775808"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bounds, err := copyRangeBounds(tc.typ, sqltypes.MakeTrusted(tc.typ, []byte(tc.lo)), sqltypes.MakeTrusted(tc.typ, []byte(tc.hi)), tc.n)
+			require.NoError(t, err)
+			var got []string
+			for _, bound := range bounds {
+				assert.Equal(t, tc.typ, bound.Type())
+				got = append(got, bound.ToString())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func checkStream(t *testing.T, query string, lastpk []sqltypes.Value, wantQuery string, wantStream []string, options *binlogdatapb.VStreamOptions) {
 	t.Helper()
 
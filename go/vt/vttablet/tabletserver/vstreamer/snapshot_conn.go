@@ -97,7 +97,14 @@ func (conn *snapshotConn) streamWithSnapshot(ctx context.Context, table, query s
 
 // snapshot performs the snapshotting.
 func (conn *snapshotConn) startSnapshot(ctx context.Context, table string) (gtid string, err error) {
-	lockConn, err := mysqlConnect(ctx, conn.cp)
+	return startSharedSnapshot(ctx, conn.cp, table, conn)
+}
+
+// startSharedSnapshot starts a snapshot of the specified table on each of
+// conns while the table is locked, so that they all read it as of the
+// returned GTID set.
+func startSharedSnapshot(ctx context.Context, cp dbconfigs.Connector, table string, conns ...*snapshotConn) (gtid string, err error) {
+	lockConn, err := mysqlConnect(ctx, cp)
 	if err != nil {
 		return "", err
 	}
@@ -129,14 +136,16 @@ func (conn *snapshotConn) startSnapshot(ctx context.Context, table string) (gtid
 
 	// Starting a transaction now will allow us to start the read later,
 	// which will happen after we release the lock on the table.
-	if _, err := conn.ExecuteFetch("set transaction isolation level repeatable read", 1, false); err != nil {
-		return "", err
-	}
-	if _, err := conn.ExecuteFetch("start transaction with consistent snapshot, read only", 1, false); err != nil {
-		return "", err
-	}
-	if _, err := conn.ExecuteFetch("set @@session.time_zone = '+00:00'", 1, false); err != nil {
-		return "", err
+	for _, conn := range conns {
+		if _, err := conn.ExecuteFetch("set transaction isolation level repeatable read", 1, false); err != nil {
+			return "", err
+		}
+		if _, err := conn.ExecuteFetch("start transaction with consistent snapshot, read only", 1, false); err != nil {
+			return "", err
+		}
+		if _, err := conn.ExecuteFetch("set @@session.time_zone = '+00:00'", 1, false); err != nil {
+			return "", err
+		}
 	}
 	return replication.EncodePosition(mpos), nil
 }
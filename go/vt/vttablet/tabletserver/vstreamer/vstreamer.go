@@ -97,6 +97,11 @@ type vstreamer struct {
 	sequenceNumber int64
 	eventGTID      replication.GTID
 
+	// excludedServers are the servers whose transactions are not streamed,
+	// and excludeTransaction is set while parsing one of their transactions.
+	excludedServers    map[replication.SID]bool
+	excludeTransaction bool
+
 	phase   string
 	vse     *Engine
 	options *binlogdatapb.VStreamOptions
@@ -225,6 +230,9 @@ func (vs *vstreamer) replicate(ctx context.Context) error {
 	if err := vs.refreshHistorianForStartup(ctx); err != nil {
 		return wrapError(err, vs.pos, vs.vse)
 	}
+	if err := vs.initExcludedServers(); err != nil {
+		return wrapError(err, vs.pos, vs.vse)
+	}
 
 	conn, err := binlog.NewBinlogConnection(vs.cp)
 	if err != nil {
@@ -240,6 +248,49 @@ func (vs *vstreamer) replicate(ctx context.Context) error {
 	return wrapError(err, vs.pos, vs.vse)
 }
 
+// initExcludedServers sets up the servers whose transactions are not streamed.
+// The transactions are recognized by the server UUID of their GTID, which only
+// MySQL replication preserves: a transaction applied by VReplication gets the
+// GTID of its target, so excluding servers does not break the loop of two
+// workflows replicating in opposite directions. Streaming is refused if the
+// source server itself is excluded, since none of the changes made on it would
+// be streamed.
+func (vs *vstreamer) initExcludedServers() error {
+	if vs.config == nil || len(vs.config.VStreamExcludedServerUUIDs) == 0 {
+		return nil
+	}
+	if !vs.pos.MatchesFlavor(replication.Mysql56FlavorID) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "excluding servers from the stream requires MySQL GTIDs, got position %s", replication.EncodePosition(vs.pos))
+	}
+	excluded := make(map[replication.SID]bool, len(vs.config.VStreamExcludedServerUUIDs))
+	for _, uuid := range vs.config.VStreamExcludedServerUUIDs {
+		sid, err := replication.ParseSID(uuid)
+		if err != nil {
+			return vterrors.Wrapf(err, "invalid excluded server UUID %s", uuid)
+		}
+		excluded[sid] = true
+	}
+
+	conn, err := vs.cp.Connect(vs.ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	serverUUID, err := conn.GetServerUUID()
+	if err != nil {
+		return err
+	}
+	sid, err := replication.ParseSID(serverUUID)
+	if err != nil {
+		return vterrors.Wrapf(err, "invalid server UUID %s", serverUUID)
+	}
+	if excluded[sid] {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the server UUID %s of the source is excluded from the stream, none of its changes would be streamed", serverUUID)
+	}
+	vs.excludedServers = excluded
+	return nil
+}
+
 func (vs *vstreamer) refreshHistorianForStartup(ctx context.Context) error {
 	if vs.historianRefreshedForStartup {
 		return nil
@@ -533,6 +584,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 			return nil, fmt.Errorf("can't parse FORMAT_DESCRIPTION_EVENT: %v, event data: %#v", err, ev)
 		}
 		vs.eventGTID = nil
+		vs.excludeTransaction = false
 		return nil, nil
 	}
 
@@ -566,6 +618,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 	switch {
 	case ev.IsRotate(), ev.IsStop():
 		vs.eventGTID = nil
+		vs.excludeTransaction = false
 	case ev.IsPreviousGTIDs():
 		if !shouldSend(binlogdatapb.VEventType_PREVIOUS_GTIDS) {
 			return nil, nil
@@ -574,6 +627,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 			Type: binlogdatapb.VEventType_PREVIOUS_GTIDS,
 		})
 		vs.eventGTID = nil
+		vs.excludeTransaction = false
 	case ev.IsGTID():
 		gtid, hasBegin, commitParent, sequenceNumber, err := ev.GTID(vs.format)
 		if err != nil {
@@ -586,6 +640,13 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 				SequenceNumber: sequenceNumber,
 			})
 		}
+		// The changes of an excluded transaction are not streamed, but its
+		// GTID still is, so that the position moves past it.
+		sid, _ := gtid.SourceServer().(replication.SID)
+		vs.excludeTransaction = vs.excludedServers[sid]
+		if vs.excludeTransaction {
+			vs.vse.vstreamerTransactionsExcluded.Add(1)
+		}
 		vs.pos = replication.AppendGTID(vs.pos, gtid)
 		vs.commitParent = commitParent
 		vs.sequenceNumber = sequenceNumber
@@ -612,7 +673,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 		// could be using SBR. Vitess itself will never run into cases where it needs to consume non rbr statements.
 		switch cat := sqlparser.Preview(q.SQL); cat {
 		case sqlparser.StmtInsert:
-			if !shouldSend(binlogdatapb.VEventType_INSERT) {
+			if !shouldSend(binlogdatapb.VEventType_INSERT) || vs.excludeTransaction {
 				return nil, nil
 			}
 			mustSend := mustSendStmt(q, vs.cp.DBName())
@@ -623,7 +684,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 				})
 			}
 		case sqlparser.StmtUpdate:
-			if !shouldSend(binlogdatapb.VEventType_UPDATE) {
+			if !shouldSend(binlogdatapb.VEventType_UPDATE) || vs.excludeTransaction {
 				return nil, nil
 			}
 			mustSend := mustSendStmt(q, vs.cp.DBName())
@@ -634,7 +695,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 				})
 			}
 		case sqlparser.StmtDelete:
-			if !shouldSend(binlogdatapb.VEventType_DELETE) {
+			if !shouldSend(binlogdatapb.VEventType_DELETE) || vs.excludeTransaction {
 				return nil, nil
 			}
 			mustSend := mustSendStmt(q, vs.cp.DBName())
@@ -645,7 +706,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 				})
 			}
 		case sqlparser.StmtReplace:
-			if !shouldSend(binlogdatapb.VEventType_REPLACE) {
+			if !shouldSend(binlogdatapb.VEventType_REPLACE) || vs.excludeTransaction {
 				return nil, nil
 			}
 			mustSend := mustSendStmt(q, vs.cp.DBName())
@@ -676,7 +737,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 					Gtid: replication.EncodePosition(vs.pos),
 				})
 			}
-			if mustSendDDL(q, vs.cp.DBName(), vs.filter, vs.vse.env.Environment().Parser()) {
+			if !vs.excludeTransaction && mustSendDDL(q, vs.cp.DBName(), vs.filter, vs.vse.env.Environment().Parser()) {
 				vevents = append(vevents, &binlogdatapb.VEvent{
 					Type:      binlogdatapb.VEventType_DDL,
 					Statement: q.SQL,
@@ -728,7 +789,7 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 			return nil, fmt.Errorf("unexpected statement type %s in row-based replication: %q", cat, q.SQL)
 		}
 	case ev.IsRowsQuery():
-		if !shouldSend(binlogdatapb.VEventType_ROWS_QUERY) {
+		if !shouldSend(binlogdatapb.VEventType_ROWS_QUERY) || vs.excludeTransaction {
 			return nil, nil
 		}
 		query, err := ev.RowsQuery(vs.format)
@@ -755,6 +816,11 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 		if err != nil {
 			return nil, vterrors.Wrapf(err, "failed to parse table map from binlog event: %#v", ev)
 		}
+		// The rows of the tables of an excluded transaction are not streamed,
+		// but those of the sidecar tables still are.
+		if vs.excludeTransaction && tm.Database != sidecar.GetName() {
+			return nil, nil
+		}
 		if plan, ok := vs.plans[id]; ok {
 			// When the underlying mysql server restarts the table map can change.
 			// Usually the vstreamer will also error out when this happens, and vstreamer re-initializes its table map.
@@ -811,6 +877,9 @@ func (vs *vstreamer) parseEvent(ev mysql.BinlogEvent, bufferAndTransmit func(vev
 		if plan == nil {
 			return nil, nil
 		}
+		if vs.excludeTransaction && !plan.IsInternal {
+			return nil, nil
+		}
 		rows, err := ev.Rows(vs.format, plan.TableMap)
 		if err != nil {
 			return nil, err
@@ -1887,6 +1887,76 @@ func TestVStreamFilteredTerminalEventsFlushBufferedState(t *testing.T) {
 	wg.Wait()
 }
 
+func TestExcludedServers(t *testing.T) {
+	execStatement(t, "create table excluded_servers(id int, val varbinary(128), primary key(id))")
+	defer execStatement(t, "drop table excluded_servers")
+
+	const excludedUUID = "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	pos := primaryPosition(t)
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{
+			Match: "excluded_servers",
+		}},
+	}
+	options := &binlogdatapb.VStreamOptions{
+		ConfigOverrides: map[string]string{"vstream-exclude-server-uuids": excludedUUID},
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		rows     []string
+		lastGTID string
+		wg       sync.WaitGroup
+	)
+	wg.Go(func() {
+		_ = engine.Stream(ctx, pos, nil, filter, throttlerapp.VStreamerName, func(evs []*binlogdatapb.VEvent) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, ev := range evs {
+				switch ev.Type {
+				case binlogdatapb.VEventType_ROW:
+					for _, change := range ev.RowEvent.RowChanges {
+						rows = append(rows, string(change.After.Values))
+					}
+				case binlogdatapb.VEventType_GTID:
+					lastGTID = ev.Gtid
+				}
+			}
+			if len(rows) > 0 {
+				return io.EOF
+			}
+			return nil
+		}, options)
+	})
+
+	// The change coming back from the excluded server is not streamed, but
+	// the position moves past it.
+	execStatements(t, []string{
+		fmt.Sprintf("set gtid_next='%s:1'", excludedUUID),
+		"insert into excluded_servers values (1, 'looped')",
+		"set gtid_next='AUTOMATIC'",
+		"insert into excluded_servers values (2, 'local')",
+	})
+	wg.Wait()
+
+	mu.Lock()
+	assert.Equal(t, []string{"2local"}, rows)
+	assert.Contains(t, lastGTID, excludedUUID+":1")
+	mu.Unlock()
+
+	// The source server itself cannot be excluded.
+	qr, err := env.Mysqld.FetchSuperQuery(ctx, "select @@global.server_uuid")
+	require.NoError(t, err)
+	options.ConfigOverrides["vstream-exclude-server-uuids"] = qr.Rows[0][0].ToString()
+	err = engine.Stream(ctx, pos, nil, filter, throttlerapp.VStreamerName, func([]*binlogdatapb.VEvent) error {
+		return nil
+	}, options)
+	assert.ErrorContains(t, err, "of the source is excluded from the stream")
+}
+
 func TestBuffering(t *testing.T) {
 	reset := AdjustPacketSize(10)
 	defer reset()
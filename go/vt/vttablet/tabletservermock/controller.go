@@ -99,7 +99,7 @@ type Controller struct {
 // NewController returns a mock of tabletserver.Controller
 func NewController() *Controller {
 	return &Controller{
-		stats:               tabletenv.NewStats(servenv.NewExporter("MockController", "Tablet")),
+		stats:               tabletenv.NewStats(servenv.NewExporter("MockController", "Tablet"), 0),
 		queryServiceEnabled: false,
 		BroadcastData:       make(chan *BroadcastData, 10),
 		StateChanges:        make(chan *StateChange, 10),
@@ -285,6 +285,11 @@ func (tqsc *Controller) IsDiskStalled() bool {
 	return false
 }
 
+// WarmUpOnNextServe is part of the tabletserver.Controller interface
+func (tqsc *Controller) WarmUpOnNextServe() {
+	tqsc.MethodCalled["WarmUpOnNextServe"] = true
+}
+
 // EnterLameduck implements tabletserver.Controller.
 func (tqsc *Controller) EnterLameduck() {
 	tqsc.mu.Lock()
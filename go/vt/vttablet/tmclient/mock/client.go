@@ -122,6 +122,21 @@ func (mr *MockTabletManagerClientMockRecorder) CheckThrottler(ctx, tablet, reque
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckThrottler", reflect.TypeOf((*MockTabletManagerClient)(nil).CheckThrottler), ctx, tablet, request)
 }
 
+// CloneFromTablet mocks base method.
+func (m *MockTabletManagerClient) CloneFromTablet(ctx context.Context, tablet *topodata.Tablet, req *tabletmanagerdata.CloneFromTabletRequest) (logutil.EventStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneFromTablet", ctx, tablet, req)
+	ret0, _ := ret[0].(logutil.EventStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneFromTablet indicates an expected call of CloneFromTablet.
+func (mr *MockTabletManagerClientMockRecorder) CloneFromTablet(ctx, tablet, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneFromTablet", reflect.TypeOf((*MockTabletManagerClient)(nil).CloneFromTablet), ctx, tablet, req)
+}
+
 // Close mocks base method.
 func (m *MockTabletManagerClient) Close() {
 	m.ctrl.T.Helper()
@@ -388,6 +403,21 @@ func (mr *MockTabletManagerClientMockRecorder) GetSchema(ctx, tablet, request an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchema", reflect.TypeOf((*MockTabletManagerClient)(nil).GetSchema), ctx, tablet, request)
 }
 
+// GetSchemaSnapshot mocks base method.
+func (m *MockTabletManagerClient) GetSchemaSnapshot(ctx context.Context, tablet *topodata.Tablet, sinceVersion string) (*tabletmanagerdata.GetSchemaSnapshotResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaSnapshot", ctx, tablet, sinceVersion)
+	ret0, _ := ret[0].(*tabletmanagerdata.GetSchemaSnapshotResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaSnapshot indicates an expected call of GetSchemaSnapshot.
+func (mr *MockTabletManagerClientMockRecorder) GetSchemaSnapshot(ctx, tablet, sinceVersion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaSnapshot", reflect.TypeOf((*MockTabletManagerClient)(nil).GetSchemaSnapshot), ctx, tablet, sinceVersion)
+}
+
 // GetThrottlerStatus mocks base method.
 func (m *MockTabletManagerClient) GetThrottlerStatus(ctx context.Context, tablet *topodata.Tablet, request *tabletmanagerdata.GetThrottlerStatusRequest) (*tabletmanagerdata.GetThrottlerStatusResponse, error) {
 	m.ctrl.T.Helper()
@@ -594,6 +624,21 @@ func (mr *MockTabletManagerClientMockRecorder) PromoteReplica(ctx, tablet, semiS
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteReplica", reflect.TypeOf((*MockTabletManagerClient)(nil).PromoteReplica), ctx, tablet, semiSync)
 }
 
+// PurgeBinaryLogs mocks base method.
+func (m *MockTabletManagerClient) PurgeBinaryLogs(ctx context.Context, tablet *topodata.Tablet, request *tabletmanagerdata.PurgeBinaryLogsRequest) (*tabletmanagerdata.PurgeBinaryLogsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBinaryLogs", ctx, tablet, request)
+	ret0, _ := ret[0].(*tabletmanagerdata.PurgeBinaryLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeBinaryLogs indicates an expected call of PurgeBinaryLogs.
+func (mr *MockTabletManagerClientMockRecorder) PurgeBinaryLogs(ctx, tablet, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBinaryLogs", reflect.TypeOf((*MockTabletManagerClient)(nil).PurgeBinaryLogs), ctx, tablet, request)
+}
+
 // ReadReparentJournalInfo mocks base method.
 func (m *MockTabletManagerClient) ReadReparentJournalInfo(ctx context.Context, tablet *topodata.Tablet) (int32, error) {
 	m.ctrl.T.Helper()
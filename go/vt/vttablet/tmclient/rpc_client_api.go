@@ -74,6 +74,11 @@ type TabletManagerClient interface {
 	// GetSchema asks the remote tablet for its database schema
 	GetSchema(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetSchemaRequest) (*tabletmanagerdatapb.SchemaDefinition, error)
 
+	// GetSchemaSnapshot asks the remote tablet for a snapshot of its schema.
+	// If sinceVersion is the version of a previous snapshot the tablet still
+	// knows, the snapshot only contains the changes since that snapshot.
+	GetSchemaSnapshot(ctx context.Context, tablet *topodatapb.Tablet, sinceVersion string) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error)
+
 	// GetPermissions asks the remote tablet for its permissions list
 	GetPermissions(ctx context.Context, tablet *topodatapb.Tablet) (*tabletmanagerdatapb.Permissions, error)
 
@@ -268,6 +273,10 @@ type TabletManagerClient interface {
 	// ResetReplicationParameters resets the replica replication parameters
 	ResetReplicationParameters(ctx context.Context, tablet *topodatapb.Tablet) error
 
+	// PurgeBinaryLogs purges the binary logs of a tablet that are no longer
+	// needed by any VReplication stream reading from its shard.
+	PurgeBinaryLogs(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error)
+
 	// SetReplicationSource tells a tablet to start replicating from the
 	// passed in tablet alias, and wait for the row in the
 	// reparent_journal table (if timeCreatedNS is non-zero).
@@ -293,6 +302,10 @@ type TabletManagerClient interface {
 	// RestoreFromBackup deletes local data and restores database from backup
 	RestoreFromBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromBackupRequest) (logutil.EventStream, error)
 
+	// CloneFromTablet deletes local data and clones the data of another
+	// tablet with the MySQL CLONE plugin
+	CloneFromTablet(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CloneFromTabletRequest) (logutil.EventStream, error)
+
 	// Throttler
 	CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error)
 	GetThrottlerStatus(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetThrottlerStatusRequest) (*tabletmanagerdatapb.GetThrottlerStatusResponse, error)
@@ -370,6 +370,50 @@ func tmRPCTestGetSchemaPanic(ctx context.Context, t *testing.T, client tmclient.
 	expectHandleRPCPanic(t, "GetSchema", false /*verbose*/, err)
 }
 
+var testGetSchemaSnapshotReply = &tabletmanagerdatapb.GetSchemaSnapshotResponse{
+	Version: "version2",
+	Tables: []*tabletmanagerdatapb.SchemaSnapshotTable{{
+		Name:    "table_name",
+		Version: "table_version",
+		Columns: []*tabletmanagerdatapb.SchemaSnapshotColumn{{
+			Name:       "col1",
+			Type:       querypb.Type_INT64,
+			ColumnType: "bigint",
+		}, {
+			Name:       "col2",
+			Type:       querypb.Type_VARCHAR,
+			ColumnType: "varchar(255)",
+			Collation:  "utf8mb4_0900_ai_ci",
+			Nullable:   true,
+		}},
+		PrimaryKeyColumns: []string{"col1"},
+		Indexes: []*tabletmanagerdatapb.SchemaSnapshotIndex{{
+			Name:    "PRIMARY",
+			Unique:  true,
+			Columns: []string{"col1"},
+		}},
+	}},
+	DroppedTables: []string{"table_name2"},
+}
+
+func (fra *fakeRPCTM) GetSchemaSnapshot(ctx context.Context, request *tabletmanagerdatapb.GetSchemaSnapshotRequest) (*tabletmanagerdatapb.GetSchemaSnapshotResponse, error) {
+	if fra.panics {
+		panic(errors.New("test-triggered panic"))
+	}
+	compare(fra.t, "GetSchemaSnapshot sinceVersion", request.SinceVersion, "version1")
+	return testGetSchemaSnapshotReply, nil
+}
+
+func tmRPCTestGetSchemaSnapshot(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	result, err := client.GetSchemaSnapshot(ctx, tablet, "version1")
+	compareError(t, "GetSchemaSnapshot", err, result, testGetSchemaSnapshotReply)
+}
+
+func tmRPCTestGetSchemaSnapshotPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetSchemaSnapshot(ctx, tablet, "version1")
+	expectHandleRPCPanic(t, "GetSchemaSnapshot", false /*verbose*/, err)
+}
+
 var testGetPermissionsReply = &tabletmanagerdatapb.Permissions{
 	UserPermissions: []*tabletmanagerdatapb.UserPermission{
 		{
@@ -1362,6 +1406,35 @@ func tmRPCTestResetReplicationParametersPanic(ctx context.Context, t *testing.T,
 	expectHandleRPCPanic(t, "ResetReplicationParameters", true /*verbose*/, err)
 }
 
+var (
+	testPurgeBinaryLogsRequest = &tabletmanagerdatapb.PurgeBinaryLogsRequest{
+		Flush:         true,
+		MinBinaryLogs: 3,
+	}
+	testPurgeBinaryLogsResponse = &tabletmanagerdatapb.PurgeBinaryLogsResponse{
+		PurgedBinaryLogs:   []string{"vt-bin.000001", "vt-bin.000002"},
+		RetainedBinaryLogs: []string{"vt-bin.000003", "vt-bin.000004", "vt-bin.000005"},
+	}
+)
+
+func (fra *fakeRPCTM) PurgeBinaryLogs(ctx context.Context, req *tabletmanagerdatapb.PurgeBinaryLogsRequest) (*tabletmanagerdatapb.PurgeBinaryLogsResponse, error) {
+	if fra.panics {
+		panic(errors.New("test-triggered panic"))
+	}
+	compare(fra.t, "PurgeBinaryLogs request", req, testPurgeBinaryLogsRequest)
+	return testPurgeBinaryLogsResponse, nil
+}
+
+func tmRPCTestPurgeBinaryLogs(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	resp, err := client.PurgeBinaryLogs(ctx, tablet, testPurgeBinaryLogsRequest)
+	compareError(t, "PurgeBinaryLogs", err, resp, testPurgeBinaryLogsResponse)
+}
+
+func tmRPCTestPurgeBinaryLogsPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.PurgeBinaryLogs(ctx, tablet, testPurgeBinaryLogsRequest)
+	expectHandleRPCPanic(t, "PurgeBinaryLogs", true /*verbose*/, err)
+}
+
 var (
 	testSetReplicationSourceCalled         = false
 	testForceStartReplica                  = true
@@ -1466,6 +1539,8 @@ var (
 	testBackupAllowPrimary      = false
 	testBackupCalled            = false
 	testRestoreFromBackupCalled = false
+	testCloneFromTabletCalled   = false
+	testCloneDonorAlias         = &topodatapb.TabletAlias{Cell: "test", Uid: 100}
 )
 
 func (fra *fakeRPCTM) Backup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.BackupRequest) error {
@@ -1515,6 +1590,16 @@ func (fra *fakeRPCTM) RestoreFromBackup(ctx context.Context, logger logutil.Logg
 	return nil
 }
 
+func (fra *fakeRPCTM) CloneFromTablet(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.CloneFromTabletRequest) error {
+	if fra.panics {
+		panic(errors.New("test-triggered panic"))
+	}
+	compare(fra.t, "CloneFromTablet args", request.DonorAlias, testCloneDonorAlias)
+	logStuff(logger, 10)
+	testCloneFromTabletCalled = true
+	return nil
+}
+
 func (fra *fakeRPCTM) CheckThrottler(ctx context.Context, req *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	if fra.panics {
 		panic(errors.New("test-triggered panic"))
@@ -1554,6 +1639,27 @@ func tmRPCTestRestoreFromBackupPanic(ctx context.Context, t *testing.T, client t
 	expectHandleRPCPanic(t, "RestoreFromBackup", true /*verbose*/, err)
 }
 
+func tmRPCTestCloneFromTablet(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	stream, err := client.CloneFromTablet(ctx, tablet, &tabletmanagerdatapb.CloneFromTabletRequest{DonorAlias: testCloneDonorAlias})
+	if err != nil {
+		t.Fatalf("CloneFromTablet failed: %v", err)
+	}
+	err = compareLoggedStuff(t, "CloneFromTablet", stream, 10)
+	compareError(t, "CloneFromTablet", err, true, testCloneFromTabletCalled)
+}
+
+func tmRPCTestCloneFromTabletPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	stream, err := client.CloneFromTablet(ctx, tablet, &tabletmanagerdatapb.CloneFromTabletRequest{DonorAlias: testCloneDonorAlias})
+	if err != nil {
+		t.Fatalf("CloneFromTablet failed: %v", err)
+	}
+	e, err := stream.Recv()
+	if err == nil {
+		t.Fatalf("Unexpected CloneFromTablet logs: %v", e)
+	}
+	expectHandleRPCPanic(t, "CloneFromTablet", true /*verbose*/, err)
+}
+
 func tmRPCTestCheckThrottler(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CheckThrottlerRequest) {
 	_, err := client.CheckThrottler(ctx, tablet, req)
 	expectHandleRPCPanic(t, "CheckThrottler", false /*verbose*/, err)
@@ -1594,6 +1700,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	// Various read-only methods
 	tmRPCTestPing(ctx, t, client, tablet)
 	tmRPCTestGetSchema(ctx, t, client, tablet)
+	tmRPCTestGetSchemaSnapshot(ctx, t, client, tablet)
 	tmRPCTestGetPermissions(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVars(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactions(ctx, t, client, tablet)
@@ -1645,10 +1752,12 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestReplicaWasPromoted(ctx, t, client, tablet)
 	tmRPCTestReplicaWasRestarted(ctx, t, client, tablet)
 	tmRPCTestResetReplicationParameters(ctx, t, client, tablet)
+	tmRPCTestPurgeBinaryLogs(ctx, t, client, tablet)
 
 	// Backup / restore related methods
 	tmRPCTestBackup(ctx, t, client, tablet)
 	tmRPCTestRestoreFromBackup(ctx, t, client, tablet, restoreFromBackupRequest)
+	tmRPCTestCloneFromTablet(ctx, t, client, tablet)
 
 	// Throttler related methods
 	tmRPCTestCheckThrottler(ctx, t, client, tablet, checkThrottlerRequest)
@@ -1661,6 +1770,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	// Various read-only methods
 	tmRPCTestPingPanic(ctx, t, client, tablet)
 	tmRPCTestGetSchemaPanic(ctx, t, client, tablet)
+	tmRPCTestGetSchemaSnapshotPanic(ctx, t, client, tablet)
 	tmRPCTestGetPermissionsPanic(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVarsPanic(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactionsPanic(ctx, t, client, tablet)
@@ -1707,10 +1817,12 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestInitReplicaPanic(ctx, t, client, tablet)
 	tmRPCTestReplicaWasPromotedPanic(ctx, t, client, tablet)
 	tmRPCTestResetReplicationParametersPanic(ctx, t, client, tablet)
+	tmRPCTestPurgeBinaryLogsPanic(ctx, t, client, tablet)
 	tmRPCTestReplicaWasRestartedPanic(ctx, t, client, tablet)
 	// Backup / restore related methods
 	tmRPCTestBackupPanic(ctx, t, client, tablet)
 	tmRPCTestRestoreFromBackupPanic(ctx, t, client, tablet, restoreFromBackupRequest)
+	tmRPCTestCloneFromTabletPanic(ctx, t, client, tablet)
 
 	client.Close()
 }
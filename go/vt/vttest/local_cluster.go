@@ -18,13 +18,16 @@ package vttest
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
@@ -41,6 +44,7 @@ import (
 	"vitess.io/vitess/go/vt/vtenv"
 
 	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/json2"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
@@ -81,6 +85,13 @@ type Config struct {
 	// as the VSchema for the V3 API
 	SchemaDir string
 
+	// SeedDir is the directory for SQL seed files. Within this dir, there
+	// should be a subdir for each keyspace. Within each keyspace dir, each
+	// .sql file is executed through vtgate, in lexical order, once the
+	// cluster is up and the schema has been loaded. Seeds are not applied
+	// in OnlyMySQL mode.
+	SeedDir string
+
 	// DefaultSchemaDir is the default directory for initial schema files.
 	// If no schema is found in SchemaDir, default to this location.
 	DefaultSchemaDir string
@@ -408,6 +419,10 @@ func (db *LocalCluster) Setup() error {
 			return err
 		}
 
+		if err := db.loadSeeds(); err != nil {
+			return err
+		}
+
 		if db.Seed != nil {
 			log.Info("Populating database with random data...")
 			if err := db.populateWithRandomData(); err != nil {
@@ -534,6 +549,24 @@ func (db *LocalCluster) loadSchema(shouldRunDatabaseMigrations bool) error {
 	return nil
 }
 
+func (db *LocalCluster) loadSeeds() error {
+	if db.SeedDir == "" || db.OnlyMySQL {
+		return nil
+	}
+
+	log.Info("Loading seed data...")
+
+	for _, kpb := range db.Topology.Keyspaces {
+		glob, _ := filepath.Glob(path.Join(db.SeedDir, kpb.Name, "*.sql"))
+		for _, filename := range glob {
+			if err := db.LoadSeedFile(kpb.Name, filename); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (db *LocalCluster) createVTSchema() error {
 	var sidecardbExec sidecardb.Exec = func(ctx context.Context, query string, maxRows int, useDB bool) (*sqltypes.Result, error) {
 		if useDB {
@@ -770,6 +803,38 @@ func LoadSQLFile(filename, sourceroot string) ([]string, error) {
 	return sql, nil
 }
 
+// SeedKeyspace executes the given SQL statements through vtgate, targeting
+// keyspace, so rows in sharded keyspaces are routed by their vindexes.
+// Statements are separated by semicolons and share a session, so explicit
+// transactions are allowed.
+func (db *LocalCluster) SeedKeyspace(keyspace, sql string) error {
+	if db.vt == nil {
+		return errors.New("SeedKeyspace(): vtcombo is not running")
+	}
+	return db.vt.Seed(keyspace, sql)
+}
+
+// LoadSeedFile executes the SQL statements in filename through vtgate,
+// targeting keyspace. See SeedKeyspace.
+func (db *LocalCluster) LoadSeedFile(keyspace, filename string) error {
+	sql, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	log.Info("Seeding keyspace", slog.String("keyspace", keyspace), slog.String("file", filename))
+	return db.SeedKeyspace(keyspace, string(sql))
+}
+
+// PatchVSchema merges patch into the vschema of keyspace on the running
+// cluster. Tables and vindexes in patch are added, replacing any existing
+// entry with the same name.
+func (db *LocalCluster) PatchVSchema(keyspace string, patch *vschemapb.Keyspace) error {
+	if db.vt == nil {
+		return errors.New("PatchVSchema(): vtcombo is not running")
+	}
+	return db.vt.PatchVSchema(keyspace, patch)
+}
+
 func (db *LocalCluster) VTProcess() *VtProcess {
 	return db.vt
 }
@@ -794,3 +859,43 @@ func (vt *VtProcess) ReadVSchema() (*any, error) {
 	}
 	return &results, nil
 }
+
+// Seed posts sql to the seed endpoint of vtcombo, which executes it against
+// keyspace.
+func (vt *VtProcess) Seed(keyspace, sql string) error {
+	return vt.postFixture("/debug/vtcombo/seed", keyspace, "text/plain", []byte(sql))
+}
+
+// PatchVSchema posts patch to the vschema endpoint of vtcombo, which merges
+// it into the vschema of keyspace.
+func (vt *VtProcess) PatchVSchema(keyspace string, patch *vschemapb.Keyspace) error {
+	data, err := json2.MarshalPB(patch)
+	if err != nil {
+		return err
+	}
+	return vt.postFixture("/debug/vtcombo/vschema", keyspace, "application/json", data)
+}
+
+func (vt *VtProcess) postFixture(endpoint, keyspace, contentType string, body []byte) error {
+	u := "http://" + net.JoinHostPort(vt.BindAddress, strconv.Itoa(vt.Port)) + endpoint + "?keyspace=" + url.QueryEscape(keyspace)
+	httpClient := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := httpClient.Post(u, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	res, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var result struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(res, &result) == nil && result.Error != "" {
+			return fmt.Errorf("%s: %s", endpoint, result.Error)
+		}
+		return fmt.Errorf("%s: %s: %s", endpoint, resp.Status, strings.TrimSpace(string(res)))
+	}
+	return nil
+}
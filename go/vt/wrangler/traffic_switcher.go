@@ -293,9 +293,9 @@ func (wr *Wrangler) getWorkflowState(ctx context.Context, targetKeyspace, workfl
 				return nil, nil, err
 			}
 			for _, table := range ts.Tables() {
-				rr := globalRules[table]
-				// If a rule exists for the table and points to the target keyspace, writes
-				// have been switched.
+				rr := globalRules.ToTables(table, time.Now())
+				// If a rule in effect exists for the table and points to the target keyspace,
+				// writes have been switched.
 				if len(rr) > 0 && rr[0] == fmt.Sprintf("%s.%s", targetKeyspace, table) {
 					state.WritesSwitched = true
 					break
@@ -1136,16 +1136,16 @@ func (ts *trafficSwitcher) switchTableReads(ctx context.Context, cells []string,
 		for _, table := range ts.Tables() {
 			if direction == workflow.DirectionForward {
 				log.Info("Route direction forward")
-				toTarget := []string{ts.TargetKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toTarget
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toTarget
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toTarget
+				toTarget := ts.TargetKeyspaceName() + "." + table
+				rules.Set(table+"@"+tt, toTarget)
+				rules.Set(ts.TargetKeyspaceName()+"."+table+"@"+tt, toTarget)
+				rules.Set(ts.SourceKeyspaceName()+"."+table+"@"+tt, toTarget)
 			} else {
 				log.Info("Route direction backwards")
-				toSource := []string{ts.SourceKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toSource
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toSource
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toSource
+				toSource := ts.SourceKeyspaceName() + "." + table
+				rules.Set(table+"@"+tt, toSource)
+				rules.Set(ts.TargetKeyspaceName()+"."+table+"@"+tt, toSource)
+				rules.Set(ts.SourceKeyspaceName()+"."+table+"@"+tt, toSource)
 			}
 		}
 	}
@@ -1596,8 +1596,8 @@ func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
 			sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
 			delete(rules, targetKsTable)
 			ts.Logger().Infof("Deleted routing: %s", targetKsTable)
-			rules[table] = []string{targetKsTable}
-			rules[sourceKsTable] = []string{targetKsTable}
+			rules.Set(table, targetKsTable)
+			rules.Set(sourceKsTable, targetKsTable)
 			ts.Logger().Infof("Added routing: %v %v", table, sourceKsTable)
 		}
 		if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
@@ -1767,11 +1767,13 @@ func doValidateWorkflowHasCompleted(ctx context.Context, ts *trafficSwitcher) er
 			if err != nil {
 				rec.RecordError(errors.New("could not get RoutingRules"))
 			}
-			for fromTable, toTables := range rules {
-				for _, toTable := range toTables {
-					for _, table := range ts.Tables() {
-						if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table) {
-							rec.RecordError(fmt.Errorf("routing still exists from keyspace %s table %s to %s", ts.SourceKeyspaceName(), table, fromTable))
+			for fromTable, rrs := range rules {
+				for _, rr := range rrs {
+					for _, toTable := range rr.ToTables {
+						for _, table := range ts.Tables() {
+							if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table) {
+								rec.RecordError(fmt.Errorf("routing still exists from keyspace %s table %s to %s", ts.SourceKeyspaceName(), table, fromTable))
+							}
 						}
 					}
 				}
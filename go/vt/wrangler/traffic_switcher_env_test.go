@@ -357,12 +357,12 @@ func newTestTableMigraterCustom(ctx context.Context, t *testing.T, sourceShards,
 		)
 	}
 
-	require.NoError(t, topotools.SaveRoutingRules(ctx, tme.wr.ts, map[string][]string{
+	require.NoError(t, topotools.SaveRoutingRules(ctx, tme.wr.ts, topotools.NewRoutingRules(map[string][]string{
 		"t1":     {"ks1.t1"},
 		"ks2.t1": {"ks1.t1"},
 		"t2":     {"ks1.t2"},
 		"ks2.t2": {"ks1.t2"},
-	}))
+	})))
 	require.NoError(t, tme.ts.RebuildSrvVSchema(ctx, nil))
 
 	tme.targetKeyspace = "ks2"
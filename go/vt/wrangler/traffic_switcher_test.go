@@ -2069,8 +2069,9 @@ func TestNoOrphanedRoutingRulesOnFailedCreate(t *testing.T) {
 func checkRouting(t *testing.T, wr *Wrangler, want map[string][]string) {
 	t.Helper()
 	ctx := t.Context()
-	got, err := topotools.GetRoutingRules(ctx, wr.ts)
+	rules, err := topotools.GetRoutingRules(ctx, wr.ts)
 	require.NoError(t, err)
+	got := rules.ToTablesMap(time.Now())
 	assert.Equal(t, want, got, "rules:\n%v, want\n%v", got, want)
 	cells, err := wr.ts.GetCellInfoNames(ctx)
 	require.NoError(t, err)
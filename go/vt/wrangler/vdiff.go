@@ -1427,6 +1427,12 @@ func (vc *contextVCursor) Environment() *vtenv.Environment {
 	return vc.env
 }
 
+// TrackMemory does not limit the memory of the VDiff, which must read all
+// the rows of the table.
+func (vc *contextVCursor) TrackMemory(bytes int64) error {
+	return nil
+}
+
 // -----------------------------------------------------------------
 // Utility functions
 